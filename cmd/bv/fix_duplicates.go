@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// errFixDuplicatesQuit is returned when the user quits before anything is written
+var errFixDuplicatesQuit = errors.New("quit without changes")
+
+// duplicateFields are the rows of the side-by-side comparison
+var duplicateFields = []struct {
+	name  string
+	value func(issue *model.Issue) string
+}{
+	{"Title", func(i *model.Issue) string { return i.Title }},
+	{"Status", func(i *model.Issue) string { return string(i.Status) }},
+	{"Priority", func(i *model.Issue) string { return fmt.Sprintf("P%d", i.Priority) }},
+	{"Type", func(i *model.Issue) string { return string(i.IssueType) }},
+	{"Assignee", func(i *model.Issue) string { return i.Assignee }},
+	{"Labels", func(i *model.Issue) string { return strings.Join(i.Labels, ", ") }},
+	{"Depends on", func(i *model.Issue) string {
+		var ids []string
+		for _, dep := range i.Dependencies {
+			if dep != nil {
+				ids = append(ids, dep.DependsOnID)
+			}
+		}
+		return strings.Join(ids, ", ")
+	}},
+	{"Comments", func(i *model.Issue) string { return strconv.Itoa(len(i.Comments)) }},
+	{"Updated", func(i *model.Issue) string {
+		if i.UpdatedAt.IsZero() {
+			return ""
+		}
+		return i.UpdatedAt.Format("2006-01-02 15:04")
+	}},
+	{"Description", func(i *model.Issue) string { return strings.Join(strings.Fields(i.Description), " ") }},
+}
+
+// runFixDuplicates walks through each ID that several lines of beadsPath
+// share: it shows the copies side by side and asks whether to merge them,
+// give all but one a new ID, or leave them. Nothing is written until every
+// group is decided and the plan confirmed (or assumeYes).
+func runFixDuplicates(beadsPath string, reserved []string, in io.Reader, out io.Writer, width int, assumeYes bool) (*loader.DuplicateSet, loader.DuplicateResult, error) {
+	set, err := loader.FindDuplicates(beadsPath)
+	if err != nil {
+		return nil, loader.DuplicateResult{}, err
+	}
+	if len(set.Groups) == 0 {
+		fmt.Fprintf(out, "No duplicate issue IDs in %s\n", beadsPath)
+		return set, loader.DuplicateResult{}, nil
+	}
+	fmt.Fprintf(out, "%d issue IDs appear more than once in %s\n", len(set.Groups), beadsPath)
+
+	reader := bufio.NewReader(in)
+	fixes := make(map[string]loader.DuplicateFix, len(set.Groups))
+	var plan []string
+	for n, g := range set.Groups {
+		fmt.Fprintf(out, "\n[%d/%d] %s appears %d times", n+1, len(set.Groups), g.ID, len(g.Copies))
+		if g.Identical {
+			fmt.Fprint(out, " (identical copies)")
+		}
+		fmt.Fprintln(out)
+		printDuplicateCopies(out, g, width)
+
+		fix, err := promptDuplicateFix(reader, out, g)
+		if err != nil {
+			return set, loader.DuplicateResult{}, err
+		}
+		switch fix.Action {
+		case loader.DuplicateMerge:
+			fixes[g.ID] = fix
+			plan = append(plan, fmt.Sprintf("%s: merge %d copies, keeping the fields of #%d", g.ID, len(g.Copies), fix.Keep+1))
+		case loader.DuplicateReID:
+			fixes[g.ID] = fix
+			others := "the other copy gets a new ID"
+			if len(g.Copies) > 2 {
+				others = fmt.Sprintf("the %d other copies get new IDs", len(g.Copies)-1)
+			}
+			plan = append(plan, fmt.Sprintf("%s: #%d keeps the ID, %s", g.ID, fix.Keep+1, others))
+		}
+	}
+
+	if len(fixes) == 0 {
+		fmt.Fprintln(out, "\nNothing to change.")
+		return set, loader.DuplicateResult{}, nil
+	}
+	fmt.Fprintln(out, "\nPlan:")
+	for _, line := range plan {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	if !assumeYes {
+		fmt.Fprintf(out, "Write these changes to %s? [y/N]: ", beadsPath)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			return set, loader.DuplicateResult{}, errFixDuplicatesQuit
+		}
+	}
+
+	result, err := set.Resolve(fixes, reserved)
+	if err != nil {
+		return set, result, err
+	}
+	for _, id := range result.Merged {
+		fmt.Fprintf(out, "✓ Merged the copies of %s\n", id)
+	}
+	for _, g := range set.Groups {
+		newIDs := result.Renamed[g.ID]
+		next := 0
+		for i, c := range g.Copies {
+			if len(newIDs) == 0 || i == fixes[g.ID].Keep {
+				continue
+			}
+			fmt.Fprintf(out, "✓ %s from line %d is now %s\n", g.ID, c.Line, newIDs[next])
+			next++
+		}
+	}
+	return set, result, nil
+}
+
+// promptDuplicateFix asks how to resolve g until it gets a valid answer.
+// Enter merges identical copies; differing copies need an explicit choice.
+func promptDuplicateFix(reader *bufio.Reader, out io.Writer, g loader.DuplicateGroup) (loader.DuplicateFix, error) {
+	fmt.Fprintln(out, "  m, m<N>: merge into one issue keeping #1's (or #N's) fields, uniting labels, dependencies and comments")
+	fmt.Fprintln(out, "  r, r<N>: #1 (or #N) keeps the ID, the other copies get new IDs")
+	fmt.Fprintln(out, "  s: skip   q: quit without writing")
+	for {
+		if g.Identical {
+			fmt.Fprint(out, "Choice [m]: ")
+		} else {
+			fmt.Fprint(out, "Choice: ")
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Fprintln(out)
+			return loader.DuplicateFix{}, errFixDuplicatesQuit
+		}
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if answer == "" && g.Identical {
+			answer = "m"
+		}
+		switch {
+		case answer == "s":
+			return loader.DuplicateFix{Action: loader.DuplicateSkip}, nil
+		case answer == "q":
+			return loader.DuplicateFix{}, errFixDuplicatesQuit
+		case strings.HasPrefix(answer, "m") || strings.HasPrefix(answer, "r"):
+			fix := loader.DuplicateFix{Action: loader.DuplicateMerge}
+			if answer[0] == 'r' {
+				fix.Action = loader.DuplicateReID
+			}
+			if rest := strings.TrimSpace(answer[1:]); rest != "" {
+				n, err := strconv.Atoi(strings.TrimPrefix(rest, "#"))
+				if err != nil || n < 1 || n > len(g.Copies) {
+					fmt.Fprintf(out, "  Pick a copy from #1 to #%d\n", len(g.Copies))
+					continue
+				}
+				fix.Keep = n - 1
+			}
+			return fix, nil
+		}
+		if err != nil {
+			return loader.DuplicateFix{}, errFixDuplicatesQuit
+		}
+		fmt.Fprintln(out, "  Answer m, m<N>, r, r<N>, s or q")
+	}
+}
+
+// printDuplicateCopies prints the copies of g in columns, marking the rows
+// where they differ with *
+func printDuplicateCopies(out io.Writer, g loader.DuplicateGroup, width int) {
+	const labelWidth = 14
+	if width <= 0 {
+		width = 100
+	}
+	colWidth := max(16, (width-labelWidth)/len(g.Copies)-2)
+
+	cells := []string{""}
+	for i, c := range g.Copies {
+		cells = append(cells, fmt.Sprintf("#%d (line %d)", i+1, c.Line))
+	}
+	printDuplicateRow(out, cells, labelWidth, colWidth)
+
+	for _, field := range duplicateFields {
+		row := []string{field.name}
+		differs := false
+		for i, c := range g.Copies {
+			value := "(unparsable)"
+			if c.Issue != nil {
+				value = field.value(c.Issue)
+			}
+			if i > 0 && value != row[1] {
+				differs = true
+			}
+			row = append(row, value)
+		}
+		if differs {
+			row[0] = "* " + row[0]
+		} else {
+			row[0] = "  " + row[0]
+		}
+		printDuplicateRow(out, row, labelWidth, colWidth)
+	}
+}
+
+func printDuplicateRow(out io.Writer, cells []string, labelWidth, colWidth int) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-*s", labelWidth, cells[0]))
+	for _, cell := range cells[1:] {
+		sb.WriteString("  ")
+		sb.WriteString(fmt.Sprintf("%-*s", colWidth, truncateTitle(cell, colWidth)))
+	}
+	fmt.Fprintln(out, strings.TrimRight(sb.String(), " "))
+}
@@ -5,33 +5,46 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"golang.org/x/term"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/audit"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/daemon"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mcp"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notify"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/onboarding"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/templates"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -40,15 +53,39 @@ import (
 func main() {
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
+	keymapFlag := flag.Bool("keymap", false, "List the TUI key bindings and the action names used to remap them")
+	accessibleFlag := flag.Bool("accessible", false, "Screen-reader friendly TUI: plain text labels, single pane, one status line")
+	asciiFlag := flag.Bool("ascii", false, "Draw borders, progress bars and sparklines with ASCII only")
 	// Update flags (bv-182)
 	updateFlag := flag.Bool("update", false, "Update bv to the latest version")
 	checkUpdateFlag := flag.Bool("check-update", false, "Check if a new version is available")
 	rollbackFlag := flag.Bool("rollback", false, "Rollback to the previous version (from backup)")
-	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update)")
+	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update, --fix-duplicates)")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
+	brandTitle := flag.String("brand-title", "", "Title for exported reports and sites (overrides .bv/branding.yaml)")
+	brandLogo := flag.String("brand-logo", "", "Logo image (file or URL) for exported reports and sites")
+	brandAccent := flag.String("brand-accent", "", "Accent color (#rrggbb) for exported reports and sites")
+	brandFooter := flag.String("brand-footer", "", "Footer text for exported reports and sites")
+	exportFilter := flag.String("export-filter", "", "Only export issues matching a filter expression, plus their dependencies (e.g., 'open label:api'; applies to --export-md, --export-pages, --export-graph, --show-graph, --export-mermaid, --export-plantuml, --export-ics, --export-outline)")
+	importMD := flag.String("import-md", "", "Import a Markdown TODO list as beads (headings become epics, checkboxes tasks)")
+	importDryRun := flag.Bool("import-dry-run", false, "Show what --import-md would create without writing the beads file")
+	renameFrom := flag.String("rename", "", "Rename an issue ID, rewriting the dependencies and comments that refer to it (with --rename-to; also: bv rename OLD NEW)")
+	renameTo := flag.String("rename-to", "", "New ID for --rename")
+	renameDryRun := flag.Bool("rename-dry-run", false, "Show the diff --rename would write without changing any file")
+	archiveOlderThan := flag.String("archive-older-than", "", "Move issues closed before this (a duration like 90d, or a date) to .beads/archive.jsonl (also: bv archive --older-than 90d)")
+	archiveDryRun := flag.Bool("archive-dry-run", false, "List the issues --archive-older-than would move without changing any file")
+	includeArchived := flag.Bool("include-archived", false, "Also load the issues moved to .beads/archive.jsonl (Ctrl+A toggles them in the TUI)")
+	fmtFlag := flag.Bool("fmt", false, "Rewrite the beads file in canonical key order and serialization, without changing any issue (also: bv fmt)")
+	fmtCheck := flag.Bool("fmt-check", false, "With --fmt, only report whether the beads file is canonically formatted (exit 1 if not)")
+	fixDuplicates := flag.Bool("fix-duplicates", false, "Find issues sharing an ID, show them side by side and merge or re-ID them interactively")
+	newIDFlag := flag.Bool("new-id", false, "Print an unused issue ID in the project's prefix-xxxx style (also: bv new-id)")
+	newIDCount := flag.Int("new-id-count", 1, "Number of distinct IDs for --new-id")
+	newIDPrefix := flag.String("new-id-prefix", "", "Prefix for --new-id (default: the one most issues use)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
+	mcpFlag := flag.Bool("mcp", false, "Run a Model Context Protocol server on stdio for AI agents")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
+	planAgents := flag.Int("plan-agents", 0, "Balance --robot-plan tracks across N agents by estimated minutes (0 = one track per work stream)")
 	robotPriority := flag.Bool("robot-priority", false, "Output priority recommendations as JSON for AI agents")
 	robotTriage := flag.Bool("robot-triage", false, "Output unified triage as JSON (the mega-command for AI agents)")
 	robotTriageByTrack := flag.Bool("robot-triage-by-track", false, "Group triage recommendations by execution track (bv-87)")
@@ -66,15 +103,40 @@ func main() {
 	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle")
 	suggestConfidence := flag.Float64("suggest-confidence", 0.0, "Minimum confidence for suggestions (0.0-1.0)")
 	suggestBead := flag.String("suggest-bead", "", "Filter suggestions for specific bead ID")
+	// Cycle report with fix suggestions
+	robotCycles := flag.Bool("robot-cycles", false, "Output dependency cycles with a suggested minimal edge set to remove as JSON")
+	cyclesLimit := flag.Int("cycles-limit", 20, "Max cycles enumerated per strongly connected component (use with --robot-cycles)")
+	robotClusters := flag.Bool("robot-clusters", false, "Output clusters of related open issues (Louvain community detection) as JSON")
+	robotStale := flag.Bool("robot-stale", false, "Output stale issues grouped by status with suggested actions as JSON")
+	robotUnblock := flag.Bool("robot-unblock", false, "Output open blockers ranked by how many issues closing each one would unblock as JSON")
+	robotRecur := flag.Bool("robot-recur", false, "Create issues for due recurring templates in .beads/templates/ and output the schedule as JSON")
+	recurDryRun := flag.Bool("recur-dry-run", false, "Show what --robot-recur would create without writing the beads file")
+	staleDays := flag.Int("stale-days", 0, "Days without an update before an issue is stale (use with --robot-stale; 0 = stale_warning_days from .bv/drift.yaml)")
 	// Graph export (bv-136)
 	robotGraph := flag.Bool("robot-graph", false, "Output dependency graph as JSON/DOT/Mermaid for AI agents")
+	robotList := flag.Bool("robot-list", false, "Output one summary per issue as JSON for AI agents")
+	outputFormat := flag.String("format", "json", "Output format for --robot-list and --robot-graph: json, or ndjson to stream one record per line; --robot-standup also takes markdown, --profile-startup table (its default) or json, --new-id json")
 	graphFormat := flag.String("graph-format", "json", "Graph output format: json, dot, mermaid")
 	graphRoot := flag.String("graph-root", "", "Subgraph from specific root issue ID")
 	graphDepth := flag.Int("graph-depth", 0, "Max depth for subgraph (0 = unlimited)")
+	// Standalone dependency diagrams
+	exportMermaid := flag.String("export-mermaid", "", "Write a Mermaid dependency diagram to a file (.mmd, or .md for a fenced block; - for stdout)")
+	exportPlantUML := flag.String("export-plantuml", "", "Write a PlantUML dependency diagram to a file (.puml, or .md for a fenced block; - for stdout)")
+	exportICS := flag.String("export-ics", "", "Write due dates and projected ETA windows as an iCalendar file (- for stdout)")
+	exportOutline := flag.String("export-outline", "", "Write the epic/parent-child hierarchy as an outline (.opml for OPML, otherwise Markdown; - for stdout)")
+	outlineFormat := flag.String("outline-format", "", "Outline format: markdown or opml (default: from the --export-outline extension)")
+	plantUMLStyle := flag.String("plantuml-style", "component", "PlantUML diagram style: component or wbs (work breakdown tree)")
+	diagramRoot := flag.String("root", "", "Diagram only this issue and what it depends on (--export-mermaid, --export-plantuml)")
+	diagramDepth := flag.Int("depth", 0, "Dependency levels below --root to diagram (0 = unlimited)")
+	diagramStatus := flag.String("status", "", "Diagram only issues with these statuses, comma-separated (e.g., open,in_progress)")
 	// Graph snapshot export (bv-94)
 	exportGraph := flag.String("export-graph", "", "Export graph: .html for interactive, .png/.svg for static (auto-names if empty)")
 	graphPreset := flag.String("graph-preset", "compact", "Graph layout preset: compact (default) or roomy")
 	graphTitle := flag.String("graph-title", "", "Title for graph export (default: project name)")
+	showGraph := flag.Bool("show-graph", false, "Draw the dependency graph as an image inline in the terminal (kitty or sixel graphics)")
+	graphProtocol := flag.String("graph-protocol", "auto", "Inline image protocol for --show-graph: auto, kitty or sixel")
+	graphWidth := flag.Int("graph-width", 0, "Max image width in pixels for --show-graph (default: fit the terminal width)")
+	exportBadge := flag.String("export-badge", "", "Write the project health score as an SVG shield (e.g., health.svg)")
 	// Robot output filters (bv-84)
 	robotMinConf := flag.Float64("robot-min-confidence", 0.0, "Filter robot outputs by minimum confidence (0.0-1.0)")
 	robotMaxResults := flag.Int("robot-max-results", 0, "Limit robot output count (0 = use defaults)")
@@ -90,26 +152,48 @@ func main() {
 	semanticQuery := flag.String("search", "", "Semantic search query (vector-based; builds/updates index on first run)")
 	robotSearch := flag.Bool("robot-search", false, "Output semantic search results as JSON for AI agents (use with --search)")
 	searchLimit := flag.Int("search-limit", 10, "Max results for --search/--robot-search")
+	robotDuplicates := flag.Bool("robot-duplicates", false, "Output probable duplicate issue pairs (embedding similarity) as JSON")
+	duplicatesThreshold := flag.Float64("duplicates-threshold", search.DefaultDuplicateThreshold, "Min similarity (0-1) for --robot-duplicates pairs")
+	duplicatesIncludeClosed := flag.Bool("duplicates-include-closed", false, "Include closed issues in --robot-duplicates")
 	searchMode := flag.String("search-mode", "", "Search ranking mode: text or hybrid (default: BV_SEARCH_MODE or text)")
 	searchPreset := flag.String("search-preset", "", "Hybrid preset name (default: BV_SEARCH_PRESET or default)")
 	searchWeights := flag.String("search-weights", "", "Hybrid weights JSON (overrides preset; keys: text,pagerank,status,impact,priority,recency)")
-	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
+	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, date, or JSONL file)")
+	diffTo := flag.String("diff-to", "", "Compare --diff-since against this JSONL file or git ref instead of the current issues")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
+	lazyText := flag.Bool("lazy-text", false, "TUI: read descriptions, notes and comments only when an issue is opened (saves memory on very large files)")
 	profileStartup := flag.Bool("profile-startup", false, "Output detailed startup timing profile for diagnostics")
-	profileJSON := flag.Bool("profile-json", false, "Output profile in JSON format (use with --profile-startup)")
-	noHooks := flag.Bool("no-hooks", false, "Skip running hooks during export")
+	profileJSON := flag.Bool("profile-json", false, "Output profile in JSON format (use with --profile-startup; same as --format json)")
+	noHooks := flag.Bool("no-hooks", false, "Skip running hooks during export and on TUI reloads")
+	noPlugins := flag.Bool("no-plugins", false, "Skip the .bv/plugins/ analyzers (TUI detail view, --robot-triage, --robot-insights)")
 	workspaceConfig := flag.String("workspace", "", "Load issues from workspace config file (.bv/workspace.yaml)")
+	workspaceInit := flag.String("workspace-init", "", "Scan a directory for repos with .beads folders and write DIR/.bv/workspace.yaml")
+	workspaceInitForce := flag.Bool("workspace-init-force", false, "Overwrite an existing workspace.yaml (use with --workspace-init)")
+	workspaceInitDepth := flag.Int("workspace-init-depth", workspace.DefaultScanDepth, "Directory levels to scan below DIR (use with --workspace-init)")
+	hooksInstall := flag.Bool("hooks-install", false, "Install a git pre-commit hook that validates the beads file (also: bv hooks install)")
+	hooksUninstall := flag.Bool("hooks-uninstall", false, "Remove the git hooks installed by --hooks-install (also: bv hooks uninstall)")
+	hooksAppendID := flag.Bool("hooks-append-id", false, "With --hooks-install, also install a commit-msg hook that appends the branch's bead ID")
+	mergeDriver := flag.Bool("merge-driver", false, "Three-way merge of beads files, run by git as: bv merge-driver BASE OURS THEIRS (writes the result to OURS)")
+	mergeDriverInstall := flag.Bool("merge-driver-install", false, "Register bv merge-driver for the beads files in git config and .gitattributes (also: bv merge-driver install)")
+	hookPreCommit := flag.Bool("hook-pre-commit", false, "Validate the staged beads file (run by the installed pre-commit hook)")
+	hookCommitMsg := flag.String("hook-commit-msg", "", "Append the active bead ID to a commit message file (run by the installed commit-msg hook)")
 	repoFilter := flag.String("repo", "", "Filter issues by repository prefix (e.g., 'api-' or 'api')")
 	saveBaseline := flag.String("save-baseline", "", "Save current metrics as baseline with optional description")
 	baselineInfo := flag.Bool("baseline-info", false, "Show information about the current baseline")
 	checkDrift := flag.Bool("check-drift", false, "Check for drift from baseline (exit codes: 0=OK, 1=critical, 2=warning)")
 	robotDriftCheck := flag.Bool("robot-drift", false, "Output drift check as JSON (use with --check-drift)")
+	checkCycles := flag.Bool("check-cycles", false, "Exit 1 if the dependency graph has cycles")
+	checkStale := flag.String("check-stale", "", "Exit 1 if unresolved issues went this long without an update (e.g. 14d, 2w, or days)")
+	checkOrphans := flag.Bool("check-orphans", false, "Exit 1 if dependencies reference issues that do not exist")
+	robotCheck := flag.Bool("robot-check", false, "Output --check-cycles/--check-stale/--check-orphans results as JSON")
 	robotHistory := flag.Bool("robot-history", false, "Output bead-to-commit correlations as JSON")
 	beadHistory := flag.String("bead-history", "", "Show history for specific bead ID")
 	historySince := flag.String("history-since", "", "Limit history to commits after this date/ref (e.g., '30 days ago', '2024-01-01')")
 	historyLimit := flag.Int("history-limit", 500, "Max commits to analyze (0 = unlimited)")
 	minConfidence := flag.Float64("min-confidence", 0.0, "Filter correlations by minimum confidence (0.0-1.0)")
+	robotCommits := flag.String("robot-commits", "", "Output commits related to an issue as JSON (ID mentions, branch names, co-commits, titles)")
+	robotBlame := flag.Bool("robot-blame", false, "Output the git author who last changed each issue's status and priority as JSON")
 	// Correlation audit flags (bv-e1u6)
 	robotExplainCorrelation := flag.String("robot-explain-correlation", "", "Explain why a commit is linked to a bead (format: SHA:beadID)")
 	robotConfirmCorrelation := flag.String("robot-confirm-correlation", "", "Confirm a correlation is correct (format: SHA:beadID)")
@@ -148,9 +232,23 @@ func main() {
 	robotSprintShow := flag.String("robot-sprint-show", "", "Output specific sprint details as JSON")
 	// Forecast flags (bv-158)
 	robotForecast := flag.String("robot-forecast", "", "Output ETA forecast for bead ID, or 'all' for all open issues")
+	robotExplain := flag.String("robot-explain", "", "Output the full priority score breakdown for a bead ID as JSON")
+	robotDossier := flag.String("robot-dossier", "", "Output the impact dossier of a bead ID as JSON: blockers, unblocks, critical path, score, risk and ETA")
+	exportDossier := flag.String("export-dossier", "", "Print the impact dossier of a bead ID as Markdown (for design docs and PR descriptions)")
 	forecastLabel := flag.String("forecast-label", "", "Filter forecast by label")
 	forecastSprint := flag.String("forecast-sprint", "", "Filter forecast by sprint ID")
 	forecastAgents := flag.Int("forecast-agents", 1, "Number of parallel agents for capacity calculation")
+	forecastRuns := flag.Int("forecast-runs", 1000, "Monte Carlo runs for the --robot-forecast completion distribution")
+	robotVelocity := flag.Bool("robot-velocity", false, "Output the velocity model learned from closed issues (type weights, label velocity) as JSON")
+	robotPlugins := flag.Bool("robot-plugins", false, "Run the .bv/plugins/ analyzers and output their annotations as JSON")
+	robotStandup := flag.Bool("robot-standup", false, "Output a standup summary: closed, opened, newly blocked/unblocked and stalled issues (JSON, or --format=markdown)")
+	notifyFlag := flag.Bool("notify", false, "Post the backlog digest to the targets in .bv/notify.yaml (also: bv notify)")
+	notifyDryRun := flag.Bool("notify-dry-run", false, "Print the --notify digest and its targets without sending")
+	standupSince := flag.String("standup-since", "24h", "Period for --robot-standup: a duration (24h, 72h) or a date (2006-01-02, RFC3339)")
+	robotAudit := flag.Bool("robot-audit", false, "Output the writes bv made to the beads file (.bv/audit.jsonl) as JSON")
+	auditSince := flag.String("audit-since", "", "Limit --robot-audit to a period: a duration (24h, 168h) or a date (2006-01-02, RFC3339)")
+	auditIssue := flag.String("audit-issue", "", "Limit --robot-audit to the writes touching a bead ID")
+	robotWeights := flag.Bool("robot-weights", false, "Output the triage score weights in effect (built-in or from .bv/analysis.yaml) as JSON")
 	// Capacity simulation flags (bv-160)
 	robotCapacity := flag.Bool("robot-capacity", false, "Output capacity simulation and completion projection as JSON")
 	capacityAgents := flag.Int("agents", 1, "Number of parallel agents for capacity simulation")
@@ -175,12 +273,78 @@ func main() {
 	pagesTitle := flag.String("pages-title", "", "Custom title for static site")
 	pagesIncludeClosed := flag.Bool("pages-include-closed", true, "Include closed issues in export (default: true)")
 	pagesIncludeHistory := flag.Bool("pages-include-history", true, "Include git history for time-travel (default: true)")
+	pagesIncremental := flag.Bool("pages-incremental", false, "Update an existing beads.sqlite3 in place, rewriting only changed issues")
+	pagesSigningKey := flag.String("pages-signing-key", "", "Sign the export's chunk manifest with this minisign or PEM Ed25519 secret key")
+	verifyPages := flag.String("verify-pages", "", "Verify the signed manifest and database hashes of an exported static site")
+	verifyKey := flag.String("verify-key", "", "Public key (minisign .pub, PEM, or base64) for --verify-pages")
+	pagesPush := flag.Bool("pages-push", false, "Commit the exported site to --pages-branch and push it to origin")
+	pagesBranch := flag.String("pages-branch", "gh-pages", "Branch that --pages-push publishes the static site to")
 	previewPages := flag.String("preview-pages", "", "Preview existing static site bundle")
 	pagesWizard := flag.Bool("pages", false, "Launch interactive Pages deployment wizard")
+	daemonFlag := flag.Bool("daemon", false, "Keep issues and analysis in memory and answer robot queries on .bv/daemon.sock (also: bv daemon)")
+	daemonQuery := flag.String("daemon-query", "", "Send a command (status, triage, next, plan, priority, insights, shutdown) to the running bv daemon and print its JSON response")
+	daemonArgs := flag.String("daemon-args", "", "JSON args for --daemon-query, e.g. '{\"agents\": 3}'")
+	serveFlag := flag.Bool("serve", false, "Serve a read-only JSON API and web UI over HTTP (also: bv serve)")
+	servePort := flag.Int("serve-port", export.DefaultServePort, "Port for --serve")
+	serveHost := flag.String("serve-host", "127.0.0.1", "Address for --serve to bind (use 0.0.0.0 to share on the network)")
 	// Debug rendering flag (for diagnosing TUI issues)
 	debugRender := flag.String("debug-render", "", "Render a view and output to file (views: insights, board)")
 	debugWidth := flag.Int("debug-width", 180, "Width for debug render")
 	debugHeight := flag.Int("debug-height", 50, "Height for debug render")
+
+	// "bv publish <dir> [flags]" is shorthand for --export-pages <dir>
+	publishArgs, publishing, publishErr := rewritePublishArgs(os.Args[1:])
+	if publishErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", publishErr)
+		os.Exit(2)
+	}
+	// "bv serve [--port N] [--host H]" is shorthand for --serve
+	publishArgs, _ = rewriteServeArgs(publishArgs)
+	// "bv workspace init --scan DIR" is shorthand for --workspace-init DIR
+	publishArgs, _, workspaceErr := rewriteWorkspaceArgs(publishArgs)
+	if workspaceErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", workspaceErr)
+		os.Exit(2)
+	}
+	// "bv hooks install|uninstall" is shorthand for --hooks-install/--hooks-uninstall
+	publishArgs, _, hooksErr := rewriteHooksArgs(publishArgs)
+	if hooksErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", hooksErr)
+		os.Exit(2)
+	}
+	// "bv notify [--dry-run]" is shorthand for --notify [--notify-dry-run]
+	publishArgs, _ = rewriteNotifyArgs(publishArgs)
+	// "bv new-id [--count N] [--prefix P]" is shorthand for --new-id
+	publishArgs, _ = rewriteNewIDArgs(publishArgs)
+	// "bv rename OLD NEW [--dry-run]" is shorthand for --rename/--rename-to
+	publishArgs, _, renameErr := rewriteRenameArgs(publishArgs)
+	if renameErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", renameErr)
+		os.Exit(2)
+	}
+	// "bv merge-driver install" and "bv merge-driver BASE OURS THEIRS" are
+	// shorthand for --merge-driver-install and --merge-driver BASE OURS THEIRS
+	publishArgs, _, mergeDriverErr := rewriteMergeDriverArgs(publishArgs)
+	if mergeDriverErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", mergeDriverErr)
+		os.Exit(2)
+	}
+	// "bv fmt [--check]" is shorthand for --fmt [--fmt-check]
+	publishArgs, _ = rewriteFmtArgs(publishArgs)
+	// "bv archive --older-than 90d [--dry-run]" is shorthand for --archive-older-than
+	publishArgs, _, archiveErr := rewriteArchiveArgs(publishArgs)
+	if archiveErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", archiveErr)
+		os.Exit(2)
+	}
+	// "bv daemon [stop|status|query <command> [args]]" is shorthand for
+	// --daemon and --daemon-query
+	publishArgs, _, daemonErr := rewriteDaemonArgs(publishArgs)
+	if daemonErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", daemonErr)
+		os.Exit(2)
+	}
+	os.Args = append(os.Args[:1], publishArgs...)
 	flag.Parse()
 
 	// Ensure static export flags are retained even when build tags strip features in some environments.
@@ -188,6 +352,12 @@ func main() {
 	_ = pagesTitle
 	_ = pagesIncludeClosed
 	_ = pagesIncludeHistory
+	_ = pagesIncremental
+	_ = pagesSigningKey
+	_ = verifyPages
+	_ = verifyKey
+	_ = pagesPush
+	_ = pagesBranch
 	_ = previewPages
 	_ = pagesWizard
 	_ = debugRender
@@ -197,6 +367,10 @@ func main() {
 	_ = forecastLabel
 	_ = forecastSprint
 	_ = forecastAgents
+	_ = forecastRuns
+	_ = robotVelocity
+	_ = robotStandup
+	_ = robotPlugins
 	_ = robotCapacity
 	_ = capacityAgents
 	_ = capacityLabel
@@ -208,6 +382,10 @@ func main() {
 
 	robotMode := envRobot ||
 		*robotHelp ||
+		*mcpFlag ||
+		*daemonFlag ||
+		*daemonQuery != "" ||
+		*newIDFlag ||
 		*robotInsights ||
 		*robotPlan ||
 		*robotPriority ||
@@ -222,10 +400,20 @@ func main() {
 		*robotLabelAttention ||
 		*robotAlerts ||
 		*robotSuggest ||
+		*robotCycles ||
+		*robotClusters ||
+		*robotStale ||
+		*robotUnblock ||
+		*robotRecur ||
 		*robotGraph ||
+		*robotList ||
 		*robotSearch ||
+		*robotDuplicates ||
 		*robotDriftCheck ||
+		*robotCheck ||
 		*robotHistory ||
+		*robotCommits != "" ||
+		*robotBlame ||
 		*robotFileBeads != "" ||
 		*fileHotspots ||
 		*robotImpact != "" ||
@@ -237,6 +425,14 @@ func main() {
 		*robotSprintList ||
 		*robotSprintShow != "" ||
 		*robotForecast != "" ||
+		*robotVelocity ||
+		*robotStandup ||
+		*robotAudit ||
+		*robotWeights ||
+		*robotPlugins ||
+		*robotExplain != "" ||
+		*robotDossier != "" ||
+		*exportDossier != "" ||
 		*robotBurndown != "" ||
 		*robotByLabel != "" ||
 		*robotByAssignee != "" ||
@@ -270,6 +466,18 @@ func main() {
 		fmt.Println("Use these commands to understand project state without parsing raw JSONL.")
 		fmt.Println("")
 		fmt.Println("Commands:")
+		fmt.Println("  --mcp")
+		fmt.Println("      Runs a Model Context Protocol server (JSON-RPC 2.0 over stdio).")
+		fmt.Println("      Tools: list_issues {filter?, limit?}, get_issue {id}, triage,")
+		fmt.Println("      plan {agents?}, simulate_close {id}. The beads file is re-read on")
+		fmt.Println("      every call. Register it with your agent as the command: bv --mcp")
+		fmt.Println("")
+		fmt.Println("  bv daemon / bv daemon query <command> ['<json args>']")
+		fmt.Println("      Keeps issues and analysis warm and answers status, triage, next,")
+		fmt.Println("      plan {agents}, priority {limit} and insights {limit} on .bv/daemon.sock,")
+		fmt.Println("      cached until the beads file changes. Responses: {ok, data_hash, cached,")
+		fmt.Println("      result, error}. Example: bv daemon query plan '{\"agents\": 3}' | jq .result")
+		fmt.Println("")
 		fmt.Println("  --robot-plan")
 		fmt.Println("      Outputs a dependency-respecting execution plan as JSON.")
 		fmt.Println("      Shows what can be worked on now and what it unblocks.")
@@ -278,6 +486,10 @@ func main() {
 		fmt.Println("      - items: Actionable issues sorted by priority within each track")
 		fmt.Println("      - unblocks: Issues that become actionable when this item is done")
 		fmt.Println("      - summary: Highlights highest-impact item to work on first")
+		fmt.Println("      - estimated_minutes / makespan_minutes: Per-item and per-track work estimates")
+		fmt.Println("      - critical_track: Track that determines overall completion")
+		fmt.Println("      Use --plan-agents N to schedule all open work onto N balanced agent tracks")
+		fmt.Println("      (items then carry schedule.start_minute / schedule.finish_minute).")
 		fmt.Println("")
 		fmt.Println("  --robot-insights")
 		fmt.Println("      Outputs a JSON object containing deep graph analysis.")
@@ -346,6 +558,25 @@ func main() {
 		fmt.Println("      Example: bv --robot-history --history-since '30 days ago'")
 		fmt.Println("      Example: bv --robot-history --min-confidence 0.7")
 		fmt.Println("")
+		fmt.Println("  --robot-commits <id>")
+		fmt.Println("      Outputs the commits related to one issue as JSON, newest first.")
+		fmt.Println("      Commits are linked when they mention the ID, were made on a branch named")
+		fmt.Println("      after it, changed the issue alongside code, or have a subject matching its title.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - commits: sha, message, author, files, method, confidence, reason")
+		fmt.Println("      - methods: Commit count per correlation method")
+		fmt.Println("      Honors --history-since, --history-limit and --min-confidence.")
+		fmt.Println("      Example: bv --robot-commits bv-123 | jq '.commits[] | {short_sha, method, message}'")
+		fmt.Println("")
+		fmt.Println("  --robot-blame")
+		fmt.Println("      Attributes each issue's current status and priority to the commit that last changed them.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - issues: status/priority attributions (value, commit_sha, author, timestamp)")
+		fmt.Println("      - uncommitted_fields: Fields edited in the working copy since the attributed commit")
+		fmt.Println("      - authors: Per-author counts of fields they last changed")
+		fmt.Println("      - unattributed: Issues never committed to git")
+		fmt.Println("      Example: bv --robot-blame | jq '.issues[] | {issue_id, status_by: .status.author}'")
+		fmt.Println("")
 		fmt.Println("  --robot-file-beads <path>")
 		fmt.Println("      Outputs beads that have touched a file path as JSON.")
 		fmt.Println("      Answers: 'What beads have touched this file, and why?'")
@@ -414,6 +645,18 @@ func main() {
 		fmt.Println("      Example: bv --robot-related bv-abc1")
 		fmt.Println("      Example: bv --robot-related bv-abc1 --related-include-closed")
 		fmt.Println("")
+		fmt.Println("  --robot-duplicates")
+		fmt.Println("      Outputs probable duplicate issue pairs as JSON, ranked by the")
+		fmt.Println("      similarity of their embeddings (title, labels, description).")
+		fmt.Println("      Uses the BV_SEMANTIC_EMBEDDER backend; vectors are cached in .bv/embeddings.")
+		fmt.Println("      Key fields per pair:")
+		fmt.Println("      - issue1/issue2 with titles and statuses")
+		fmt.Println("      - similarity: 0.0-1.0 cosine similarity")
+		fmt.Println("      Options:")
+		fmt.Printf("      - --duplicates-threshold <0.0-1.0>: Min similarity (default %.1f)\n", search.DefaultDuplicateThreshold)
+		fmt.Println("      - --duplicates-include-closed: Include closed issues")
+		fmt.Println("      Example: bv --robot-duplicates --duplicates-threshold 0.7")
+		fmt.Println("")
 		fmt.Println("  --robot-sprint-list")
 		fmt.Println("      Outputs all sprints as JSON for planning and forecasting.")
 		fmt.Println("      Key fields:")
@@ -444,14 +687,91 @@ func main() {
 		fmt.Println("  --robot-forecast <id|all>")
 		fmt.Println("      Outputs ETA forecast for a specific bead or all open issues.")
 		fmt.Println("      Returns estimated completion date, confidence, and factors.")
+		fmt.Println("      completion: Monte Carlo distribution of the date everything is done")
+		fmt.Println("        (the filtered backlog, or the bead plus its open children)")
+		fmt.Println("        - p50, p85, p95: Dates done in 50/85/95% of runs")
+		fmt.Println("        - histogram: Runs finishing per date bucket, with cumulative share")
+		fmt.Println("        - added_blockers: Open blockers outside the scope pulled in")
 		fmt.Println("      Options:")
 		fmt.Println("        --forecast-label=X    Filter by label")
 		fmt.Println("        --forecast-sprint=Y   Filter by sprint")
 		fmt.Println("        --forecast-agents=N   Parallel agents (default: 1)")
+		fmt.Println("        --forecast-runs=N     Simulation runs (default: 1000)")
 		fmt.Println("      Example: bv --robot-forecast bv-123")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-label=backend")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-agents=2")
 		fmt.Println("")
+		fmt.Println("  --robot-velocity")
+		fmt.Println("      Outputs the parameters the ETA estimator learns from closed issues.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - types: Per issue type, the cycle time distribution (creation to close)")
+		fmt.Println("          and the complexity weight: the fixed prior_weight blended with the")
+		fmt.Println("          type's median cycle time relative to all closures, by sample count")
+		fmt.Println("        - labels: Per label, cycle times plus the velocity (estimated minutes")
+		fmt.Println("          closed per day) over the last window_days")
+		fmt.Println("        - overall: The same for all closures, used for unlabeled issues")
+		fmt.Println("      Example: bv --robot-velocity | jq '.types[] | {issue_type, weight}'")
+		fmt.Println("")
+		fmt.Println("  --robot-explain <id>")
+		fmt.Println("      Explains one bead's impact score, as the Insights panel shows it.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - score, rank, out_of: Composite score and position among open issues")
+		fmt.Println("        - components[]: factor, weight, normalized, contribution, reason")
+		fmt.Println("          (pagerank, betweenness, blocker_ratio, staleness, priority_boost,")
+		fmt.Println("          time_to_impact, urgency, risk)")
+		fmt.Println("        - top_reasons: The three largest contributors")
+		fmt.Println("        - graph: Raw PageRank, betweenness, eigenvector, HITS, depth, degrees")
+		fmt.Println("        - risk_signals, what_if, eta: Risk detail, unblock impact, forecast")
+		fmt.Println("      Closed beads get graph metrics only (score is null).")
+		fmt.Println("      Example: bv --robot-explain bv-123 | jq '.components[] | {factor, contribution, reason}'")
+		fmt.Println("")
+		fmt.Println("  --robot-dossier <id>")
+		fmt.Println("      Impact dossier of one bead: what it depends on and what waits on it.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - direct_blockers, transitive_blockers: Open upstream issues with depth")
+		fmt.Println("        - direct_unblocks, transitive_unblocks: Open downstream issues with depth")
+		fmt.Println("        - critical_path: on_critical_path, the longest chain through the bead,")
+		fmt.Println("          its position and the project's longest chain (project_length)")
+		fmt.Println("        - score, rank, graph, risk_signals, what_if, eta: As in --robot-explain")
+		fmt.Println("      --export-dossier <id> prints the same as Markdown for PR descriptions.")
+		fmt.Println("      Example: bv --export-dossier bv-123 | pbcopy")
+		fmt.Println("")
+		fmt.Println("  --robot-standup [--standup-since=24h] [--format=json|markdown]")
+		fmt.Println("      Standup summary of a period: issues closed and opened, newly blocked and")
+		fmt.Println("      unblocked, and in-progress work not updated for 7 days. The current beads")
+		fmt.Println("      file is compared with the last commit of it before the period (see")
+		fmt.Println("      baseline); without git history the sections come from issue timestamps.")
+		fmt.Println("      Example: bv --robot-standup --standup-since 72h --format=markdown")
+		fmt.Println("")
+		fmt.Println("  --robot-audit [--audit-since=168h] [--audit-issue=<id>]")
+		fmt.Println("      Writes bv made to the beads file, from .bv/audit.jsonl: edits, priority")
+		fmt.Println("      applies, dependency changes, merges, issues created or imported.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - entries[]: time, action, issue_ids, changes, author (git user), source")
+		fmt.Println("      In the TUI, Y shows the same log.")
+		fmt.Println("      Example: bv --robot-audit --audit-issue bv-123 | jq '.entries[] | {time, action, changes}'")
+		fmt.Println("")
+		fmt.Println("  --robot-weights")
+		fmt.Println("      Triage score weights in effect. Weights set under weights: in")
+		fmt.Println("      .bv/analysis.yaml replace the built-in ones; unless the result sums to 1")
+		fmt.Println("      it is scaled to, with a warning.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - effective: weights used for scoring (sum to 1)")
+		fmt.Println("        - configured, configured_sum: before scaling; overridden: keys set in the file")
+		fmt.Println("        - warnings: an invalid file (ignored) or weights not summing to 1")
+		fmt.Println("      Example: bv --robot-weights | jq '.effective'")
+		fmt.Println("")
+		fmt.Println("  --robot-plugins")
+		fmt.Println("      Runs every executable in .bv/plugins/ and outputs their annotations.")
+		fmt.Println("      Each plugin gets {\"version\", \"project_dir\", \"issues\"} as JSON on stdin and")
+		fmt.Println("      prints {\"annotations\": [{\"issue_id\", \"scores\", \"badges\", \"warnings\"}]}.")
+		fmt.Println("      The same annotations appear in the TUI detail view and under .plugins in")
+		fmt.Println("      --robot-triage and --robot-insights (disable with --no-plugins).")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - plugins[]: name, annotations, duration_ms, error for each plugin")
+		fmt.Println("        - annotations: Per issue ID, the annotations of every plugin")
+		fmt.Println("      Example: bv --robot-plugins | jq '.annotations[\"bv-123\"]'")
+		fmt.Println("")
 		fmt.Println("  --robot-capacity [--agents=N] [--capacity-label=X]")
 		fmt.Println("      Outputs capacity simulation and completion projection as JSON.")
 		fmt.Println("      Analyzes work remaining, parallelizability, and bottlenecks.")
@@ -486,9 +806,70 @@ func main() {
 		fmt.Println("      Generates a readable status report with Mermaid.js visualizations.")
 		fmt.Println("      Runs pre-export and post-export hooks if configured in .bv/hooks.yaml")
 		fmt.Println("")
+		fmt.Println("  --export-filter <expr>")
+		fmt.Println("      Limits --export-md, --export-pages, --export-graph and the diagram exports to matching issues")
+		fmt.Println("      plus everything they transitively depend on (blockers and parents).")
+		fmt.Println("      Uses the TUI filter names (all, open, closed, ready, label:X) and")
+		fmt.Println("      status:X, type:X, priority:N, assignee:X, custom.NAME:X (custom.NAME:*")
+		fmt.Println("      for any value). Terms are ANDed; commas list alternatives; a leading -")
+		fmt.Println("      negates; other words search ID and title.")
+		fmt.Println("      Example: bv --export-md api.md --export-filter 'open label:api,backend -type:epic'")
+		fmt.Println("")
+		fmt.Println("  --import-md <file> [--import-dry-run]")
+		fmt.Println("      Imports a Markdown TODO list into the beads file.")
+		fmt.Println("      Headings become epics (nested by level); checkbox items become tasks,")
+		fmt.Println("      children of the enclosing checkbox or heading via parent-child deps.")
+		fmt.Println("      Checked items ([x]) are imported as closed; other text becomes descriptions.")
+		fmt.Println("      Example: bv --import-md TODO.md --import-dry-run")
+		fmt.Println("")
+		fmt.Println("  --rename <old> --rename-to <new> [--rename-dry-run]  (or: bv rename <old> <new> [--dry-run])")
+		fmt.Println("      Renames an issue ID and rewrites every dependency (parent links included)")
+		fmt.Println("      and comment that refers to it, printing the diff of each changed line.")
+		fmt.Println("      With --workspace, qualified references in the other repos are rewritten too.")
+		fmt.Println("      Example: bv rename bv-k3x9 auth-login --dry-run")
+		fmt.Println("")
+		fmt.Println("  --fmt [--fmt-check]  (or: bv fmt [--check])")
+		fmt.Println("      Rewrites the beads file (its shards and archive.jsonl too) with keys in")
+		fmt.Println("      canonical order, compact JSON, LF line endings and no blank lines, so")
+		fmt.Println("      tools that serialize differently stop producing noisy diffs. Issues are")
+		fmt.Println("      unchanged. --fmt-check writes nothing and exits 1 if a file needs it.")
+		fmt.Println("")
+		fmt.Println("  --archive-older-than <age> [--archive-dry-run]  (or: bv archive --older-than <age> [--dry-run])")
+		fmt.Println("      Moves issues closed longer ago than <age> (90d, 720h, or a date) from the")
+		fmt.Println("      beads file to .beads/archive.jsonl, keeping the file fast to load.")
+		fmt.Println("      Archived issues are left out of every view; --include-archived loads")
+		fmt.Println("      them too, and Ctrl+A toggles them in the TUI.")
+		fmt.Println("      Example: bv archive --older-than 90d --dry-run")
+		fmt.Println("")
+		fmt.Println("  --fix-duplicates [--yes]")
+		fmt.Println("      Finds issues sharing an ID (e.g., after a bad merge) and shows each set")
+		fmt.Println("      side by side. Merge them into one issue (labels, dependencies and")
+		fmt.Println("      comments united) or give all but one copy a new ID, carrying its own")
+		fmt.Println("      dependencies along. Writes once, after confirmation (--yes skips it).")
+		fmt.Println("")
 		fmt.Println("  --no-hooks")
 		fmt.Println("      Skip running hooks during export. Useful for CI or quick exports.")
 		fmt.Println("")
+		fmt.Println("  --keymap")
+		fmt.Println("      Lists the TUI key bindings with their action names, including remaps.")
+		fmt.Println("      Remap keys in ~/.config/bv/keymap.yaml by action name, e.g.")
+		fmt.Println("        view.board: B")
+		fmt.Println("        nav.down: [j, ctrl+n]")
+		fmt.Println("      A remapped action no longer answers to its old keys.")
+		fmt.Println("")
+		fmt.Println("  --accessible")
+		fmt.Println("      Screen-reader friendly TUI: list rows in plain words instead of icons")
+		fmt.Println("      and badges, a single pane instead of the split view, and a footer")
+		fmt.Println("      that reads out the selected issue and notifications on one line.")
+		fmt.Println("      Turn it on for good with 'accessible: true' in ~/.config/bv/display.yaml.")
+		fmt.Println("      Colors are dropped whenever NO_COLOR is set.")
+		fmt.Println("")
+		fmt.Println("  --ascii")
+		fmt.Println("      Draw borders, progress bars, sparklines and markers with ASCII only,")
+		fmt.Println("      for terminals or fonts without Unicode support. Turned on by itself")
+		fmt.Println("      when the locale is not UTF-8 or TERM is linux, vt* or dumb;")
+		fmt.Println("      force it with 'ascii: true' or 'ascii: false' in ~/.config/bv/display.yaml.")
+		fmt.Println("")
 		fmt.Println("  Hook Configuration (.bv/hooks.yaml)")
 		fmt.Println("      Configure hooks to automate export workflows:")
 		fmt.Println("      - pre-export: Validation, notifications (failure cancels export)")
@@ -496,14 +877,17 @@ func main() {
 		fmt.Println("      Environment variables: BV_EXPORT_PATH, BV_EXPORT_FORMAT,")
 		fmt.Println("        BV_ISSUE_COUNT, BV_TIMESTAMP")
 		fmt.Println("")
-		fmt.Println("  --diff-since <commit|date>")
-		fmt.Println("      Shows changes since a historical point.")
-		fmt.Println("      Accepts: SHA, branch name, tag, HEAD~N, or date (YYYY-MM-DD)")
+		fmt.Println("  --diff-since <commit|date|file.jsonl> [--diff-to <commit|date|file.jsonl>]")
+		fmt.Println("      Shows changes since a historical point (or between two snapshots).")
+		fmt.Println("      Accepts: SHA, branch name, tag, HEAD~N, date (YYYY-MM-DD), or a JSONL snapshot file")
+		fmt.Println("      --diff-to replaces the current issues as the newer side of the comparison.")
 		fmt.Println("      Key output:")
 		fmt.Println("      - new_issues: Issues added since then")
 		fmt.Println("      - closed_issues: Issues that were closed")
 		fmt.Println("      - removed_issues: Issues deleted from tracker")
 		fmt.Println("      - modified_issues: Issues with field changes")
+		fmt.Println("      - status_changes / priority_changes: {issue_id, old_value, new_value}")
+		fmt.Println("      - dependency_changes: {issue_id, depends_on_id, type, change: added|removed}")
 		fmt.Println("      - new_cycles: Circular dependencies introduced")
 		fmt.Println("      - resolved_cycles: Circular dependencies fixed")
 		fmt.Println("      - summary.health_trend: 'improving', 'degrading', or 'stable'")
@@ -515,7 +899,8 @@ func main() {
 		fmt.Println("      Examples: --as-of HEAD~30, --as-of v1.0.0, --as-of '2024-01-01'")
 		fmt.Println("")
 		fmt.Println("  --robot-diff")
-		fmt.Println("      Output diff as JSON (use with --diff-since).")
+		fmt.Println("      Output diff as JSON (use with --diff-since, optionally --diff-to).")
+		fmt.Println("      Example: bv --robot-diff --diff-since yesterday.jsonl --diff-to today.jsonl")
 		fmt.Println("      Fields: generated_at, resolved_revision, from_data_hash, to_data_hash, diff{...}")
 		fmt.Println("      Diff payload includes metric deltas, cycles introduced/resolved, and modified issues.")
 		fmt.Println("")
@@ -558,6 +943,17 @@ func main() {
 		fmt.Println("        --graph-depth N: Limit subgraph depth (0 = unlimited)")
 		fmt.Println("      Fields: format, graph (string for dot/mermaid), nodes, edges, filters_applied, explanation")
 		fmt.Println("      Example: bv --robot-graph --graph-format=dot --label=api > api-deps.dot")
+		fmt.Println("      With --format=ndjson (json graph format only), streams one record per line:")
+		fmt.Println("      edges first (written before PageRank finishes), then nodes, then a summary.")
+		fmt.Println("      Example: bv --robot-graph --format=ndjson | jq -c 'select(.kind==\"edge\")'")
+		fmt.Println("")
+		fmt.Println("  --robot-list [--format=json|ndjson]")
+		fmt.Println("      Outputs one summary per issue: id, title, status, priority, issue_type,")
+		fmt.Println("      assignee, labels, ready, blocked_by (open blockers). Needs no graph analysis.")
+		fmt.Println("      Filters: --robot-by-label, --robot-by-assignee")
+		fmt.Println("      With --format=ndjson, writes one issue per line as it goes, so output")
+		fmt.Println("      starts immediately and memory stays flat on large repos.")
+		fmt.Println("      Example: bv --robot-list --format=ndjson | jq -c 'select(.ready)'")
 		fmt.Println("")
 		fmt.Println("  --export-graph <path.png|path.svg> [--graph-style=force|grid] [--graph-preset=compact|roomy]")
 		fmt.Println("      Export dependency graph as PNG or SVG image (pure Go, no external dependencies).")
@@ -583,6 +979,52 @@ func main() {
 		fmt.Println("      Example: bv --export-graph deps.svg --label=api --graph-title='API Dependencies'")
 		fmt.Println("      Example: bv --export-graph full.png --graph-style=force --graph-preset=roomy")
 		fmt.Println("")
+		fmt.Println("  --show-graph [--graph-protocol=auto|kitty|sixel] [--graph-width=PIXELS]")
+		fmt.Println("      Draw the PNG graph inline in the terminal instead of writing a file, far")
+		fmt.Println("      easier to read than ASCII boxes on large graphs. The protocol is detected")
+		fmt.Println("      from the environment: kitty for kitty, WezTerm and Ghostty; sixel for foot,")
+		fmt.Println("      mlterm, contour and Windows Terminal. Inside tmux, name it explicitly")
+		fmt.Println("      (and enable allow-passthrough). The image is scaled down to the terminal")
+		fmt.Println("      width, assuming 10-pixel cells; --graph-width overrides that.")
+		fmt.Println("      Takes --label, --export-filter, --graph-title and --graph-preset.")
+		fmt.Println("      Example: bv --show-graph --label=api")
+		fmt.Println("")
+		fmt.Println("  --export-mermaid <path.mmd|path.md|-> [--root ID] [--depth N] [--status LIST]")
+		fmt.Println("      Write just the Mermaid dependency diagram, without the rest of the report.")
+		fmt.Println("      A .md path gets a fenced ```mermaid block ready to paste into docs;")
+		fmt.Println("      - writes to stdout. --root keeps one issue and what it depends on,")
+		fmt.Println("      --depth limits how many levels down, and --status keeps only issues")
+		fmt.Println("      with the listed statuses. --export-filter applies too.")
+		fmt.Println("      Example: bv --export-mermaid docs/auth.md --root bv-12 --depth 2 --status open,in_progress")
+		fmt.Println("")
+		fmt.Println("  --export-plantuml <path.puml|path.md|-> [--plantuml-style=component|wbs]")
+		fmt.Println("      The same diagram as PlantUML, for doc toolchains that render it. Takes")
+		fmt.Println("      --root, --depth, --status and --export-filter like --export-mermaid.")
+		fmt.Println("        component (default): issues as boxes, arrows to what each depends on")
+		fmt.Println("        wbs: work breakdown tree, epics over their children and each issue")
+		fmt.Println("             over what it depends on")
+		fmt.Println("      Example: bv --export-plantuml docs/plan.puml --plantuml-style=wbs --root bv-1")
+		fmt.Println("")
+		fmt.Println("  --export-outline <path.opml|path.md|-> [--outline-format=markdown|opml]")
+		fmt.Println("      Write the parent-child hierarchy (epics over their children) as an outline")
+		fmt.Println("      for outliners and mind-mapping tools. Every entry shows its status, priority")
+		fmt.Println("      and type; closed issues are checked off. .opml paths get OPML 2.0, anything")
+		fmt.Println("      else a Markdown task list. --brand-title names the outline; --export-filter applies.")
+		fmt.Println("      Example: bv --export-outline roadmap.opml --export-filter 'type:epic'")
+		fmt.Println("")
+		fmt.Println("  --export-ics <path.ics|->  [--forecast-agents N]")
+		fmt.Println("      Write an iCalendar file to overlay planned work on a calendar client.")
+		fmt.Println("      Issues with a due date become to-dos (VTODO) due then; open issues get")
+		fmt.Println("      an all-day event (VEVENT) spanning their ETA window, as in --robot-forecast.")
+		fmt.Println("      UIDs are stable, so re-importing updates entries. --export-filter applies.")
+		fmt.Println("      Example: bv --export-ics ~/Calendars/beads.ics --forecast-agents 2")
+		fmt.Println("")
+		fmt.Println("  --export-badge <path.svg>")
+		fmt.Println("      Write the project health score (0-100) as a shields-style SVG badge for READMEs.")
+		fmt.Println("      The score weighs blocked issues, dependency cycles, stale issues, priority")
+		fmt.Println("      inversions and the velocity trend; see project_health.score in --robot-triage.")
+		fmt.Println("      Example: bv --export-badge docs/health.svg")
+		fmt.Println("")
 		fmt.Println("  --robot-insights")
 		fmt.Println("      Graph metrics JSON for agents.")
 		fmt.Println("      Top lists: Bottlenecks (betweenness), Keystones (critical path), Influencers (eigenvector),")
@@ -607,6 +1049,7 @@ func main() {
 		fmt.Println("  --robot-plan")
 		fmt.Println("      Execution tracks grouped for parallel work. Includes data_hash, analysis_config, status.")
 		fmt.Println("      plan.tracks[].items[].unblocks shows what completes next; summary.highest_impact surfaces best unblocker.")
+		fmt.Println("      --plan-agents N balances tracks across N agents; plan.critical_track and plan.makespan_minutes show the bottleneck.")
 		fmt.Println("")
 		fmt.Println("  --robot-priority")
 		fmt.Println("      Priority recommendations with explanations. Includes data_hash, analysis_config, status.")
@@ -627,6 +1070,51 @@ func main() {
 		fmt.Println("      Includes label_scope and label_context in output with health metrics.")
 		fmt.Println("      Example: bv --robot-insights --label api")
 		fmt.Println("")
+		fmt.Println("  --robot-cycles [--cycles-limit=N]")
+		fmt.Println("      Lists dependency cycles among blocking edges with fix suggestions.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - components: Strongly connected components containing cycles")
+		fmt.Println("      - cycles: Member IDs plus edge metadata (type, created_at, created_by)")
+		fmt.Println("      - feedback_arc_set: Suggested minimal edge set to remove, with bd commands")
+		fmt.Println("      - acyclic_after: True when removing the suggested edges breaks every cycle")
+		fmt.Println("      Example: bv --robot-cycles | jq '.feedback_arc_set[].command'")
+		fmt.Println("")
+		fmt.Println("  --robot-clusters")
+		fmt.Println("      Groups open issues into clusters of tightly linked work for partitioning.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - clusters: id, anchor, size, open_count, members, internal/external edges, labels")
+		fmt.Println("      - assignments: Issue ID -> cluster id (unclustered issues are omitted)")
+		fmt.Println("      - modularity: Partition quality (higher = cleaner separation)")
+		fmt.Println("      Example: bv --robot-clusters | jq '.clusters[] | select(.external_edges == 0) | .members'")
+		fmt.Println("")
+		fmt.Println("  --robot-stale [--stale-days=N]")
+		fmt.Println("      Lists unresolved issues with no update in N days, grouped by status.")
+		fmt.Println("      Thresholds default to .bv/drift.yaml (stale_warning_days, in_progress_stale_multiplier,")
+		fmt.Println("      label_overrides); --stale-days replaces the base and label thresholds.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - groups: in_progress, blocked, open; each with count and issues")
+		fmt.Println("      - issues: staleness_days, threshold_days, severity, open_blockers")
+		fmt.Println("      - suggested_actions: action, reason and bd command (check_in, release, unblock, close...)")
+		fmt.Println("      Example: bv --robot-stale --stale-days 30 | jq '.groups[].issues[] | {id, staleness_days}'")
+		fmt.Println("")
+		fmt.Println("  --robot-unblock")
+		fmt.Println("      Ranks the open blockers by how many issues closing each one would unblock,")
+		fmt.Println("      counting the cascade: the \"pull this thread first\" queue.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - items: unblocks_count, unblocks_ids (freed directly), waiting_count, critical_path_score")
+		fmt.Println("      - actionable: the blocker can be started now; otherwise blocked_by lists its own blockers")
+		fmt.Println("      Example: bv --robot-unblock | jq '.items[] | select(.actionable) | .id' | head -3")
+		fmt.Println("")
+		fmt.Println("  --robot-recur [--recur-dry-run]")
+		fmt.Println("      Materializes recurring issue templates from .beads/templates/*.yaml.")
+		fmt.Println("      Each due template gets one issue for its latest occurrence; the issue's")
+		fmt.Println("      external_ref (template:NAME@DATE) keeps repeated runs idempotent.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - created: New issues (id, title, template, date, due_date)")
+		fmt.Println("      - schedule: Latest and next occurrence per recurring template")
+		fmt.Println("      - warnings: Template files that failed to parse or validate")
+		fmt.Println("      Example: bv --robot-recur --recur-dry-run | jq '.created[].title'")
+		fmt.Println("")
 		fmt.Println("  --robot-triage / --robot-next")
 		fmt.Println("      Unified triage (mega command) or single top pick. QuickRef includes top picks, quick_wins, blockers_to_clear.")
 		fmt.Println("")
@@ -635,11 +1123,17 @@ func main() {
 		fmt.Println("      Example: bv --recipe actionable")
 		fmt.Println("      Built-in recipes: default, actionable, recent, blocked, high-impact, stale")
 		fmt.Println("")
-		fmt.Println("  --profile-startup")
+		fmt.Println("  --profile-startup [--format table|json]")
 		fmt.Println("      Outputs detailed startup timing profile for diagnostics.")
-		fmt.Println("      Shows Phase 1 (blocking) and Phase 2 (async) breakdown.")
-		fmt.Println("      Provides recommendations based on timing analysis.")
-		fmt.Println("      Use with --profile-json for machine-readable output.")
+		fmt.Println("      Shows Phase 1 (blocking) and Phase 2 (async) breakdown, timeouts,")
+		fmt.Println("      and suggestions for tuning the analysis (e.g. approximate betweenness).")
+		fmt.Println("      --format json (or --profile-json) for machine-readable output.")
+		fmt.Println("      In the TUI, Ctrl+G shows the same diagnostics.")
+		fmt.Println("")
+		fmt.Println("  --lazy-text")
+		fmt.Println("      Stream the beads file without descriptions, design, acceptance criteria,")
+		fmt.Println("      notes and comments, and read them when an issue is opened in the TUI.")
+		fmt.Println("      Cuts memory on very large files. Robot output and exports are unaffected.")
 		fmt.Println("")
 		fmt.Println("  --workspace CONFIG")
 		fmt.Println("      Load issues from workspace configuration file.")
@@ -647,6 +1141,32 @@ func main() {
 		fmt.Println("      Aggregates issues from multiple repositories with namespaced IDs.")
 		fmt.Println("      Example: bv --workspace .bv/workspace.yaml")
 		fmt.Println("")
+		fmt.Println("  workspace init [--scan DIR] [--force] [--depth N]")
+		fmt.Println("      Find repos with a .beads folder under DIR (default: current directory,")
+		fmt.Println("      3 levels deep) and write DIR/.bv/workspace.yaml with one prefix per repo.")
+		fmt.Println("      Same as --workspace-init DIR. --force overwrites an existing config.")
+		fmt.Println("      Example: bv workspace init --scan ~/src/platform")
+		fmt.Println("")
+		fmt.Println("  hooks install [--append-id]")
+		fmt.Println("      Install a git pre-commit hook that rejects commits whose staged beads")
+		fmt.Println("      file has malformed lines, invalid issues or dependency cycles.")
+		fmt.Println("      --append-id also installs a commit-msg hook that adds 'Refs: <id>' for")
+		fmt.Println("      the bead named by the current branch when the message names none.")
+		fmt.Println("      Existing hooks are kept and run first. Same as --hooks-install.")
+		fmt.Println("  hooks uninstall")
+		fmt.Println("      Remove bv's hooks and restore the ones they replaced.")
+		fmt.Println("      Example: bv hooks install --append-id")
+		fmt.Println("")
+		fmt.Println("  merge-driver install")
+		fmt.Println("      Register 'bv merge-driver %O %A %B' as git merge driver \"beads\" in the")
+		fmt.Println("      repo's git config and route .beads/*.jsonl to it in .gitattributes.")
+		fmt.Println("      Git then merges beads files issue by issue: issues from both sides are")
+		fmt.Println("      kept, each field takes the side that changed it (the later updated_at")
+		fmt.Println("      when both did), and labels, dependencies and comments are united.")
+		fmt.Println("      Commit .gitattributes; every clone runs the install once. By hand:")
+		fmt.Println("        echo '.beads/*.jsonl merge=beads' >> .gitattributes")
+		fmt.Println("        git config merge.beads.driver 'bv merge-driver %O %A %B'")
+		fmt.Println("")
 		fmt.Println("  --repo PREFIX")
 		fmt.Println("      Filter issues by repository prefix.")
 		fmt.Println("      Use with --workspace to focus on one repo in a multi-repo view.")
@@ -675,6 +1195,19 @@ func main() {
 		fmt.Println("      Output drift check as JSON (use with --check-drift).")
 		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, baseline}")
 		fmt.Println("")
+		fmt.Println("  --check-cycles, --check-stale=14d, --check-orphans")
+		fmt.Println("      Gate scripts and agent pipelines on backlog health. Flags combine;")
+		fmt.Println("      each selected check prints PASS or FAIL with up to 10 violations.")
+		fmt.Println("        --check-cycles    Dependency cycles among blocking edges")
+		fmt.Println("        --check-stale=D   Unresolved issues not updated for D (14, 14d, 2w, 1m)")
+		fmt.Println("        --check-orphans   Dependencies on issue IDs missing from the tracker")
+		fmt.Println("      Exit codes: 0 = all checks pass, 1 = violations found, 2 = usage error")
+		fmt.Println("      Example: bv --check-cycles --check-orphans || exit 1")
+		fmt.Println("")
+		fmt.Println("  --robot-check")
+		fmt.Println("      Output check results as JSON (use with --check-*).")
+		fmt.Println("      Output: {passed, exit_code, checks: [{name, passed, violations}]}")
+		fmt.Println("")
 		fmt.Println("  Static Site Export & GitHub Pages (bv-7pu):")
 		fmt.Println("      --pages")
 		fmt.Println("          Launch interactive Pages deployment wizard.")
@@ -695,9 +1228,77 @@ func main() {
 		fmt.Println("      --pages-title <title>")
 		fmt.Println("          Custom title for the static site (default: 'Project Issues')")
 		fmt.Println("")
+		fmt.Println("      --brand-title, --brand-logo, --brand-accent, --brand-footer")
+		fmt.Println("          Brand the site and --export-md reports: title, logo (file or URL),")
+		fmt.Println("          accent color (#rrggbb) and footer text. Defaults come from")
+		fmt.Println("          .bv/branding.yaml (title, logo, accent_color, footer); --pages-title")
+		fmt.Println("          still sets the site title.")
+		fmt.Println("          Example: bv --export-pages ./bv-pages --brand-logo logo.svg --brand-accent '#e11d48'")
+		fmt.Println("")
 		fmt.Println("      --pages-include-closed=false")
 		fmt.Println("          Exclude closed issues from export (default: include all)")
 		fmt.Println("")
+		fmt.Println("      --pages-push [--pages-branch=gh-pages]")
+		fmt.Println("          Commit the exported site as the whole tree of a branch in this repo")
+		fmt.Println("          (your working tree and index are untouched) and push it to origin.")
+		fmt.Println("          Example: bv --export-pages ./bv-pages --pages-push")
+		fmt.Println("")
+		fmt.Println("      bv publish <dir> [flags]")
+		fmt.Println("          Shorthand for --export-pages <dir>, titled after the project directory")
+		fmt.Println("          unless --pages-title is given. Combine with --pages-push to share it.")
+		fmt.Println("          Example: bv publish ./bv-pages --pages-push")
+		fmt.Println("")
+		fmt.Println("      --pages-incremental")
+		fmt.Println("          Diff against the existing beads.sqlite3 by content hash and apply")
+		fmt.Println("          INSERT/UPDATE/DELETE instead of rebuilding (falls back to a full")
+		fmt.Println("          rebuild when there is no compatible database).")
+		fmt.Println("          Example: bv --export-pages ./bv-pages --pages-incremental")
+		fmt.Println("")
+		fmt.Println("      --pages-signing-key <key>")
+		fmt.Println("          Sign beads.sqlite3.config.json, which lists the hashes of the database")
+		fmt.Println("          and its chunks, writing a minisign signature next to it. Takes an")
+		fmt.Println("          unencrypted minisign secret key or a PEM Ed25519 key. The --pages")
+		fmt.Println("          wizard reads \"signing_key\" from ~/.config/bv/pages-wizard.json.")
+		fmt.Println("          Example: bv --export-pages ./bv-pages --pages-signing-key ~/.minisign/bv.key")
+		fmt.Println("")
+		fmt.Println("      --verify-pages <dir> --verify-key <pubkey>")
+		fmt.Println("          Check an export's manifest signature, then the database and chunks")
+		fmt.Println("          against it. Exits 1 on any mismatch.")
+		fmt.Println("          Example: bv --verify-pages ./bv-pages --verify-key bv.pub")
+		fmt.Println("")
+		fmt.Println("  Serve Mode:")
+		fmt.Println("      bv serve [--port 8080] [--host 127.0.0.1]")
+		fmt.Println("          Run a read-only HTTP server with a web UI and a JSON API")
+		fmt.Println("          (/api/issues[?filter=], /api/issues/<id>, /api/metrics, /api/triage,")
+		fmt.Println("          /api/meta). The beads file is re-read as it changes.")
+		fmt.Println("          Use --host 0.0.0.0 to let teammates on the network connect.")
+		fmt.Println("          Also available as --serve --serve-port N --serve-host H.")
+		fmt.Println("")
+		fmt.Println("  Issue IDs:")
+		fmt.Println("      bv new-id [--count N] [--prefix P] [--format json]")
+		fmt.Println("          Print unused IDs in the style of the beads file (prefix-xxxx, with")
+		fmt.Println("          the prefix most issues use), one per line. IDs of deleted issues")
+		fmt.Println("          are never reused. Also available as --new-id --new-id-count N.")
+		fmt.Println("          Example: id=$(bv new-id)")
+		fmt.Println("")
+		fmt.Println("  Daemon Mode (.bv/daemon.sock):")
+		fmt.Println("      bv daemon")
+		fmt.Println("          Keep the issues and their analysis in memory and answer robot")
+		fmt.Println("          queries on a unix socket, one JSON line per request and response:")
+		fmt.Println("          {\"command\": \"triage\", \"args\": {\"by_track\": true}}. Commands: status,")
+		fmt.Println("          triage, next, plan {agents}, priority {limit}, insights {limit},")
+		fmt.Println("          shutdown. Results are cached until the beads file changes.")
+		fmt.Println("      bv daemon query <command> ['<json args>']")
+		fmt.Println("          Send one command and print the response (also: --daemon-query,")
+		fmt.Println("          --daemon-args). bv daemon status and bv daemon stop are shorthands.")
+		fmt.Println("")
+		fmt.Println("  Notifications (.bv/notify.yaml):")
+		fmt.Println("      bv notify [--dry-run]")
+		fmt.Println("          Post a digest (triage top picks, newly blocked, overdue) to the")
+		fmt.Println("          Slack, Discord, JSON webhook or SMTP targets in .bv/notify.yaml.")
+		fmt.Println("          Run it from cron or CI; --dry-run prints the digest instead.")
+		fmt.Println("          Also available as --notify [--notify-dry-run].")
+		fmt.Println("")
 		fmt.Println("  Drift Detection Configuration (.bv/drift.yaml)")
 		fmt.Println("      Customize drift detection thresholds:")
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
@@ -711,6 +1312,24 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --keymap: the TUI bindings, with the user's remaps applied
+	if *keymapFlag {
+		keymap, err := ui.LoadKeymap(ui.KeymapConfigPath())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, section := range keymap.AllSections() {
+			fmt.Println(section.Title)
+			for _, b := range section.Bindings {
+				fmt.Printf("  %-14s %-26s %s\n", b.KeysLabel(), b.Action, b.Help)
+			}
+			fmt.Println("")
+		}
+		fmt.Printf("Remap keys in %s\n", ui.KeymapConfigPath())
+		os.Exit(0)
+	}
+
 	// Handle --check-update (bv-182)
 	if *checkUpdateFlag {
 		available, newVersion, releaseURL, err := updater.CheckUpdateAvailable()
@@ -905,6 +1524,16 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --daemon-query: answered by the running daemon, nothing to load
+	if *daemonQuery != "" {
+		os.Exit(runDaemonQuery(*daemonQuery, *daemonArgs))
+	}
+
+	// Handle --daemon / bv daemon: the daemon loads the beads file itself
+	if *daemonFlag {
+		os.Exit(runDaemon())
+	}
+
 	// Get project directory for baseline operations (moved up to allow info check without loading issues)
 	projectDir, _ := os.Getwd()
 	baselinePath := baseline.DefaultPath(projectDir)
@@ -925,6 +1554,47 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --workspace-init (scans the tree; no issues needed)
+	if *workspaceInit != "" {
+		root, err := filepath.Abs(*workspaceInit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		repos, err := workspace.ScanRepos(root, *workspaceInitDepth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(repos) == 0 {
+			fmt.Fprintf(os.Stderr, "No repos with a .beads folder found under %s (depth %d)\n", root, *workspaceInitDepth)
+			os.Exit(1)
+		}
+		configPath := workspace.ConfigPath(root)
+		cfg := workspace.Config{Name: filepath.Base(root), Repos: repos}
+		if err := workspace.WriteConfig(configPath, cfg, *workspaceInitForce); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Found %d repos:\n", len(repos))
+		for _, r := range repos {
+			fmt.Printf("  %-20s %-24s %s\n", r.Name, r.Path, r.Prefix)
+		}
+		fmt.Printf("Wrote %s\n", configPath)
+		fmt.Printf("Open it with: bv --workspace %s\n", configPath)
+		os.Exit(0)
+	}
+
+	// Handle git hook installation and the hooks themselves (no issues needed)
+	if *hooksInstall || *hooksUninstall || *hookPreCommit || *hookCommitMsg != "" {
+		os.Exit(runGitHookCommand(*hooksInstall, *hooksUninstall, *hooksAppendID, *hookPreCommit, *hookCommitMsg))
+	}
+
+	// Handle the git merge driver and its installation (no issues needed)
+	if *mergeDriver || *mergeDriverInstall {
+		os.Exit(runMergeDriverCommand(*mergeDriverInstall, flag.Args()))
+	}
+
 	// Validate recipe name if provided (before loading issues)
 	var activeRecipe *recipe.Recipe
 	if *recipeName != "" {
@@ -944,8 +1614,11 @@ func main() {
 	loadStart := time.Now()
 	var issues []model.Issue
 	var beadsPath string
+	var textIndex *loader.TextIndex // Set with --lazy-text
+	var startTutorial bool          // Set after first-run setup
 	var workspaceInfo *workspace.LoadSummary
-	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
+	var asOfResolved string                 // Resolved commit SHA when using --as-of (for robot output metadata)
+	var analysisConfig analysisConfigStatus // .bv/analysis.yaml overrides, unset with --as-of
 
 	if *asOf != "" {
 		// Time-travel mode: load historical issues from git
@@ -975,6 +1648,10 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Loaded %d issues from %s\n", len(issues), *asOf)
 			}
 		}
+	} else if *diffSince != "" && *diffTo != "" {
+		// Both diff endpoints are explicit snapshots; the working tree's
+		// issues are not needed (and may not exist).
+		beadsPath = ""
 	} else if *workspaceConfig != "" {
 		// Load from workspace configuration
 		loadedIssues, results, err := workspace.LoadAllFromConfig(context.Background(), *workspaceConfig)
@@ -995,6 +1672,16 @@ func main() {
 				}
 			}
 		}
+		if len(summary.UnresolvedDeps) > 0 && !envRobot {
+			fmt.Fprintf(os.Stderr, "Warning: %d cross-repo dependencies could not be resolved\n", len(summary.UnresolvedDeps))
+			for i, u := range summary.UnresolvedDeps {
+				if i == 10 {
+					fmt.Fprintf(os.Stderr, "  ... and %d more\n", len(summary.UnresolvedDeps)-10)
+					break
+				}
+				fmt.Fprintf(os.Stderr, "  - %s\n", u)
+			}
+		}
 		// No live reload for workspace mode (multiple files)
 		beadsPath = ""
 
@@ -1002,10 +1689,41 @@ func main() {
 		// Workspace config is typically at .bv/workspace.yaml, so project root is two levels up
 		workspaceRoot := filepath.Dir(filepath.Dir(*workspaceConfig))
 		_ = loader.EnsureBVInGitignore(workspaceRoot)
+		analysisConfig = loadAnalysisConfig(workspaceRoot)
 	} else {
 		// Load from single repo (original behavior)
 		var err error
-		issues, err = loader.LoadIssues("")
+
+		// First run: offer to set up .beads instead of failing
+		if !robotMode && stdoutIsTTY && term.IsTerminal(int(os.Stdin.Fd())) {
+			if dir, dirErr := loader.GetBeadsDir(""); dirErr == nil && onboarding.NeedsSetup(dir) {
+				_, setupErr := onboarding.NewWizard(dir).Run()
+				switch {
+				case errors.Is(setupErr, onboarding.ErrDeclined):
+					// Fall through to the usual load (and error, if still missing)
+				case setupErr != nil:
+					fmt.Fprintf(os.Stderr, "Error setting up beads: %v\n", setupErr)
+					os.Exit(1)
+				default:
+					startTutorial = true
+				}
+			}
+		}
+
+		if *lazyText && !robotMode && *exportFile == "" && *exportPages == "" {
+			// Text fields are read on demand in the TUI; exports need them up front
+			beadsDir, _ := loader.GetBeadsDir("")
+			if beadsPath, err = loader.FindJSONLPath(beadsDir); err == nil {
+				issues, textIndex, err = loader.LoadIssuesLazy(beadsPath, loader.ParseOptions{IncludeArchived: *includeArchived})
+			}
+		} else if *includeArchived {
+			beadsDir, _ := loader.GetBeadsDir("")
+			if beadsPath, err = loader.FindJSONLPath(beadsDir); err == nil {
+				issues, err = loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{IncludeArchived: true})
+			}
+		} else {
+			issues, err = loader.LoadIssues("")
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
@@ -1020,6 +1738,7 @@ func main() {
 		// This is done silently and only in single-repo mode.
 		projectDir := filepath.Dir(beadsDir)
 		_ = loader.EnsureBVInGitignore(projectDir)
+		analysisConfig = loadAnalysisConfig(projectDir)
 	}
 	loadDuration := time.Since(loadStart)
 
@@ -1082,52 +1801,34 @@ func main() {
 			os.Exit(1)
 		}
 
-		embedder, err := search.NewEmbedderFromConfig(embedCfg)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-
 		projectDir, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		indexPath := search.DefaultIndexPath(projectDir, embedCfg)
-		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
 
 		docs := search.DocumentsFromIssues(issuesForSearch)
-		if !*robotSearch && !loaded {
-			fmt.Fprintf(os.Stderr, "Building semantic index (%d issues)...\n", len(docs))
-		}
-
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
+		var progress io.Writer = os.Stderr
+		if *robotSearch {
+			progress = nil
+		}
+		si, err := openSemanticIndex(ctx, semanticIndexOptions{
+			ProjectDir: projectDir,
+			Config:     embedCfg,
+			Docs:       docs,
+			IndexPath:  search.DefaultIndexPath,
+			Query:      *semanticQuery,
+			Warn:       os.Stderr,
+			Progress:   progress,
+		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error building semantic index: %v\n", err)
-			os.Exit(1)
-		}
-		if !loaded || syncStats.Changed() {
-			if err := idx.Save(indexPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving semantic index: %v\n", err)
-				os.Exit(1)
-			}
-		}
-
-		qvecs, err := embedder.Embed(ctx, []string{*semanticQuery})
-		if err != nil || len(qvecs) != 1 {
-			if err == nil {
-				err = fmt.Errorf("embedder returned %d vectors for query", len(qvecs))
-			}
-			fmt.Fprintf(os.Stderr, "Error embedding query: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		embedCfg, embedder, idx, indexPath, loaded, syncStats := si.Config, si.Embedder, si.Index, si.Path, si.Loaded, si.Stats
 
 		limit := *searchLimit
 		if limit <= 0 {
@@ -1137,7 +1838,7 @@ func main() {
 		if searchCfg.Mode == search.SearchModeHybrid {
 			fetchLimit = search.HybridCandidateLimit(limit, len(issuesForSearch), *semanticQuery)
 		}
-		results, err := idx.SearchTopK(qvecs[0], fetchLimit)
+		results, err := idx.SearchTopK(si.Query, fetchLimit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error searching index: %v\n", err)
 			os.Exit(1)
@@ -1257,12 +1958,76 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-duplicates
+	if *robotDuplicates {
+		if *duplicatesThreshold < 0 || *duplicatesThreshold > 1 {
+			fmt.Fprintf(os.Stderr, "Error: --duplicates-threshold must be between 0 and 1, got %v\n", *duplicatesThreshold)
+			os.Exit(1)
+		}
+		projectDir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		si, err := openSemanticIndex(ctx, semanticIndexOptions{
+			ProjectDir: projectDir,
+			Config:     search.EmbeddingConfigFromEnv(),
+			Docs:       search.SimilarityDocuments(issuesForSearch),
+			IndexPath:  search.SimilarityIndexPath,
+			Warn:       os.Stderr,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		out := buildRobotDuplicatesOutput(issuesForSearch, si, *duplicatesThreshold, *duplicatesIncludeClosed)
+		out.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+		out.DataHash = dataHash
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding duplicates: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --pages wizard (bv-10g)
 	if *pagesWizard {
-		if err := runPagesWizard(issues, beadsPath); err != nil {
+		brand, err := exportBranding(export.Branding{Title: *brandTitle, Logo: *brandLogo, AccentColor: *brandAccent, Footer: *brandFooter})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := runPagesWizard(issues, beadsPath, brand); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --verify-pages (no analysis needed either)
+	if *verifyPages != "" {
+		if *verifyKey == "" {
+			fmt.Fprintln(os.Stderr, "Error: --verify-pages requires --verify-key")
+			os.Exit(2)
+		}
+		key, err := export.LoadVerifyKey(*verifyKey)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		result, err := export.VerifyExport(*verifyPages, key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Verification FAILED: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Signature and hashes OK (key %s, %d files)\n", result.KeyID, result.Files)
+		fmt.Printf("  %s\n", result.TrustedComment)
 		os.Exit(0)
 	}
 
@@ -1275,9 +2040,61 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --mcp: stdout carries only JSON-RPC from here on
+	if *mcpFlag {
+		load := func() ([]model.Issue, error) {
+			if beadsPath == "" {
+				return issues, nil
+			}
+			return loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{IncludeArchived: *includeArchived})
+		}
+		if err := mcp.NewServer(version.Version, load).Serve(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running MCP server: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --serve / bv serve
+	if *serveFlag {
+		load := func() ([]model.Issue, error) {
+			if beadsPath == "" {
+				return issues, nil
+			}
+			return loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{IncludeArchived: *includeArchived})
+		}
+		title := *pagesTitle
+		if title == "" {
+			if cwd, err := os.Getwd(); err == nil {
+				title = filepath.Base(cwd)
+			}
+		}
+		addr := net.JoinHostPort(*serveHost, strconv.Itoa(*servePort))
+		fmt.Printf("Serving %d issues at http://%s (Ctrl+C to stop)\n", len(issues), addr)
+		if err := export.NewServer(addr, title, load).ListenAndServe(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running server: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --export-pages (bv-73f)
 	if *exportPages != "" {
 		fmt.Println("Exporting static site...")
+		brand, err := exportBranding(export.Branding{Title: *brandTitle, Logo: *brandLogo, AccentColor: *brandAccent, Footer: *brandFooter})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if *pagesTitle == "" {
+			*pagesTitle = brand.Title
+		}
+		if publishing && *pagesTitle == "" {
+			if cwd, err := os.Getwd(); err == nil {
+				*pagesTitle = filepath.Base(cwd)
+			}
+		}
+		brand.Title = *pagesTitle
 		fmt.Printf("  → Loading %d issues\n", len(issues))
 
 		// Filter closed issues if not requested
@@ -1292,6 +2109,7 @@ func main() {
 			exportIssues = openIssues
 			fmt.Printf("  → Filtering to %d open issues\n", len(exportIssues))
 		}
+		exportIssues = applyExportFilter(exportIssues, *exportFilter)
 
 		// Load and run pre-export hooks (bv-qjc.3)
 		cwd, _ := os.Getwd()
@@ -1352,9 +2170,9 @@ func main() {
 			issuePointers[i] = &exportIssues[i]
 		}
 		exporter := export.NewSQLiteExporter(issuePointers, deps, stats, &triage)
-		if *pagesTitle != "" {
-			exporter.Config.Title = *pagesTitle
-		}
+		exporter.Config.SetBranding(brand)
+		exporter.Config.Incremental = *pagesIncremental
+		exporter.Config.SigningKey = *pagesSigningKey
 
 		// Export SQLite database
 		fmt.Println("  → Writing database and JSON files...")
@@ -1362,10 +2180,19 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
 			os.Exit(1)
 		}
+		if d := exporter.Delta; d != nil {
+			fmt.Printf("  → Incremental update: %d inserted, %d updated, %d deleted, %d unchanged\n",
+				d.Inserted, d.Updated, d.Deleted, d.Unchanged)
+		} else if *pagesIncremental {
+			fmt.Println("  → No compatible database to update; rebuilt from scratch")
+		}
+		if *pagesSigningKey != "" {
+			fmt.Printf("  → Signed manifest: %s\n", export.ManifestSignatureFileName)
+		}
 
 		// Copy viewer assets
 		fmt.Println("  → Copying viewer assets...")
-		if err := copyViewerAssets(*exportPages, *pagesTitle); err != nil {
+		if err := copyViewerAssets(*exportPages, brand); err != nil {
 			fmt.Fprintf(os.Stderr, "Error copying assets: %v\n", err)
 			os.Exit(1)
 		}
@@ -1408,6 +2235,27 @@ func main() {
 			}
 		}
 
+		// Publish to a branch of this repo (e.g. for GitHub Pages)
+		if *pagesPush {
+			fmt.Printf("  → Publishing to branch %s...\n", *pagesBranch)
+			// GitHub Pages would otherwise run Jekyll and hide underscore paths
+			if err := os.WriteFile(filepath.Join(*exportPages, ".nojekyll"), nil, 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing .nojekyll: %v\n", err)
+				os.Exit(1)
+			}
+			cwd, _ := os.Getwd()
+			commit, err := export.CommitBundleToBranch(cwd, *exportPages, *pagesBranch, "origin", "Publish static site via bv")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error publishing: %v\n", err)
+				os.Exit(1)
+			}
+			if commit == "" {
+				fmt.Printf("  → %s already up to date; pushed to origin\n", *pagesBranch)
+			} else {
+				fmt.Printf("  → Committed %s to %s and pushed to origin\n", commit[:min(len(commit), 12)], *pagesBranch)
+			}
+		}
+
 		fmt.Println("")
 		fmt.Printf("✓ Static site exported to: %s\n", *exportPages)
 		fmt.Println("")
@@ -1566,8 +2414,104 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-list
+	if *robotList {
+		ndjson, err := parseOutputFormat(*outputFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		issueMap := make(map[string]*model.Issue, len(issues))
+		for i := range issues {
+			issueMap[issues[i].ID] = &issues[i]
+		}
+		summaries := func(yield func(export.IssueSummary) bool) {
+			for _, iss := range issues {
+				if *robotByLabel != "" && !slices.Contains(iss.Labels, *robotByLabel) {
+					continue
+				}
+				if *robotByAssignee != "" && iss.Assignee != *robotByAssignee {
+					continue
+				}
+				if !yield(export.SummarizeIssue(iss, issueMap)) {
+					return
+				}
+			}
+		}
+
+		if ndjson {
+			w := bufio.NewWriter(os.Stdout)
+			encoder := json.NewEncoder(w)
+			for summary := range summaries {
+				if err := encoder.Encode(summary); err != nil {
+					fmt.Fprintf(os.Stderr, "Error encoding issue list: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding issue list: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
+		output := struct {
+			GeneratedAt string                `json:"generated_at"`
+			DataHash    string                `json:"data_hash"`
+			Count       int                   `json:"count"`
+			Issues      []export.IssueSummary `json:"issues"`
+			UsageHints  []string              `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			Issues:      slices.Collect(summaries),
+			UsageHints: []string{
+				"jq '.issues[] | select(.ready) | .id' - Ready issue IDs",
+				"jq '.issues[] | select(.blocked_by) | {id, blocked_by}' - What each blocked issue waits on",
+				"--format=ndjson - Stream one issue per line for large repos",
+			},
+		}
+		if output.Issues == nil {
+			output.Issues = []export.IssueSummary{}
+		}
+		output.Count = len(output.Issues)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding issue list: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-graph (bv-136)
 	if *robotGraph {
+		ndjson, err := parseOutputFormat(*outputFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		if ndjson {
+			if f := strings.ToLower(*graphFormat); f != "json" {
+				fmt.Fprintf(os.Stderr, "Error: --format=ndjson requires --graph-format=json (got %s)\n", f)
+				os.Exit(2)
+			}
+			// Phase 1 returns immediately; edges stream while PageRank runs
+			stats := analysis.NewAnalyzer(issues).AnalyzeAsync(context.Background())
+			config := export.GraphExportConfig{
+				Format:   export.GraphFormatJSON,
+				Label:    *labelScope,
+				Root:     *graphRoot,
+				Depth:    *graphDepth,
+				DataHash: dataHash,
+			}
+			if err := export.StreamGraphNDJSON(os.Stdout, issues, stats, config); err != nil {
+				fmt.Fprintf(os.Stderr, "Error exporting graph: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
 		analyzer := analysis.NewAnalyzer(issues)
 		stats := analyzer.Analyze()
 
@@ -1605,12 +2549,107 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Handle --export-graph (bv-94) - PNG/SVG/HTML export
-	if *exportGraph != "" {
-		analyzer := analysis.NewAnalyzer(issues)
-		stats := analyzer.Analyze()
-
-		// Apply label filter if specified
+	// Handle --export-mermaid
+	if *exportMermaid != "" {
+		statuses, err := export.ParseDiagramStatuses(*diagramStatus)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --status: %v\n", err)
+			os.Exit(2)
+		}
+		opts := export.DiagramOptions{Root: *diagramRoot, Depth: *diagramDepth, Statuses: statuses}
+		diagram, err := export.GenerateMermaid(applyExportFilter(issues, *exportFilter), opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeDiagram(*exportMermaid, diagram, "mermaid"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing diagram: %v\n", err)
+			os.Exit(1)
+		}
+		if *exportMermaid != "-" {
+			fmt.Printf("Wrote Mermaid diagram to %s\n", *exportMermaid)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --export-plantuml
+	if *exportPlantUML != "" {
+		style, err := export.ParsePlantUMLStyle(*plantUMLStyle)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --plantuml-style: %v\n", err)
+			os.Exit(2)
+		}
+		statuses, err := export.ParseDiagramStatuses(*diagramStatus)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --status: %v\n", err)
+			os.Exit(2)
+		}
+		opts := export.DiagramOptions{Root: *diagramRoot, Depth: *diagramDepth, Statuses: statuses}
+		diagram, err := export.GeneratePlantUML(applyExportFilter(issues, *exportFilter), opts, style)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeDiagram(*exportPlantUML, diagram, "plantuml"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing diagram: %v\n", err)
+			os.Exit(1)
+		}
+		if *exportPlantUML != "-" {
+			fmt.Printf("Wrote PlantUML diagram to %s\n", *exportPlantUML)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --export-outline
+	if *exportOutline != "" {
+		format, err := export.ParseOutlineFormat(*outlineFormat, *exportOutline)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --outline-format: %v\n", err)
+			os.Exit(2)
+		}
+		brand, err := exportBranding(export.Branding{Title: *brandTitle})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		outline := export.GenerateOutline(applyExportFilter(issues, *exportFilter), format, brand.Title, time.Now())
+		if *exportOutline == "-" {
+			fmt.Print(outline)
+			os.Exit(0)
+		}
+		if err := os.WriteFile(*exportOutline, []byte(outline), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing outline: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote outline to %s\n", *exportOutline)
+		os.Exit(0)
+	}
+
+	// Handle --export-ics
+	if *exportICS != "" {
+		now := time.Now()
+		analyzer := analysis.NewAnalyzer(issues)
+		graphStats := analyzer.Analyze()
+		etas := analysis.EstimateETAs(issues, &graphStats, *forecastAgents, now)
+		calendar := export.GenerateICS(applyExportFilter(issues, *exportFilter), etas, now)
+		if *exportICS == "-" {
+			fmt.Print(calendar)
+			os.Exit(0)
+		}
+		if err := os.WriteFile(*exportICS, []byte(calendar), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing calendar: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote calendar to %s\n", *exportICS)
+		os.Exit(0)
+	}
+
+	// Handle --export-graph (bv-94) - PNG/SVG/HTML export, and --show-graph
+	if *exportGraph != "" || *showGraph {
+		analyzer := analysis.NewAnalyzer(issues)
+		stats := analyzer.Analyze()
+
+		// Apply label filter if specified
 		exportIssues := issues
 		if *labelScope != "" {
 			var filtered []model.Issue
@@ -1624,12 +2663,50 @@ func main() {
 			}
 			exportIssues = filtered
 		}
+		exportIssues = applyExportFilter(exportIssues, *exportFilter)
 
 		if len(exportIssues) == 0 {
 			fmt.Fprintf(os.Stderr, "No issues to export (check filters)\n")
 			os.Exit(1)
 		}
 
+		if *showGraph {
+			protocol, err := export.ParseGraphicsProtocol(*graphProtocol, os.Getenv)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --graph-protocol: %v\n", err)
+				os.Exit(2)
+			}
+			if protocol == export.GraphicsNone {
+				fmt.Fprintln(os.Stderr, "Error: no inline image support detected in this terminal.")
+				fmt.Fprintln(os.Stderr, "Pass --graph-protocol=kitty or --graph-protocol=sixel if it has one, or use --export-graph graph.png")
+				os.Exit(1)
+			}
+			img, err := export.RenderGraphImage(export.GraphSnapshotOptions{
+				Title:    *graphTitle,
+				Preset:   *graphPreset,
+				Issues:   exportIssues,
+				Stats:    &stats,
+				DataHash: dataHash,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error rendering graph: %v\n", err)
+				os.Exit(1)
+			}
+			maxWidth := *graphWidth
+			if maxWidth <= 0 {
+				// Terminals only report their cell size in pixels when asked
+				// interactively; 10 pixels is a typical cell width.
+				if cols, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+					maxWidth = cols * 10
+				}
+			}
+			if err := export.WriteTerminalImage(os.Stdout, img, protocol, maxWidth); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
 		// Get project name from current directory
 		cwd, _ := os.Getwd()
 		projectName := filepath.Base(cwd)
@@ -1687,6 +2764,18 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --export-badge - project health score as an SVG shield
+	if *exportBadge != "" {
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		score := analysis.ComputeHealthScore(issues, len(stats.Cycles()), time.Now())
+		if err := export.SaveHealthBadge(score, *exportBadge); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting badge: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ Health badge exported to %s (%d/100, %s)\n", *exportBadge, score.Score, score.Level)
+		os.Exit(0)
+	}
+
 	// Handle --robot-alerts (drift + proactive)
 	if *robotAlerts {
 		projectDir, _ := os.Getwd()
@@ -1828,9 +2917,174 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-cycles
+	if *robotCycles {
+		config := analysis.DefaultCycleReportConfig()
+		config.MaxCyclesPerComponent = *cyclesLimit
+
+		output := analysis.GenerateRobotCyclesOutput(issues, config, dataHash)
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding cycles: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-clusters
+	if *robotClusters {
+		output := analysis.GenerateRobotClustersOutput(issues, analysis.DefaultClusterConfig(), dataHash)
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding clusters: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-unblock
+	if *robotUnblock {
+		output := analysis.GenerateRobotUnblockOutput(issues, dataHash, time.Now())
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding unblock queue: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-stale
+	if *robotStale {
+		projectDir, _ := os.Getwd()
+		driftConfig, err := drift.LoadConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading drift config: %v\n", err)
+			os.Exit(1)
+		}
+
+		config := analysis.StaleReportConfig{
+			ThresholdDays:        driftConfig.StaleWarningDays,
+			InProgressMultiplier: driftConfig.InProgressStaleMultiplier,
+		}
+		if *staleDays > 0 {
+			// An explicit threshold applies to every label
+			config.ThresholdDays = *staleDays
+		} else {
+			for label, lc := range driftConfig.LabelOverrides {
+				if lc != nil && lc.StaleWarningDays > 0 {
+					if config.LabelThresholdDays == nil {
+						config.LabelThresholdDays = make(map[string]int)
+					}
+					config.LabelThresholdDays[label] = lc.StaleWarningDays
+				}
+			}
+		}
+
+		output := analysis.GenerateRobotStaleOutput(issues, config, dataHash, time.Now())
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding stale report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-recur
+	if *robotRecur {
+		if beadsPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --robot-recur needs a local beads file (not supported with --as-of or --workspace)\n")
+			os.Exit(1)
+		}
+		beadsDir := filepath.Dir(beadsPath)
+		templatesDir := templates.Dir(beadsDir)
+		tmpls, warnings, err := templates.Load(templatesDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading templates: %v\n", err)
+			os.Exit(1)
+		}
+
+		now := time.Now().UTC()
+		created, schedule, err := templates.Materialize(tmpls, issues, now, filepath.Base(filepath.Dir(beadsDir)))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error evaluating recurrence: %v\n", err)
+			os.Exit(1)
+		}
+		if len(created) > 0 && !*recurDryRun {
+			if err := loader.AppendIssuesToFile(beadsPath, created); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing recurring issues: %v\n", err)
+				os.Exit(1)
+			}
+			recordCLIAudit(beadsPath, audit.ActionRecur, created)
+		}
+
+		output := templates.RobotRecurOutput{
+			GeneratedAt:   now.Format(time.RFC3339),
+			DataHash:      dataHash,
+			DryRun:        *recurDryRun,
+			TemplatesDir:  templatesDir,
+			TemplateCount: len(tmpls),
+			CreatedCount:  len(created),
+			Created:       []templates.CreatedRef{},
+			Schedule:      schedule,
+			Warnings:      warnings,
+			UsageHints: []string{
+				"jq '.created[] | {id, title}' - Issues created by this run",
+				"jq '.schedule[] | {template, next}' - Next occurrence per template",
+				"--recur-dry-run - Preview without writing the beads file",
+			},
+		}
+		if output.Schedule == nil {
+			output.Schedule = []templates.Occurrence{}
+		}
+		createdByID := make(map[string]model.Issue, len(created))
+		for _, issue := range created {
+			createdByID[issue.ID] = issue
+		}
+		for _, occ := range schedule {
+			if issue, ok := createdByID[occ.IssueID]; ok && occ.Created {
+				output.Created = append(output.Created, templates.CreatedRef{
+					ID:       issue.ID,
+					Title:    issue.Title,
+					Template: occ.Template,
+					Date:     occ.Date,
+					DueDate:  issue.DueDate,
+				})
+			}
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding recurrence report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --profile-startup
 	if *profileStartup {
-		runProfileStartup(issues, loadDuration, *profileJSON, *forceFullAnalysis)
+		// --format picks table or json; --profile-json is the older spelling
+		jsonOutput := *profileJSON
+		if flagWasSet("format") {
+			switch strings.ToLower(strings.TrimSpace(*outputFormat)) {
+			case "json":
+				jsonOutput = true
+			case "table":
+				jsonOutput = false
+			default:
+				fmt.Fprintf(os.Stderr, "Error: unknown --format %q for --profile-startup (want table or json)\n", *outputFormat)
+				os.Exit(1)
+			}
+		}
+		runProfileStartup(issues, loadDuration, jsonOutput, *forceFullAnalysis)
 		os.Exit(0)
 	}
 
@@ -2007,6 +3261,43 @@ func main() {
 		os.Exit(result.ExitCode())
 	}
 
+	// Handle --check-cycles / --check-stale / --check-orphans
+	if *checkCycles || *checkStale != "" || *checkOrphans || *robotCheck {
+		if !*checkCycles && *checkStale == "" && !*checkOrphans {
+			fmt.Fprintln(os.Stderr, "Error: --robot-check requires --check-cycles, --check-stale or --check-orphans")
+			os.Exit(2)
+		}
+		now := time.Now()
+		var results []analysis.CheckResult
+		if *checkCycles {
+			results = append(results, analysis.CheckCycles(issues))
+		}
+		if *checkStale != "" {
+			days, err := analysis.ParseCheckDays(*checkStale, now)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --check-stale: %v\n", err)
+				os.Exit(2)
+			}
+			results = append(results, analysis.CheckStale(issues, days, now))
+		}
+		if *checkOrphans {
+			results = append(results, analysis.CheckOrphans(issues))
+		}
+		report := analysis.NewCheckReport(results, analysis.ComputeDataHash(issues), now)
+
+		if *robotCheck {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding check result: %v\n", err)
+				os.Exit(2)
+			}
+		} else {
+			fmt.Print(report.Summary())
+		}
+		os.Exit(report.ExitCode)
+	}
+
 	if *robotInsights {
 		analyzer := analysis.NewAnalyzer(issues)
 		if *forceFullAnalysis {
@@ -2132,6 +3423,7 @@ func main() {
 			FullStats        interface{}                `json:"full_stats"`
 			TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`      // Issues with highest downstream impact (bv-83)
 			AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"` // bv-181: Canonical advanced features
+			Plugins          *plugins.Report            `json:"plugins,omitempty"`           // Annotations from .bv/plugins/
 			UsageHints       []string                   `json:"usage_hints"`                 // bv-84: Agent-friendly hints
 		}{
 			GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
@@ -2146,6 +3438,7 @@ func main() {
 			FullStats:        fullStats,
 			TopWhatIfs:       topWhatIfs,
 			AdvancedInsights: advancedInsights,
+			Plugins:          loadPluginReport(issues, *noPlugins),
 			UsageHints: []string{
 				"jq '.Bottlenecks[:5] | map(.ID)' - Top 5 bottleneck IDs",
 				"jq '.CriticalPath[:3]' - Top 3 critical path items",
@@ -2175,7 +3468,7 @@ func main() {
 		// However, we still emit a stable status contract for agents. If the user
 		// explicitly asks for full analysis, honor it; otherwise, skip expensive
 		// centrality metrics and record the skip reasons deterministically.
-		cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
+		cfg := analysis.TunedConfigForSize(len(issues), countEdges(issues))
 		if *forceFullAnalysis {
 			cfg = analysis.FullAnalysisConfig()
 		} else {
@@ -2193,12 +3486,15 @@ func main() {
 			cfg.CyclesSkipReason = skipReason
 		}
 
-		plan := analyzer.GetExecutionPlan()
-
 		stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
 		stats.WaitForPhase2()
 		status := stats.Status()
 
+		plan := analyzer.GetExecutionPlanWithOptions(analysis.PlanOptions{
+			Agents: *planAgents,
+			Stats:  stats,
+		})
+
 		// Wrap with metadata
 		output := struct {
 			GeneratedAt    string                  `json:"generated_at"`
@@ -2227,6 +3523,8 @@ func main() {
 				"jq '.plan.tracks[].items[] | select(.unblocks | length > 0)' - Items that unblock others",
 				"jq '.plan.summary' - High-level execution summary",
 				"jq '[.plan.tracks[].items[]] | length' - Total items across all tracks",
+				"jq '.plan.tracks[] | {track_id, estimated_minutes, makespan_minutes, critical}' - Track load and makespan",
+				"jq '.plan.critical_track' - Track that determines overall completion",
 			},
 		}
 
@@ -2241,7 +3539,7 @@ func main() {
 
 	if *robotPriority {
 		analyzer := analysis.NewAnalyzer(issues)
-		cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
+		cfg := analysis.TunedConfigForSize(len(issues), countEdges(issues))
 		if *forceFullAnalysis {
 			cfg = analysis.FullAnalysisConfig()
 		}
@@ -2461,6 +3759,7 @@ func main() {
 			AsOfCommit  string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA
 			Triage      analysis.TriageResult  `json:"triage"`
 			Feedback    *analysis.FeedbackJSON `json:"feedback,omitempty"` // bv-90: Feedback loop state
+			Plugins     *plugins.Report        `json:"plugins,omitempty"`  // Annotations from .bv/plugins/
 			UsageHints  []string               `json:"usage_hints"`        // bv-84: Agent-friendly hints
 		}{
 			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
@@ -2469,6 +3768,7 @@ func main() {
 			AsOfCommit:  asOfResolved,
 			Triage:      triage,
 			Feedback:    feedbackInfo,
+			Plugins:     loadPluginReport(issues, *noPlugins),
 			UsageHints: []string{
 				"jq '.triage.quick_ref.top_picks[:3]' - Top 3 picks for immediate work",
 				"jq '.triage.recommendations[3:10] | map({id,title,score})' - Next candidates after top picks",
@@ -2482,6 +3782,7 @@ func main() {
 				"jq '.triage.recommendations_by_track[].top_pick' - Top pick per track",
 				"jq '.triage.recommendations_by_label[].claim_command' - Claim commands per label",
 				"jq '.feedback.weight_adjustments' - View feedback-adjusted weights (bv-90)",
+				"jq '.plugins.annotations[.triage.quick_ref.top_picks[0].id]' - Plugin annotations for the top pick",
 			},
 		}
 		encoder := json.NewEncoder(os.Stdout)
@@ -2777,72 +4078,190 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Handle correlation audit commands (bv-e1u6)
-	if *robotExplainCorrelation != "" || *robotConfirmCorrelation != "" || *robotRejectCorrelation != "" || *robotCorrelationStats {
+	// Handle --robot-commits
+	if *robotCommits != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
 			os.Exit(1)
 		}
-
-		feedbackStore := correlation.NewFeedbackStore(beadsDir)
-		if err := feedbackStore.Load(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading feedback: %v\n", err)
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 			os.Exit(1)
 		}
 
-		// Handle --robot-correlation-stats
-		if *robotCorrelationStats {
-			stats := feedbackStore.GetStats()
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			if err := encoder.Encode(stats); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding stats: %v\n", err)
-				os.Exit(1)
+		found := false
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
 			}
-			os.Exit(0)
+			found = found || issue.ID == *robotCommits
 		}
-
-		// Parse SHA:beadID format
-		parseCorrelationArg := func(arg string) (string, string, error) {
-			parts := strings.SplitN(arg, ":", 2)
-			if len(parts) != 2 {
-				return "", "", fmt.Errorf("expected format: SHA:beadID, got: %s", arg)
-			}
-			return parts[0], parts[1], nil
+		if !found {
+			fmt.Fprintf(os.Stderr, "Issue not found: %s\n", *robotCommits)
+			os.Exit(1)
 		}
 
-		// Handle --robot-explain-correlation
-		if *robotExplainCorrelation != "" {
-			commitSHA, beadID, err := parseCorrelationArg(*robotExplainCorrelation)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-
-			// Generate history report to find the correlation
-			cwd, err := os.Getwd()
+		opts := correlation.CorrelatorOptions{
+			BeadID: *robotCommits,
+			Limit:  *historyLimit,
+		}
+		if *historySince != "" {
+			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
 				os.Exit(1)
 			}
-			beadsPath, err := loader.FindJSONLPath(beadsDir)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+			if !since.IsZero() {
+				opts.Since = &since
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+		}
 
-			beadInfos := make([]correlation.BeadInfo, len(issues))
-			for i, issue := range issues {
-				beadInfos[i] = correlation.BeadInfo{
-					ID:     issue.ID,
-					Title:  issue.Title,
-					Status: string(issue.Status),
-				}
-			}
+		report, err := correlation.NewCorrelator(cwd, beadsPath).GenerateReport(beadInfos, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error correlating commits: %v\n", err)
+			os.Exit(1)
+		}
+		if *minConfidence > 0 {
+			report.Histories = correlation.NewScorer().FilterHistoriesByConfidence(report.Histories, *minConfidence)
+		}
+		output := correlation.GenerateRobotCommitsOutput(report, *robotCommits, dataHash)
 
-			opts := correlation.CorrelatorOptions{BeadID: beadID}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding commits: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-blame
+	if *robotBlame {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		blame, err := correlation.NewExtractor(cwd, beadsPath).ExtractBlame(correlation.ExtractOptions{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error computing blame: %v\n", err)
+			os.Exit(1)
+		}
+		targets := make([]correlation.BlameTarget, len(issues))
+		for i, issue := range issues {
+			targets[i] = correlation.BlameTarget{
+				ID:       issue.ID,
+				Title:    issue.Title,
+				Status:   string(issue.Status),
+				Priority: issue.Priority,
+			}
+		}
+		output := correlation.GenerateRobotBlameOutput(blame, targets, dataHash)
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding blame: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle correlation audit commands (bv-e1u6)
+	if *robotExplainCorrelation != "" || *robotConfirmCorrelation != "" || *robotRejectCorrelation != "" || *robotCorrelationStats {
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		feedbackStore := correlation.NewFeedbackStore(beadsDir)
+		if err := feedbackStore.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading feedback: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Handle --robot-correlation-stats
+		if *robotCorrelationStats {
+			stats := feedbackStore.GetStats()
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(stats); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding stats: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
+		// Parse SHA:beadID format
+		parseCorrelationArg := func(arg string) (string, string, error) {
+			parts := strings.SplitN(arg, ":", 2)
+			if len(parts) != 2 {
+				return "", "", fmt.Errorf("expected format: SHA:beadID, got: %s", arg)
+			}
+			return parts[0], parts[1], nil
+		}
+
+		// Handle --robot-explain-correlation
+		if *robotExplainCorrelation != "" {
+			commitSHA, beadID, err := parseCorrelationArg(*robotExplainCorrelation)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// Generate history report to find the correlation
+			cwd, err := os.Getwd()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+				os.Exit(1)
+			}
+			beadsPath, err := loader.FindJSONLPath(beadsDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+				os.Exit(1)
+			}
+			correlator := correlation.NewCorrelator(cwd, beadsPath)
+
+			beadInfos := make([]correlation.BeadInfo, len(issues))
+			for i, issue := range issues {
+				beadInfos[i] = correlation.BeadInfo{
+					ID:     issue.ID,
+					Title:  issue.Title,
+					Status: string(issue.Status),
+				}
+			}
+
+			opts := correlation.CorrelatorOptions{BeadID: beadID}
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
@@ -3792,6 +5211,75 @@ func main() {
 	}
 
 	// Handle --robot-forecast flag (bv-158)
+	if *robotExplain != "" {
+		explanation, err := analysis.NewAnalyzer(issues).ExplainIssue(*robotExplain, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			*analysis.IssueExplanation
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
+			DataHash:         dataHash,
+			IssueExplanation: explanation,
+			UsageHints: []string{
+				"jq '.components | sort_by(-.contribution) | .[0]' - Largest score contributor",
+				"jq '.components[] | {factor, contribution, reason}' - Breakdown with reasons",
+				"jq '.what_if.unblocked_issue_ids' - What closing this unblocks",
+			},
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding explanation: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-dossier / --export-dossier
+	if *robotDossier != "" || *exportDossier != "" {
+		id := *robotDossier
+		if id == "" {
+			id = *exportDossier
+		}
+		dossier, err := analysis.NewAnalyzer(issues).ImpactDossier(id, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if *exportDossier != "" {
+			fmt.Print(export.RenderImpactDossier(dossier))
+			os.Exit(0)
+		}
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			*analysis.ImpactDossier
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+			DataHash:      dataHash,
+			ImpactDossier: dossier,
+			UsageHints: []string{
+				"jq '[.direct_blockers[], .transitive_blockers[]] | map(.id)' - Everything that must close first",
+				"jq '.critical_path | {on_critical_path, chain}' - Critical path membership",
+				"--export-dossier <id> - The same dossier as Markdown for a PR description",
+			},
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding dossier: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if *robotForecast != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
@@ -3860,16 +5348,18 @@ func main() {
 			LatestETA     time.Time `json:"latest_eta"`
 		}
 		type ForecastOutput struct {
-			GeneratedAt   time.Time              `json:"generated_at"`
-			Agents        int                    `json:"agents"`
-			Filters       map[string]string      `json:"filters,omitempty"`
-			ForecastCount int                    `json:"forecast_count"`
-			Forecasts     []analysis.ETAEstimate `json:"forecasts"`
-			Summary       *ForecastSummary       `json:"summary,omitempty"`
+			GeneratedAt   time.Time                   `json:"generated_at"`
+			Agents        int                         `json:"agents"`
+			Filters       map[string]string           `json:"filters,omitempty"`
+			ForecastCount int                         `json:"forecast_count"`
+			Forecasts     []analysis.ETAEstimate      `json:"forecasts"`
+			Summary       *ForecastSummary            `json:"summary,omitempty"`
+			Completion    analysis.CompletionForecast `json:"completion"`
 		}
 
 		var forecasts []analysis.ETAEstimate
 		var outputErr error
+		var scope []string // nil: every open issue
 
 		if *robotForecast == "all" {
 			// Forecast all open issues
@@ -3883,6 +5373,12 @@ func main() {
 				}
 				forecasts = append(forecasts, eta)
 			}
+			if len(targetIssues) < len(issues) {
+				scope = make([]string, 0, len(targetIssues))
+				for _, iss := range targetIssues {
+					scope = append(scope, iss.ID)
+				}
+			}
 		} else {
 			// Single issue forecast
 			eta, err := analysis.EstimateETAForIssue(issues, &graphStats, *robotForecast, agents, now)
@@ -3891,8 +5387,15 @@ func main() {
 				os.Exit(1)
 			}
 			forecasts = append(forecasts, eta)
+			// An epic is done when its open children are
+			scope = analysis.EpicForecastScope(issues, *robotForecast)
 		}
 
+		forecastCfg := analysis.DefaultForecastConfig()
+		forecastCfg.Agents = agents
+		forecastCfg.Runs = *forecastRuns
+		completion := analysis.SimulateCompletion(issues, &graphStats, scope, forecastCfg, now)
+
 		// Build summary if multiple forecasts
 		var summary *ForecastSummary
 		if len(forecasts) > 1 {
@@ -3934,6 +5437,7 @@ func main() {
 			ForecastCount: len(forecasts),
 			Forecasts:     forecasts,
 			Summary:       summary,
+			Completion:    completion,
 		}
 		if len(filters) > 0 {
 			output.Filters = filters
@@ -3948,6 +5452,369 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-velocity
+	if *robotVelocity {
+		output := analysis.GenerateRobotVelocityOutput(issues, dataHash, time.Now())
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding velocity: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-plugins
+	if *robotPlugins {
+		report := loadPluginReport(issues, false)
+		if report == nil {
+			report = &plugins.Report{Plugins: []plugins.Status{}, Annotations: map[string][]plugins.Annotation{}}
+		}
+		cwd, _ := os.Getwd()
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			PluginDir   string `json:"plugin_dir"`
+			*plugins.Report
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			PluginDir:   plugins.Dir(cwd),
+			Report:      report,
+			UsageHints: []string{
+				"jq '.plugins[] | select(.error)' - Plugins that failed",
+				"jq '.annotations | to_entries[] | select(any(.value[]; .warnings)) | .key' - Issues with plugin warnings",
+				"--no-plugins - Leave plugin annotations out of --robot-triage and --robot-insights",
+			},
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding plugins: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --rename: change an issue ID and every reference to it
+	if *renameFrom != "" {
+		if *renameTo == "" {
+			fmt.Fprintf(os.Stderr, "Error: --rename needs --rename-to <new id>\n")
+			os.Exit(2)
+		}
+		var plan *loader.RenamePlan
+		var err error
+		auditPath := beadsPath
+		switch {
+		case *workspaceConfig != "":
+			plan, err = workspace.PlanRename(*workspaceConfig, *renameFrom, *renameTo)
+			auditPath = *workspaceConfig
+		case beadsPath != "":
+			plan, err = loader.PlanIssueRename(beadsPath, *renameFrom, *renameTo)
+		default:
+			err = fmt.Errorf("needs a local beads file or --workspace (not supported with --as-of)")
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error renaming %s: %v\n", *renameFrom, err)
+			os.Exit(1)
+		}
+		fmt.Print(plan.Diff())
+		summary := fmt.Sprintf("%d line(s) in %d file(s)", len(plan.Lines), len(plan.Files()))
+		if *renameDryRun {
+			fmt.Printf("\nDry run: renaming %s to %s would rewrite %s\n", plan.OldID, plan.NewID, summary)
+			os.Exit(0)
+		}
+		if err := plan.Apply(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error renaming %s: %v\n", plan.OldID, err)
+			os.Exit(1)
+		}
+		recordCLIAudit(auditPath, audit.ActionRename, []model.Issue{{ID: plan.OldID}, {ID: plan.NewID}},
+			fmt.Sprintf("%s renamed to %s (%s)", plan.OldID, plan.NewID, summary))
+		fmt.Printf("\n✓ Renamed %s to %s: rewrote %s\n", plan.OldID, plan.NewID, summary)
+		os.Exit(0)
+	}
+
+	// Handle --fmt: canonical formatting of the beads files
+	if *fmtFlag {
+		if beadsPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --fmt needs a local beads file (not supported with --as-of or --workspace)\n")
+			os.Exit(1)
+		}
+		plan, err := loader.PlanFormat(beadsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting %s: %v\n", beadsPath, err)
+			os.Exit(1)
+		}
+		for _, f := range plan.Files {
+			for _, line := range f.Skipped {
+				fmt.Fprintf(os.Stderr, "Warning: %s:%d is not valid JSON; left as is\n", f.Path, line)
+			}
+		}
+		if *fmtCheck {
+			unformatted := 0
+			for _, f := range plan.Files {
+				if f.Rewritten {
+					fmt.Printf("%s: %d of %d issue line(s) not canonical\n", f.Path, f.Changed, f.Issues)
+					unformatted++
+				}
+			}
+			if unformatted > 0 {
+				os.Exit(1)
+			}
+			fmt.Println("✓ Beads files are canonically formatted")
+			os.Exit(0)
+		}
+		if err := plan.Apply(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting %s: %v\n", beadsPath, err)
+			os.Exit(1)
+		}
+		for _, f := range plan.Files {
+			if f.Rewritten {
+				fmt.Printf("✓ %s: reformatted %d of %d issue line(s)\n", f.Path, f.Changed, f.Issues)
+			} else {
+				fmt.Printf("  %s: already canonical\n", f.Path)
+			}
+		}
+		os.Exit(0)
+	}
+
+	// Handle --archive-older-than: move old closed issues to archive.jsonl
+	if *archiveOlderThan != "" {
+		if beadsPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --archive-older-than needs a local beads file (not supported with --as-of or --workspace)\n")
+			os.Exit(1)
+		}
+		cutoff, err := parseSince("--archive-older-than", *archiveOlderThan, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		plan, err := loader.PlanArchive(beadsPath, cutoff)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error archiving issues: %v\n", err)
+			os.Exit(1)
+		}
+		if len(plan.Issues) == 0 {
+			fmt.Printf("No issues closed before %s\n", cutoff.Format("2006-01-02"))
+			os.Exit(0)
+		}
+		archived := make([]model.Issue, len(plan.Issues))
+		for i, issue := range plan.Issues {
+			archived[i] = model.Issue{ID: issue.ID}
+			fmt.Printf("  %-14s closed %s  %s\n", issue.ID, issue.ClosedAt.Format("2006-01-02"), truncateTitle(issue.Title, 60))
+		}
+		if *archiveDryRun {
+			fmt.Printf("\nDry run: would move %d issue(s) closed before %s to %s\n", len(plan.Issues), cutoff.Format("2006-01-02"), plan.ArchivePath)
+			os.Exit(0)
+		}
+		if err := plan.Apply(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error archiving issues: %v\n", err)
+			os.Exit(1)
+		}
+		recordCLIAudit(beadsPath, audit.ActionArchive, archived,
+			fmt.Sprintf("%d issue(s) closed before %s moved to %s", len(plan.Issues), cutoff.Format("2006-01-02"), loader.ArchiveFileName))
+		fmt.Printf("\n✓ Moved %d issue(s) to %s (bv --include-archived or Ctrl+A shows them)\n", len(plan.Issues), plan.ArchivePath)
+		os.Exit(0)
+	}
+
+	// Handle --fix-duplicates: interactive repair of IDs used by several lines
+	if *fixDuplicates {
+		if beadsPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --fix-duplicates needs a local beads file (not supported with --as-of or --workspace)\n")
+			os.Exit(1)
+		}
+		var reserved []string
+		if deletions, err := loader.LoadDeletions(filepath.Dir(beadsPath)); err == nil {
+			for _, d := range deletions {
+				reserved = append(reserved, d.ID)
+			}
+		}
+		width, _, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width = 100
+		}
+		set, result, err := runFixDuplicates(beadsPath, reserved, os.Stdin, os.Stdout, width, *yesFlag)
+		if errors.Is(err, errFixDuplicatesQuit) {
+			fmt.Println("No changes written.")
+			os.Exit(0)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fixing duplicates: %v\n", err)
+			os.Exit(1)
+		}
+		var changed []model.Issue
+		var changes []string
+		for _, g := range set.Groups {
+			newIDs, renamed := result.Renamed[g.ID]
+			if !renamed && !slices.Contains(result.Merged, g.ID) {
+				continue
+			}
+			changed = append(changed, model.Issue{ID: g.ID})
+			if !renamed {
+				changes = append(changes, fmt.Sprintf("%s: %d copies merged", g.ID, len(g.Copies)))
+			}
+			for _, id := range newIDs {
+				changed = append(changed, model.Issue{ID: id})
+				changes = append(changes, g.ID+" re-IDed as "+id)
+			}
+		}
+		if len(changed) > 0 {
+			recordCLIAudit(beadsPath, audit.ActionDedupe, changed, changes...)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --new-id: IDs for scripts that append issues themselves
+	if *newIDFlag {
+		if *newIDCount < 1 {
+			fmt.Fprintf(os.Stderr, "Error: --new-id-count must be at least 1\n")
+			os.Exit(2)
+		}
+		prefix := strings.TrimSuffix(*newIDPrefix, "-")
+		var reserved []string
+		if beadsPath != "" {
+			if prefix == "" {
+				prefix = loader.IssuePrefix(issues, filepath.Base(filepath.Dir(filepath.Dir(beadsPath))))
+			}
+			// Reusing the ID of a deleted issue would resurrect its deletion entry
+			if deletions, err := loader.LoadDeletions(filepath.Dir(beadsPath)); err == nil {
+				for _, d := range deletions {
+					reserved = append(reserved, d.ID)
+				}
+			}
+		} else if prefix == "" {
+			prefix = loader.IssuePrefix(issues, "")
+		}
+		ids := loader.NewIssueIDs(issues, prefix, *newIDCount, reserved)
+		if flagWasSet("format") && *outputFormat == "json" {
+			output := struct {
+				Prefix string   `json:"prefix"`
+				IDs    []string `json:"ids"`
+			}{Prefix: prefix, IDs: ids}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(output); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding IDs: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			for _, id := range ids {
+				fmt.Println(id)
+			}
+		}
+		os.Exit(0)
+	}
+
+	// Handle --notify: post the digest to webhooks / email
+	if *notifyFlag || *notifyDryRun {
+		os.Exit(runNotify(issues, *notifyDryRun))
+	}
+
+	// Handle --robot-audit
+	if *robotAudit {
+		if beadsPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --robot-audit needs a local beads file (not supported with --as-of or --workspace)\n")
+			os.Exit(1)
+		}
+		now := time.Now()
+		var since time.Time
+		if *auditSince != "" {
+			var err error
+			if since, err = parseSince("--audit-since", *auditSince, now); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		projectDir := filepath.Dir(filepath.Dir(beadsPath))
+		entries, err := audit.Load(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading audit log: %v\n", err)
+			os.Exit(1)
+		}
+		entries = audit.Filter(entries, since, *auditIssue)
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+
+		output := audit.RobotAuditOutput{
+			GeneratedAt: now.UTC().Format(time.RFC3339),
+			Path:        audit.Path(projectDir),
+			IssueID:     *auditIssue,
+			Count:       len(entries),
+			Entries:     entries,
+			UsageHints: []string{
+				"jq '.entries[] | select(.action == \"edit\") | {time, issue_ids, changes}' - Field edits",
+				"jq '[.entries[].author] | unique' - Who wrote through bv",
+				"--audit-since 168h --audit-issue bv-123 - Narrow to a period or a bead",
+			},
+		}
+		if !since.IsZero() {
+			output.Since = since.UTC().Format(time.RFC3339)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding audit log: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-weights
+	if *robotWeights {
+		output := analysis.RobotWeightsOutput{
+			GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+			ConfigPath:    analysisConfig.Path,
+			ConfigLoaded:  analysisConfig.Loaded,
+			WeightsReport: analysis.CurrentWeightsReport(),
+			Warnings:      analysisConfig.Warnings,
+			UsageHints: []string{
+				"jq '.effective' - Weights used by --robot-triage and --robot-priority",
+				"jq '.overridden' - Weights set in .bv/analysis.yaml",
+				"--robot-explain bv-123 - How the weights combine for one issue",
+			},
+		}
+		if output.Warnings == nil {
+			output.Warnings = []string{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding weights: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-standup
+	if *robotStandup {
+		now := time.Now()
+		since, err := parseSince("--standup-since", *standupSince, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		cwd, _ := os.Getwd()
+		baseline, revision := loadStandupBaseline(loader.NewGitLoader(cwd), since)
+		standup := analysis.ComputeStandup(baseline, revision, issues, since, now)
+
+		switch strings.ToLower(*outputFormat) {
+		case "markdown", "md":
+			fmt.Print(standup.Markdown())
+		default:
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(analysis.GenerateRobotStandupOutput(standup, dataHash)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding standup: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-capacity flag (bv-160)
 	if *robotCapacity {
 		// Build graph stats for analysis
@@ -4169,22 +6036,28 @@ func main() {
 
 		gitLoader := loader.NewGitLoader(cwd)
 
-		// Load historical issues
-		historicalIssues, err := gitLoader.LoadAt(*diffSince)
+		// Load historical issues (git revision or JSONL snapshot file)
+		historicalIssues, revision, err := loadDiffSource(gitLoader, *diffSince)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffSince, err)
 			os.Exit(1)
 		}
 
-		// Get revision info for timestamp
-		revision, err := gitLoader.ResolveRevision(*diffSince)
-		if err != nil {
-			revision = *diffSince
-		}
-
 		// Create snapshots
 		fromSnapshot := analysis.NewSnapshotAt(historicalIssues, time.Time{}, revision)
 		toSnapshot := analysis.NewSnapshot(issues)
+		toHash := dataHash
+		var toRevision string
+		if *diffTo != "" {
+			toIssues, resolved, err := loadDiffSource(gitLoader, *diffTo)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffTo, err)
+				os.Exit(1)
+			}
+			toRevision = resolved
+			toSnapshot = analysis.NewSnapshotAt(toIssues, time.Time{}, resolved)
+			toHash = analysis.ComputeDataHash(toIssues)
+		}
 
 		// Compute diff
 		diff := analysis.CompareSnapshots(fromSnapshot, toSnapshot)
@@ -4192,21 +6065,23 @@ func main() {
 		if *robotDiff {
 			// JSON output
 			output := struct {
-				GeneratedAt      string                 `json:"generated_at"`
-				ResolvedRevision string                 `json:"resolved_revision"`
-				AsOf             string                 `json:"as_of,omitempty"`        // "to" snapshot ref (if --as-of used)
-				AsOfCommit       string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA for "to"
-				FromDataHash     string                 `json:"from_data_hash"`
-				ToDataHash       string                 `json:"to_data_hash"`
-				Diff             *analysis.SnapshotDiff `json:"diff"`
+				GeneratedAt        string                 `json:"generated_at"`
+				ResolvedRevision   string                 `json:"resolved_revision"`
+				ResolvedToRevision string                 `json:"resolved_to_revision,omitempty"` // --diff-to ref or file
+				AsOf               string                 `json:"as_of,omitempty"`                // "to" snapshot ref (if --as-of used)
+				AsOfCommit         string                 `json:"as_of_commit,omitempty"`         // Resolved commit SHA for "to"
+				FromDataHash       string                 `json:"from_data_hash"`
+				ToDataHash         string                 `json:"to_data_hash"`
+				Diff               *analysis.SnapshotDiff `json:"diff"`
 			}{
-				GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
-				ResolvedRevision: revision,
-				AsOf:             *asOf,
-				AsOfCommit:       asOfResolved,
-				FromDataHash:     analysis.ComputeDataHash(historicalIssues),
-				ToDataHash:       dataHash,
-				Diff:             diff,
+				GeneratedAt:        time.Now().UTC().Format(time.RFC3339),
+				ResolvedRevision:   revision,
+				ResolvedToRevision: toRevision,
+				AsOf:               *asOf,
+				AsOfCommit:         asOfResolved,
+				FromDataHash:       analysis.ComputeDataHash(historicalIssues),
+				ToDataHash:         toHash,
+				Diff:               diff,
 			}
 
 			encoder := json.NewEncoder(os.Stdout)
@@ -4253,8 +6128,59 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *importMD != "" {
+		if beadsPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --import-md needs a local beads file (not supported with --as-of or --workspace)\n")
+			os.Exit(1)
+		}
+		data, err := os.ReadFile(*importMD)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *importMD, err)
+			os.Exit(1)
+		}
+		projectName := filepath.Base(filepath.Dir(filepath.Dir(beadsPath)))
+		imported, summary, err := loader.ParseMarkdownTodos(data, issues, projectName, time.Now().UTC())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", *importMD, err)
+			os.Exit(1)
+		}
+		if len(imported) == 0 {
+			fmt.Printf("No headings or checkbox items found in %s\n", *importMD)
+			os.Exit(0)
+		}
+
+		// Print the imported tree, indented by parent-child depth
+		depth := make(map[string]int, len(imported))
+		for _, issue := range imported {
+			marker := "[ ]"
+			if issue.IssueType == model.TypeEpic {
+				marker = "#"
+			} else if issue.Status == model.StatusClosed {
+				marker = "[x]"
+			}
+			for _, dep := range issue.Dependencies {
+				depth[issue.ID] = depth[dep.DependsOnID] + 1
+			}
+			fmt.Printf("%s%-10s %s %s\n", strings.Repeat("  ", depth[issue.ID]), issue.ID, marker, issue.Title)
+		}
+
+		counts := fmt.Sprintf("%d epics, %d tasks (%d already done)", summary.Epics, summary.Tasks, summary.Closed)
+		if *importDryRun {
+			fmt.Printf("\nDry run: would import %d issues from %s: %s\n", len(imported), *importMD, counts)
+			os.Exit(0)
+		}
+		if err := loader.AppendIssuesToFile(beadsPath, imported); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing beads: %v\n", err)
+			os.Exit(1)
+		}
+		recordCLIAudit(beadsPath, audit.ActionImport, imported, "from "+*importMD)
+		fmt.Printf("\n✓ Imported %d issues from %s into %s: %s\n", len(imported), *importMD, beadsPath, counts)
+		os.Exit(0)
+	}
+
 	if *exportFile != "" {
 		fmt.Printf("Exporting to %s...\n", *exportFile)
+		exportIssues := applyExportFilter(issues, *exportFilter)
 
 		// Load and run pre-export hooks
 		cwd, _ := os.Getwd()
@@ -4267,7 +6193,7 @@ func main() {
 				ctx := hooks.ExportContext{
 					ExportPath:   *exportFile,
 					ExportFormat: "markdown",
-					IssueCount:   len(issues),
+					IssueCount:   len(exportIssues),
 					Timestamp:    time.Now(),
 				}
 				executor = hooks.NewExecutor(hookLoader.Config(), ctx)
@@ -4281,7 +6207,12 @@ func main() {
 		}
 
 		// Perform the export
-		if err := export.SaveMarkdownToFile(issues, *exportFile); err != nil {
+		brand, err := exportBranding(export.Branding{Title: *brandTitle, Logo: *brandLogo, AccentColor: *brandAccent, Footer: *brandFooter})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := export.SaveBrandedMarkdownToFile(exportIssues, *exportFile, brand); err != nil {
 			fmt.Printf("Error exporting: %v\n", err)
 			os.Exit(1)
 		}
@@ -4303,7 +6234,8 @@ func main() {
 		os.Exit(0)
 	}
 
-	if len(issues) == 0 {
+	// A freshly set up project opens on the tutorial even without issues
+	if len(issues) == 0 && !startTutorial {
 		fmt.Println("No issues found. Create some with 'bd create'!")
 		os.Exit(0)
 	}
@@ -4317,15 +6249,55 @@ func main() {
 	// Initial Model with live reload support
 	m := ui.NewModel(issues, activeRecipe, beadsPath)
 	defer m.Stop() // Clean up file watcher
+	m.SetLoadDuration(loadDuration)
+	if textIndex != nil {
+		m.SetTextIndex(textIndex)
+	}
+	if *includeArchived {
+		m.SetShowArchived(true)
+	}
+	if startTutorial {
+		m.OpenTutorial()
+	}
+	accessible := *accessibleFlag
+	ascii := *asciiFlag || ui.UnicodeUnsupported()
+	if displayConfig, err := ui.LoadDisplayConfig(ui.DisplayConfigPath()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	} else {
+		if displayConfig.Accessible {
+			accessible = true
+		}
+		if displayConfig.ASCII != nil && !*asciiFlag {
+			ascii = *displayConfig.ASCII
+		}
+	}
+	if accessible {
+		m.SetAccessible(true)
+	}
+	if ascii {
+		m.SetASCII(true)
+	}
+	if *noPlugins {
+		m.SetPluginsEnabled(false)
+	}
+	if *noHooks {
+		m.SetHooksEnabled(false)
+	}
 
 	// Enable workspace mode if loading from workspace config
 	if workspaceInfo != nil {
+		repos := make([]ui.WorkspaceRepo, len(workspaceInfo.Repos))
+		for i, r := range workspaceInfo.Repos {
+			repos[i] = ui.WorkspaceRepo(r)
+		}
 		m.EnableWorkspaceMode(ui.WorkspaceInfo{
 			Enabled:      true,
 			RepoCount:    workspaceInfo.TotalRepos,
 			FailedCount:  workspaceInfo.FailedRepos,
 			TotalIssues:  workspaceInfo.TotalIssues,
 			RepoPrefixes: workspaceInfo.RepoPrefixes,
+			Repos:        repos,
+			StatePath:    ui.WorkspaceRepoStatePath(*workspaceConfig),
 		})
 	}
 
@@ -4352,26 +6324,150 @@ func main() {
 			}()
 		}
 	}
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running beads viewer: %v\n", err)
-		os.Exit(1)
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error running beads viewer: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// countEdges counts blocking dependencies for config sizing
+func countEdges(issues []model.Issue) int {
+	count := 0
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepBlocks {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// printDiffSummary prints a human-readable diff summary
+// loadDiffSource loads one side of a --diff-since/--diff-to comparison. An
+// existing file is read as a JSONL snapshot; anything else is treated as a
+// git revision. The returned label is the file path or resolved commit.
+func loadDiffSource(gitLoader *loader.GitLoader, spec string) ([]model.Issue, string, error) {
+	if info, err := os.Stat(spec); err == nil && !info.IsDir() {
+		issues, err := loader.LoadIssuesFromFile(spec)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading snapshot %s: %w", spec, err)
+		}
+		return issues, spec, nil
+	}
+
+	issues, err := gitLoader.LoadAt(spec)
+	if err != nil {
+		return nil, "", err
+	}
+	revision, err := gitLoader.ResolveRevision(spec)
+	if err != nil {
+		revision = spec
+	}
+	return issues, revision, nil
+}
+
+// analysisConfigStatus records which .bv/analysis.yaml was read at startup,
+// for --robot-weights
+type analysisConfigStatus struct {
+	Path     string
+	Loaded   bool     // The file exists and is valid
+	Warnings []string // Also printed to stderr
+}
+
+// loadAnalysisConfig installs the overrides of .bv/analysis.yaml. An invalid
+// file is reported and ignored rather than failing startup.
+func loadAnalysisConfig(projectDir string) analysisConfigStatus {
+	status := analysisConfigStatus{Path: analysis.UserConfigPath(projectDir)}
+	userConfig, err := analysis.LoadUserConfig(projectDir)
+	if err != nil {
+		status.Warnings = []string{fmt.Sprintf("ignoring %s: %v", status.Path, err)}
+	} else {
+		analysis.SetUserConfig(userConfig)
+		status.Loaded = userConfig != nil
+		for _, w := range userConfig.Warnings() {
+			status.Warnings = append(status.Warnings, fmt.Sprintf("%s: %s", status.Path, w))
+		}
+	}
+	for _, w := range status.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	return status
+}
+
+// recordCLIAudit notes issues written from the command line in
+// .bv/audit.jsonl. The write already happened, so a failure only warns.
+func recordCLIAudit(beadsPath, action string, issues []model.Issue, changes ...string) {
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	entry := audit.Entry{Action: action, IssueIDs: ids, Changes: changes, Source: audit.SourceCLI}
+	if err := audit.Append(filepath.Dir(filepath.Dir(beadsPath)), entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: audit log not written: %v\n", err)
+	}
+}
+
+// parseSince turns a --standup-since, --audit-since or --archive-older-than
+// value, a duration back from now (24h, 90d) or a date, into the start of
+// the period
+func parseSince(flagName, spec string, now time.Time) (time.Time, error) {
+	spec = strings.TrimSpace(spec)
+	if d, err := time.ParseDuration(spec); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if days, err := strconv.Atoi(strings.TrimSuffix(spec, "d")); err == nil && strings.HasSuffix(spec, "d") && days > 0 {
+		return now.AddDate(0, 0, -days), nil
+	}
+	if t, err := time.Parse(time.RFC3339, spec); err == nil {
+		return t, nil
 	}
+	if t, err := time.ParseInLocation("2006-01-02", spec, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q (want a duration like 24h or 90d, or a date like 2006-01-02)", flagName, spec)
 }
 
-// countEdges counts blocking dependencies for config sizing
-func countEdges(issues []model.Issue) int {
-	count := 0
-	for _, issue := range issues {
-		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepBlocks {
-				count++
-			}
+// loadStandupBaseline loads the beads file as of the last commit touching it
+// at or before since. It returns nil when there is no such commit, and the
+// standup falls back to issue timestamps.
+func loadStandupBaseline(gitLoader *loader.GitLoader, since time.Time) ([]model.Issue, string) {
+	revisions, err := gitLoader.ListRevisions(0)
+	if err != nil {
+		return nil, ""
+	}
+	for _, rev := range revisions {
+		if rev.Timestamp.After(since) {
+			continue
 		}
+		issues, err := gitLoader.LoadAt(rev.SHA)
+		if err != nil {
+			return nil, ""
+		}
+		return issues, rev.SHA
 	}
-	return count
+	return nil, ""
+}
+
+// loadPluginReport runs the .bv/plugins/ analyzers of the current directory.
+// It returns nil when disabled or when there are no plugins; failures are
+// reported in the returned report, not as errors.
+func loadPluginReport(issues []model.Issue, disabled bool) *plugins.Report {
+	if disabled {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	report, err := plugins.RunAll(context.Background(), cwd, issues, plugins.DefaultTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: plugins: %v\n", err)
+		return nil
+	}
+	return report
 }
 
-// printDiffSummary prints a human-readable diff summary
 func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
 	fmt.Printf("Changes since %s\n", since)
 	fmt.Println("=" + repeatChar('=', len("Changes since "+since)))
@@ -4410,6 +6506,9 @@ func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
 	if diff.Summary.CyclesResolved > 0 {
 		fmt.Printf("  ✓ %d cycles resolved\n", diff.Summary.CyclesResolved)
 	}
+	if diff.Summary.DepsAdded > 0 || diff.Summary.DepsRemoved > 0 {
+		fmt.Printf("  ⇄ %d dependencies added, %d removed\n", diff.Summary.DepsAdded, diff.Summary.DepsRemoved)
+	}
 	fmt.Println()
 
 	// New issues
@@ -4703,6 +6802,11 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 			}
 		}
 
+		// Search filter (saved searches)
+		if f.Search != "" && !recipe.MatchesSearch(issue, f.Search) {
+			continue
+		}
+
 		result = append(result, issue)
 	}
 
@@ -4782,7 +6886,7 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 		for _, issue := range issues {
 			edgeCount += len(issue.Dependencies)
 		}
-		config = analysis.ConfigForSize(nodeCount, edgeCount)
+		config = analysis.TunedConfigForSize(nodeCount, edgeCount)
 	}
 
 	// Run profiled analysis
@@ -4809,7 +6913,7 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			LoadJSONL:       loadDuration.String(),
 			Profile:         profile,
 			TotalWithLoad:   totalWithLoad.String(),
-			Recommendations: generateProfileRecommendations(profile, loadDuration, totalWithLoad),
+			Recommendations: analysis.ProfileRecommendations(profile, loadDuration),
 		}
 
 		encoder := json.NewEncoder(os.Stdout)
@@ -4843,18 +6947,19 @@ func printProfileReport(profile *analysis.StartupProfile, loadDuration, totalWit
 	fmt.Println("Phase 2 (async in normal mode, sync for profiling):")
 	printMetricLine("PageRank", profile.PageRank, profile.PageRankTO, profile.Config.ComputePageRank)
 	printMetricLine("Betweenness", profile.Betweenness, profile.BetweennessTO, profile.Config.ComputeBetweenness)
+	printBetweennessSampleLine(profile)
 	printMetricLine("Eigenvector", profile.Eigenvector, false, profile.Config.ComputeEigenvector)
 	printMetricLine("HITS", profile.HITS, profile.HITSTO, profile.Config.ComputeHITS)
 	printMetricLine("Critical Path", profile.CriticalPath, false, profile.Config.ComputeCriticalPath)
 	printCyclesLine(profile)
-	fmt.Printf("  Total Phase 2:   %v\n\n", formatDuration(profile.Phase2))
+	fmt.Printf("  Total Phase 2:   %v (%d workers)\n\n", formatDuration(profile.Phase2), profile.Phase2Workers)
 
 	// Total
 	fmt.Printf("Total startup:     %v\n\n", formatDuration(totalWithLoad))
 
 	// Configuration used
 	fmt.Println("Configuration:")
-	fmt.Printf("  Size tier: %s\n", getSizeTier(profile.NodeCount))
+	fmt.Printf("  Size tier: %s\n", analysis.SizeTier(profile.NodeCount))
 	skipped := profile.Config.SkippedMetrics()
 	if len(skipped) > 0 {
 		var names []string
@@ -4868,7 +6973,7 @@ func printProfileReport(profile *analysis.StartupProfile, loadDuration, totalWit
 	fmt.Println()
 
 	// Recommendations
-	recommendations := generateProfileRecommendations(profile, loadDuration, totalWithLoad)
+	recommendations := analysis.ProfileRecommendations(profile, loadDuration)
 	if len(recommendations) > 0 {
 		fmt.Println("Recommendations:")
 		for _, rec := range recommendations {
@@ -4890,6 +6995,19 @@ func printMetricLine(name string, duration time.Duration, timedOut, computed boo
 	fmt.Printf("  %-14s %v%s\n", name+":", formatDuration(duration), suffix)
 }
 
+// printBetweennessSampleLine prints approximate betweenness sampling diagnostics
+func printBetweennessSampleLine(profile *analysis.StartupProfile) {
+	if profile.BetweennessSample == 0 {
+		return
+	}
+	state := "budget exhausted"
+	if profile.BetweennessConverged {
+		state = "converged"
+	}
+	fmt.Printf("    %-12s %d pivots, %d rounds, ±%.1f%% error (%s)\n", "sample:",
+		profile.BetweennessSample, profile.BetweennessRounds, profile.BetweennessError*100, state)
+}
+
 // printCyclesLine prints the cycles metric line with count
 func printCyclesLine(profile *analysis.StartupProfile) {
 	if !profile.Config.ComputeCycles {
@@ -4915,73 +7033,6 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%6dms", d.Milliseconds())
 }
 
-// getSizeTier returns the size tier name based on node count
-func getSizeTier(nodeCount int) string {
-	switch {
-	case nodeCount < 100:
-		return "Small (<100 issues)"
-	case nodeCount < 500:
-		return "Medium (100-500 issues)"
-	case nodeCount < 2000:
-		return "Large (500-2000 issues)"
-	default:
-		return "XL (>2000 issues)"
-	}
-}
-
-// generateProfileRecommendations generates actionable recommendations based on profile
-func generateProfileRecommendations(profile *analysis.StartupProfile, loadDuration, totalWithLoad time.Duration) []string {
-	var recs []string
-
-	// Check overall startup time
-	if totalWithLoad < 500*time.Millisecond {
-		recs = append(recs, "✓ Startup within acceptable range (<500ms)")
-	} else if totalWithLoad < 1*time.Second {
-		recs = append(recs, "✓ Startup acceptable (<1s)")
-	} else if totalWithLoad < 2*time.Second {
-		// Check if full analysis is being used (no skipped metrics on a large graph)
-		if len(profile.Config.SkippedMetrics()) == 0 && profile.NodeCount >= 500 {
-			recs = append(recs, "⚠ Startup is slow (1-2s) - if using --force-full-analysis, consider removing it")
-		} else {
-			recs = append(recs, "⚠ Startup is slow (1-2s)")
-		}
-	} else {
-		recs = append(recs, "⚠ Startup is very slow (>2s) - optimization recommended")
-	}
-
-	// Check for timeouts
-	if profile.PageRankTO {
-		recs = append(recs, "⚠ PageRank timed out - graph may be too large or dense")
-	}
-	if profile.BetweennessTO {
-		recs = append(recs, "⚠ Betweenness timed out - this is expected for large graphs (>500 nodes)")
-	}
-	if profile.HITSTO {
-		recs = append(recs, "⚠ HITS timed out - graph may have convergence issues")
-	}
-	if profile.CyclesTO {
-		recs = append(recs, "⚠ Cycle detection timed out - graph may have many overlapping cycles")
-	}
-
-	// Check which metric is taking longest
-	if profile.Config.ComputeBetweenness && profile.Betweenness > 0 {
-		phase2NoZero := profile.Phase2
-		if phase2NoZero > 0 {
-			betweennessPercent := float64(profile.Betweenness) / float64(phase2NoZero) * 100
-			if betweennessPercent > 50 {
-				recs = append(recs, fmt.Sprintf("⚠ Betweenness taking %.0f%% of Phase 2 time - consider skipping for large graphs", betweennessPercent))
-			}
-		}
-	}
-
-	// Check for cycles
-	if profile.CycleCount > 0 {
-		recs = append(recs, fmt.Sprintf("⚠ Found %d circular dependencies - resolve to improve graph health", profile.CycleCount))
-	}
-
-	return recs
-}
-
 // filterByRepo filters issues to only include those from a specific repository.
 // The filter matches issue IDs that start with the given prefix.
 // If the prefix doesn't end with a separator character, it normalizes by checking
@@ -5092,12 +7143,40 @@ func buildAttentionReason(score analysis.LabelAttentionScore) string {
 // Static Pages Export Helpers (bv-73f)
 // ============================================================================
 
+// writeDiagram writes diagram source to path, or to stdout for "-". A
+// Markdown path gets the source as a fenced block in the given language.
+func writeDiagram(path, diagram, language string) error {
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".md" || ext == ".markdown" {
+		diagram = "```" + language + "\n" + diagram + "```\n"
+	}
+	if path == "-" {
+		_, err := os.Stdout.WriteString(diagram)
+		return err
+	}
+	return os.WriteFile(path, []byte(diagram), 0644)
+}
+
+// exportBranding is the branding in .bv/branding.yaml under the working
+// directory with the --brand-* flags applied on top.
+func exportBranding(flags export.Branding) (export.Branding, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return export.Branding{}, err
+	}
+	brand, err := export.LoadBranding(cwd)
+	if err != nil {
+		return export.Branding{}, err
+	}
+	brand = brand.Merge(flags)
+	return brand, brand.Validate()
+}
+
 // copyViewerAssets copies the viewer HTML/JS/CSS assets to the output directory.
 // If title is provided, it replaces the default title in index.html.
-func copyViewerAssets(outputDir, title string) error {
+func copyViewerAssets(outputDir string, brand export.Branding) error {
 	// First try to use embedded assets (production builds)
 	if export.HasEmbeddedAssets() {
-		return export.CopyEmbeddedAssets(outputDir, title)
+		return export.CopyEmbeddedAssetsBranded(outputDir, brand)
 	}
 
 	// Fall back to filesystem-based approach (development mode)
@@ -5126,14 +7205,6 @@ func copyViewerAssets(outputDir, title string) error {
 		src := filepath.Join(assetsDir, file)
 		dst := filepath.Join(outputDir, file)
 
-		// Special handling for index.html to replace title
-		if file == "index.html" && title != "" {
-			if err := copyFileWithTitleReplacement(src, dst, title); err != nil {
-				return fmt.Errorf("copy %s: %w", file, err)
-			}
-			continue
-		}
-
 		if err := copyFile(src, dst); err != nil {
 			// Skip missing optional files
 			if os.IsNotExist(err) {
@@ -5161,7 +7232,8 @@ func copyViewerAssets(outputDir, title string) error {
 		}
 	}
 
-	return nil
+	// Title, logo, accent color and footer
+	return export.BrandViewer(outputDir, brand)
 }
 
 func maybeBuildHybridWasmAssets(assetsDir string) error {
@@ -5237,20 +7309,6 @@ func copyFile(src, dst string) error {
 	return err
 }
 
-// copyFileWithTitleReplacement copies a file while replacing the default title.
-func copyFileWithTitleReplacement(src, dst, title string) error {
-	content, err := os.ReadFile(src)
-	if err != nil {
-		return err
-	}
-
-	// Replace title in <title> tag and in the h1 header
-	result := strings.Replace(string(content), "<title>Beads Viewer</title>", "<title>"+title+"</title>", 1)
-	result = strings.Replace(result, `<h1 class="text-xl font-semibold">Beads Viewer</h1>`, `<h1 class="text-xl font-semibold">`+title+`</h1>`, 1)
-
-	return os.WriteFile(dst, []byte(result), 0644)
-}
-
 // copyDir recursively copies a directory.
 func copyDir(src, dst string) error {
 	srcInfo, err := os.Stat(src)
@@ -5614,8 +7672,544 @@ func isCommandAvailable(name string) bool {
 	return err == nil
 }
 
+// flagWasSet reports whether name was given on the command line, as opposed
+// to left at its default
+func flagWasSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseOutputFormat validates --format, reporting whether NDJSON streaming
+// was requested
+func parseOutputFormat(format string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "json":
+		return false, nil
+	case "ndjson", "jsonl":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown --format %q (want json or ndjson)", format)
+	}
+}
+
+// rewritePublishArgs turns "publish <dir> [flags]" into
+// "--export-pages <dir> [flags]"; other argument lists pass through.
+func rewritePublishArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "publish" {
+		return args, false, nil
+	}
+	if len(args) < 2 || strings.HasPrefix(args[1], "-") {
+		return nil, true, fmt.Errorf("usage: bv publish <dir> [--pages-title T] [--pages-push] [--pages-branch B]")
+	}
+	rewritten := append([]string{"--export-pages", args[1]}, args[2:]...)
+	return rewritten, true, nil
+}
+
+// rewriteNotifyArgs turns "notify [--dry-run] [flags]" into
+// "--notify [--notify-dry-run] [flags]"; other argument lists pass through.
+func rewriteNotifyArgs(args []string) ([]string, bool) {
+	if len(args) == 0 || args[0] != "notify" {
+		return args, false
+	}
+	rewritten := []string{"--notify"}
+	for _, arg := range args[1:] {
+		if arg == "--dry-run" || arg == "-dry-run" {
+			arg = "--notify-dry-run"
+		}
+		rewritten = append(rewritten, arg)
+	}
+	return rewritten, true
+}
+
+// rewriteMergeDriverArgs turns "merge-driver install" into
+// "--merge-driver-install" and "merge-driver BASE OURS THEIRS" into
+// "--merge-driver BASE OURS THEIRS"; other argument lists pass through.
+func rewriteMergeDriverArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "merge-driver" {
+		return args, false, nil
+	}
+	const usage = "usage: bv merge-driver install | bv merge-driver <base> <ours> <theirs>"
+	switch {
+	case len(args) == 2 && args[1] == "install":
+		return []string{"--merge-driver-install"}, true, nil
+	case len(args) == 4:
+		return append([]string{"--merge-driver"}, args[1:]...), true, nil
+	}
+	return nil, true, fmt.Errorf(usage)
+}
+
+// rewriteFmtArgs turns "fmt [--check] [flags]" into
+// "--fmt [--fmt-check] [flags]"; other argument lists pass through.
+func rewriteFmtArgs(args []string) ([]string, bool) {
+	if len(args) == 0 || args[0] != "fmt" {
+		return args, false
+	}
+	rewritten := []string{"--fmt"}
+	for _, arg := range args[1:] {
+		if arg == "--check" || arg == "-check" {
+			arg = "--fmt-check"
+		}
+		rewritten = append(rewritten, arg)
+	}
+	return rewritten, true
+}
+
+// rewriteNewIDArgs turns "new-id [--count N] [--prefix P] [flags]" into
+// "--new-id [--new-id-count N] [--new-id-prefix P] [flags]"; other argument
+// lists pass through.
+func rewriteNewIDArgs(args []string) ([]string, bool) {
+	if len(args) == 0 || args[0] != "new-id" {
+		return args, false
+	}
+	rewritten := []string{"--new-id"}
+	for _, arg := range args[1:] {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && (name == "count" || name == "prefix") {
+			arg = "--new-id-" + name
+			if hasValue {
+				arg += "=" + value
+			}
+		}
+		rewritten = append(rewritten, arg)
+	}
+	return rewritten, true
+}
+
+// runNotify builds the digest from .bv/notify.yaml and sends it to every
+// target, or prints it with dryRun. It returns the process exit code.
+func runNotify(issues []model.Issue, dryRun bool) int {
+	cwd, _ := os.Getwd()
+	cfg, err := notify.LoadConfig(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	now := time.Now()
+	since, _ := cfg.SinceDuration()
+	baseline, revision := loadStandupBaseline(loader.NewGitLoader(cwd), now.Add(-since))
+	digest := notify.BuildDigest(issues, baseline, revision, cfg, filepath.Base(cwd), now)
+
+	if dryRun {
+		fmt.Print(digest.Text(func(s string) string { return s }))
+		fmt.Println()
+		for _, target := range cfg.Targets {
+			fmt.Printf("Would send to %s\n", target.Describe())
+		}
+		return 0
+	}
+	if cfg.SkipEmpty && digest.Empty() {
+		fmt.Println("Nothing to report; skipped (skip_empty)")
+		return 0
+	}
+
+	failed := 0
+	for _, target := range cfg.Targets {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := notify.Send(ctx, target, digest)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", target.Describe(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Sent digest to %s\n", target.Describe())
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// rewriteServeArgs turns "serve [--port N] [--host H] [flags]" into
+// "--serve --serve-port N --serve-host H [flags]"; other argument lists
+// pass through.
+func rewriteServeArgs(args []string) ([]string, bool) {
+	if len(args) == 0 || args[0] != "serve" {
+		return args, false
+	}
+	rewritten := []string{"--serve"}
+	for _, arg := range args[1:] {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && (name == "port" || name == "host") {
+			arg = "--serve-" + name
+			if hasValue {
+				arg += "=" + value
+			}
+		}
+		rewritten = append(rewritten, arg)
+	}
+	return rewritten, true
+}
+
+// rewriteWorkspaceArgs turns "workspace init [--scan DIR] [--force] [--depth N]"
+// into "--workspace-init DIR [--workspace-init-force] [--workspace-init-depth N]";
+// DIR defaults to the current directory. Other argument lists pass through.
+func rewriteWorkspaceArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "workspace" {
+		return args, false, nil
+	}
+	const usage = "usage: bv workspace init [--scan DIR] [--force] [--depth N]"
+	if len(args) < 2 || args[1] != "init" {
+		return nil, true, fmt.Errorf(usage)
+	}
+	dir := "."
+	var rest []string
+	for i := 2; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") {
+			return nil, true, fmt.Errorf("unexpected argument %q; %s", arg, usage)
+		}
+		switch name {
+		case "scan":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return nil, true, fmt.Errorf("--scan needs a directory; %s", usage)
+				}
+				i++
+				value = args[i]
+			}
+			dir = value
+		case "force":
+			rest = append(rest, "--workspace-init-force")
+		case "depth":
+			rest = append(rest, "--workspace-init-depth")
+			if hasValue {
+				rest[len(rest)-1] += "=" + value
+			}
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return append([]string{"--workspace-init", dir}, rest...), true, nil
+}
+
+// rewriteRenameArgs turns "rename OLD NEW [--dry-run] [flags]" into
+// "--rename OLD --rename-to NEW [--rename-dry-run] [flags]"; other argument
+// lists pass through.
+func rewriteRenameArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "rename" {
+		return args, false, nil
+	}
+	if len(args) < 3 || strings.HasPrefix(args[1], "-") || strings.HasPrefix(args[2], "-") {
+		return nil, true, fmt.Errorf("usage: bv rename <old id> <new id> [--dry-run]")
+	}
+	rewritten := []string{"--rename", args[1], "--rename-to", args[2]}
+	for _, arg := range args[3:] {
+		if arg == "--dry-run" || arg == "-dry-run" {
+			arg = "--rename-dry-run"
+		}
+		rewritten = append(rewritten, arg)
+	}
+	return rewritten, true, nil
+}
+
+// rewriteArchiveArgs turns "archive --older-than AGE [--dry-run] [flags]"
+// into "--archive-older-than AGE [--archive-dry-run] [flags]"; other
+// argument lists pass through.
+func rewriteArchiveArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "archive" {
+		return args, false, nil
+	}
+	const usage = "usage: bv archive --older-than <age, e.g. 90d> [--dry-run]"
+	var rewritten []string
+	age := ""
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case !strings.HasPrefix(arg, "-"):
+			return nil, true, fmt.Errorf("unexpected argument %q; %s", arg, usage)
+		case name == "older-than":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return nil, true, fmt.Errorf("--older-than needs an age; %s", usage)
+				}
+				i++
+				value = args[i]
+			}
+			age = value
+		case name == "dry-run":
+			rewritten = append(rewritten, "--archive-dry-run")
+		default:
+			rewritten = append(rewritten, arg)
+		}
+	}
+	if age == "" {
+		return nil, true, fmt.Errorf(usage)
+	}
+	return append([]string{"--archive-older-than", age}, rewritten...), true, nil
+}
+
+// rewriteDaemonArgs turns "daemon" into "--daemon", "daemon status|stop"
+// into "--daemon-query status|shutdown" and "daemon query <command> [args]"
+// into "--daemon-query <command> [--daemon-args args]"; other argument
+// lists pass through.
+func rewriteDaemonArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "daemon" {
+		return args, false, nil
+	}
+	const usage = "usage: bv daemon [status | stop | query <command> ['<json args>']]"
+	if len(args) == 1 || strings.HasPrefix(args[1], "-") {
+		return append([]string{"--daemon"}, args[1:]...), true, nil
+	}
+	switch args[1] {
+	case "status", "stop":
+		if len(args) > 2 {
+			return nil, true, fmt.Errorf("unexpected argument %q; %s", args[2], usage)
+		}
+		command := args[1]
+		if command == "stop" {
+			command = "shutdown"
+		}
+		return []string{"--daemon-query", command}, true, nil
+	case "query":
+		if len(args) < 3 || len(args) > 4 {
+			return nil, true, fmt.Errorf(usage)
+		}
+		rewritten := []string{"--daemon-query", args[2]}
+		if len(args) == 4 {
+			rewritten = append(rewritten, "--daemon-args", args[3])
+		}
+		return rewritten, true, nil
+	default:
+		return nil, true, fmt.Errorf(usage)
+	}
+}
+
+// runDaemon serves robot queries about the project's beads file until
+// interrupted or sent shutdown. It returns the process exit code.
+func runDaemon() int {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+	projectDir := filepath.Dir(beadsDir)
+	_ = loader.EnsureBVInGitignore(projectDir)
+	loadAnalysisConfig(projectDir)
+
+	socket := daemon.SocketPath(projectDir)
+	listener, err := daemon.Listen(socket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	server, err := daemon.NewServer(beadsPath)
+	if err != nil {
+		_ = listener.Close()
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Reload and re-warm as soon as the file changes; queries also check
+	if w, err := watcher.NewWatcher(beadsPath,
+		watcher.WithDebounceDuration(200*time.Millisecond),
+		watcher.WithOnChange(func() {
+			if err := server.Refresh(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}),
+	); err == nil && w.Start() == nil {
+		defer w.Stop()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		server.Close()
+	}()
+
+	fmt.Fprintf(os.Stderr, "bv daemon: %d issues from %s, listening on %s (Ctrl+C or bv daemon stop to end)\n",
+		server.Issues(), beadsPath, socket)
+	if err := server.Serve(listener); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running daemon: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runDaemonQuery sends one command to the running daemon and prints its
+// response. It returns the process exit code.
+func runDaemonQuery(command, args string) int {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	socket := daemon.SocketPath(filepath.Dir(beadsDir))
+	req := daemon.Request{Command: command}
+	if args != "" {
+		if !json.Valid([]byte(args)) {
+			fmt.Fprintf(os.Stderr, "Error: --daemon-args is not valid JSON: %s\n", args)
+			return 2
+		}
+		req.Args = json.RawMessage(args)
+	}
+	resp, err := daemon.Query(socket, req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(resp); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding daemon response: %v\n", err)
+		return 1
+	}
+	if !resp.OK {
+		return 1
+	}
+	return 0
+}
+
+// rewriteHooksArgs turns "hooks install [--append-id]" into
+// "--hooks-install [--hooks-append-id]" and "hooks uninstall" into
+// "--hooks-uninstall"; other argument lists pass through.
+func rewriteHooksArgs(args []string) ([]string, bool, error) {
+	if len(args) == 0 || args[0] != "hooks" {
+		return args, false, nil
+	}
+	const usage = "usage: bv hooks install [--append-id] | bv hooks uninstall"
+	if len(args) < 2 {
+		return nil, true, fmt.Errorf(usage)
+	}
+	var rewritten []string
+	switch args[1] {
+	case "install":
+		rewritten = []string{"--hooks-install"}
+		for _, arg := range args[2:] {
+			if strings.TrimLeft(arg, "-") != "append-id" || !strings.HasPrefix(arg, "-") {
+				return nil, true, fmt.Errorf("unexpected argument %q; %s", arg, usage)
+			}
+			rewritten = append(rewritten, "--hooks-append-id")
+		}
+	case "uninstall":
+		if len(args) > 2 {
+			return nil, true, fmt.Errorf("unexpected argument %q; %s", args[2], usage)
+		}
+		rewritten = []string{"--hooks-uninstall"}
+	default:
+		return nil, true, fmt.Errorf(usage)
+	}
+	return rewritten, true, nil
+}
+
+// runGitHookCommand installs or removes bv's git hooks, or runs one of
+// them, and returns the process exit code. The hooks run from the top of
+// the work tree, so the current directory is the repository.
+func runGitHookCommand(install, uninstall, appendID, preCommit bool, commitMsgFile string) int {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+		return 1
+	}
+
+	switch {
+	case install:
+		paths, err := hooks.InstallGitHooks(cwd, hooks.GitHookOptions{AppendBeadID: appendID})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error installing git hooks: %v\n", err)
+			return 1
+		}
+		for _, p := range paths {
+			fmt.Printf("Installed %s\n", p)
+		}
+		return 0
+
+	case uninstall:
+		paths, err := hooks.UninstallGitHooks(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing git hooks: %v\n", err)
+			return 1
+		}
+		if len(paths) == 0 {
+			fmt.Println("No bv git hooks installed")
+		}
+		for _, p := range paths {
+			fmt.Printf("Removed %s\n", p)
+		}
+		return 0
+	}
+
+	// The hooks do nothing in repositories without beads
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return 0
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return 0
+	}
+
+	if preCommit {
+		if abs, err := filepath.Abs(beadsPath); err == nil {
+			beadsPath = abs
+		}
+		problems, err := hooks.ValidateStagedBeads(cwd, beadsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "bv pre-commit: %v\n", err)
+			return 1
+		}
+		if len(problems) > 0 {
+			fmt.Fprintln(os.Stderr, "bv pre-commit: the staged beads file has problems:")
+			for _, p := range problems {
+				fmt.Fprintf(os.Stderr, "  %s\n", p)
+			}
+			fmt.Fprintln(os.Stderr, "Fix them, or commit with --no-verify to skip this check.")
+			return 1
+		}
+		return 0
+	}
+
+	// commit-msg: never block a commit over a missing bead ID
+	issues, err := loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{WarningHandler: func(string) {}})
+	if err != nil {
+		return 0
+	}
+	beadInfos := make([]correlation.BeadInfo, len(issues))
+	for i, issue := range issues {
+		beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+	}
+	ctx, err := correlation.DetectBranchContext(cwd, beadInfos)
+	if err != nil || len(ctx.BranchIssueIDs) == 0 {
+		return 0
+	}
+	if _, err := hooks.AppendBeadID(commitMsgFile, ctx.BranchIssueIDs[0], issues); err != nil {
+		fmt.Fprintf(os.Stderr, "bv commit-msg: %v\n", err)
+	}
+	return 0
+}
+
+// applyExportFilter narrows issues to an --export-filter expression plus
+// their dependency closure, exiting on an invalid expression
+func applyExportFilter(issues []model.Issue, expr string) []model.Issue {
+	filter, err := export.ParseFilter(expr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --export-filter: %v\n", err)
+		os.Exit(1)
+	}
+	if filter.IsEmpty() {
+		return issues
+	}
+	filtered, matched := export.FilterIssues(issues, filter)
+	fmt.Printf("  → Filter %q matched %d issues (%d with dependencies)\n", filter.Expr, matched, len(filtered))
+	return filtered
+}
+
 // runPagesWizard runs the interactive deployment wizard (bv-10g).
-func runPagesWizard(issues []model.Issue, beadsPath string) error {
+func runPagesWizard(issues []model.Issue, beadsPath string, brand export.Branding) error {
 	wizard := export.NewWizard(beadsPath)
 
 	// Run interactive wizard to collect configuration
@@ -5692,8 +8286,10 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 	}
 	exporter := export.NewSQLiteExporter(issuePointers, deps, stats, &triage)
 	if config.Title != "" {
-		exporter.Config.Title = config.Title
+		brand.Title = config.Title
 	}
+	exporter.Config.SetBranding(brand)
+	exporter.Config.SigningKey = config.SigningKey
 
 	// Export SQLite database
 	fmt.Println("  -> Writing database and JSON files...")
@@ -5703,7 +8299,7 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 
 	// Copy viewer assets
 	fmt.Println("  -> Copying viewer assets...")
-	if err := copyViewerAssets(bundlePath, config.Title); err != nil {
+	if err := copyViewerAssets(bundlePath, brand); err != nil {
 		return fmt.Errorf("failed to copy assets: %w", err)
 	}
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -136,6 +137,18 @@ func TestApplyRecipeFilters_TitleAndPrefix(t *testing.T) {
 	}
 }
 
+func TestApplyRecipeFilters_Search(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "S-1", Title: "Session timeout", Description: "Users get logged out"},
+		{ID: "S-2", Title: "Logout button", Description: "Move it to the menu"},
+	}
+	r := &recipe.Recipe{Filters: recipe.FilterConfig{Search: "logged timeout"}}
+	got := applyRecipeFilters(issues, r)
+	if len(got) != 1 || got[0].ID != "S-1" {
+		t.Fatalf("expected S-1 only, got %#v", got)
+	}
+}
+
 func TestApplyRecipeFilters_TagsAndDates(t *testing.T) {
 	now := time.Now()
 	old := now.Add(-48 * time.Hour)
@@ -268,3 +281,258 @@ func repoRoot(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestRewritePublishArgs(t *testing.T) {
+	args, publishing, err := rewritePublishArgs([]string{"publish", "./site", "--pages-push"})
+	if err != nil || !publishing {
+		t.Fatalf("expected publish mode, got %v %v", publishing, err)
+	}
+	if strings.Join(args, " ") != "--export-pages ./site --pages-push" {
+		t.Errorf("unexpected rewrite %v", args)
+	}
+
+	if args, publishing, _ := rewritePublishArgs([]string{"--robot-triage"}); publishing || len(args) != 1 {
+		t.Errorf("non-publish args should pass through, got %v %v", args, publishing)
+	}
+	if _, _, err := rewritePublishArgs([]string{"publish", "--pages-push"}); err == nil {
+		t.Error("publish without a directory should fail")
+	}
+}
+
+func TestRewriteServeArgs(t *testing.T) {
+	args, serving := rewriteServeArgs([]string{"serve", "--port", "9090", "-host=0.0.0.0", "--pages-title", "X"})
+	if !serving {
+		t.Fatal("expected serve mode")
+	}
+	if got := strings.Join(args, " "); got != "--serve --serve-port 9090 --serve-host=0.0.0.0 --pages-title X" {
+		t.Errorf("unexpected rewrite %q", got)
+	}
+	if args, serving := rewriteServeArgs([]string{"--robot-triage"}); serving || len(args) != 1 {
+		t.Errorf("non-serve args should pass through, got %v %v", args, serving)
+	}
+}
+
+func TestRewriteNotifyArgs(t *testing.T) {
+	args, notifying := rewriteNotifyArgs([]string{"notify", "--dry-run", "--label", "api"})
+	if !notifying || strings.Join(args, " ") != "--notify --notify-dry-run --label api" {
+		t.Errorf("unexpected rewrite %v %v", args, notifying)
+	}
+	if args, notifying := rewriteNotifyArgs([]string{"--robot-triage"}); notifying || len(args) != 1 {
+		t.Errorf("non-notify args should pass through, got %v %v", args, notifying)
+	}
+}
+
+func TestRewriteFmtArgs(t *testing.T) {
+	args, formatting := rewriteFmtArgs([]string{"fmt", "--check"})
+	if !formatting || strings.Join(args, " ") != "--fmt --fmt-check" {
+		t.Errorf("unexpected rewrite %v %v", args, formatting)
+	}
+	if args, formatting := rewriteFmtArgs([]string{"--robot-triage"}); formatting || len(args) != 1 {
+		t.Errorf("non-fmt args should pass through, got %v %v", args, formatting)
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	for format, want := range map[string]bool{"": false, "json": false, "NDJSON": true, "jsonl": true} {
+		got, err := parseOutputFormat(format)
+		if err != nil || got != want {
+			t.Errorf("parseOutputFormat(%q) = %v, %v; want %v", format, got, err, want)
+		}
+	}
+	if _, err := parseOutputFormat("xml"); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
+
+func TestRewriteWorkspaceArgs(t *testing.T) {
+	args, ok, err := rewriteWorkspaceArgs([]string{"workspace", "init", "--scan", "~/src", "--force", "--depth=2"})
+	if err != nil || !ok {
+		t.Fatalf("expected workspace mode, got %v %v", ok, err)
+	}
+	want := []string{"--workspace-init", "~/src", "--workspace-init-force", "--workspace-init-depth=2"}
+	if strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("got %v, want %v", args, want)
+	}
+
+	if args, _, _ := rewriteWorkspaceArgs([]string{"workspace", "init"}); len(args) != 2 || args[1] != "." {
+		t.Errorf("expected the current directory by default, got %v", args)
+	}
+	if args, ok, _ := rewriteWorkspaceArgs([]string{"--workspace", "x.yaml"}); ok || len(args) != 2 {
+		t.Errorf("--workspace should pass through, got %v %v", args, ok)
+	}
+	for _, bad := range [][]string{{"workspace"}, {"workspace", "list"}, {"workspace", "init", "--scan"}, {"workspace", "init", "dir"}} {
+		if _, _, err := rewriteWorkspaceArgs(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestRewriteNewIDArgs(t *testing.T) {
+	args, ok := rewriteNewIDArgs([]string{"new-id", "--count", "3", "-prefix=web", "--format", "json"})
+	if want := "--new-id --new-id-count 3 --new-id-prefix=web --format json"; !ok || strings.Join(args, " ") != want {
+		t.Errorf("got %v %v, want %s", args, ok, want)
+	}
+	if args, ok := rewriteNewIDArgs([]string{"--robot-triage"}); ok || len(args) != 1 {
+		t.Errorf("other flags should pass through, got %v %v", args, ok)
+	}
+}
+
+func TestRewriteDaemonArgs(t *testing.T) {
+	for in, want := range map[string]string{
+		"daemon":                         "--daemon",
+		"daemon status":                  "--daemon-query status",
+		"daemon stop":                    "--daemon-query shutdown",
+		"daemon query next":              "--daemon-query next",
+		`daemon query plan {"agents":2}`: `--daemon-query plan --daemon-args {"agents":2}`,
+		"daemon --force-full-analysis":   "--daemon --force-full-analysis",
+	} {
+		args, ok, err := rewriteDaemonArgs(strings.Fields(in))
+		if err != nil || !ok || strings.Join(args, " ") != want {
+			t.Errorf("rewriteDaemonArgs(%q) = %v %v %v, want %s", in, args, ok, err, want)
+		}
+	}
+	if args, ok, _ := rewriteDaemonArgs([]string{"--robot-triage"}); ok || len(args) != 1 {
+		t.Errorf("other flags should pass through, got %v %v", args, ok)
+	}
+	for _, bad := range [][]string{{"daemon", "start"}, {"daemon", "query"}, {"daemon", "stop", "now"}} {
+		if _, _, err := rewriteDaemonArgs(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestRewriteHooksArgs(t *testing.T) {
+	args, ok, err := rewriteHooksArgs([]string{"hooks", "install", "--append-id"})
+	if err != nil || !ok {
+		t.Fatalf("expected hooks mode, got %v %v", ok, err)
+	}
+	if want := "--hooks-install --hooks-append-id"; strings.Join(args, " ") != want {
+		t.Errorf("got %v, want %s", args, want)
+	}
+	if args, _, _ := rewriteHooksArgs([]string{"hooks", "uninstall"}); len(args) != 1 || args[0] != "--hooks-uninstall" {
+		t.Errorf("got %v, want --hooks-uninstall", args)
+	}
+	if args, ok, _ := rewriteHooksArgs([]string{"--robot-triage"}); ok || len(args) != 1 {
+		t.Errorf("other flags should pass through, got %v %v", args, ok)
+	}
+	for _, bad := range [][]string{{"hooks"}, {"hooks", "list"}, {"hooks", "install", "--force"}, {"hooks", "uninstall", "x"}} {
+		if _, _, err := rewriteHooksArgs(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestRunFixDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.jsonl")
+	content := `{"id":"bv-1","title":"Login","status":"open","issue_type":"task"}
+{"id":"bv-1","title":"Login page","status":"open","issue_type":"task"}
+{"id":"bv-2","title":"Twice","status":"open","issue_type":"task"}
+{"id":"bv-2","title":"Twice","status":"open","issue_type":"task"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Quitting writes nothing
+	var out strings.Builder
+	if _, _, err := runFixDuplicates(path, nil, strings.NewReader("r\nq\n"), &out, 80, false); err != errFixDuplicatesQuit {
+		t.Fatalf("quit: err = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Fatalf("quitting changed the file:\n%s", data)
+	}
+
+	// An invalid answer is asked again; Enter merges identical copies
+	out.Reset()
+	_, result, err := runFixDuplicates(path, nil, strings.NewReader("m3\nr2\n\ny\n"), &out, 80, false)
+	if err != nil {
+		t.Fatalf("runFixDuplicates() error = %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Pick a copy from #1 to #2") || !strings.Contains(out.String(), "* Title") {
+		t.Errorf("output:\n%s", out.String())
+	}
+	if len(result.Renamed["bv-1"]) != 1 || strings.Join(result.Merged, ",") != "bv-2" {
+		t.Fatalf("result = %+v", result)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), `{"id":"`+result.Renamed["bv-1"][0]+`"`) || strings.Count(string(data), `"id":"bv-2"`) != 1 {
+		t.Errorf("file after fix:\n%s", data)
+	}
+}
+
+func TestRewriteRenameArgs(t *testing.T) {
+	args, ok, err := rewriteRenameArgs([]string{"rename", "bv-1", "auth-1", "--dry-run", "--workspace", "ws.yaml"})
+	if want := "--rename bv-1 --rename-to auth-1 --rename-dry-run --workspace ws.yaml"; err != nil || !ok || strings.Join(args, " ") != want {
+		t.Errorf("got %v %v %v, want %s", args, ok, err, want)
+	}
+	if args, ok, _ := rewriteRenameArgs([]string{"--robot-triage"}); ok || len(args) != 1 {
+		t.Errorf("other flags should pass through, got %v %v", args, ok)
+	}
+	for _, bad := range [][]string{{"rename"}, {"rename", "bv-1"}, {"rename", "bv-1", "--dry-run"}} {
+		if _, _, err := rewriteRenameArgs(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestRewriteArchiveArgs(t *testing.T) {
+	args, ok, err := rewriteArchiveArgs([]string{"archive", "--older-than", "90d", "--dry-run"})
+	if want := "--archive-older-than 90d --archive-dry-run"; err != nil || !ok || strings.Join(args, " ") != want {
+		t.Errorf("got %v %v %v, want %s", args, ok, err, want)
+	}
+	if args, _, _ := rewriteArchiveArgs([]string{"archive", "--older-than=2024-01-01"}); strings.Join(args, " ") != "--archive-older-than 2024-01-01" {
+		t.Errorf("--older-than=VALUE: got %v", args)
+	}
+	for _, bad := range [][]string{{"archive"}, {"archive", "--older-than"}, {"archive", "90d"}} {
+		if _, _, err := rewriteArchiveArgs(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	if got, err := parseSince("--archive-older-than", "90d", now); err != nil || !got.Equal(now.AddDate(0, 0, -90)) {
+		t.Errorf("parseSince(90d) = %v, %v", got, err)
+	}
+	if _, err := parseSince("--archive-older-than", "d", now); err == nil {
+		t.Error("parseSince(d) should fail")
+	}
+}
+
+func TestRewriteMergeDriverArgs(t *testing.T) {
+	args, ok, err := rewriteMergeDriverArgs([]string{"merge-driver", "base", "ours", "theirs"})
+	if err != nil || !ok || strings.Join(args, " ") != "--merge-driver base ours theirs" {
+		t.Errorf("got %v %v %v", args, ok, err)
+	}
+	if args, _, _ := rewriteMergeDriverArgs([]string{"merge-driver", "install"}); strings.Join(args, " ") != "--merge-driver-install" {
+		t.Errorf("install: got %v", args)
+	}
+	if _, _, err := rewriteMergeDriverArgs([]string{"merge-driver", "base", "ours"}); err == nil {
+		t.Error("expected an error for two files")
+	}
+}
+
+func TestRunMergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("base", `{"id":"bv-1","title":"A","status":"open","issue_type":"task"}`+"\n")
+	ours := write("ours", `{"id":"bv-1","title":"A","status":"closed","issue_type":"task"}`+"\n")
+	theirs := write("theirs", `{"id":"bv-1","title":"A","status":"open","issue_type":"task"}`+"\n"+
+		`{"id":"bv-2","title":"B","status":"open","issue_type":"task"}`+"\n")
+
+	var stderr strings.Builder
+	if code := runMergeDriver(base, ours, theirs, &stderr); code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+	data, _ := os.ReadFile(ours)
+	want := `{"id":"bv-1","title":"A","status":"closed","issue_type":"task"}` + "\n" +
+		`{"id":"bv-2","title":"B","status":"open","issue_type":"task"}` + "\n"
+	if string(data) != want {
+		t.Errorf("merged:\n%s\nwant:\n%s", data, want)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// runMergeDriverCommand registers bv as git's merge driver for the beads
+// files, or runs it on the base, ours and theirs files git passes, and
+// returns the process exit code
+func runMergeDriverCommand(install bool, args []string) int {
+	if install {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			return 1
+		}
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		line, changed, err := hooks.InstallMergeDriver(cwd, beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error installing merge driver: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Registered merge driver %q: %s\n", hooks.MergeDriverName, hooks.MergeDriverCommand)
+		if changed {
+			fmt.Printf("Added to .gitattributes: %s (commit it to share the routing)\n", line)
+		} else {
+			fmt.Printf(".gitattributes already has: %s\n", line)
+		}
+		fmt.Println("Each clone needs the driver registered once: bv merge-driver install")
+		return 0
+	}
+	if len(args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: bv merge-driver <base> <ours> <theirs>  (run by git; see bv merge-driver install)")
+		return 2
+	}
+	return runMergeDriver(args[0], args[1], args[2], os.Stderr)
+}
+
+// runMergeDriver merges theirsPath into oursPath with base as the common
+// ancestor. When the files cannot be merged by issue (conflict markers
+// already in them), it falls back to git's line merge, which leaves
+// conflict markers for the user.
+func runMergeDriver(basePath, oursPath, theirsPath string, stderr io.Writer) int {
+	var data [3][]byte
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "bv merge-driver: %v\n", err)
+			return 1
+		}
+		data[i] = b
+	}
+
+	merged, err := loader.MergeThreeWay(data[0], data[1], data[2])
+	if err != nil {
+		fmt.Fprintf(stderr, "bv merge-driver: %v; falling back to a line merge\n", err)
+		cmd := exec.Command("git", "merge-file", "-L", "ours", "-L", "base", "-L", "theirs", oursPath, basePath, theirsPath)
+		if err := cmd.Run(); err != nil {
+			if exit, ok := err.(*exec.ExitError); ok {
+				return exit.ExitCode()
+			}
+			return 1
+		}
+		return 0
+	}
+	if err := os.WriteFile(oursPath, merged.Data, 0o644); err != nil {
+		fmt.Fprintf(stderr, "bv merge-driver: %v\n", err)
+		return 1
+	}
+	for _, r := range merged.Resolutions {
+		fmt.Fprintf(stderr, "bv merge-driver: %s %s changed on both sides; kept %s (updated later)\n", r.ID, r.Field, r.Winner)
+	}
+	return 0
+}
@@ -27,51 +27,13 @@ func captureStdout(t *testing.T, f func()) string {
 	return buf.String()
 }
 
-func TestFormatDurationAndSizeTier(t *testing.T) {
+func TestFormatDuration(t *testing.T) {
 	if got := formatDuration(500 * time.Microsecond); got != "  0.50ms" {
 		t.Fatalf("formatDuration micro expected 0.50ms got %s", got)
 	}
 	if got := formatDuration(42 * time.Millisecond); got != "    42ms" {
 		t.Fatalf("formatDuration ms expected 42ms got %s", got)
 	}
-	if tier := getSizeTier(50); tier != "Small (<100 issues)" {
-		t.Fatalf("size tier small wrong: %s", tier)
-	}
-	if tier := getSizeTier(150); tier != "Medium (100-500 issues)" {
-		t.Fatalf("size tier medium wrong: %s", tier)
-	}
-	if tier := getSizeTier(800); tier != "Large (500-2000 issues)" {
-		t.Fatalf("size tier large wrong: %s", tier)
-	}
-	if tier := getSizeTier(5000); tier != "XL (>2000 issues)" {
-		t.Fatalf("size tier XL wrong: %s", tier)
-	}
-}
-
-func TestGenerateProfileRecommendations(t *testing.T) {
-	cfg := analysis.FullAnalysisConfig()
-	profile := &analysis.StartupProfile{
-		NodeCount:  600,
-		Config:     cfg,
-		PageRankTO: true,
-	}
-	recs := generateProfileRecommendations(profile, 200*time.Millisecond, 600*time.Millisecond)
-	if len(recs) == 0 {
-		t.Fatalf("expected recommendations")
-	}
-	foundStartup := false
-	foundPR := false
-	for _, r := range recs {
-		if strings.Contains(r, "Startup") {
-			foundStartup = true
-		}
-		if strings.Contains(r, "PageRank timed out") {
-			foundPR = true
-		}
-	}
-	if !foundStartup || !foundPR {
-		t.Fatalf("missing expected recommendations: %+v", recs)
-	}
 }
 
 func TestPrintMetricAndCyclesLines(t *testing.T) {
@@ -89,6 +51,24 @@ func TestPrintMetricAndCyclesLines(t *testing.T) {
 	}
 }
 
+func TestPrintBetweennessSampleLine(t *testing.T) {
+	out := captureStdout(t, func() {
+		printBetweennessSampleLine(&analysis.StartupProfile{})
+		printBetweennessSampleLine(&analysis.StartupProfile{
+			BetweennessSample:    80,
+			BetweennessRounds:    3,
+			BetweennessError:     0.042,
+			BetweennessConverged: true,
+		})
+	})
+	if strings.Count(out, "\n") != 1 {
+		t.Fatalf("expected exactly one line for the approximate profile, got %q", out)
+	}
+	if !strings.Contains(out, "80 pivots") || !strings.Contains(out, "4.2%") || !strings.Contains(out, "converged") {
+		t.Fatalf("unexpected sample line: %q", out)
+	}
+}
+
 func TestPrintCyclesLineSkipped(t *testing.T) {
 	profile := &analysis.StartupProfile{Config: analysis.AnalysisConfig{ComputeCycles: false}}
 	out := captureStdout(t, func() {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
@@ -37,6 +39,159 @@ type robotSearchOutput struct {
 	UsageHints  []string              `json:"usage_hints,omitempty"`
 }
 
+// semanticIndex is a synced vector index plus the embedded query.
+type semanticIndex struct {
+	Config   search.EmbeddingConfig
+	Embedder search.Embedder
+	Index    *search.VectorIndex
+	Path     string
+	Loaded   bool
+	Stats    search.IndexSyncStats
+	Query    []float32
+}
+
+// semanticIndexOptions describes the index openSemanticIndex syncs.
+type semanticIndexOptions struct {
+	ProjectDir string
+	Config     search.EmbeddingConfig
+	Docs       map[string]string
+	// IndexPath picks the cache file (search.DefaultIndexPath or
+	// search.SimilarityIndexPath).
+	IndexPath func(projectDir string, cfg search.EmbeddingConfig) string
+	// Query is embedded into semanticIndex.Query when set.
+	Query string
+	// Warn receives fallback warnings; Progress, when non-nil, build progress.
+	Warn     io.Writer
+	Progress io.Writer
+}
+
+// openSemanticIndex syncs the index for opts.Config and embeds the query. If
+// a model backend is unavailable it warns and falls back to the built-in
+// hash embedder, so semantic commands still return results.
+func openSemanticIndex(ctx context.Context, opts semanticIndexOptions) (*semanticIndex, error) {
+	si, err := syncSemanticIndex(ctx, opts)
+	if err == nil || opts.Config.Provider == search.ProviderHash {
+		return si, err
+	}
+	fmt.Fprintf(opts.Warn, "Warning: %v\nFalling back to the built-in hash embedder.\n", err)
+	opts.Config = search.EmbeddingConfig{Provider: search.ProviderHash}.Normalized()
+	return syncSemanticIndex(ctx, opts)
+}
+
+func syncSemanticIndex(ctx context.Context, opts semanticIndexOptions) (*semanticIndex, error) {
+	cfg := opts.Config
+	embedder, err := search.NewEmbedderFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	indexPath := opts.IndexPath(opts.ProjectDir, cfg)
+	idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
+	if err != nil {
+		return nil, err
+	}
+	if !loaded && opts.Progress != nil {
+		fmt.Fprintf(opts.Progress, "Building semantic index (%d issues, %s)...\n", len(opts.Docs), cfg.Provider)
+	}
+
+	stats, err := search.SyncVectorIndex(ctx, idx, embedder, opts.Docs, 64)
+	if err != nil {
+		return nil, fmt.Errorf("building semantic index: %w", err)
+	}
+	var query []float32
+	if opts.Query != "" {
+		qvecs, err := embedder.Embed(ctx, []string{opts.Query})
+		if err == nil && len(qvecs) != 1 {
+			err = fmt.Errorf("embedder returned %d vectors for query", len(qvecs))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("embedding query: %w", err)
+		}
+		query = qvecs[0]
+	}
+	if !loaded || stats.Changed() {
+		if err := idx.Save(indexPath); err != nil {
+			return nil, fmt.Errorf("saving semantic index: %w", err)
+		}
+	}
+	return &semanticIndex{
+		Config:   cfg,
+		Embedder: embedder,
+		Index:    idx,
+		Path:     indexPath,
+		Loaded:   loaded,
+		Stats:    stats,
+		Query:    query,
+	}, nil
+}
+
+type robotDuplicatePair struct {
+	Issue1     string       `json:"issue1"`
+	Title1     string       `json:"title1"`
+	Status1    model.Status `json:"status1"`
+	Issue2     string       `json:"issue2"`
+	Title2     string       `json:"title2"`
+	Status2    model.Status `json:"status2"`
+	Similarity float64      `json:"similarity"`
+}
+
+type robotDuplicatesOutput struct {
+	GeneratedAt   string                `json:"generated_at"`
+	DataHash      string                `json:"data_hash"`
+	Provider      search.Provider       `json:"provider"`
+	Model         string                `json:"model,omitempty"`
+	Dim           int                   `json:"dim"`
+	IndexPath     string                `json:"index_path"`
+	Index         search.IndexSyncStats `json:"index"`
+	Threshold     float64               `json:"threshold"`
+	IncludeClosed bool                  `json:"include_closed"`
+	Count         int                   `json:"count"`
+	Pairs         []robotDuplicatePair  `json:"pairs"`
+	UsageHints    []string              `json:"usage_hints,omitempty"`
+}
+
+// buildRobotDuplicatesOutput lists issue pairs from the similarity index si
+// at or above threshold, skipping closed issues unless includeClosed.
+func buildRobotDuplicatesOutput(issues []model.Issue, si *semanticIndex, threshold float64, includeClosed bool) robotDuplicatesOutput {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	keep := func(id string) bool {
+		issue := byID[id]
+		return issue != nil && (includeClosed || !issue.Status.IsClosed())
+	}
+
+	out := robotDuplicatesOutput{
+		Provider:      si.Config.Provider,
+		Model:         si.Config.Model,
+		Dim:           si.Embedder.Dim(),
+		IndexPath:     si.Path,
+		Index:         si.Stats,
+		Threshold:     threshold,
+		IncludeClosed: includeClosed,
+		Pairs:         []robotDuplicatePair{},
+		UsageHints: []string{
+			"jq '.pairs[] | \"\\(.issue1) \\(.issue2) \\(.similarity)\"' - List pairs",
+			"jq '.pairs[] | select(.similarity > 0.9)' - Near-identical issues",
+			"jq -r '.pairs[] | \"bd dep add \\(.issue1) \\(.issue2) --type=related\"' - Link each pair",
+		},
+	}
+	for _, p := range si.Index.SimilarPairs(threshold, keep) {
+		a, b := byID[p.Issue1], byID[p.Issue2]
+		out.Pairs = append(out.Pairs, robotDuplicatePair{
+			Issue1:     a.ID,
+			Title1:     a.Title,
+			Status1:    a.Status,
+			Issue2:     b.ID,
+			Title2:     b.Title,
+			Status2:    b.Status,
+			Similarity: p.Similarity,
+		})
+	}
+	out.Count = len(out.Pairs)
+	return out
+}
+
 func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
@@ -0,0 +1,194 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DigestKind categorizes an attention digest entry
+type DigestKind string
+
+const (
+	// DigestPriorityInversion is a low-priority issue blocking P0/P1 work
+	DigestPriorityInversion DigestKind = "priority_inversion"
+	// DigestLongBlocked is an issue that has been blocked for a long time
+	DigestLongBlocked DigestKind = "long_blocked"
+	// DigestStaleInProgress is in-progress work without recent updates
+	DigestStaleInProgress DigestKind = "stale_in_progress"
+)
+
+// DigestItem is one issue that needs attention, with a one-line reason
+type DigestItem struct {
+	Kind     DigestKind `json:"kind"`
+	IssueID  string     `json:"issue_id"`
+	Title    string     `json:"title"`
+	Priority int        `json:"priority"`
+	Days     int        `json:"days,omitempty"`     // Days stale or blocked
+	Blocking []string   `json:"blocking,omitempty"` // High-priority issues held up (inversions)
+	Reason   string     `json:"reason"`
+}
+
+// DigestConfig holds the thresholds for the attention digest
+type DigestConfig struct {
+	// StaleInProgressDays flags in-progress issues not updated for this long
+	// Default: 7
+	StaleInProgressDays int
+
+	// LongBlockedDays flags blocked issues not updated for this long
+	// Default: 14
+	LongBlockedDays int
+
+	// InversionMaxPriority is the most urgent priority that counts as
+	// "high"; a less urgent issue blocking one of these is an inversion
+	// Default: 1 (P0/P1)
+	InversionMaxPriority int
+}
+
+// DefaultDigestConfig returns sensible defaults
+func DefaultDigestConfig() DigestConfig {
+	return DigestConfig{
+		StaleInProgressDays:  7,
+		LongBlockedDays:      14,
+		InversionMaxPriority: 1,
+	}
+}
+
+// ComputeAttentionDigest lists priority inversions, long-blocked issues and
+// stale in-progress work, in that order. Within a kind the most urgent
+// entries come first.
+func ComputeAttentionDigest(issues []model.Issue, cfg DigestConfig, now time.Time) []DigestItem {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	daysSince := func(issue *model.Issue) int {
+		ts := issue.UpdatedAt
+		if ts.IsZero() {
+			ts = issue.CreatedAt
+		}
+		if ts.IsZero() {
+			return 0
+		}
+		return int(now.Sub(ts).Hours() / 24)
+	}
+
+	// openBlockers returns the unresolved issues blocking issue
+	openBlockers := func(issue *model.Issue) []string {
+		var ids []string
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.IsClosed() {
+				ids = append(ids, blocker.ID)
+			}
+		}
+		return ids
+	}
+
+	var inversions, blocked, stale []DigestItem
+
+	// Priority inversions: walk high-priority issues and flag their
+	// less urgent open blockers
+	held := make(map[string][]string) // Blocker ID -> high-priority issues it holds up
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Priority > cfg.InversionMaxPriority {
+			continue
+		}
+		for _, id := range openBlockers(issue) {
+			if byID[id].Priority > cfg.InversionMaxPriority {
+				held[id] = append(held[id], issue.ID)
+			}
+		}
+	}
+	for id, targets := range held {
+		blocker := byID[id]
+		sort.Slice(targets, func(a, b int) bool {
+			pa, pb := byID[targets[a]].Priority, byID[targets[b]].Priority
+			if pa != pb {
+				return pa < pb
+			}
+			return targets[a] < targets[b]
+		})
+		top := byID[targets[0]]
+		reason := fmt.Sprintf("P%d blocks P%d %s", blocker.Priority, top.Priority, top.ID)
+		if len(targets) > 1 {
+			reason += fmt.Sprintf(" (+%d more)", len(targets)-1)
+		}
+		inversions = append(inversions, DigestItem{
+			Kind:     DigestPriorityInversion,
+			IssueID:  blocker.ID,
+			Title:    blocker.Title,
+			Priority: blocker.Priority,
+			Blocking: targets,
+			Reason:   reason,
+		})
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		days := daysSince(issue)
+		switch {
+		case issue.Status == model.StatusInProgress && days >= cfg.StaleInProgressDays:
+			stale = append(stale, DigestItem{
+				Kind:     DigestStaleInProgress,
+				IssueID:  issue.ID,
+				Title:    issue.Title,
+				Priority: issue.Priority,
+				Days:     days,
+				Reason:   fmt.Sprintf("in progress, no update for %dd", days),
+			})
+		case !issue.Status.IsClosed() && days >= cfg.LongBlockedDays:
+			blockers := openBlockers(issue)
+			if issue.Status != model.StatusBlocked && len(blockers) == 0 {
+				continue
+			}
+			reason := fmt.Sprintf("blocked %dd", days)
+			if len(blockers) > 0 {
+				reason += " by " + blockers[0]
+				if len(blockers) > 1 {
+					reason += fmt.Sprintf(" (+%d more)", len(blockers)-1)
+				}
+			}
+			blocked = append(blocked, DigestItem{
+				Kind:     DigestLongBlocked,
+				IssueID:  issue.ID,
+				Title:    issue.Title,
+				Priority: issue.Priority,
+				Days:     days,
+				Reason:   reason,
+			})
+		}
+	}
+
+	sort.Slice(inversions, func(a, b int) bool {
+		pa, pb := byID[inversions[a].Blocking[0]].Priority, byID[inversions[b].Blocking[0]].Priority
+		if pa != pb {
+			return pa < pb
+		}
+		if len(inversions[a].Blocking) != len(inversions[b].Blocking) {
+			return len(inversions[a].Blocking) > len(inversions[b].Blocking)
+		}
+		return inversions[a].IssueID < inversions[b].IssueID
+	})
+	byDays := func(items []DigestItem) {
+		sort.Slice(items, func(a, b int) bool {
+			if items[a].Days != items[b].Days {
+				return items[a].Days > items[b].Days
+			}
+			return items[a].IssueID < items[b].IssueID
+		})
+	}
+	byDays(blocked)
+	byDays(stale)
+
+	digest := make([]DigestItem, 0, len(inversions)+len(blocked)+len(stale))
+	digest = append(digest, inversions...)
+	digest = append(digest, blocked...)
+	return append(digest, stale...)
+}
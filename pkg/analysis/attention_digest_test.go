@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeAttentionDigest(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.Add(-time.Duration(d) * 24 * time.Hour) }
+	blockedBy := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+
+	issues := []model.Issue{
+		// P3 chore blocking a P0: inversion
+		{ID: "low", Title: "Cleanup", Status: model.StatusOpen, Priority: 3, UpdatedAt: daysAgo(1)},
+		{ID: "urgent", Title: "Outage", Status: model.StatusOpen, Priority: 0, UpdatedAt: daysAgo(20), Dependencies: blockedBy("urgent", "low")},
+		// P1 blocked by a P1: not an inversion, but blocked for 30 days
+		{ID: "peer", Title: "Peer", Status: model.StatusOpen, Priority: 1, UpdatedAt: daysAgo(2)},
+		{ID: "waiting", Title: "Waiting", Status: model.StatusOpen, Priority: 1, UpdatedAt: daysAgo(30), Dependencies: blockedBy("waiting", "peer")},
+		// Blocker closed: no longer blocked
+		{ID: "done", Title: "Done", Status: model.StatusClosed, Priority: 4, UpdatedAt: daysAgo(40)},
+		{ID: "free", Title: "Free", Status: model.StatusOpen, Priority: 0, UpdatedAt: daysAgo(40), Dependencies: blockedBy("free", "done")},
+		// In progress for 10 days vs. 2 days
+		{ID: "slow", Title: "Slow", Status: model.StatusInProgress, Priority: 2, UpdatedAt: daysAgo(10)},
+		{ID: "fresh", Title: "Fresh", Status: model.StatusInProgress, Priority: 2, UpdatedAt: daysAgo(2)},
+	}
+
+	digest := ComputeAttentionDigest(issues, DefaultDigestConfig(), now)
+	want := []struct {
+		kind DigestKind
+		id   string
+	}{
+		{DigestPriorityInversion, "low"},
+		{DigestLongBlocked, "waiting"},
+		{DigestLongBlocked, "urgent"},
+		{DigestStaleInProgress, "slow"},
+	}
+	if len(digest) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(digest), len(want), digest)
+	}
+	for i, w := range want {
+		if digest[i].Kind != w.kind || digest[i].IssueID != w.id {
+			t.Errorf("item %d = %s %s, want %s %s", i, digest[i].Kind, digest[i].IssueID, w.kind, w.id)
+		}
+	}
+	if digest[0].Reason != "P3 blocks P0 urgent" {
+		t.Errorf("inversion reason = %q", digest[0].Reason)
+	}
+	if digest[1].Reason != "blocked 30d by peer" || digest[3].Reason != "in progress, no update for 10d" {
+		t.Errorf("unexpected reasons %q, %q", digest[1].Reason, digest[3].Reason)
+	}
+}
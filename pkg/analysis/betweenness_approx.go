@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"math"
 	"math/rand"
 	"sort"
 	"time"
@@ -48,6 +49,12 @@ type BetweennessResult struct {
 
 	// TimedOut indicates if computation was interrupted by timeout
 	TimedOut bool
+
+	// Adaptive sampling diagnostics (zero unless AdaptiveBetweenness was used)
+	Rounds         int     // Sampling rounds performed
+	Converged      bool    // Top-k ranking stabilized before budget/limit was hit
+	RankStability  float64 // Overlap of top-k between the last two rounds (0-1)
+	EstimatedError float64 // Mean relative 95% CI half-width of top-k scores (0 = exact)
 }
 
 // ApproxBetweenness computes approximate betweenness centrality using sampling.
@@ -263,3 +270,208 @@ func RecommendSampleSize(nodeCount, edgeCount int) int {
 		return 200
 	}
 }
+
+// AdaptiveBetweennessConfig controls AdaptiveBetweenness.
+type AdaptiveBetweennessConfig struct {
+	// InitialSample is the number of pivots in the first round.
+	InitialSample int
+	// MaxSample caps total pivots (0 = node count, i.e. may reach exact).
+	MaxSample int
+	// Budget is the wall-clock time after which no new round is started.
+	Budget time.Duration
+	// TopK is the ranking prefix compared between rounds. Default: 10.
+	TopK int
+	// StabilityThreshold is the minimum top-k overlap to declare convergence. Default: 0.9.
+	StabilityThreshold float64
+	// Seed makes pivot selection deterministic.
+	Seed int64
+}
+
+// AdaptiveBetweenness grows the pivot sample until the top-k betweenness
+// ranking stabilizes, the time budget is spent, or MaxSample is reached.
+//
+// Pivots are drawn from a single seeded permutation, so each round extends the
+// previous sample (sample sizes double per round). Per-pivot contributions are
+// tracked to estimate the standard error of every score; EstimatedError is the
+// mean relative 95% confidence half-width over the top-k nodes, with a finite
+// population correction so it reaches 0 when every node is a pivot.
+func AdaptiveBetweenness(g *simple.DirectedGraph, cfg AdaptiveBetweennessConfig) BetweennessResult {
+	start := time.Now()
+	nodes := graph.NodesOf(g.Nodes())
+	n := len(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	result := BetweennessResult{
+		Scores:     make(map[int64]float64),
+		Mode:       BetweennessApproximate,
+		TotalNodes: n,
+	}
+	if n == 0 {
+		result.Converged = true
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	if cfg.TopK <= 0 {
+		cfg.TopK = 10
+	}
+	if cfg.StabilityThreshold <= 0 {
+		cfg.StabilityThreshold = 0.9
+	}
+	maxSample := cfg.MaxSample
+	if maxSample <= 0 || maxSample > n {
+		maxSample = n
+	}
+	sample := cfg.InitialSample
+	if sample <= 0 {
+		sample = RecommendSampleSize(n, g.Edges().Len())
+	}
+	if sample > maxSample {
+		sample = maxSample
+	}
+
+	// One seeded permutation so each round extends the previous sample.
+	pivots := make([]graph.Node, n)
+	for i, j := range rand.New(rand.NewSource(cfg.Seed)).Perm(n) {
+		pivots[i] = nodes[j]
+	}
+	sums := make(map[int64]float64)
+	sumSq := make(map[int64]float64)
+	used := 0
+	var prevTop []int64
+
+	for {
+		accumulatePivots(g, pivots[used:sample], sums, sumSq)
+		used = sample
+		result.Rounds++
+
+		top := topKNodes(sums, cfg.TopK)
+		if prevTop != nil {
+			result.RankStability = topKOverlap(prevTop, top)
+			if result.RankStability >= cfg.StabilityThreshold {
+				result.Converged = true
+			}
+		}
+		prevTop = top
+
+		if used >= n {
+			// Every node has been a pivot: scores are exact.
+			result.Converged = true
+			break
+		}
+		if result.Converged || used >= maxSample {
+			break
+		}
+		if cfg.Budget > 0 && time.Since(start) >= cfg.Budget {
+			break
+		}
+		sample = min(used*2, maxSample)
+	}
+
+	scale := float64(n) / float64(used)
+	for id, v := range sums {
+		result.Scores[id] = v * scale
+	}
+	result.SampleSize = used
+	if used >= n {
+		result.Mode = BetweennessExact
+	} else {
+		result.EstimatedError = estimateRelativeError(prevTop, sums, sumSq, used, n)
+	}
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// accumulatePivots adds single-source contributions of pivots into sums and sumSq.
+func accumulatePivots(g *simple.DirectedGraph, pivots []graph.Node, sums, sumSq map[int64]float64) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	for _, pivot := range pivots {
+		wg.Add(1)
+		go func(p graph.Node) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			localBC := make(map[int64]float64)
+			singleSourceBetweenness(g, p, localBC)
+
+			mu.Lock()
+			for id, val := range localBC {
+				sums[id] += val
+				sumSq[id] += val * val
+			}
+			mu.Unlock()
+		}(pivot)
+	}
+	wg.Wait()
+}
+
+// topKNodes returns the k node IDs with the highest score (ties by ID).
+func topKNodes(scores map[int64]float64, k int) []int64 {
+	ids := make([]int64, 0, len(scores))
+	for id, v := range scores {
+		if v > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+// topKOverlap returns |a ∩ b| / max(|a|, |b|), or 1 when both are empty.
+func topKOverlap(a, b []int64) float64 {
+	size := max(len(a), len(b))
+	if size == 0 {
+		return 1
+	}
+	inA := make(map[int64]bool, len(a))
+	for _, id := range a {
+		inA[id] = true
+	}
+	shared := 0
+	for _, id := range b {
+		if inA[id] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(size)
+}
+
+// estimateRelativeError computes the mean relative 95% CI half-width for the
+// given nodes after k of n pivots have been sampled without replacement.
+func estimateRelativeError(ids []int64, sums, sumSq map[int64]float64, k, n int) float64 {
+	if k < 2 || len(ids) == 0 {
+		return 1
+	}
+	fpc := math.Sqrt(float64(n-k) / float64(n-1))
+	total := 0.0
+	counted := 0
+	for _, id := range ids {
+		mean := sums[id] / float64(k)
+		if mean <= 0 {
+			continue
+		}
+		variance := (sumSq[id] - float64(k)*mean*mean) / float64(k-1)
+		if variance < 0 {
+			variance = 0
+		}
+		stderr := math.Sqrt(variance/float64(k)) * fpc
+		total += 1.96 * stderr / mean
+		counted++
+	}
+	if counted == 0 {
+		return 1
+	}
+	return total / float64(counted)
+}
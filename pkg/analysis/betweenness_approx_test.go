@@ -1,7 +1,9 @@
 package analysis
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
@@ -113,6 +115,83 @@ func TestBetweennessMode_ConfigIntegration(t *testing.T) {
 	}
 }
 
+func TestAdaptiveBetweenness_ReachesExactOnSmallGraph(t *testing.T) {
+	analyzer := NewAnalyzer(generateChainGraph(30))
+	result := AdaptiveBetweenness(analyzer.g, AdaptiveBetweennessConfig{InitialSample: 4, StabilityThreshold: 1.01, Seed: 1})
+
+	if result.Mode != BetweennessExact {
+		t.Fatalf("expected exact mode once every node is a pivot, got %s", result.Mode)
+	}
+	if result.SampleSize != 30 || result.EstimatedError != 0 {
+		t.Errorf("expected full sample with zero error, got sample=%d error=%v", result.SampleSize, result.EstimatedError)
+	}
+	if result.Rounds < 3 {
+		t.Errorf("expected sample doubling over several rounds, got %d", result.Rounds)
+	}
+
+	exact := ApproxBetweenness(analyzer.g, 100, 1)
+	for id, want := range exact.Scores {
+		if got := result.Scores[id]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("node %d: adaptive=%v exact=%v", id, got, want)
+		}
+	}
+}
+
+func TestAdaptiveBetweenness_ConvergesWithErrorBound(t *testing.T) {
+	analyzer := NewAnalyzer(generateChainGraph(400))
+	result := AdaptiveBetweenness(analyzer.g, AdaptiveBetweennessConfig{InitialSample: 20, MaxSample: 200, Seed: 1})
+
+	if result.Mode != BetweennessApproximate {
+		t.Fatalf("expected approximate mode, got %s", result.Mode)
+	}
+	if result.SampleSize < 20 || result.SampleSize > 200 {
+		t.Errorf("sample size %d outside [20, 200]", result.SampleSize)
+	}
+	if !result.Converged && result.SampleSize != 200 {
+		t.Errorf("expected convergence or max sample, got %+v", result)
+	}
+	if result.EstimatedError <= 0 || result.EstimatedError > 1 {
+		t.Errorf("expected error estimate in (0, 1], got %v", result.EstimatedError)
+	}
+}
+
+func TestAdaptiveBetweenness_RespectsBudget(t *testing.T) {
+	analyzer := NewAnalyzer(generateChainGraph(300))
+	result := AdaptiveBetweenness(analyzer.g, AdaptiveBetweennessConfig{
+		InitialSample:      10,
+		Budget:             time.Nanosecond,
+		StabilityThreshold: 1.01, // never converge
+		Seed:               1,
+	})
+	if result.Rounds != 1 || result.SampleSize != 10 {
+		t.Errorf("expected a single round when budget is exhausted, got rounds=%d sample=%d", result.Rounds, result.SampleSize)
+	}
+	if result.Converged {
+		t.Error("should not report convergence when stopped by budget")
+	}
+}
+
+func TestAnalyze_AdaptiveBetweennessReportsError(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BetweennessMode = BetweennessApproximate
+	cfg.BetweennessSampleSize = 10
+	cfg.BetweennessAdaptive = true
+	cfg.BetweennessMaxSampleSize = 40
+	cfg.BetweennessTimeout = 5 * time.Second
+
+	stats, profile := NewAnalyzer(generateChainGraph(200)).AnalyzeWithProfile(cfg)
+	st := stats.Status()
+	if st.Betweenness.Sample == 0 || st.Betweenness.Error <= 0 {
+		t.Errorf("expected sample and error estimate in status, got %+v", st.Betweenness)
+	}
+	if profile.BetweennessSample != st.Betweenness.Sample || profile.BetweennessError != st.Betweenness.Error {
+		t.Errorf("profile and status disagree: profile=%+v status=%+v", profile, st.Betweenness)
+	}
+	if profile.BetweennessRounds == 0 {
+		t.Error("expected adaptive rounds in profile")
+	}
+}
+
 // BenchmarkApproxBetweenness_vs_Exact benchmarks approximate vs exact betweenness
 func BenchmarkApproxBetweenness_500nodes_Exact(b *testing.B) {
 	issues := generateChainGraph(500)
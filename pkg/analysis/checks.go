@@ -0,0 +1,187 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// maxCheckLines caps the violations printed per check in CheckReport.Summary.
+const maxCheckLines = 10
+
+// CheckViolation is one backlog-health problem found by a check.
+type CheckViolation struct {
+	IssueID string   `json:"issue_id"`
+	Message string   `json:"message"`
+	Related []string `json:"related,omitempty"` // Other issues involved (cycle members, missing targets)
+}
+
+// CheckResult is the outcome of a single --check-* flag.
+type CheckResult struct {
+	Name       string           `json:"name"`
+	Passed     bool             `json:"passed"`
+	Violations []CheckViolation `json:"violations"`
+}
+
+// CheckReport aggregates the checks run in one invocation.
+type CheckReport struct {
+	GeneratedAt string        `json:"generated_at"`
+	DataHash    string        `json:"data_hash"`
+	Passed      bool          `json:"passed"`
+	ExitCode    int           `json:"exit_code"`
+	Checks      []CheckResult `json:"checks"`
+}
+
+// NewCheckReport combines check results into a report.
+func NewCheckReport(results []CheckResult, dataHash string, now time.Time) CheckReport {
+	report := CheckReport{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		Passed:      true,
+		Checks:      results,
+	}
+	for _, r := range results {
+		if !r.Passed {
+			report.Passed = false
+			report.ExitCode = 1
+		}
+	}
+	return report
+}
+
+// Summary renders a compact human-readable report, one line per check
+// followed by up to maxCheckLines violations.
+func (r CheckReport) Summary() string {
+	var sb strings.Builder
+	for _, c := range r.Checks {
+		if c.Passed {
+			fmt.Fprintf(&sb, "PASS %s\n", c.Name)
+			continue
+		}
+		fmt.Fprintf(&sb, "FAIL %s: %d violation(s)\n", c.Name, len(c.Violations))
+		for i, v := range c.Violations {
+			if i == maxCheckLines {
+				fmt.Fprintf(&sb, "  ... and %d more\n", len(c.Violations)-maxCheckLines)
+				break
+			}
+			fmt.Fprintf(&sb, "  %s: %s\n", v.IssueID, v.Message)
+		}
+	}
+	return sb.String()
+}
+
+// finish marks a result passed when it has no violations.
+func (c CheckResult) finish() CheckResult {
+	if c.Violations == nil {
+		c.Violations = []CheckViolation{}
+	}
+	c.Passed = len(c.Violations) == 0
+	return c
+}
+
+// CheckCycles reports every dependency cycle among blocking edges.
+func CheckCycles(issues []model.Issue) CheckResult {
+	result := CheckResult{Name: "cycles"}
+	report := BuildCycleReport(issues, DefaultCycleReportConfig())
+	for _, cycle := range report.Cycles {
+		if len(cycle.Members) == 0 {
+			continue
+		}
+		path := append(append([]string{}, cycle.Members...), cycle.Members[0])
+		result.Violations = append(result.Violations, CheckViolation{
+			IssueID: cycle.Members[0],
+			Message: "cycle " + strings.Join(path, " -> "),
+			Related: cycle.Members,
+		})
+	}
+	return result.finish()
+}
+
+// CheckStale reports unresolved issues not updated for at least thresholdDays.
+// Unlike --robot-stale, the threshold applies uniformly to every status.
+func CheckStale(issues []model.Issue, thresholdDays int, now time.Time) CheckResult {
+	result := CheckResult{Name: fmt.Sprintf("stale (>= %dd)", thresholdDays)}
+	report := BuildStaleReport(issues, StaleReportConfig{
+		ThresholdDays:        thresholdDays,
+		InProgressMultiplier: 1,
+	}, now)
+
+	var stale []StaleIssue
+	for _, group := range report.Groups {
+		stale = append(stale, group.Issues...)
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		if stale[i].StalenessDays != stale[j].StalenessDays {
+			return stale[i].StalenessDays > stale[j].StalenessDays
+		}
+		return stale[i].ID < stale[j].ID
+	})
+	for _, s := range stale {
+		result.Violations = append(result.Violations, CheckViolation{
+			IssueID: s.ID,
+			Message: fmt.Sprintf("%s, not updated for %d days: %s", s.Status, s.StalenessDays, s.Title),
+		})
+	}
+	return result.finish()
+}
+
+// CheckOrphans reports dependencies that reference issues missing from the
+// tracker, typically left behind by deleted or mistyped IDs.
+func CheckOrphans(issues []model.Issue) CheckResult {
+	result := CheckResult{Name: "orphans"}
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+
+	sorted := append([]model.Issue{}, issues...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for _, issue := range sorted {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.DependsOnID == "" || known[dep.DependsOnID] {
+				continue
+			}
+			result.Violations = append(result.Violations, CheckViolation{
+				IssueID: issue.ID,
+				Message: fmt.Sprintf("%s dependency on missing issue %s", dep.Type, dep.DependsOnID),
+				Related: []string{dep.DependsOnID},
+			})
+		}
+	}
+	return result.finish()
+}
+
+// ParseCheckDays parses a --check-stale threshold: a plain number of days or
+// a duration such as "14d", "2w", "1m" or "1y".
+func ParseCheckDays(s string, now time.Time) (int, error) {
+	s = strings.TrimSpace(s)
+	invalid := fmt.Errorf("invalid stale threshold %q (want e.g. 14, 14d, 2w)", s)
+	if days, err := strconv.Atoi(s); err == nil {
+		if days <= 0 {
+			return 0, invalid
+		}
+		return days, nil
+	}
+	if len(s) < 2 {
+		return 0, invalid
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, invalid
+	}
+	switch s[len(s)-1] {
+	case 'd':
+		return n, nil
+	case 'w':
+		return n * 7, nil
+	case 'm':
+		return int(now.Sub(now.AddDate(0, -n, 0)).Hours() / 24), nil
+	case 'y':
+		return int(now.Sub(now.AddDate(-n, 0, 0)).Hours() / 24), nil
+	}
+	return 0, invalid
+}
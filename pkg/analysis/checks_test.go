@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCheckCycles(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen},
+	}
+	result := CheckCycles(issues)
+	if result.Passed || len(result.Violations) != 1 {
+		t.Fatalf("expected one cycle violation, got %+v", result)
+	}
+	if !strings.Contains(result.Violations[0].Message, "->") || len(result.Violations[0].Related) != 2 {
+		t.Errorf("unexpected violation %+v", result.Violations[0])
+	}
+
+	if result := CheckCycles(issues[2:]); !result.Passed || result.Violations == nil {
+		t.Errorf("acyclic graph should pass with an empty violation list, got %+v", result)
+	}
+}
+
+func TestCheckStale(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	issues := []model.Issue{
+		{ID: "fresh", Status: model.StatusOpen, UpdatedAt: ago(3)},
+		{ID: "old", Status: model.StatusOpen, UpdatedAt: ago(20)},
+		{ID: "oldest", Status: model.StatusBlocked, UpdatedAt: ago(40)},
+		{ID: "wip", Status: model.StatusInProgress, UpdatedAt: ago(10)}, // Uniform threshold, not halved
+		{ID: "done", Status: model.StatusClosed, UpdatedAt: ago(100)},
+	}
+
+	result := CheckStale(issues, 14, now)
+	if result.Passed || len(result.Violations) != 2 {
+		t.Fatalf("expected 2 stale issues, got %+v", result)
+	}
+	if result.Violations[0].IssueID != "oldest" || result.Violations[1].IssueID != "old" {
+		t.Errorf("expected most stale first, got %s, %s", result.Violations[0].IssueID, result.Violations[1].IssueID)
+	}
+	if !CheckStale(issues, 60, now).Passed {
+		t.Error("expected no stale issues at a 60 day threshold")
+	}
+}
+
+func TestCheckOrphans(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "B", Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+			{IssueID: "B", DependsOnID: "gone", Type: model.DepParentChild},
+		}},
+		{ID: "A", Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "typo-1", Type: model.DepBlocks}}},
+	}
+	result := CheckOrphans(issues)
+	if result.Passed || len(result.Violations) != 2 {
+		t.Fatalf("expected 2 orphan dependencies, got %+v", result)
+	}
+	if result.Violations[0].IssueID != "A" || result.Violations[0].Related[0] != "typo-1" {
+		t.Errorf("unexpected first violation %+v", result.Violations[0])
+	}
+	if !CheckOrphans(issues[:0]).Passed {
+		t.Error("empty tracker should pass")
+	}
+}
+
+func TestCheckReport(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	pass := CheckResult{Name: "cycles", Passed: true, Violations: []CheckViolation{}}
+	fail := CheckResult{Name: "orphans"}
+	for i := 0; i < 12; i++ {
+		fail.Violations = append(fail.Violations, CheckViolation{IssueID: "X", Message: "missing"})
+	}
+
+	if r := NewCheckReport([]CheckResult{pass}, "h", now); !r.Passed || r.ExitCode != 0 {
+		t.Errorf("expected passing report, got %+v", r)
+	}
+	r := NewCheckReport([]CheckResult{pass, fail}, "h", now)
+	if r.Passed || r.ExitCode != 1 {
+		t.Fatalf("expected failing report, got %+v", r)
+	}
+	summary := r.Summary()
+	if !strings.Contains(summary, "PASS cycles") || !strings.Contains(summary, "FAIL orphans: 12 violation(s)") {
+		t.Errorf("unexpected summary:\n%s", summary)
+	}
+	if !strings.Contains(summary, "... and 2 more") {
+		t.Errorf("expected truncated violations in summary:\n%s", summary)
+	}
+}
+
+func TestParseCheckDays(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"14", 14},
+		{"14d", 14},
+		{"2w", 14},
+		{"1m", 28}, // February
+		{"1y", 365},
+	}
+	for _, tt := range tests {
+		got, err := ParseCheckDays(tt.in, now)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCheckDays(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "0", "-3", "d", "14x", "abc"} {
+		if _, err := ParseCheckDays(bad, now); err == nil {
+			t.Errorf("ParseCheckDays(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ClusterConfig configures community detection over the issue graph.
+type ClusterConfig struct {
+	// MinSize is the smallest group reported as a cluster. Issues in smaller
+	// groups are listed as unclustered.
+	// Default: 2
+	MinSize int
+
+	// MaxIterations bounds local-moving passes per Louvain level.
+	// Default: 100
+	MaxIterations int
+
+	// IncludeClosed keeps closed issues (and edges through them) in the graph.
+	// Work partitioning usually only cares about open work.
+	// Default: false
+	IncludeClosed bool
+}
+
+// DefaultClusterConfig returns sensible defaults.
+func DefaultClusterConfig() ClusterConfig {
+	return ClusterConfig{
+		MinSize:       2,
+		MaxIterations: 100,
+	}
+}
+
+// Edge weights for the undirected projection. Blocking and hierarchy edges
+// bind issues more tightly than informational links.
+var clusterEdgeWeights = map[model.DependencyType]float64{
+	model.DepBlocks:         1.0,
+	model.DepParentChild:    1.0,
+	model.DepRelated:        0.5,
+	model.DepDiscoveredFrom: 0.5,
+}
+
+// Cluster is a group of densely connected issues.
+type Cluster struct {
+	ID            int      `json:"id"` // 1-based, ordered by size
+	Anchor        string   `json:"anchor"`
+	AnchorTitle   string   `json:"anchor_title,omitempty"`
+	Size          int      `json:"size"`
+	OpenCount     int      `json:"open_count"`
+	Members       []string `json:"members"`
+	InternalEdges int      `json:"internal_edges"`
+	ExternalEdges int      `json:"external_edges"`   // Edges crossing into other clusters
+	Labels        []string `json:"labels,omitempty"` // Most common labels, up to 3
+}
+
+// ClusterResult is the outcome of community detection.
+type ClusterResult struct {
+	ClusterCount int            `json:"cluster_count"`
+	Clusters     []Cluster      `json:"clusters"`
+	Assignments  map[string]int `json:"assignments"` // Issue ID -> Cluster.ID
+	Unclustered  []string       `json:"unclustered"`
+	Modularity   float64        `json:"modularity"`
+	Iterations   int            `json:"iterations"`
+	Converged    bool           `json:"converged"`
+	Method       string         `json:"method"`
+}
+
+// ClusterOf returns the cluster ID for an issue, or 0 if it is unclustered.
+func (r ClusterResult) ClusterOf(id string) int {
+	return r.Assignments[id]
+}
+
+// RobotClustersOutput is the JSON output structure for --robot-clusters.
+type RobotClustersOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	ClusterResult
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotClustersOutput creates the full robot-clusters output.
+func GenerateRobotClustersOutput(issues []model.Issue, config ClusterConfig, dataHash string) RobotClustersOutput {
+	return RobotClustersOutput{
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		DataHash:      dataHash,
+		ClusterResult: BuildClusters(issues, config),
+		UsageHints: []string{
+			"jq '.clusters[] | {id, anchor, size, open_count}' - Cluster overview",
+			"jq '.clusters[0].members' - Issues in the largest cluster",
+			"jq '.assignments[\"ISSUE-ID\"]' - Cluster of a given issue",
+			"jq '.clusters[] | select(.external_edges == 0)' - Fully independent work partitions",
+		},
+	}
+}
+
+// BuildClusters groups related issues by running the Louvain modularity
+// heuristic on the undirected, weighted projection of the dependency graph.
+// Nodes are visited in ID order and ties keep the earlier community, so the
+// result is deterministic for a given input.
+func BuildClusters(issues []model.Issue, config ClusterConfig) ClusterResult {
+	if config.MinSize <= 0 {
+		config.MinSize = DefaultClusterConfig().MinSize
+	}
+	if config.MaxIterations <= 0 {
+		config.MaxIterations = DefaultClusterConfig().MaxIterations
+	}
+
+	result := ClusterResult{
+		Clusters:    []Cluster{},
+		Assignments: make(map[string]int),
+		Unclustered: []string{},
+		Method:      "louvain",
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		if issue.Status.IsTombstone() || (!config.IncludeClosed && issue.Status.IsClosed()) {
+			continue
+		}
+		issueMap[issue.ID] = issue
+	}
+	if len(issueMap) == 0 {
+		result.Converged = true
+		return result
+	}
+
+	ids := make([]string, 0, len(issueMap))
+	for id := range issueMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Undirected weighted adjacency; parallel edges accumulate weight.
+	adj := make(map[string]map[string]float64, len(ids))
+	edgeCount := make(map[[2]string]bool)
+	for _, id := range ids {
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil || dep.DependsOnID == id {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; !ok {
+				continue
+			}
+			w, ok := clusterEdgeWeights[dep.Type]
+			if !ok {
+				if !dep.Type.IsBlocking() {
+					continue
+				}
+				w = 1.0
+			}
+			a, b := id, dep.DependsOnID
+			if adj[a] == nil {
+				adj[a] = make(map[string]float64)
+			}
+			if adj[b] == nil {
+				adj[b] = make(map[string]float64)
+			}
+			adj[a][b] += w
+			adj[b][a] += w
+			if b < a {
+				a, b = b, a
+			}
+			edgeCount[[2]string{a, b}] = true
+		}
+	}
+
+	community, passes, converged := louvain(ids, adj, config.MaxIterations)
+	result.Iterations = passes
+	result.Converged = converged
+
+	labels := make(map[string]string, len(ids))
+	groups := make(map[string][]string)
+	for i, id := range ids {
+		labels[id] = ids[community[i]]
+		groups[labels[id]] = append(groups[labels[id]], id)
+	}
+
+	degree := func(id string) float64 {
+		var d float64
+		for _, w := range adj[id] {
+			d += w
+		}
+		return d
+	}
+
+	for _, members := range groups {
+		if len(members) < config.MinSize {
+			result.Unclustered = append(result.Unclustered, members...)
+			continue
+		}
+		c := Cluster{Members: members, Size: len(members)}
+		bestDeg := -1.0
+		labelCounts := make(map[string]int)
+		for _, id := range members {
+			issue := issueMap[id]
+			if !issue.Status.IsClosed() {
+				c.OpenCount++
+			}
+			if d := degree(id); d > bestDeg {
+				bestDeg = d
+				c.Anchor = id
+			}
+			for _, l := range issue.Labels {
+				labelCounts[l]++
+			}
+		}
+		c.AnchorTitle = issueMap[c.Anchor].Title
+		c.Labels = topLabels(labelCounts, 3)
+		result.Clusters = append(result.Clusters, c)
+	}
+	sort.Strings(result.Unclustered)
+
+	sort.Slice(result.Clusters, func(i, j int) bool {
+		if result.Clusters[i].Size != result.Clusters[j].Size {
+			return result.Clusters[i].Size > result.Clusters[j].Size
+		}
+		return result.Clusters[i].Anchor < result.Clusters[j].Anchor
+	})
+	for i := range result.Clusters {
+		result.Clusters[i].ID = i + 1
+		for _, id := range result.Clusters[i].Members {
+			result.Assignments[id] = i + 1
+		}
+	}
+	result.ClusterCount = len(result.Clusters)
+
+	for edge := range edgeCount {
+		ca, cb := result.Assignments[edge[0]], result.Assignments[edge[1]]
+		if ca != 0 && ca == cb {
+			result.Clusters[ca-1].InternalEdges++
+			continue
+		}
+		if ca != 0 {
+			result.Clusters[ca-1].ExternalEdges++
+		}
+		if cb != 0 {
+			result.Clusters[cb-1].ExternalEdges++
+		}
+	}
+
+	result.Modularity = modularity(ids, adj, labels)
+	return result
+}
+
+// louvain partitions ids into communities and returns, for each index in ids,
+// the index of a representative member. It alternates local moving (each node
+// joins the neighbouring community with the best modularity gain) with
+// aggregation of communities into super-nodes until no node moves.
+func louvain(ids []string, adj map[string]map[string]float64, maxPasses int) ([]int, int, bool) {
+	n := len(ids)
+	index := make(map[string]int, n)
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	// Level graph: weights[i][j] between super-nodes, self-loops hold
+	// internal weight counted in both directions.
+	weights := make([]map[int]float64, n)
+	for i, id := range ids {
+		weights[i] = make(map[int]float64, len(adj[id]))
+		for nb, w := range adj[id] {
+			weights[i][index[nb]] += w
+		}
+	}
+	// members[s] lists original node indices inside super-node s.
+	members := make([][]int, n)
+	for i := range members {
+		members[i] = []int{i}
+	}
+
+	passes := 0
+	converged := true
+	for {
+		size := len(weights)
+		degree := make([]float64, size)
+		var m2 float64
+		for i, row := range weights {
+			for _, w := range row {
+				degree[i] += w
+			}
+			m2 += degree[i]
+		}
+		if m2 == 0 {
+			break
+		}
+
+		comm := make([]int, size)
+		tot := make([]float64, size)
+		for i := range comm {
+			comm[i] = i
+			tot[i] = degree[i]
+		}
+
+		moved := false
+		for {
+			if passes >= maxPasses {
+				converged = false
+				break
+			}
+			passes++
+			changed := false
+			for i := 0; i < size; i++ {
+				own := comm[i]
+				tot[own] -= degree[i]
+
+				linkTo := make(map[int]float64)
+				for j, w := range weights[i] {
+					if j != i {
+						linkTo[comm[j]] += w
+					}
+				}
+				candidates := make([]int, 0, len(linkTo))
+				for c := range linkTo {
+					candidates = append(candidates, c)
+				}
+				sort.Ints(candidates)
+
+				best := own
+				bestGain := linkTo[own] - tot[own]*degree[i]/m2
+				for _, c := range candidates {
+					if gain := linkTo[c] - tot[c]*degree[i]/m2; gain > bestGain+1e-12 {
+						best, bestGain = c, gain
+					}
+				}
+
+				comm[i] = best
+				tot[best] += degree[i]
+				if best != own {
+					changed = true
+					moved = true
+				}
+			}
+			if !changed {
+				break
+			}
+		}
+		if !moved || !converged {
+			members = aggregateMembers(members, comm)
+			break
+		}
+
+		// Aggregate communities into super-nodes for the next level.
+		renumber := make(map[int]int)
+		for i := 0; i < size; i++ {
+			if _, ok := renumber[comm[i]]; !ok {
+				renumber[comm[i]] = len(renumber)
+			}
+		}
+		next := make([]map[int]float64, len(renumber))
+		for i := range next {
+			next[i] = make(map[int]float64)
+		}
+		for i, row := range weights {
+			ci := renumber[comm[i]]
+			for j, w := range row {
+				next[ci][renumber[comm[j]]] += w
+			}
+		}
+		for i := range comm {
+			comm[i] = renumber[comm[i]]
+		}
+		members = aggregateMembers(members, comm)
+		weights = next
+	}
+
+	result := make([]int, n)
+	for _, group := range members {
+		rep := group[0]
+		for _, i := range group {
+			if i < rep {
+				rep = i
+			}
+		}
+		for _, i := range group {
+			result[i] = rep
+		}
+	}
+	return result, passes, converged
+}
+
+// aggregateMembers merges super-node member lists according to comm.
+func aggregateMembers(members [][]int, comm []int) [][]int {
+	merged := make(map[int][]int)
+	order := []int{}
+	for s, group := range members {
+		c := comm[s]
+		if _, ok := merged[c]; !ok {
+			order = append(order, c)
+		}
+		merged[c] = append(merged[c], group...)
+	}
+	out := make([][]int, 0, len(order))
+	for _, c := range order {
+		out = append(out, merged[c])
+	}
+	return out
+}
+
+// modularity computes Newman's weighted modularity Q for a label assignment.
+func modularity(ids []string, adj map[string]map[string]float64, labels map[string]string) float64 {
+	var twoM float64
+	internal := make(map[string]float64)
+	degreeSum := make(map[string]float64)
+	for _, id := range ids {
+		for nb, w := range adj[id] {
+			twoM += w
+			degreeSum[labels[id]] += w
+			if labels[nb] == labels[id] {
+				internal[labels[id]] += w
+			}
+		}
+	}
+	if twoM == 0 {
+		return 0
+	}
+	var q float64
+	for label, d := range degreeSum {
+		q += internal[label]/twoM - (d/twoM)*(d/twoM)
+	}
+	return q
+}
+
+// topLabels returns up to n labels by descending count, ties broken by name.
+func topLabels(counts map[string]int, n int) []string {
+	if len(counts) == 0 {
+		return nil
+	}
+	labels := make([]string, 0, len(counts))
+	for l := range counts {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if counts[labels[i]] != counts[labels[j]] {
+			return counts[labels[i]] > counts[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+	if len(labels) > n {
+		labels = labels[:n]
+	}
+	return labels
+}
@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildClusters_Empty(t *testing.T) {
+	result := BuildClusters(nil, DefaultClusterConfig())
+	if result.ClusterCount != 0 || !result.Converged {
+		t.Fatalf("expected empty converged result, got %+v", result)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), "null") {
+		t.Errorf("empty result should encode without nulls: %s", data)
+	}
+}
+
+func TestBuildClusters_SeparatesDenseGroups(t *testing.T) {
+	// Two triangles joined by a single bridge edge C->D.
+	issues := []model.Issue{
+		depIssue("A", "B", "C"),
+		depIssue("B", "C"),
+		depIssue("C", "D"),
+		depIssue("D", "E", "F"),
+		depIssue("E", "F"),
+		depIssue("F"),
+		depIssue("LONE"),
+	}
+	issues[0].Labels = []string{"api", "backend"}
+	issues[1].Labels = []string{"api"}
+
+	result := BuildClusters(issues, DefaultClusterConfig())
+	if !result.Converged {
+		t.Fatal("expected label propagation to converge")
+	}
+	if result.ClusterCount != 2 {
+		t.Fatalf("expected 2 clusters, got %d: %+v", result.ClusterCount, result.Clusters)
+	}
+	if result.ClusterOf("A") != result.ClusterOf("B") || result.ClusterOf("B") != result.ClusterOf("C") {
+		t.Errorf("A, B, C should share a cluster: %v", result.Assignments)
+	}
+	if result.ClusterOf("D") != result.ClusterOf("E") || result.ClusterOf("E") != result.ClusterOf("F") {
+		t.Errorf("D, E, F should share a cluster: %v", result.Assignments)
+	}
+	if result.ClusterOf("A") == result.ClusterOf("D") {
+		t.Error("triangles should land in different clusters")
+	}
+	if result.ClusterOf("LONE") != 0 || len(result.Unclustered) != 1 || result.Unclustered[0] != "LONE" {
+		t.Errorf("isolated issue should be unclustered, got %v", result.Unclustered)
+	}
+
+	for _, c := range result.Clusters {
+		if c.InternalEdges != 3 || c.ExternalEdges != 1 {
+			t.Errorf("cluster %d: internal=%d external=%d, want 3/1", c.ID, c.InternalEdges, c.ExternalEdges)
+		}
+	}
+	abc := result.Clusters[result.ClusterOf("A")-1]
+	if len(abc.Labels) == 0 || abc.Labels[0] != "api" {
+		t.Errorf("expected most common label first, got %v", abc.Labels)
+	}
+	if result.Modularity <= 0.3 {
+		t.Errorf("expected clearly positive modularity, got %.3f", result.Modularity)
+	}
+}
+
+func TestBuildClusters_ClosedAndNonBlockingEdges(t *testing.T) {
+	issues := []model.Issue{
+		depIssue("A", "B"),
+		depIssue("B"),
+		depIssue("X"),
+		depIssue("Y"),
+	}
+	issues[1].Status = model.StatusClosed
+	issues[2].Dependencies = []*model.Dependency{{IssueID: "X", DependsOnID: "Y", Type: model.DepRelated}}
+
+	result := BuildClusters(issues, DefaultClusterConfig())
+	if result.ClusterOf("A") != 0 {
+		t.Errorf("edge to closed issue should be ignored by default, got %v", result.Assignments)
+	}
+	if result.ClusterOf("X") == 0 || result.ClusterOf("X") != result.ClusterOf("Y") {
+		t.Errorf("related issues should cluster together, got %v", result.Assignments)
+	}
+
+	cfg := DefaultClusterConfig()
+	cfg.IncludeClosed = true
+	result = BuildClusters(issues, cfg)
+	c := result.ClusterOf("A")
+	if c == 0 || c != result.ClusterOf("B") {
+		t.Fatalf("IncludeClosed should keep the A-B edge, got %v", result.Assignments)
+	}
+	if result.Clusters[c-1].OpenCount != 1 {
+		t.Errorf("open_count = %d, want 1", result.Clusters[c-1].OpenCount)
+	}
+}
+
+func TestBuildClusters_Deterministic(t *testing.T) {
+	var issues []model.Issue
+	for i := 0; i < 30; i++ {
+		id := string(rune('a'+i%26)) + strings.Repeat("x", i/26)
+		var deps []string
+		if i > 0 {
+			deps = append(deps, string(rune('a'+(i-1)%26))+strings.Repeat("x", (i-1)/26))
+		}
+		issues = append(issues, depIssue(id, deps...))
+	}
+
+	first := BuildClusters(issues, DefaultClusterConfig())
+	for i := 0; i < 5; i++ {
+		again := BuildClusters(issues, DefaultClusterConfig())
+		for id, c := range first.Assignments {
+			if again.Assignments[id] != c {
+				t.Fatalf("run %d: assignment for %s changed %d -> %d", i, id, c, again.Assignments[id])
+			}
+		}
+	}
+}
+
+func TestGenerateRobotClustersOutput(t *testing.T) {
+	issues := []model.Issue{depIssue("A", "B"), depIssue("B")}
+	out := GenerateRobotClustersOutput(issues, DefaultClusterConfig(), "hash123")
+	if out.DataHash != "hash123" || out.GeneratedAt == "" {
+		t.Errorf("missing metadata: %+v", out)
+	}
+	if out.ClusterCount != 1 || out.Clusters[0].Size != 2 {
+		t.Errorf("expected one cluster of size 2, got %+v", out.Clusters)
+	}
+	if len(out.UsageHints) == 0 {
+		t.Error("expected usage hints")
+	}
+}
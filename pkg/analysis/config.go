@@ -1,6 +1,9 @@
 package analysis
 
-import "time"
+import (
+	"runtime"
+	"time"
+)
 
 // AnalysisConfig controls which metrics to compute and their timeouts.
 // This enables size-based algorithm selection for optimal performance.
@@ -12,6 +15,8 @@ type AnalysisConfig struct {
 	BetweennessMode        BetweennessMode // "exact", "approximate", or "skip"
 	BetweennessSampleSize  int             // Sample size for approximate mode
 	BetweennessIsApproximate bool          // True if approximation was used (set after computation)
+	BetweennessAdaptive    bool            // Grow the sample until rankings stabilize (approximate mode only)
+	BetweennessMaxSampleSize int           // Cap for adaptive sampling (0 = node count)
 
 	// PageRank
 	ComputePageRank    bool
@@ -34,6 +39,52 @@ type AnalysisConfig struct {
 
 	// Critical path scoring (fast, O(V+E))
 	ComputeCriticalPath bool
+
+	// Phase2Workers bounds how many Phase 2 metrics run concurrently.
+	// 0 means use GOMAXPROCS; 1 restores fully sequential computation.
+	Phase2Workers int
+}
+
+// phase2Workers returns the worker pool size for the given number of tasks.
+func (c AnalysisConfig) phase2Workers(tasks int) int {
+	workers := c.Phase2Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > tasks {
+		workers = tasks
+	}
+	return workers
+}
+
+// phase2Deadline returns the shared deadline for all Phase 2 workers: the
+// longest enabled metric timeout for each round of work the pool must run
+// (one round when every metric gets its own worker), plus a small grace period.
+func (c AnalysisConfig) phase2Deadline(workers int) time.Duration {
+	longest := time.Duration(0)
+	timed := 0
+	for _, m := range []struct {
+		enabled bool
+		timeout time.Duration
+	}{
+		{c.ComputePageRank, c.PageRankTimeout},
+		{c.ComputeBetweenness, c.BetweennessTimeout},
+		{c.ComputeHITS, c.HITSTimeout},
+		{c.ComputeCycles, c.CyclesTimeout},
+	} {
+		if m.enabled {
+			timed++
+			longest = max(longest, m.timeout)
+		}
+	}
+	if longest <= 0 {
+		longest = 2 * time.Second
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	rounds := max(1, (timed+workers-1)/workers)
+	return time.Duration(rounds)*longest + 100*time.Millisecond
 }
 
 // DefaultConfig returns the default analysis configuration.
@@ -138,6 +189,7 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 			cfg.ComputeBetweenness = true
 			cfg.BetweennessMode = BetweennessApproximate
 			cfg.BetweennessSampleSize = RecommendSampleSize(nodeCount, edgeCount)
+			cfg.BetweennessAdaptive = true
 			cfg.BetweennessTimeout = 500 * time.Millisecond // More time for sampling
 		} else {
 			cfg.ComputeBetweenness = false
@@ -154,6 +206,7 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 			ComputeBetweenness:    true,
 			BetweennessMode:       BetweennessApproximate,
 			BetweennessSampleSize: RecommendSampleSize(nodeCount, edgeCount),
+			BetweennessAdaptive:   true,
 			BetweennessTimeout:    500 * time.Millisecond,
 
 			ComputePageRank: true,
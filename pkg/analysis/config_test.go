@@ -214,3 +214,29 @@ func TestFullAnalysisConfig(t *testing.T) {
 		t.Errorf("Expected high max cycles in full config, got %d", cfg.MaxCyclesToStore)
 	}
 }
+
+func TestPhase2WorkersAndDeadline(t *testing.T) {
+	cfg := DefaultConfig()
+
+	cfg.Phase2Workers = 4
+	if got := cfg.phase2Workers(6); got != 4 {
+		t.Errorf("phase2Workers(6) = %d, want 4", got)
+	}
+	if got := cfg.phase2Workers(2); got != 2 {
+		t.Errorf("phase2Workers should not exceed task count, got %d", got)
+	}
+	cfg.Phase2Workers = 0
+	if got := cfg.phase2Workers(100); got < 1 {
+		t.Errorf("default phase2Workers should be at least 1, got %d", got)
+	}
+
+	// Four timed metrics at 500ms: one round when parallel, four when sequential.
+	parallel := cfg.phase2Deadline(4)
+	sequential := cfg.phase2Deadline(1)
+	if parallel < 500*time.Millisecond || parallel >= time.Second {
+		t.Errorf("parallel deadline = %v, want ~500ms", parallel)
+	}
+	if sequential < 2*time.Second {
+		t.Errorf("sequential deadline = %v, want >= 2s", sequential)
+	}
+}
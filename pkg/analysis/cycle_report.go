@@ -0,0 +1,528 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// CycleReportConfig configures --robot-cycles output.
+type CycleReportConfig struct {
+	// MaxCyclesPerComponent caps elementary cycles enumerated inside a single
+	// strongly connected component. Dense SCCs can contain exponentially many
+	// cycles, so enumeration stops once this many have been found.
+	// Default: 20
+	MaxCyclesPerComponent int
+
+	// MaxSearchSteps bounds the DFS work spent enumerating cycles per component.
+	// Default: 50000
+	MaxSearchSteps int
+}
+
+// DefaultCycleReportConfig returns sensible defaults.
+func DefaultCycleReportConfig() CycleReportConfig {
+	return CycleReportConfig{
+		MaxCyclesPerComponent: 20,
+		MaxSearchSteps:        50000,
+	}
+}
+
+// CycleEdge is a blocking dependency edge that participates in a cycle.
+// From depends on To (i.e. To blocks From), matching `bd dep add From To`.
+type CycleEdge struct {
+	From      string     `json:"from"`
+	To        string     `json:"to"`
+	Type      string     `json:"type"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty"`
+}
+
+// CycleDetail is one elementary dependency cycle.
+type CycleDetail struct {
+	Index     int         `json:"index"`
+	Component int         `json:"component"` // Index into CycleReport.Components
+	Members   []string    `json:"members"`   // Cycle path without the closing node
+	Length    int         `json:"length"`
+	Edges     []CycleEdge `json:"edges"`
+}
+
+// CycleComponent is a strongly connected component containing at least one cycle.
+type CycleComponent struct {
+	Index         int      `json:"index"`
+	Members       []string `json:"members"`
+	EdgeCount     int      `json:"edge_count"`
+	CycleCount    int      `json:"cycle_count"`              // Cycles enumerated for this component
+	Truncated     bool     `json:"truncated,omitempty"`      // Enumeration hit MaxCyclesPerComponent/MaxSearchSteps
+	FeedbackEdges int      `json:"feedback_edges,omitempty"` // Edges in the suggested removal set
+}
+
+// FeedbackArcEdge is an edge suggested for removal to make the graph acyclic.
+type FeedbackArcEdge struct {
+	CycleEdge
+	FromTitle    string `json:"from_title,omitempty"`
+	ToTitle      string `json:"to_title,omitempty"`
+	Component    int    `json:"component"`
+	BreaksCycles []int  `json:"breaks_cycles"` // Indices into CycleReport.Cycles containing this edge
+	Collateral   int    `json:"collateral"`    // Other issues blocked by To (dependents beyond From)
+	Command      string `json:"command"`
+}
+
+// CycleReport lists every cyclic component, its enumerated cycles and a
+// suggested minimal edge set whose removal leaves the dependency graph acyclic.
+type CycleReport struct {
+	HasCycles      bool              `json:"has_cycles"`
+	ComponentCount int               `json:"component_count"`
+	CycleCount     int               `json:"cycle_count"`
+	Truncated      bool              `json:"truncated"`
+	Components     []CycleComponent  `json:"components"`
+	Cycles         []CycleDetail     `json:"cycles"`
+	FeedbackArcSet []FeedbackArcEdge `json:"feedback_arc_set"`
+	AcyclicAfter   bool              `json:"acyclic_after"` // Verified: removing FeedbackArcSet breaks every cycle
+	Method         string            `json:"method"`
+}
+
+// RobotCyclesOutput is the JSON output structure for --robot-cycles.
+type RobotCyclesOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	CycleReport
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotCyclesOutput creates the full robot-cycles output.
+func GenerateRobotCyclesOutput(issues []model.Issue, config CycleReportConfig, dataHash string) RobotCyclesOutput {
+	return RobotCyclesOutput{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		CycleReport: BuildCycleReport(issues, config),
+		UsageHints: []string{
+			"jq '.feedback_arc_set[].command' - Commands that break every cycle",
+			"jq '.cycles[] | .members' - Member IDs of each cycle",
+			"jq '.components[] | select(.truncated)' - Components with too many cycles to enumerate",
+			"jq '.feedback_arc_set | length' - Minimum edges to remove (heuristic)",
+		},
+	}
+}
+
+// BuildCycleReport detects dependency cycles among blocking edges and proposes
+// a feedback arc set using the Eades-Lin-Smyth ordering heuristic, followed by
+// a pruning pass that restores any edge not needed to keep the graph acyclic.
+func BuildCycleReport(issues []model.Issue, config CycleReportConfig) CycleReport {
+	if config.MaxCyclesPerComponent <= 0 {
+		config.MaxCyclesPerComponent = DefaultCycleReportConfig().MaxCyclesPerComponent
+	}
+	if config.MaxSearchSteps <= 0 {
+		config.MaxSearchSteps = DefaultCycleReportConfig().MaxSearchSteps
+	}
+
+	report := CycleReport{
+		Components:     []CycleComponent{},
+		Cycles:         []CycleDetail{},
+		FeedbackArcSet: []FeedbackArcEdge{},
+		AcyclicAfter:   true,
+		Method:         "eades-lin-smyth+prune",
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+
+	// Forward adjacency over blocking edges: adj[a] contains b when a depends on b.
+	adj := make(map[string][]string)
+	meta := make(map[[2]string]CycleEdge)
+	dependents := make(map[string]int)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; !ok {
+				continue
+			}
+			key := [2]string{issue.ID, dep.DependsOnID}
+			if _, dup := meta[key]; dup {
+				continue
+			}
+			edge := CycleEdge{From: issue.ID, To: dep.DependsOnID, Type: string(dep.Type), CreatedBy: dep.CreatedBy}
+			if edge.Type == "" {
+				edge.Type = string(model.DepBlocks)
+			}
+			if !dep.CreatedAt.IsZero() {
+				t := dep.CreatedAt
+				edge.CreatedAt = &t
+			}
+			meta[key] = edge
+			adj[issue.ID] = append(adj[issue.ID], dep.DependsOnID)
+			dependents[dep.DependsOnID]++
+		}
+	}
+	for k := range adj {
+		sort.Strings(adj[k])
+	}
+
+	ids := make([]string, 0, len(issueMap))
+	for id := range issueMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, scc := range stronglyConnected(ids, adj) {
+		inSCC := make(map[string]bool, len(scc))
+		for _, id := range scc {
+			inSCC[id] = true
+		}
+		edgeCount := 0
+		for _, u := range scc {
+			for _, v := range adj[u] {
+				if inSCC[v] {
+					edgeCount++
+				}
+			}
+		}
+		// Singletons only matter if they have a self-loop.
+		if len(scc) == 1 && edgeCount == 0 {
+			continue
+		}
+
+		compIdx := len(report.Components)
+		comp := CycleComponent{Index: compIdx, Members: scc, EdgeCount: edgeCount}
+
+		cycles, truncated := enumerateCycles(scc, inSCC, adj, config.MaxCyclesPerComponent, config.MaxSearchSteps)
+		comp.CycleCount = len(cycles)
+		comp.Truncated = truncated
+		if truncated {
+			report.Truncated = true
+		}
+
+		firstCycle := len(report.Cycles)
+		for _, members := range cycles {
+			detail := CycleDetail{
+				Index:     len(report.Cycles),
+				Component: compIdx,
+				Members:   members,
+				Length:    len(members),
+			}
+			for i := range members {
+				from := members[i]
+				to := members[(i+1)%len(members)]
+				detail.Edges = append(detail.Edges, meta[[2]string{from, to}])
+			}
+			report.Cycles = append(report.Cycles, detail)
+		}
+
+		for _, key := range feedbackArcSet(scc, inSCC, adj) {
+			fa := FeedbackArcEdge{
+				CycleEdge:  meta[key],
+				FromTitle:  issueMap[key[0]].Title,
+				ToTitle:    issueMap[key[1]].Title,
+				Component:  compIdx,
+				Collateral: dependents[key[1]] - 1,
+				Command:    fmt.Sprintf("bd dep remove %s %s", key[0], key[1]),
+			}
+			for _, c := range report.Cycles[firstCycle:] {
+				for _, e := range c.Edges {
+					if e.From == key[0] && e.To == key[1] {
+						fa.BreaksCycles = append(fa.BreaksCycles, c.Index)
+						break
+					}
+				}
+			}
+			if fa.BreaksCycles == nil {
+				fa.BreaksCycles = []int{}
+			}
+			report.FeedbackArcSet = append(report.FeedbackArcSet, fa)
+			comp.FeedbackEdges++
+		}
+
+		report.Components = append(report.Components, comp)
+	}
+
+	report.ComponentCount = len(report.Components)
+	report.CycleCount = len(report.Cycles)
+	report.HasCycles = report.ComponentCount > 0
+
+	if report.HasCycles {
+		removed := make(map[[2]string]bool, len(report.FeedbackArcSet))
+		for _, fa := range report.FeedbackArcSet {
+			removed[[2]string{fa.From, fa.To}] = true
+		}
+		report.AcyclicAfter = isAcyclicWithout(ids, adj, removed)
+	}
+
+	return report
+}
+
+// stronglyConnected returns the SCCs of the graph using Tarjan's algorithm.
+// Members are sorted and components are ordered by their smallest member ID.
+func stronglyConnected(ids []string, adj map[string][]string) [][]string {
+	index := 0
+	indices := make(map[string]int, len(ids))
+	lowlink := make(map[string]int, len(ids))
+	onStack := make(map[string]bool, len(ids))
+	var stack []string
+	var sccs [][]string
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, seen := indices[w]; !seen {
+				strongConnect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var scc []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sort.Strings(scc)
+			sccs = append(sccs, scc)
+		}
+	}
+
+	for _, id := range ids {
+		if _, seen := indices[id]; !seen {
+			strongConnect(id)
+		}
+	}
+
+	sort.Slice(sccs, func(i, j int) bool { return sccs[i][0] < sccs[j][0] })
+	return sccs
+}
+
+// enumerateCycles lists elementary cycles inside one SCC. Each cycle starts at
+// its smallest member and only visits larger members, so every cycle is found
+// exactly once. Returns truncated=true when limit or step budget is exhausted.
+func enumerateCycles(scc []string, inSCC map[string]bool, adj map[string][]string, limit, maxSteps int) ([][]string, bool) {
+	var cycles [][]string
+	steps := 0
+	truncated := false
+
+	for _, start := range scc {
+		var path []string
+		onPath := make(map[string]bool)
+
+		var dfs func(u string) bool
+		dfs = func(u string) bool {
+			steps++
+			if steps > maxSteps || len(cycles) >= limit {
+				truncated = true
+				return false
+			}
+			path = append(path, u)
+			onPath[u] = true
+			for _, v := range adj[u] {
+				if !inSCC[v] || v < start {
+					continue
+				}
+				if v == start {
+					cycle := make([]string, len(path))
+					copy(cycle, path)
+					cycles = append(cycles, cycle)
+					if len(cycles) >= limit {
+						truncated = true
+						return false
+					}
+					continue
+				}
+				if onPath[v] {
+					continue
+				}
+				if !dfs(v) {
+					return false
+				}
+			}
+			path = path[:len(path)-1]
+			onPath[u] = false
+			return true
+		}
+
+		if !dfs(start) {
+			break
+		}
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool { return len(cycles[i]) < len(cycles[j]) })
+	return cycles, truncated
+}
+
+// feedbackArcSet returns edges within an SCC whose removal leaves it acyclic.
+// Nodes are linearly ordered with the Eades-Lin-Smyth greedy heuristic (peel
+// sinks and sources, otherwise take the node with the largest out-in degree
+// difference); edges pointing backwards in that order form the initial set.
+// Each candidate is then re-added if doing so does not reintroduce a cycle.
+func feedbackArcSet(scc []string, inSCC map[string]bool, adj map[string][]string) [][2]string {
+	out := make(map[string]map[string]bool, len(scc))
+	in := make(map[string]map[string]bool, len(scc))
+	for _, u := range scc {
+		out[u] = make(map[string]bool)
+		if in[u] == nil {
+			in[u] = make(map[string]bool)
+		}
+	}
+	for _, u := range scc {
+		for _, v := range adj[u] {
+			if !inSCC[v] || u == v {
+				continue
+			}
+			out[u][v] = true
+			in[v][u] = true
+		}
+	}
+
+	remaining := make(map[string]bool, len(scc))
+	for _, u := range scc {
+		remaining[u] = true
+	}
+	remove := func(u string) {
+		delete(remaining, u)
+		for v := range out[u] {
+			delete(in[v], u)
+		}
+		for v := range in[u] {
+			delete(out[v], u)
+		}
+	}
+
+	var head, tail []string
+	for len(remaining) > 0 {
+		progressed := true
+		for progressed {
+			progressed = false
+			for _, u := range scc {
+				if remaining[u] && len(out[u]) == 0 {
+					tail = append([]string{u}, tail...)
+					remove(u)
+					progressed = true
+				}
+			}
+			for _, u := range scc {
+				if remaining[u] && len(in[u]) == 0 {
+					head = append(head, u)
+					remove(u)
+					progressed = true
+				}
+			}
+		}
+		if len(remaining) == 0 {
+			break
+		}
+		best := ""
+		bestDelta := 0
+		for _, u := range scc {
+			if !remaining[u] {
+				continue
+			}
+			delta := len(out[u]) - len(in[u])
+			if best == "" || delta > bestDelta {
+				best, bestDelta = u, delta
+			}
+		}
+		head = append(head, best)
+		remove(best)
+	}
+
+	order := append(head, tail...)
+	pos := make(map[string]int, len(order))
+	for i, u := range order {
+		pos[u] = i
+	}
+
+	var candidates [][2]string
+	kept := make(map[string][]string, len(scc))
+	for _, u := range scc {
+		for _, v := range adj[u] {
+			if !inSCC[v] {
+				continue
+			}
+			if u == v || pos[u] >= pos[v] {
+				candidates = append(candidates, [2]string{u, v})
+			} else {
+				kept[u] = append(kept[u], v)
+			}
+		}
+	}
+
+	// Prune: restore any candidate edge that does not close a cycle.
+	var result [][2]string
+	for _, e := range candidates {
+		if e[0] != e[1] && !reachable(kept, e[1], e[0]) {
+			kept[e[0]] = append(kept[e[0]], e[1])
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// reachable reports whether target can be reached from source in adj.
+func reachable(adj map[string][]string, source, target string) bool {
+	seen := map[string]bool{source: true}
+	queue := []string{source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		if u == target {
+			return true
+		}
+		for _, v := range adj[u] {
+			if !seen[v] {
+				seen[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return false
+}
+
+// isAcyclicWithout reports whether the graph has no cycles once removed edges are dropped.
+func isAcyclicWithout(ids []string, adj map[string][]string, removed map[[2]string]bool) bool {
+	indegree := make(map[string]int, len(ids))
+	for _, u := range ids {
+		for _, v := range adj[u] {
+			if !removed[[2]string{u, v}] {
+				indegree[v]++
+			}
+		}
+	}
+	var queue []string
+	for _, id := range ids {
+		if indegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, v := range adj[u] {
+			if removed[[2]string{u, v}] {
+				continue
+			}
+			indegree[v]--
+			if indegree[v] == 0 {
+				queue = append(queue, v)
+			}
+		}
+	}
+	return visited == len(ids)
+}
@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+func depIssue(id string, deps ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: "Issue " + id, Status: model.StatusOpen, IssueType: model.TypeTask}
+	for _, d := range deps {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{
+			IssueID:     id,
+			DependsOnID: d,
+			Type:        model.DepBlocks,
+		})
+	}
+	return issue
+}
+
+func TestBuildCycleReport_NoCycles(t *testing.T) {
+	report := BuildCycleReport(testutil.QuickChain(5), DefaultCycleReportConfig())
+	if report.HasCycles {
+		t.Fatalf("expected no cycles for chain, got %+v", report)
+	}
+	if !report.AcyclicAfter {
+		t.Error("acyclic graph should report acyclic_after=true")
+	}
+	if report.Cycles == nil || report.FeedbackArcSet == nil || report.Components == nil {
+		t.Error("slices should be non-nil for stable JSON output")
+	}
+}
+
+func TestBuildCycleReport_SimpleCycle(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	issues := []model.Issue{
+		depIssue("A", "B"),
+		depIssue("B", "C"),
+		depIssue("C", "A"),
+	}
+	issues[2].Dependencies[0].CreatedAt = created
+	issues[2].Dependencies[0].CreatedBy = "alice"
+
+	report := BuildCycleReport(issues, DefaultCycleReportConfig())
+	if !report.HasCycles || report.ComponentCount != 1 || report.CycleCount != 1 {
+		t.Fatalf("expected 1 component with 1 cycle, got %+v", report)
+	}
+
+	cycle := report.Cycles[0]
+	if strings.Join(cycle.Members, ",") != "A,B,C" {
+		t.Errorf("members = %v, want [A B C]", cycle.Members)
+	}
+	if len(cycle.Edges) != 3 {
+		t.Fatalf("expected 3 edges, got %d", len(cycle.Edges))
+	}
+	last := cycle.Edges[2]
+	if last.From != "C" || last.To != "A" || last.CreatedBy != "alice" || last.CreatedAt == nil || !last.CreatedAt.Equal(created) {
+		t.Errorf("edge metadata not carried through: %+v", last)
+	}
+
+	if len(report.FeedbackArcSet) != 1 {
+		t.Fatalf("expected a single edge to break a 3-cycle, got %d", len(report.FeedbackArcSet))
+	}
+	fa := report.FeedbackArcSet[0]
+	if !strings.HasPrefix(fa.Command, "bd dep remove ") {
+		t.Errorf("unexpected command %q", fa.Command)
+	}
+	if len(fa.BreaksCycles) != 1 || fa.BreaksCycles[0] != 0 {
+		t.Errorf("expected edge to break cycle 0, got %v", fa.BreaksCycles)
+	}
+	if !report.AcyclicAfter {
+		t.Error("removing the feedback arc set should leave the graph acyclic")
+	}
+}
+
+func TestBuildCycleReport_SharedEdgePreferred(t *testing.T) {
+	// Two cycles share edge B->A: A->B->A and A->C->B->A.
+	issues := []model.Issue{
+		depIssue("A", "B", "C"),
+		depIssue("B", "A"),
+		depIssue("C", "B"),
+	}
+
+	report := BuildCycleReport(issues, DefaultCycleReportConfig())
+	if report.CycleCount != 2 {
+		t.Fatalf("expected 2 cycles, got %d: %+v", report.CycleCount, report.Cycles)
+	}
+	if len(report.FeedbackArcSet) != 1 {
+		t.Fatalf("expected a single shared edge to break both cycles, got %+v", report.FeedbackArcSet)
+	}
+	if got := len(report.FeedbackArcSet[0].BreaksCycles); got != 2 {
+		t.Errorf("expected the suggested edge to break 2 cycles, got %d", got)
+	}
+	if !report.AcyclicAfter {
+		t.Error("expected acyclic_after=true")
+	}
+}
+
+func TestBuildCycleReport_SelfLoopAndIgnoredRelated(t *testing.T) {
+	self := depIssue("S", "S")
+	related := depIssue("R1", "R2")
+	related2 := depIssue("R2")
+	related2.Dependencies = []*model.Dependency{{IssueID: "R2", DependsOnID: "R1", Type: model.DepRelated}}
+
+	report := BuildCycleReport([]model.Issue{self, related, related2}, DefaultCycleReportConfig())
+	if report.ComponentCount != 1 {
+		t.Fatalf("expected only the self-loop component, got %+v", report.Components)
+	}
+	if len(report.FeedbackArcSet) != 1 || report.FeedbackArcSet[0].From != "S" || report.FeedbackArcSet[0].To != "S" {
+		t.Errorf("expected self-loop removal suggestion, got %+v", report.FeedbackArcSet)
+	}
+	if !report.AcyclicAfter {
+		t.Error("expected acyclic_after=true")
+	}
+}
+
+func TestBuildCycleReport_TruncatesDenseComponents(t *testing.T) {
+	// Complete digraph on 6 nodes has hundreds of elementary cycles.
+	ids := []string{"N1", "N2", "N3", "N4", "N5", "N6"}
+	var issues []model.Issue
+	for _, id := range ids {
+		var deps []string
+		for _, other := range ids {
+			if other != id {
+				deps = append(deps, other)
+			}
+		}
+		issues = append(issues, depIssue(id, deps...))
+	}
+
+	cfg := DefaultCycleReportConfig()
+	cfg.MaxCyclesPerComponent = 5
+	report := BuildCycleReport(issues, cfg)
+	if !report.Truncated || !report.Components[0].Truncated {
+		t.Error("expected truncation flag for dense component")
+	}
+	if report.CycleCount != 5 {
+		t.Errorf("expected cycle enumeration capped at 5, got %d", report.CycleCount)
+	}
+	if !report.AcyclicAfter {
+		t.Error("feedback arc set must still break every cycle when enumeration is truncated")
+	}
+}
+
+func TestGenerateRobotCyclesOutput(t *testing.T) {
+	out := GenerateRobotCyclesOutput(testutil.QuickCycle(4), DefaultCycleReportConfig(), "hash123")
+	if out.DataHash != "hash123" || out.GeneratedAt == "" {
+		t.Errorf("missing metadata: %+v", out)
+	}
+	if !out.HasCycles || len(out.FeedbackArcSet) == 0 {
+		t.Error("expected cycles and fix suggestions for cycle fixture")
+	}
+	if len(out.UsageHints) == 0 {
+		t.Error("expected usage hints")
+	}
+}
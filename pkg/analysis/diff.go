@@ -82,6 +82,11 @@ type SnapshotDiff struct {
 	ReopenedIssues []model.Issue   `json:"reopened_issues"` // Status changed from closed to open
 	ModifiedIssues []ModifiedIssue `json:"modified_issues"` // Changed between snapshots
 
+	// Structured views of common changes (issues present in both snapshots)
+	StatusChanges     []ValueChange      `json:"status_changes"`
+	PriorityChanges   []ValueChange      `json:"priority_changes"`
+	DependencyChanges []DependencyChange `json:"dependency_changes"`
+
 	// Graph changes
 	NewCycles      [][]string `json:"new_cycles"`      // Cycles appearing in To
 	ResolvedCycles [][]string `json:"resolved_cycles"` // Cycles resolved (were in From, not in To)
@@ -109,6 +114,23 @@ type FieldChange struct {
 	NewValue string `json:"new_value"`
 }
 
+// ValueChange records one field transition for an issue
+type ValueChange struct {
+	IssueID  string `json:"issue_id"`
+	Title    string `json:"title"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// DependencyChange records a dependency edge added to or removed from an issue.
+// A type change (e.g. related -> blocks) appears as a removal plus an addition.
+type DependencyChange struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"`
+	Change      string `json:"change"` // "added" or "removed"
+}
+
 // MetricDeltas tracks changes in key metrics
 type MetricDeltas struct {
 	TotalIssues    int     `json:"total_issues"`
@@ -132,6 +154,8 @@ type DiffSummary struct {
 	IssuesModified   int    `json:"issues_modified"`
 	CyclesIntroduced int    `json:"cycles_introduced"`
 	CyclesResolved   int    `json:"cycles_resolved"`
+	DepsAdded        int    `json:"dependencies_added"`
+	DepsRemoved      int    `json:"dependencies_removed"`
 	NetIssueChange   int    `json:"net_issue_change"`
 	HealthTrend      string `json:"health_trend"` // "improving", "degrading", "stable"
 }
@@ -139,10 +163,13 @@ type DiffSummary struct {
 // CompareSnapshots computes the diff between two snapshots
 func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 	diff := &SnapshotDiff{
-		FromTimestamp: from.Timestamp,
-		ToTimestamp:   to.Timestamp,
-		FromRevision:  from.Revision,
-		ToRevision:    to.Revision,
+		FromTimestamp:     from.Timestamp,
+		ToTimestamp:       to.Timestamp,
+		FromRevision:      from.Revision,
+		ToRevision:        to.Revision,
+		StatusChanges:     []ValueChange{},
+		PriorityChanges:   []ValueChange{},
+		DependencyChanges: []DependencyChange{},
 	}
 
 	// Build issue maps for quick lookup
@@ -166,6 +193,17 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 
 		// Compute full change set once to reuse below.
 		changes := detectChanges(fromIssue, toIssue)
+		for _, change := range changes {
+			vc := ValueChange{IssueID: id, Title: toIssue.Title, OldValue: change.OldValue, NewValue: change.NewValue}
+			switch change.Field {
+			case "status":
+				diff.StatusChanges = append(diff.StatusChanges, vc)
+			case "priority":
+				diff.PriorityChanges = append(diff.PriorityChanges, vc)
+			case "dependencies":
+				diff.DependencyChanges = append(diff.DependencyChanges, dependencyChanges(id, fromIssue.Dependencies, toIssue.Dependencies)...)
+			}
+		}
 
 		// Check for status changes
 		isStatusChange := false
@@ -231,10 +269,54 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 	sortIssuesByID(diff.RemovedIssues)
 	sortIssuesByID(diff.ReopenedIssues)
 	sortModifiedByID(diff.ModifiedIssues)
+	sortValueChangesByID(diff.StatusChanges)
+	sortValueChangesByID(diff.PriorityChanges)
+	sort.Slice(diff.DependencyChanges, func(i, j int) bool {
+		a, b := diff.DependencyChanges[i], diff.DependencyChanges[j]
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		if a.DependsOnID != b.DependsOnID {
+			return a.DependsOnID < b.DependsOnID
+		}
+		if a.Change != b.Change {
+			return a.Change > b.Change // "removed" before "added"
+		}
+		return a.Type < b.Type
+	})
 
 	return diff
 }
 
+// dependencyChanges lists edges added or removed between two dependency lists
+func dependencyChanges(issueID string, from, to []*model.Dependency) []DependencyChange {
+	type edge struct{ dependsOn, depType string }
+	collect := func(deps []*model.Dependency) map[edge]bool {
+		set := make(map[edge]bool)
+		for _, dep := range deps {
+			if dep == nil || dep.DependsOnID == "" {
+				continue
+			}
+			set[edge{dep.DependsOnID, string(dep.Type)}] = true
+		}
+		return set
+	}
+	fromSet, toSet := collect(from), collect(to)
+
+	var changes []DependencyChange
+	for e := range toSet {
+		if !fromSet[e] {
+			changes = append(changes, DependencyChange{IssueID: issueID, DependsOnID: e.dependsOn, Type: e.depType, Change: "added"})
+		}
+	}
+	for e := range fromSet {
+		if !toSet[e] {
+			changes = append(changes, DependencyChange{IssueID: issueID, DependsOnID: e.dependsOn, Type: e.depType, Change: "removed"})
+		}
+	}
+	return changes
+}
+
 // detectChanges identifies what fields changed between two issues
 func detectChanges(from, to model.Issue) []FieldChange {
 	var changes []FieldChange
@@ -482,6 +564,13 @@ func calculateSummary(diff *SnapshotDiff) DiffSummary {
 		CyclesIntroduced: len(diff.NewCycles),
 		CyclesResolved:   len(diff.ResolvedCycles),
 	}
+	for _, dc := range diff.DependencyChanges {
+		if dc.Change == "added" {
+			summary.DepsAdded++
+		} else {
+			summary.DepsRemoved++
+		}
+	}
 
 	summary.TotalChanges = summary.IssuesAdded + summary.IssuesClosed +
 		summary.IssuesRemoved + summary.IssuesReopened + summary.IssuesModified
@@ -602,6 +691,12 @@ func sortIssuesByID(issues []model.Issue) {
 	})
 }
 
+func sortValueChangesByID(changes []ValueChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].IssueID < changes[j].IssueID
+	})
+}
+
 func sortModifiedByID(modified []ModifiedIssue) {
 	sort.Slice(modified, func(i, j int) bool {
 		return modified[i].IssueID < modified[j].IssueID
@@ -415,3 +415,47 @@ func TestCompareSnapshots_DependencyTypeChange(t *testing.T) {
 		t.Error("expected dependency change to be detected when Type changes from related to blocks")
 	}
 }
+
+func TestCompareSnapshots_StructuredChanges(t *testing.T) {
+	fromIssues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Priority: 2, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepRelated},
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Priority: 1},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 1},
+	}
+	toIssues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusInProgress, Priority: 0, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Title: "B", Status: model.StatusClosed, Priority: 1},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 1},
+	}
+
+	diff := CompareSnapshots(NewSnapshot(fromIssues), NewSnapshot(toIssues))
+
+	if len(diff.StatusChanges) != 2 || diff.StatusChanges[0].IssueID != "A" || diff.StatusChanges[1].NewValue != "closed" {
+		t.Errorf("unexpected status changes: %+v", diff.StatusChanges)
+	}
+	if len(diff.PriorityChanges) != 1 || diff.PriorityChanges[0].OldValue != "P2" || diff.PriorityChanges[0].NewValue != "P0" {
+		t.Errorf("unexpected priority changes: %+v", diff.PriorityChanges)
+	}
+
+	want := []DependencyChange{
+		{IssueID: "A", DependsOnID: "B", Type: "related", Change: "removed"},
+		{IssueID: "A", DependsOnID: "B", Type: "blocks", Change: "added"},
+		{IssueID: "A", DependsOnID: "C", Type: "blocks", Change: "removed"},
+	}
+	if len(diff.DependencyChanges) != len(want) {
+		t.Fatalf("expected %d dependency changes, got %+v", len(want), diff.DependencyChanges)
+	}
+	for i, w := range want {
+		if diff.DependencyChanges[i] != w {
+			t.Errorf("dependency change %d = %+v, want %+v", i, diff.DependencyChanges[i], w)
+		}
+	}
+	if diff.Summary.DepsAdded != 1 || diff.Summary.DepsRemoved != 2 {
+		t.Errorf("summary deps added/removed = %d/%d, want 1/2", diff.Summary.DepsAdded, diff.Summary.DepsRemoved)
+	}
+}
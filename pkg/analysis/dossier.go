@@ -0,0 +1,240 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DossierIssue is an issue upstream or downstream of the dossier's subject.
+type DossierIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Depth    int    `json:"depth"` // 1 = direct, 2 = through one other issue, ...
+}
+
+// CriticalPathMembership places an issue on the longest chain of open
+// blocking dependencies.
+type CriticalPathMembership struct {
+	OnCriticalPath bool `json:"on_critical_path"` // The longest chain through the issue is a longest chain overall
+	ProjectLength  int  `json:"project_length"`   // Issues on the project's longest chain
+	// Chain is the longest chain through the issue, first blocker first
+	Chain    []string `json:"chain"`
+	Position int      `json:"position"` // 1-based index of the issue in Chain
+}
+
+// ImpactDossier gathers everything about one issue's place in the
+// dependency graph, for design docs and PR descriptions.
+type ImpactDossier struct {
+	IssueID   string `json:"issue_id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Priority  int    `json:"priority"`
+	IssueType string `json:"issue_type"`
+	Assignee  string `json:"assignee,omitempty"`
+
+	// Open issues that must close first, and open issues waiting on this one
+	DirectBlockers     []DossierIssue `json:"direct_blockers"`
+	TransitiveBlockers []DossierIssue `json:"transitive_blockers"` // Depth 2 and beyond
+	DirectUnblocks     []DossierIssue `json:"direct_unblocks"`
+	TransitiveUnblocks []DossierIssue `json:"transitive_unblocks"` // Depth 2 and beyond
+
+	CriticalPath CriticalPathMembership `json:"critical_path"`
+
+	// From ExplainIssue; Score, Risk and ETA are nil for closed issues
+	Score  *float64     `json:"score"`
+	Rank   int          `json:"rank,omitempty"`
+	OutOf  int          `json:"out_of,omitempty"`
+	Graph  GraphMetrics `json:"graph"`
+	Risk   *RiskSignals `json:"risk_signals,omitempty"`
+	WhatIf *WhatIfDelta `json:"what_if,omitempty"`
+	ETA    *ETAEstimate `json:"eta,omitempty"`
+}
+
+// ImpactDossier builds the impact dossier of one issue.
+func (a *Analyzer) ImpactDossier(issueID string, now time.Time) (*ImpactDossier, error) {
+	exp, err := a.ExplainIssue(issueID, now)
+	if err != nil {
+		return nil, err
+	}
+	issue := a.issueMap[issueID]
+
+	upstream, downstream := a.openBlockingEdges()
+	d := &ImpactDossier{
+		IssueID:   issue.ID,
+		Title:     issue.Title,
+		Status:    string(issue.Status),
+		Priority:  issue.Priority,
+		IssueType: string(issue.IssueType),
+		Assignee:  issue.Assignee,
+		Score:     exp.Score,
+		Rank:      exp.Rank,
+		OutOf:     exp.OutOf,
+		Graph:     exp.Graph,
+		Risk:      exp.Risk,
+		WhatIf:    exp.WhatIf,
+		ETA:       exp.ETA,
+	}
+	d.DirectBlockers, d.TransitiveBlockers = a.dossierReach(issueID, upstream)
+	d.DirectUnblocks, d.TransitiveUnblocks = a.dossierReach(issueID, downstream)
+	d.CriticalPath = longestChainThrough(issueID, upstream, downstream, a.issueMap)
+	return d, nil
+}
+
+// openBlockingEdges maps each open issue to its open blockers (upstream) and
+// to the open issues it blocks (downstream). Tombstones are ignored.
+func (a *Analyzer) openBlockingEdges() (upstream, downstream map[string][]string) {
+	upstream = make(map[string][]string)
+	downstream = make(map[string][]string)
+	open := func(id string) bool {
+		issue, ok := a.issueMap[id]
+		return ok && !issue.Status.IsClosed() && !issue.Status.IsTombstone()
+	}
+	for id, issue := range a.issueMap {
+		if !open(id) {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !open(dep.DependsOnID) || dep.DependsOnID == id {
+				continue
+			}
+			upstream[id] = append(upstream[id], dep.DependsOnID)
+			downstream[dep.DependsOnID] = append(downstream[dep.DependsOnID], id)
+		}
+	}
+	for _, edges := range []map[string][]string{upstream, downstream} {
+		for id := range edges {
+			sort.Strings(edges[id])
+		}
+	}
+	return upstream, downstream
+}
+
+// dossierReach walks edges breadth-first from issueID and splits what it
+// reaches into direct (depth 1) and transitive issues, each sorted by depth,
+// priority and ID.
+func (a *Analyzer) dossierReach(issueID string, edges map[string][]string) (direct, transitive []DossierIssue) {
+	direct, transitive = []DossierIssue{}, []DossierIssue{}
+	seen := map[string]bool{issueID: true}
+	frontier := []string{issueID}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []string
+		for _, id := range frontier {
+			for _, other := range edges[id] {
+				if seen[other] {
+					continue
+				}
+				seen[other] = true
+				next = append(next, other)
+				issue := a.issueMap[other]
+				entry := DossierIssue{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Priority: issue.Priority, Depth: depth}
+				if depth == 1 {
+					direct = append(direct, entry)
+				} else {
+					transitive = append(transitive, entry)
+				}
+			}
+		}
+		frontier = next
+	}
+	for _, list := range [][]DossierIssue{direct, transitive} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Depth != list[j].Depth {
+				return list[i].Depth < list[j].Depth
+			}
+			if list[i].Priority != list[j].Priority {
+				return list[i].Priority < list[j].Priority
+			}
+			return list[i].ID < list[j].ID
+		})
+	}
+	return direct, transitive
+}
+
+// longestChainThrough finds the longest chain of open blocking dependencies
+// through issueID and compares it with the longest chain in the project.
+// Issues in a dependency cycle count once and do not extend chains.
+func longestChainThrough(issueID string, upstream, downstream map[string][]string, issues map[string]model.Issue) CriticalPathMembership {
+	// longest[id] is the number of issues on the longest chain ending (or, for
+	// downstream, starting) at id; next[id] is the neighbour that achieves it
+	chains := func(edges map[string][]string) (map[string]int, map[string]string) {
+		longest := make(map[string]int)
+		next := make(map[string]string)
+		visiting := make(map[string]bool)
+		var walk func(id string) int
+		walk = func(id string) int {
+			if n, ok := longest[id]; ok {
+				return n
+			}
+			if visiting[id] {
+				return 0 // Cycle: stop here
+			}
+			visiting[id] = true
+			best := 1
+			for _, other := range edges[id] {
+				if n := walk(other) + 1; n > best {
+					best = n
+					next[id] = other
+				}
+			}
+			visiting[id] = false
+			longest[id] = best
+			return best
+		}
+		ids := make([]string, 0, len(issues))
+		for id := range issues {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			walk(id)
+		}
+		return longest, next
+	}
+	up, upNext := chains(upstream)
+	down, downNext := chains(downstream)
+
+	m := CriticalPathMembership{Chain: []string{}}
+	for id, n := range up {
+		if other := issues[id]; !other.Status.IsClosed() && !other.Status.IsTombstone() && n > m.ProjectLength {
+			m.ProjectLength = n
+		}
+	}
+	// Closed issues are on no chain
+	if issue, ok := issues[issueID]; !ok || issue.Status.IsClosed() || issue.Status.IsTombstone() {
+		return m
+	}
+
+	onChain := map[string]bool{issueID: true}
+	var before []string
+	for id := upNext[issueID]; id != "" && !onChain[id]; id = upNext[id] {
+		onChain[id] = true
+		before = append(before, id)
+	}
+	for i, j := 0, len(before)-1; i < j; i, j = i+1, j-1 {
+		before[i], before[j] = before[j], before[i]
+	}
+	m.Chain = append(before, issueID)
+	m.Position = len(m.Chain)
+	for id := downNext[issueID]; id != "" && !onChain[id]; id = downNext[id] {
+		onChain[id] = true
+		m.Chain = append(m.Chain, id)
+	}
+	m.OnCriticalPath = up[issueID]+down[issueID]-1 >= m.ProjectLength && m.ProjectLength > 1
+	return m
+}
+
+// Summary is a one-line description of the dossier's blocking position
+func (d *ImpactDossier) Summary() string {
+	blockers := len(d.DirectBlockers) + len(d.TransitiveBlockers)
+	unblocks := len(d.DirectUnblocks) + len(d.TransitiveUnblocks)
+	s := fmt.Sprintf("%d open blockers (%d direct), unblocks %d (%d direct)", blockers, len(d.DirectBlockers), unblocks, len(d.DirectUnblocks))
+	if d.CriticalPath.OnCriticalPath {
+		s += fmt.Sprintf(", on the critical path (%d issues)", d.CriticalPath.ProjectLength)
+	}
+	return s
+}
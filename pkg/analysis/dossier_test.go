@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func dossierIDs(issues []DossierIssue) string {
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestImpactDossier(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	// A → B → C → F is the longest chain; D also waits on B; X (closed)
+	// no longer blocks B; E stands alone
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+		{ID: "B", Title: "API", Status: model.StatusInProgress, Priority: 1, IssueType: model.TypeFeature, Assignee: "sam",
+			Dependencies: append(blocks("B", "A"), &model.Dependency{IssueID: "B", DependsOnID: "X", Type: model.DepBlocks})},
+		{ID: "C", Title: "UI", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Dependencies: blocks("C", "B")},
+		{ID: "D", Title: "Docs", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask, Dependencies: blocks("D", "B")},
+		{ID: "F", Title: "Launch", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeTask, Dependencies: blocks("F", "C")},
+		{ID: "E", Title: "Alone", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask},
+		{ID: "X", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask},
+	}
+	an := NewAnalyzer(issues)
+
+	d, err := an.ImpactDossier("B", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dossierIDs(d.DirectBlockers); got != "A" {
+		t.Errorf("direct blockers = %s, want A (closed X excluded)", got)
+	}
+	if len(d.TransitiveBlockers) != 0 {
+		t.Errorf("transitive blockers = %s", dossierIDs(d.TransitiveBlockers))
+	}
+	if got := dossierIDs(d.DirectUnblocks); got != "C,D" {
+		t.Errorf("direct unblocks = %s, want C,D", got)
+	}
+	if got := dossierIDs(d.TransitiveUnblocks); got != "F" || d.TransitiveUnblocks[0].Depth != 2 {
+		t.Errorf("transitive unblocks = %+v, want F at depth 2", d.TransitiveUnblocks)
+	}
+	cp := d.CriticalPath
+	if !cp.OnCriticalPath || cp.ProjectLength != 4 || strings.Join(cp.Chain, ",") != "A,B,C,F" || cp.Position != 2 {
+		t.Errorf("critical path = %+v", cp)
+	}
+	if d.Score == nil || d.ETA == nil || d.Assignee != "sam" {
+		t.Errorf("expected score, ETA and assignee for an open issue: %+v", d)
+	}
+	if !strings.Contains(d.Summary(), "on the critical path") {
+		t.Errorf("summary = %q", d.Summary())
+	}
+
+	d, err = an.ImpactDossier("E", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.CriticalPath.OnCriticalPath || len(d.CriticalPath.Chain) != 1 || d.CriticalPath.ProjectLength != 4 {
+		t.Errorf("E critical path = %+v", d.CriticalPath)
+	}
+
+	d, err = an.ImpactDossier("X", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Score != nil || len(d.CriticalPath.Chain) != 0 || len(d.DirectUnblocks) != 0 {
+		t.Errorf("closed issue dossier = %+v", d)
+	}
+
+	if _, err := an.ImpactDossier("missing", now); err == nil {
+		t.Error("expected an error for an unknown issue")
+	}
+}
+
+func TestImpactDossierCycle(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	d, err := NewAnalyzer(issues).ImpactDossier("A", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dossierIDs(d.DirectBlockers) != "B" || dossierIDs(d.DirectUnblocks) != "B" {
+		t.Errorf("cycle dossier blockers=%s unblocks=%s", dossierIDs(d.DirectBlockers), dossierIDs(d.DirectUnblocks))
+	}
+	if len(d.CriticalPath.Chain) > 2 {
+		t.Errorf("cycle chain = %v, want members counted once", d.CriticalPath.Chain)
+	}
+}
@@ -0,0 +1,163 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EffortTotals sums the estimated_minutes of a group of issues.
+type EffortTotals struct {
+	Key              string `json:"key,omitempty"`   // Epic ID, label or assignee ("" when unassigned)
+	Title            string `json:"title,omitempty"` // Epic title
+	OpenIssues       int    `json:"open_issues"`
+	RemainingMinutes int    `json:"remaining_minutes"` // Estimates of the open issues
+	UnestimatedOpen  int    `json:"unestimated_open"`  // Open issues without an estimate
+	CompletedMinutes int    `json:"completed_minutes"` // Estimates of the closed issues
+}
+
+// EffortRollup totals estimated effort for the project and per group.
+// Groups are sorted by remaining minutes, then key.
+type EffortRollup struct {
+	Total      EffortTotals   `json:"total"`
+	ByEpic     []EffortTotals `json:"by_epic"`     // An epic covers itself and its parent-child descendants
+	ByLabel    []EffortTotals `json:"by_label"`    // An issue counts toward each of its labels
+	ByAssignee []EffortTotals `json:"by_assignee"` // Open work only
+}
+
+// add counts issue toward the totals
+func (e *EffortTotals) add(issue model.Issue) {
+	minutes := 0
+	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+		minutes = *issue.EstimatedMinutes
+	}
+	if issue.Status == model.StatusClosed {
+		e.CompletedMinutes += minutes
+		return
+	}
+	e.OpenIssues++
+	e.RemainingMinutes += minutes
+	if minutes == 0 {
+		e.UnestimatedOpen++
+	}
+}
+
+// ComputeEffortTotals sums the estimates of issues.
+func ComputeEffortTotals(issues []model.Issue) EffortTotals {
+	var total EffortTotals
+	for _, issue := range issues {
+		if !issue.Status.IsTombstone() {
+			total.add(issue)
+		}
+	}
+	return total
+}
+
+// ComputeEffortRollup totals estimates for the project and per epic, label
+// and assignee. Tombstoned issues are ignored.
+func ComputeEffortRollup(issues []model.Issue) EffortRollup {
+	rollup := EffortRollup{
+		Total:      ComputeEffortTotals(issues),
+		ByEpic:     []EffortTotals{},
+		ByLabel:    []EffortTotals{},
+		ByAssignee: []EffortTotals{},
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		if !issue.Status.IsTombstone() {
+			issueMap[issue.ID] = issue
+		}
+	}
+	children := parentChildIndex(issues)
+	for _, issue := range issues {
+		if issue.IssueType != model.TypeEpic || issue.Status.IsTombstone() {
+			continue
+		}
+		totals := EffortTotals{Key: issue.ID, Title: issue.Title}
+		for _, id := range subtreeIDs(children, issue.ID) {
+			if member, ok := issueMap[id]; ok {
+				totals.add(member)
+			}
+		}
+		rollup.ByEpic = append(rollup.ByEpic, totals)
+	}
+
+	byLabel := make(map[string]*EffortTotals)
+	byAssignee := make(map[string]*EffortTotals)
+	for _, issue := range issueMap {
+		for _, label := range issue.Labels {
+			if byLabel[label] == nil {
+				byLabel[label] = &EffortTotals{Key: label}
+			}
+			byLabel[label].add(issue)
+		}
+		if issue.Status != model.StatusClosed {
+			if byAssignee[issue.Assignee] == nil {
+				byAssignee[issue.Assignee] = &EffortTotals{Key: issue.Assignee}
+			}
+			byAssignee[issue.Assignee].add(issue)
+		}
+	}
+	for _, totals := range byLabel {
+		rollup.ByLabel = append(rollup.ByLabel, *totals)
+	}
+	for _, totals := range byAssignee {
+		rollup.ByAssignee = append(rollup.ByAssignee, *totals)
+	}
+
+	for _, group := range [][]EffortTotals{rollup.ByEpic, rollup.ByLabel, rollup.ByAssignee} {
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].RemainingMinutes != group[j].RemainingMinutes {
+				return group[i].RemainingMinutes > group[j].RemainingMinutes
+			}
+			return group[i].Key < group[j].Key
+		})
+	}
+	return rollup
+}
+
+// ComputeIssueEffort sums the estimates of rootID and its parent-child
+// descendants, open and closed.
+func ComputeIssueEffort(issues []model.Issue, rootID string) EffortTotals {
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	totals := EffortTotals{Key: rootID, Title: issueMap[rootID].Title}
+	for _, id := range subtreeIDs(parentChildIndex(issues), rootID) {
+		if issue, ok := issueMap[id]; ok && !issue.Status.IsTombstone() {
+			totals.add(issue)
+		}
+	}
+	return totals
+}
+
+// parentChildIndex maps each issue ID to its children through parent-child links
+func parentChildIndex(issues []model.Issue) map[string][]string {
+	children := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	return children
+}
+
+// subtreeIDs returns rootID followed by everything below it in children,
+// breadth first
+func subtreeIDs(children map[string][]string, rootID string) []string {
+	seen := map[string]bool{rootID: true}
+	subtree := []string{rootID}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range children[subtree[i]] {
+			if !seen[child] {
+				seen[child] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+	return subtree
+}
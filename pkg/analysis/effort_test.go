@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeEffortRollup(t *testing.T) {
+	est := func(minutes int) *int { return &minutes }
+	child := func(id, parent string, status model.Status, minutes *int, labels ...string) model.Issue {
+		return model.Issue{ID: id, Status: status, IssueType: model.TypeTask, Labels: labels, EstimatedMinutes: minutes,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}}
+	}
+	issues := []model.Issue{
+		{ID: "epic", Title: "Launch", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		child("a", "epic", model.StatusOpen, est(60), "api"),
+		child("b", "a", model.StatusClosed, est(30), "api"),
+		child("c", "epic", model.StatusOpen, nil),
+		{ID: "loose", Status: model.StatusOpen, IssueType: model.TypeTask, Assignee: "bob", EstimatedMinutes: est(240)},
+		{ID: "gone", Status: model.StatusTombstone, EstimatedMinutes: est(999)},
+	}
+
+	r := ComputeEffortRollup(issues)
+	want := EffortTotals{OpenIssues: 4, RemainingMinutes: 300, UnestimatedOpen: 2, CompletedMinutes: 30}
+	if r.Total != want {
+		t.Errorf("total = %+v, want %+v", r.Total, want)
+	}
+	if len(r.ByEpic) != 1 {
+		t.Fatalf("expected one epic, got %+v", r.ByEpic)
+	}
+	if e := r.ByEpic[0]; e.Title != "Launch" || e.OpenIssues != 3 || e.RemainingMinutes != 60 || e.CompletedMinutes != 30 {
+		t.Errorf("epic should cover its grandchildren, got %+v", e)
+	}
+	if len(r.ByLabel) != 1 || r.ByLabel[0].Key != "api" || r.ByLabel[0].CompletedMinutes != 30 {
+		t.Errorf("unexpected label totals %+v", r.ByLabel)
+	}
+	if len(r.ByAssignee) != 2 || r.ByAssignee[0].Key != "bob" || r.ByAssignee[1].OpenIssues != 3 {
+		t.Errorf("assignees should be sorted by remaining work, got %+v", r.ByAssignee)
+	}
+
+	if got := ComputeIssueEffort(issues, "a"); got.RemainingMinutes != 60 || got.CompletedMinutes != 30 {
+		t.Errorf("issue effort of a = %+v", got)
+	}
+}
@@ -26,6 +26,7 @@ type ETAEstimate struct {
 
 // EstimateETAForIssue estimates an ETA for a single issue using:
 // - Complexity minutes: estimated_minutes (explicit) or derived from median estimate × type weight × depth × description length.
+// - Type weight: learned from the cycle times of closed issues of the type (see LearnVelocityModel).
 // - Velocity minutes/day: derived from recent closures of issues sharing labels (fallback to global, then default).
 // - ETA days = minutes / (velocity * agents), with a simple confidence interval.
 func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string, agents int, now time.Time) (ETAEstimate, error) {
@@ -38,14 +39,36 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 		return ETAEstimate{}, fmt.Errorf("issue %q not found", issueID)
 	}
 
+	return estimateETA(issue, stats, agents, now, LearnVelocityModel(issues, now)), nil
+}
+
+// EstimateETAs estimates an ETA for every issue that is not closed, as
+// EstimateETAForIssue does for one. The velocity model is learned once for
+// all of them.
+func EstimateETAs(issues []model.Issue, stats *GraphStats, agents int, now time.Time) map[string]ETAEstimate {
+	vm := LearnVelocityModel(issues, now)
+	etas := make(map[string]ETAEstimate, len(issues))
+	for _, issue := range issues {
+		if issue.Status == model.StatusClosed {
+			continue
+		}
+		etas[issue.ID] = estimateETA(issue, stats, agents, now, vm)
+	}
+	return etas
+}
+
+// etaVelocityWindow is how far back closures count toward velocity
+const etaVelocityWindow = 30 * 24 * time.Hour
+
+func estimateETA(issue model.Issue, stats *GraphStats, agents int, now time.Time, vm *VelocityModel) ETAEstimate {
 	if agents <= 0 {
 		agents = 1
 	}
+	medianMinutes := vm.MedianEstimateMinutes
 
-	medianMinutes := computeMedianEstimatedMinutes(issues)
-	complexityMinutes, complexityFactors := estimateComplexityMinutes(issue, stats, medianMinutes)
+	complexityMinutes, complexityFactors := estimateComplexityMinutes(issue, stats, medianMinutes, vm)
 
-	velocityPerDay, velocitySamples, velocityFactors := estimateVelocityMinutesPerDay(issues, issue, now, medianMinutes)
+	velocityPerDay, velocitySamples, velocityFactors := estimateVelocityMinutesPerDay(issue, vm)
 	if velocityPerDay <= 0 {
 		// Conservative default: one median-sized issue per (work) week.
 		velocityPerDay = float64(medianMinutes) / 5.0
@@ -78,7 +101,7 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 	}
 
 	return ETAEstimate{
-		IssueID:               issueID,
+		IssueID:               issue.ID,
 		EstimatedMinutes:      complexityMinutes,
 		EstimatedDays:         estimatedDays,
 		ETADate:               eta,
@@ -88,10 +111,12 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 		VelocityMinutesPerDay: velocityPerDay,
 		Agents:                agents,
 		Factors:               factors,
-	}, nil
+	}
 }
 
-func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinutes int) (int, []string) {
+// estimateComplexityMinutes derives the work in an issue. A nil vm uses the
+// fixed type weights.
+func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinutes int, vm *VelocityModel) (int, []string) {
 	var factors []string
 
 	baseMinutes := medianMinutes
@@ -106,23 +131,13 @@ func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinut
 	}
 	factors = append(factors, fmt.Sprintf("estimate: %s (%dm)", estimateSource, baseMinutes))
 
-	// Type weight
-	typeWeight := 1.0
-	switch issue.IssueType {
-	case model.TypeBug:
-		typeWeight = 1.0
-	case model.TypeTask:
-		typeWeight = 1.0
-	case model.TypeChore:
-		typeWeight = 0.8
-	case model.TypeFeature:
-		typeWeight = 1.3
-	case model.TypeEpic:
-		typeWeight = 2.0
-	default:
-		typeWeight = 1.0
+	// Type weight, learned from cycle times once issues of the type have closed
+	typeWeight, typeSamples := vm.TypeWeight(issue.IssueType)
+	if typeSamples > 0 {
+		factors = append(factors, fmt.Sprintf("type: %s×%.2f (learned, %d closed)", issue.IssueType, typeWeight, typeSamples))
+	} else {
+		factors = append(factors, fmt.Sprintf("type: %s×%.1f", issue.IssueType, typeWeight))
 	}
-	factors = append(factors, fmt.Sprintf("type: %s×%.1f", issue.IssueType, typeWeight))
 
 	// Dependency depth (critical path depth) — deeper issues tend to carry more coordination cost.
 	depth := 0.0
@@ -148,13 +163,11 @@ func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinut
 	return derived, factors
 }
 
-func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now time.Time, medianMinutes int) (float64, int, []string) {
-	const windowDays = 30
-	since := now.Add(-time.Duration(windowDays) * 24 * time.Hour)
-
+func estimateVelocityMinutesPerDay(issue model.Issue, vm *VelocityModel) (float64, int, []string) {
+	velocity := vm.VelocityForLabel
 	labels := issue.Labels
 	if len(labels) == 0 {
-		v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes)
+		v, n := velocity("")
 		return v, n, []string{fmt.Sprintf("velocity: global (%d samples/30d)", n)}
 	}
 
@@ -163,7 +176,7 @@ func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now
 	bestV := 0.0
 	bestN := 0
 	for _, label := range labels {
-		v, n := velocityMinutesPerDayForLabel(issues, label, since, medianMinutes)
+		v, n := velocity(label)
 		if n == 0 || v <= 0 {
 			continue
 		}
@@ -178,7 +191,7 @@ func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now
 	}
 
 	// Fallback: global velocity.
-	v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes)
+	v, n := velocity("")
 	return v, n, []string{fmt.Sprintf("velocity: global (%d samples/30d)", n)}
 }
 
@@ -187,32 +200,13 @@ func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since tim
 	samples := 0
 
 	for _, iss := range issues {
-		if iss.Status != model.StatusClosed {
-			continue
-		}
-
-		// Robust closure time: use ClosedAt if available, else UpdatedAt
-		closedAt := iss.UpdatedAt
-		if iss.ClosedAt != nil {
-			closedAt = *iss.ClosedAt
-		}
-
-		if closedAt.Before(since) {
-			continue
-		}
 		if label != "" && !hasLabel(iss.Labels, label) {
 			continue
 		}
-
-		minutes := medianMinutes
-		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
-			minutes = *iss.EstimatedMinutes
-		}
-		if minutes <= 0 {
-			minutes = DefaultEstimatedMinutes
+		if minutes, ok := recentClosureMinutes(iss, since, medianMinutes); ok {
+			total += minutes
+			samples++
 		}
-		total += minutes
-		samples++
 	}
 
 	if samples == 0 {
@@ -221,6 +215,32 @@ func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since tim
 	return float64(total) / 30.0, samples
 }
 
+// recentClosureMinutes returns the estimated minutes of iss if it was
+// closed since since
+func recentClosureMinutes(iss model.Issue, since time.Time, medianMinutes int) (int, bool) {
+	if iss.Status != model.StatusClosed {
+		return 0, false
+	}
+
+	// Robust closure time: use ClosedAt if available, else UpdatedAt
+	closedAt := iss.UpdatedAt
+	if iss.ClosedAt != nil {
+		closedAt = *iss.ClosedAt
+	}
+	if closedAt.Before(since) {
+		return 0, false
+	}
+
+	minutes := medianMinutes
+	if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+		minutes = *iss.EstimatedMinutes
+	}
+	if minutes <= 0 {
+		minutes = DefaultEstimatedMinutes
+	}
+	return minutes, true
+}
+
 func hasLabel(labels []string, target string) bool {
 	for _, l := range labels {
 		if l == target {
@@ -51,6 +51,37 @@ func TestEstimateETAForIssue_Basic(t *testing.T) {
 	}
 }
 
+func TestEstimateETAs_MatchesPerIssue(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-48 * time.Hour)
+	minutes := 120
+
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, IssueType: model.TypeFeature, Labels: []string{"api"}},
+		{ID: "b", Status: model.StatusInProgress, IssueType: model.TypeBug, Labels: []string{"api", "ui"}},
+		{ID: "c", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "d", Status: model.StatusClosed, Labels: []string{"api"}, ClosedAt: &closedAt, EstimatedMinutes: &minutes},
+	}
+
+	etas := EstimateETAs(issues, nil, 2, now)
+	if _, ok := etas["d"]; ok {
+		t.Error("closed issues should have no ETA")
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		want, err := EstimateETAForIssue(issues, nil, id, 2, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := etas[id]
+		if !ok {
+			t.Fatalf("missing ETA for %s", id)
+		}
+		if got.EstimatedMinutes != want.EstimatedMinutes || got.EstimatedDays != want.EstimatedDays || !got.ETADate.Equal(want.ETADate) {
+			t.Errorf("%s: EstimateETAs = %+v, EstimateETAForIssue = %+v", id, got, want)
+		}
+	}
+}
+
 func TestEstimateETAForIssue_NotFound(t *testing.T) {
 	now := time.Now()
 	issues := []model.Issue{}
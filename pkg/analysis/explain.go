@@ -0,0 +1,151 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// IssueExplanation is the full reasoning behind one issue's impact score,
+// as shown in the Insights panel, for --robot-explain.
+type IssueExplanation struct {
+	IssueID  string `json:"issue_id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+
+	// Score is the composite impact score; nil for closed issues, which are not ranked
+	Score *float64 `json:"score"`
+	Rank  int      `json:"rank,omitempty"` // 1-based position among open issues
+	OutOf int      `json:"out_of,omitempty"`
+
+	Components []ScoreComponent `json:"components,omitempty"`
+	TopReasons []PriorityReason `json:"top_reasons,omitempty"`
+	Graph      GraphMetrics     `json:"graph"`
+	Risk       *RiskSignals     `json:"risk_signals,omitempty"`
+	WhatIf     *WhatIfDelta     `json:"what_if,omitempty"`
+	ETA        *ETAEstimate     `json:"eta,omitempty"`
+}
+
+// ScoreComponent is one weighted term of the impact score.
+type ScoreComponent struct {
+	Factor       string  `json:"factor"`
+	Weight       float64 `json:"weight"`       // Share of the composite score
+	Normalized   float64 `json:"normalized"`   // 0-1 before weighting
+	Contribution float64 `json:"contribution"` // weight × normalized
+	Reason       string  `json:"reason"`
+}
+
+// GraphMetrics are the raw centrality and degree values for one issue.
+type GraphMetrics struct {
+	PageRank          float64 `json:"pagerank"`
+	Betweenness       float64 `json:"betweenness"`
+	Eigenvector       float64 `json:"eigenvector"`
+	Hub               float64 `json:"hub"`
+	Authority         float64 `json:"authority"`
+	CriticalPathDepth float64 `json:"critical_path_depth"`
+	InDegree          int     `json:"in_degree"`  // Issues that depend on this one
+	OutDegree         int     `json:"out_degree"` // Issues this one depends on
+	BlocksCount       int     `json:"blocks_count"`
+}
+
+// ExplainIssue computes the score breakdown, graph metrics, risk signals,
+// what-if delta and ETA for a single issue.
+func (a *Analyzer) ExplainIssue(issueID string, now time.Time) (*IssueExplanation, error) {
+	issue, ok := a.issueMap[issueID]
+	if !ok {
+		return nil, fmt.Errorf("issue %q not found", issueID)
+	}
+
+	stats := a.Analyze()
+	blocks := 0
+	for _, other := range a.issueMap {
+		for _, dep := range other.Dependencies {
+			if dep != nil && dep.DependsOnID == issueID && dep.Type.IsBlocking() {
+				blocks++
+			}
+		}
+	}
+
+	exp := &IssueExplanation{
+		IssueID:  issue.ID,
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: issue.Priority,
+		Graph: GraphMetrics{
+			PageRank:          stats.GetPageRankScore(issueID),
+			Betweenness:       stats.GetBetweennessScore(issueID),
+			Eigenvector:       stats.GetEigenvectorScore(issueID),
+			Hub:               stats.GetHubScore(issueID),
+			Authority:         stats.GetAuthorityScore(issueID),
+			CriticalPathDepth: stats.GetCriticalPathScore(issueID),
+			InDegree:          stats.InDegree[issueID],
+			OutDegree:         stats.OutDegree[issueID],
+			BlocksCount:       blocks,
+		},
+	}
+	if issue.Status == model.StatusClosed {
+		return exp, nil
+	}
+
+	scores := a.ComputeImpactScoresFromStats(&stats, now)
+	for i := range scores {
+		if scores[i].IssueID != issueID {
+			continue
+		}
+		score := scores[i]
+		exp.Score = &score.Score
+		exp.Rank = i + 1
+		exp.OutOf = len(scores)
+		exp.Components = scoreComponents(score.Breakdown, exp.Graph, issue, now)
+		exp.TopReasons = GenerateTopReasons(score)
+		exp.Risk = score.Breakdown.RiskSignals
+		break
+	}
+
+	exp.WhatIf = a.computeWhatIfDelta(issueID)
+	issues := make([]model.Issue, 0, len(a.issueMap))
+	for _, iss := range a.issueMap {
+		issues = append(issues, iss)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	if eta, err := EstimateETAForIssue(issues, &stats, issueID, 1, now); err == nil {
+		exp.ETA = &eta
+	}
+	return exp, nil
+}
+
+// scoreComponents lists the impact score terms in ScoreBreakdown order, each with
+// a human-readable reason.
+func scoreComponents(b ScoreBreakdown, g GraphMetrics, issue model.Issue, now time.Time) []ScoreComponent {
+	staleness := "Update time unknown"
+	if !issue.UpdatedAt.IsZero() {
+		staleness = fmt.Sprintf("Last updated %d days ago", int(now.Sub(issue.UpdatedAt).Hours()/24))
+	}
+	urgency := b.UrgencyExplanation
+	if urgency == "" {
+		urgency = "No urgency labels"
+	}
+	risk := b.RiskExplanation
+	if risk == "" {
+		risk = "No notable risk signals"
+	}
+
+	w := CurrentScoreWeights()
+	return []ScoreComponent{
+		{"pagerank", w.PageRank, b.PageRankNorm, b.PageRank,
+			fmt.Sprintf("PageRank %.4f (%.0f%% of the highest): how much work ultimately depends on this", g.PageRank, b.PageRankNorm*100)},
+		{"betweenness", w.Betweenness, b.BetweennessNorm, b.Betweenness,
+			fmt.Sprintf("Betweenness %.4f (%.0f%% of the highest): how often it bridges dependency chains", g.Betweenness, b.BetweennessNorm*100)},
+		{"blocker_ratio", w.BlockerRatio, b.BlockerRatioNorm, b.BlockerRatio,
+			fmt.Sprintf("Directly blocks %d issue(s)", g.BlocksCount)},
+		{"staleness", w.Staleness, b.StalenessNorm, b.Staleness, staleness},
+		{"priority_boost", w.PriorityBoost, b.PriorityBoostNorm, b.PriorityBoost,
+			fmt.Sprintf("Explicit priority P%d", issue.Priority)},
+		{"time_to_impact", w.TimeToImpact, b.TimeToImpactNorm, b.TimeToImpact, b.TimeToImpactExplanation},
+		{"urgency", w.Urgency, b.UrgencyNorm, b.Urgency, urgency},
+		{"risk", w.Risk, b.RiskNorm, b.Risk, risk},
+	}
+}
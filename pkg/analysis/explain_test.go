@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestExplainIssue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen, Priority: 0, UpdatedAt: now.AddDate(0, 0, -3)},
+		{ID: "B", Title: "Mid", Status: model.StatusOpen, Priority: 2, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Leaf", Status: model.StatusOpen, Priority: 2, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "D", Title: "Done", Status: model.StatusClosed, Priority: 1},
+	}
+	an := NewAnalyzer(issues)
+
+	exp, err := an.ExplainIssue("A", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp.Score == nil || exp.Rank < 1 || exp.OutOf != 3 {
+		t.Fatalf("expected A ranked among 3 open issues, got score=%v rank=%d/%d", exp.Score, exp.Rank, exp.OutOf)
+	}
+	if len(exp.Components) != 8 {
+		t.Fatalf("expected 8 score components, got %d", len(exp.Components))
+	}
+	sum := 0.0
+	for _, c := range exp.Components {
+		if c.Reason == "" {
+			t.Errorf("component %s has no reason", c.Factor)
+		}
+		sum += c.Contribution
+	}
+	if math.Abs(sum-*exp.Score) > 1e-9 {
+		t.Errorf("components sum to %f, score is %f", sum, *exp.Score)
+	}
+	if exp.Components[2].Reason != "Directly blocks 1 issue(s)" || exp.Graph.BlocksCount != 1 {
+		t.Errorf("unexpected blocker reason %q", exp.Components[2].Reason)
+	}
+	if exp.Components[3].Reason != "Last updated 3 days ago" {
+		t.Errorf("unexpected staleness reason %q", exp.Components[3].Reason)
+	}
+	if exp.WhatIf == nil || exp.WhatIf.TransitiveUnblocks != 2 {
+		t.Errorf("expected closing A to unblock 2 transitively, got %+v", exp.WhatIf)
+	}
+	if exp.ETA == nil || exp.ETA.IssueID != "A" {
+		t.Errorf("expected an ETA for A, got %+v", exp.ETA)
+	}
+
+	closed, err := an.ExplainIssue("D", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Score != nil || closed.Components != nil || closed.ETA != nil {
+		t.Errorf("closed issue should only carry graph metrics, got %+v", closed)
+	}
+
+	if _, err := an.ExplainIssue("missing", now); err == nil {
+		t.Error("unknown issue should fail")
+	}
+}
@@ -239,15 +239,16 @@ func (f *FeedbackData) GetEffectiveWeights() map[string]float64 {
 
 // getEffectiveWeightsLocked is the internal version that assumes lock is already held
 func (f *FeedbackData) getEffectiveWeightsLocked() map[string]float64 {
+	w := CurrentScoreWeights()
 	baseWeights := map[string]float64{
-		"PageRank":      WeightPageRank,
-		"Betweenness":   WeightBetweenness,
-		"BlockerRatio":  WeightBlockerRatio,
-		"Staleness":     WeightStaleness,
-		"PriorityBoost": WeightPriorityBoost,
-		"TimeToImpact":  WeightTimeToImpact,
-		"Urgency":       WeightUrgency,
-		"Risk":          WeightRisk,
+		"PageRank":      w.PageRank,
+		"Betweenness":   w.Betweenness,
+		"BlockerRatio":  w.BlockerRatio,
+		"Staleness":     w.Staleness,
+		"PriorityBoost": w.PriorityBoost,
+		"TimeToImpact":  w.TimeToImpact,
+		"Urgency":       w.Urgency,
+		"Risk":          w.Risk,
 	}
 
 	effective := make(map[string]float64)
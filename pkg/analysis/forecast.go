@@ -0,0 +1,295 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ForecastConfig configures the completion forecast simulation.
+type ForecastConfig struct {
+	// Agents is the number of issues worked on in parallel.
+	// Default: 1
+	Agents int
+
+	// Runs is the number of simulated schedules.
+	// Default: 1000
+	Runs int
+
+	// Buckets is the number of histogram buckets.
+	// Default: 12
+	Buckets int
+
+	// Seed makes the simulation reproducible.
+	// Default: 1
+	Seed int64
+}
+
+// DefaultForecastConfig returns sensible defaults.
+func DefaultForecastConfig() ForecastConfig {
+	return ForecastConfig{
+		Agents:  1,
+		Runs:    1000,
+		Buckets: 12,
+		Seed:    1,
+	}
+}
+
+// ForecastBucket is one bar of the completion date histogram.
+type ForecastBucket struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Runs       int       `json:"runs"`       // Runs finishing in [Start, End)
+	Cumulative float64   `json:"cumulative"` // Share of runs finished by End
+}
+
+// CompletionForecast is the distribution of the date by which a set of
+// issues is done, from a Monte Carlo simulation over their ETA estimates.
+type CompletionForecast struct {
+	IssueCount    int              `json:"issue_count"`
+	AddedBlockers int              `json:"added_blockers"` // Open blockers outside the scope that must finish first
+	Runs          int              `json:"runs"`
+	Agents        int              `json:"agents"`
+	Start         time.Time        `json:"start"`
+	P50           time.Time        `json:"p50"`
+	P85           time.Time        `json:"p85"`
+	P95           time.Time        `json:"p95"`
+	MeanDays      float64          `json:"mean_days"`
+	Histogram     []ForecastBucket `json:"histogram,omitempty"`
+}
+
+// EpicForecastScope returns epicID followed by its open descendants through
+// parent-child links, in ID order.
+func EpicForecastScope(issues []model.Issue, epicID string) []string {
+	open := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		open[issue.ID] = issue.Status != model.StatusClosed
+	}
+
+	var descendants []string
+	for _, id := range subtreeIDs(parentChildIndex(issues), epicID)[1:] {
+		if open[id] {
+			descendants = append(descendants, id)
+		}
+	}
+	sort.Strings(descendants)
+	return append([]string{epicID}, descendants...)
+}
+
+// SimulateCompletion forecasts when the open issues in scope (every open
+// issue when scope is nil) are all closed. Each run samples every issue's
+// duration around its single-agent ETA, wider for low-confidence estimates,
+// and schedules the issues on cfg.Agents workers so that no issue starts
+// before its blockers finish. Open blockers outside the scope are pulled in.
+func SimulateCompletion(issues []model.Issue, stats *GraphStats, scope []string, cfg ForecastConfig, now time.Time) CompletionForecast {
+	def := DefaultForecastConfig()
+	if cfg.Agents <= 0 {
+		cfg.Agents = def.Agents
+	}
+	if cfg.Runs <= 0 {
+		cfg.Runs = def.Runs
+	}
+	if cfg.Buckets <= 0 {
+		cfg.Buckets = def.Buckets
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	isOpen := func(id string) bool {
+		issue, ok := issueMap[id]
+		return ok && issue.Status != model.StatusClosed
+	}
+
+	// Scope plus its transitive open blockers
+	inScope := make(map[string]bool)
+	var queue []string
+	if scope == nil {
+		for _, issue := range issues {
+			if issue.Status != model.StatusClosed {
+				inScope[issue.ID] = true
+				queue = append(queue, issue.ID)
+			}
+		}
+	} else {
+		for _, id := range scope {
+			if isOpen(id) && !inScope[id] {
+				inScope[id] = true
+				queue = append(queue, id)
+			}
+		}
+	}
+	requested := len(inScope)
+	blockers := make(map[string][]string)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !isOpen(dep.DependsOnID) {
+				continue
+			}
+			blockers[id] = append(blockers[id], dep.DependsOnID)
+			if !inScope[dep.DependsOnID] {
+				inScope[dep.DependsOnID] = true
+				queue = append(queue, dep.DependsOnID)
+			}
+		}
+	}
+
+	forecast := CompletionForecast{
+		IssueCount:    len(inScope),
+		AddedBlockers: len(inScope) - requested,
+		Runs:          cfg.Runs,
+		Agents:        cfg.Agents,
+		Start:         now,
+		P50:           now,
+		P85:           now,
+		P95:           now,
+	}
+	if len(inScope) == 0 {
+		return forecast
+	}
+
+	order := forecastOrder(inScope, blockers)
+	index := make(map[string]int, len(order))
+	for i, id := range order {
+		index[id] = i
+	}
+	deps := make([][]int, len(order))
+	for i, id := range order {
+		for _, b := range blockers[id] {
+			// Blockers later in the order are in a cycle; they do not hold the issue back
+			if j := index[b]; j < i {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+
+	etas := EstimateETAs(issues, stats, 1, now)
+	mu := make([]float64, len(order))
+	sigma := make([]float64, len(order))
+	for i, id := range order {
+		eta := etas[id]
+		sigma[i] = 0.3 + 0.7*(1-eta.Confidence)
+		mu[i] = math.Inf(-1) // Zero duration
+		if eta.EstimatedDays > 0 {
+			// Lognormal with the estimate as its mean
+			mu[i] = math.Log(eta.EstimatedDays) - sigma[i]*sigma[i]/2
+		}
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	finish := make([]float64, len(order))
+	workers := make([]float64, cfg.Agents)
+	makespans := make([]float64, cfg.Runs)
+	total := 0.0
+	for run := range makespans {
+		for w := range workers {
+			workers[w] = 0
+		}
+		makespan := 0.0
+		for i := range order {
+			ready := 0.0
+			for _, j := range deps[i] {
+				ready = max(ready, finish[j])
+			}
+			w := 0
+			for k := range workers {
+				if workers[k] < workers[w] {
+					w = k
+				}
+			}
+			finish[i] = max(ready, workers[w]) + math.Exp(mu[i]+sigma[i]*rng.NormFloat64())
+			workers[w] = finish[i]
+			makespan = max(makespan, finish[i])
+		}
+		makespans[run] = makespan
+		total += makespan
+	}
+	sort.Float64s(makespans)
+
+	at := func(days float64) time.Time { return now.Add(durationDays(days)) }
+	percentile := func(p float64) float64 {
+		return makespans[max(int(math.Ceil(p*float64(len(makespans))))-1, 0)]
+	}
+	forecast.P50 = at(percentile(0.50))
+	forecast.P85 = at(percentile(0.85))
+	forecast.P95 = at(percentile(0.95))
+	forecast.MeanDays = total / float64(cfg.Runs)
+	forecast.Histogram = forecastHistogram(makespans, cfg.Buckets, at)
+	return forecast
+}
+
+// forecastOrder sorts the scope so that blockers come first (Kahn's
+// algorithm, ties by ID). Issues left in cycles are appended by ID.
+func forecastOrder(inScope map[string]bool, blockers map[string][]string) []string {
+	pending := make(map[string]int, len(inScope))
+	blocks := make(map[string][]string)
+	var ready []string
+	for id := range inScope {
+		pending[id] = len(blockers[id])
+		for _, b := range blockers[id] {
+			blocks[b] = append(blocks[b], id)
+		}
+		if pending[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+	sort.Strings(ready)
+
+	order := make([]string, 0, len(inScope))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		delete(pending, id)
+		var unblocked []string
+		for _, next := range blocks[id] {
+			if pending[next]--; pending[next] == 0 {
+				unblocked = append(unblocked, next)
+			}
+		}
+		sort.Strings(unblocked)
+		ready = append(ready, unblocked...)
+	}
+
+	cyclic := make([]string, 0, len(pending))
+	for id := range pending {
+		cyclic = append(cyclic, id)
+	}
+	sort.Strings(cyclic)
+	return append(order, cyclic...)
+}
+
+// forecastHistogram buckets sorted makespans (in days) into equal-width bars
+func forecastHistogram(makespans []float64, buckets int, at func(float64) time.Time) []ForecastBucket {
+	lo, hi := makespans[0], makespans[len(makespans)-1]
+	if hi-lo < 1e-9 {
+		buckets = 1
+	}
+	width := (hi - lo) / float64(buckets)
+
+	histogram := make([]ForecastBucket, buckets)
+	for b := range histogram {
+		histogram[b].Start = at(lo + float64(b)*width)
+		histogram[b].End = at(lo + float64(b+1)*width)
+	}
+	histogram[buckets-1].End = at(hi)
+	for _, days := range makespans {
+		b := buckets - 1
+		if width > 0 {
+			b = min(int((days-lo)/width), buckets-1)
+		}
+		histogram[b].Runs++
+	}
+	done := 0
+	for b := range histogram {
+		done += histogram[b].Runs
+		histogram[b].Cumulative = float64(done) / float64(len(makespans))
+	}
+	return histogram
+}
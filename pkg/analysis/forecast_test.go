@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSimulateCompletion(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	minutes := 480
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, IssueType: model.TypeTask, EstimatedMinutes: &minutes},
+		{ID: "b", Status: model.StatusOpen, IssueType: model.TypeTask, EstimatedMinutes: &minutes, Dependencies: blocks("b", "a")},
+		{ID: "c", Status: model.StatusOpen, IssueType: model.TypeTask, EstimatedMinutes: &minutes},
+		{ID: "d", Status: model.StatusClosed, IssueType: model.TypeTask, EstimatedMinutes: &minutes},
+	}
+	cfg := DefaultForecastConfig()
+
+	all := SimulateCompletion(issues, nil, nil, cfg, now)
+	if all.IssueCount != 3 || all.AddedBlockers != 0 {
+		t.Fatalf("expected the 3 open issues, got %d (+%d blockers)", all.IssueCount, all.AddedBlockers)
+	}
+	if all.P50.Before(now) || all.P85.Before(all.P50) || all.P95.Before(all.P85) {
+		t.Errorf("percentiles out of order: %v %v %v", all.P50, all.P85, all.P95)
+	}
+	runs := 0
+	for _, b := range all.Histogram {
+		runs += b.Runs
+	}
+	if runs != cfg.Runs || all.Histogram[len(all.Histogram)-1].Cumulative != 1 {
+		t.Errorf("histogram should cover every run, got %d of %d", runs, cfg.Runs)
+	}
+	if again := SimulateCompletion(issues, nil, nil, cfg, now); !reflect.DeepEqual(all, again) {
+		t.Error("the same seed should give the same forecast")
+	}
+
+	// b pulls in its blocker a
+	scoped := SimulateCompletion(issues, nil, []string{"b"}, cfg, now)
+	if scoped.IssueCount != 2 || scoped.AddedBlockers != 1 {
+		t.Errorf("expected b plus blocker a, got %d (+%d)", scoped.IssueCount, scoped.AddedBlockers)
+	}
+
+	// More agents run a and c in parallel
+	cfg.Agents = 2
+	if parallel := SimulateCompletion(issues, nil, nil, cfg, now); !parallel.P50.Before(all.P50) {
+		t.Errorf("two agents should finish sooner: %v vs %v", parallel.P50, all.P50)
+	}
+
+	if none := SimulateCompletion(issues, nil, []string{"d"}, cfg, now); none.IssueCount != 0 || !none.P95.Equal(now) || none.Histogram != nil {
+		t.Errorf("a closed scope should be done now, got %+v", none)
+	}
+}
+
+func TestSimulateCompletion_Cycle(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "x", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "x", DependsOnID: "y", Type: model.DepBlocks}}},
+		{ID: "y", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "y", DependsOnID: "x", Type: model.DepBlocks}}},
+	}
+	forecast := SimulateCompletion(issues, nil, nil, DefaultForecastConfig(), now)
+	if forecast.IssueCount != 2 || !forecast.P50.After(now) {
+		t.Errorf("a cycle should still be scheduled, got %+v", forecast)
+	}
+}
+
+func TestEpicForecastScope(t *testing.T) {
+	child := func(id, parent string, status model.Status) model.Issue {
+		return model.Issue{ID: id, Status: status, Dependencies: []*model.Dependency{
+			{IssueID: id, DependsOnID: parent, Type: model.DepParentChild},
+		}}
+	}
+	issues := []model.Issue{
+		{ID: "epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		child("t2", "epic", model.StatusOpen),
+		child("t1", "epic", model.StatusClosed),
+		child("t3", "t1", model.StatusOpen),
+		child("other", "elsewhere", model.StatusOpen),
+	}
+	got := EpicForecastScope(issues, "epic")
+	if want := []string{"epic", "t2", "t3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EpicForecastScope = %v, want %v", got, want)
+	}
+}
@@ -45,7 +45,14 @@ type StartupProfile struct {
 	KCore         time.Duration `json:"kcore"`        // bv-85
 	Articulation  time.Duration `json:"articulation"` // bv-85
 	Slack         time.Duration `json:"slack"`        // bv-85
-	Phase2        time.Duration `json:"phase2_total"`
+	Phase2        time.Duration `json:"phase2_total"` // Wall clock; metrics run concurrently
+	Phase2Workers int           `json:"phase2_workers"`
+
+	// Approximate betweenness diagnostics (zero when exact or skipped)
+	BetweennessSample    int     `json:"betweenness_sample,omitempty"`
+	BetweennessRounds    int     `json:"betweenness_rounds,omitempty"`
+	BetweennessError     float64 `json:"betweenness_error_estimate,omitempty"`
+	BetweennessConverged bool    `json:"betweenness_converged,omitempty"`
 
 	// Configuration used
 	Config AnalysisConfig `json:"config"`
@@ -115,10 +122,11 @@ type MetricStatus struct {
 
 // statusEntry records computation state for a single metric.
 type statusEntry struct {
-	State   string        `json:"state"`            // computed|approx|timeout|skipped
-	Reason  string        `json:"reason,omitempty"` // explanation when skipped/timeout/approx
-	Sample  int           `json:"sample,omitempty"` // sample size when approximate
-	Elapsed time.Duration `json:"ms,omitempty"`     // elapsed time
+	State   string        `json:"state"`                    // computed|approx|timeout|skipped
+	Reason  string        `json:"reason,omitempty"`         // explanation when skipped/timeout/approx
+	Sample  int           `json:"sample,omitempty"`         // sample size when approximate
+	Error   float64       `json:"error_estimate,omitempty"` // relative 95% CI half-width when approximate
+	Elapsed time.Duration `json:"ms,omitempty"`             // elapsed time
 }
 
 // IsPhase2Ready returns true if Phase 2 metrics have been computed.
@@ -557,8 +565,9 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 				continue
 			}
 
+			// Self-dependencies cannot gate anything and gonum rejects self edges
 			v, exists := idToNode[dep.DependsOnID]
-			if exists {
+			if exists && v != u {
 				// Issue (u) depends on v → edge u -> v
 				// Optimization: Use simple.Node directly to avoid internal map lookups in g.Node()
 				g.SetEdge(g.NewEdge(simple.Node(u), simple.Node(v)))
@@ -582,20 +591,35 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 // If SetConfig was called, uses that config. Otherwise uses ConfigForSize() to
 // automatically select appropriate algorithms based on graph size.
 func (a *Analyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
-	var config AnalysisConfig
+	return a.AnalyzeAsyncWithConfig(ctx, a.effectiveConfig())
+}
+
+// AnalyzePhase1 computes Phase 1 metrics like AnalyzeAsync but defers Phase 2
+// until the returned start function is first called, so interactive callers
+// can render before the expensive metrics compete for CPU.
+func (a *Analyzer) AnalyzePhase1(ctx context.Context) (*GraphStats, func()) {
+	return a.analyze(ctx, a.effectiveConfig())
+}
+
+// effectiveConfig returns the config set by SetConfig, or TunedConfigForSize.
+func (a *Analyzer) effectiveConfig() AnalysisConfig {
 	if a.config != nil {
-		config = *a.config
-	} else {
-		nodeCount := len(a.issueMap)
-		edgeCount := a.g.Edges().Len()
-		config = ConfigForSize(nodeCount, edgeCount)
+		return *a.config
 	}
-	return a.AnalyzeAsyncWithConfig(ctx, config)
+	return TunedConfigForSize(len(a.issueMap), a.g.Edges().Len())
 }
 
 // AnalyzeAsyncWithConfig performs graph analysis with a custom configuration.
 // This allows callers to override the default size-based algorithm selection.
 func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisConfig) *GraphStats {
+	stats, start := a.analyze(ctx, config)
+	start()
+	return stats
+}
+
+// analyze computes Phase 1 and returns a function that launches Phase 2 in
+// the background; calls after the first are no-ops.
+func (a *Analyzer) analyze(ctx context.Context, config AnalysisConfig) (*GraphStats, func()) {
 	nodeCount := len(a.issueMap)
 	edgeCount := a.g.Edges().Len()
 
@@ -640,16 +664,17 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 		}
 		stats.phase2Ready = true
 		close(stats.phase2Done)
-		return stats
+		return stats, func() {}
 	}
 
 	// Phase 1: Fast metrics (degree centrality, topo sort, density)
 	a.computePhase1(stats)
 
 	// Phase 2: Expensive metrics in background goroutine
-	go a.computePhase2(ctx, stats, config)
-
-	return stats
+	var once sync.Once
+	return stats, func() {
+		once.Do(func() { go a.computePhase2(ctx, stats, config) })
+	}
 }
 
 // Analyze performs synchronous graph analysis (for backward compatibility).
@@ -799,6 +824,10 @@ func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupP
 }
 
 // computePhase2WithProfile calculates expensive metrics with timing instrumentation.
+// PageRank, Betweenness, Eigenvector, HITS, critical path and cycle detection are
+// independent of each other, so they run concurrently on a bounded worker pool
+// (see AnalysisConfig.Phase2Workers). All workers share a phase-wide deadline
+// equal to the longest enabled metric timeout; per-metric timeouts still apply.
 func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphStats, config AnalysisConfig, profile *StartupProfile) {
 	localPageRank := make(map[string]float64)
 	localBetweenness := make(map[string]float64)
@@ -815,192 +844,223 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	actualBetweennessSample := 0
 	cyclesTruncated := false
 
+	// phaseCtx carries the shared deadline; it is assigned once the pool size
+	// is known, before any task runs.
+	var phaseCtx context.Context
+	var tasks []func()
+
 	// PageRank
-	if ctx.Err() == nil && config.ComputePageRank {
-		prStart := time.Now()
-		prDone := make(chan map[int64]float64, 1)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Panic -> implicitly causes timeout in parent
-				}
+	if config.ComputePageRank {
+		tasks = append(tasks, func() {
+			prStart := time.Now()
+			prDone := make(chan map[int64]float64, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						// Panic -> implicitly causes timeout in parent
+					}
+				}()
+				prDone <- computePageRank(a.g, 0.85, 1e-6)
 			}()
-			prDone <- computePageRank(a.g, 0.85, 1e-6)
-		}()
 
-		timer := time.NewTimer(config.PageRankTimeout)
-		select {
-		case pr := <-prDone:
-			timer.Stop()
-			for id, score := range pr {
-				localPageRank[a.nodeToID[id]] = score
+			timer := time.NewTimer(config.PageRankTimeout)
+			defer timer.Stop()
+			select {
+			case pr := <-prDone:
+				for id, score := range pr {
+					localPageRank[a.nodeToID[id]] = score
+				}
+			case <-timer.C:
+				profile.PageRankTO = true
+			case <-phaseCtx.Done():
+				profile.PageRankTO = true
 			}
-		case <-timer.C:
-			profile.PageRankTO = true
-			if len(a.issueMap) > 0 {
+			if profile.PageRankTO && len(a.issueMap) > 0 {
 				uniform := 1.0 / float64(len(a.issueMap))
 				for id := range a.issueMap {
 					localPageRank[id] = uniform
 				}
 			}
-		case <-ctx.Done():
-			timer.Stop()
-			// Abort immediately
-			return
-		}
-		profile.PageRank = time.Since(prStart)
+			profile.PageRank = time.Since(prStart)
+		})
 	}
 
 	// Betweenness
-	if ctx.Err() == nil && config.ComputeBetweenness {
-		bwStart := time.Now()
-		bwDone := make(chan BetweennessResult, 1)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Panic -> implicitly causes timeout in parent
+	if config.ComputeBetweenness {
+		tasks = append(tasks, func() {
+			bwStart := time.Now()
+			bwDone := make(chan BetweennessResult, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						// Panic -> implicitly causes timeout in parent
+					}
+				}()
+				// Choose algorithm based on mode
+				if config.BetweennessMode == BetweennessApproximate && config.BetweennessAdaptive {
+					// Leave headroom so the final round finishes before the hard timeout.
+					bwDone <- AdaptiveBetweenness(a.g, AdaptiveBetweennessConfig{
+						InitialSample: config.BetweennessSampleSize,
+						MaxSample:     config.BetweennessMaxSampleSize,
+						Budget:        config.BetweennessTimeout / 2,
+						Seed:          1,
+					})
+				} else if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
+					bwDone <- ApproxBetweenness(a.g, config.BetweennessSampleSize, 1)
+				} else {
+					// Exact mode or mode not set (default to exact)
+					exact := network.Betweenness(a.g)
+					bwDone <- BetweennessResult{
+						Scores:     exact,
+						Mode:       BetweennessExact,
+						TotalNodes: a.g.Nodes().Len(),
+					}
 				}
 			}()
-			// Choose algorithm based on mode
-			if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
-				bwDone <- ApproxBetweenness(a.g, config.BetweennessSampleSize, 1)
-			} else {
-				// Exact mode or mode not set (default to exact)
-				exact := network.Betweenness(a.g)
-				bwDone <- BetweennessResult{
-					Scores:     exact,
-					Mode:       BetweennessExact,
-					TotalNodes: a.g.Nodes().Len(),
-				}
-			}
-		}()
 
-		timer := time.NewTimer(config.BetweennessTimeout)
-		select {
-		case result := <-bwDone:
-			timer.Stop()
-			for id, score := range result.Scores {
-				localBetweenness[a.nodeToID[id]] = score
-			}
-			// Track if approximation was used
-			if result.Mode == BetweennessApproximate {
-				betweennessIsApprox = true
-				actualBetweennessSample = result.SampleSize
+			timer := time.NewTimer(config.BetweennessTimeout)
+			defer timer.Stop()
+			select {
+			case result := <-bwDone:
+				for id, score := range result.Scores {
+					localBetweenness[a.nodeToID[id]] = score
+				}
+				// Track if approximation was used
+				if result.Mode == BetweennessApproximate {
+					betweennessIsApprox = true
+					actualBetweennessSample = result.SampleSize
+					profile.BetweennessSample = result.SampleSize
+					profile.BetweennessRounds = result.Rounds
+					profile.BetweennessError = result.EstimatedError
+					profile.BetweennessConverged = result.Converged
+				}
+			case <-timer.C:
+				profile.BetweennessTO = true
+			case <-phaseCtx.Done():
+				profile.BetweennessTO = true
 			}
-		case <-timer.C:
-			profile.BetweennessTO = true
-		case <-ctx.Done():
-			timer.Stop()
-			return
-		}
-		profile.Betweenness = time.Since(bwStart)
+			profile.Betweenness = time.Since(bwStart)
+		})
 	}
 
 	// Eigenvector
-	if ctx.Err() == nil && config.ComputeEigenvector {
-		evStart := time.Now()
-		for id, score := range computeEigenvector(a.g) {
-			localEigenvector[a.nodeToID[id]] = score
-		}
-		profile.Eigenvector = time.Since(evStart)
+	if config.ComputeEigenvector {
+		tasks = append(tasks, func() {
+			evStart := time.Now()
+			for id, score := range computeEigenvector(a.g) {
+				localEigenvector[a.nodeToID[id]] = score
+			}
+			profile.Eigenvector = time.Since(evStart)
+		})
 	}
 
 	// HITS
-	if ctx.Err() == nil && config.ComputeHITS && a.g.Edges().Len() > 0 {
-		hitsStart := time.Now()
-		hitsDone := make(chan map[int64]network.HubAuthority, 1)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Panic -> implicitly causes timeout in parent
-				}
+	if config.ComputeHITS && a.g.Edges().Len() > 0 {
+		tasks = append(tasks, func() {
+			hitsStart := time.Now()
+			hitsDone := make(chan map[int64]network.HubAuthority, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						// Panic -> implicitly causes timeout in parent
+					}
+				}()
+				hitsDone <- network.HITS(a.g, 1e-3)
 			}()
-			hitsDone <- network.HITS(a.g, 1e-3)
-		}()
 
-		timer := time.NewTimer(config.HITSTimeout)
-		select {
-		case hubAuth := <-hitsDone:
-			timer.Stop()
-			for id, ha := range hubAuth {
-				localHubs[a.nodeToID[id]] = ha.Hub
-				localAuthorities[a.nodeToID[id]] = ha.Authority
+			timer := time.NewTimer(config.HITSTimeout)
+			defer timer.Stop()
+			select {
+			case hubAuth := <-hitsDone:
+				for id, ha := range hubAuth {
+					localHubs[a.nodeToID[id]] = ha.Hub
+					localAuthorities[a.nodeToID[id]] = ha.Authority
+				}
+			case <-timer.C:
+				profile.HITSTO = true
+			case <-phaseCtx.Done():
+				profile.HITSTO = true
 			}
-		case <-timer.C:
-			profile.HITSTO = true
-		case <-ctx.Done():
-			timer.Stop()
-			return
-		}
-		profile.HITS = time.Since(hitsStart)
+			profile.HITS = time.Since(hitsStart)
+		})
 	}
 
 	// Critical Path
-	if ctx.Err() == nil && config.ComputeCriticalPath {
-		cpStart := time.Now()
-		sorted, err := topo.Sort(a.g)
-		if err == nil {
-			localCriticalPath = a.computeHeights(sorted)
-		}
-		profile.CriticalPath = time.Since(cpStart)
+	if config.ComputeCriticalPath {
+		tasks = append(tasks, func() {
+			cpStart := time.Now()
+			sorted, err := topo.Sort(a.g)
+			if err == nil {
+				localCriticalPath = a.computeHeights(sorted)
+			}
+			profile.CriticalPath = time.Since(cpStart)
+		})
 	}
 
 	// Cycles
-	if ctx.Err() == nil && config.ComputeCycles {
-		cyclesStart := time.Now()
-		maxCycles := config.MaxCyclesToStore
-		if maxCycles == 0 {
-			maxCycles = 100
-		}
+	if config.ComputeCycles {
+		tasks = append(tasks, func() {
+			cyclesStart := time.Now()
+			maxCycles := config.MaxCyclesToStore
+			if maxCycles == 0 {
+				maxCycles = 100
+			}
 
-		sccs := topo.TarjanSCC(a.g)
-		hasCycles := false
-		for _, scc := range sccs {
-			if len(scc) > 1 {
-				hasCycles = true
-				break
+			sccs := topo.TarjanSCC(a.g)
+			hasCycles := false
+			for _, scc := range sccs {
+				if len(scc) > 1 {
+					hasCycles = true
+					break
+				}
 			}
-		}
 
-		if hasCycles {
-			cyclesDone := make(chan [][]graph.Node, 1)
-			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						// Panic -> implicitly causes timeout in parent
-					}
+			if hasCycles {
+				cyclesDone := make(chan [][]graph.Node, 1)
+				go func() {
+					defer func() {
+						if r := recover(); r != nil {
+							// Panic -> implicitly causes timeout in parent
+						}
+					}()
+					cyclesDone <- findCyclesSafe(a.g, maxCycles)
 				}()
-				cyclesDone <- findCyclesSafe(a.g, maxCycles)
-			}()
 
-			timer := time.NewTimer(config.CyclesTimeout)
-			select {
-			case cycles := <-cyclesDone:
-				timer.Stop()
-				profile.CycleCount = len(cycles)
-				cyclesToProcess := cycles
-				if len(cyclesToProcess) > maxCycles {
-					cyclesToProcess = cyclesToProcess[:maxCycles]
-					cyclesTruncated = true
-				}
+				timer := time.NewTimer(config.CyclesTimeout)
+				defer timer.Stop()
+				select {
+				case cycles := <-cyclesDone:
+					profile.CycleCount = len(cycles)
+					cyclesToProcess := cycles
+					if len(cyclesToProcess) > maxCycles {
+						cyclesToProcess = cyclesToProcess[:maxCycles]
+						cyclesTruncated = true
+					}
 
-				for _, cycle := range cyclesToProcess {
-					var cycleIDs []string
-					for _, n := range cycle {
-						cycleIDs = append(cycleIDs, a.nodeToID[n.ID()])
+					for _, cycle := range cyclesToProcess {
+						var cycleIDs []string
+						for _, n := range cycle {
+							cycleIDs = append(cycleIDs, a.nodeToID[n.ID()])
+						}
+						localCycles = append(localCycles, cycleIDs)
 					}
-					localCycles = append(localCycles, cycleIDs)
+				case <-timer.C:
+					profile.CyclesTO = true
+				case <-phaseCtx.Done():
+					profile.CyclesTO = true
 				}
-			case <-timer.C:
-				profile.CyclesTO = true
-			case <-ctx.Done():
-				timer.Stop()
-				return
 			}
-		}
-		profile.Cycles = time.Since(cyclesStart)
+			profile.Cycles = time.Since(cyclesStart)
+		})
 	}
 
+	workers := config.phase2Workers(len(tasks))
+	profile.Phase2Workers = workers
+	var cancel context.CancelFunc
+	phaseCtx, cancel = context.WithTimeout(ctx, config.phase2Deadline(workers))
+	defer cancel()
+	runPhase2Tasks(ctx, workers, tasks)
+
 	// Check cancellation before advanced signals
 	if ctx.Err() != nil {
 		return
@@ -1062,6 +1122,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			State:   stateFromTiming(config.ComputeBetweenness, profile.BetweennessTO),
 			Reason:  betweennessReason(config, betweennessIsApprox),
 			Sample:  actualBetweennessSample,
+			Error:   profile.BetweennessError,
 			Elapsed: profile.Betweenness,
 		},
 		Eigenvector:  statusEntry{State: stateFromTiming(config.ComputeEigenvector, false), Elapsed: profile.Eigenvector},
@@ -1153,6 +1214,48 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 	a.computePhase2WithProfile(ctx, stats, config, dummyProfile)
 }
 
+// runPhase2Tasks runs independent metric computations on at most workers
+// goroutines and blocks until every started task has returned. Tasks that have
+// not started when ctx is cancelled are skipped; the caller discards results.
+func runPhase2Tasks(ctx context.Context, workers int, tasks []func()) {
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var panicked any
+
+	// Re-raise a worker panic on the calling goroutine so computePhase2's
+	// recover still marks metrics as failed instead of crashing the process.
+	defer func() {
+		if panicked != nil {
+			panic(panicked)
+		}
+	}()
+
+	for _, task := range tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func(run func()) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { panicked = r })
+				}
+			}()
+			run()
+		}(task)
+	}
+	wg.Wait()
+}
+
 func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
 	heights := make(map[int64]float64)
 	impactScores := make(map[string]float64)
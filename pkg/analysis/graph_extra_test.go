@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -55,3 +56,41 @@ func TestAnalyzerAnalyzeWithConfigCachesPhase2(t *testing.T) {
 	// Tiny sleep to avoid zero durations in formatDuration paths
 	time.Sleep(1 * time.Millisecond)
 }
+
+func TestAnalyzePhase1DefersPhase2(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	stats, start := NewAnalyzer(issues).AnalyzePhase1(context.Background())
+	if stats.InDegree["A"] != 1 {
+		t.Fatalf("expected Phase 1 metrics before start, got in-degree %d", stats.InDegree["A"])
+	}
+	time.Sleep(10 * time.Millisecond)
+	if stats.IsPhase2Ready() {
+		t.Fatal("Phase 2 should not run before start is called")
+	}
+	start()
+	start() // Second call must not launch Phase 2 again
+	stats.WaitForPhase2()
+	if stats.GetPageRankScore("A") == 0 {
+		t.Error("expected PageRank after Phase 2")
+	}
+
+	empty, start := NewAnalyzer(nil).AnalyzePhase1(context.Background())
+	start()
+	if !empty.IsPhase2Ready() {
+		t.Error("empty graph should be Phase 2 ready immediately")
+	}
+}
+
+// A self-dependency used to panic in gonum's SetEdge; it is ignored instead.
+func TestNewAnalyzerIgnoresSelfDependency(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	stats := NewAnalyzer(issues).Analyze()
+	if stats.NodeCount != 1 || stats.EdgeCount != 0 {
+		t.Errorf("expected 1 node and no edges, got %d nodes and %d edges", stats.NodeCount, stats.EdgeCount)
+	}
+}
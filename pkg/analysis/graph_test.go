@@ -722,3 +722,47 @@ func TestGetBlockerChain(t *testing.T) {
 		}
 	})
 }
+
+func TestAnalyzeParallelPhase2MatchesSequential(t *testing.T) {
+	var issues []model.Issue
+	for i := 0; i < 40; i++ {
+		issue := model.Issue{ID: fmt.Sprintf("P-%d", i), Title: "t", Status: model.StatusOpen}
+		if i > 0 {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: fmt.Sprintf("P-%d", i/2), Type: model.DepBlocks})
+		}
+		if i%7 == 0 && i > 0 {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: fmt.Sprintf("P-%d", i+1), Type: model.DepBlocks})
+		}
+		issues = append(issues, issue)
+	}
+	// Close a cycle so cycle detection has work to do.
+	issues[1].Dependencies = append(issues[1].Dependencies, &model.Dependency{DependsOnID: "P-3", Type: model.DepBlocks})
+
+	sequentialCfg := analysis.DefaultConfig()
+	sequentialCfg.Phase2Workers = 1
+	parallelCfg := analysis.DefaultConfig()
+	parallelCfg.Phase2Workers = 6
+
+	seq := analysis.NewAnalyzer(issues).AnalyzeWithConfig(sequentialCfg)
+	par, profile := analysis.NewAnalyzer(issues).AnalyzeWithProfile(parallelCfg)
+
+	if profile.Phase2Workers != 6 {
+		t.Errorf("profile.Phase2Workers = %d, want 6", profile.Phase2Workers)
+	}
+	for id, want := range seq.PageRank() {
+		if got := par.GetPageRankScore(id); fmt.Sprintf("%.9f", got) != fmt.Sprintf("%.9f", want) {
+			t.Errorf("pagerank[%s] = %v, want %v", id, got, want)
+		}
+	}
+	for id, want := range seq.Betweenness() {
+		if got := par.GetBetweennessScore(id); fmt.Sprintf("%.9f", got) != fmt.Sprintf("%.9f", want) {
+			t.Errorf("betweenness[%s] = %v, want %v", id, got, want)
+		}
+	}
+	if len(seq.Cycles()) != len(par.Cycles()) || len(par.Cycles()) == 0 {
+		t.Errorf("cycles differ: sequential=%v parallel=%v", seq.Cycles(), par.Cycles())
+	}
+	if st := par.Status(); st.PageRank.State != "computed" || st.HITS.State != "computed" {
+		t.Errorf("unexpected status after parallel run: %+v", st)
+	}
+}
@@ -0,0 +1,130 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Weights of the project health components; they sum to 1
+const (
+	healthBlockedWeight    = 0.25
+	healthCyclesWeight     = 0.15
+	healthStalenessWeight  = 0.20
+	healthInversionsWeight = 0.15
+	healthVelocityWeight   = 0.25
+)
+
+// HealthComponent is one input of the project health score.
+type HealthComponent struct {
+	Name   string  `json:"name"`   // blocked, cycles, staleness, inversions, velocity
+	Score  int     `json:"score"`  // 0-100, higher is healthier
+	Weight float64 `json:"weight"` // Share of the composite score
+	Detail string  `json:"detail"` // What the score is based on
+}
+
+// HealthScore is a composite 0-100 measure of project health.
+type HealthScore struct {
+	Score      int               `json:"score"`
+	Level      string            `json:"level"` // healthy, warning or critical
+	Components []HealthComponent `json:"components"`
+}
+
+// ComputeHealthScore rates the project from its open issues:
+//   - blocked: share of open issues waiting on open blockers
+//   - cycles: dependency cycles, 25 points each
+//   - staleness: share of open issues not updated in DefaultStaleThresholdDays
+//   - inversions: low-priority issues blocking P0/P1 work, 20 points each
+//   - velocity: closures in the last 4 weeks relative to the 4 before
+//
+// cycleCount comes from the graph analysis (GraphStats.Cycles).
+func ComputeHealthScore(issues []model.Issue, cycleCount int, now time.Time) HealthScore {
+	byID := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	staleBefore := now.Add(-DefaultStaleThresholdDays * 24 * time.Hour)
+
+	open, blocked, stale := 0, 0, 0
+	for _, issue := range issues {
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		open++
+		if issue.Status == model.StatusBlocked || hasOpenBlocker(issue, byID) {
+			blocked++
+		}
+		updated := issue.UpdatedAt
+		if updated.IsZero() {
+			updated = issue.CreatedAt
+		}
+		if !updated.IsZero() && updated.Before(staleBefore) {
+			stale++
+		}
+	}
+
+	inversions := 0
+	for _, item := range ComputeAttentionDigest(issues, DefaultDigestConfig(), now) {
+		if item.Kind == DigestPriorityInversion {
+			inversions++
+		}
+	}
+
+	// Weekly is newest first: compare the last 4 weeks with the 4 before
+	recent, prior := 0, 0
+	for i, week := range ComputeProjectVelocity(issues, now.UTC(), 8).Weekly {
+		if i < 4 {
+			recent += week.Closed
+		} else {
+			prior += week.Closed
+		}
+	}
+	velocity := 100
+	switch {
+	case prior > 0:
+		velocity = clampScore(int(math.Round(100 * float64(recent) / float64(prior))))
+	case recent == 0 && open > 0:
+		velocity = 50 // Nothing closing, but nothing to compare with either
+	}
+
+	share := func(n int) int {
+		if open == 0 {
+			return 100
+		}
+		return clampScore(int(math.Round(100 * (1 - float64(n)/float64(open)))))
+	}
+	components := []HealthComponent{
+		{Name: "blocked", Score: share(blocked), Weight: healthBlockedWeight,
+			Detail: fmt.Sprintf("%d of %d open issues blocked", blocked, open)},
+		{Name: "cycles", Score: clampScore(100 - 25*cycleCount), Weight: healthCyclesWeight,
+			Detail: fmt.Sprintf("%d dependency cycles", cycleCount)},
+		{Name: "staleness", Score: share(stale), Weight: healthStalenessWeight,
+			Detail: fmt.Sprintf("%d of %d open issues not updated in %d days", stale, open, DefaultStaleThresholdDays)},
+		{Name: "inversions", Score: clampScore(100 - 20*inversions), Weight: healthInversionsWeight,
+			Detail: fmt.Sprintf("%d low-priority issues blocking P0/P1 work", inversions)},
+		{Name: "velocity", Score: velocity, Weight: healthVelocityWeight,
+			Detail: fmt.Sprintf("%d closed in the last 4 weeks, %d in the 4 before", recent, prior)},
+	}
+
+	total := 0.0
+	for _, c := range components {
+		total += float64(c.Score) * c.Weight
+	}
+	score := clampScore(int(math.Round(total)))
+	return HealthScore{Score: score, Level: HealthLevelFromScore(score), Components: components}
+}
+
+// hasOpenBlocker reports whether a blocking dependency of issue is still open
+func hasOpenBlocker(issue model.Issue, byID map[string]model.Issue) bool {
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !blocker.Status.IsTombstone() {
+			return true
+		}
+	}
+	return false
+}
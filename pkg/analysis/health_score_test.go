@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeHealthScore(t *testing.T) {
+	now := time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+	closedAt := func(daysAgo int) *time.Time {
+		ts := now.Add(-time.Duration(daysAgo) * 24 * time.Hour)
+		return &ts
+	}
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "urgent", Priority: 0, Status: model.StatusOpen, UpdatedAt: recent, Dependencies: blocks("minor")},
+		{ID: "minor", Priority: 3, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "free", Priority: 2, Status: model.StatusInProgress, UpdatedAt: recent},
+		{ID: "other", Priority: 2, Status: model.StatusOpen, UpdatedAt: recent},
+		// One closure in the last 4 weeks against two in the 4 before
+		{ID: "c1", Status: model.StatusClosed, ClosedAt: closedAt(3)},
+		{ID: "c2", Status: model.StatusClosed, ClosedAt: closedAt(40)},
+		{ID: "c3", Status: model.StatusClosed, ClosedAt: closedAt(45)},
+	}
+
+	h := ComputeHealthScore(issues, 1, now)
+	want := map[string]int{"blocked": 75, "cycles": 75, "staleness": 75, "inversions": 80, "velocity": 50}
+	for _, c := range h.Components {
+		if c.Score != want[c.Name] {
+			t.Errorf("%s = %d (%s), want %d", c.Name, c.Score, c.Detail, want[c.Name])
+		}
+	}
+	// 0.25*75 + 0.15*75 + 0.20*75 + 0.15*80 + 0.25*50 = 69.5
+	if h.Score != 70 || h.Level != HealthLevelHealthy {
+		t.Errorf("score = %d %s, want 70 healthy", h.Score, h.Level)
+	}
+
+	if empty := ComputeHealthScore(nil, 0, now); empty.Score != 100 {
+		t.Errorf("an empty project should score 100, got %d", empty.Score)
+	}
+}
@@ -1557,7 +1557,7 @@ func ComputeLabelPageRank(sg LabelSubgraph) LabelPageRankResult {
 		}
 		for _, to := range toList {
 			toNode, exists := idToNode[to]
-			if !exists {
+			if !exists || toNode == fromNode {
 				continue
 			}
 			// Edge direction: blocker -> blocked (from blocks to)
@@ -1729,7 +1729,7 @@ func ComputeLabelCriticalPath(sg LabelSubgraph) LabelCriticalPathResult {
 		}
 		for _, to := range toList {
 			toNode, exists := idToNode[to]
-			if !exists {
+			if !exists || toNode == fromNode {
 				continue
 			}
 			g.SetEdge(g.NewEdge(g.Node(fromNode), g.Node(toNode)))
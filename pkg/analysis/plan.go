@@ -1,7 +1,9 @@
 package analysis
 
 import (
+	"fmt"
 	"sort"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
@@ -13,6 +15,15 @@ type PlanItem struct {
 	Priority    int      `json:"priority"`
 	Status      string   `json:"status"`
 	UnblocksIDs []string `json:"unblocks"` // Issues that become actionable when this is done
+
+	EstimatedMinutes int           `json:"estimated_minutes,omitempty"`
+	Schedule         *PlanSchedule `json:"schedule,omitempty"` // Balanced plans only
+}
+
+// PlanSchedule places an item on its agent's timeline, in minutes from now
+type PlanSchedule struct {
+	StartMinute  int `json:"start_minute"`
+	FinishMinute int `json:"finish_minute"`
 }
 
 // ExecutionTrack represents a group of related actionable items
@@ -20,6 +31,11 @@ type ExecutionTrack struct {
 	TrackID string     `json:"track_id"`
 	Items   []PlanItem `json:"items"`
 	Reason  string     `json:"reason"` // Why these are grouped
+
+	Agent            int  `json:"agent,omitempty"`    // 1-based agent index (balanced plans)
+	EstimatedMinutes int  `json:"estimated_minutes"`  // Open work owned by this track
+	MakespanMinutes  int  `json:"makespan_minutes"`   // Time until the track finishes, including waits on other tracks
+	Critical         bool `json:"critical,omitempty"` // Track that determines overall completion
 }
 
 // ExecutionPlan is the complete work plan with parallel tracks
@@ -28,6 +44,23 @@ type ExecutionPlan struct {
 	TotalActionable int              `json:"total_actionable"`
 	TotalBlocked    int              `json:"total_blocked"`
 	Summary         PlanSummary      `json:"summary"`
+
+	Mode            string `json:"mode"` // "structural" or "balanced"
+	Agents          int    `json:"agents,omitempty"`
+	MakespanMinutes int    `json:"makespan_minutes"`
+	CriticalTrack   string `json:"critical_track,omitempty"`
+}
+
+// PlanOptions tunes GetExecutionPlanWithOptions.
+type PlanOptions struct {
+	// Agents > 0 replaces structural tracks (one per connected work stream)
+	// with one balanced track per agent: all open issues are list-scheduled
+	// by estimated minutes, longest remaining chain first, respecting
+	// blocking dependencies.
+	Agents int
+
+	// Stats feeds dependency depth into per-issue estimates (optional).
+	Stats *GraphStats
 }
 
 // PlanSummary provides quick insights about the plan
@@ -40,6 +73,12 @@ type PlanSummary struct {
 // GetExecutionPlan generates a dependency-respecting execution plan
 // with parallel tracks identified for concurrent work.
 func (a *Analyzer) GetExecutionPlan() ExecutionPlan {
+	return a.GetExecutionPlanWithOptions(PlanOptions{})
+}
+
+// GetExecutionPlanWithOptions generates an execution plan annotated with
+// estimated minutes (see EstimateETAForIssue) and per-track makespans.
+func (a *Analyzer) GetExecutionPlanWithOptions(opts PlanOptions) ExecutionPlan {
 	actionable := a.GetActionableIssues()
 
 	// Build set of actionable IDs for quick lookup
@@ -58,8 +97,17 @@ func (a *Analyzer) GetExecutionPlan() ExecutionPlan {
 	// This groups actionable issues that belong to the same work stream
 	components := a.findConnectedComponents()
 
+	minutes := a.estimatePlanMinutes(opts.Stats)
+
 	// Build tracks from components, filtering to actionable issues only
-	tracks := a.buildTracks(components, actionableSet, unblocksMap)
+	mode := "structural"
+	var tracks []ExecutionTrack
+	if opts.Agents > 0 {
+		mode = "balanced"
+		tracks = a.buildBalancedTracks(opts.Agents, minutes)
+	} else {
+		tracks = a.buildTracks(components, actionableSet, unblocksMap, minutes)
+	}
 
 	// Calculate totals
 	totalOpen := 0
@@ -72,12 +120,51 @@ func (a *Analyzer) GetExecutionPlan() ExecutionPlan {
 	// Find highest impact issue
 	summary := a.computePlanSummary(actionable, unblocksMap)
 
-	return ExecutionPlan{
+	plan := ExecutionPlan{
 		Tracks:          tracks,
 		TotalActionable: len(actionable),
 		TotalBlocked:    totalOpen - len(actionable),
 		Summary:         summary,
+		Mode:            mode,
+		Agents:          opts.Agents,
+	}
+	markCriticalTrack(&plan)
+	return plan
+}
+
+// estimatePlanMinutes estimates work for every open issue using the ETA model.
+func (a *Analyzer) estimatePlanMinutes(stats *GraphStats) map[string]int {
+	issues := make([]model.Issue, 0, len(a.issueMap))
+	for _, issue := range a.issueMap {
+		issues = append(issues, issue)
+	}
+	median := computeMedianEstimatedMinutes(issues)
+	vm := LearnVelocityModel(issues, time.Now())
+
+	minutes := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		if issue.Status == model.StatusClosed || issue.Status.IsTombstone() {
+			continue
+		}
+		minutes[issue.ID], _ = estimateComplexityMinutes(issue, stats, median, vm)
+	}
+	return minutes
+}
+
+// markCriticalTrack flags the track with the longest makespan.
+func markCriticalTrack(plan *ExecutionPlan) {
+	critical := -1
+	for i, track := range plan.Tracks {
+		if critical < 0 || track.MakespanMinutes > plan.Tracks[critical].MakespanMinutes {
+			critical = i
+		}
+	}
+	if critical < 0 {
+		return
 	}
+	plan.Tracks[critical].Critical = true
+	plan.CriticalTrack = plan.Tracks[critical].TrackID
+	plan.MakespanMinutes = plan.Tracks[critical].MakespanMinutes
 }
 
 // computeUnblocks finds issues that would become actionable if the given issue is closed
@@ -210,7 +297,7 @@ func (a *Analyzer) findConnectedComponents() map[string][]string {
 }
 
 // buildTracks creates execution tracks from connected components
-func (a *Analyzer) buildTracks(components map[string][]string, actionableSet map[string]bool, unblocksMap map[string][]string) []ExecutionTrack {
+func (a *Analyzer) buildTracks(components map[string][]string, actionableSet map[string]bool, unblocksMap map[string][]string, minutes map[string]int) []ExecutionTrack {
 	var tracks []ExecutionTrack
 	trackNum := 1
 
@@ -248,14 +335,21 @@ func (a *Analyzer) buildTracks(components map[string][]string, actionableSet map
 		items := make([]PlanItem, len(actionableMembers))
 		for i, issue := range actionableMembers {
 			items[i] = PlanItem{
-				ID:          issue.ID,
-				Title:       issue.Title,
-				Priority:    issue.Priority,
-				Status:      string(issue.Status),
-				UnblocksIDs: unblocksMap[issue.ID],
+				ID:               issue.ID,
+				Title:            issue.Title,
+				Priority:         issue.Priority,
+				Status:           string(issue.Status),
+				UnblocksIDs:      unblocksMap[issue.ID],
+				EstimatedMinutes: minutes[issue.ID],
 			}
 		}
 
+		// One agent works the whole stream serially, blocked members included
+		streamMinutes := 0
+		for _, id := range members {
+			streamMinutes += minutes[id]
+		}
+
 		// Determine track reason
 		reason := "Independent work stream"
 		if len(actionableMembers) == 1 {
@@ -265,9 +359,11 @@ func (a *Analyzer) buildTracks(components map[string][]string, actionableSet map
 		}
 
 		tracks = append(tracks, ExecutionTrack{
-			TrackID: generateTrackID(trackNum),
-			Items:   items,
-			Reason:  reason,
+			TrackID:          generateTrackID(trackNum),
+			Items:            items,
+			Reason:           reason,
+			EstimatedMinutes: streamMinutes,
+			MakespanMinutes:  streamMinutes,
 		})
 		trackNum++
 	}
@@ -275,6 +371,172 @@ func (a *Analyzer) buildTracks(components map[string][]string, actionableSet map
 	return tracks
 }
 
+// buildBalancedTracks list-schedules every open issue onto agents. An agent
+// that frees up takes the released issue with the longest remaining chain
+// (its own minutes plus the longest path through open dependents); when
+// nothing is released yet it idles until the earliest blocker finishes.
+// Issues stuck in dependency cycles are released once nothing else can run.
+func (a *Analyzer) buildBalancedTracks(agents int, minutes map[string]int) []ExecutionTrack {
+	ids := make([]string, 0, len(minutes))
+	for id := range minutes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	blockers := make(map[string][]string, len(ids))
+	dependents := make(map[string][]string, len(ids))
+	for _, id := range ids {
+		for _, dep := range a.issueMap[id].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || dep.DependsOnID == id {
+				continue
+			}
+			if _, open := minutes[dep.DependsOnID]; !open {
+				continue
+			}
+			blockers[id] = append(blockers[id], dep.DependsOnID)
+			dependents[dep.DependsOnID] = append(dependents[dep.DependsOnID], id)
+		}
+	}
+
+	// Remaining chain length; cycles are cut by the in-progress guard.
+	chain := make(map[string]int, len(ids))
+	visiting := make(map[string]bool)
+	var rank func(id string) int
+	rank = func(id string) int {
+		if v, ok := chain[id]; ok {
+			return v
+		}
+		if visiting[id] {
+			return 0
+		}
+		visiting[id] = true
+		longest := 0
+		for _, d := range dependents[id] {
+			if r := rank(d); r > longest {
+				longest = r
+			}
+		}
+		visiting[id] = false
+		chain[id] = minutes[id] + longest
+		return chain[id]
+	}
+	for _, id := range ids {
+		rank(id)
+	}
+
+	pending := make(map[string]int, len(ids)) // Unscheduled blockers per issue
+	release := make(map[string]int, len(ids)) // Earliest start once released
+	var released []string
+	for _, id := range ids {
+		pending[id] = len(blockers[id])
+		if pending[id] == 0 {
+			released = append(released, id)
+		}
+	}
+
+	better := func(x, y string) bool {
+		if chain[x] != chain[y] {
+			return chain[x] > chain[y]
+		}
+		px, py := a.issueMap[x].Priority, a.issueMap[y].Priority
+		if px != py {
+			return px < py
+		}
+		return x < y
+	}
+
+	free := make([]int, agents)
+	items := make([][]PlanItem, agents)
+	work := make([]int, agents)
+	scheduled := make(map[string]bool, len(ids))
+	for len(scheduled) < len(ids) {
+		agent := 0
+		for k := range free {
+			if free[k] < free[agent] {
+				agent = k
+			}
+		}
+		now := free[agent]
+
+		if len(released) == 0 {
+			// Everything left waits on a cycle; release the best candidate.
+			var pick string
+			for _, id := range ids {
+				if !scheduled[id] && (pick == "" || better(id, pick)) {
+					pick = id
+				}
+			}
+			released = append(released, pick)
+		}
+
+		best := -1
+		nextRelease := -1
+		for i, id := range released {
+			if release[id] > now {
+				if nextRelease < 0 || release[id] < nextRelease {
+					nextRelease = release[id]
+				}
+				continue
+			}
+			if best < 0 || better(id, released[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			free[agent] = nextRelease
+			continue
+		}
+
+		id := released[best]
+		released = append(released[:best], released[best+1:]...)
+		scheduled[id] = true
+		finish := now + minutes[id]
+		free[agent] = finish
+		work[agent] += minutes[id]
+
+		issue := a.issueMap[id]
+		items[agent] = append(items[agent], PlanItem{
+			ID:               id,
+			Title:            issue.Title,
+			Priority:         issue.Priority,
+			Status:           string(issue.Status),
+			UnblocksIDs:      a.computeUnblocks(id),
+			EstimatedMinutes: minutes[id],
+			Schedule:         &PlanSchedule{StartMinute: now, FinishMinute: finish},
+		})
+
+		for _, d := range dependents[id] {
+			if scheduled[d] {
+				continue
+			}
+			if finish > release[d] {
+				release[d] = finish
+			}
+			pending[d]--
+			if pending[d] == 0 {
+				released = append(released, d)
+			}
+		}
+	}
+
+	var tracks []ExecutionTrack
+	for k := 0; k < agents; k++ {
+		if len(items[k]) == 0 {
+			continue
+		}
+		last := items[k][len(items[k])-1]
+		tracks = append(tracks, ExecutionTrack{
+			TrackID:          generateTrackID(len(tracks) + 1),
+			Items:            items[k],
+			Reason:           fmt.Sprintf("Agent %d of %d, balanced by estimated minutes", k+1, agents),
+			Agent:            k + 1,
+			EstimatedMinutes: work[k],
+			MakespanMinutes:  last.Schedule.FinishMinute,
+		})
+	}
+	return tracks
+}
+
 // computePlanSummary finds the highest-impact actionable issue
 func (a *Analyzer) computePlanSummary(actionable []model.Issue, unblocksMap map[string][]string) PlanSummary {
 	if len(actionable) == 0 {
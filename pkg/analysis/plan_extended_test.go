@@ -147,3 +147,121 @@ func TestPlan_ComplexPriorities(t *testing.T) {
 		t.Errorf("Expected p3 fourth, got %s", items[3].ID)
 	}
 }
+
+func planIssue(id string, minutes int, deps ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2, EstimatedMinutes: &minutes}
+	for _, d := range deps {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: d, Type: model.DepBlocks})
+	}
+	return issue
+}
+
+// TestPlan_StructuralTracksCarryEstimates tests makespan annotation of structural tracks
+func TestPlan_StructuralTracksCarryEstimates(t *testing.T) {
+	issues := []model.Issue{
+		planIssue("A", 30, "B"),
+		planIssue("B", 60),
+		planIssue("C", 45),
+	}
+
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlan()
+	if plan.Mode != "structural" || plan.Agents != 0 {
+		t.Errorf("expected structural mode, got %q with %d agents", plan.Mode, plan.Agents)
+	}
+	if len(plan.Tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %d", len(plan.Tracks))
+	}
+	// Track for A/B owns blocked A too: 30 + 60
+	if plan.Tracks[0].EstimatedMinutes != 90 || plan.Tracks[0].MakespanMinutes != 90 {
+		t.Errorf("track A/B minutes = %d/%d, want 90/90", plan.Tracks[0].EstimatedMinutes, plan.Tracks[0].MakespanMinutes)
+	}
+	if plan.Tracks[0].Items[0].EstimatedMinutes != 60 {
+		t.Errorf("item B estimate = %d, want 60", plan.Tracks[0].Items[0].EstimatedMinutes)
+	}
+	if !plan.Tracks[0].Critical || plan.Tracks[1].Critical || plan.CriticalTrack != plan.Tracks[0].TrackID {
+		t.Errorf("expected first track to be critical, got %+v", plan.Tracks)
+	}
+	if plan.MakespanMinutes != 90 {
+		t.Errorf("plan makespan = %d, want 90", plan.MakespanMinutes)
+	}
+}
+
+// TestPlan_BalancedAcrossAgents tests longest-chain-first list scheduling
+func TestPlan_BalancedAcrossAgents(t *testing.T) {
+	issues := []model.Issue{
+		planIssue("A", 120),
+		planIssue("B", 60),
+		planIssue("C", 60),
+		planIssue("D", 60),
+	}
+
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlanWithOptions(analysis.PlanOptions{Agents: 2})
+	if plan.Mode != "balanced" || len(plan.Tracks) != 2 {
+		t.Fatalf("expected 2 balanced tracks, got mode=%q tracks=%d", plan.Mode, len(plan.Tracks))
+	}
+	if plan.MakespanMinutes != 180 {
+		t.Errorf("makespan = %d, want 180", plan.MakespanMinutes)
+	}
+	total := 0
+	for _, track := range plan.Tracks {
+		total += track.EstimatedMinutes
+		if track.Agent == 0 {
+			t.Errorf("track %s missing agent index", track.TrackID)
+		}
+	}
+	if total != 300 {
+		t.Errorf("total scheduled minutes = %d, want 300", total)
+	}
+	if plan.Tracks[0].Items[0].ID != "A" {
+		t.Errorf("longest item should start first, got %s", plan.Tracks[0].Items[0].ID)
+	}
+}
+
+// TestPlan_BalancedRespectsDependencies tests that agents wait for blockers
+func TestPlan_BalancedRespectsDependencies(t *testing.T) {
+	issues := []model.Issue{
+		planIssue("A", 30, "B"),
+		planIssue("B", 60),
+		planIssue("C", 30),
+	}
+
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlanWithOptions(analysis.PlanOptions{Agents: 2})
+	finish := make(map[string]int)
+	start := make(map[string]int)
+	for _, track := range plan.Tracks {
+		for _, item := range track.Items {
+			if item.Schedule == nil {
+				t.Fatalf("balanced item %s missing schedule", item.ID)
+			}
+			start[item.ID] = item.Schedule.StartMinute
+			finish[item.ID] = item.Schedule.FinishMinute
+		}
+	}
+	if len(start) != 3 {
+		t.Fatalf("expected all open issues scheduled, got %v", start)
+	}
+	if start["A"] < finish["B"] {
+		t.Errorf("A starts at %d before blocker B finishes at %d", start["A"], finish["B"])
+	}
+	if plan.MakespanMinutes != 90 {
+		t.Errorf("makespan = %d, want 90", plan.MakespanMinutes)
+	}
+}
+
+// TestPlan_BalancedHandlesCycles tests that cyclic work is still scheduled
+func TestPlan_BalancedHandlesCycles(t *testing.T) {
+	issues := []model.Issue{
+		planIssue("A", 10, "B"),
+		planIssue("B", 10, "A"),
+		planIssue("C", 10, "A"),
+	}
+
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlanWithOptions(analysis.PlanOptions{Agents: 3})
+	count := 0
+	for _, track := range plan.Tracks {
+		count += len(track.Items)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 scheduled items, got %d", count)
+	}
+}
@@ -50,7 +50,8 @@ type ScoreBreakdown struct {
 	RiskSignals *RiskSignals `json:"risk_signals,omitempty"`
 }
 
-// Weights for composite score (total = 1.0)
+// Default weights for composite score (total = 1.0). .bv/analysis.yaml can
+// override them; see CurrentScoreWeights.
 const (
 	WeightPageRank      = 0.22 // Fundamental dependency importance
 	WeightBetweenness   = 0.20 // Bottleneck/bridging importance
@@ -119,6 +120,7 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 	medianMinutes := a.computeMedianEstimatedMinutes()
 
 	// Compute impact scores from stats
+	weights := CurrentScoreWeights()
 	var scores []ImpactScore
 
 	for id, issue := range a.issueMap {
@@ -149,14 +151,14 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 
 		// Compute weighted score
 		breakdown := ScoreBreakdown{
-			PageRank:      prNorm * WeightPageRank,
-			Betweenness:   bwNorm * WeightBetweenness,
-			BlockerRatio:  blockerNorm * WeightBlockerRatio,
-			Staleness:     stalenessNorm * WeightStaleness,
-			PriorityBoost: priorityNorm * WeightPriorityBoost,
-			TimeToImpact:  timeToImpactNorm * WeightTimeToImpact,
-			Urgency:       urgencyNorm * WeightUrgency,
-			Risk:          riskSignals.CompositeRisk * WeightRisk,
+			PageRank:      prNorm * weights.PageRank,
+			Betweenness:   bwNorm * weights.Betweenness,
+			BlockerRatio:  blockerNorm * weights.BlockerRatio,
+			Staleness:     stalenessNorm * weights.Staleness,
+			PriorityBoost: priorityNorm * weights.PriorityBoost,
+			TimeToImpact:  timeToImpactNorm * weights.TimeToImpact,
+			Urgency:       urgencyNorm * weights.Urgency,
+			Risk:          riskSignals.CompositeRisk * weights.Risk,
 
 			PageRankNorm:      prNorm,
 			BetweennessNorm:   bwNorm,
@@ -502,6 +504,7 @@ func (a *Analyzer) GenerateRecommendationsWithThresholds(thresholds Recommendati
 	stats := a.Analyze()
 	coreMap := stats.CoreNumber()
 	slackMap := stats.Slack()
+	criticalPath := stats.CriticalPathScore()
 	artSet := make(map[string]bool)
 	for _, id := range stats.ArticulationPoints() {
 		artSet[id] = true
@@ -535,7 +538,7 @@ func (a *Analyzer) GenerateRecommendationsWithThresholds(thresholds Recommendati
 		if rec != nil {
 			if rec.Confidence >= thresholds.MinConfidence {
 				// Compute what-if delta for this recommendation (bv-83)
-				rec.WhatIf = a.computeWhatIfDelta(score.IssueID)
+				rec.WhatIf = a.whatIfDelta(score.IssueID, criticalPath)
 				recommendations = append(recommendations, *rec)
 			}
 		}
@@ -766,8 +769,12 @@ const MaxUnblockedIDsShown = 10
 // computeWhatIfDelta calculates the impact of completing an issue (bv-83)
 func (a *Analyzer) computeWhatIfDelta(issueID string) *WhatIfDelta {
 	stats := a.Analyze()
-	criticalPath := stats.CriticalPathScore()
+	return a.whatIfDelta(issueID, stats.CriticalPathScore())
+}
 
+// whatIfDelta is computeWhatIfDelta with precomputed critical path scores,
+// so callers looping over many issues analyze the graph only once.
+func (a *Analyzer) whatIfDelta(issueID string, criticalPath map[string]float64) *WhatIfDelta {
 	// Get direct unblocks using existing method
 	directUnblocks := a.computeUnblocks(issueID)
 	directCount := len(directUnblocks)
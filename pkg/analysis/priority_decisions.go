@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PriorityDecisionsFile is the log of reviewed priority suggestions, kept in
+// the project's .bv directory
+const PriorityDecisionsFile = "priority_decisions.jsonl"
+
+// PriorityDecisionAction is what the user did with a priority suggestion
+type PriorityDecisionAction string
+
+const (
+	PriorityAccepted  PriorityDecisionAction = "accept"  // Priority rewritten in the beads file
+	PriorityDismissed PriorityDecisionAction = "dismiss" // Not suggested again unless it changes
+	PrioritySnoozed   PriorityDecisionAction = "snooze"  // Hidden until SnoozeUntil
+)
+
+// PriorityDecision is one entry of the decision log
+type PriorityDecision struct {
+	IssueID           string                 `json:"issue_id"`
+	Action            PriorityDecisionAction `json:"action"`
+	CurrentPriority   int                    `json:"current_priority"`
+	SuggestedPriority int                    `json:"suggested_priority"`
+	ImpactScore       float64                `json:"impact_score"`
+	DecidedAt         time.Time              `json:"decided_at"`
+	SnoozeUntil       *time.Time             `json:"snooze_until,omitempty"`
+}
+
+// NewPriorityDecision records action on rec at now
+func NewPriorityDecision(rec PriorityRecommendation, action PriorityDecisionAction, now time.Time) PriorityDecision {
+	return PriorityDecision{
+		IssueID:           rec.IssueID,
+		Action:            action,
+		CurrentPriority:   rec.CurrentPriority,
+		SuggestedPriority: rec.SuggestedPriority,
+		ImpactScore:       rec.ImpactScore,
+		DecidedAt:         now.UTC(),
+	}
+}
+
+// PriorityDecisionLog is an append-only JSONL log of decisions on priority
+// suggestions. The latest decision per issue decides whether a suggestion
+// is hidden. A nil log hides nothing.
+type PriorityDecisionLog struct {
+	path   string
+	mu     sync.RWMutex
+	latest map[string]PriorityDecision
+}
+
+// LoadPriorityDecisionLog reads .bv/priority_decisions.jsonl from projectDir.
+// A missing file yields an empty log; malformed lines are skipped.
+func LoadPriorityDecisionLog(projectDir string) (*PriorityDecisionLog, error) {
+	log := &PriorityDecisionLog{
+		path:   filepath.Join(projectDir, ".bv", PriorityDecisionsFile),
+		latest: make(map[string]PriorityDecision),
+	}
+
+	file, err := os.Open(log.path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening priority decisions: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var d PriorityDecision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil || d.IssueID == "" {
+			continue
+		}
+		log.latest[d.IssueID] = d
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading priority decisions: %w", err)
+	}
+	return log, nil
+}
+
+// Record appends d to the log file, creating .bv if needed
+func (l *PriorityDecisionLog) Record(d PriorityDecision) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("creating .bv directory: %w", err)
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("encoding priority decision: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening priority decisions: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing priority decision: %w", err)
+	}
+
+	l.latest[d.IssueID] = d
+	return nil
+}
+
+// Latest returns the most recent decision for issueID
+func (l *PriorityDecisionLog) Latest(issueID string) (PriorityDecision, bool) {
+	if l == nil {
+		return PriorityDecision{}, false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	d, ok := l.latest[issueID]
+	return d, ok
+}
+
+// Hides reports whether rec should not be suggested at now: it was dismissed
+// while suggesting the same change, or it is snoozed
+func (l *PriorityDecisionLog) Hides(rec PriorityRecommendation, now time.Time) bool {
+	d, ok := l.Latest(rec.IssueID)
+	if !ok {
+		return false
+	}
+	switch d.Action {
+	case PriorityDismissed:
+		return d.CurrentPriority == rec.CurrentPriority && d.SuggestedPriority == rec.SuggestedPriority
+	case PrioritySnoozed:
+		return d.SnoozeUntil != nil && now.Before(*d.SnoozeUntil)
+	}
+	return false
+}
+
+// Pending returns the recommendations the log does not hide, in order
+func (l *PriorityDecisionLog) Pending(recs []PriorityRecommendation, now time.Time) []PriorityRecommendation {
+	pending := make([]PriorityRecommendation, 0, len(recs))
+	for _, rec := range recs {
+		if !l.Hides(rec, now) {
+			pending = append(pending, rec)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPriorityDecisionLog(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recs := []PriorityRecommendation{
+		{IssueID: "a", CurrentPriority: 3, SuggestedPriority: 1},
+		{IssueID: "b", CurrentPriority: 2, SuggestedPriority: 0},
+		{IssueID: "c", CurrentPriority: 0, SuggestedPriority: 2},
+	}
+
+	log, err := LoadPriorityDecisionLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := log.Pending(recs, now); len(got) != 3 {
+		t.Fatalf("an empty log should hide nothing, got %d pending", len(got))
+	}
+
+	if err := log.Record(NewPriorityDecision(recs[0], PriorityDismissed, now)); err != nil {
+		t.Fatal(err)
+	}
+	snooze := NewPriorityDecision(recs[1], PrioritySnoozed, now)
+	until := now.Add(24 * time.Hour)
+	snooze.SnoozeUntil = &until
+	if err := log.Record(snooze); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reload from disk to check persistence
+	log, err = LoadPriorityDecisionLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := log.Pending(recs, now)
+	if len(pending) != 1 || pending[0].IssueID != "c" {
+		t.Errorf("expected only c pending, got %+v", pending)
+	}
+
+	// A snooze expires; a dismissal holds only for the same suggestion
+	later := until.Add(time.Minute)
+	changed := recs[0]
+	changed.SuggestedPriority = 0
+	if log.Hides(recs[1], later) {
+		t.Error("an expired snooze should not hide the suggestion")
+	}
+	if !log.Hides(recs[0], later) {
+		t.Error("a dismissal should keep hiding the same suggestion")
+	}
+	if log.Hides(changed, now) {
+		t.Error("a dismissal should not hide a different suggestion")
+	}
+
+	// Malformed lines are skipped
+	path := filepath.Join(dir, ".bv", PriorityDecisionsFile)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+	if log, err = LoadPriorityDecisionLog(dir); err != nil || !log.Hides(recs[0], now) {
+		t.Errorf("malformed lines should be skipped (err %v)", err)
+	}
+
+	var none *PriorityDecisionLog
+	if none.Hides(recs[0], now) {
+		t.Error("a nil log should hide nothing")
+	}
+}
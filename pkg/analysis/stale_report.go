@@ -0,0 +1,307 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// StaleReportConfig configures --robot-stale output.
+type StaleReportConfig struct {
+	// ThresholdDays is how long an unresolved issue may go without an update
+	// before it counts as stale.
+	// Default: 14
+	ThresholdDays int
+
+	// InProgressMultiplier scales the threshold for in-progress work, which is
+	// expected to move faster than the backlog.
+	// Default: 0.5
+	InProgressMultiplier float64
+
+	// LabelThresholdDays overrides ThresholdDays for issues carrying a label.
+	// When several labels match, the tightest threshold wins.
+	LabelThresholdDays map[string]int
+}
+
+// DefaultStaleReportConfig returns sensible defaults.
+func DefaultStaleReportConfig() StaleReportConfig {
+	return StaleReportConfig{
+		ThresholdDays:        14,
+		InProgressMultiplier: 0.5,
+	}
+}
+
+// thresholdFor returns the staleness threshold in days for an issue.
+func (c StaleReportConfig) thresholdFor(issue model.Issue) int {
+	days := 0
+	for _, label := range issue.Labels {
+		if d, ok := c.LabelThresholdDays[label]; ok && d > 0 && (days == 0 || d < days) {
+			days = d
+		}
+	}
+	if days == 0 {
+		days = c.ThresholdDays
+	}
+	if issue.Status == model.StatusInProgress {
+		days = int(float64(days) * c.InProgressMultiplier)
+	}
+	if days < 1 {
+		days = 1
+	}
+	return days
+}
+
+// StaleAction is a suggested next step for a stale issue.
+type StaleAction struct {
+	Action  string `json:"action"`
+	Reason  string `json:"reason"`
+	Command string `json:"command,omitempty"`
+}
+
+// StaleIssue is one issue that has not been updated within its threshold.
+type StaleIssue struct {
+	ID               string        `json:"id"`
+	Title            string        `json:"title"`
+	Status           string        `json:"status"`
+	Priority         int           `json:"priority"`
+	Assignee         string        `json:"assignee,omitempty"`
+	Labels           []string      `json:"labels,omitempty"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+	StalenessDays    int           `json:"staleness_days"`
+	ThresholdDays    int           `json:"threshold_days"`
+	Severity         string        `json:"severity"`                // "warning", or "critical" at twice the threshold
+	OpenBlockers     []string      `json:"open_blockers,omitempty"` // Unresolved issues blocking this one
+	SuggestedActions []StaleAction `json:"suggested_actions"`
+}
+
+// StaleStatusGroup holds the stale issues sharing a status.
+type StaleStatusGroup struct {
+	Status string       `json:"status"`
+	Count  int          `json:"count"`
+	Issues []StaleIssue `json:"issues"`
+}
+
+// StaleReport lists stale issues grouped by status, most stale first.
+type StaleReport struct {
+	ThresholdDays           int                `json:"threshold_days"`
+	InProgressThresholdDays int                `json:"in_progress_threshold_days"`
+	LabelThresholdDays      map[string]int     `json:"label_threshold_days,omitempty"`
+	UnresolvedCount         int                `json:"unresolved_count"`
+	StaleCount              int                `json:"stale_count"`
+	CriticalCount           int                `json:"critical_count"`
+	Groups                  []StaleStatusGroup `json:"groups"`
+}
+
+// RobotStaleOutput is the JSON output structure for --robot-stale.
+type RobotStaleOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	StaleReport
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotStaleOutput creates the full robot-stale output.
+func GenerateRobotStaleOutput(issues []model.Issue, config StaleReportConfig, dataHash string, now time.Time) RobotStaleOutput {
+	return RobotStaleOutput{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		StaleReport: BuildStaleReport(issues, config, now),
+		UsageHints: []string{
+			"jq '.groups[] | {status, count}' - Stale counts per status",
+			"jq '.groups[].issues[] | select(.severity == \"critical\") | .id' - Issues stale for twice their threshold",
+			"jq '.groups[].issues[].suggested_actions[0].command' - First suggested command per issue",
+			"--stale-days N - Override the threshold (default from .bv/drift.yaml stale_warning_days)",
+		},
+	}
+}
+
+// staleStatusOrder lists groups from most to least urgent.
+var staleStatusOrder = []model.Status{model.StatusInProgress, model.StatusBlocked, model.StatusOpen}
+
+// BuildStaleReport finds unresolved issues that have not been updated within
+// their threshold and suggests a hygiene action for each.
+func BuildStaleReport(issues []model.Issue, config StaleReportConfig, now time.Time) StaleReport {
+	defaults := DefaultStaleReportConfig()
+	if config.ThresholdDays <= 0 {
+		config.ThresholdDays = defaults.ThresholdDays
+	}
+	if config.InProgressMultiplier <= 0 {
+		config.InProgressMultiplier = defaults.InProgressMultiplier
+	}
+
+	report := StaleReport{
+		ThresholdDays:           config.ThresholdDays,
+		InProgressThresholdDays: config.thresholdFor(model.Issue{Status: model.StatusInProgress}),
+		LabelThresholdDays:      config.LabelThresholdDays,
+		Groups:                  []StaleStatusGroup{},
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+
+	byStatus := make(map[model.Status][]StaleIssue)
+	for _, issue := range issues {
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		report.UnresolvedCount++
+
+		updated := issue.UpdatedAt
+		if updated.IsZero() {
+			updated = issue.CreatedAt
+		}
+		if updated.IsZero() {
+			continue
+		}
+		days := int(now.Sub(updated).Hours() / 24)
+		threshold := config.thresholdFor(issue)
+		if days < threshold {
+			continue
+		}
+
+		var blockers []string
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, ok := issueMap[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !blocker.Status.IsTombstone() {
+				blockers = append(blockers, blocker.ID)
+			}
+		}
+
+		severity := "warning"
+		if days >= 2*threshold {
+			severity = "critical"
+			report.CriticalCount++
+		}
+
+		byStatus[issue.Status] = append(byStatus[issue.Status], StaleIssue{
+			ID:               issue.ID,
+			Title:            issue.Title,
+			Status:           string(issue.Status),
+			Priority:         issue.Priority,
+			Assignee:         issue.Assignee,
+			Labels:           issue.Labels,
+			UpdatedAt:        updated,
+			StalenessDays:    days,
+			ThresholdDays:    threshold,
+			Severity:         severity,
+			OpenBlockers:     blockers,
+			SuggestedActions: suggestStaleActions(issue, days, threshold, blockers),
+		})
+		report.StaleCount++
+	}
+
+	// Known statuses first, then anything else alphabetically
+	order := append([]model.Status{}, staleStatusOrder...)
+	var extra []model.Status
+	for status := range byStatus {
+		known := false
+		for _, s := range staleStatusOrder {
+			known = known || s == status
+		}
+		if !known {
+			extra = append(extra, status)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+	order = append(order, extra...)
+
+	for _, status := range order {
+		items := byStatus[status]
+		if len(items) == 0 {
+			continue
+		}
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].StalenessDays != items[j].StalenessDays {
+				return items[i].StalenessDays > items[j].StalenessDays
+			}
+			if items[i].Priority != items[j].Priority {
+				return items[i].Priority < items[j].Priority
+			}
+			return items[i].ID < items[j].ID
+		})
+		report.Groups = append(report.Groups, StaleStatusGroup{
+			Status: string(status),
+			Count:  len(items),
+			Issues: items,
+		})
+	}
+
+	return report
+}
+
+// suggestStaleActions proposes hygiene steps for a stale issue, most useful first.
+func suggestStaleActions(issue model.Issue, days, threshold int, blockers []string) []StaleAction {
+	var actions []StaleAction
+	switch issue.Status {
+	case model.StatusInProgress:
+		actions = append(actions,
+			StaleAction{
+				Action:  "check_in",
+				Reason:  fmt.Sprintf("In progress with no update for %dd", days),
+				Command: fmt.Sprintf("bd show %s", issue.ID),
+			},
+			StaleAction{
+				Action:  "release",
+				Reason:  "Return to the backlog if nobody is actively working on it",
+				Command: fmt.Sprintf("bd update %s --status=open", issue.ID),
+			},
+		)
+	case model.StatusBlocked:
+		if len(blockers) == 0 {
+			actions = append(actions, StaleAction{
+				Action:  "unblock",
+				Reason:  "Marked blocked but every blocker is resolved",
+				Command: fmt.Sprintf("bd update %s --status=open", issue.ID),
+			})
+		} else {
+			actions = append(actions, StaleAction{
+				Action:  "chase_blocker",
+				Reason:  fmt.Sprintf("Blocked by %d open issue(s); %s is first", len(blockers), blockers[0]),
+				Command: fmt.Sprintf("bd show %s", blockers[0]),
+			})
+		}
+	default:
+		if len(blockers) > 0 {
+			actions = append(actions, StaleAction{
+				Action:  "chase_blocker",
+				Reason:  fmt.Sprintf("Waiting on %d open issue(s); %s is first", len(blockers), blockers[0]),
+				Command: fmt.Sprintf("bd show %s", blockers[0]),
+			})
+		} else if issue.Priority <= 1 {
+			actions = append(actions, StaleAction{
+				Action:  "claim",
+				Reason:  fmt.Sprintf("P%d and unblocked, yet untouched for %dd", issue.Priority, days),
+				Command: fmt.Sprintf("bd update %s --status=in_progress", issue.ID),
+			})
+		}
+		if issue.Priority < 4 {
+			actions = append(actions, StaleAction{
+				Action:  "deprioritize",
+				Reason:  "Lower the priority if it is no longer important",
+				Command: fmt.Sprintf("bd update %s --priority=%d", issue.ID, issue.Priority+1),
+			})
+		}
+	}
+
+	if days >= 2*threshold && issue.Status != model.StatusInProgress {
+		actions = append(actions, StaleAction{
+			Action:  "close",
+			Reason:  fmt.Sprintf("Untouched for %dd (over twice the %dd threshold); close if obsolete", days, threshold),
+			Command: fmt.Sprintf("bd close %s", issue.ID),
+		})
+	}
+	if len(actions) == 0 {
+		actions = append(actions, StaleAction{
+			Action:  "review",
+			Reason:  "Confirm the issue is still relevant",
+			Command: fmt.Sprintf("bd show %s", issue.ID),
+		})
+	}
+	return actions
+}
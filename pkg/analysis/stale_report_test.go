@@ -0,0 +1,93 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildStaleReport_GroupsByStatus(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	issues := []model.Issue{
+		{ID: "fresh", Status: model.StatusOpen, Priority: 2, UpdatedAt: ago(3)},
+		{ID: "old", Status: model.StatusOpen, Priority: 3, UpdatedAt: ago(40)},
+		{ID: "older", Status: model.StatusOpen, Priority: 1, UpdatedAt: ago(20)},
+		{ID: "wip", Status: model.StatusInProgress, Priority: 1, UpdatedAt: ago(8)},
+		{ID: "wip-fresh", Status: model.StatusInProgress, Priority: 1, UpdatedAt: ago(5)},
+		{ID: "stuck", Status: model.StatusBlocked, Priority: 2, UpdatedAt: ago(15), Dependencies: []*model.Dependency{
+			{IssueID: "stuck", DependsOnID: "done", Type: model.DepBlocks},
+		}},
+		{ID: "done", Status: model.StatusClosed, UpdatedAt: ago(100)},
+		{ID: "no-date", Status: model.StatusOpen},
+	}
+
+	report := BuildStaleReport(issues, DefaultStaleReportConfig(), now)
+
+	if report.ThresholdDays != 14 || report.InProgressThresholdDays != 7 {
+		t.Errorf("unexpected thresholds %d/%d", report.ThresholdDays, report.InProgressThresholdDays)
+	}
+	if report.UnresolvedCount != 7 || report.StaleCount != 4 || report.CriticalCount != 1 {
+		t.Errorf("unexpected counts: unresolved=%d stale=%d critical=%d",
+			report.UnresolvedCount, report.StaleCount, report.CriticalCount)
+	}
+
+	var order []string
+	for _, g := range report.Groups {
+		order = append(order, g.Status)
+	}
+	if len(order) != 3 || order[0] != "in_progress" || order[1] != "blocked" || order[2] != "open" {
+		t.Fatalf("unexpected group order %v", order)
+	}
+
+	open := report.Groups[2]
+	if open.Count != 2 || open.Issues[0].ID != "old" || open.Issues[1].ID != "older" {
+		t.Fatalf("open group should be sorted most stale first, got %+v", open.Issues)
+	}
+	if open.Issues[0].Severity != "critical" || open.Issues[0].StalenessDays != 40 {
+		t.Errorf("40d against a 14d threshold should be critical, got %+v", open.Issues[0])
+	}
+	if last := open.Issues[0].SuggestedActions[len(open.Issues[0].SuggestedActions)-1]; last.Command != "bd close old" {
+		t.Errorf("critical issue should suggest closing, got %+v", last)
+	}
+	if first := open.Issues[1].SuggestedActions[0]; first.Action != "claim" {
+		t.Errorf("unblocked P1 should suggest claiming, got %+v", first)
+	}
+
+	blocked := report.Groups[1].Issues[0]
+	if len(blocked.OpenBlockers) != 0 || blocked.SuggestedActions[0].Command != "bd update stuck --status=open" {
+		t.Errorf("blocked issue with closed blockers should suggest unblocking, got %+v", blocked)
+	}
+
+	wip := report.Groups[0].Issues
+	if len(wip) != 1 || wip[0].ID != "wip" || wip[0].ThresholdDays != 7 {
+		t.Errorf("in-progress threshold should be halved, got %+v", wip)
+	}
+}
+
+func TestBuildStaleReport_LabelThresholds(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "hot", Status: model.StatusOpen, Labels: []string{"urgent", "api"}, UpdatedAt: now.AddDate(0, 0, -4)},
+		{ID: "slow", Status: model.StatusOpen, Labels: []string{"research"}, UpdatedAt: now.AddDate(0, 0, -20)},
+	}
+	config := DefaultStaleReportConfig()
+	config.LabelThresholdDays = map[string]int{"urgent": 3, "api": 10, "research": 60}
+
+	report := BuildStaleReport(issues, config, now)
+	if report.StaleCount != 1 || report.Groups[0].Issues[0].ID != "hot" {
+		t.Fatalf("expected only hot to be stale, got %+v", report.Groups)
+	}
+	if got := report.Groups[0].Issues[0].ThresholdDays; got != 3 {
+		t.Errorf("tightest label threshold should win, got %d", got)
+	}
+}
+
+func TestBuildStaleReport_Empty(t *testing.T) {
+	report := BuildStaleReport(nil, StaleReportConfig{}, time.Now())
+	if report.Groups == nil || report.StaleCount != 0 || report.ThresholdDays != 14 {
+		t.Errorf("empty input should give defaults and a non-nil group list, got %+v", report)
+	}
+}
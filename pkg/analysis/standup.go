@@ -0,0 +1,229 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// StandupItem is one issue in a standup section.
+type StandupItem struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Status   model.Status `json:"status"`
+	Priority int          `json:"priority"`
+	Assignee string       `json:"assignee,omitempty"`
+	Reason   string       `json:"reason,omitempty"`
+}
+
+// Standup summarizes what changed in a period, standup style.
+type Standup struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Baseline is the commit whose beads file was compared with the current
+	// one; empty when the sections come from issue timestamps alone
+	Baseline     string        `json:"baseline,omitempty"`
+	Closed       []StandupItem `json:"closed"`
+	Opened       []StandupItem `json:"opened"`
+	NewlyBlocked []StandupItem `json:"newly_blocked"`
+	Unblocked    []StandupItem `json:"unblocked"`
+	Stalled      []StandupItem `json:"stalled"` // In progress without recent updates
+}
+
+// ComputeStandup reports the issues closed, opened, newly blocked and
+// unblocked since since, plus in-progress work that has stalled.
+//
+// With a baseline (the issues as of since, from git history) statuses and
+// blockers are compared directly. Without one (baseline nil) the sections
+// are derived from created_at, closed_at and updated_at, and an issue
+// counts as unblocked when one of its blockers closed in the period.
+func ComputeStandup(baseline []model.Issue, baselineRevision string, issues []model.Issue, since, now time.Time) Standup {
+	s := Standup{
+		Since:        since,
+		Until:        now,
+		Baseline:     baselineRevision,
+		Closed:       []StandupItem{},
+		Opened:       []StandupItem{},
+		NewlyBlocked: []StandupItem{},
+		Unblocked:    []StandupItem{},
+		Stalled:      []StandupItem{},
+	}
+
+	byID := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	var before map[string]model.Issue
+	if baseline != nil {
+		before = make(map[string]model.Issue, len(baseline))
+		for _, issue := range baseline {
+			before[issue.ID] = issue
+		}
+	}
+	inPeriod := func(t *time.Time) bool {
+		return t != nil && !t.IsZero() && !t.Before(since)
+	}
+
+	for _, issue := range issues {
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		old, existed := before[issue.ID]
+		if before == nil {
+			existed = !inPeriod(&issue.CreatedAt)
+		}
+
+		if !existed {
+			s.Opened = append(s.Opened, standupItem(issue, ""))
+		}
+		if issue.Status.IsClosed() {
+			closedInPeriod := inPeriod(issue.ClosedAt)
+			if before != nil {
+				closedInPeriod = !existed || !old.Status.IsClosed()
+			}
+			if closedInPeriod {
+				s.Closed = append(s.Closed, standupItem(issue, ""))
+			}
+			continue
+		}
+		if !existed {
+			continue
+		}
+
+		blockers := standupBlockers(issue, byID)
+		blocked := issue.Status == model.StatusBlocked || len(blockers) > 0
+		var newlyBlocked, unblocked bool
+		if before != nil {
+			wasBlocked := old.Status == model.StatusBlocked || len(standupBlockers(old, before)) > 0
+			newlyBlocked = blocked && !wasBlocked
+			unblocked = !blocked && wasBlocked
+		} else if blocked {
+			// Marked blocked in the period, or blocked by an issue opened in it
+			newlyBlocked = issue.Status == model.StatusBlocked && inPeriod(&issue.UpdatedAt)
+			for _, id := range blockers {
+				if blocker := byID[id]; inPeriod(&blocker.CreatedAt) {
+					newlyBlocked = true
+				}
+			}
+		} else {
+			for _, dep := range issue.Dependencies {
+				if dep != nil && dep.Type.IsBlocking() && inPeriod(byID[dep.DependsOnID].ClosedAt) {
+					unblocked = true
+				}
+			}
+		}
+
+		if newlyBlocked {
+			reason := "marked blocked"
+			if len(blockers) > 0 {
+				reason = "blocked by " + strings.Join(blockers, ", ")
+			}
+			s.NewlyBlocked = append(s.NewlyBlocked, standupItem(issue, reason))
+		}
+		if unblocked {
+			s.Unblocked = append(s.Unblocked, standupItem(issue, "ready to start"))
+		}
+	}
+
+	for _, item := range ComputeAttentionDigest(issues, DefaultDigestConfig(), now) {
+		if item.Kind == DigestStaleInProgress {
+			s.Stalled = append(s.Stalled, standupItem(byID[item.IssueID], item.Reason))
+		}
+	}
+
+	for _, section := range [][]StandupItem{s.Closed, s.Opened, s.NewlyBlocked, s.Unblocked} {
+		sort.Slice(section, func(i, j int) bool {
+			if section[i].Priority != section[j].Priority {
+				return section[i].Priority < section[j].Priority
+			}
+			return section[i].ID < section[j].ID
+		})
+	}
+	return s
+}
+
+// standupBlockers returns the open issues in byID that block issue
+func standupBlockers(issue model.Issue, byID map[string]model.Issue) []string {
+	var ids []string
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !blocker.Status.IsTombstone() {
+			ids = append(ids, blocker.ID)
+		}
+	}
+	return ids
+}
+
+func standupItem(issue model.Issue, reason string) StandupItem {
+	return StandupItem{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   issue.Status,
+		Priority: issue.Priority,
+		Assignee: issue.Assignee,
+		Reason:   reason,
+	}
+}
+
+// Markdown renders the standup as a Markdown document for chat or notes.
+func (s Standup) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Standup: %s – %s\n", s.Since.Local().Format("Mon Jan 2 15:04"), s.Until.Local().Format("Mon Jan 2 15:04")))
+	sections := []struct {
+		title string
+		items []StandupItem
+	}{
+		{"Closed", s.Closed},
+		{"Opened", s.Opened},
+		{"Newly blocked", s.NewlyBlocked},
+		{"Unblocked", s.Unblocked},
+		{"Stalled in progress", s.Stalled},
+	}
+	for _, section := range sections {
+		sb.WriteString(fmt.Sprintf("\n## %s (%d)\n\n", section.title, len(section.items)))
+		if len(section.items) == 0 {
+			sb.WriteString("_None_\n")
+			continue
+		}
+		for _, item := range section.items {
+			sb.WriteString(fmt.Sprintf("- **%s** %s (P%d", item.ID, item.Title, item.Priority))
+			if item.Assignee != "" {
+				sb.WriteString(", @" + item.Assignee)
+			}
+			sb.WriteString(")")
+			if item.Reason != "" {
+				sb.WriteString(" — " + item.Reason)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// RobotStandupOutput is the --robot-standup output.
+type RobotStandupOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	Standup
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotStandupOutput wraps a standup for --robot-standup.
+func GenerateRobotStandupOutput(s Standup, dataHash string) RobotStandupOutput {
+	return RobotStandupOutput{
+		GeneratedAt: s.Until.UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		Standup:     s,
+		UsageHints: []string{
+			"jq '.closed[] | .id' - What got done",
+			"jq '.newly_blocked[] | {id, reason}' - New blockers to raise",
+			"jq '.stalled[] | {id, assignee, reason}' - In-progress work to check on",
+			"--format=markdown - The same summary as Markdown",
+		},
+	}
+}
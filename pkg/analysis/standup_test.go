@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeStandup(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	hoursAgo := func(h int) time.Time { return now.Add(-time.Duration(h) * time.Hour) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	week := 24 * 8
+	issues := []model.Issue{
+		{ID: "done", Title: "Done", Status: model.StatusClosed, CreatedAt: hoursAgo(week), ClosedAt: ptr(hoursAgo(2))},
+		{ID: "old-done", Status: model.StatusClosed, CreatedAt: hoursAgo(week), ClosedAt: ptr(hoursAgo(48))},
+		{ID: "new", Title: "New", Status: model.StatusOpen, CreatedAt: hoursAgo(3), UpdatedAt: hoursAgo(3)},
+		{ID: "waits", Status: model.StatusOpen, CreatedAt: hoursAgo(week), UpdatedAt: hoursAgo(5), Dependencies: blocks("new")},
+		{ID: "freed", Status: model.StatusOpen, CreatedAt: hoursAgo(week), UpdatedAt: hoursAgo(week), Dependencies: blocks("done")},
+		{ID: "stuck", Status: model.StatusInProgress, Assignee: "alice", CreatedAt: hoursAgo(week), UpdatedAt: hoursAgo(week)},
+	}
+
+	ids := func(items []StandupItem) string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// From timestamps alone
+	s := ComputeStandup(nil, "", issues, since, now)
+	for name, got := range map[string]string{
+		"closed":        ids(s.Closed),
+		"opened":        ids(s.Opened),
+		"newly_blocked": ids(s.NewlyBlocked),
+		"unblocked":     ids(s.Unblocked),
+		"stalled":       ids(s.Stalled),
+	} {
+		want := map[string]string{"closed": "done", "opened": "new", "newly_blocked": "waits", "unblocked": "freed", "stalled": "stuck"}[name]
+		if got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if s.NewlyBlocked[0].Reason != "blocked by new" {
+		t.Errorf("unexpected reason %q", s.NewlyBlocked[0].Reason)
+	}
+
+	// Against a baseline: "freed" was not blocked before (done was already
+	// closed there), so only the status comparison counts
+	baseline := []model.Issue{issues[0], issues[1], issues[4], issues[5],
+		{ID: "waits", Status: model.StatusOpen}}
+	baseline[0].Status = model.StatusClosed
+	s = ComputeStandup(baseline, "abc123", issues, since, now)
+	if s.Baseline != "abc123" || ids(s.Closed) != "" || ids(s.Opened) != "new" || ids(s.NewlyBlocked) != "waits" || ids(s.Unblocked) != "" {
+		t.Errorf("unexpected baseline standup %+v", s)
+	}
+
+	md := s.Markdown()
+	for _, want := range []string{"## Opened (1)", "- **new** New (P0)", "## Stalled in progress (1)", "@alice"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
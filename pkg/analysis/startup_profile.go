@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProfileRecommendations reviews a startup profile and suggests how to tune
+// AnalysisConfig. loadDuration is the time spent reading the beads file.
+// Lines start with ✓ (fine), ⚠ (a problem) or → (a setting to change).
+func ProfileRecommendations(profile *StartupProfile, loadDuration time.Duration) []string {
+	var recs []string
+	cfg := profile.Config
+	totalWithLoad := loadDuration + profile.Total
+
+	// Check overall startup time
+	if totalWithLoad < 500*time.Millisecond {
+		recs = append(recs, "✓ Startup within acceptable range (<500ms)")
+	} else if totalWithLoad < 1*time.Second {
+		recs = append(recs, "✓ Startup acceptable (<1s)")
+	} else if totalWithLoad < 2*time.Second {
+		// Check if full analysis is being used (no skipped metrics on a large graph)
+		if len(cfg.SkippedMetrics()) == 0 && profile.NodeCount >= 500 {
+			recs = append(recs, "⚠ Startup is slow (1-2s) - if using --force-full-analysis, consider removing it")
+		} else {
+			recs = append(recs, "⚠ Startup is slow (1-2s)")
+		}
+	} else {
+		recs = append(recs, "⚠ Startup is very slow (>2s) - optimization recommended")
+	}
+
+	// Check for timeouts
+	if profile.PageRankTO {
+		recs = append(recs, "⚠ PageRank timed out - graph may be too large or dense")
+		recs = append(recs, fmt.Sprintf("→ Raise PageRankTimeout (now %v) to get complete scores", cfg.PageRankTimeout))
+	}
+	if profile.BetweennessTO {
+		recs = append(recs, "⚠ Betweenness timed out - this is expected for large graphs (>500 nodes)")
+	}
+	if profile.HITSTO {
+		recs = append(recs, "⚠ HITS timed out - graph may have convergence issues")
+		recs = append(recs, "→ Disable HITS (ComputeHITS=false); triage scoring does not use it")
+	}
+	if profile.CyclesTO {
+		recs = append(recs, "⚠ Cycle detection timed out - graph may have many overlapping cycles")
+		recs = append(recs, fmt.Sprintf("→ Lower MaxCyclesToStore (now %d) or disable cycle detection (ComputeCycles=false)", cfg.MaxCyclesToStore))
+	}
+
+	// Check which metric is taking longest
+	betweennessShare := 0.0
+	if cfg.ComputeBetweenness && profile.Betweenness > 0 && profile.Phase2 > 0 {
+		betweennessShare = float64(profile.Betweenness) / float64(profile.Phase2) * 100
+		if betweennessShare > 50 {
+			recs = append(recs, fmt.Sprintf("⚠ Betweenness taking %.0f%% of Phase 2 time - consider skipping for large graphs", betweennessShare))
+		}
+	}
+
+	// Betweenness tuning: exact is O(V·E), sampling keeps the ranking
+	switch {
+	case cfg.ComputeBetweenness && cfg.BetweennessMode != BetweennessApproximate &&
+		profile.NodeCount >= 100 && (profile.BetweennessTO || betweennessShare > 50):
+		recs = append(recs, fmt.Sprintf("→ Enable approximate betweenness (BetweennessMode=approximate, BetweennessSampleSize=%d)",
+			RecommendSampleSize(profile.NodeCount, profile.EdgeCount)))
+	case cfg.BetweennessMode == BetweennessApproximate && profile.BetweennessSample > 0 && !profile.BetweennessConverged:
+		recs = append(recs, fmt.Sprintf("→ Approximate betweenness stopped at ±%.1f%% before converging - raise BetweennessTimeout (now %v) or BetweennessMaxSampleSize",
+			profile.BetweennessError*100, cfg.BetweennessTimeout))
+	}
+
+	// Reading the file dominates: long text fields are the usual cause
+	if loadDuration > time.Second && loadDuration > profile.Total {
+		recs = append(recs, "→ Loading the beads file takes longest - try --lazy-text to skip long text fields until an issue is opened")
+	}
+
+	// Check for cycles
+	if profile.CycleCount > 0 {
+		recs = append(recs, fmt.Sprintf("⚠ Found %d circular dependencies - resolve to improve graph health", profile.CycleCount))
+	}
+
+	return recs
+}
+
+// SizeTier names the size tier ConfigForSize picks for nodeCount issues
+func SizeTier(nodeCount int) string {
+	switch {
+	case nodeCount < 100:
+		return "Small (<100 issues)"
+	case nodeCount < 500:
+		return "Medium (100-500 issues)"
+	case nodeCount < 2000:
+		return "Large (500-2000 issues)"
+	default:
+		return "XL (>2000 issues)"
+	}
+}
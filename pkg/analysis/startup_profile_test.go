@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSizeTier(t *testing.T) {
+	for n, want := range map[int]string{
+		50:   "Small (<100 issues)",
+		150:  "Medium (100-500 issues)",
+		800:  "Large (500-2000 issues)",
+		5000: "XL (>2000 issues)",
+	} {
+		if got := SizeTier(n); got != want {
+			t.Errorf("SizeTier(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestProfileRecommendations(t *testing.T) {
+	has := func(recs []string, text string) bool {
+		for _, r := range recs {
+			if strings.Contains(r, text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	recs := ProfileRecommendations(&StartupProfile{
+		NodeCount:  600,
+		Config:     FullAnalysisConfig(),
+		PageRankTO: true,
+	}, 200*time.Millisecond)
+	if !has(recs, "Startup") || !has(recs, "PageRank timed out") || !has(recs, "Raise PageRankTimeout") {
+		t.Errorf("missing expected recommendations: %+v", recs)
+	}
+
+	// Slow exact betweenness suggests sampling
+	recs = ProfileRecommendations(&StartupProfile{
+		NodeCount:     800,
+		EdgeCount:     1200,
+		Config:        FullAnalysisConfig(),
+		Betweenness:   900 * time.Millisecond,
+		BetweennessTO: true,
+		Phase2:        time.Second,
+		Total:         time.Second,
+	}, 100*time.Millisecond)
+	if !has(recs, "Enable approximate betweenness") {
+		t.Errorf("expected approximate betweenness suggestion: %+v", recs)
+	}
+
+	// Sampling that ran out of budget suggests a bigger one
+	cfg := ConfigForSize(3000, 4000)
+	recs = ProfileRecommendations(&StartupProfile{
+		NodeCount:         3000,
+		Config:            cfg,
+		BetweennessSample: 200,
+		BetweennessError:  0.08,
+	}, 100*time.Millisecond)
+	if !has(recs, "before converging") || has(recs, "Enable approximate") {
+		t.Errorf("expected sampling budget suggestion: %+v", recs)
+	}
+
+	// A small, fast profile needs no tuning
+	recs = ProfileRecommendations(&StartupProfile{NodeCount: 10, Config: ConfigForSize(10, 5), Total: 5 * time.Millisecond}, time.Millisecond)
+	for _, r := range recs {
+		if strings.HasPrefix(r, "→") || strings.HasPrefix(r, "⚠") {
+			t.Errorf("unexpected recommendation %q", r)
+		}
+	}
+}
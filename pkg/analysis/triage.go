@@ -94,6 +94,8 @@ type BlockerItem struct {
 type ProjectHealth struct {
 	Counts    HealthCounts `json:"counts"`
 	Graph     GraphHealth  `json:"graph"`
+	Score     HealthScore  `json:"score"`               // Composite 0-100 health score
+	Effort    EffortTotals `json:"effort"`              // Estimated minutes remaining and done
 	Velocity  *Velocity    `json:"velocity,omitempty"`  // nil until labels view ready
 	Staleness *Staleness   `json:"staleness,omitempty"` // nil until history ready
 }
@@ -394,6 +396,8 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 		topID = recommendations[0].ID
 	}
 
+	graphHealth := buildGraphHealth(stats)
+
 	elapsed := time.Since(start)
 	projectVelocity := ComputeProjectVelocity(issues, now.UTC(), 8)
 
@@ -429,7 +433,9 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 		RecommendationsByLabel: recsByLabel,
 		ProjectHealth: ProjectHealth{
 			Counts:   counts,
-			Graph:    buildGraphHealth(stats),
+			Graph:    graphHealth,
+			Score:    ComputeHealthScore(issues, graphHealth.CycleCount, now),
+			Effort:   ComputeEffortTotals(issues),
 			Velocity: projectVelocity,
 			// Staleness remains nil until history integration is ready
 		},
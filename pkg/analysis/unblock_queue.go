@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// UnblockQueueItem is an unclosed issue that other unclosed work waits on.
+type UnblockQueueItem struct {
+	ID                string   `json:"id"`
+	Title             string   `json:"title"`
+	Status            string   `json:"status"`
+	Priority          int      `json:"priority"`
+	Assignee          string   `json:"assignee,omitempty"`
+	UnblocksCount     int      `json:"unblocks_count"`       // Issues freed by closing it, counting the cascade
+	UnblocksIDs       []string `json:"unblocks_ids"`         // Issues freed directly
+	WaitingCount      int      `json:"waiting_count"`        // Unclosed issues it blocks directly, freed or not
+	CriticalPathScore float64  `json:"critical_path_score"`  // Depth of the dependency chain behind it
+	Actionable        bool     `json:"actionable"`           // Nothing blocks it; it can be started now
+	BlockedBy         []string `json:"blocked_by,omitempty"` // Its own open blockers, when not actionable
+}
+
+// UnblockQueue orders the open blockers by how much work closing each one
+// frees: the "pull this thread first" list.
+type UnblockQueue struct {
+	OpenBlockerCount int                `json:"open_blocker_count"`
+	ActionableCount  int                `json:"actionable_count"`
+	Items            []UnblockQueueItem `json:"items"`
+}
+
+// BuildUnblockQueue finds every unclosed issue blocking other unclosed work
+// and ranks them by the issues they would transitively unblock. Ties go to
+// issues that can be started now, then to the longer critical path, then to
+// higher priority. stats may be nil or still computing; the critical path
+// then counts as 0.
+func BuildUnblockQueue(analyzer *Analyzer, stats *GraphStats) UnblockQueue {
+	actionable := make(map[string]bool)
+	for _, issue := range analyzer.GetActionableIssues() {
+		actionable[issue.ID] = true
+	}
+
+	waiting := make(map[string]int)
+	for _, issue := range analyzer.issueMap {
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		for _, blocker := range analyzer.GetOpenBlockers(issue.ID) {
+			waiting[blocker]++
+		}
+	}
+
+	queue := UnblockQueue{Items: make([]UnblockQueueItem, 0, len(waiting))}
+	for id, count := range waiting {
+		issue := analyzer.issueMap[id]
+		item := UnblockQueueItem{
+			ID:            id,
+			Title:         issue.Title,
+			Status:        string(issue.Status),
+			Priority:      issue.Priority,
+			Assignee:      issue.Assignee,
+			UnblocksCount: analyzer.countTransitiveUnblocks(id),
+			UnblocksIDs:   analyzer.computeUnblocks(id),
+			WaitingCount:  count,
+			Actionable:    actionable[id],
+		}
+		if stats != nil {
+			item.CriticalPathScore = stats.GetCriticalPathScore(id)
+		}
+		if !item.Actionable {
+			item.BlockedBy = analyzer.GetOpenBlockers(id)
+		} else {
+			queue.ActionableCount++
+		}
+		queue.Items = append(queue.Items, item)
+	}
+	queue.OpenBlockerCount = len(queue.Items)
+
+	sort.Slice(queue.Items, func(i, j int) bool {
+		a, b := queue.Items[i], queue.Items[j]
+		if a.UnblocksCount != b.UnblocksCount {
+			return a.UnblocksCount > b.UnblocksCount
+		}
+		if a.Actionable != b.Actionable {
+			return a.Actionable
+		}
+		if a.CriticalPathScore != b.CriticalPathScore {
+			return a.CriticalPathScore > b.CriticalPathScore
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	return queue
+}
+
+// RobotUnblockOutput is the JSON output structure for --robot-unblock.
+type RobotUnblockOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	UnblockQueue
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotUnblockOutput creates the full robot-unblock output.
+func GenerateRobotUnblockOutput(issues []model.Issue, dataHash string, now time.Time) RobotUnblockOutput {
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	return RobotUnblockOutput{
+		GeneratedAt:  now.UTC().Format(time.RFC3339),
+		DataHash:     dataHash,
+		UnblockQueue: BuildUnblockQueue(analyzer, &stats),
+		UsageHints: []string{
+			"jq '.items[0]' - The blocker to pull first",
+			"jq '.items[] | select(.actionable) | {id, unblocks_count}' - Blockers that can be started now",
+			"jq '.items[] | select(.actionable | not) | {id, blocked_by}' - Blockers waiting on other work",
+		},
+	}
+}
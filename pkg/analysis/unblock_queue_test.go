@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func unblockQueueIssues() []model.Issue {
+	blockedBy := func(id string, status model.Status, priority int, blockers ...string) model.Issue {
+		issue := model.Issue{ID: id, Title: id, Status: status, Priority: priority}
+		for _, b := range blockers {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+		}
+		return issue
+	}
+	return []model.Issue{
+		blockedBy("a", model.StatusOpen, 2),
+		blockedBy("b", model.StatusOpen, 2, "a"),
+		blockedBy("c", model.StatusOpen, 2, "b"),
+		blockedBy("x", model.StatusOpen, 1),
+		blockedBy("z", model.StatusOpen, 2),
+		blockedBy("y", model.StatusOpen, 2, "x", "z"),
+		blockedBy("w", model.StatusClosed, 0),
+		blockedBy("v", model.StatusOpen, 0, "w"),
+	}
+}
+
+func TestBuildUnblockQueue(t *testing.T) {
+	analyzer := NewAnalyzer(unblockQueueIssues())
+	stats := analyzer.Analyze()
+	queue := BuildUnblockQueue(analyzer, &stats)
+
+	var order []string
+	for _, item := range queue.Items {
+		order = append(order, item.ID)
+	}
+	if want := []string{"a", "b", "x", "z"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("queue order = %v, want %v", order, want)
+	}
+	if queue.OpenBlockerCount != 4 || queue.ActionableCount != 3 {
+		t.Errorf("counts = %d blockers, %d actionable", queue.OpenBlockerCount, queue.ActionableCount)
+	}
+
+	a, b, x := queue.Items[0], queue.Items[1], queue.Items[2]
+	if a.UnblocksCount != 2 || !reflect.DeepEqual(a.UnblocksIDs, []string{"b"}) || !a.Actionable {
+		t.Errorf("a = %+v, want 2 unblocked through b", a)
+	}
+	if b.Actionable || !reflect.DeepEqual(b.BlockedBy, []string{"a"}) {
+		t.Errorf("b = %+v, want blocked by a", b)
+	}
+	// y also waits on z, so closing x alone frees nothing
+	if x.UnblocksCount != 0 || x.WaitingCount != 1 {
+		t.Errorf("x = %+v", x)
+	}
+}
+
+func TestBuildUnblockQueueWithoutStats(t *testing.T) {
+	queue := BuildUnblockQueue(NewAnalyzer(unblockQueueIssues()), nil)
+	if len(queue.Items) != 4 || queue.Items[0].CriticalPathScore != 0 {
+		t.Errorf("queue without stats = %+v", queue.Items)
+	}
+}
+
+func TestGenerateRobotUnblockOutput(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	output := GenerateRobotUnblockOutput(unblockQueueIssues(), "hash", now)
+	data, err := json.Marshal(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"generated_at", "data_hash", "open_blocker_count", "actionable_count", "items", "usage_hints"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("output missing %q", key)
+		}
+	}
+	if decoded["generated_at"] != "2025-03-01T12:00:00Z" {
+		t.Errorf("generated_at = %v", decoded["generated_at"])
+	}
+}
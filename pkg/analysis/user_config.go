@@ -0,0 +1,469 @@
+package analysis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UserConfigFilename is the analysis tuning file inside .bv/
+const UserConfigFilename = "analysis.yaml"
+
+// UserConfig is the contents of .bv/analysis.yaml. Every field is optional;
+// unset fields keep the size-based defaults of ConfigForSize and the
+// built-in score weights.
+//
+//	betweenness:
+//	  mode: approximate   # exact, approximate or skip
+//	  timeout: 2s
+//	  sample_size: 200
+//	pagerank:
+//	  timeout: 1s
+//	hits:
+//	  enabled: false
+//	weights:
+//	  staleness: 0.15
+type UserConfig struct {
+	Betweenness  *BetweennessSettings `yaml:"betweenness"`
+	PageRank     *MetricSettings      `yaml:"pagerank"`
+	HITS         *MetricSettings      `yaml:"hits"`
+	Cycles       *CycleSettings       `yaml:"cycles"`
+	Eigenvector  *MetricSettings      `yaml:"eigenvector"`
+	CriticalPath *MetricSettings      `yaml:"critical_path"`
+
+	// Phase2Workers bounds concurrent Phase 2 metrics (0: GOMAXPROCS)
+	Phase2Workers *int `yaml:"phase2_workers"`
+
+	// Weights override the composite score weights, by component
+	Weights *WeightSettings `yaml:"weights"`
+}
+
+// MetricSettings toggles one metric and bounds its time.
+type MetricSettings struct {
+	Enabled *bool  `yaml:"enabled"`
+	Timeout string `yaml:"timeout"` // Duration, e.g. 500ms; ignored by metrics without one
+}
+
+// BetweennessSettings tunes betweenness centrality.
+type BetweennessSettings struct {
+	MetricSettings `yaml:",inline"`
+	Mode           string `yaml:"mode"`            // exact, approximate or skip
+	SampleSize     *int   `yaml:"sample_size"`     // Pivots for approximate mode
+	Adaptive       *bool  `yaml:"adaptive"`        // Grow the sample until rankings settle
+	MaxSampleSize  *int   `yaml:"max_sample_size"` // Cap for adaptive sampling (0: node count)
+}
+
+// CycleSettings tunes cycle detection.
+type CycleSettings struct {
+	MetricSettings `yaml:",inline"`
+	MaxStored      *int `yaml:"max_stored"`
+}
+
+// WeightSettings overrides score weights; nil keeps the built-in weight.
+type WeightSettings struct {
+	PageRank      *float64 `yaml:"pagerank"`
+	Betweenness   *float64 `yaml:"betweenness"`
+	BlockerRatio  *float64 `yaml:"blocker_ratio"`
+	Staleness     *float64 `yaml:"staleness"`
+	PriorityBoost *float64 `yaml:"priority_boost"`
+	TimeToImpact  *float64 `yaml:"time_to_impact"`
+	Urgency       *float64 `yaml:"urgency"`
+	Risk          *float64 `yaml:"risk"`
+}
+
+// ScoreWeights are the weights of the composite impact score components.
+type ScoreWeights struct {
+	PageRank      float64 `json:"pagerank"`
+	Betweenness   float64 `json:"betweenness"`
+	BlockerRatio  float64 `json:"blocker_ratio"`
+	Staleness     float64 `json:"staleness"`
+	PriorityBoost float64 `json:"priority_boost"`
+	TimeToImpact  float64 `json:"time_to_impact"`
+	Urgency       float64 `json:"urgency"`
+	Risk          float64 `json:"risk"`
+}
+
+// DefaultScoreWeights returns the built-in weights (the Weight* constants).
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		PageRank:      WeightPageRank,
+		Betweenness:   WeightBetweenness,
+		BlockerRatio:  WeightBlockerRatio,
+		Staleness:     WeightStaleness,
+		PriorityBoost: WeightPriorityBoost,
+		TimeToImpact:  WeightTimeToImpact,
+		Urgency:       WeightUrgency,
+		Risk:          WeightRisk,
+	}
+}
+
+// Sum returns the total of all weights
+func (w ScoreWeights) Sum() float64 {
+	return w.PageRank + w.Betweenness + w.BlockerRatio + w.Staleness +
+		w.PriorityBoost + w.TimeToImpact + w.Urgency + w.Risk
+}
+
+// Normalized returns w scaled to sum to 1, so composite scores stay in [0, 1]
+func (w ScoreWeights) Normalized() ScoreWeights {
+	sum := w.Sum()
+	if sum <= 0 {
+		return w
+	}
+	return ScoreWeights{
+		PageRank:      w.PageRank / sum,
+		Betweenness:   w.Betweenness / sum,
+		BlockerRatio:  w.BlockerRatio / sum,
+		Staleness:     w.Staleness / sum,
+		PriorityBoost: w.PriorityBoost / sum,
+		TimeToImpact:  w.TimeToImpact / sum,
+		Urgency:       w.Urgency / sum,
+		Risk:          w.Risk / sum,
+	}
+}
+
+// weightSumTolerance is how far configured weights may sum from 1 before
+// Warnings mentions it
+const weightSumTolerance = 1e-6
+
+// UserConfigPath returns the analysis config path for a project
+func UserConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", UserConfigFilename)
+}
+
+// LoadUserConfig loads and validates .bv/analysis.yaml. A missing file
+// yields nil: nothing is overridden.
+func LoadUserConfig(projectDir string) (*UserConfig, error) {
+	data, err := os.ReadFile(UserConfigPath(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading analysis config: %w", err)
+	}
+
+	var config UserConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true) // A typo should not silently do nothing
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing %s: %w", UserConfigFilename, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", UserConfigFilename, err)
+	}
+	return &config, nil
+}
+
+// Validate checks modes, durations, sizes and weights.
+func (u *UserConfig) Validate() error {
+	for _, m := range []struct {
+		name     string
+		settings *MetricSettings
+	}{
+		{"betweenness", u.betweennessMetric()},
+		{"pagerank", u.PageRank},
+		{"hits", u.HITS},
+		{"cycles", u.cyclesMetric()},
+	} {
+		if m.settings == nil || m.settings.Timeout == "" {
+			continue
+		}
+		if _, err := parseTimeout(m.settings.Timeout); err != nil {
+			return fmt.Errorf("%s.timeout: %w", m.name, err)
+		}
+	}
+	for _, m := range []struct {
+		name     string
+		settings *MetricSettings
+	}{{"eigenvector", u.Eigenvector}, {"critical_path", u.CriticalPath}} {
+		if m.settings != nil && m.settings.Timeout != "" {
+			return fmt.Errorf("%s.timeout: %s has no timeout", m.name, m.name)
+		}
+	}
+
+	if b := u.Betweenness; b != nil {
+		switch BetweennessMode(b.Mode) {
+		case "", BetweennessExact, BetweennessApproximate, BetweennessSkip:
+		default:
+			return fmt.Errorf("betweenness.mode: unknown mode %q (want exact, approximate or skip)", b.Mode)
+		}
+		if b.Enabled != nil && *b.Enabled && BetweennessMode(b.Mode) == BetweennessSkip {
+			return fmt.Errorf("betweenness: enabled with mode skip")
+		}
+		if b.SampleSize != nil && *b.SampleSize < 1 {
+			return fmt.Errorf("betweenness.sample_size must be >= 1")
+		}
+		if b.MaxSampleSize != nil && *b.MaxSampleSize < 0 {
+			return fmt.Errorf("betweenness.max_sample_size must be >= 0")
+		}
+	}
+	if c := u.Cycles; c != nil && c.MaxStored != nil && *c.MaxStored < 1 {
+		return fmt.Errorf("cycles.max_stored must be >= 1")
+	}
+	if u.Phase2Workers != nil && *u.Phase2Workers < 0 {
+		return fmt.Errorf("phase2_workers must be >= 0")
+	}
+
+	if u.Weights != nil {
+		for name, w := range u.Weights.byName() {
+			if w != nil && *w < 0 {
+				return fmt.Errorf("weights.%s must be >= 0", name)
+			}
+		}
+		if u.Weights.Apply(DefaultScoreWeights()).Sum() <= 0 {
+			return fmt.Errorf("weights: at least one weight must be positive")
+		}
+	}
+	return nil
+}
+
+// Warnings reports settings that load but are adjusted on use: weights
+// that do not sum to 1 are scaled until they do.
+func (u *UserConfig) Warnings() []string {
+	if u == nil || u.Weights == nil {
+		return nil
+	}
+	var warnings []string
+	if sum := u.Weights.Apply(DefaultScoreWeights()).Sum(); math.Abs(sum-1) > weightSumTolerance {
+		warnings = append(warnings, fmt.Sprintf(
+			"weights sum to %.4g (unset weights keep their defaults), not 1; scoring scales them to sum to 1", sum))
+	}
+	return warnings
+}
+
+// Apply returns cfg with the overrides set in u
+func (u *UserConfig) Apply(cfg AnalysisConfig) AnalysisConfig {
+	if u == nil {
+		return cfg
+	}
+	applyMetric := func(s *MetricSettings, enabled *bool, timeout *time.Duration) {
+		if s == nil {
+			return
+		}
+		if s.Enabled != nil {
+			*enabled = *s.Enabled
+		}
+		if d, err := parseTimeout(s.Timeout); err == nil && timeout != nil {
+			*timeout = d
+		}
+	}
+
+	if b := u.Betweenness; b != nil {
+		applyMetric(&b.MetricSettings, &cfg.ComputeBetweenness, &cfg.BetweennessTimeout)
+		if b.Mode != "" {
+			cfg.BetweennessMode = BetweennessMode(b.Mode)
+			cfg.ComputeBetweenness = cfg.BetweennessMode != BetweennessSkip
+		}
+		if cfg.ComputeBetweenness && cfg.BetweennessMode == BetweennessSkip {
+			cfg.BetweennessMode = BetweennessExact
+		}
+		if b.SampleSize != nil {
+			cfg.BetweennessSampleSize = *b.SampleSize
+		}
+		if b.Adaptive != nil {
+			cfg.BetweennessAdaptive = *b.Adaptive
+		}
+		if b.MaxSampleSize != nil {
+			cfg.BetweennessMaxSampleSize = *b.MaxSampleSize
+		}
+		if cfg.ComputeBetweenness {
+			cfg.BetweennessSkipReason = ""
+		} else {
+			cfg.BetweennessMode = BetweennessSkip
+			cfg.BetweennessSkipReason = "disabled in " + UserConfigFilename
+		}
+	}
+	if u.PageRank != nil {
+		applyMetric(u.PageRank, &cfg.ComputePageRank, &cfg.PageRankTimeout)
+		cfg.PageRankSkipReason = skipReason(cfg.ComputePageRank, cfg.PageRankSkipReason, u.PageRank)
+	}
+	if u.HITS != nil {
+		applyMetric(u.HITS, &cfg.ComputeHITS, &cfg.HITSTimeout)
+		cfg.HITSSkipReason = skipReason(cfg.ComputeHITS, cfg.HITSSkipReason, u.HITS)
+	}
+	if c := u.Cycles; c != nil {
+		applyMetric(&c.MetricSettings, &cfg.ComputeCycles, &cfg.CyclesTimeout)
+		cfg.CyclesSkipReason = skipReason(cfg.ComputeCycles, cfg.CyclesSkipReason, &c.MetricSettings)
+		if c.MaxStored != nil {
+			cfg.MaxCyclesToStore = *c.MaxStored
+		}
+	}
+	applyMetric(u.Eigenvector, &cfg.ComputeEigenvector, nil)
+	applyMetric(u.CriticalPath, &cfg.ComputeCriticalPath, nil)
+	if u.Phase2Workers != nil {
+		cfg.Phase2Workers = *u.Phase2Workers
+	}
+	return cfg
+}
+
+// Apply returns w with the overridden weights replaced
+func (s *WeightSettings) Apply(w ScoreWeights) ScoreWeights {
+	if s == nil {
+		return w
+	}
+	set := func(dst *float64, v *float64) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	set(&w.PageRank, s.PageRank)
+	set(&w.Betweenness, s.Betweenness)
+	set(&w.BlockerRatio, s.BlockerRatio)
+	set(&w.Staleness, s.Staleness)
+	set(&w.PriorityBoost, s.PriorityBoost)
+	set(&w.TimeToImpact, s.TimeToImpact)
+	set(&w.Urgency, s.Urgency)
+	set(&w.Risk, s.Risk)
+	return w
+}
+
+// Overridden names the weights s sets, in score component order
+func (s *WeightSettings) Overridden() []string {
+	if s == nil {
+		return nil
+	}
+	var names []string
+	byName := s.byName()
+	for _, name := range weightNames {
+		if byName[name] != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// weightNames lists the weight keys in score component order
+var weightNames = []string{
+	"pagerank", "betweenness", "blocker_ratio", "staleness",
+	"priority_boost", "time_to_impact", "urgency", "risk",
+}
+
+func (s *WeightSettings) byName() map[string]*float64 {
+	return map[string]*float64{
+		"pagerank":       s.PageRank,
+		"betweenness":    s.Betweenness,
+		"blocker_ratio":  s.BlockerRatio,
+		"staleness":      s.Staleness,
+		"priority_boost": s.PriorityBoost,
+		"time_to_impact": s.TimeToImpact,
+		"urgency":        s.Urgency,
+		"risk":           s.Risk,
+	}
+}
+
+func (u *UserConfig) betweennessMetric() *MetricSettings {
+	if u.Betweenness == nil {
+		return nil
+	}
+	return &u.Betweenness.MetricSettings
+}
+
+func (u *UserConfig) cyclesMetric() *MetricSettings {
+	if u.Cycles == nil {
+		return nil
+	}
+	return &u.Cycles.MetricSettings
+}
+
+// skipReason explains a metric the user turned off, and clears the reason
+// of one they turned on
+func skipReason(computed bool, reason string, s *MetricSettings) string {
+	switch {
+	case computed:
+		return ""
+	case s.Enabled != nil && !*s.Enabled:
+		return "disabled in " + UserConfigFilename
+	}
+	return reason
+}
+
+func parseTimeout(spec string) (time.Duration, error) {
+	d, err := time.ParseDuration(spec)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("want a positive duration like 500ms or 2s, got %q", spec)
+	}
+	return d, nil
+}
+
+// userConfig holds the project's .bv/analysis.yaml once SetUserConfig is
+// called at startup; nil means built-in defaults
+var userConfig atomic.Pointer[UserConfig]
+
+// SetUserConfig installs the overrides every analyzer of this process
+// applies. Pass nil to restore the defaults.
+func SetUserConfig(u *UserConfig) {
+	userConfig.Store(u)
+}
+
+// TunedConfigForSize is ConfigForSize with the overrides of SetUserConfig
+func TunedConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
+	cfg := userConfig.Load().Apply(ConfigForSize(nodeCount, edgeCount))
+	if cfg.BetweennessMode == BetweennessApproximate && cfg.BetweennessSampleSize == 0 {
+		cfg.BetweennessSampleSize = RecommendSampleSize(nodeCount, edgeCount)
+	}
+	return cfg
+}
+
+// ConfiguredScoreWeights returns the built-in weights with the overrides of
+// SetUserConfig, before normalization
+func ConfiguredScoreWeights() ScoreWeights {
+	w := DefaultScoreWeights()
+	if u := userConfig.Load(); u != nil {
+		w = u.Weights.Apply(w)
+	}
+	return w
+}
+
+// CurrentScoreWeights returns the score weights in effect: the configured
+// weights, normalized when any are overridden
+func CurrentScoreWeights() ScoreWeights {
+	u := userConfig.Load()
+	if u == nil || u.Weights == nil {
+		return DefaultScoreWeights()
+	}
+	return ConfiguredScoreWeights().Normalized()
+}
+
+// WeightsReport describes the score weights in effect.
+type WeightsReport struct {
+	Effective     ScoreWeights `json:"effective"`      // Used for scoring
+	Configured    ScoreWeights `json:"configured"`     // Defaults with the overrides, before normalization
+	Defaults      ScoreWeights `json:"defaults"`       // Built-in
+	Overridden    []string     `json:"overridden"`     // Weights set in analysis.yaml
+	ConfiguredSum float64      `json:"configured_sum"` // Sum of Configured
+	Normalized    bool         `json:"normalized"`     // Configured weights were scaled to sum to 1
+}
+
+// CurrentWeightsReport reports the weights of SetUserConfig
+func CurrentWeightsReport() WeightsReport {
+	configured := ConfiguredScoreWeights()
+	overridden := []string{}
+	if u := userConfig.Load(); u != nil && u.Weights != nil {
+		overridden = append(overridden, u.Weights.Overridden()...)
+	}
+	return WeightsReport{
+		Effective:     CurrentScoreWeights(),
+		Configured:    configured,
+		Defaults:      DefaultScoreWeights(),
+		Overridden:    overridden,
+		ConfiguredSum: configured.Sum(),
+		Normalized:    len(overridden) > 0 && math.Abs(configured.Sum()-1) > weightSumTolerance,
+	}
+}
+
+// RobotWeightsOutput is the --robot-weights output.
+type RobotWeightsOutput struct {
+	GeneratedAt  string `json:"generated_at"`
+	ConfigPath   string `json:"config_path,omitempty"`
+	ConfigLoaded bool   `json:"config_loaded"` // analysis.yaml exists and is valid
+	WeightsReport
+	Warnings   []string `json:"warnings"`
+	UsageHints []string `json:"usage_hints"`
+}
@@ -0,0 +1,169 @@
+package analysis
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeUserConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(UserConfigPath(dir), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadUserConfig_Missing(t *testing.T) {
+	u, err := LoadUserConfig(t.TempDir())
+	if err != nil || u != nil {
+		t.Fatalf("missing file: got %+v, %v; want nil, nil", u, err)
+	}
+	// A nil config changes nothing
+	if got, want := u.Apply(ConfigForSize(50, 10)), ConfigForSize(50, 10); got != want {
+		t.Errorf("nil Apply changed the config: %+v", got)
+	}
+}
+
+func TestLoadUserConfig_Apply(t *testing.T) {
+	dir := writeUserConfig(t, `
+betweenness:
+  mode: approximate
+  timeout: 3s
+  sample_size: 40
+pagerank:
+  timeout: 750ms
+hits:
+  enabled: false
+cycles:
+  max_stored: 10
+phase2_workers: 2
+weights:
+  staleness: 0.2
+`)
+	u, err := LoadUserConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := u.Apply(ConfigForSize(50, 10))
+	if cfg.BetweennessMode != BetweennessApproximate || !cfg.ComputeBetweenness ||
+		cfg.BetweennessTimeout != 3*time.Second || cfg.BetweennessSampleSize != 40 {
+		t.Errorf("betweenness not applied: %+v", cfg)
+	}
+	if cfg.PageRankTimeout != 750*time.Millisecond || !cfg.ComputePageRank {
+		t.Errorf("pagerank not applied: %+v", cfg)
+	}
+	if cfg.ComputeHITS || cfg.HITSSkipReason != "disabled in analysis.yaml" {
+		t.Errorf("hits should be disabled with a reason: %+v", cfg)
+	}
+	if cfg.MaxCyclesToStore != 10 || cfg.Phase2Workers != 2 {
+		t.Errorf("cycles/workers not applied: %+v", cfg)
+	}
+
+	w := u.Weights.Apply(DefaultScoreWeights())
+	if w.Staleness != 0.2 || w.PageRank != WeightPageRank {
+		t.Errorf("weights = %+v", w)
+	}
+}
+
+func TestLoadUserConfig_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown field":    "betweness:\n  mode: exact\n",
+		"bad mode":         "betweenness:\n  mode: fast\n",
+		"bad timeout":      "pagerank:\n  timeout: soon\n",
+		"zero timeout":     "hits:\n  timeout: 0s\n",
+		"enabled and skip": "betweenness:\n  enabled: true\n  mode: skip\n",
+		"sample size":      "betweenness:\n  sample_size: 0\n",
+		"negative weight":  "weights:\n  risk: -0.1\n",
+		"no timeout":       "eigenvector:\n  timeout: 1s\n",
+		"all zero weights": "weights: {pagerank: 0, betweenness: 0, blocker_ratio: 0, staleness: 0, priority_boost: 0, time_to_impact: 0, urgency: 0, risk: 0}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadUserConfig(writeUserConfig(t, content))
+			if err == nil || !strings.Contains(err.Error(), UserConfigFilename) {
+				t.Errorf("want an %s error, got %v", UserConfigFilename, err)
+			}
+		})
+	}
+}
+
+func TestUserConfig_SkipOverridesSizeDefault(t *testing.T) {
+	// XL graphs skip betweenness by default; the user can turn it back on
+	enabled := true
+	u := &UserConfig{Betweenness: &BetweennessSettings{MetricSettings: MetricSettings{Enabled: &enabled}}}
+	cfg := u.Apply(ConfigForSize(5000, 5000))
+	if !cfg.ComputeBetweenness || cfg.BetweennessMode == BetweennessSkip || cfg.BetweennessSkipReason != "" {
+		t.Errorf("betweenness should be re-enabled: %+v", cfg)
+	}
+
+	u = &UserConfig{Betweenness: &BetweennessSettings{Mode: string(BetweennessSkip)}}
+	cfg = u.Apply(ConfigForSize(50, 10))
+	if cfg.ComputeBetweenness || cfg.BetweennessSkipReason == "" {
+		t.Errorf("mode skip should disable betweenness: %+v", cfg)
+	}
+}
+
+func TestSetUserConfig(t *testing.T) {
+	t.Cleanup(func() { SetUserConfig(nil) })
+
+	if CurrentScoreWeights() != DefaultScoreWeights() {
+		t.Fatal("without a user config the built-in weights apply")
+	}
+	if math.Abs(DefaultScoreWeights().Sum()-1) > 1e-9 {
+		t.Errorf("built-in weights sum to %v, want 1", DefaultScoreWeights().Sum())
+	}
+
+	risk := 0.5
+	SetUserConfig(&UserConfig{
+		Betweenness: &BetweennessSettings{Mode: string(BetweennessApproximate)},
+		Weights:     &WeightSettings{Risk: &risk},
+	})
+	if w := ConfiguredScoreWeights(); w.Risk != 0.5 {
+		t.Errorf("configured risk weight = %v, want 0.5", w.Risk)
+	}
+	if w := CurrentScoreWeights(); math.Abs(w.Sum()-1) > 1e-9 || math.Abs(w.Risk-0.5/1.4) > 1e-9 {
+		t.Errorf("effective weights should be normalized: %+v", w)
+	}
+	cfg := TunedConfigForSize(300, 600)
+	if cfg.BetweennessMode != BetweennessApproximate || cfg.BetweennessSampleSize != RecommendSampleSize(300, 600) {
+		t.Errorf("approximate mode without a sample size should use the recommended one: %+v", cfg)
+	}
+}
+
+func TestUserConfig_WeightWarnings(t *testing.T) {
+	t.Cleanup(func() { SetUserConfig(nil) })
+
+	// Moving 0.05 from staleness to urgency keeps the sum at 1
+	staleness, urgency := 0.0, 0.15
+	u := &UserConfig{Weights: &WeightSettings{Staleness: &staleness, Urgency: &urgency}}
+	if w := u.Warnings(); len(w) != 0 {
+		t.Errorf("balanced weights should not warn: %v", w)
+	}
+
+	staleness = 0.15
+	if w := u.Warnings(); len(w) != 1 || !strings.Contains(w[0], "sum to 1.15") {
+		t.Errorf("want a sum warning, got %v", w)
+	}
+
+	SetUserConfig(u)
+	report := CurrentWeightsReport()
+	if !report.Normalized || math.Abs(report.ConfiguredSum-1.15) > 1e-9 || math.Abs(report.Effective.Sum()-1) > 1e-9 {
+		t.Errorf("report = %+v", report)
+	}
+	if strings.Join(report.Overridden, ",") != "staleness,urgency" {
+		t.Errorf("overridden = %v, want staleness,urgency", report.Overridden)
+	}
+
+	SetUserConfig(nil)
+	if report := CurrentWeightsReport(); report.Normalized || len(report.Overridden) != 0 || report.Effective != DefaultScoreWeights() {
+		t.Errorf("built-in report = %+v", report)
+	}
+}
@@ -0,0 +1,224 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// velocityPriorSamples is how many closures the fixed type weight counts as
+// when it is blended with the weight learned from cycle times
+const velocityPriorSamples = 5
+
+// priorTypeWeights are the complexity weights used before any issue of the
+// type has closed
+var priorTypeWeights = map[model.IssueType]float64{
+	model.TypeBug:     1.0,
+	model.TypeTask:    1.0,
+	model.TypeChore:   0.8,
+	model.TypeFeature: 1.3,
+	model.TypeEpic:    2.0,
+}
+
+// CycleTimeStats summarizes how long closed issues took from creation to close.
+type CycleTimeStats struct {
+	Samples    int     `json:"samples"`
+	MedianDays float64 `json:"median_days"`
+	P85Days    float64 `json:"p85_days"`
+	MeanDays   float64 `json:"mean_days"`
+}
+
+// TypeVelocity is the learned complexity weight of an issue type.
+type TypeVelocity struct {
+	IssueType   model.IssueType `json:"issue_type"`
+	CycleTime   CycleTimeStats  `json:"cycle_time"`
+	PriorWeight float64         `json:"prior_weight"`
+	Weight      float64         `json:"weight"` // Prior blended with the median cycle time relative to all closures
+}
+
+// LabelVelocity is the learned throughput of a label ("" for all closures).
+type LabelVelocity struct {
+	Label          string         `json:"label,omitempty"`
+	CycleTime      CycleTimeStats `json:"cycle_time"`
+	RecentClosures int            `json:"recent_closures"` // Closures inside the velocity window
+	MinutesPerDay  float64        `json:"minutes_per_day"` // Estimated minutes closed per day in the window
+}
+
+// VelocityModel holds the parameters the ETA estimator learns from closed
+// issues: a complexity weight per issue type and a velocity per label.
+// A nil model falls back to the fixed type weights and no velocity.
+type VelocityModel struct {
+	WindowDays            int             `json:"window_days"`
+	MedianEstimateMinutes int             `json:"median_estimate_minutes"`
+	Overall               LabelVelocity   `json:"overall"`
+	Types                 []TypeVelocity  `json:"types"`
+	Labels                []LabelVelocity `json:"labels"`
+
+	types  map[model.IssueType]TypeVelocity
+	labels map[string]LabelVelocity
+}
+
+// LearnVelocityModel learns the ETA parameters from the closed issues.
+// Type weights come from cycle times over all history; label velocities from
+// the closures of the last 30 days, as estimated minutes per day.
+func LearnVelocityModel(issues []model.Issue, now time.Time) *VelocityModel {
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	since := now.Add(-etaVelocityWindow)
+
+	type labelStats struct {
+		cycleTimes []float64
+		minutes    int // Estimated minutes of the recent closures
+		closures   int
+	}
+	overall := &labelStats{}
+	byLabel := make(map[string]*labelStats)
+	byType := make(map[model.IssueType][]float64)
+	for _, issue := range issues {
+		if issue.Status != model.StatusClosed {
+			continue
+		}
+		stats := []*labelStats{overall}
+		for _, label := range issue.Labels {
+			if byLabel[label] == nil {
+				byLabel[label] = &labelStats{}
+			}
+			stats = append(stats, byLabel[label])
+		}
+		days, hasCycleTime := cycleTimeDays(issue)
+		if hasCycleTime {
+			byType[issue.IssueType] = append(byType[issue.IssueType], days)
+		}
+		minutes, recent := recentClosureMinutes(issue, since, medianMinutes)
+		for _, s := range stats {
+			if hasCycleTime {
+				s.cycleTimes = append(s.cycleTimes, days)
+			}
+			if recent {
+				s.minutes += minutes
+				s.closures++
+			}
+		}
+	}
+
+	vm := &VelocityModel{
+		WindowDays:            int(etaVelocityWindow / (24 * time.Hour)),
+		MedianEstimateMinutes: medianMinutes,
+		Labels:                []LabelVelocity{},
+		types:                 make(map[model.IssueType]TypeVelocity),
+		labels:                make(map[string]LabelVelocity),
+	}
+	learn := func(name string, s *labelStats) LabelVelocity {
+		lv := LabelVelocity{Label: name, CycleTime: summarizeCycleTimes(s.cycleTimes), RecentClosures: s.closures}
+		if s.closures > 0 {
+			lv.MinutesPerDay = float64(s.minutes) / 30.0
+		}
+		return lv
+	}
+	vm.Overall = learn("", overall)
+	vm.labels[""] = vm.Overall
+	for name, s := range byLabel {
+		vm.labels[name] = learn(name, s)
+		vm.Labels = append(vm.Labels, vm.labels[name])
+	}
+	sort.Slice(vm.Labels, func(i, j int) bool { return vm.Labels[i].Label < vm.Labels[j].Label })
+
+	for t := range priorTypeWeights {
+		if _, ok := byType[t]; !ok {
+			byType[t] = nil
+		}
+	}
+	for t, cycleTimes := range byType {
+		tv := TypeVelocity{IssueType: t, CycleTime: summarizeCycleTimes(cycleTimes), PriorWeight: priorTypeWeight(t)}
+		tv.Weight = tv.PriorWeight
+		if n := tv.CycleTime.Samples; n > 0 && vm.Overall.CycleTime.MedianDays > 0 {
+			learned := clampFloat(tv.CycleTime.MedianDays/vm.Overall.CycleTime.MedianDays, 0.25, 4.0)
+			tv.Weight = (float64(n)*learned + velocityPriorSamples*tv.PriorWeight) / float64(n+velocityPriorSamples)
+		}
+		vm.types[t] = tv
+		vm.Types = append(vm.Types, tv)
+	}
+	sort.Slice(vm.Types, func(i, j int) bool { return vm.Types[i].IssueType < vm.Types[j].IssueType })
+	return vm
+}
+
+// TypeWeight returns the complexity weight of issue type t and the number
+// of closures it was learned from.
+func (vm *VelocityModel) TypeWeight(t model.IssueType) (float64, int) {
+	if vm != nil {
+		if tv, ok := vm.types[t]; ok {
+			return tv.Weight, tv.CycleTime.Samples
+		}
+	}
+	return priorTypeWeight(t), 0
+}
+
+// VelocityForLabel returns the velocity in minutes/day of the recent closures
+// sharing label ("" for all closures) and how many closures it is based on.
+func (vm *VelocityModel) VelocityForLabel(label string) (float64, int) {
+	if vm == nil {
+		return 0, 0
+	}
+	lv := vm.labels[label]
+	return lv.MinutesPerDay, lv.RecentClosures
+}
+
+func priorTypeWeight(t model.IssueType) float64 {
+	if w, ok := priorTypeWeights[t]; ok {
+		return w
+	}
+	return 1.0
+}
+
+// cycleTimeDays is the time from creation to close, if both are known
+func cycleTimeDays(issue model.Issue) (float64, bool) {
+	if issue.ClosedAt == nil || issue.CreatedAt.IsZero() || !issue.ClosedAt.After(issue.CreatedAt) {
+		return 0, false
+	}
+	return issue.ClosedAt.Sub(issue.CreatedAt).Hours() / 24, true
+}
+
+func summarizeCycleTimes(days []float64) CycleTimeStats {
+	if len(days) == 0 {
+		return CycleTimeStats{}
+	}
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+	total := 0.0
+	for _, d := range sorted {
+		total += d
+	}
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	return CycleTimeStats{
+		Samples:    len(sorted),
+		MedianDays: median,
+		P85Days:    sorted[max(int(math.Ceil(0.85*float64(len(sorted))))-1, 0)],
+		MeanDays:   total / float64(len(sorted)),
+	}
+}
+
+// RobotVelocityOutput is the --robot-velocity output.
+type RobotVelocityOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	*VelocityModel
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotVelocityOutput creates the full robot-velocity output.
+func GenerateRobotVelocityOutput(issues []model.Issue, dataHash string, now time.Time) RobotVelocityOutput {
+	return RobotVelocityOutput{
+		GeneratedAt:   now.UTC().Format(time.RFC3339),
+		DataHash:      dataHash,
+		VelocityModel: LearnVelocityModel(issues, now),
+		UsageHints: []string{
+			"jq '.types[] | {issue_type, weight, prior_weight, samples: .cycle_time.samples}' - Learned type weights",
+			"jq '.labels | sort_by(.minutes_per_day) | .[:5]' - Slowest labels",
+			"jq '.overall.cycle_time' - Cycle time distribution of all closures",
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLearnVelocityModel(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	minutes := 60
+	closed := func(id string, typ model.IssueType, cycleDays float64, labels ...string) model.Issue {
+		closedAt := now.Add(-24 * time.Hour)
+		return model.Issue{
+			ID: id, Status: model.StatusClosed, IssueType: typ, Labels: labels, EstimatedMinutes: &minutes,
+			CreatedAt: closedAt.Add(-time.Duration(cycleDays * float64(24*time.Hour))), ClosedAt: &closedAt,
+		}
+	}
+	// Bugs close in 1 day, features in 4: features are slower than the prior says
+	var issues []model.Issue
+	for i := 0; i < 5; i++ {
+		issues = append(issues,
+			closed(fmt.Sprintf("b%d", i), model.TypeBug, 1, "api"),
+			closed(fmt.Sprintf("f%d", i), model.TypeFeature, 4, "ui"))
+	}
+	issues = append(issues, model.Issue{ID: "open", Status: model.StatusOpen, IssueType: model.TypeFeature, Labels: []string{"ui"}})
+
+	vm := LearnVelocityModel(issues, now)
+	if vm.Overall.CycleTime.Samples != 10 || vm.Overall.RecentClosures != 10 {
+		t.Fatalf("expected 10 closures overall, got %+v", vm.Overall)
+	}
+	if got := vm.Overall.CycleTime.MedianDays; math.Abs(got-2.5) > 1e-9 {
+		t.Errorf("overall median cycle time = %v, want 2.5", got)
+	}
+
+	// Learned ratios (0.4 and 1.6) are blended with the priors over 5+5 samples
+	bug, n := vm.TypeWeight(model.TypeBug)
+	if n != 5 || math.Abs(bug-0.7) > 1e-9 {
+		t.Errorf("bug weight = %v from %d, want 0.7 from 5", bug, n)
+	}
+	feature, _ := vm.TypeWeight(model.TypeFeature)
+	if math.Abs(feature-1.45) > 1e-9 {
+		t.Errorf("feature weight = %v, want 1.45", feature)
+	}
+	if chore, n := vm.TypeWeight(model.TypeChore); chore != 0.8 || n != 0 {
+		t.Errorf("a type with no closures should keep its prior, got %v from %d", chore, n)
+	}
+
+	if v, n := vm.VelocityForLabel("api"); n != 5 || v != 10 {
+		t.Errorf("api velocity = %v from %d, want 10 min/day from 5", v, n)
+	}
+	if len(vm.Labels) != 2 || vm.Labels[0].Label != "api" {
+		t.Errorf("expected labels api and ui, got %+v", vm.Labels)
+	}
+
+	// The ETA uses the learned weight and says so
+	eta, err := EstimateETAForIssue(issues, nil, "open", 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.Join(eta.Factors, "; "), "type: feature×1.45 (learned, 5 closed)") {
+		t.Errorf("expected a learned type factor, got %v", eta.Factors)
+	}
+
+	var none *VelocityModel
+	if w, _ := none.TypeWeight(model.TypeEpic); w != 2.0 {
+		t.Errorf("a nil model should use the prior weight, got %v", w)
+	}
+}
@@ -143,11 +143,13 @@ func (a *Analyzer) GenerateEnhancedRecommendationsWithThresholds(thresholds Reco
 	now := time.Now().UTC()
 
 	// Enhance each score with what-if deltas
+	stats := a.Analyze()
+	criticalPath := stats.CriticalPathScore()
 	for _, score := range scores {
 		rec, hasRec := recMap[score.IssueID]
 
 		// Generate what-if for all scores (not just those with recommendations)
-		whatIf := a.computeWhatIfDelta(score.IssueID)
+		whatIf := a.whatIfDelta(score.IssueID, criticalPath)
 		topReasons := GenerateTopReasons(score)
 
 		// Determine if caps were applied
@@ -224,6 +226,15 @@ type WhatIfEntry struct {
 	Delta   WhatIfDelta `json:"delta"`
 }
 
+// WhatIf returns the impact of completing a single issue, or nil if the
+// issue is unknown.
+func (a *Analyzer) WhatIf(issueID string) *WhatIfDelta {
+	if _, ok := a.issueMap[issueID]; !ok {
+		return nil
+	}
+	return a.computeWhatIfDelta(issueID)
+}
+
 // TopWhatIfDeltas returns the top N issues with highest downstream impact (bv-83)
 func (a *Analyzer) TopWhatIfDeltas(n int) []WhatIfEntry {
 	if n <= 0 {
@@ -232,11 +243,13 @@ func (a *Analyzer) TopWhatIfDeltas(n int) []WhatIfEntry {
 
 	var results []WhatIfEntry
 
+	stats := a.Analyze()
+	criticalPath := stats.CriticalPathScore()
 	for id, issue := range a.issueMap {
 		if issue.Status == model.StatusClosed {
 			continue
 		}
-		delta := a.computeWhatIfDelta(id)
+		delta := a.whatIfDelta(id, criticalPath)
 		if delta == nil {
 			continue
 		}
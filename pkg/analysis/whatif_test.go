@@ -332,3 +332,23 @@ func TestExplanationStatus_Capped(t *testing.T) {
 		t.Error("expected capped fields to be set")
 	}
 }
+
+func TestAnalyzerWhatIf(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+	an := NewAnalyzer(issues)
+
+	delta := an.WhatIf("A")
+	if delta == nil {
+		t.Fatal("expected a delta for A")
+	}
+	if delta.DirectUnblocks != 1 || delta.TransitiveUnblocks != 2 {
+		t.Errorf("closing A: direct %d transitive %d, want 1 and 2", delta.DirectUnblocks, delta.TransitiveUnblocks)
+	}
+	if an.WhatIf("missing") != nil {
+		t.Error("unknown issue should yield nil")
+	}
+}
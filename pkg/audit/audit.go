@@ -0,0 +1,174 @@
+// Package audit keeps a trail of the writes bv makes to the beads file in
+// .bv/audit.jsonl, one JSON entry per line, with when and by whom.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Filename is the audit log filename inside .bv/
+const Filename = "audit.jsonl"
+
+// Actions
+const (
+	ActionEdit         = "edit"         // Fields of one issue edited
+	ActionPriority     = "priority"     // Suggested priorities applied
+	ActionDependencies = "dependencies" // Dependencies of one issue changed
+	ActionMerge        = "merge"        // Merge conflict resolved
+	ActionCreate       = "create"       // Issue created from a template
+	ActionImport       = "import"       // Issues imported (Markdown, GitHub)
+	ActionRecur        = "recur"        // Recurring issues materialized
+	ActionDedupe       = "dedupe"       // Issues sharing an ID merged or re-IDed
+	ActionRename       = "rename"       // Issue ID changed, with the references to it
+	ActionArchive      = "archive"      // Old closed issues moved to archive.jsonl
+)
+
+// Sources
+const (
+	SourceTUI = "tui"
+	SourceCLI = "cli"
+)
+
+// Entry is one mutating action.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	IssueIDs []string  `json:"issue_ids,omitempty"`
+	Changes  []string  `json:"changes,omitempty"` // What was written, e.g. field names
+	Author   string    `json:"author"`
+	Source   string    `json:"source"` // tui or cli
+}
+
+// Path returns .bv/audit.jsonl under projectDir
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", Filename)
+}
+
+// GitAuthor returns "Name <email>" from the git config of projectDir,
+// falling back to $USER
+func GitAuthor(projectDir string) string {
+	get := func(key string) string {
+		out, err := exec.Command("git", "-C", projectDir, "config", key).Output()
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(out))
+	}
+	name, email := get("user.name"), get("user.email")
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
+		return name
+	case email != "":
+		return email
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "unknown"
+}
+
+// Append adds e to the audit log of projectDir, stamping the time and
+// author when they are not set
+func Append(projectDir string, e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Author == "" {
+		e.Author = GitAuthor(projectDir)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+
+	path := Path(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating .bv directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// Load reads the audit log of projectDir, oldest first. A missing log
+// yields no entries; malformed lines are skipped.
+func Load(projectDir string) ([]Entry, error) {
+	f, err := os.Open(Path(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil || e.Action == "" {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Filter selects the entries at or after since (when set) that touch
+// issueID (when set)
+func Filter(entries []Entry, since time.Time, issueID string) []Entry {
+	var out []Entry
+	for _, e := range entries {
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if issueID != "" && !touches(e, issueID) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func touches(e Entry, issueID string) bool {
+	for _, id := range e.IssueIDs {
+		if id == issueID {
+			return true
+		}
+	}
+	return false
+}
+
+// RobotAuditOutput is the --robot-audit output.
+type RobotAuditOutput struct {
+	GeneratedAt string   `json:"generated_at"`
+	Path        string   `json:"path"`
+	Since       string   `json:"since,omitempty"`
+	IssueID     string   `json:"issue_id,omitempty"`
+	Count       int      `json:"count"`
+	Entries     []Entry  `json:"entries"` // Oldest first
+	UsageHints  []string `json:"usage_hints"`
+}
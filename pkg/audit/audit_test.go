@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	if entries, err := Load(dir); err != nil || len(entries) != 0 {
+		t.Fatalf("missing log: got %v, %v", entries, err)
+	}
+
+	base := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	for i, e := range []Entry{
+		{Action: ActionEdit, IssueIDs: []string{"A"}, Changes: []string{"status"}, Source: SourceTUI},
+		{Action: ActionPriority, IssueIDs: []string{"A", "B"}, Source: SourceTUI},
+		{Action: ActionImport, IssueIDs: []string{"C"}, Source: SourceCLI},
+	} {
+		e.Time = base.Add(time.Duration(i) * time.Hour)
+		e.Author = "Dana <dana@example.com>"
+		if err := Append(dir, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Stray lines are skipped
+	f, err := os.OpenFile(Path(dir), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("not json\n{}\n")
+	_ = f.Close()
+
+	entries, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].Action != ActionEdit || !entries[2].Time.Equal(base.Add(2*time.Hour)) {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	if got := Filter(entries, base.Add(time.Hour), ""); len(got) != 2 {
+		t.Errorf("since filter kept %d entries, want 2", len(got))
+	}
+	if got := Filter(entries, time.Time{}, "A"); len(got) != 2 || got[1].Action != ActionPriority {
+		t.Errorf("issue filter = %+v", got)
+	}
+}
+
+func TestAppendStampsTimeAndAuthor(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("USER", "dana")
+	if err := Append(dir, Entry{Action: ActionCreate, IssueIDs: []string{"A"}}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := Load(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("entries = %v, %v", entries, err)
+	}
+	if entries[0].Time.IsZero() || time.Since(entries[0].Time) > time.Minute {
+		t.Errorf("time = %v", entries[0].Time)
+	}
+	if strings.TrimSpace(entries[0].Author) == "" {
+		t.Error("author should be filled in")
+	}
+}
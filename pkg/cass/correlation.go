@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -207,6 +208,48 @@ func (c *Correlator) Correlate(ctx context.Context, issue *model.Issue) Correlat
 	return result
 }
 
+// DefaultIndexConcurrency bounds how many beads CorrelateAll correlates at once.
+const DefaultIndexConcurrency = 4
+
+// CorrelateAll correlates every issue up front, running at most concurrency
+// correlations at a time, and returns the number of sessions found per bead.
+// Results land in the cache, so later Correlate calls for these beads are
+// instant. Beads left when ctx is done are skipped.
+func (c *Correlator) CorrelateAll(ctx context.Context, issues []model.Issue, concurrency int) map[string]int {
+	if concurrency <= 0 {
+		concurrency = DefaultIndexConcurrency
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		counts = make(map[string]int, len(issues))
+		sem    = make(chan struct{}, concurrency)
+	)
+	for i := range issues {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return counts
+		}
+		wg.Add(1)
+		go func(issue *model.Issue) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := c.Correlate(ctx, issue)
+			if ctx.Err() != nil {
+				return
+			}
+			mu.Lock()
+			counts[issue.ID] = result.TotalFound
+			mu.Unlock()
+		}(&issues[i])
+	}
+	wg.Wait()
+	return counts
+}
+
 // searchByID searches for the bead ID literally in sessions.
 func (c *Correlator) searchByID(ctx context.Context, beadID string) []ScoredResult {
 	// Quote the ID for exact matching
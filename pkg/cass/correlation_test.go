@@ -3,6 +3,7 @@ package cass
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -632,3 +633,57 @@ func TestCorrelator_EmptyResults(t *testing.T) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestCorrelator_CorrelateAll(t *testing.T) {
+	detector := NewDetector()
+	detector.lookPath = func(name string) (string, error) { return "/usr/bin/cass", nil }
+	detector.runCommand = func(ctx context.Context, name string, args ...string) (int, error) { return 0, nil }
+	_ = detector.Check()
+
+	searcher := NewSearcher(detector)
+	var mu sync.Mutex
+	calls := 0
+	searcher.runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		for _, arg := range args {
+			if arg == `"bv-1"` {
+				return []byte(`{"results": [
+					{"source_path": "/p/s1.json", "title": "bv-1 work", "score": 0.9, "snippet": "bv-1"},
+					{"source_path": "/p/s2.json", "title": "more bv-1", "score": 0.8, "snippet": "bv-1"}
+				], "meta": {"total": 2}}`), nil
+			}
+		}
+		return []byte(`{"results": [], "meta": {"total": 0}}`), nil
+	}
+
+	cache := NewCache()
+	correlator := NewCorrelator(searcher, cache, "/p")
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "First"},
+		{ID: "bv-2", Title: "Second"},
+		{ID: "bv-3", Title: "Third"},
+	}
+
+	counts := correlator.CorrelateAll(context.Background(), issues, 2)
+	if counts["bv-1"] != 2 || counts["bv-2"] != 0 || len(counts) != 3 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+	for _, issue := range issues {
+		if correlator.GetCached(issue.ID) == nil {
+			t.Errorf("expected %s to be cached", issue.ID)
+		}
+	}
+
+	// A second pass is served from the cache
+	mu.Lock()
+	before := calls
+	mu.Unlock()
+	correlator.CorrelateAll(context.Background(), issues, 2)
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != before {
+		t.Errorf("expected no new searches on a cached pass, got %d", calls-before)
+	}
+}
@@ -0,0 +1,237 @@
+package cass
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// MaxTranscriptSize is the max bytes read from a session file (16MB).
+const MaxTranscriptSize = 16 * 1024 * 1024
+
+// TranscriptMessage is a single turn of a coding-agent session.
+type TranscriptMessage struct {
+	Role      string    // "user", "assistant", "system", "tool" (may be empty)
+	Text      string    // Message text (markdown)
+	Timestamp time.Time // Zero if the session does not record it
+}
+
+// Transcript is the full conversation of a session file referenced by a
+// SearchResult.
+type Transcript struct {
+	SourcePath string
+	Messages   []TranscriptMessage
+}
+
+// CodeBlock is a fenced code block found in a transcript message.
+type CodeBlock struct {
+	Message  int    // Index into Transcript.Messages
+	Language string // Fence info string, e.g. "go"
+	Code     string
+}
+
+// LoadTranscript reads the session file at path. Agents store sessions in
+// several shapes (JSONL of messages, Claude/Codex event envelopes, a JSON
+// document with a "messages" array); anything unrecognized is shown as a
+// single plain-text message.
+func LoadTranscript(path string) (*Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open session: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxTranscriptSize))
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+	return &Transcript{SourcePath: path, Messages: ParseTranscript(data)}, nil
+}
+
+// ParseTranscript extracts the messages of a session file's contents.
+func ParseTranscript(data []byte) []TranscriptMessage {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil
+	}
+
+	// Whole-document JSON: {"messages": [...]} or a bare array.
+	var doc any
+	if json.Unmarshal(trimmed, &doc) == nil {
+		switch v := doc.(type) {
+		case map[string]any:
+			if list, ok := v["messages"].([]any); ok {
+				return messagesFromList(list)
+			}
+			if msg, ok := messageFromRecord(v); ok {
+				return []TranscriptMessage{msg}
+			}
+		case []any:
+			if msgs := messagesFromList(v); len(msgs) > 0 {
+				return msgs
+			}
+		}
+	}
+
+	// JSONL: one record per line.
+	var msgs []TranscriptMessage
+	parsedAny := false
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxTranscriptSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var rec map[string]any
+		if json.Unmarshal(line, &rec) != nil {
+			continue
+		}
+		parsedAny = true
+		if msg, ok := messageFromRecord(rec); ok {
+			msgs = append(msgs, msg)
+		}
+	}
+	if parsedAny {
+		return msgs
+	}
+
+	return []TranscriptMessage{{Text: string(trimmed)}}
+}
+
+func messagesFromList(list []any) []TranscriptMessage {
+	var msgs []TranscriptMessage
+	for _, item := range list {
+		if rec, ok := item.(map[string]any); ok {
+			if msg, ok := messageFromRecord(rec); ok {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs
+}
+
+// messageFromRecord understands plain {"role","content"} messages as well
+// as envelopes that nest them under "message" (Claude Code) or "payload"
+// (Codex). Records without text (tool results, metadata) are skipped.
+func messageFromRecord(rec map[string]any) (TranscriptMessage, bool) {
+	msg := TranscriptMessage{Timestamp: parseTimestamp(rec["timestamp"])}
+
+	inner := rec
+	for _, key := range []string{"message", "payload"} {
+		if nested, ok := rec[key].(map[string]any); ok {
+			inner = nested
+			if ts := parseTimestamp(nested["timestamp"]); !ts.IsZero() && msg.Timestamp.IsZero() {
+				msg.Timestamp = ts
+			}
+			break
+		}
+	}
+
+	msg.Role = stringField(inner, "role")
+	if msg.Role == "" {
+		switch t := stringField(rec, "type"); t {
+		case "user", "assistant", "system":
+			msg.Role = t
+		}
+	}
+
+	msg.Text = strings.TrimSpace(contentText(inner["content"]))
+	if msg.Text == "" {
+		msg.Text = strings.TrimSpace(stringField(inner, "text"))
+	}
+	return msg, msg.Text != ""
+}
+
+// contentText flattens a message's content, which is either a string or a
+// list of typed parts.
+func contentText(content any) string {
+	switch v := content.(type) {
+	case string:
+		return v
+	case []any:
+		var parts []string
+		for _, item := range v {
+			switch p := item.(type) {
+			case string:
+				parts = append(parts, p)
+			case map[string]any:
+				if text := stringField(p, "text"); text != "" {
+					parts = append(parts, text)
+				} else if stringField(p, "type") == "tool_use" {
+					parts = append(parts, fmt.Sprintf("[tool: %s]", stringField(p, "name")))
+				}
+			}
+		}
+		return strings.Join(parts, "\n\n")
+	}
+	return ""
+}
+
+func stringField(rec map[string]any, key string) string {
+	s, _ := rec[key].(string)
+	return s
+}
+
+func parseTimestamp(v any) time.Time {
+	switch ts := v.(type) {
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return t
+		}
+	case float64:
+		// Epoch seconds or milliseconds
+		if ts > 1e12 {
+			return time.UnixMilli(int64(ts))
+		}
+		return time.Unix(int64(ts), 0)
+	}
+	return time.Time{}
+}
+
+// CodeBlocks returns every fenced code block in the transcript, in order.
+func (t *Transcript) CodeBlocks() []CodeBlock {
+	var blocks []CodeBlock
+	for i, msg := range t.Messages {
+		for _, b := range ExtractCodeBlocks(msg.Text) {
+			b.Message = i
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
+
+// ExtractCodeBlocks returns the ``` fenced code blocks in markdown text.
+// An unterminated fence runs to the end of the text.
+func ExtractCodeBlocks(text string) []CodeBlock {
+	var blocks []CodeBlock
+	var current *CodeBlock
+	var body []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "```") {
+			if current != nil {
+				body = append(body, line)
+			}
+			continue
+		}
+		if current == nil {
+			current = &CodeBlock{Language: strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))}
+			body = nil
+			continue
+		}
+		current.Code = strings.Join(body, "\n")
+		blocks = append(blocks, *current)
+		current = nil
+	}
+	if current != nil {
+		current.Code = strings.Join(body, "\n")
+		blocks = append(blocks, *current)
+	}
+	return blocks
+}
@@ -0,0 +1,130 @@
+package cass
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTranscript_Formats(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		roles []string
+		texts []string
+	}{
+		{
+			name: "plain jsonl",
+			data: `{"role":"user","content":"fix the bug"}
+{"role":"assistant","content":"done"}`,
+			roles: []string{"user", "assistant"},
+			texts: []string{"fix the bug", "done"},
+		},
+		{
+			name: "claude envelopes with content parts",
+			data: `{"type":"summary","summary":"ignored"}
+{"type":"user","message":{"role":"user","content":"hello"},"timestamp":"2025-01-02T03:04:05Z"}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"part one"},{"type":"tool_use","name":"Bash"}]}}`,
+			roles: []string{"user", "assistant"},
+			texts: []string{"hello", "part one\n\n[tool: Bash]"},
+		},
+		{
+			name:  "codex payload",
+			data:  `{"type":"response_item","payload":{"role":"assistant","content":[{"type":"output_text","text":"ok"}]}}`,
+			roles: []string{"assistant"},
+			texts: []string{"ok"},
+		},
+		{
+			name:  "messages document",
+			data:  `{"title":"x","messages":[{"role":"user","text":"q"},{"role":"assistant","content":"a"}]}`,
+			roles: []string{"user", "assistant"},
+			texts: []string{"q", "a"},
+		},
+		{
+			name:  "plain text",
+			data:  "just some notes\nacross lines",
+			roles: []string{""},
+			texts: []string{"just some notes\nacross lines"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs := ParseTranscript([]byte(tt.data))
+			if len(msgs) != len(tt.texts) {
+				t.Fatalf("got %d messages, want %d: %+v", len(msgs), len(tt.texts), msgs)
+			}
+			for i, msg := range msgs {
+				if msg.Role != tt.roles[i] {
+					t.Errorf("msg %d role = %q, want %q", i, msg.Role, tt.roles[i])
+				}
+				if msg.Text != tt.texts[i] {
+					t.Errorf("msg %d text = %q, want %q", i, msg.Text, tt.texts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseTranscript_Timestamp(t *testing.T) {
+	msgs := ParseTranscript([]byte(`{"type":"user","message":{"role":"user","content":"hi"},"timestamp":"2025-01-02T03:04:05Z"}` + "\n"))
+	if len(msgs) != 1 || msgs[0].Timestamp.IsZero() {
+		t.Fatalf("expected timestamped message, got %+v", msgs)
+	}
+	if msgs[0].Timestamp.Year() != 2025 {
+		t.Errorf("Timestamp = %v, want 2025", msgs[0].Timestamp)
+	}
+}
+
+func TestLoadTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	if err := os.WriteFile(path, []byte(`{"role":"user","content":"hi"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tr, err := LoadTranscript(path)
+	if err != nil {
+		t.Fatalf("LoadTranscript: %v", err)
+	}
+	if tr.SourcePath != path || len(tr.Messages) != 1 {
+		t.Errorf("unexpected transcript %+v", tr)
+	}
+
+	if _, err := LoadTranscript(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected error for missing session file")
+	}
+}
+
+func TestExtractCodeBlocks(t *testing.T) {
+	text := "intro\n```go\nfmt.Println(1)\n```\nmiddle\n```\nls -la\necho hi\n```\n```sh\nunterminated"
+	blocks := ExtractCodeBlocks(text)
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3: %+v", len(blocks), blocks)
+	}
+	if blocks[0].Language != "go" || blocks[0].Code != "fmt.Println(1)" {
+		t.Errorf("block 0 = %+v", blocks[0])
+	}
+	if blocks[1].Language != "" || blocks[1].Code != "ls -la\necho hi" {
+		t.Errorf("block 1 = %+v", blocks[1])
+	}
+	if blocks[2].Language != "sh" || blocks[2].Code != "unterminated" {
+		t.Errorf("block 2 = %+v", blocks[2])
+	}
+}
+
+func TestTranscript_CodeBlocks(t *testing.T) {
+	tr := &Transcript{Messages: []TranscriptMessage{
+		{Text: "no code"},
+		{Text: "```\na\n```"},
+		{Text: "```\nb\n```\n```\nc\n```"},
+	}}
+	blocks := tr.CodeBlocks()
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(blocks))
+	}
+	want := []int{1, 2, 2}
+	for i, b := range blocks {
+		if b.Message != want[i] {
+			t.Errorf("block %d message = %d, want %d", i, b.Message, want[i])
+		}
+	}
+}
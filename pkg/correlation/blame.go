@@ -0,0 +1,194 @@
+package correlation
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// FieldAttribution records the commit that last set a field of an issue
+type FieldAttribution struct {
+	Value       string    `json:"value"`
+	CommitSHA   string    `json:"commit_sha"`
+	CommitMsg   string    `json:"commit_message"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"author_email"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// IssueBlame attributes an issue's status and priority to their last modifiers.
+// UncommittedFields lists fields whose value in the working copy differs from
+// the last committed value, i.e. the attribution is for the previous value.
+type IssueBlame struct {
+	IssueID           string            `json:"issue_id"`
+	Title             string            `json:"title,omitempty"`
+	Status            *FieldAttribution `json:"status,omitempty"`
+	Priority          *FieldAttribution `json:"priority,omitempty"`
+	UncommittedFields []string          `json:"uncommitted_fields,omitempty"`
+}
+
+// BlameTarget is the current state of an issue to attribute
+type BlameTarget struct {
+	ID       string
+	Title    string
+	Status   string
+	Priority int
+}
+
+// AuthorBlameCount counts the fields an author was the last to change
+type AuthorBlameCount struct {
+	Author        string `json:"author"`
+	StatusCount   int    `json:"status_count"`
+	PriorityCount int    `json:"priority_count"`
+}
+
+// BlameReport is the blame output for a set of issues
+type BlameReport struct {
+	IssueCount   int                `json:"issue_count"`
+	Attributed   int                `json:"attributed_count"`
+	Issues       []IssueBlame       `json:"issues"`
+	Authors      []AuthorBlameCount `json:"authors"`
+	Unattributed []string           `json:"unattributed"`
+}
+
+// ExtractBlame walks the git history of the beads file (newest first) and
+// records, for every issue, the commit that last changed its status and its
+// priority. Creating an issue counts as setting both.
+func (e *Extractor) ExtractBlame(opts ExtractOptions) (map[string]*IssueBlame, error) {
+	blame := make(map[string]*IssueBlame)
+	err := e.runGitLog(opts, func(r io.Reader) error {
+		return scanGitLog(r, func(info commitInfo, diff []byte) {
+			recordBlame(blame, info, diff, opts.BeadID)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("extracting blame: %w", err)
+	}
+	return blame, nil
+}
+
+// recordBlame attributes fields changed in one commit. Commits must be fed
+// newest first so that the first attribution recorded is the latest one.
+func recordBlame(blame map[string]*IssueBlame, info commitInfo, diff []byte, issueID string) {
+	for id, pair := range diffIssueLines(diff, issueID) {
+		if pair.new == nil {
+			continue
+		}
+		b := blame[id]
+		if b == nil {
+			b = &IssueBlame{IssueID: id}
+			blame[id] = b
+		}
+		created := pair.old == nil
+		if b.Status == nil && (created || pair.old.Status != pair.new.Status) {
+			b.Status = newFieldAttribution(pair.new.Status, info)
+		}
+		if b.Priority == nil && (created || formatPriority(pair.old.Priority) != formatPriority(pair.new.Priority)) {
+			b.Priority = newFieldAttribution(formatPriority(pair.new.Priority), info)
+		}
+	}
+}
+
+func newFieldAttribution(value string, info commitInfo) *FieldAttribution {
+	return &FieldAttribution{
+		Value:       value,
+		CommitSHA:   info.SHA,
+		CommitMsg:   info.Message,
+		Author:      info.Author,
+		AuthorEmail: info.AuthorEmail,
+		Timestamp:   info.Timestamp,
+	}
+}
+
+// AttributeIssue returns the blame for one issue, flagging fields whose
+// current value was never committed.
+func AttributeIssue(blame map[string]*IssueBlame, target BlameTarget) IssueBlame {
+	result := IssueBlame{IssueID: target.ID, Title: target.Title}
+	b := blame[target.ID]
+	if b == nil {
+		return result
+	}
+	result.Status = b.Status
+	result.Priority = b.Priority
+	if b.Status != nil && b.Status.Value != target.Status {
+		result.UncommittedFields = append(result.UncommittedFields, "status")
+	}
+	if b.Priority != nil && b.Priority.Value != fmt.Sprintf("P%d", target.Priority) {
+		result.UncommittedFields = append(result.UncommittedFields, "priority")
+	}
+	return result
+}
+
+// BuildBlameReport attributes every target and summarizes last modifiers by
+// author. Issues appear in target order.
+func BuildBlameReport(blame map[string]*IssueBlame, targets []BlameTarget) BlameReport {
+	report := BlameReport{
+		IssueCount:   len(targets),
+		Issues:       make([]IssueBlame, 0, len(targets)),
+		Authors:      []AuthorBlameCount{},
+		Unattributed: []string{},
+	}
+
+	byAuthor := make(map[string]*AuthorBlameCount)
+	count := func(author string) *AuthorBlameCount {
+		c := byAuthor[author]
+		if c == nil {
+			c = &AuthorBlameCount{Author: author}
+			byAuthor[author] = c
+		}
+		return c
+	}
+
+	for _, target := range targets {
+		ib := AttributeIssue(blame, target)
+		if ib.Status == nil && ib.Priority == nil {
+			report.Unattributed = append(report.Unattributed, target.ID)
+			continue
+		}
+		report.Attributed++
+		if ib.Status != nil {
+			count(ib.Status.Author).StatusCount++
+		}
+		if ib.Priority != nil {
+			count(ib.Priority.Author).PriorityCount++
+		}
+		report.Issues = append(report.Issues, ib)
+	}
+
+	for _, c := range byAuthor {
+		report.Authors = append(report.Authors, *c)
+	}
+	sort.Slice(report.Authors, func(i, j int) bool {
+		ti := report.Authors[i].StatusCount + report.Authors[i].PriorityCount
+		tj := report.Authors[j].StatusCount + report.Authors[j].PriorityCount
+		if ti != tj {
+			return ti > tj
+		}
+		return report.Authors[i].Author < report.Authors[j].Author
+	})
+	return report
+}
+
+// RobotBlameOutput is the JSON output structure for --robot-blame
+type RobotBlameOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	BlameReport
+	UsageHints []string `json:"usage_hints"`
+}
+
+// GenerateRobotBlameOutput creates the full robot-blame output
+func GenerateRobotBlameOutput(blame map[string]*IssueBlame, targets []BlameTarget, dataHash string) RobotBlameOutput {
+	return RobotBlameOutput{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		BlameReport: BuildBlameReport(blame, targets),
+		UsageHints: []string{
+			"jq '.issues[] | {issue_id, status_by: .status.author, priority_by: .priority.author}' - Last modifiers per issue",
+			"jq '.issues[] | select(.issue_id == \"ISSUE-ID\")' - Blame for one issue",
+			"jq '.issues[] | select(.uncommitted_fields)' - Issues with uncommitted status/priority edits",
+			"jq '.authors' - Who last touched the most fields",
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package correlation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestBuildBlameReport(t *testing.T) {
+	at := func(value, author string) *FieldAttribution {
+		return &FieldAttribution{Value: value, Author: author, CommitSHA: "sha-" + author, Timestamp: time.Now()}
+	}
+	blame := map[string]*IssueBlame{
+		"A": {IssueID: "A", Status: at("in_progress", "alice"), Priority: at("P1", "bob")},
+		"B": {IssueID: "B", Status: at("open", "alice"), Priority: at("P2", "alice")},
+	}
+	targets := []BlameTarget{
+		{ID: "A", Title: "Alpha", Status: "in_progress", Priority: 1},
+		{ID: "B", Title: "Beta", Status: "closed", Priority: 2},
+		{ID: "C", Title: "Gamma", Status: "open", Priority: 3},
+	}
+
+	report := BuildBlameReport(blame, targets)
+	if report.IssueCount != 3 || report.Attributed != 2 {
+		t.Fatalf("expected 2 of 3 issues attributed, got %+v", report)
+	}
+	if len(report.Unattributed) != 1 || report.Unattributed[0] != "C" {
+		t.Errorf("expected C unattributed, got %v", report.Unattributed)
+	}
+	if report.Issues[0].IssueID != "A" || report.Issues[0].Title != "Alpha" || len(report.Issues[0].UncommittedFields) != 0 {
+		t.Errorf("unexpected blame for A: %+v", report.Issues[0])
+	}
+	if got := report.Issues[1].UncommittedFields; len(got) != 1 || got[0] != "status" {
+		t.Errorf("B's status differs from the committed value, got %v", got)
+	}
+	if len(report.Authors) != 2 || report.Authors[0].Author != "alice" || report.Authors[0].StatusCount != 2 || report.Authors[0].PriorityCount != 1 {
+		t.Errorf("unexpected author summary: %+v", report.Authors)
+	}
+}
+
+func TestExtractBlame_ParsedLog(t *testing.T) {
+	// Newest commit first, as git log emits it
+	data := []byte(`def456789012345678901234567890abcdef1234` + "\x00" + `2025-01-16T11:00:00Z` + "\x00" + `Bob` + "\x00" + `bob@example.com` + "\x00" + `Reprioritize
+
+-{"id":"bv-1","title":"Login","status":"in_progress","priority":2}
++{"id":"bv-1","title":"Login","status":"in_progress","priority":0}
+abc123def456789012345678901234567890abcd` + "\x00" + `2025-01-15T10:00:00Z` + "\x00" + `Alice` + "\x00" + `alice@example.com` + "\x00" + `Claim
+
+-{"id":"bv-1","title":"Login","status":"open","priority":2}
++{"id":"bv-1","title":"Login","status":"in_progress","priority":2}
+cde345678901234567890abcdef1234567890abc` + "\x00" + `2025-01-14T09:00:00Z` + "\x00" + `Carol` + "\x00" + `carol@example.com` + "\x00" + `Create
+
++{"id":"bv-1","title":"Login","status":"open","priority":2}
+`)
+
+	blame := make(map[string]*IssueBlame)
+	err := scanGitLog(bytes.NewReader(data), func(info commitInfo, diff []byte) {
+		recordBlame(blame, info, diff, "")
+	})
+	if err != nil {
+		t.Fatalf("scanGitLog: %v", err)
+	}
+	b := blame["bv-1"]
+	if b == nil || b.Status == nil || b.Priority == nil {
+		t.Fatalf("expected both fields attributed, got %+v", b)
+	}
+	if b.Status.Author != "Alice" || b.Status.Value != "in_progress" {
+		t.Errorf("status should be attributed to Alice, got %+v", b.Status)
+	}
+	if b.Priority.Author != "Bob" || b.Priority.Value != "P0" {
+		t.Errorf("priority should be attributed to Bob, got %+v", b.Priority)
+	}
+}
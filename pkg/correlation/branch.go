@@ -0,0 +1,71 @@
+package correlation
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// branchContextCommits is how many recent commits are scanned for bead IDs
+const branchContextCommits = 10
+
+// BranchContext describes the issues in flight on the checked-out branch
+type BranchContext struct {
+	Branch         string   `json:"branch"`           // Empty when HEAD is detached
+	BranchIssueIDs []string `json:"branch_issue_ids"` // Unclosed issues named by the branch itself
+	IssueIDs       []string `json:"issue_ids"`        // Unclosed issues named by the branch or recent commits
+}
+
+// DetectBranchContext finds the current branch and the unclosed beads named
+// in it or mentioned by its most recent commits. IDs from the branch name
+// come first, then mentions from newest to oldest commit.
+func DetectBranchContext(repoPath string, beads []BeadInfo) (*BranchContext, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("detecting current branch: %w", err)
+	}
+	ctx := &BranchContext{Branch: strings.TrimSpace(string(out))}
+	if ctx.Branch == "HEAD" {
+		ctx.Branch = ""
+	}
+
+	known := make(map[string]string, len(beads))
+	for _, b := range beads {
+		if b.Status != "closed" && b.Status != "tombstone" {
+			known[strings.ToLower(b.ID)] = b.ID
+		}
+	}
+	if len(known) == 0 {
+		return ctx, nil
+	}
+
+	seen := make(map[string]bool)
+	addIDs := func(ids []string) {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				ctx.IssueIDs = append(ctx.IssueIDs, id)
+			}
+		}
+	}
+	ctx.BranchIssueIDs = branchBeadIDs(ctx.Branch, known)
+	addIDs(ctx.BranchIssueIDs)
+
+	cmd = exec.Command("git", "log", fmt.Sprintf("-n%d", branchContextCommits), "--format=%B%x1e")
+	cmd.Dir = repoPath
+	out, err = cmd.Output()
+	if err != nil {
+		// Recent commits only add context; the branch alone is still useful
+		return ctx, nil
+	}
+	for _, message := range strings.Split(string(out), "\x1e") {
+		var ids []string
+		for _, m := range mentionedIDs(message, known) {
+			ids = append(ids, m.ID)
+		}
+		addIDs(ids)
+	}
+	return ctx, nil
+}
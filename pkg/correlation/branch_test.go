@@ -0,0 +1,75 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectBranchContext(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(file, msg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(msg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+
+	beads := []BeadInfo{
+		{ID: "bv-1", Status: "open"},
+		{ID: "bv-2", Status: "in_progress"},
+		{ID: "bv-3", Status: "closed"},
+		{ID: "bv-4", Status: "open"},
+	}
+
+	git("init", "-q", "-b", "main")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+
+	if _, err := DetectBranchContext(repo, beads); err == nil {
+		t.Error("expected an error before the first commit")
+	}
+
+	commit("a.go", "Start bv-4")
+	git("checkout", "-q", "-b", "feature/bv-2-export")
+	commit("b.go", "Wire exporter (bv-1, bv-3)")
+
+	ctx, err := DetectBranchContext(repo, beads)
+	if err != nil {
+		t.Fatalf("DetectBranchContext: %v", err)
+	}
+	if ctx.Branch != "feature/bv-2-export" {
+		t.Errorf("Branch = %q", ctx.Branch)
+	}
+	// Branch name first, then commits newest first; closed bv-3 is skipped
+	if want := []string{"bv-2", "bv-1", "bv-4"}; !reflect.DeepEqual(ctx.IssueIDs, want) {
+		t.Errorf("IssueIDs = %v, want %v", ctx.IssueIDs, want)
+	}
+	if want := []string{"bv-2"}; !reflect.DeepEqual(ctx.BranchIssueIDs, want) {
+		t.Errorf("BranchIssueIDs = %v, want %v", ctx.BranchIssueIDs, want)
+	}
+
+	git("checkout", "-q", "--detach")
+	ctx, err = DetectBranchContext(repo, beads)
+	if err != nil {
+		t.Fatalf("DetectBranchContext: %v", err)
+	}
+	if ctx.Branch != "" {
+		t.Errorf("detached HEAD should have no branch, got %q", ctx.Branch)
+	}
+}
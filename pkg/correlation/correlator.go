@@ -15,6 +15,7 @@ type Correlator struct {
 	repoPath    string
 	extractor   *Extractor
 	coCommitter *CoCommitExtractor
+	linker      *CommitLinker
 }
 
 // NewCorrelator creates a new correlator for the given repository.
@@ -26,6 +27,7 @@ func NewCorrelator(repoPath string, beadsFilePath ...string) *Correlator {
 		repoPath:    repoPath,
 		extractor:   NewExtractor(repoPath, beadsFilePath...),
 		coCommitter: NewCoCommitExtractor(repoPath),
+		linker:      NewCommitLinker(repoPath),
 	}
 }
 
@@ -59,6 +61,14 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		return nil, fmt.Errorf("extracting co-commits: %w", err)
 	}
 
+	// Link commits by ID mentions, branch names and titles. Co-commits come
+	// first so they win when both methods find the same commit.
+	linked, err := c.linker.Link(beads, extractOpts)
+	if err != nil {
+		return nil, fmt.Errorf("linking commits: %w", err)
+	}
+	commits = append(commits, linked...)
+
 	// Build bead histories
 	histories := c.buildHistories(beads, events, commits)
 
@@ -82,6 +82,24 @@ type beadSnapshot struct {
 
 // Extract extracts bead lifecycle events from git history
 func (e *Extractor) Extract(opts ExtractOptions) ([]BeadEvent, error) {
+	var events []BeadEvent
+	err := e.runGitLog(opts, func(r io.Reader) error {
+		var parseErr error
+		events, parseErr = e.parseGitLogOutput(r, opts.BeadID)
+		return parseErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort chronologically (git log returns newest first)
+	reverseEvents(events)
+
+	return events, nil
+}
+
+// runGitLog runs git log over the beads file and streams its output to parse
+func (e *Extractor) runGitLog(opts ExtractOptions, parse func(io.Reader) error) error {
 	// Build git log command
 	logArgs := e.buildGitLogArgs(opts)
 
@@ -93,15 +111,15 @@ func (e *Extractor) Extract(opts ExtractOptions) ([]BeadEvent, error) {
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, fmt.Errorf("creating stdout pipe: %w", err)
+		return fmt.Errorf("creating stdout pipe: %w", err)
 	}
 
 	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("starting git log: %w", err)
+		return fmt.Errorf("starting git log: %w", err)
 	}
 
 	// Parse output stream
-	events, parseErr := e.parseGitLogOutput(stdout, opts.BeadID)
+	parseErr := parse(stdout)
 
 	// If parsing failed, ensure we drain the pipe or kill the process to avoid deadlock
 	// where git log is blocked writing to full pipe while we wait for it to exit.
@@ -110,21 +128,18 @@ func (e *Extractor) Extract(opts ExtractOptions) ([]BeadEvent, error) {
 		_ = cmd.Process.Kill()
 		// We still need to wait to clean up zombies, but now it should exit quickly
 		_ = cmd.Wait()
-		return nil, fmt.Errorf("parsing git log output: %w", parseErr)
+		return fmt.Errorf("parsing git log output: %w", parseErr)
 	}
 
 	if err := cmd.Wait(); err != nil {
 		// If git log failed (non-zero exit), prefer that error
 		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("git log failed: %s", string(exitErr.Stderr))
+			return fmt.Errorf("git log failed: %s", string(exitErr.Stderr))
 		}
-		return nil, fmt.Errorf("git log failed: %w", err)
+		return fmt.Errorf("git log failed: %w", err)
 	}
 
-	// Sort chronologically (git log returns newest first)
-	reverseEvents(events)
-
-	return events, nil
+	return nil
 }
 
 // buildGitLogArgs constructs the git log command arguments
@@ -186,7 +201,18 @@ func insertBefore(slice []string, marker, value string) []string {
 // parseGitLogOutput parses the combined commit info and diff output from a stream
 func (e *Extractor) parseGitLogOutput(r io.Reader, filterBeadID string) ([]BeadEvent, error) {
 	var events []BeadEvent
+	err := scanGitLog(r, func(info commitInfo, diff []byte) {
+		events = append(events, e.parseDiff(diff, info, filterBeadID)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
 
+// scanGitLog splits git log -p output into commits and hands each commit's
+// diff to fn. Commits without a diff are skipped.
+func scanGitLog(r io.Reader, fn func(info commitInfo, diff []byte)) error {
 	// Use bufio.Reader instead of Scanner to handle long lines
 	const maxScanTokenSize = 10 * 1024 * 1024 // 10MB
 	reader := bufio.NewReaderSize(r, maxScanTokenSize)
@@ -201,8 +227,7 @@ func (e *Extractor) parseGitLogOutput(r io.Reader, filterBeadID string) ([]BeadE
 		}
 		diffBytes := diffBuffer.Bytes()
 		if len(diffBytes) > 0 {
-			diffEvents := e.parseDiff(diffBytes, *currentCommit, filterBeadID)
-			events = append(events, diffEvents...)
+			fn(*currentCommit, diffBytes)
 		}
 		diffBuffer.Reset()
 	}
@@ -214,7 +239,7 @@ func (e *Extractor) parseGitLogOutput(r io.Reader, filterBeadID string) ([]BeadE
 			if err == io.EOF {
 				break
 			}
-			return nil, err
+			return err
 		}
 
 		if isPrefix {
@@ -223,7 +248,7 @@ func (e *Extractor) parseGitLogOutput(r io.Reader, filterBeadID string) ([]BeadE
 			for isPrefix {
 				_, isPrefix, err = reader.ReadLine()
 				if err != nil && err != io.EOF {
-					return nil, err
+					return err
 				}
 				if err == io.EOF {
 					break
@@ -263,7 +288,7 @@ func (e *Extractor) parseGitLogOutput(r io.Reader, filterBeadID string) ([]BeadE
 	// Process final commit
 	processCommit()
 
-	return events, nil
+	return nil
 }
 
 // commitPattern matches the start of a commit in our custom log format
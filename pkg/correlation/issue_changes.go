@@ -0,0 +1,155 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// FieldChange describes a single field of an issue that changed in a commit
+type FieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// IssueChange is one commit's worth of changes to a single issue
+type IssueChange struct {
+	CommitSHA   string        `json:"commit_sha"`
+	CommitMsg   string        `json:"commit_message"`
+	Author      string        `json:"author"`
+	AuthorEmail string        `json:"author_email"`
+	Timestamp   time.Time     `json:"timestamp"`
+	Created     bool          `json:"created,omitempty"`
+	Deleted     bool          `json:"deleted,omitempty"`
+	Changes     []FieldChange `json:"changes"`
+}
+
+// issueFields is the subset of an issue line compared between revisions
+type issueFields struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	Priority    *int     `json:"priority"`
+	Assignee    string   `json:"assignee"`
+	IssueType   string   `json:"issue_type"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+// ExtractIssueChanges walks the git history of the beads file and returns the
+// field-level changes made to one issue, oldest first. Commits that rewrite the
+// issue line without changing a tracked field are omitted.
+func (e *Extractor) ExtractIssueChanges(issueID string, opts ExtractOptions) ([]IssueChange, error) {
+	opts.BeadID = issueID
+
+	var changes []IssueChange
+	err := e.runGitLog(opts, func(r io.Reader) error {
+		return scanGitLog(r, func(info commitInfo, diff []byte) {
+			if change, ok := parseIssueChange(diff, info, issueID); ok {
+				changes = append(changes, change)
+			}
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("extracting changes for %s: %w", issueID, err)
+	}
+
+	// git log returns newest first
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes, nil
+}
+
+// issueLinePair holds the removed and added JSONL lines for one issue in a commit
+type issueLinePair struct {
+	old, new *issueFields
+}
+
+// diffIssueLines collects the removed/added issue lines in a commit's diff,
+// keyed by issue ID. When issueID is non-empty only that issue is collected.
+func diffIssueLines(diff []byte, issueID string) map[string]*issueLinePair {
+	pairs := make(map[string]*issueLinePair)
+
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	scanner.Buffer(make([]byte, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "-{") && !strings.HasPrefix(line, "+{") {
+			continue
+		}
+		var fields issueFields
+		if err := json.Unmarshal([]byte(line[1:]), &fields); err != nil || fields.ID == "" {
+			continue
+		}
+		if issueID != "" && fields.ID != issueID {
+			continue
+		}
+		pair := pairs[fields.ID]
+		if pair == nil {
+			pair = &issueLinePair{}
+			pairs[fields.ID] = pair
+		}
+		if line[0] == '-' {
+			pair.old = &fields
+		} else {
+			pair.new = &fields
+		}
+	}
+	return pairs
+}
+
+// parseIssueChange compares the removed and added lines for issueID in a diff
+func parseIssueChange(diff []byte, info commitInfo, issueID string) (IssueChange, bool) {
+	var oldIssue, newIssue *issueFields
+	if pair := diffIssueLines(diff, issueID)[issueID]; pair != nil {
+		oldIssue, newIssue = pair.old, pair.new
+	}
+
+	change := IssueChange{
+		CommitSHA:   info.SHA,
+		CommitMsg:   info.Message,
+		Author:      info.Author,
+		AuthorEmail: info.AuthorEmail,
+		Timestamp:   info.Timestamp,
+		Changes:     []FieldChange{},
+	}
+
+	switch {
+	case oldIssue == nil && newIssue == nil:
+		return change, false
+	case oldIssue == nil:
+		change.Created = true
+		return change, true
+	case newIssue == nil:
+		change.Deleted = true
+		return change, true
+	}
+
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			change.Changes = append(change.Changes, FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	add("status", oldIssue.Status, newIssue.Status)
+	add("priority", formatPriority(oldIssue.Priority), formatPriority(newIssue.Priority))
+	add("title", oldIssue.Title, newIssue.Title)
+	add("assignee", oldIssue.Assignee, newIssue.Assignee)
+	add("type", oldIssue.IssueType, newIssue.IssueType)
+	add("labels", strings.Join(oldIssue.Labels, ", "), strings.Join(newIssue.Labels, ", "))
+	add("description", oldIssue.Description, newIssue.Description)
+
+	return change, len(change.Changes) > 0
+}
+
+func formatPriority(p *int) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf("P%d", *p)
+}
@@ -0,0 +1,126 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseIssueChange(t *testing.T) {
+	info := commitInfo{
+		SHA:       "abc123",
+		Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+		Author:    "Alice",
+		Message:   "Triage",
+	}
+
+	t.Run("created", func(t *testing.T) {
+		diff := []byte(`+{"id":"bv-1","title":"New","status":"open","priority":2}
++{"id":"bv-2","title":"Other","status":"open"}
+`)
+		change, ok := parseIssueChange(diff, info, "bv-1")
+		if !ok || !change.Created || len(change.Changes) != 0 {
+			t.Fatalf("expected creation, got %+v ok=%v", change, ok)
+		}
+		if change.CommitSHA != "abc123" || change.Author != "Alice" {
+			t.Errorf("commit metadata not carried through: %+v", change)
+		}
+	})
+
+	t.Run("field changes", func(t *testing.T) {
+		diff := []byte(`-{"id":"bv-1","title":"Fix login","status":"open","priority":2,"description":"old"}
++{"id":"bv-1","title":"Fix login","status":"in_progress","priority":1,"description":"new text","labels":["auth"]}
+`)
+		change, ok := parseIssueChange(diff, info, "bv-1")
+		if !ok {
+			t.Fatal("expected a change")
+		}
+		want := map[string][2]string{
+			"status":      {"open", "in_progress"},
+			"priority":    {"P2", "P1"},
+			"labels":      {"", "auth"},
+			"description": {"old", "new text"},
+		}
+		if len(change.Changes) != len(want) {
+			t.Fatalf("expected %d field changes, got %+v", len(want), change.Changes)
+		}
+		for _, fc := range change.Changes {
+			w, ok := want[fc.Field]
+			if !ok || fc.OldValue != w[0] || fc.NewValue != w[1] {
+				t.Errorf("unexpected change %+v", fc)
+			}
+		}
+	})
+
+	t.Run("untracked fields only", func(t *testing.T) {
+		diff := []byte(`-{"id":"bv-1","title":"A","status":"open","updated_at":"2025-01-01T00:00:00Z"}
++{"id":"bv-1","title":"A","status":"open","updated_at":"2025-01-02T00:00:00Z"}
+`)
+		if _, ok := parseIssueChange(diff, info, "bv-1"); ok {
+			t.Error("timestamp-only rewrite should not produce a change")
+		}
+	})
+
+	t.Run("other issue", func(t *testing.T) {
+		diff := []byte(`+{"id":"bv-10","title":"Similar ID","status":"open"}
+`)
+		if _, ok := parseIssueChange(diff, info, "bv-1"); ok {
+			t.Error("changes to other issues should be ignored")
+		}
+	})
+}
+
+func TestExtractIssueChanges_GitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	beadsFile := filepath.Join(repo, ".beads", "beads.jsonl")
+	commit := func(content, msg string) {
+		t.Helper()
+		if err := os.WriteFile(beadsFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+
+	git("init", "-q")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+	if err := os.MkdirAll(filepath.Dir(beadsFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	commit(`{"id":"bv-1","title":"Login","status":"open","priority":2}
+`, "Create bv-1")
+	commit(`{"id":"bv-1","title":"Login","status":"open","priority":2}
+{"id":"bv-2","title":"Other","status":"open"}
+`, "Create bv-2")
+	commit(`{"id":"bv-1","title":"Login","status":"in_progress","priority":1}
+{"id":"bv-2","title":"Other","status":"open"}
+`, "Claim bv-1")
+
+	changes, err := NewExtractor(repo).ExtractIssueChanges("bv-1", ExtractOptions{})
+	if err != nil {
+		t.Fatalf("ExtractIssueChanges: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if !changes[0].Created || changes[0].CommitMsg != "Create bv-1" {
+		t.Errorf("first change should be the creation, got %+v", changes[0])
+	}
+	if changes[1].CommitMsg != "Claim bv-1" || len(changes[1].Changes) != 2 || changes[1].Author != "Test User" {
+		t.Errorf("second change should carry status and priority, got %+v", changes[1])
+	}
+}
@@ -0,0 +1,456 @@
+// Package correlation provides commit linking by bead ID mentions, branch names and titles.
+package correlation
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Confidence of links made by the CommitLinker. Mentions are scored with
+// CalculateConfidence like other explicit matches.
+const (
+	branchLinkConfidence = 0.80
+	titleLinkMinScore    = 0.5 // Minimum title similarity to link at all
+	titleLinkBase        = 0.30
+	titleLinkRange       = 0.40 // Confidence = base + range*similarity
+)
+
+// gitLinkFormat delimits records with \x1e so that bodies may span lines;
+// numstat lines follow each record
+const gitLinkFormat = "%x1e%H%x00%P%x00%aI%x00%an%x00%ae%x00%s%x00%b%x1f"
+
+var (
+	// beadIDToken matches hyphenated words that may contain a bead ID
+	// (bv-12, bv-5bqh, proj-a1.2, feature-bv-12-login)
+	beadIDToken = regexp.MustCompile(`[A-Za-z0-9_]+(?:-[A-Za-z0-9_]+)+(?:\.[0-9]+)*`)
+
+	mergeBranchPattern = regexp.MustCompile(`^Merge (?:remote-tracking )?branch '([^']+)'`)
+	mergePRPattern     = regexp.MustCompile(`^Merge pull request #\d+ from (\S+)`)
+
+	// conventionalPrefix strips "feat(ui): " style prefixes before title matching
+	conventionalPrefix = regexp.MustCompile(`^[a-z]+(\([^)]*\))?!?:\s*`)
+)
+
+// titleStopWords are ignored when comparing commit subjects to bead titles
+var titleStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"add": true, "adds": true, "added": true, "fix": true, "fixes": true, "fixed": true,
+	"update": true, "updates": true, "updated": true, "use": true, "when": true,
+	"this": true, "that": true, "not": true, "are": true, "was": true, "all": true,
+}
+
+// CommitLinker links code commits to beads with a single pass over the git
+// log: bead IDs mentioned in commit messages, bead IDs in the names of the
+// branches commits were made on, and commit subjects that closely match a
+// bead's title. It complements co-commit detection, which only sees commits
+// that also touched the beads file.
+type CommitLinker struct {
+	repoPath string
+}
+
+// NewCommitLinker creates a linker for the given repository
+func NewCommitLinker(repoPath string) *CommitLinker {
+	return &CommitLinker{repoPath: repoPath}
+}
+
+// linkCommit is a commit read by the linker
+type linkCommit struct {
+	info    commitInfo
+	parents []string
+	body    string
+	files   []FileChange
+}
+
+// Link returns one CorrelatedCommit per (commit, bead) link found. Commits
+// that only touch excluded paths (such as the beads files) are not linked.
+func (l *CommitLinker) Link(beads []BeadInfo, opts ExtractOptions) ([]CorrelatedCommit, error) {
+	known := make(map[string]string, len(beads)) // lowercase ID -> ID
+	for _, b := range beads {
+		if opts.BeadID == "" || b.ID == opts.BeadID {
+			known[strings.ToLower(b.ID)] = b.ID
+		}
+	}
+	if len(known) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"log", "--numstat", "--format=" + gitLinkFormat}
+	if opts.Since != nil {
+		args = append(args, "--since="+opts.Since.Format(time.RFC3339))
+	}
+	if opts.Until != nil {
+		args = append(args, "--until="+opts.Until.Format(time.RFC3339))
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Limit))
+	}
+	commits, err := l.logCommits(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []CorrelatedCommit
+	linked := make(map[string]bool) // SHAs linked by ID or branch
+
+	add := func(c linkCommit, beadID string, method CorrelationMethod, conf float64, reason string) {
+		if len(c.files) == 0 {
+			return
+		}
+		links = append(links, CorrelatedCommit{
+			BeadID:      beadID,
+			SHA:         c.info.SHA,
+			ShortSHA:    shortSHA(c.info.SHA),
+			Message:     c.info.Message,
+			Author:      c.info.Author,
+			AuthorEmail: c.info.AuthorEmail,
+			Timestamp:   c.info.Timestamp,
+			Files:       c.files,
+			Method:      method,
+			Confidence:  conf,
+			Reason:      reason,
+		})
+		linked[c.info.SHA] = true
+	}
+
+	// ID mentions in subject or body
+	for _, c := range commits {
+		ids := mentionedIDs(c.info.Message+"\n"+c.body, known)
+		for _, m := range ids {
+			add(c, m.ID, MethodExplicitID, CalculateConfidence(m.MatchType, len(ids)),
+				fmt.Sprintf("Commit message mentions %s (%s)", m.ID, m.MatchType))
+		}
+	}
+
+	// Branch names: commits brought in by merges of ID-named branches, and
+	// commits on ID-named branches that are not merged yet
+	for _, c := range commits {
+		if len(c.parents) < 2 {
+			continue
+		}
+		branch := mergedBranchName(c.info.Message)
+		if ids := branchBeadIDs(branch, known); len(ids) > 0 {
+			branchCommits, err := l.logCommits("log", "--numstat", "--format="+gitLinkFormat, c.parents[0]+".."+c.parents[1])
+			if err != nil {
+				continue // Non-fatal: the merge may reference pruned history
+			}
+			for _, bc := range branchCommits {
+				for _, id := range ids {
+					add(bc, id, MethodBranchName, branchLinkConfidence, fmt.Sprintf("Made on branch '%s', merged in %s", branch, shortSHA(c.info.SHA)))
+				}
+			}
+		}
+	}
+	for _, ref := range l.branchRefs() {
+		ids := branchBeadIDs(ref, known)
+		if len(ids) == 0 {
+			continue
+		}
+		branchCommits, err := l.logCommits("log", "--numstat", "--format="+gitLinkFormat, "HEAD.."+ref)
+		if err != nil {
+			continue
+		}
+		for _, bc := range branchCommits {
+			for _, id := range ids {
+				add(bc, id, MethodBranchName, branchLinkConfidence, fmt.Sprintf("Made on unmerged branch '%s'", ref))
+			}
+		}
+	}
+
+	// Fuzzy title match, only for commits not linked any other way
+	matcher := newTitleMatcher(beads, known)
+	for _, c := range commits {
+		if linked[c.info.SHA] || len(c.parents) > 1 {
+			continue
+		}
+		if beadID, score, ok := matcher.best(c.info.Message); ok {
+			add(c, beadID, MethodTitleMatch, titleLinkBase+titleLinkRange*score,
+				fmt.Sprintf("Commit subject resembles the bead title (%.0f%% similar)", score*100))
+		}
+	}
+
+	return dedupLinks(links), nil
+}
+
+// dedupLinks keeps the most confident link per (commit, bead)
+func dedupLinks(links []CorrelatedCommit) []CorrelatedCommit {
+	type key struct{ sha, bead string }
+	best := make(map[key]int, len(links))
+	var out []CorrelatedCommit
+	for _, link := range links {
+		k := key{link.SHA, link.BeadID}
+		if i, ok := best[k]; ok {
+			if link.Confidence > out[i].Confidence {
+				out[i] = link
+			}
+			continue
+		}
+		best[k] = len(out)
+		out = append(out, link)
+	}
+	return out
+}
+
+// logCommits runs git log with the link format and parses its output
+func (l *CommitLinker) logCommits(args ...string) ([]linkCommit, error) {
+	cmd := exec.Command("git", append([]string{"-c", "color.ui=false"}, args...)...)
+	cmd.Dir = l.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+	return parseLinkLog(out), nil
+}
+
+// parseLinkLog parses records produced with gitLinkFormat and --numstat
+func parseLinkLog(out []byte) []linkCommit {
+	var commits []linkCommit
+	for _, record := range bytes.Split(out, []byte{0x1e}) {
+		header, stats, found := bytes.Cut(record, []byte{0x1f})
+		if !found {
+			continue
+		}
+		parts := strings.SplitN(string(header), "\x00", 7)
+		if len(parts) != 7 {
+			continue
+		}
+		info, err := parseCommitInfo(strings.Join([]string{parts[0], parts[2], parts[3], parts[4], parts[5]}, "\x00"))
+		if err != nil {
+			continue
+		}
+		c := linkCommit{
+			info:    info,
+			parents: strings.Fields(parts[1]),
+			body:    parts[6],
+		}
+		for _, line := range strings.Split(string(stats), "\n") {
+			if f, ok := parseNumstatLine(line); ok && !isExcludedPath(f.Path) {
+				c.files = append(c.files, f)
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// parseNumstatLine parses "12\t3\tpath" (binary files show "-")
+func parseNumstatLine(line string) (FileChange, bool) {
+	parts := strings.Split(line, "\t")
+	if len(parts) != 3 {
+		return FileChange{}, false
+	}
+	ins, _ := strconv.Atoi(parts[0])
+	del, _ := strconv.Atoi(parts[1])
+	path := parts[2]
+	action := "M"
+	if strings.Contains(path, " => ") {
+		path = extractNewPath(path)
+		action = "R"
+	}
+	return FileChange{Path: path, Action: action, Insertions: ins, Deletions: del}, true
+}
+
+// branchRefs lists local and remote branch names
+func (l *CommitLinker) branchRefs() []string {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes")
+	cmd.Dir = l.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(out))
+}
+
+// mentionedIDs finds known bead IDs in a commit message. IDs may sit inside
+// longer hyphenated words, as in branch names like feature-bv-12-login.
+func mentionedIDs(message string, known map[string]string) []IDMatch {
+	var matches []IDMatch
+	seen := make(map[string]bool)
+	for _, loc := range beadIDToken.FindAllStringIndex(message, -1) {
+		token := message[loc[0]:loc[1]]
+		parts := strings.Split(token, "-")
+		for i := 0; i < len(parts); i++ {
+			for j := len(parts); j > i+1; j-- {
+				id, ok := known[strings.ToLower(strings.Join(parts[i:j], "-"))]
+				if !ok || seen[id] {
+					continue
+				}
+				seen[id] = true
+				matches = append(matches, IDMatch{ID: id, MatchType: classifyMention(message, loc[0], loc[1]), RawMatch: token})
+			}
+		}
+	}
+	return matches
+}
+
+// classifyMention classifies an ID mention at message[start:end] by the
+// brackets around it or the keyword before it ("Closes bv-12")
+func classifyMention(message string, start, end int) string {
+	id := message[start:end]
+	if start > 0 && message[start-1] == '[' && end < len(message) && message[end] == ']' {
+		return classifyMatch("[" + id + "]")
+	}
+	before := strings.Fields(message[:start])
+	if len(before) == 0 {
+		return classifyMatch(id)
+	}
+	return classifyMatch(before[len(before)-1] + " " + id)
+}
+
+// mergedBranchName extracts the branch name from a merge commit subject
+func mergedBranchName(subject string) string {
+	if m := mergeBranchPattern.FindStringSubmatch(subject); m != nil {
+		return m[1]
+	}
+	if m := mergePRPattern.FindStringSubmatch(subject); m != nil {
+		// "owner/branch" -> "branch"
+		if _, branch, ok := strings.Cut(m[1], "/"); ok {
+			return branch
+		}
+		return m[1]
+	}
+	return ""
+}
+
+// branchBeadIDs returns the known bead IDs that appear in a branch name
+func branchBeadIDs(branch string, known map[string]string) []string {
+	if branch == "" {
+		return nil
+	}
+	var ids []string
+	for _, m := range mentionedIDs(branch, known) {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+// titleMatcher finds the bead whose title best matches a commit subject
+type titleMatcher struct {
+	ids    []string
+	tokens []map[string]bool
+	index  map[string][]int // token -> beads whose titles contain it
+}
+
+func newTitleMatcher(beads []BeadInfo, known map[string]string) *titleMatcher {
+	m := &titleMatcher{index: make(map[string][]int)}
+	for _, b := range beads {
+		if _, ok := known[strings.ToLower(b.ID)]; !ok {
+			continue
+		}
+		tokens := titleTokens(b.Title)
+		if len(tokens) < 2 {
+			continue
+		}
+		i := len(m.ids)
+		m.ids = append(m.ids, b.ID)
+		m.tokens = append(m.tokens, tokens)
+		for t := range tokens {
+			m.index[t] = append(m.index[t], i)
+		}
+	}
+	return m
+}
+
+// best returns the bead most similar to subject by Dice coefficient over
+// significant words. Ties are ambiguous and not linked.
+func (m *titleMatcher) best(subject string) (string, float64, bool) {
+	tokens := titleTokens(conventionalPrefix.ReplaceAllString(strings.ToLower(subject), ""))
+	if len(tokens) < 2 {
+		return "", 0, false
+	}
+	shared := make(map[int]int)
+	for t := range tokens {
+		for _, i := range m.index[t] {
+			shared[i]++
+		}
+	}
+
+	candidates := make([]int, 0, len(shared))
+	for i := range shared {
+		candidates = append(candidates, i)
+	}
+	sort.Ints(candidates)
+
+	bestIdx, bestScore, tie := -1, 0.0, false
+	for _, i := range candidates {
+		if shared[i] < 2 {
+			continue
+		}
+		score := 2 * float64(shared[i]) / float64(len(tokens)+len(m.tokens[i]))
+		switch {
+		case score > bestScore:
+			bestIdx, bestScore, tie = i, score, false
+		case score == bestScore:
+			tie = true
+		}
+	}
+	if bestIdx < 0 || tie || bestScore < titleLinkMinScore {
+		return "", 0, false
+	}
+	return m.ids[bestIdx], bestScore, true
+}
+
+// titleTokens returns the significant lowercase words of a title
+func titleTokens(title string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		if len(word) >= 3 && !titleStopWords[word] {
+			tokens[word] = true
+		}
+	}
+	return tokens
+}
+
+// RobotCommitsOutput is the --robot-commits output: the commits related to one issue
+type RobotCommitsOutput struct {
+	GeneratedAt string             `json:"generated_at"`
+	DataHash    string             `json:"data_hash"`
+	IssueID     string             `json:"issue_id"`
+	Title       string             `json:"title"`
+	Commits     []CorrelatedCommit `json:"commits"`
+	Methods     map[string]int     `json:"methods"` // Commit count per correlation method
+	UsageHints  []string           `json:"usage_hints"`
+}
+
+// GenerateRobotCommitsOutput lists the commits a history report relates to
+// issueID, newest first
+func GenerateRobotCommitsOutput(report *HistoryReport, issueID, dataHash string) RobotCommitsOutput {
+	out := RobotCommitsOutput{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		IssueID:     issueID,
+		Commits:     []CorrelatedCommit{},
+		Methods:     make(map[string]int),
+		UsageHints: []string{
+			"jq '.commits[] | {short_sha, method, confidence, message}' - Related commits",
+			"jq '.commits[] | select(.confidence >= 0.8)' - High-confidence links only",
+			"jq '[.commits[].files[].path] | unique' - Files touched for this issue",
+		},
+	}
+	if report == nil {
+		return out
+	}
+	history, ok := report.Histories[issueID]
+	if !ok {
+		return out
+	}
+	out.Title = history.Title
+	out.Commits = append(out.Commits, history.Commits...)
+	sort.SliceStable(out.Commits, func(i, j int) bool {
+		return out.Commits[i].Timestamp.After(out.Commits[j].Timestamp)
+	})
+	for _, c := range out.Commits {
+		out.Methods[c.Method.String()]++
+	}
+	return out
+}
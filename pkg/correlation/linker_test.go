@@ -0,0 +1,233 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMentionedIDs(t *testing.T) {
+	known := map[string]string{"bv-12": "bv-12", "bv-5bqh": "bv-5bqh", "bv-1": "bv-1"}
+
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"Fix login (bv-12)", []string{"bv-12"}},
+		{"Closes BV-5BQH", []string{"bv-5bqh"}},
+		{"Merge branch 'feature-bv-12-login'", []string{"bv-12"}},
+		{"[bv-1] and bv-12", []string{"bv-1", "bv-12"}},
+		{"bv-123 is not bv-12", []string{"bv-12"}},
+		{"no ids here", nil},
+	}
+	for _, tt := range tests {
+		got := mentionedIDs(tt.message, known)
+		if len(got) != len(tt.want) {
+			t.Errorf("mentionedIDs(%q) = %+v, want %v", tt.message, got, tt.want)
+			continue
+		}
+		for i, m := range got {
+			if m.ID != tt.want[i] {
+				t.Errorf("mentionedIDs(%q)[%d] = %s, want %s", tt.message, i, m.ID, tt.want[i])
+			}
+		}
+	}
+
+	if m := mentionedIDs("Closes bv-12", known); m[0].MatchType != "closes" {
+		t.Errorf("MatchType = %q, want closes", m[0].MatchType)
+	}
+	if m := mentionedIDs("[bv-12] Fix", known); m[0].MatchType != "bracket" {
+		t.Errorf("MatchType = %q, want bracket", m[0].MatchType)
+	}
+}
+
+func TestMergedBranchName(t *testing.T) {
+	tests := map[string]string{
+		"Merge branch 'bv-12-login'":                       "bv-12-login",
+		"Merge branch 'bv-12' into main":                   "bv-12",
+		"Merge remote-tracking branch 'origin/bv-7'":       "origin/bv-7",
+		"Merge pull request #42 from alice/bv-12-fix-auth": "bv-12-fix-auth",
+		"Fix bv-12": "",
+	}
+	for subject, want := range tests {
+		if got := mergedBranchName(subject); got != want {
+			t.Errorf("mergedBranchName(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
+
+func TestTitleMatcher(t *testing.T) {
+	beads := []BeadInfo{
+		{ID: "bv-1", Title: "Keyboard navigation in board view"},
+		{ID: "bv-2", Title: "Export graph as SVG"},
+		{ID: "bv-3", Title: "Misc"},
+	}
+	known := map[string]string{"bv-1": "bv-1", "bv-2": "bv-2", "bv-3": "bv-3"}
+	m := newTitleMatcher(beads, known)
+
+	id, score, ok := m.best("feat(board): keyboard navigation for board view")
+	if !ok || id != "bv-1" {
+		t.Fatalf("best = %s %.2f %v, want bv-1", id, score, ok)
+	}
+	if score < titleLinkMinScore || score > 1 {
+		t.Errorf("score = %.2f out of range", score)
+	}
+
+	if _, _, ok := m.best("Refactor loader caching"); ok {
+		t.Error("unrelated subject should not match")
+	}
+	if _, _, ok := m.best("Export everything"); ok {
+		t.Error("single shared word should not match")
+	}
+}
+
+func TestParseLinkLog(t *testing.T) {
+	out := []byte("\x1eabc123\x00p1 p2\x002025-01-15T10:00:00Z\x00Alice\x00alice@example.com\x00Merge branch 'bv-1'\x00Body line\nsecond\n\x1f\n\n" +
+		"12\t3\tpkg/ui/board.go\n-\t-\timage.png\n4\t0\t.beads/beads.jsonl\n" +
+		"\x1edef456\x00p1\x002025-01-14T10:00:00Z\x00Bob\x00bob@example.com\x00Fix bug\x00\x1f\n")
+
+	commits := parseLinkLog(out)
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+	c := commits[0]
+	if c.info.SHA != "abc123" || c.info.Author != "Alice" || len(c.parents) != 2 {
+		t.Errorf("unexpected header: %+v", c)
+	}
+	if c.body != "Body line\nsecond\n" {
+		t.Errorf("body = %q", c.body)
+	}
+	if len(c.files) != 2 || c.files[0].Insertions != 12 || c.files[1].Path != "image.png" {
+		t.Errorf("files = %+v, want board.go and image.png (beads file excluded)", c.files)
+	}
+	if len(commits[1].files) != 0 {
+		t.Errorf("commit without numstat should have no files, got %+v", commits[1].files)
+	}
+}
+
+func TestCommitLinker_GitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(file, msg string) {
+		t.Helper()
+		path := filepath.Join(repo, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(msg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+
+	git("init", "-q", "-b", "main")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+	commit("README.md", "Initial commit")
+	commit("auth.go", "Fix session expiry (bv-1)")
+	commit(".beads/beads.jsonl", "Update bv-1 status")
+	git("checkout", "-q", "-b", "bv-2-export")
+	commit("export.go", "Write exporter")
+	git("checkout", "-q", "main")
+	git("merge", "-q", "--no-ff", "-m", "Merge branch 'bv-2-export'", "bv-2-export")
+	commit("board.go", "Keyboard navigation for the board view")
+	git("checkout", "-q", "-b", "feature/bv-3")
+	commit("wip.go", "Work in progress")
+	git("checkout", "-q", "main")
+
+	beads := []BeadInfo{
+		{ID: "bv-1", Title: "Sessions expire early"},
+		{ID: "bv-2", Title: "Export graph"},
+		{ID: "bv-3", Title: "Something else"},
+		{ID: "bv-4", Title: "Board keyboard navigation"},
+	}
+	links, err := NewCommitLinker(repo).Link(beads, ExtractOptions{})
+	if err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+
+	byBead := make(map[string][]CorrelatedCommit)
+	for _, l := range links {
+		byBead[l.BeadID] = append(byBead[l.BeadID], l)
+	}
+	check := func(beadID, message string, method CorrelationMethod) {
+		t.Helper()
+		for _, l := range byBead[beadID] {
+			if l.Message == message {
+				if l.Method != method {
+					t.Errorf("%s ↔ %q method = %s, want %s", beadID, message, l.Method, method)
+				}
+				if l.Confidence <= 0 || l.Confidence > 1 || l.Reason == "" {
+					t.Errorf("%s ↔ %q has confidence %.2f reason %q", beadID, message, l.Confidence, l.Reason)
+				}
+				return
+			}
+		}
+		t.Errorf("expected %q to be linked to %s, got %+v", message, beadID, byBead[beadID])
+	}
+	check("bv-1", "Fix session expiry (bv-1)", MethodExplicitID)
+	check("bv-2", "Write exporter", MethodBranchName)
+	check("bv-3", "Work in progress", MethodBranchName)
+	check("bv-4", "Keyboard navigation for the board view", MethodTitleMatch)
+
+	if len(byBead["bv-1"]) != 1 {
+		t.Errorf("beads-file-only commit should not be linked: %+v", byBead["bv-1"])
+	}
+
+	// Filtering to one bead links only that bead
+	links, err = NewCommitLinker(repo).Link(beads, ExtractOptions{BeadID: "bv-2"})
+	if err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	for _, l := range links {
+		if l.BeadID != "bv-2" {
+			t.Errorf("unexpected link to %s with BeadID filter", l.BeadID)
+		}
+	}
+}
+
+func TestGenerateRobotCommitsOutput(t *testing.T) {
+	now := time.Now()
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {
+				BeadID: "bv-1",
+				Title:  "Login",
+				Commits: []CorrelatedCommit{
+					{SHA: "old", Timestamp: now.Add(-time.Hour), Method: MethodCoCommitted},
+					{SHA: "new", Timestamp: now, Method: MethodExplicitID},
+					{SHA: "mid", Timestamp: now.Add(-time.Minute), Method: MethodExplicitID},
+				},
+			},
+		},
+	}
+
+	out := GenerateRobotCommitsOutput(report, "bv-1", "hash")
+	if out.Title != "Login" || out.DataHash != "hash" {
+		t.Errorf("unexpected header: %+v", out)
+	}
+	if len(out.Commits) != 3 || out.Commits[0].SHA != "new" || out.Commits[2].SHA != "old" {
+		t.Errorf("commits should be newest first: %+v", out.Commits)
+	}
+	if out.Methods["explicit_id"] != 2 || out.Methods["co_committed"] != 1 {
+		t.Errorf("methods = %v", out.Methods)
+	}
+
+	missing := GenerateRobotCommitsOutput(report, "bv-9", "hash")
+	if missing.Commits == nil || len(missing.Commits) != 0 {
+		t.Errorf("unknown issue should produce an empty commit list, got %+v", missing.Commits)
+	}
+}
@@ -35,6 +35,18 @@ var MethodRanges = map[CorrelationMethod]ConfidenceRange{
 		Max:    0.85,
 		Desc:   "By same author during bead's active window (temporal correlation)",
 	},
+	MethodBranchName: {
+		Method: MethodBranchName,
+		Min:    0.80,
+		Max:    0.80,
+		Desc:   "Made on a branch named after the bead (developer intent)",
+	},
+	MethodTitleMatch: {
+		Method: MethodTitleMatch,
+		Min:    0.50,
+		Max:    0.70,
+		Desc:   "Commit subject resembles the bead title (fuzzy match)",
+	},
 }
 
 // Scorer provides methods for calculating and combining confidence scores.
@@ -397,6 +409,18 @@ func (s *Scorer) ExtractSignals(commit CorrelatedCommit) []CorrelationSignal {
 			Weight: 15,
 			Detail: fmt.Sprintf("By assignee: %s", commit.Author),
 		})
+	case MethodBranchName:
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalMessageMatch,
+			Weight: 35,
+			Detail: "Branch name contains bead ID reference",
+		})
+	case MethodTitleMatch:
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalMessageMatch,
+			Weight: 20,
+			Detail: "Commit subject resembles bead title",
+		})
 	}
 
 	// File-based signals
@@ -433,6 +457,10 @@ func (s *Scorer) buildSummary(commit CorrelatedCommit, signals []CorrelationSign
 		methodDesc = "Explicitly references bead ID"
 	case MethodTemporalAuthor:
 		methodDesc = "Temporal+author correlation"
+	case MethodBranchName:
+		methodDesc = "Made on bead's branch"
+	case MethodTitleMatch:
+		methodDesc = "Subject matches bead title"
 	}
 
 	return fmt.Sprintf("%s (%.0f%% confidence, %d signals)",
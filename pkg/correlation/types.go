@@ -57,6 +57,10 @@ const (
 	MethodExplicitID CorrelationMethod = "explicit_id"
 	// MethodTemporalAuthor means the commit is temporally close and by the assignee
 	MethodTemporalAuthor CorrelationMethod = "temporal_author"
+	// MethodBranchName means the commit was made on a branch named after the bead
+	MethodBranchName CorrelationMethod = "branch_name"
+	// MethodTitleMatch means the commit subject closely matches the bead title
+	MethodTitleMatch CorrelationMethod = "title_match"
 )
 
 // String returns the string representation of CorrelationMethod
@@ -67,7 +71,7 @@ func (c CorrelationMethod) String() string {
 // IsValid returns true if the correlation method is a recognized value
 func (c CorrelationMethod) IsValid() bool {
 	switch c {
-	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor:
+	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor, MethodBranchName, MethodTitleMatch:
 		return true
 	}
 	return false
@@ -0,0 +1,174 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// command is one query the daemon answers. Results of cached commands are
+// kept until the beads file changes.
+type command struct {
+	cache bool
+	run   func(s *Server, args json.RawMessage) (any, error)
+}
+
+// commands is filled in init: status and next refer back to it
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"status":   {run: status},
+		"triage":   {cache: true, run: triage},
+		"next":     {cache: true, run: next},
+		"plan":     {cache: true, run: plan},
+		"priority": {cache: true, run: priority},
+		"insights": {cache: true, run: insights},
+		"shutdown": {run: func(*Server, json.RawMessage) (any, error) {
+			return map[string]string{"message": "bv daemon stopping"}, nil
+		}},
+	}
+}
+
+// decodeArgs unmarshals command args strictly, so a misspelt option is an
+// error rather than silently ignored
+func decodeArgs(args json.RawMessage, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid args: %w", err)
+	}
+	return nil
+}
+
+// StatusResult is the status command's result.
+type StatusResult struct {
+	BeadsPath string   `json:"beads_path"`
+	Issues    int      `json:"issues"`
+	LoadedAt  string   `json:"loaded_at"`
+	StartedAt string   `json:"started_at"`
+	Uptime    string   `json:"uptime"`
+	Requests  int      `json:"requests"`   // Including this one
+	CacheHits int      `json:"cache_hits"` // Requests answered without recomputing
+	Reloads   int      `json:"reloads"`    // Times the beads file changed
+	Commands  []string `json:"commands"`
+}
+
+func status(s *Server, _ json.RawMessage) (any, error) {
+	return StatusResult{
+		BeadsPath: s.beadsPath,
+		Issues:    len(s.snap.issues),
+		LoadedAt:  s.snap.loadedAt.UTC().Format(time.RFC3339),
+		StartedAt: s.startedAt.UTC().Format(time.RFC3339),
+		Uptime:    time.Since(s.startedAt).Round(time.Second).String(),
+		Requests:  s.requests,
+		CacheHits: s.cacheHits,
+		Reloads:   s.reloads,
+		Commands:  CommandNames(),
+	}, nil
+}
+
+// triage is --robot-triage: args {"by_track": bool, "by_label": bool}
+func triage(s *Server, args json.RawMessage) (any, error) {
+	var opts struct {
+		ByTrack bool `json:"by_track"`
+		ByLabel bool `json:"by_label"`
+	}
+	if err := decodeArgs(args, &opts); err != nil {
+		return nil, err
+	}
+	return analysis.ComputeTriageWithOptions(s.snap.issues, analysis.TriageOptions{
+		GroupByTrack:  opts.ByTrack,
+		GroupByLabel:  opts.ByLabel,
+		WaitForPhase2: true,
+	}), nil
+}
+
+// NextResult is the next command's result, as --robot-next.
+type NextResult struct {
+	ID       string   `json:"id,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Score    float64  `json:"score,omitempty"`
+	Reasons  []string `json:"reasons,omitempty"`
+	Unblocks int      `json:"unblocks"`
+	ClaimCmd string   `json:"claim_command,omitempty"`
+	ShowCmd  string   `json:"show_command,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+// next is --robot-next, taken from the cached default triage
+func next(s *Server, args json.RawMessage) (any, error) {
+	if err := decodeArgs(args, &struct{}{}); err != nil {
+		return nil, err
+	}
+	raw, _, err := s.runLocked(Request{Command: "triage"})
+	if err != nil {
+		return nil, err
+	}
+	var result analysis.TriageResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("decoding triage: %w", err)
+	}
+	if len(result.QuickRef.TopPicks) == 0 {
+		return NextResult{Message: "No actionable items available"}, nil
+	}
+	top := result.QuickRef.TopPicks[0]
+	return NextResult{
+		ID:       top.ID,
+		Title:    top.Title,
+		Score:    top.Score,
+		Reasons:  top.Reasons,
+		Unblocks: top.Unblocks,
+		ClaimCmd: fmt.Sprintf("bd update %s --status=in_progress", top.ID),
+		ShowCmd:  fmt.Sprintf("bd show %s", top.ID),
+	}, nil
+}
+
+// plan is --robot-plan: args {"agents": int}
+func plan(s *Server, args json.RawMessage) (any, error) {
+	var opts struct {
+		Agents int `json:"agents"`
+	}
+	if err := decodeArgs(args, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Agents < 0 {
+		return nil, fmt.Errorf("invalid args: agents must be >= 0")
+	}
+	analyzer, stats := s.snap.graph()
+	return analyzer.GetExecutionPlanWithOptions(analysis.PlanOptions{
+		Agents: opts.Agents,
+		Stats:  stats,
+	}), nil
+}
+
+// priority is --robot-priority: args {"limit": int} (default 10)
+func priority(s *Server, args json.RawMessage) (any, error) {
+	opts := struct {
+		Limit int `json:"limit"`
+	}{Limit: 10}
+	if err := decodeArgs(args, &opts); err != nil {
+		return nil, err
+	}
+	analyzer, _ := s.snap.graph()
+	recs := analyzer.GenerateEnhancedRecommendations()
+	if opts.Limit > 0 && len(recs) > opts.Limit {
+		recs = recs[:opts.Limit]
+	}
+	return recs, nil
+}
+
+// insights is --robot-insights: args {"limit": int} (default 50)
+func insights(s *Server, args json.RawMessage) (any, error) {
+	opts := struct {
+		Limit int `json:"limit"`
+	}{Limit: 50}
+	if err := decodeArgs(args, &opts); err != nil {
+		return nil, err
+	}
+	_, stats := s.snap.graph()
+	return stats.GenerateInsights(opts.Limit), nil
+}
@@ -0,0 +1,349 @@
+// Package daemon keeps the issues of a beads file and their graph analysis
+// in memory and answers robot queries over a unix socket, so agents that
+// call bv dozens of times per task skip process startup and re-analysis.
+//
+// The protocol is newline-delimited JSON: each request line is
+// {"command": "triage", "args": {...}} and is answered by one Response line.
+// A connection may send any number of requests.
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SocketFilename is the daemon socket filename inside .bv/
+const SocketFilename = "daemon.sock"
+
+// maxLineBytes caps a single request or response line.
+const maxLineBytes = 64 * 1024 * 1024
+
+// SocketPath returns .bv/daemon.sock under projectDir
+func SocketPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", SocketFilename)
+}
+
+// Request is one query.
+type Request struct {
+	Command string          `json:"command"`
+	Args    json.RawMessage `json:"args,omitempty"`
+}
+
+// Response answers one Request.
+type Response struct {
+	OK       bool            `json:"ok"`
+	Command  string          `json:"command"`
+	DataHash string          `json:"data_hash,omitempty"`
+	Cached   bool            `json:"cached,omitempty"` // Served from memory without recomputing
+	Result   json.RawMessage `json:"result,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// fileStamp identifies one version of the beads file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot is one loaded version of the beads file and the analysis
+// computed from it so far.
+type snapshot struct {
+	issues   []model.Issue
+	dataHash string
+	loadedAt time.Time
+
+	analyzer *analysis.Analyzer
+	stats    *analysis.GraphStats // Full analysis, computed on first use
+}
+
+// graph returns the analyzer and full graph statistics of the snapshot,
+// computing them the first time
+func (snap *snapshot) graph() (*analysis.Analyzer, *analysis.GraphStats) {
+	if snap.stats == nil {
+		snap.analyzer = analysis.NewAnalyzer(snap.issues)
+		cfg := analysis.TunedConfigForSize(len(snap.issues), countEdges(snap.issues))
+		snap.analyzer.SetConfig(&cfg)
+		stats := snap.analyzer.Analyze()
+		snap.stats = &stats
+	}
+	return snap.analyzer, snap.stats
+}
+
+// Server answers queries about one beads file. The file is re-read when its
+// size or modification time changes; results are kept until then.
+type Server struct {
+	beadsPath string
+	startedAt time.Time
+
+	mu        sync.Mutex // Guards the fields below; held while computing
+	snap      *snapshot
+	stamp     fileStamp
+	results   map[string]json.RawMessage // Keyed by command and compacted args
+	requests  int
+	cacheHits int
+	reloads   int
+
+	listener  net.Listener
+	closeOnce sync.Once
+}
+
+// NewServer loads beadsPath and warms the analysis most queries need.
+func NewServer(beadsPath string) (*Server, error) {
+	s := &Server{beadsPath: beadsPath, startedAt: time.Now()}
+	if err := s.Refresh(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Issues returns how many issues are loaded
+func (s *Server) Issues() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.snap.issues)
+}
+
+// Refresh reloads the beads file when it changed since the last load, then
+// computes the graph analysis and triage ahead of the next query.
+func (s *Server) Refresh() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed, err := s.refreshLocked()
+	if err != nil || !changed {
+		return err
+	}
+	s.snap.graph()
+	_, _, err = s.runLocked(Request{Command: "triage"})
+	return err
+}
+
+// refreshLocked reloads the beads file if its stamp changed
+func (s *Server) refreshLocked() (bool, error) {
+	info, err := os.Stat(s.beadsPath)
+	if err != nil {
+		return false, fmt.Errorf("checking beads file: %w", err)
+	}
+	stamp := fileStamp{modTime: info.ModTime(), size: info.Size()}
+	if s.snap != nil && stamp == s.stamp {
+		return false, nil
+	}
+
+	issues, err := loader.LoadIssuesFromFile(s.beadsPath)
+	if err != nil {
+		return false, fmt.Errorf("loading issues: %w", err)
+	}
+	if s.snap != nil {
+		s.reloads++
+	}
+	s.snap = &snapshot{
+		issues:   issues,
+		dataHash: analysis.ComputeDataHash(issues),
+		loadedAt: time.Now(),
+	}
+	s.stamp = stamp
+	s.results = make(map[string]json.RawMessage)
+	return true, nil
+}
+
+// Handle answers one request.
+func (s *Server) Handle(req Request) Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	resp := Response{Command: req.Command}
+	if _, ok := commands[req.Command]; !ok {
+		resp.Error = fmt.Sprintf("unknown command %q (commands: %s)", req.Command, strings.Join(CommandNames(), ", "))
+		return resp
+	}
+	if _, err := s.refreshLocked(); err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	result, cached, err := s.runLocked(req)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	resp.OK = true
+	resp.DataHash = s.snap.dataHash
+	resp.Cached = cached
+	resp.Result = result
+	return resp
+}
+
+// runLocked answers req from the result cache or by running its command
+func (s *Server) runLocked(req Request) (json.RawMessage, bool, error) {
+	cmd := commands[req.Command]
+	args := json.RawMessage("{}")
+	if len(bytes.TrimSpace(req.Args)) > 0 && string(req.Args) != "null" {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, req.Args); err != nil {
+			return nil, false, fmt.Errorf("invalid args: %w", err)
+		}
+		args = compact.Bytes()
+	}
+
+	key := req.Command + " " + string(args)
+	if cmd.cache {
+		if result, ok := s.results[key]; ok {
+			s.cacheHits++
+			return result, true, nil
+		}
+	}
+	out, err := cmd.run(s, args)
+	if err != nil {
+		return nil, false, err
+	}
+	result, err := json.Marshal(out)
+	if err != nil {
+		return nil, false, fmt.Errorf("encoding result: %w", err)
+	}
+	if cmd.cache {
+		s.results[key] = result
+	}
+	return result, false, nil
+}
+
+// Listen opens the socket at path. A socket left behind by a daemon that
+// is no longer running is replaced; a live one is an error.
+func Listen(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("a bv daemon is already running on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("removing stale socket: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating .bv directory: %w", err)
+	}
+	return net.Listen("unix", path)
+}
+
+// Serve answers connections on l until Close is called or a client sends
+// the shutdown command.
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// Close stops Serve
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		l := s.listener
+		s.mu.Unlock()
+		if l != nil {
+			_ = l.Close()
+		}
+	})
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(line, &req); err != nil {
+			resp = Response{Error: "invalid request: " + err.Error()}
+		} else {
+			resp = s.Handle(req)
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+		if resp.OK && req.Command == "shutdown" {
+			s.Close()
+			return
+		}
+	}
+}
+
+// Query sends req to the daemon listening on socketPath and returns its
+// response.
+func Query(socketPath string, req Request) (Response, error) {
+	conn, err := net.DialTimeout("unix", socketPath, 2*time.Second)
+	if err != nil {
+		return Response{}, fmt.Errorf("no bv daemon is running on %s (start one with: bv daemon)", socketPath)
+	}
+	defer conn.Close()
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, fmt.Errorf("encoding request: %w", err)
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return Response{}, fmt.Errorf("sending request: %w", err)
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return Response{}, fmt.Errorf("reading response: %w", err)
+		}
+		return Response{}, fmt.Errorf("daemon closed the connection without answering")
+	}
+	var resp Response
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+		return Response{}, fmt.Errorf("decoding response: %w", err)
+	}
+	return resp, nil
+}
+
+// CommandNames lists the commands the daemon answers, sorted
+func CommandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func countEdges(issues []model.Issue) int {
+	edges := 0
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepBlocks {
+				edges++
+			}
+		}
+	}
+	return edges
+}
@@ -0,0 +1,120 @@
+package daemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBeads = `{"id":"d-1","title":"Root","status":"open","priority":1,"issue_type":"task"}
+{"id":"d-2","title":"Child","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"d-2","depends_on_id":"d-1","type":"blocks"}]}
+`
+
+func writeBeads(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestServerCachesUntilFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := writeBeads(t, dir, testBeads)
+	s, err := NewServer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Triage is warmed by NewServer
+	resp := s.Handle(Request{Command: "triage"})
+	if !resp.OK || !resp.Cached || resp.DataHash == "" {
+		t.Fatalf("triage should be served warm: %+v", resp)
+	}
+	resp = s.Handle(Request{Command: "next"})
+	var next NextResult
+	if err := json.Unmarshal(resp.Result, &next); err != nil || next.ID != "d-1" {
+		t.Fatalf("next = %+v (%v), want d-1", next, err)
+	}
+
+	// Rewriting the file drops the cache
+	hash := resp.DataHash
+	more := testBeads + `{"id":"d-3","title":"New","status":"open","priority":0,"issue_type":"bug"}` + "\n"
+	writeBeads(t, dir, more)
+	resp = s.Handle(Request{Command: "status"})
+	var status StatusResult
+	if err := json.Unmarshal(resp.Result, &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Issues != 3 || status.Reloads != 1 || resp.DataHash == hash {
+		t.Errorf("status after change = %+v, hash %s", status, resp.DataHash)
+	}
+	if resp := s.Handle(Request{Command: "triage"}); !resp.OK || resp.Cached {
+		t.Errorf("triage after a change should be recomputed: %+v", resp)
+	}
+}
+
+func TestServerRejectsBadRequests(t *testing.T) {
+	s, err := NewServer(writeBeads(t, t.TempDir(), testBeads))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range []Request{
+		{Command: "robot-triage"},
+		{Command: "plan", Args: json.RawMessage(`{"agent": 2}`)},
+		{Command: "plan", Args: json.RawMessage(`{"agents": -1}`)},
+		{Command: "priority", Args: json.RawMessage(`{"limit": "ten"}`)},
+	} {
+		if resp := s.Handle(req); resp.OK || resp.Error == "" {
+			t.Errorf("%s %s should fail, got %+v", req.Command, req.Args, resp)
+		}
+	}
+	if resp := s.Handle(Request{Command: "plan", Args: json.RawMessage(`{"agents": 2}`)}); !resp.OK {
+		t.Errorf("plan with agents: %+v", resp)
+	}
+}
+
+func TestQueryOverSocket(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewServer(writeBeads(t, dir, testBeads))
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := SocketPath(dir)
+	l, err := Listen(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(l) }()
+
+	if _, err := Listen(socket); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("second Listen should see the live daemon, got %v", err)
+	}
+
+	resp, err := Query(socket, Request{Command: "priority", Args: json.RawMessage(`{"limit": 1}`)})
+	if err != nil || !resp.OK {
+		t.Fatalf("priority over the socket: %+v, %v", resp, err)
+	}
+	if resp, err := Query(socket, Request{Command: "shutdown"}); err != nil || !resp.OK {
+		t.Fatalf("shutdown: %+v, %v", resp, err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not stop after shutdown")
+	}
+	if _, err := Query(socket, Request{Command: "status"}); err == nil {
+		t.Error("query after shutdown should fail")
+	}
+}
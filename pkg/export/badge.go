@@ -0,0 +1,66 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// Badge colors per health level, as used by shields.io
+var badgeColors = map[string]string{
+	analysis.HealthLevelHealthy:  "#4c1",
+	analysis.HealthLevelWarning:  "#dfb317",
+	analysis.HealthLevelCritical: "#e05d44",
+}
+
+// RenderHealthBadge renders the health score as a flat SVG shield, e.g.
+// "health | 82/100", colored by level.
+func RenderHealthBadge(score analysis.HealthScore) string {
+	return renderBadge("health", fmt.Sprintf("%d/100", score.Score), badgeColors[score.Level])
+}
+
+// SaveHealthBadge writes the health badge SVG to path.
+func SaveHealthBadge(score analysis.HealthScore, path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create badge directory: %w", err)
+		}
+	}
+	return os.WriteFile(path, []byte(RenderHealthBadge(score)), 0o644)
+}
+
+// renderBadge lays out a two-part shield. Text width is approximated at
+// 7px per character of 11px Verdana.
+func renderBadge(label, value, color string) string {
+	if color == "" {
+		color = "#9f9f9f"
+	}
+	labelWidth := 7*len(label) + 10
+	valueWidth := 7*len(value) + 10
+	width := labelWidth + valueWidth
+	label, value = html.EscapeString(label), html.EscapeString(value)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+  <title>%[4]s: %[5]s</title>
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%[2]d" height="20" fill="#555"/>
+    <rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/>
+    <rect width="%[1]d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text>
+    <text x="%[7]d" y="14">%[4]s</text>
+    <text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text>
+    <text x="%[8]d" y="14">%[5]s</text>
+  </g>
+</svg>
+`, width, labelWidth, valueWidth, label, value, color, labelWidth/2, labelWidth+valueWidth/2)
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+func TestSaveHealthBadge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs", "health.svg")
+	score := analysis.HealthScore{Score: 35, Level: analysis.HealthLevelCritical}
+	if err := SaveHealthBadge(score, path); err != nil {
+		t.Fatalf("SaveHealthBadge: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := string(data)
+	for _, want := range []string{"<svg", "health: 35/100", `fill="#e05d44"`, "</svg>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("badge missing %q:\n%s", want, svg)
+		}
+	}
+}
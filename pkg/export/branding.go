@@ -0,0 +1,192 @@
+// Package export provides data export functionality for bv.
+//
+// This file carries project branding (title, logo, accent color, footer)
+// into the static viewer and the Markdown report.
+package export
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BrandingConfigFilename is the project config with export branding.
+const BrandingConfigFilename = "branding.yaml"
+
+// Branding customizes how exported dashboards and reports look.
+type Branding struct {
+	// Title replaces "Beads Viewer" in the page title and header
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+
+	// Logo is an image shown beside the title: an http(s) or data: URL, or
+	// a local file, which is copied into the export
+	Logo string `yaml:"logo,omitempty" json:"logo,omitempty"`
+
+	// AccentColor is a CSS hex color (#rgb or #rrggbb) replacing the
+	// viewer's blue accents
+	AccentColor string `yaml:"accent_color,omitempty" json:"accent_color,omitempty"`
+
+	// Footer is a line of text shown at the bottom of every page
+	Footer string `yaml:"footer,omitempty" json:"footer,omitempty"`
+}
+
+var hexColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// LoadBranding loads .bv/branding.yaml from projectDir. A relative logo
+// path is taken relative to projectDir. Returns empty branding if the file
+// doesn't exist.
+func LoadBranding(projectDir string) (Branding, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, ".bv", BrandingConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Branding{}, nil
+		}
+		return Branding{}, fmt.Errorf("reading branding config: %w", err)
+	}
+
+	var b Branding
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return Branding{}, fmt.Errorf("parsing branding config: %w", err)
+	}
+	if b.Logo != "" && !isRemoteLogo(b.Logo) && !filepath.IsAbs(b.Logo) {
+		b.Logo = filepath.Join(projectDir, b.Logo)
+	}
+	if err := b.Validate(); err != nil {
+		return Branding{}, fmt.Errorf("branding config: %w", err)
+	}
+	return b, nil
+}
+
+// Validate checks the accent color.
+func (b Branding) Validate() error {
+	if b.AccentColor != "" && !hexColorRegex.MatchString(b.AccentColor) {
+		return fmt.Errorf("accent color %q is not a hex color like #e11d48", b.AccentColor)
+	}
+	return nil
+}
+
+// Merge returns b with the fields set in override replacing its own.
+func (b Branding) Merge(override Branding) Branding {
+	if override.Title != "" {
+		b.Title = override.Title
+	}
+	if override.Logo != "" {
+		b.Logo = override.Logo
+	}
+	if override.AccentColor != "" {
+		b.AccentColor = override.AccentColor
+	}
+	if override.Footer != "" {
+		b.Footer = override.Footer
+	}
+	return b
+}
+
+// isRemoteLogo reports whether logo is a URL rather than a local file
+func isRemoteLogo(logo string) bool {
+	lower := strings.ToLower(logo)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "data:")
+}
+
+// viewerLogo is where the viewer finds the logo: URLs as they are, local
+// files under the name BrandViewer copies them to
+func viewerLogo(logo string) string {
+	if logo == "" || isRemoteLogo(logo) {
+		return logo
+	}
+	return "logo" + strings.ToLower(filepath.Ext(logo))
+}
+
+// BrandViewer applies b to the viewer already copied into outputDir: it
+// rewrites index.html and copies a local logo next to it.
+func BrandViewer(outputDir string, b Branding) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	if b.Logo != "" && !isRemoteLogo(b.Logo) {
+		data, err := os.ReadFile(b.Logo)
+		if err != nil {
+			return fmt.Errorf("read logo: %w", err)
+		}
+		name := viewerLogo(b.Logo)
+		if err := os.WriteFile(filepath.Join(outputDir, name), data, 0644); err != nil {
+			return fmt.Errorf("copy logo: %w", err)
+		}
+		b.Logo = name
+	}
+
+	indexPath := filepath.Join(outputDir, "index.html")
+	content, err := os.ReadFile(indexPath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(indexPath, []byte(applyBranding(string(content), b)), 0644)
+}
+
+// applyBranding rewrites the viewer's index.html. Text is HTML-escaped;
+// the logo, accent and footer go between the bv:* markers in the page.
+func applyBranding(content string, b Branding) string {
+	content = replaceTitle(content, b.Title)
+	if b.Logo != "" {
+		img := fmt.Sprintf(`<img src="%s" alt="" class="h-8 w-auto max-w-[8rem] object-contain rounded">`, html.EscapeString(b.Logo))
+		content = replaceMarked(content, "<!-- bv:logo -->", "<!-- /bv:logo -->", img)
+	}
+	if b.AccentColor != "" {
+		content = replaceMarked(content, "/* bv:accent */", "/* /bv:accent */", accentPalette(b.AccentColor))
+		content = strings.Replace(content,
+			`<meta name="theme-color" content="#0ea5e9"`,
+			`<meta name="theme-color" content="`+b.AccentColor+`"`, 1)
+	}
+	if b.Footer != "" {
+		footer := `<footer class="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-6 text-center text-sm text-gray-500 dark:text-gray-400">` +
+			html.EscapeString(b.Footer) + `</footer>`
+		content = replaceMarked(content, "<!-- bv:footer -->", "<!-- /bv:footer -->", footer)
+	}
+	return content
+}
+
+// replaceMarked replaces what lies between the start and end markers,
+// keeping the markers
+func replaceMarked(content, start, end, with string) string {
+	i := strings.Index(content, start)
+	if i < 0 {
+		return content
+	}
+	j := strings.Index(content[i:], end)
+	if j < 0 {
+		return content
+	}
+	return content[:i+len(start)] + with + content[i+j:]
+}
+
+// accentPalette builds the Tailwind "beads" color scale from one color:
+// lighter shades mix in white, darker ones black, with 500 the color itself
+func accentPalette(color string) string {
+	r, g, b := parseHexColor(color)
+	mix := func(shade int, toward float64, amount float64) string {
+		m := func(c uint8) uint8 { return uint8(float64(c) + (toward-float64(c))*amount + 0.5) }
+		return fmt.Sprintf("%d: '#%02x%02x%02x'", shade, m(r), m(g), m(b))
+	}
+	shades := []string{
+		mix(50, 255, 0.95), mix(100, 255, 0.9), mix(200, 255, 0.75), mix(300, 255, 0.55), mix(400, 255, 0.3),
+		mix(500, 0, 0),
+		mix(600, 0, 0.15), mix(700, 0, 0.3), mix(800, 0, 0.45), mix(900, 0, 0.6),
+	}
+	return "beads: { " + strings.Join(shades, ", ") + " }"
+}
+
+// parseHexColor splits a validated #rgb or #rrggbb color into channels
+func parseHexColor(color string) (uint8, uint8, uint8) {
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, _ := strconv.ParseUint(hex, 16, 32)
+	return uint8(v >> 16), uint8(v >> 8), uint8(v)
+}
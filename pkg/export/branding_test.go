@@ -0,0 +1,175 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadBranding(t *testing.T) {
+	dir := t.TempDir()
+	if b, err := LoadBranding(dir); err != nil || b != (Branding{}) {
+		t.Fatalf("missing config = %+v, %v", b, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "title: Acme\nlogo: assets/logo.png\naccent_color: \"#e11d48\"\nfooter: Internal\n"
+	if err := os.WriteFile(filepath.Join(dir, ".bv", BrandingConfigFilename), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadBranding(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Branding{Title: "Acme", Logo: filepath.Join(dir, "assets", "logo.png"), AccentColor: "#e11d48", Footer: "Internal"}
+	if b != want {
+		t.Errorf("LoadBranding() = %+v, want %+v", b, want)
+	}
+
+	merged := b.Merge(Branding{Title: "Override", AccentColor: "#000"})
+	if merged.Title != "Override" || merged.AccentColor != "#000" || merged.Footer != "Internal" {
+		t.Errorf("Merge() = %+v", merged)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".bv", BrandingConfigFilename), []byte("accent_color: red\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBranding(dir); err == nil {
+		t.Error("LoadBranding() accepted a non-hex accent color")
+	}
+}
+
+func TestCopyEmbeddedAssetsBranded(t *testing.T) {
+	if !HasEmbeddedAssets() {
+		t.Skip("viewer assets not embedded")
+	}
+	logo := filepath.Join(t.TempDir(), "Mark.SVG")
+	if err := os.WriteFile(logo, []byte("<svg/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	brand := Branding{Title: "Acme <Ops>", Logo: logo, AccentColor: "#e11d48", Footer: "© Acme & Co"}
+	if err := CopyEmbeddedAssetsBranded(outDir, brand); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, want := range []string{
+		"<title>Acme &lt;Ops&gt;</title>",
+		`font-semibold">Acme &lt;Ops&gt;</h1>`,
+		`<!-- bv:logo --><img src="logo.svg"`,
+		"500: '#e11d48'",
+		`<meta name="theme-color" content="#e11d48"`,
+		"© Acme &amp; Co</footer>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("index.html is missing %q", want)
+		}
+	}
+	if strings.Contains(page, "'#0ea5e9'") {
+		t.Error("default accent palette left in place")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "logo.svg")); err != nil {
+		t.Errorf("logo not copied: %v", err)
+	}
+}
+
+func TestCopyEmbeddedAssets_Unbranded(t *testing.T) {
+	if !HasEmbeddedAssets() {
+		t.Skip("viewer assets not embedded")
+	}
+	outDir := t.TempDir()
+	if err := CopyEmbeddedAssets(outDir, ""); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(outDir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := ViewerAssetsFS.ReadFile("viewer_assets/index.html")
+	if string(got) != string(want) {
+		t.Error("index.html changed without any branding")
+	}
+}
+
+func TestAccentPalette(t *testing.T) {
+	palette := accentPalette("#f00")
+	for _, want := range []string{"50: '#fff2f2'", "500: '#ff0000'", "900: '#660000'"} {
+		if !strings.Contains(palette, want) {
+			t.Errorf("accentPalette(#f00) = %s, missing %s", palette, want)
+		}
+	}
+}
+
+func TestSQLiteExport_BrandingMeta(t *testing.T) {
+	outDir := t.TempDir()
+	exp := NewSQLiteExporter([]*model.Issue{
+		makeTestIssue("brand-1", "Branded", model.StatusOpen, 1, model.TypeTask),
+	}, nil, nil, nil)
+	exp.Config.SetBranding(Branding{Title: "Acme", Logo: "/tmp/acme.png", AccentColor: "#123456", Footer: "Footer"})
+	if err := exp.Export(outDir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, "data", "meta.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta ExportMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Title != "Acme" || meta.Logo != "logo.png" || meta.AccentColor != "#123456" || meta.Footer != "Footer" {
+		t.Errorf("meta.json = %+v", meta)
+	}
+}
+
+func TestGenerateBrandedMarkdown(t *testing.T) {
+	issues := []model.Issue{*makeTestIssue("md-1", "Report", model.StatusOpen, 1, model.TypeTask)}
+	md, err := GenerateBrandedMarkdown(issues, Branding{Title: "Acme Report", Logo: "img/logo.png", AccentColor: "#e11d48", Footer: "Confidential"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(md, "<img src=\"img/logo.png\" alt=\"\" height=\"48\">\n\n# Acme Report\n") {
+		t.Errorf("report header = %q", md[:80])
+	}
+	if !strings.Contains(md, "```mermaid\n%%{init: {'themeVariables': {'lineColor': '#e11d48'") {
+		t.Error("accent color not applied to the dependency graph")
+	}
+	if !strings.HasSuffix(md, "*Confidential*\n") {
+		t.Error("footer missing from the end of the report")
+	}
+
+	if _, err := GenerateBrandedMarkdown(issues, Branding{AccentColor: "blue"}); err == nil {
+		t.Error("GenerateBrandedMarkdown() accepted an invalid accent color")
+	}
+}
+
+func TestSaveBrandedMarkdownToFile_RelativeLogo(t *testing.T) {
+	dir := t.TempDir()
+	logo := filepath.Join(dir, "assets", "logo.png")
+	report := filepath.Join(dir, "reports", "status.md")
+	if err := os.MkdirAll(filepath.Dir(report), 0755); err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{*makeTestIssue("md-1", "Report", model.StatusOpen, 1, model.TypeTask)}
+	if err := SaveBrandedMarkdownToFile(issues, report, Branding{Logo: logo}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `<img src="../assets/logo.png"`) {
+		t.Errorf("logo not linked relative to the report: %q", string(data[:60]))
+	}
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DiagramOptions picks the part of the dependency graph a diagram shows.
+type DiagramOptions struct {
+	Root     string         // Only this issue and what it depends on ("" = every issue)
+	Depth    int            // Dependency levels below Root (0 = unlimited)
+	Statuses []model.Status // Only issues with these statuses (empty = all)
+}
+
+// ParseDiagramStatuses parses a comma-separated status list such as
+// "open,in_progress".
+func ParseDiagramStatuses(spec string) ([]model.Status, error) {
+	var statuses []model.Status
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		status := model.Status(strings.ReplaceAll(part, "-", "_"))
+		if !status.IsValid() {
+			return nil, fmt.Errorf("unknown status %q (use open, in_progress, blocked or closed)", part)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SelectDiagramIssues returns the issues a diagram with opts shows. The
+// subgraph under Root is found before the status filter applies, so issues
+// reached through a filtered-out one still appear.
+func SelectDiagramIssues(issues []model.Issue, opts DiagramOptions) ([]model.Issue, error) {
+	if opts.Depth < 0 {
+		return nil, fmt.Errorf("depth must not be negative")
+	}
+	selected := issues
+	if opts.Root != "" {
+		found := false
+		for _, issue := range issues {
+			if issue.ID == opts.Root {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("issue %s not found", opts.Root)
+		}
+		selected = extractSubgraph(issues, opts.Root, opts.Depth)
+	} else if opts.Depth > 0 {
+		return nil, fmt.Errorf("depth needs a root issue")
+	}
+
+	if len(opts.Statuses) == 0 {
+		return selected, nil
+	}
+	want := make(map[model.Status]bool, len(opts.Statuses))
+	for _, status := range opts.Statuses {
+		want[status] = true
+	}
+	var filtered []model.Issue
+	for _, issue := range selected {
+		if want[issue.Status] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered, nil
+}
+
+// diagramIssueIDs is the set of IDs whose edges a diagram draws
+func diagramIssueIDs(issues []model.Issue) map[string]bool {
+	ids := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		ids[issue.ID] = true
+	}
+	return ids
+}
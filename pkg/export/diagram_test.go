@@ -0,0 +1,104 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// diagramIssues is a chain bv-1 -> bv-2 -> bv-3 -> bv-4 (each depends on
+// the next) plus an unrelated bv-5
+func diagramIssues() []model.Issue {
+	dep := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "bv-1", Title: "Epic", Status: model.StatusOpen, Dependencies: dep("bv-1", "bv-2")},
+		{ID: "bv-2", Title: "Done step", Status: model.StatusClosed, Dependencies: dep("bv-2", "bv-3")},
+		{ID: "bv-3", Title: "Next step", Status: model.StatusInProgress, Dependencies: dep("bv-3", "bv-4")},
+		{ID: "bv-4", Title: "Last step", Status: model.StatusOpen},
+		{ID: "bv-5", Title: "Unrelated", Status: model.StatusOpen},
+	}
+}
+
+func selectedIDs(t *testing.T, opts DiagramOptions) string {
+	t.Helper()
+	selected, err := SelectDiagramIssues(diagramIssues(), opts)
+	if err != nil {
+		t.Fatalf("SelectDiagramIssues(%+v) error = %v", opts, err)
+	}
+	var ids []string
+	for _, issue := range selected {
+		ids = append(ids, issue.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSelectDiagramIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		opts DiagramOptions
+		want string
+	}{
+		{"everything", DiagramOptions{}, "bv-1,bv-2,bv-3,bv-4,bv-5"},
+		{"root", DiagramOptions{Root: "bv-2"}, "bv-2,bv-3,bv-4"},
+		{"root and depth", DiagramOptions{Root: "bv-1", Depth: 2}, "bv-1,bv-2,bv-3"},
+		{"status", DiagramOptions{Statuses: []model.Status{model.StatusOpen}}, "bv-1,bv-4,bv-5"},
+		// The closed bv-2 is dropped, but the issues reached through it stay
+		{"root and status", DiagramOptions{Root: "bv-1", Statuses: []model.Status{model.StatusOpen, model.StatusInProgress}}, "bv-1,bv-3,bv-4"},
+	}
+	for _, tt := range tests {
+		if got := selectedIDs(t, tt.opts); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	for _, opts := range []DiagramOptions{{Root: "bv-9"}, {Depth: 2}, {Root: "bv-1", Depth: -1}} {
+		if _, err := SelectDiagramIssues(diagramIssues(), opts); err == nil {
+			t.Errorf("SelectDiagramIssues(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestParseDiagramStatuses(t *testing.T) {
+	statuses, err := ParseDiagramStatuses("open, In-Progress,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0] != model.StatusOpen || statuses[1] != model.StatusInProgress {
+		t.Errorf("ParseDiagramStatuses() = %v", statuses)
+	}
+	if statuses, err := ParseDiagramStatuses(""); err != nil || len(statuses) != 0 {
+		t.Errorf("empty spec = %v, %v", statuses, err)
+	}
+	if _, err := ParseDiagramStatuses("done"); err == nil {
+		t.Error("ParseDiagramStatuses() accepted an unknown status")
+	}
+}
+
+func TestGenerateMermaid(t *testing.T) {
+	diagram, err := GenerateMermaid(diagramIssues(), DiagramOptions{Root: "bv-2", Depth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(diagram, "graph TD\n") {
+		t.Errorf("diagram does not start with graph TD:\n%s", diagram)
+	}
+	for _, want := range []string{`bv-2["bv-2<br/>Done step"]`, "bv-2 ==> bv-3"} {
+		if !strings.Contains(diagram, want) {
+			t.Errorf("diagram is missing %q:\n%s", want, diagram)
+		}
+	}
+	for _, unwanted := range []string{"bv-1", "bv-4", "bv-5"} {
+		if strings.Contains(diagram, unwanted) {
+			t.Errorf("diagram includes %s outside the subgraph:\n%s", unwanted, diagram)
+		}
+	}
+
+	// Same output as the graph export's Mermaid format for the same issues
+	issues := diagramIssues()
+	if got, _ := GenerateMermaid(issues, DiagramOptions{}); !strings.HasPrefix(got, generateMermaid(issues, diagramIssueIDs(issues))) {
+		t.Error("standalone and --robot-graph Mermaid output differ")
+	}
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// RenderImpactDossier renders an impact dossier as Markdown, for pasting into
+// design docs and PR descriptions.
+func RenderImpactDossier(d *analysis.ImpactDossier) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %s %s: %s\n\n", getTypeEmoji(d.IssueType), d.IssueID, d.Title))
+	sb.WriteString(fmt.Sprintf("%s %s · %s", getStatusEmoji(d.Status), d.Status, getPriorityLabel(d.Priority)))
+	if d.Assignee != "" {
+		sb.WriteString(" · @" + d.Assignee)
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString("**Impact:** " + d.Summary() + "\n\n")
+
+	sb.WriteString("### Blocked by\n\n")
+	writeDossierIssues(&sb, d.DirectBlockers, d.TransitiveBlockers, "Nothing open blocks this issue.")
+
+	sb.WriteString("### Unblocks\n\n")
+	writeDossierIssues(&sb, d.DirectUnblocks, d.TransitiveUnblocks, "No open issue waits on this one.")
+
+	sb.WriteString("### Critical path\n\n")
+	cp := d.CriticalPath
+	switch {
+	case len(cp.Chain) == 0:
+		sb.WriteString("Closed issues are not on the critical path.\n\n")
+	case cp.OnCriticalPath:
+		sb.WriteString(fmt.Sprintf("On the critical path: the longest chain of open work (%d issues) runs through this issue, at step %d.\n\n", cp.ProjectLength, cp.Position))
+	default:
+		sb.WriteString(fmt.Sprintf("Not on the critical path: the longest chain through this issue has %d of %d issues.\n\n", len(cp.Chain), cp.ProjectLength))
+	}
+	if len(cp.Chain) > 1 {
+		steps := make([]string, len(cp.Chain))
+		for i, id := range cp.Chain {
+			steps[i] = id
+			if id == d.IssueID {
+				steps[i] = "**" + id + "**"
+			}
+		}
+		sb.WriteString(strings.Join(steps, " → ") + "\n\n")
+	}
+
+	sb.WriteString("### Score, risk and ETA\n\n")
+	sb.WriteString("| Metric | Value |\n|---|---|\n")
+	if d.Score != nil {
+		sb.WriteString(fmt.Sprintf("| Impact score | %.3f (#%d of %d open) |\n", *d.Score, d.Rank, d.OutOf))
+	}
+	sb.WriteString(fmt.Sprintf("| PageRank | %.4f |\n", d.Graph.PageRank))
+	sb.WriteString(fmt.Sprintf("| Betweenness | %.4f |\n", d.Graph.Betweenness))
+	sb.WriteString(fmt.Sprintf("| Downstream depth | %.0f |\n", d.Graph.CriticalPathDepth))
+	if d.Risk != nil {
+		sb.WriteString(fmt.Sprintf("| Risk | %.2f (status %.2f, churn %.2f, fan variance %.2f) |\n",
+			d.Risk.CompositeRisk, d.Risk.StatusRisk, d.Risk.ActivityChurn, d.Risk.FanVariance))
+	}
+	if d.WhatIf != nil && d.WhatIf.TransitiveUnblocks > 0 {
+		sb.WriteString(fmt.Sprintf("| Closing it | %s |\n", d.WhatIf.Explanation))
+	}
+	if d.ETA != nil {
+		sb.WriteString(fmt.Sprintf("| ETA | %s (%s – %s, %.0f%% confidence) |\n",
+			d.ETA.ETADate.Format("2006-01-02"), d.ETA.ETADateLow.Format("Jan 2"), d.ETA.ETADateHigh.Format("Jan 2"), d.ETA.Confidence*100))
+	}
+	return sb.String()
+}
+
+// writeDossierIssues lists direct issues, then transitive ones labeled with
+// their depth
+func writeDossierIssues(sb *strings.Builder, direct, transitive []analysis.DossierIssue, none string) {
+	if len(direct) == 0 {
+		sb.WriteString(none + "\n\n")
+		return
+	}
+	for _, issue := range append(append([]analysis.DossierIssue{}, direct...), transitive...) {
+		depth := ""
+		if issue.Depth > 1 {
+			depth = fmt.Sprintf("*(depth %d)* ", issue.Depth)
+		}
+		sb.WriteString(fmt.Sprintf("- %s%s **%s** %s (P%d)\n", depth, getStatusEmoji(issue.Status), issue.ID, issue.Title, issue.Priority))
+	}
+	sb.WriteString("\n")
+}
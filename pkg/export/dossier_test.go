@@ -0,0 +1,49 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRenderImpactDossier(t *testing.T) {
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+		{ID: "B", Title: "API", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeFeature, Dependencies: blocks("B", "A")},
+		{ID: "C", Title: "UI", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Dependencies: blocks("C", "B")},
+		{ID: "D", Title: "Launch", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeTask, Dependencies: blocks("D", "C")},
+	}
+	d, err := analysis.NewAnalyzer(issues).ImpactDossier("B", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := RenderImpactDossier(d)
+	for _, want := range []string{
+		"B: API",
+		"**Impact:** 1 open blockers (1 direct), unblocks 2 (1 direct), on the critical path (4 issues)",
+		"### Blocked by",
+		"**A** Schema (P1)",
+		"*(depth 2)*",
+		"A → **B** → C → D",
+		"| Impact score |",
+		"| ETA |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dossier missing %q:\n%s", want, out)
+		}
+	}
+
+	d, err = analysis.NewAnalyzer(issues).ImpactDossier("A", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := RenderImpactDossier(d); !strings.Contains(out, "Nothing open blocks this issue.") {
+		t.Errorf("expected empty blockers note:\n%s", out)
+	}
+}
@@ -0,0 +1,276 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Filter is a parsed issue filter expression. The expression uses the TUI
+// filter names (all, open, closed, ready, label:X) plus a few field terms:
+//
+//	status:X  type:X  priority:N  assignee:X  label:X  custom.NAME:X
+//
+// custom.NAME matches a custom field case-insensitively (any element of a
+// list field) and custom.NAME:* matches issues that have the field. Terms are separated by spaces and must all match. A value may list
+// alternatives separated by commas (label:api,ui), a leading "-" negates
+// a term (-label:wontfix), and any other word is a case-insensitive
+// search over ID and title.
+type Filter struct {
+	Expr  string
+	terms []filterTerm
+}
+
+type filterTerm struct {
+	key    string // "open", "closed", "ready", "status", "type", "priority", "assignee", "label", "custom", "text"
+	field  string // Custom field name for "custom"
+	values []string
+	negate bool
+}
+
+// ParseFilter parses a filter expression. An empty expression (or "all")
+// matches every issue.
+func ParseFilter(expr string) (Filter, error) {
+	f := Filter{Expr: strings.TrimSpace(expr)}
+	for _, word := range strings.Fields(f.Expr) {
+		term := filterTerm{}
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			term.negate = true
+			word = word[1:]
+		}
+
+		key, value, hasValue := strings.Cut(word, ":")
+		if !hasValue {
+			switch strings.ToLower(word) {
+			case "all":
+				if term.negate {
+					return Filter{}, fmt.Errorf("cannot negate %q", "all")
+				}
+				continue
+			case "open", "closed", "ready":
+				term.key = strings.ToLower(word)
+			default:
+				term.key = "text"
+				term.values = []string{strings.ToLower(word)}
+			}
+			f.terms = append(f.terms, term)
+			continue
+		}
+
+		if len(key) > len("custom.") && strings.EqualFold(key[:len("custom.")], "custom.") {
+			// Field names keep their case; only the prefix is case-insensitive
+			key, term.field = "custom", key[len("custom."):]
+		}
+		key = strings.ToLower(key)
+		switch key {
+		case "status", "type", "priority", "assignee", "label", "custom":
+		default:
+			return Filter{}, fmt.Errorf("unknown filter field %q (want status, type, priority, assignee, label or custom.NAME)", key)
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			}
+			if key == "priority" {
+				v = strings.TrimPrefix(strings.ToLower(v), "p")
+				if _, err := strconv.Atoi(v); err != nil {
+					return Filter{}, fmt.Errorf("invalid priority %q", v)
+				}
+			}
+			term.values = append(term.values, v)
+		}
+		if len(term.values) == 0 {
+			return Filter{}, fmt.Errorf("filter %q has no value", word)
+		}
+		term.key = key
+		f.terms = append(f.terms, term)
+	}
+	return f, nil
+}
+
+// IsEmpty reports whether the filter matches every issue
+func (f Filter) IsEmpty() bool {
+	return len(f.terms) == 0
+}
+
+// Match reports whether issue satisfies every term. issueMap resolves
+// blockers for the "ready" term and may be nil.
+func (f Filter) Match(issue model.Issue, issueMap map[string]*model.Issue) bool {
+	for _, term := range f.terms {
+		if term.match(issue, issueMap) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t filterTerm) match(issue model.Issue, issueMap map[string]*model.Issue) bool {
+	switch t.key {
+	case "open":
+		return issue.Status != model.StatusClosed
+	case "closed":
+		return issue.Status == model.StatusClosed
+	case "ready":
+		return IsReady(issue, issueMap)
+	case "text":
+		return strings.Contains(strings.ToLower(issue.ID), t.values[0]) ||
+			strings.Contains(strings.ToLower(issue.Title), t.values[0])
+	}
+
+	for _, v := range t.values {
+		switch t.key {
+		case "status":
+			if strings.EqualFold(string(issue.Status), v) {
+				return true
+			}
+		case "type":
+			if strings.EqualFold(string(issue.IssueType), v) {
+				return true
+			}
+		case "priority":
+			if strconv.Itoa(issue.Priority) == v {
+				return true
+			}
+		case "assignee":
+			if strings.EqualFold(issue.Assignee, v) {
+				return true
+			}
+		case "label":
+			for _, l := range issue.Labels {
+				if l == v {
+					return true
+				}
+			}
+		case "custom":
+			if matchCustomField(issue.CustomFields, t.field, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchCustomField reports whether the custom field name equals v
+// case-insensitively, or has an element that does when it is a list.
+// The value "*" matches any issue that has the field.
+func matchCustomField(fields map[string]any, name, v string) bool {
+	value, ok := fields[name]
+	if !ok {
+		return false
+	}
+	if v == "*" {
+		return true
+	}
+	if list, isList := value.([]any); isList {
+		for _, item := range list {
+			if strings.EqualFold(model.FormatCustomField(item), v) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.EqualFold(model.FormatCustomField(value), v)
+}
+
+// IsReady reports whether issue is open or in progress with no open
+// blocking dependency, matching the TUI's "ready" filter
+func IsReady(issue model.Issue, issueMap map[string]*model.Issue) bool {
+	if issue.Status == model.StatusClosed || issue.Status == model.StatusBlocked {
+		return false
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || dep.Type != model.DepBlocks {
+			continue
+		}
+		if blocker, exists := issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+			return false
+		}
+	}
+	return true
+}
+
+// IssueSummary is the compact per-issue record used by list-style robot
+// outputs and the MCP list_issues tool.
+type IssueSummary struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  int      `json:"priority"`
+	IssueType string   `json:"issue_type"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Ready     bool     `json:"ready"`
+	BlockedBy []string `json:"blocked_by,omitempty"` // Open blocking dependencies
+}
+
+// SummarizeIssue builds the IssueSummary for issue.
+func SummarizeIssue(issue model.Issue, issueMap map[string]*model.Issue) IssueSummary {
+	summary := IssueSummary{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		Status:    string(issue.Status),
+		Priority:  issue.Priority,
+		IssueType: string(issue.IssueType),
+		Assignee:  issue.Assignee,
+		Labels:    issue.Labels,
+		Ready:     IsReady(issue, issueMap),
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || dep.Type != model.DepBlocks {
+			continue
+		}
+		if blocker, exists := issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+			summary.BlockedBy = append(summary.BlockedBy, dep.DependsOnID)
+		}
+	}
+	return summary
+}
+
+// FilterIssues returns the issues matching f together with everything they
+// transitively depend on, so exported subsets keep their blockers and
+// parents. Input order is preserved. The second result is the number of
+// direct matches.
+func FilterIssues(issues []model.Issue, f Filter) ([]model.Issue, int) {
+	if f.IsEmpty() {
+		return issues, len(issues)
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	keep := make(map[string]bool)
+	var queue []string
+	matched := 0
+	for _, issue := range issues {
+		if f.Match(issue, issueMap) {
+			matched++
+			keep[issue.ID] = true
+			queue = append(queue, issue.ID)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil || keep[dep.DependsOnID] {
+				continue
+			}
+			if _, exists := issueMap[dep.DependsOnID]; exists {
+				keep[dep.DependsOnID] = true
+				queue = append(queue, dep.DependsOnID)
+			}
+		}
+	}
+
+	result := make([]model.Issue, 0, len(keep))
+	for _, issue := range issues {
+		if keep[issue.ID] {
+			result = append(result, issue)
+		}
+	}
+	return result, matched
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func filterFixture() []model.Issue {
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "bv-1", Title: "API gateway", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeFeature, Labels: []string{"api"}, Dependencies: blocks("bv-1", "bv-2"),
+			CustomFields: map[string]any{"severity": "High", "customers": []any{"acme", "globex"}}},
+		{ID: "bv-2", Title: "Auth service", Status: model.StatusInProgress, Priority: 0, IssueType: model.TypeTask, Assignee: "Alice", Dependencies: blocks("bv-2", "bv-3")},
+		{ID: "bv-3", Title: "Schema", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask},
+		{ID: "bv-4", Title: "Docs", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeChore, Labels: []string{"docs", "api"},
+			CustomFields: map[string]any{"severity": "low", "points": 3.0}},
+		{ID: "bv-5", Title: "Epic", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeEpic, Labels: []string{"api"}},
+	}
+}
+
+func TestParseFilter_Match(t *testing.T) {
+	issues := filterFixture()
+	issueMap := make(map[string]*model.Issue)
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"bv-1", "bv-2", "bv-3", "bv-4", "bv-5"}},
+		{"all", []string{"bv-1", "bv-2", "bv-3", "bv-4", "bv-5"}},
+		{"closed", []string{"bv-3"}},
+		{"ready", []string{"bv-2", "bv-4", "bv-5"}},
+		{"open label:api", []string{"bv-1", "bv-4", "bv-5"}},
+		{"label:api -type:epic", []string{"bv-1", "bv-4"}},
+		{"priority:0,p1", []string{"bv-1", "bv-2", "bv-5"}},
+		{"status:in_progress assignee:alice", []string{"bv-2"}},
+		{"AUTH", []string{"bv-2"}},
+		{"bv-4", []string{"bv-4"}},
+		{"custom.severity:high", []string{"bv-1"}},
+		{"Custom.severity:LOW", []string{"bv-4"}},
+		{"custom.customers:globex", []string{"bv-1"}},
+		{"custom.points:3", []string{"bv-4"}},
+		{"custom.severity:*", []string{"bv-1", "bv-4"}},
+		{"-custom.severity:*", []string{"bv-2", "bv-3", "bv-5"}},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.expr, err)
+		}
+		var got []string
+		for _, issue := range issues {
+			if f.Match(issue, issueMap) {
+				got = append(got, issue.ID)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.expr, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.expr, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	for _, expr := range []string{"owner:bob", "priority:high", "label:", "-all", "custom.:x"} {
+		if _, err := ParseFilter(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+func TestFilterIssues_DependencyClosure(t *testing.T) {
+	issues := filterFixture()
+	f, err := ParseFilter("bv-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, matched := FilterIssues(issues, f)
+	if matched != 1 {
+		t.Errorf("expected 1 direct match, got %d", matched)
+	}
+	if len(got) != 3 || got[0].ID != "bv-1" || got[1].ID != "bv-2" || got[2].ID != "bv-3" {
+		t.Errorf("expected bv-1 with its transitive blockers in input order, got %v", got)
+	}
+
+	empty, _ := ParseFilter("")
+	if all, n := FilterIssues(issues, empty); len(all) != len(issues) || n != len(issues) {
+		t.Errorf("empty filter should keep everything, got %d/%d", len(all), n)
+	}
+}
+
+func TestSummarizeIssue(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Title: "A", Status: model.StatusOpen},
+		{ID: "b", Title: "B", Status: model.StatusClosed},
+		{ID: "c", Title: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "c", DependsOnID: "a", Type: model.DepBlocks},
+			{IssueID: "c", DependsOnID: "b", Type: model.DepBlocks},
+		}},
+	}
+	issueMap := make(map[string]*model.Issue)
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	summary := SummarizeIssue(issues[2], issueMap)
+	if summary.Ready || len(summary.BlockedBy) != 1 || summary.BlockedBy[0] != "a" {
+		t.Errorf("c should be blocked only by open a, got %+v", summary)
+	}
+	if summary := SummarizeIssue(issues[0], issueMap); !summary.Ready || summary.BlockedBy != nil {
+		t.Errorf("a should be ready, got %+v", summary)
+	}
+}
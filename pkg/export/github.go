@@ -331,6 +331,89 @@ func InitAndPush(bundlePath string, repoFullName string, forceOverwrite bool) er
 	return nil
 }
 
+// CommitBundleToBranch commits the contents of bundlePath as the whole
+// tree of branch in the git repository containing repoDir, without touching
+// that repository's working tree or index. The branch is created as an
+// orphan if missing. When remote is non-empty the branch is pushed to it.
+// It returns the new commit, or "" when the bundle matches the branch tip.
+func CommitBundleToBranch(repoDir, bundlePath, branch, remote, message string) (string, error) {
+	absBundle, err := filepath.Abs(bundlePath)
+	if err != nil {
+		return "", fmt.Errorf("resolve bundle path: %w", err)
+	}
+	if info, err := os.Stat(absBundle); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("bundle directory not found: %s", bundlePath)
+	}
+
+	git := func(env []string, args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), env...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(out)))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	gitDir, err := git(nil, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %w", err)
+	}
+
+	// Stage the bundle into a throwaway index so the user's index is untouched
+	indexFile, err := os.CreateTemp("", "bv-pages-index-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp index: %w", err)
+	}
+	indexPath := indexFile.Name()
+	indexFile.Close()
+	os.Remove(indexPath) // git refuses an empty file as an index
+	defer os.Remove(indexPath)
+
+	env := []string{"GIT_INDEX_FILE=" + indexPath}
+	if _, err := git(env, "--git-dir="+gitDir, "--work-tree="+absBundle, "add", "-A", "--force", "."); err != nil {
+		return "", err
+	}
+	tree, err := git(env, "--git-dir="+gitDir, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	ref := "refs/heads/" + branch
+	parent, _ := git(nil, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	parentTree := ""
+	if parent != "" {
+		parentTree, _ = git(nil, "rev-parse", parent+"^{tree}")
+	}
+
+	var commit string
+	if tree != parentTree {
+		commitArgs := []string{"commit-tree", tree, "-m", message}
+		updateArgs := []string{"update-ref", ref}
+		if parent != "" {
+			commitArgs = append(commitArgs, "-p", parent)
+		}
+		if commit, err = git(nil, commitArgs...); err != nil {
+			return "", err
+		}
+		updateArgs = append(updateArgs, commit)
+		if parent != "" {
+			updateArgs = append(updateArgs, parent)
+		}
+		if _, err := git(nil, updateArgs...); err != nil {
+			return "", err
+		}
+	}
+
+	if remote != "" {
+		if _, err := git(nil, "push", remote, ref+":"+ref); err != nil {
+			return commit, err
+		}
+	}
+	return commit, nil
+}
+
 // EnableGitHubPages enables GitHub Pages for a repository.
 func EnableGitHubPages(repoFullName string) (string, error) {
 	fmt.Println("  -> Enabling GitHub Pages...")
@@ -1,9 +1,10 @@
 package export
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
-	"hash/fnv"
+	"io"
 	"sort"
 	"strings"
 
@@ -355,184 +356,176 @@ func sanitizeDOTID(id string) string {
 
 // generateMermaid creates a Mermaid diagram format graph.
 func generateMermaid(issues []model.Issue, issueIDs map[string]bool) string {
-	var sb strings.Builder
-
-	sb.WriteString("graph TD\n")
-
-	// Class definitions for styling
-	sb.WriteString("    classDef open fill:#50FA7B,stroke:#333,color:#000\n")
-	sb.WriteString("    classDef inprogress fill:#8BE9FD,stroke:#333,color:#000\n")
-	sb.WriteString("    classDef blocked fill:#FF5555,stroke:#333,color:#000\n")
-	sb.WriteString("    classDef closed fill:#6272A4,stroke:#333,color:#fff\n")
-	sb.WriteString("\n")
-
-	// Sort issues for deterministic output
-	sortedIssues := make([]model.Issue, len(issues))
-	copy(sortedIssues, issues)
-	sort.Slice(sortedIssues, func(i, j int) bool {
-		return sortedIssues[i].ID < sortedIssues[j].ID
-	})
-
-	// Build deterministic, collision-free Mermaid IDs
-	safeIDMap := make(map[string]string)
-	usedSafe := make(map[string]bool)
+	return GenerateMermaidGraph(issues, issueIDs, MermaidConfig{})
+}
 
-	getSafeID := func(orig string) string {
-		if safe, ok := safeIDMap[orig]; ok {
-			return safe
-		}
-		base := sanitizeMermaidID(orig)
-		if base == "" {
-			base = "node"
-		}
-		safe := base
-		if usedSafe[safe] && safeIDMap[orig] == "" {
-			// Collision: derive stable hash-based suffix
-			h := fnv.New32a()
-			_, _ = h.Write([]byte(orig))
-			safe = fmt.Sprintf("%s_%x", base, h.Sum32())
-		}
-		usedSafe[safe] = true
-		safeIDMap[orig] = safe
-		return safe
+// generateAdjacency creates a JSON adjacency list representation.
+func generateAdjacency(issues []model.Issue, issueIDs map[string]bool, stats *analysis.GraphStats) *AdjacencyGraph {
+	// Get PageRank
+	var pageRank map[string]float64
+	if stats != nil {
+		pageRank = stats.PageRank()
 	}
 
-	// Pre-calculate all safe IDs to ensure consistency
+	sortedIssues := sortIssuesByID(issues)
+
+	// Build nodes
+	nodes := make([]AdjacencyNode, 0, len(sortedIssues))
 	for _, i := range sortedIssues {
-		getSafeID(i.ID)
+		nodes = append(nodes, adjacencyNode(i, pageRank))
 	}
 
-	// Nodes
+	// Build edges
+	var edges []AdjacencyEdge
 	for _, i := range sortedIssues {
-		safeID := getSafeID(i.ID)
-		safeTitle := sanitizeMermaidText(i.Title)
-		safeLabelID := sanitizeMermaidText(i.ID)
-
-		sb.WriteString(fmt.Sprintf("    %s[\"%s<br/>%s\"]\n", safeID, safeLabelID, safeTitle))
-
-		// Apply class based on status
-		var class string
-		switch i.Status {
-		case model.StatusOpen:
-			class = "open"
-		case model.StatusInProgress:
-			class = "inprogress"
-		case model.StatusBlocked:
-			class = "blocked"
-		case model.StatusClosed:
-			class = "closed"
-		}
-		sb.WriteString(fmt.Sprintf("    class %s %s\n", safeID, class))
+		edges = append(edges, adjacencyEdges(i, issueIDs)...)
 	}
 
-	sb.WriteString("\n")
+	return &AdjacencyGraph{
+		Nodes: nodes,
+		Edges: edges,
+	}
+}
 
-	// Edges
-	for _, i := range sortedIssues {
-		// Sort dependencies
-		deps := make([]*model.Dependency, len(i.Dependencies))
-		copy(deps, i.Dependencies)
-		sort.Slice(deps, func(a, b int) bool {
-			if deps[a] == nil {
-				return false
-			}
-			if deps[b] == nil {
-				return true
-			}
-			return deps[a].DependsOnID < deps[b].DependsOnID
-		})
+// sortIssuesByID returns a copy of issues sorted by ID for deterministic output.
+func sortIssuesByID(issues []model.Issue) []model.Issue {
+	sorted := make([]model.Issue, len(issues))
+	copy(sorted, issues)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
 
-		for _, dep := range deps {
-			if dep == nil || !issueIDs[dep.DependsOnID] {
-				continue
-			}
+// adjacencyNode builds the node for an issue, with PageRank when available.
+func adjacencyNode(i model.Issue, pageRank map[string]float64) AdjacencyNode {
+	node := AdjacencyNode{
+		ID:       i.ID,
+		Title:    i.Title,
+		Status:   string(i.Status),
+		Priority: i.Priority,
+		Labels:   i.Labels,
+	}
+	if pr, ok := pageRank[i.ID]; ok {
+		node.PageRank = pr
+	}
+	return node
+}
 
-			safeFromID := getSafeID(i.ID)
-			safeToID := getSafeID(dep.DependsOnID)
+// adjacencyEdges returns an issue's edges to other issues in issueIDs,
+// sorted by target.
+func adjacencyEdges(i model.Issue, issueIDs map[string]bool) []AdjacencyEdge {
+	// Sort dependencies
+	deps := make([]*model.Dependency, len(i.Dependencies))
+	copy(deps, i.Dependencies)
+	sort.Slice(deps, func(a, b int) bool {
+		if deps[a] == nil {
+			return false
+		}
+		if deps[b] == nil {
+			return true
+		}
+		return deps[a].DependsOnID < deps[b].DependsOnID
+	})
 
-			linkStyle := "-.->" // Dashed for related
-			if dep.Type == model.DepBlocks {
-				linkStyle = "==>" // Bold for blockers
-			}
+	var edges []AdjacencyEdge
+	for _, dep := range deps {
+		if dep == nil || !issueIDs[dep.DependsOnID] {
+			continue
+		}
 
-			sb.WriteString(fmt.Sprintf("    %s %s %s\n", safeFromID, linkStyle, safeToID))
+		edgeType := "related"
+		if dep.Type == model.DepBlocks {
+			edgeType = "blocks"
 		}
+
+		edges = append(edges, AdjacencyEdge{
+			From: i.ID,
+			To:   dep.DependsOnID,
+			Type: edgeType,
+		})
 	}
+	return edges
+}
 
-	return sb.String()
+// graphStreamSummary is the final record of an NDJSON graph stream.
+type graphStreamSummary struct {
+	Kind           string            `json:"kind"`
+	Nodes          int               `json:"nodes"`
+	Edges          int               `json:"edges"`
+	FiltersApplied map[string]string `json:"filters_applied,omitempty"`
+	DataHash       string            `json:"data_hash,omitempty"`
 }
 
-// generateAdjacency creates a JSON adjacency list representation.
-func generateAdjacency(issues []model.Issue, issueIDs map[string]bool, stats *analysis.GraphStats) *AdjacencyGraph {
-	// Get PageRank
-	var pageRank map[string]float64
-	if stats != nil {
-		pageRank = stats.PageRank()
+// StreamGraphNDJSON writes the adjacency graph as newline-delimited JSON:
+// one {"kind":"edge"} record per dependency, then one {"kind":"node"} record
+// per issue, then a {"kind":"summary"} line. Edges need no graph metrics, so
+// they are flushed before waiting for Phase 2 (PageRank); consumers can start
+// on the structure while the analysis finishes.
+func StreamGraphNDJSON(w io.Writer, issues []model.Issue, stats *analysis.GraphStats, config GraphExportConfig) error {
+	filteredIssues := sortIssuesByID(filterIssues(issues, config))
+	issueIDs := make(map[string]bool, len(filteredIssues))
+	for _, i := range filteredIssues {
+		issueIDs[i.ID] = true
 	}
 
-	// Sort issues for deterministic output
-	sortedIssues := make([]model.Issue, len(issues))
-	copy(sortedIssues, issues)
-	sort.Slice(sortedIssues, func(i, j int) bool {
-		return sortedIssues[i].ID < sortedIssues[j].ID
-	})
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
 
-	// Build nodes
-	nodes := make([]AdjacencyNode, 0, len(sortedIssues))
-	for _, i := range sortedIssues {
-		node := AdjacencyNode{
-			ID:       i.ID,
-			Title:    i.Title,
-			Status:   string(i.Status),
-			Priority: i.Priority,
-			Labels:   i.Labels,
-		}
-		if pageRank != nil {
-			if pr, ok := pageRank[i.ID]; ok {
-				node.PageRank = pr
+	edgeCount := 0
+	for _, i := range filteredIssues {
+		for _, edge := range adjacencyEdges(i, issueIDs) {
+			record := struct {
+				Kind string `json:"kind"`
+				AdjacencyEdge
+			}{"edge", edge}
+			if err := enc.Encode(record); err != nil {
+				return fmt.Errorf("write edge %s->%s: %w", edge.From, edge.To, err)
 			}
+			edgeCount++
 		}
-		nodes = append(nodes, node)
+	}
+	if err := bw.Flush(); err != nil {
+		return err
 	}
 
-	// Build edges
-	var edges []AdjacencyEdge
-	for _, i := range sortedIssues {
-		// Sort dependencies
-		deps := make([]*model.Dependency, len(i.Dependencies))
-		copy(deps, i.Dependencies)
-		sort.Slice(deps, func(a, b int) bool {
-			if deps[a] == nil {
-				return false
-			}
-			if deps[b] == nil {
-				return true
-			}
-			return deps[a].DependsOnID < deps[b].DependsOnID
-		})
-
-		for _, dep := range deps {
-			if dep == nil || !issueIDs[dep.DependsOnID] {
-				continue
-			}
-
-			edgeType := "related"
-			if dep.Type == model.DepBlocks {
-				edgeType = "blocks"
-			}
-
-			edges = append(edges, AdjacencyEdge{
-				From: i.ID,
-				To:   dep.DependsOnID,
-				Type: edgeType,
-			})
+	var pageRank map[string]float64
+	if stats != nil {
+		stats.WaitForPhase2()
+		pageRank = stats.PageRank()
+	}
+	for _, i := range filteredIssues {
+		record := struct {
+			Kind string `json:"kind"`
+			AdjacencyNode
+		}{"node", adjacencyNode(i, pageRank)}
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("write node %s: %w", i.ID, err)
 		}
 	}
 
-	return &AdjacencyGraph{
-		Nodes: nodes,
-		Edges: edges,
+	summary := graphStreamSummary{
+		Kind:     "summary",
+		Nodes:    len(filteredIssues),
+		Edges:    edgeCount,
+		DataHash: config.DataHash,
+	}
+	if config.Label != "" || config.Root != "" {
+		summary.FiltersApplied = make(map[string]string)
+		if config.Label != "" {
+			summary.FiltersApplied["label"] = config.Label
+		}
+		if config.Root != "" {
+			summary.FiltersApplied["root"] = config.Root
+		}
+		if config.Depth > 0 {
+			summary.FiltersApplied["depth"] = fmt.Sprintf("%d", config.Depth)
+		}
+	}
+	if err := enc.Encode(summary); err != nil {
+		return fmt.Errorf("write summary: %w", err)
 	}
+	return bw.Flush()
 }
 
 // GraphExportResultJSON returns the result as JSON bytes.
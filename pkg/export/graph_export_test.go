@@ -310,3 +310,43 @@ func TestExportGraph_DeterministicOutput(t *testing.T) {
 		t.Error("DOT output should be deterministic across calls")
 	}
 }
+
+func TestStreamGraphNDJSON(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-2", Title: "Second", Status: model.StatusOpen, Priority: 2,
+			Dependencies: []*model.Dependency{
+				{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks},
+				{IssueID: "bv-2", DependsOnID: "missing", Type: model.DepBlocks},
+			},
+		},
+		{ID: "bv-1", Title: "First", Status: model.StatusOpen, Priority: 1},
+	}
+	stats := analysis.NewAnalyzer(issues).AnalyzeAsync(t.Context())
+
+	var out strings.Builder
+	if err := StreamGraphNDJSON(&out, issues, stats, GraphExportConfig{DataHash: "h"}); err != nil {
+		t.Fatalf("StreamGraphNDJSON failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected edge, 2 nodes and summary, got %d lines:\n%s", len(lines), out.String())
+	}
+	var kinds []string
+	for _, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		kinds = append(kinds, rec["kind"].(string))
+	}
+	if got := strings.Join(kinds, ","); got != "edge,node,node,summary" {
+		t.Errorf("unexpected record order %s", got)
+	}
+	if !strings.Contains(lines[0], `"type":"blocks"`) || !strings.Contains(lines[1], `"id":"bv-1"`) {
+		t.Errorf("unexpected records:\n%s", out.String())
+	}
+	if !strings.Contains(lines[3], `"nodes":2,"edges":1`) {
+		t.Errorf("unexpected summary %s", lines[3])
+	}
+}
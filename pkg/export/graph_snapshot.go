@@ -2,6 +2,7 @@ package export
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 	"io"
 	"math"
@@ -302,6 +303,22 @@ func statusColor(s model.Status) color.RGBA {
 }
 
 func renderPNG(opts GraphSnapshotOptions, layout layoutResult) error {
+	return rasterizeLayout(layout).SavePNG(opts.Path)
+}
+
+// RenderGraphImage renders the same picture as a PNG snapshot, as an image
+// in memory. Path and Format are ignored.
+func RenderGraphImage(opts GraphSnapshotOptions) (image.Image, error) {
+	if len(opts.Issues) == 0 {
+		return nil, fmt.Errorf("no issues to export")
+	}
+	if opts.Stats == nil {
+		return nil, fmt.Errorf("graph stats are required for snapshot export")
+	}
+	return rasterizeLayout(buildLayout(opts)).Image(), nil
+}
+
+func rasterizeLayout(layout layoutResult) *gg.Context {
 	dc := gg.NewContext(layout.Width, layout.Height)
 	dc.SetColor(colorBackdrop)
 	dc.Clear()
@@ -340,7 +357,7 @@ func renderPNG(opts GraphSnapshotOptions, layout layoutResult) error {
 		drawNode(dc, n)
 	}
 
-	return dc.SavePNG(opts.Path)
+	return dc
 }
 
 func renderSVG(opts GraphSnapshotOptions, layout layoutResult) error {
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const (
+	icsDateTime = "20060102T150405Z"
+	icsDate     = "20060102"
+	// icsLineOctets is the longest content line RFC 5545 allows before folding
+	icsLineOctets = 75
+)
+
+// GenerateICS renders issues as an iCalendar (RFC 5545) file. An issue with
+// a due date becomes a VTODO due then; an open issue with an ETA becomes an
+// all-day VEVENT spanning its ETA window, marked free so it overlays the
+// calendar without blocking time. UIDs are stable, so re-importing the file
+// updates the entries instead of duplicating them.
+func GenerateICS(issues []model.Issue, etas map[string]analysis.ETAEstimate, now time.Time) string {
+	sorted := make([]model.Issue, len(issues))
+	copy(sorted, issues)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	w := &icsWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//beads_viewer//bv//EN")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:Beads")
+
+	stamp := now.UTC().Format(icsDateTime)
+	for _, issue := range sorted {
+		if issue.Status == model.StatusTombstone {
+			continue
+		}
+		if issue.DueDate != nil {
+			writeDueTodo(w, issue, stamp)
+		}
+		if eta, ok := etas[issue.ID]; ok && !issue.Status.IsClosed() {
+			writeETAEvent(w, issue, eta, stamp)
+		}
+	}
+
+	w.line("END:VCALENDAR")
+	return w.String()
+}
+
+func writeDueTodo(w *icsWriter, issue model.Issue, stamp string) {
+	w.line("BEGIN:VTODO")
+	w.line("UID:" + icsUID(issue.ID, "due"))
+	w.line("DTSTAMP:" + stamp)
+	w.line("SUMMARY:" + icsText(issue.ID+": "+issue.Title))
+	w.line("DUE:" + issue.DueDate.UTC().Format(icsDateTime))
+	w.line("PRIORITY:" + fmt.Sprint(icsPriority(issue.Priority)))
+	switch {
+	case issue.Status.IsClosed():
+		w.line("STATUS:COMPLETED")
+		if issue.ClosedAt != nil {
+			w.line("COMPLETED:" + issue.ClosedAt.UTC().Format(icsDateTime))
+		}
+	case issue.Status == model.StatusInProgress:
+		w.line("STATUS:IN-PROCESS")
+	default:
+		w.line("STATUS:NEEDS-ACTION")
+	}
+	writeIssueDetails(w, issue, "")
+	w.line("END:VTODO")
+}
+
+func writeETAEvent(w *icsWriter, issue model.Issue, eta analysis.ETAEstimate, stamp string) {
+	start, end := eta.ETADateLow, eta.ETADateHigh
+	if start.IsZero() {
+		start = eta.ETADate
+	}
+	if end.Before(start) {
+		end = start
+	}
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + icsUID(issue.ID, "eta"))
+	w.line("DTSTAMP:" + stamp)
+	w.line("SUMMARY:" + icsText("ETA "+issue.ID+": "+issue.Title))
+	// All-day events end on the day after the last one they cover
+	w.line("DTSTART;VALUE=DATE:" + start.Format(icsDate))
+	w.line("DTEND;VALUE=DATE:" + end.AddDate(0, 0, 1).Format(icsDate))
+	w.line("TRANSP:TRANSPARENT")
+	w.line("STATUS:TENTATIVE")
+	summary := fmt.Sprintf("Projected completion %s (%.1f days of work, %.0f%% confidence)",
+		eta.ETADate.Format("2006-01-02"), eta.EstimatedDays, eta.Confidence*100)
+	writeIssueDetails(w, issue, summary)
+	w.line("END:VEVENT")
+}
+
+// writeIssueDetails writes the description and categories shared by both
+// kinds of entry; lead goes before the issue description
+func writeIssueDetails(w *icsWriter, issue model.Issue, lead string) {
+	var parts []string
+	if lead != "" {
+		parts = append(parts, lead)
+	}
+	meta := fmt.Sprintf("%s · %s · P%d", issue.IssueType, issue.Status, issue.Priority)
+	if issue.Assignee != "" {
+		meta += " · @" + issue.Assignee
+	}
+	parts = append(parts, meta)
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		parts = append(parts, desc)
+	}
+	w.line("DESCRIPTION:" + icsText(strings.Join(parts, "\n\n")))
+	if len(issue.Labels) > 0 {
+		labels := make([]string, len(issue.Labels))
+		for i, label := range issue.Labels {
+			labels[i] = icsText(label)
+		}
+		w.line("CATEGORIES:" + strings.Join(labels, ","))
+	}
+}
+
+// icsUID is a stable UID for one kind of entry of an issue
+func icsUID(issueID, kind string) string {
+	return icsText(issueID) + "-" + kind + "@beads"
+}
+
+// icsPriority maps beads priorities (0 = critical .. 4 = backlog) onto
+// iCalendar's 1 (highest) .. 9 (lowest)
+func icsPriority(priority int) int {
+	if priority < 0 {
+		priority = 0
+	}
+	if priority > 4 {
+		priority = 4
+	}
+	return 1 + priority*2
+}
+
+// icsText escapes a TEXT value
+func icsText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+// icsWriter writes CRLF-terminated content lines, folding long ones
+type icsWriter struct {
+	sb strings.Builder
+}
+
+// line writes one content line, folded at 75 octets without splitting a
+// UTF-8 sequence; continuation lines start with a space
+func (w *icsWriter) line(content string) {
+	limit := icsLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.sb.WriteString(content[:cut])
+		w.sb.WriteString("\r\n ")
+		content = content[cut:]
+		limit = icsLineOctets - 1
+	}
+	w.sb.WriteString(content)
+	w.sb.WriteString("\r\n")
+}
+
+func (w *icsWriter) String() string {
+	return w.sb.String()
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestGenerateICS(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2025, 3, 20, 17, 0, 0, 0, time.UTC)
+	closed := now.Add(-time.Hour)
+
+	withDue := *makeTestIssue("ics-1", "Ship, finally; really", model.StatusInProgress, 1, model.TypeTask)
+	withDue.DueDate = &due
+	doneDue := *makeTestIssue("ics-2", "Done", model.StatusClosed, 2, model.TypeBug)
+	doneDue.DueDate = &due
+	doneDue.ClosedAt = &closed
+	plain := *makeTestIssue("ics-3", "Nothing scheduled", model.StatusOpen, 3, model.TypeTask)
+
+	etas := map[string]analysis.ETAEstimate{
+		"ics-1": {IssueID: "ics-1", ETADate: now.AddDate(0, 0, 3), ETADateLow: now.AddDate(0, 0, 2), ETADateHigh: now.AddDate(0, 0, 5), EstimatedDays: 3, Confidence: 0.4},
+		"ics-2": {IssueID: "ics-2", ETADate: now, ETADateLow: now, ETADateHigh: now},
+	}
+
+	ics := GenerateICS([]model.Issue{plain, doneDue, withDue}, etas, now)
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VTODO\r\nUID:ics-1-due@beads\r\nDTSTAMP:20250310T090000Z\r\nSUMMARY:ics-1: Ship\\, finally\\; really\r\nDUE:20250320T170000Z\r\nPRIORITY:3\r\nSTATUS:IN-PROCESS\r\n",
+		"UID:ics-2-due@beads",
+		"STATUS:COMPLETED\r\nCOMPLETED:20250310T080000Z\r\n",
+		"BEGIN:VEVENT\r\nUID:ics-1-eta@beads\r\n",
+		"DTSTART;VALUE=DATE:20250312\r\nDTEND;VALUE=DATE:20250316\r\nTRANSP:TRANSPARENT\r\n",
+		"CATEGORIES:test\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ICS is missing %q", want)
+		}
+	}
+	if strings.Contains(ics, "ics-2-eta") {
+		t.Error("closed issue got an ETA event")
+	}
+	if strings.Contains(ics, "ics-3") {
+		t.Error("issue without a due date or ETA appeared")
+	}
+	if strings.Index(ics, "ics-1-due") > strings.Index(ics, "ics-2-due") {
+		t.Error("entries not sorted by issue ID")
+	}
+}
+
+func TestICSWriter_Folds(t *testing.T) {
+	w := &icsWriter{}
+	w.line("DESCRIPTION:" + strings.Repeat("é", 60))
+	for i, line := range strings.Split(strings.TrimSuffix(w.String(), "\r\n"), "\r\n") {
+		if len(line) > icsLineOctets {
+			t.Errorf("line %d has %d octets", i, len(line))
+		}
+		if i > 0 && !strings.HasPrefix(line, " ") {
+			t.Errorf("continuation line %d does not start with a space", i)
+		}
+		if !strings.Contains(line, "é") && i > 0 {
+			t.Errorf("line %d = %q", i, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(w.String(), "\r\n ", "")
+	if unfolded != "DESCRIPTION:"+strings.Repeat("é", 60)+"\r\n" {
+		t.Error("unfolding did not restore the line")
+	}
+}
@@ -3,6 +3,7 @@ package export
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -119,3 +120,77 @@ exit 0
 		t.Fatalf("Unexpected InitAndPush error: %v", err)
 	}
 }
+
+func TestCommitBundleToBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "bv test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "bv@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "bv test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "bv@example.com")
+
+	remote := t.TempDir()
+	run(remote, "init", "--bare", "-q")
+	repo := t.TempDir()
+	run(repo, "init", "-q")
+	run(repo, "remote", "add", "origin", remote)
+	if err := os.WriteFile(filepath.Join(repo, "main.txt"), []byte("work in progress"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run(repo, "add", "main.txt")
+
+	bundle := filepath.Join(repo, "site")
+	if err := os.MkdirAll(filepath.Join(bundle, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"index.html": "<html>", "data/meta.json": "{}"} {
+		if err := os.WriteFile(filepath.Join(bundle, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commit, err := CommitBundleToBranch(repo, bundle, "gh-pages", "origin", "Publish")
+	if err != nil {
+		t.Fatalf("CommitBundleToBranch failed: %v", err)
+	}
+	if commit == "" {
+		t.Fatal("expected a commit for a new branch")
+	}
+	if files := run(repo, "ls-tree", "-r", "--name-only", "gh-pages"); files != "data/meta.json\nindex.html" {
+		t.Errorf("branch should hold exactly the bundle, got %q", files)
+	}
+	if got := run(remote, "rev-parse", "gh-pages"); got != commit {
+		t.Errorf("remote gh-pages at %s, want %s", got, commit)
+	}
+	if staged := run(repo, "diff", "--cached", "--name-only"); staged != "main.txt" {
+		t.Errorf("user's index should be untouched, got %q", staged)
+	}
+
+	// Unchanged bundle: no new commit
+	again, err := CommitBundleToBranch(repo, bundle, "gh-pages", "", "Publish")
+	if err != nil || again != "" {
+		t.Errorf("expected no commit for an unchanged bundle, got %q %v", again, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(bundle, "index.html"), []byte("<html>v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	next, err := CommitBundleToBranch(repo, bundle, "gh-pages", "", "Publish")
+	if err != nil || next == "" {
+		t.Fatalf("expected a second commit, got %q %v", next, err)
+	}
+	if parent := run(repo, "rev-parse", next+"^"); parent != commit {
+		t.Errorf("second commit should build on the first, parent %s", parent)
+	}
+}
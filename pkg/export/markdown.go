@@ -3,7 +3,9 @@ package export
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
@@ -72,9 +74,27 @@ func sanitizeMermaidText(text string) string {
 
 // GenerateMarkdown creates a comprehensive markdown report of all issues
 func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
+	return GenerateBrandedMarkdown(issues, Branding{Title: title})
+}
+
+// GenerateBrandedMarkdown creates the markdown report with project branding:
+// the logo above the title, the accent color on the dependency graph and the
+// footer at the end.
+func GenerateBrandedMarkdown(issues []model.Issue, brand Branding) (string, error) {
+	if err := brand.Validate(); err != nil {
+		return "", err
+	}
+	title := brand.Title
+	if title == "" {
+		title = "Beads Export"
+	}
+
 	var sb strings.Builder
 
 	// Header
+	if brand.Logo != "" {
+		sb.WriteString(fmt.Sprintf("<img src=\"%s\" alt=\"\" height=\"48\">\n\n", html.EscapeString(filepath.ToSlash(brand.Logo))))
+	}
 	sb.WriteString(fmt.Sprintf("# %s\n\n", title))
 	sb.WriteString(fmt.Sprintf("*Generated: %s*\n\n", time.Now().Format(time.RFC1123)))
 
@@ -118,6 +138,9 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	// Dependency Graph (Mermaid)
 	sb.WriteString("## Dependency Graph\n\n")
 	sb.WriteString("```mermaid\n")
+	if brand.AccentColor != "" {
+		sb.WriteString(fmt.Sprintf("%%%%{init: {'themeVariables': {'lineColor': '%s', 'primaryBorderColor': '%s'}}}%%%%\n", brand.AccentColor, brand.AccentColor))
+	}
 
 	issueIDs := make(map[string]bool)
 	for _, i := range issues {
@@ -199,6 +222,17 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 			sb.WriteString("\n")
 		}
 
+		if len(i.Attachments) > 0 {
+			sb.WriteString("### Attachments\n\n")
+			for _, a := range i.Attachments {
+				if a == nil {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("- [%s](%s)\n", a.Label(), a.URL))
+			}
+			sb.WriteString("\n")
+		}
+
 		if len(i.Comments) > 0 {
 			sb.WriteString("### Comments\n\n")
 			for _, c := range i.Comments {
@@ -217,6 +251,10 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 		sb.WriteString("---\n\n")
 	}
 
+	if brand.Footer != "" {
+		sb.WriteString(fmt.Sprintf("*%s*\n", strings.TrimSpace(brand.Footer)))
+	}
+
 	return sb.String(), nil
 }
 
@@ -280,6 +318,12 @@ func getPriorityLabel(priority int) string {
 
 // SaveMarkdownToFile writes the generated markdown to a file
 func SaveMarkdownToFile(issues []model.Issue, filename string) error {
+	return SaveBrandedMarkdownToFile(issues, filename, Branding{})
+}
+
+// SaveBrandedMarkdownToFile writes the markdown report with branding. A
+// local logo is linked relative to the report.
+func SaveBrandedMarkdownToFile(issues []model.Issue, filename string, brand Branding) error {
 	// Make a copy to avoid mutating the caller's slice
 	issuesCopy := make([]model.Issue, len(issues))
 	copy(issuesCopy, issues)
@@ -297,7 +341,17 @@ func SaveMarkdownToFile(issues []model.Issue, filename string) error {
 		return issuesCopy[i].CreatedAt.After(issuesCopy[j].CreatedAt)
 	})
 
-	content, err := GenerateMarkdown(issuesCopy, "Beads Export")
+	if brand.Logo != "" && !isRemoteLogo(brand.Logo) {
+		if logo, err := filepath.Abs(brand.Logo); err == nil {
+			if dir, err := filepath.Abs(filepath.Dir(filename)); err == nil {
+				if rel, err := filepath.Rel(dir, logo); err == nil {
+					brand.Logo = rel
+				}
+			}
+		}
+	}
+
+	content, err := GenerateBrandedMarkdown(issuesCopy, brand)
 	if err != nil {
 		return err
 	}
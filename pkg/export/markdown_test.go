@@ -1516,3 +1516,26 @@ func TestGenerateMarkdown_ClosedIssueNoCommands(t *testing.T) {
 		t.Error("Closed issue should not have command snippets")
 	}
 }
+
+func TestGenerateMarkdown_Attachments(t *testing.T) {
+	issues := []model.Issue{{
+		ID:        "ATT-1",
+		Title:     "With links",
+		Status:    model.StatusOpen,
+		IssueType: model.TypeTask,
+		Attachments: []*model.Attachment{
+			{URL: "https://example.com/spec", Title: "Spec"},
+			{URL: "docs/trace.log"},
+		},
+	}}
+
+	md, err := GenerateMarkdown(issues, "Links")
+	if err != nil {
+		t.Fatalf("GenerateMarkdown returned error: %v", err)
+	}
+	for _, want := range []string{"### Attachments", "- [Spec](https://example.com/spec)", "- [docs/trace.log](docs/trace.log)"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected %q in output", want)
+		}
+	}
+}
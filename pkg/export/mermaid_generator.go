@@ -14,6 +14,16 @@ type MermaidConfig struct {
 	ShowNoDependenciesNode bool // If true, adds a "No Dependencies" node when no edges exist
 }
 
+// GenerateMermaid generates a standalone Mermaid diagram of the part of the
+// dependency graph opts selects.
+func GenerateMermaid(issues []model.Issue, opts DiagramOptions) (string, error) {
+	selected, err := SelectDiagramIssues(issues, opts)
+	if err != nil {
+		return "", err
+	}
+	return GenerateMermaidGraph(selected, diagramIssueIDs(selected), MermaidConfig{ShowNoDependenciesNode: true}), nil
+}
+
 // GenerateMermaidGraph generates a Mermaid diagram for the given issues.
 func GenerateMermaidGraph(issues []model.Issue, issueIDs map[string]bool, config MermaidConfig) string {
 	var sb strings.Builder
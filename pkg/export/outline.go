@@ -0,0 +1,192 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// OutlineFormat picks how the epic hierarchy is written.
+type OutlineFormat string
+
+const (
+	// OutlineMarkdown is an indented Markdown task list.
+	OutlineMarkdown OutlineFormat = "markdown"
+	// OutlineOPML is OPML 2.0, which outliners and mind-mapping tools import.
+	OutlineOPML OutlineFormat = "opml"
+)
+
+// ParseOutlineFormat parses a format name. "" picks the format from the
+// extension of path: OPML for .opml, Markdown otherwise.
+func ParseOutlineFormat(name, path string) (OutlineFormat, error) {
+	switch format := OutlineFormat(strings.ToLower(strings.TrimSpace(name))); format {
+	case "":
+		if strings.EqualFold(filepath.Ext(path), ".opml") {
+			return OutlineOPML, nil
+		}
+		return OutlineMarkdown, nil
+	case "md":
+		return OutlineMarkdown, nil
+	case OutlineMarkdown, OutlineOPML:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown outline format %q (use markdown or opml)", name)
+}
+
+// outlineNode is an issue with the issues whose parent it is
+type outlineNode struct {
+	Issue    model.Issue
+	Children []*outlineNode
+}
+
+// buildOutline arranges issues by their parent-child dependencies. Issues
+// without a parent among issues are the roots. An issue with several parents
+// goes under the first by ID; on a cycle, the issue with the lowest ID
+// becomes a root.
+func buildOutline(issues []model.Issue) []*outlineNode {
+	sorted := sortIssuesByID(issues)
+	nodes := make(map[string]*outlineNode, len(sorted))
+	for _, issue := range sorted {
+		nodes[issue.ID] = &outlineNode{Issue: issue}
+	}
+	parentOf := make(map[string]string)
+	for _, issue := range sorted {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild || dep.DependsOnID == issue.ID || nodes[dep.DependsOnID] == nil {
+				continue
+			}
+			if current, ok := parentOf[issue.ID]; !ok || dep.DependsOnID < current {
+				parentOf[issue.ID] = dep.DependsOnID
+			}
+		}
+	}
+
+	// sorted is in ID order, so children end up in ID order too
+	children := make(map[string][]string)
+	for _, issue := range sorted {
+		if parent, ok := parentOf[issue.ID]; ok {
+			children[parent] = append(children[parent], issue.ID)
+		}
+	}
+
+	placed := make(map[string]bool, len(sorted))
+	var attach func(node *outlineNode)
+	attach = func(node *outlineNode) {
+		placed[node.Issue.ID] = true
+		for _, id := range children[node.Issue.ID] {
+			if !placed[id] {
+				node.Children = append(node.Children, nodes[id])
+				attach(nodes[id])
+			}
+		}
+	}
+
+	var roots []*outlineNode
+	for _, issue := range sorted {
+		if _, ok := parentOf[issue.ID]; !ok {
+			roots = append(roots, nodes[issue.ID])
+			attach(nodes[issue.ID])
+		}
+	}
+	for _, issue := range sorted {
+		if !placed[issue.ID] {
+			roots = append(roots, nodes[issue.ID])
+			attach(nodes[issue.ID])
+		}
+	}
+	return roots
+}
+
+// GenerateOutline writes the parent-child hierarchy of issues as an outline
+// in format, each entry annotated with its status, priority and type.
+func GenerateOutline(issues []model.Issue, format OutlineFormat, title string, now time.Time) string {
+	if title == "" {
+		title = "Beads Outline"
+	}
+	roots := buildOutline(issues)
+	if format == OutlineOPML {
+		return generateOPML(roots, title, now)
+	}
+	return generateMarkdownOutline(roots, title)
+}
+
+func generateMarkdownOutline(roots []*outlineNode, title string) string {
+	var sb strings.Builder
+	sb.WriteString("# " + title + "\n\n")
+	var walk func(node *outlineNode, depth int)
+	walk = func(node *outlineNode, depth int) {
+		i := node.Issue
+		box := " "
+		if i.Status.IsClosed() {
+			box = "x"
+		}
+		sb.WriteString(fmt.Sprintf("%s- [%s] **%s** %s — %s %s · %s · %s\n",
+			strings.Repeat("  ", depth), box, i.ID, outlineTitle(i.Title),
+			getStatusEmoji(string(i.Status)), i.Status, getPriorityLabel(i.Priority), i.IssueType))
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return sb.String()
+}
+
+func generateOPML(roots []*outlineNode, title string, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(`<opml version="2.0">` + "\n")
+	sb.WriteString("  <head>\n")
+	sb.WriteString("    <title>" + html.EscapeString(title) + "</title>\n")
+	sb.WriteString("    <dateCreated>" + now.UTC().Format(time.RFC1123Z) + "</dateCreated>\n")
+	sb.WriteString("  </head>\n")
+	sb.WriteString("  <body>\n")
+	var walk func(node *outlineNode, depth int)
+	walk = func(node *outlineNode, depth int) {
+		i := node.Issue
+		indent := strings.Repeat("  ", depth+2)
+		text := fmt.Sprintf("%s %s [%s]", i.ID, outlineTitle(i.Title), i.Status)
+		sb.WriteString(fmt.Sprintf(`%s<outline text="%s" _id="%s" _status="%s" _priority="%d" _type="%s"`,
+			indent, html.EscapeString(text), html.EscapeString(i.ID), i.Status, i.Priority, html.EscapeString(string(i.IssueType))))
+		if i.Status.IsClosed() {
+			sb.WriteString(` _complete="true"`)
+		}
+		if note := strings.TrimSpace(i.Description); note != "" {
+			sb.WriteString(` _note="` + opmlAttr(note) + `"`)
+		}
+		if len(node.Children) == 0 {
+			sb.WriteString("/>\n")
+			return
+		}
+		sb.WriteString(">\n")
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+		sb.WriteString(indent + "</outline>\n")
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	sb.WriteString("  </body>\n")
+	sb.WriteString("</opml>\n")
+	return sb.String()
+}
+
+// outlineTitle puts a title on one line
+func outlineTitle(title string) string {
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// opmlAttr escapes an attribute value, keeping line breaks as character
+// references so they survive attribute normalization
+func opmlAttr(value string) string {
+	value = html.EscapeString(value)
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	value = strings.ReplaceAll(value, "\n", "&#10;")
+	return strings.ReplaceAll(value, "\t", "&#9;")
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func outlineIssues() []model.Issue {
+	epic := *makeTestIssue("ol-1", "Epic", model.StatusOpen, 1, model.TypeEpic)
+	child := *makeTestIssue("ol-2", "Child & \"quoted\"", model.StatusClosed, 2, model.TypeTask)
+	child.Dependencies = []*model.Dependency{{IssueID: "ol-2", DependsOnID: "ol-1", Type: model.DepParentChild}}
+	grandchild := *makeTestIssue("ol-3", "Grandchild", model.StatusInProgress, 2, model.TypeTask)
+	grandchild.Description = "line one\nline two"
+	grandchild.Dependencies = []*model.Dependency{
+		{IssueID: "ol-3", DependsOnID: "ol-2", Type: model.DepParentChild},
+		{IssueID: "ol-3", DependsOnID: "ol-4", Type: model.DepBlocks},
+	}
+	loose := *makeTestIssue("ol-4", "Loose", model.StatusOpen, 3, model.TypeBug)
+	return []model.Issue{grandchild, loose, child, epic}
+}
+
+func TestGenerateOutline_Markdown(t *testing.T) {
+	md := GenerateOutline(outlineIssues(), OutlineMarkdown, "", time.Now())
+	lines := strings.Split(strings.TrimSpace(md), "\n")
+	want := []string{
+		"# Beads Outline",
+		"",
+		"- [ ] **ol-1** Epic — 🟢 open",
+		"  - [x] **ol-2** Child & \"quoted\" — ⚫ closed",
+		"    - [ ] **ol-3** Grandchild — 🔵 in_progress",
+		"- [ ] **ol-4** Loose — 🟢 open",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("outline has %d lines, want %d:\n%s", len(lines), len(want), md)
+	}
+	for n, prefix := range want {
+		if !strings.HasPrefix(lines[n], prefix) {
+			t.Errorf("line %d = %q, want prefix %q", n, lines[n], prefix)
+		}
+	}
+}
+
+func TestGenerateOutline_OPML(t *testing.T) {
+	out := GenerateOutline(outlineIssues(), OutlineOPML, "Plan", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	type outline struct {
+		Text     string    `xml:"text,attr"`
+		Status   string    `xml:"_status,attr"`
+		Note     string    `xml:"_note,attr"`
+		Children []outline `xml:"outline"`
+	}
+	var doc struct {
+		Title string    `xml:"head>title"`
+		Body  []outline `xml:"body>outline"`
+	}
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("OPML does not parse: %v\n%s", err, out)
+	}
+	if doc.Title != "Plan" || len(doc.Body) != 2 {
+		t.Fatalf("title %q with %d top-level outlines", doc.Title, len(doc.Body))
+	}
+	child := doc.Body[0].Children[0]
+	if child.Text != `ol-2 Child & "quoted" [closed]` || child.Status != "closed" {
+		t.Errorf("child outline = %+v", child)
+	}
+	if grandchild := child.Children[0]; grandchild.Note != "line one\nline two" {
+		t.Errorf("note = %q", grandchild.Note)
+	}
+}
+
+func TestBuildOutline_Cycle(t *testing.T) {
+	a := *makeTestIssue("cy-1", "A", model.StatusOpen, 1, model.TypeEpic)
+	a.Dependencies = []*model.Dependency{{IssueID: "cy-1", DependsOnID: "cy-2", Type: model.DepParentChild}}
+	b := *makeTestIssue("cy-2", "B", model.StatusOpen, 1, model.TypeEpic)
+	b.Dependencies = []*model.Dependency{{IssueID: "cy-2", DependsOnID: "cy-1", Type: model.DepParentChild}}
+
+	roots := buildOutline([]model.Issue{b, a})
+	if len(roots) != 1 || roots[0].Issue.ID != "cy-1" || len(roots[0].Children) != 1 || len(roots[0].Children[0].Children) != 0 {
+		t.Errorf("cycle not broken at the lowest ID")
+	}
+}
+
+func TestParseOutlineFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name, path string
+		want       OutlineFormat
+	}{
+		{"", "plan.opml", OutlineOPML},
+		{"", "plan.md", OutlineMarkdown},
+		{"", "-", OutlineMarkdown},
+		{"OPML", "-", OutlineOPML},
+		{"md", "plan.opml", OutlineMarkdown},
+	} {
+		if got, err := ParseOutlineFormat(tc.name, tc.path); err != nil || got != tc.want {
+			t.Errorf("ParseOutlineFormat(%q, %q) = %v, %v", tc.name, tc.path, got, err)
+		}
+	}
+	if _, err := ParseOutlineFormat("freemind", ""); err == nil {
+		t.Error("ParseOutlineFormat accepted an unknown format")
+	}
+}
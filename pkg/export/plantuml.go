@@ -0,0 +1,180 @@
+package export
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// PlantUMLStyle picks the kind of PlantUML diagram.
+type PlantUMLStyle string
+
+const (
+	// PlantUMLComponent draws issues as boxes with dependency arrows.
+	PlantUMLComponent PlantUMLStyle = "component"
+	// PlantUMLWBS draws a work breakdown tree: epics over their children,
+	// and each issue over what it depends on.
+	PlantUMLWBS PlantUMLStyle = "wbs"
+)
+
+// ParsePlantUMLStyle parses a style name; "" is the component style.
+func ParsePlantUMLStyle(name string) (PlantUMLStyle, error) {
+	switch style := PlantUMLStyle(strings.ToLower(strings.TrimSpace(name))); style {
+	case "":
+		return PlantUMLComponent, nil
+	case PlantUMLComponent, PlantUMLWBS:
+		return style, nil
+	}
+	return "", fmt.Errorf("unknown PlantUML style %q (use component or wbs)", name)
+}
+
+// plantUMLStatusColors match the Mermaid diagram's status classes
+var plantUMLStatusColors = map[model.Status]string{
+	model.StatusOpen:       "#50FA7B",
+	model.StatusInProgress: "#8BE9FD",
+	model.StatusBlocked:    "#FF5555",
+	model.StatusClosed:     "#6272A4",
+}
+
+var plantUMLAliasRegex = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// GeneratePlantUML generates a PlantUML diagram of the part of the
+// dependency graph opts selects.
+func GeneratePlantUML(issues []model.Issue, opts DiagramOptions, style PlantUMLStyle) (string, error) {
+	selected, err := SelectDiagramIssues(issues, opts)
+	if err != nil {
+		return "", err
+	}
+	sorted := sortIssuesByID(selected)
+	if style == PlantUMLWBS {
+		return generatePlantUMLWBS(sorted, opts.Root), nil
+	}
+	return generatePlantUMLComponents(sorted), nil
+}
+
+func generatePlantUMLComponents(issues []model.Issue) string {
+	issueIDs := diagramIssueIDs(issues)
+	aliases := make(map[string]string, len(issues))
+	used := make(map[string]bool, len(issues))
+	for _, i := range issues {
+		alias := "issue_" + plantUMLAliasRegex.ReplaceAllString(i.ID, "_")
+		if used[alias] {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(i.ID))
+			alias = fmt.Sprintf("%s_%x", alias, h.Sum32())
+		}
+		used[alias] = true
+		aliases[i.ID] = alias
+	}
+
+	var sb strings.Builder
+	sb.WriteString("@startuml\n")
+	sb.WriteString("skinparam componentStyle rectangle\n")
+	sb.WriteString("' Arrows point from an issue to what it depends on; bold arrows are blockers\n\n")
+
+	for _, i := range issues {
+		sb.WriteString(fmt.Sprintf("component \"%s\\n%s\" as %s", plantUMLText(i.ID), plantUMLText(i.Title), aliases[i.ID]))
+		if color, ok := plantUMLStatusColors[i.Status]; ok {
+			sb.WriteString(" " + color)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	for _, i := range issues {
+		for _, edge := range adjacencyEdges(i, issueIDs) {
+			arrow := "..>"
+			if edge.Type == "blocks" {
+				arrow = "-[bold]->"
+			}
+			sb.WriteString(fmt.Sprintf("%s %s %s\n", aliases[edge.From], arrow, aliases[edge.To]))
+		}
+	}
+
+	sb.WriteString("@enduml\n")
+	return sb.String()
+}
+
+// generatePlantUMLWBS nests each issue under the issue that depends on it,
+// except that children go under their parent. An issue reached again is
+// listed without its subtree. issues must be sorted by ID.
+func generatePlantUMLWBS(issues []model.Issue, root string) string {
+	issueIDs := diagramIssueIDs(issues)
+	children := make(map[string][]string)
+	hasParent := make(map[string]bool)
+	for _, i := range issues {
+		for _, dep := range i.Dependencies {
+			if dep == nil || !issueIDs[dep.DependsOnID] || dep.DependsOnID == i.ID {
+				continue
+			}
+			parent, child := i.ID, dep.DependsOnID
+			if dep.Type == model.DepParentChild {
+				parent, child = dep.DependsOnID, i.ID
+			}
+			children[parent] = append(children[parent], child)
+			hasParent[child] = true
+		}
+	}
+	byID := make(map[string]model.Issue, len(issues))
+	for _, i := range issues {
+		byID[i.ID] = i
+	}
+
+	var sb strings.Builder
+	sb.WriteString("@startwbs\n")
+	sb.WriteString("* Issues\n")
+
+	expanded := make(map[string]bool)
+	var walk func(id string, depth int)
+	walk = func(id string, depth int) {
+		i := byID[id]
+		label := plantUMLText(i.ID) + " " + plantUMLText(i.Title)
+		if expanded[id] {
+			sb.WriteString(fmt.Sprintf("%s %s (see above)\n", strings.Repeat("*", depth), label))
+			return
+		}
+		expanded[id] = true
+		marker := strings.Repeat("*", depth)
+		if color, ok := plantUMLStatusColors[i.Status]; ok {
+			marker += "[" + color + "]"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", marker, label))
+		kids := children[id]
+		sort.Strings(kids)
+		for n, kid := range kids {
+			if n > 0 && kids[n-1] == kid {
+				continue
+			}
+			walk(kid, depth+1)
+		}
+	}
+
+	if _, ok := byID[root]; ok {
+		walk(root, 2)
+	}
+	for _, i := range issues {
+		if !hasParent[i.ID] && !expanded[i.ID] {
+			walk(i.ID, 2)
+		}
+	}
+	// Whatever is left sits on a cycle
+	for _, i := range issues {
+		if !expanded[i.ID] {
+			walk(i.ID, 2)
+		}
+	}
+
+	sb.WriteString("@endwbs\n")
+	return sb.String()
+}
+
+// plantUMLText makes text safe inside a quoted PlantUML label
+func plantUMLText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.ReplaceAll(text, `"`, "'")
+	return strings.ReplaceAll(text, `\`, "/")
+}
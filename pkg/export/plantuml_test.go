@@ -0,0 +1,92 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestGeneratePlantUML_Component(t *testing.T) {
+	diagram, err := GeneratePlantUML(diagramIssues(), DiagramOptions{Root: "bv-2", Depth: 1}, PlantUMLComponent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(diagram, "@startuml\n") || !strings.HasSuffix(diagram, "@enduml\n") {
+		t.Errorf("diagram is not wrapped in @startuml/@enduml:\n%s", diagram)
+	}
+	for _, want := range []string{
+		`component "bv-2\nDone step" as issue_bv_2 #6272A4`,
+		`component "bv-3\nNext step" as issue_bv_3 #8BE9FD`,
+		"issue_bv_2 -[bold]-> issue_bv_3",
+	} {
+		if !strings.Contains(diagram, want) {
+			t.Errorf("diagram is missing %q:\n%s", want, diagram)
+		}
+	}
+	if strings.Contains(diagram, "issue_bv_4") {
+		t.Errorf("diagram goes deeper than --depth 1:\n%s", diagram)
+	}
+}
+
+func TestGeneratePlantUML_WBS(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Epic", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Child \"A\"", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepParentChild},
+			{IssueID: "bv-2", DependsOnID: "bv-4", Type: model.DepBlocks},
+		}},
+		{ID: "bv-3", Title: "Child B", Status: model.StatusClosed, Dependencies: []*model.Dependency{
+			{IssueID: "bv-3", DependsOnID: "bv-1", Type: model.DepParentChild},
+			{IssueID: "bv-3", DependsOnID: "bv-4", Type: model.DepBlocks},
+		}},
+		{ID: "bv-4", Title: "Shared blocker", Status: model.StatusBlocked},
+	}
+	diagram, err := GeneratePlantUML(issues, DiagramOptions{}, PlantUMLWBS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"@startwbs",
+		"* Issues",
+		"**[#50FA7B] bv-1 Epic",
+		"***[#50FA7B] bv-2 Child 'A'",
+		"****[#FF5555] bv-4 Shared blocker",
+		"***[#6272A4] bv-3 Child B",
+		"**** bv-4 Shared blocker (see above)",
+		"@endwbs",
+		"",
+	}, "\n")
+	if diagram != want {
+		t.Errorf("WBS =\n%s\nwant\n%s", diagram, want)
+	}
+}
+
+func TestGeneratePlantUML_WBSCycle(t *testing.T) {
+	dep := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "a", Title: "A", Dependencies: dep("a", "b")},
+		{ID: "b", Title: "B", Dependencies: dep("b", "a")},
+	}
+	diagram, err := GeneratePlantUML(issues, DiagramOptions{}, PlantUMLWBS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diagram, "** a A\n*** b B\n**** a A (see above)\n") {
+		t.Errorf("cycle not broken:\n%s", diagram)
+	}
+}
+
+func TestParsePlantUMLStyle(t *testing.T) {
+	if style, err := ParsePlantUMLStyle(""); err != nil || style != PlantUMLComponent {
+		t.Errorf("default style = %q, %v", style, err)
+	}
+	if style, err := ParsePlantUMLStyle("WBS"); err != nil || style != PlantUMLWBS {
+		t.Errorf("WBS = %q, %v", style, err)
+	}
+	if _, err := ParsePlantUMLStyle("sequence"); err == nil {
+		t.Error("ParsePlantUMLStyle() accepted an unknown style")
+	}
+}
@@ -0,0 +1,284 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements "bv serve": a small read-only HTTP server with a JSON
+// API over the live issue data and a minimal embedded web UI, for teammates
+// who do not use the terminal. API payloads reuse the SQLite export structs.
+package export
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+//go:embed serve_ui.html
+var serveUIPage string
+
+// DefaultServePort is the default port for bv serve.
+const DefaultServePort = 8080
+
+// serveRefreshInterval is how often the server re-reads issues on demand.
+const serveRefreshInterval = 2 * time.Second
+
+// serveSnapshot is the analysed form of one version of the issue data.
+type serveSnapshot struct {
+	dataHash string
+	loadedAt time.Time
+	raw      []model.Issue
+	byID     map[string]*model.Issue
+	issues   []ExportIssue
+	metrics  []ExportMetrics
+	triage   analysis.TriageResult
+	depCount int
+}
+
+// Server serves issues, metrics and triage read-only over HTTP.
+type Server struct {
+	addr  string
+	title string
+	load  func() ([]model.Issue, error)
+
+	mu       sync.Mutex
+	snapshot *serveSnapshot
+	checked  time.Time
+
+	server *http.Server
+}
+
+// NewServer creates a server listening on addr. load is called to (re)read
+// the issues; results are cached and re-analysed only when the data changes.
+func NewServer(addr, title string, load func() ([]model.Issue, error)) *Server {
+	if title == "" {
+		title = "Beads Viewer"
+	}
+	return &Server{addr: addr, title: title, load: load}
+}
+
+// Handler returns the HTTP handler with the API and web UI routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /api/meta", s.handleMeta)
+	mux.HandleFunc("GET /api/issues", s.handleIssues)
+	mux.HandleFunc("GET /api/issues/{id}", s.handleIssue)
+	mux.HandleFunc("GET /api/metrics", s.handleMetrics)
+	mux.HandleFunc("GET /api/triage", s.handleTriage)
+	return mux
+}
+
+// ListenAndServe runs the server until interrupted, then shuts down cleanly.
+func (s *Server) ListenAndServe() error {
+	// Analyse once up front so the first request is fast and errors surface early
+	if _, err := s.current(); err != nil {
+		return err
+	}
+
+	s.server = &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- err
+		}
+	}()
+
+	select {
+	case <-stop:
+		fmt.Println("\nShutting down server...")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return s.server.Shutdown(ctx)
+	case err := <-errChan:
+		return err
+	}
+}
+
+// current returns the latest snapshot, reloading the issues at most once
+// per serveRefreshInterval. If a reload fails the previous snapshot is kept.
+func (s *Server) current() (*serveSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshot != nil && time.Since(s.checked) < serveRefreshInterval {
+		return s.snapshot, nil
+	}
+	s.checked = time.Now()
+
+	issues, err := s.load()
+	if err != nil {
+		if s.snapshot != nil {
+			fmt.Fprintf(os.Stderr, "Warning: reloading issues failed, serving previous data: %v\n", err)
+			return s.snapshot, nil
+		}
+		return nil, fmt.Errorf("load issues: %w", err)
+	}
+	hash := analysis.ComputeDataHash(issues)
+	if s.snapshot != nil && s.snapshot.dataHash == hash {
+		return s.snapshot, nil
+	}
+	s.snapshot = buildServeSnapshot(issues, hash)
+	return s.snapshot, nil
+}
+
+// buildServeSnapshot runs the graph analysis and triage over issues.
+func buildServeSnapshot(issues []model.Issue, hash string) *serveSnapshot {
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	triage := analysis.ComputeTriage(issues)
+
+	snap := &serveSnapshot{
+		dataHash: hash,
+		loadedAt: time.Now().UTC(),
+		raw:      issues,
+		byID:     make(map[string]*model.Issue, len(issues)),
+		triage:   triage,
+	}
+	ptrs := make([]*model.Issue, len(issues))
+	var deps []*model.Dependency
+	for i := range issues {
+		ptrs[i] = &issues[i]
+		snap.byID[issues[i].ID] = &issues[i]
+		for _, dep := range issues[i].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			deps = append(deps, &model.Dependency{
+				IssueID:     issues[i].ID,
+				DependsOnID: dep.DependsOnID,
+				Type:        dep.Type,
+			})
+		}
+	}
+	snap.depCount = len(deps)
+
+	snap.issues = NewSQLiteExporter(ptrs, deps, &stats, &triage).GetExportedIssues()
+	snap.metrics = make([]ExportMetrics, len(snap.issues))
+	for i, issue := range snap.issues {
+		snap.metrics[i] = ExportMetrics{
+			IssueID:        issue.ID,
+			PageRank:       issue.PageRank,
+			Betweenness:    issue.Betweenness,
+			CriticalPath:   issue.CriticalPath,
+			TriageScore:    issue.TriageScore,
+			BlocksCount:    issue.BlocksCount,
+			BlockedByCount: issue.BlockedByCount,
+		}
+	}
+	return snap
+}
+
+// handleIndex serves the embedded web UI.
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, strings.ReplaceAll(serveUIPage, "{{TITLE}}", html.EscapeString(s.title)))
+}
+
+// handleMeta returns export metadata for the current data.
+func (s *Server) handleMeta(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshotOrError(w)
+	if !ok {
+		return
+	}
+	writeServeJSON(w, http.StatusOK, ExportMeta{
+		Version:     "1.0.0",
+		GeneratedAt: snap.loadedAt,
+		IssueCount:  len(snap.issues),
+		DepCount:    snap.depCount,
+		DataHash:    snap.dataHash,
+		Title:       s.title,
+	})
+}
+
+// handleIssues returns all issues, or those matching ?filter= (see ParseFilter).
+func (s *Server) handleIssues(w http.ResponseWriter, r *http.Request) {
+	filter, err := ParseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		writeServeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	snap, ok := s.snapshotOrError(w)
+	if !ok {
+		return
+	}
+	result := make([]ExportIssue, 0, len(snap.issues))
+	for i, issue := range snap.issues {
+		if filter.Match(snap.raw[i], snap.byID) {
+			result = append(result, issue)
+		}
+	}
+	writeServeJSON(w, http.StatusOK, result)
+}
+
+// handleIssue returns a single issue by ID.
+func (s *Server) handleIssue(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshotOrError(w)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	for _, issue := range snap.issues {
+		if issue.ID == id {
+			writeServeJSON(w, http.StatusOK, issue)
+			return
+		}
+	}
+	writeServeJSON(w, http.StatusNotFound, map[string]string{"error": "issue not found: " + id})
+}
+
+// handleMetrics returns graph metrics for every issue.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshotOrError(w)
+	if !ok {
+		return
+	}
+	writeServeJSON(w, http.StatusOK, snap.metrics)
+}
+
+// handleTriage returns the triage result (same shape as --robot-triage's triage).
+func (s *Server) handleTriage(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshotOrError(w)
+	if !ok {
+		return
+	}
+	writeServeJSON(w, http.StatusOK, snap.triage)
+}
+
+// snapshotOrError returns the current snapshot, writing a 500 on failure.
+func (s *Server) snapshotOrError(w http.ResponseWriter) (*serveSnapshot, bool) {
+	snap, err := s.current()
+	if err != nil {
+		writeServeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return nil, false
+	}
+	return snap, true
+}
+
+// writeServeJSON writes v as indented JSON with the given status code.
+func writeServeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	issues := []model.Issue{
+		*makeTestIssue("s-1", "Root task", model.StatusOpen, 1, model.TypeTask),
+		*makeTestIssue("s-2", "Blocked bug", model.StatusOpen, 0, model.TypeBug),
+		*makeTestIssue("s-3", "Done", model.StatusClosed, 2, model.TypeTask),
+	}
+	issues[1].Dependencies = []*model.Dependency{{IssueID: "s-2", DependsOnID: "s-1", Type: model.DepBlocks}}
+
+	srv := httptest.NewServer(NewServer("", "My <Project>", func() ([]model.Issue, error) {
+		return issues, nil
+	}).Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getServeJSON(t *testing.T, url string, wantStatus int, v any) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("GET %s: status %d, want %d", url, resp.StatusCode, wantStatus)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s: decode: %v", url, err)
+		}
+	}
+}
+
+func TestServer_Issues(t *testing.T) {
+	srv := newTestServer(t)
+
+	var all []ExportIssue
+	getServeJSON(t, srv.URL+"/api/issues", http.StatusOK, &all)
+	if len(all) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(all))
+	}
+
+	var open []ExportIssue
+	getServeJSON(t, srv.URL+"/api/issues?filter=open+type:bug", http.StatusOK, &open)
+	if len(open) != 1 || open[0].ID != "s-2" {
+		t.Errorf("expected only s-2, got %+v", open)
+	}
+	getServeJSON(t, srv.URL+"/api/issues?filter=bogus:x", http.StatusBadRequest, nil)
+
+	var one ExportIssue
+	getServeJSON(t, srv.URL+"/api/issues/s-2", http.StatusOK, &one)
+	if one.Title != "Blocked bug" || len(one.BlockedByIDs) != 1 || one.BlockedByIDs[0] != "s-1" {
+		t.Errorf("unexpected issue %+v", one)
+	}
+	getServeJSON(t, srv.URL+"/api/issues/nope", http.StatusNotFound, nil)
+}
+
+func TestServer_MetricsTriageMeta(t *testing.T) {
+	srv := newTestServer(t)
+
+	var metrics []ExportMetrics
+	getServeJSON(t, srv.URL+"/api/metrics", http.StatusOK, &metrics)
+	if len(metrics) != 3 {
+		t.Errorf("expected metrics for 3 issues, got %d", len(metrics))
+	}
+
+	var triage struct {
+		Recommendations []struct {
+			ID string `json:"id"`
+		} `json:"recommendations"`
+	}
+	getServeJSON(t, srv.URL+"/api/triage", http.StatusOK, &triage)
+	if len(triage.Recommendations) == 0 {
+		t.Error("expected triage recommendations")
+	}
+
+	var meta ExportMeta
+	getServeJSON(t, srv.URL+"/api/meta", http.StatusOK, &meta)
+	if meta.IssueCount != 3 || meta.DepCount != 1 || meta.DataHash == "" {
+		t.Errorf("unexpected meta %+v", meta)
+	}
+}
+
+func TestServer_IndexAndReadOnly(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "<title>My &lt;Project&gt;</title>") {
+		t.Errorf("index page missing escaped title (status %d)", resp.StatusCode)
+	}
+
+	resp, err = http.Post(srv.URL+"/api/issues", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST should be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_KeepsSnapshotOnReloadError(t *testing.T) {
+	calls := 0
+	s := NewServer("", "", func() ([]model.Issue, error) {
+		calls++
+		if calls > 1 {
+			return nil, errors.New("file vanished")
+		}
+		return []model.Issue{*makeTestIssue("k-1", "Kept", model.StatusOpen, 1, model.TypeTask)}, nil
+	})
+	if _, err := s.current(); err != nil {
+		t.Fatal(err)
+	}
+	s.checked = s.checked.Add(-serveRefreshInterval)
+	snap, err := s.current()
+	if err != nil || len(snap.issues) != 1 || calls != 2 {
+		t.Errorf("expected previous snapshot after failed reload, got %v (calls %d)", err, calls)
+	}
+}
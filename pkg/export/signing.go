@@ -0,0 +1,278 @@
+// Package export provides data export functionality for bv.
+//
+// This file signs the chunk manifest of a static export so that whoever
+// downloads a published dashboard can check it came from the key holder.
+package export
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ManifestFileName is the chunk manifest written next to beads.sqlite3.
+const ManifestFileName = "beads.sqlite3.config.json"
+
+// ManifestSignatureFileName holds the minisign signature of the manifest.
+const ManifestSignatureFileName = ManifestFileName + ".minisig"
+
+// SigningKey is an Ed25519 key that signs export manifests.
+type SigningKey struct {
+	ID      [8]byte
+	Private ed25519.PrivateKey
+}
+
+// VerifyKey is the public half of a SigningKey.
+type VerifyKey struct {
+	ID     [8]byte
+	Public ed25519.PublicKey
+}
+
+// KeyID formats a key ID the way minisign prints it.
+func KeyID(id [8]byte) string {
+	return fmt.Sprintf("%016X", binary.LittleEndian.Uint64(id[:]))
+}
+
+// LoadSigningKey reads a minisign secret key (created with `minisign -G -W`,
+// i.e. without a password) or a PEM PKCS#8 Ed25519 key (`openssl genpkey
+// -algorithm ed25519`).
+func LoadSigningKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing key %s: %w", path, err)
+		}
+		priv, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("signing key %s is not an Ed25519 key", path)
+		}
+		return &SigningKey{ID: pemKeyID(priv.Public().(ed25519.PublicKey)), Private: priv}, nil
+	}
+
+	raw, err := minisignPayload(data)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", path, err)
+	}
+	// sig_alg(2) kdf_alg(2) chk_alg(2) salt(32) opslimit(8) memlimit(8)
+	// key_id(8) secret_key(64) checksum(32)
+	if len(raw) != 158 || string(raw[:2]) != "Ed" {
+		return nil, fmt.Errorf("signing key %s is not a minisign secret key", path)
+	}
+	if raw[2] != 0 || raw[3] != 0 {
+		return nil, fmt.Errorf("signing key %s is password protected; create one with minisign -G -W", path)
+	}
+	key := &SigningKey{Private: ed25519.PrivateKey(append([]byte{}, raw[62:126]...))}
+	copy(key.ID[:], raw[54:62])
+	if !bytes.Equal(ed25519.NewKeyFromSeed(key.Private.Seed()), key.Private) {
+		return nil, fmt.Errorf("signing key %s is corrupt", path)
+	}
+	return key, nil
+}
+
+// LoadVerifyKey reads a minisign public key file, a PEM Ed25519 public key,
+// or the base64 minisign public key itself (as given to `minisign -P`).
+func LoadVerifyKey(pathOrKey string) (*VerifyKey, error) {
+	data, err := os.ReadFile(pathOrKey)
+	if os.IsNotExist(err) && !strings.ContainsAny(pathOrKey, `/\`) {
+		data, err = []byte(pathOrKey), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read public key: %w", err)
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key: %w", err)
+		}
+		pub, ok := parsed.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key is not an Ed25519 key")
+		}
+		return &VerifyKey{ID: pemKeyID(pub), Public: pub}, nil
+	}
+
+	raw, err := minisignPayload(data)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	if len(raw) != 42 || string(raw[:2]) != "Ed" {
+		return nil, fmt.Errorf("not a minisign public key")
+	}
+	key := &VerifyKey{Public: ed25519.PublicKey(append([]byte{}, raw[10:]...))}
+	copy(key.ID[:], raw[2:10])
+	return key, nil
+}
+
+// SignManifest writes a minisign signature of the export's manifest to
+// ManifestSignatureFileName, so `minisign -Vm beads.sqlite3.config.json -p
+// key.pub` (or bv --verify-pages) can check it. The manifest pins the hash
+// of the database and of every chunk, so the signature covers them too.
+func SignManifest(outputDir string, key *SigningKey) error {
+	manifest, err := os.ReadFile(filepath.Join(outputDir, ManifestFileName))
+	if err != nil {
+		return fmt.Errorf("read manifest: %w", err)
+	}
+	sig := ed25519.Sign(key.Private, manifest)
+	trusted := fmt.Sprintf("timestamp:%d\tfile:%s", time.Now().Unix(), ManifestFileName)
+	global := ed25519.Sign(key.Private, append(append([]byte{}, sig...), trusted...))
+
+	raw := append([]byte("Ed"), key.ID[:]...)
+	raw = append(raw, sig...)
+	var out strings.Builder
+	fmt.Fprintf(&out, "untrusted comment: signature from bv secret key %s\n", KeyID(key.ID))
+	fmt.Fprintf(&out, "%s\n", base64.StdEncoding.EncodeToString(raw))
+	fmt.Fprintf(&out, "trusted comment: %s\n", trusted)
+	fmt.Fprintf(&out, "%s\n", base64.StdEncoding.EncodeToString(global))
+	return os.WriteFile(filepath.Join(outputDir, ManifestSignatureFileName), []byte(out.String()), 0644)
+}
+
+// ManifestVerification describes a successfully verified export.
+type ManifestVerification struct {
+	KeyID          string
+	TrustedComment string
+	Files          int // Database and chunk files whose hashes matched
+}
+
+// VerifyExport checks the manifest signature of the export in dir against
+// key, then checks the database and chunks against the hashes the manifest
+// lists.
+func VerifyExport(dir string, key *VerifyKey) (*ManifestVerification, error) {
+	manifest, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	sigData, err := os.ReadFile(filepath.Join(dir, ManifestSignatureFileName))
+	if err != nil {
+		return nil, fmt.Errorf("read signature: %w", err)
+	}
+	trusted, err := verifyMinisign(manifest, sigData, key)
+	if err != nil {
+		return nil, err
+	}
+	result := &ManifestVerification{KeyID: KeyID(key.ID), TrustedComment: trusted}
+
+	var config ChunkConfig
+	if err := json.Unmarshal(manifest, &config); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if config.Hash == "" {
+		return nil, fmt.Errorf("manifest has no database hash")
+	}
+	dbPath := filepath.Join(dir, "beads.sqlite3")
+	if _, err := os.Stat(dbPath); err == nil || !config.Chunked {
+		if err := checkFileHash(dbPath, config.Hash, config.TotalSize); err != nil {
+			return nil, err
+		}
+		result.Files++
+	}
+	if config.Chunked {
+		if len(config.Chunks) != config.ChunkCount {
+			return nil, fmt.Errorf("manifest lists %d chunks, expected %d", len(config.Chunks), config.ChunkCount)
+		}
+		whole := sha256.New()
+		for _, chunk := range config.Chunks {
+			path := filepath.Join(dir, filepath.FromSlash(chunk.Path))
+			if !filepath.IsLocal(filepath.FromSlash(chunk.Path)) {
+				return nil, fmt.Errorf("chunk path %q leaves the export", chunk.Path)
+			}
+			if err := checkFileHash(path, chunk.Hash, chunk.Size); err != nil {
+				return nil, err
+			}
+			data, _ := os.ReadFile(path)
+			whole.Write(data)
+			result.Files++
+		}
+		if hex.EncodeToString(whole.Sum(nil)) != config.Hash {
+			return nil, fmt.Errorf("chunks do not add up to the database hash")
+		}
+	}
+	return result, nil
+}
+
+// verifyMinisign checks a legacy ("Ed") minisign signature of msg and
+// returns its trusted comment
+func verifyMinisign(msg, sigData []byte, key *VerifyKey) (string, error) {
+	lines := strings.Split(strings.ReplaceAll(string(sigData), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return "", fmt.Errorf("malformed signature file")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != 74 {
+		return "", fmt.Errorf("malformed signature")
+	}
+	if string(raw[:2]) != "Ed" {
+		return "", fmt.Errorf("unsupported signature algorithm %q", raw[:2])
+	}
+	if !bytes.Equal(raw[2:10], key.ID[:]) {
+		var id [8]byte
+		copy(id[:], raw[2:10])
+		return "", fmt.Errorf("signed with key %s, not %s", KeyID(id), KeyID(key.ID))
+	}
+	sig := raw[10:]
+	if !ed25519.Verify(key.Public, msg, sig) {
+		return "", fmt.Errorf("signature does not match the manifest")
+	}
+	trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || !ed25519.Verify(key.Public, append(append([]byte{}, sig...), trusted...), global) {
+		return "", fmt.Errorf("trusted comment signature does not match")
+	}
+	return trusted, nil
+}
+
+// checkFileHash compares a file's SHA-256 (and size, when known) to the
+// manifest
+func checkFileHash(path, want string, size int64) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if size > 0 && int64(len(data)) != size {
+		return fmt.Errorf("%s is %d bytes, manifest says %d", filepath.Base(path), len(data), size)
+	}
+	h := sha256.Sum256(data)
+	if hex.EncodeToString(h[:]) != want {
+		return fmt.Errorf("%s does not match its hash in the manifest", filepath.Base(path))
+	}
+	return nil
+}
+
+// minisignPayload decodes the base64 line of a minisign key file, skipping
+// the untrusted comment
+func minisignPayload(data []byte) ([]byte, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("not a minisign or PEM key")
+		}
+		return raw, nil
+	}
+	return nil, fmt.Errorf("empty key file")
+}
+
+// pemKeyID derives a key ID for PEM keys, which do not carry one
+func pemKeyID(pub ed25519.PublicKey) [8]byte {
+	var id [8]byte
+	h := sha256.Sum256(pub)
+	copy(id[:], h[:8])
+	return id
+}
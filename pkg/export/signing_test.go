@@ -0,0 +1,207 @@
+package export
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// writeMinisignKeys writes an unencrypted minisign key pair to dir and
+// returns the secret and public key paths
+func writeMinisignKeys(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	sk := make([]byte, 158)
+	copy(sk, "Ed\x00\x00B2")
+	copy(sk[54:], id)
+	copy(sk[62:], priv)
+	pk := append(append([]byte("Ed"), id...), pub...)
+
+	skPath := filepath.Join(dir, "bv.key")
+	pkPath := filepath.Join(dir, "bv.pub")
+	if err := os.WriteFile(skPath, []byte("untrusted comment: minisign encrypted secret key\n"+base64.StdEncoding.EncodeToString(sk)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pkPath, []byte("untrusted comment: minisign public key\n"+base64.StdEncoding.EncodeToString(pk)+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return skPath, pkPath
+}
+
+func signedExport(t *testing.T, chunked bool) (string, string) {
+	t.Helper()
+	keyDir := t.TempDir()
+	skPath, pkPath := writeMinisignKeys(t, keyDir)
+
+	outDir := t.TempDir()
+	exp := NewSQLiteExporter([]*model.Issue{
+		makeTestIssue("sig-1", "Signed", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("sig-2", "Also signed", model.StatusClosed, 2, model.TypeBug),
+	}, nil, nil, nil)
+	exp.Config.SigningKey = skPath
+	if chunked {
+		exp.Config.ChunkThreshold = 1
+		exp.Config.ChunkSize = 4096
+	}
+	if err := exp.Export(outDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	return outDir, pkPath
+}
+
+func TestSignedExport_Verifies(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		outDir, pkPath := signedExport(t, chunked)
+		key, err := LoadVerifyKey(pkPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := VerifyExport(outDir, key)
+		if err != nil {
+			t.Fatalf("chunked=%v: VerifyExport() error = %v", chunked, err)
+		}
+		if !strings.Contains(result.TrustedComment, "file:"+ManifestFileName) {
+			t.Errorf("trusted comment = %q", result.TrustedComment)
+		}
+		if chunked && result.Files < 2 {
+			t.Errorf("chunked export verified %d files, want the database and its chunks", result.Files)
+		}
+		if result.KeyID != "0807060504030201" {
+			t.Errorf("KeyID = %s", result.KeyID)
+		}
+	}
+}
+
+func TestSignedExport_DetectsTampering(t *testing.T) {
+	outDir, pkPath := signedExport(t, true)
+	key, err := LoadVerifyKey(pkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := filepath.Join(outDir, "chunks", "00000.bin")
+	data, err := os.ReadFile(chunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)/2] ^= 0xff
+	if err := os.WriteFile(chunk, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyExport(outDir, key); err == nil {
+		t.Error("VerifyExport() accepted a modified chunk")
+	}
+
+	manifest := filepath.Join(outDir, ManifestFileName)
+	data, err = os.ReadFile(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manifest, append(data, ' '), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyExport(outDir, key); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("VerifyExport() on a modified manifest = %v, want a signature error", err)
+	}
+}
+
+func TestSignedExport_WrongKey(t *testing.T) {
+	outDir, _ := signedExport(t, false)
+	_, otherPub := writeMinisignKeys(t, t.TempDir())
+	key, err := LoadVerifyKey(otherPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyExport(outDir, key); err == nil {
+		t.Error("VerifyExport() accepted a signature from another key")
+	}
+}
+
+func TestUnsignedExport_RemovesStaleSignature(t *testing.T) {
+	outDir, _ := signedExport(t, false)
+	exp := NewSQLiteExporter([]*model.Issue{
+		makeTestIssue("sig-1", "Changed", model.StatusOpen, 1, model.TypeTask),
+	}, nil, nil, nil)
+	if err := exp.Export(outDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, ManifestSignatureFileName)); !os.IsNotExist(err) {
+		t.Error("unsigned export left the earlier signature behind")
+	}
+}
+
+func TestLoadKeys_PEM(t *testing.T) {
+	dir := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skPath := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(skPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	der, err = x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkPath := filepath.Join(dir, "key.pub.pem")
+	if err := os.WriteFile(pkPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sk, err := LoadSigningKey(skPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := LoadVerifyKey(pkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk.ID != pk.ID {
+		t.Error("PEM key halves derive different key IDs")
+	}
+
+	outDir := t.TempDir()
+	if err := writeJSON(filepath.Join(outDir, ManifestFileName), ChunkConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SignManifest(outDir, sk); err != nil {
+		t.Fatal(err)
+	}
+	manifest, _ := os.ReadFile(filepath.Join(outDir, ManifestFileName))
+	sig, _ := os.ReadFile(filepath.Join(outDir, ManifestSignatureFileName))
+	if _, err := verifyMinisign(manifest, sig, pk); err != nil {
+		t.Errorf("verifyMinisign() error = %v", err)
+	}
+}
+
+func TestLoadSigningKey_Encrypted(t *testing.T) {
+	dir := t.TempDir()
+	skPath, _ := writeMinisignKeys(t, dir)
+	data, _ := os.ReadFile(skPath)
+	raw, _ := base64.StdEncoding.DecodeString(strings.Split(string(data), "\n")[1])
+	copy(raw[2:4], "Sc")
+	if err := os.WriteFile(skPath, []byte(base64.StdEncoding.EncodeToString(raw)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSigningKey(skPath); err == nil || !strings.Contains(err.Error(), "password") {
+		t.Errorf("LoadSigningKey() on an encrypted key = %v", err)
+	}
+}
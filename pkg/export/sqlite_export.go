@@ -30,6 +30,9 @@ type SQLiteExporter struct {
 	Stats   *analysis.GraphStats
 	Triage  *analysis.TriageResult
 	Config  SQLiteExportConfig
+	// Delta reports what the last Export changed when Config.Incremental
+	// updated an existing database; nil when the database was rebuilt
+	Delta   *IncrementalResult
 	gitHash string
 }
 
@@ -66,6 +69,15 @@ func (e *SQLiteExporter) SetGitHash(hash string) {
 
 // Export writes the SQLite database and supporting files to the output directory.
 func (e *SQLiteExporter) Export(outputDir string) error {
+	var signingKey *SigningKey
+	if e.Config.SigningKey != "" {
+		key, err := LoadSigningKey(e.Config.SigningKey)
+		if err != nil {
+			return err
+		}
+		signingKey = key
+	}
+
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("create output dir: %w", err)
@@ -79,6 +91,58 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 
 	dbPath := filepath.Join(outputDir, "beads.sqlite3")
 
+	e.Delta = nil
+	if e.Config.Incremental {
+		delta, err := e.updateDatabase(dbPath)
+		if err != nil {
+			return fmt.Errorf("incremental update: %w", err)
+		}
+		e.Delta = delta
+	}
+	if e.Delta == nil {
+		if err := e.buildDatabase(dbPath); err != nil {
+			return err
+		}
+	}
+
+	// Write robot JSON outputs
+	if e.Config.IncludeRobotOutputs {
+		if err := e.writeRobotOutputs(dataDir); err != nil {
+			return fmt.Errorf("write robot outputs: %w", err)
+		}
+	}
+
+	// Write pre-computed graph layout for fast client-side rendering
+	if err := e.writeGraphLayout(dataDir); err != nil {
+		return fmt.Errorf("write graph layout: %w", err)
+	}
+
+	// Chunk if needed
+	if err := e.chunkIfNeeded(outputDir, dbPath); err != nil {
+		return fmt.Errorf("chunk database: %w", err)
+	}
+
+	// Sign the manifest, or drop the signature of an earlier export so it
+	// does not linger next to a manifest it no longer matches
+	sigPath := filepath.Join(outputDir, ManifestSignatureFileName)
+	if signingKey == nil {
+		if err := os.Remove(sigPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove stale signature: %w", err)
+		}
+		return nil
+	}
+	if err := SignManifest(outputDir, signingKey); err != nil {
+		return fmt.Errorf("sign manifest: %w", err)
+	}
+
+	return nil
+}
+
+// buildDatabase writes a fresh database at dbPath, replacing any existing one.
+func (e *SQLiteExporter) buildDatabase(dbPath string) error {
+	// Carry the metrics history of the existing database, if any, into the rebuild
+	history := readMetricsHistory(dbPath)
+
 	// Remove existing database if present
 	_ = os.Remove(dbPath)
 
@@ -104,11 +168,26 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 		return fmt.Errorf("insert issues: %w", err)
 	}
 
+	// Record content hashes for later incremental exports
+	if err := e.insertHashes(db); err != nil {
+		return fmt.Errorf("insert hashes: %w", err)
+	}
+
 	// Insert dependencies
 	if err := e.insertDependencies(db); err != nil {
 		return fmt.Errorf("insert dependencies: %w", err)
 	}
 
+	// Insert custom fields
+	if err := e.insertCustomFields(db); err != nil {
+		return fmt.Errorf("insert custom fields: %w", err)
+	}
+
+	// Insert attachments
+	if err := e.insertAttachments(db); err != nil {
+		return fmt.Errorf("insert attachments: %w", err)
+	}
+
 	// Insert metrics
 	if err := e.insertMetrics(db); err != nil {
 		return fmt.Errorf("insert metrics: %w", err)
@@ -119,6 +198,16 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 		return fmt.Errorf("insert triage: %w", err)
 	}
 
+	// Insert transitive dependency closure
+	if err := e.insertDependencyClosure(db); err != nil {
+		return fmt.Errorf("insert dependency closure: %w", err)
+	}
+
+	// Append this export to the metrics history
+	if err := e.recordMetricsHistory(db, history); err != nil {
+		return fmt.Errorf("record metrics history: %w", err)
+	}
+
 	// Create FTS index (modernc.org/sqlite has FTS5 built-in)
 	if err := CreateFTSIndex(db); err != nil {
 		// Defensive: log but continue if FTS5 creation fails for any reason
@@ -151,23 +240,6 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 	}
 	dbClosed = true
 
-	// Write robot JSON outputs
-	if e.Config.IncludeRobotOutputs {
-		if err := e.writeRobotOutputs(dataDir); err != nil {
-			return fmt.Errorf("write robot outputs: %w", err)
-		}
-	}
-
-	// Write pre-computed graph layout for fast client-side rendering
-	if err := e.writeGraphLayout(dataDir); err != nil {
-		return fmt.Errorf("write graph layout: %w", err)
-	}
-
-	// Chunk if needed
-	if err := e.chunkIfNeeded(outputDir, dbPath); err != nil {
-		return fmt.Errorf("chunk database: %w", err)
-	}
-
 	return nil
 }
 
@@ -189,32 +261,7 @@ func (e *SQLiteExporter) insertIssues(db *sql.DB) error {
 	defer stmt.Close()
 
 	for _, issue := range e.Issues {
-		labels := "[]"
-		if len(issue.Labels) > 0 {
-			labelsJSON, _ := json.Marshal(issue.Labels)
-			labels = string(labelsJSON)
-		}
-
-		var closedAt *string
-		if issue.ClosedAt != nil {
-			s := issue.ClosedAt.Format(time.RFC3339)
-			closedAt = &s
-		}
-
-		_, err := stmt.Exec(
-			issue.ID,
-			issue.Title,
-			issue.Description,
-			string(issue.Status),
-			issue.Priority,
-			string(issue.IssueType),
-			issue.Assignee,
-			labels,
-			issue.CreatedAt.Format(time.RFC3339),
-			issue.UpdatedAt.Format(time.RFC3339),
-			closedAt,
-		)
-		if err != nil {
+		if _, err := stmt.Exec(issueRow(issue)...); err != nil {
 			return fmt.Errorf("insert issue %s: %w", issue.ID, err)
 		}
 	}
@@ -222,6 +269,35 @@ func (e *SQLiteExporter) insertIssues(db *sql.DB) error {
 	return tx.Commit()
 }
 
+// issueRow returns the issues table column values for issue, in schema order.
+func issueRow(issue *model.Issue) []any {
+	labels := "[]"
+	if len(issue.Labels) > 0 {
+		labelsJSON, _ := json.Marshal(issue.Labels)
+		labels = string(labelsJSON)
+	}
+
+	var closedAt *string
+	if issue.ClosedAt != nil {
+		s := issue.ClosedAt.Format(time.RFC3339)
+		closedAt = &s
+	}
+
+	return []any{
+		issue.ID,
+		issue.Title,
+		issue.Description,
+		string(issue.Status),
+		issue.Priority,
+		string(issue.IssueType),
+		issue.Assignee,
+		labels,
+		issue.CreatedAt.Format(time.RFC3339),
+		issue.UpdatedAt.Format(time.RFC3339),
+		closedAt,
+	}
+}
+
 // insertDependencies inserts all dependencies into the database.
 func (e *SQLiteExporter) insertDependencies(db *sql.DB) error {
 	tx, err := db.Begin()
@@ -252,6 +328,77 @@ func (e *SQLiteExporter) insertDependencies(db *sql.DB) error {
 	return tx.Commit()
 }
 
+// insertCustomFields inserts the custom fields of all issues.
+func (e *SQLiteExporter) insertCustomFields(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO custom_fields (issue_id, name, value) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, issue := range e.Issues {
+		for _, row := range customFieldRows(issue) {
+			if _, err := stmt.Exec(issue.ID, row[0], row[1]); err != nil {
+				return fmt.Errorf("insert custom field %s of %s: %w", row[0], issue.ID, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertAttachments inserts the attachments of all issues.
+func (e *SQLiteExporter) insertAttachments(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO attachments (issue_id, position, url, title) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, issue := range e.Issues {
+		for pos, a := range issue.Attachments {
+			if a == nil {
+				continue
+			}
+			if _, err := stmt.Exec(issue.ID, pos, a.URL, a.Title); err != nil {
+				return fmt.Errorf("insert attachment %s of %s: %w", a.URL, issue.ID, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// customFieldRows returns (name, value) pairs for the custom_fields table,
+// sorted by name. Strings are stored as is and other values as JSON.
+func customFieldRows(issue *model.Issue) [][2]string {
+	var rows [][2]string
+	for _, name := range issue.CustomFieldNames() {
+		value, isString := issue.CustomFields[name].(string)
+		if !isString {
+			data, err := json.Marshal(issue.CustomFields[name])
+			if err != nil {
+				continue
+			}
+			value = string(data)
+		}
+		rows = append(rows, [2]string{name, value})
+	}
+	return rows
+}
+
 // insertMetrics inserts computed graph metrics for all issues.
 func (e *SQLiteExporter) insertMetrics(db *sql.DB) error {
 	if e.Stats == nil {
@@ -414,6 +561,15 @@ func (e *SQLiteExporter) insertMeta(db *sql.DB) error {
 	if e.Config.Title != "" {
 		meta["title"] = e.Config.Title
 	}
+	if e.Config.Logo != "" {
+		meta["logo"] = viewerLogo(e.Config.Logo)
+	}
+	if e.Config.AccentColor != "" {
+		meta["accent_color"] = e.Config.AccentColor
+	}
+	if e.Config.Footer != "" {
+		meta["footer"] = e.Config.Footer
+	}
 
 	for key, value := range meta {
 		if err := InsertMetaValue(db, key, value); err != nil {
@@ -446,6 +602,9 @@ func (e *SQLiteExporter) writeRobotOutputs(dataDir string) error {
 		IssueCount:  len(e.Issues),
 		DepCount:    len(e.Deps),
 		Title:       e.Config.Title,
+		Logo:        viewerLogo(e.Config.Logo),
+		AccentColor: e.Config.AccentColor,
+		Footer:      e.Config.Footer,
 	}
 	if err := writeJSON(filepath.Join(dataDir, "meta.json"), meta); err != nil {
 		return fmt.Errorf("write meta.json: %w", err)
@@ -461,14 +620,28 @@ func (e *SQLiteExporter) chunkIfNeeded(outputDir, dbPath string) error {
 		return err
 	}
 
+	f, err := os.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Calculate file hash; the manifest always records it so a signed
+	// manifest covers the database whether or not it is chunked
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return fmt.Errorf("hash database: %w", err)
+	}
+
 	// Write chunk config regardless of whether we chunk
 	config := ChunkConfig{
 		TotalSize: info.Size(),
+		Hash:      hex.EncodeToString(hasher.Sum(nil)),
 	}
 
 	if info.Size() < e.Config.ChunkThreshold {
 		config.Chunked = false
-		return writeJSON(filepath.Join(outputDir, "beads.sqlite3.config.json"), config)
+		return writeJSON(filepath.Join(outputDir, ManifestFileName), config)
 	}
 
 	// Chunk the database
@@ -477,19 +650,6 @@ func (e *SQLiteExporter) chunkIfNeeded(outputDir, dbPath string) error {
 		return fmt.Errorf("create chunks dir: %w", err)
 	}
 
-	f, err := os.Open(dbPath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	// Calculate file hash
-	hasher := sha256.New()
-	if _, err := io.Copy(hasher, f); err != nil {
-		return fmt.Errorf("hash database: %w", err)
-	}
-	config.Hash = hex.EncodeToString(hasher.Sum(nil))
-
 	// Reset file position
 	if _, err := f.Seek(0, 0); err != nil {
 		return err
@@ -539,7 +699,7 @@ func (e *SQLiteExporter) chunkIfNeeded(outputDir, dbPath string) error {
 		})
 	}
 
-	return writeJSON(filepath.Join(outputDir, "beads.sqlite3.config.json"), config)
+	return writeJSON(filepath.Join(outputDir, ManifestFileName), config)
 }
 
 // writeJSON writes data as JSON to a file.
@@ -657,6 +817,9 @@ func (e *SQLiteExporter) ExportToJSON(path string) error {
 			IssueCount:  len(issues),
 			DepCount:    len(e.Deps),
 			Title:       title,
+			Logo:        e.Config.Logo,
+			AccentColor: e.Config.AccentColor,
+			Footer:      e.Config.Footer,
 		},
 		Issues: issues,
 	}
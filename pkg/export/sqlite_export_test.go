@@ -366,6 +366,86 @@ func TestExport_WithLabels(t *testing.T) {
 	}
 }
 
+func TestExport_WithCustomFields(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	issue := makeTestIssue("custom-1", "Custom Test", model.StatusOpen, 2, model.TypeTask)
+	issue.CustomFields = map[string]any{
+		"customer": "Acme",
+		"points":   float64(5),
+		"blocked":  true,
+	}
+
+	exp := NewSQLiteExporter([]*model.Issue{issue}, nil, nil, nil)
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT name, value FROM custom_fields WHERE issue_id = ? ORDER BY name`, "custom-1")
+	if err != nil {
+		t.Fatalf("Query custom_fields failed: %v", err)
+	}
+	defer rows.Close()
+
+	got := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			t.Fatal(err)
+		}
+		got[name] = value
+	}
+	want := map[string]string{"customer": "Acme", "points": "5", "blocked": "true"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d custom fields, got %v", len(want), got)
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("custom field %s = %q, want %q", name, got[name], value)
+		}
+	}
+}
+
+func TestExport_WithAttachments(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	issue := makeTestIssue("att-1", "Attachment Test", model.StatusOpen, 2, model.TypeTask)
+	issue.Attachments = []*model.Attachment{
+		{URL: "https://example.com/spec", Title: "Spec"},
+		{URL: "docs/trace.log"},
+	}
+
+	exp := NewSQLiteExporter([]*model.Issue{issue}, nil, nil, nil)
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	var url, title string
+	if err := db.QueryRow(`SELECT url, title FROM attachments WHERE issue_id = ? AND position = 0`, "att-1").Scan(&url, &title); err != nil {
+		t.Fatalf("Query attachments failed: %v", err)
+	}
+	if url != "https://example.com/spec" || title != "Spec" {
+		t.Errorf("first attachment = %q %q", url, title)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM attachments WHERE issue_id = ?`, "att-1").Scan(&count); err != nil || count != 2 {
+		t.Errorf("Expected 2 attachments, got %d (err %v)", count, err)
+	}
+}
+
 func TestExport_WithClosedAt(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,183 @@
+// Package export provides data export functionality for bv.
+//
+// This file fills the dependency_closure and metrics_history tables, which
+// let the static viewer answer transitive "what does closing X unblock"
+// questions and chart metric trends with plain SQL instead of walking the
+// graph client-side.
+package export
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+// metricsHistoryRow is one issue's metrics in one export snapshot.
+type metricsHistoryRow struct {
+	SnapshotAt        string
+	GitCommit         sql.NullString
+	IssueID           string
+	Status            sql.NullString
+	PageRank          float64
+	Betweenness       float64
+	CriticalPathDepth int
+	TriageScore       float64
+	BlocksCount       int
+	BlockedByCount    int
+}
+
+// insertDependencyClosure records, for every exported issue, each issue it
+// transitively depends on through blocking dependencies, with the shortest
+// number of hops.
+func (e *SQLiteExporter) insertDependencyClosure(db *sql.DB) error {
+	exported := make(map[string]bool, len(e.Issues))
+	for _, issue := range e.Issues {
+		exported[issue.ID] = true
+	}
+	blockers := make(map[string][]string)
+	seenEdge := make(map[[2]string]bool)
+	for _, dep := range e.Deps {
+		if dep == nil || !dep.Type.IsBlocking() || !exported[dep.IssueID] || !exported[dep.DependsOnID] {
+			continue
+		}
+		edge := [2]string{dep.IssueID, dep.DependsOnID}
+		if seenEdge[edge] {
+			continue
+		}
+		seenEdge[edge] = true
+		blockers[dep.IssueID] = append(blockers[dep.IssueID], dep.DependsOnID)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO dependency_closure (issue_id, depends_on_id, depth) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, issue := range e.Issues {
+		if len(blockers[issue.ID]) == 0 {
+			continue
+		}
+		// Breadth-first, so the first visit has the shortest depth; the
+		// issue itself is pre-visited to stop at cycles
+		visited := map[string]bool{issue.ID: true}
+		frontier := []string{issue.ID}
+		for depth := 1; len(frontier) > 0; depth++ {
+			var next []string
+			for _, id := range frontier {
+				for _, blocker := range blockers[id] {
+					if visited[blocker] {
+						continue
+					}
+					visited[blocker] = true
+					next = append(next, blocker)
+					if _, err := stmt.Exec(issue.ID, blocker, depth); err != nil {
+						return fmt.Errorf("insert closure %s->%s: %w", issue.ID, blocker, err)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+
+	return tx.Commit()
+}
+
+// readMetricsHistory loads the metrics_history rows of an earlier export
+// database. A missing file or table yields no rows.
+func readMetricsHistory(path string) []metricsHistoryRow {
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+		SELECT snapshot_at, git_commit, issue_id, status, pagerank, betweenness,
+			critical_path_depth, triage_score, blocks_count, blocked_by_count
+		FROM metrics_history
+	`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var history []metricsHistoryRow
+	for rows.Next() {
+		var r metricsHistoryRow
+		if err := rows.Scan(&r.SnapshotAt, &r.GitCommit, &r.IssueID, &r.Status, &r.PageRank, &r.Betweenness,
+			&r.CriticalPathDepth, &r.TriageScore, &r.BlocksCount, &r.BlockedByCount); err != nil {
+			return nil
+		}
+		history = append(history, r)
+	}
+	return history
+}
+
+// recordMetricsHistory appends a snapshot of the current issue_metrics to
+// metrics_history after restoring rows carried over from a previous
+// export, then drops snapshots beyond Config.HistorySnapshots.
+func (e *SQLiteExporter) recordMetricsHistory(db *sql.DB, carried []metricsHistoryRow) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(carried) > 0 {
+		stmt, err := tx.Prepare(`
+			INSERT OR REPLACE INTO metrics_history (snapshot_at, git_commit, issue_id, status, pagerank, betweenness,
+				critical_path_depth, triage_score, blocks_count, blocked_by_count)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, r := range carried {
+			if _, err := stmt.Exec(r.SnapshotAt, r.GitCommit, r.IssueID, r.Status, r.PageRank, r.Betweenness,
+				r.CriticalPathDepth, r.TriageScore, r.BlocksCount, r.BlockedByCount); err != nil {
+				return fmt.Errorf("restore history for %s: %w", r.IssueID, err)
+			}
+		}
+	}
+
+	var gitCommit *string
+	if e.gitHash != "" {
+		gitCommit = &e.gitHash
+	}
+	_, err = tx.Exec(`
+		INSERT OR REPLACE INTO metrics_history (snapshot_at, git_commit, issue_id, status, pagerank, betweenness,
+			critical_path_depth, triage_score, blocks_count, blocked_by_count)
+		SELECT ?, ?, m.issue_id, i.status, m.pagerank, m.betweenness,
+			m.critical_path_depth, m.triage_score, m.blocks_count, m.blocked_by_count
+		FROM issue_metrics m
+		JOIN issues i ON i.id = m.issue_id
+	`, time.Now().UTC().Format(time.RFC3339), gitCommit)
+	if err != nil {
+		return fmt.Errorf("snapshot metrics: %w", err)
+	}
+
+	if e.Config.HistorySnapshots > 0 {
+		_, err := tx.Exec(`
+			DELETE FROM metrics_history WHERE snapshot_at NOT IN (
+				SELECT DISTINCT snapshot_at FROM metrics_history ORDER BY snapshot_at DESC LIMIT ?
+			)
+		`, e.Config.HistorySnapshots)
+		if err != nil {
+			return fmt.Errorf("prune history: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
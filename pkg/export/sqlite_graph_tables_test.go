@@ -0,0 +1,139 @@
+package export
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestExport_DependencyClosure(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// c-3 -> c-2 -> c-1, c-4 -> c-1 directly and via c-3, plus a c-5 <-> c-6 cycle
+	issues := []*model.Issue{
+		makeTestIssue("c-1", "Root", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("c-2", "Middle", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("c-3", "Leaf", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("c-4", "Shortcut", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("c-5", "Cycle A", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("c-6", "Cycle B", model.StatusOpen, 1, model.TypeTask),
+	}
+	deps := []*model.Dependency{
+		{IssueID: "c-2", DependsOnID: "c-1", Type: model.DepBlocks},
+		{IssueID: "c-3", DependsOnID: "c-2", Type: model.DepBlocks},
+		{IssueID: "c-4", DependsOnID: "c-3", Type: model.DepBlocks},
+		{IssueID: "c-4", DependsOnID: "c-1", Type: model.DepBlocks},
+		{IssueID: "c-5", DependsOnID: "c-6", Type: model.DepBlocks},
+		{IssueID: "c-6", DependsOnID: "c-5", Type: model.DepBlocks},
+		{IssueID: "c-3", DependsOnID: "c-1", Type: model.DepRelated},
+	}
+
+	if err := NewSQLiteExporter(issues, deps, nil, nil).Export(tmpDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT issue_id, depth FROM dependency_closure WHERE depends_on_id = 'c-1' ORDER BY issue_id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int)
+	for rows.Next() {
+		var id string
+		var depth int
+		if err := rows.Scan(&id, &depth); err != nil {
+			t.Fatal(err)
+		}
+		got[id] = depth
+	}
+	rows.Close()
+	want := map[string]int{"c-2": 1, "c-3": 2, "c-4": 1}
+	if len(got) != len(want) {
+		t.Fatalf("closing c-1 should reach %v, got %v", want, got)
+	}
+	for id, depth := range want {
+		if got[id] != depth {
+			t.Errorf("%s: depth %d, want %d", id, got[id], depth)
+		}
+	}
+
+	var cycleRows int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM dependency_closure WHERE issue_id IN ('c-5', 'c-6')`).Scan(&cycleRows); err != nil {
+		t.Fatal(err)
+	}
+	if cycleRows != 2 {
+		t.Errorf("cycle members should reach each other once, got %d rows", cycleRows)
+	}
+}
+
+func TestExport_MetricsHistorySurvivesRebuilds(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	issues := []model.Issue{
+		*makeTestIssue("h-1", "First", model.StatusOpen, 1, model.TypeTask),
+		*makeTestIssue("h-2", "Second", model.StatusOpen, 2, model.TypeTask),
+	}
+	issues[1].Dependencies = []*model.Dependency{{IssueID: "h-2", DependsOnID: "h-1", Type: model.DepBlocks}}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	ptrs := []*model.Issue{&issues[0], &issues[1]}
+	deps := []*model.Dependency{issues[1].Dependencies[0]}
+
+	countSnapshots := func() (snapshots, rows int) {
+		t.Helper()
+		db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		if err := db.QueryRow(`SELECT COUNT(DISTINCT snapshot_at), COUNT(*) FROM metrics_history`).Scan(&snapshots, &rows); err != nil {
+			t.Fatal(err)
+		}
+		return snapshots, rows
+	}
+
+	exp := NewSQLiteExporter(ptrs, deps, &stats, nil)
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, rows := countSnapshots(); snapshots != 1 || rows != 2 {
+		t.Fatalf("expected one snapshot of 2 issues, got %d snapshots / %d rows", snapshots, rows)
+	}
+
+	// Pretend the first snapshot is older so the next one gets its own key
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`UPDATE metrics_history SET snapshot_at = '2000-01-01T00:00:00Z'`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	exp = NewSQLiteExporter(ptrs, deps, &stats, nil)
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, rows := countSnapshots(); snapshots != 2 || rows != 4 {
+		t.Errorf("full rebuild should keep earlier history, got %d snapshots / %d rows", snapshots, rows)
+	}
+
+	// Pruning keeps only the newest snapshot
+	exp = NewSQLiteExporter(ptrs, deps, &stats, nil)
+	exp.Config.HistorySnapshots = 1
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, _ := countSnapshots(); snapshots != 1 {
+		t.Errorf("expected history pruned to 1 snapshot, got %d", snapshots)
+	}
+}
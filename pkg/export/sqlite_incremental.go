@@ -0,0 +1,318 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements incremental SQLite export: instead of rebuilding
+// beads.sqlite3, issues are diffed against the export_hashes table and only
+// inserted, updated or deleted rows are written. Graph-wide tables (metrics,
+// triage, the dependency closure, the overview view) are still regenerated since any change can
+// shift them, but the full rebuild and VACUUM are skipped.
+package export
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// IncrementalResult counts the issue rows an incremental export touched.
+type IncrementalResult struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+}
+
+// issueHashes returns a content hash per issue covering its row and its
+// outgoing dependencies.
+func (e *SQLiteExporter) issueHashes() map[string]string {
+	depsByIssue := e.depsByIssue()
+	hashes := make(map[string]string, len(e.Issues))
+	for _, issue := range e.Issues {
+		var deps [][2]string
+		for _, dep := range depsByIssue[issue.ID] {
+			deps = append(deps, [2]string{dep.DependsOnID, string(dep.Type)})
+		}
+		sort.Slice(deps, func(i, j int) bool {
+			if deps[i][0] != deps[j][0] {
+				return deps[i][0] < deps[j][0]
+			}
+			return deps[i][1] < deps[j][1]
+		})
+		data, _ := json.Marshal(struct {
+			Row         []any
+			Deps        [][2]string
+			Custom      [][2]string
+			Attachments []*model.Attachment
+		}{issueRow(issue), deps, customFieldRows(issue), issue.Attachments})
+		sum := sha256.Sum256(data)
+		hashes[issue.ID] = hex.EncodeToString(sum[:])
+	}
+	return hashes
+}
+
+// depsByIssue groups the exporter's dependencies by dependent issue.
+func (e *SQLiteExporter) depsByIssue() map[string][]*model.Dependency {
+	byIssue := make(map[string][]*model.Dependency)
+	for _, dep := range e.Deps {
+		if dep != nil {
+			byIssue[dep.IssueID] = append(byIssue[dep.IssueID], dep)
+		}
+	}
+	return byIssue
+}
+
+// insertHashes records the content hash of every exported issue.
+func (e *SQLiteExporter) insertHashes(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO export_hashes (issue_id, hash) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, hash := range e.issueHashes() {
+		if _, err := stmt.Exec(id, hash); err != nil {
+			return fmt.Errorf("insert hash for %s: %w", id, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// updateDatabase applies the difference between the exporter's issues and
+// an existing database at dbPath. It returns nil without error when there
+// is nothing usable to diff against (no database, another schema version,
+// or one written before hashes were recorded); the caller then rebuilds.
+func (e *SQLiteExporter) updateDatabase(dbPath string) (*IncrementalResult, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, nil
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	var version string
+	if err := db.QueryRow(`SELECT value FROM export_meta WHERE key = 'schema_version'`).Scan(&version); err != nil ||
+		version != strconv.Itoa(SchemaVersion) {
+		return nil, nil
+	}
+	old, err := loadHashes(db)
+	if err != nil {
+		return nil, nil
+	}
+
+	result, err := e.applyIssueDelta(db, old)
+	if err != nil {
+		return nil, err
+	}
+
+	// Graph-wide data depends on every issue, so regenerate it
+	for _, table := range []string{"issue_metrics", "triage_recommendations", "dependency_closure"} {
+		if _, err := db.Exec(`DELETE FROM ` + table); err != nil {
+			return nil, fmt.Errorf("clear %s: %w", table, err)
+		}
+	}
+	if err := e.insertMetrics(db); err != nil {
+		return nil, fmt.Errorf("insert metrics: %w", err)
+	}
+	if err := e.insertTriageRecommendations(db); err != nil {
+		return nil, fmt.Errorf("insert triage: %w", err)
+	}
+	if err := e.insertDependencyClosure(db); err != nil {
+		return nil, fmt.Errorf("insert dependency closure: %w", err)
+	}
+	if err := e.recordMetricsHistory(db, nil); err != nil {
+		return nil, fmt.Errorf("record metrics history: %w", err)
+	}
+	if _, err := db.Exec(`DROP TABLE IF EXISTS issue_overview_mv`); err != nil {
+		return nil, fmt.Errorf("drop issue_overview_mv: %w", err)
+	}
+	if err := CreateMaterializedViews(db); err != nil {
+		return nil, fmt.Errorf("create materialized views: %w", err)
+	}
+	if err := e.populateOverviewMetrics(db); err != nil {
+		return nil, fmt.Errorf("populate overview metrics: %w", err)
+	}
+	if err := e.insertMeta(db); err != nil {
+		return nil, fmt.Errorf("insert meta: %w", err)
+	}
+	_, _ = db.Exec(`PRAGMA optimize`)
+
+	if err := db.Close(); err != nil {
+		return nil, fmt.Errorf("close database: %w", err)
+	}
+	return result, nil
+}
+
+// loadHashes reads the export_hashes table.
+func loadHashes(db *sql.DB) (map[string]string, error) {
+	rows, err := db.Query(`SELECT issue_id, hash FROM export_hashes`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var id, hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return nil, err
+		}
+		hashes[id] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// applyIssueDelta inserts, updates and deletes issue rows (with their
+// dependencies, FTS entries and hashes) so the database matches e.Issues.
+func (e *SQLiteExporter) applyIssueDelta(db *sql.DB, old map[string]string) (*IncrementalResult, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var ftsCount int
+	_ = tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'issues_fts'`).Scan(&ftsCount)
+	hasFTS := ftsCount > 0
+
+	// The FTS table uses issues as external content, so entries must be
+	// removed with the old column values before a row changes
+	ftsDelete := func(id string) error {
+		if !hasFTS {
+			return nil
+		}
+		_, err := tx.Exec(`
+			INSERT INTO issues_fts(issues_fts, rowid, id, title, description, labels, assignee)
+			SELECT 'delete', rowid, id, title, description, labels, assignee FROM issues WHERE id = ?
+		`, id)
+		return err
+	}
+	ftsInsert := func(id string) error {
+		if !hasFTS {
+			return nil
+		}
+		_, err := tx.Exec(`
+			INSERT INTO issues_fts(rowid, id, title, description, labels, assignee)
+			SELECT rowid, id, title, description, labels, assignee FROM issues WHERE id = ?
+		`, id)
+		return err
+	}
+
+	result := &IncrementalResult{}
+	hashes := e.issueHashes()
+
+	var removed []string
+	for id := range old {
+		if _, ok := hashes[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		if err := ftsDelete(id); err != nil {
+			return nil, fmt.Errorf("remove %s from search index: %w", id, err)
+		}
+		for _, q := range []string{
+			`DELETE FROM issues WHERE id = ?`,
+			`DELETE FROM dependencies WHERE issue_id = ?`,
+			`DELETE FROM custom_fields WHERE issue_id = ?`,
+			`DELETE FROM attachments WHERE issue_id = ?`,
+			`DELETE FROM export_hashes WHERE issue_id = ?`,
+		} {
+			if _, err := tx.Exec(q, id); err != nil {
+				return nil, fmt.Errorf("delete issue %s: %w", id, err)
+			}
+		}
+		result.Deleted++
+	}
+
+	depsByIssue := e.depsByIssue()
+	for _, issue := range e.Issues {
+		prev, existed := old[issue.ID]
+		hash := hashes[issue.ID]
+		switch {
+		case existed && prev == hash:
+			result.Unchanged++
+			continue
+		case existed:
+			if err := ftsDelete(issue.ID); err != nil {
+				return nil, fmt.Errorf("remove %s from search index: %w", issue.ID, err)
+			}
+			_, err := tx.Exec(`
+				UPDATE issues SET (id, title, description, status, priority, issue_type, assignee, labels, created_at, updated_at, closed_at)
+				= (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) WHERE id = ?
+			`, append(issueRow(issue), issue.ID)...)
+			if err != nil {
+				return nil, fmt.Errorf("update issue %s: %w", issue.ID, err)
+			}
+			result.Updated++
+		default:
+			_, err := tx.Exec(`
+				INSERT INTO issues (id, title, description, status, priority, issue_type, assignee, labels, created_at, updated_at, closed_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, issueRow(issue)...)
+			if err != nil {
+				return nil, fmt.Errorf("insert issue %s: %w", issue.ID, err)
+			}
+			result.Inserted++
+		}
+
+		if _, err := tx.Exec(`DELETE FROM dependencies WHERE issue_id = ?`, issue.ID); err != nil {
+			return nil, fmt.Errorf("clear dependencies of %s: %w", issue.ID, err)
+		}
+		for _, dep := range depsByIssue[issue.ID] {
+			_, err := tx.Exec(`INSERT INTO dependencies (issue_id, depends_on_id, type) VALUES (?, ?, ?)`,
+				dep.IssueID, dep.DependsOnID, string(dep.Type))
+			if err != nil {
+				return nil, fmt.Errorf("insert dependency %s->%s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+		}
+		if _, err := tx.Exec(`DELETE FROM custom_fields WHERE issue_id = ?`, issue.ID); err != nil {
+			return nil, fmt.Errorf("clear custom fields of %s: %w", issue.ID, err)
+		}
+		for _, row := range customFieldRows(issue) {
+			_, err := tx.Exec(`INSERT INTO custom_fields (issue_id, name, value) VALUES (?, ?, ?)`, issue.ID, row[0], row[1])
+			if err != nil {
+				return nil, fmt.Errorf("insert custom field %s of %s: %w", row[0], issue.ID, err)
+			}
+		}
+		if _, err := tx.Exec(`DELETE FROM attachments WHERE issue_id = ?`, issue.ID); err != nil {
+			return nil, fmt.Errorf("clear attachments of %s: %w", issue.ID, err)
+		}
+		for pos, a := range issue.Attachments {
+			if a == nil {
+				continue
+			}
+			_, err := tx.Exec(`INSERT INTO attachments (issue_id, position, url, title) VALUES (?, ?, ?, ?)`, issue.ID, pos, a.URL, a.Title)
+			if err != nil {
+				return nil, fmt.Errorf("insert attachment %s of %s: %w", a.URL, issue.ID, err)
+			}
+		}
+		if err := ftsInsert(issue.ID); err != nil {
+			return nil, fmt.Errorf("index %s for search: %w", issue.ID, err)
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO export_hashes (issue_id, hash) VALUES (?, ?)`, issue.ID, hash); err != nil {
+			return nil, fmt.Errorf("record hash for %s: %w", issue.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestExport_IncrementalUpdate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	issues := []*model.Issue{
+		makeTestIssue("inc-1", "Unchanged", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("inc-2", "Will change", model.StatusOpen, 2, model.TypeTask),
+		makeTestIssue("inc-3", "Will be deleted", model.StatusOpen, 2, model.TypeBug),
+	}
+	deps := []*model.Dependency{
+		{IssueID: "inc-2", DependsOnID: "inc-1", Type: model.DepBlocks},
+		{IssueID: "inc-3", DependsOnID: "inc-1", Type: model.DepBlocks},
+	}
+
+	// Incremental mode with no database yet falls back to a full build
+	exp := NewSQLiteExporter(issues, deps, nil, nil)
+	exp.Config.Incremental = true
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatalf("initial export failed: %v", err)
+	}
+	if exp.Delta != nil {
+		t.Fatalf("expected a full build, got delta %+v", exp.Delta)
+	}
+
+	changed := *issues[1]
+	changed.Title = "Renamed searchterm"
+	next := []*model.Issue{
+		issues[0],
+		&changed,
+		makeTestIssue("inc-4", "Brand new", model.StatusOpen, 0, model.TypeFeature),
+	}
+	nextDeps := []*model.Dependency{
+		{IssueID: "inc-4", DependsOnID: "inc-2", Type: model.DepBlocks},
+	}
+
+	exp = NewSQLiteExporter(next, nextDeps, nil, nil)
+	exp.Config.Incremental = true
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatalf("incremental export failed: %v", err)
+	}
+	want := IncrementalResult{Inserted: 1, Updated: 1, Deleted: 1, Unchanged: 1}
+	if exp.Delta == nil || *exp.Delta != want {
+		t.Fatalf("expected delta %+v, got %+v", want, exp.Delta)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT id, title FROM issue_overview_mv ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for rows.Next() {
+		var id, title string
+		if err := rows.Scan(&id, &title); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, id+"="+title)
+	}
+	rows.Close()
+	if len(got) != 3 || got[0] != "inc-1=Unchanged" || got[1] != "inc-2=Renamed searchterm" || got[2] != "inc-4=Brand new" {
+		t.Errorf("unexpected overview rows %v", got)
+	}
+
+	var depFrom, depTo string
+	var depCount int
+	if err := db.QueryRow(`SELECT COUNT(*), MIN(issue_id), MIN(depends_on_id) FROM dependencies`).Scan(&depCount, &depFrom, &depTo); err != nil {
+		t.Fatal(err)
+	}
+	if depCount != 1 || depFrom != "inc-4" || depTo != "inc-2" {
+		t.Errorf("expected only inc-4 -> inc-2, got %d rows (%s -> %s)", depCount, depFrom, depTo)
+	}
+
+	var hits int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues_fts WHERE issues_fts MATCH 'searchterm'`).Scan(&hits); err != nil {
+		t.Fatalf("FTS query failed: %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("expected the renamed issue to be searchable, got %d hits", hits)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues_fts WHERE issues_fts MATCH 'deleted'`).Scan(&hits); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 0 {
+		t.Errorf("deleted issue should be gone from the search index, got %d hits", hits)
+	}
+
+	// A rerun with identical data touches nothing
+	exp = NewSQLiteExporter(next, nextDeps, nil, nil)
+	exp.Config.Incremental = true
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if exp.Delta == nil || exp.Delta.Unchanged != 3 || exp.Delta.Inserted+exp.Delta.Updated+exp.Delta.Deleted != 0 {
+		t.Errorf("expected no changes on rerun, got %+v", exp.Delta)
+	}
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // Schema version for tracking migrations
-const SchemaVersion = 1
+const SchemaVersion = 4
 
 // CreateSchema creates all tables, indexes, and triggers in the database.
 func CreateSchema(db *sql.DB) error {
@@ -31,6 +31,14 @@ func CreateSchema(db *sql.DB) error {
 		return fmt.Errorf("create meta table: %w", err)
 	}
 
+	if err := createHashTable(db); err != nil {
+		return fmt.Errorf("create hash table: %w", err)
+	}
+
+	if err := createGraphTables(db); err != nil {
+		return fmt.Errorf("create graph tables: %w", err)
+	}
+
 	return nil
 }
 
@@ -71,6 +79,36 @@ func createCoreTables(db *sql.DB) error {
 		return fmt.Errorf("create dependencies table: %w", err)
 	}
 
+	// Custom fields - one row per field; strings are stored as is, other
+	// values as JSON
+	customSQL := `
+		CREATE TABLE IF NOT EXISTS custom_fields (
+			issue_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			value TEXT,
+			PRIMARY KEY (issue_id, name),
+			FOREIGN KEY (issue_id) REFERENCES issues(id)
+		)
+	`
+	if _, err := db.Exec(customSQL); err != nil {
+		return fmt.Errorf("create custom_fields table: %w", err)
+	}
+
+	// Attachments - links and files in the order they appear on the issue
+	attachmentsSQL := `
+		CREATE TABLE IF NOT EXISTS attachments (
+			issue_id TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			url TEXT NOT NULL,
+			title TEXT,
+			PRIMARY KEY (issue_id, position),
+			FOREIGN KEY (issue_id) REFERENCES issues(id)
+		)
+	`
+	if _, err := db.Exec(attachmentsSQL); err != nil {
+		return fmt.Errorf("create attachments table: %w", err)
+	}
+
 	return nil
 }
 
@@ -155,6 +193,72 @@ func createMetaTable(db *sql.DB) error {
 	return nil
 }
 
+// createHashTable creates the per-issue content hash table used by
+// incremental exports.
+func createHashTable(db *sql.DB) error {
+	hashSQL := `
+		CREATE TABLE IF NOT EXISTS export_hashes (
+			issue_id TEXT PRIMARY KEY,
+			hash TEXT NOT NULL
+		)
+	`
+	if _, err := db.Exec(hashSQL); err != nil {
+		return fmt.Errorf("create export_hashes table: %w", err)
+	}
+
+	return nil
+}
+
+// createGraphTables creates the transitive dependency closure and the
+// per-export metrics history.
+func createGraphTables(db *sql.DB) error {
+	// Every (issue, transitive blocker) pair with the shortest hop count, so
+	// "what does closing X unblock" is a single indexed lookup
+	closureSQL := `
+		CREATE TABLE IF NOT EXISTS dependency_closure (
+			issue_id TEXT NOT NULL,
+			depends_on_id TEXT NOT NULL,
+			depth INTEGER NOT NULL,
+			PRIMARY KEY (issue_id, depends_on_id)
+		)
+	`
+	if _, err := db.Exec(closureSQL); err != nil {
+		return fmt.Errorf("create dependency_closure table: %w", err)
+	}
+
+	// One row per issue per export snapshot
+	historySQL := `
+		CREATE TABLE IF NOT EXISTS metrics_history (
+			snapshot_at TEXT NOT NULL,
+			git_commit TEXT,
+			issue_id TEXT NOT NULL,
+			status TEXT,
+			pagerank REAL DEFAULT 0,
+			betweenness REAL DEFAULT 0,
+			critical_path_depth INTEGER DEFAULT 0,
+			triage_score REAL DEFAULT 0,
+			blocks_count INTEGER DEFAULT 0,
+			blocked_by_count INTEGER DEFAULT 0,
+			PRIMARY KEY (snapshot_at, issue_id)
+		)
+	`
+	if _, err := db.Exec(historySQL); err != nil {
+		return fmt.Errorf("create metrics_history table: %w", err)
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_closure_depends ON dependency_closure(depends_on_id, depth)`,
+		`CREATE INDEX IF NOT EXISTS idx_history_issue ON metrics_history(issue_id, snapshot_at)`,
+	}
+	for _, sql := range indexes {
+		if _, err := db.Exec(sql); err != nil {
+			return fmt.Errorf("create index: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // CreateFTSIndex creates the FTS5 full-text search virtual table.
 // This must be called after issues are inserted.
 func CreateFTSIndex(db *sql.DB) error {
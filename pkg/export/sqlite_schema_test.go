@@ -32,7 +32,8 @@ func TestCreateSchema(t *testing.T) {
 	}
 
 	// Verify tables exist
-	tables := []string{"issues", "dependencies", "issue_metrics", "triage_recommendations", "export_meta"}
+	tables := []string{"issues", "dependencies", "issue_metrics", "triage_recommendations", "export_meta",
+		"export_hashes", "dependency_closure", "metrics_history"}
 	for _, table := range tables {
 		var name string
 		err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name=?`, table).Scan(&name)
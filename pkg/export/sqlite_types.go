@@ -64,6 +64,9 @@ type ExportMeta struct {
 	DepCount    int       `json:"dependency_count"`
 	DataHash    string    `json:"data_hash,omitempty"`
 	Title       string    `json:"title,omitempty"`
+	Logo        string    `json:"logo,omitempty"`
+	AccentColor string    `json:"accent_color,omitempty"`
+	Footer      string    `json:"footer,omitempty"`
 }
 
 // SQLiteExportConfig configures the SQLite export process.
@@ -74,6 +77,11 @@ type SQLiteExportConfig struct {
 	// Title is a custom title for the static site
 	Title string
 
+	// Logo, AccentColor and Footer brand the static site (see Branding)
+	Logo        string
+	AccentColor string
+	Footer      string
+
 	// ChunkThreshold is the file size (bytes) above which to chunk the database
 	// Default: 5MB
 	ChunkThreshold int64
@@ -87,6 +95,28 @@ type SQLiteExportConfig struct {
 
 	// PageSize is the SQLite page size (optimal: 1024 for httpvfs)
 	PageSize int
+
+	// Incremental updates an existing database in place, rewriting only
+	// issues whose content hash changed, instead of rebuilding it
+	Incremental bool
+
+	// HistorySnapshots is how many export snapshots metrics_history keeps
+	// (0 = unlimited). Default: 50
+	HistorySnapshots int
+
+	// SigningKey is a minisign or PEM Ed25519 secret key file. When set,
+	// Export signs the chunk manifest (see SignManifest)
+	SigningKey string
+}
+
+// Branding returns the config's title, logo, accent color and footer.
+func (c SQLiteExportConfig) Branding() Branding {
+	return Branding{Title: c.Title, Logo: c.Logo, AccentColor: c.AccentColor, Footer: c.Footer}
+}
+
+// SetBranding sets the config's title, logo, accent color and footer.
+func (c *SQLiteExportConfig) SetBranding(b Branding) {
+	c.Title, c.Logo, c.AccentColor, c.Footer = b.Title, b.Logo, b.AccentColor, b.Footer
 }
 
 // DefaultSQLiteExportConfig returns sensible defaults for export configuration.
@@ -96,10 +126,12 @@ func DefaultSQLiteExportConfig() SQLiteExportConfig {
 		ChunkSize:           1 * 1024 * 1024, // 1MB
 		IncludeRobotOutputs: true,
 		PageSize:            1024,
+		HistorySnapshots:    50,
 	}
 }
 
-// ChunkConfig describes how a large database was chunked.
+// ChunkConfig describes how a large database was chunked. It is written
+// to ManifestFileName for every export, chunked or not.
 type ChunkConfig struct {
 	Chunked    bool        `json:"chunked"`
 	ChunkCount int         `json:"chunk_count"`
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// GraphicsProtocol is a terminal inline image protocol.
+type GraphicsProtocol string
+
+const (
+	// GraphicsNone means the terminal can't show images.
+	GraphicsNone GraphicsProtocol = ""
+	// GraphicsKitty is the kitty graphics protocol (kitty, WezTerm, Ghostty).
+	GraphicsKitty GraphicsProtocol = "kitty"
+	// GraphicsSixel is DEC sixel graphics (foot, mlterm, Windows Terminal, ...).
+	GraphicsSixel GraphicsProtocol = "sixel"
+)
+
+// kittyChunkSize is the most base64 payload the kitty protocol takes per
+// escape sequence
+const kittyChunkSize = 4096
+
+// ParseGraphicsProtocol parses a protocol name. "auto" (or "") detects the
+// protocol from the environment, which may yield GraphicsNone.
+func ParseGraphicsProtocol(name string, getenv func(string) string) (GraphicsProtocol, error) {
+	switch protocol := GraphicsProtocol(strings.ToLower(strings.TrimSpace(name))); protocol {
+	case "", "auto":
+		return DetectGraphicsProtocol(getenv), nil
+	case GraphicsKitty, GraphicsSixel:
+		return protocol, nil
+	}
+	return GraphicsNone, fmt.Errorf("unknown graphics protocol %q (use auto, kitty or sixel)", name)
+}
+
+// DetectGraphicsProtocol guesses the image protocol of the terminal from
+// environment variables. Inside tmux or screen it reports GraphicsNone, since
+// images need passthrough there; name the protocol explicitly instead.
+func DetectGraphicsProtocol(getenv func(string) string) GraphicsProtocol {
+	if getenv("TMUX") != "" || strings.HasPrefix(getenv("TERM"), "screen") {
+		return GraphicsNone
+	}
+	termName := strings.ToLower(getenv("TERM"))
+	termProgram := strings.ToLower(getenv("TERM_PROGRAM"))
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", termName == "xterm-kitty",
+		termProgram == "wezterm", termProgram == "ghostty", termName == "xterm-ghostty":
+		return GraphicsKitty
+	case strings.HasPrefix(termName, "foot"), strings.HasPrefix(termName, "mlterm"),
+		strings.HasPrefix(termName, "contour"), strings.HasPrefix(termName, "yaft"),
+		getenv("WT_SESSION") != "":
+		return GraphicsSixel
+	}
+	return GraphicsNone
+}
+
+// WriteTerminalImage writes img to w as an inline image in protocol,
+// scaled down to at most maxWidth pixels wide (0 = no limit).
+func WriteTerminalImage(w io.Writer, img image.Image, protocol GraphicsProtocol, maxWidth int) error {
+	img = fitWidth(img, maxWidth)
+	switch protocol {
+	case GraphicsKitty:
+		return writeKittyImage(w, img)
+	case GraphicsSixel:
+		return writeSixelImage(w, img)
+	}
+	return fmt.Errorf("terminal does not support inline images")
+}
+
+// fitWidth scales img down to maxWidth, keeping its aspect ratio
+func fitWidth(img image.Image, maxWidth int) image.Image {
+	b := img.Bounds()
+	if maxWidth <= 0 || b.Dx() <= maxWidth {
+		return img
+	}
+	height := max(1, b.Dy()*maxWidth/b.Dx())
+	scaled := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, b, draw.Src, nil)
+	return scaled
+}
+
+// writeKittyImage sends img as PNG in base64 chunks. q=2 keeps the terminal
+// from answering, so no reply ends up on the shell's input.
+func writeKittyImage(w io.Writer, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	payload := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var sb strings.Builder
+	for first := true; first || payload != ""; first = false {
+		chunk := payload
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			sb.WriteString(fmt.Sprintf("\x1b_Ga=T,f=100,q=2,m=%d;%s\x1b\\", more, chunk))
+		} else {
+			sb.WriteString(fmt.Sprintf("\x1b_Gm=%d;%s\x1b\\", more, chunk))
+		}
+	}
+	sb.WriteString("\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeSixelImage maps img onto the 216-color web-safe palette and encodes
+// it as sixels: bands six pixels high, one pass per color in the band.
+func writeSixelImage(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	pal := image.NewPaletted(image.Rect(0, 0, width, height), palette.WebSafe)
+	draw.Draw(pal, pal.Bounds(), img, b.Min, draw.Src)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\x1bPq\"1;1;%d;%d", width, height))
+
+	used := make([]bool, len(pal.Palette))
+	for _, idx := range pal.Pix {
+		used[idx] = true
+	}
+	for idx, c := range pal.Palette {
+		if !used[idx] {
+			continue
+		}
+		r, g, bl, _ := c.RGBA()
+		sb.WriteString(fmt.Sprintf("#%d;2;%d;%d;%d", idx, r*100/0xffff, g*100/0xffff, bl*100/0xffff))
+	}
+
+	rows := make([][]byte, len(pal.Palette))
+	inBand := make([]bool, len(pal.Palette))
+	for y0 := 0; y0 < height; y0 += 6 {
+		var colors []int
+		for dy := 0; dy < 6 && y0+dy < height; dy++ {
+			line := pal.Pix[(y0+dy)*pal.Stride : (y0+dy)*pal.Stride+width]
+			for x, idx := range line {
+				if !inBand[idx] {
+					inBand[idx] = true
+					colors = append(colors, int(idx))
+					if rows[idx] == nil {
+						rows[idx] = make([]byte, width)
+					}
+				}
+				rows[idx][x] |= 1 << dy
+			}
+		}
+		for _, idx := range colors {
+			sb.WriteString(fmt.Sprintf("#%d", idx))
+			writeSixelRow(&sb, rows[idx])
+			sb.WriteString("$")
+			clear(rows[idx])
+			inBand[idx] = false
+		}
+		sb.WriteString("-")
+	}
+	sb.WriteString("\x1b\\\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeSixelRow writes one color's sixels for a band, run-length encoded,
+// dropping the empty sixels at the end
+func writeSixelRow(sb *strings.Builder, row []byte) {
+	end := len(row)
+	for end > 0 && row[end-1] == 0 {
+		end--
+	}
+	for x := 0; x < end; {
+		run := 1
+		for x+run < end && row[x+run] == row[x] {
+			run++
+		}
+		ch := byte('?' + row[x])
+		if run > 3 {
+			sb.WriteString(fmt.Sprintf("!%d%c", run, ch))
+		} else {
+			for range run {
+				sb.WriteByte(ch)
+			}
+		}
+		x += run
+	}
+}
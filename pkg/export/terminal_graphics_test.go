@@ -0,0 +1,137 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestDetectGraphicsProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		env  map[string]string
+		want GraphicsProtocol
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, GraphicsKitty},
+		{map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, GraphicsKitty},
+		{map[string]string{"TERM": "foot"}, GraphicsSixel},
+		{map[string]string{"TERM": "xterm-256color", "WT_SESSION": "abc"}, GraphicsSixel},
+		{map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux-0/default,1,0"}, GraphicsNone},
+		{map[string]string{"TERM": "xterm-256color"}, GraphicsNone},
+	} {
+		getenv := func(key string) string { return tc.env[key] }
+		if got := DetectGraphicsProtocol(getenv); got != tc.want {
+			t.Errorf("DetectGraphicsProtocol(%v) = %q, want %q", tc.env, got, tc.want)
+		}
+	}
+	if _, err := ParseGraphicsProtocol("iterm", func(string) string { return "" }); err == nil {
+		t.Error("ParseGraphicsProtocol accepted an unknown protocol")
+	}
+}
+
+func TestWriteTerminalImage_Kitty(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range img.Pix {
+		img.Pix[i] = byte(rng.IntN(256)) // noise, so the PNG spans several chunks
+	}
+	var out bytes.Buffer
+	if err := WriteTerminalImage(&out, img, GraphicsKitty, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := regexp.MustCompile("\x1b_G([^;]*);([^\x1b]*)\x1b\\\\").FindAllStringSubmatch(out.String(), -1)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want several", len(chunks))
+	}
+	if !strings.HasPrefix(chunks[0][1], "a=T,f=100") {
+		t.Errorf("first chunk controls = %q", chunks[0][1])
+	}
+	var payload strings.Builder
+	for n, chunk := range chunks {
+		wantMore := "m=1"
+		if n == len(chunks)-1 {
+			wantMore = "m=0"
+		}
+		if !strings.HasSuffix(chunk[1], wantMore) {
+			t.Errorf("chunk %d controls = %q, want %s", n, chunk[1], wantMore)
+		}
+		if len(chunk[2]) > kittyChunkSize {
+			t.Errorf("chunk %d carries %d bytes", n, len(chunk[2]))
+		}
+		payload.WriteString(chunk[2])
+	}
+	data, err := base64.StdEncoding.DecodeString(payload.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("decoded bounds %v, want %v", decoded.Bounds(), img.Bounds())
+	}
+}
+
+func TestWriteTerminalImage_Sixel(t *testing.T) {
+	// Top 6 rows white, the next 2 black
+	img := image.NewRGBA(image.Rect(0, 0, 10, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 10; x++ {
+			c := color.RGBA{255, 255, 255, 255}
+			if y >= 6 {
+				c = color.RGBA{0, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var out bytes.Buffer
+	if err := WriteTerminalImage(&out, img, GraphicsSixel, 0); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "\x1bPq\"1;1;10;8") || !strings.HasSuffix(got, "\x1b\\\n") {
+		t.Fatalf("sixel framing wrong: %q", got)
+	}
+	// White is palette entry 215, black entry 0
+	for _, want := range []string{"#215;2;100;100;100", "#0;2;0;0;0", "#215!10~$-", "#0!10B$-"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("sixel output missing %q: %q", want, got)
+		}
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	if got := fitWidth(img, 100).Bounds(); got.Dx() != 100 || got.Dy() != 25 {
+		t.Errorf("fitWidth bounds = %v", got)
+	}
+	if got := fitWidth(img, 0); got != image.Image(img) {
+		t.Error("fitWidth scaled without a limit")
+	}
+}
+
+func TestRenderGraphImage(t *testing.T) {
+	issues := []model.Issue{
+		*makeTestIssue("img-1", "One", model.StatusOpen, 1, model.TypeTask),
+		*makeTestIssue("img-2", "Two", model.StatusBlocked, 1, model.TypeTask),
+	}
+	issues[1].Dependencies = []*model.Dependency{{IssueID: "img-2", DependsOnID: "img-1", Type: model.DepBlocks}}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	img, err := RenderGraphImage(GraphSnapshotOptions{Issues: issues, Stats: &stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Empty() {
+		t.Error("rendered an empty image")
+	}
+}
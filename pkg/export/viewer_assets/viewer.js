@@ -1731,6 +1731,70 @@ function getIssueDependencies(id) {
   return { blocks, blockedBy };
 }
 
+/**
+ * Check whether the export includes the precomputed dependency_closure table
+ */
+function hasDependencyClosure() {
+  const { data } = safeQuery(`
+    SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'dependency_closure'
+  `);
+  return data.length > 0;
+}
+
+/**
+ * Get open issues that transitively depend on an issue, nearest first
+ */
+function getTransitiveDependents(id) {
+  const { data } = safeQuery(`
+    SELECT i.*, c.depth FROM dependency_closure c
+    JOIN issue_overview_mv i ON i.id = c.issue_id
+    WHERE c.depends_on_id = ? AND i.status != 'closed'
+    ORDER BY c.depth, i.priority, i.id
+  `, [id]);
+  return data;
+}
+
+/**
+ * What-if analysis from dependency_closure, for when the WASM graph
+ * engine is unavailable. Newly unblocked issues are direct dependents
+ * whose only open blocker is the closed issue.
+ */
+function whatIfCloseSQL(issueId) {
+  const cascade = getTransitiveDependents(issueId);
+  const { data: unblocked } = safeQuery(`
+    SELECT DISTINCT d.issue_id FROM dependencies d
+    JOIN issues i ON i.id = d.issue_id
+    WHERE d.depends_on_id = ? AND (d.type = 'blocks' OR d.type = '') AND i.status != 'closed'
+      AND NOT EXISTS (
+        SELECT 1 FROM dependencies d2
+        JOIN issues b ON b.id = d2.depends_on_id
+        WHERE d2.issue_id = d.issue_id AND (d2.type = 'blocks' OR d2.type = '')
+          AND d2.depends_on_id != ? AND b.status != 'closed'
+      )
+  `, [issueId, issueId]);
+
+  return {
+    newly_unblocked: unblocked.length,
+    cascade_count: cascade.length,
+    cascade_issue_ids: cascade.map(row => row.id),
+    unblocked_issue_ids: unblocked.map(row => row.issue_id),
+  };
+}
+
+/**
+ * Get an issue's metrics across export snapshots, oldest first
+ */
+function getMetricsHistory(id) {
+  const { data } = safeQuery(`
+    SELECT snapshot_at, git_commit, status, pagerank, betweenness, critical_path_depth,
+           triage_score, blocks_count, blocked_by_count
+    FROM metrics_history
+    WHERE issue_id = ?
+    ORDER BY snapshot_at
+  `, [id]);
+  return data;
+}
+
 // ============================================================================
 // URL State Sync - Shareable filtered views
 // ============================================================================
@@ -2254,6 +2318,7 @@ function beadsApp() {
 
     // Graph engine state
     graphReady: false,
+    closureReady: false,
     graphMetrics: null,
     whatIfResult: null,
     topKSet: null,
@@ -2497,6 +2562,7 @@ function beadsApp() {
 
         // Load issues for list view (initial data)
         this.loadIssues();
+        this.closureReady = hasDependencyClosure();
 
         // Handle initial route from URL hash
         if (window.location.hash) {
@@ -3183,8 +3249,11 @@ function beadsApp() {
      * Compute what-if cascade impact for an issue
      */
     computeWhatIf(issueId) {
-      if (!this.graphReady) return;
-      this.whatIfResult = whatIfClose(issueId);
+      if (this.graphReady) {
+        this.whatIfResult = whatIfClose(issueId);
+      } else if (this.closureReady) {
+        this.whatIfResult = whatIfCloseSQL(issueId);
+      }
     },
 
     /**
@@ -3416,6 +3485,8 @@ window.beadsViewer = {
   countIssues,
   getIssue,
   getIssueDependencies,
+  getTransitiveDependents,
+  getMetricsHistory,
   getStats,
   getMeta,
   getFilterOptions,
@@ -3440,6 +3511,7 @@ window.beadsViewer = {
   buildClosedSet,
   recalculateMetrics,
   whatIfClose,
+  whatIfCloseSQL,
   topWhatIf,
   getActionableIssues,
   getCycleBreakSuggestions,
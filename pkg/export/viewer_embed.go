@@ -20,8 +20,14 @@ var ViewerAssetsFS embed.FS
 // CopyEmbeddedAssets copies all embedded viewer assets to the specified output directory.
 // If title is provided, it replaces "Beads Viewer" in index.html.
 func CopyEmbeddedAssets(outputDir, title string) error {
+	return CopyEmbeddedAssetsBranded(outputDir, Branding{Title: title})
+}
+
+// CopyEmbeddedAssetsBranded copies the embedded viewer assets to outputDir
+// and applies b to them (see BrandViewer).
+func CopyEmbeddedAssetsBranded(outputDir string, b Branding) error {
 	// Walk the embedded filesystem and copy all files
-	return fs.WalkDir(ViewerAssetsFS, "viewer_assets", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(ViewerAssetsFS, "viewer_assets", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -47,12 +53,6 @@ func CopyEmbeddedAssets(outputDir, title string) error {
 			return err
 		}
 
-		// Special handling for index.html to replace the title
-		if relPath == "index.html" && title != "" {
-			contentStr := replaceTitle(string(content), title)
-			content = []byte(contentStr)
-		}
-
 		// Ensure parent directory exists
 		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
 			return err
@@ -61,6 +61,10 @@ func CopyEmbeddedAssets(outputDir, title string) error {
 		// Write the file
 		return os.WriteFile(destPath, content, 0644)
 	})
+	if err != nil {
+		return err
+	}
+	return BrandViewer(outputDir, b)
 }
 
 // replaceTitle replaces the default title in HTML content with the provided title.
@@ -77,8 +81,9 @@ func replaceTitle(content, title string) string {
 	// Replace title in <title> tag
 	content = strings.Replace(content, "<title>Beads Viewer</title>", "<title>"+safeTitle+"</title>", 1)
 
-	// Replace title in h1 header
-	content = strings.Replace(content, `<h1 class="text-xl font-semibold">Beads Viewer</h1>`, `<h1 class="text-xl font-semibold">`+safeTitle+`</h1>`, 1)
+	// Replace title in h1 header and the home screen name
+	content = strings.Replace(content, `<h1 class="text-lg sm:text-xl font-semibold">Beads Viewer</h1>`, `<h1 class="text-lg sm:text-xl font-semibold">`+safeTitle+`</h1>`, 1)
+	content = strings.Replace(content, `<meta name="apple-mobile-web-app-title" content="Beads">`, `<meta name="apple-mobile-web-app-title" content="`+safeTitle+`">`, 1)
 
 	return content
 }
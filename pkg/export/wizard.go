@@ -38,6 +38,10 @@ type WizardConfig struct {
 
 	// Output path for bundle
 	OutputPath string `json:"output_path,omitempty"`
+
+	// SigningKey signs the export's chunk manifest (edit the saved config
+	// to set it; reconfiguring keeps it)
+	SigningKey string `json:"signing_key,omitempty"`
 }
 
 // WizardResult contains the result of running the wizard.
@@ -106,6 +110,9 @@ func (w *Wizard) offerSavedConfig(saved *WizardConfig) (bool, error) {
 		fmt.Printf("  Target: Local export\n")
 		fmt.Printf("  Path:   %s\n", saved.OutputPath)
 	}
+	if saved.SigningKey != "" {
+		fmt.Printf("  Signing key: %s\n", saved.SigningKey)
+	}
 	fmt.Println("")
 
 	var useSaved bool = true
@@ -141,6 +148,8 @@ func (w *Wizard) Run() (*WizardResult, error) {
 		if err != nil {
 			return nil, err
 		}
+		// Reconfiguring keeps the signing key, which the wizard does not ask for
+		w.config.SigningKey = savedConfig.SigningKey
 		if useSaved {
 			// Use saved config and mark as update
 			w.config = savedConfig
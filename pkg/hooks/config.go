@@ -1,6 +1,8 @@
 // Package hooks provides a hook system for bv export automation.
 // Hooks are configured via .bv/hooks.yaml and run at specific points
-// in the export pipeline (pre-export, post-export).
+// in the export pipeline (pre-export, post-export), or on data events seen
+// when the TUI reloads the beads file (see RunEventHooks). It also installs
+// the git hooks that validate the beads file on commit (see InstallGitHooks).
 package hooks
 
 import (
@@ -21,6 +23,16 @@ const (
 	PreExport HookPhase = "pre-export"
 	// PostExport runs after export is written. Failure is logged but doesn't break export.
 	PostExport HookPhase = "post-export"
+
+	// OnReload runs after every reload of the beads file, with the added,
+	// modified and removed issues on stdin.
+	OnReload HookPhase = "on-reload"
+	// OnIssueClosed runs once per issue found closed on reload, with the
+	// issue on stdin.
+	OnIssueClosed HookPhase = "on-issue-closed"
+	// OnNewBlocked runs once per open issue that became blocked on reload,
+	// with the issue on stdin.
+	OnNewBlocked HookPhase = "on-new-blocked"
 )
 
 // Hook defines a single hook configuration
@@ -41,6 +53,11 @@ type Config struct {
 type HooksByPhase struct {
 	PreExport  []Hook `yaml:"pre-export,omitempty" json:"pre-export,omitempty"`
 	PostExport []Hook `yaml:"post-export,omitempty" json:"post-export,omitempty"`
+
+	// Data event hooks; failures are logged and never affect bv
+	OnReload      []Hook `yaml:"on-reload,omitempty" json:"on-reload,omitempty"`
+	OnIssueClosed []Hook `yaml:"on-issue-closed,omitempty" json:"on-issue-closed,omitempty"`
+	OnNewBlocked  []Hook `yaml:"on-new-blocked,omitempty" json:"on-new-blocked,omitempty"`
 }
 
 // ExportContext contains information passed to hooks via environment variables
@@ -126,6 +143,9 @@ func (l *Loader) Load() error {
 func (l *Loader) normalizeConfig(config *Config) {
 	config.Hooks.PreExport, l.warnings = normalizeHooks(config.Hooks.PreExport, PreExport, l.warnings)
 	config.Hooks.PostExport, l.warnings = normalizeHooks(config.Hooks.PostExport, PostExport, l.warnings)
+	config.Hooks.OnReload, l.warnings = normalizeHooks(config.Hooks.OnReload, OnReload, l.warnings)
+	config.Hooks.OnIssueClosed, l.warnings = normalizeHooks(config.Hooks.OnIssueClosed, OnIssueClosed, l.warnings)
+	config.Hooks.OnNewBlocked, l.warnings = normalizeHooks(config.Hooks.OnNewBlocked, OnNewBlocked, l.warnings)
 }
 
 // normalizeHooks applies defaults, drops empty commands, and accumulates warnings.
@@ -144,7 +164,7 @@ func normalizeHooks(hooks []Hook, phase HookPhase, warnings []string) ([]Hook, [
 			if phase == PreExport {
 				hook.OnError = "fail" // pre-export failures cancel export by default
 			} else {
-				hook.OnError = "continue" // post-export failures don't break export by default
+				hook.OnError = "continue" // post-export and event failures don't break anything by default
 			}
 		}
 		if hook.Name == "" {
@@ -163,7 +183,7 @@ func (l *Loader) Config() *Config {
 	return l.config
 }
 
-// HasHooks returns true if any hooks are configured
+// HasHooks returns true if any export hooks are configured
 func (l *Loader) HasHooks() bool {
 	if l.config == nil {
 		return false
@@ -171,6 +191,15 @@ func (l *Loader) HasHooks() bool {
 	return len(l.config.Hooks.PreExport) > 0 || len(l.config.Hooks.PostExport) > 0
 }
 
+// HasEventHooks returns true if any data event hooks are configured
+func (l *Loader) HasEventHooks() bool {
+	if l.config == nil {
+		return false
+	}
+	h := l.config.Hooks
+	return len(h.OnReload) > 0 || len(h.OnIssueClosed) > 0 || len(h.OnNewBlocked) > 0
+}
+
 // GetHooks returns hooks for a specific phase
 func (l *Loader) GetHooks(phase HookPhase) []Hook {
 	if l.config == nil {
@@ -182,6 +211,12 @@ func (l *Loader) GetHooks(phase HookPhase) []Hook {
 		return l.config.Hooks.PreExport
 	case PostExport:
 		return l.config.Hooks.PostExport
+	case OnReload:
+		return l.config.Hooks.OnReload
+	case OnIssueClosed:
+		return l.config.Hooks.OnIssueClosed
+	case OnNewBlocked:
+		return l.config.Hooks.OnNewBlocked
 	default:
 		return nil
 	}
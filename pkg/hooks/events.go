@@ -0,0 +1,180 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DataEvents are the changes between two loads of the beads file.
+type DataEvents struct {
+	Added    []model.Issue
+	Modified []model.Issue // Status or updated_at changed
+	Removed  []string      // IDs
+
+	Closed       []model.Issue
+	NewlyBlocked []model.Issue
+	BlockedBy    map[string][]string // Open blockers per newly blocked issue ID
+}
+
+// DetectEvents compares the issues before and after a reload. An issue is
+// closed when it was open (or absent) before, and newly blocked when it was
+// open and unblocked before and now has status blocked or an open blocker.
+func DetectEvents(before, after []model.Issue) DataEvents {
+	events := DataEvents{BlockedBy: make(map[string][]string)}
+
+	oldByID := make(map[string]model.Issue, len(before))
+	for _, issue := range before {
+		oldByID[issue.ID] = issue
+	}
+	newByID := make(map[string]model.Issue, len(after))
+	for _, issue := range after {
+		newByID[issue.ID] = issue
+	}
+
+	for _, issue := range after {
+		old, existed := oldByID[issue.ID]
+		switch {
+		case !existed:
+			events.Added = append(events.Added, issue)
+		case old.Status != issue.Status || !old.UpdatedAt.Equal(issue.UpdatedAt):
+			events.Modified = append(events.Modified, issue)
+		}
+
+		if issue.Status.IsClosed() {
+			if !existed || !old.Status.IsClosed() {
+				events.Closed = append(events.Closed, issue)
+			}
+			continue
+		}
+		if !existed || issue.Status.IsTombstone() {
+			continue
+		}
+		blockers := openBlockers(issue, newByID)
+		if issue.Status != model.StatusBlocked && len(blockers) == 0 {
+			continue
+		}
+		if old.Status == model.StatusBlocked || len(openBlockers(old, oldByID)) > 0 {
+			continue
+		}
+		events.NewlyBlocked = append(events.NewlyBlocked, issue)
+		events.BlockedBy[issue.ID] = blockers
+	}
+
+	for _, issue := range before {
+		if _, ok := newByID[issue.ID]; !ok {
+			events.Removed = append(events.Removed, issue.ID)
+		}
+	}
+	sort.Strings(events.Removed)
+	return events
+}
+
+// openBlockers returns the IDs of open issues in byID that block issue
+func openBlockers(issue model.Issue, byID map[string]model.Issue) []string {
+	var ids []string
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !blocker.Status.IsTombstone() {
+			ids = append(ids, blocker.ID)
+		}
+	}
+	return ids
+}
+
+// EventContext contains information passed to event hooks via environment
+// variables
+type EventContext struct {
+	BeadsPath  string    // BV_BEADS_PATH: The reloaded beads file
+	IssueCount int       // BV_ISSUE_COUNT: Issues after the reload
+	Timestamp  time.Time // BV_TIMESTAMP: Reload time (RFC3339)
+}
+
+// toEnv converts the event context to environment variables. issueID is set
+// for per-issue events.
+func (c EventContext) toEnv(phase HookPhase, issueID string) []string {
+	env := []string{
+		fmt.Sprintf("BV_EVENT=%s", phase),
+		fmt.Sprintf("BV_BEADS_PATH=%s", c.BeadsPath),
+		fmt.Sprintf("BV_ISSUE_COUNT=%d", c.IssueCount),
+		fmt.Sprintf("BV_TIMESTAMP=%s", c.Timestamp.Format(time.RFC3339)),
+	}
+	if issueID != "" {
+		env = append(env, fmt.Sprintf("BV_ISSUE_ID=%s", issueID))
+	}
+	return env
+}
+
+// reloadPayload is piped to on-reload hooks
+type reloadPayload struct {
+	Event      HookPhase     `json:"event"`
+	Timestamp  string        `json:"timestamp"`
+	IssueCount int           `json:"issue_count"`
+	Added      []model.Issue `json:"added"`
+	Modified   []model.Issue `json:"modified"`
+	Removed    []string      `json:"removed"`
+}
+
+// RunEventHooks runs the data event hooks for one reload: on-reload once,
+// then on-issue-closed and on-new-blocked once per affected issue. Hooks run
+// in order and a failure never stops the others.
+func RunEventHooks(config *Config, events DataEvents, ctx EventContext) []HookResult {
+	if config == nil {
+		return nil
+	}
+	var results []HookResult
+
+	if len(config.Hooks.OnReload) > 0 {
+		payload, err := json.Marshal(reloadPayload{
+			Event:      OnReload,
+			Timestamp:  ctx.Timestamp.Format(time.RFC3339),
+			IssueCount: ctx.IssueCount,
+			Added:      nonNilIssues(events.Added),
+			Modified:   nonNilIssues(events.Modified),
+			Removed:    append([]string{}, events.Removed...),
+		})
+		if err == nil {
+			for _, hook := range config.Hooks.OnReload {
+				results = append(results, runCommand(hook, OnReload, ctx.toEnv(OnReload, ""), payload))
+			}
+		}
+	}
+
+	perIssue := func(hooks []Hook, phase HookPhase, issues []model.Issue, extraEnv func(model.Issue) []string) {
+		if len(hooks) == 0 {
+			return
+		}
+		for _, issue := range issues {
+			payload, err := json.Marshal(issue)
+			if err != nil {
+				continue
+			}
+			env := ctx.toEnv(phase, issue.ID)
+			if extraEnv != nil {
+				env = append(env, extraEnv(issue)...)
+			}
+			for _, hook := range hooks {
+				results = append(results, runCommand(hook, phase, env, payload))
+			}
+		}
+	}
+	perIssue(config.Hooks.OnIssueClosed, OnIssueClosed, events.Closed, nil)
+	perIssue(config.Hooks.OnNewBlocked, OnNewBlocked, events.NewlyBlocked, func(issue model.Issue) []string {
+		return []string{"BV_BLOCKED_BY=" + strings.Join(events.BlockedBy[issue.ID], ",")}
+	})
+	return results
+}
+
+// nonNilIssues keeps empty lists as [] in JSON payloads
+func nonNilIssues(issues []model.Issue) []model.Issue {
+	if issues == nil {
+		return []model.Issue{}
+	}
+	return issues
+}
@@ -0,0 +1,168 @@
+//go:build !windows
+
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func blockedBy(id, blocker string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: id, DependsOnID: blocker, Type: model.DepBlocks}}
+}
+
+func TestDetectEvents(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	before := []model.Issue{
+		{ID: "done", Status: model.StatusInProgress, UpdatedAt: t0},
+		{ID: "api", Status: model.StatusOpen, UpdatedAt: t0},
+		{ID: "ui", Status: model.StatusOpen, UpdatedAt: t0, Dependencies: blockedBy("ui", "api")},
+		{ID: "marked", Status: model.StatusOpen, UpdatedAt: t0},
+		{ID: "same", Status: model.StatusOpen, UpdatedAt: t0},
+		{ID: "gone", Status: model.StatusOpen, UpdatedAt: t0},
+	}
+	after := []model.Issue{
+		{ID: "done", Status: model.StatusClosed, UpdatedAt: t1},
+		{ID: "api", Status: model.StatusOpen, UpdatedAt: t1, Dependencies: blockedBy("api", "new")},
+		{ID: "ui", Status: model.StatusOpen, UpdatedAt: t0, Dependencies: blockedBy("ui", "api")},
+		{ID: "marked", Status: model.StatusBlocked, UpdatedAt: t1},
+		{ID: "same", Status: model.StatusOpen, UpdatedAt: t0},
+		{ID: "new", Status: model.StatusOpen, UpdatedAt: t1},
+	}
+
+	events := DetectEvents(before, after)
+	ids := func(issues []model.Issue) string {
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := ids(events.Added); got != "new" {
+		t.Errorf("Added = %s", got)
+	}
+	if got := ids(events.Modified); got != "done,api,marked" {
+		t.Errorf("Modified = %s", got)
+	}
+	if got := strings.Join(events.Removed, ","); got != "gone" {
+		t.Errorf("Removed = %s", got)
+	}
+	if got := ids(events.Closed); got != "done" {
+		t.Errorf("Closed = %s", got)
+	}
+	// ui was already blocked by api; api and marked became blocked
+	if got := ids(events.NewlyBlocked); got != "api,marked" {
+		t.Errorf("NewlyBlocked = %s", got)
+	}
+	if got := events.BlockedBy["api"]; len(got) != 1 || got[0] != "new" {
+		t.Errorf("BlockedBy[api] = %v", got)
+	}
+}
+
+func TestRunEventHooks(t *testing.T) {
+	out := t.TempDir()
+	config := &Config{Hooks: HooksByPhase{
+		OnReload: []Hook{{Name: "reload", Command: "cat > " + filepath.Join(out, "reload.json"), Timeout: 5 * time.Second}},
+		OnIssueClosed: []Hook{
+			{Name: "closed", Command: "cat > " + filepath.Join(out, "$BV_ISSUE_ID.json"), Timeout: 5 * time.Second},
+			{Name: "broken", Command: "exit 2", Timeout: 5 * time.Second},
+		},
+		OnNewBlocked: []Hook{{Name: "blocked", Command: "echo $BV_EVENT $BV_ISSUE_ID $BV_BLOCKED_BY > " + filepath.Join(out, "blocked.txt"), Timeout: 5 * time.Second}},
+	}}
+	events := DataEvents{
+		Added:        []model.Issue{{ID: "new", Title: "New"}},
+		Closed:       []model.Issue{{ID: "done", Title: "Done", Status: model.StatusClosed}},
+		NewlyBlocked: []model.Issue{{ID: "api"}},
+		BlockedBy:    map[string][]string{"api": {"new", "db"}},
+	}
+
+	results := RunEventHooks(config, events, EventContext{BeadsPath: "/x/.beads/issues.jsonl", IssueCount: 3, Timestamp: time.Now()})
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	for _, r := range results {
+		if r.Hook.Name == "broken" {
+			if r.Success || r.Phase != OnIssueClosed {
+				t.Errorf("broken hook result = %+v", r)
+			}
+		} else if !r.Success {
+			t.Errorf("hook %s failed: %v %s", r.Hook.Name, r.Error, r.Stderr)
+		}
+	}
+
+	var reload struct {
+		Event      string        `json:"event"`
+		IssueCount int           `json:"issue_count"`
+		Added      []model.Issue `json:"added"`
+		Modified   []model.Issue `json:"modified"`
+	}
+	data, err := os.ReadFile(filepath.Join(out, "reload.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &reload); err != nil {
+		t.Fatal(err)
+	}
+	if reload.Event != "on-reload" || reload.IssueCount != 3 || len(reload.Added) != 1 || reload.Modified == nil {
+		t.Errorf("reload payload = %s", data)
+	}
+
+	var closed model.Issue
+	data, err = os.ReadFile(filepath.Join(out, "done.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &closed); err != nil || closed.ID != "done" || closed.Title != "Done" {
+		t.Errorf("closed payload = %s (%v)", data, err)
+	}
+
+	data, err = os.ReadFile(filepath.Join(out, "blocked.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "on-new-blocked api new,db" {
+		t.Errorf("blocked env = %q", got)
+	}
+}
+
+func TestLoaderEventHooks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	yaml := `hooks:
+  on-issue-closed:
+    - command: ./notify.sh
+  on-new-blocked:
+    - name: page
+      command: ./page.sh
+      timeout: 5s
+`
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "hooks.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loader := NewLoader(WithProjectDir(dir))
+	if err := loader.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if loader.HasHooks() {
+		t.Error("event hooks are not export hooks")
+	}
+	if !loader.HasEventHooks() {
+		t.Fatal("expected event hooks")
+	}
+	closed := loader.GetHooks(OnIssueClosed)
+	if len(closed) != 1 || closed[0].Name != "on-issue-closed-1" || closed[0].OnError != "continue" || closed[0].Timeout != DefaultTimeout {
+		t.Errorf("on-issue-closed hooks = %+v", closed)
+	}
+	if blocked := loader.GetHooks(OnNewBlocked); len(blocked) != 1 || blocked[0].Timeout != 5*time.Second {
+		t.Errorf("on-new-blocked hooks = %+v", blocked)
+	}
+}
@@ -101,6 +101,12 @@ func getShellCommand() (string, string) {
 
 // runHook executes a single hook with timeout and environment
 func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
+	return runCommand(hook, phase, e.context.ToEnv(), nil)
+}
+
+// runCommand runs a hook's command with the context variables in contextEnv
+// and stdin (if any) piped in
+func runCommand(hook Hook, phase HookPhase, contextEnv []string, stdin []byte) HookResult {
 	result := HookResult{
 		Hook:  hook,
 		Phase: phase,
@@ -123,8 +129,8 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 	// Build environment
 	cmd.Env = os.Environ()
 
-	// Add export context variables
-	cmd.Env = append(cmd.Env, e.context.ToEnv()...)
+	// Add export or event context variables
+	cmd.Env = append(cmd.Env, contextEnv...)
 
 	// Add hook-specific env vars (with ${VAR} expansion from current env)
 	// Sort keys for deterministic environment order
@@ -141,6 +147,10 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, expandedValue))
 	}
 
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
 	// Capture output
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
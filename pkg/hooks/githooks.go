@@ -0,0 +1,275 @@
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// gitHookMarker identifies git hooks written by InstallGitHooks
+const gitHookMarker = "# Installed by bv (beads_viewer)"
+
+// chainedSuffix is appended to hooks that existed before bv installed its
+// own; bv's hook runs them first and uninstall puts them back
+const chainedSuffix = ".pre-bv"
+
+// GitHookNames lists the git hooks managed by bv
+var GitHookNames = []string{"pre-commit", "commit-msg"}
+
+// GitHookOptions controls the hooks written by InstallGitHooks
+type GitHookOptions struct {
+	AppendBeadID bool // Also install commit-msg, appending the active bead ID to messages without one
+}
+
+// hookNames returns the hooks to install for opts
+func (o GitHookOptions) hookNames() []string {
+	if o.AppendBeadID {
+		return GitHookNames
+	}
+	return []string{"pre-commit"}
+}
+
+// GitHooksDir returns the hooks directory git uses for the repository at
+// repoPath, honoring core.hooksPath
+func GitHooksDir(repoPath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	dir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoPath, dir)
+	}
+	return dir, nil
+}
+
+// gitHookScript renders the shell script for a managed hook
+func gitHookScript(name string, opts GitHookOptions) string {
+	var run string
+	switch name {
+	case "pre-commit":
+		run = "exec bv --hook-pre-commit"
+	case "commit-msg":
+		run = `exec bv --hook-commit-msg "$1"`
+	}
+	return fmt.Sprintf(`#!/bin/sh
+%s
+# Remove with: bv hooks uninstall
+if [ -x "$0%s" ]; then
+	"$0%s" "$@" || exit $?
+fi
+if ! command -v bv >/dev/null 2>&1; then
+	echo "bv not found in PATH; skipping %s checks" >&2
+	exit 0
+fi
+%s
+`, gitHookMarker, chainedSuffix, chainedSuffix, name, run)
+}
+
+// isBVHook reports whether the file at path was written by InstallGitHooks
+func isBVHook(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && bytes.Contains(data, []byte(gitHookMarker))
+}
+
+// InstallGitHooks writes bv's pre-commit hook, and the commit-msg hook when
+// opts.AppendBeadID is set, into the repository's hooks directory and
+// returns their paths. Existing hooks not written by bv are kept next to
+// them and run first. Reinstalling replaces bv's own hooks, so it can be
+// used to change options.
+func InstallGitHooks(repoPath string, opts GitHookOptions) ([]string, error) {
+	// Start from a clean slate so dropped options remove their hooks
+	if _, err := UninstallGitHooks(repoPath); err != nil {
+		return nil, err
+	}
+	dir, err := GitHooksDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating hooks directory: %w", err)
+	}
+
+	var installed []string
+	for _, name := range opts.hookNames() {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil && !isBVHook(path) {
+			if _, err := os.Stat(path + chainedSuffix); err == nil {
+				return installed, fmt.Errorf("%s exists and so does %s; move one of them away first", path, path+chainedSuffix)
+			}
+			if err := os.Rename(path, path+chainedSuffix); err != nil {
+				return installed, fmt.Errorf("keeping existing %s hook: %w", name, err)
+			}
+		}
+		if err := os.WriteFile(path, []byte(gitHookScript(name, opts)), 0o755); err != nil {
+			return installed, fmt.Errorf("writing %s hook: %w", name, err)
+		}
+		installed = append(installed, path)
+	}
+	return installed, nil
+}
+
+// UninstallGitHooks removes the hooks written by InstallGitHooks, restoring
+// any hooks they replaced, and returns the removed paths. Hooks bv did not
+// write are left alone.
+func UninstallGitHooks(repoPath string) ([]string, error) {
+	dir, err := GitHooksDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, name := range GitHookNames {
+		path := filepath.Join(dir, name)
+		if !isBVHook(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("removing %s hook: %w", name, err)
+		}
+		removed = append(removed, path)
+		if _, err := os.Stat(path + chainedSuffix); err == nil {
+			if err := os.Rename(path+chainedSuffix, path); err != nil {
+				return removed, fmt.Errorf("restoring previous %s hook: %w", name, err)
+			}
+		}
+	}
+	return removed, nil
+}
+
+// ValidateStagedBeads checks the staged version of the beads file for
+// malformed or invalid lines and dependency cycles. It returns one message
+// per problem; nothing is checked when the beads file is not staged.
+func ValidateStagedBeads(repoPath, beadsPath string) ([]string, error) {
+	rel, err := filepath.Rel(repoPath, beadsPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("beads file %s is outside the repository", beadsPath)
+	}
+	rel = filepath.ToSlash(rel)
+
+	cmd := exec.Command("git", "diff", "--cached", "--name-only", "--diff-filter=ACMR", "--", rel)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing staged files: %w", err)
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return nil, nil
+	}
+
+	cmd = exec.Command("git", "show", ":"+rel)
+	cmd.Dir = repoPath
+	staged, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading staged %s: %w", rel, err)
+	}
+	return ValidateBeadsData(rel, staged)
+}
+
+// ValidateBeadsData checks beads JSONL content for malformed or invalid
+// lines and dependency cycles, returning one message per problem
+func ValidateBeadsData(name string, data []byte) ([]string, error) {
+	var problems []string
+	issues, err := loader.ParseIssuesWithOptions(bytes.NewReader(data), loader.ParseOptions{
+		WarningHandler: func(msg string) {
+			// Format migrations are upgrades, not errors
+			if !strings.HasPrefix(msg, "applied schema migrations") {
+				problems = append(problems, fmt.Sprintf("%s: %s", name, strings.TrimPrefix(msg, "skipping ")))
+			}
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	for _, v := range analysis.CheckCycles(issues).Violations {
+		problems = append(problems, fmt.Sprintf("%s: dependency %s", name, v.Message))
+	}
+	return problems, nil
+}
+
+// commitMsgSkip matches messages git or tools generate, which are left as is
+var commitMsgSkip = regexp.MustCompile(`^(Merge |Revert "|fixup! |squash! |amend! )`)
+
+// AppendBeadID adds a "Refs: <id>" trailer to the commit message file at
+// path unless the message already mentions a known bead ID, is empty, or
+// was generated by git. It reports whether the message was changed.
+func AppendBeadID(path, beadID string, issues []model.Issue) (bool, error) {
+	if beadID == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("reading commit message: %w", err)
+	}
+
+	// Ignore comment lines and everything below the scissors line
+	var body []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "# ------------------------ >8 ------------------------") {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			body = append(body, line)
+		}
+	}
+	message := strings.TrimSpace(strings.Join(body, "\n"))
+	if message == "" || commitMsgSkip.MatchString(message) {
+		return false, nil
+	}
+	lower := strings.ToLower(message)
+	for _, issue := range issues {
+		if mentionsID(lower, strings.ToLower(issue.ID)) {
+			return false, nil
+		}
+	}
+
+	trailer := "Refs: " + beadID
+	content := string(data)
+	// Insert before git's comment block, if any, so the trailer survives cleanup
+	insertAt := len(content)
+	if idx := strings.Index(content, "\n#"); idx >= 0 && strings.TrimSpace(content[:idx]) != "" {
+		insertAt = idx + 1
+	}
+	head := strings.TrimRight(content[:insertAt], "\n")
+	updated := head + "\n\n" + trailer + "\n"
+	if rest := strings.TrimLeft(content[insertAt:], "\n"); rest != "" {
+		updated += "\n" + rest
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return false, fmt.Errorf("writing commit message: %w", err)
+	}
+	return true, nil
+}
+
+// mentionsID reports whether text contains id as a whole word
+func mentionsID(text, id string) bool {
+	if id == "" {
+		return false
+	}
+	for start := 0; ; {
+		i := strings.Index(text[start:], id)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(id)
+		if (i == 0 || !isIDChar(text[i-1])) && (end == len(text) || !isIDChar(text[end])) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+func isIDChar(c byte) bool {
+	return c == '-' || c == '_' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,205 @@
+package hooks
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func initGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	return repo
+}
+
+func TestInstallGitHooks(t *testing.T) {
+	repo := initGitRepo(t)
+	dir, err := GitHooksDir(repo)
+	if err != nil {
+		t.Fatalf("GitHooksDir: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existing := "#!/bin/sh\necho mine\n"
+	if err := os.WriteFile(filepath.Join(dir, "pre-commit"), []byte(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := InstallGitHooks(repo, GitHookOptions{AppendBeadID: true})
+	if err != nil {
+		t.Fatalf("InstallGitHooks: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("installed %v, want pre-commit and commit-msg", paths)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "pre-commit"))
+	if !strings.Contains(string(data), "bv --hook-pre-commit") {
+		t.Errorf("pre-commit hook = %q", data)
+	}
+	if kept, _ := os.ReadFile(filepath.Join(dir, "pre-commit"+chainedSuffix)); string(kept) != existing {
+		t.Errorf("existing hook should be kept, got %q", kept)
+	}
+
+	// Reinstalling without the option drops commit-msg and keeps the chain
+	paths, err = InstallGitHooks(repo, GitHookOptions{})
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("reinstall = %v %v, want pre-commit only", paths, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "commit-msg")); !os.IsNotExist(err) {
+		t.Error("commit-msg should be removed when --append-id is dropped")
+	}
+	if kept, _ := os.ReadFile(filepath.Join(dir, "pre-commit"+chainedSuffix)); string(kept) != existing {
+		t.Errorf("existing hook should survive a reinstall, got %q", kept)
+	}
+
+	removed, err := UninstallGitHooks(repo)
+	if err != nil || len(removed) != 1 {
+		t.Fatalf("UninstallGitHooks = %v %v", removed, err)
+	}
+	if restored, _ := os.ReadFile(filepath.Join(dir, "pre-commit")); string(restored) != existing {
+		t.Errorf("existing hook should be restored, got %q", restored)
+	}
+	if removed, _ := UninstallGitHooks(repo); len(removed) != 0 {
+		t.Errorf("hooks not written by bv must be left alone, removed %v", removed)
+	}
+}
+
+func TestValidateBeadsData(t *testing.T) {
+	good := []byte(`{"id":"bv-1","title":"A","status":"open","issue_type":"task"}
+{"id":"bv-2","title":"B","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+`)
+	problems, err := ValidateBeadsData("beads.jsonl", good)
+	if err != nil || len(problems) != 0 {
+		t.Fatalf("valid file reported %v %v", problems, err)
+	}
+
+	bad := []byte(`{"id":"bv-1","title":"A","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-1","depends_on_id":"bv-2","type":"blocks"}]}
+{"id":"bv-2","title":"B","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"","status":"open","issue_type":"task"}
+not json
+`)
+	problems, err = ValidateBeadsData("beads.jsonl", bad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.Join(problems, "\n")
+	for _, want := range []string{"line 3", "line 4", "cycle"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("problems should mention %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestValidateStagedBeads(t *testing.T) {
+	repo := initGitRepo(t)
+	beadsPath := filepath.Join(repo, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beadsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(beadsPath, []byte("not json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Not staged: nothing to check
+	if problems, err := ValidateStagedBeads(repo, beadsPath); err != nil || len(problems) != 0 {
+		t.Fatalf("unstaged file reported %v %v", problems, err)
+	}
+
+	cmd := exec.Command("git", "add", ".")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git add failed: %v\n%s", err, out)
+	}
+	// The staged version is checked, not the working copy
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"bv-1","title":"A","status":"open","issue_type":"task"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := ValidateStagedBeads(repo, beadsPath)
+	if err != nil || len(problems) != 1 {
+		t.Fatalf("staged file reported %v %v, want one problem", problems, err)
+	}
+}
+
+func TestAppendBeadID(t *testing.T) {
+	issues := []model.Issue{{ID: "bv-1"}, {ID: "bv-12"}}
+	write := func(content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	path := write("Fix login\n\n# Please enter the commit message\n")
+	changed, err := AppendBeadID(path, "bv-12", issues)
+	if err != nil || !changed {
+		t.Fatalf("AppendBeadID = %v %v, want a change", changed, err)
+	}
+	got, _ := os.ReadFile(path)
+	if want := "Fix login\n\nRefs: bv-12\n\n# Please enter the commit message\n"; string(got) != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+
+	for _, msg := range []string{
+		"Fix login (BV-1)\n",
+		"Merge branch 'main'\n",
+		"fixup! Fix login\n",
+		"# only comments\n",
+	} {
+		path := write(msg)
+		if changed, err := AppendBeadID(path, "bv-12", issues); err != nil || changed {
+			t.Errorf("AppendBeadID(%q) = %v %v, want no change", msg, changed, err)
+		}
+	}
+
+	// bv-1 inside bv-12 is not a mention of bv-1
+	path = write("Fix bv-123 follow-up\n")
+	if changed, _ := AppendBeadID(path, "bv-1", issues); !changed {
+		t.Error("a longer ID should not count as a mention")
+	}
+}
+
+func TestInstallMergeDriver(t *testing.T) {
+	repo := initGitRepo(t)
+	beadsDir := filepath.Join(repo, "svc", ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitattributes"), []byte("*.png binary"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	line, changed, err := InstallMergeDriver(filepath.Join(repo, "svc"), beadsDir)
+	if err != nil {
+		t.Fatalf("InstallMergeDriver: %v", err)
+	}
+	if line != "svc/.beads/*.jsonl merge=beads" || !changed {
+		t.Errorf("line = %q, changed = %v", line, changed)
+	}
+	if data, _ := os.ReadFile(filepath.Join(repo, ".gitattributes")); string(data) != "*.png binary\n"+line+"\n" {
+		t.Errorf(".gitattributes = %q", data)
+	}
+	cmd := exec.Command("git", "config", "merge.beads.driver")
+	cmd.Dir = repo
+	if out, err := cmd.Output(); err != nil || strings.TrimSpace(string(out)) != MergeDriverCommand {
+		t.Errorf("merge.beads.driver = %q, %v", out, err)
+	}
+
+	if _, changed, err := InstallMergeDriver(repo, beadsDir); err != nil || changed {
+		t.Errorf("reinstall changed .gitattributes (%v, %v)", changed, err)
+	}
+}
@@ -0,0 +1,87 @@
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MergeDriverName is the git merge driver bv registers ("merge=beads")
+const MergeDriverName = "beads"
+
+// MergeDriverCommand is how git runs the driver: base, ours (where the
+// result goes) and theirs
+const MergeDriverCommand = "bv merge-driver %O %A %B"
+
+// InstallMergeDriver registers bv's merge driver in the repository's git
+// config and routes the JSONL files of beadsDir to it in the .gitattributes
+// at the top of the work tree. It returns the attributes line and whether
+// .gitattributes had to be changed; running it again changes nothing.
+func InstallMergeDriver(repoPath, beadsDir string) (string, bool, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	top := strings.TrimSpace(string(out))
+
+	for key, value := range map[string]string{
+		"merge." + MergeDriverName + ".name":   "bv three-way merge for beads JSONL",
+		"merge." + MergeDriverName + ".driver": MergeDriverCommand,
+	} {
+		cmd := exec.Command("git", "config", key, value)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", false, fmt.Errorf("git config %s: %v: %s", key, err, bytes.TrimSpace(out))
+		}
+	}
+
+	rel, err := relativeTo(top, beadsDir)
+	if err != nil {
+		return "", false, err
+	}
+	line := filepath.ToSlash(filepath.Join(rel, "*.jsonl")) + " merge=" + MergeDriverName
+
+	path := filepath.Join(top, ".gitattributes")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", false, fmt.Errorf("reading .gitattributes: %w", err)
+	}
+	for _, existing := range strings.Split(string(data), "\n") {
+		if strings.Join(strings.Fields(existing), " ") == line {
+			return line, false, nil
+		}
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, line+"\n"...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", false, fmt.Errorf("writing .gitattributes: %w", err)
+	}
+	return line, true, nil
+}
+
+// relativeTo returns dir relative to the work tree top, resolving symlinks
+// on both so temp directories and the like compare equal
+func relativeTo(top, dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(top); err == nil {
+		top = resolved
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the work tree %s", dir, top)
+	}
+	return rel, nil
+}
@@ -0,0 +1,180 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ArchiveFileName holds old closed issues moved out of the beads file by
+// bv archive. It sits next to the beads file and is only read on request.
+const ArchiveFileName = "archive.jsonl"
+
+// ArchivePath returns the archive file next to the beads file at path.
+func ArchivePath(path string) string {
+	return filepath.Join(filepath.Dir(path), ArchiveFileName)
+}
+
+// LoadArchive reads the archived issues in beadsDir.
+// Missing file is treated as "nothing archived" (empty slice, nil error).
+func LoadArchive(beadsDir string, opts ParseOptions) ([]model.Issue, error) {
+	path := filepath.Join(beadsDir, ArchiveFileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return []model.Issue{}, nil
+	}
+	return loadIssuesFile(path, opts)
+}
+
+// withArchive appends the archived issues next to path to issues. An issue
+// that is in both keeps its copy in the beads file. An unreadable archive is
+// reported as a warning and leaves issues untouched.
+func withArchive(path string, issues []model.Issue, opts ParseOptions) []model.Issue {
+	archived, err := LoadArchive(filepath.Dir(path), opts)
+	if err != nil {
+		if opts.WarningHandler != nil {
+			opts.WarningHandler(err.Error())
+		} else if os.Getenv("BV_ROBOT") != "1" {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return issues
+	}
+	seen := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		seen[issue.ID] = true
+	}
+	for _, issue := range archived {
+		if !seen[issue.ID] {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// ArchivedIssue is one issue an archive plan moves.
+type ArchivedIssue struct {
+	ID       string
+	Title    string
+	ClosedAt time.Time
+	Path     string // The beads file (or shard) it is moved out of
+}
+
+// ArchivePlan is an archive run worked out against the current files.
+// Nothing is written until Apply.
+type ArchivePlan struct {
+	ArchivePath string
+	Issues      []ArchivedIssue
+
+	files   []renamedFile
+	archive []byte // Current archive contents; nil when there is none yet
+	moved   []byte // Lines appended to the archive
+}
+
+// PlanArchive plans moving the issues closed before cutoff out of the beads
+// file at path (and the uncompressed shards next to it) into the archive.
+// Issues without closed_at count as closed when they were last updated.
+func PlanArchive(path string, cutoff time.Time) (*ArchivePlan, error) {
+	if IsCompressedJSONL(path) {
+		return nil, ErrCompressedReadOnly
+	}
+	plan := &ArchivePlan{ArchivePath: ArchivePath(path)}
+	archive, err := os.ReadFile(plan.ArchivePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	plan.archive = archive
+
+	var moved bytes.Buffer
+	for _, p := range EditableFiles(path) {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		lines := bytes.Split(data, []byte("\n"))
+		kept := lines[:0:0]
+		for i, line := range lines {
+			body := line
+			if i == 0 {
+				body = stripBOM(body)
+			}
+			issue, ok := archivable(bytes.TrimSpace(body), cutoff)
+			if !ok {
+				kept = append(kept, line)
+				continue
+			}
+			issue.Path = p
+			plan.Issues = append(plan.Issues, issue)
+			moved.Write(bytes.TrimSpace(body))
+			moved.WriteByte('\n')
+		}
+		if len(kept) < len(lines) {
+			plan.files = append(plan.files, renamedFile{path: p, data: data, out: bytes.Join(kept, []byte("\n"))})
+		}
+	}
+	plan.moved = moved.Bytes()
+	return plan, nil
+}
+
+// archivable reports whether the raw issue line is closed and was closed
+// before cutoff
+func archivable(line []byte, cutoff time.Time) (ArchivedIssue, bool) {
+	if len(line) == 0 || line[0] != '{' {
+		return ArchivedIssue{}, false
+	}
+	var issue model.Issue
+	if json.Unmarshal(line, &issue) != nil || issue.ID == "" || !issue.Status.IsClosed() {
+		return ArchivedIssue{}, false
+	}
+	closed := issue.UpdatedAt
+	if issue.ClosedAt != nil {
+		closed = *issue.ClosedAt
+	}
+	if closed.IsZero() || !closed.Before(cutoff) {
+		return ArchivedIssue{}, false
+	}
+	return ArchivedIssue{ID: issue.ID, Title: issue.Title, ClosedAt: closed}, true
+}
+
+// Apply moves the planned issues. The beads files and the archive must be
+// unchanged since the plan was made. The archive is written first, so an
+// interrupted run leaves an issue in both files rather than in neither;
+// loading with IncludeArchived prefers the beads file's copy.
+func (p *ArchivePlan) Apply() error {
+	if len(p.Issues) == 0 {
+		return nil
+	}
+	archive, err := os.ReadFile(p.ArchivePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	if !bytes.Equal(archive, p.archive) {
+		return fmt.Errorf("%s changed since the archive was planned; run it again", ArchiveFileName)
+	}
+	if err := checkUnchanged(p.files, "archive"); err != nil {
+		return err
+	}
+
+	out := append([]byte{}, p.archive...)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		out = append(out, '\n')
+	}
+	out = append(out, p.moved...)
+	if p.archive == nil {
+		err = os.WriteFile(p.ArchivePath, out, 0644)
+	} else {
+		err = writeFileAtomic(p.ArchivePath, out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	for _, f := range p.files {
+		if err := writeFileAtomic(f.path, f.out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestPlanArchive(t *testing.T) {
+	dir := t.TempDir()
+	path := writeShard(t, dir, "beads.jsonl",
+		`{"id":"bv-1","title":"Old","status":"closed","issue_type":"task","closed_at":"2024-01-01T00:00:00Z"}`,
+		`{"id":"bv-2","title":"Recent","status":"closed","issue_type":"task","closed_at":"2024-06-01T00:00:00Z"}`,
+		`{"id":"bv-3","title":"Open","status":"open","issue_type":"task","updated_at":"2023-01-01T00:00:00Z"}`,
+		`{"id":"bv-4","title":"No closed_at","status":"closed","issue_type":"task","updated_at":"2023-01-01T00:00:00Z"}`)
+	cutoff := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	plan, err := loader.PlanArchive(path, cutoff)
+	if err != nil {
+		t.Fatalf("PlanArchive() error = %v", err)
+	}
+	var ids []string
+	for _, issue := range plan.Issues {
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "bv-1,bv-4" {
+		t.Fatalf("archived = %s, want bv-1,bv-4", got)
+	}
+	if _, err := os.Stat(plan.ArchivePath); !os.IsNotExist(err) {
+		t.Fatal("planning wrote the archive")
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ID != "bv-2" || issues[1].ID != "bv-3" {
+		t.Errorf("beads file after archiving = %+v", issues)
+	}
+	all, err := loader.LoadIssuesFromFileWithOptions(path, loader.ParseOptions{IncludeArchived: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 {
+		t.Errorf("with archive: got %d issues, want 4", len(all))
+	}
+
+	// The archive is never picked as the beads file
+	if found, err := loader.FindJSONLPath(dir); err != nil || found != path {
+		t.Errorf("FindJSONLPath() = %s, %v", found, err)
+	}
+
+	// A second run appends to the archive
+	plan, err = loader.PlanArchive(path, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Issues) != 1 || plan.Issues[0].ID != "bv-2" {
+		t.Fatalf("second run = %+v", plan.Issues)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	archived, err := loader.LoadArchive(dir, loader.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 3 {
+		t.Errorf("archive holds %d issues, want 3", len(archived))
+	}
+}
+
+func TestArchive_BeadsFileWins(t *testing.T) {
+	dir := t.TempDir()
+	path := writeShard(t, dir, "beads.jsonl", `{"id":"bv-1","title":"Reopened","status":"open","issue_type":"task"}`)
+	writeShard(t, dir, loader.ArchiveFileName,
+		`{"id":"bv-1","title":"Archived copy","status":"closed","issue_type":"task"}`,
+		`{"id":"bv-2","title":"Archived","status":"closed","issue_type":"task"}`)
+
+	issues, err := loader.LoadIssuesFromFileWithOptions(path, loader.ParseOptions{IncludeArchived: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].Title != "Reopened" || issues[1].ID != "bv-2" {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestArchivePlan_RefusesChangedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeShard(t, dir, "beads.jsonl", `{"id":"bv-1","title":"Old","status":"closed","issue_type":"task","closed_at":"2024-01-01T00:00:00Z"}`)
+	plan, err := loader.PlanArchive(path, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(); err == nil {
+		t.Error("Apply() should refuse a file changed since planning")
+	}
+	if _, err := os.Stat(filepath.Join(dir, loader.ArchiveFileName)); !os.IsNotExist(err) {
+		t.Error("archive written despite the refusal")
+	}
+}
@@ -0,0 +1,120 @@
+package loader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// compressedExts are the compression suffixes accepted after ".jsonl", in
+// order of preference when no uncompressed file exists.
+var compressedExts = []string{".gz", ".zst"}
+
+// ErrCompressedReadOnly is returned when asked to write to a compressed beads file.
+var ErrCompressedReadOnly = errors.New("compressed beads files are read-only; decompress the file to edit issues")
+
+// IsCompressedJSONL reports whether path names a gzip or zstd compressed JSONL file.
+func IsCompressedJSONL(path string) bool {
+	return trimCompressedExt(path) != path
+}
+
+// trimCompressedExt returns name without its compression suffix, if any.
+func trimCompressedExt(name string) string {
+	for _, ext := range compressedExts {
+		if strings.HasSuffix(name, ".jsonl"+ext) {
+			return strings.TrimSuffix(name, ext)
+		}
+	}
+	return name
+}
+
+// OpenJSONL opens a beads JSONL file for reading. Files ending in .jsonl.gz
+// are decompressed on the fly; .jsonl.zst files are piped through the zstd
+// command, which must be on PATH.
+func OpenJSONL(path string) (io.ReadCloser, error) {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("reading gzip header of %s: %w", path, err)
+		}
+		return &gzipFile{Reader: zr, file: file}, nil
+	case strings.HasSuffix(path, ".zst"):
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+		return openZstd(path)
+	default:
+		return os.Open(path)
+	}
+}
+
+// gzipFile closes both the gzip stream and the underlying file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	err := g.Reader.Close()
+	if cerr := g.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// zstdPipe streams the output of `zstd -dc` and reports a failed
+// decompression as a read error instead of a silently truncated file.
+type zstdPipe struct {
+	out    io.ReadCloser
+	cmd    *exec.Cmd
+	stderr bytes.Buffer
+	done   bool
+}
+
+func openZstd(path string) (io.ReadCloser, error) {
+	bin, err := exec.LookPath("zstd")
+	if err != nil {
+		return nil, fmt.Errorf("reading %s needs the zstd command: %w", path, err)
+	}
+	p := &zstdPipe{cmd: exec.Command(bin, "-dc", "--", path)}
+	p.cmd.Stderr = &p.stderr
+	if p.out, err = p.cmd.StdoutPipe(); err != nil {
+		return nil, fmt.Errorf("starting zstd: %w", err)
+	}
+	if err := p.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting zstd: %w", err)
+	}
+	return p, nil
+}
+
+func (p *zstdPipe) Read(b []byte) (int, error) {
+	n, err := p.out.Read(b)
+	if err == io.EOF && !p.done {
+		p.done = true
+		if werr := p.cmd.Wait(); werr != nil {
+			return n, fmt.Errorf("zstd: %w: %s", werr, strings.TrimSpace(p.stderr.String()))
+		}
+	}
+	return n, err
+}
+
+func (p *zstdPipe) Close() error {
+	if p.done {
+		return nil
+	}
+	p.done = true
+	_ = p.cmd.Process.Kill()
+	_ = p.cmd.Wait()
+	return nil
+}
@@ -0,0 +1,124 @@
+package loader_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const compressFixture = `{"id":"Z-1","title":"Zipped","status":"open","issue_type":"task"}` + "\n" +
+	`{"id":"Z-2","title":"Also zipped","status":"closed","issue_type":"bug"}` + "\n"
+
+func writeGzip(t *testing.T, path, content string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadIssuesFromFile_Gzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl.gz")
+	writeGzip(t, path, compressFixture)
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadIssuesFromFile() error = %v", err)
+	}
+	if len(issues) != 2 || issues[1].Title != "Also zipped" {
+		t.Errorf("unexpected issues %+v", issues)
+	}
+
+	// Lazy loading falls back to a full load without an index
+	lazy, index, err := loader.LoadIssuesLazy(path, loader.ParseOptions{})
+	if err != nil || index != nil || len(lazy) != 2 {
+		t.Errorf("LoadIssuesLazy() = %d issues, index %v, err %v", len(lazy), index, err)
+	}
+
+	if err := os.WriteFile(path, []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.LoadIssuesFromFile(path); err == nil {
+		t.Error("expected an error for a corrupt gzip file")
+	}
+}
+
+func TestLoadIssuesFromFile_Zstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd command not available")
+	}
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(plain, []byte(compressFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("zstd", "-q", "--rm", plain).CombinedOutput(); err != nil {
+		t.Fatalf("zstd: %v: %s", err, out)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(plain + ".zst")
+	if err != nil {
+		t.Fatalf("LoadIssuesFromFile() error = %v", err)
+	}
+	if len(issues) != 2 || issues[0].ID != "Z-1" {
+		t.Errorf("unexpected issues %+v", issues)
+	}
+
+	if err := os.WriteFile(plain+".zst", []byte("not zstd"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.LoadIssuesFromFile(plain + ".zst"); err == nil {
+		t.Error("expected an error for a corrupt zstd file")
+	}
+}
+
+func TestFindJSONLPath_Compressed(t *testing.T) {
+	dir := t.TempDir()
+	writeGzip(t, filepath.Join(dir, "beads.jsonl.gz"), compressFixture)
+	writeGzip(t, filepath.Join(dir, "deletions.jsonl.gz"), compressFixture)
+
+	if path, err := loader.FindJSONLPath(dir); err != nil || filepath.Base(path) != "beads.jsonl.gz" {
+		t.Errorf("FindJSONLPath() = %s, %v; want beads.jsonl.gz", path, err)
+	}
+
+	writeGzip(t, filepath.Join(dir, "issues.jsonl.gz"), compressFixture)
+	if path, _ := loader.FindJSONLPath(dir); filepath.Base(path) != "issues.jsonl.gz" {
+		t.Errorf("FindJSONLPath() = %s, want issues.jsonl.gz", path)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "issues.jsonl"), []byte(compressFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path, _ := loader.FindJSONLPath(dir); filepath.Base(path) != "issues.jsonl" {
+		t.Errorf("FindJSONLPath() = %s, want the uncompressed issues.jsonl", path)
+	}
+}
+
+func TestCompressedFilesAreReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl.gz")
+	writeGzip(t, path, compressFixture)
+
+	if err := loader.UpdateIssueInFile(path, model.Issue{ID: "Z-1", Title: "x"}); !errors.Is(err, loader.ErrCompressedReadOnly) {
+		t.Errorf("UpdateIssueInFile() error = %v, want ErrCompressedReadOnly", err)
+	}
+	if err := loader.AppendIssuesToFile(path, []model.Issue{{ID: "Z-3"}}); !errors.Is(err, loader.ErrCompressedReadOnly) {
+		t.Errorf("AppendIssuesToFile() error = %v, want ErrCompressedReadOnly", err)
+	}
+	if !loader.IsCompressedJSONL(path) || loader.IsCompressedJSONL("issues.jsonl") || loader.IsCompressedJSONL("notes.gz") {
+		t.Error("IsCompressedJSONL() misclassified a path")
+	}
+}
@@ -0,0 +1,138 @@
+package loader
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DeletionsFileName is the manifest bd writes when issues are deleted.
+const DeletionsFileName = "deletions.jsonl"
+
+// Deletion is one entry of the deletions manifest.
+type Deletion struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"ts"`
+	By        string    `json:"by,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// LoadDeletions reads the deletions manifest in beadsDir.
+// Missing file is treated as "no deletions" (empty slice, nil error).
+func LoadDeletions(beadsDir string) ([]Deletion, error) {
+	return LoadDeletionsFromFile(filepath.Join(beadsDir, DeletionsFileName))
+}
+
+// LoadDeletionsFromFile reads a deletions manifest from a specific path.
+// Missing file is treated as "no deletions" (empty slice, nil error).
+func LoadDeletionsFromFile(path string) ([]Deletion, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return []Deletion{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open deletions file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseDeletions(file)
+}
+
+// ParseDeletions parses a deletions manifest. Malformed lines and entries
+// without an ID are skipped with warnings, like ParseSprints. A later entry
+// for the same ID replaces an earlier one.
+func ParseDeletions(r io.Reader) ([]Deletion, error) {
+	warn := func(msg string) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+	if os.Getenv("BV_ROBOT") == "1" {
+		warn = func(string) {}
+	}
+
+	scanner := bufio.NewScanner(r)
+	const maxCapacity = 1024 * 1024 // 1MB
+	scanner.Buffer(make([]byte, 64*1024), maxCapacity)
+
+	var deletions []Deletion
+	index := make(map[string]int)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if lineNum == 1 {
+			line = stripBOM(line)
+		}
+		if len(line) == 0 {
+			continue
+		}
+
+		var d Deletion
+		if err := json.Unmarshal(line, &d); err != nil {
+			warn(fmt.Sprintf("skipping malformed deletion JSON on line %d: %v", lineNum, err))
+			continue
+		}
+		if d.ID == "" {
+			warn(fmt.Sprintf("skipping deletion without id on line %d", lineNum))
+			continue
+		}
+		if i, ok := index[d.ID]; ok {
+			deletions[i] = d
+			continue
+		}
+		index[d.ID] = len(deletions)
+		deletions = append(deletions, d)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading deletions stream: %w", err)
+	}
+
+	return deletions, nil
+}
+
+// ApplyDeletions applies tombstones to issues. An issue is deleted when its
+// status is already tombstone, or when the manifest lists it and it was not
+// updated after the deletion (a re-created issue survives). Deleted issues
+// are dropped, or kept with StatusTombstone when keep is true.
+func ApplyDeletions(issues []model.Issue, deletions []Deletion, keep bool) []model.Issue {
+	deletedAt := make(map[string]time.Time, len(deletions))
+	for _, d := range deletions {
+		deletedAt[d.ID] = d.Timestamp
+	}
+
+	result := issues[:0]
+	for _, issue := range issues {
+		ts, listed := deletedAt[issue.ID]
+		deleted := issue.Status.IsTombstone() ||
+			(listed && (ts.IsZero() || !issue.UpdatedAt.After(ts)))
+		if !deleted {
+			result = append(result, issue)
+			continue
+		}
+		if keep {
+			issue.Status = model.StatusTombstone
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// applyDeletionsFor applies the manifest next to path. An unreadable
+// manifest is reported as a warning and leaves issues untouched.
+func applyDeletionsFor(path string, issues []model.Issue, opts ParseOptions) []model.Issue {
+	deletions, err := LoadDeletions(filepath.Dir(path))
+	if err != nil {
+		if opts.WarningHandler != nil {
+			opts.WarningHandler(err.Error())
+		} else if os.Getenv("BV_ROBOT") != "1" {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	return ApplyDeletions(issues, deletions, opts.IncludeDeleted)
+}
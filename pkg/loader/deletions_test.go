@@ -0,0 +1,95 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestParseDeletions(t *testing.T) {
+	input := `{"id":"A","ts":"2025-01-01T00:00:00Z","by":"alice","reason":"dup"}` + "\n" +
+		"not json\n" +
+		`{"ts":"2025-01-01T00:00:00Z"}` + "\n" +
+		`{"id":"A","ts":"2025-03-01T00:00:00Z","by":"bob"}` + "\n"
+
+	deletions, err := loader.ParseDeletions(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDeletions() error = %v", err)
+	}
+	if len(deletions) != 1 {
+		t.Fatalf("expected 1 deletion, got %d", len(deletions))
+	}
+	if deletions[0].By != "bob" || deletions[0].Timestamp.Month() != time.March {
+		t.Errorf("later entry should win, got %+v", deletions[0])
+	}
+}
+
+func TestLoadDeletions_Missing(t *testing.T) {
+	deletions, err := loader.LoadDeletions(t.TempDir())
+	if err != nil || len(deletions) != 0 {
+		t.Errorf("LoadDeletions() = %v, %v; want empty, nil", deletions, err)
+	}
+}
+
+func TestApplyDeletions(t *testing.T) {
+	deletedAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "keep", Status: model.StatusOpen},
+		{ID: "gone", Status: model.StatusOpen, UpdatedAt: deletedAt.Add(-time.Hour)},
+		{ID: "recreated", Status: model.StatusOpen, UpdatedAt: deletedAt.Add(time.Hour)},
+		{ID: "tomb", Status: model.StatusTombstone},
+	}
+	deletions := []loader.Deletion{
+		{ID: "gone", Timestamp: deletedAt},
+		{ID: "recreated", Timestamp: deletedAt},
+	}
+
+	hidden := loader.ApplyDeletions(append([]model.Issue(nil), issues...), deletions, false)
+	var ids []string
+	for _, issue := range hidden {
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "keep,recreated" {
+		t.Errorf("ApplyDeletions(keep=false) = %s, want keep,recreated", got)
+	}
+
+	shown := loader.ApplyDeletions(append([]model.Issue(nil), issues...), deletions, true)
+	if len(shown) != 4 || shown[1].Status != model.StatusTombstone {
+		t.Errorf("ApplyDeletions(keep=true) should mark deleted issues as tombstones, got %+v", shown)
+	}
+}
+
+func TestLoadIssuesFromFile_AppliesDeletions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beads.jsonl")
+	data := `{"id":"A","title":"a","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"B","title":"b","status":"open","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"id":"B","ts":"2025-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, loader.DeletionsFileName), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadIssuesFromFile() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "A" {
+		t.Errorf("expected only A, got %+v", issues)
+	}
+
+	issues, err = loader.LoadIssuesFromFileWithOptions(path, loader.ParseOptions{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("LoadIssuesFromFileWithOptions() error = %v", err)
+	}
+	if len(issues) != 2 || !issues[1].Status.IsTombstone() {
+		t.Errorf("expected B as a tombstone, got %+v", issues)
+	}
+}
@@ -0,0 +1,336 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DuplicateCopy is one of several lines in a beads file that share an ID.
+type DuplicateCopy struct {
+	Line  int          // 1-based line number in the file
+	Issue *model.Issue // nil when the line does not decode as an issue
+	raw   []byte
+}
+
+// DuplicateGroup is the lines sharing one ID, in file order.
+type DuplicateGroup struct {
+	ID        string
+	Copies    []DuplicateCopy
+	Identical bool // Every copy is the same JSON (key order and whitespace aside)
+}
+
+// DuplicateSet is a beads file together with the IDs it repeats.
+type DuplicateSet struct {
+	Path   string
+	Groups []DuplicateGroup
+
+	data []byte   // Contents of Path when loaded
+	ids  []string // Every issue ID in the file, for picking new ones
+}
+
+// DuplicateAction is how Resolve fixes one DuplicateGroup.
+type DuplicateAction int
+
+const (
+	// DuplicateSkip leaves the copies as they are.
+	DuplicateSkip DuplicateAction = iota
+	// DuplicateMerge keeps one issue: the fields of the kept copy, plus the
+	// labels, dependencies and comments of all copies.
+	DuplicateMerge
+	// DuplicateReID keeps the ID on one copy and gives every other copy a
+	// fresh ID in the same prefix.
+	DuplicateReID
+)
+
+// DuplicateFix resolves one DuplicateGroup.
+type DuplicateFix struct {
+	Action DuplicateAction
+	Keep   int // Index of the copy whose fields win (merge) or that keeps the ID (re-ID)
+}
+
+// DuplicateResult reports what Resolve changed.
+type DuplicateResult struct {
+	Merged  []string            // IDs now carried by a single merged issue
+	Renamed map[string][]string // Duplicated ID -> new IDs of the re-IDed copies, in file order
+}
+
+// FindDuplicates reads the beads file at path and groups the issue lines
+// that share an ID, e.g. after a merge that kept both sides of a hunk.
+func FindDuplicates(path string) (*DuplicateSet, error) {
+	if IsCompressedJSONL(path) {
+		return nil, ErrCompressedReadOnly
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read beads file: %w", err)
+	}
+
+	set := &DuplicateSet{Path: path, data: data}
+	index := make(map[string]int)
+	var groups []DuplicateGroup
+	for i, line := range bytes.Split(stripBOM(data), []byte("\n")) {
+		id, body := issueLineID(line)
+		if id == "" {
+			continue
+		}
+		dup := DuplicateCopy{Line: i + 1, raw: body}
+		var issue model.Issue
+		if json.Unmarshal(body, &issue) == nil {
+			dup.Issue = &issue
+		}
+		g, ok := index[id]
+		if !ok {
+			g = len(groups)
+			index[id] = g
+			groups = append(groups, DuplicateGroup{ID: id})
+			set.ids = append(set.ids, id)
+		}
+		groups[g].Copies = append(groups[g].Copies, dup)
+	}
+
+	for _, g := range groups {
+		if len(g.Copies) < 2 {
+			continue
+		}
+		g.Identical = true
+		first := canonicalJSON(g.Copies[0].raw)
+		for _, c := range g.Copies[1:] {
+			if canonicalJSON(c.raw) != first {
+				g.Identical = false
+				break
+			}
+		}
+		set.Groups = append(set.Groups, g)
+	}
+	return set, nil
+}
+
+// canonicalJSON re-encodes raw with sorted keys and no whitespace
+func canonicalJSON(raw []byte) string {
+	var obj any
+	if json.Unmarshal(raw, &obj) != nil {
+		return string(raw)
+	}
+	canon, err := json.Marshal(obj)
+	if err != nil {
+		return string(raw)
+	}
+	return string(canon)
+}
+
+// Resolve rewrites the beads file with fixes applied; groups without a fix
+// are left alone. A re-IDed copy has its own dependency and comment
+// references moved to the new ID; dependencies on the old ID from other
+// issues keep pointing at the copy that kept it. New IDs avoid every ID in
+// the file and reserved (such as deleted IDs). The write is atomic and
+// fails if the file changed since FindDuplicates.
+func (s *DuplicateSet) Resolve(fixes map[string]DuplicateFix, reserved []string) (DuplicateResult, error) {
+	result := DuplicateResult{Renamed: make(map[string][]string)}
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read beads file: %w", err)
+	}
+	if !bytes.Equal(data, s.data) {
+		return result, fmt.Errorf("%s changed since duplicates were found; run again", filepath.Base(s.Path))
+	}
+
+	taken := make([]model.Issue, 0, len(s.ids))
+	for _, id := range s.ids {
+		taken = append(taken, model.Issue{ID: id})
+	}
+
+	// replace maps a 1-based line number to its new content; nil drops it
+	replace := make(map[int][]byte)
+	for _, g := range s.Groups {
+		fix, ok := fixes[g.ID]
+		if !ok || fix.Action == DuplicateSkip {
+			continue
+		}
+		if fix.Keep < 0 || fix.Keep >= len(g.Copies) {
+			return result, fmt.Errorf("%s: no copy %d to keep", g.ID, fix.Keep+1)
+		}
+
+		switch fix.Action {
+		case DuplicateMerge:
+			merged, err := mergeDuplicateCopies(g, fix.Keep)
+			if err != nil {
+				return result, fmt.Errorf("failed to merge %s: %w", g.ID, err)
+			}
+			for i, c := range g.Copies {
+				replace[c.Line] = nil
+				if i == fix.Keep {
+					replace[c.Line] = merged
+				}
+			}
+			result.Merged = append(result.Merged, g.ID)
+
+		case DuplicateReID:
+			prefix := IssuePrefix([]model.Issue{{ID: g.ID}}, IssuePrefix(taken, ""))
+			newIDs := NewIssueIDs(taken, prefix, len(g.Copies)-1, reserved)
+			next := 0
+			for i, c := range g.Copies {
+				if i == fix.Keep {
+					continue
+				}
+				renamed, err := renameIssueLine(c.raw, g.ID, newIDs[next])
+				if err != nil {
+					return result, fmt.Errorf("failed to re-ID %s: %w", g.ID, err)
+				}
+				replace[c.Line] = renamed
+				taken = append(taken, model.Issue{ID: newIDs[next]})
+				next++
+			}
+			result.Renamed[g.ID] = newIDs
+
+		default:
+			return result, fmt.Errorf("%s: unknown duplicate action %d", g.ID, fix.Action)
+		}
+	}
+	if len(replace) == 0 {
+		return result, nil
+	}
+
+	bom := data[:len(data)-len(stripBOM(data))]
+	lines := bytes.Split(stripBOM(data), []byte("\n"))
+	out := append([]byte{}, bom...)
+	for i, line := range lines {
+		updated, ok := replace[i+1]
+		switch {
+		case !ok:
+			out = append(out, line...)
+		case updated == nil:
+			continue
+		default:
+			out = append(out, updated...)
+			if bytes.HasSuffix(line, []byte("\r")) {
+				out = append(out, '\r')
+			}
+		}
+		if i < len(lines)-1 {
+			out = append(out, '\n')
+		}
+	}
+	return result, writeFileAtomic(s.Path, out)
+}
+
+// mergeDuplicateCopies returns the kept copy of g with the labels,
+// dependencies and comments of every copy, each listed once
+func mergeDuplicateCopies(g DuplicateGroup, keep int) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(g.Copies[keep].raw, &obj); err != nil {
+		return nil, err
+	}
+	order := []int{keep}
+	for i := range g.Copies {
+		if i != keep {
+			order = append(order, i)
+		}
+	}
+
+	var labels []string
+	seenLabel := make(map[string]bool)
+	var deps, comments []json.RawMessage
+	seenDep := make(map[string]bool)
+	seenComment := make(map[string]bool)
+	changed := false
+	for n, i := range order {
+		var fields struct {
+			Labels       []string          `json:"labels"`
+			Dependencies []json.RawMessage `json:"dependencies"`
+			Comments     []json.RawMessage `json:"comments"`
+		}
+		if err := json.Unmarshal(g.Copies[i].raw, &fields); err != nil {
+			continue
+		}
+		for _, label := range fields.Labels {
+			if !seenLabel[label] {
+				seenLabel[label] = true
+				labels = append(labels, label)
+				changed = changed || n > 0
+			}
+		}
+		for _, raw := range fields.Dependencies {
+			var dep struct {
+				DependsOnID string `json:"depends_on_id"`
+				Type        string `json:"type"`
+			}
+			if json.Unmarshal(raw, &dep) != nil || dep.DependsOnID == g.ID {
+				continue
+			}
+			key := dep.DependsOnID + "\x00" + dep.Type
+			if !seenDep[key] {
+				seenDep[key] = true
+				deps = append(deps, raw)
+				changed = changed || n > 0
+			}
+		}
+		for _, raw := range fields.Comments {
+			var c struct {
+				Author string `json:"author"`
+				Text   string `json:"text"`
+			}
+			if json.Unmarshal(raw, &c) != nil {
+				continue
+			}
+			key := c.Author + "\x00" + c.Text
+			if !seenComment[key] {
+				seenComment[key] = true
+				comments = append(comments, raw)
+				changed = changed || n > 0
+			}
+		}
+	}
+	if !changed {
+		return g.Copies[keep].raw, nil
+	}
+
+	for key, v := range map[string]any{"labels": labels, "dependencies": deps, "comments": comments} {
+		b, err := marshalNoEscape(v)
+		if err != nil {
+			return nil, err
+		}
+		if string(b) == "null" {
+			continue
+		}
+		obj[key] = b
+	}
+	return marshalIssue(obj)
+}
+
+// renameIssueLine gives a raw issue line newID, moving the issue_id of its
+// dependencies and comments along with it
+func renameIssueLine(raw []byte, oldID, newID string) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+	id, err := marshalNoEscape(newID)
+	if err != nil {
+		return nil, err
+	}
+	obj["id"] = id
+
+	for _, key := range []string{"dependencies", "comments"} {
+		var items []map[string]json.RawMessage
+		if obj[key] == nil || json.Unmarshal(obj[key], &items) != nil {
+			continue
+		}
+		for _, item := range items {
+			var owner string
+			if json.Unmarshal(item["issue_id"], &owner) == nil && owner == oldID {
+				item["issue_id"] = id
+			}
+		}
+		b, err := marshalNoEscape(items)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = b
+	}
+	return marshalIssue(obj)
+}
@@ -0,0 +1,125 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+const duplicatesFixture = `{"id":"bv-1","title":"Login","status":"open","issue_type":"task","labels":["auth"],"comments":[{"issue_id":"bv-1","author":"ann","text":"first"}]}
+{"id":"bv-2","title":"Depends on login","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-1","title":"Login page","status":"in_progress","issue_type":"task","labels":["ui","auth"],"dependencies":[{"issue_id":"bv-1","depends_on_id":"bv-3","type":"blocks"}]}
+{"id":"bv-3","title":"Same twice","status":"open","issue_type":"task"}
+{"title":"Same twice","issue_type":"task","status":"open","id":"bv-3"}
+`
+
+func writeDuplicatesFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeShard(t, dir, "beads.jsonl", duplicatesFixture)
+	return filepath.Join(dir, "beads.jsonl")
+}
+
+func TestFindDuplicates(t *testing.T) {
+	set, err := loader.FindDuplicates(writeDuplicatesFixture(t))
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if len(set.Groups) != 2 {
+		t.Fatalf("got %d groups, want 2: %+v", len(set.Groups), set.Groups)
+	}
+	login, same := set.Groups[0], set.Groups[1]
+	if login.ID != "bv-1" || login.Identical || len(login.Copies) != 2 || login.Copies[1].Line != 3 {
+		t.Errorf("bv-1 group = %+v", login)
+	}
+	if login.Copies[1].Issue == nil || login.Copies[1].Issue.Title != "Login page" {
+		t.Errorf("second bv-1 copy not parsed: %+v", login.Copies[1])
+	}
+	if same.ID != "bv-3" || !same.Identical {
+		t.Errorf("bv-3 copies differ only in key order and should be identical: %+v", same)
+	}
+}
+
+func TestDuplicateSet_Merge(t *testing.T) {
+	path := writeDuplicatesFixture(t)
+	set, err := loader.FindDuplicates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := set.Resolve(map[string]loader.DuplicateFix{
+		"bv-1": {Action: loader.DuplicateMerge, Keep: 1},
+		"bv-3": {Action: loader.DuplicateMerge},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if strings.Join(result.Merged, ",") != "bv-1,bv-3" {
+		t.Errorf("merged = %v", result.Merged)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues after merge, want 3", len(issues))
+	}
+	login := issues[1]
+	if login.ID != "bv-1" || login.Title != "Login page" || login.Status != "in_progress" {
+		t.Errorf("the kept copy's fields should win: %+v", login)
+	}
+	if strings.Join(login.Labels, ",") != "ui,auth" || len(login.Dependencies) != 1 || len(login.Comments) != 1 {
+		t.Errorf("labels, dependencies and comments should be unioned: %+v", login)
+	}
+}
+
+func TestDuplicateSet_ReID(t *testing.T) {
+	path := writeDuplicatesFixture(t)
+	set, err := loader.FindDuplicates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := set.Resolve(map[string]loader.DuplicateFix{
+		"bv-1": {Action: loader.DuplicateReID},
+	}, []string{"bv-old"})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	newIDs := result.Renamed["bv-1"]
+	if len(newIDs) != 1 || !strings.HasPrefix(newIDs[0], "bv-") || newIDs[0] == "bv-1" {
+		t.Fatalf("renamed = %v", result.Renamed)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if lines[0] != strings.Split(duplicatesFixture, "\n")[0] || lines[1] != strings.Split(duplicatesFixture, "\n")[1] {
+		t.Errorf("the copy keeping the ID and its dependents should be untouched:\n%s", data)
+	}
+	if !strings.Contains(lines[2], `"id":"`+newIDs[0]+`"`) ||
+		!strings.Contains(lines[2], `"issue_id":"`+newIDs[0]+`","depends_on_id":"bv-3"`) {
+		t.Errorf("re-IDed copy should carry its dependencies along: %s", lines[2])
+	}
+	if strings.Count(string(data), `"id":"bv-3"`) != 2 {
+		t.Errorf("groups without a fix should be left alone:\n%s", data)
+	}
+}
+
+func TestDuplicateSet_ResolveRefusesChangedFile(t *testing.T) {
+	path := writeDuplicatesFixture(t)
+	set, err := loader.FindDuplicates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(duplicatesFixture+`{"id":"bv-4"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Resolve(map[string]loader.DuplicateFix{"bv-3": {Action: loader.DuplicateMerge}}, nil); err == nil {
+		t.Error("Resolve() should refuse a file that changed since it was read")
+	}
+}
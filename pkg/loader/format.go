@@ -0,0 +1,214 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Canonical key order: the fields of the model structs in declaration
+// order, as bd and AppendIssuesToFile write them. Keys bv does not model
+// follow in alphabetical order.
+var (
+	issueKeyOrder   = jsonKeyOrder(model.Issue{})
+	nestedKeyOrders = map[string][]string{
+		"dependencies": jsonKeyOrder(model.Dependency{}),
+		"comments":     jsonKeyOrder(model.Comment{}),
+		"attachments":  jsonKeyOrder(model.Attachment{}),
+	}
+)
+
+// jsonKeyOrder lists the JSON names of the fields of v's struct type
+func jsonKeyOrder(v any) []string {
+	t := reflect.TypeOf(v)
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// FormattedFile is what formatting does to one beads file.
+type FormattedFile struct {
+	Path      string
+	Issues    int   // Issue lines in the file
+	Changed   int   // Issue lines whose formatting changes
+	Skipped   []int // 1-based numbers of lines that are not valid JSON, left as they are
+	Rewritten bool  // Whether the file changes at all (line endings, BOM and blank lines included)
+}
+
+// FormatPlan is a formatting run worked out against the current files.
+// Nothing is written until Apply.
+type FormatPlan struct {
+	Files []FormattedFile
+
+	files []renamedFile
+}
+
+// PlanFormat plans rewriting the beads file at path, the uncompressed
+// shards next to it and the archive in canonical form: one compact JSON
+// object per line with keys in canonical order (see issueKeyOrder), strings
+// escaped the same way throughout, LF line endings, no BOM and no blank
+// lines. Values are kept as they are, so loading the file gives the same
+// issues. Files with merge conflict markers are refused.
+func PlanFormat(path string) (*FormatPlan, error) {
+	if IsCompressedJSONL(path) {
+		return nil, ErrCompressedReadOnly
+	}
+	paths := EditableFiles(path)
+	if _, err := os.Stat(ArchivePath(path)); err == nil {
+		paths = append(paths, ArchivePath(path))
+	}
+
+	plan := &FormatPlan{}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		file := FormattedFile{Path: p}
+		var out bytes.Buffer
+		for i, line := range bytes.Split(stripBOM(data), []byte("\n")) {
+			body := bytes.TrimSpace(line)
+			if len(body) == 0 {
+				continue
+			}
+			if isConflictMarker(body) {
+				return nil, fmt.Errorf("%s has merge conflict markers (line %d); resolve them first", p, i+1)
+			}
+			formatted, err := formatIssueLine(body)
+			if err != nil {
+				file.Skipped = append(file.Skipped, i+1)
+				formatted = body
+			} else {
+				file.Issues++
+				if !bytes.Equal(formatted, body) {
+					file.Changed++
+				}
+			}
+			out.Write(formatted)
+			out.WriteByte('\n')
+		}
+		if !bytes.Equal(out.Bytes(), data) {
+			file.Rewritten = true
+			plan.files = append(plan.files, renamedFile{path: p, data: data, out: out.Bytes()})
+		}
+		plan.Files = append(plan.Files, file)
+	}
+	return plan, nil
+}
+
+// Changed reports whether any file would be rewritten
+func (p *FormatPlan) Changed() bool {
+	return len(p.files) > 0
+}
+
+// Apply writes the formatted files. Each must be unchanged since the plan
+// was made; each is replaced atomically.
+func (p *FormatPlan) Apply() error {
+	if err := checkUnchanged(p.files, "formatting"); err != nil {
+		return err
+	}
+	for _, f := range p.files {
+		if err := writeFileAtomic(f.path, f.out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatIssueLine re-encodes one issue line in canonical form
+func formatIssueLine(line []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("not an issue object")
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("trailing data after the issue")
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, obj, issueKeyOrder, true); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical encodes v with object keys in order first and the rest
+// sorted. At the top level of an issue, the objects in dependencies,
+// comments and attachments get their own model order.
+func writeCanonical(buf *bytes.Buffer, v any, order []string, top bool) error {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		known := make(map[string]bool, len(order))
+		for _, k := range order {
+			known[k] = true
+			if _, ok := v[k]; ok {
+				keys = append(keys, k)
+			}
+		}
+		var rest []string
+		for k := range v {
+			if !known[k] {
+				rest = append(rest, k)
+			}
+		}
+		sort.Strings(rest)
+		keys = append(keys, rest...)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name, err := marshalNoEscape(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(name)
+			buf.WriteByte(':')
+			var childOrder []string
+			if top {
+				childOrder = nestedKeyOrders[k]
+			}
+			if err := writeCanonical(buf, v[k], childOrder, false); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item, order, false); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		buf.WriteString(v.String())
+	default:
+		b, err := marshalNoEscape(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package loader_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestPlanFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := writeShard(t, dir, "beads.jsonl",
+		"\ufeff"+`{"zeta":1.50,"status":"open","id":"bv-1","title":"A <b>","issue_type":"task","priority":1,"dependencies":[{"type":"blocks","depends_on_id":"bv-2","issue_id":"bv-1"}]}`+"\r",
+		"",
+		`{"id":"bv-2", "title": "B", "status": "closed", "issue_type": "bug", "custom_fields": {"b": 2, "a": [3, {"y": 1, "x": 2}]}}`,
+		`{not json`)
+	before, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := loader.PlanFormat(path)
+	if err != nil {
+		t.Fatalf("PlanFormat() error = %v", err)
+	}
+	if !plan.Changed() || len(plan.Files) != 1 {
+		t.Fatalf("plan = %+v", plan.Files)
+	}
+	if f := plan.Files[0]; f.Issues != 2 || f.Changed != 2 || !reflect.DeepEqual(f.Skipped, []int{4}) {
+		t.Errorf("file = %+v", f)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := `{"id":"bv-1","title":"A <b>","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"bv-1","depends_on_id":"bv-2","type":"blocks"}],"zeta":1.50}` + "\n" +
+		`{"id":"bv-2","title":"B","status":"closed","issue_type":"bug","custom_fields":{"a":[3,{"x":2,"y":1}],"b":2}}` + "\n" +
+		`{not json` + "\n"
+	if string(data) != want {
+		t.Errorf("formatted file:\n%s\nwant:\n%s", data, want)
+	}
+	after, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("formatting changed the issues:\nbefore %+v\nafter  %+v", before, after)
+	}
+
+	// Formatting is idempotent
+	plan, err = loader.PlanFormat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Changed() {
+		t.Errorf("second run would rewrite %+v", plan.Files)
+	}
+}
+
+func TestPlanFormat_RefusesConflicts(t *testing.T) {
+	path := writeShard(t, t.TempDir(), "beads.jsonl",
+		`<<<<<<< HEAD`,
+		`{"id":"bv-1","title":"A","status":"open","issue_type":"task"}`,
+		`=======`,
+		`{"id":"bv-1","title":"B","status":"open","issue_type":"task"}`,
+		`>>>>>>> branch`)
+	if _, err := loader.PlanFormat(path); err == nil {
+		t.Error("PlanFormat() should refuse a file with conflict markers")
+	}
+}
@@ -0,0 +1,119 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// skipText discards a JSON value without allocating it.
+type skipText struct{}
+
+func (skipText) UnmarshalJSON([]byte) error { return nil }
+
+// lazyIssue shadows the long text fields of model.Issue so decoding skips them.
+type lazyIssue struct {
+	model.Issue
+	Description        skipText `json:"description"`
+	Design             skipText `json:"design"`
+	AcceptanceCriteria skipText `json:"acceptance_criteria"`
+	Notes              skipText `json:"notes"`
+	Comments           skipText `json:"comments"`
+}
+
+// decodeIssue unmarshals one JSONL line, leaving out the text fields when
+// lazyText is set.
+func decodeIssue(line []byte, lazyText bool) (model.Issue, error) {
+	if !lazyText {
+		var issue model.Issue
+		err := json.Unmarshal(line, &issue)
+		return issue, err
+	}
+	var li lazyIssue
+	err := json.Unmarshal(line, &li)
+	return li.Issue, err
+}
+
+// TextIndex records where each issue's line starts in a JSONL file, so the
+// text fields skipped by ParseOptions.LazyText can be read back when an
+// issue is opened.
+type TextIndex struct {
+	path    string
+	offsets map[string]int64
+}
+
+// LoadIssuesLazy reads issues from path without their long text fields and
+// returns an index for hydrating them on demand. It is meant for very large
+// files where only a few issues are ever opened; LoadIssuesFromFile keeps
+// everything in memory. Compressed and sharded files, and loads that include
+// the archive, are loaded in full and the returned index is nil.
+func LoadIssuesLazy(path string, opts ParseOptions) ([]model.Issue, *TextIndex, error) {
+	if IsCompressedJSONL(path) || shardSet(path) != nil || opts.IncludeArchived {
+		issues, err := LoadIssuesFromFileWithOptions(path, opts)
+		return issues, nil, err
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("no beads issues found at %s", path)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open issues file: %w", err)
+	}
+	defer file.Close()
+
+	opts.LazyText = true
+	index := &TextIndex{path: path, offsets: make(map[string]int64)}
+	var issues []model.Issue
+	err = StreamIssues(file, opts, func(issue model.Issue, offset int64) error {
+		issues = append(issues, issue)
+		index.offsets[issue.ID] = offset
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return applyDeletionsFor(path, issues, opts), index, nil
+}
+
+// Hydrate fills in the text fields of issue from disk. It fails when the
+// issue is not indexed or the file changed so the indexed line no longer
+// holds it; reload the file to rebuild the index.
+func (x *TextIndex) Hydrate(issue *model.Issue) error {
+	offset, ok := x.offsets[issue.ID]
+	if !ok {
+		return fmt.Errorf("issue %s is not in the text index", issue.ID)
+	}
+	file, err := os.Open(x.path)
+	if err != nil {
+		return fmt.Errorf("opening issues file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("seeking to %s: %w", issue.ID, err)
+	}
+
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("reading %s: %w", issue.ID, err)
+	}
+	line = bytes.TrimRight(line, "\r\n")
+	if offset == 0 {
+		line = stripBOM(line)
+	}
+
+	var full model.Issue
+	if err := json.Unmarshal(line, &full); err != nil || full.ID != issue.ID {
+		return fmt.Errorf("%s changed on disk since it was indexed", x.path)
+	}
+	issue.Description = full.Description
+	issue.Design = full.Design
+	issue.AcceptanceCriteria = full.AcceptanceCriteria
+	issue.Notes = full.Notes
+	issue.Comments = full.Comments
+	return nil
+}
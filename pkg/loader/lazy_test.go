@@ -0,0 +1,106 @@
+package loader_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const lazyFixture = "\xEF\xBB\xBF" + `{"id":"A-1","title":"First","description":"alpha body","notes":"n1","status":"open","issue_type":"task"}` + "\r\n" +
+	"\n" +
+	`not json` + "\n" +
+	`{"id":"A-2","title":"Second","description":"beta body","design":"d2","acceptance_criteria":"ac2","comments":[{"id":1,"author":"x","text":"hi"}],"status":"open","issue_type":"task"}`
+
+func TestStreamIssues_Offsets(t *testing.T) {
+	var ids []string
+	var offsets []int64
+	err := loader.StreamIssues(strings.NewReader(lazyFixture), loader.ParseOptions{WarningHandler: func(string) {}},
+		func(issue model.Issue, offset int64) error {
+			ids = append(ids, issue.ID)
+			offsets = append(offsets, offset)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("StreamIssues() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "A-1" || ids[1] != "A-2" {
+		t.Fatalf("unexpected issues %v", ids)
+	}
+	if offsets[0] != 0 || offsets[1] != int64(strings.Index(lazyFixture, `{"id":"A-2"`)) {
+		t.Errorf("unexpected offsets %v", offsets)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = loader.StreamIssues(strings.NewReader(lazyFixture), loader.ParseOptions{WarningHandler: func(string) {}},
+		func(model.Issue, int64) error { calls++; return stop })
+	if err != stop || calls != 1 {
+		t.Errorf("expected the callback error to stop parsing, got %v after %d calls", err, calls)
+	}
+}
+
+func TestParseIssues_LazyText(t *testing.T) {
+	issues, err := loader.ParseIssuesWithOptions(strings.NewReader(lazyFixture), loader.ParseOptions{
+		WarningHandler: func(string) {},
+		LazyText:       true,
+	})
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("ParseIssuesWithOptions() = %d issues, %v", len(issues), err)
+	}
+	second := issues[1]
+	if second.Title != "Second" || second.Status != model.StatusOpen {
+		t.Errorf("metadata should still be parsed, got %+v", second)
+	}
+	if second.Description != "" || second.Design != "" || second.AcceptanceCriteria != "" || second.Comments != nil {
+		t.Errorf("text fields should be skipped, got %+v", second)
+	}
+}
+
+func TestLoadIssuesLazy_Hydrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, []byte(lazyFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, index, err := loader.LoadIssuesLazy(path, loader.ParseOptions{WarningHandler: func(string) {}})
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("LoadIssuesLazy() = %d issues, %v", len(issues), err)
+	}
+	if issues[0].Description != "" {
+		t.Fatal("expected text fields to be left out")
+	}
+
+	for i, want := range []string{"alpha body", "beta body"} {
+		issue := issues[i]
+		if err := index.Hydrate(&issue); err != nil {
+			t.Fatalf("Hydrate(%s) error = %v", issue.ID, err)
+		}
+		if issue.Description != want {
+			t.Errorf("Hydrate(%s) description = %q, want %q", issue.ID, issue.Description, want)
+		}
+	}
+	second := issues[1]
+	_ = index.Hydrate(&second)
+	if second.Design != "d2" || second.AcceptanceCriteria != "ac2" || len(second.Comments) != 1 {
+		t.Errorf("expected every text field hydrated, got %+v", second)
+	}
+
+	unknown := model.Issue{ID: "missing"}
+	if err := index.Hydrate(&unknown); err == nil {
+		t.Error("expected an error for an unindexed issue")
+	}
+
+	// Rewriting the file shifts the lines; hydration must not read the wrong issue
+	if err := os.WriteFile(path, []byte(`{"id":"A-0","title":"New","status":"open","issue_type":"task"}`+"\n"+lazyFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stale := issues[1]
+	if err := index.Hydrate(&stale); err == nil || stale.Description != "" {
+		t.Errorf("expected a stale index error, got %v (description %q)", err, stale.Description)
+	}
+}
@@ -3,11 +3,11 @@ package loader
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -42,7 +42,8 @@ func GetBeadsDir(repoPath string) (string, error) {
 
 // FindJSONLPath locates the beads JSONL file in the given directory.
 // Prefers issues.jsonl (canonical per beads upstream) over beads.jsonl (backward compat).
-// Skips backup files and merge artifacts.
+// Compressed copies (issues.jsonl.gz, issues.jsonl.zst) are used when no
+// uncompressed file of the same name exists. Skips backup files and merge artifacts.
 func FindJSONLPath(beadsDir string) (string, error) {
 	return FindJSONLPathWithWarnings(beadsDir, nil)
 }
@@ -62,17 +63,24 @@ func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (stri
 			continue
 		}
 		name := e.Name()
+		base := trimCompressedExt(name)
 
-		// Must be a .jsonl file
-		if !strings.HasSuffix(name, ".jsonl") {
+		// Must be a .jsonl file, optionally compressed
+		if !strings.HasSuffix(base, ".jsonl") {
 			continue
 		}
 
-		// Skip backups, merge artifacts, and deletion manifests
-		if strings.Contains(name, ".backup") ||
-			strings.Contains(name, ".orig") ||
-			strings.Contains(name, ".merge") ||
-			name == "deletions.jsonl" {
+		// Skip backups, merge artifacts, deletion manifests and the archive
+		if strings.Contains(base, ".backup") ||
+			strings.Contains(base, ".orig") ||
+			strings.Contains(base, ".merge") ||
+			base == DeletionsFileName ||
+			base == ArchiveFileName {
+			continue
+		}
+
+		// Shards are merged by the loader; they only stand in for a missing main file
+		if shardPattern.MatchString(name) {
 			continue
 		}
 
@@ -93,6 +101,10 @@ func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (stri
 	}
 
 	if len(candidates) == 0 {
+		// A directory of shards: the newest shard is where new issues go
+		if shards := FindShardPaths(beadsDir); len(shards) > 0 {
+			return shards[len(shards)-1], nil
+		}
 		return "", fmt.Errorf("no beads JSONL file found in %s", beadsDir)
 	}
 
@@ -101,20 +113,28 @@ func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (stri
 	// 2. beads.jsonl (backward compatibility)
 	// 3. beads.base.jsonl (fallback, may be present during merge resolution)
 	// 4. First candidate
+	// Each name is tried uncompressed first, then as .gz and .zst.
 	preferredNames := PreferredJSONLNames
 
 	for _, preferred := range preferredNames {
-		for _, name := range candidates {
-			if name == preferred {
-				path := filepath.Join(beadsDir, name)
-				// Check if file has content (skip empty files)
-				if info, err := os.Stat(path); err == nil && info.Size() > 0 {
-					return path, nil
+		for _, ext := range append([]string{""}, compressedExts...) {
+			for _, name := range candidates {
+				if name == preferred+ext {
+					path := filepath.Join(beadsDir, name)
+					// Check if file has content (skip empty files)
+					if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+						return path, nil
+					}
 				}
 			}
 		}
 	}
 
+	// Uncompressed files win the fallback too
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return !IsCompressedJSONL(candidates[i]) && IsCompressedJSONL(candidates[j])
+	})
+
 	// Fall back to first non-empty candidate
 	for _, name := range candidates {
 		path := filepath.Join(beadsDir, name)
@@ -157,16 +177,56 @@ type ParseOptions struct {
 	// Lines longer than this are skipped with a warning.
 	// If 0, uses DefaultMaxBufferSize (10MB).
 	BufferSize int
+
+	// LazyText skips the long text fields (description, design, acceptance
+	// criteria, notes and comments) while parsing, leaving them empty.
+	// See LoadIssuesLazy for loading them back on demand.
+	LazyText bool
+
+	// IncludeDeleted keeps issues removed by the deletions manifest (or
+	// already tombstoned) with StatusTombstone instead of dropping them.
+	IncludeDeleted bool
+
+	// IncludeArchived appends the issues moved to archive.jsonl by
+	// bv archive; see PlanArchive. They are left out by default.
+	IncludeArchived bool
+
+	// MigrationHandler is called for each issue that used older field names
+	// (see migrateIssue) with the migrations applied to it. If nil, a single
+	// summary warning is reported through WarningHandler instead.
+	MigrationHandler func(issueID string, migrations []string)
 }
 
 // LoadIssuesFromFileWithOptions reads issues from a file with custom options.
+// When the file is a shard (beads-0001.jsonl) or the main beads file of a
+// directory that also holds shards, the main file and all shards are merged;
+// see FindShardPaths. Deleted issues are dropped; see ApplyDeletions.
+// Archived issues are added only with opts.IncludeArchived.
 func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) ([]model.Issue, error) {
+	var issues []model.Issue
+	var err error
+	if files := shardSet(path); files != nil {
+		issues, err = loadShards(files, opts)
+	} else {
+		issues, err = loadIssuesFile(path, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if opts.IncludeArchived {
+		issues = withArchive(path, issues, opts)
+	}
+	return applyDeletionsFor(path, issues, opts), nil
+}
+
+// loadIssuesFile reads issues from a single file.
+func loadIssuesFile(path string, opts ParseOptions) ([]model.Issue, error) {
 	// Check if file exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("no beads issues found at %s", path)
 	}
 
-	file, err := os.Open(path)
+	file, err := OpenJSONL(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open issues file: %w", err)
 	}
@@ -189,7 +249,22 @@ func ParseIssues(r io.Reader) ([]model.Issue, error) {
 // ParseIssuesWithOptions parses JSONL content with custom options.
 func ParseIssuesWithOptions(r io.Reader, opts ParseOptions) ([]model.Issue, error) {
 	var issues []model.Issue
+	err := StreamIssues(r, opts, func(issue model.Issue, _ int64) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
 
+// StreamIssues parses JSONL content one line at a time and calls fn for each
+// valid issue along with the byte offset of its line, so callers can process
+// files larger than they want to hold in memory. Malformed and invalid lines
+// are skipped with a warning, as in ParseIssuesWithOptions. An error returned
+// by fn stops parsing and is returned unchanged.
+func StreamIssues(r io.Reader, opts ParseOptions, fn func(issue model.Issue, offset int64) error) error {
 	// Determine buffer size
 	maxCapacity := opts.BufferSize
 	if maxCapacity <= 0 {
@@ -210,62 +285,108 @@ func ParseIssuesWithOptions(r io.Reader, opts ParseOptions) ([]model.Issue, erro
 		}
 	}
 
+	onMigration := opts.MigrationHandler
+	migrated := make(map[string]int)
+	if onMigration == nil {
+		onMigration = func(_ string, migrations []string) {
+			for _, m := range migrations {
+				migrated[m]++
+			}
+		}
+	}
+
+	var offset int64
 	lineNum := 0
 	for {
 		lineNum++
-		// ReadLine returns a single line, not including the end-of-line bytes.
-		// If the line was too long for the buffer then isPrefix is set and the
-		// beginning of the line is returned.
-		line, isPrefix, err := reader.ReadLine()
-		if err != nil {
+		lineStart := offset
+		// ReadSlice returns the line including its newline. If the line does
+		// not fit in the buffer, ErrBufferFull is returned with the beginning.
+		line, err := reader.ReadSlice('\n')
+		offset += int64(len(line))
+		if err == bufio.ErrBufferFull {
+			// Line too long. Discard the rest of the line.
+			warn(fmt.Sprintf("skipping line %d: line too long (exceeds %d bytes)", lineNum, maxCapacity))
+			for err == bufio.ErrBufferFull {
+				line, err = reader.ReadSlice('\n')
+				offset += int64(len(line))
+			}
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("error skipping long line at line %d: %w", lineNum, err)
+			}
 			if err == io.EOF {
 				break
 			}
-			return nil, fmt.Errorf("error reading issues stream at line %d: %w", lineNum, err)
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("error reading issues stream at line %d: %w", lineNum, err)
 		}
+		atEOF := err == io.EOF
 
-		if isPrefix {
-			// Line too long. Discard the rest of the line.
-			warn(fmt.Sprintf("skipping line %d: line too long (exceeds %d bytes)", lineNum, maxCapacity))
-			for isPrefix {
-				_, isPrefix, err = reader.ReadLine()
-				if err != nil && err != io.EOF {
-					return nil, fmt.Errorf("error skipping long line at line %d: %w", lineNum, err)
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) > 0 {
+			// Strip UTF-8 BOM if present on the first line
+			if lineNum == 1 {
+				line = stripBOM(line)
+			}
+
+			issue, err := decodeIssue(line, opts.LazyText)
+			if err == nil {
+				if applied := migrateIssue(line, &issue); len(applied) > 0 {
+					onMigration(issue.ID, applied)
+				}
+			}
+			if err != nil {
+				// Skip malformed lines but warn
+				warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
+			} else if err := issue.Validate(); err != nil {
+				// Skip invalid issues
+				warn(fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
+			} else {
+				for _, err := range dropInvalidAttachments(&issue) {
+					warn(fmt.Sprintf("dropping attachment of %s on line %d: %v", issue.ID, lineNum, err))
 				}
-				if err == io.EOF {
-					break
+				if err := fn(issue, lineStart); err != nil {
+					return err
 				}
 			}
-			continue
 		}
 
-		if len(line) == 0 {
-			continue
+		if atEOF {
+			break
 		}
+	}
 
-		// Strip UTF-8 BOM if present on the first line
-		if lineNum == 1 {
-			line = stripBOM(line)
-		}
+	if len(migrated) > 0 {
+		warn(migrationSummary(migrated))
+	}
+	return nil
+}
 
-		var issue model.Issue
-		if err := json.Unmarshal(line, &issue); err != nil {
-			// Skip malformed lines but warn
-			warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
+// dropInvalidAttachments removes attachments whose URL fails validation,
+// returning why each one was dropped.
+func dropInvalidAttachments(issue *model.Issue) []error {
+	if len(issue.Attachments) == 0 {
+		return nil
+	}
+	var errs []error
+	valid := issue.Attachments[:0]
+	for _, a := range issue.Attachments {
+		if a == nil {
 			continue
 		}
-
-		// Validate issue
-		if err := issue.Validate(); err != nil {
-			// Skip invalid issues
-			warn(fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
+		if err := a.Validate(); err != nil {
+			errs = append(errs, err)
 			continue
 		}
-
-		issues = append(issues, issue)
+		valid = append(valid, a)
 	}
-
-	return issues, nil
+	issue.Attachments = valid
+	if len(valid) == 0 {
+		issue.Attachments = nil
+	}
+	return errs
 }
 
 // stripBOM removes the UTF-8 Byte Order Mark if present
@@ -51,3 +51,31 @@ func TestParseIssuesWithOptions_LineTooLong(t *testing.T) {
 		t.Errorf("Expected warning containing %q, got: %v", expectedWarning, warnings)
 	}
 }
+
+func TestParseIssues_DropsInvalidAttachments(t *testing.T) {
+	input := `{"id":"A-1","title":"Links","status":"open","issue_type":"task","attachments":[` +
+		`{"url":"https://example.com/spec","title":"Spec"},` +
+		`{"url":"javascript:alert(1)"},` +
+		`{"url":"docs/design.png"},` +
+		`{"url":"https://"},` +
+		`{"url":""}]}` + "\n"
+
+	var warnings []string
+	issues, err := loader.ParseIssuesWithOptions(strings.NewReader(input), loader.ParseOptions{
+		WarningHandler: func(msg string) { warnings = append(warnings, msg) },
+	})
+	if err != nil || len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d (err %v)", len(issues), err)
+	}
+
+	var urls []string
+	for _, a := range issues[0].Attachments {
+		urls = append(urls, a.URL)
+	}
+	if got := strings.Join(urls, " "); got != "https://example.com/spec docs/design.png" {
+		t.Errorf("kept attachments %q", got)
+	}
+	if len(warnings) != 3 || !strings.Contains(warnings[0], "A-1 on line 1") {
+		t.Errorf("expected 3 warnings for A-1, got %v", warnings)
+	}
+}
@@ -0,0 +1,188 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+var (
+	// mdHeadingPattern matches ATX headings ("## Backend")
+	mdHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// mdCheckboxPattern matches list items with a checkbox ("- [ ] task", "1. [x] done")
+	mdCheckboxPattern = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.*?)\s*$`)
+	// mdFencePattern matches the start or end of a fenced code block
+	mdFencePattern = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// MarkdownImportSummary counts what ParseMarkdownTodos produced
+type MarkdownImportSummary struct {
+	Epics  int `json:"epics"`
+	Tasks  int `json:"tasks"`
+	Closed int `json:"closed"` // Checked items, imported as closed tasks
+}
+
+// ParseMarkdownTodos converts a Markdown TODO list into new issues.
+// Headings become epics, nested under the enclosing heading of a higher
+// level. Checkbox items become tasks, children of the enclosing checkbox
+// (by indentation) or else of the current heading; checked items are
+// closed. Parent-child links use parent-child dependencies. Other text
+// under a heading or indented below a checkbox becomes its description.
+// IDs avoid those in existing, see NewIssueID.
+func ParseMarkdownTodos(data []byte, existing []model.Issue, fallbackPrefix string, now time.Time) ([]model.Issue, MarkdownImportSummary, error) {
+	type headingFrame struct {
+		level int
+		idx   int
+	}
+	type itemFrame struct {
+		indent int
+		idx    int
+	}
+
+	var (
+		issues   []model.Issue
+		summary  MarkdownImportSummary
+		headings []headingFrame
+		items    []itemFrame
+		descIdx  = -1 // Issue collecting description text
+		inFence  bool
+		fenceCut int // Indentation of the open code fence, stripped from its lines
+	)
+	known := append([]model.Issue(nil), existing...)
+
+	add := func(title string, typ model.IssueType, closed bool, parent int) int {
+		issue := model.Issue{
+			ID:        NewIssueID(known, fallbackPrefix),
+			Title:     title,
+			Status:    model.StatusOpen,
+			Priority:  2,
+			IssueType: typ,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if closed {
+			issue.Status = model.StatusClosed
+			closedAt := now
+			issue.ClosedAt = &closedAt
+		}
+		if parent >= 0 {
+			issue.Dependencies = []*model.Dependency{{
+				IssueID:     issue.ID,
+				DependsOnID: issues[parent].ID,
+				Type:        model.DepParentChild,
+				CreatedAt:   now,
+			}}
+		}
+		known = append(known, issue)
+		issues = append(issues, issue)
+		return len(issues) - 1
+	}
+	appendDesc := func(idx int, line string) {
+		if idx < 0 {
+			return
+		}
+		desc := issues[idx].Description
+		if line == "" && (desc == "" || strings.HasSuffix(desc, "\n\n")) {
+			return
+		}
+		issues[idx].Description = desc + line + "\n"
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(stripBOM(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(strings.ReplaceAll(scanner.Text(), "\t", "    "), " \r")
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if isFence := mdFencePattern.MatchString(line); isFence || inFence {
+			if isFence && !inFence {
+				fenceCut = indent
+			}
+			appendDesc(descIdx, line[min(indent, fenceCut):])
+			inFence = inFence != isFence
+			continue
+		}
+
+		if m := mdHeadingPattern.FindStringSubmatch(line); m != nil {
+			title := strings.TrimSpace(m[2])
+			if title == "" {
+				return nil, summary, fmt.Errorf("line %d: empty heading", lineNo)
+			}
+			level := len(m[1])
+			for len(headings) > 0 && headings[len(headings)-1].level >= level {
+				headings = headings[:len(headings)-1]
+			}
+			parent := -1
+			if len(headings) > 0 {
+				parent = headings[len(headings)-1].idx
+			}
+			idx := add(title, model.TypeEpic, false, parent)
+			summary.Epics++
+			headings = append(headings, headingFrame{level: level, idx: idx})
+			items = nil
+			descIdx = idx
+			continue
+		}
+
+		if m := mdCheckboxPattern.FindStringSubmatch(line); m != nil {
+			title := strings.TrimSpace(m[3])
+			if title == "" {
+				return nil, summary, fmt.Errorf("line %d: checkbox without text", lineNo)
+			}
+			indent = len(m[1])
+			for len(items) > 0 && items[len(items)-1].indent >= indent {
+				items = items[:len(items)-1]
+			}
+			parent := -1
+			if len(items) > 0 {
+				parent = items[len(items)-1].idx
+			} else if len(headings) > 0 {
+				parent = headings[len(headings)-1].idx
+			}
+			closed := m[2] != " "
+			idx := add(title, model.TypeTask, closed, parent)
+			summary.Tasks++
+			if closed {
+				summary.Closed++
+			}
+			items = append(items, itemFrame{indent: indent, idx: idx})
+			descIdx = idx
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			appendDesc(descIdx, "")
+			continue
+		}
+
+		// Text indented under a checkbox belongs to it; anything else ends
+		// the list and goes to the heading
+		for len(items) > 0 && items[len(items)-1].indent >= indent {
+			items = items[:len(items)-1]
+		}
+		switch {
+		case len(items) > 0:
+			descIdx = items[len(items)-1].idx
+		case len(headings) > 0:
+			descIdx = headings[len(headings)-1].idx
+		default:
+			descIdx = -1
+		}
+		appendDesc(descIdx, strings.TrimSpace(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, summary, fmt.Errorf("reading markdown: %w", err)
+	}
+
+	for i := range issues {
+		issues[i].Description = strings.TrimSpace(issues[i].Description)
+	}
+	return issues, summary, nil
+}
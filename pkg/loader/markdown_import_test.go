@@ -0,0 +1,109 @@
+package loader
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestParseMarkdownTodos(t *testing.T) {
+	md := "# Launch\n" +
+		"\n" +
+		"Everything for v1.\n" +
+		"\n" +
+		"## Backend\n" +
+		"- [x] Set up database\n" +
+		"- [ ] Auth\n" +
+		"  Use OAuth for now.\n" +
+		"\t- [ ] Google login\n" +
+		"\t- [X] Session store\n" +
+		"- [ ] API docs\n" +
+		"\n" +
+		"```\n" +
+		"- [ ] not a task\n" +
+		"```\n" +
+		"## Frontend\n" +
+		"1. [ ] Landing page\n" +
+		"* plain note\n"
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	existing := []model.Issue{{ID: "bv-1"}, {ID: "bv-2"}}
+
+	issues, summary, err := ParseMarkdownTodos([]byte(md), existing, "fallback", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Epics != 3 || summary.Tasks != 6 || summary.Closed != 2 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	byTitle := make(map[string]model.Issue)
+	ids := make(map[string]string)
+	for _, issue := range issues {
+		byTitle[issue.Title] = issue
+		ids[issue.ID] = issue.Title
+		if !strings.HasPrefix(issue.ID, "bv-") || issue.ID == "bv-1" || issue.ID == "bv-2" {
+			t.Errorf("ID %q should use the existing prefix and be new", issue.ID)
+		}
+	}
+	if len(byTitle) != 9 {
+		t.Fatalf("expected 9 issues, got %d: %v", len(issues), ids)
+	}
+
+	parentOf := func(title string) string {
+		deps := byTitle[title].Dependencies
+		if len(deps) == 0 {
+			return ""
+		}
+		if deps[0].Type != model.DepParentChild {
+			t.Errorf("%s: expected a parent-child dependency, got %s", title, deps[0].Type)
+		}
+		return ids[deps[0].DependsOnID]
+	}
+	for child, parent := range map[string]string{
+		"Launch":          "",
+		"Backend":         "Launch",
+		"Frontend":        "Launch",
+		"Set up database": "Backend",
+		"Auth":            "Backend",
+		"Google login":    "Auth",
+		"Session store":   "Auth",
+		"API docs":        "Backend",
+		"Landing page":    "Frontend",
+	} {
+		if got := parentOf(child); got != parent {
+			t.Errorf("%s: parent %q, want %q", child, got, parent)
+		}
+	}
+
+	if byTitle["Launch"].IssueType != model.TypeEpic || byTitle["Auth"].IssueType != model.TypeTask {
+		t.Error("headings should be epics and checkboxes tasks")
+	}
+	if done := byTitle["Session store"]; done.Status != model.StatusClosed || done.ClosedAt == nil {
+		t.Errorf("checked item should be closed, got %+v", done)
+	}
+	if got := byTitle["Launch"].Description; got != "Everything for v1." {
+		t.Errorf("unexpected heading description %q", got)
+	}
+	if got := byTitle["Auth"].Description; got != "Use OAuth for now." {
+		t.Errorf("unexpected task description %q", got)
+	}
+	if got := byTitle["API docs"].Description; got != "```\n- [ ] not a task\n```" {
+		t.Errorf("code fences should be kept as description, got %q", got)
+	}
+	if got := byTitle["Frontend"].Description; got != "* plain note" {
+		t.Errorf("plain bullets after the list belong to the heading, got %q", got)
+	}
+}
+
+func TestParseMarkdownTodos_Empty(t *testing.T) {
+	issues, summary, err := ParseMarkdownTodos([]byte("just prose\n- a bullet\n"), nil, "proj", time.Now())
+	if err != nil || len(issues) != 0 || summary.Tasks != 0 {
+		t.Errorf("expected nothing to import, got %v %+v %v", issues, summary, err)
+	}
+	issues, _, err = ParseMarkdownTodos([]byte("- [ ] lone task\n"), nil, "proj", time.Now())
+	if err != nil || len(issues) != 1 || len(issues[0].Dependencies) != 0 || !strings.HasPrefix(issues[0].ID, "proj-") {
+		t.Errorf("top-level checkbox should be a parentless task, got %+v %v", issues, err)
+	}
+}
@@ -0,0 +1,246 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// CurrentVariant names the beads file itself in a MergeSet.
+const CurrentVariant = "current"
+
+// mergeArtifactPattern matches the sides of a conflicted beads file that git
+// and bd leave next to it, such as beads.orig.jsonl or beads.left.jsonl.
+var mergeArtifactPattern = regexp.MustCompile(`^(?:beads|issues)\.(orig|merge|left|right)\.jsonl$`)
+
+// conflictMarkers start the lines git writes around a conflict hunk
+var conflictMarkers = [][]byte{[]byte("<<<<<<<"), []byte("======="), []byte(">>>>>>>"), []byte("|||||||")}
+
+// FindMergeArtifacts returns the merge artifact files in beadsDir, sorted by name.
+func FindMergeArtifacts(beadsDir string) []string {
+	entries, err := os.ReadDir(beadsDir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && mergeArtifactPattern.MatchString(e.Name()) {
+			paths = append(paths, filepath.Join(beadsDir, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// MergeVersion is one variant's copy of a conflicting issue.
+type MergeVersion struct {
+	Variant string       // CurrentVariant or the artifact kind (orig, merge, left, right)
+	Issue   *model.Issue // nil when the variant does not have the issue
+	raw     []byte
+}
+
+// MergeConflict is an issue whose variants disagree.
+type MergeConflict struct {
+	ID       string
+	Versions []MergeVersion // One per variant, in MergeSet.Variants order
+}
+
+// MergeSet is a beads file together with its merge artifacts.
+type MergeSet struct {
+	Path      string   // The beads file a resolution is written to
+	Variants  []string // CurrentVariant followed by the artifact kinds
+	Artifacts []string // Paths of the artifact files
+	Conflicts []MergeConflict
+
+	data []byte // Contents of Path when loaded
+}
+
+// mergeVariant is a parsed variant file: issue lines by ID in file order
+type mergeVariant struct {
+	order []string
+	lines map[string][]byte
+	canon map[string]string
+}
+
+// LoadMergeSet reads the beads file at path and the merge artifacts next to
+// it, and lists every issue that is not identical across all of them
+// (including issues missing from some). Key order and whitespace are ignored.
+func LoadMergeSet(path string) (*MergeSet, error) {
+	if IsCompressedJSONL(path) {
+		return nil, ErrCompressedReadOnly
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read beads file: %w", err)
+	}
+
+	set := &MergeSet{
+		Path:      path,
+		Variants:  []string{CurrentVariant},
+		Artifacts: FindMergeArtifacts(filepath.Dir(path)),
+		data:      data,
+	}
+	variants := []mergeVariant{parseMergeVariant(data)}
+	for _, artifact := range set.Artifacts {
+		content, err := os.ReadFile(artifact)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read merge artifact %s: %w", filepath.Base(artifact), err)
+		}
+		set.Variants = append(set.Variants, mergeArtifactPattern.FindStringSubmatch(filepath.Base(artifact))[1])
+		variants = append(variants, parseMergeVariant(content))
+	}
+
+	seen := make(map[string]bool)
+	for _, v := range variants {
+		for _, id := range v.order {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			conflict := MergeConflict{ID: id}
+			differs := false
+			for i, other := range variants {
+				if other.canon[id] != variants[0].canon[id] {
+					differs = true
+				}
+				version := MergeVersion{Variant: set.Variants[i], raw: other.lines[id]}
+				if version.raw != nil {
+					var issue model.Issue
+					if json.Unmarshal(version.raw, &issue) == nil {
+						version.Issue = &issue
+					}
+				}
+				conflict.Versions = append(conflict.Versions, version)
+			}
+			if differs {
+				set.Conflicts = append(set.Conflicts, conflict)
+			}
+		}
+	}
+	return set, nil
+}
+
+// parseMergeVariant indexes the issue lines of a variant. Conflict markers and
+// unparsable lines are ignored; the first line for an ID wins.
+func parseMergeVariant(data []byte) mergeVariant {
+	v := mergeVariant{lines: make(map[string][]byte), canon: make(map[string]string)}
+	for _, line := range bytes.Split(stripBOM(data), []byte("\n")) {
+		id, body := issueLineID(line)
+		if id == "" || v.lines[id] != nil {
+			continue
+		}
+		var obj any
+		if json.Unmarshal(body, &obj) != nil {
+			continue
+		}
+		canon, err := json.Marshal(obj)
+		if err != nil {
+			continue
+		}
+		v.order = append(v.order, id)
+		v.lines[id] = body
+		v.canon[id] = string(canon)
+	}
+	return v
+}
+
+// issueLineID returns the ID and trimmed JSON of an issue line, or "" when
+// the line is blank, a conflict marker or not an issue
+func issueLineID(line []byte) (string, []byte) {
+	body := bytes.TrimSpace(line)
+	if len(body) == 0 || isConflictMarker(body) {
+		return "", nil
+	}
+	var head struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &head) != nil {
+		return "", nil
+	}
+	return head.ID, body
+}
+
+func isConflictMarker(line []byte) bool {
+	for _, marker := range conflictMarkers {
+		if bytes.HasPrefix(line, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve writes the merged beads file. choices maps a conflict ID to the
+// index of the chosen version; unlisted conflicts keep the current version.
+// Choosing a variant that lacks the issue removes it. Conflict markers and
+// repeated lines for the same ID are dropped; all other lines are kept. The
+// write is atomic and fails if the file changed since LoadMergeSet.
+func (s *MergeSet) Resolve(choices map[string]int) error {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read beads file: %w", err)
+	}
+	if !bytes.Equal(data, s.data) {
+		return fmt.Errorf("%s changed since the merge was loaded; reopen merge assist", filepath.Base(s.Path))
+	}
+
+	chosen := make(map[string][]byte, len(s.Conflicts))
+	var added []string
+	for _, c := range s.Conflicts {
+		i := choices[c.ID]
+		if i <= 0 || i >= len(c.Versions) {
+			continue
+		}
+		chosen[c.ID] = c.Versions[i].raw
+		if c.Versions[0].raw == nil && c.Versions[i].raw != nil {
+			added = append(added, c.ID)
+		}
+	}
+
+	eol := []byte("\n")
+	if bytes.Contains(data, []byte("\r\n")) {
+		eol = []byte("\r\n")
+	}
+	bom := data[:len(data)-len(stripBOM(data))]
+	out := append([]byte{}, bom...)
+	emit := func(line []byte) {
+		out = append(append(out, line...), eol...)
+	}
+
+	written := make(map[string]bool)
+	for _, line := range bytes.Split(stripBOM(data), []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if isConflictMarker(bytes.TrimSpace(line)) {
+			continue
+		}
+		id, _ := issueLineID(line)
+		switch {
+		case id == "":
+			if len(bytes.TrimSpace(line)) > 0 {
+				emit(line)
+			}
+		case written[id]:
+			// A second copy, e.g. from both sides of a conflict hunk
+		default:
+			written[id] = true
+			if raw, ok := chosen[id]; ok {
+				if raw != nil {
+					emit(raw)
+				}
+				continue
+			}
+			emit(line)
+		}
+	}
+	for _, id := range added {
+		emit(chosen[id])
+	}
+
+	return writeFileAtomic(s.Path, out)
+}
@@ -0,0 +1,286 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MergeResolution records a field both sides changed differently, settled
+// in favour of the side updated last.
+type MergeResolution struct {
+	ID     string
+	Field  string
+	Winner string // "ours" or "theirs"
+}
+
+// ThreeWayMerge is the result of MergeThreeWay.
+type ThreeWayMerge struct {
+	Data        []byte
+	Resolutions []MergeResolution
+}
+
+// mergeSide is one version of a beads file in a three-way merge
+type mergeSide struct {
+	order  []string
+	lines  map[string][]byte
+	fields map[string]map[string]json.RawMessage
+	other  [][]byte // Lines that are not issues, kept as they are
+}
+
+// mergeSetKeys are the list fields merged as sets rather than as a whole,
+// with the key identifying an element
+var mergeSetKeys = map[string]func(json.RawMessage) string{
+	"labels": func(raw json.RawMessage) string { return canonicalJSON(raw) },
+	"dependencies": func(raw json.RawMessage) string {
+		var dep struct {
+			DependsOnID string `json:"depends_on_id"`
+			Type        string `json:"type"`
+		}
+		if json.Unmarshal(raw, &dep) != nil {
+			return canonicalJSON(raw)
+		}
+		return dep.DependsOnID + "\x00" + dep.Type
+	},
+	"comments": func(raw json.RawMessage) string {
+		var c struct {
+			Author string `json:"author"`
+			Text   string `json:"text"`
+		}
+		if json.Unmarshal(raw, &c) != nil {
+			return canonicalJSON(raw)
+		}
+		return c.Author + "\x00" + c.Text
+	},
+}
+
+// MergeThreeWay merges two versions of a beads file that both descend from
+// base, as a git merge driver does. Issues from either side are kept, unless
+// one side deleted an issue the other left untouched. When both sides edited
+// an issue, each field takes the side that changed it; a field both changed
+// differently takes the side with the later updated_at (ours on a tie).
+// Labels, dependencies and comments are merged as sets, so additions from
+// both sides survive and a removal on either side sticks. Issues keep ours'
+// order, with theirs' new issues after them; lines that are not issues are
+// kept from both sides. Conflict markers in any version are an error.
+func MergeThreeWay(base, ours, theirs []byte) (*ThreeWayMerge, error) {
+	var sides [3]mergeSide
+	for i, data := range [][]byte{base, ours, theirs} {
+		side, err := parseMergeSide(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", [...]string{"base", "ours", "theirs"}[i], err)
+		}
+		sides[i] = side
+	}
+	b, o, t := sides[0], sides[1], sides[2]
+
+	result := &ThreeWayMerge{}
+	var lines [][]byte
+	for _, id := range o.order {
+		line, keep := mergeIssue(id, b, o, t, result)
+		if keep {
+			lines = append(lines, line)
+		}
+	}
+	for _, id := range t.order {
+		if _, ok := o.lines[id]; ok {
+			continue
+		}
+		line, keep := mergeIssue(id, b, o, t, result)
+		if keep {
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, o.other...)
+	seen := make(map[string]bool, len(o.other))
+	for _, line := range o.other {
+		seen[string(line)] = true
+	}
+	for _, line := range t.other {
+		if !seen[string(line)] {
+			lines = append(lines, line)
+		}
+	}
+
+	eol := []byte("\n")
+	if bytes.Contains(ours, []byte("\r\n")) {
+		eol = []byte("\r\n")
+	}
+	var out bytes.Buffer
+	out.Write(ours[:len(ours)-len(stripBOM(ours))])
+	for _, line := range lines {
+		out.Write(line)
+		out.Write(eol)
+	}
+	result.Data = out.Bytes()
+	return result, nil
+}
+
+func parseMergeSide(data []byte) (mergeSide, error) {
+	side := mergeSide{lines: make(map[string][]byte), fields: make(map[string]map[string]json.RawMessage)}
+	for i, line := range bytes.Split(stripBOM(data), []byte("\n")) {
+		body := bytes.TrimSpace(line)
+		if len(body) == 0 {
+			continue
+		}
+		if isConflictMarker(body) {
+			return side, fmt.Errorf("merge conflict markers on line %d", i+1)
+		}
+		id, _ := issueLineID(body)
+		var obj map[string]json.RawMessage
+		if id == "" || json.Unmarshal(body, &obj) != nil {
+			side.other = append(side.other, body)
+			continue
+		}
+		if _, ok := side.lines[id]; !ok {
+			side.order = append(side.order, id)
+		}
+		side.lines[id] = body
+		side.fields[id] = obj
+	}
+	return side, nil
+}
+
+// mergeIssue merges the versions of one issue, reporting false when the
+// merged file should not have it
+func mergeIssue(id string, b, o, t mergeSide, result *ThreeWayMerge) ([]byte, bool) {
+	baseLine, inBase := b.lines[id]
+	ourLine, inOurs := o.lines[id]
+	theirLine, inTheirs := t.lines[id]
+	same := func(x, y []byte) bool { return canonicalJSON(x) == canonicalJSON(y) }
+
+	switch {
+	case inOurs && !inTheirs:
+		// Theirs deleted it: gone, unless ours edited it since
+		return ourLine, !inBase || !same(ourLine, baseLine)
+	case inTheirs && !inOurs:
+		return theirLine, !inBase || !same(theirLine, baseLine)
+	case same(ourLine, theirLine):
+		return ourLine, true
+	case inBase && same(ourLine, baseLine):
+		return theirLine, true
+	case inBase && same(theirLine, baseLine):
+		return ourLine, true
+	}
+
+	ourFields, theirFields := o.fields[id], t.fields[id]
+	baseFields := b.fields[id] // nil when both sides added the issue
+	ourTime, theirTime := rawTime(ourFields["updated_at"]), rawTime(theirFields["updated_at"])
+	winner, newer := "ours", ourFields
+	if theirTime.After(ourTime) {
+		winner, newer = "theirs", theirFields
+	}
+
+	merged := make(map[string]json.RawMessage, len(ourFields))
+	var keys []string
+	seen := make(map[string]bool, len(ourFields)+len(theirFields))
+	for _, fields := range []map[string]json.RawMessage{baseFields, ourFields, theirFields} {
+		for k := range fields {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		bv, inB := baseFields[k]
+		ov, inO := ourFields[k]
+		tv, inT := theirFields[k]
+		if setKey, ok := mergeSetKeys[k]; ok {
+			if items := mergeSet(bv, ov, tv, setKey); items != nil {
+				merged[k] = items
+			}
+			continue
+		}
+		if k == "updated_at" {
+			if theirTime.After(ourTime) {
+				merged[k] = tv
+			} else if inO {
+				merged[k] = ov
+			}
+			continue
+		}
+		eq := func(x json.RawMessage, inX bool, y json.RawMessage, inY bool) bool {
+			return inX == inY && (!inX || same(x, y))
+		}
+		var v json.RawMessage
+		var present bool
+		switch {
+		case eq(ov, inO, tv, inT):
+			v, present = ov, inO
+		case baseFields != nil && eq(ov, inO, bv, inB):
+			v, present = tv, inT
+		case baseFields != nil && eq(tv, inT, bv, inB):
+			v, present = ov, inO
+		default:
+			v, present = newer[k]
+			result.Resolutions = append(result.Resolutions, MergeResolution{ID: id, Field: k, Winner: winner})
+		}
+		if present {
+			merged[k] = v
+		}
+	}
+
+	raw, err := marshalNoEscape(merged)
+	if err != nil {
+		return ourLine, true
+	}
+	if formatted, err := formatIssueLine(raw); err == nil {
+		raw = formatted
+	}
+	return raw, true
+}
+
+// mergeSet merges list fields element by element: an element stays when
+// both sides have it, or one side added it; removing it on either side
+// removes it. nil leaves the field out.
+func mergeSet(base, ours, theirs json.RawMessage, key func(json.RawMessage) string) json.RawMessage {
+	if ours == nil && theirs == nil {
+		return nil
+	}
+	var b, o, t []json.RawMessage
+	_ = json.Unmarshal(base, &b)
+	_ = json.Unmarshal(ours, &o)
+	_ = json.Unmarshal(theirs, &t)
+	keys := func(items []json.RawMessage) map[string]bool {
+		set := make(map[string]bool, len(items))
+		for _, item := range items {
+			set[key(item)] = true
+		}
+		return set
+	}
+	inB, inO, inT := keys(b), keys(o), keys(t)
+
+	merged := []json.RawMessage{}
+	seen := make(map[string]bool)
+	for _, items := range [][]json.RawMessage{o, t} {
+		for _, item := range items {
+			k := key(item)
+			if seen[k] || (inB[k] && !(inO[k] && inT[k])) {
+				continue
+			}
+			seen[k] = true
+			merged = append(merged, item)
+		}
+	}
+	if len(merged) == 0 && ours == nil {
+		return nil
+	}
+	out, err := marshalNoEscape(merged)
+	if err != nil {
+		return ours
+	}
+	return out
+}
+
+// rawTime parses a JSON timestamp, zero when absent or invalid
+func rawTime(raw json.RawMessage) time.Time {
+	var t time.Time
+	if raw != nil {
+		_ = json.Unmarshal(raw, &t)
+	}
+	return t
+}
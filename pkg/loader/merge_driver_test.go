@@ -0,0 +1,90 @@
+package loader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestMergeThreeWay(t *testing.T) {
+	base := `{"id":"bv-1","title":"Login","status":"open","priority":2,"issue_type":"task","updated_at":"2025-01-01T00:00:00Z","labels":["auth","old"]}
+{"id":"bv-2","title":"Delete me","status":"open","issue_type":"task"}
+{"id":"bv-3","title":"Both edit","status":"open","issue_type":"task","updated_at":"2025-01-01T00:00:00Z"}
+`
+	ours := `{"id":"bv-1","title":"Login page","status":"open","priority":2,"issue_type":"task","updated_at":"2025-01-02T00:00:00Z","labels":["auth","ui"],"dependencies":[{"issue_id":"bv-1","depends_on_id":"bv-3","type":"blocks"}]}
+{"id":"bv-3","title":"Ours","status":"open","issue_type":"task","updated_at":"2025-01-05T00:00:00Z"}
+{"id":"bv-4","title":"Added by us","status":"open","issue_type":"task"}
+`
+	theirs := `{"id":"bv-1","title":"Login","status":"in_progress","priority":1,"issue_type":"task","updated_at":"2025-01-03T00:00:00Z","labels":["auth","old","backend"],"dependencies":[{"issue_id":"bv-1","depends_on_id":"bv-9","type":"blocks"}]}
+{"id":"bv-2","title":"Delete me","status":"open","issue_type":"task"}
+{"id":"bv-3","title":"Theirs","status":"closed","issue_type":"task","updated_at":"2025-01-04T00:00:00Z"}
+{"id":"bv-5","title":"Added by them","status":"open","issue_type":"task"}
+`
+	merged, err := loader.MergeThreeWay([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeThreeWay() error = %v", err)
+	}
+	issues, err := loader.ParseIssues(strings.NewReader(string(merged.Data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[string]int)
+	var ids []string
+	for i, issue := range issues {
+		byID[issue.ID] = i
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "bv-1,bv-3,bv-4,bv-5" {
+		t.Fatalf("issues = %s, want bv-1,bv-3,bv-4,bv-5 (bv-2 deleted by ours)", got)
+	}
+
+	login := issues[byID["bv-1"]]
+	if login.Title != "Login page" || login.Status != "in_progress" || login.Priority != 1 {
+		t.Errorf("non-overlapping edits not combined: %+v", login)
+	}
+	if got := strings.Join(login.Labels, ","); got != "auth,ui,backend" {
+		t.Errorf("labels = %s, want auth,ui,backend (old removed by ours)", got)
+	}
+	if len(login.Dependencies) != 2 {
+		t.Errorf("dependencies = %+v, want the union", login.Dependencies)
+	}
+	if login.UpdatedAt.Format("2006-01-02") != "2025-01-03" {
+		t.Errorf("updated_at = %v, want the later one", login.UpdatedAt)
+	}
+
+	// Both changed the title and ours was updated later; only theirs changed the status
+	both := issues[byID["bv-3"]]
+	if both.Title != "Ours" || both.Status != "closed" {
+		t.Errorf("overlapping edits = %+v, want our title and their status", both)
+	}
+	if len(merged.Resolutions) != 1 || merged.Resolutions[0].ID != "bv-3" || merged.Resolutions[0].Winner != "ours" {
+		t.Errorf("resolutions = %+v", merged.Resolutions)
+	}
+}
+
+func TestMergeThreeWay_KeepsEditOverDeletion(t *testing.T) {
+	base := `{"id":"bv-1","title":"A","status":"open","issue_type":"task"}` + "\n"
+	ours := `{"id":"bv-1","title":"A, edited","status":"open","issue_type":"task"}` + "\n"
+	merged, err := loader.MergeThreeWay([]byte(base), []byte(ours), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(merged.Data) != ours {
+		t.Errorf("merged = %q, want ours kept", merged.Data)
+	}
+
+	// Unchanged lines keep their exact bytes
+	ours = `{"title":"A","id":"bv-1","status":"open","issue_type":"task"}` + "\r\n"
+	merged, err = loader.MergeThreeWay([]byte(base), []byte(ours), []byte(base))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(merged.Data) != ours {
+		t.Errorf("merged = %q, want %q", merged.Data, ours)
+	}
+
+	if _, err := loader.MergeThreeWay([]byte(base), []byte("<<<<<<< HEAD\n"), []byte(base)); err == nil {
+		t.Error("conflict markers should be an error")
+	}
+}
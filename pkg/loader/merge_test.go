@@ -0,0 +1,116 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func writeMergeFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	current := `{"id":"A","title":"same","status":"open","issue_type":"task"}` + "\n" +
+		"<<<<<<< HEAD\n" +
+		`{"id":"B","title":"ours","status":"open","issue_type":"task"}` + "\n" +
+		"=======\n" +
+		`{"id":"B","title":"theirs","status":"closed","issue_type":"task"}` + "\n" +
+		">>>>>>> feature\n" +
+		`{"id":"D","title":"only here","status":"open","issue_type":"task"}` + "\n"
+	orig := `{"issue_type":"task","status":"open","id":"A","title":"same"}` + "\n" +
+		`{"id":"B","title":"theirs","status":"closed","issue_type":"task"}` + "\n" +
+		`{"id":"C","title":"new upstream","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"D","title":"only here","status":"open","issue_type":"task"}` + "\n"
+	writeShard(t, dir, "beads.jsonl", strings.TrimSuffix(current, "\n"))
+	writeShard(t, dir, "beads.orig.jsonl", strings.TrimSuffix(orig, "\n"))
+	writeShard(t, dir, "beads.backup.jsonl", `{"id":"X"}`)
+	return filepath.Join(dir, "beads.jsonl")
+}
+
+func TestFindMergeArtifacts(t *testing.T) {
+	path := writeMergeFixture(t)
+	writeShard(t, filepath.Dir(path), "beads.left.jsonl", `{"id":"A"}`)
+
+	var got []string
+	for _, p := range loader.FindMergeArtifacts(filepath.Dir(path)) {
+		got = append(got, filepath.Base(p))
+	}
+	if strings.Join(got, ",") != "beads.left.jsonl,beads.orig.jsonl" {
+		t.Errorf("FindMergeArtifacts() = %v", got)
+	}
+}
+
+func TestLoadMergeSet_Conflicts(t *testing.T) {
+	set, err := loader.LoadMergeSet(writeMergeFixture(t))
+	if err != nil {
+		t.Fatalf("LoadMergeSet() error = %v", err)
+	}
+	if strings.Join(set.Variants, ",") != "current,orig" {
+		t.Errorf("Variants = %v", set.Variants)
+	}
+
+	// A differs only in key order; D is identical
+	var ids []string
+	for _, c := range set.Conflicts {
+		ids = append(ids, c.ID)
+	}
+	if strings.Join(ids, ",") != "B,C" {
+		t.Fatalf("conflicts = %v, want B,C", ids)
+	}
+	b := set.Conflicts[0]
+	if b.Versions[0].Issue == nil || b.Versions[0].Issue.Title != "ours" || b.Versions[1].Issue.Title != "theirs" {
+		t.Errorf("unexpected versions for B: %+v", b.Versions)
+	}
+	if c := set.Conflicts[1]; c.Versions[0].Issue != nil || c.Versions[1].Issue == nil {
+		t.Errorf("C should be missing from current only: %+v", c.Versions)
+	}
+}
+
+func TestMergeSet_Resolve(t *testing.T) {
+	path := writeMergeFixture(t)
+	set, err := loader.LoadMergeSet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Resolve(map[string]int{"B": 1, "C": 1}); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"A","title":"same","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"B","title":"theirs","status":"closed","issue_type":"task"}` + "\n" +
+		`{"id":"D","title":"only here","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"C","title":"new upstream","status":"open","issue_type":"task"}` + "\n"
+	if string(data) != want {
+		t.Errorf("merged file =\n%s\nwant\n%s", data, want)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 4 {
+		t.Errorf("merged file should load cleanly, got %d issues, err %v", len(issues), err)
+	}
+}
+
+func TestMergeSet_ResolveKeepCurrentAndStale(t *testing.T) {
+	path := writeMergeFixture(t)
+	set, err := loader.LoadMergeSet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Resolve(nil); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "<<<<<<<") || strings.Contains(string(data), "theirs") || strings.Contains(string(data), `"C"`) {
+		t.Errorf("keeping current should drop markers, the duplicate B and C:\n%s", data)
+	}
+
+	// The file changed since the set was loaded
+	if err := set.Resolve(nil); err == nil {
+		t.Error("expected error when the file changed after LoadMergeSet")
+	}
+}
@@ -0,0 +1,113 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Schema migrations recognized by the decoder. Each one maps a field name
+// used by older beads files onto the current model.
+const (
+	// MigrationDependencyTargetID renames dependencies[].target_id to depends_on_id
+	MigrationDependencyTargetID = "dependency target_id → depends_on_id"
+	// MigrationDependencyDependsOn renames dependencies[].depends_on to depends_on_id
+	MigrationDependencyDependsOn = "dependency depends_on → depends_on_id"
+	// MigrationIssueType renames the issue-level type field to issue_type
+	MigrationIssueType = "type → issue_type"
+)
+
+// legacyIssue holds the older field names a line may use.
+type legacyIssue struct {
+	Type         model.IssueType    `json:"type"`
+	Dependencies []legacyDependency `json:"dependencies"`
+}
+
+type legacyDependency struct {
+	TargetID  string `json:"target_id"`
+	DependsOn string `json:"depends_on"`
+}
+
+// legacyMarkers are the JSON keys that can only come from older formats.
+// Lines without any of them (and with an issue_type) skip the second decode.
+var legacyMarkers = [][]byte{[]byte(`"target_id"`), []byte(`"depends_on"`)}
+
+// migrateIssue normalizes older field names in a decoded line onto issue
+// and returns the migrations applied. Fields already set in the current
+// format are never overwritten.
+func migrateIssue(line []byte, issue *model.Issue) []string {
+	legacyKeys := issue.IssueType == ""
+	for _, marker := range legacyMarkers {
+		if bytes.Contains(line, marker) {
+			legacyKeys = true
+			break
+		}
+	}
+	if !legacyKeys {
+		return nil
+	}
+
+	var legacy legacyIssue
+	if json.Unmarshal(line, &legacy) != nil {
+		return nil
+	}
+
+	var applied []string
+	note := func(name string) {
+		for _, a := range applied {
+			if a == name {
+				return
+			}
+		}
+		applied = append(applied, name)
+	}
+
+	if issue.IssueType == "" && legacy.Type != "" {
+		issue.IssueType = legacy.Type
+		note(MigrationIssueType)
+	}
+	for i, ld := range legacy.Dependencies {
+		if i >= len(issue.Dependencies) || issue.Dependencies[i] == nil || issue.Dependencies[i].DependsOnID != "" {
+			continue
+		}
+		dep := issue.Dependencies[i]
+		switch {
+		case ld.TargetID != "":
+			dep.DependsOnID = ld.TargetID
+			note(MigrationDependencyTargetID)
+		case ld.DependsOn != "":
+			dep.DependsOnID = ld.DependsOn
+			note(MigrationDependencyDependsOn)
+		default:
+			continue
+		}
+		// Older formats only listed the target; the owner is the issue itself
+		if dep.IssueID == "" {
+			dep.IssueID = issue.ID
+		}
+	}
+	return applied
+}
+
+// migrationSummary formats migration counts for a single warning, e.g.
+// "applied schema migrations: type → issue_type (3 issues)".
+func migrationSummary(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		unit := "issues"
+		if counts[name] == 1 {
+			unit = "issue"
+		}
+		parts[i] = fmt.Sprintf("%s (%d %s)", name, counts[name], unit)
+	}
+	return "applied schema migrations for an older beads format: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,69 @@
+package loader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestParseIssues_MigratesOlderFieldNames(t *testing.T) {
+	input := `{"id":"A","title":"a","status":"open","type":"bug","dependencies":[{"target_id":"B","type":"blocks"}]}` + "\n" +
+		`{"id":"B","title":"b","status":"open","issue_type":"task","dependencies":[{"depends_on":"C","type":"related"},null]}` + "\n" +
+		`{"id":"C","title":"c","status":"open","issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"A","target_id":"B","type":"blocks"}]}` + "\n"
+
+	applied := make(map[string][]string)
+	issues, err := loader.ParseIssuesWithOptions(strings.NewReader(input), loader.ParseOptions{
+		MigrationHandler: func(id string, migrations []string) {
+			applied[id] = migrations
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseIssuesWithOptions() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+
+	a := issues[0]
+	if a.IssueType != "bug" {
+		t.Errorf("A issue_type = %q, want bug", a.IssueType)
+	}
+	if len(a.Dependencies) != 1 || a.Dependencies[0].DependsOnID != "B" || a.Dependencies[0].IssueID != "A" {
+		t.Errorf("A dependency not migrated: %+v", a.Dependencies[0])
+	}
+	if got := strings.Join(applied["A"], "; "); got != loader.MigrationIssueType+"; "+loader.MigrationDependencyTargetID {
+		t.Errorf("A migrations = %q", got)
+	}
+
+	if b := issues[1]; b.Dependencies[0].DependsOnID != "C" {
+		t.Errorf("B dependency not migrated: %+v", b.Dependencies[0])
+	}
+	if got := applied["B"]; len(got) != 1 || got[0] != loader.MigrationDependencyDependsOn {
+		t.Errorf("B migrations = %v", got)
+	}
+
+	// Current field names win over legacy ones
+	if c := issues[2]; c.Dependencies[0].DependsOnID != "A" {
+		t.Errorf("C dependency should keep depends_on_id, got %+v", c.Dependencies[0])
+	}
+	if _, ok := applied["C"]; ok {
+		t.Errorf("C should not be migrated, got %v", applied["C"])
+	}
+}
+
+func TestParseIssues_MigrationSummaryWarning(t *testing.T) {
+	input := `{"id":"A","title":"a","status":"open","type":"task"}` + "\n" +
+		`{"id":"B","title":"b","status":"open","type":"task"}` + "\n"
+
+	var warnings []string
+	issues, err := loader.ParseIssuesWithOptions(strings.NewReader(input), loader.ParseOptions{
+		WarningHandler: func(msg string) { warnings = append(warnings, msg) },
+	})
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d (err %v)", len(issues), err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], loader.MigrationIssueType+" (2 issues)") {
+		t.Errorf("expected one summary warning, got %v", warnings)
+	}
+}
@@ -0,0 +1,278 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// issueIDPattern is what a new issue ID may look like. Workspace prefixes
+// may end in ":" or "/".
+var issueIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]*$`)
+
+// ValidateIssueID reports whether id can name an issue: letters, digits and
+// . - _ : /, starting with a letter or digit.
+func ValidateIssueID(id string) error {
+	if !issueIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid issue ID %q (use letters, digits and . - _ : /)", id)
+	}
+	return nil
+}
+
+// RenameFile is one beads file a rename rewrites. IDs maps each way the
+// file may write the old ID to the new ID written the same way: repos of a
+// workspace refer to one another's issues by qualified ID (api-AUTH-1).
+type RenameFile struct {
+	Path string
+	IDs  map[string]string
+}
+
+// RenameLine is one line a rename changes.
+type RenameLine struct {
+	Path    string
+	Line    int      // 1-based line number
+	IssueID string   // The issue on the line, before the rename
+	Fields  []string // What changed: id, dependencies, comments
+	Before  string
+	After   string
+}
+
+// RenamePlan is a rename worked out against the current files. Nothing is
+// written until Apply.
+type RenamePlan struct {
+	OldID string
+	NewID string
+	Lines []RenameLine
+
+	files []renamedFile
+}
+
+// renamedFile is a file's contents when planned and after the rename
+type renamedFile struct {
+	path      string
+	data, out []byte
+}
+
+// PlanIssueRename plans renaming oldID to newID in the beads file at path,
+// the uncompressed shards next to it and the archive.
+func PlanIssueRename(path, oldID, newID string) (*RenamePlan, error) {
+	if IsCompressedJSONL(path) {
+		return nil, ErrCompressedReadOnly
+	}
+	paths := RenameFiles(path)
+	files := make([]RenameFile, len(paths))
+	for i, p := range paths {
+		files[i] = RenameFile{Path: p, IDs: map[string]string{oldID: newID}}
+	}
+	return PlanRename(oldID, newID, files)
+}
+
+// RenameFiles returns the files a rename in the beads file at path rewrites:
+// the file, its uncompressed shards and the archive next to it, if any.
+func RenameFiles(path string) []string {
+	paths := EditableFiles(path)
+	if _, err := os.Stat(ArchivePath(path)); err == nil {
+		paths = append(paths, ArchivePath(path))
+	}
+	return paths
+}
+
+// PlanRename plans renaming the issue oldID to newID across files: the
+// issue's own id and every dependency (parent-child links included) and
+// comment that refers to it. Mentions in free text are left alone. The
+// issue must be in one of the files and no file may already use the new ID.
+func PlanRename(oldID, newID string, files []RenameFile) (*RenamePlan, error) {
+	if err := ValidateIssueID(newID); err != nil {
+		return nil, err
+	}
+	if oldID == newID {
+		return nil, fmt.Errorf("%s already has that ID", oldID)
+	}
+
+	plan := &RenamePlan{OldID: oldID, NewID: newID}
+	found := false
+	now, err := marshalNoEscape(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		taken := make(map[string]bool, len(f.IDs))
+		for _, to := range f.IDs {
+			taken[to] = true
+		}
+
+		lines := bytes.Split(data, []byte("\n"))
+		changed := false
+		for i, line := range lines {
+			var prefix []byte
+			if i == 0 {
+				prefix = line[:len(line)-len(stripBOM(line))]
+			}
+			body := bytes.TrimSuffix(line[len(prefix):], []byte("\r"))
+			suffix := line[len(prefix)+len(body):]
+
+			id, _ := issueLineID(body)
+			if id == "" {
+				continue
+			}
+			if taken[id] {
+				return nil, fmt.Errorf("issue %s already exists in %s", id, filepath.Base(f.Path))
+			}
+			if !mentionsAny(body, f.IDs) {
+				continue
+			}
+			updated, fields, err := renameReferences(body, f.IDs, now)
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewrite %s: %w", id, err)
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			if _, ok := f.IDs[id]; ok {
+				found = true
+			}
+			plan.Lines = append(plan.Lines, RenameLine{
+				Path:    f.Path,
+				Line:    i + 1,
+				IssueID: id,
+				Fields:  fields,
+				Before:  string(body),
+				After:   string(updated),
+			})
+			lines[i] = append(append(append([]byte{}, prefix...), updated...), suffix...)
+			changed = true
+		}
+		if changed {
+			plan.files = append(plan.files, renamedFile{path: f.Path, data: data, out: bytes.Join(lines, []byte("\n"))})
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("issue %s not found", oldID)
+	}
+	return plan, nil
+}
+
+// mentionsAny is a cheap check that line might refer to one of ids
+func mentionsAny(line []byte, ids map[string]string) bool {
+	for from := range ids {
+		if bytes.Contains(line, []byte(from)) {
+			return true
+		}
+	}
+	return false
+}
+
+// renameReferences rewrites the references to ids in a raw issue line and
+// bumps updated_at, returning which fields changed (none leaves raw as is)
+func renameReferences(raw []byte, ids map[string]string, now json.RawMessage) ([]byte, []string, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, nil, err
+	}
+	rename := func(v json.RawMessage) (json.RawMessage, bool) {
+		var s string
+		if json.Unmarshal(v, &s) != nil {
+			return v, false
+		}
+		to, ok := ids[s]
+		if !ok {
+			return v, false
+		}
+		b, err := marshalNoEscape(to)
+		if err != nil {
+			return v, false
+		}
+		return b, true
+	}
+
+	var fields []string
+	if v, ok := rename(obj["id"]); ok {
+		obj["id"] = v
+		fields = append(fields, "id")
+	}
+	for _, list := range []struct{ key, field string }{
+		{"dependencies", "issue_id"}, {"dependencies", "depends_on_id"}, {"comments", "issue_id"},
+	} {
+		var items []map[string]json.RawMessage
+		if obj[list.key] == nil || json.Unmarshal(obj[list.key], &items) != nil {
+			continue
+		}
+		touched := false
+		for _, item := range items {
+			if v, ok := rename(item[list.field]); ok {
+				item[list.field] = v
+				touched = true
+			}
+		}
+		if !touched {
+			continue
+		}
+		b, err := marshalNoEscape(items)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj[list.key] = b
+		if len(fields) == 0 || fields[len(fields)-1] != list.key {
+			fields = append(fields, list.key)
+		}
+	}
+	if len(fields) == 0 {
+		return raw, nil, nil
+	}
+	obj["updated_at"] = now
+	out, err := marshalIssue(obj)
+	return out, fields, err
+}
+
+// Files returns the paths the plan rewrites
+func (p *RenamePlan) Files() []string {
+	paths := make([]string, len(p.files))
+	for i, f := range p.files {
+		paths[i] = f.path
+	}
+	return paths
+}
+
+// Diff renders the plan as a line diff, one hunk per changed line.
+func (p *RenamePlan) Diff() string {
+	var sb strings.Builder
+	for _, l := range p.Lines {
+		fmt.Fprintf(&sb, "@@ %s:%d %s (%s)\n", l.Path, l.Line, l.IssueID, strings.Join(l.Fields, ", "))
+		fmt.Fprintf(&sb, "-%s\n+%s\n", l.Before, l.After)
+	}
+	return sb.String()
+}
+
+// Apply writes the rename. Every file must be unchanged since the plan was
+// made. All files are staged before any is replaced, so a failure leaves no
+// file referring to the new ID while another still uses the old one.
+func (p *RenamePlan) Apply() error {
+	if err := checkUnchanged(p.files, "rename"); err != nil {
+		return err
+	}
+	return writeFilesAtomic(p.files)
+}
+
+// checkUnchanged fails unless every file still holds what it held when the
+// named operation was planned
+func checkUnchanged(files []renamedFile, what string) error {
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.path, err)
+		}
+		if !bytes.Equal(data, f.data) {
+			return fmt.Errorf("%s changed since the %s was planned; run it again", filepath.Base(f.path), what)
+		}
+	}
+	return nil
+}
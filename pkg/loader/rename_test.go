@@ -0,0 +1,136 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+const renameFixture = `{"id":"bv-1","title":"Epic","status":"open","issue_type":"epic","comments":[{"id":1,"issue_id":"bv-1","author":"ann","text":"see bv-1"}]}
+{"id":"bv-2","title":"Child","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"parent-child"}]}
+{"id":"bv-10","title":"Unrelated","status":"open","issue_type":"task"}`
+
+func TestPlanIssueRename(t *testing.T) {
+	dir := t.TempDir()
+	path := writeShard(t, dir, "beads.jsonl", renameFixture)
+
+	plan, err := loader.PlanIssueRename(path, "bv-1", "auth-1")
+	if err != nil {
+		t.Fatalf("PlanIssueRename() error = %v", err)
+	}
+	if len(plan.Lines) != 2 {
+		t.Fatalf("got %d changed lines, want 2: %+v", len(plan.Lines), plan.Lines)
+	}
+	if got := strings.Join(plan.Lines[0].Fields, ","); got != "id,comments" {
+		t.Errorf("fields of the renamed issue = %s", got)
+	}
+	if plan.Lines[1].IssueID != "bv-2" || strings.Join(plan.Lines[1].Fields, ",") != "dependencies" {
+		t.Errorf("dependent line = %+v", plan.Lines[1])
+	}
+	if diff := plan.Diff(); !strings.Contains(diff, ":2 bv-2 (dependencies)") || !strings.Contains(diff, `+{"id":"bv-2","title":"Child",`) {
+		t.Errorf("diff:\n%s", diff)
+	}
+
+	// Planning writes nothing
+	if data, _ := os.ReadFile(path); string(data) != renameFixture+"\n" {
+		t.Fatalf("plan changed the file:\n%s", data)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues[0].ID != "auth-1" || issues[0].Comments[0].IssueID != "auth-1" || issues[0].Comments[0].Text != "see bv-1" {
+		t.Errorf("renamed issue = %+v", issues[0])
+	}
+	if dep := issues[1].Dependencies[0]; dep.DependsOnID != "auth-1" || dep.IssueID != "bv-2" {
+		t.Errorf("dependency = %+v", dep)
+	}
+	if issues[2].ID != "bv-10" {
+		t.Errorf("an ID that merely starts with the old one must not change: %s", issues[2].ID)
+	}
+}
+
+func TestPlanIssueRename_Errors(t *testing.T) {
+	path := writeShard(t, t.TempDir(), "beads.jsonl", renameFixture)
+	for name, ids := range map[string][2]string{
+		"missing":   {"bv-9", "bv-99"},
+		"taken":     {"bv-1", "bv-10"},
+		"invalid":   {"bv-1", "bad id"},
+		"unchanged": {"bv-1", "bv-1"},
+	} {
+		if _, err := loader.PlanIssueRename(path, ids[0], ids[1]); err == nil {
+			t.Errorf("%s: expected an error renaming %s to %q", name, ids[0], ids[1])
+		}
+	}
+}
+
+func TestPlanIssueRename_Archive(t *testing.T) {
+	dir := t.TempDir()
+	path := writeShard(t, dir, "beads.jsonl", renameFixture)
+	archive := writeShard(t, dir, loader.ArchiveFileName,
+		`{"id":"bv-3","title":"Old","status":"closed","issue_type":"task","dependencies":[{"issue_id":"bv-3","depends_on_id":"bv-1","type":"blocks"}]}`)
+
+	plan, err := loader.PlanIssueRename(path, "bv-1", "auth-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := plan.Files(); len(files) != 2 || files[1] != archive {
+		t.Fatalf("files = %v, want the beads file and the archive", files)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(archive); !strings.Contains(string(data), `"depends_on_id":"auth-1"`) {
+		t.Errorf("archived dependency not renamed:\n%s", data)
+	}
+
+	// An archived issue holds its ID too
+	if _, err := loader.PlanIssueRename(path, "auth-1", "bv-3"); err == nil {
+		t.Error("renaming to an archived issue's ID should fail")
+	}
+}
+
+func TestPlanRename_Workspace(t *testing.T) {
+	api := writeShard(t, t.TempDir(), "beads.jsonl", `{"id":"AUTH-1","title":"Login","status":"open","issue_type":"task"}`)
+	web := writeShard(t, t.TempDir(), "beads.jsonl",
+		`{"id":"UI-1","title":"Form","status":"open","issue_type":"task","dependencies":[{"issue_id":"UI-1","depends_on_id":"api-AUTH-1","type":"blocks"}]}`)
+
+	plan, err := loader.PlanRename("api-AUTH-1", "api-AUTH-2", []loader.RenameFile{
+		{Path: api, IDs: map[string]string{"AUTH-1": "AUTH-2", "api-AUTH-1": "api-AUTH-2"}},
+		{Path: web, IDs: map[string]string{"api-AUTH-1": "api-AUTH-2"}},
+	})
+	if err != nil {
+		t.Fatalf("PlanRename() error = %v", err)
+	}
+	if len(plan.Files()) != 2 {
+		t.Fatalf("files = %v", plan.Files())
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(web); !strings.Contains(string(data), `"depends_on_id":"api-AUTH-2"`) {
+		t.Errorf("cross-repo dependency not renamed:\n%s", data)
+	}
+	if data, _ := os.ReadFile(api); !strings.Contains(string(data), `"id":"AUTH-2"`) {
+		t.Errorf("issue not renamed in its repo:\n%s", data)
+	}
+
+	// A file changed after planning is not overwritten
+	plan, err = loader.PlanRename("AUTH-2", "AUTH-3", []loader.RenameFile{{Path: api, IDs: map[string]string{"AUTH-2": "AUTH-3"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(api), "beads.jsonl"), []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(); err == nil {
+		t.Error("Apply() should refuse a file changed since planning")
+	}
+}
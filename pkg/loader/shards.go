@@ -0,0 +1,123 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// shardPattern matches shard files such as beads-0001.jsonl or
+// issues-20250101.jsonl.gz written by tools that append one file per day.
+var shardPattern = regexp.MustCompile(`^(?:beads|issues)-(\d+)\.jsonl(?:\.gz|\.zst)?$`)
+
+// IsShardPath reports whether path names a beads shard file.
+func IsShardPath(path string) bool {
+	return shardPattern.MatchString(filepath.Base(path))
+}
+
+// FindShardPaths returns the shard files in beadsDir ordered by shard number.
+func FindShardPaths(beadsDir string) []string {
+	entries, err := os.ReadDir(beadsDir)
+	if err != nil {
+		return nil
+	}
+	type shard struct {
+		name string
+		num  uint64
+	}
+	var shards []shard
+	for _, e := range entries {
+		m := shardPattern.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		num, _ := strconv.ParseUint(m[1], 10, 64)
+		shards = append(shards, shard{name: e.Name(), num: num})
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].num != shards[j].num {
+			return shards[i].num < shards[j].num
+		}
+		return shards[i].name < shards[j].name
+	})
+
+	paths := make([]string, len(shards))
+	for i, s := range shards {
+		paths[i] = filepath.Join(beadsDir, s.name)
+	}
+	return paths
+}
+
+// shardSet returns every file whose issues path belongs with: the main beads
+// file (if any) followed by the shards in order. It is nil when path is a
+// plain file with no shards next to it.
+func shardSet(path string) []string {
+	dir := filepath.Dir(path)
+	shards := FindShardPaths(dir)
+	if len(shards) == 0 {
+		return nil
+	}
+	if !IsShardPath(path) && !isPreferredJSONLName(filepath.Base(path)) {
+		return nil
+	}
+	var files []string
+	if main, err := FindJSONLPath(dir); err == nil && !IsShardPath(main) {
+		files = append(files, main)
+	}
+	return append(files, shards...)
+}
+
+// EditableFiles returns path followed by the uncompressed shards it belongs
+// with: the files an edit touching every issue may rewrite.
+func EditableFiles(path string) []string {
+	files := []string{path}
+	for _, shard := range shardSet(path) {
+		if shard != path && !IsCompressedJSONL(shard) {
+			files = append(files, shard)
+		}
+	}
+	return files
+}
+
+// isPreferredJSONLName reports whether name is one of PreferredJSONLNames,
+// optionally compressed.
+func isPreferredJSONLName(name string) bool {
+	base := trimCompressedExt(name)
+	for _, preferred := range PreferredJSONLNames {
+		if base == preferred {
+			return true
+		}
+	}
+	return false
+}
+
+// loadShards reads every file and merges issues that share an ID, keeping the
+// copy with the latest updated_at (the later file on ties). Issues keep the
+// position where their ID first appeared.
+func loadShards(files []string, opts ParseOptions) ([]model.Issue, error) {
+	var merged []model.Issue
+	index := make(map[string]int)
+	for _, path := range files {
+		issues, err := loadIssuesFile(path, opts)
+		if err != nil {
+			return nil, fmt.Errorf("loading shard %s: %w", filepath.Base(path), err)
+		}
+		for _, issue := range issues {
+			i, seen := index[issue.ID]
+			if !seen {
+				index[issue.ID] = len(merged)
+				merged = append(merged, issue)
+				continue
+			}
+			if !issue.UpdatedAt.Before(merged[i].UpdatedAt) {
+				merged[i] = issue
+			}
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,110 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeShard(t *testing.T, dir, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindShardPaths_Order(t *testing.T) {
+	dir := t.TempDir()
+	writeShard(t, dir, "beads-10.jsonl", `{"id":"A"}`)
+	writeShard(t, dir, "beads-2.jsonl", `{"id":"B"}`)
+	writeShard(t, dir, "issues-2.jsonl.gz", `{"id":"C"}`)
+	writeShard(t, dir, "beads-x.jsonl", `{"id":"D"}`)
+
+	var got []string
+	for _, p := range loader.FindShardPaths(dir) {
+		got = append(got, filepath.Base(p))
+	}
+	want := []string{"beads-2.jsonl", "issues-2.jsonl.gz", "beads-10.jsonl"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("FindShardPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestFindJSONLPath_Shards(t *testing.T) {
+	dir := t.TempDir()
+	writeShard(t, dir, "beads-1.jsonl", `{"id":"A"}`)
+	writeShard(t, dir, "beads-2.jsonl", `{"id":"B"}`)
+
+	path, err := loader.FindJSONLPath(dir)
+	if err != nil {
+		t.Fatalf("FindJSONLPath() error = %v", err)
+	}
+	if filepath.Base(path) != "beads-2.jsonl" {
+		t.Errorf("without a main file got %s, want newest shard", filepath.Base(path))
+	}
+
+	writeShard(t, dir, "beads.jsonl", `{"id":"M"}`)
+	path, err = loader.FindJSONLPath(dir)
+	if err != nil {
+		t.Fatalf("FindJSONLPath() error = %v", err)
+	}
+	if filepath.Base(path) != "beads.jsonl" {
+		t.Errorf("with a main file got %s, want beads.jsonl", filepath.Base(path))
+	}
+}
+
+func TestLoadIssues_MergesShards(t *testing.T) {
+	repo := t.TempDir()
+	dir := filepath.Join(repo, ".beads")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeShard(t, dir, "beads.jsonl",
+		`{"id":"A","title":"main","status":"open","issue_type":"task","updated_at":"2025-01-02T00:00:00Z"}`,
+		`{"id":"B","title":"main","status":"open","issue_type":"task","updated_at":"2025-01-01T00:00:00Z"}`)
+	writeShard(t, dir, "beads-1.jsonl",
+		`{"id":"A","title":"older","status":"open","issue_type":"task","updated_at":"2025-01-01T00:00:00Z"}`,
+		`{"id":"B","title":"tie","status":"open","issue_type":"task","updated_at":"2025-01-01T00:00:00Z"}`,
+		`{"id":"C","title":"new","status":"open","issue_type":"task"}`)
+
+	issues, err := loader.LoadIssues(repo)
+	if err != nil {
+		t.Fatalf("LoadIssues() error = %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.ID+"="+issue.Title)
+	}
+	want := "A=main,B=tie,C=new"
+	if strings.Join(got, ",") != want {
+		t.Errorf("merged issues = %v, want %s", got, want)
+	}
+}
+
+func TestUpdateIssueInFile_OtherShard(t *testing.T) {
+	dir := t.TempDir()
+	writeShard(t, dir, "beads-1.jsonl", `{"id":"A","title":"old","status":"open","issue_type":"task"}`)
+	newest := writeShard(t, dir, "beads-2.jsonl", `{"id":"B","title":"b","status":"open","issue_type":"task"}`)
+
+	issue := model.Issue{ID: "A", Title: "renamed", Status: model.StatusOpen, IssueType: model.TypeTask}
+	if err := loader.UpdateIssueInFile(newest, issue); err != nil {
+		t.Fatalf("UpdateIssueInFile() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "beads-1.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"title":"renamed"`) {
+		t.Errorf("shard not updated: %s", data)
+	}
+
+	if err := loader.UpdateIssueInFile(newest, model.Issue{ID: "missing"}); err == nil {
+		t.Error("expected error for unknown issue")
+	}
+}
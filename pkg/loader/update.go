@@ -0,0 +1,432 @@
+package loader
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// UpdateIssueInFile rewrites the line for issue.ID in a beads JSONL file,
+// copying the user-editable fields (title, description, status, priority,
+// issue type, assignee, labels, estimate) from issue and bumping updated_at.
+// Fields bv does not model and all other lines are preserved verbatim.
+// The write is atomic (temp file + rename) to be safe with watchers.
+func UpdateIssueInFile(path string, issue model.Issue) error {
+	return rewriteIssueLine(path, issue.ID, func(raw []byte) ([]byte, error) {
+		return applyIssueFields(raw, issue)
+	})
+}
+
+// UpdateDependenciesInFile replaces the dependencies of issueID in a beads
+// JSONL file and bumps updated_at, preserving everything else like
+// UpdateIssueInFile.
+func UpdateDependenciesInFile(path, issueID string, deps []*model.Dependency) error {
+	return rewriteIssueLine(path, issueID, func(raw []byte) ([]byte, error) {
+		return applyDependencies(raw, deps)
+	})
+}
+
+// UpdateIssueFieldsInFile sets only the given top-level fields of issueID in
+// a beads JSONL file and bumps updated_at, so changes made on disk since the
+// issue was loaded survive. A nil value removes the field.
+func UpdateIssueFieldsInFile(path, issueID string, fields map[string]any) error {
+	return rewriteIssueLine(path, issueID, func(raw []byte) ([]byte, error) {
+		return applyFields(raw, fields)
+	})
+}
+
+// AppendIssuesToFile adds new issues to the end of a beads JSONL file,
+// following the file's line endings. It refuses IDs already in the file.
+// The write is atomic like UpdateIssueInFile.
+func AppendIssuesToFile(path string, issues []model.Issue) error {
+	if IsCompressedJSONL(path) {
+		return ErrCompressedReadOnly
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read beads file: %w", err)
+	}
+
+	adding := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		if issue.ID == "" || adding[issue.ID] {
+			return fmt.Errorf("invalid or duplicate issue ID %q", issue.ID)
+		}
+		adding[issue.ID] = true
+	}
+	for _, line := range bytes.Split(stripBOM(data), []byte("\n")) {
+		var head struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(bytes.TrimSpace(line), &head) == nil && adding[head.ID] {
+			return fmt.Errorf("issue %s already exists in %s", head.ID, path)
+		}
+	}
+
+	eol := []byte("\n")
+	if bytes.Contains(data, []byte("\r\n")) {
+		eol = []byte("\r\n")
+	}
+	out := append([]byte{}, data...)
+	if len(bytes.TrimSpace(stripBOM(out))) > 0 && !bytes.HasSuffix(out, []byte("\n")) {
+		out = append(out, eol...)
+	}
+	for _, issue := range issues {
+		line, err := marshalNoEscape(issue)
+		if err != nil {
+			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+		out = append(append(out, line...), eol...)
+	}
+
+	return writeFileAtomic(path, out)
+}
+
+// idAlphabet matches the lowercase base36 suffixes bd generates
+const idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// NewIssueID returns an unused ID of the form <prefix>-<4 base36 chars>.
+// The prefix is the one most existing issues use, or fallbackPrefix when
+// there are none.
+func NewIssueID(issues []model.Issue, fallbackPrefix string) string {
+	return NewIssueIDs(issues, IssuePrefix(issues, fallbackPrefix), 1, nil)[0]
+}
+
+// IssuePrefix returns the prefix (the part before the last "-") most
+// existing IDs use, or fallbackPrefix when there are none; "bd" when both
+// are empty.
+func IssuePrefix(issues []model.Issue, fallbackPrefix string) string {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		if i := strings.LastIndex(issue.ID, "-"); i > 0 {
+			counts[issue.ID[:i]]++
+		}
+	}
+	prefix := fallbackPrefix
+	best := 0
+	for p, n := range counts {
+		if n > best || (n == best && p < prefix) {
+			prefix, best = p, n
+		}
+	}
+	if prefix == "" {
+		prefix = "bd"
+	}
+	return prefix
+}
+
+// NewIssueIDs returns n distinct IDs of the form <prefix>-<base36 suffix>
+// that no issue uses and that are not in reserved, such as the IDs of
+// deleted issues. Suffixes are 4 characters, longer once those are crowded.
+func NewIssueIDs(issues []model.Issue, prefix string, n int, reserved []string) []string {
+	taken := make(map[string]bool, len(issues)+len(reserved)+n)
+	for _, issue := range issues {
+		taken[issue.ID] = true
+	}
+	for _, id := range reserved {
+		taken[id] = true
+	}
+
+	ids := make([]string, 0, n)
+	length := 4
+	for len(ids) < n {
+		found := false
+		for attempt := 0; attempt < 20 && !found; attempt++ {
+			suffix := make([]byte, length)
+			for i := range suffix {
+				r, err := rand.Int(rand.Reader, big.NewInt(int64(len(idAlphabet))))
+				if err != nil {
+					r = big.NewInt(int64(time.Now().UnixNano() % int64(len(idAlphabet))))
+				}
+				suffix[i] = idAlphabet[r.Int64()]
+			}
+			if id := prefix + "-" + string(suffix); !taken[id] {
+				taken[id] = true
+				ids = append(ids, id)
+				found = true
+			}
+		}
+		if !found {
+			length++
+		}
+	}
+	return ids
+}
+
+// errIssueNotInFile is returned by rewriteIssueLineIn when id is absent.
+var errIssueNotInFile = errors.New("issue not in file")
+
+// rewriteIssueLine replaces the line for id with apply(line), keeping a
+// leading BOM and CRLF line endings, and writes the file atomically. In a
+// sharded directory the issue may live in any uncompressed shard; newer
+// files are searched first.
+func rewriteIssueLine(path, id string, apply func(raw []byte) ([]byte, error)) error {
+	if IsCompressedJSONL(path) {
+		return ErrCompressedReadOnly
+	}
+	files := []string{path}
+	set := shardSet(path)
+	for i := len(set) - 1; i >= 0; i-- {
+		if set[i] != path && !IsCompressedJSONL(set[i]) {
+			files = append(files, set[i])
+		}
+	}
+	for _, file := range files {
+		if err := rewriteIssueLineIn(file, id, apply); !errors.Is(err, errIssueNotInFile) {
+			return err
+		}
+	}
+	return fmt.Errorf("issue %s not found in %s", id, path)
+}
+
+// rewriteIssueLineIn is rewriteIssueLine for a single file.
+func rewriteIssueLineIn(path, id string, apply func(raw []byte) ([]byte, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read beads file: %w", err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	found := false
+	for i, line := range lines {
+		var prefix []byte
+		if i == 0 {
+			prefix = line[:len(line)-len(stripBOM(line))]
+		}
+		body := bytes.TrimSuffix(line[len(prefix):], []byte("\r"))
+		suffix := line[len(prefix)+len(body):]
+
+		var head struct {
+			ID string `json:"id"`
+		}
+		if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &head) != nil || head.ID != id {
+			continue
+		}
+
+		updated, err := apply(body)
+		if err != nil {
+			return fmt.Errorf("failed to update issue %s: %w", id, err)
+		}
+		lines[i] = append(append(append([]byte{}, prefix...), updated...), suffix...)
+		found = true
+		break
+	}
+	if !found {
+		return errIssueNotInFile
+	}
+
+	return writeFileAtomic(path, bytes.Join(lines, []byte("\n")))
+}
+
+// applyIssueFields merges the editable fields of issue into a raw JSON object
+func applyIssueFields(raw []byte, issue model.Issue) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	var prevStatus model.Status
+	if v, ok := obj["status"]; ok {
+		_ = json.Unmarshal(v, &prevStatus)
+	}
+
+	set := func(key string, value any) error {
+		b, err := marshalNoEscape(value)
+		if err != nil {
+			return err
+		}
+		obj[key] = b
+		return nil
+	}
+
+	now := time.Now().UTC()
+	fields := []struct {
+		key   string
+		value any
+		omit  bool
+	}{
+		{"title", issue.Title, false},
+		{"description", issue.Description, false},
+		{"status", issue.Status, false},
+		{"priority", issue.Priority, false},
+		{"issue_type", issue.IssueType, false},
+		{"assignee", issue.Assignee, issue.Assignee == ""},
+		{"labels", issue.Labels, len(issue.Labels) == 0},
+		{"estimated_minutes", issue.EstimatedMinutes, issue.EstimatedMinutes == nil},
+		{"updated_at", now, false},
+	}
+	for _, f := range fields {
+		if f.omit {
+			delete(obj, f.key)
+			continue
+		}
+		if err := set(f.key, f.value); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep closed_at consistent with the status transition
+	switch {
+	case issue.Status.IsClosed() && !prevStatus.IsClosed():
+		if err := set("closed_at", now); err != nil {
+			return nil, err
+		}
+	case !issue.Status.IsClosed() && prevStatus.IsClosed():
+		delete(obj, "closed_at")
+	}
+
+	return marshalIssue(obj)
+}
+
+// applyFields sets or removes the given fields of a raw JSON issue object
+func applyFields(raw []byte, fields map[string]any) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	for key, value := range fields {
+		if value == nil {
+			delete(obj, key)
+			continue
+		}
+		b, err := marshalNoEscape(value)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = b
+	}
+	now, err := marshalNoEscape(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	obj["updated_at"] = now
+
+	return marshalIssue(obj)
+}
+
+// applyDependencies replaces the dependencies of a raw JSON issue object
+func applyDependencies(raw []byte, deps []*model.Dependency) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	if len(deps) == 0 {
+		delete(obj, "dependencies")
+	} else {
+		b, err := marshalNoEscape(deps)
+		if err != nil {
+			return nil, err
+		}
+		obj["dependencies"] = b
+	}
+	now, err := marshalNoEscape(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	obj["updated_at"] = now
+
+	return marshalIssue(obj)
+}
+
+// marshalIssue encodes a raw issue object with its keys in canonical order
+// (see formatIssueLine), so that rewriting a line changes only the values
+// that were edited
+func marshalIssue(obj map[string]json.RawMessage) ([]byte, error) {
+	b, err := marshalNoEscape(obj)
+	if err != nil {
+		return nil, err
+	}
+	return formatIssueLine(b)
+}
+
+// marshalNoEscape encodes v as compact JSON without HTML escaping so that
+// markdown like <br> or && stays readable in the file
+func marshalNoEscape(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// writeFileAtomic replaces path with data via a temp file in the same directory
+func writeFileAtomic(path string, data []byte) error {
+	tmpName, err := stageFile(path, data)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+// writeFilesAtomic replaces each file with its new contents. Every temp file
+// is written before any is renamed into place, and files already replaced
+// are put back when a later rename fails, so the files change together.
+func writeFilesAtomic(files []renamedFile) error {
+	tmpNames := make([]string, 0, len(files))
+	for _, f := range files {
+		tmpName, err := stageFile(f.path, f.out)
+		if err != nil {
+			for _, t := range tmpNames {
+				_ = os.Remove(t)
+			}
+			return err
+		}
+		tmpNames = append(tmpNames, tmpName)
+	}
+	for i, f := range files {
+		if err := os.Rename(tmpNames[i], f.path); err != nil {
+			for _, t := range tmpNames[i:] {
+				_ = os.Remove(t)
+			}
+			for _, done := range files[:i] {
+				_ = writeFileAtomic(done.path, done.data)
+			}
+			return fmt.Errorf("failed to rename temp file: %w", err)
+		}
+	}
+	return nil
+}
+
+// stageFile writes data to a new temp file next to path, with path's
+// permissions, and returns its name
+func stageFile(path string, data []byte) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat beads file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+	_ = os.Chmod(tmpName, info.Mode().Perm())
+	return tmpName, nil
+}
@@ -0,0 +1,306 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestUpdateIssueInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bv-1","title":"Login","description":"old","status":"open","priority":2,"issue_type":"task","assignee":"bob","custom_field":{"x":1}}
+{"id":"bv-2","title":"Other","status":"closed","priority":3,"issue_type":"bug","closed_at":"2025-01-01T00:00:00Z"}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issue := model.Issue{
+		ID:          "bv-1",
+		Title:       "Login <fast>",
+		Description: "new && better",
+		Status:      model.StatusClosed,
+		Priority:    1,
+		IssueType:   model.TypeBug,
+		Labels:      []string{"auth"},
+	}
+	if err := UpdateIssueInFile(path, issue); err != nil {
+		t.Fatalf("UpdateIssueInFile: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) != 3 || lines[2] != "" {
+		t.Fatalf("expected two lines and a trailing newline, got %q", data)
+	}
+	if lines[1] != strings.Split(content, "\n")[1] {
+		t.Errorf("other issues must be preserved verbatim, got %s", lines[1])
+	}
+	for _, want := range []string{`"title":"Login <fast>"`, `"description":"new && better"`, `"status":"closed"`, `"priority":1`, `"issue_type":"bug"`, `"labels":["auth"]`, `"custom_field":{"x":1}`, `"closed_at":`, `"updated_at":`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("updated line missing %s: %s", want, lines[0])
+		}
+	}
+	if strings.Contains(lines[0], "assignee") {
+		t.Errorf("cleared assignee should be removed: %s", lines[0])
+	}
+
+	loaded, err := LoadIssuesFromFile(path)
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("reload failed: %v (%d issues)", err, len(loaded))
+	}
+	if loaded[0].Title != issue.Title || loaded[0].ClosedAt == nil {
+		t.Errorf("reloaded issue not updated: %+v", loaded[0])
+	}
+
+	// Reopening clears closed_at
+	reopen := model.Issue{ID: "bv-2", Title: "Other", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeBug}
+	if err := UpdateIssueInFile(path, reopen); err != nil {
+		t.Fatalf("UpdateIssueInFile reopen: %v", err)
+	}
+	loaded, _ = LoadIssuesFromFile(path)
+	if loaded[1].Status != model.StatusOpen || loaded[1].ClosedAt != nil {
+		t.Errorf("reopened issue should have no closed_at: %+v", loaded[1])
+	}
+}
+
+func TestUpdateIssueInFile_Estimate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bv-1","title":"Login","status":"open","priority":2,"issue_type":"task","estimated_minutes":30}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := issues[0]
+	estimate := 90
+	issue.EstimatedMinutes = &estimate
+	if err := UpdateIssueInFile(path, issue); err != nil {
+		t.Fatalf("UpdateIssueInFile: %v", err)
+	}
+	issues, err = LoadIssuesFromFile(path)
+	if err != nil || issues[0].EstimatedMinutes == nil || *issues[0].EstimatedMinutes != 90 {
+		t.Fatalf("estimate not written: %+v (err %v)", issues[0].EstimatedMinutes, err)
+	}
+
+	// Clearing the estimate removes the field
+	issue.EstimatedMinutes = nil
+	if err := UpdateIssueInFile(path, issue); err != nil {
+		t.Fatalf("UpdateIssueInFile clear: %v", err)
+	}
+	issues, _ = LoadIssuesFromFile(path)
+	if issues[0].EstimatedMinutes != nil {
+		t.Errorf("cleared estimate should be removed, got %d", *issues[0].EstimatedMinutes)
+	}
+}
+
+func TestUpdateIssueInFile_CanonicalOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bv-1","title":"Login","status":"open","priority":2,"issue_type":"task","zz_custom":1}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issue := model.Issue{ID: "bv-1", Title: "Login", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask}
+	deps := []*model.Dependency{{IssueID: "bv-1", DependsOnID: "bv-2", Type: model.DepBlocks}}
+	writes := map[string]func() error{
+		"edit":         func() error { return UpdateIssueInFile(path, issue) },
+		"fields":       func() error { return UpdateIssueFieldsInFile(path, "bv-1", map[string]any{"assignee": "ann"}) },
+		"dependencies": func() error { return UpdateDependenciesInFile(path, "bv-1", deps) },
+	}
+	for name, write := range writes {
+		if err := write(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		line := strings.TrimSuffix(string(data), "\n")
+		canonical, err := formatIssueLine([]byte(line))
+		if err != nil || string(canonical) != line {
+			t.Errorf("%s: line not in canonical key order:\n%s", name, line)
+		}
+		if !strings.HasPrefix(line, `{"id":"bv-1","title":"Login",`) || !strings.HasSuffix(line, `"zz_custom":1}`) {
+			t.Errorf("%s: keys reordered: %s", name, line)
+		}
+	}
+}
+
+func TestUpdateIssueInFile_NotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, []byte(`{"id":"bv-10","title":"Similar"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := UpdateIssueInFile(path, model.Issue{ID: "bv-1", Title: "x", Status: model.StatusOpen, IssueType: model.TypeTask})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestUpdateIssueFieldsInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bv-1","title":"Edited on disk","description":"kept","status":"in_progress","priority":2,"issue_type":"task","assignee":"bob"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateIssueFieldsInFile(path, "bv-1", map[string]any{"priority": 0, "assignee": nil}); err != nil {
+		t.Fatalf("UpdateIssueFieldsInFile: %v", err)
+	}
+	loaded, err := LoadIssuesFromFile(path)
+	if err != nil || len(loaded) != 1 {
+		t.Fatalf("reload failed: %v (%d issues)", err, len(loaded))
+	}
+	got := loaded[0]
+	if got.Priority != 0 || got.Assignee != "" {
+		t.Errorf("fields not written: %+v", got)
+	}
+	if got.Title != "Edited on disk" || got.Description != "kept" || got.Status != model.StatusInProgress {
+		t.Errorf("other fields must be left as they are on disk: %+v", got)
+	}
+	if got.UpdatedAt.IsZero() {
+		t.Error("updated_at should be bumped")
+	}
+}
+
+func TestWriteFilesAtomic_StagesFirst(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second file cannot be staged, so the first must not be replaced
+	err := writeFilesAtomic([]renamedFile{
+		{path: path, data: []byte("old\n"), out: []byte("new\n")},
+		{path: filepath.Join(dir, "missing", "issues.jsonl"), out: []byte("new\n")},
+	})
+	if err == nil {
+		t.Fatal("expected an error staging a file in a missing directory")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old\n" {
+		t.Errorf("first file replaced before every file was staged: %q", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("temp files left behind: %v", entries)
+	}
+}
+
+func TestUpdateDependenciesInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := "{\"id\":\"bv-1\",\"title\":\"One\",\"status\":\"open\",\"priority\":2,\"issue_type\":\"task\",\"dependencies\":[{\"issue_id\":\"bv-1\",\"depends_on_id\":\"bv-2\",\"type\":\"blocks\"}],\"custom_field\":true}\r\n" +
+		"{\"id\":\"bv-2\",\"title\":\"Two\",\"status\":\"open\",\"priority\":2,\"issue_type\":\"task\"}\r\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deps := []*model.Dependency{{IssueID: "bv-1", DependsOnID: "bv-2", Type: model.DepParentChild}}
+	if err := UpdateDependenciesInFile(path, "bv-1", deps); err != nil {
+		t.Fatalf("UpdateDependenciesInFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if !strings.HasSuffix(lines[0], "\r") || lines[1] != strings.Split(content, "\n")[1] {
+		t.Fatalf("line endings or other lines not preserved: %q", data)
+	}
+	for _, want := range []string{`"type":"parent-child"`, `"custom_field":true`, `"updated_at":`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("updated line missing %s: %s", want, lines[0])
+		}
+	}
+
+	// Removing every dependency drops the field
+	if err := UpdateDependenciesInFile(path, "bv-1", nil); err != nil {
+		t.Fatalf("UpdateDependenciesInFile clear: %v", err)
+	}
+	loaded, err := LoadIssuesFromFile(path)
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("reload failed: %v (%d issues)", err, len(loaded))
+	}
+	if len(loaded[0].Dependencies) != 0 {
+		t.Errorf("expected no dependencies, got %+v", loaded[0].Dependencies)
+	}
+}
+
+func TestAppendIssuesToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := "{\"id\":\"bv-1\",\"title\":\"First\",\"status\":\"open\",\"priority\":2,\"issue_type\":\"task\"}\r\n" +
+		"{\"id\":\"bv-2\",\"title\":\"Second\",\"status\":\"open\",\"priority\":2,\"issue_type\":\"task\"}"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issue := model.Issue{ID: "bv-3", Title: "Third <new>", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeChore}
+	if err := AppendIssuesToFile(path, []model.Issue{issue}); err != nil {
+		t.Fatalf("AppendIssuesToFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), content+"\r\n") || !strings.HasSuffix(string(data), "\r\n") {
+		t.Fatalf("existing lines and CRLF endings should be kept, got %q", data)
+	}
+
+	issues, err := LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 || issues[2].Title != "Third <new>" || issues[2].IssueType != model.TypeChore {
+		t.Errorf("appended issue not loaded back: %+v", issues)
+	}
+
+	if err := AppendIssuesToFile(path, []model.Issue{{ID: "bv-2", Title: "Dup"}}); err == nil {
+		t.Error("expected an error for an existing ID")
+	}
+}
+
+func TestNewIssueID(t *testing.T) {
+	if id := NewIssueID(nil, "proj"); !strings.HasPrefix(id, "proj-") || len(id) != len("proj-")+4 {
+		t.Errorf("unexpected fallback ID %q", id)
+	}
+	issues := []model.Issue{{ID: "bv-1"}, {ID: "bv-2"}, {ID: "other-1"}}
+	if id := NewIssueID(issues, "proj"); !strings.HasPrefix(id, "bv-") {
+		t.Errorf("expected the dominant prefix, got %q", id)
+	}
+}
+
+func TestNewIssueIDs(t *testing.T) {
+	if p := IssuePrefix([]model.Issue{{ID: "bv-1"}, {ID: "web-app-2"}, {ID: "web-app-3"}}, "x"); p != "web-app" {
+		t.Errorf("IssuePrefix = %q, want web-app", p)
+	}
+	if p := IssuePrefix(nil, ""); p != "bd" {
+		t.Errorf("IssuePrefix with nothing to go on = %q, want bd", p)
+	}
+
+	// A batch is distinct and avoids both existing and reserved IDs
+	issues := []model.Issue{{ID: "bv-0000"}}
+	reserved := []string{"bv-0001"}
+	ids := NewIssueIDs(issues, "bv", 50, reserved)
+	if len(ids) != 50 {
+		t.Fatalf("got %d IDs, want 50", len(ids))
+	}
+	seen := map[string]bool{"bv-0000": true, "bv-0001": true}
+	for _, id := range ids {
+		if seen[id] {
+			t.Errorf("ID %q repeats or collides", id)
+		}
+		seen[id] = true
+		if !strings.HasPrefix(id, "bv-") || len(id) != len("bv-")+4 {
+			t.Errorf("unexpected ID %q", id)
+		}
+	}
+}
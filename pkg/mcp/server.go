@@ -0,0 +1,217 @@
+// Package mcp implements a Model Context Protocol server for bv.
+// It speaks newline-delimited JSON-RPC 2.0 over stdio and exposes the
+// same analysis as the --robot-* flags as MCP tools, so agents can call
+// bv directly instead of parsing command output.
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// supportedProtocolVersions lists MCP revisions this server understands,
+// newest first. The client's version is echoed back when supported.
+var supportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// maxLineBytes caps a single JSON-RPC message.
+const maxLineBytes = 16 * 1024 * 1024
+
+// request is an incoming JSON-RPC request or notification (no ID).
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outgoing JSON-RPC response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server answers MCP requests about the issues returned by its loader.
+type Server struct {
+	name    string
+	version string
+	load    func() ([]model.Issue, error)
+	tools   []tool
+
+	mu sync.Mutex // serialises writes
+}
+
+// NewServer creates a server. load is called on every tool call so agents
+// always see the current state of the beads file.
+func NewServer(version string, load func() ([]model.Issue, error)) *Server {
+	return &Server{name: "bv", version: version, load: load, tools: builtinTools()}
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted. Each message is one line of JSON.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handleMessage(line); resp != nil {
+			if err := s.write(w, resp); err != nil {
+				return fmt.Errorf("write response: %w", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read request: %w", err)
+	}
+	return nil
+}
+
+// write encodes one response as a single line.
+func (s *Server) write(w io.Writer, resp *response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// handleMessage dispatches one raw message. Notifications yield nil.
+func (s *Server) handleMessage(line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error: "+err.Error())
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		id := req.ID
+		if id == nil {
+			id = json.RawMessage("null")
+		}
+		return errorResponse(id, codeInvalidRequest, "invalid JSON-RPC 2.0 request")
+	}
+	isNotification := req.ID == nil
+
+	result, rpcErr := s.dispatch(req)
+	if isNotification {
+		return nil
+	}
+	if rpcErr != nil {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatch runs a method and returns its result or a protocol error.
+func (s *Server) dispatch(req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := supportedProtocolVersions[0]
+		for _, v := range supportedProtocolVersions {
+			if v == params.ProtocolVersion {
+				version = v
+			}
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+			"instructions":    "bv analyses a beads issue tracker. Start with triage for what to work on next, plan for parallel tracks, and simulate_close to see what finishing an issue unblocks.",
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		list := make([]map[string]any, len(s.tools))
+		for i, t := range s.tools {
+			list[i] = map[string]any{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.InputSchema,
+			}
+		}
+		return map[string]any{"tools": list}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+		}
+		for _, t := range s.tools {
+			if t.Name == params.Name {
+				return s.callTool(t, params.Arguments), nil
+			}
+		}
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + params.Name}
+	default:
+		if strings.HasPrefix(req.Method, "notifications/") {
+			return nil, nil
+		}
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+// callTool runs a tool and wraps its output as MCP text content. Tool
+// failures are reported in the result (isError) rather than as protocol
+// errors, so the agent can read and react to them.
+func (s *Server) callTool(t tool, rawArgs json.RawMessage) map[string]any {
+	if len(rawArgs) == 0 || string(rawArgs) == "null" {
+		rawArgs = json.RawMessage("{}")
+	}
+	issues, err := s.load()
+	if err != nil {
+		return toolError(fmt.Errorf("load issues: %w", err))
+	}
+	out, err := t.Run(issues, rawArgs)
+	if err != nil {
+		return toolError(err)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return toolError(fmt.Errorf("encode result: %w", err))
+	}
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": string(data)}},
+	}
+}
+
+func toolError(err error) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": err.Error()}},
+		"isError": true,
+	}
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}}
+}
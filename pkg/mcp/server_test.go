@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func testIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "m-1", Title: "Root", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Labels: []string{"api"}},
+		{ID: "m-2", Title: "Child", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug,
+			Dependencies: []*model.Dependency{{IssueID: "m-2", DependsOnID: "m-1", Type: model.DepBlocks}}},
+		{ID: "m-3", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask},
+	}
+}
+
+// roundTrip sends each request line and returns the decoded responses.
+func roundTrip(t *testing.T, s *Server, lines ...string) []response {
+	t.Helper()
+	var out strings.Builder
+	if err := s.Serve(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var resps []response
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var r response
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("bad response line %q: %v", scanner.Text(), err)
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+// toolText calls a tool and returns its text content and isError flag.
+func toolText(t *testing.T, s *Server, name, args string) (string, bool) {
+	t.Helper()
+	resps := roundTrip(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"`+name+`","arguments":`+args+`}}`)
+	if len(resps) != 1 || resps[0].Error != nil {
+		t.Fatalf("tools/call %s: unexpected responses %+v", name, resps)
+	}
+	result := resps[0].Result.(map[string]any)
+	content := result["content"].([]any)[0].(map[string]any)
+	isError, _ := result["isError"].(bool)
+	return content["text"].(string), isError
+}
+
+func newTestServer() *Server {
+	return NewServer("test", func() ([]model.Issue, error) { return testIssues(), nil })
+}
+
+func TestServe_Handshake(t *testing.T) {
+	resps := roundTrip(t, newTestServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"nope"}`,
+		`not json`,
+	)
+	if len(resps) != 4 {
+		t.Fatalf("expected 4 responses (notification gets none), got %d", len(resps))
+	}
+
+	init := resps[0].Result.(map[string]any)
+	if init["protocolVersion"] != "2024-11-05" {
+		t.Errorf("expected the client's protocol version echoed, got %v", init["protocolVersion"])
+	}
+
+	var names []string
+	for _, tl := range resps[1].Result.(map[string]any)["tools"].([]any) {
+		names = append(names, tl.(map[string]any)["name"].(string))
+	}
+	if got := strings.Join(names, ","); got != "list_issues,get_issue,triage,plan,simulate_close" {
+		t.Errorf("unexpected tools %s", got)
+	}
+
+	if resps[2].Error == nil || resps[2].Error.Code != codeMethodNotFound {
+		t.Errorf("expected method not found, got %+v", resps[2])
+	}
+	if resps[3].Error == nil || resps[3].Error.Code != codeParseError {
+		t.Errorf("expected parse error, got %+v", resps[3])
+	}
+}
+
+func TestTools_ListAndGet(t *testing.T) {
+	s := newTestServer()
+
+	text, isErr := toolText(t, s, "list_issues", `{"filter":"open","limit":1}`)
+	if isErr {
+		t.Fatalf("list_issues failed: %s", text)
+	}
+	var list struct {
+		Total     int                   `json:"total"`
+		Truncated bool                  `json:"truncated"`
+		Issues    []export.IssueSummary `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(text), &list); err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 2 || !list.Truncated || len(list.Issues) != 1 || list.Issues[0].ID != "m-2" || list.Issues[0].Ready {
+		t.Errorf("unexpected list %+v", list)
+	}
+
+	if text, isErr := toolText(t, s, "list_issues", `{"filter":"bogus:x"}`); !isErr {
+		t.Errorf("bad filter should be a tool error, got %s", text)
+	}
+
+	text, isErr = toolText(t, s, "get_issue", `{"id":"m-1"}`)
+	if isErr || !strings.Contains(text, `"blocks": [`) || !strings.Contains(text, `"m-2"`) {
+		t.Errorf("get_issue should list dependents, got %s", text)
+	}
+	if _, isErr := toolText(t, s, "get_issue", `{"id":"zzz"}`); !isErr {
+		t.Error("unknown issue should be a tool error")
+	}
+}
+
+func TestTools_AnalysisTools(t *testing.T) {
+	s := newTestServer()
+
+	text, isErr := toolText(t, s, "simulate_close", `{"id":"m-1"}`)
+	if isErr || !strings.Contains(text, `"direct_unblocks": 1`) {
+		t.Errorf("simulate_close: %s", text)
+	}
+	if text, isErr := toolText(t, s, "triage", `{}`); isErr || !strings.Contains(text, `"recommendations"`) {
+		t.Errorf("triage: %s", text)
+	}
+	if text, isErr := toolText(t, s, "plan", `null`); isErr || !strings.Contains(text, `"tracks"`) {
+		t.Errorf("plan: %s", text)
+	}
+}
+
+func TestTools_LoadError(t *testing.T) {
+	s := NewServer("test", func() ([]model.Issue, error) { return nil, errors.New("no beads") })
+	text, isErr := toolText(t, s, "triage", `{}`)
+	if !isErr || !strings.Contains(text, "no beads") {
+		t.Errorf("expected load error surfaced as tool error, got %q", text)
+	}
+}
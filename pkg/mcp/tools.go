@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// defaultListLimit caps list_issues results unless the caller asks for more.
+const defaultListLimit = 50
+
+// tool is one MCP tool: its advertised schema and implementation.
+type tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any
+	Run         func(issues []model.Issue, args json.RawMessage) (any, error)
+}
+
+// schema builds a JSON Schema object from property definitions.
+func schema(properties map[string]any, required ...string) map[string]any {
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// builtinTools returns the tools exposed by the server.
+func builtinTools() []tool {
+	return []tool{
+		{
+			Name:        "list_issues",
+			Description: "List issues, optionally narrowed by a filter expression such as 'open label:api -type:epic', 'ready', 'priority:0,1' or free text matched against IDs and titles.",
+			InputSchema: schema(map[string]any{
+				"filter": map[string]any{"type": "string", "description": "Filter expression (see bv --export-filter)"},
+				"limit":  map[string]any{"type": "integer", "description": fmt.Sprintf("Maximum issues to return (default %d, 0 = no limit)", defaultListLimit)},
+			}),
+			Run: listIssues,
+		},
+		{
+			Name:        "get_issue",
+			Description: "Get one issue with its full description, dependencies, and the IDs of issues that depend on it.",
+			InputSchema: schema(map[string]any{
+				"id": map[string]any{"type": "string", "description": "Issue ID"},
+			}, "id"),
+			Run: getIssue,
+		},
+		{
+			Name:        "triage",
+			Description: "Ranked recommendations of what to work on next, quick wins, blockers to clear and project health (same as bv --robot-triage).",
+			InputSchema: schema(map[string]any{}),
+			Run: func(issues []model.Issue, _ json.RawMessage) (any, error) {
+				return analysis.ComputeTriage(issues), nil
+			},
+		},
+		{
+			Name:        "plan",
+			Description: "Dependency-respecting execution plan grouped into parallel tracks (same as bv --robot-plan).",
+			InputSchema: schema(map[string]any{
+				"agents": map[string]any{"type": "integer", "description": "Balance tracks across this many agents by estimated minutes (0 = one track per work stream)"},
+			}),
+			Run: plan,
+		},
+		{
+			Name:        "simulate_close",
+			Description: "What closing an issue would unblock: direct and transitive unblocks, blocked reduction and estimated days saved.",
+			InputSchema: schema(map[string]any{
+				"id": map[string]any{"type": "string", "description": "Issue ID to simulate closing"},
+			}, "id"),
+			Run: simulateClose,
+		},
+	}
+}
+
+// decodeArgs unmarshals tool arguments, reporting bad input as a tool error.
+func decodeArgs(raw json.RawMessage, v any) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// findIssue returns the issue with the given ID.
+func findIssue(issues []model.Issue, id string) (*model.Issue, error) {
+	if id == "" {
+		return nil, fmt.Errorf("missing required argument: id")
+	}
+	for i := range issues {
+		if issues[i].ID == id {
+			return &issues[i], nil
+		}
+	}
+	return nil, fmt.Errorf("issue not found: %s", id)
+}
+
+func listIssues(issues []model.Issue, raw json.RawMessage) (any, error) {
+	args := struct {
+		Filter string `json:"filter"`
+		Limit  *int   `json:"limit"`
+	}{}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	filter, err := export.ParseFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	limit := defaultListLimit
+	if args.Limit != nil {
+		limit = *args.Limit
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	matched := make([]export.IssueSummary, 0)
+	for _, issue := range issues {
+		if !filter.Match(issue, issueMap) {
+			continue
+		}
+		matched = append(matched, export.SummarizeIssue(issue, issueMap))
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Priority != matched[j].Priority {
+			return matched[i].Priority < matched[j].Priority
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	total := len(matched)
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return struct {
+		Total     int                   `json:"total"`
+		Returned  int                   `json:"returned"`
+		Truncated bool                  `json:"truncated"`
+		Issues    []export.IssueSummary `json:"issues"`
+	}{total, len(matched), len(matched) < total, matched}, nil
+}
+
+func getIssue(issues []model.Issue, raw json.RawMessage) (any, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	issue, err := findIssue(issues, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []string
+	for _, other := range issues {
+		for _, dep := range other.Dependencies {
+			if dep != nil && dep.DependsOnID == issue.ID && dep.Type.IsBlocking() {
+				blocks = append(blocks, other.ID)
+				break
+			}
+		}
+	}
+	sort.Strings(blocks)
+
+	return struct {
+		*model.Issue
+		Blocks []string `json:"blocks,omitempty"`
+	}{issue, blocks}, nil
+}
+
+func plan(issues []model.Issue, raw json.RawMessage) (any, error) {
+	var args struct {
+		Agents int `json:"agents"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if args.Agents < 0 {
+		return nil, fmt.Errorf("agents must be >= 0")
+	}
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	return struct {
+		GeneratedAt string                 `json:"generated_at"`
+		DataHash    string                 `json:"data_hash"`
+		Plan        analysis.ExecutionPlan `json:"plan"`
+	}{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		DataHash:    analysis.ComputeDataHash(issues),
+		Plan:        analyzer.GetExecutionPlanWithOptions(analysis.PlanOptions{Agents: args.Agents, Stats: &stats}),
+	}, nil
+}
+
+func simulateClose(issues []model.Issue, raw json.RawMessage) (any, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	issue, err := findIssue(issues, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	delta := analysis.NewAnalyzer(issues).WhatIf(issue.ID)
+	if delta == nil {
+		return nil, fmt.Errorf("issue not found: %s", issue.ID)
+	}
+	return analysis.WhatIfEntry{IssueID: issue.ID, Title: issue.Title, Delta: *delta}, nil
+}
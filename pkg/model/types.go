@@ -1,7 +1,12 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -32,6 +37,15 @@ type Issue struct {
 	Dependencies       []*Dependency `json:"dependencies,omitempty"`
 	Comments           []*Comment    `json:"comments,omitempty"`
 	SourceRepo         string        `json:"source_repo,omitempty"`
+
+	// CustomFields holds team-specific fields bv does not interpret. They
+	// are shown in the detail view, filterable as custom.<name>:value and
+	// included in exports.
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+
+	// Attachments links the issue to files and web pages (designs, logs,
+	// tickets elsewhere). Invalid URLs are dropped on load.
+	Attachments []*Attachment `json:"attachments,omitempty"`
 }
 
 // Clone creates a deep copy of the issue
@@ -88,9 +102,66 @@ func (i Issue) Clone() Issue {
 		}
 	}
 
+	if i.Attachments != nil {
+		clone.Attachments = make([]*Attachment, len(i.Attachments))
+		for idx, a := range i.Attachments {
+			if a != nil {
+				v := *a
+				clone.Attachments[idx] = &v
+			}
+		}
+	}
+
+	if i.CustomFields != nil {
+		clone.CustomFields = make(map[string]any, len(i.CustomFields))
+		for k, v := range i.CustomFields {
+			clone.CustomFields[k] = v
+		}
+	}
+
 	return clone
 }
 
+// CustomFieldNames returns the names of the issue's custom fields, sorted
+func (i Issue) CustomFieldNames() []string {
+	names := make([]string, 0, len(i.CustomFields))
+	for name := range i.CustomFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatCustomField renders a custom field value as plain text: strings as
+// is, whole numbers without a decimal point, lists joined by ", " and
+// objects as JSON.
+func FormatCustomField(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	case []any:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = FormatCustomField(item)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
 // Validate checks if the issue data is logically valid
 func (i *Issue) Validate() error {
 	if i.ID == "" {
@@ -218,6 +289,63 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Attachment is a file or web page linked to an issue
+type Attachment struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// Label returns the attachment title, or its URL when it has none
+func (a Attachment) Label() string {
+	if a.Title != "" {
+		return a.Title
+	}
+	return a.URL
+}
+
+// IsFile reports whether the attachment is a local file rather than a link
+func (a Attachment) IsFile() bool {
+	u, err := url.Parse(a.URL)
+	return err == nil && (u.Scheme == "" || u.Scheme == "file" || isDriveLetter(u.Scheme))
+}
+
+// Validate checks that the URL is an http(s), mailto or file link, or a
+// file path (relative paths are resolved against the project root)
+func (a Attachment) Validate() error {
+	if strings.TrimSpace(a.URL) == "" {
+		return fmt.Errorf("attachment URL cannot be empty")
+	}
+	u, err := url.Parse(a.URL)
+	if err != nil {
+		return fmt.Errorf("invalid attachment URL %q: %w", a.URL, err)
+	}
+	switch {
+	case u.Scheme == "" || isDriveLetter(u.Scheme):
+		return nil
+	case u.Scheme == "http" || u.Scheme == "https":
+		if u.Host == "" {
+			return fmt.Errorf("attachment URL %q has no host", a.URL)
+		}
+	case u.Scheme == "mailto":
+		if u.Opaque == "" {
+			return fmt.Errorf("attachment URL %q has no address", a.URL)
+		}
+	case u.Scheme == "file":
+		if u.Path == "" {
+			return fmt.Errorf("attachment URL %q has no path", a.URL)
+		}
+	default:
+		return fmt.Errorf("unsupported attachment URL scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// isDriveLetter reports whether a parsed URL scheme is really a Windows
+// drive letter (C:\path)
+func isDriveLetter(scheme string) bool {
+	return len(scheme) == 1
+}
+
 // Sprint represents a time-boxed period of work
 type Sprint struct {
 	ID             string    `json:"id"`
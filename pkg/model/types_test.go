@@ -669,3 +669,68 @@ func TestIssue_Clone_NilFields(t *testing.T) {
 		t.Errorf("Comments should be nil")
 	}
 }
+
+func TestIssue_CustomFields(t *testing.T) {
+	var issue Issue
+	data := `{"id":"C-1","title":"Custom","status":"open","issue_type":"task",` +
+		`"custom_fields":{"team":"core","points":3,"tags":["a","b"],"meta":{"k":1},"urgent":true}}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if got := strings.Join(issue.CustomFieldNames(), ","); got != "meta,points,tags,team,urgent" {
+		t.Errorf("CustomFieldNames() = %s", got)
+	}
+
+	tests := map[string]string{
+		"team":   "core",
+		"points": "3",
+		"tags":   "a, b",
+		"meta":   `{"k":1}`,
+		"urgent": "true",
+	}
+	for name, want := range tests {
+		if got := FormatCustomField(issue.CustomFields[name]); got != want {
+			t.Errorf("FormatCustomField(%s) = %q, want %q", name, got, want)
+		}
+	}
+
+	clone := issue.Clone()
+	clone.CustomFields["team"] = "other"
+	if issue.CustomFields["team"] != "core" {
+		t.Errorf("Modifying clone affected original CustomFields")
+	}
+}
+
+func TestAttachment_Validate(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+		file  bool
+	}{
+		{"https://example.com/spec", true, false},
+		{"http://localhost:8080/logs", true, false},
+		{"mailto:team@example.com", true, false},
+		{"file:///tmp/trace.log", true, true},
+		{"docs/design.png", true, true},
+		{"/var/log/app.log", true, true},
+		{`C:\docs\plan.pdf`, true, true},
+		{"", false, false},
+		{"https://", false, false},
+		{"javascript:alert(1)", false, false},
+		{"mailto:", false, false},
+	}
+	for _, tt := range tests {
+		a := Attachment{URL: tt.url}
+		if err := a.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) error = %v, want valid=%v", tt.url, err, tt.valid)
+		}
+		if tt.valid && a.IsFile() != tt.file {
+			t.Errorf("IsFile(%q) = %v, want %v", tt.url, a.IsFile(), tt.file)
+		}
+	}
+
+	if label := (Attachment{URL: "https://x.test", Title: "Spec"}).Label(); label != "Spec" {
+		t.Errorf("Label() = %q, want Spec", label)
+	}
+}
@@ -0,0 +1,190 @@
+// Package notify posts a digest of the backlog to chat webhooks or email,
+// as configured in .bv/notify.yaml.
+package notify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilename is the notify config filename inside .bv/
+const ConfigFilename = "notify.yaml"
+
+// Digest sections
+const (
+	SectionTriage       = "triage"
+	SectionNewlyBlocked = "newly_blocked"
+	SectionOverdue      = "overdue"
+)
+
+// Target types
+const (
+	TargetSlack   = "slack"
+	TargetDiscord = "discord"
+	TargetWebhook = "webhook" // Posts the digest as JSON
+	TargetSMTP    = "smtp"
+)
+
+// Config is the contents of .bv/notify.yaml.
+type Config struct {
+	// Title heads the digest. Default: "Backlog digest"
+	Title string `yaml:"title"`
+
+	// TopN is how many triage recommendations to include. Default: 5
+	TopN int `yaml:"top_n"`
+
+	// Sections to include, in order. Default: triage, newly_blocked, overdue
+	Sections []string `yaml:"sections"`
+
+	// Since is how far back "newly blocked" looks, as a duration. Default: 24h
+	Since string `yaml:"since"`
+
+	// SkipEmpty sends nothing when every section is empty
+	SkipEmpty bool `yaml:"skip_empty"`
+
+	// Targets receive the digest; each is sent independently
+	Targets []Target `yaml:"targets"`
+}
+
+// Target is one destination for the digest.
+type Target struct {
+	Type string `yaml:"type"` // slack, discord, webhook or smtp
+
+	// Webhook URL (slack, discord, webhook). URLEnv names an environment
+	// variable holding it instead, to keep secrets out of the repo.
+	URL    string `yaml:"url,omitempty"`
+	URLEnv string `yaml:"url_env,omitempty"`
+
+	// SMTP settings. The password is only read from PasswordEnv.
+	Host        string   `yaml:"host,omitempty"`
+	Port        int      `yaml:"port,omitempty"` // Default: 587
+	Username    string   `yaml:"username,omitempty"`
+	PasswordEnv string   `yaml:"password_env,omitempty"`
+	From        string   `yaml:"from,omitempty"`
+	To          []string `yaml:"to,omitempty"`
+}
+
+// DefaultConfig returns the defaults, with no targets.
+func DefaultConfig() *Config {
+	return &Config{
+		Title:    "Backlog digest",
+		TopN:     5,
+		Sections: []string{SectionTriage, SectionNewlyBlocked, SectionOverdue},
+		Since:    "24h",
+	}
+}
+
+// ConfigPath returns the notify config path for a project
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// LoadConfig loads and validates .bv/notify.yaml. Unlike other .bv configs
+// a missing file is an error: there is nowhere to send the digest.
+func LoadConfig(projectDir string) (*Config, error) {
+	path := ConfigPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no notify config: create %s with at least one target", path)
+		}
+		return nil, fmt.Errorf("reading notify config: %w", err)
+	}
+
+	config := DefaultConfig()
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing notify config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notify config: %w", err)
+	}
+	return config, nil
+}
+
+// Validate checks the sections, the lookback and every target.
+func (c *Config) Validate() error {
+	if c.TopN < 0 {
+		return fmt.Errorf("top_n must be >= 0")
+	}
+	for _, s := range c.Sections {
+		switch s {
+		case SectionTriage, SectionNewlyBlocked, SectionOverdue:
+		default:
+			return fmt.Errorf("unknown section %q (want %s, %s or %s)", s, SectionTriage, SectionNewlyBlocked, SectionOverdue)
+		}
+	}
+	if _, err := c.SinceDuration(); err != nil {
+		return err
+	}
+	if len(c.Targets) == 0 {
+		return fmt.Errorf("no targets")
+	}
+	for i, t := range c.Targets {
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("target %d (%s): %w", i+1, t.Type, err)
+		}
+	}
+	return nil
+}
+
+// SinceDuration parses Since.
+func (c *Config) SinceDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(c.Since)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("since must be a positive duration like 24h, got %q", c.Since)
+	}
+	return d, nil
+}
+
+func (t Target) validate() error {
+	switch t.Type {
+	case TargetSlack, TargetDiscord, TargetWebhook:
+		if t.URL == "" && t.URLEnv == "" {
+			return fmt.Errorf("url or url_env is required")
+		}
+	case TargetSMTP:
+		if t.Host == "" || t.From == "" || len(t.To) == 0 {
+			return fmt.Errorf("host, from and to are required")
+		}
+	default:
+		return fmt.Errorf("unknown type (want %s, %s, %s or %s)", TargetSlack, TargetDiscord, TargetWebhook, TargetSMTP)
+	}
+	return nil
+}
+
+// webhookURL returns the target's URL, reading URLEnv if set
+func (t Target) webhookURL() (string, error) {
+	if t.URLEnv == "" {
+		return t.URL, nil
+	}
+	url := strings.TrimSpace(os.Getenv(t.URLEnv))
+	if url == "" {
+		return "", fmt.Errorf("environment variable %s is not set", t.URLEnv)
+	}
+	return url, nil
+}
+
+// Describe names the target without revealing its secrets, for logs.
+func (t Target) Describe() string {
+	switch t.Type {
+	case TargetSMTP:
+		return fmt.Sprintf("smtp %s → %s", t.Host, strings.Join(t.To, ", "))
+	default:
+		if t.URLEnv != "" {
+			return fmt.Sprintf("%s ($%s)", t.Type, t.URLEnv)
+		}
+		host := t.URL
+		if i := strings.Index(host, "://"); i >= 0 {
+			host = host[i+3:]
+		}
+		if i := strings.Index(host, "/"); i >= 0 {
+			host = host[:i]
+		}
+		return fmt.Sprintf("%s (%s)", t.Type, host)
+	}
+}
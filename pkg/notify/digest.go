@@ -0,0 +1,149 @@
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DigestItem is one issue in a digest section.
+type DigestItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee,omitempty"`
+	Detail   string `json:"detail,omitempty"` // Why the issue is listed
+}
+
+// DigestSection is a titled list of issues.
+type DigestSection struct {
+	Name  string       `json:"name"` // triage, newly_blocked or overdue
+	Title string       `json:"title"`
+	Items []DigestItem `json:"items"`
+}
+
+// Digest is what gets posted to each target.
+type Digest struct {
+	Title       string          `json:"title"`
+	Project     string          `json:"project,omitempty"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Sections    []DigestSection `json:"sections"`
+}
+
+// BuildDigest assembles the configured sections. baseline is the issue set
+// as of the start of the lookback (nil to use timestamps); see
+// analysis.ComputeStandup.
+func BuildDigest(issues, baseline []model.Issue, baselineRevision string, cfg *Config, project string, now time.Time) Digest {
+	d := Digest{Title: cfg.Title, Project: project, GeneratedAt: now}
+	since, _ := cfg.SinceDuration()
+
+	for _, name := range cfg.Sections {
+		section := DigestSection{Name: name, Items: []DigestItem{}}
+		switch name {
+		case SectionTriage:
+			section.Title = "Top picks"
+			if cfg.TopN > 0 {
+				triage := analysis.ComputeTriageWithOptionsAndTime(issues, analysis.TriageOptions{TopN: cfg.TopN}, now)
+				for _, rec := range triage.Recommendations {
+					section.Items = append(section.Items, DigestItem{
+						ID: rec.ID, Title: rec.Title, Priority: rec.Priority,
+						Detail: fmt.Sprintf("score %.2f: %s", rec.Score, rec.Action),
+					})
+				}
+			}
+		case SectionNewlyBlocked:
+			section.Title = fmt.Sprintf("Newly blocked (last %s)", formatSince(since))
+			standup := analysis.ComputeStandup(baseline, baselineRevision, issues, now.Add(-since), now)
+			for _, item := range standup.NewlyBlocked {
+				section.Items = append(section.Items, DigestItem{
+					ID: item.ID, Title: item.Title, Priority: item.Priority, Assignee: item.Assignee, Detail: item.Reason,
+				})
+			}
+		case SectionOverdue:
+			section.Title = "Overdue"
+			section.Items = overdueItems(issues, now)
+		}
+		d.Sections = append(d.Sections, section)
+	}
+	return d
+}
+
+// overdueItems lists open issues past their due date, most overdue first
+func overdueItems(issues []model.Issue, now time.Time) []DigestItem {
+	var overdue []model.Issue
+	for _, issue := range issues {
+		if issue.DueDate != nil && issue.DueDate.Before(now) && !issue.Status.IsClosed() && !issue.Status.IsTombstone() {
+			overdue = append(overdue, issue)
+		}
+	}
+	sort.Slice(overdue, func(i, j int) bool {
+		if !overdue[i].DueDate.Equal(*overdue[j].DueDate) {
+			return overdue[i].DueDate.Before(*overdue[j].DueDate)
+		}
+		return overdue[i].ID < overdue[j].ID
+	})
+
+	items := []DigestItem{}
+	for _, issue := range overdue {
+		days := int(now.Sub(*issue.DueDate).Hours() / 24)
+		detail := fmt.Sprintf("due %s", issue.DueDate.Format("2006-01-02"))
+		if days > 0 {
+			detail += fmt.Sprintf(", %dd overdue", days)
+		}
+		items = append(items, DigestItem{ID: issue.ID, Title: issue.Title, Priority: issue.Priority, Assignee: issue.Assignee, Detail: detail})
+	}
+	return items
+}
+
+// formatSince renders a lookback like "24h" or "3d"
+func formatSince(d time.Duration) string {
+	if d >= 48*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(d.String(), "0s"), "0m")
+}
+
+// Empty reports whether every section is empty
+func (d Digest) Empty() bool {
+	for _, s := range d.Sections {
+		if len(s.Items) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Text renders the digest as plain text with bold markers; bold wraps a
+// heading or ID in the target's markup (e.g. *x* for Slack, **x** for
+// Discord).
+func (d Digest) Text(bold func(string) string) string {
+	var sb strings.Builder
+	title := d.Title
+	if d.Project != "" {
+		title += " — " + d.Project
+	}
+	sb.WriteString(bold(title))
+	sb.WriteString("\n")
+	for _, s := range d.Sections {
+		sb.WriteString(fmt.Sprintf("\n%s (%d)\n", bold(s.Title), len(s.Items)))
+		if len(s.Items) == 0 {
+			sb.WriteString("  none\n")
+			continue
+		}
+		for _, item := range s.Items {
+			sb.WriteString(fmt.Sprintf("• %s P%d %s", bold(item.ID), item.Priority, item.Title))
+			if item.Assignee != "" {
+				sb.WriteString(" (@" + item.Assignee + ")")
+			}
+			if item.Detail != "" {
+				sb.WriteString(" — " + item.Detail)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
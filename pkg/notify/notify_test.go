@@ -0,0 +1,169 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadConfig(dir); err == nil || !strings.Contains(err.Error(), "no notify config") {
+		t.Errorf("a missing config should be an error, got %v", err)
+	}
+
+	write := func(content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(ConfigPath(dir), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("top_n: 3\ntargets:\n  - type: discord\n    url_env: BV_DISCORD\n")
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.TopN != 3 || cfg.Since != "24h" || len(cfg.Sections) != 3 || cfg.Title != "Backlog digest" {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+	if got := cfg.Targets[0].Describe(); got != "discord ($BV_DISCORD)" {
+		t.Errorf("Describe = %q", got)
+	}
+
+	for content, want := range map[string]string{
+		"targets: []\n": "no targets",
+		"sections: [triage, gossip]\ntargets: []\n":              "unknown section",
+		"since: soon\ntargets:\n  - type: webhook\n    url: x\n": "since must be",
+		"targets:\n  - type: slack\n":                            "url or url_env",
+		"targets:\n  - type: smtp\n    host: h\n":                "host, from and to",
+		"targets:\n  - type: pager\n":                            "unknown type",
+	} {
+		write(content)
+		if _, err := LoadConfig(dir); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("config %q: expected %q, got %v", content, want, err)
+		}
+	}
+}
+
+func testDigest(t *testing.T) Digest {
+	t.Helper()
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	due := now.Add(-72 * time.Hour)
+	issues := []model.Issue{
+		{ID: "late", Title: "Late", Status: model.StatusOpen, Priority: 1, Assignee: "bob", DueDate: &due, CreatedAt: due},
+		{ID: "new", Title: "New", Status: model.StatusOpen, Priority: 0, CreatedAt: now.Add(-time.Hour)},
+		{ID: "waits", Title: "Waits", Status: model.StatusOpen, Priority: 2, CreatedAt: due,
+			Dependencies: []*model.Dependency{{DependsOnID: "new", Type: model.DepBlocks}}},
+	}
+	cfg := DefaultConfig()
+	cfg.TopN = 2
+	return BuildDigest(issues, nil, "", cfg, "proj", now)
+}
+
+func TestBuildDigest(t *testing.T) {
+	d := testDigest(t)
+	if len(d.Sections) != 3 || d.Empty() {
+		t.Fatalf("expected three sections, got %+v", d.Sections)
+	}
+	if got := len(d.Sections[0].Items); got != 2 {
+		t.Errorf("triage should hold top_n picks, got %d", got)
+	}
+	if items := d.Sections[1].Items; len(items) != 1 || items[0].ID != "waits" || items[0].Detail != "blocked by new" {
+		t.Errorf("unexpected newly blocked %+v", items)
+	}
+	if items := d.Sections[2].Items; len(items) != 1 || items[0].Detail != "due 2025-03-09, 3d overdue" {
+		t.Errorf("unexpected overdue %+v", items)
+	}
+	text := d.Text(func(s string) string { return "*" + s + "*" })
+	for _, want := range []string{"*Backlog digest — proj*", "*Newly blocked (last 24h)* (1)", "• *late* P1 Late (@bob)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestSendWebhooks(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		if r.URL.Path == "/fail" {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	d := testDigest(t)
+	ctx := context.Background()
+	if err := Send(ctx, Target{Type: TargetSlack, URL: server.URL + "/slack"}, d); err != nil {
+		t.Fatalf("slack: %v", err)
+	}
+	t.Setenv("BV_TEST_DISCORD", server.URL+"/discord")
+	if err := Send(ctx, Target{Type: TargetDiscord, URLEnv: "BV_TEST_DISCORD"}, d); err != nil {
+		t.Fatalf("discord: %v", err)
+	}
+	if err := Send(ctx, Target{Type: TargetWebhook, URL: server.URL + "/json"}, d); err != nil {
+		t.Fatalf("webhook: %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 posts, got %d", len(bodies))
+	}
+	if text, _ := bodies[0]["text"].(string); !strings.Contains(text, "*Overdue* (1)") {
+		t.Errorf("slack text should use *bold*: %q", text)
+	}
+	if content, _ := bodies[1]["content"].(string); !strings.Contains(content, "**Overdue** (1)") {
+		t.Errorf("discord content should use **bold**: %q", content)
+	}
+	if bodies[2]["title"] != "Backlog digest" || bodies[2]["sections"] == nil {
+		t.Errorf("webhook should post the digest as JSON: %v", bodies[2])
+	}
+
+	err := Send(ctx, Target{Type: TargetSlack, URL: server.URL + "/fail"}, d)
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("expected the HTTP error, got %v", err)
+	}
+	if err := Send(ctx, Target{Type: TargetDiscord, URLEnv: "BV_TEST_UNSET"}, d); err == nil {
+		t.Error("an unset url_env should fail")
+	}
+}
+
+func TestSendSMTP(t *testing.T) {
+	var gotAddr string
+	var gotMsg []byte
+	sendMail = func(addr string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+		gotAddr, gotMsg = addr, msg
+		return nil
+	}
+	defer func() { sendMail = smtp.SendMail }()
+
+	target := Target{Type: TargetSMTP, Host: "mail.test", From: "bv@test", To: []string{"a@test", "b@test"}}
+	if err := Send(context.Background(), target, testDigest(t)); err != nil {
+		t.Fatalf("smtp: %v", err)
+	}
+	msg := string(gotMsg)
+	if gotAddr != "mail.test:587" || !strings.Contains(msg, "To: a@test, b@test\r\n") || !strings.Contains(msg, "Subject: =?utf-8?q?") {
+		t.Errorf("unexpected message to %s:\n%s", gotAddr, msg)
+	}
+	if !strings.Contains(msg, "Overdue (1)\r\n") {
+		t.Errorf("body should be the plain digest:\n%s", msg)
+	}
+
+	target.Username, target.PasswordEnv = "bot", "BV_TEST_SMTP_UNSET"
+	if err := Send(context.Background(), target, testDigest(t)); err == nil {
+		t.Error("an unset password_env should fail")
+	}
+}
@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// discordMaxContent is Discord's message length limit
+const discordMaxContent = 2000
+
+// httpClient posts webhooks; a var so tests can swap it
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// sendMail delivers SMTP messages; a var so tests can capture them
+var sendMail = smtp.SendMail
+
+// Send delivers the digest to one target.
+func Send(ctx context.Context, target Target, digest Digest) error {
+	switch target.Type {
+	case TargetSlack:
+		text := digest.Text(func(s string) string { return "*" + s + "*" })
+		return postJSON(ctx, target, map[string]string{"text": text})
+	case TargetDiscord:
+		content := digest.Text(func(s string) string { return "**" + s + "**" })
+		if runes := []rune(content); len(runes) > discordMaxContent {
+			content = string(runes[:discordMaxContent-2]) + "\n…"
+		}
+		return postJSON(ctx, target, map[string]string{"content": content})
+	case TargetWebhook:
+		return postJSON(ctx, target, digest)
+	case TargetSMTP:
+		return sendSMTP(target, digest)
+	default:
+		return fmt.Errorf("unknown target type %q", target.Type)
+	}
+}
+
+// postJSON posts payload to the target's webhook URL
+func postJSON(ctx context.Context, target Target, payload any) error {
+	url, err := target.webhookURL()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting to %s: %w", target.Describe(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("%s returned %s: %s", target.Describe(), resp.Status, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// sendSMTP mails the digest as plain text
+func sendSMTP(target Target, digest Digest) error {
+	port := target.Port
+	if port == 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if target.Username != "" {
+		password := ""
+		if target.PasswordEnv != "" {
+			password = os.Getenv(target.PasswordEnv)
+			if password == "" {
+				return fmt.Errorf("environment variable %s is not set", target.PasswordEnv)
+			}
+		}
+		auth = smtp.PlainAuth("", target.Username, password, target.Host)
+	}
+	addr := fmt.Sprintf("%s:%d", target.Host, port)
+	if err := sendMail(addr, auth, target.From, target.To, buildMessage(target, digest)); err != nil {
+		return fmt.Errorf("mailing via %s: %w", addr, err)
+	}
+	return nil
+}
+
+// buildMessage renders the digest as an RFC 5322 plain text message
+func buildMessage(target Target, digest Digest) []byte {
+	subject := digest.Title
+	if digest.Project != "" {
+		subject += " — " + digest.Project
+	}
+	var sb strings.Builder
+	sb.WriteString("From: " + target.From + "\r\n")
+	sb.WriteString("To: " + strings.Join(target.To, ", ") + "\r\n")
+	sb.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	sb.WriteString("Date: " + digest.GeneratedAt.Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	body := digest.Text(func(s string) string { return s })
+	sb.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(sb.String())
+}
@@ -0,0 +1,141 @@
+// Package onboarding sets up a project for bv on first run.
+//
+// When the working directory has no .beads directory, or one without a
+// beads file, bv offers a guided setup instead of failing: it creates
+// .beads/beads.jsonl, optionally imports existing work from a Markdown TODO
+// list or GitHub issues, and then opens the TUI tutorial.
+package onboarding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BeadsFilename is the issues file created in a new beads directory
+const BeadsFilename = "beads.jsonl"
+
+// githubFields are the `gh issue list --json` fields ParseGitHubIssues reads
+const githubFields = "number,title,body,state,labels,createdAt,updatedAt,closedAt"
+
+// NeedsSetup reports whether beadsDir has no beads JSONL file, either
+// because it is missing or empty. A project whose beads file has no issues
+// yet is left alone.
+func NeedsSetup(beadsDir string) bool {
+	_, err := loader.FindJSONLPath(beadsDir)
+	return err != nil
+}
+
+// InitBeads creates beadsDir and an empty beads file in it, returning the
+// path of the beads file. An existing beads file is kept as is.
+func InitBeads(beadsDir string) (string, error) {
+	if path, err := loader.FindJSONLPath(beadsDir); err == nil {
+		return path, nil
+	}
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		return "", fmt.Errorf("creating beads directory: %w", err)
+	}
+	path := filepath.Join(beadsDir, BeadsFilename)
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		return "", fmt.Errorf("creating beads file: %w", err)
+	}
+	return path, nil
+}
+
+// githubIssue is one entry of `gh issue list --json` output
+type githubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	ClosedAt  *time.Time `json:"closedAt"`
+}
+
+// FetchGitHubIssues lists the issues of repo ("owner/name", or the current
+// directory's repository when empty) with the gh CLI, in the JSON format
+// ParseGitHubIssues reads. Closed issues are included when includeClosed.
+func FetchGitHubIssues(repo string, includeClosed bool, limit int) ([]byte, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, errors.New("the gh CLI is not installed (https://cli.github.com)")
+	}
+	state := "open"
+	if includeClosed {
+		state = "all"
+	}
+	args := []string{"issue", "list", "--state", state, "--limit", fmt.Sprint(limit), "--json", githubFields}
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+	cmd := exec.Command("gh", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("gh issue list: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh issue list: %w", err)
+	}
+	return out, nil
+}
+
+// ParseGitHubIssues converts `gh issue list --json` output into new issues,
+// oldest first. Each keeps its labels and links back to GitHub through an
+// external ref of the form gh-<number>. Issues labelled "bug" or
+// "enhancement" become bugs and features; the rest are tasks. IDs avoid
+// those in existing, see loader.NewIssueID.
+func ParseGitHubIssues(data []byte, existing []model.Issue, fallbackPrefix string) ([]model.Issue, error) {
+	var gh []githubIssue
+	if err := json.Unmarshal(data, &gh); err != nil {
+		return nil, fmt.Errorf("parsing GitHub issues: %w", err)
+	}
+
+	known := append([]model.Issue(nil), existing...)
+	issues := make([]model.Issue, 0, len(gh))
+	// gh lists newest first; import in creation order
+	for i := len(gh) - 1; i >= 0; i-- {
+		g := gh[i]
+		ref := fmt.Sprintf("gh-%d", g.Number)
+		issue := model.Issue{
+			ID:          loader.NewIssueID(known, fallbackPrefix),
+			Title:       strings.TrimSpace(g.Title),
+			Description: strings.TrimSpace(g.Body),
+			Status:      model.StatusOpen,
+			Priority:    2,
+			IssueType:   model.TypeTask,
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+			ExternalRef: &ref,
+		}
+		for _, label := range g.Labels {
+			issue.Labels = append(issue.Labels, label.Name)
+			switch strings.ToLower(label.Name) {
+			case "bug":
+				issue.IssueType = model.TypeBug
+			case "enhancement", "feature":
+				if issue.IssueType == model.TypeTask {
+					issue.IssueType = model.TypeFeature
+				}
+			}
+		}
+		if strings.EqualFold(g.State, "closed") {
+			issue.Status = model.StatusClosed
+			issue.ClosedAt = g.ClosedAt
+		}
+		known = append(known, issue)
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
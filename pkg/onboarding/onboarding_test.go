@@ -0,0 +1,97 @@
+package onboarding
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestNeedsSetupAndInitBeads(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if !NeedsSetup(beadsDir) {
+		t.Fatal("a missing beads directory needs setup")
+	}
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !NeedsSetup(beadsDir) {
+		t.Fatal("an empty beads directory needs setup")
+	}
+
+	path, err := InitBeads(beadsDir)
+	if err != nil {
+		t.Fatalf("InitBeads() error = %v", err)
+	}
+	if filepath.Base(path) != BeadsFilename {
+		t.Errorf("expected %s, got %s", BeadsFilename, path)
+	}
+	if NeedsSetup(beadsDir) {
+		t.Error("an initialized beads directory needs no setup")
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 0 {
+		t.Errorf("expected an empty, loadable beads file, got %d issues, %v", len(issues), err)
+	}
+
+	// An existing beads file is kept
+	existing := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte(`{"id":"bv-1","title":"Keep","status":"open","issue_type":"task"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := InitBeads(beadsDir); err != nil || got != existing {
+		t.Errorf("InitBeads() = %s, %v; want the existing %s", got, err, existing)
+	}
+}
+
+func TestParseGitHubIssues(t *testing.T) {
+	data := []byte(`[
+		{"number": 12, "title": "Crash on start", "body": "Stack trace", "state": "OPEN",
+		 "labels": [{"name": "bug"}, {"name": "ui"}],
+		 "createdAt": "2025-02-01T10:00:00Z", "updatedAt": "2025-02-02T10:00:00Z", "closedAt": null},
+		{"number": 3, "title": " Dark mode ", "body": "", "state": "CLOSED",
+		 "labels": [{"name": "enhancement"}],
+		 "createdAt": "2025-01-01T10:00:00Z", "updatedAt": "2025-01-05T10:00:00Z", "closedAt": "2025-01-05T10:00:00Z"}
+	]`)
+	existing := []model.Issue{{ID: "app-aaaa", Title: "Existing"}}
+
+	issues, err := ParseGitHubIssues(data, existing, "fallback")
+	if err != nil {
+		t.Fatalf("ParseGitHubIssues() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+
+	// Oldest first
+	first, second := issues[0], issues[1]
+	if first.Title != "Dark mode" || first.Status != model.StatusClosed || first.ClosedAt == nil || first.IssueType != model.TypeFeature {
+		t.Errorf("unexpected closed issue: %+v", first)
+	}
+	if first.ExternalRef == nil || *first.ExternalRef != "gh-3" {
+		t.Errorf("expected external ref gh-3, got %v", first.ExternalRef)
+	}
+	if second.IssueType != model.TypeBug || second.Status != model.StatusOpen || second.Description != "Stack trace" {
+		t.Errorf("unexpected open issue: %+v", second)
+	}
+	if len(second.Labels) != 2 || second.Labels[1] != "ui" {
+		t.Errorf("labels not kept: %v", second.Labels)
+	}
+	for _, issue := range issues {
+		if issue.ID == "app-aaaa" || issue.ID[:4] != "app-" {
+			t.Errorf("ID %s should use the existing prefix and not collide", issue.ID)
+		}
+		if err := issue.Validate(); err != nil {
+			t.Errorf("issue %s invalid: %v", issue.ID, err)
+		}
+	}
+
+	if _, err := ParseGitHubIssues([]byte("not json"), nil, "x"); err == nil {
+		t.Error("expected an error for malformed gh output")
+	}
+}
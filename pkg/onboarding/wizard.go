@@ -0,0 +1,213 @@
+package onboarding
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/audit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/huh"
+)
+
+// ErrDeclined is returned by Wizard.Run when the user chooses not to set up
+// the project
+var ErrDeclined = errors.New("beads setup declined")
+
+// githubImportLimit caps how many GitHub issues are imported
+const githubImportLimit = 500
+
+// Import sources offered by the wizard
+const (
+	SourceNone     = "none"
+	SourceMarkdown = "markdown"
+	SourceGitHub   = "github"
+)
+
+// Result describes what the wizard set up.
+type Result struct {
+	BeadsPath string
+	Source    string // SourceNone, SourceMarkdown or SourceGitHub
+	Imported  int
+}
+
+// Wizard guides the user through creating a beads directory.
+type Wizard struct {
+	beadsDir string
+}
+
+// NewWizard creates a setup wizard for beadsDir.
+func NewWizard(beadsDir string) *Wizard {
+	return &Wizard{beadsDir: beadsDir}
+}
+
+// newForm creates a form in the same style as the export wizard
+func newForm(groups ...*huh.Group) *huh.Form {
+	return huh.NewForm(groups...).WithTheme(huh.ThemeDracula())
+}
+
+// Run asks whether to create the beads file and what to import into it,
+// then does so. Returns ErrDeclined if the user says no or aborts.
+func (w *Wizard) Run() (*Result, error) {
+	projectDir := filepath.Dir(w.beadsDir)
+	w.printBanner(projectDir)
+
+	create := true
+	source := SourceNone
+	form := newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Set up beads in %s?", projectDir)).
+				Description("Creates "+filepath.Join(filepath.Base(w.beadsDir), BeadsFilename)).
+				Value(&create).
+				Affirmative("Yes, set up").
+				Negative("No, exit"),
+		),
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Start with").
+				Options(
+					huh.NewOption("An empty project", SourceNone),
+					huh.NewOption("Issues from a Markdown TODO list", SourceMarkdown),
+					huh.NewOption("Issues from GitHub (requires gh CLI)", SourceGitHub),
+				).
+				Value(&source),
+		).WithHideFunc(func() bool { return !create }),
+	)
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil, ErrDeclined
+		}
+		return nil, err
+	}
+	if !create {
+		return nil, ErrDeclined
+	}
+
+	// Read the import before touching the disk, so a failed import leaves
+	// the project as it was and the wizard is offered again next time
+	prefix := filepath.Base(projectDir)
+	var imported []model.Issue
+	switch source {
+	case SourceMarkdown:
+		issues, err := w.importMarkdown(prefix)
+		if err != nil {
+			return nil, err
+		}
+		imported = issues
+	case SourceGitHub:
+		issues, err := w.importGitHub(prefix)
+		if err != nil {
+			return nil, err
+		}
+		imported = issues
+	}
+
+	path, err := InitBeads(w.beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(imported) > 0 {
+		if err := loader.AppendIssuesToFile(path, imported); err != nil {
+			return nil, fmt.Errorf("writing imported issues: %w", err)
+		}
+		ids := make([]string, 0, len(imported))
+		for _, issue := range imported {
+			ids = append(ids, issue.ID)
+		}
+		// The issues are written; a missing audit entry is not worth failing setup
+		_ = audit.Append(projectDir, audit.Entry{Action: audit.ActionImport, IssueIDs: ids, Changes: []string{"setup " + source}, Source: audit.SourceCLI})
+	}
+
+	fmt.Printf("✓ Created %s", path)
+	if source != SourceNone {
+		fmt.Printf(" with %d imported issues", len(imported))
+	}
+	fmt.Println("")
+	return &Result{BeadsPath: path, Source: source, Imported: len(imported)}, nil
+}
+
+// importMarkdown asks for a Markdown TODO list and parses it
+func (w *Wizard) importMarkdown(prefix string) ([]model.Issue, error) {
+	path := "TODO.md"
+	form := newForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Markdown file").
+				Description("Headings become epics, checkboxes tasks").
+				Value(&path).
+				Validate(func(s string) error {
+					if _, err := os.Stat(strings.TrimSpace(s)); err != nil {
+						return fmt.Errorf("cannot read %s", s)
+					}
+					return nil
+				}),
+		),
+	)
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil, ErrDeclined
+		}
+		return nil, err
+	}
+
+	path = strings.TrimSpace(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	issues, _, err := loader.ParseMarkdownTodos(data, nil, prefix, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return issues, nil
+}
+
+// importGitHub asks for a repository and fetches its issues with gh
+func (w *Wizard) importGitHub(prefix string) ([]model.Issue, error) {
+	var repo string
+	includeClosed := false
+	form := newForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("GitHub repository (owner/name)").
+				Description("Leave empty for this directory's repository").
+				Value(&repo),
+			huh.NewConfirm().
+				Title("Include closed issues?").
+				Value(&includeClosed),
+		),
+	)
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil, ErrDeclined
+		}
+		return nil, err
+	}
+
+	fmt.Println("Fetching GitHub issues...")
+	data, err := FetchGitHubIssues(strings.TrimSpace(repo), includeClosed, githubImportLimit)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGitHubIssues(data, nil, prefix)
+}
+
+func (w *Wizard) printBanner(projectDir string) {
+	fmt.Println("")
+	fmt.Println("╔══════════════════════════════════════════════════════════════════╗")
+	fmt.Println("║           bv → Project Setup                                     ║")
+	fmt.Println("╠══════════════════════════════════════════════════════════════════╣")
+	fmt.Println("║  No beads issues were found here. This wizard will:              ║")
+	fmt.Println("║    1. Create .beads/beads.jsonl                                  ║")
+	fmt.Println("║    2. Optionally import a Markdown TODO list or GitHub issues    ║")
+	fmt.Println("║    3. Open bv with the interactive tutorial                      ║")
+	fmt.Println("║                                                                  ║")
+	fmt.Println("║  Press Ctrl+C anytime to cancel                                  ║")
+	fmt.Println("╚══════════════════════════════════════════════════════════════════╝")
+	fmt.Printf("Project: %s\n\n", projectDir)
+}
@@ -0,0 +1,260 @@
+// Package plugins runs external analyzers from .bv/plugins/.
+//
+// A plugin is any executable in that directory. It receives the issues as
+// JSON on stdin and prints annotations (extra scores, badges and warnings per
+// issue) as JSON on stdout:
+//
+//	stdin:  {"version": 1, "project_dir": "/path/to/repo", "issues": [...]}
+//	stdout: {"annotations": [{"issue_id": "bv-12", "scores": {"risk": 0.8},
+//	         "badges": ["pci"], "warnings": ["touches payment code"]}]}
+//
+// A plugin that fails, times out or prints invalid JSON is reported in the
+// Report without affecting the others.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DirName is the plugin directory inside .bv/
+const DirName = "plugins"
+
+// ProtocolVersion is sent to plugins as "version"
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds one plugin run
+const DefaultTimeout = 10 * time.Second
+
+// Plugin is an executable in .bv/plugins/.
+type Plugin struct {
+	Name string // File name without extension
+	Path string
+}
+
+// Input is written to a plugin's stdin.
+type Input struct {
+	Version    int           `json:"version"`
+	ProjectDir string        `json:"project_dir"`
+	Issues     []model.Issue `json:"issues"`
+}
+
+// Annotation is what a plugin reports about one issue.
+type Annotation struct {
+	IssueID  string             `json:"issue_id"`
+	Plugin   string             `json:"plugin,omitempty"` // Filled in by bv
+	Scores   map[string]float64 `json:"scores,omitempty"`
+	Badges   []string           `json:"badges,omitempty"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// Output is read from a plugin's stdout.
+type Output struct {
+	Annotations []Annotation `json:"annotations"`
+}
+
+// Status is the outcome of one plugin run.
+type Status struct {
+	Name        string `json:"name"`
+	Annotations int    `json:"annotations"`
+	Ignored     int    `json:"ignored,omitempty"` // Annotations for unknown issues
+	DurationMs  int64  `json:"duration_ms"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Report merges the annotations of every plugin.
+type Report struct {
+	Plugins     []Status                `json:"plugins"`
+	Annotations map[string][]Annotation `json:"annotations"` // Keyed by issue ID
+}
+
+// Dir returns the plugin directory for a project
+func Dir(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", DirName)
+}
+
+// Discover lists the executables in .bv/plugins/, sorted by name. A missing
+// directory means no plugins.
+func Discover(projectDir string) ([]Plugin, error) {
+	entries, err := os.ReadDir(Dir(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading plugin directory: %w", err)
+	}
+
+	var found []Plugin
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !isExecutable(name, info) {
+			continue
+		}
+		found = append(found, Plugin{
+			Name: strings.TrimSuffix(name, filepath.Ext(name)),
+			Path: filepath.Join(Dir(projectDir), name),
+		})
+	}
+	return found, nil
+}
+
+// isExecutable reports whether a plugin directory entry can be run
+func isExecutable(name string, info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Run executes the plugin with input on stdin and parses its annotations.
+func (p Plugin) Run(ctx context.Context, input Input) ([]Annotation, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("encoding input: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Dir = input.ProjectDir
+	cmd.Stdin = bytes.NewReader(payload)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on children that still hold stdout after a timeout kill
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out")
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, truncate(msg, 200))
+		}
+		return nil, err
+	}
+
+	var out Output
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+	return out.Annotations, nil
+}
+
+// RunAll runs every plugin concurrently, each bounded by timeout, and merges
+// their annotations. It returns nil when the project has no plugins.
+func RunAll(ctx context.Context, projectDir string, issues []model.Issue, timeout time.Duration) (*Report, error) {
+	found, err := Discover(projectDir)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	input := Input{Version: ProtocolVersion, ProjectDir: projectDir, Issues: issues}
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+
+	statuses := make([]Status, len(found))
+	results := make([][]Annotation, len(found))
+	var wg sync.WaitGroup
+	for i, p := range found {
+		wg.Add(1)
+		go func(i int, p Plugin) {
+			defer wg.Done()
+			runCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			annotations, err := p.Run(runCtx, input)
+			statuses[i] = Status{Name: p.Name, DurationMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				statuses[i].Error = err.Error()
+				return
+			}
+			for _, a := range annotations {
+				if !known[a.IssueID] {
+					statuses[i].Ignored++
+					continue
+				}
+				a.Plugin = p.Name
+				results[i] = append(results[i], a)
+			}
+			statuses[i].Annotations = len(results[i])
+		}(i, p)
+	}
+	wg.Wait()
+
+	report := &Report{Plugins: statuses, Annotations: make(map[string][]Annotation)}
+	for _, annotations := range results {
+		for _, a := range annotations {
+			report.Annotations[a.IssueID] = append(report.Annotations[a.IssueID], a)
+		}
+	}
+	return report, nil
+}
+
+// For returns the annotations of an issue, in plugin order
+func (r *Report) For(issueID string) []Annotation {
+	if r == nil {
+		return nil
+	}
+	return r.Annotations[issueID]
+}
+
+// Failed returns the plugins that did not complete
+func (r *Report) Failed() []Status {
+	if r == nil {
+		return nil
+	}
+	var failed []Status
+	for _, s := range r.Plugins {
+		if s.Error != "" {
+			failed = append(failed, s)
+		}
+	}
+	return failed
+}
+
+// SortedScores returns an annotation's score names in order
+func (a Annotation) SortedScores() []string {
+	names := make([]string, 0, len(a.Scores))
+	for name := range a.Scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// truncate shortens s to max runes with an ellipsis
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
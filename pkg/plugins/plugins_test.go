@@ -0,0 +1,117 @@
+//go:build !windows
+
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writePlugin(t *testing.T, dir, name, script string, mode os.FileMode) {
+	t.Helper()
+	pluginDir := Dir(dir)
+	if err := os.MkdirAll(pluginDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, name), []byte(script), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	if found, err := Discover(dir); err != nil || found != nil {
+		t.Fatalf("missing directory: got %v, %v", found, err)
+	}
+
+	writePlugin(t, dir, "security.sh", "#!/bin/sh\n", 0o755)
+	writePlugin(t, dir, "README.md", "not a plugin", 0o644)
+	writePlugin(t, dir, ".hidden", "#!/bin/sh\n", 0o755)
+
+	found, err := Discover(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Name != "security" {
+		t.Fatalf("got %+v, want only the security plugin", found)
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	dir := t.TempDir()
+	// Reads the issue count from stdin to prove the input arrives
+	writePlugin(t, dir, "risk", `#!/bin/sh
+count=$(grep -o '"id":' | wc -l | tr -d ' ')
+cat <<EOF
+{"annotations": [
+  {"issue_id": "A", "scores": {"risk": 0.8, "count": $count}, "badges": ["pci"]},
+  {"issue_id": "GHOST", "warnings": ["unknown"]}
+]}
+EOF
+`, 0o755)
+	writePlugin(t, dir, "lint", `#!/bin/sh
+echo '{"annotations": [{"issue_id": "A", "warnings": ["no owner"]}]}'
+`, 0o755)
+	writePlugin(t, dir, "broken", "#!/bin/sh\necho oops >&2\nexit 3\n", 0o755)
+	writePlugin(t, dir, "garbage", "#!/bin/sh\necho not json\n", 0o755)
+	writePlugin(t, dir, "slow", "#!/bin/sh\nsleep 5\n", 0o755)
+
+	issues := []model.Issue{{ID: "A", Title: "Pay"}, {ID: "B", Title: "Other"}}
+	report, err := RunAll(context.Background(), dir, issues, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := make(map[string]Status)
+	for _, s := range report.Plugins {
+		statuses[s.Name] = s
+	}
+	if s := statuses["risk"]; s.Error != "" || s.Annotations != 1 || s.Ignored != 1 {
+		t.Errorf("risk status = %+v, want 1 annotation and 1 ignored", s)
+	}
+	if s := statuses["broken"]; !strings.Contains(s.Error, "oops") {
+		t.Errorf("broken error = %q, want stderr included", s.Error)
+	}
+	if s := statuses["garbage"]; !strings.Contains(s.Error, "invalid output") {
+		t.Errorf("garbage error = %q", s.Error)
+	}
+	if s := statuses["slow"]; s.Error != "timed out" {
+		t.Errorf("slow error = %q, want timed out", s.Error)
+	}
+	if len(report.Failed()) != 3 {
+		t.Errorf("Failed() = %d plugins, want 3", len(report.Failed()))
+	}
+
+	// Plugins are merged in name order: lint before risk
+	annotations := report.For("A")
+	if len(annotations) != 2 || annotations[0].Plugin != "lint" || annotations[1].Plugin != "risk" {
+		t.Fatalf("annotations for A = %+v", annotations)
+	}
+	risk := annotations[1]
+	if risk.Scores["risk"] != 0.8 || risk.Scores["count"] != 2 || risk.Badges[0] != "pci" {
+		t.Errorf("risk annotation = %+v", risk)
+	}
+	if got := risk.SortedScores(); strings.Join(got, ",") != "count,risk" {
+		t.Errorf("SortedScores() = %v", got)
+	}
+	if report.For("B") != nil || report.For("GHOST") != nil {
+		t.Error("expected no annotations for B or unknown issues")
+	}
+}
+
+func TestRunAllNoPlugins(t *testing.T) {
+	report, err := RunAll(context.Background(), t.TempDir(), nil, 0)
+	if err != nil || report != nil {
+		t.Fatalf("got %+v, %v; want nil report", report, err)
+	}
+	var nilReport *Report
+	if nilReport.For("A") != nil || nilReport.Failed() != nil {
+		t.Error("nil report should be empty")
+	}
+}
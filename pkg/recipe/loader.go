@@ -220,6 +220,86 @@ func (l *Loader) Source(name string) string {
 	return l.sources[name]
 }
 
+// SaveUserRecipe adds r to the user config, replacing a user recipe of
+// the same name, and makes it available from this loader. Comments and
+// other recipes in the file are kept.
+func (l *Loader) SaveUserRecipe(r Recipe) error {
+	if l.userPath == "" {
+		return fmt.Errorf("no user recipe file")
+	}
+	if r.Name == "" {
+		return fmt.Errorf("recipe has no name")
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(l.userPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %s: %w", l.userPath, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: expected a mapping at the top level", l.userPath)
+	}
+
+	var recipes *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "recipes" {
+			recipes = root.Content[i+1]
+		}
+	}
+	switch {
+	case recipes == nil:
+		recipes = &yaml.Node{Kind: yaml.MappingNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "recipes"}, recipes)
+	case recipes.Kind == yaml.ScalarNode && recipes.Tag == "!!null":
+		*recipes = yaml.Node{Kind: yaml.MappingNode} // "recipes:" with nothing under it
+	case recipes.Kind != yaml.MappingNode:
+		return fmt.Errorf("%s: recipes is not a mapping", l.userPath)
+	}
+
+	body := r
+	body.Name = "" // the key names the recipe
+	var value yaml.Node
+	if err := value.Encode(&body); err != nil {
+		return err
+	}
+	replaced := false
+	for i := 0; i+1 < len(recipes.Content); i += 2 {
+		if recipes.Content[i].Value == r.Name {
+			recipes.Content[i+1] = &value
+			replaced = true
+		}
+	}
+	if !replaced {
+		recipes.Content = append(recipes.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: r.Name}, &value)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.userPath), 0755); err != nil {
+		return err
+	}
+	tmpPath := l.userPath + ".tmp"
+	if err := os.WriteFile(tmpPath, out, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, l.userPath); err != nil {
+		return err
+	}
+
+	l.recipes[r.Name] = r
+	l.sources[r.Name] = "user"
+	return nil
+}
+
 // LoadDefault creates a loader and loads with default settings
 func LoadDefault() (*Loader, error) {
 	loader := NewLoader()
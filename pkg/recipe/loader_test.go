@@ -3,6 +3,7 @@ package recipe_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
@@ -316,3 +317,70 @@ func TestLoaderList(t *testing.T) {
 		t.Error("Expected non-empty list")
 	}
 }
+
+func TestSaveUserRecipe(t *testing.T) {
+	tmpDir := t.TempDir()
+	userPath := filepath.Join(tmpDir, "recipes.yaml")
+	existing := `# my recipes
+recipes:
+  mine:
+    description: Keep me # still here
+    filters:
+      status: [open]
+`
+	if err := os.WriteFile(userPath, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loader := recipe.NewLoader(recipe.WithUserPath(userPath), recipe.WithProjectDir(""))
+	if err := loader.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := recipe.Recipe{Name: "search-login", Description: "Saved search: login", Filters: recipe.FilterConfig{Search: "login"}}
+	if err := loader.SaveUserRecipe(saved); err != nil {
+		t.Fatalf("SaveUserRecipe: %v", err)
+	}
+	saved.Description = "Saved search: login (again)"
+	if err := loader.SaveUserRecipe(saved); err != nil {
+		t.Fatalf("SaveUserRecipe replacing: %v", err)
+	}
+	if r := loader.Get("search-login"); r == nil || loader.Source("search-login") != "user" {
+		t.Fatal("saved recipe not available from the loader")
+	}
+
+	data, err := os.ReadFile(userPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# my recipes", "# still here", "search: login"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("user config lost %q:\n%s", want, data)
+		}
+	}
+
+	reloaded := recipe.NewLoader(recipe.WithUserPath(userPath), recipe.WithProjectDir(""))
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if r := reloaded.Get("search-login"); r == nil || r.Filters.Search != "login" || r.Description != "Saved search: login (again)" {
+		t.Errorf("reloaded recipe = %+v", r)
+	}
+	if reloaded.Get("mine") == nil {
+		t.Error("existing user recipe lost")
+	}
+}
+
+func TestSaveUserRecipe_NewFile(t *testing.T) {
+	userPath := filepath.Join(t.TempDir(), "bv", "recipes.yaml")
+	loader := recipe.NewLoader(recipe.WithUserPath(userPath), recipe.WithProjectDir(""))
+	if err := loader.SaveUserRecipe(recipe.Recipe{Name: "search-x", Filters: recipe.FilterConfig{Search: "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := recipe.NewLoader(recipe.WithUserPath(userPath), recipe.WithProjectDir(""))
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if r := reloaded.Get("search-x"); r == nil || r.Filters.Search != "x" {
+		t.Errorf("reloaded recipe = %+v", r)
+	}
+}
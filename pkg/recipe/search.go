@@ -0,0 +1,61 @@
+package recipe
+
+import (
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SearchField is a named piece of issue text that a search looks in
+type SearchField struct {
+	Name string // "title", "description", "comment", ...
+	Text string
+}
+
+// SearchFields returns the text a search looks in, most telling first
+func SearchFields(issue model.Issue) []SearchField {
+	fields := []SearchField{
+		{"id", issue.ID},
+		{"title", issue.Title},
+		{"labels", strings.Join(issue.Labels, " ")},
+		{"assignee", issue.Assignee},
+		{"description", issue.Description},
+		{"design", issue.Design},
+		{"acceptance", issue.AcceptanceCriteria},
+		{"notes", issue.Notes},
+	}
+	for _, c := range issue.Comments {
+		if c != nil {
+			fields = append(fields, SearchField{"comment", c.Text})
+		}
+	}
+	return fields
+}
+
+// SearchTerms splits a query into lower-case words. The ~ that marks a
+// semantic query in the TUI is dropped.
+func SearchTerms(query string) []string {
+	query = strings.TrimPrefix(strings.TrimSpace(query), "~")
+	return strings.Fields(strings.ToLower(query))
+}
+
+// MatchesSearch reports whether every word of query appears somewhere in
+// the issue's text, ignoring case. An empty query matches everything.
+func MatchesSearch(issue model.Issue, query string) bool {
+	terms := SearchTerms(query)
+	if len(terms) == 0 {
+		return true
+	}
+	var sb strings.Builder
+	for _, f := range SearchFields(issue) {
+		sb.WriteString(strings.ToLower(f.Text))
+		sb.WriteString("\n")
+	}
+	text := sb.String()
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package recipe_test
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+func TestMatchesSearch(t *testing.T) {
+	issue := model.Issue{
+		ID:          "bv-12",
+		Title:       "Fix login timeout",
+		Description: "Sessions expire after 5 minutes",
+		Labels:      []string{"auth"},
+		Comments:    []*model.Comment{{Text: "Seen on Safari only"}},
+	}
+	for query, want := range map[string]bool{
+		"":                   true,
+		"LOGIN":              true,
+		"login safari":       true,
+		"~sessions auth":     true,
+		"bv-12 expire":       true,
+		"login chrome":       false,
+		"logout":             false,
+		"timeout   minutes ": true,
+	} {
+		if got := recipe.MatchesSearch(issue, query); got != want {
+			t.Errorf("MatchesSearch(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
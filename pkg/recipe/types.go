@@ -32,6 +32,7 @@ type FilterConfig struct {
 	Actionable    *bool    `yaml:"actionable,omitempty" json:"actionable,omitempty"`         // true = no open blockers
 	TitleContains string   `yaml:"title_contains,omitempty" json:"title_contains,omitempty"` // Substring match
 	IDPrefix      string   `yaml:"id_prefix,omitempty" json:"id_prefix,omitempty"`           // e.g., "bv-" for project filtering
+	Search        string   `yaml:"search,omitempty" json:"search,omitempty"`                 // Words found anywhere in the issue text (saved searches)
 }
 
 // SortConfig defines how to order issues
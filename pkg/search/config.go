@@ -38,11 +38,11 @@ func NewEmbedderFromConfig(cfg EmbeddingConfig) (Embedder, error) {
 	case "", ProviderHash:
 		return NewHashEmbedder(cfg.Dim), nil
 	case ProviderPythonSentenceTransformers:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (mvp placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+		return NewPythonEmbedder(cfg)
 	case ProviderOpenAI:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+		return NewOpenAIEmbedder(cfg)
 	default:
-		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q", cfg.Provider, ProviderHash)
+		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q, %q or %q", cfg.Provider, ProviderHash, ProviderPythonSentenceTransformers, ProviderOpenAI)
 	}
 }
 
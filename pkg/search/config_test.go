@@ -292,6 +292,9 @@ func saveSearchEnv() func() {
 // =============================================================================
 
 func TestNewEmbedderFromConfig(t *testing.T) {
+	t.Setenv(EnvSemanticPython, "bv-no-such-python")
+	t.Setenv(EnvOpenAIAPIKey, "")
+
 	tests := []struct {
 		name        string
 		cfg         EmbeddingConfig
@@ -336,16 +339,16 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 			},
 		},
 		{
-			name:        "python-sentence-transformers not implemented",
+			name:        "python-sentence-transformers without python",
 			cfg:         EmbeddingConfig{Provider: ProviderPythonSentenceTransformers, Dim: 384},
 			wantErr:     true,
-			errContains: "not implemented",
+			errContains: "needs Python",
 		},
 		{
-			name:        "openai not implemented",
+			name:        "openai without API key",
 			cfg:         EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536},
 			wantErr:     true,
-			errContains: "not implemented",
+			errContains: EnvOpenAIAPIKey,
 		},
 		{
 			name:        "unknown provider error",
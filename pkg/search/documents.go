@@ -45,3 +45,31 @@ func DocumentsFromIssues(issues []model.Issue) map[string]string {
 	}
 	return docs
 }
+
+// SimilarityDocument is IssueDocument without the ID, so two issues
+// describing the same work embed alike. Used for similar-issue lookups.
+func SimilarityDocument(issue model.Issue) string {
+	var parts []string
+	if title := strings.TrimSpace(issue.Title); title != "" {
+		parts = append(parts, title, title)
+	}
+	if labels := strings.TrimSpace(strings.Join(issue.Labels, " ")); labels != "" {
+		parts = append(parts, labels)
+	}
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		parts = append(parts, desc)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// SimilarityDocuments builds an ID->SimilarityDocument map for indexing.
+func SimilarityDocuments(issues []model.Issue) map[string]string {
+	docs := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		docs[issue.ID] = SimilarityDocument(issue)
+	}
+	return docs
+}
@@ -325,3 +325,21 @@ func TestIssueDocument_PreservesContent(t *testing.T) {
 		t.Errorf("Content not preserved correctly:\ngot: %q\nwant: %q", result, expected)
 	}
 }
+
+func TestSimilarityDocument_OmitsID(t *testing.T) {
+	a := model.Issue{ID: "bv-1", Title: "Login fails", Labels: []string{"auth"}, Description: "Safari only"}
+	b := a
+	b.ID = "bv-2"
+
+	if SimilarityDocument(a) != SimilarityDocument(b) {
+		t.Errorf("Issues differing only by ID should share a document: %q vs %q", SimilarityDocument(a), SimilarityDocument(b))
+	}
+	if want := "Login fails\nLogin fails\nauth\nSafari only"; SimilarityDocument(a) != want {
+		t.Errorf("SimilarityDocument = %q, want %q", SimilarityDocument(a), want)
+	}
+
+	docs := SimilarityDocuments([]model.Issue{a, {Title: "no id"}})
+	if len(docs) != 1 || docs["bv-1"] == "" {
+		t.Errorf("Expected one document keyed by ID, got %v", docs)
+	}
+}
@@ -11,15 +11,29 @@ import (
 )
 
 // DefaultIndexPath returns the default semantic index path under the given project directory.
-// The filename is keyed by provider+dim to avoid mixing incompatible embeddings.
+// The filename is keyed by provider+model+dim to avoid mixing incompatible embeddings.
 func DefaultIndexPath(projectDir string, cfg EmbeddingConfig) string {
+	return embeddingsPath(projectDir, "index", cfg)
+}
+
+// SimilarityIndexPath returns the path of the index of SimilarityDocuments,
+// kept next to the search index.
+func SimilarityIndexPath(projectDir string, cfg EmbeddingConfig) string {
+	return embeddingsPath(projectDir, "similar", cfg)
+}
+
+func embeddingsPath(projectDir, kind string, cfg EmbeddingConfig) string {
 	cfg = cfg.Normalized()
 	provider := cfg.Provider
 	if provider == "" {
 		provider = ProviderHash
 	}
-	safeProvider := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(string(provider))
-	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", safeProvider, cfg.Dim))
+	key := string(provider)
+	if cfg.Model != "" {
+		key += "-" + cfg.Model
+	}
+	safeKey := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_").Replace(key)
+	return filepath.Join(projectDir, ".bv", "embeddings", fmt.Sprintf("%s-%s-%d.bvvi", kind, safeKey, cfg.Dim))
 }
 
 type IndexSyncStats struct {
@@ -29,6 +43,7 @@ type IndexSyncStats struct {
 	Removed  int `json:"removed"`
 	Skipped  int `json:"skipped"`
 	Embedded int `json:"embedded"`
+	Reused   int `json:"reused"` // Vectors copied from another entry with the same content
 }
 
 func (s IndexSyncStats) Changed() bool {
@@ -92,6 +107,16 @@ func SyncVectorIndex(ctx context.Context, idx *VectorIndex, embedder Embedder, d
 
 	// Use sortedIDs to safely iterate over keys without holding lock or racing
 	existingIDs := idx.sortedIDs()
+
+	// Vectors are reusable by content hash, so an issue whose text matches
+	// an existing entry (renamed, or edited back) is not embedded again.
+	byHash := make(map[ContentHash][]float32, len(existingIDs))
+	for _, issueID := range existingIDs {
+		if entry, ok := idx.Get(issueID); ok {
+			byHash[entry.ContentHash] = entry.Vector
+		}
+	}
+
 	for _, issueID := range existingIDs {
 		if _, ok := docIDs[issueID]; !ok {
 			idx.Remove(issueID)
@@ -123,6 +148,13 @@ func SyncVectorIndex(ctx context.Context, idx *VectorIndex, embedder Embedder, d
 		} else {
 			stats.Added++
 		}
+		if vec, cached := byHash[ch]; cached {
+			if err := idx.Upsert(id, ch, vec); err != nil {
+				return stats, err
+			}
+			stats.Reused++
+			continue
+		}
 		toEmbedIDs = append(toEmbedIDs, id)
 		toEmbedTexts = append(toEmbedTexts, text)
 		toEmbedHashes = append(toEmbedHashes, ch)
@@ -94,3 +94,36 @@ func TestLoadOrNewVectorIndex(t *testing.T) {
 		t.Fatalf("expected 1 entry, got %d", loadedIdx.Size())
 	}
 }
+
+func TestSyncVectorIndex_ReusesVectorsByContentHash(t *testing.T) {
+	embedder := NewHashEmbedder(16)
+	idx := NewVectorIndex(embedder.Dim())
+
+	text := "Fix login flow\nAdd OAuth redirect handling"
+	if _, err := SyncVectorIndex(context.Background(), idx, embedder, map[string]string{"A": text}, 0); err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+
+	// A renamed to A2 with the same text: nothing is embedded
+	stats, err := SyncVectorIndex(context.Background(), idx, embedder, map[string]string{"A2": text}, 0)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if stats.Added != 1 || stats.Removed != 1 || stats.Reused != 1 || stats.Embedded != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if _, ok := idx.Get("A2"); !ok {
+		t.Fatal("expected A2 to be indexed")
+	}
+}
+
+func TestDefaultIndexPath(t *testing.T) {
+	got := DefaultIndexPath("/repo", EmbeddingConfig{Provider: ProviderOpenAI, Model: "text-embedding-3-small", Dim: 384})
+	want := filepath.Join("/repo", ".bv", "embeddings", "index-openai-text-embedding-3-small-384.bvvi")
+	if got != want {
+		t.Errorf("DefaultIndexPath() = %s, want %s", got, want)
+	}
+	if got := DefaultIndexPath("/repo", EmbeddingConfig{}); filepath.Base(got) != "index-hash-384.bvvi" {
+		t.Errorf("DefaultIndexPath() = %s, want index-hash-384.bvvi", got)
+	}
+}
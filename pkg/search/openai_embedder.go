@@ -0,0 +1,139 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultOpenAIEmbeddingModel supports shortened vectors, so it can produce
+// DefaultEmbeddingDim-sized embeddings.
+const DefaultOpenAIEmbeddingModel = "text-embedding-3-small"
+
+const (
+	// EnvOpenAIAPIKey holds the API key for the openai embedder.
+	EnvOpenAIAPIKey = "OPENAI_API_KEY"
+	// EnvOpenAIBaseURL points the openai embedder at a compatible server
+	// (default: https://api.openai.com/v1).
+	EnvOpenAIBaseURL = "OPENAI_BASE_URL"
+)
+
+// OpenAIEmbedder embeds texts through an OpenAI-compatible /embeddings API.
+type OpenAIEmbedder struct {
+	baseURL string
+	apiKey  string
+	model   string
+	dim     int
+	client  *http.Client
+}
+
+// NewOpenAIEmbedder returns an embedder for cfg using the key and base URL
+// from the environment.
+func NewOpenAIEmbedder(cfg EmbeddingConfig) (*OpenAIEmbedder, error) {
+	cfg = cfg.Normalized()
+	apiKey := strings.TrimSpace(os.Getenv(EnvOpenAIAPIKey))
+	if apiKey == "" {
+		return nil, fmt.Errorf("semantic embedder %q needs %s; set %s=%q for the built-in fallback", ProviderOpenAI, EnvOpenAIAPIKey, EnvSemanticEmbedder, ProviderHash)
+	}
+	baseURL := strings.TrimRight(strings.TrimSpace(os.Getenv(EnvOpenAIBaseURL)), "/")
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = DefaultOpenAIEmbeddingModel
+	}
+	return &OpenAIEmbedder{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		model:   model,
+		dim:     cfg.Dim,
+		client:  &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+func (*OpenAIEmbedder) Provider() Provider { return ProviderOpenAI }
+func (o *OpenAIEmbedder) Dim() int         { return o.dim }
+
+type openAIEmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (o *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	reqBody := openAIEmbeddingRequest{Model: o.model, Input: texts}
+	// Only the text-embedding-3 family can shorten its vectors
+	if strings.HasPrefix(o.model, "text-embedding-3") {
+		reqBody.Dimensions = o.dim
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("encode embedding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read embedding response: %w", err)
+	}
+	var parsed openAIEmbeddingResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse embedding response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil && parsed.Error.Message != "" {
+			return nil, fmt.Errorf("embedding API (HTTP %d): %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, fmt.Errorf("embedding API returned HTTP %d", resp.StatusCode)
+	}
+
+	out := make([][]float32, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding API returned index %d for %d texts", d.Index, len(texts))
+		}
+		if len(d.Embedding) != o.dim {
+			return nil, fmt.Errorf("model %s returned %d-dim vectors; set %s=%d", o.model, len(d.Embedding), EnvSemanticDim, len(d.Embedding))
+		}
+		normalizeL2(d.Embedding)
+		out[d.Index] = d.Embedding
+	}
+	for i, vec := range out {
+		if vec == nil {
+			return nil, fmt.Errorf("embedding API returned no vector for text %d", i)
+		}
+	}
+	return out, nil
+}
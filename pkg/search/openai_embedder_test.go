@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestOpenAIEmbedder(t *testing.T, handler http.HandlerFunc) *OpenAIEmbedder {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	t.Setenv(EnvOpenAIAPIKey, "test-key")
+	t.Setenv(EnvOpenAIBaseURL, srv.URL+"/")
+
+	e, err := NewOpenAIEmbedder(EmbeddingConfig{Provider: ProviderOpenAI, Dim: 2})
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder() error = %v", err)
+	}
+	return e
+}
+
+func TestOpenAIEmbedder_Embed(t *testing.T) {
+	e := newTestOpenAIEmbedder(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" || r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, `{"error":{"message":"bad request"}}`, http.StatusBadRequest)
+			return
+		}
+		var req openAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if req.Model != DefaultOpenAIEmbeddingModel || req.Dimensions != 2 || len(req.Input) != 2 {
+			t.Errorf("unexpected request %+v", req)
+		}
+		// Out of order, and not normalized
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,2]},{"index":0,"embedding":[3,4]}]}`))
+	})
+
+	vecs, err := e.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vecs) != 2 || math.Abs(float64(vecs[0][0])-0.6) > 1e-6 || vecs[1][1] != 1 {
+		t.Errorf("unexpected vectors %v", vecs)
+	}
+}
+
+func TestOpenAIEmbedder_Errors(t *testing.T) {
+	e := newTestOpenAIEmbedder(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+	})
+	if _, err := e.Embed(context.Background(), []string{"a"}); err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Errorf("expected the API error message, got %v", err)
+	}
+
+	e = newTestOpenAIEmbedder(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"index":0,"embedding":[1,2,3]}]}`))
+	})
+	if _, err := e.Embed(context.Background(), []string{"a"}); err == nil || !strings.Contains(err.Error(), EnvSemanticDim+"=3") {
+		t.Errorf("expected a dimension hint, got %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// DefaultSentenceTransformersModel is a small local model whose 384-dim
+// vectors match DefaultEmbeddingDim.
+const DefaultSentenceTransformersModel = "all-MiniLM-L6-v2"
+
+// EnvSemanticPython overrides the Python interpreter used by the
+// sentence-transformers embedder (default: python3).
+const EnvSemanticPython = "BV_SEMANTIC_PYTHON"
+
+// pythonEmbedScript serves embedding requests over stdin/stdout, one JSON
+// object per line, so the model is loaded once per bv session.
+const pythonEmbedScript = `
+import json, sys
+from sentence_transformers import SentenceTransformer
+model = SentenceTransformer(sys.argv[1])
+print(json.dumps({"ready": True}), flush=True)
+for line in sys.stdin:
+    try:
+        texts = json.loads(line)["texts"]
+        vecs = model.encode(texts, normalize_embeddings=True)
+        print(json.dumps({"vectors": [[float(x) for x in v] for v in vecs]}), flush=True)
+    except Exception as e:
+        print(json.dumps({"error": str(e)}), flush=True)
+`
+
+// PythonEmbedder embeds texts with a sentence-transformers model running in
+// a long-lived Python subprocess.
+type PythonEmbedder struct {
+	python string
+	model  string
+	dim    int
+
+	mu   sync.Mutex
+	proc *pythonProcess
+}
+
+// pythonProcess is one running embedding subprocess.
+type pythonProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr *strings.Builder
+	ready  bool
+}
+
+type pythonEmbedResponse struct {
+	Ready   bool        `json:"ready"`
+	Vectors [][]float32 `json:"vectors"`
+	Error   string      `json:"error"`
+}
+
+// NewPythonEmbedder returns an embedder for cfg. The subprocess starts on
+// the first Embed call.
+func NewPythonEmbedder(cfg EmbeddingConfig) (*PythonEmbedder, error) {
+	cfg = cfg.Normalized()
+	python := strings.TrimSpace(os.Getenv(EnvSemanticPython))
+	if python == "" {
+		python = "python3"
+	}
+	path, err := exec.LookPath(python)
+	if err != nil {
+		return nil, fmt.Errorf("semantic embedder %q needs Python (%s): %w; set %s=%q for the built-in fallback", ProviderPythonSentenceTransformers, python, err, EnvSemanticEmbedder, ProviderHash)
+	}
+	model := cfg.Model
+	if model == "" {
+		model = DefaultSentenceTransformersModel
+	}
+	return &PythonEmbedder{python: path, model: model, dim: cfg.Dim}, nil
+}
+
+func (*PythonEmbedder) Provider() Provider { return ProviderPythonSentenceTransformers }
+func (p *PythonEmbedder) Dim() int         { return p.dim }
+
+// Embed sends texts to the subprocess. If ctx ends first the subprocess is
+// killed and restarted on the next call.
+func (p *PythonEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.proc == nil {
+		proc, err := p.start()
+		if err != nil {
+			return nil, err
+		}
+		p.proc = proc
+	}
+	proc := p.proc
+
+	type result struct {
+		vecs [][]float32
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		vecs, err := p.request(proc, texts)
+		done <- result{vecs, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = proc.cmd.Process.Kill()
+		<-done
+		p.stop()
+		return nil, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			p.stop()
+			return nil, r.err
+		}
+		return r.vecs, nil
+	}
+}
+
+// start launches the subprocess; the model loads in the background.
+func (p *PythonEmbedder) start() (*pythonProcess, error) {
+	cmd := exec.Command(p.python, "-c", pythonEmbedScript, p.model)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("embedding process stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("embedding process stdout: %w", err)
+	}
+	stderr := &strings.Builder{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start embedding process: %w", err)
+	}
+	return &pythonProcess{cmd: cmd, stdin: stdin, stdout: bufio.NewReader(stdout), stderr: stderr}, nil
+}
+
+// request runs one embedding round trip, first waiting for the model to
+// load if this is the process's first request.
+func (p *PythonEmbedder) request(proc *pythonProcess, texts []string) ([][]float32, error) {
+	if !proc.ready {
+		resp, err := proc.readResponse()
+		if err == nil && !resp.Ready {
+			err = fmt.Errorf("embedding process did not report ready")
+		}
+		if err != nil {
+			// The process has exited, so stderr is complete
+			_ = proc.cmd.Wait()
+			if msg := lastLine(proc.stderr.String()); msg != "" {
+				return nil, fmt.Errorf("load model %s: %s", p.model, msg)
+			}
+			return nil, fmt.Errorf("load model %s: %w", p.model, err)
+		}
+		proc.ready = true
+	}
+
+	line, err := json.Marshal(map[string][]string{"texts": texts})
+	if err != nil {
+		return nil, fmt.Errorf("encode embedding request: %w", err)
+	}
+	if _, err := proc.stdin.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("write to embedding process: %w", err)
+	}
+	resp, err := proc.readResponse()
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Vectors) != len(texts) {
+		return nil, fmt.Errorf("embedding process returned %d vectors for %d texts", len(resp.Vectors), len(texts))
+	}
+	for _, vec := range resp.Vectors {
+		if len(vec) != p.dim {
+			return nil, fmt.Errorf("model %s returned %d-dim vectors; set %s=%d", p.model, len(vec), EnvSemanticDim, len(vec))
+		}
+	}
+	return resp.Vectors, nil
+}
+
+func (proc *pythonProcess) readResponse() (pythonEmbedResponse, error) {
+	var resp pythonEmbedResponse
+	line, err := proc.stdout.ReadBytes('\n')
+	if err != nil {
+		return resp, fmt.Errorf("read from embedding process: %w", err)
+	}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return resp, fmt.Errorf("parse embedding response: %w", err)
+	}
+	if resp.Error != "" {
+		return resp, fmt.Errorf("embedding process: %s", resp.Error)
+	}
+	return resp, nil
+}
+
+// stop kills the subprocess; the next Embed call starts a new one.
+func (p *PythonEmbedder) stop() {
+	if p.proc == nil {
+		return
+	}
+	_ = p.proc.stdin.Close()
+	_ = p.proc.cmd.Process.Kill()
+	_ = p.proc.cmd.Wait()
+	p.proc = nil
+}
+
+// Close stops the subprocess.
+func (p *PythonEmbedder) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stop()
+	return nil
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
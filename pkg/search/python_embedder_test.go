@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubSentenceTransformers stands in for the real package: vectors are
+// [len(text), 1, 0], "missing" fails to load and "slow" hangs.
+const stubSentenceTransformers = `
+import time
+
+class SentenceTransformer:
+    def __init__(self, name):
+        if name == "missing":
+            raise RuntimeError("no such model: " + name)
+
+    def encode(self, texts, normalize_embeddings=False):
+        if "slow" in texts:
+            time.sleep(30)
+        return [[float(len(t)), 1.0, 0.0] for t in texts]
+`
+
+func newStubPythonEmbedder(t *testing.T, model string) *PythonEmbedder {
+	t.Helper()
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sentence_transformers.py"), []byte(stubSentenceTransformers), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PYTHONPATH", dir)
+	t.Setenv(EnvSemanticPython, "python3")
+
+	e, err := NewPythonEmbedder(EmbeddingConfig{Provider: ProviderPythonSentenceTransformers, Model: model, Dim: 3})
+	if err != nil {
+		t.Fatalf("NewPythonEmbedder() error = %v", err)
+	}
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestPythonEmbedder_Embed(t *testing.T) {
+	e := newStubPythonEmbedder(t, "stub")
+
+	vecs, err := e.Embed(context.Background(), []string{"ab", "abcd"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vecs) != 2 || vecs[0][0] != 2 || vecs[1][0] != 4 {
+		t.Fatalf("unexpected vectors %v", vecs)
+	}
+	pid := e.proc.cmd.Process.Pid
+
+	// The subprocess (and its loaded model) is reused
+	if _, err := e.Embed(context.Background(), []string{"x"}); err != nil {
+		t.Fatalf("second Embed() error = %v", err)
+	}
+	if e.proc == nil || e.proc.cmd.Process.Pid != pid {
+		t.Error("expected the embedding process to be reused")
+	}
+}
+
+func TestPythonEmbedder_LoadFailure(t *testing.T) {
+	e := newStubPythonEmbedder(t, "missing")
+
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "no such model") {
+		t.Fatalf("expected the model load error, got %v", err)
+	}
+	if e.proc != nil {
+		t.Error("failed process should be cleared")
+	}
+}
+
+func TestPythonEmbedder_ContextCancel(t *testing.T) {
+	e := newStubPythonEmbedder(t, "stub")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := e.Embed(ctx, []string{"slow"}); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+
+	// A fresh process serves the next request
+	vecs, err := e.Embed(context.Background(), []string{"abc"})
+	if err != nil || len(vecs) != 1 || vecs[0][0] != 3 {
+		t.Fatalf("Embed() after cancel = %v, %v", vecs, err)
+	}
+}
+
+func TestPythonEmbedder_DimMismatch(t *testing.T) {
+	e := newStubPythonEmbedder(t, "stub")
+	e.dim = 8
+
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), EnvSemanticDim+"=3") {
+		t.Fatalf("expected a dimension hint, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultDuplicateThreshold is the similarity at or above which two issues
+// are reported as probable duplicates.
+const DefaultDuplicateThreshold = 0.8
+
+// DefaultSimilarThreshold is the lowest similarity worth showing as a
+// related issue.
+const DefaultSimilarThreshold = 0.5
+
+// SimilarPair is two indexed issues and the cosine similarity of their
+// embeddings. Issue1 sorts before Issue2.
+type SimilarPair struct {
+	Issue1     string  `json:"issue1"`
+	Issue2     string  `json:"issue2"`
+	Similarity float64 `json:"similarity"`
+}
+
+// SimilarTo returns up to k issues most similar to issueID, best first,
+// leaving out issueID itself.
+func (idx *VectorIndex) SimilarTo(issueID string, k int) ([]SearchResult, error) {
+	entry, ok := idx.Get(issueID)
+	if !ok {
+		return nil, fmt.Errorf("issue %s is not indexed", issueID)
+	}
+	results, err := idx.SearchTopK(entry.Vector, k+1)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SearchResult, 0, k)
+	for _, r := range results {
+		if r.IssueID != issueID && len(out) < k {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// SimilarPairs returns every pair of indexed issues with similarity of at
+// least threshold, best first. keep, when non-nil, limits the pairs to
+// issues it accepts.
+func (idx *VectorIndex) SimilarPairs(threshold float64, keep func(issueID string) bool) []SimilarPair {
+	ids := idx.sortedIDs()
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	candidates := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := idx.entries[id]; ok && (keep == nil || keep(id)) {
+			candidates = append(candidates, id)
+		}
+	}
+
+	var pairs []SimilarPair
+	for i, a := range candidates {
+		va := idx.entries[a].Vector
+		for _, b := range candidates[i+1:] {
+			score := dotFloat32(va, idx.entries[b].Vector)
+			if score >= threshold {
+				pairs = append(pairs, SimilarPair{Issue1: a, Issue2: b, Similarity: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Similarity > pairs[j].Similarity
+	})
+	return pairs
+}
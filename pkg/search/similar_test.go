@@ -0,0 +1,50 @@
+package search
+
+import "testing"
+
+func TestVectorIndex_SimilarTo(t *testing.T) {
+	idx := NewVectorIndex(3)
+	_ = idx.Upsert("a", ContentHash{}, []float32{1, 0, 0})
+	_ = idx.Upsert("b", ContentHash{}, []float32{0.8, 0.6, 0})
+	_ = idx.Upsert("c", ContentHash{}, []float32{0, 0, 1})
+
+	got, err := idx.SimilarTo("a", 1)
+	if err != nil {
+		t.Fatalf("SimilarTo: %v", err)
+	}
+	if len(got) != 1 || got[0].IssueID != "b" {
+		t.Fatalf("expected b as nearest to a, got %+v", got)
+	}
+
+	if _, err := idx.SimilarTo("missing", 3); err == nil {
+		t.Fatal("expected error for unindexed issue")
+	}
+}
+
+func TestVectorIndex_SimilarPairs(t *testing.T) {
+	idx := NewVectorIndex(3)
+	_ = idx.Upsert("a", ContentHash{}, []float32{1, 0, 0})
+	_ = idx.Upsert("b", ContentHash{}, []float32{0.8, 0.6, 0})
+	_ = idx.Upsert("c", ContentHash{}, []float32{1, 0, 0})
+	_ = idx.Upsert("d", ContentHash{}, []float32{0, 0, 1})
+
+	pairs := idx.SimilarPairs(0.75, nil)
+	want := []SimilarPair{
+		{Issue1: "a", Issue2: "c", Similarity: 1},
+		{Issue1: "a", Issue2: "b", Similarity: 0.8},
+		{Issue1: "b", Issue2: "c", Similarity: 0.8},
+	}
+	if len(pairs) != len(want) {
+		t.Fatalf("expected %d pairs, got %+v", len(want), pairs)
+	}
+	for i := range want {
+		if pairs[i].Issue1 != want[i].Issue1 || pairs[i].Issue2 != want[i].Issue2 {
+			t.Errorf("pair %d = %+v, want %+v", i, pairs[i], want[i])
+		}
+	}
+
+	kept := idx.SimilarPairs(0.75, func(id string) bool { return id != "c" })
+	if len(kept) != 1 || kept[0].Issue1 != "a" || kept[0].Issue2 != "b" {
+		t.Fatalf("expected only a/b without c, got %+v", kept)
+	}
+}
@@ -0,0 +1,391 @@
+// Package templates loads reusable issue templates from .beads/templates/
+// and turns them into new beads, including scheduled recurring ones.
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DirName is the directory inside .beads/ holding one YAML file per template
+const DirName = "templates"
+
+// Template describes an issue to create on demand or on a schedule
+type Template struct {
+	Name        string      `yaml:"name" json:"name"`                                   // Defaults to the file name
+	Title       string      `yaml:"title" json:"title"`                                 // Pattern; see RenderTitle
+	Type        string      `yaml:"type,omitempty" json:"type,omitempty"`               // Issue type (default: task)
+	Priority    *int        `yaml:"priority,omitempty" json:"priority,omitempty"`       // Default: 2
+	Labels      []string    `yaml:"labels,omitempty" json:"labels,omitempty"`           // Labels added to every instance
+	Assignee    string      `yaml:"assignee,omitempty" json:"assignee,omitempty"`       // Optional owner
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"` // Issue body
+	Checklist   []string    `yaml:"checklist,omitempty" json:"checklist,omitempty"`     // Appended to the body as "- [ ]" items
+	Recurrence  *Recurrence `yaml:"recurrence,omitempty" json:"recurrence,omitempty"`   // Schedule for --robot-recur
+	Path        string      `yaml:"-" json:"path"`                                      // File the template was loaded from
+}
+
+// Recurrence schedules a template. Occurrences fall on Start plus whole
+// multiples of Every.
+type Recurrence struct {
+	Every        string `yaml:"every" json:"every"`                                       // daily, weekly, monthly, or Nd/Nw/Nm
+	Start        string `yaml:"start" json:"start"`                                       // First occurrence (YYYY-MM-DD)
+	DueAfterDays int    `yaml:"due_after_days,omitempty" json:"due_after_days,omitempty"` // Sets due_date this long after the occurrence
+}
+
+// intervalPattern matches intervals like "3d", "2w" or "1m"
+var intervalPattern = regexp.MustCompile(`^(\d+)([dwm])$`)
+
+// interval returns the recurrence step as months and days
+func (r Recurrence) interval() (months, days int, err error) {
+	switch strings.ToLower(strings.TrimSpace(r.Every)) {
+	case "daily":
+		return 0, 1, nil
+	case "weekly":
+		return 0, 7, nil
+	case "biweekly":
+		return 0, 14, nil
+	case "monthly":
+		return 1, 0, nil
+	case "quarterly":
+		return 3, 0, nil
+	}
+	matches := intervalPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(r.Every)))
+	if matches == nil {
+		return 0, 0, fmt.Errorf("invalid recurrence interval %q (use daily, weekly, monthly or Nd/Nw/Nm)", r.Every)
+	}
+	n, _ := strconv.Atoi(matches[1])
+	if n <= 0 {
+		return 0, 0, fmt.Errorf("recurrence interval %q must be positive", r.Every)
+	}
+	switch matches[2] {
+	case "w":
+		return 0, 7 * n, nil
+	case "m":
+		return n, 0, nil
+	default:
+		return 0, n, nil
+	}
+}
+
+// start parses the first occurrence date
+func (r Recurrence) start() (time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(r.Start), time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid recurrence start %q (use YYYY-MM-DD): %w", r.Start, err)
+	}
+	return start, nil
+}
+
+// Validate checks that the schedule can be evaluated
+func (r Recurrence) Validate() error {
+	if _, _, err := r.interval(); err != nil {
+		return err
+	}
+	if _, err := r.start(); err != nil {
+		return err
+	}
+	if r.DueAfterDays < 0 {
+		return fmt.Errorf("due_after_days must not be negative")
+	}
+	return nil
+}
+
+// Occurrences returns the latest occurrence on or before now and the one
+// after it. ok is false when the schedule has not started yet.
+func (r Recurrence) Occurrences(now time.Time) (latest, next time.Time, ok bool, err error) {
+	months, days, err := r.interval()
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	start, err := r.start()
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if today.Before(start) {
+		return time.Time{}, start, false, nil
+	}
+
+	// Step from the start each time so monthly schedules keep their day,
+	// clamped to the end of shorter months (Jan 31 -> Feb 28 -> Mar 31)
+	at := func(n int) time.Time {
+		if months == 0 {
+			return start.AddDate(0, 0, n*days)
+		}
+		first := time.Date(start.Year(), start.Month()+time.Month(n*months), 1, 0, 0, 0, 0, time.UTC)
+		lastDay := first.AddDate(0, 1, -1).Day()
+		return first.AddDate(0, 0, min(start.Day(), lastDay)-1)
+	}
+	n := 0
+	if days > 0 && months == 0 {
+		n = int(today.Sub(start).Hours()/24) / days
+	} else {
+		n = (today.Year()-start.Year())*12 + int(today.Month()-start.Month())
+		n /= months
+		for n > 0 && at(n).After(today) {
+			n--
+		}
+	}
+	return at(n), at(n + 1), true, nil
+}
+
+// Validate checks that the template can be instantiated
+func (t Template) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if strings.TrimSpace(t.Title) == "" {
+		return fmt.Errorf("template %q: title cannot be empty", t.Name)
+	}
+	if t.Type != "" && !model.IssueType(t.Type).IsValid() {
+		return fmt.Errorf("template %q: invalid type %q", t.Name, t.Type)
+	}
+	if t.Priority != nil && (*t.Priority < 0 || *t.Priority > 4) {
+		return fmt.Errorf("template %q: priority must be between 0 and 4", t.Name)
+	}
+	if t.Recurrence != nil {
+		if err := t.Recurrence.Validate(); err != nil {
+			return fmt.Errorf("template %q: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// RenderTitle expands the title pattern for a date. Supported placeholders:
+// {{date}} (2025-01-31), {{year}}, {{month}} (01), {{day}} (31) and
+// {{week}} (ISO week, e.g. W05).
+func (t Template) RenderTitle(at time.Time) string {
+	_, week := at.ISOWeek()
+	return strings.NewReplacer(
+		"{{date}}", at.Format("2006-01-02"),
+		"{{year}}", at.Format("2006"),
+		"{{month}}", at.Format("01"),
+		"{{day}}", at.Format("02"),
+		"{{week}}", fmt.Sprintf("W%02d", week),
+	).Replace(t.Title)
+}
+
+// Body returns the issue description with the checklist appended
+func (t Template) Body() string {
+	body := strings.TrimRight(t.Description, "\n")
+	if len(t.Checklist) == 0 {
+		return body
+	}
+	var sb strings.Builder
+	sb.WriteString(body)
+	if body != "" {
+		sb.WriteString("\n\n")
+	}
+	for i, item := range t.Checklist {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("- [ ] ")
+		sb.WriteString(item)
+	}
+	return sb.String()
+}
+
+// ExternalRef identifies the instance of a template created for an
+// occurrence, so a schedule never materializes the same date twice
+func ExternalRef(name string, occurrence time.Time) string {
+	return fmt.Sprintf("template:%s@%s", name, occurrence.Format("2006-01-02"))
+}
+
+// Instantiate builds a new open issue from the template. occurrence dates
+// the title placeholders; a zero occurrence means an ad-hoc instance.
+func (t Template) Instantiate(id string, occurrence, now time.Time) model.Issue {
+	at := occurrence
+	if at.IsZero() {
+		at = now
+	}
+	issue := model.Issue{
+		ID:          id,
+		Title:       t.RenderTitle(at),
+		Description: t.Body(),
+		Status:      model.StatusOpen,
+		Priority:    2,
+		IssueType:   model.TypeTask,
+		Assignee:    t.Assignee,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if t.Priority != nil {
+		issue.Priority = *t.Priority
+	}
+	if t.Type != "" {
+		issue.IssueType = model.IssueType(t.Type)
+	}
+	if len(t.Labels) > 0 {
+		issue.Labels = append([]string(nil), t.Labels...)
+	}
+	if !occurrence.IsZero() {
+		ref := ExternalRef(t.Name, occurrence)
+		issue.ExternalRef = &ref
+		if t.Recurrence != nil && t.Recurrence.DueAfterDays > 0 {
+			due := occurrence.AddDate(0, 0, t.Recurrence.DueAfterDays)
+			issue.DueDate = &due
+		}
+	}
+	return issue
+}
+
+// Dir returns the templates directory for a beads directory
+func Dir(beadsDir string) string {
+	return filepath.Join(beadsDir, DirName)
+}
+
+// Load reads every *.yaml/*.yml template in dir, sorted by name. A missing
+// directory yields no templates; malformed files are skipped and reported
+// as warnings.
+func Load(dir string) ([]Template, []string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("reading templates directory: %w", err)
+	}
+
+	var templates []Template
+	var warnings []string
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", entry.Name(), err))
+			continue
+		}
+		var t Template
+		if err := yaml.Unmarshal(data, &t); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: parsing template: %v", entry.Name(), err))
+			continue
+		}
+		if t.Name == "" {
+			t.Name = strings.TrimSuffix(entry.Name(), ext)
+		}
+		t.Path = path
+		if err := t.Validate(); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", entry.Name(), err))
+			continue
+		}
+		if other, dup := seen[t.Name]; dup {
+			warnings = append(warnings, fmt.Sprintf("%s: template %q already defined in %s", entry.Name(), t.Name, other))
+			continue
+		}
+		seen[t.Name] = entry.Name()
+		templates = append(templates, t)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, warnings, nil
+}
+
+// Occurrence is a scheduled instance of a recurring template
+type Occurrence struct {
+	Template string    `json:"template"`
+	Date     time.Time `json:"date"`
+	Next     time.Time `json:"next"`
+	IssueID  string    `json:"issue_id,omitempty"` // Instance for Date, when materialized
+	Created  bool      `json:"created,omitempty"`  // Materialized by this run
+}
+
+// Schedule returns the latest occurrence of every recurring template that
+// has started. Occurrences already materialized carry the existing issue ID.
+// Missed earlier occurrences are not caught up: only the latest one counts.
+func Schedule(templates []Template, issues []model.Issue, now time.Time) ([]Occurrence, error) {
+	existing := make(map[string]string)
+	for _, issue := range issues {
+		if issue.ExternalRef != nil && strings.HasPrefix(*issue.ExternalRef, "template:") {
+			existing[*issue.ExternalRef] = issue.ID
+		}
+	}
+
+	var occurrences []Occurrence
+	for _, t := range templates {
+		if t.Recurrence == nil {
+			continue
+		}
+		latest, next, ok, err := t.Recurrence.Occurrences(now)
+		if err != nil {
+			return nil, fmt.Errorf("template %q: %w", t.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		occurrences = append(occurrences, Occurrence{
+			Template: t.Name,
+			Date:     latest,
+			Next:     next,
+			IssueID:  existing[ExternalRef(t.Name, latest)],
+		})
+	}
+	return occurrences, nil
+}
+
+// Materialize creates an issue for every scheduled occurrence that has no
+// instance yet. It returns the new issues, which the caller writes, and the
+// schedule with each occurrence's issue ID filled in.
+func Materialize(templates []Template, issues []model.Issue, now time.Time, fallbackPrefix string) ([]model.Issue, []Occurrence, error) {
+	schedule, err := Schedule(templates, issues, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := make(map[string]Template, len(templates))
+	for _, t := range templates {
+		byName[t.Name] = t
+	}
+
+	known := append([]model.Issue(nil), issues...)
+	var created []model.Issue
+	for i, occ := range schedule {
+		if occ.IssueID != "" {
+			continue
+		}
+		issue := byName[occ.Template].Instantiate(loader.NewIssueID(known, fallbackPrefix), occ.Date, now)
+		known = append(known, issue)
+		created = append(created, issue)
+		schedule[i].IssueID = issue.ID
+		schedule[i].Created = true
+	}
+	return created, schedule, nil
+}
+
+// RobotRecurOutput is the JSON output structure for --robot-recur
+type RobotRecurOutput struct {
+	GeneratedAt   string       `json:"generated_at"`
+	DataHash      string       `json:"data_hash"`
+	DryRun        bool         `json:"dry_run"`
+	TemplatesDir  string       `json:"templates_dir"`
+	TemplateCount int          `json:"template_count"`
+	CreatedCount  int          `json:"created_count"`
+	Created       []CreatedRef `json:"created"`
+	Schedule      []Occurrence `json:"schedule"`
+	Warnings      []string     `json:"warnings,omitempty"`
+	UsageHints    []string     `json:"usage_hints"`
+}
+
+// CreatedRef describes an issue materialized from a schedule
+type CreatedRef struct {
+	ID       string     `json:"id"`
+	Title    string     `json:"title"`
+	Template string     `json:"template"`
+	Date     time.Time  `json:"date"`
+	DueDate  *time.Time `json:"due_date,omitempty"`
+}
@@ -0,0 +1,158 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if tmpls, warnings, err := Load(filepath.Join(t.TempDir(), "missing")); err != nil || tmpls != nil || warnings != nil {
+		t.Fatalf("missing directory should yield nothing, got %v %v %v", tmpls, warnings, err)
+	}
+
+	dir := t.TempDir()
+	writeTemplate(t, dir, "weekly.yaml", `
+title: "Weekly review {{week}}"
+type: chore
+priority: 0
+labels: [review]
+recurrence:
+  every: weekly
+  start: 2025-01-06
+`)
+	writeTemplate(t, dir, "bug.yml", "name: a-bug\ntitle: Bug report\ntype: bug\n")
+	writeTemplate(t, dir, "broken.yaml", "title: [unclosed\n")
+	writeTemplate(t, dir, "badtype.yaml", "title: X\ntype: saga\n")
+	writeTemplate(t, dir, "badrecur.yaml", "title: X\nrecurrence:\n  every: fortnightly\n  start: 2025-01-01\n")
+	writeTemplate(t, dir, "notes.txt", "ignored")
+
+	tmpls, warnings, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmpls) != 2 || tmpls[0].Name != "a-bug" || tmpls[1].Name != "weekly" {
+		t.Fatalf("expected a-bug and weekly sorted by name, got %+v", tmpls)
+	}
+	if *tmpls[1].Priority != 0 || tmpls[1].Path != filepath.Join(dir, "weekly.yaml") {
+		t.Errorf("unexpected weekly template %+v", tmpls[1])
+	}
+	if len(warnings) != 3 {
+		t.Errorf("expected 3 warnings, got %v", warnings)
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	p := 1
+	tmpl := Template{
+		Name:        "release",
+		Title:       "Release {{year}}-{{month}} ({{week}}, {{date}})",
+		Type:        "feature",
+		Priority:    &p,
+		Labels:      []string{"release"},
+		Description: "Ship it.\n",
+		Checklist:   []string{"Tag", "Announce"},
+		Recurrence:  &Recurrence{Every: "monthly", Start: "2025-01-31", DueAfterDays: 3},
+	}
+	now := time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC)
+	occurrence := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	issue := tmpl.Instantiate("bv-x1", occurrence, now)
+	if issue.Title != "Release 2025-02 (W06, 2025-02-03)" {
+		t.Errorf("unexpected title %q", issue.Title)
+	}
+	if issue.Description != "Ship it.\n\n- [ ] Tag\n- [ ] Announce" {
+		t.Errorf("unexpected body %q", issue.Description)
+	}
+	if issue.Priority != 1 || issue.IssueType != model.TypeFeature || issue.Status != model.StatusOpen {
+		t.Errorf("unexpected fields %+v", issue)
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != "template:release@2025-02-03" {
+		t.Errorf("unexpected external_ref %v", issue.ExternalRef)
+	}
+	if issue.DueDate == nil || !issue.DueDate.Equal(occurrence.AddDate(0, 0, 3)) {
+		t.Errorf("unexpected due date %v", issue.DueDate)
+	}
+
+	adhoc := Template{Name: "plain", Title: "Plain {{date}}"}.Instantiate("bv-x2", time.Time{}, now)
+	if adhoc.Title != "Plain 2025-03-05" || adhoc.ExternalRef != nil || adhoc.Priority != 2 || adhoc.IssueType != model.TypeTask {
+		t.Errorf("ad-hoc instance should use defaults and today's date, got %+v", adhoc)
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	now := time.Date(2025, 5, 20, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		every, start string
+		latest, next string
+		ok           bool
+	}{
+		{"weekly", "2025-01-06", "2025-05-19", "2025-05-26", true},
+		{"3d", "2025-05-20", "2025-05-20", "2025-05-23", true},
+		{"monthly", "2025-01-31", "2025-04-30", "2025-05-31", true},
+		{"monthly", "2025-01-15", "2025-05-15", "2025-06-15", true},
+		{"2m", "2025-01-25", "2025-03-25", "2025-05-25", true},
+		{"daily", "2025-06-01", "", "2025-06-01", false},
+	}
+	for _, tt := range tests {
+		latest, next, ok, err := Recurrence{Every: tt.every, Start: tt.start}.Occurrences(now)
+		if err != nil {
+			t.Fatalf("%s from %s: %v", tt.every, tt.start, err)
+		}
+		got := func(ts time.Time) string {
+			if ts.IsZero() {
+				return ""
+			}
+			return ts.Format("2006-01-02")
+		}
+		if ok != tt.ok || got(latest) != tt.latest || got(next) != tt.next {
+			t.Errorf("%s from %s: got %s/%s/%v, want %s/%s/%v",
+				tt.every, tt.start, got(latest), got(next), ok, tt.latest, tt.next, tt.ok)
+		}
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	now := time.Date(2025, 5, 20, 15, 0, 0, 0, time.UTC)
+	tmpls := []Template{
+		{Name: "adhoc", Title: "No schedule"},
+		{Name: "daily", Title: "Standup {{date}}", Recurrence: &Recurrence{Every: "daily", Start: "2025-05-01"}},
+		{Name: "weekly", Title: "Review {{week}}", Recurrence: &Recurrence{Every: "weekly", Start: "2025-01-06"}},
+	}
+	existingRef := "template:weekly@2025-05-19"
+	issues := []model.Issue{
+		{ID: "bv-a1", Title: "Other"},
+		{ID: "bv-b2", Title: "Review W21", ExternalRef: &existingRef},
+	}
+
+	created, schedule, err := Materialize(tmpls, issues, now, "fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0].Title != "Standup 2025-05-20" || !strings.HasPrefix(created[0].ID, "bv-") {
+		t.Fatalf("expected one standup with the bv prefix, got %+v", created)
+	}
+	if len(schedule) != 2 || !schedule[0].Created || schedule[0].IssueID != created[0].ID {
+		t.Errorf("daily occurrence should be marked created, got %+v", schedule)
+	}
+	if schedule[1].Created || schedule[1].IssueID != "bv-b2" {
+		t.Errorf("weekly occurrence already exists as bv-b2, got %+v", schedule[1])
+	}
+
+	// A second run is a no-op
+	again, _, err := Materialize(tmpls, append(issues, created...), now, "fallback")
+	if err != nil || len(again) != 0 {
+		t.Errorf("second run should create nothing, got %+v %v", again, err)
+	}
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/x/ansi"
+	"gopkg.in/yaml.v3"
+)
+
+// DisplayConfigFilename is the user config for display preferences, kept
+// next to ui-state.json.
+const DisplayConfigFilename = "display.yaml"
+
+// DisplayConfig holds display preferences that apply to every project.
+type DisplayConfig struct {
+	// Accessible turns on the screen-reader friendly mode, as --accessible
+	Accessible bool `yaml:"accessible"`
+	// ASCII forces the ASCII-only rendering on or off, as --ascii. Unset
+	// leaves it to UnicodeUnsupported.
+	ASCII *bool `yaml:"ascii"`
+}
+
+// DisplayConfigPath returns the path to the display config file.
+func DisplayConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "bv", DisplayConfigFilename)
+}
+
+// LoadDisplayConfig reads the display config at path. A missing file gives
+// the defaults.
+func LoadDisplayConfig(path string) (*DisplayConfig, error) {
+	config := &DisplayConfig{}
+	if path == "" {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("reading display config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing display config: %w", err)
+	}
+	return config, nil
+}
+
+// SetAccessible switches the screen-reader friendly mode. The list shows
+// plain words instead of icons and badges, the split view and toasts are
+// replaced by a single pane, and the footer becomes one plain status line
+// that describes the selected issue whenever nothing else is reported.
+func (m *Model) SetAccessible(on bool) {
+	m.accessible = on
+	m.updateListDelegate()
+	if m.ready {
+		m.resizePanes()
+	}
+}
+
+// accessibleRow renders a list row in plain words, e.g.
+// "> bv-12 bug P1 open: Fix login crash, quick win, 2 comments"
+func accessibleRow(i IssueItem, selected bool, width int) string {
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+	row := prefix + i.Issue.ID + " " + accessibleIssueTags(i) + ": " + i.Issue.Title
+	if extras := accessibleIssueExtras(i); len(extras) > 0 {
+		row += ", " + strings.Join(extras, ", ")
+	}
+	return ansi.Truncate(row, width, "…")
+}
+
+// accessibleIssueTags describes type, priority and status in words
+func accessibleIssueTags(i IssueItem) string {
+	return fmt.Sprintf("%s P%d %s", i.Issue.IssueType, i.Issue.Priority, strings.ReplaceAll(string(i.Issue.Status), "_", " "))
+}
+
+// accessibleIssueExtras lists the indicators the regular row shows as glyphs
+func accessibleIssueExtras(i IssueItem) []string {
+	var extras []string
+	switch i.DiffStatus {
+	case DiffStatusNew:
+		extras = append(extras, "new since snapshot")
+	case DiffStatusClosed:
+		extras = append(extras, "closed since snapshot")
+	case DiffStatusModified:
+		extras = append(extras, "modified since snapshot")
+	}
+	if i.IsQuickWin {
+		extras = append(extras, "quick win")
+	}
+	if i.UnblocksCount > 0 {
+		extras = append(extras, fmt.Sprintf("unblocks %d", i.UnblocksCount))
+	}
+	if i.Issue.Assignee != "" {
+		extras = append(extras, "assigned to "+i.Issue.Assignee)
+	}
+	if n := len(i.Issue.Comments); n == 1 {
+		extras = append(extras, "1 comment")
+	} else if n > 1 {
+		extras = append(extras, fmt.Sprintf("%d comments", n))
+	}
+	return extras
+}
+
+// accessibleSelection describes the selected issue for the status line,
+// e.g. "3 of 40: bv-12 bug P1 open: Fix login crash"
+func (m Model) accessibleSelection() string {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		if m.list.FilterState() != list.Unfiltered {
+			return "No matching issues"
+		}
+		return "No issues"
+	}
+	return fmt.Sprintf("%d of %d: %s %s: %s", m.list.Index()+1, len(m.list.VisibleItems()),
+		item.Issue.ID, accessibleIssueTags(item), item.Issue.Title)
+}
+
+// renderAccessibleFooter renders the footer as one plain line: the status
+// message, else the newest notification, else the selected issue
+func (m Model) renderAccessibleFooter() string {
+	var line string
+	switch active := m.toasts.Active(); {
+	case m.statusMsg != "" && m.statusIsError:
+		line = "Error: " + m.statusMsg
+	case m.statusMsg != "":
+		line = m.statusMsg
+	case len(active) > 0:
+		toast := active[len(active)-1]
+		line = toast.Level.label() + ": " + toast.Message
+	default:
+		line = m.accessibleSelection()
+	}
+	if m.pastSnapshot != nil {
+		line = "Viewing past " + m.pastSnapshot.ShortSHA + ", read-only. " + line
+	}
+	return ansi.Truncate(line, m.width, "…")
+}
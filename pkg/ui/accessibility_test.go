@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLoadDisplayConfig(t *testing.T) {
+	dir := t.TempDir()
+	config, err := LoadDisplayConfig(filepath.Join(dir, "missing.yaml"))
+	if err != nil || config.Accessible {
+		t.Fatalf("expected defaults for a missing file, got %+v, %v", config, err)
+	}
+
+	path := filepath.Join(dir, DisplayConfigFilename)
+	if err := os.WriteFile(path, []byte("accessible: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if config, err := LoadDisplayConfig(path); err != nil || !config.Accessible {
+		t.Errorf("expected accessible mode, got %+v, %v", config, err)
+	}
+
+	if err := os.WriteFile(path, []byte("accessible: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDisplayConfig(path); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestAccessibleMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Fix login crash", Status: model.StatusInProgress, Priority: 1, IssueType: model.TypeBug, Assignee: "sam"},
+		{ID: "bv-2", Title: "Dark mode", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeFeature},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetAccessible(true)
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	m = tm.(Model)
+
+	if m.isSplitView {
+		t.Fatal("accessible mode should not split the screen")
+	}
+	view := m.View()
+	for _, want := range []string{
+		"> bv-1 bug P1 in progress: Fix login crash, quick win, assigned to sam",
+		"  bv-2 feature P2 open: Dark mode",
+		"1 of 2: bv-1 bug P1 in progress: Fix login crash",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("accessible view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "▸") {
+		t.Error("accessible rows should not use glyphs")
+	}
+
+	// Moving the selection is announced on the status line
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = tm.(Model)
+	if footer := m.renderFooter(); footer != "2 of 2: bv-2 feature P2 open: Dark mode" {
+		t.Errorf("unexpected status line %q", footer)
+	}
+
+	// Notifications are read out on the status line instead of overlaid
+	m.toasts.Push(ToastWarning, "Beads file reloaded")
+	if footer := m.renderFooter(); footer != "Warning: Beads file reloaded" {
+		t.Errorf("unexpected status line %q", footer)
+	}
+	m.statusMsg, m.statusIsError = "Copy failed", true
+	if footer := m.renderFooter(); footer != "Error: Copy failed" {
+		t.Errorf("unexpected status line %q", footer)
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// asciiGlyphs maps the single-cell glyphs the views draw with to ASCII
+// stand-ins of the same width, so layouts computed with the Unicode glyphs
+// still line up. Emoji take two cells and are left alone.
+var asciiGlyphs = map[rune]rune{
+	// Sparkline levels, lowest to highest
+	'▁': '_', '▂': '.', '▃': ':', '▄': '-', '▅': '=', '▆': '+', '▇': '*', '█': '#',
+	// Progress bar fill and track
+	'▉': '#', '▊': '#', '▋': '#', '▌': '#', '▍': '#', '▎': '#', '▏': '|',
+	'▐': '#', '▀': '#', '▔': '-', '▕': '|',
+	'▓': '#', '▒': ':', '░': '.',
+	// Markers and arrows
+	'•': '*', '·': '.', '●': '*', '○': 'o', '◉': '@', '◆': '*', '◈': '*', '◇': 'o', '■': '#', '□': 'o',
+	'▸': '>', '►': '>', '▶': '>', '›': '>', '◂': '<', '◀': '<', '‹': '<',
+	'▲': '^', '▴': '^', '▼': 'v', '▾': 'v',
+	'→': '>', '←': '<', '↑': '^', '↓': 'v', '↔': '-', '↕': '|', '↳': '>', '↻': '~', '⇄': '=',
+	'…': '.', '—': '-', '–': '-', '✓': '+', '✔': '+', '✗': 'x', '✘': 'x', '×': 'x',
+}
+
+// toASCIIRune swaps one glyph for its ASCII stand-in
+func toASCIIRune(r rune) rune {
+	if r < 0x80 {
+		return r
+	}
+	if a, ok := asciiGlyphs[r]; ok {
+		return a
+	}
+	switch {
+	case r >= 0x2500 && r <= 0x257F: // Box drawing
+		switch r {
+		case '─', '━', '┄', '┅', '┈', '┉', '╌', '╍', '═', '╴', '╶', '╸', '╺', '╼', '╾':
+			return '-'
+		case '│', '┃', '┆', '┇', '┊', '┋', '╎', '╏', '║', '╵', '╷', '╹', '╻', '╽', '╿':
+			return '|'
+		case '╱':
+			return '/'
+		case '╲':
+			return '\\'
+		}
+		return '+' // Corners, tees and crossings
+	case r >= 0x2580 && r <= 0x259F: // Remaining block elements
+		return '#'
+	case r >= 0x2800 && r <= 0x28FF: // Braille spinner frames
+		return '*'
+	}
+	return r
+}
+
+// ToASCII replaces box-drawing, progress bar, sparkline and marker glyphs in
+// s with ASCII. ANSI styling is untouched and the display width is kept.
+func ToASCII(s string) string {
+	return strings.Map(toASCIIRune, s)
+}
+
+// UnicodeUnsupported guesses whether the terminal cannot show the Unicode
+// glyphs: the locale is set and is not UTF-8, or TERM names a console known
+// to lack them. An unset locale is taken as fine, as most terminals are.
+func UnicodeUnsupported() bool {
+	switch term := os.Getenv("TERM"); {
+	case term == "linux", term == "dumb", strings.HasPrefix(term, "vt"):
+		return true
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := strings.ToLower(os.Getenv(key)); v != "" {
+			return !strings.Contains(v, "utf-8") && !strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// SetASCII switches the ASCII-only rendering: every frame has its
+// box-drawing, progress bar, sparkline and marker glyphs swapped for ASCII.
+func (m *Model) SetASCII(on bool) {
+	m.ascii = on
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestToASCII(t *testing.T) {
+	tests := map[string]string{
+		"╭──╮\n│ok│\n╰──╯": "+--+\n|ok|\n+--+",
+		"██▓▒░":            "###:.",
+		"▁▂▃▄▅▆▇█":         "_.:-=+*#",
+		"▸ bv-1 → bv-2 …":  "> bv-1 > bv-2 .",
+		"🐛 bug":            "🐛 bug",
+	}
+	for in, want := range tests {
+		got := ToASCII(in)
+		if got != want {
+			t.Errorf("ToASCII(%q) = %q, want %q", in, got, want)
+		}
+		if lipgloss.Width(got) != lipgloss.Width(in) {
+			t.Errorf("ToASCII(%q) changed the width", in)
+		}
+	}
+}
+
+func TestUnicodeUnsupported(t *testing.T) {
+	tests := []struct {
+		term, lcAll, lang string
+		want              bool
+	}{
+		{"xterm-256color", "", "en_US.UTF-8", false},
+		{"xterm-256color", "", "", false},
+		{"xterm-256color", "", "C", true},
+		{"xterm-256color", "C.utf8", "C", false},
+		{"xterm-256color", "POSIX", "en_US.UTF-8", true},
+		{"linux", "", "en_US.UTF-8", true},
+		{"vt100", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("TERM", tt.term)
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_CTYPE", "")
+		t.Setenv("LANG", tt.lang)
+		if got := UnicodeUnsupported(); got != tt.want {
+			t.Errorf("TERM=%q LC_ALL=%q LANG=%q: got %v, want %v", tt.term, tt.lcAll, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Fix login crash", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeBug},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetASCII(true)
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	m = tm.(Model)
+
+	for name, view := range map[string]string{
+		"split view": m.View(),
+		"board":      viewAfter(m, runeKey("b")),
+		"tutorial":   viewAfter(m, runeKey("`")),
+	} {
+		for _, r := range view {
+			if (r >= 0x2500 && r <= 0x259F) || (r >= 0x2800 && r <= 0x28FF) {
+				t.Errorf("%s: glyph %q left in ASCII mode", name, r)
+				break
+			}
+		}
+		if !strings.Contains(view, "bv-1") && name != "tutorial" {
+			t.Errorf("%s: issue missing from view", name)
+		}
+	}
+}
+
+func viewAfter(m Model, msg tea.Msg) string {
+	tm, _ := m.Update(msg)
+	return tm.(Model).View()
+}
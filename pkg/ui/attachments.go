@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// attachmentTarget returns what to hand to the browser for a: links as is,
+// and file paths made absolute against the project root
+func attachmentTarget(a model.Attachment, projectDir string) string {
+	if !a.IsFile() || strings.HasPrefix(a.URL, "file:") || filepath.IsAbs(a.URL) || projectDir == "" {
+		return a.URL
+	}
+	return filepath.Join(projectDir, a.URL)
+}
+
+// browserAvailable reports whether links can be opened here. Without a
+// display (SSH sessions, containers) links are printed instead.
+func browserAvailable() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	case "linux":
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return false
+		}
+		_, err := exec.LookPath("xdg-open")
+		return err == nil
+	}
+	return false
+}
+
+// renderAttachmentsMD lists the issue's attachments for the detail view,
+// marking the selected one with ▸
+func renderAttachmentsMD(attachments []*model.Attachment, selected int) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### 📎 Attachments\n")
+	for i, a := range attachments {
+		marker := "-"
+		if i == selected {
+			marker = "- ▸"
+		}
+		icon := "🔗"
+		if a.IsFile() {
+			icon = "📄"
+		}
+		if a.Title != "" {
+			sb.WriteString(fmt.Sprintf("%s %s **%s** `%s`\n", marker, icon, a.Title, a.URL))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s %s `%s`\n", marker, icon, a.URL))
+		}
+	}
+	sb.WriteString("\n*u/U select · o open*\n\n")
+	return sb.String()
+}
+
+// selectedAttachment returns the attachment under the cursor in the detail
+// view, or nil when the issue has none
+func (m *Model) selectedAttachment() *model.Attachment {
+	issue := m.issueMap[m.selectedIssueID()]
+	if issue == nil || len(issue.Attachments) == 0 {
+		return nil
+	}
+	if m.attachmentIdx < 0 || m.attachmentIdx >= len(issue.Attachments) {
+		m.attachmentIdx = 0
+	}
+	return issue.Attachments[m.attachmentIdx]
+}
+
+// cycleAttachment moves the attachment cursor and shows the target
+func (m *Model) cycleAttachment(delta int) {
+	issue := m.issueMap[m.selectedIssueID()]
+	if issue == nil || len(issue.Attachments) == 0 {
+		return
+	}
+	n := len(issue.Attachments)
+	m.attachmentIdx = ((m.attachmentIdx+delta)%n + n) % n
+	m.updateViewportContent()
+	a := issue.Attachments[m.attachmentIdx]
+	m.statusMsg = fmt.Sprintf("📎 %d/%d %s — @ open · u/U next", m.attachmentIdx+1, n, a.Label())
+	m.statusIsError = false
+}
+
+// openAttachment opens the selected attachment in the browser, or prints
+// its target in the status bar when no browser is available
+func (m *Model) openAttachment() {
+	a := m.selectedAttachment()
+	if a == nil {
+		m.statusMsg = "No attachments on this issue"
+		m.statusIsError = true
+		return
+	}
+	projectDir, _ := historyRepoPath(m.beadsPath)
+	target := attachmentTarget(*a, projectDir)
+	if !browserAvailable() {
+		m.statusMsg = fmt.Sprintf("🔗 %s", target)
+		m.statusIsError = false
+		return
+	}
+	if err := openBrowserURL(target); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not open browser: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("🌐 Opened %s", a.Label())
+	m.statusIsError = false
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openAttentionDigest computes the digest from the loaded issues and shows it
+func (m *Model) openAttentionDigest() {
+	m.attentionDigest = analysis.ComputeAttentionDigest(m.issues, analysis.DefaultDigestConfig(), time.Now())
+	m.digestCursor = 0
+	m.showDigestPanel = true
+}
+
+// handleDigestPanelKeys handles keys while the attention digest is open
+func (m Model) handleDigestPanelKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	s := msg.String()
+	switch {
+	case s == "ctrl+c":
+		return m, tea.Quit
+	case s == "esc" || s == "q" || s == "A":
+		m.showDigestPanel = false
+	case s == "j" || s == "down":
+		if m.digestCursor < len(m.attentionDigest)-1 {
+			m.digestCursor++
+		}
+	case s == "k" || s == "up":
+		if m.digestCursor > 0 {
+			m.digestCursor--
+		}
+	case s == "enter":
+		m.jumpToDigestItem(m.digestCursor)
+	case len(s) == 1 && s[0] >= '1' && s[0] <= '9':
+		m.jumpToDigestItem(int(s[0] - '1'))
+	}
+	return m, nil
+}
+
+// jumpToDigestItem closes the digest and shows the issue of entry idx
+func (m *Model) jumpToDigestItem(idx int) {
+	if idx < 0 || idx >= len(m.attentionDigest) {
+		return
+	}
+	id := m.attentionDigest[idx].IssueID
+	m.showDigestPanel = false
+	if !m.showIssueDetails(id) {
+		m.statusMsg = fmt.Sprintf("❌ %s is not in the list", id)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("👀 %s: %s", id, m.attentionDigest[idx].Reason)
+	m.statusIsError = false
+}
+
+// digestKindHeading returns the section heading and icon for a digest kind
+func digestKindHeading(kind analysis.DigestKind) (string, string) {
+	switch kind {
+	case analysis.DigestPriorityInversion:
+		return "Priority inversions", "⇅"
+	case analysis.DigestLongBlocked:
+		return "Long blocked", "⛔"
+	default:
+		return "Stale in progress", "💤"
+	}
+}
+
+// renderDigestPanel renders the attention digest modal
+func (m Model) renderDigestPanel() string {
+	t := m.theme
+	width := min(84, m.width-4)
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		MaxHeight(m.height - 4)
+
+	titleStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Primary)
+	headingStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	reasonStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("👀 Attention Digest"))
+	sb.WriteString("\n\n")
+
+	if len(m.attentionDigest) == 0 {
+		sb.WriteString(t.Renderer.NewStyle().Foreground(ColorSuccess).Render("✓ Nothing stale, long blocked, or inverted"))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor visible on short terminals
+	maxRows := m.height - 14
+	if maxRows < 5 {
+		maxRows = 5
+	}
+	start := 0
+	if m.digestCursor >= maxRows {
+		start = m.digestCursor - maxRows + 1
+	}
+
+	var lastKind analysis.DigestKind
+	for i, item := range m.attentionDigest {
+		if item.Kind != lastKind {
+			lastKind = item.Kind
+			if i >= start && i < start+maxRows {
+				heading, _ := digestKindHeading(item.Kind)
+				count := 0
+				for _, other := range m.attentionDigest {
+					if other.Kind == item.Kind {
+						count++
+					}
+				}
+				if i > start {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(headingStyle.Render(fmt.Sprintf("%s (%d)", heading, count)))
+				sb.WriteString("\n")
+			}
+		}
+		if i < start || i >= start+maxRows {
+			continue
+		}
+
+		_, icon := digestKindHeading(item.Kind)
+		cursor := "  "
+		if i == m.digestCursor {
+			cursor = "▸ "
+		}
+		shortcut := "  "
+		if i < 9 {
+			shortcut = fmt.Sprintf("%d ", i+1)
+		}
+		head := fmt.Sprintf("%s%s%s P%d %s ", cursor, shortcut, icon, item.Priority, item.IssueID)
+		titleWidth := width - 6 - lipgloss.Width(head)
+		line := head + truncateRunesHelper(item.Title, max(titleWidth, 10), "…")
+		if i == m.digestCursor {
+			line = t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+		sb.WriteString(reasonStyle.Render("       " + item.Reason))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
+		"j/k: navigate • Enter/1-9: jump to issue • Esc: close"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(sb.String()),
+	)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAttentionDigestPanel(t *testing.T) {
+	old := time.Now().Add(-20 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "low", Title: "Cleanup", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeChore, UpdatedAt: time.Now()},
+		{ID: "urgent", Title: "Outage", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug, UpdatedAt: time.Now(),
+			Dependencies: []*model.Dependency{{IssueID: "urgent", DependsOnID: "low", Type: model.DepBlocks}}},
+		{ID: "slow", Title: "Slow work", Status: model.StatusInProgress, Priority: 2, IssueType: model.TypeTask, UpdatedAt: old},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 100, 40
+	m.resizePanes()
+
+	key := func(k string) {
+		tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = tm.(Model)
+	}
+
+	key("A")
+	if !m.showDigestPanel || len(m.attentionDigest) != 2 {
+		t.Fatalf("A should open the digest with 2 items, got %+v", m.attentionDigest)
+	}
+	view := m.View()
+	for _, want := range []string{"Attention Digest", "inversions", "P3 blocks P0 urgent", "no update for 20d"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("digest view missing %q", want)
+		}
+	}
+
+	// j moves within the panel instead of the list; 2 jumps to the stale item
+	key("j")
+	if m.digestCursor != 1 {
+		t.Fatalf("j should move the digest cursor, got %d", m.digestCursor)
+	}
+	key("2")
+	if m.showDigestPanel || m.selectedIssueID() != "slow" || m.focused != focusDetail {
+		t.Fatalf("2 should jump to slow, got %q focus=%v", m.selectedIssueID(), m.focused)
+	}
+	if !strings.Contains(m.statusMsg, "no update") {
+		t.Errorf("status should carry the reason, got %q", m.statusMsg)
+	}
+}
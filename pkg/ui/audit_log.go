@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/audit"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// recordAudit appends a write made from the TUI to .bv/audit.jsonl. A
+// failure to record is reported but does not undo the write.
+func (m *Model) recordAudit(e audit.Entry) tea.Cmd {
+	if m.workDir == "" {
+		return nil
+	}
+	e.Source = audit.SourceTUI
+	if err := audit.Append(m.workDir, e); err != nil {
+		return m.toasts.Push(ToastError, fmt.Sprintf("Audit log not written: %v", err))
+	}
+	return nil
+}
+
+// showAuditLogModal loads the audit log, newest first, and opens it
+func (m *Model) showAuditLogModal() {
+	if m.workDir == "" {
+		m.statusMsg = "❌ No project directory for the audit log"
+		m.statusIsError = true
+		return
+	}
+	entries, err := audit.Load(m.workDir)
+	if err != nil {
+		m.statusMsg = "❌ " + err.Error()
+		m.statusIsError = true
+		return
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	m.auditEntries = entries
+	m.auditScroll = 0
+	m.showAuditLog = true
+}
+
+// handleAuditLogKeys handles keys while the audit log is open
+func (m Model) handleAuditLogKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	maxScroll := len(m.auditEntries) - m.notificationPageSize()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "j", "down":
+		if m.auditScroll < maxScroll {
+			m.auditScroll++
+		}
+	case "k", "up":
+		if m.auditScroll > 0 {
+			m.auditScroll--
+		}
+	case "g", "home":
+		m.auditScroll = 0
+	case "G", "end":
+		m.auditScroll = maxScroll
+	case "esc", "q", "Y":
+		m.showAuditLog = false
+	}
+	return m, nil
+}
+
+// renderAuditLog renders the audit log modal, one write per row
+func (m Model) renderAuditLog() string {
+	t := m.theme
+	width := min(100, m.width-4)
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	timeStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	actionStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("📜 Audit Log (%d)", len(m.auditEntries))))
+	sb.WriteString("\n\n")
+
+	if len(m.auditEntries) == 0 {
+		sb.WriteString(hintStyle.Render("Nothing written through bv yet"))
+		sb.WriteString("\n")
+	} else {
+		page := m.notificationPageSize()
+		end := min(len(m.auditEntries), m.auditScroll+page)
+		textWidth := width - 34 // padding, time, action
+		for _, e := range m.auditEntries[m.auditScroll:end] {
+			what := strings.Join(e.IssueIDs, ", ")
+			if len(e.Changes) > 0 {
+				if what != "" {
+					what += ": "
+				}
+				what += strings.Join(e.Changes, ", ")
+			}
+			what += " · " + e.Author
+			sb.WriteString(timeStyle.Render(e.Time.Local().Format("2006-01-02 15:04")))
+			sb.WriteString("  ")
+			sb.WriteString(actionStyle.Render(fmt.Sprintf("%-12s", e.Action)))
+			sb.WriteString(truncateRunesHelper(what, textWidth, "…"))
+			sb.WriteString("\n")
+		}
+		if len(m.auditEntries) > page {
+			sb.WriteString(hintStyle.Render(fmt.Sprintf("%d–%d of %d", m.auditScroll+1, end, len(m.auditEntries))))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("Newest first • j/k: scroll • Esc: close"))
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
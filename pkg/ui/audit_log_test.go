@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/audit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAuditLogRecordsWrites(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusOpen},
+		{ID: "B", Title: "Second", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 120, 40
+	m.workDir = t.TempDir()
+	update := func(msg tea.Msg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	update(IssueEditedMsg{IssueID: "A", Changed: []string{"status"}})
+	update(IssueEditedMsg{IssueID: "B"}) // Nothing written
+	update(PrioritiesAppliedMsg{Requested: 2, Applied: []string{"A", "B"}})
+	update(DependenciesSavedMsg{IssueID: "B", Err: errors.New("locked")})
+
+	entries, err := audit.Load(m.workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v, want the edit and the priority apply", entries)
+	}
+	if e := entries[0]; e.Action != audit.ActionEdit || e.IssueIDs[0] != "A" || e.Changes[0] != "status" || e.Source != audit.SourceTUI || e.Author == "" {
+		t.Errorf("edit entry = %+v", e)
+	}
+	if e := entries[1]; e.Action != audit.ActionPriority || strings.Join(e.IssueIDs, ",") != "A,B" {
+		t.Errorf("priority entry = %+v", e)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Y")})
+	if !m.showAuditLog || len(m.auditEntries) != 2 || m.auditEntries[0].Action != audit.ActionPriority {
+		t.Fatalf("Y should open the log newest first, got open=%v %+v", m.showAuditLog, m.auditEntries)
+	}
+	if view := m.renderAuditLog(); !strings.Contains(view, "Audit Log (2)") || !strings.Contains(view, "A: status") {
+		t.Errorf("audit view = %q", view)
+	}
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showAuditLog {
+		t.Error("esc should close the audit log")
+	}
+}
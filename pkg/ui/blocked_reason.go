@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxAnnotatedBlockers is how many blocker IDs a list row names
+const maxAnnotatedBlockers = 2
+
+// computeOpenBlockers maps each unclosed issue to the unclosed issues
+// blocking it. Issues with nothing in their way are left out.
+func computeOpenBlockers(analyzer *analysis.Analyzer, issues []model.Issue) map[string][]string {
+	blockers := make(map[string][]string)
+	if analyzer == nil {
+		return blockers
+	}
+	for _, issue := range issues {
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		if ids := analyzer.GetOpenBlockers(issue.ID); len(ids) > 0 {
+			blockers[issue.ID] = ids
+		}
+	}
+	return blockers
+}
+
+// blockerAnnotation sums up why an issue is blocked, e.g. "⛔ 2: bv-x, bv-y"
+// or "⛔ 3: bv-x, bv-y, …"
+func blockerAnnotation(ids []string) string {
+	shown := ids
+	if len(shown) > maxAnnotatedBlockers {
+		shown = append(shown[:maxAnnotatedBlockers:maxAnnotatedBlockers], "…")
+	}
+	return fmt.Sprintf("⛔ %d: %s", len(ids), strings.Join(shown, ", "))
+}
+
+// openBlockerMenu lists the open blockers of the selected issue so one can
+// be jumped to, the first preselected
+func (m *Model) openBlockerMenu() {
+	id := m.selectedIssueID()
+	if len(m.openBlockers[id]) == 0 {
+		m.statusMsg = fmt.Sprintf("%s has no open blockers", id)
+		m.statusIsError = false
+		return
+	}
+	m.blockerMenuFor = id
+	m.blockerMenuIdx = 0
+	m.showBlockerMenu = true
+}
+
+// handleBlockerMenuKeys handles keys while the blocker menu is open
+func (m Model) handleBlockerMenuKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	blockers := m.openBlockers[m.blockerMenuFor]
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "j", "down":
+		if m.blockerMenuIdx < len(blockers)-1 {
+			m.blockerMenuIdx++
+		}
+	case "k", "up":
+		if m.blockerMenuIdx > 0 {
+			m.blockerMenuIdx--
+		}
+	case "enter", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		idx := m.blockerMenuIdx
+		if key != "enter" {
+			idx = int(key[0] - '1')
+		}
+		if idx >= len(blockers) {
+			return m, nil
+		}
+		m.showBlockerMenu = false
+		if !m.showIssueDetails(blockers[idx]) {
+			m.statusMsg = fmt.Sprintf("❌ %s is not in the list", blockers[idx])
+			m.statusIsError = true
+		}
+	case "esc", "q", "b":
+		m.showBlockerMenu = false
+	}
+	return m, nil
+}
+
+// renderBlockerMenu renders the open blockers of an issue, one per line
+func (m Model) renderBlockerMenu() string {
+	t := m.theme
+	width := min(80, m.width-4)
+	blockers := m.openBlockers[m.blockerMenuFor]
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Blocked).
+		Padding(1, 2).
+		Width(width)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Blocked)
+	idStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	statusStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	selectedStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("⛔ %s is waiting on %d", m.blockerMenuFor, len(blockers))))
+	sb.WriteString("\n\n")
+	for i, id := range blockers {
+		title, status := "", ""
+		if issue, ok := m.issueMap[id]; ok {
+			title, status = issue.Title, string(issue.Status)
+		}
+		marker, rowStyle := "  ", t.Renderer.NewStyle()
+		if i == m.blockerMenuIdx {
+			marker, rowStyle = "▸ ", selectedStyle
+		}
+		num := " "
+		if i < 9 {
+			num = fmt.Sprintf("%d", i+1)
+		}
+		line := fmt.Sprintf("%s%s %s %s ", marker, num, idStyle.Render(id), statusStyle.Render(fmt.Sprintf("[%s]", status)))
+		sb.WriteString(line)
+		sb.WriteString(rowStyle.Render(truncateRunesHelper(title, max(10, width-6-lipgloss.Width(line)), "…")))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("j/k: move • Enter or 1-9: jump to blocker • Esc: close"))
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBlockerAnnotation(t *testing.T) {
+	if got := blockerAnnotation([]string{"bv-x", "bv-y"}); got != "⛔ 2: bv-x, bv-y" {
+		t.Errorf("two blockers = %q", got)
+	}
+	ids := []string{"bv-x", "bv-y", "bv-z"}
+	if got := blockerAnnotation(ids); got != "⛔ 3: bv-x, bv-y, …" {
+		t.Errorf("three blockers = %q", got)
+	}
+	if ids[2] != "bv-z" {
+		t.Error("annotation changed the blocker list")
+	}
+}
+
+func TestBlockerMenu(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	blocks := func(id string) *model.Dependency {
+		return &model.Dependency{IssueID: "bl-1", DependsOnID: id, Type: model.DepBlocks}
+	}
+	issues := []model.Issue{
+		{ID: "bl-1", Title: "Ship release", Status: model.StatusOpen, Priority: 0,
+			Dependencies: []*model.Dependency{blocks("bl-2"), blocks("bl-3"), blocks("bl-4")}},
+		{ID: "bl-2", Title: "Fix migration", Status: model.StatusInProgress, Priority: 1},
+		{ID: "bl-3", Title: "Write changelog", Status: model.StatusOpen, Priority: 2},
+		{ID: "bl-4", Title: "Old blocker", Status: model.StatusClosed, Priority: 3},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	if got := m.openBlockers["bl-1"]; len(got) != 2 {
+		t.Fatalf("open blockers = %v, want bl-2 and bl-3", got)
+	}
+	if view := m.View(); !strings.Contains(view, "⛔ 2: bl-2, bl-3") {
+		t.Error("list row missing the blocked reason")
+	}
+
+	m = pressKeys(m, "tab", "<")
+	if !m.showBlockerMenu || m.blockerMenuFor != "bl-1" {
+		t.Fatalf("< in the detail view: menu open=%v for %q", m.showBlockerMenu, m.blockerMenuFor)
+	}
+	if view := m.View(); !strings.Contains(view, "Write changelog") {
+		t.Error("blocker menu not rendered")
+	}
+	m = pressKeys(m, "2")
+	if m.showBlockerMenu || m.selectedIssueID() != "bl-3" || m.focused != focusDetail {
+		t.Errorf("2: menu open=%v, selected %q", m.showBlockerMenu, m.selectedIssueID())
+	}
+
+	// The blocker is not blocked itself
+	m = pressKeys(m, "<")
+	if m.showBlockerMenu || !strings.Contains(m.statusMsg, "no open blockers") {
+		t.Errorf("< on an unblocked issue: menu open=%v, status %q", m.showBlockerMenu, m.statusMsg)
+	}
+}
+
+func TestBlockerMenuKeymap(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "bl-1", Title: "Ship release", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "bl-1", DependsOnID: "bl-2", Type: model.DepBlocks}}},
+		{ID: "bl-2", Title: "Fix migration", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = pressKeys(updated.(Model), "tab")
+
+	// b stays the board, even with the detail focused
+	if got := pressKeys(m, "b"); got.showBlockerMenu || !got.isBoardView {
+		t.Errorf("b in the detail view: menu open=%v, board=%v", got.showBlockerMenu, got.isBoardView)
+	}
+
+	k, err := LoadKeymap(writeKeymap(t, "detail.blockers: ctrl+b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.keymap = k
+	if got := pressKeys(m, "<"); got.showBlockerMenu {
+		t.Error("< should no longer open the blocker menu")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	if got := updated.(Model); !got.showBlockerMenu || got.blockerMenuFor != "bl-1" {
+		t.Errorf("ctrl+b: menu open=%v for %q", got.showBlockerMenu, got.blockerMenuFor)
+	}
+}
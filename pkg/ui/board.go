@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -56,6 +57,19 @@ type BoardModel struct {
 	// expandedCardID tracks which card is currently expanded inline
 	// Empty string means no card is expanded
 	expandedCardID string
+
+	// Risk heatmap overlay: composite risk per issue, nil when off
+	riskScores map[string]float64
+
+	// Graph metrics and triage scores shown in the detail panel, nil until set
+	graphStats   *analysis.GraphStats
+	triageScores map[string]float64
+
+	// Workspace mode: cards carry a colored repo badge
+	showRepoBadges bool
+
+	// Closed/total children of each epic, counted over all issues
+	epicProgress map[string]epicProgress
 }
 
 // searchMatch holds info about a matching card (bv-yg39)
@@ -72,6 +86,14 @@ const (
 	ColClosed     = 3
 )
 
+// Board column widths
+const (
+	boardMinColWidth  = 28 // Narrowest readable column when several are shown
+	boardTinyColWidth = 16 // Floor for a single column on a tiny terminal
+
+	boardDetailMinWidth = 121 // Narrowest terminal that fits the detail panel
+)
+
 // SwimLaneMode determines how cards are grouped into columns (bv-wjs0)
 type SwimLaneMode int
 
@@ -797,6 +819,31 @@ func (b *BoardModel) CollapseExpanded() {
 	b.expandedCardID = ""
 }
 
+// SetRiskScores turns the risk heatmap on with the given composite risk
+// scores, or off when scores is nil
+func (b *BoardModel) SetRiskScores(scores map[string]float64) {
+	b.riskScores = scores
+	b.lastDetailID = ""
+}
+
+// SetMetrics gives the detail panel the graph metrics and triage scores of
+// the board's issues
+func (b *BoardModel) SetMetrics(stats *analysis.GraphStats, triageScores map[string]float64) {
+	b.graphStats = stats
+	b.triageScores = triageScores
+	b.lastDetailID = ""
+}
+
+// SetEpicProgress gives epic cards the closed/total counts of their children
+func (b *BoardModel) SetEpicProgress(progress map[string]epicProgress) {
+	b.epicProgress = progress
+}
+
+// SetWorkspaceMode shows or hides the repo badge on each card
+func (b *BoardModel) SetWorkspaceMode(enabled bool) {
+	b.showRepoBadges = enabled
+}
+
 // IsCardExpanded returns true if the specified card is currently expanded
 func (b *BoardModel) IsCardExpanded(id string) bool {
 	return b.expandedCardID != "" && b.expandedCardID == id
@@ -831,7 +878,7 @@ func (b BoardModel) View(width, height int) string {
 	// Detail panel takes ~35% of width when shown, min 40 chars
 	boardWidth := width
 	detailWidth := 0
-	if b.showDetail && width > 120 {
+	if b.showDetail && width >= boardDetailMinWidth {
 		detailWidth = width * 35 / 100
 		if detailWidth < 40 {
 			detailWidth = 40
@@ -842,20 +889,21 @@ func (b BoardModel) View(width, height int) string {
 		boardWidth = width - detailWidth - 1 // 1 char gap
 	}
 
-	// Calculate column widths - distribute space evenly
-	// Minimum column width for readability, NO maximum cap (bv-ic17)
-	minColWidth := 28
+	// Only the columns that fit are shown, down to the focused one alone
+	firstCol, endCol := b.visibleColumns(boardWidth)
+	shownCols := endCol - firstCol
 
-	// Calculate available width (subtract gaps between columns)
-	gaps := numCols - 1
-	availableWidth := boardWidth - (gaps * 2) // 2 chars gap between columns
+	// Calculate column widths - distribute space evenly, NO maximum cap (bv-ic17)
+	availableWidth := boardWidth - (shownCols * 2) // Each column's border
 
 	// Distribute width evenly across columns, respecting minimum
-	baseWidth := availableWidth / numCols
-	if baseWidth < minColWidth {
-		baseWidth = minColWidth
+	baseWidth := availableWidth / shownCols
+	if baseWidth < boardMinColWidth && shownCols > 1 {
+		baseWidth = boardMinColWidth
+	}
+	if baseWidth < boardTinyColWidth {
+		baseWidth = boardTinyColWidth
 	}
-	// NO maxColWidth cap - use all available horizontal space
 
 	colHeight := height - 6 // Account for column header + title bar (bv-tf6j)
 	if colHeight < 8 {
@@ -891,6 +939,9 @@ func (b BoardModel) View(width, height int) string {
 	var renderedCols []string
 
 	for i, colIdx := range b.activeColIdx {
+		if i < firstCol || i >= endCol {
+			continue
+		}
 		isFocused := b.focusedCol == i
 		issues := b.columns[colIdx]
 		issueCount := len(issues)
@@ -1055,7 +1106,7 @@ func (b BoardModel) View(width, height int) string {
 	columnsView := lipgloss.JoinHorizontal(lipgloss.Top, renderedCols...)
 
 	// Build title bar with swimlane mode and hidden column indicator (bv-tf6j)
-	titleBar := b.renderTitleBar(boardWidth, t)
+	titleBar := b.renderTitleBar(boardWidth, firstCol, endCol, t)
 
 	// Combine title bar and columns
 	boardView := lipgloss.JoinVertical(lipgloss.Left, titleBar, columnsView)
@@ -1069,8 +1120,27 @@ func (b BoardModel) View(width, height int) string {
 	return boardView
 }
 
+// visibleColumns returns the range of active columns that fit in width at
+// the minimum column width, scrolled to keep the focused column in view. A
+// narrow terminal gets the focused column alone, scrolled with h/l.
+func (b BoardModel) visibleColumns(width int) (first, end int) {
+	numCols := len(b.activeColIdx)
+	fit := width / (boardMinColWidth + 2) // Each column's border
+	if fit < 1 {
+		fit = 1
+	}
+	if fit >= numCols {
+		return 0, numCols
+	}
+	first = b.focusedCol - fit + 1
+	if first < 0 {
+		first = 0
+	}
+	return first, first + fit
+}
+
 // renderTitleBar creates the board title bar with swimlane mode and hidden column count (bv-tf6j)
-func (b BoardModel) renderTitleBar(width int, t Theme) string {
+func (b BoardModel) renderTitleBar(width, firstCol, endCol int, t Theme) string {
 	// Build title: "BOARD [by: Status]" or "BOARD [by: Priority] [+2 hidden]"
 	modeName := b.GetSwimLaneModeName()
 	title := fmt.Sprintf("BOARD [by: %s]", modeName)
@@ -1081,6 +1151,13 @@ func (b BoardModel) renderTitleBar(width int, t Theme) string {
 		title = fmt.Sprintf("%s [+%d hidden]", title, hiddenCount)
 	}
 
+	// Say which columns are in view when they do not all fit
+	if numCols := len(b.activeColIdx); endCol-firstCol == 1 && numCols > 1 {
+		title = fmt.Sprintf("%s [col %d/%d, h/l]", title, firstCol+1, numCols)
+	} else if endCol-firstCol < numCols {
+		title = fmt.Sprintf("%s [cols %d-%d/%d, h/l]", title, firstCol+1, endCol, numCols)
+	}
+
 	// Style the title bar
 	titleStyle := t.Renderer.NewStyle().
 		Width(width).
@@ -1163,6 +1240,8 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 		borderColor = lipgloss.AdaptiveColor{Light: "#7b1fa2", Dark: "#ce93d8"} // Purple - current search match
 	} else if isAnyMatch {
 		borderColor = lipgloss.AdaptiveColor{Light: "#1565c0", Dark: "#64b5f6"} // Blue - search match
+	} else if b.riskScores != nil {
+		borderColor = GetHeatGradientColor(b.riskScores[issue.ID], t) // Risk heatmap
 	} else if hasBlockingDeps {
 		borderColor = lipgloss.AdaptiveColor{Light: "#c62828", Dark: "#ef5350"} // Red - blocked
 	} else if blocksOthers {
@@ -1204,8 +1283,16 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 		prioStyle = prioStyle.Foreground(t.Secondary)
 	}
 
+	// Repo badge in workspace mode, colored per prefix like list rows
+	repoBadge := ""
+	if b.showRepoBadges {
+		if prefix := ExtractRepoPrefix(issue.ID); prefix != "" {
+			repoBadge = RenderRepoBadge(prefix) + " "
+		}
+	}
+
 	// Truncate ID for narrow cards - reserve space for age indicator
-	maxIDLen := width - 14 // Icon(2) + space + P#(2) + space + age(6) + spacing
+	maxIDLen := width - 14 - lipgloss.Width(repoBadge) // Icon(2) + space + P#(2) + space + age(6) + spacing
 	if maxIDLen < 6 {
 		maxIDLen = 6
 	}
@@ -1219,9 +1306,10 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 	ageColor := getAgeColor(issue.UpdatedAt)
 	ageStyled := t.Renderer.NewStyle().Foreground(ageColor).Render(ageText)
 
-	line1 := fmt.Sprintf("%s %s %s %s",
+	line1 := fmt.Sprintf("%s %s %s%s %s",
 		t.Renderer.NewStyle().Foreground(iconColor).Render(icon),
 		prioStyle.Render(prioText),
+		repoBadge,
 		t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary).Render(displayID),
 		ageStyled,
 	)
@@ -1265,6 +1353,11 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 		}
 	}
 
+	// Epic progress: share of children closed
+	if p, ok := b.epicProgress[issue.ID]; ok {
+		meta = append(meta, renderEpicProgress(p, 5, t))
+	}
+
 	// Blocks count: ⚡→N (this card blocks N others) - from reverse index
 	if blockedIDs, ok := b.blocksIndex[issue.ID]; ok && len(blockedIDs) > 0 {
 		blocksStyle := t.Renderer.NewStyle().Foreground(t.Feature)
@@ -1279,11 +1372,16 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 		}
 		var labelParts []string
 		for i := 0; i < maxLabels; i++ {
-			labelParts = append(labelParts, truncateRunesHelper(issue.Labels[i], 8, ""))
+			labelParts = append(labelParts, t.LabelStyle(issue.Labels[i]).Render(truncateRunesHelper(issue.Labels[i], 8, "")))
+		}
+		meta = append(meta, strings.Join(labelParts, t.Renderer.NewStyle().Foreground(t.Secondary).Render(",")))
+	}
+
+	// Risk percentage in heatmap mode
+	if b.riskScores != nil {
+		if score, ok := b.riskScores[issue.ID]; ok {
+			meta = append([]string{RenderRiskCell(score, true)}, meta...)
 		}
-		labelText := strings.Join(labelParts, ",")
-		labelStyle := t.Renderer.NewStyle().Foreground(t.InProgress)
-		meta = append(meta, labelStyle.Render(labelText))
 	}
 
 	line3 := ""
@@ -1431,8 +1529,7 @@ func (b BoardModel) renderExpandedCard(issue model.Issue, width int, _, _ int) s
 	// ══════════════════════════════════════════════════════════════════════════
 	var labelLine string
 	if len(issue.Labels) > 0 {
-		labelStyle := t.Renderer.NewStyle().Foreground(t.InProgress)
-		labelLine = labelStyle.Render("🏷 " + strings.Join(issue.Labels, ", "))
+		labelLine = "🏷 " + t.RenderLabels(issue.Labels, ", ")
 	}
 
 	// ══════════════════════════════════════════════════════════════════════════
@@ -1559,6 +1656,13 @@ func (b *BoardModel) renderDetailPanel(width, height int) string {
 				content.WriteString(fmt.Sprintf("\n💡 Completing this would unblock %d issue(s)\n\n", len(blockedIDs)))
 			}
 
+			// Metrics, for triage without leaving the board
+			if metrics := b.detailMetrics(issue.ID); metrics != "" {
+				content.WriteString("**Metrics:**\n")
+				content.WriteString(metrics)
+				content.WriteString("\n")
+			}
+
 			// Description
 			if issue.Description != "" {
 				content.WriteString("---\n\n")
@@ -1615,3 +1719,24 @@ func (b *BoardModel) renderDetailPanel(width, height int) string {
 
 	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, titleBar, sb.String()))
 }
+
+// detailMetrics lists the triage score, graph metrics and risk of an issue
+// as Markdown bullets, empty when no metrics are known
+func (b *BoardModel) detailMetrics(id string) string {
+	var sb strings.Builder
+	if score, ok := b.triageScores[id]; ok {
+		sb.WriteString(fmt.Sprintf("- Triage score: %.2f\n", score))
+	}
+	if b.graphStats != nil {
+		if b.graphStats.IsPhase2Ready() {
+			sb.WriteString(fmt.Sprintf("- PageRank: %.4f\n", b.graphStats.GetPageRankScore(id)))
+			sb.WriteString(fmt.Sprintf("- Betweenness: %.4f\n", b.graphStats.GetBetweennessScore(id)))
+			sb.WriteString(fmt.Sprintf("- Critical path depth: %.0f\n", b.graphStats.GetCriticalPathScore(id)))
+		}
+		sb.WriteString(fmt.Sprintf("- Blocks %d · depends on %d\n", b.graphStats.InDegree[id], b.graphStats.OutDegree[id]))
+	}
+	if score, ok := b.riskScores[id]; ok {
+		sb.WriteString(fmt.Sprintf("- Risk: %.2f\n", score))
+	}
+	return sb.String()
+}
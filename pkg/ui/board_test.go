@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 
@@ -978,6 +979,32 @@ func TestDetailPanelRenderWithWidth(t *testing.T) {
 	}
 }
 
+// TestDetailPanelMetrics verifies the detail panel shows the selected card's
+// triage score and graph metrics
+func TestDetailPanelMetrics(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Priority: 0},
+		{ID: "B", Title: "API", Status: model.StatusOpen, Priority: 1,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	b := ui.NewBoardModel(issues, theme)
+	b.ShowDetail()
+
+	if out := b.View(160, 40); strings.Contains(out, "Metrics") {
+		t.Error("no metrics should show before they are set")
+	}
+
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	b.SetMetrics(&stats, map[string]float64{"A": 0.75})
+	out := b.View(160, 40)
+	for _, want := range []string{"Metrics", "Triage score: 0.75", "PageRank", "Blocks 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("detail panel missing %q:\n%s", want, out)
+		}
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Layout Tests at Various Widths (bv-4agf)
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -1801,3 +1828,60 @@ func TestInlineCardExpansion_ShowsDescription(t *testing.T) {
 		t.Error("Expanded card should show description content")
 	}
 }
+
+// TestBoardRepoBadges verifies cards carry repo badges only in workspace mode
+func TestBoardRepoBadges(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "api-AUTH-1", Title: "Auth", Status: model.StatusOpen, CreatedAt: createTime(1)},
+		{ID: "web-UI-1", Title: "Login", Status: model.StatusInProgress, CreatedAt: createTime(1)},
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+	if out := b.View(160, 40); strings.Contains(out, "[API]") {
+		t.Error("repo badges should be hidden outside workspace mode")
+	}
+
+	b.SetWorkspaceMode(true)
+	out := b.View(160, 40)
+	if !strings.Contains(out, "[API]") || !strings.Contains(out, "[WEB]") {
+		t.Errorf("expected repo badges on cards, got:\n%s", out)
+	}
+}
+
+// TestBoardNarrowTerminalShowsFittingColumns checks that a narrow board
+// shows only the columns that fit, down to the focused one alone
+func TestBoardNarrowTerminalShowsFittingColumns(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "open-1", Status: model.StatusOpen, Title: "Open work"},
+		{ID: "prog-1", Status: model.StatusInProgress, Title: "Doing"},
+		{ID: "blk-1", Status: model.StatusBlocked, Title: "Stuck"},
+		{ID: "done-1", Status: model.StatusClosed, Title: "Finished"},
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+
+	wide := b.View(160, 30)
+	for _, id := range []string{"open-1", "prog-1", "blk-1", "done-1"} {
+		if !strings.Contains(wide, id) {
+			t.Errorf("wide board missing %s", id)
+		}
+	}
+
+	mid := b.View(70, 30)
+	if !strings.Contains(mid, "[cols 1-2/4, h/l]") || strings.Contains(mid, "blk-1") {
+		t.Errorf("expected the first two columns at 70 wide:\n%s", mid)
+	}
+
+	b.MoveRight()
+	b.MoveRight()
+	narrow := b.View(40, 30)
+	if !strings.Contains(narrow, "blk-1") || strings.Contains(narrow, "prog-1") {
+		t.Errorf("expected only the focused Blocked column at 40 wide:\n%s", narrow)
+	}
+	if !strings.Contains(narrow, "[col 3/4, h/l]") {
+		t.Errorf("title bar should say which column is shown:\n%s", narrow)
+	}
+	for _, line := range strings.Split(narrow, "\n") {
+		if w := lipgloss.Width(line); w > 40 {
+			t.Fatalf("line %d cells wide on a 40 cell board: %q", w, line)
+		}
+	}
+}
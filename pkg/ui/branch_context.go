@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// workingOnBadge marks list rows pinned by the current branch
+const workingOnBadge = "📌"
+
+// BranchContextLoadedMsg is sent when the current branch and its in-flight
+// issues have been detected
+type BranchContextLoadedMsg struct {
+	Context *correlation.BranchContext
+	Error   error
+}
+
+// LoadBranchContextCmd returns a command that detects the checked-out branch
+// and the unclosed issues named by it or its recent commits
+func LoadBranchContextCmd(issues []model.Issue, beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return BranchContextLoadedMsg{Error: err}
+		}
+		beads := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beads[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+			}
+		}
+		ctx, err := correlation.DetectBranchContext(repoPath, beads)
+		return BranchContextLoadedMsg{Context: ctx, Error: err}
+	}
+}
+
+// setBranchContext pins the branch's issues at the top of the list,
+// keeping the current selection
+func (m *Model) setBranchContext(ctx *correlation.BranchContext) {
+	m.branchContext = ctx
+	m.workingOn = nil
+	if ctx != nil && len(ctx.IssueIDs) > 0 {
+		m.workingOn = make(map[string]bool, len(ctx.IssueIDs))
+		for _, id := range ctx.IssueIDs {
+			m.workingOn[id] = true
+		}
+	}
+
+	selectedID := ""
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		selectedID = item.Issue.ID
+	}
+	m.updateListDelegate()
+	m.refreshFilteredViews()
+	if selectedID != "" {
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+}
+
+// pinWorkingOn moves the issues in flight on the current branch to the front
+// of items and issues, keeping the existing order within each group
+func (m *Model) pinWorkingOn(items []list.Item, issues []model.Issue) {
+	if len(m.workingOn) == 0 {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return m.isWorkingOn(items[i]) && !m.isWorkingOn(items[j])
+	})
+	sort.SliceStable(issues, func(i, j int) bool {
+		return m.workingOn[issues[i].ID] && !m.workingOn[issues[j].ID]
+	})
+}
+
+// isWorkingOn reports whether a list item is pinned by the current branch
+func (m Model) isWorkingOn(item list.Item) bool {
+	issueItem, ok := item.(IssueItem)
+	return ok && m.workingOn[issueItem.Issue.ID]
+}
+
+// workingOnCount returns how many pinned issues are in the list
+func (m Model) workingOnCount() int {
+	n := 0
+	for _, item := range m.list.Items() {
+		if m.isWorkingOn(item) {
+			n++
+		}
+	}
+	return n
+}
+
+// withBranchHeaderLabel appends the "Working on" section label to a list
+// column header when pinned issues are shown and the label fits in width
+func (m Model) withBranchHeaderLabel(header string, width int) string {
+	n := m.workingOnCount()
+	if n == 0 {
+		return header
+	}
+	labeled := fmt.Sprintf("%s   %s Working on (%d)", header, workingOnBadge, n)
+	if lipgloss.Width(labeled) > width {
+		return header
+	}
+	return labeled
+}
+
+// renderBranchBadge renders the status bar indicator for the current branch
+func (m Model) renderBranchBadge() string {
+	if m.branchContext == nil || m.branchContext.Branch == "" {
+		return ""
+	}
+	label := "⎇ " + truncateRunesHelper(m.branchContext.Branch, 24, "…")
+	if n := len(m.workingOn); n > 0 {
+		label += fmt.Sprintf(" %s%d", workingOnBadge, n)
+	}
+	return lipgloss.NewStyle().
+		Background(ColorBgHighlight).
+		Foreground(ColorInfo).
+		Padding(0, 1).
+		Render(label)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSetBranchContext_PinsWorkingOn(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "First", Status: model.StatusOpen, Priority: 0},
+		{ID: "bv-2", Title: "Second", Status: model.StatusOpen, Priority: 1},
+		{ID: "bv-3", Title: "Third", Status: model.StatusInProgress, Priority: 3},
+	}
+	m := NewModel(issues, nil, "")
+	m.list.Select(1)
+
+	m.setBranchContext(&correlation.BranchContext{Branch: "feature/bv-3-login", IssueIDs: []string{"bv-3"}})
+
+	items := m.list.Items()
+	if first := items[0].(IssueItem).Issue.ID; first != "bv-3" {
+		t.Fatalf("first item = %s, want pinned bv-3", first)
+	}
+	if next := items[1].(IssueItem).Issue.ID; next != "bv-1" {
+		t.Errorf("unpinned items should keep their order, got %s second", next)
+	}
+	if sel, ok := m.list.SelectedItem().(IssueItem); !ok || sel.Issue.ID != "bv-2" {
+		t.Errorf("selection should stay on bv-2, got %+v", m.list.SelectedItem())
+	}
+	if m.workingOnCount() != 1 {
+		t.Errorf("workingOnCount = %d, want 1", m.workingOnCount())
+	}
+
+	header := m.withBranchHeaderLabel("  ID TITLE", 80)
+	if !strings.Contains(header, "Working on (1)") {
+		t.Errorf("header = %q, want Working on label", header)
+	}
+	if got := m.withBranchHeaderLabel("  ID TITLE", 12); got != "  ID TITLE" {
+		t.Errorf("label should be dropped when it does not fit, got %q", got)
+	}
+	if badge := m.renderBranchBadge(); !strings.Contains(badge, "feature/bv-3-login") {
+		t.Errorf("branch badge = %q", badge)
+	}
+
+	// Switching to a branch with nothing in flight unpins
+	m.setBranchContext(&correlation.BranchContext{Branch: "main"})
+	if first := m.list.Items()[0].(IssueItem).Issue.ID; first != "bv-1" {
+		t.Errorf("first item = %s, want bv-1 after unpinning", first)
+	}
+	if got := m.withBranchHeaderLabel("  ID TITLE", 80); got != "  ID TITLE" {
+		t.Errorf("header = %q, want no label", got)
+	}
+}
+
+func TestRenderBranchBadge_Detached(t *testing.T) {
+	m := NewModel(nil, nil, "")
+	if m.renderBranchBadge() != "" {
+		t.Error("no badge expected before the branch is known")
+	}
+	m.setBranchContext(&correlation.BranchContext{})
+	if m.renderBranchBadge() != "" {
+		t.Error("no badge expected for a detached HEAD")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -28,6 +29,8 @@ type CassSessionModal struct {
 	copied      bool       // Flash feedback for clipboard copy
 	copiedAt    time.Time  // When copy happened
 	maxDisplay  int        // Max sessions to show (rest are summarized)
+
+	feedback *correlation.FeedbackStore // Confirm/reject/ignore decisions to show
 }
 
 // NewCassSessionModal creates a modal from correlation results.
@@ -175,6 +178,9 @@ func (m CassSessionModal) View() string {
 			matchReason := m.formatMatchReason(session)
 			b.WriteString("    ")
 			b.WriteString(matchReasonStyle.Render(matchReason))
+			if label := feedbackLabel(m.feedback, cassFeedbackKey(session.SourcePath), m.beadID); label != "" {
+				b.WriteString(matchReasonStyle.Render(" · " + label))
+			}
 			b.WriteString("\n")
 
 			// Snippet box
@@ -197,9 +203,9 @@ func (m CassSessionModal) View() string {
 	}
 
 	// Footer with keybindings
-	footerText := "[j/k] Navigate    [y] Copy search cmd    [V/Esc] Close"
+	footerText := "[j/k] Navigate  [⏎] Transcript  [y] Copy search cmd  [V/Esc] Close\n[C/X/I] Confirm / reject / ignore match"
 	if showCopied {
-		footerText = "[j/k] Navigate    ✓ Copied!              [V/Esc] Close"
+		footerText = "[j/k] Navigate  [⏎] Transcript  ✓ Copied!            [V/Esc] Close\n[C/X/I] Confirm / reject / ignore match"
 	}
 	b.WriteString(footerStyle.Render(footerText))
 
@@ -315,6 +321,19 @@ func (m CassSessionModal) HasSessions() bool {
 	return len(m.sessions) > 0
 }
 
+// SetFeedbackStore sets the store whose feedback on each session is shown.
+func (m *CassSessionModal) SetFeedbackStore(store *correlation.FeedbackStore) {
+	m.feedback = store
+}
+
+// SelectedSession returns the highlighted session, if any.
+func (m CassSessionModal) SelectedSession() (cass.ScoredResult, bool) {
+	if m.selected < 0 || m.selected >= len(m.sessions) {
+		return cass.ScoredResult{}, false
+	}
+	return m.sessions[m.selected], true
+}
+
 // CenterModal returns the modal view centered in the given dimensions.
 func (m CassSessionModal) CenterModal(termWidth, termHeight int) string {
 	modal := m.View()
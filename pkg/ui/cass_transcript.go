@@ -0,0 +1,486 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CassTranscriptLoadedMsg carries a session transcript read from the cass store
+type CassTranscriptLoadedMsg struct {
+	Session    cass.ScoredResult
+	Transcript *cass.Transcript
+	Err        error
+}
+
+// loadCassTranscriptCmd reads the session file of a cass search hit
+func loadCassTranscriptCmd(session cass.ScoredResult) tea.Cmd {
+	return func() tea.Msg {
+		tr, err := cass.LoadTranscript(session.SourcePath)
+		return CassTranscriptLoadedMsg{Session: session, Transcript: tr, Err: err}
+	}
+}
+
+// transcriptLineKind tells View how to style a rendered line
+type transcriptLineKind int
+
+const (
+	transcriptText transcriptLineKind = iota
+	transcriptHeader
+	transcriptCode
+)
+
+type transcriptLine struct {
+	text  string
+	kind  transcriptLineKind
+	block int // Code block index for transcriptCode lines, else -1
+}
+
+// CassTranscriptModel is a full-screen, scrollable view of a cass session
+// with in-transcript search and copying of fenced code blocks.
+type CassTranscriptModel struct {
+	session    cass.ScoredResult
+	transcript *cass.Transcript
+	blocks     []cass.CodeBlock
+
+	lines      []transcriptLine
+	blockLines []int // First rendered line of each code block
+	wrapWidth  int   // Width lines were last wrapped to
+
+	scroll int
+	block  int // Selected code block, -1 for none
+
+	searching bool
+	input     textinput.Model
+	query     string
+	matches   []int // Rendered lines containing query
+	match     int
+
+	width  int
+	height int
+	theme  Theme
+}
+
+// NewCassTranscriptModel opens the viewer for a loaded transcript
+func NewCassTranscriptModel(session cass.ScoredResult, tr *cass.Transcript, theme Theme) CassTranscriptModel {
+	ti := textinput.New()
+	ti.Placeholder = "search transcript"
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+	return CassTranscriptModel{
+		session:    session,
+		transcript: tr,
+		blocks:     tr.CodeBlocks(),
+		block:      -1,
+		input:      ti,
+		theme:      theme,
+		width:      80,
+		height:     24,
+	}
+}
+
+// SetSize updates the dimensions, re-wrapping the transcript when the
+// width changes
+func (m *CassTranscriptModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if w := m.contentWidth(); w != m.wrapWidth {
+		m.layout(w)
+	}
+}
+
+func (m *CassTranscriptModel) contentWidth() int {
+	w := m.width - 4
+	if w < 20 {
+		w = 20
+	}
+	return w
+}
+
+// pageSize is the number of transcript lines visible at once
+func (m *CassTranscriptModel) pageSize() int {
+	h := m.height - 4 // title, subtitle, status line, footer
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// layout renders the transcript into lines of at most width runes
+func (m *CassTranscriptModel) layout(width int) {
+	m.wrapWidth = width
+	m.lines = nil
+	m.blockLines = make([]int, 0, len(m.blocks))
+
+	block := 0
+	for i, msg := range m.transcript.Messages {
+		if i > 0 {
+			m.lines = append(m.lines, transcriptLine{block: -1})
+		}
+		header := "── " + transcriptRoleLabel(msg.Role)
+		if !msg.Timestamp.IsZero() {
+			header += " · " + msg.Timestamp.Local().Format("2006-01-02 15:04")
+		}
+		m.lines = append(m.lines, transcriptLine{text: header + " ──", kind: transcriptHeader, block: -1})
+
+		inCode := false
+		for _, raw := range strings.Split(msg.Text, "\n") {
+			fence := strings.HasPrefix(strings.TrimSpace(raw), "```")
+			switch {
+			case fence && !inCode:
+				inCode = true
+				m.blockLines = append(m.blockLines, len(m.lines))
+				label := fmt.Sprintf("┌ code #%d", block+1)
+				if lang := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(raw), "```")); lang != "" {
+					label += " " + lang
+				}
+				m.lines = append(m.lines, transcriptLine{text: label, kind: transcriptCode, block: block})
+			case fence && inCode:
+				inCode = false
+				m.lines = append(m.lines, transcriptLine{text: "└", kind: transcriptCode, block: block})
+				block++
+			case inCode:
+				text := "│ " + strings.ReplaceAll(raw, "\t", "    ")
+				m.lines = append(m.lines, transcriptLine{text: truncateRunesHelper(text, width, "…"), kind: transcriptCode, block: block})
+			case strings.TrimSpace(raw) == "":
+				m.lines = append(m.lines, transcriptLine{block: -1})
+			default:
+				for _, wrapped := range strings.Split(wrapText(raw, width), "\n") {
+					m.lines = append(m.lines, transcriptLine{text: wrapped, block: -1})
+				}
+			}
+		}
+		if inCode {
+			block++ // Unterminated fence ends with the message
+		}
+	}
+
+	if m.query != "" {
+		m.findMatches()
+	}
+	m.clampScroll()
+}
+
+func transcriptRoleLabel(role string) string {
+	switch role {
+	case "user":
+		return "👤 user"
+	case "assistant":
+		return "🤖 assistant"
+	case "system":
+		return "⚙️ system"
+	case "":
+		return "📄 session"
+	default:
+		return role
+	}
+}
+
+func (m *CassTranscriptModel) clampScroll() {
+	maxScroll := len(m.lines) - m.pageSize()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.scroll > maxScroll {
+		m.scroll = maxScroll
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+// ScrollBy moves the view by delta lines
+func (m *CassTranscriptModel) ScrollBy(delta int) {
+	m.scroll += delta
+	m.clampScroll()
+}
+
+// ScrollToTop jumps to the start of the transcript
+func (m *CassTranscriptModel) ScrollToTop() {
+	m.scroll = 0
+}
+
+// ScrollToBottom jumps to the end of the transcript
+func (m *CassTranscriptModel) ScrollToBottom() {
+	m.scroll = len(m.lines)
+	m.clampScroll()
+}
+
+// scrollTo brings line into view, near the top of the page
+func (m *CassTranscriptModel) scrollTo(line int) {
+	if line < m.scroll || line >= m.scroll+m.pageSize() {
+		m.scroll = line - 2
+		m.clampScroll()
+	}
+}
+
+// Searching reports whether the search input has focus
+func (m *CassTranscriptModel) Searching() bool {
+	return m.searching
+}
+
+// StartSearch focuses the search input
+func (m *CassTranscriptModel) StartSearch() tea.Cmd {
+	m.searching = true
+	m.input.SetValue(m.query)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+// CancelSearch leaves the search input without changing the query
+func (m *CassTranscriptModel) CancelSearch() {
+	m.searching = false
+	m.input.Blur()
+}
+
+// ApplySearch searches for query (case-insensitive) and jumps to the first
+// match at or after the current position
+func (m *CassTranscriptModel) ApplySearch(query string) {
+	m.searching = false
+	m.input.Blur()
+	m.query = strings.TrimSpace(query)
+	m.findMatches()
+	m.match = 0
+	for i, line := range m.matches {
+		if line >= m.scroll {
+			m.match = i
+			break
+		}
+	}
+	if len(m.matches) > 0 {
+		m.scrollTo(m.matches[m.match])
+	}
+}
+
+func (m *CassTranscriptModel) findMatches() {
+	m.matches = nil
+	if m.query == "" {
+		return
+	}
+	needle := strings.ToLower(m.query)
+	for i, line := range m.lines {
+		if strings.Contains(strings.ToLower(line.text), needle) {
+			m.matches = append(m.matches, i)
+		}
+	}
+	if m.match >= len(m.matches) {
+		m.match = 0
+	}
+}
+
+// NextMatch moves to the following (delta 1) or previous (delta -1) match,
+// wrapping around
+func (m *CassTranscriptModel) NextMatch(delta int) {
+	n := len(m.matches)
+	if n == 0 {
+		return
+	}
+	m.match = ((m.match+delta)%n + n) % n
+	m.scrollTo(m.matches[m.match])
+}
+
+// SelectBlock selects the following (delta 1) or previous (delta -1) code
+// block and scrolls to it
+func (m *CassTranscriptModel) SelectBlock(delta int) {
+	n := len(m.blocks)
+	if n == 0 {
+		return
+	}
+	if m.block < 0 {
+		// Start from the first block on screen
+		m.block = 0
+		for i, line := range m.blockLines {
+			if line >= m.scroll {
+				m.block = i
+				break
+			}
+		}
+		if delta < 0 && m.blockLines[m.block] >= m.scroll+m.pageSize() {
+			m.block = n - 1
+		}
+	} else {
+		m.block = ((m.block+delta)%n + n) % n
+	}
+	if m.block < len(m.blockLines) {
+		m.scrollTo(m.blockLines[m.block])
+	}
+}
+
+// SelectedBlock returns the selected code block, or the first one visible
+// when none has been selected
+func (m *CassTranscriptModel) SelectedBlock() (int, *cass.CodeBlock) {
+	if m.block >= 0 && m.block < len(m.blocks) {
+		return m.block, &m.blocks[m.block]
+	}
+	for i := m.scroll; i < len(m.lines) && i < m.scroll+m.pageSize(); i++ {
+		if b := m.lines[i].block; b >= 0 && b < len(m.blocks) {
+			return b, &m.blocks[b]
+		}
+	}
+	return -1, nil
+}
+
+// View renders the transcript
+func (m *CassTranscriptModel) View() string {
+	if m.wrapWidth == 0 {
+		m.layout(m.contentWidth())
+	}
+	t := m.theme
+	width := m.contentWidth()
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	headerStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	codeStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	selCodeStyle := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	matchStyle := t.Renderer.NewStyle().Foreground(t.InProgress).Bold(true)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+
+	title := m.session.Title
+	if title == "" {
+		title = filepath.Base(m.session.SourcePath)
+	}
+	var out []string
+	out = append(out, titleStyle.Render(truncateRunesHelper("📜 "+title, width, "…")))
+	sub := fmt.Sprintf("%s · %d messages · %d code blocks · %s",
+		m.session.Agent, len(m.transcript.Messages), len(m.blocks), m.session.SourcePath)
+	out = append(out, dimStyle.Render(truncateRunesHelper(sub, width, "…")))
+
+	current := -1
+	if len(m.matches) > 0 {
+		current = m.matches[m.match]
+	}
+	end := m.scroll + m.pageSize()
+	if end > len(m.lines) {
+		end = len(m.lines)
+	}
+	for i := m.scroll; i < end; i++ {
+		line := m.lines[i]
+		style := textStyle
+		switch {
+		case i == current:
+			style = matchStyle
+		case line.kind == transcriptHeader:
+			style = headerStyle
+		case line.kind == transcriptCode && line.block == m.block:
+			style = selCodeStyle
+		case line.kind == transcriptCode:
+			style = codeStyle
+		}
+		out = append(out, style.Render(line.text))
+	}
+	for i := end - m.scroll; i < m.pageSize(); i++ {
+		out = append(out, "")
+	}
+
+	var status string
+	switch {
+	case m.searching:
+		status = m.input.View()
+	case m.query != "" && len(m.matches) == 0:
+		status = dimStyle.Render(fmt.Sprintf("No matches for %q", m.query))
+	case m.query != "":
+		status = dimStyle.Render(fmt.Sprintf("Match %d/%d for %q", m.match+1, len(m.matches), m.query))
+	case m.block >= 0:
+		status = dimStyle.Render(fmt.Sprintf("Code block %d/%d selected", m.block+1, len(m.blocks)))
+	}
+	out = append(out, status)
+
+	pos := 100
+	if maxScroll := len(m.lines) - m.pageSize(); maxScroll > 0 {
+		pos = m.scroll * 100 / maxScroll
+	}
+	footer := fmt.Sprintf("j/k scroll | / search | n/N match | [/] code block | y copy | esc back   %d%%", pos)
+	out = append(out, dimStyle.Render(truncateRunesHelper(footer, width, "…")))
+
+	return lipgloss.NewStyle().Padding(0, 2).Render(strings.Join(out, "\n"))
+}
+
+// openCassTranscript starts loading the session selected in the cass modal
+func (m *Model) openCassTranscript() tea.Cmd {
+	session, ok := m.cassModal.SelectedSession()
+	if !ok {
+		return nil
+	}
+	if session.SourcePath == "" {
+		m.statusMsg = "❌ Session has no source file in the cass store"
+		m.statusIsError = true
+		return nil
+	}
+	m.statusMsg = "📜 Loading transcript…"
+	m.statusIsError = false
+	return loadCassTranscriptCmd(session)
+}
+
+// handleCassTranscriptKeys handles keys while the transcript viewer is open
+func (m Model) handleCassTranscriptKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	tv := &m.cassTranscript
+	if tv.Searching() {
+		switch msg.String() {
+		case "esc":
+			tv.CancelSearch()
+		case "enter":
+			tv.ApplySearch(tv.input.Value())
+		default:
+			var cmd tea.Cmd
+			tv.input, cmd = tv.input.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		// Back to the session list
+		m.showCassTranscript = false
+		m.showCassModal = true
+		m.focused = focusCassModal
+	case "j", "down":
+		tv.ScrollBy(1)
+	case "k", "up":
+		tv.ScrollBy(-1)
+	case "ctrl+d":
+		tv.ScrollBy(tv.pageSize() / 2)
+	case "ctrl+u":
+		tv.ScrollBy(-tv.pageSize() / 2)
+	case "pgdown", " ":
+		tv.ScrollBy(tv.pageSize())
+	case "pgup":
+		tv.ScrollBy(-tv.pageSize())
+	case "g", "home":
+		tv.ScrollToTop()
+	case "G", "end":
+		tv.ScrollToBottom()
+	case "/":
+		return m, tv.StartSearch()
+	case "n":
+		tv.NextMatch(1)
+	case "N":
+		tv.NextMatch(-1)
+	case "]":
+		tv.SelectBlock(1)
+	case "[":
+		tv.SelectBlock(-1)
+	case "y", "c":
+		n, block := tv.SelectedBlock()
+		if block == nil {
+			m.statusMsg = "No code block to copy"
+			m.statusIsError = false
+			return m, nil
+		}
+		if _, err := copyText(block.Code); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+			m.statusIsError = true
+		} else {
+			m.statusMsg = fmt.Sprintf("📋 Copied code block #%d to clipboard", n+1)
+			m.statusIsError = false
+		}
+	}
+	return m, nil
+}
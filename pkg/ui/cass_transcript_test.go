@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
+)
+
+func newTestTranscriptModel() CassTranscriptModel {
+	tr := &cass.Transcript{
+		SourcePath: "/sessions/s1.jsonl",
+		Messages: []cass.TranscriptMessage{
+			{Role: "user", Text: "Please fix the parser bug"},
+			{Role: "assistant", Text: "Here is the fix:\n```go\nreturn parse(x)\n```\nAnd a test:\n```\ngo test ./...\n```"},
+			{Role: "user", Text: "the parser still fails"},
+		},
+	}
+	session := cass.ScoredResult{SearchResult: cass.SearchResult{SourcePath: tr.SourcePath, Agent: "claude", Title: "Parser fix"}}
+	m := NewCassTranscriptModel(session, tr, testTheme())
+	m.SetSize(80, 40)
+	return m
+}
+
+func TestCassTranscript_Search(t *testing.T) {
+	m := newTestTranscriptModel()
+	m.ApplySearch("PARSER")
+	if len(m.matches) != 2 {
+		t.Fatalf("matches = %d, want 2", len(m.matches))
+	}
+	first := m.matches[m.match]
+	m.NextMatch(1)
+	if m.matches[m.match] == first {
+		t.Error("NextMatch should move to the second match")
+	}
+	m.NextMatch(1)
+	if m.matches[m.match] != first {
+		t.Error("NextMatch should wrap around to the first match")
+	}
+	m.NextMatch(-1)
+	if m.match != 1 {
+		t.Errorf("NextMatch(-1) match = %d, want 1", m.match)
+	}
+
+	m.ApplySearch("nothing like this")
+	if len(m.matches) != 0 {
+		t.Errorf("expected no matches, got %d", len(m.matches))
+	}
+	if !strings.Contains(m.View(), "No matches") {
+		t.Error("View should report missing matches")
+	}
+}
+
+func TestCassTranscript_CodeBlocks(t *testing.T) {
+	m := newTestTranscriptModel()
+	if len(m.blocks) != 2 {
+		t.Fatalf("blocks = %d, want 2", len(m.blocks))
+	}
+
+	// Without a selection, the first visible block is copied
+	n, block := m.SelectedBlock()
+	if n != 0 || block == nil || block.Code != "return parse(x)" {
+		t.Errorf("SelectedBlock = %d %+v, want block 0", n, block)
+	}
+
+	m.SelectBlock(1)
+	m.SelectBlock(1)
+	n, block = m.SelectedBlock()
+	if n != 1 || block.Code != "go test ./..." {
+		t.Errorf("SelectedBlock = %d %+v, want block 1", n, block)
+	}
+	m.SelectBlock(1)
+	if n, _ = m.SelectedBlock(); n != 0 {
+		t.Errorf("SelectBlock should wrap to block 0, got %d", n)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "code #1 go") || !strings.Contains(view, "code #2") {
+		t.Error("View should label code blocks")
+	}
+}
+
+func TestCassTranscript_Scroll(t *testing.T) {
+	m := newTestTranscriptModel()
+	m.SetSize(80, 8)
+	m.ScrollToBottom()
+	if m.scroll != len(m.lines)-m.pageSize() {
+		t.Errorf("scroll = %d, want %d", m.scroll, len(m.lines)-m.pageSize())
+	}
+	m.ScrollBy(100)
+	if m.scroll != len(m.lines)-m.pageSize() {
+		t.Error("ScrollBy should clamp at the bottom")
+	}
+	m.ScrollToTop()
+	m.ScrollBy(-1)
+	if m.scroll != 0 {
+		t.Errorf("scroll = %d, want 0", m.scroll)
+	}
+}
+
+func TestCassSessionModal_SelectedSession(t *testing.T) {
+	result := cass.CorrelationResult{
+		BeadID: "bv-1",
+		TopSessions: []cass.ScoredResult{
+			{SearchResult: cass.SearchResult{SourcePath: "/a.jsonl"}},
+			{SearchResult: cass.SearchResult{SourcePath: "/b.jsonl"}},
+		},
+	}
+	modal := NewCassSessionModal("bv-1", result, testTheme())
+	modal.selected = 1
+	s, ok := modal.SelectedSession()
+	if !ok || s.SourcePath != "/b.jsonl" {
+		t.Errorf("SelectedSession = %+v %v, want /b.jsonl", s, ok)
+	}
+
+	empty := NewCassSessionModal("bv-2", cass.CorrelationResult{}, testTheme())
+	if _, ok := empty.SelectedSession(); ok {
+		t.Error("SelectedSession should report false without sessions")
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"golang.org/x/term"
+)
+
+// copyFormat selects how an issue is rendered for the clipboard
+type copyFormat int
+
+const (
+	copyID copyFormat = iota
+	copyMarkdown
+	copyJSON
+	copyBDCommand
+)
+
+// copyFormatKeys maps list/detail keys to clipboard formats
+var copyFormatKeys = map[string]copyFormat{
+	"y": copyID,
+	"C": copyMarkdown,
+	"J": copyJSON,
+	"B": copyBDCommand,
+}
+
+func (f copyFormat) String() string {
+	switch f {
+	case copyMarkdown:
+		return "Markdown"
+	case copyJSON:
+		return "JSON"
+	case copyBDCommand:
+		return "bd command"
+	default:
+		return "ID"
+	}
+}
+
+// formatIssueForCopy renders an issue in the given clipboard format
+func formatIssueForCopy(issue model.Issue, format copyFormat) (string, error) {
+	switch format {
+	case copyMarkdown:
+		return issueMarkdown(issue), nil
+	case copyJSON:
+		data, err := json.MarshalIndent(issue, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encoding %s: %w", issue.ID, err)
+		}
+		return string(data), nil
+	case copyBDCommand:
+		return issueBDCommand(issue), nil
+	default:
+		return issue.ID, nil
+	}
+}
+
+// issueMarkdown formats an issue as a Markdown summary
+func issueMarkdown(issue model.Issue) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# %s %s\n\n", GetTypeIconMD(string(issue.IssueType)), issue.Title))
+	sb.WriteString(fmt.Sprintf("**ID:** %s  \n", issue.ID))
+	sb.WriteString(fmt.Sprintf("**Status:** %s  \n", strings.ToUpper(string(issue.Status))))
+	sb.WriteString(fmt.Sprintf("**Priority:** P%d  \n", issue.Priority))
+	if issue.Assignee != "" {
+		sb.WriteString(fmt.Sprintf("**Assignee:** @%s  \n", issue.Assignee))
+	}
+	sb.WriteString(fmt.Sprintf("**Created:** %s  \n", issue.CreatedAt.Format("2006-01-02")))
+
+	if len(issue.Labels) > 0 {
+		sb.WriteString(fmt.Sprintf("**Labels:** %s  \n", strings.Join(issue.Labels, ", ")))
+	}
+
+	if issue.Description != "" {
+		sb.WriteString(fmt.Sprintf("\n## Description\n\n%s\n", issue.Description))
+	}
+
+	if issue.AcceptanceCriteria != "" {
+		sb.WriteString(fmt.Sprintf("\n## Acceptance Criteria\n\n%s\n", issue.AcceptanceCriteria))
+	}
+
+	// Dependencies
+	if len(issue.Dependencies) > 0 {
+		sb.WriteString("\n## Dependencies\n\n")
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", dep.DependsOnID, dep.Type))
+		}
+	}
+
+	if len(issue.Attachments) > 0 {
+		sb.WriteString("\n## Attachments\n\n")
+		for _, a := range issue.Attachments {
+			if a == nil {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("- [%s](%s)\n", a.Label(), a.URL))
+		}
+	}
+
+	return sb.String()
+}
+
+// issueBDCommand builds a `bd create` command that recreates the issue, plus
+// `bd dep add` lines for its blocking dependencies
+func issueBDCommand(issue model.Issue) string {
+	args := []string{
+		"bd create",
+		"--title=" + shellQuote(issue.Title),
+		"--type=" + string(issue.IssueType),
+		fmt.Sprintf("--priority=%d", issue.Priority),
+	}
+	if issue.Description != "" {
+		args = append(args, "--description="+shellQuote(issue.Description))
+	}
+	if len(issue.Labels) > 0 {
+		args = append(args, "--labels="+shellQuote(strings.Join(issue.Labels, ",")))
+	}
+	if issue.Assignee != "" {
+		args = append(args, "--assignee="+shellQuote(issue.Assignee))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(args, " \\\n  "))
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type.IsBlocking() {
+			sb.WriteString(fmt.Sprintf("\nbd dep add %s %s", shellQuote(issue.ID), shellQuote(dep.DependsOnID)))
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// shellQuote single-quotes s for POSIX shells when it contains anything
+// beyond a conservative set of safe characters
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.,:/@+=", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// inSSHSession reports whether bv is running over SSH, where the system
+// clipboard (if any) belongs to the remote host
+func inSSHSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// copyText puts text on the clipboard. It uses the system clipboard locally
+// and falls back to an OSC52 escape sequence (understood by most modern
+// terminals, including through tmux/screen) over SSH or when no clipboard
+// utility is available. It returns "OSC52" when the fallback was used.
+func copyText(text string) (string, error) {
+	if !inSSHSession() {
+		err := clipboard.WriteAll(text)
+		if err == nil {
+			return "", nil
+		}
+		if !term.IsTerminal(int(os.Stderr.Fd())) {
+			return "", err
+		}
+	}
+	if err := writeOSC52(os.Stderr, text); err != nil {
+		return "", err
+	}
+	return "OSC52", nil
+}
+
+// writeOSC52 writes an OSC52 clipboard sequence, wrapped for tmux or screen
+func writeOSC52(w io.Writer, text string) error {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	if _, err := seq.WriteTo(w); err != nil {
+		return fmt.Errorf("OSC52 clipboard: %w", err)
+	}
+	return nil
+}
+
+// copySelectedIssue copies the selected issue in the given format
+func (m *Model) copySelectedIssue(format copyFormat) {
+	selectedItem := m.list.SelectedItem()
+	if selectedItem == nil {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return
+	}
+
+	issueItem, ok := selectedItem.(IssueItem)
+	if !ok {
+		m.statusMsg = "❌ Invalid item type"
+		m.statusIsError = true
+		return
+	}
+	issue, err := m.hydrateIssue(issueItem.Issue)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not read the text of %s, not copied: %v", issue.ID, err)
+		m.statusIsError = true
+		return
+	}
+
+	text, err := formatIssueForCopy(issue, format)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+		m.statusIsError = true
+		return
+	}
+	via, err := copyText(text)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+		m.statusIsError = true
+		return
+	}
+
+	if format == copyID {
+		m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", issue.ID)
+	} else {
+		m.statusMsg = fmt.Sprintf("📋 Copied %s as %s", issue.ID, format)
+	}
+	if via != "" {
+		m.statusMsg += " (via " + via + ")"
+	}
+	m.statusIsError = false
+}
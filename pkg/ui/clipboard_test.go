@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFormatIssueForCopy(t *testing.T) {
+	issue := model.Issue{
+		ID:          "bv-1",
+		Title:       "Don't break login",
+		Description: "Steps:\n1. `rm` cookies",
+		Status:      model.StatusOpen,
+		Priority:    1,
+		IssueType:   model.TypeBug,
+		Assignee:    "alice",
+		Labels:      []string{"auth", "ui"},
+		Dependencies: []*model.Dependency{
+			{IssueID: "bv-1", DependsOnID: "bv-0", Type: model.DepBlocks},
+			{IssueID: "bv-1", DependsOnID: "bv-9", Type: model.DepRelated},
+		},
+	}
+
+	if got, _ := formatIssueForCopy(issue, copyID); got != "bv-1" {
+		t.Errorf("ID format: got %q", got)
+	}
+
+	md, _ := formatIssueForCopy(issue, copyMarkdown)
+	if !strings.Contains(md, "Don't break login") || !strings.Contains(md, "**Priority:** P1") {
+		t.Errorf("unexpected markdown:\n%s", md)
+	}
+
+	js, err := formatIssueForCopy(issue, copyJSON)
+	if err != nil {
+		t.Fatalf("JSON format: %v", err)
+	}
+	var decoded model.Issue
+	if err := json.Unmarshal([]byte(js), &decoded); err != nil || decoded.ID != "bv-1" || len(decoded.Labels) != 2 {
+		t.Errorf("JSON does not round-trip: %v %+v", err, decoded)
+	}
+
+	bd, _ := formatIssueForCopy(issue, copyBDCommand)
+	for _, want := range []string{
+		`bd create \`,
+		`--title='Don'\''t break login'`,
+		"--type=bug",
+		"--priority=1",
+		"--labels=auth,ui",
+		"--assignee=alice",
+		"bd dep add bv-1 bv-0",
+	} {
+		if !strings.Contains(bd, want) {
+			t.Errorf("bd command missing %q:\n%s", want, bd)
+		}
+	}
+	if strings.Contains(bd, "bv-9") {
+		t.Errorf("non-blocking dependencies should be skipped:\n%s", bd)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"simple":      "simple",
+		"a,b":         "a,b",
+		"":            "''",
+		"two words":   "'two words'",
+		"it's":        `'it'\''s'`,
+		"$(rm -rf /)": "'$(rm -rf /)'",
+	}
+	for in, want := range cases {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	var buf bytes.Buffer
+	if err := writeOSC52(&buf, "bv-1"); err != nil {
+		t.Fatalf("writeOSC52: %v", err)
+	}
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("bv-1")) + "\x07"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-0/default,1,0")
+	buf.Reset()
+	_ = writeOSC52(&buf, "bv-1")
+	if !strings.HasPrefix(buf.String(), "\x1bPtmux;") {
+		t.Errorf("expected tmux passthrough wrapper, got %q", buf.String())
+	}
+}
+
+func TestCopySelectedIssueNoSelection(t *testing.T) {
+	m := NewModel(nil, nil, "")
+	m.copySelectedIssue(copyJSON)
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "No issue selected") {
+		t.Fatalf("expected error status for missing selection, got %q", m.statusMsg)
+	}
+}
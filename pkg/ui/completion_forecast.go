@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxForecastAgents caps the agents the forecast can be run with
+const maxForecastAgents = 9
+
+// openCompletionForecast simulates the completion of the whole backlog
+func (m *Model) openCompletionForecast() {
+	if m.forecastAgents == 0 {
+		m.forecastAgents = 1
+	}
+	m.forecastEpic = ""
+	m.runCompletionForecast()
+	m.showForecast = true
+}
+
+// runCompletionForecast simulates the current scope with the current agents
+func (m *Model) runCompletionForecast() {
+	var scope []string // nil: every open issue
+	if m.forecastEpic != "" {
+		scope = analysis.EpicForecastScope(m.issues, m.forecastEpic)
+	}
+	cfg := analysis.DefaultForecastConfig()
+	cfg.Agents = m.forecastAgents
+	m.forecast = analysis.SimulateCompletion(m.issues, m.analysis, scope, cfg, time.Now())
+}
+
+// forecastEpicFor returns the epic holding issueID: the issue itself if it
+// is an epic, else its nearest epic ancestor through parent-child links
+func (m *Model) forecastEpicFor(issueID string) string {
+	seen := make(map[string]bool)
+	for id := issueID; id != "" && !seen[id]; {
+		seen[id] = true
+		issue, ok := m.issueMap[id]
+		if !ok {
+			return ""
+		}
+		if issue.IssueType == model.TypeEpic {
+			return id
+		}
+		parent := ""
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				parent = dep.DependsOnID
+				break
+			}
+		}
+		id = parent
+	}
+	return ""
+}
+
+// handleForecastKeys handles keys while the completion forecast is open
+func (m Model) handleForecastKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "F":
+		m.showForecast = false
+	case "tab", "e":
+		// Switch between the backlog and the selected issue's epic
+		if m.forecastEpic != "" {
+			m.forecastEpic = ""
+		} else if epic := m.forecastEpicFor(m.selectedIssueID()); epic != "" {
+			m.forecastEpic = epic
+		} else {
+			m.statusMsg = "The selected issue is not in an epic"
+			m.statusIsError = false
+			return m, nil
+		}
+		m.runCompletionForecast()
+	case "+", "=":
+		if m.forecastAgents < maxForecastAgents {
+			m.forecastAgents++
+			m.runCompletionForecast()
+		}
+	case "-":
+		if m.forecastAgents > 1 {
+			m.forecastAgents--
+			m.runCompletionForecast()
+		}
+	}
+	return m, nil
+}
+
+// renderCompletionForecast renders the completion forecast modal
+func (m Model) renderCompletionForecast() string {
+	t := m.theme
+	width := min(76, m.width-4)
+	f := m.forecast
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		MaxHeight(m.height - 4)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("📈 Completion Forecast"))
+	sb.WriteString("\n")
+	scope := "Whole backlog"
+	if m.forecastEpic != "" {
+		scope = "Epic " + m.forecastEpic
+		if epic, ok := m.issueMap[m.forecastEpic]; ok {
+			scope += " " + epic.Title
+		}
+	}
+	sb.WriteString(truncateRunesHelper(scope, width-6, "…"))
+	sb.WriteString("\n")
+	summary := fmt.Sprintf("%d open issues · %d agent(s) · %d runs", f.IssueCount, f.Agents, f.Runs)
+	if f.AddedBlockers > 0 {
+		summary += fmt.Sprintf(" · +%d outside blockers", f.AddedBlockers)
+	}
+	sb.WriteString(mutedStyle.Render(summary))
+	sb.WriteString("\n\n")
+
+	if f.IssueCount == 0 {
+		sb.WriteString(t.Renderer.NewStyle().Foreground(ColorSuccess).Render("✓ Nothing left open"))
+		sb.WriteString("\n")
+	} else {
+		date := func(d time.Time) string { return d.Format("Mon Jan 2 2006") }
+		for _, p := range []struct {
+			label string
+			at    time.Time
+		}{{"50%", f.P50}, {"85%", f.P85}, {"95%", f.P95}} {
+			sb.WriteString(fmt.Sprintf("  %s likely by  %s\n", p.label, t.Renderer.NewStyle().Bold(true).Render(date(p.at))))
+		}
+		sb.WriteString(mutedStyle.Render(fmt.Sprintf("  Mean: %.1f days", f.MeanDays)))
+		sb.WriteString("\n\n")
+
+		peak := 0
+		for _, b := range f.Histogram {
+			peak = max(peak, b.Runs)
+		}
+		barWidth := max(width-30, 10)
+		for _, b := range f.Histogram {
+			bar := strings.Repeat("█", (b.Runs*barWidth+peak-1)/peak)
+			sb.WriteString(fmt.Sprintf("  %s  %s %s\n",
+				b.End.Format("Jan 02"),
+				t.Renderer.NewStyle().Foreground(t.Primary).Render(padRight(bar, barWidth)),
+				mutedStyle.Render(fmt.Sprintf("%3.0f%%", b.Cumulative*100))))
+		}
+		sb.WriteString(mutedStyle.Render("  Bars: runs finishing in each span · %: chance done by the date"))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
+		"Tab: backlog/selected epic • +/-: agents • Esc: close"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(sb.String()),
+	)
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCompletionForecastModal(t *testing.T) {
+	child := func(id, parent string) model.Issue {
+		return model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}}
+	}
+	issues := []model.Issue{
+		{ID: "epic", Title: "Launch", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		child("task", "epic"),
+		child("sub", "task"),
+		{ID: "loose", Title: "Loose", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 100, 40
+	key := func(s string) {
+		t.Helper()
+		m, _ = m.handleForecastKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+
+	m.openCompletionForecast()
+	if !m.showForecast || m.forecast.IssueCount != 4 || len(m.forecast.Histogram) == 0 {
+		t.Fatalf("expected a backlog forecast of 4 issues, got %+v", m.forecast)
+	}
+	if view := m.renderCompletionForecast(); !strings.Contains(view, "Whole backlog") || !strings.Contains(view, "85% likely by") {
+		t.Errorf("view should show the scope and percentiles:\n%s", view)
+	}
+
+	// A grandchild scopes the forecast to its epic
+	if got := m.forecastEpicFor("sub"); got != "epic" {
+		t.Errorf("forecastEpicFor(sub) = %q, want epic", got)
+	}
+	if got := m.forecastEpicFor("loose"); got != "" {
+		t.Errorf("an issue outside epics should have none, got %q", got)
+	}
+	m.selectIssueInList("sub")
+	key("e")
+	if m.forecastEpic != "epic" || m.forecast.IssueCount != 3 {
+		t.Errorf("expected the epic's 3 issues, got %q with %d", m.forecastEpic, m.forecast.IssueCount)
+	}
+	key("+")
+	if m.forecast.Agents != 2 {
+		t.Errorf("+ should add an agent, got %d", m.forecast.Agents)
+	}
+	key("e")
+	if m.forecastEpic != "" || m.forecast.IssueCount != 4 {
+		t.Errorf("e again should return to the backlog, got %q", m.forecastEpic)
+	}
+
+	key("F")
+	if m.showForecast {
+		t.Error("F should close the forecast")
+	}
+}
@@ -21,6 +21,7 @@ const (
 	ContextRepoPicker        Context = "repo-picker"
 	ContextAgentPrompt       Context = "agent-prompt"
 	ContextCassSession       Context = "cass-session"
+	ContextPalette           Context = "palette"
 
 	// Views
 	ContextInsights       Context = "insights"
@@ -51,8 +52,13 @@ const (
 func (m Model) CurrentContext() Context {
 	// === Overlays (most specific - check first) ===
 
+	// Command palette
+	if m.showPalette {
+		return ContextPalette
+	}
+
 	// Cass session modal (bv-qi94)
-	if m.showCassModal {
+	if m.showCassModal || m.showCassTranscript {
 		return ContextCassSession
 	}
 
@@ -201,6 +207,7 @@ func (c Context) Description() string {
 		ContextRepoPicker:         "Repo picker",
 		ContextAgentPrompt:        "Agent prompt",
 		ContextCassSession:        "Cass session preview",
+		ContextPalette:            "Command palette",
 		ContextInsights:           "Insights panel",
 		ContextFlowMatrix:         "Flow matrix",
 		ContextGraph:              "Dependency graph",
@@ -228,7 +235,7 @@ func (c Context) IsOverlay() bool {
 	case ContextLabelPicker, ContextRecipePicker, ContextHelp, ContextQuitConfirm,
 		ContextLabelHealthDetail, ContextLabelDrilldown, ContextLabelGraphAnalysis,
 		ContextTimeTravelInput, ContextAlerts, ContextRepoPicker, ContextAgentPrompt,
-		ContextCassSession:
+		ContextCassSession, ContextPalette:
 		return true
 	}
 	return false
@@ -26,6 +26,7 @@ var ContextHelpContent = map[Context]string{
 	ContextAttention:      contextHelpAttention,
 	ContextAgentPrompt:    contextHelpAgentPrompt,
 	ContextCassSession:    contextHelpCassSession,
+	ContextPalette:        contextHelpPalette,
 }
 
 // GetContextHelp returns the help content for a given context.
@@ -91,19 +92,18 @@ func RenderContextHelp(ctx Context, theme Theme, width, height int) string {
 const contextHelpList = `## List View
 
 **Navigation**
-  j/k       Move up/down
+  j/k       Move up/down (5j moves 5)
   Enter     View issue details
   g/G       Jump to top/bottom
 
 **Filtering**
-  o         Open issues only
-  c         Closed issues only
+  o/c       Open / closed issues only
   r         Ready (no blockers)
   a         All issues
+  z/W       Starred / snoozed only
   /         Fuzzy search
   Ctrl+S    Semantic search (AI)
-  H         Hybrid ranking
-  Alt+H     Hybrid preset
+  H/Alt+H   Hybrid ranking / preset
 
 **Switch Views**
   b         Board view
@@ -112,6 +112,12 @@ const contextHelpList = `## List View
   h         History view
 
 **Actions**
+  */Z/e     Star / snooze / private note
+  A/E/Y     Digest / notifications / audit log
+  m         Risk heatmap overlay
+  P         Review priority suggestions
+  F/R/Q     Forecast / Effort rollup / Unblock queue
+  +         New issue from template
   U         Self-update bv
   V         Preview cass sessions`
 
@@ -120,10 +126,15 @@ const contextHelpGraph = `## Graph View
 **Navigation**
   j/k       Navigate nodes vertically
   h/l       Navigate siblings
+  3j/3l     Count: repeat the move 3 times
   Enter     View selected issue
   f         Focus on subgraph
   Esc       Exit to list
 
+**Zoom**
+  +/-       Compact (IDs) · normal (titles)
+            · detailed (status, PageRank)
+
 **Understanding the Graph**
 • Arrows point TO what's blocked
   (A → B means A blocks B)
@@ -135,7 +146,7 @@ const contextHelpBoard = `## Board View
 
 **Navigation**
   h/l       Move between columns
-  j/k       Move within column
+  j/k       Move within column (3j: 3 cards)
   1-4/H     Jump to column by number/first
   gg/G      Go to top/bottom of column
 
@@ -153,7 +164,7 @@ const contextHelpBoard = `## Board View
 **Visual Indicators** (card borders)
   🔴 Red     Has blockers
   🟡 Yellow  High-impact (blocks others)
-  🟢 Green   Ready to work
+  🟢 Green   Ready to work (m: by risk)
 
 **Actions**
   Tab       Toggle detail panel
@@ -180,6 +191,10 @@ const contextHelpInsights = `## Insights Panel
   e         Toggle explanations
   x         Toggle calculations
 
+**Priority Picks**
+  Space     Expand pick: score bars, unblocks
+  P         Apply suggested priority (expanded)
+
 **Attention Indicators**
 • Stale: Open too long
 • Blocked chains: Bottlenecks
@@ -209,6 +224,7 @@ const contextHelpHistory = `## History View
 
 **Actions**
   y         Copy commit SHA
+  C/X/I     Confirm/reject/ignore match
   o         Open commit in browser
   Esc       Return to list`
 
@@ -216,12 +232,25 @@ const contextHelpDetail = `## Detail View
 
 **Navigation**
   j/k       Scroll content
+  n/N       Select next/previous linked issue
+  gd        Open the selected linked issue
+  <         Jump to an open blocker (menu)
+  u/U       Select next/previous attachment
+  Ctrl+o    Back to the previously visited issue
+  Ctrl+i/]  Forward again (Ctrl+] in split view)
   Esc       Return to list
-  Tab       Switch to split view
 
-**Actions (from list view)**
-  O         Open in editor
-  C         Copy issue ID
+**Actions**
+  @         Open attachment (printed if no browser)
+  O         Edit issue in $EDITOR
+  D         Edit dependencies (add/remove links)
+  Ctrl+r    Rename ID (rewrites references)
+  y         Copy issue ID
+  C/J/B     Copy as Markdown / JSON / bd command
+  I         Copy impact dossier (blockers, critical path)
+  *         Star / unstar (starred sort first)
+  Z         Snooze until a date / wake
+  e         Edit private note (.bv/notes/)
 
 **Info Shown**
 • Full description (markdown)
@@ -252,9 +281,15 @@ const contextHelpFilter = `## Filter Mode
   c         Closed only
   r         Ready (no blockers)
   a         All (clear filter)
+  z         Starred only
+  W         Snoozed only
 
 **Search**
   /         Start fuzzy search
+  ↑/↓       Past searches (in the bar)
+  Tab       Complete a label (Ctrl+N/P pick)
+  Ctrl+F    Results pane with snippets
+            (s saves the search as a recipe)
   Ctrl+S    Semantic search (AI)
   H         Hybrid ranking
   Alt+H     Hybrid preset
@@ -400,13 +435,20 @@ the selected bead via cass search.
 
 **Navigation**
   j/k       Move between sessions
-  Enter     Expand session details
+  Enter     Open full transcript
   Esc       Close modal
 
 **Actions**
   y         Copy cass command
+  C/X/I     Confirm/reject/ignore match
   o         Open session file
 
+**Transcript**
+  j/k g/G   Scroll
+  /  n/N    Search, next/prev match
+  [ ]       Select code block
+  y         Copy code block
+
 **Match Types**
   ID        Direct bead ID match
   File      Modified same files
@@ -414,3 +456,18 @@ the selected bead via cass search.
 
 Sessions ranked by relevance score.
 Only shown when cass is installed.`
+
+const contextHelpPalette = `## Command Palette
+
+Runs any action of the view it was
+opened from, opens a tutorial page or
+jumps to an issue by ID or title.
+
+**Navigation**
+  type      Fuzzy search
+  ↑/↓       Move selection
+  Enter     Run
+  Esc       Close
+
+Issues are listed once you type.
+Remapped keys are shown as remapped.`
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+)
+
+// feedbackBy identifies feedback recorded from the TUI in the feedback store
+const feedbackBy = "bv-tui"
+
+// cassFeedbackKey is stored in place of a commit SHA for feedback on a cass
+// session ↔ bead match, so one store covers both kinds of correlation
+func cassFeedbackKey(sourcePath string) string {
+	return "cass:" + sourcePath
+}
+
+// correlationFeedbackKeys maps the confirm/reject/ignore keys shared by the
+// history view and the cass modal to feedback types
+var correlationFeedbackKeys = map[string]correlation.FeedbackType{
+	"C": correlation.FeedbackConfirm,
+	"X": correlation.FeedbackReject,
+	"I": correlation.FeedbackIgnore,
+}
+
+// feedbackLabel describes existing feedback on a match, or "" if there is none
+func feedbackLabel(store *correlation.FeedbackStore, key, beadID string) string {
+	if store == nil {
+		return ""
+	}
+	fb, ok := store.Get(key, beadID)
+	if !ok {
+		return ""
+	}
+	switch fb.Type {
+	case correlation.FeedbackConfirm:
+		return "✓ confirmed"
+	case correlation.FeedbackReject:
+		return "✗ rejected"
+	case correlation.FeedbackIgnore:
+		return "⊘ ignored"
+	default:
+		return string(fb.Type)
+	}
+}
+
+// loadFeedbackStore opens the correlation feedback kept next to the beads file
+func loadFeedbackStore(beadsPath string) *correlation.FeedbackStore {
+	if beadsPath == "" {
+		return nil
+	}
+	store := correlation.NewFeedbackStore(filepath.Dir(beadsPath))
+	if err := store.Load(); err != nil {
+		return nil
+	}
+	return store
+}
+
+// recordCorrelationFeedback saves feedback on the match between key (a
+// commit SHA or cassFeedbackKey) and beadID
+func (m *Model) recordCorrelationFeedback(kind correlation.FeedbackType, key, beadID string, conf float64, what string) {
+	if m.correlationFeedback == nil {
+		m.statusMsg = "❌ No beads directory to store correlation feedback in"
+		m.statusIsError = true
+		return
+	}
+	if key == "" || beadID == "" {
+		m.statusMsg = "❌ No correlation selected"
+		m.statusIsError = true
+		return
+	}
+
+	store := m.correlationFeedback
+	var err error
+	switch kind {
+	case correlation.FeedbackConfirm:
+		err = store.Confirm(key, beadID, feedbackBy, conf, "")
+	case correlation.FeedbackReject:
+		err = store.Reject(key, beadID, feedbackBy, conf, "")
+	default:
+		err = store.Ignore(key, beadID, feedbackBy, conf, "")
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Feedback not saved: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("%s %s ↔ %s", feedbackLabel(store, key, beadID), what, beadID)
+	m.statusIsError = false
+}
+
+// historyFeedback records feedback on the commit ↔ bead pair selected in
+// the history view
+func (m *Model) historyFeedback(kind correlation.FeedbackType) {
+	sha, beadID, conf, ok := m.historyView.SelectedCorrelation()
+	if !ok {
+		m.statusMsg = "❌ No correlation selected"
+		m.statusIsError = true
+		return
+	}
+	short := sha
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	m.recordCorrelationFeedback(kind, sha, beadID, conf, short)
+}
+
+// cassSessionFeedback records feedback on the session selected in the cass modal
+func (m *Model) cassSessionFeedback(kind correlation.FeedbackType) {
+	session, ok := m.cassModal.SelectedSession()
+	if !ok || session.SourcePath == "" {
+		m.statusMsg = "❌ No session selected"
+		m.statusIsError = true
+		return
+	}
+	m.recordCorrelationFeedback(kind, cassFeedbackKey(session.SourcePath), m.cassModal.beadID, session.Score, "session")
+}
+
+// correlationFeedbackStats summarizes the feedback for the insights panel
+func (m *Model) correlationFeedbackStats() correlation.FeedbackStats {
+	if m.correlationFeedback == nil {
+		return correlation.FeedbackStats{}
+	}
+	return m.correlationFeedback.GetStats()
+}
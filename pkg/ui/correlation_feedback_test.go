@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRecordCorrelationFeedback(t *testing.T) {
+	dir := t.TempDir()
+	beadsPath := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(beadsPath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}, nil, beadsPath)
+	if m.correlationFeedback == nil {
+		t.Fatal("expected a feedback store next to the beads file")
+	}
+
+	m.recordCorrelationFeedback(correlation.FeedbackConfirm, "abc123", "bv-1", 0.9, "abc123")
+	m.recordCorrelationFeedback(correlation.FeedbackReject, cassFeedbackKey("/s.jsonl"), "bv-1", 0.4, "session")
+	if m.statusIsError {
+		t.Fatalf("unexpected error: %s", m.statusMsg)
+	}
+	if !strings.Contains(m.statusMsg, "✗ rejected") {
+		t.Errorf("statusMsg = %q, want rejection", m.statusMsg)
+	}
+
+	// Feedback is persisted next to the beads file
+	reloaded := loadFeedbackStore(beadsPath)
+	stats := reloaded.GetStats()
+	if stats.Confirmed != 1 || stats.Rejected != 1 {
+		t.Errorf("stats = %+v, want 1 confirmed and 1 rejected", stats)
+	}
+	if got := feedbackLabel(reloaded, "abc123", "bv-1"); got != "✓ confirmed" {
+		t.Errorf("feedbackLabel = %q, want confirmed", got)
+	}
+}
+
+func TestRecordCorrelationFeedback_NoStore(t *testing.T) {
+	m := NewModel(nil, nil, "")
+	m.recordCorrelationFeedback(correlation.FeedbackConfirm, "abc123", "bv-1", 0.9, "abc123")
+	if !m.statusIsError {
+		t.Error("expected an error without a beads directory")
+	}
+}
+
+func TestCassSessionModal_ShowsFeedback(t *testing.T) {
+	store := correlation.NewFeedbackStore(t.TempDir())
+	if err := store.Ignore(cassFeedbackKey("/a.jsonl"), "bv-1", "test", 0.5, ""); err != nil {
+		t.Fatal(err)
+	}
+	result := cass.CorrelationResult{
+		BeadID:      "bv-1",
+		TopSessions: []cass.ScoredResult{{SearchResult: cass.SearchResult{SourcePath: "/a.jsonl", Agent: "claude"}}},
+	}
+	modal := NewCassSessionModal("bv-1", result, testTheme())
+	modal.SetFeedbackStore(store)
+	if !strings.Contains(modal.View(), "⊘ ignored") {
+		t.Error("modal should show existing feedback for the session")
+	}
+}
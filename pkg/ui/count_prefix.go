@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countPrefixTimeout is how long a count waits for a motion in views where a
+// lone digit is a command of its own (board columns, tutorial pages). After
+// that the digits run as ordinary keys, like vim's timeoutlen.
+const countPrefixTimeout = 600 * time.Millisecond
+
+// maxCountPrefix caps a count so a stray run of digits can't spin for long
+const maxCountPrefix = 999
+
+// countPrefix accumulates a vim-style numeric prefix, such as the 5 in 5j
+// or the 10 in 10ctrl+d, which repeats the motion that follows it.
+type countPrefix struct {
+	digits string
+	seq    int // bumped per digit, so only the latest timeout counts
+}
+
+// countTimeoutMsg fires countPrefixTimeout after the digit numbered seq
+type countTimeoutMsg struct {
+	seq int
+}
+
+// feed adds key to the count if it is a digit and reports whether it was.
+// 0 only continues a count, so a bare 0 keeps its own meaning.
+func (c *countPrefix) feed(key string) bool {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' || (key == "0" && c.digits == "") {
+		return false
+	}
+	c.digits += key
+	c.seq++
+	return true
+}
+
+// pending reports whether digits have been typed
+func (c *countPrefix) pending() bool {
+	return c.digits != ""
+}
+
+// take returns the count, 1 if none was typed, and clears it
+func (c *countPrefix) take() int {
+	n, err := strconv.Atoi(c.digits)
+	c.digits = ""
+	if err != nil || n < 1 {
+		return 1
+	}
+	return min(n, maxCountPrefix)
+}
+
+// takeDigits returns the typed digits as keys and clears the count
+func (c *countPrefix) takeDigits() []tea.KeyMsg {
+	keys := make([]tea.KeyMsg, 0, len(c.digits))
+	for _, r := range c.digits {
+		keys = append(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	c.digits = ""
+	return keys
+}
+
+// expired reports whether msg is the timeout of the count still pending
+func (c *countPrefix) expired(msg countTimeoutMsg) bool {
+	return c.pending() && msg.seq == c.seq
+}
+
+// timeout schedules the countTimeoutMsg for the latest digit
+func (c *countPrefix) timeout() tea.Cmd {
+	seq := c.seq
+	return tea.Tick(countPrefixTimeout, func(time.Time) tea.Msg {
+		return countTimeoutMsg{seq: seq}
+	})
+}
+
+// String is the count as typed, for the status bar
+func (c *countPrefix) String() string {
+	return c.digits
+}
+
+// countableKeys are the motions a count repeats, per view
+var countableKeys = map[focus]map[string]bool{
+	focusList: {"j": true, "k": true, "down": true, "up": true, "ctrl+d": true, "ctrl+u": true},
+	focusBoard: {"j": true, "k": true, "down": true, "up": true, "h": true, "l": true, "left": true, "right": true,
+		"ctrl+d": true, "ctrl+u": true, "n": true, "N": true},
+	focusGraph: {"j": true, "k": true, "down": true, "up": true, "h": true, "l": true, "left": true, "right": true,
+		"ctrl+d": true, "ctrl+u": true, "pgdown": true, "pgup": true},
+}
+
+// tutorialCountableKeys are the tutorial motions a count repeats
+var tutorialCountableKeys = map[string]bool{
+	"j": true, "k": true, "down": true, "up": true, "ctrl+d": true, "ctrl+u": true,
+	"l": true, "h": true, "right": true, "left": true, "n": true, "p": true,
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func countTestModel(t *testing.T) Model {
+	t.Helper()
+	var issues []model.Issue
+	for i := 1; i <= 8; i++ {
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("cnt-%d", i), Title: "Issue", Status: model.StatusOpen, Priority: 2})
+	}
+	issues = append(issues, model.Issue{ID: "cnt-wip", Title: "Started", Status: model.StatusInProgress, Priority: 2})
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	return updated.(Model)
+}
+
+// namedKeys are the non-rune keys pressKeys understands; anything else is typed
+var namedKeys = map[string]tea.KeyType{
+	"ctrl+d": tea.KeyCtrlD,
+	"ctrl+f": tea.KeyCtrlF,
+	"ctrl+n": tea.KeyCtrlN,
+	"enter":  tea.KeyEnter,
+	"esc":    tea.KeyEsc,
+	"tab":    tea.KeyTab,
+	"up":     tea.KeyUp,
+	"down":   tea.KeyDown,
+}
+
+func pressKeys(m Model, keys ...string) Model {
+	for _, k := range keys {
+		msg := runeKey(k)
+		if t, ok := namedKeys[k]; ok {
+			msg = tea.KeyMsg{Type: t}
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestCountPrefix_Feed(t *testing.T) {
+	var c countPrefix
+	if c.feed("0") || c.pending() {
+		t.Error("a bare 0 started a count")
+	}
+	for _, k := range []string{"1", "0"} {
+		if !c.feed(k) {
+			t.Errorf("digit %s not taken", k)
+		}
+	}
+	if c.feed("j") {
+		t.Error("j taken as a digit")
+	}
+	if n := c.take(); n != 10 {
+		t.Errorf("take() = %d, want 10", n)
+	}
+	if n := c.take(); n != 1 {
+		t.Errorf("take() without a count = %d, want 1", n)
+	}
+	for range 5 {
+		c.feed("9")
+	}
+	if n := c.take(); n != maxCountPrefix {
+		t.Errorf("take() = %d, want the %d cap", n, maxCountPrefix)
+	}
+}
+
+func TestCountPrefix_List(t *testing.T) {
+	m := countTestModel(t)
+	m = pressKeys(m, "3", "j")
+	if got := m.list.Index(); got != 3 {
+		t.Errorf("3j moved the list to %d, want 3", got)
+	}
+	m = pressKeys(m, "2", "k")
+	if got := m.list.Index(); got != 1 {
+		t.Errorf("2k moved the list to %d, want 1", got)
+	}
+	// A count before a non-motion key is dropped
+	m = pressKeys(m, "4", "o")
+	before := m.list.Index()
+	m = pressKeys(m, "j")
+	if got := m.list.Index(); got != before+1 {
+		t.Errorf("j after a dropped count moved the list from %d to %d", before, got)
+	}
+}
+
+func TestCountPrefix_Board(t *testing.T) {
+	m := countTestModel(t)
+	m.isBoardView = true
+	m.focused = focusBoard
+
+	want := m
+	want = want.handleBoardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	want = want.handleBoardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+
+	m = pressKeys(m, "2", "j")
+	if got, exp := m.board.SelectedIssue(), want.board.SelectedIssue(); got == nil || exp == nil || got.ID != exp.ID {
+		t.Errorf("2j selected %v, want %v", got, exp)
+	}
+	if m.board.SelectedIssue().Status != model.StatusOpen {
+		t.Error("the 2 of 2j jumped to a column")
+	}
+
+	// A lone 2 jumps to the In Progress column once it times out
+	m = pressKeys(m, "2")
+	updated, _ := m.Update(countTimeoutMsg{seq: m.keyCount.seq})
+	m = updated.(Model)
+	if sel := m.board.SelectedIssue(); sel == nil || sel.ID != "cnt-wip" {
+		t.Errorf("lone 2 selected %v, want the in-progress issue", sel)
+	}
+}
+
+func TestCountPrefix_Graph(t *testing.T) {
+	m := countTestModel(t)
+	m.isGraphView = true
+	m.focused = focusGraph
+
+	want := m.graphView
+	for range 4 {
+		want.MoveDown()
+	}
+	m = pressKeys(m, "4", "j")
+	if got, exp := m.graphView.SelectedIssue(), want.SelectedIssue(); got == nil || exp == nil || got.ID != exp.ID {
+		t.Errorf("4j selected %v, want %v", got, exp)
+	}
+}
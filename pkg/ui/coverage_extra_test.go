@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
@@ -255,10 +257,10 @@ func TestHandleRecipePickerAndInsightsKeys(t *testing.T) {
 	}
 	m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
 	m.focused = focusInsights
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyEnter})
 
 	// Recipe picker escape path
 	m.showRecipePicker = true
@@ -515,9 +517,9 @@ func TestBoardAndInsightsExtraKeys(t *testing.T) {
 
 	// Insights escape and tab navigation
 	m.focused = focusInsights
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyTab})
-	m = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.handleInsightsKeys(tea.KeyMsg{Type: tea.KeyEsc})
 	if m.focused != focusList {
 		t.Fatalf("Esc should return focus to list")
 	}
@@ -940,3 +942,201 @@ func TestHelpOverlayScroll(t *testing.T) {
 		t.Fatalf("expected helpScroll=0 after Space, got %d", m.helpScroll)
 	}
 }
+
+func TestPastSnapshotEnterAndReturn(t *testing.T) {
+	live := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen},
+		{ID: "C", Title: "Gamma", Status: model.StatusClosed},
+	}
+	past := []model.Issue{
+		{ID: "A", Title: "Alpha (old)", Status: model.StatusOpen},
+	}
+	var tm tea.Model = NewModel(live, nil, "")
+	tm, _ = tm.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	tm, _ = tm.Update(PastSnapshotLoadedMsg{
+		Snapshot: PastSnapshot{SHA: "abc1234def", ShortSHA: "abc1234", Subject: "Import backlog\n\nbody"},
+		Issues:   past,
+	})
+	m := tm.(Model)
+
+	if m.ViewingPast() == nil || m.ViewingPast().ShortSHA != "abc1234" {
+		t.Fatalf("expected past snapshot to be active, got %+v", m.ViewingPast())
+	}
+	if len(m.list.Items()) != 1 || m.countOpen != 1 || m.countClosed != 0 {
+		t.Fatalf("expected list and counts from the snapshot, got %d items open=%d closed=%d",
+			len(m.list.Items()), m.countOpen, m.countClosed)
+	}
+	if m.focused != focusList {
+		t.Errorf("expected focus on list after loading snapshot, got %v", m.focused)
+	}
+	m.statusMsg = ""
+	if footer := m.renderFooter(); !strings.Contains(footer, "VIEWING PAST abc1234 Import backlog") {
+		t.Errorf("expected past banner in footer, got %q", footer)
+	}
+
+	// Editing is disabled while viewing the past
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	m = tm.(Model)
+	if cmd != nil || !m.statusIsError {
+		t.Error("expected open-in-editor to be refused in a past snapshot")
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = tm.(Model)
+	if m.ViewingPast() != nil {
+		t.Fatal("expected t to return to the present")
+	}
+	if len(m.list.Items()) != 3 || m.countClosed != 1 {
+		t.Fatalf("expected live data restored, got %d items closed=%d", len(m.list.Items()), m.countClosed)
+	}
+	if footer := m.renderFooter(); strings.Contains(footer, "VIEWING PAST") {
+		t.Error("banner should disappear after returning to the present")
+	}
+}
+
+func TestPastSnapshotLoadError(t *testing.T) {
+	var tm tea.Model = NewModel([]model.Issue{{ID: "A", Status: model.StatusOpen}}, nil, "")
+	tm, _ = tm.Update(PastSnapshotLoadedMsg{
+		Snapshot: PastSnapshot{SHA: "deadbeef", ShortSHA: "deadbee"},
+		Error:    errors.New("no beads file"),
+	})
+	m := tm.(Model)
+	if m.ViewingPast() != nil || !m.statusIsError {
+		t.Fatalf("expected load error to leave live data in place, got past=%v err=%v", m.ViewingPast(), m.statusIsError)
+	}
+}
+
+func TestDetailHistoryTab(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Title: "Alpha", Status: model.StatusInProgress}}
+	var tm tea.Model = NewModel(issues, nil, "")
+	tm, _ = tm.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m := tm.(Model)
+	if !m.isSplitView {
+		t.Fatal("expected split view at width 140")
+	}
+	m.focused = focusDetail
+
+	tm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = tm.(Model)
+	if m.detailTab != detailTabHistory {
+		t.Fatal("expected v to switch to the history tab")
+	}
+	if cmd == nil || m.issueChanges["A"] == nil || !m.issueChanges["A"].loading {
+		t.Fatal("expected change history to start loading")
+	}
+	if !strings.Contains(m.viewport.View(), "Loading git history") {
+		t.Errorf("expected loading placeholder, got %q", m.viewport.View())
+	}
+
+	tm, _ = m.Update(IssueChangesLoadedMsg{
+		IssueID: "A",
+		Changes: []correlation.IssueChange{
+			{CommitSHA: "abc1234def", Author: "alice", CommitMsg: "Create", Timestamp: time.Now(), Created: true},
+			{CommitSHA: "bcd2345efa", Author: "bob", CommitMsg: "Claim", Timestamp: time.Now(), Changes: []correlation.FieldChange{
+				{Field: "status", OldValue: "open", NewValue: "in_progress"},
+				{Field: "priority", OldValue: "P2", NewValue: "P1"},
+			}},
+		},
+	})
+	m = tm.(Model)
+	view := m.viewport.View()
+	for _, want := range []string{"abc1234", "created", "bcd2345", "progress", "P1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("history tab missing %q:\n%s", want, view)
+		}
+	}
+
+	// A second v returns to the details tab without reloading
+	tm, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = tm.(Model)
+	if m.detailTab != detailTabInfo || cmd != nil {
+		t.Errorf("expected details tab without a load command, tab=%v", m.detailTab)
+	}
+}
+
+func TestDetailBlameHeader(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Title: "Alpha", Status: model.StatusInProgress, Priority: 1}}
+	var tm tea.Model = NewModel(issues, nil, "")
+	tm, _ = tm.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m := tm.(Model)
+
+	if cmd := m.blameCmd(); cmd == nil || !m.blameLoading {
+		t.Fatal("expected blame to start loading with the detail pane visible")
+	}
+	if cmd := m.blameCmd(); cmd != nil {
+		t.Error("blame should load only once")
+	}
+
+	at := func(value, author string) *correlation.FieldAttribution {
+		return &correlation.FieldAttribution{Value: value, Author: author, CommitSHA: "abc1234def", Timestamp: time.Now().Add(-time.Hour)}
+	}
+	tm, _ = m.Update(BlameLoadedMsg{Blame: map[string]*correlation.IssueBlame{
+		"A": {IssueID: "A", Status: at("in_progress", "alice"), Priority: at("P2", "bob")},
+	}})
+	m = tm.(Model)
+
+	view := m.viewport.View()
+	for _, want := range []string{"Last changed", "alice", "abc1234", "uncommitted"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail header missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "bob") {
+		t.Error("priority differs from the committed value; its author should not be shown")
+	}
+}
+
+func TestSplitViewResizeAndOrientation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = tm.(Model)
+	if !m.isSplitView || m.splitStacked {
+		t.Fatalf("expected side-by-side split on a wide terminal")
+	}
+	before := m.list.Width()
+
+	key := func(k tea.KeyMsg) {
+		tm, _ = m.Update(k)
+		m = tm.(Model)
+	}
+	key(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if m.list.Width() <= before || m.splitRatio <= DefaultSplitRatio {
+		t.Fatalf("ctrl+l should grow the list pane: width %d -> %d, ratio %.2f", before, m.list.Width(), m.splitRatio)
+	}
+	for i := 0; i < 20; i++ {
+		key(tea.KeyMsg{Type: tea.KeyCtrlH})
+	}
+	if m.splitRatio != MinSplitRatio {
+		t.Errorf("ratio should clamp at %.2f, got %.2f", MinSplitRatio, m.splitRatio)
+	}
+
+	// Auto stacks the panes on a narrow but tall terminal
+	tm, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = tm.(Model)
+	if !m.isSplitView || !m.splitStacked {
+		t.Fatalf("expected stacked split on a narrow tall terminal")
+	}
+	if view := m.renderSplitView(); lipgloss.Height(view) > m.height-1 || lipgloss.Width(view) > m.width {
+		t.Errorf("stacked view %dx%d overflows the %dx%d body", lipgloss.Width(view), lipgloss.Height(view), m.width, m.height-1)
+	}
+
+	// | cycles to forced side-by-side, which needs a wide terminal
+	key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	if m.splitOrientation != SplitVertical || m.isSplitView {
+		t.Errorf("forced side-by-side on a narrow terminal should disable the split, got %v split=%v", m.splitOrientation, m.isSplitView)
+	}
+
+	// The layout is remembered for the next session
+	if saved := LoadUIState(); saved.SplitRatio != MinSplitRatio || saved.SplitOrientation != SplitVertical {
+		t.Errorf("layout not persisted: %+v", saved)
+	}
+	if next := NewModel(issues, nil, ""); next.splitRatio != MinSplitRatio || next.splitOrientation != SplitVertical {
+		t.Errorf("new model should restore the layout, got %.2f %v", next.splitRatio, next.splitOrientation)
+	}
+}
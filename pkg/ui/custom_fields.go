@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// CustomFieldsConfigFilename is the project config that controls how custom
+// fields appear in the detail view.
+const CustomFieldsConfigFilename = "custom_fields.yaml"
+
+// CustomFieldsConfig orders and hides custom fields in the detail view.
+type CustomFieldsConfig struct {
+	// Order lists fields shown first, in this order. Other fields follow
+	// alphabetically.
+	Order []string `yaml:"order,omitempty"`
+
+	// Hidden lists fields left out of the detail view.
+	Hidden []string `yaml:"hidden,omitempty"`
+}
+
+// LoadCustomFieldsConfig loads .bv/custom_fields.yaml from projectDir.
+// Returns an empty config if the file doesn't exist.
+func LoadCustomFieldsConfig(projectDir string) (*CustomFieldsConfig, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, ".bv", CustomFieldsConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &CustomFieldsConfig{}, nil
+		}
+		return nil, fmt.Errorf("reading custom fields config: %w", err)
+	}
+
+	config := &CustomFieldsConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing custom fields config: %w", err)
+	}
+	return config, nil
+}
+
+// loadCustomFieldsConfig finds the project config next to the beads file,
+// falling back to an empty config when it is missing or invalid.
+func loadCustomFieldsConfig(beadsPath string) *CustomFieldsConfig {
+	projectDir, err := historyRepoPath(beadsPath)
+	if err != nil {
+		return &CustomFieldsConfig{}
+	}
+	config, err := LoadCustomFieldsConfig(projectDir)
+	if err != nil {
+		return &CustomFieldsConfig{}
+	}
+	return config
+}
+
+// FieldNames returns the custom field names of issue in display order.
+func (c *CustomFieldsConfig) FieldNames(issue model.Issue) []string {
+	names := issue.CustomFieldNames()
+	if c == nil || len(names) == 0 {
+		return names
+	}
+
+	hidden := make(map[string]bool, len(c.Hidden))
+	for _, name := range c.Hidden {
+		hidden[name] = true
+	}
+
+	ordered := make([]string, 0, len(names))
+	listed := make(map[string]bool, len(c.Order))
+	for _, name := range c.Order {
+		if _, ok := issue.CustomFields[name]; ok && !hidden[name] && !listed[name] {
+			ordered = append(ordered, name)
+			listed[name] = true
+		}
+	}
+	for _, name := range names {
+		if !hidden[name] && !listed[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+// renderCustomFieldsMD renders the issue's custom fields as a markdown table
+// for the detail view.
+func (c *CustomFieldsConfig) renderCustomFieldsMD(issue model.Issue) string {
+	names := c.FieldNames(issue)
+	if len(names) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### Custom Fields\n")
+	sb.WriteString("| Field | Value |\n|---|---|\n")
+	for _, name := range names {
+		value := model.FormatCustomField(issue.CustomFields[name])
+		value = strings.ReplaceAll(value, "|", "\\|")
+		value = strings.ReplaceAll(value, "\n", " ")
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", name, value))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
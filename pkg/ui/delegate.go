@@ -19,6 +19,14 @@ type IssueDelegate struct {
 	PriorityHints     map[string]*analysis.PriorityRecommendation
 	WorkspaceMode     bool // When true, shows repo prefix badges
 	ShowSearchScores  bool // Show semantic/hybrid score badge when search is active
+	ShowRiskHeatmap   bool // Prefix rows with a heat-colored composite risk cell
+	RiskScores        map[string]float64
+	SessionCounts     map[string]int          // Correlated cass sessions per bead
+	WorkingOn         map[string]bool         // Issues pinned by the current git branch
+	Starred           map[string]bool         // Issues starred by the user
+	EpicProgress      map[string]epicProgress // Closed/total children of epics
+	OpenBlockers      map[string][]string     // Unclosed blockers of blocked issues
+	Accessible        bool                    // Plain words instead of icons and badges
 }
 
 func (d IssueDelegate) Height() int {
@@ -49,6 +57,11 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 	isSelected := index == m.Index()
 
+	if d.Accessible {
+		fmt.Fprint(w, accessibleRow(i, isSelected, width))
+		return
+	}
+
 	// ══════════════════════════════════════════════════════════════════════════
 	// POLISHED ROW LAYOUT - Stripe-level visual hierarchy
 	// Layout: [sel] [type] [prio-badge] [status-badge] [ID] [title...] [meta]
@@ -68,6 +81,20 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	rightWidth := 0
 	var rightParts []string
 
+	// Blocked reason: the open blockers, e.g. "⛔ 2: bv-x, bv-y"
+	if ids := d.OpenBlockers[i.Issue.ID]; len(ids) > 0 && width > 50 {
+		annotation := t.Renderer.NewStyle().Foreground(t.Blocked).Render(truncateRunesHelper(blockerAnnotation(ids), 30, "…"))
+		rightParts = append(rightParts, annotation)
+		rightWidth += lipgloss.Width(annotation) + 1
+	}
+
+	// Epic progress: share of children closed
+	if p, ok := d.EpicProgress[i.Issue.ID]; ok && width > 60 {
+		bar := renderEpicProgress(p, 8, t)
+		rightParts = append(rightParts, bar)
+		rightWidth += lipgloss.Width(bar) + 1
+	}
+
 	// Show Age and Comments only if we have reasonable width
 	if width > 60 {
 		// Age - with subtle styling
@@ -87,6 +114,15 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		}
 	}
 
+	// Correlated cass coding sessions
+	if width > 80 {
+		if n := d.SessionCounts[i.Issue.ID]; n > 0 {
+			sessionStr := fmt.Sprintf("sessions: %d", n)
+			rightParts = append(rightParts, t.Renderer.NewStyle().Foreground(ColorInfo).Render(sessionStr))
+			rightWidth += lipgloss.Width(sessionStr) + 1
+		}
+	}
+
 	// Sparkline (Graph Score) - visualization of importance
 	if width > 120 {
 		spark := RenderSparkline(i.GraphScore, 5)
@@ -107,12 +143,22 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	// Labels (if present and we have room) - render as mini tags
 	if width > 140 && len(i.Issue.Labels) > 0 {
 		labelStr := truncateRunesHelper(strings.Join(i.Issue.Labels, ","), 20, "…")
-		labelStyle := t.Renderer.NewStyle().
-			Foreground(ColorPrimary).
-			Background(ColorBgSubtle).
-			Padding(0, 1)
-		rightParts = append(rightParts, labelStyle.Render(labelStr))
-		rightWidth += lipgloss.Width(labelStyle.Render(labelStr)) + 1
+		pillStyle := t.Renderer.NewStyle().Background(ColorBgSubtle)
+		var pill strings.Builder
+		pill.WriteString(pillStyle.Render(" "))
+		for n, part := range strings.Split(labelStr, ",") {
+			if n > 0 {
+				pill.WriteString(pillStyle.Foreground(ColorSecondary).Render(","))
+			}
+			var color lipgloss.TerminalColor = ColorPrimary
+			if n < len(i.Issue.Labels) {
+				color = t.LabelColor(i.Issue.Labels[n])
+			}
+			pill.WriteString(pillStyle.Foreground(color).Render(part))
+		}
+		pill.WriteString(pillStyle.Render(" "))
+		rightParts = append(rightParts, pill.String())
+		rightWidth += lipgloss.Width(pill.String()) + 1
 	}
 
 	// Left side fixed columns with polished badges
@@ -138,6 +184,11 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftFixedWidth += 2
 	}
 
+	// Risk heatmap cell
+	if d.ShowRiskHeatmap {
+		leftFixedWidth += 4
+	}
+
 	// Triage indicator width (bv-151) - use lipgloss.Width for accurate emoji measurement
 	if i.IsQuickWin {
 		leftFixedWidth += lipgloss.Width("⭐") + 1 // emoji + space
@@ -174,6 +225,18 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftFixedWidth += lipgloss.Width(badge) + 1
 	}
 
+	// Working-on badge width
+	pinned := d.WorkingOn[i.Issue.ID]
+	if pinned {
+		leftFixedWidth += lipgloss.Width(workingOnBadge) + 1
+	}
+
+	// Star badge width
+	starred := d.Starred[i.Issue.ID]
+	if starred {
+		leftFixedWidth += lipgloss.Width(starredBadge) + 1
+	}
+
 	// Title gets everything in between
 	titleWidth := width - leftFixedWidth - rightWidth - 2
 	if titleWidth < 5 {
@@ -201,6 +264,13 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftSide.WriteString("  ")
 	}
 
+	// Risk heatmap cell
+	if d.ShowRiskHeatmap {
+		score, ok := d.RiskScores[i.Issue.ID]
+		leftSide.WriteString(RenderRiskCell(score, ok))
+		leftSide.WriteString(" ")
+	}
+
 	// Repo badge (workspace mode)
 	if repoBadge != "" {
 		leftSide.WriteString(repoBadge)
@@ -267,6 +337,18 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftSide.WriteString(" ")
 	}
 
+	// Working-on badge (current branch)
+	if pinned {
+		leftSide.WriteString(workingOnBadge)
+		leftSide.WriteString(" ")
+	}
+
+	// Star badge
+	if starred {
+		leftSide.WriteString(t.Renderer.NewStyle().Foreground(ColorWarning).Render(starredBadge))
+		leftSide.WriteString(" ")
+	}
+
 	// Title with emphasis when selected
 	titleStyle := t.Renderer.NewStyle()
 	if isSelected {
@@ -292,6 +374,10 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 	// Apply row background for selection and clamp width
 	rowStyle := t.Renderer.NewStyle().Width(width).MaxWidth(width)
+	// Underline the last pinned row to close the "Working on" section
+	if pinned && !d.pinnedAt(m, index+1) {
+		rowStyle = rowStyle.Underline(true)
+	}
 	if isSelected {
 		row = rowStyle.Background(t.Highlight).Render(row)
 	} else {
@@ -300,3 +386,13 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 	fmt.Fprint(w, row)
 }
+
+// pinnedAt reports whether the list item at index is pinned by the current branch
+func (d IssueDelegate) pinnedAt(m list.Model, index int) bool {
+	items := m.Items()
+	if index < 0 || index >= len(items) {
+		return false
+	}
+	item, ok := items[index].(IssueItem)
+	return ok && d.WorkingOn[item.Issue.ID]
+}
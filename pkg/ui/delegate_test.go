@@ -139,3 +139,28 @@ func TestIssueDelegate_RenderNarrow(t *testing.T) {
 		t.Fatalf("narrow output should hide comments count: %q", out)
 	}
 }
+
+func TestIssueDelegate_RenderSessionCount(t *testing.T) {
+	item := newTestIssueItem("api-7")
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	delegate := IssueDelegate{
+		Theme:         theme,
+		SessionCounts: map[string]int{"api-7": 3},
+	}
+
+	l := list.New([]list.Item{item}, delegate, 0, 0)
+	l.SetWidth(120)
+
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, item)
+	if !strings.Contains(buf.String(), "sessions: 3") {
+		t.Fatalf("expected session badge, got %q", buf.String())
+	}
+
+	delegate.SessionCounts = nil
+	buf.Reset()
+	delegate.Render(&buf, l, 0, item)
+	if strings.Contains(buf.String(), "sessions:") {
+		t.Fatalf("expected no session badge without counts, got %q", buf.String())
+	}
+}
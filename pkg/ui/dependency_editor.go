@@ -0,0 +1,579 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// depEditorTypes are the link types the editor can add, in tab order
+var depEditorTypes = []model.DependencyType{model.DepBlocks, model.DepRelated, model.DepParentChild}
+
+// DependenciesSavedMsg is sent after the dependency editor writes an issue
+type DependenciesSavedMsg struct {
+	IssueID string
+	Changes []string // e.g. "+blocks bv-2", "-related bv-3"
+	Err     error
+}
+
+// DependencyEditorModel edits the outgoing dependencies of one issue:
+// existing links can be removed and new ones added by fuzzy-searching
+// other issues. Changes are staged until saved.
+type DependencyEditorModel struct {
+	issue    model.Issue
+	issues   []model.Issue
+	original []*model.Dependency
+	deps     []*model.Dependency // Working copy
+	cursor   int
+
+	adding     bool // Search mode for a new link
+	input      textinput.Model
+	candidates []model.Issue
+	candIdx    int
+	depType    model.DependencyType
+
+	confirmCycles bool // Save was requested once despite cycles
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewDependencyEditorModel opens the editor for issue; issues are all loaded
+// issues, used for the search and for cycle detection
+func NewDependencyEditorModel(issue model.Issue, issues []model.Issue, theme Theme) DependencyEditorModel {
+	ti := textinput.New()
+	ti.Placeholder = "search issues..."
+	ti.CharLimit = 80
+	ti.Width = 40
+
+	deps := make([]*model.Dependency, 0, len(issue.Dependencies))
+	for _, dep := range issue.Dependencies {
+		if dep != nil {
+			d := *dep
+			deps = append(deps, &d)
+		}
+	}
+	original := make([]*model.Dependency, len(deps))
+	copy(original, deps)
+
+	return DependencyEditorModel{
+		issue:    issue,
+		issues:   issues,
+		original: original,
+		deps:     deps,
+		input:    ti,
+		depType:  model.DepBlocks,
+		theme:    theme,
+	}
+}
+
+// SetSize updates the editor dimensions
+func (m *DependencyEditorModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// IssueID returns the issue being edited
+func (m *DependencyEditorModel) IssueID() string {
+	return m.issue.ID
+}
+
+// Dependencies returns the edited dependency list
+func (m *DependencyEditorModel) Dependencies() []*model.Dependency {
+	return m.deps
+}
+
+// Adding reports whether the editor is searching for a new link
+func (m *DependencyEditorModel) Adding() bool {
+	return m.adding
+}
+
+// MoveUp moves the cursor in the current list
+func (m *DependencyEditorModel) MoveUp() {
+	if m.adding {
+		if m.candIdx > 0 {
+			m.candIdx--
+		}
+	} else if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor in the current list
+func (m *DependencyEditorModel) MoveDown() {
+	if m.adding {
+		if m.candIdx < len(m.candidates)-1 {
+			m.candIdx++
+		}
+	} else if m.cursor < len(m.deps)-1 {
+		m.cursor++
+	}
+}
+
+// StartAdding switches to the issue search
+func (m *DependencyEditorModel) StartAdding() {
+	m.adding = true
+	m.input.SetValue("")
+	m.input.Focus()
+	m.filterCandidates()
+}
+
+// StopAdding returns to the dependency list without adding
+func (m *DependencyEditorModel) StopAdding() {
+	m.adding = false
+	m.input.Blur()
+}
+
+// CycleType switches the type of the link being added
+func (m *DependencyEditorModel) CycleType() {
+	for i, t := range depEditorTypes {
+		if t == m.depType {
+			m.depType = depEditorTypes[(i+1)%len(depEditorTypes)]
+			return
+		}
+	}
+	m.depType = depEditorTypes[0]
+}
+
+// UpdateInput processes a key message for the search input
+func (m *DependencyEditorModel) UpdateInput(msg tea.Msg) {
+	m.input, _ = m.input.Update(msg)
+	m.filterCandidates()
+}
+
+// SelectedCandidate returns the highlighted search result
+func (m *DependencyEditorModel) SelectedCandidate() *model.Issue {
+	if !m.adding || m.candIdx < 0 || m.candIdx >= len(m.candidates) {
+		return nil
+	}
+	return &m.candidates[m.candIdx]
+}
+
+// AddSelected links the highlighted search result with the current type.
+// It returns a problem description when nothing was added.
+func (m *DependencyEditorModel) AddSelected() string {
+	target := m.SelectedCandidate()
+	if target == nil {
+		return "no matching issue"
+	}
+	if m.hasLink(target.ID, m.depType) {
+		return fmt.Sprintf("%s is already linked as %s", target.ID, m.depType)
+	}
+	m.deps = append(m.deps, &model.Dependency{
+		IssueID:     m.issue.ID,
+		DependsOnID: target.ID,
+		Type:        m.depType,
+		CreatedAt:   time.Now().UTC(),
+		CreatedBy:   os.Getenv("USER"),
+	})
+	m.cursor = len(m.deps) - 1
+	m.confirmCycles = false
+	m.StopAdding()
+	return ""
+}
+
+// RemoveSelected drops the dependency under the cursor
+func (m *DependencyEditorModel) RemoveSelected() {
+	if m.cursor < 0 || m.cursor >= len(m.deps) {
+		return
+	}
+	m.deps = append(m.deps[:m.cursor:m.cursor], m.deps[m.cursor+1:]...)
+	if m.cursor >= len(m.deps) && m.cursor > 0 {
+		m.cursor--
+	}
+	m.confirmCycles = false
+}
+
+// Changes describes the staged edits, e.g. "+blocks bv-2" and "-related bv-3"
+func (m *DependencyEditorModel) Changes() []string {
+	key := func(d *model.Dependency) string { return string(depTypeOrDefault(d.Type)) + " " + d.DependsOnID }
+	before := make(map[string]bool, len(m.original))
+	for _, d := range m.original {
+		before[key(d)] = true
+	}
+	after := make(map[string]bool, len(m.deps))
+	for _, d := range m.deps {
+		after[key(d)] = true
+	}
+
+	var changes []string
+	for _, d := range m.deps {
+		if !before[key(d)] {
+			changes = append(changes, "+"+key(d))
+		}
+	}
+	for _, d := range m.original {
+		if !after[key(d)] {
+			changes = append(changes, "-"+key(d))
+		}
+	}
+	return changes
+}
+
+// RequestSave reports whether the edits can be written. When the edits
+// introduce cycles the first request only arms a confirmation.
+func (m *DependencyEditorModel) RequestSave() bool {
+	if len(m.newCycles()) > 0 && !m.confirmCycles {
+		m.confirmCycles = true
+		return false
+	}
+	return true
+}
+
+// cycleFor returns the cycle that linking the issue to targetID with type
+// t would close, or nil. Related links never form cycles; blocking and
+// parent-child links are checked within their own kind.
+func (m *DependencyEditorModel) cycleFor(targetID string, t model.DependencyType, exclude *model.Dependency) []string {
+	t = depTypeOrDefault(t)
+	if t == model.DepRelated || t == model.DepDiscoveredFrom {
+		return nil
+	}
+	graph := make([]model.Issue, 0, len(m.issues)+1)
+	for _, issue := range m.issues {
+		if issue.ID == m.issue.ID {
+			continue
+		}
+		graph = append(graph, model.Issue{ID: issue.ID, Dependencies: depsOfType(issue.Dependencies, t, nil)})
+	}
+	graph = append(graph, model.Issue{ID: m.issue.ID, Dependencies: depsOfType(m.deps, t, exclude)})
+
+	if cyclic, path := analysis.WouldCreateCycle(graph, m.issue.ID, targetID); cyclic {
+		return path
+	}
+	return nil
+}
+
+// newCycles returns the cycles closed by links added in this session
+func (m *DependencyEditorModel) newCycles() [][]string {
+	existing := make(map[*model.Dependency]bool, len(m.original))
+	for _, d := range m.original {
+		existing[d] = true
+	}
+	var cycles [][]string
+	for _, d := range m.deps {
+		if existing[d] {
+			continue
+		}
+		if path := m.cycleFor(d.DependsOnID, d.Type, d); path != nil {
+			cycles = append(cycles, path)
+		}
+	}
+	return cycles
+}
+
+func (m *DependencyEditorModel) hasLink(targetID string, t model.DependencyType) bool {
+	for _, d := range m.deps {
+		if d.DependsOnID == targetID && depTypeOrDefault(d.Type) == depTypeOrDefault(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterCandidates fuzzy-matches the query against issue IDs and titles
+func (m *DependencyEditorModel) filterCandidates() {
+	query := strings.TrimSpace(m.input.Value())
+	type scored struct {
+		issue model.Issue
+		score int
+	}
+	var matches []scored
+	for _, issue := range m.issues {
+		if issue.ID == m.issue.ID {
+			continue
+		}
+		score := 1
+		if query != "" {
+			score = fuzzyScore(issue.ID, query) * 2
+			if s := fuzzyScore(issue.Title, query); s > score {
+				score = s
+			}
+		}
+		if score > 0 {
+			matches = append(matches, scored{issue, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		// Open work first, then by ID
+		ci, cj := matches[i].issue.Status.IsClosed(), matches[j].issue.Status.IsClosed()
+		if ci != cj {
+			return !ci
+		}
+		return matches[i].issue.ID < matches[j].issue.ID
+	})
+
+	m.candidates = make([]model.Issue, len(matches))
+	for i, match := range matches {
+		m.candidates[i] = match.issue
+	}
+	m.candIdx = 0
+}
+
+// depsOfType returns the links of type t, leaving out exclude
+func depsOfType(deps []*model.Dependency, t model.DependencyType, exclude *model.Dependency) []*model.Dependency {
+	var out []*model.Dependency
+	for _, d := range deps {
+		if d != nil && d != exclude && depTypeOrDefault(d.Type) == t {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// depTypeOrDefault treats an empty type as blocks, as the loader does
+func depTypeOrDefault(t model.DependencyType) model.DependencyType {
+	if t == "" {
+		return model.DepBlocks
+	}
+	return t
+}
+
+// depLinkLabel describes an outgoing link from the edited issue's side
+func depLinkLabel(t model.DependencyType) string {
+	switch depTypeOrDefault(t) {
+	case model.DepBlocks:
+		return "blocked by"
+	case model.DepRelated:
+		return "related to"
+	case model.DepParentChild:
+		return "child of"
+	case model.DepDiscoveredFrom:
+		return "discovered from"
+	default:
+		return string(t)
+	}
+}
+
+// View renders the dependency editor overlay
+func (m *DependencyEditorModel) View() string {
+	if m.width == 0 {
+		m.width = 80
+	}
+	if m.height == 0 {
+		m.height = 24
+	}
+	t := m.theme
+
+	boxWidth := 64
+	if m.width-6 < boxWidth {
+		boxWidth = m.width - 6
+	}
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+	maxVisible := m.height - 16
+	if maxVisible > 10 {
+		maxVisible = 10
+	}
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	itemStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	issueLine := func(prefix string, issue *model.Issue, id string, width int) string {
+		title := "(unknown issue)"
+		status := ""
+		if issue != nil {
+			title = issue.Title
+			status = " [" + string(issue.Status) + "]"
+		}
+		return truncateRunesHelper(prefix+id+status+" "+title, width, "…")
+	}
+	issueByID := func(id string) *model.Issue {
+		for i := range m.issues {
+			if m.issues[i].ID == id {
+				return &m.issues[i]
+			}
+		}
+		return nil
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Dependencies of "+m.issue.ID))
+	lines = append(lines, dimStyle.Render(truncateRunesHelper(m.issue.Title, boxWidth-6, "…")))
+	lines = append(lines, "")
+
+	if len(m.deps) == 0 {
+		lines = append(lines, dimStyle.Render("  No dependencies"))
+	}
+	for i, d := range m.deps {
+		prefix := "  "
+		style := itemStyle
+		if !m.adding && i == m.cursor {
+			prefix = "> "
+			style = selStyle
+		}
+		label := fmt.Sprintf("%-15s ", depLinkLabel(d.Type))
+		lines = append(lines, style.Render(issueLine(prefix+label, issueByID(d.DependsOnID), d.DependsOnID, boxWidth-6)))
+	}
+
+	if changes := m.Changes(); len(changes) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, dimStyle.Render(truncateRunesHelper("Unsaved: "+strings.Join(changes, ", "), boxWidth-6, "…")))
+	}
+	for _, cycle := range m.newCycles() {
+		lines = append(lines, warnStyle.Render(truncateRunesHelper("⚠ Cycle: "+strings.Join(cycle, " → "), boxWidth-6, "…")))
+	}
+	if m.confirmCycles {
+		lines = append(lines, warnStyle.Render("Press ctrl+s again to save with the cycle"))
+	}
+
+	if m.adding {
+		lines = append(lines, "")
+		lines = append(lines, titleStyle.Render("Add: "+depLinkLabel(m.depType)+" …")+dimStyle.Render("  (tab: type)"))
+		inputStyle := t.Renderer.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(t.Secondary).
+			Padding(0, 1).
+			Width(boxWidth - 6)
+		lines = append(lines, inputStyle.Render(m.input.View()))
+
+		if len(m.candidates) == 0 {
+			lines = append(lines, dimStyle.Render("  No matching issues"))
+		} else {
+			start := 0
+			if m.candIdx >= maxVisible {
+				start = m.candIdx - maxVisible + 1
+			}
+			end := start + maxVisible
+			if end > len(m.candidates) {
+				end = len(m.candidates)
+			}
+			for i := start; i < end; i++ {
+				c := m.candidates[i]
+				prefix := "  "
+				style := itemStyle
+				if i == m.candIdx {
+					prefix = "> "
+					style = selStyle
+				}
+				lines = append(lines, style.Render(issueLine(prefix, &c, c.ID, boxWidth-6)))
+			}
+		}
+
+		// Immediate feedback for the highlighted result
+		if c := m.SelectedCandidate(); c != nil {
+			if m.hasLink(c.ID, m.depType) {
+				lines = append(lines, dimStyle.Render("Already linked as "+string(m.depType)))
+			} else if cycle := m.cycleFor(c.ID, m.depType, nil); cycle != nil {
+				lines = append(lines, warnStyle.Render(truncateRunesHelper("⚠ Would create cycle: "+strings.Join(cycle, " → "), boxWidth-6, "…")))
+			}
+		}
+	}
+
+	lines = append(lines, "")
+	if m.adding {
+		lines = append(lines, dimStyle.Render("↑/↓: navigate | tab: type | enter: add | esc: back"))
+	} else {
+		lines = append(lines, dimStyle.Render("a: add | x: remove | ctrl+s: save | esc: cancel"))
+	}
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openDependencyEditor opens the editor for the selected issue
+func (m *Model) openDependencyEditor() {
+	if m.refuseReadOnly() {
+		return
+	}
+	sel, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return
+	}
+	m.depEditor = NewDependencyEditorModel(sel.Issue, m.issues, m.theme)
+	m.depEditor.SetSize(m.width, m.height-1)
+	m.showDepEditor = true
+}
+
+// handleDependencyEditorKeys handles keys while the dependency editor is open
+func (m Model) handleDependencyEditorKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	ed := &m.depEditor
+	if ed.Adding() {
+		switch msg.String() {
+		case "esc":
+			ed.StopAdding()
+		case "up", "ctrl+p", "ctrl+k":
+			ed.MoveUp()
+		case "down", "ctrl+n", "ctrl+j":
+			ed.MoveDown()
+		case "tab":
+			ed.CycleType()
+		case "enter":
+			if problem := ed.AddSelected(); problem != "" {
+				m.statusMsg = "❌ " + problem
+				m.statusIsError = true
+			}
+		default:
+			ed.UpdateInput(msg)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		if len(ed.Changes()) > 0 {
+			m.statusMsg = fmt.Sprintf("Discarded dependency changes to %s", ed.IssueID())
+			m.statusIsError = false
+		}
+		m.showDepEditor = false
+	case "j", "down":
+		ed.MoveDown()
+	case "k", "up":
+		ed.MoveUp()
+	case "a", "+", "/":
+		ed.StartAdding()
+	case "x", "d", "delete", "backspace":
+		ed.RemoveSelected()
+	case "ctrl+s", "enter":
+		changes := ed.Changes()
+		if len(changes) == 0 {
+			m.statusMsg = fmt.Sprintf("🔗 No dependency changes to %s", ed.IssueID())
+			m.statusIsError = false
+			m.showDepEditor = false
+			return m, nil
+		}
+		if !ed.RequestSave() {
+			return m, nil
+		}
+		m.showDepEditor = false
+		return m, saveDependenciesCmd(m.beadsPath, ed.IssueID(), ed.Dependencies(), changes)
+	}
+	return m, nil
+}
+
+// saveDependenciesCmd writes an issue's dependencies to the beads file
+func saveDependenciesCmd(beadsPath, issueID string, deps []*model.Dependency, changes []string) tea.Cmd {
+	return func() tea.Msg {
+		err := loader.UpdateDependenciesInFile(beadsPath, issueID, deps)
+		return DependenciesSavedMsg{IssueID: issueID, Changes: changes, Err: err}
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDependencyEditorCycles(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "C", Title: "Gamma", Status: model.StatusOpen},
+	}
+	ed := NewDependencyEditorModel(issues[0], issues, DefaultTheme(nil))
+
+	if path := ed.cycleFor("B", model.DepBlocks, nil); strings.Join(path, ",") != "A,B,A" {
+		t.Errorf("A blocked by B should close a cycle, got %v", path)
+	}
+	if path := ed.cycleFor("B", model.DepRelated, nil); path != nil {
+		t.Errorf("related links never form cycles, got %v", path)
+	}
+	if path := ed.cycleFor("B", model.DepParentChild, nil); path != nil {
+		t.Errorf("parent-child is checked separately from blocks, got %v", path)
+	}
+
+	ed.StartAdding()
+	ed.UpdateInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("beta")})
+	if c := ed.SelectedCandidate(); c == nil || c.ID != "B" {
+		t.Fatalf("search for beta should select B, got %+v", c)
+	}
+	if view := ed.View(); !strings.Contains(view, "Would create cycle") {
+		t.Errorf("expected an immediate cycle warning:\n%s", view)
+	}
+	if problem := ed.AddSelected(); problem != "" {
+		t.Fatalf("AddSelected: %s", problem)
+	}
+	if ed.RequestSave() {
+		t.Fatal("first save with a cycle should ask for confirmation")
+	}
+	if !ed.RequestSave() {
+		t.Fatal("second save should go through")
+	}
+
+	ed.RemoveSelected()
+	if len(ed.Changes()) != 0 || len(ed.newCycles()) != 0 {
+		t.Errorf("removing the new link should leave no changes, got %v", ed.Changes())
+	}
+}
+
+func TestDependencyEditorSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"C","type":"related"}]}
+{"id":"B","title":"Beta","status":"open","priority":1,"issue_type":"task"}
+{"id":"C","title":"Gamma","status":"open","priority":1,"issue_type":"task"}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	m.selectIssueInList("A")
+	send := func(msg tea.KeyMsg) tea.Cmd {
+		tm, cmd := m.Update(msg)
+		m = tm.(Model)
+		return cmd
+	}
+	runes := func(k string) tea.Cmd { return send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+
+	runes("D")
+	if !m.showDepEditor {
+		t.Fatal("D should open the dependency editor")
+	}
+	runes("x") // Remove the related link to C
+	runes("a")
+	for _, r := range "beta" { // Letters must reach the search, not global keys
+		runes(string(r))
+	}
+	if m.isBoardView || m.depEditor.SelectedCandidate() == nil || m.depEditor.SelectedCandidate().ID != "B" {
+		t.Fatalf("search should select B")
+	}
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	cmd := send(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil || m.showDepEditor {
+		t.Fatal("ctrl+s should close the editor and save")
+	}
+	saved, ok := cmd().(DependenciesSavedMsg)
+	if !ok || saved.Err != nil {
+		t.Fatalf("unexpected save result %+v", saved)
+	}
+	if strings.Join(saved.Changes, ",") != "+blocks B,-related C" {
+		t.Errorf("unexpected changes %v", saved.Changes)
+	}
+
+	reloaded, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps := reloaded[0].Dependencies
+	if len(deps) != 1 || deps[0].DependsOnID != "B" || deps[0].Type != model.DepBlocks {
+		t.Errorf("unexpected saved dependencies %+v", deps)
+	}
+
+	// Past snapshots are read-only
+	m.pastSnapshot = &PastSnapshot{}
+	runes("D")
+	if m.showDepEditor || !m.statusIsError {
+		t.Error("dependency editor should refuse read-only views")
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DossierReadyMsg carries the impact dossier of an issue as Markdown.
+type DossierReadyMsg struct {
+	IssueID  string
+	Markdown string
+	Error    error
+}
+
+// ImpactDossierCmd builds the impact dossier of an issue in the background;
+// it needs a full graph analysis.
+func ImpactDossierCmd(issues []model.Issue, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		dossier, err := analysis.NewAnalyzer(issues).ImpactDossier(issueID, time.Now())
+		if err != nil {
+			return DossierReadyMsg{IssueID: issueID, Error: err}
+		}
+		return DossierReadyMsg{IssueID: issueID, Markdown: export.RenderImpactDossier(dossier)}
+	}
+}
+
+// copyDossierCmd starts building the dossier of the selected issue
+func (m *Model) copyDossierCmd() tea.Cmd {
+	issueItem, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return nil
+	}
+	m.statusMsg = fmt.Sprintf("Building impact dossier for %s…", issueItem.Issue.ID)
+	m.statusIsError = false
+	return ImpactDossierCmd(analysisScope(m.issues), issueItem.Issue.ID)
+}
+
+// handleDossierReady copies a finished dossier to the clipboard
+func (m *Model) handleDossierReady(msg DossierReadyMsg) {
+	if msg.Error != nil {
+		m.statusMsg = fmt.Sprintf("❌ Dossier error: %v", msg.Error)
+		m.statusIsError = true
+		return
+	}
+	via, err := copyText(msg.Markdown)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("📋 Copied impact dossier of %s", msg.IssueID)
+	if via != "" {
+		m.statusMsg += " (via " + via + ")"
+	}
+	m.statusIsError = false
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// effortGroupings are the tabs of the effort rollup, in Tab order
+var effortGroupings = []string{"Epic", "Label", "Assignee"}
+
+// renderEffortMD renders the estimate line of the detail view. Issues with
+// children also get the effort rolled up over them.
+func (m *Model) renderEffortMD(issue model.Issue) string {
+	var sb strings.Builder
+	if minutes := estimateMinutes(issue); minutes > 0 {
+		sb.WriteString(fmt.Sprintf("**Estimate:** %s\n\n", formatEstimate(minutes)))
+	}
+	if hasChildren(m.issues, issue.ID) {
+		total := analysis.ComputeIssueEffort(m.issues, issue.ID)
+		sb.WriteString(fmt.Sprintf("**Effort with children:** %s\n\n", effortSummary(total)))
+	}
+	return sb.String()
+}
+
+// hasChildren reports whether any issue is a parent-child child of id
+func hasChildren(issues []model.Issue, id string) bool {
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild && dep.DependsOnID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// effortSummary describes remaining and completed work, e.g.
+// "6h left · 2h done · 1 unestimated"
+func effortSummary(e analysis.EffortTotals) string {
+	parts := []string{formatEstimate(e.RemainingMinutes) + " left", formatEstimate(e.CompletedMinutes) + " done"}
+	if e.UnestimatedOpen > 0 {
+		parts = append(parts, fmt.Sprintf("%d unestimated", e.UnestimatedOpen))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// openEffortRollup totals the estimates per epic, label and assignee
+func (m *Model) openEffortRollup() {
+	m.effortRollup = analysis.ComputeEffortRollup(m.issues)
+	m.effortCursor = 0
+	m.showEffortRollup = true
+}
+
+// effortRows returns the rows of the current grouping
+func (m *Model) effortRows() []analysis.EffortTotals {
+	switch m.effortGroup {
+	case 1:
+		return m.effortRollup.ByLabel
+	case 2:
+		return m.effortRollup.ByAssignee
+	default:
+		return m.effortRollup.ByEpic
+	}
+}
+
+// handleEffortRollupKeys handles keys while the effort rollup is open
+func (m Model) handleEffortRollupKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "R":
+		m.showEffortRollup = false
+	case "tab", "l", "right":
+		m.effortGroup = (m.effortGroup + 1) % len(effortGroupings)
+		m.effortCursor = 0
+	case "shift+tab", "h", "left":
+		m.effortGroup = (m.effortGroup + len(effortGroupings) - 1) % len(effortGroupings)
+		m.effortCursor = 0
+	case "j", "down":
+		if m.effortCursor < len(m.effortRows())-1 {
+			m.effortCursor++
+		}
+	case "k", "up":
+		if m.effortCursor > 0 {
+			m.effortCursor--
+		}
+	case "enter":
+		// Epic rows are issues; jump to the selected one
+		rows := m.effortRows()
+		if m.effortGroup == 0 && m.effortCursor < len(rows) {
+			id := rows[m.effortCursor].Key
+			m.showEffortRollup = false
+			if !m.showIssueDetails(id) {
+				m.statusMsg = fmt.Sprintf("❌ %s is not in the list", id)
+				m.statusIsError = true
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderEffortRollup renders the effort rollup modal
+func (m Model) renderEffortRollup() string {
+	t := m.theme
+	width := min(84, m.width-4)
+	total := m.effortRollup.Total
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		MaxHeight(m.height - 4)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("⏱ Effort Rollup"))
+	sb.WriteString("\n")
+	sb.WriteString(mutedStyle.Render(fmt.Sprintf("Project: %d open · %s", total.OpenIssues, effortSummary(total))))
+	sb.WriteString("\n\n")
+
+	var tabs []string
+	for i, name := range effortGroupings {
+		if i == m.effortGroup {
+			tabs = append(tabs, t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Render("["+name+"]"))
+		} else {
+			tabs = append(tabs, mutedStyle.Render(" "+name+" "))
+		}
+	}
+	sb.WriteString(strings.Join(tabs, " "))
+	sb.WriteString("\n\n")
+
+	rows := m.effortRows()
+	if len(rows) == 0 {
+		sb.WriteString(mutedStyle.Render("Nothing to total"))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor visible on short terminals
+	maxRows := max(m.height-16, 5)
+	start := 0
+	if m.effortCursor >= maxRows {
+		start = m.effortCursor - maxRows + 1
+	}
+	barWidth := 10
+	for i := start; i < len(rows) && i < start+maxRows; i++ {
+		row := rows[i]
+		name := row.Key
+		switch {
+		case m.effortGroup == 0 && row.Title != "":
+			name += " " + row.Title
+		case m.effortGroup == 2 && name == "":
+			name = "(unassigned)"
+		}
+		cursor := "  "
+		if i == m.effortCursor {
+			cursor = "▸ "
+		}
+		done := 0.0
+		if sum := row.RemainingMinutes + row.CompletedMinutes; sum > 0 {
+			done = float64(row.CompletedMinutes) / float64(sum)
+		}
+		filled := int(done*float64(barWidth) + 0.5)
+		tail := fmt.Sprintf(" %3d open %9s left %s", row.OpenIssues, formatEstimate(row.RemainingMinutes),
+			strings.Repeat("█", filled)+strings.Repeat("░", barWidth-filled))
+		if row.UnestimatedOpen > 0 {
+			tail += fmt.Sprintf(" %d?", row.UnestimatedOpen)
+		}
+		nameWidth := max(width-6-lipgloss.Width(cursor)-lipgloss.Width(tail), 10)
+		line := cursor + padRight(truncateRunesHelper(name, nameWidth, "…"), nameWidth) + tail
+		if i == m.effortCursor {
+			line = t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
+		"Bar: share done · N?: unestimated open • Tab: grouping • Enter: jump to epic • Esc: close"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(sb.String()),
+	)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEffortRollupModal(t *testing.T) {
+	est := func(minutes int) *int { return &minutes }
+	issues := []model.Issue{
+		{ID: "epic", Title: "Launch", Status: model.StatusOpen, IssueType: model.TypeEpic, EstimatedMinutes: est(60)},
+		{ID: "task", Title: "Task", Status: model.StatusOpen, IssueType: model.TypeTask, Assignee: "alice",
+			Labels: []string{"api"}, EstimatedMinutes: est(120),
+			Dependencies: []*model.Dependency{{IssueID: "task", DependsOnID: "epic", Type: model.DepParentChild}}},
+		{ID: "done", Title: "Done", Status: model.StatusClosed, IssueType: model.TypeTask, EstimatedMinutes: est(30),
+			Dependencies: []*model.Dependency{{IssueID: "done", DependsOnID: "epic", Type: model.DepParentChild}}},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 100, 40
+
+	detail := m.renderEffortMD(issues[0])
+	if !strings.Contains(detail, "**Estimate:** 1h") || !strings.Contains(detail, "3h left · 30m done") {
+		t.Errorf("detail should show the estimate and the epic's rollup, got %q", detail)
+	}
+	if detail := m.renderEffortMD(issues[1]); strings.Contains(detail, "children") {
+		t.Errorf("a leaf issue has no rollup, got %q", detail)
+	}
+
+	m.openEffortRollup()
+	if view := m.renderEffortRollup(); !strings.Contains(view, "Launch") || !strings.Contains(view, "3h left") {
+		t.Errorf("view should list the epic:\n%s", view)
+	}
+	m, _ = m.handleEffortRollupKeys(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.handleEffortRollupKeys(tea.KeyMsg{Type: tea.KeyTab})
+	if rows := m.effortRows(); len(rows) != 2 || rows[0].Key != "alice" {
+		t.Errorf("expected assignee rows led by alice, got %+v", rows)
+	}
+	if view := m.renderEffortRollup(); !strings.Contains(view, "(unassigned)") {
+		t.Errorf("the unassigned row should be named:\n%s", view)
+	}
+
+	m, _ = m.handleEffortRollupKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if m.showEffortRollup {
+		t.Error("R should close the rollup")
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// epicProgress counts the direct children of an epic
+type epicProgress struct {
+	Closed int
+	Total  int
+}
+
+// Percent returns the share of children closed, 0-100
+func (p epicProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Closed * 100 / p.Total
+}
+
+// computeEpicProgress counts the closed and total children of each epic
+// through parent-child dependencies. Tombstoned children don't count;
+// epics without children are left out.
+func computeEpicProgress(issues []model.Issue) map[string]epicProgress {
+	epics := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.IssueType == model.TypeEpic {
+			epics[issue.ID] = true
+		}
+	}
+	progress := make(map[string]epicProgress)
+	for _, issue := range issues {
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		counted := make(map[string]bool) // a child linked twice counts once
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild || !epics[dep.DependsOnID] || dep.DependsOnID == issue.ID || counted[dep.DependsOnID] {
+				continue
+			}
+			counted[dep.DependsOnID] = true
+			p := progress[dep.DependsOnID]
+			p.Total++
+			if issue.Status.IsClosed() {
+				p.Closed++
+			}
+			progress[dep.DependsOnID] = p
+		}
+	}
+	return progress
+}
+
+// renderEpicProgress renders a bar of width cells and the percentage,
+// e.g. "███░░ 60%"
+func renderEpicProgress(p epicProgress, width int, t Theme) string {
+	filled := p.Closed * width / p.Total
+	color := t.Open
+	if p.Closed == p.Total {
+		color = t.Closed
+	}
+	barStyle := t.Renderer.NewStyle().Foreground(color)
+	emptyStyle := t.Renderer.NewStyle().Foreground(t.Border)
+	return barStyle.Render(strings.Repeat("█", filled)) +
+		emptyStyle.Render(strings.Repeat("░", width-filled)) +
+		t.Renderer.NewStyle().Foreground(t.Secondary).Render(fmt.Sprintf(" %3d%%", p.Percent()))
+}
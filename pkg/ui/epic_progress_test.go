@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func epicProgressIssues() []model.Issue {
+	child := func(id string, status model.Status, parent string) model.Issue {
+		return model.Issue{ID: id, Title: id, Status: status, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}}
+	}
+	twice := child("ep-4", model.StatusOpen, "ep-1")
+	twice.Dependencies = append(twice.Dependencies, &model.Dependency{IssueID: "ep-4", DependsOnID: "ep-1", Type: model.DepParentChild})
+	return []model.Issue{
+		{ID: "ep-1", Title: "Checkout epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		child("ep-2", model.StatusClosed, "ep-1"),
+		child("ep-3", model.StatusClosed, "ep-1"),
+		twice,
+		child("ep-5", model.StatusTombstone, "ep-1"),
+		child("ep-6", model.StatusOpen, "ep-7"), // parent is not an epic
+		{ID: "ep-7", Title: "Feature", Status: model.StatusOpen, IssueType: model.TypeFeature},
+		{ID: "ep-8", Title: "Empty epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+	}
+}
+
+func TestComputeEpicProgress(t *testing.T) {
+	progress := computeEpicProgress(epicProgressIssues())
+	if got := progress["ep-1"]; got != (epicProgress{Closed: 2, Total: 3}) {
+		t.Errorf("ep-1 = %+v, want 2 of 3", got)
+	}
+	if got := progress["ep-1"].Percent(); got != 66 {
+		t.Errorf("ep-1 percent = %d", got)
+	}
+	if len(progress) != 1 {
+		t.Errorf("progress = %+v, want only ep-1", progress)
+	}
+}
+
+func TestEpicProgressInListAndBoard(t *testing.T) {
+	issues := epicProgressIssues()
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	progress := computeEpicProgress(issues)
+
+	item := IssueItem{Issue: issues[0]}
+	delegate := IssueDelegate{Theme: theme, EpicProgress: progress}
+	l := list.New([]list.Item{item}, delegate, 0, 0)
+	l.SetWidth(120)
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, item)
+	if out := buf.String(); !strings.Contains(out, "█████░░░  66%") {
+		t.Errorf("list row missing progress: %q", out)
+	}
+
+	board := NewBoardModel(issues, theme)
+	board.SetEpicProgress(progress)
+	if card := board.renderCard(issues[0], 30, false, 0, 0); !strings.Contains(card, "███░░  66%") {
+		t.Errorf("board card missing progress: %q", card)
+	}
+	if card := board.renderCard(issues[7], 30, false, 0, 0); strings.Contains(card, "%") {
+		t.Errorf("childless epic shows progress: %q", card)
+	}
+}
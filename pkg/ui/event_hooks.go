@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EventHooksDoneMsg carries the results of the data event hooks of a reload.
+type EventHooksDoneMsg struct {
+	Results []hooks.HookResult
+	Error   error // Loading .bv/hooks.yaml failed
+}
+
+// SetHooksEnabled turns the .bv/hooks.yaml data event hooks on or off
+// (--no-hooks).
+func (m *Model) SetHooksEnabled(enabled bool) {
+	m.hooksDisabled = !enabled
+}
+
+// eventHooksCmd runs the on-reload, on-issue-closed and on-new-blocked hooks
+// for a reload in the background. The config is read on every reload so
+// edits to hooks.yaml apply without restarting.
+func (m *Model) eventHooksCmd(before, after []model.Issue) tea.Cmd {
+	if m.hooksDisabled || m.workDir == "" {
+		return nil
+	}
+	workDir, beadsPath := m.workDir, m.beadsPath
+	return func() tea.Msg {
+		loader := hooks.NewLoader(hooks.WithProjectDir(workDir))
+		if err := loader.Load(); err != nil {
+			return EventHooksDoneMsg{Error: err}
+		}
+		if !loader.HasEventHooks() {
+			return nil
+		}
+		events := hooks.DetectEvents(before, after)
+		ctx := hooks.EventContext{BeadsPath: beadsPath, IssueCount: len(after), Timestamp: time.Now()}
+		return EventHooksDoneMsg{Results: hooks.RunEventHooks(loader.Config(), events, ctx)}
+	}
+}
+
+// handleEventHooksDone reports failed event hooks as a toast
+func (m *Model) handleEventHooksDone(msg EventHooksDoneMsg) tea.Cmd {
+	if msg.Error != nil {
+		return m.toasts.Push(ToastError, fmt.Sprintf("Hooks: %v", msg.Error))
+	}
+	var failed []hooks.HookResult
+	for _, r := range msg.Results {
+		if !r.Success {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	text := fmt.Sprintf("%s hook %q failed: %v", failed[0].Phase, failed[0].Hook.Name, failed[0].Error)
+	if len(failed) > 1 {
+		text = fmt.Sprintf("%d hooks failed (%s %q: %v)", len(failed), failed[0].Phase, failed[0].Hook.Name, failed[0].Error)
+	}
+	return m.toasts.Push(ToastWarning, text)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestEventHooksOnReload(t *testing.T) {
+	before := []model.Issue{{ID: "A", Status: model.StatusOpen}}
+	after := []model.Issue{{ID: "A", Status: model.StatusClosed}}
+
+	m := NewModel(before, nil, "")
+	if m.eventHooksCmd(before, after) != nil {
+		t.Error("no hooks should run without a project directory")
+	}
+
+	// Without event hooks configured the command finishes silently
+	m.workDir = t.TempDir()
+	cmd := m.eventHooksCmd(before, after)
+	if cmd == nil {
+		t.Fatal("expected a hooks command")
+	}
+	if msg := cmd(); msg != nil {
+		t.Errorf("no hooks configured, got %#v", msg)
+	}
+
+	config := "hooks:\n  on-issue-closed:\n    - name: celebrate\n      command: exit 1\n"
+	if err := os.MkdirAll(filepath.Join(m.workDir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(m.workDir, ".bv", "hooks.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := m.eventHooksCmd(before, after)().(EventHooksDoneMsg)
+	if !ok || len(msg.Results) != 1 || msg.Results[0].Phase != hooks.OnIssueClosed {
+		t.Fatalf("got %#v, want one on-issue-closed result", msg)
+	}
+	if m.handleEventHooksDone(msg) == nil {
+		t.Error("a failed hook should raise a toast")
+	}
+	if active := m.toasts.Active(); len(active) != 1 || !strings.Contains(active[0].Message, `"celebrate" failed`) {
+		t.Errorf("toasts = %+v", active)
+	}
+	if m.handleEventHooksDone(EventHooksDoneMsg{Error: errors.New("bad yaml")}) == nil {
+		t.Error("a config error should raise a toast")
+	}
+
+	m.SetHooksEnabled(false)
+	if m.eventHooksCmd(before, after) != nil {
+		t.Error("--no-hooks should not run event hooks")
+	}
+}
@@ -11,6 +11,43 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// GraphZoom is how much each node box in the graph view shows.
+type GraphZoom int
+
+const (
+	// GraphZoomCompact shows ID-only chips, so more neighbours fit per row.
+	GraphZoomCompact GraphZoom = iota - 1
+	// GraphZoomNormal shows the ID and title (the default).
+	GraphZoomNormal
+	// GraphZoomDetailed adds the status and PageRank rank to each box.
+	GraphZoomDetailed
+)
+
+// String returns the zoom level name shown in the status bar.
+func (z GraphZoom) String() string {
+	switch z {
+	case GraphZoomCompact:
+		return "compact"
+	case GraphZoomDetailed:
+		return "detailed"
+	default:
+		return "normal"
+	}
+}
+
+// boxLimits is how many neighbour boxes fit in a row at this zoom, and how
+// wide each may be
+func (z GraphZoom) boxLimits() (maxBoxes, minWidth, maxWidth int) {
+	switch z {
+	case GraphZoomCompact:
+		return 10, 8, 14
+	case GraphZoomDetailed:
+		return 4, 16, 28
+	default:
+		return 5, 12, 20
+	}
+}
+
 // GraphModel represents the dependency graph view with visual ASCII art visualization
 type GraphModel struct {
 	issues       []model.Issue
@@ -38,6 +75,16 @@ type GraphModel struct {
 	rankCriticalPath map[string]int
 	rankInDegree     map[string]int
 	rankOutDegree    map[string]int
+
+	// Community detection (toggled with 'c'); computed lazily
+	showClusters bool
+	clusters     analysis.ClusterResult
+
+	// Workspace mode: nodes carry a colored repo badge
+	showRepoBadges bool
+
+	// How much each node box shows (toggled with +/-)
+	zoom GraphZoom
 }
 
 // NewGraphModel creates a new graph view from issues
@@ -125,11 +172,107 @@ func (g *GraphModel) rebuildGraph() {
 		sort.Strings(g.sortedIDs)
 	}
 
+	// Group nodes by cluster, keeping the ordering above within each group
+	if g.showClusters {
+		cfg := analysis.DefaultClusterConfig()
+		cfg.IncludeClosed = true
+		g.clusters = analysis.BuildClusters(g.issues, cfg)
+		sort.SliceStable(g.sortedIDs, func(i, j int) bool {
+			ci := g.clusters.ClusterOf(g.sortedIDs[i])
+			cj := g.clusters.ClusterOf(g.sortedIDs[j])
+			if (ci == 0) != (cj == 0) {
+				return cj == 0 // Unclustered issues last
+			}
+			return ci < cj
+		})
+	}
+
 	if g.selectedIdx >= len(g.sortedIDs) {
 		g.selectedIdx = 0
 	}
 }
 
+// SetWorkspaceMode shows or hides the repo badge on each node
+func (g *GraphModel) SetWorkspaceMode(enabled bool) {
+	g.showRepoBadges = enabled
+}
+
+// repoBadge returns the colored badge for id's repo plus a trailing space,
+// or "" outside workspace mode
+func (g *GraphModel) repoBadge(id string) string {
+	if !g.showRepoBadges {
+		return ""
+	}
+	prefix := ExtractRepoPrefix(id)
+	if prefix == "" {
+		return ""
+	}
+	return RenderRepoBadge(prefix) + " "
+}
+
+// ToggleClusters switches between the default ordering and nodes grouped by
+// detected cluster, keeping the current selection.
+func (g *GraphModel) ToggleClusters() {
+	var selectedID string
+	if len(g.sortedIDs) > 0 && g.selectedIdx >= 0 && g.selectedIdx < len(g.sortedIDs) {
+		selectedID = g.sortedIDs[g.selectedIdx]
+	}
+	g.showClusters = !g.showClusters
+	g.rebuildGraph()
+	if selectedID != "" {
+		g.SelectByID(selectedID)
+	}
+}
+
+// ShowingClusters reports whether nodes are grouped by cluster.
+func (g *GraphModel) ShowingClusters() bool {
+	return g.showClusters
+}
+
+// ClusterCount returns the number of detected clusters (0 when not grouping).
+func (g *GraphModel) ClusterCount() int {
+	if !g.showClusters {
+		return 0
+	}
+	return g.clusters.ClusterCount
+}
+
+// ZoomIn shows more of each node, up to GraphZoomDetailed.
+func (g *GraphModel) ZoomIn() {
+	if g.zoom < GraphZoomDetailed {
+		g.zoom++
+	}
+}
+
+// ZoomOut shows less of each node, down to GraphZoomCompact.
+func (g *GraphModel) ZoomOut() {
+	if g.zoom > GraphZoomCompact {
+		g.zoom--
+	}
+}
+
+// Zoom returns the current zoom level.
+func (g *GraphModel) Zoom() GraphZoom {
+	return g.zoom
+}
+
+// clusterColor picks a stable color for a cluster ID.
+func clusterColor(id int, t Theme) lipgloss.AdaptiveColor {
+	palette := []lipgloss.AdaptiveColor{
+		t.Primary,
+		t.Open,
+		t.Feature,
+		t.InProgress,
+		t.Task,
+		t.Blocked,
+		{Light: "#C0307A", Dark: "#FF79C6"}, // Pink
+	}
+	if id <= 0 {
+		return t.Secondary
+	}
+	return palette[(id-1)%len(palette)]
+}
+
 // computeRankings precomputes rankings for all metrics
 func (g *GraphModel) computeRankings() {
 	g.rankPageRank = make(map[string]int)
@@ -328,7 +471,11 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 		Bold(true).
 		Foreground(t.Primary).
 		Width(width)
-	lines = append(lines, headerStyle.Render(fmt.Sprintf("📊 Nodes (%d)", len(g.sortedIDs))))
+	header := fmt.Sprintf("📊 Nodes (%d)", len(g.sortedIDs))
+	if g.showClusters {
+		header = fmt.Sprintf("📊 Nodes (%d) · %d clusters", len(g.sortedIDs), g.clusters.ClusterCount)
+	}
+	lines = append(lines, headerStyle.Render(header))
 	lines = append(lines, strings.Repeat("─", width))
 
 	visibleItems := height - 4
@@ -358,9 +505,21 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 
 		isSelected := i == g.selectedIdx
 		statusIcon := getStatusIcon(issue.Status)
-		maxIDLen := width - 4
+		badge := g.repoBadge(id)
+		maxIDLen := width - 4 - lipgloss.Width(badge)
+		if g.showClusters {
+			maxIDLen -= 2
+		}
 		displayID := smartTruncateID(id, maxIDLen)
-		line := fmt.Sprintf("%s %s", statusIcon, displayID)
+		line := fmt.Sprintf("%s %s%s", statusIcon, badge, displayID)
+		if g.showClusters {
+			cid := g.clusters.ClusterOf(id)
+			marker := "·"
+			if cid > 0 {
+				marker = "●"
+			}
+			line = t.Renderer.NewStyle().Foreground(clusterColor(cid, t)).Render(marker) + " " + line
+		}
 
 		var style lipgloss.Style
 		if isSelected {
@@ -426,17 +585,45 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 	// COMPREHENSIVE METRICS PANEL - ALL 8 metrics with values AND ranks
 	// ═══════════════════════════════════════════════════════════════════════
 	sections = append(sections, g.renderMetricsPanel(id, width, t))
+	if g.showClusters {
+		sections = append(sections, g.renderClusterSummary(id, width, t))
+	}
 
 	// Navigation hint
 	navStyle := t.Renderer.NewStyle().
 		Foreground(t.Secondary).
 		Italic(true)
 	sections = append(sections, "")
-	sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • g: back to list"))
+	zoomHint := fmt.Sprintf("+/-: zoom (%s)", g.zoom)
+	if g.showClusters {
+		sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • "+zoomHint+" • c: ungroup • g: back to list"))
+	} else {
+		sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • "+zoomHint+" • g: back to list"))
+	}
 
 	return strings.Join(sections, "\n")
 }
 
+// renderClusterSummary describes the cluster containing the selected issue
+func (g *GraphModel) renderClusterSummary(id string, width int, t Theme) string {
+	cid := g.clusters.ClusterOf(id)
+	if cid == 0 || cid > len(g.clusters.Clusters) {
+		return t.Renderer.NewStyle().
+			Foreground(t.Secondary).
+			Italic(true).
+			Width(width).
+			Render("  Not part of any cluster")
+	}
+	c := g.clusters.Clusters[cid-1]
+	marker := t.Renderer.NewStyle().Foreground(clusterColor(cid, t)).Bold(true).Render("●")
+	summary := fmt.Sprintf(" Cluster %d/%d · %d issues (%d open) · anchor %s · %d external links",
+		cid, g.clusters.ClusterCount, c.Size, c.OpenCount, c.Anchor, c.ExternalEdges)
+	if len(c.Labels) > 0 {
+		summary += " · " + strings.Join(c.Labels, ", ")
+	}
+	return " " + marker + truncateRunesHelper(summary, width-3, "…")
+}
+
 // renderBlockersVisual renders blocker nodes as boxes
 func (g *GraphModel) renderBlockersVisual(blockerIDs []string, width int, t Theme) string {
 	headerStyle := t.Renderer.NewStyle().
@@ -448,32 +635,12 @@ func (g *GraphModel) renderBlockersVisual(blockerIDs []string, width int, t Them
 	header := headerStyle.Render("▲ BLOCKED BY (must complete first) ▲")
 
 	// Calculate box width based on available space and number of blockers
-	maxBoxes := 5
-	if len(blockerIDs) < maxBoxes {
-		maxBoxes = len(blockerIDs)
-	}
-	if maxBoxes < 1 {
-		maxBoxes = 1
-	}
-	boxWidth := (width - 4) / maxBoxes
-	if boxWidth > 20 {
-		boxWidth = 20
-	}
-	if boxWidth < 12 {
-		boxWidth = 12
-	}
-	// Ensure boxWidth doesn't exceed available space (narrow terminals)
-	if boxWidth > width-2 {
-		boxWidth = width - 2
-	}
-	if boxWidth < 8 {
-		boxWidth = 8
-	}
+	maxBoxes, boxWidth := g.neighbourBoxes(len(blockerIDs), width)
 
 	var boxes []string
 	for i, bid := range blockerIDs {
-		if i >= 5 {
-			remaining := len(blockerIDs) - 5
+		if i >= maxBoxes {
+			remaining := len(blockerIDs) - maxBoxes
 			boxes = append(boxes, t.Renderer.NewStyle().
 				Foreground(t.Secondary).
 				Italic(true).
@@ -489,21 +656,23 @@ func (g *GraphModel) renderBlockersVisual(blockerIDs []string, width int, t Them
 	return header + "\n" + centered
 }
 
-// renderDependentsVisual renders dependent nodes as boxes
-func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t Theme) string {
-	maxBoxes := 5
-	if len(dependentIDs) < maxBoxes {
-		maxBoxes = len(dependentIDs)
+// neighbourBoxes returns how many of count neighbour boxes to draw in a row
+// of the given width at the current zoom, and how wide each is
+func (g *GraphModel) neighbourBoxes(count, width int) (int, int) {
+	maxBoxes, minWidth, maxWidth := g.zoom.boxLimits()
+	shown := maxBoxes
+	if count < shown {
+		shown = count
 	}
-	if maxBoxes < 1 {
-		maxBoxes = 1
+	if shown < 1 {
+		shown = 1
 	}
-	boxWidth := (width - 4) / maxBoxes
-	if boxWidth > 20 {
-		boxWidth = 20
+	boxWidth := (width - 4) / shown
+	if boxWidth > maxWidth {
+		boxWidth = maxWidth
 	}
-	if boxWidth < 12 {
-		boxWidth = 12
+	if boxWidth < minWidth {
+		boxWidth = minWidth
 	}
 	// Ensure boxWidth doesn't exceed available space (narrow terminals)
 	if boxWidth > width-2 {
@@ -512,11 +681,17 @@ func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t
 	if boxWidth < 8 {
 		boxWidth = 8
 	}
+	return maxBoxes, boxWidth
+}
+
+// renderDependentsVisual renders dependent nodes as boxes
+func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t Theme) string {
+	maxBoxes, boxWidth := g.neighbourBoxes(len(dependentIDs), width)
 
 	var boxes []string
 	for i, did := range dependentIDs {
-		if i >= 5 {
-			remaining := len(dependentIDs) - 5
+		if i >= maxBoxes {
+			remaining := len(dependentIDs) - maxBoxes
 			boxes = append(boxes, t.Renderer.NewStyle().
 				Foreground(t.Secondary).
 				Italic(true).
@@ -540,9 +715,32 @@ func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t
 	return centered + "\n" + header
 }
 
+// crossRepoBorder is a dashed border marking neighbours from another workspace repo
+var crossRepoBorder = lipgloss.Border{
+	Top:         "╌",
+	Bottom:      "╌",
+	Left:        "╎",
+	Right:       "╎",
+	TopLeft:     "╭",
+	TopRight:    "╮",
+	BottomLeft:  "╰",
+	BottomRight: "╯",
+}
+
+// isCrossRepo reports whether id belongs to a different workspace repo than
+// the selected issue. Issues without a source repo are never cross-repo.
+func (g *GraphModel) isCrossRepo(id string) bool {
+	ego, other := g.SelectedIssue(), g.issueMap[id]
+	if ego == nil || other == nil || ego.SourceRepo == "" || other.SourceRepo == "" {
+		return false
+	}
+	return ego.SourceRepo != other.SourceRepo
+}
+
 // renderNodeBox renders a single node as an ASCII box
 func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool) string {
 	issue := g.issueMap[id]
+	crossRepo := !isEgo && g.isCrossRepo(id)
 
 	var statusIcon, displayID, title string
 	var statusColor lipgloss.AdaptiveColor
@@ -563,6 +761,9 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 
 	// Build box content
 	line1 := fmt.Sprintf("%s %s", statusIcon, displayID)
+	if badge := g.repoBadge(id); badge != "" && boxWidth > 14 {
+		line1 = fmt.Sprintf("%s %s%s", statusIcon, badge, smartTruncateID(id, boxWidth-4-lipgloss.Width(badge)))
+	}
 
 	var boxStyle lipgloss.Style
 	if isEgo {
@@ -575,6 +776,15 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 			Width(boxWidth).
 			Align(lipgloss.Center).
 			Padding(0, 1)
+	} else if crossRepo {
+		// Dashed border in the epic color so cross-repo edges stand out
+		boxStyle = t.Renderer.NewStyle().
+			Border(crossRepoBorder).
+			BorderForeground(t.Epic).
+			Foreground(statusColor).
+			Width(boxWidth).
+			Align(lipgloss.Center).
+			Padding(0, 0)
 	} else {
 		boxStyle = t.Renderer.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -586,9 +796,19 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 	}
 
 	content := line1
-	if title != "" && boxWidth > 14 {
+	if title != "" && boxWidth > 14 && g.zoom != GraphZoomCompact {
 		content = line1 + "\n" + title
 	}
+	if issue != nil && g.zoom == GraphZoomDetailed {
+		detail := string(issue.Status)
+		if rank := g.rankPageRank[id]; rank > 0 {
+			detail += fmt.Sprintf(" · PR #%d", rank)
+		}
+		content += "\n" + truncateRunesHelper(detail, boxWidth-4, "…")
+	}
+	if crossRepo && boxWidth > 14 {
+		content += "\n" + truncateRunesHelper("⇄ "+issue.SourceRepo, boxWidth-4, "…")
+	}
 
 	return boxStyle.Render(content)
 }
@@ -615,7 +835,8 @@ func (g *GraphModel) renderEgoNode(id string, issue *model.Issue, width int, t T
 	}
 
 	icons := fmt.Sprintf("%s %s %s", statusIcon, prioIcon, typeIcon)
-	displayID := smartTruncateID(id, egoWidth-4)
+	badge := g.repoBadge(id)
+	displayID := badge + smartTruncateID(id, egoWidth-4-lipgloss.Width(badge))
 	title := ""
 	if issue.Title != "" {
 		title = truncateRunesHelper(issue.Title, egoWidth-4, "…")
@@ -630,6 +851,15 @@ func (g *GraphModel) renderEgoNode(id string, issue *model.Issue, width int, t T
 	blockerCount := len(g.blockers[id])
 	dependentCount := len(g.dependents[id])
 	content += fmt.Sprintf("\n⬆%d  ⬇%d", blockerCount, dependentCount)
+	crossRepoCount := 0
+	for _, other := range append(append([]string{}, g.blockers[id]...), g.dependents[id]...) {
+		if g.isCrossRepo(other) {
+			crossRepoCount++
+		}
+	}
+	if crossRepoCount > 0 {
+		content += fmt.Sprintf("  ⇄%d cross-repo", crossRepoCount)
+	}
 
 	egoStyle := t.Renderer.NewStyle().
 		Border(lipgloss.DoubleBorder()).
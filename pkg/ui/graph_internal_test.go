@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestSmartTruncateID(t *testing.T) {
@@ -46,3 +50,64 @@ func TestSmartTruncateID(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphCrossRepoNeighbours(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "web-UI-1", Title: "Login page", Status: model.StatusOpen, SourceRepo: "web", Dependencies: []*model.Dependency{
+			{IssueID: "web-UI-1", DependsOnID: "api-AUTH-1", Type: model.DepBlocks},
+			{IssueID: "web-UI-1", DependsOnID: "web-UI-2", Type: model.DepBlocks},
+		}},
+		{ID: "api-AUTH-1", Title: "Auth endpoint", Status: model.StatusOpen, SourceRepo: "api"},
+		{ID: "web-UI-2", Title: "Form", Status: model.StatusOpen, SourceRepo: "web"},
+	}
+	g := NewGraphModel(issues, nil, DefaultTheme(lipgloss.NewRenderer(nil)))
+	if !g.SelectByID("web-UI-1") {
+		t.Fatal("could not select web-UI-1")
+	}
+
+	if !g.isCrossRepo("api-AUTH-1") || g.isCrossRepo("web-UI-2") || g.isCrossRepo("missing") {
+		t.Error("expected only api-AUTH-1 to be cross-repo")
+	}
+	if box := g.renderNodeBox("api-AUTH-1", 20, g.theme, false); !strings.Contains(box, "╌") || !strings.Contains(box, "⇄ api") {
+		t.Errorf("cross-repo box should be dashed and name its repo:\n%s", box)
+	}
+	if box := g.renderNodeBox("web-UI-2", 20, g.theme, false); strings.Contains(box, "╌") {
+		t.Errorf("same-repo box should keep the rounded border:\n%s", box)
+	}
+	if ego := g.renderEgoNode("web-UI-1", g.SelectedIssue(), 80, g.theme); !strings.Contains(ego, "⇄1 cross-repo") {
+		t.Errorf("ego node should count cross-repo links:\n%s", ego)
+	}
+
+	// Without source repos (single-repo projects) nothing is cross-repo
+	for i := range issues {
+		issues[i].SourceRepo = ""
+	}
+	g = NewGraphModel(issues, nil, DefaultTheme(lipgloss.NewRenderer(nil)))
+	g.SelectByID("web-UI-1")
+	if g.isCrossRepo("api-AUTH-1") {
+		t.Error("issues without a source repo must not be cross-repo")
+	}
+}
+
+func TestGraphRepoBadges(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "web-UI-1", Title: "Login page", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "web-UI-1", DependsOnID: "api-AUTH-1", Type: model.DepBlocks},
+		}},
+		{ID: "api-AUTH-1", Title: "Auth endpoint", Status: model.StatusOpen},
+	}
+	g := NewGraphModel(issues, nil, DefaultTheme(lipgloss.NewRenderer(nil)))
+	g.SelectByID("web-UI-1")
+	if out := g.View(120, 40); strings.Contains(out, "[API]") {
+		t.Error("repo badges should be hidden outside workspace mode")
+	}
+
+	g.SetWorkspaceMode(true)
+	out := g.View(120, 40)
+	if !strings.Contains(out, "[API]") || !strings.Contains(out, "[WEB]") {
+		t.Errorf("expected repo badges on nodes, got:\n%s", out)
+	}
+	if GetRepoColor("API") != GetRepoColor("api") {
+		t.Error("repo colors should not depend on prefix case")
+	}
+}
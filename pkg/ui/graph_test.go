@@ -2,6 +2,7 @@ package ui_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -885,3 +886,100 @@ func TestGraphModelReferenceToSelf(t *testing.T) {
 		t.Error("Expected non-empty view")
 	}
 }
+
+// TestGraphModelToggleClusters verifies cluster grouping keeps selection and groups members
+func TestGraphModelToggleClusters(t *testing.T) {
+	theme := createTheme()
+	blocks := func(from, to string) *model.Dependency {
+		return &model.Dependency{IssueID: from, DependsOnID: to, Type: model.DepBlocks}
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{blocks("A", "Y")}},
+		{ID: "B", Title: "B", Status: model.StatusOpen},
+		{ID: "X", Title: "X", Status: model.StatusOpen, Dependencies: []*model.Dependency{blocks("X", "B")}},
+		{ID: "Y", Title: "Y", Status: model.StatusOpen},
+		{ID: "Z", Title: "Z", Status: model.StatusOpen},
+	}
+
+	g := ui.NewGraphModel(issues, nil, theme)
+	if !g.SelectByID("X") {
+		t.Fatal("expected to select X")
+	}
+	if g.ShowingClusters() || g.ClusterCount() != 0 {
+		t.Fatal("clusters should be off by default")
+	}
+
+	g.ToggleClusters()
+	if !g.ShowingClusters() || g.ClusterCount() != 2 {
+		t.Fatalf("expected 2 clusters, got %d", g.ClusterCount())
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "X" {
+		t.Errorf("selection should survive toggling, got %v", sel)
+	}
+
+	// Members of a cluster are adjacent; unclustered Z comes last
+	var order []string
+	g.SelectByID("A")
+	for i := 0; i < g.TotalCount(); i++ {
+		order = append(order, g.SelectedIssue().ID)
+		g.MoveDown()
+	}
+	if got := fmt.Sprint(order); got != "[A Y B X Z]" {
+		t.Errorf("cluster order = %s, want [A Y B X Z]", got)
+	}
+
+	g.SelectByID("X")
+	out := g.View(120, 40)
+	if !strings.Contains(out, "2 clusters") || !strings.Contains(out, "Cluster 2/2") {
+		t.Errorf("expected cluster header and summary in view:\n%s", out)
+	}
+
+	g.ToggleClusters()
+	if g.ShowingClusters() {
+		t.Error("second toggle should turn grouping off")
+	}
+}
+
+// TestGraphModelZoom verifies what node boxes show at each zoom level
+func TestGraphModelZoom(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "ego", Title: "Ego", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "ego", DependsOnID: "dep", Type: model.DepBlocks},
+		}},
+		{ID: "dep", Title: "Neighbour title", Status: model.StatusInProgress},
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	insights := stats.GenerateInsights(10)
+	g := ui.NewGraphModel(issues, &insights, theme)
+	if !g.SelectByID("ego") {
+		t.Fatal("expected to select ego")
+	}
+	if g.Zoom() != ui.GraphZoomNormal {
+		t.Fatalf("default zoom = %s, want normal", g.Zoom())
+	}
+
+	out := g.View(120, 40)
+	if !strings.Contains(out, "Neighbour title") || strings.Contains(out, "PR #") {
+		t.Errorf("normal zoom should show titles only:\n%s", out)
+	}
+
+	g.ZoomIn()
+	g.ZoomIn() // already at the most detailed level
+	if g.Zoom() != ui.GraphZoomDetailed {
+		t.Fatalf("zoom = %s, want detailed", g.Zoom())
+	}
+	if out := g.View(120, 40); !strings.Contains(out, "in_progress · PR #") {
+		t.Errorf("detailed zoom should show status and rank:\n%s", out)
+	}
+
+	g.ZoomOut()
+	g.ZoomOut()
+	g.ZoomOut()
+	if g.Zoom() != ui.GraphZoomCompact {
+		t.Fatalf("zoom = %s, want compact", g.Zoom())
+	}
+	if out := g.View(120, 40); strings.Contains(out, "Neighbour title") {
+		t.Errorf("compact zoom should show IDs only:\n%s", out)
+	}
+}
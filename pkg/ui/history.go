@@ -161,6 +161,9 @@ type HistoryModel struct {
 	// Cass session integration state (bv-pr1l)
 	sessionCache map[string][]cass.ScoredResult // Cached sessions per bead ID
 
+	// Confirm/reject/ignore decisions shown next to correlated commits
+	feedback *correlation.FeedbackStore
+
 	// View mode transition state (bv-kvlx)
 	modeChangedAt time.Time // Timestamp of last mode toggle for transition animation
 }
@@ -193,6 +196,11 @@ func (h *HistoryModel) SetReport(report *correlation.HistoryReport) {
 	h.rebuildFilteredList()
 }
 
+// SetFeedbackStore sets the store whose correlation feedback is shown
+func (h *HistoryModel) SetFeedbackStore(store *correlation.FeedbackStore) {
+	h.feedback = store
+}
+
 // SetSessionsForBead stores correlated sessions for a bead in the cache (bv-pr1l)
 // This is called when sessions are loaded asynchronously from the main model.
 func (h *HistoryModel) SetSessionsForBead(beadID string, sessions []cass.ScoredResult) {
@@ -1214,6 +1222,35 @@ func (h *HistoryModel) SelectedCommit() *correlation.CorrelatedCommit {
 	return nil
 }
 
+// SelectedCorrelation returns the selected commit ↔ bead pair and its
+// confidence: the focused commit of the selected bead in bead mode, or the
+// selected related bead of the selected commit in git mode
+func (h *HistoryModel) SelectedCorrelation() (sha, beadID string, conf float64, ok bool) {
+	if h.IsGitMode() {
+		commit := h.SelectedGitCommit()
+		beadID = h.SelectedRelatedBeadID()
+		if commit == nil || beadID == "" {
+			return "", "", 0, false
+		}
+		if hist := h.GetHistoryForBead(beadID); hist != nil {
+			for _, c := range hist.Commits {
+				if c.SHA == commit.SHA {
+					conf = c.Confidence
+					break
+				}
+			}
+		}
+		return commit.SHA, beadID, conf, true
+	}
+
+	commit := h.SelectedCommit()
+	beadID = h.SelectedBeadID()
+	if commit == nil || beadID == "" {
+		return "", "", 0, false
+	}
+	return commit.SHA, beadID, commit.Confidence, true
+}
+
 // GetHistoryForBead returns the history for a specific bead ID
 func (h *HistoryModel) GetHistoryForBead(beadID string) *correlation.BeadHistory {
 	if h.report == nil {
@@ -2532,6 +2569,9 @@ func (h *HistoryModel) renderCommitDetail(commit correlation.CorrelatedCommit, w
 		confStyle.Render(fmt.Sprintf("%.0f%% confidence", commit.Confidence*100)),
 		t.Renderer.NewStyle().Foreground(t.Muted).Render(methodStr),
 	)
+	if label := feedbackLabel(h.feedback, commit.SHA, h.SelectedBeadID()); label != "" {
+		confLine += " " + t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Render(label)
+	}
 	lines = append(lines, confLine)
 
 	// === FILE CHANGES ===
@@ -2620,6 +2660,10 @@ func methodLabel(method correlation.CorrelationMethod) string {
 		return "(explicit ID)"
 	case correlation.MethodTemporalAuthor:
 		return "(temporal)"
+	case correlation.MethodBranchName:
+		return "(branch)"
+	case correlation.MethodTitleMatch:
+		return "(title match)"
 	default:
 		return ""
 	}
@@ -3426,6 +3470,9 @@ func (h *HistoryModel) renderGitBeadListPanel(width, height int) string {
 		}
 
 		beadLine := fmt.Sprintf("%s%s %s", indicator, statusIcon, beadStyle.Render(title))
+		if label := feedbackLabel(h.feedback, commit.SHA, beadID); label != "" {
+			beadLine += " " + t.Renderer.NewStyle().Foreground(t.Muted).Render(label)
+		}
 		lines = append(lines, beadLine)
 	}
 
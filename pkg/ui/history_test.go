@@ -2796,3 +2796,44 @@ func TestFileTree_JumpToFile(t *testing.T) {
 		t.Errorf("Expected at least 4 .go files in test data, got %d", goFileCount)
 	}
 }
+
+func TestHistoryModel_SelectedCorrelation(t *testing.T) {
+	h := NewHistoryModel(createTestHistoryReport(), testTheme())
+
+	sha, beadID, conf, ok := h.SelectedCorrelation()
+	if !ok {
+		t.Fatal("expected a selected correlation in bead mode")
+	}
+	commit := h.SelectedCommit()
+	if beadID != h.SelectedBeadID() || sha != commit.SHA || conf != commit.Confidence {
+		t.Errorf("SelectedCorrelation = %s %s %v, want %s %s %v", sha, beadID, conf, commit.SHA, h.SelectedBeadID(), commit.Confidence)
+	}
+
+	h.ToggleViewMode()
+	sha, beadID, conf, ok = h.SelectedCorrelation()
+	if !ok {
+		t.Fatal("expected a selected correlation in git mode")
+	}
+	if sha != h.SelectedGitCommit().SHA || beadID != h.SelectedRelatedBeadID() {
+		t.Errorf("git mode SelectedCorrelation = %s %s", sha, beadID)
+	}
+	if conf == 0 {
+		t.Error("git mode confidence should come from the bead's history")
+	}
+}
+
+func TestHistoryModel_ShowsFeedback(t *testing.T) {
+	h := NewHistoryModel(createTestHistoryReport(), testTheme())
+	h.SetSize(160, 40)
+
+	store := correlation.NewFeedbackStore(t.TempDir())
+	h.SetFeedbackStore(store)
+	sha, beadID, conf, _ := h.SelectedCorrelation()
+	if err := store.Reject(sha, beadID, "test", conf, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(h.View(), "✗ rejected") {
+		t.Error("history view should show rejected feedback on the selected bead's commit")
+	}
+}
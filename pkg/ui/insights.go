@@ -2,9 +2,11 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -149,6 +151,11 @@ type InsightsModel struct {
 	recommendationMap  map[string]*analysis.Recommendation // ID -> Recommendation for quick lookup
 	triageDataHash     string                              // Hash of data used for triage
 
+	// Inline drill-down of the selected pick: score breakdown, unblocks
+	// and the suggested priority change, if any
+	pickExpanded  bool
+	priorityHints map[string]*analysis.PriorityRecommendation
+
 	// Navigation state
 	focusedPanel  MetricPanel
 	selectedIndex [PanelCount]int // Selection per panel
@@ -163,6 +170,15 @@ type InsightsModel struct {
 	heatmapGrid     [][]int      // Cached grid data: [depth][score] = count
 	heatmapIssueMap [][][]string // Cached grid data: [depth][score] = []issueIDs
 
+	// Entries in the deletions manifest, shown in the summary line
+	deletedCount int
+
+	// Correlation feedback totals, shown in the summary line
+	feedbackStats correlation.FeedbackStats
+
+	// Project health score, shown above the panels; nil until triage ran
+	healthScore *analysis.HealthScore
+
 	// View options
 	showExplanations bool
 	showCalculation  bool
@@ -224,6 +240,21 @@ func (m *InsightsModel) SetInsights(ins analysis.Insights) {
 	m.insights = ins
 }
 
+// SetDeletedCount sets the number of deleted issues shown in the summary
+func (m *InsightsModel) SetDeletedCount(n int) {
+	m.deletedCount = n
+}
+
+// SetFeedbackStats sets the correlation feedback totals shown in the summary
+func (m *InsightsModel) SetFeedbackStats(stats correlation.FeedbackStats) {
+	m.feedbackStats = stats
+}
+
+// SetHealthScore sets the project health score shown above the panels
+func (m *InsightsModel) SetHealthScore(score *analysis.HealthScore) {
+	m.healthScore = score
+}
+
 // SetTopPicks sets the priority triage recommendations (bv-91)
 func (m *InsightsModel) SetTopPicks(picks []analysis.TopPick) {
 	m.topPicks = picks
@@ -240,6 +271,29 @@ func (m *InsightsModel) SetRecommendations(recs []analysis.Recommendation, dataH
 	}
 }
 
+// SetPriorityHints sets the suggested priority changes shown when a pick
+// is expanded
+func (m *InsightsModel) SetPriorityHints(hints map[string]*analysis.PriorityRecommendation) {
+	m.priorityHints = hints
+}
+
+// TogglePickExpanded expands or collapses the selected priority pick
+func (m *InsightsModel) TogglePickExpanded() {
+	if m.focusedPanel != PanelPriority || m.showHeatmap {
+		return
+	}
+	m.pickExpanded = !m.pickExpanded
+}
+
+// ExpandedPickID returns the ID of the expanded priority pick, or "" when
+// none is expanded
+func (m *InsightsModel) ExpandedPickID() string {
+	if !m.pickExpanded || m.focusedPanel != PanelPriority || m.showHeatmap {
+		return ""
+	}
+	return m.SelectedIssueID()
+}
+
 // isPanelSkipped returns true and a reason if the metric for this panel was skipped
 func (m *InsightsModel) isPanelSkipped(panel MetricPanel) (bool, string) {
 	if m.insights.Stats == nil {
@@ -660,8 +714,33 @@ func (m *InsightsModel) View() string {
 		if v.Estimated {
 			estimate = " (estimated)"
 		}
-		velocityLine = t.Base.Render(fmt.Sprintf("Velocity: 7d=%d, 30d=%d, avg=%.1fd%s%s",
-			v.Closed7, v.Closed30, v.AvgDays, weekly, estimate))
+		velocityLine = fmt.Sprintf("Velocity: 7d=%d, 30d=%d, avg=%.1fd%s%s",
+			v.Closed7, v.Closed30, v.AvgDays, weekly, estimate)
+	}
+	if m.deletedCount > 0 {
+		if velocityLine != "" {
+			velocityLine += " • "
+		}
+		velocityLine += fmt.Sprintf("Deleted: %d (X in list to show)", m.deletedCount)
+	}
+	if fs := m.feedbackStats; fs.TotalFeedback > 0 {
+		if velocityLine != "" {
+			velocityLine += " • "
+		}
+		velocityLine += fmt.Sprintf("Correlation feedback: ✓%d ✗%d ⊘%d", fs.Confirmed, fs.Rejected, fs.Ignored)
+		if fs.Confirmed+fs.Rejected > 0 {
+			velocityLine += fmt.Sprintf(", %.0f%% accurate", fs.AccuracyRate*100)
+		}
+	}
+	if velocityLine != "" {
+		velocityLine = t.Base.Render(velocityLine)
+	}
+	if health := m.renderHealthLine(t); health != "" {
+		if velocityLine != "" {
+			velocityLine = lipgloss.JoinVertical(lipgloss.Left, health, velocityLine)
+		} else {
+			velocityLine = health
+		}
 	}
 
 	// Calculate layout dimensions
@@ -678,8 +757,15 @@ func (m *InsightsModel) View() string {
 		colWidth = 25
 	}
 
+	// The expanded pick grows the priority row at the expense of the others
+	expansion := m.renderPickExpansion(mainWidth-6, t)
+
 	// With 4 rows, reduce individual row height
-	rowHeight := (m.height - 8) / 4
+	reserved := 8 + len(expansion)
+	if m.healthScore != nil {
+		reserved++ // Health line
+	}
+	rowHeight := (m.height - reserved) / 4
 	if rowHeight < 6 {
 		rowHeight = 6
 	}
@@ -705,7 +791,7 @@ func (m *InsightsModel) View() string {
 	if m.showHeatmap {
 		row4 = m.renderHeatmapPanel(mainWidth-2, rowHeight, t)
 	} else {
-		row4 = m.renderPriorityPanel(mainWidth-2, rowHeight, t)
+		row4 = m.renderPriorityPanel(mainWidth-2, rowHeight, expansion, t)
 	}
 
 	mainContent := lipgloss.JoinVertical(lipgloss.Left, row1, row2, row3, row4)
@@ -726,6 +812,35 @@ func (m *InsightsModel) View() string {
 	return mainContent
 }
 
+// renderHealthLine renders the project health score with a bar and the
+// score of each component, weakest first
+func (m *InsightsModel) renderHealthLine(t Theme) string {
+	h := m.healthScore
+	if h == nil {
+		return ""
+	}
+	color := t.Open
+	switch h.Level {
+	case analysis.HealthLevelWarning:
+		color = t.InProgress
+	case analysis.HealthLevelCritical:
+		color = t.Blocked
+	}
+	filled := (h.Score + 5) / 10
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+
+	components := append([]analysis.HealthComponent(nil), h.Components...)
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Score < components[j].Score })
+	parts := make([]string, 0, len(components))
+	for _, c := range components {
+		parts = append(parts, fmt.Sprintf("%s %d", c.Name, c.Score))
+	}
+
+	scoreStyle := t.Renderer.NewStyle().Bold(true).Foreground(color)
+	return scoreStyle.Render(fmt.Sprintf("Project health %d/100 %s %s", h.Score, bar, h.Level)) +
+		t.Renderer.NewStyle().Foreground(t.Muted).Render(" • "+strings.Join(parts, " · "))
+}
+
 func (m *InsightsModel) renderMetricPanel(panel MetricPanel, width, height int, t Theme) string {
 	info := metricDescriptions[panel]
 	items := m.getPanelItems(panel)
@@ -1078,8 +1193,10 @@ func (m *InsightsModel) renderCyclesPanel(width, height int, t Theme) string {
 	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 }
 
-// renderPriorityPanel renders the priority recommendations panel (bv-91)
-func (m *InsightsModel) renderPriorityPanel(width, height int, t Theme) string {
+// renderPriorityPanel renders the priority recommendations panel (bv-91).
+// The expansion lines of the selected pick go below the cards.
+func (m *InsightsModel) renderPriorityPanel(width, cardsHeight int, expansion []string, t Theme) string {
+	height := cardsHeight + len(expansion)
 	info := metricDescriptions[PanelPriority]
 	isFocused := m.focusedPanel == PanelPriority
 	picks := m.topPicks
@@ -1150,10 +1267,11 @@ func (m *InsightsModel) renderPriorityPanel(width, height int, t Theme) string {
 	for i := startIdx; i < endIdx; i++ {
 		pick := picks[i]
 		isSelected := isFocused && i == selectedIdx
-		pickRenderings = append(pickRenderings, m.renderPriorityItem(pick, itemWidth, height-3, isSelected, t))
+		pickRenderings = append(pickRenderings, m.renderPriorityItem(pick, itemWidth, cardsHeight-3, isSelected, t))
 	}
 
 	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, pickRenderings...))
+	lines = append(lines, expansion...)
 
 	// Scroll indicator
 	if len(picks) > visibleItems {
@@ -1337,6 +1455,78 @@ func (m *InsightsModel) renderPriorityItem(pick analysis.TopPick, width, height
 	return itemStyle.Render(sb.String())
 }
 
+// scoreTerm is one weighted component of a recommendation's score
+type scoreTerm struct {
+	label        string
+	weight       float64
+	norm         float64
+	contribution float64
+	note         string
+}
+
+// scoreTerms lists the ScoreBreakdown components in score formula order
+func scoreTerms(b analysis.ScoreBreakdown) []scoreTerm {
+	w := analysis.CurrentScoreWeights()
+	return []scoreTerm{
+		{"PageRank", w.PageRank, b.PageRankNorm, b.PageRank, ""},
+		{"Betweenness", w.Betweenness, b.BetweennessNorm, b.Betweenness, ""},
+		{"Blocker ratio", w.BlockerRatio, b.BlockerRatioNorm, b.BlockerRatio, ""},
+		{"Staleness", w.Staleness, b.StalenessNorm, b.Staleness, ""},
+		{"Priority", w.PriorityBoost, b.PriorityBoostNorm, b.PriorityBoost, ""},
+		{"Time to impact", w.TimeToImpact, b.TimeToImpactNorm, b.TimeToImpact, b.TimeToImpactExplanation},
+		{"Urgency", w.Urgency, b.UrgencyNorm, b.Urgency, b.UrgencyExplanation},
+		{"Risk", w.Risk, b.RiskNorm, b.Risk, b.RiskExplanation},
+	}
+}
+
+// renderPickExpansion renders the drill-down of the expanded priority pick:
+// a bar per score component, the issues it unblocks and the suggested
+// priority change. Returns nil when no pick is expanded.
+func (m *InsightsModel) renderPickExpansion(width int, t Theme) []string {
+	id := m.ExpandedPickID()
+	if id == "" {
+		return nil
+	}
+	rec := m.recommendationMap[id]
+	subtle := t.Renderer.NewStyle().Foreground(t.Subtext)
+	header := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+
+	lines := []string{header.Render(fmt.Sprintf("▾ Why %s", id))}
+	if rec == nil {
+		return append(lines, subtle.Italic(true).Render("  No score breakdown for this pick"))
+	}
+
+	const labelWidth, barWidth = 15, 12
+	for _, term := range scoreTerms(rec.Breakdown) {
+		line := fmt.Sprintf("  %-*s %s %3.0f%% × %.2f = %.3f",
+			labelWidth, term.label, RenderSparkline(term.norm, barWidth), term.norm*100, term.weight, term.contribution)
+		if room := width - lipgloss.Width(line) - 2; term.note != "" && room > 8 {
+			line += subtle.Render("  " + truncateRunesHelper(term.note, room, "…"))
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, fmt.Sprintf("  %-*s %s %.3f", labelWidth, "Score", strings.Repeat(" ", barWidth), rec.Score))
+
+	if len(rec.UnblocksIDs) > 0 {
+		const maxShown = 5
+		unblock := t.Renderer.NewStyle().Foreground(t.Open)
+		lines = append(lines, unblock.Bold(true).Render(fmt.Sprintf("  ↳ Unblocks %d:", len(rec.UnblocksIDs))))
+		for i, uid := range rec.UnblocksIDs {
+			if i == maxShown {
+				lines = append(lines, subtle.Render(fmt.Sprintf("    … and %d more", len(rec.UnblocksIDs)-maxShown)))
+				break
+			}
+			lines = append(lines, "    "+unblock.Render(uid)+" "+m.getBeadTitle(uid, width-len(uid)-6))
+		}
+	}
+
+	if hint := m.priorityHints[id]; hint != nil && hint.SuggestedPriority != hint.CurrentPriority {
+		action := t.Renderer.NewStyle().Foreground(t.Feature).Bold(true)
+		lines = append(lines, action.Render(fmt.Sprintf("  Suggested priority P%d (now P%d) · P to apply", hint.SuggestedPriority, hint.CurrentPriority)))
+	}
+	return lines
+}
+
 // renderHeatmapPanel renders a priority/depth heatmap visualization (bv-95)
 // Maps priority score (X) vs critical-path depth (Y) with color for urgency
 // Enhanced with cell selection, drill-down, and background gradient colors (bv-t4yg)
@@ -1,9 +1,11 @@
 package ui_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 )
@@ -581,3 +583,77 @@ func TestInsightsModelScrolling(t *testing.T) {
 		_ = m.View()
 	}
 }
+
+// TestInsightsModelFeedbackStats verifies correlation feedback appears in the summary line
+func TestInsightsModelFeedbackStats(t *testing.T) {
+	m := ui.NewInsightsModel(createTestInsights(), createTestIssueMap(), createTheme())
+	m.SetSize(120, 40)
+	if strings.Contains(m.View(), "Correlation feedback") {
+		t.Error("summary should omit correlation feedback when there is none")
+	}
+
+	m.SetFeedbackStats(correlation.FeedbackStats{TotalFeedback: 4, Confirmed: 3, Rejected: 1, AccuracyRate: 0.75})
+	view := m.View()
+	if !strings.Contains(view, "Correlation feedback: ✓3 ✗1 ⊘0, 75% accurate") {
+		t.Error("summary should include correlation feedback stats")
+	}
+}
+
+// TestInsightsModelHealthScore verifies the health score heads the view, weakest component first
+func TestInsightsModelHealthScore(t *testing.T) {
+	m := ui.NewInsightsModel(createTestInsights(), createTestIssueMap(), createTheme())
+	m.SetSize(160, 40)
+	if strings.Contains(m.View(), "Project health") {
+		t.Error("health line should be hidden until a score is set")
+	}
+
+	m.SetHealthScore(&analysis.HealthScore{Score: 64, Level: analysis.HealthLevelWarning, Components: []analysis.HealthComponent{
+		{Name: "blocked", Score: 80}, {Name: "cycles", Score: 50},
+	}})
+	view := m.View()
+	if !strings.Contains(view, "Project health 64/100") || !strings.Contains(view, "warning") {
+		t.Errorf("view should lead with the health score:\n%s", view)
+	}
+	if !strings.Contains(view, "cycles 50 · blocked 80") {
+		t.Error("components should be listed weakest first")
+	}
+}
+
+// TestInsightsModelPickExpansion verifies a priority pick expands to its score breakdown
+func TestInsightsModelPickExpansion(t *testing.T) {
+	issues := createTestIssueMap()
+	m := ui.NewInsightsModel(createTestInsights(), issues, createTheme())
+	m.SetSize(160, 60)
+	m.SetTopPicks([]analysis.TopPick{{ID: "bottleneck-1", Title: "Bottleneck", Score: 0.8, Unblocks: 1}})
+	m.SetRecommendations([]analysis.Recommendation{{
+		ID:          "bottleneck-1",
+		Score:       0.8,
+		Breakdown:   analysis.ScoreBreakdown{PageRankNorm: 1, PageRank: analysis.WeightPageRank, UrgencyExplanation: "Labelled urgent"},
+		UnblocksIDs: []string{"keystone-1"},
+	}}, "")
+	m.SetPriorityHints(map[string]*analysis.PriorityRecommendation{
+		"bottleneck-1": {IssueID: "bottleneck-1", CurrentPriority: 2, SuggestedPriority: 0},
+	})
+
+	m.TogglePickExpanded()
+	if m.ExpandedPickID() != "" {
+		t.Fatal("only the priority panel should expand")
+	}
+	m.PrevPanel() // Wraps to the priority panel
+	m.TogglePickExpanded()
+	if got := m.ExpandedPickID(); got != "bottleneck-1" {
+		t.Fatalf("expected the selected pick to expand, got %q", got)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Why bottleneck-1", "PageRank", "Labelled urgent", "Unblocks 1:", "keystone-1", "Suggested priority P0 (now P2)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expanded pick should show %q", want)
+		}
+	}
+
+	m.TogglePickExpanded()
+	if strings.Contains(m.View(), "Why bottleneck-1") {
+		t.Error("collapsing should hide the breakdown")
+	}
+}
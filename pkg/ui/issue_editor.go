@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// issueFrontMatter is the editable metadata written above the description
+type issueFrontMatter struct {
+	ID       string   `yaml:"id"`
+	Title    string   `yaml:"title"`
+	Status   string   `yaml:"status"`
+	Priority int      `yaml:"priority"`
+	Type     string   `yaml:"type"`
+	Assignee string   `yaml:"assignee"`
+	Labels   []string `yaml:"labels,flow"`
+	Estimate *int     `yaml:"estimated_minutes"` // null when unestimated
+}
+
+const issueEditHeader = "# Edit the fields and the description below, then save and quit.\n# Empty the file to cancel. The id cannot be changed.\n"
+
+// IssueEditedMsg reports the outcome of an external editor round-trip
+type IssueEditedMsg struct {
+	IssueID string
+	Changed []string // Names of the fields that were written
+	Err     error
+}
+
+// formatIssueForEdit renders an issue as front-matter markdown
+func formatIssueForEdit(issue model.Issue) ([]byte, error) {
+	fm := issueFrontMatter{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: issue.Priority,
+		Type:     string(issue.IssueType),
+		Assignee: issue.Assignee,
+		Labels:   issue.Labels,
+		Estimate: issue.EstimatedMinutes,
+	}
+	meta, err := yaml.Marshal(fm)
+	if err != nil {
+		return nil, fmt.Errorf("encoding front matter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.WriteString(issueEditHeader)
+	buf.Write(meta)
+	buf.WriteString("---\n\n")
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		buf.WriteString(desc)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// parseEditedIssue applies edited front-matter markdown to original and
+// returns the updated issue with the names of the fields that changed.
+// An empty document yields no changes.
+func parseEditedIssue(data []byte, original model.Issue) (model.Issue, []string, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if strings.TrimSpace(text) == "" {
+		return original, nil, nil
+	}
+
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return original, nil, fmt.Errorf("missing front matter: file must start with ---")
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return original, nil, fmt.Errorf("unterminated front matter: closing --- not found")
+	}
+	meta := rest[:end+1]
+	body := rest[end+len("\n---"):]
+	if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+		body = body[nl+1:]
+	} else {
+		body = ""
+	}
+
+	var fm issueFrontMatter
+	dec := yaml.NewDecoder(strings.NewReader(meta))
+	dec.KnownFields(true)
+	if err := dec.Decode(&fm); err != nil && !errors.Is(err, io.EOF) {
+		return original, nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	if fm.ID != original.ID {
+		return original, nil, fmt.Errorf("id cannot be changed (was %s, got %q)", original.ID, fm.ID)
+	}
+	if fm.Priority < 0 || fm.Priority > 4 {
+		return original, nil, fmt.Errorf("priority must be between 0 and 4, got %d", fm.Priority)
+	}
+	if fm.Estimate != nil && *fm.Estimate < 0 {
+		return original, nil, fmt.Errorf("estimated_minutes cannot be negative, got %d", *fm.Estimate)
+	}
+
+	updated := original.Clone()
+	updated.Title = strings.TrimSpace(fm.Title)
+	updated.Status = model.Status(strings.TrimSpace(fm.Status))
+	updated.Priority = fm.Priority
+	updated.IssueType = model.IssueType(strings.TrimSpace(fm.Type))
+	updated.Assignee = strings.TrimSpace(fm.Assignee)
+	updated.Labels = nil
+	seen := make(map[string]bool)
+	for _, label := range fm.Labels {
+		label = strings.TrimSpace(label)
+		if label != "" && !seen[label] {
+			seen[label] = true
+			updated.Labels = append(updated.Labels, label)
+		}
+	}
+	updated.Description = strings.TrimSpace(body)
+	updated.EstimatedMinutes = nil
+	if fm.Estimate != nil && *fm.Estimate > 0 {
+		minutes := *fm.Estimate
+		updated.EstimatedMinutes = &minutes
+	}
+
+	if err := updated.Validate(); err != nil {
+		return original, nil, err
+	}
+
+	var changed []string
+	note := func(field string, differs bool) {
+		if differs {
+			changed = append(changed, field)
+		}
+	}
+	note("title", updated.Title != original.Title)
+	note("status", updated.Status != original.Status)
+	note("priority", updated.Priority != original.Priority)
+	note("type", updated.IssueType != original.IssueType)
+	note("assignee", updated.Assignee != original.Assignee)
+	note("labels", strings.Join(updated.Labels, "\x00") != strings.Join(original.Labels, "\x00"))
+	note("description", updated.Description != strings.TrimSpace(original.Description))
+	note("estimate", estimateMinutes(updated) != estimateMinutes(original))
+	return updated, changed, nil
+}
+
+// estimateMinutes returns the issue's estimate, 0 when unestimated
+func estimateMinutes(issue model.Issue) int {
+	if issue.EstimatedMinutes == nil || *issue.EstimatedMinutes < 0 {
+		return 0
+	}
+	return *issue.EstimatedMinutes
+}
+
+// formatEstimate renders minutes of work as "45m", "2h" or "2h 30m"
+func formatEstimate(minutes int) string {
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+}
+
+// editorCommand returns the user's terminal editor split into argv.
+// The TUI is suspended while it runs, so terminal editors work; GUI editors
+// need their wait flag (e.g. "code --wait").
+func editorCommand() []string {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if fields := strings.Fields(editor); len(fields) > 0 {
+		return fields
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// editIssueCmd dumps the selected issue to a temp file and opens it in
+// $EDITOR. Without a selection it falls back to opening the beads file.
+func (m *Model) editIssueCmd() tea.Cmd {
+	if m.refuseReadOnly() {
+		return nil
+	}
+
+	sel, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		m.openInEditor()
+		return nil
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return nil
+	}
+	issue, err := m.hydrateIssue(sel.Issue)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not read the text of %s, not editing: %v", issue.ID, err)
+		m.statusIsError = true
+		return nil
+	}
+
+	path, err := writeIssueEditFile(issue)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to prepare %s for editing: %v", issue.ID, err)
+		m.statusIsError = true
+		return nil
+	}
+
+	argv := editorCommand()
+	cmd := exec.Command(argv[0], append(argv[1:], path)...)
+	beadsPath := m.beadsPath
+	return tea.ExecProcess(cmd, func(runErr error) tea.Msg {
+		return finishIssueEdit(beadsPath, issue, path, runErr)
+	})
+}
+
+// applySuggestedPriorityCmd writes the priority the analysis recommends for
+// issueID into the beads file and logs it as accepted
+func (m *Model) applySuggestedPriorityCmd(issueID string) tea.Cmd {
+	if m.refuseReadOnly() {
+		return nil
+	}
+	change, ok := m.priorityChangeFor(issueID)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("No priority change suggested for %s", issueID)
+		m.statusIsError = false
+		return nil
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return nil
+	}
+
+	field := fmt.Sprintf("priority P%d → P%d", change.rec.CurrentPriority, change.priority)
+	beadsPath, log := m.beadsPath, m.priorityDecisions
+	return func() tea.Msg {
+		if _, err := writeSuggestedPriorities(beadsPath, log, []priorityChange{change}, time.Now()); err != nil {
+			return IssueEditedMsg{IssueID: issueID, Err: err}
+		}
+		return IssueEditedMsg{IssueID: issueID, Changed: []string{field}}
+	}
+}
+
+// tempFileSafe replaces characters that are awkward in file names
+func tempFileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// writeIssueEditFile dumps issue to a new temp markdown file
+func writeIssueEditFile(issue model.Issue) (string, error) {
+	content, err := formatIssueForEdit(issue)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "bv-"+tempFileSafe(issue.ID)+"-*.md")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("closing temp file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// finishIssueEdit reads the edited temp file back, validates it and writes
+// the changes into the beads file. The temp file is removed unless the edit
+// was rejected, so the user's text is not lost.
+func finishIssueEdit(beadsPath string, original model.Issue, path string, runErr error) IssueEditedMsg {
+	msg := IssueEditedMsg{IssueID: original.ID}
+	if runErr != nil {
+		_ = os.Remove(path)
+		msg.Err = fmt.Errorf("editor exited with error: %w", runErr)
+		return msg
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		msg.Err = fmt.Errorf("reading edited file: %w", err)
+		return msg
+	}
+	updated, changed, err := parseEditedIssue(data, original)
+	if err != nil {
+		msg.Err = fmt.Errorf("%w (your edit is saved in %s)", err, path)
+		return msg
+	}
+	if len(changed) > 0 {
+		if err := loader.UpdateIssueInFile(beadsPath, updated); err != nil {
+			msg.Err = fmt.Errorf("%w (your edit is saved in %s)", err, path)
+			return msg
+		}
+	}
+	_ = os.Remove(path)
+	msg.Changed = changed
+	return msg
+}
+
+// refuseReadOnly reports (and explains in the status bar) when the current
+// view cannot be written back to the beads file
+func (m *Model) refuseReadOnly() bool {
+	switch {
+	case m.pastSnapshot != nil:
+		m.statusMsg = "⏪ Viewing a past snapshot (read-only) - press t to return"
+	case m.timeTravelMode:
+		m.statusMsg = "⏱️ Time-travel view is read-only - press t to exit before editing"
+	case m.workspaceMode:
+		m.statusMsg = "❌ Editing is not supported in workspace mode"
+	case loader.IsCompressedJSONL(m.beadsPath):
+		m.statusMsg = "📦 Compressed beads files are read-only - decompress the file to edit"
+	default:
+		return false
+	}
+	m.statusIsError = true
+	return true
+}
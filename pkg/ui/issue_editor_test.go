@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func editableIssue() model.Issue {
+	return model.Issue{
+		ID:          "bv-1",
+		Title:       "Fix login: timeout",
+		Description: "Users get logged out.\n\n## Steps\n1. Wait",
+		Status:      model.StatusOpen,
+		Priority:    2,
+		IssueType:   model.TypeBug,
+		Assignee:    "alice",
+		Labels:      []string{"auth", "ui"},
+	}
+}
+
+func TestIssueEditRoundTrip(t *testing.T) {
+	issue := editableIssue()
+	data, err := formatIssueForEdit(issue)
+	if err != nil {
+		t.Fatalf("formatIssueForEdit: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "---\n") || !strings.Contains(string(data), "## Steps") {
+		t.Fatalf("unexpected document:\n%s", data)
+	}
+
+	// Unchanged document round-trips without changes
+	got, changed, err := parseEditedIssue(data, issue)
+	if err != nil || len(changed) != 0 {
+		t.Fatalf("expected no changes, got %v (err %v)", changed, err)
+	}
+	if got.Title != issue.Title || got.Description != issue.Description {
+		t.Errorf("round trip altered the issue: %+v", got)
+	}
+
+	edited := strings.Replace(string(data), "status: open", "status: in_progress", 1)
+	edited = strings.Replace(edited, "labels: [auth, ui]", "labels: [auth, auth, backend]", 1)
+	edited += "\nMore detail.\n"
+	got, changed, err = parseEditedIssue([]byte(edited), issue)
+	if err != nil {
+		t.Fatalf("parseEditedIssue: %v", err)
+	}
+	if strings.Join(changed, ",") != "status,labels,description" {
+		t.Errorf("unexpected changed fields %v", changed)
+	}
+	if got.Status != model.StatusInProgress || strings.Join(got.Labels, ",") != "auth,backend" || !strings.HasSuffix(got.Description, "More detail.") {
+		t.Errorf("edits not applied: %+v", got)
+	}
+
+	// Estimates are set, changed and cleared through estimated_minutes
+	edited = strings.Replace(string(data), "estimated_minutes: null", "estimated_minutes: 90", 1)
+	got, changed, err = parseEditedIssue([]byte(edited), issue)
+	if err != nil || strings.Join(changed, ",") != "estimate" || estimateMinutes(got) != 90 {
+		t.Fatalf("expected a 90 minute estimate, got %v %v (err %v)", got.EstimatedMinutes, changed, err)
+	}
+	data, _ = formatIssueForEdit(got)
+	edited = strings.Replace(string(data), "estimated_minutes: 90", "estimated_minutes: 0", 1)
+	if got, changed, _ = parseEditedIssue([]byte(edited), got); got.EstimatedMinutes != nil || len(changed) != 1 {
+		t.Errorf("0 should clear the estimate, got %v %v", got.EstimatedMinutes, changed)
+	}
+	if s := formatEstimate(150); s != "2h 30m" {
+		t.Errorf("formatEstimate(150) = %q", s)
+	}
+}
+
+func TestParseEditedIssueValidation(t *testing.T) {
+	issue := editableIssue()
+	data, _ := formatIssueForEdit(issue)
+	doc := string(data)
+
+	if _, changed, err := parseEditedIssue([]byte("  \n"), issue); err != nil || changed != nil {
+		t.Errorf("empty file should cancel, got %v %v", changed, err)
+	}
+
+	cases := map[string]string{
+		"id cannot be changed":  strings.Replace(doc, "id: bv-1", "id: bv-2", 1),
+		"invalid status":        strings.Replace(doc, "status: open", "status: done", 1),
+		"priority must be":      strings.Replace(doc, "priority: 2", "priority: 9", 1),
+		"title cannot be empty": strings.Replace(doc, `title: 'Fix login: timeout'`, `title: ""`, 1),
+		"invalid front matter":  strings.Replace(doc, "assignee: alice", "owner: alice", 1),
+		"cannot be negative":    strings.Replace(doc, "estimated_minutes: null", "estimated_minutes: -5", 1),
+		"missing front matter":  "just a description",
+		"unterminated":          "---\ntitle: x\n",
+	}
+	for want, input := range cases {
+		if input == doc {
+			t.Fatalf("case %q did not modify the document", want)
+		}
+		if _, _, err := parseEditedIssue([]byte(input), issue); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestFinishIssueEditWritesBeadsFile(t *testing.T) {
+	dir := t.TempDir()
+	beadsPath := filepath.Join(dir, "issues.jsonl")
+	issue := editableIssue()
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"bv-1","title":"Fix login: timeout","description":"Users get logged out.\n\n## Steps\n1. Wait","status":"open","priority":2,"issue_type":"bug","assignee":"alice","labels":["auth","ui"]}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := writeIssueEditFile(issue)
+	if err != nil {
+		t.Fatalf("writeIssueEditFile: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	edited := strings.Replace(string(data), "priority: 2", "priority: 0", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := finishIssueEdit(beadsPath, issue, path, nil)
+	if msg.Err != nil || strings.Join(msg.Changed, ",") != "priority" {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("temp file should be removed after a successful edit")
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil || len(issues) != 1 || issues[0].Priority != 0 {
+		t.Fatalf("beads file not updated: %+v (err %v)", issues, err)
+	}
+
+	// A rejected edit keeps the temp file so the text is not lost
+	path, _ = writeIssueEditFile(issue)
+	t.Cleanup(func() { _ = os.Remove(path) })
+	_ = os.WriteFile(path, []byte("---\nid: bv-1\nstatus: bogus\n---\n"), 0o600)
+	msg = finishIssueEdit(beadsPath, issue, path, nil)
+	if msg.Err == nil || !strings.Contains(msg.Err.Error(), path) {
+		t.Fatalf("expected error mentioning the temp file, got %v", msg.Err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("temp file should be kept after a rejected edit: %v", err)
+	}
+}
+
+func TestEditIssueCmdGuards(t *testing.T) {
+	m := NewModel([]model.Issue{editableIssue()}, nil, "")
+	m.pastSnapshot = &PastSnapshot{SHA: "abc", ShortSHA: "abc"}
+	if cmd := m.editIssueCmd(); cmd != nil || !strings.Contains(m.statusMsg, "read-only") {
+		t.Errorf("past snapshot should block editing, got %q", m.statusMsg)
+	}
+	m.pastSnapshot = nil
+
+	m.beadsPath = ""
+	if cmd := m.editIssueCmd(); cmd != nil || !strings.Contains(m.statusMsg, "No beads file") {
+		t.Errorf("missing beads path should block editing, got %q", m.statusMsg)
+	}
+
+	t.Setenv("TMPDIR", t.TempDir()) // the edit file is written but the editor never runs
+	m.beadsPath = "/tmp/issues.jsonl"
+	if cmd := m.editIssueCmd(); cmd == nil {
+		t.Fatal("expected an editor command")
+	}
+
+	updated, _ := m.Update(IssueEditedMsg{IssueID: "bv-1", Changed: []string{"status", "title"}})
+	toasts := updated.(Model).toasts.Active()
+	if len(toasts) != 1 || toasts[0].Level != ToastSuccess || !strings.Contains(toasts[0].Message, "Saved bv-1 (status, title)") {
+		t.Errorf("unexpected toasts %+v", toasts)
+	}
+}
+
+func TestApplySuggestedPriorityCmd(t *testing.T) {
+	beadsPath := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"bv-1","title":"Fix login: timeout","status":"open","priority":2,"issue_type":"bug"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel([]model.Issue{editableIssue()}, nil, beadsPath)
+
+	if cmd := m.applySuggestedPriorityCmd("bv-1"); cmd != nil || !strings.Contains(m.statusMsg, "No priority change") {
+		t.Fatalf("no hint should mean no write, got %q", m.statusMsg)
+	}
+
+	m.priorityHints = map[string]*analysis.PriorityRecommendation{
+		"bv-1": {IssueID: "bv-1", CurrentPriority: 2, SuggestedPriority: 0},
+	}
+	cmd := m.applySuggestedPriorityCmd("bv-1")
+	if cmd == nil {
+		t.Fatal("expected a write command")
+	}
+	msg, ok := cmd().(IssueEditedMsg)
+	if !ok || msg.Err != nil || strings.Join(msg.Changed, ",") != "priority P2 → P0" {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil || len(issues) != 1 || issues[0].Priority != 0 {
+		t.Fatalf("beads file not updated: %+v (err %v)", issues, err)
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// issueRefToken matches tokens shaped like bead IDs (prefix-suffix, with
+// optional .N or -part children). Only tokens naming a known issue are links.
+var issueRefToken = regexp.MustCompile(`[A-Za-z0-9][A-Za-z0-9_]*-[A-Za-z0-9]+(?:[.-][A-Za-z0-9]+)*`)
+
+// issueRefText returns the free-text fields scanned for issue references
+func issueRefText(issue model.Issue) []string {
+	return []string{issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes}
+}
+
+// findIssueRefs returns the known issue IDs referenced in texts, in order of
+// first appearance, excluding selfID. References inside code are ignored.
+func findIssueRefs(texts []string, known func(id string) bool, selfID string) []string {
+	var refs []string
+	seen := map[string]bool{selfID: true}
+	for _, text := range texts {
+		forEachIssueRef(text, known, func(id string) string {
+			if !seen[id] {
+				seen[id] = true
+				refs = append(refs, id)
+			}
+			return id
+		})
+	}
+	return refs
+}
+
+// highlightIssueRefs renders known issue references as inline code so they
+// stand out; the current reference is marked with ▸
+func highlightIssueRefs(text string, known func(id string) bool, current string) string {
+	return forEachIssueRef(text, known, func(id string) string {
+		if id == current {
+			return "`▸ " + id + "`"
+		}
+		return "`" + id + "`"
+	})
+}
+
+// forEachIssueRef rewrites each reference to a known issue in markdown text
+// with replace(id). Fenced code blocks, inline code spans and tokens that are
+// part of URLs or paths are left untouched.
+func forEachIssueRef(text string, known func(id string) bool, replace func(id string) string) string {
+	if text == "" {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		// Split on backticks: odd segments are inline code
+		segments := strings.Split(line, "`")
+		for j := 0; j < len(segments); j += 2 {
+			segments[j] = replaceRefsInSegment(segments[j], known, replace)
+		}
+		lines[i] = strings.Join(segments, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func replaceRefsInSegment(s string, known func(id string) bool, replace func(id string) string) string {
+	matches := issueRefToken.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range matches {
+		start, end := loc[0], loc[1]
+		if start > 0 && strings.ContainsRune("/:=@#.", rune(s[start-1])) {
+			continue
+		}
+		// Drop trailing .N/-part segments until a known ID remains
+		// (e.g. "bv-abc1-followup" links bv-abc1)
+		id := s[start:end]
+		for !known(id) {
+			cut := strings.LastIndexAny(id, ".-")
+			if cut <= 0 {
+				break
+			}
+			id = id[:cut]
+		}
+		if !known(id) {
+			continue
+		}
+		end = start + len(id)
+		sb.WriteString(s[last:start])
+		sb.WriteString(replace(id))
+		last = end
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// issueRefPreview is a one-line summary of a linked issue for the status bar
+func issueRefPreview(issue *model.Issue, index, total int) string {
+	title := issue.Title
+	if r := []rune(title); len(r) > 60 {
+		title = string(r[:59]) + "…"
+	}
+	return fmt.Sprintf("🔗 %d/%d %s %s P%d %s · %s — gd open · n/N next",
+		index+1, total, GetTypeIconMD(string(issue.IssueType)), issue.ID, issue.Priority, issue.Status, title)
+}
+
+// knownIssue reports whether id names a loaded issue
+func (m *Model) knownIssue(id string) bool {
+	_, ok := m.issueMap[id]
+	return ok
+}
+
+// currentIssueRef returns the selected linked issue ID in the detail view
+func (m *Model) currentIssueRef() string {
+	if m.detailRefIdx < 0 || m.detailRefIdx >= len(m.detailRefs) {
+		return ""
+	}
+	return m.detailRefs[m.detailRefIdx]
+}
+
+// cycleIssueRef moves the linked-issue cursor and previews the target
+func (m *Model) cycleIssueRef(delta int) {
+	n := len(m.detailRefs)
+	if n == 0 {
+		return
+	}
+	m.detailRefIdx = ((m.detailRefIdx+delta)%n + n) % n
+	m.updateViewportContent()
+	m.previewIssueRef()
+}
+
+// previewIssueRef shows the current linked issue in the status bar
+func (m *Model) previewIssueRef() {
+	id := m.currentIssueRef()
+	if issue, ok := m.issueMap[id]; ok {
+		m.statusMsg = issueRefPreview(issue, m.detailRefIdx, len(m.detailRefs))
+		m.statusIsError = false
+	}
+}
+
+// gotoIssueRef selects the current linked issue in the list and shows its
+// details, clearing filters if they hide it
+func (m *Model) gotoIssueRef() {
+	id := m.currentIssueRef()
+	if id == "" {
+		return
+	}
+	from := m.selectedIssueID()
+	if !m.selectIssueInList(id) {
+		m.clearAllFilters()
+		if !m.selectIssueInList(id) {
+			m.statusMsg = fmt.Sprintf("❌ %s is not in the list", id)
+			m.statusIsError = true
+			return
+		}
+	}
+	m.nav.record(from, id)
+	m.focused = focusDetail
+	if !m.isSplitView {
+		m.showDetails = true
+	}
+	m.updateViewportContent()
+	m.viewport.GotoTop()
+	m.statusMsg = fmt.Sprintf("🔗 Jumped to %s", id)
+	m.statusIsError = false
+}
+
+// selectIssueInList selects the list item for id, reporting whether it was found
+func (m *Model) selectIssueInList(id string) bool {
+	for i, item := range m.list.Items() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+			m.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFindAndHighlightIssueRefs(t *testing.T) {
+	known := func(id string) bool {
+		return id == "bv-abc1" || id == "bv-2" || id == "bv-self"
+	}
+	text := "Blocked on bv-abc1 and bv-2.\n" +
+		"See bv-abc1-followup, https://example.com/bv-2 and `bv-2` in code.\n" +
+		"```\nbv-abc1 in a fence\n```\n" +
+		"Unknown bv-zzz, self bv-self, words like end-to-end."
+
+	refs := findIssueRefs([]string{text}, known, "bv-self")
+	if strings.Join(refs, ",") != "bv-abc1,bv-2" {
+		t.Fatalf("unexpected refs %v", refs)
+	}
+
+	got := highlightIssueRefs(text, known, "bv-2")
+	for _, want := range []string{
+		"Blocked on `bv-abc1` and `▸ bv-2`.",
+		"See `bv-abc1`-followup",
+		"https://example.com/bv-2 and `bv-2` in code",
+		"```\nbv-abc1 in a fence\n```",
+		"Unknown bv-zzz",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("highlighted text missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDetailLinkedIssueNavigation(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, IssueType: model.TypeTask, Description: "Needs bv-b then bv-c."},
+		{ID: "bv-b", Title: "Beta work", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeBug},
+		{ID: "bv-c", Title: "Gamma", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 80, 120 // tall enough to show the whole detail
+	m.splitOrientation = SplitVertical
+	m.resizePanes()
+	m.showDetails = true
+	m.focused = focusDetail
+	m.selectIssueInList("A")
+	m.updateViewportContent()
+
+	if strings.Join(m.detailRefs, ",") != "bv-b,bv-c" {
+		t.Fatalf("unexpected refs %v", m.detailRefs)
+	}
+	if view := m.viewport.View(); !strings.Contains(view, "Linked") || !strings.Contains(view, "Gamma") {
+		t.Errorf("expected linked issue previews in the detail view")
+	}
+
+	key := func(k string) {
+		tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = tm.(Model)
+	}
+	key("n")
+	if m.currentIssueRef() != "bv-c" || !strings.Contains(m.statusMsg, "bv-c") || !strings.Contains(m.statusMsg, "Gamma") {
+		t.Fatalf("n should select and preview bv-c, got %q (%q)", m.currentIssueRef(), m.statusMsg)
+	}
+	key("N")
+	if m.currentIssueRef() != "bv-b" {
+		t.Fatalf("N should go back to bv-b, got %q", m.currentIssueRef())
+	}
+
+	// A closed target hidden by the open filter is still reachable
+	m.currentFilter = "open"
+	m.applyFilter()
+	m.selectIssueInList("A")
+	m.updateViewportContent()
+	key("g")
+	if !m.waitingForGoto {
+		t.Fatal("g should start the goto chord")
+	}
+	key("d")
+	sel, _ := m.list.SelectedItem().(IssueItem)
+	if sel.Issue.ID != "bv-b" || m.focused != focusDetail || m.currentFilter != "all" {
+		t.Fatalf("gd should open bv-b, got %q focus=%v filter=%q", sel.Issue.ID, m.focused, m.currentFilter)
+	}
+}
@@ -52,6 +52,8 @@ type IssueItem struct {
 	IsQuickWin    bool     // True if identified as a quick win
 	IsBlocker     bool     // True if this item blocks significant downstream work
 	UnblocksCount int      // Number of items this unblocks
+
+	LocalNote string // Private note from .bv/notes/, searchable but never exported
 }
 
 func (i IssueItem) Title() string {
@@ -89,6 +91,12 @@ func (i IssueItem) FilterValue() string {
 		sb.WriteString(i.RepoPrefix)
 	}
 
+	// Private notes are searchable too
+	if i.LocalNote != "" {
+		sb.WriteString(" ")
+		sb.WriteString(i.LocalNote)
+	}
+
 	return sb.String()
 }
 
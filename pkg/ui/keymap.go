@@ -0,0 +1,573 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// KeymapConfigFilename is the user config that remaps keys, kept next to
+// ui-state.json.
+const KeymapConfigFilename = "keymap.yaml"
+
+// Binding is an action and the keys that trigger it
+type Binding struct {
+	Action string   // Name used in keymap.yaml, e.g. "view.board"
+	Keys   []string // Alternatives, as reported by tea.KeyMsg.String(); "g d" is a sequence
+	Help   string
+
+	fixed    bool // Cannot be remapped: sequences, digit ranges, force quit
+	remapped bool // Keys come from keymap.yaml
+}
+
+// KeymapSection groups related bindings in the help overlay
+type KeymapSection struct {
+	Title    string
+	Icon     string
+	Contexts []Context // Where the bindings apply; nil means every view
+	Bindings []Binding
+}
+
+// Contexts where the issue list (or the selected issue) takes commands
+var listContexts = []Context{ContextList, ContextFilter, ContextSplit, ContextDetail, ContextTimeTravel}
+
+// defaultKeymap is the registry of key bindings. Handlers match these keys
+// directly; remaps are translated back to them (see Keymap.Translate), so a
+// new binding must be added both here and to its handler.
+var defaultKeymap = []KeymapSection{
+	{Title: "Global", Icon: "🌐", Bindings: []Binding{
+		{Action: "global.palette", Keys: []string{"ctrl+p"}, Help: "Command palette"},
+		{Action: "global.help", Keys: []string{"?", "f1"}, Help: "This help"},
+		{Action: "global.shortcuts", Keys: []string{";", "f2"}, Help: "Shortcuts bar"},
+		{Action: "global.tutorial", Keys: []string{"`"}, Help: "Tutorial"},
+		{Action: "global.alerts", Keys: []string{"!"}, Help: "Alerts panel"},
+		{Action: "global.digest", Keys: []string{"A"}, Help: "Attention digest"},
+		{Action: "global.notifications", Keys: []string{"E"}, Help: "Notifications"},
+		{Action: "global.recipes", Keys: []string{"'"}, Help: "Recipes"},
+		{Action: "global.repos", Keys: []string{"w"}, Help: "Repo picker"},
+		{Action: "global.quit", Keys: []string{"q"}, Help: "Back / Quit"},
+		{Action: "global.force_quit", Keys: []string{"ctrl+c"}, Help: "Force quit", fixed: true},
+	}},
+	{Title: "Views", Icon: "👁", Bindings: []Binding{
+		{Action: "view.board", Keys: []string{"b"}, Help: "Kanban board"},
+		{Action: "view.graph", Keys: []string{"g"}, Help: "Graph view"},
+		{Action: "view.insights", Keys: []string{"i"}, Help: "Insights"},
+		{Action: "view.history", Keys: []string{"h"}, Help: "History view"},
+		{Action: "view.actionable", Keys: []string{"a"}, Help: "Actionable"},
+		{Action: "view.flow", Keys: []string{"f"}, Help: "Flow matrix"},
+		{Action: "view.labels", Keys: []string{"["}, Help: "Label dashboard"},
+		{Action: "view.attention", Keys: []string{"]"}, Help: "Attention view"},
+	}},
+	{Title: "Navigation", Icon: "🧭", Contexts: listContexts, Bindings: []Binding{
+		{Action: "nav.down", Keys: []string{"j", "down"}, Help: "Move down"},
+		{Action: "nav.up", Keys: []string{"k", "up"}, Help: "Move up"},
+		{Action: "nav.bottom", Keys: []string{"G", "end"}, Help: "Go to last"},
+		{Action: "nav.page_down", Keys: []string{"ctrl+d"}, Help: "Page down"},
+		{Action: "nav.page_up", Keys: []string{"ctrl+u"}, Help: "Page up"},
+		{Action: "nav.count", Keys: []string{"5 j"}, Help: "Repeat a motion (count prefix)", fixed: true},
+		{Action: "nav.focus", Keys: []string{"tab"}, Help: "Switch focus"},
+		{Action: "nav.split_shrink", Keys: []string{"ctrl+h"}, Help: "Shrink list pane"},
+		{Action: "nav.split_grow", Keys: []string{"ctrl+l"}, Help: "Grow list pane"},
+		{Action: "nav.split_orientation", Keys: []string{"|"}, Help: "Split orientation"},
+		{Action: "nav.open", Keys: []string{"enter"}, Help: "View details"},
+		{Action: "nav.detail_history", Keys: []string{"v"}, Help: "Detail history tab"},
+		{Action: "nav.back", Keys: []string{"ctrl+o", "alt+left"}, Help: "Jump back"},
+		{Action: "nav.forward", Keys: []string{"ctrl+]", "alt+right"}, Help: "Jump forward"},
+		{Action: "nav.close", Keys: []string{"esc"}, Help: "Back / close"},
+	}},
+	{Title: "Issue Detail", Icon: "📄", Contexts: []Context{ContextDetail, ContextSplit}, Bindings: []Binding{
+		{Action: "detail.next_link", Keys: []string{"n"}, Help: "Next linked issue"},
+		{Action: "detail.prev_link", Keys: []string{"N"}, Help: "Previous linked issue"},
+		{Action: "detail.open_link", Keys: []string{"g d"}, Help: "Open linked issue", fixed: true},
+		{Action: "detail.next_attachment", Keys: []string{"u"}, Help: "Next attachment"},
+		{Action: "detail.prev_attachment", Keys: []string{"U"}, Help: "Previous attachment"},
+		{Action: "detail.open_attachment", Keys: []string{"@"}, Help: "Open attachment"},
+		{Action: "detail.blockers", Keys: []string{"<"}, Help: "Jump to an open blocker"},
+	}},
+	{Title: "Filters & Sort", Icon: "🔍", Contexts: []Context{ContextList, ContextFilter, ContextSplit, ContextTimeTravel}, Bindings: []Binding{
+		{Action: "filter.search", Keys: []string{"/"}, Help: "Fuzzy search"},
+		{Action: "filter.results", Keys: []string{"ctrl+f"}, Help: "Search results pane"},
+		{Action: "filter.semantic", Keys: []string{"ctrl+s"}, Help: "Semantic search"},
+		{Action: "filter.hybrid", Keys: []string{"H"}, Help: "Hybrid ranking"},
+		{Action: "filter.hybrid_preset", Keys: []string{"alt+h"}, Help: "Hybrid preset"},
+		{Action: "filter.open", Keys: []string{"o"}, Help: "Open issues"},
+		{Action: "filter.closed", Keys: []string{"c"}, Help: "Closed issues"},
+		{Action: "filter.ready", Keys: []string{"r"}, Help: "Ready (unblocked)"},
+		{Action: "filter.starred", Keys: []string{"z"}, Help: "Starred issues"},
+		{Action: "filter.snoozed", Keys: []string{"W"}, Help: "Snoozed issues"},
+		{Action: "filter.label", Keys: []string{"l"}, Help: "Filter by label"},
+		{Action: "filter.deleted", Keys: []string{"X"}, Help: "Show deleted"},
+		{Action: "filter.archived", Keys: []string{"ctrl+a"}, Help: "Show archived"},
+		{Action: "sort.cycle", Keys: []string{"s"}, Help: "Cycle sort"},
+		{Action: "sort.triage", Keys: []string{"S"}, Help: "Triage sort"},
+	}},
+	{Title: "Actions", Icon: "⚡", Contexts: listContexts, Bindings: []Binding{
+		{Action: "action.priority_hints", Keys: []string{"p"}, Help: "Priority hints"},
+		{Action: "action.priority_review", Keys: []string{"P"}, Help: "Review priority suggestions"},
+		{Action: "action.forecast", Keys: []string{"F"}, Help: "Completion forecast"},
+		{Action: "action.effort", Keys: []string{"R"}, Help: "Effort rollup"},
+		{Action: "action.unblock", Keys: []string{"Q"}, Help: "Unblock queue"},
+		{Action: "action.heatmap", Keys: []string{"m"}, Help: "Risk heatmap"},
+		{Action: "action.time_travel", Keys: []string{"t"}, Help: "Time-travel"},
+		{Action: "action.quick_time_travel", Keys: []string{"T"}, Help: "Quick time-travel"},
+		{Action: "action.export", Keys: []string{"x"}, Help: "Export markdown"},
+		{Action: "action.star", Keys: []string{"*"}, Help: "Star / unstar"},
+		{Action: "action.snooze", Keys: []string{"Z"}, Help: "Snooze / wake"},
+		{Action: "action.note", Keys: []string{"e"}, Help: "Edit private note"},
+		{Action: "action.copy_id", Keys: []string{"y"}, Help: "Copy ID"},
+		{Action: "action.copy_markdown", Keys: []string{"C"}, Help: "Copy as Markdown"},
+		{Action: "action.copy_json", Keys: []string{"J"}, Help: "Copy as JSON"},
+		{Action: "action.copy_bd", Keys: []string{"B"}, Help: "Copy as bd command"},
+		{Action: "action.copy_dossier", Keys: []string{"I"}, Help: "Copy impact dossier"},
+		{Action: "action.edit", Keys: []string{"O"}, Help: "Edit issue in $EDITOR"},
+		{Action: "action.dependencies", Keys: []string{"D"}, Help: "Edit dependencies"},
+		{Action: "action.rename", Keys: []string{"ctrl+r"}, Help: "Rename issue ID"},
+		{Action: "action.merge", Keys: []string{"M"}, Help: "Merge assist"},
+		{Action: "action.new", Keys: []string{"+"}, Help: "New issue from template"},
+		{Action: "action.audit_log", Keys: []string{"Y"}, Help: "Audit log of writes"},
+		{Action: "action.diagnostics", Keys: []string{"ctrl+g"}, Help: "Startup diagnostics"},
+	}},
+	{Title: "Board", Icon: "📋", Contexts: []Context{ContextBoard}, Bindings: []Binding{
+		{Action: "board.left", Keys: []string{"h", "left"}, Help: "Previous column"},
+		{Action: "board.right", Keys: []string{"l", "right"}, Help: "Next column"},
+		{Action: "board.down", Keys: []string{"j", "down"}, Help: "Move down"},
+		{Action: "board.up", Keys: []string{"k", "up"}, Help: "Move up"},
+		{Action: "board.column", Keys: []string{"1", "2", "3", "4"}, Help: "Jump to column", fixed: true},
+		{Action: "board.top", Keys: []string{"g g"}, Help: "Top of column", fixed: true},
+		{Action: "board.count", Keys: []string{"3 j"}, Help: "Repeat a motion (count prefix)", fixed: true},
+		{Action: "board.bottom", Keys: []string{"G", "end"}, Help: "Bottom of column"},
+		{Action: "board.search", Keys: []string{"/"}, Help: "Search cards"},
+		{Action: "board.next_match", Keys: []string{"n"}, Help: "Next match"},
+		{Action: "board.prev_match", Keys: []string{"N"}, Help: "Previous match"},
+		{Action: "board.swimlanes", Keys: []string{"s"}, Help: "Cycle swimlanes"},
+		{Action: "board.empty_columns", Keys: []string{"e"}, Help: "Toggle empty columns"},
+		{Action: "board.expand", Keys: []string{"d"}, Help: "Expand card"},
+		{Action: "board.detail", Keys: []string{"tab"}, Help: "Toggle detail panel"},
+		{Action: "board.copy_id", Keys: []string{"y"}, Help: "Copy ID"},
+	}},
+	{Title: "Graph View", Icon: "📊", Contexts: []Context{ContextGraph}, Bindings: []Binding{
+		{Action: "graph.left", Keys: []string{"h", "left"}, Help: "Node to the left"},
+		{Action: "graph.right", Keys: []string{"l", "right"}, Help: "Node to the right"},
+		{Action: "graph.down", Keys: []string{"j", "down"}, Help: "Node below"},
+		{Action: "graph.up", Keys: []string{"k", "up"}, Help: "Node above"},
+		{Action: "graph.scroll_left", Keys: []string{"H"}, Help: "Scroll left"},
+		{Action: "graph.scroll_right", Keys: []string{"L"}, Help: "Scroll right"},
+		{Action: "graph.page_down", Keys: []string{"ctrl+d", "pgdown"}, Help: "Scroll down"},
+		{Action: "graph.page_up", Keys: []string{"ctrl+u", "pgup"}, Help: "Scroll up"},
+		{Action: "graph.count", Keys: []string{"3 l"}, Help: "Repeat a motion (count prefix)", fixed: true},
+		{Action: "graph.zoom_in", Keys: []string{"+", "="}, Help: "Zoom in (ID → title → status and score)"},
+		{Action: "graph.zoom_out", Keys: []string{"-"}, Help: "Zoom out (down to ID-only chips)"},
+		{Action: "graph.clusters", Keys: []string{"c"}, Help: "Group by cluster"},
+		{Action: "graph.open", Keys: []string{"enter"}, Help: "Jump to issue"},
+	}},
+	{Title: "Insights", Icon: "💡", Contexts: []Context{ContextInsights, ContextAttention}, Bindings: []Binding{
+		{Action: "insights.prev_panel", Keys: []string{"h", "left"}, Help: "Previous panel"},
+		{Action: "insights.next_panel", Keys: []string{"l", "right", "tab"}, Help: "Next panel"},
+		{Action: "insights.down", Keys: []string{"j", "down"}, Help: "Next item"},
+		{Action: "insights.up", Keys: []string{"k", "up"}, Help: "Previous item"},
+		{Action: "insights.explain", Keys: []string{"e"}, Help: "Explanations"},
+		{Action: "insights.calculation", Keys: []string{"x"}, Help: "Calc details"},
+		{Action: "insights.heatmap", Keys: []string{"m"}, Help: "Toggle heatmap"},
+		{Action: "insights.expand", Keys: []string{" "}, Help: "Expand priority pick"},
+		{Action: "insights.apply_priority", Keys: []string{"P"}, Help: "Apply suggested priority"},
+		{Action: "insights.open", Keys: []string{"enter"}, Help: "Jump to issue"},
+	}},
+	{Title: "History", Icon: "📜", Contexts: []Context{ContextHistory}, Bindings: []Binding{
+		{Action: "history.down", Keys: []string{"j", "down"}, Help: "Next bead"},
+		{Action: "history.up", Keys: []string{"k", "up"}, Help: "Previous bead"},
+		{Action: "history.commit_down", Keys: []string{"J"}, Help: "Next commit"},
+		{Action: "history.commit_up", Keys: []string{"K"}, Help: "Previous commit"},
+		{Action: "history.focus", Keys: []string{"tab"}, Help: "Toggle focus"},
+		{Action: "history.mode", Keys: []string{"v"}, Help: "Bead / Git mode"},
+		{Action: "history.search", Keys: []string{"/"}, Help: "Search"},
+		{Action: "history.copy_sha", Keys: []string{"y"}, Help: "Copy SHA"},
+		{Action: "history.confidence", Keys: []string{"c"}, Help: "Confidence filter"},
+		{Action: "history.confirm", Keys: []string{"C"}, Help: "Confirm match"},
+		{Action: "history.reject", Keys: []string{"X"}, Help: "Reject match"},
+		{Action: "history.ignore", Keys: []string{"I"}, Help: "Ignore match"},
+		{Action: "history.view_at", Keys: []string{"t"}, Help: "View at commit"},
+	}},
+}
+
+// Keymap is the registry of key bindings with the user's remaps applied
+type Keymap struct {
+	sections []KeymapSection
+	// stands maps each remapped binding's new keys to the default key the
+	// handlers match, and its old keys to "" (unbound), per section
+	stands []map[string]string
+	remaps int
+}
+
+// DefaultKeymap returns the bindings without any remaps
+func DefaultKeymap() *Keymap {
+	k := &Keymap{stands: make([]map[string]string, len(defaultKeymap))}
+	k.sections = make([]KeymapSection, len(defaultKeymap))
+	for i, s := range defaultKeymap {
+		s.Bindings = append([]Binding(nil), s.Bindings...)
+		k.sections[i] = s
+	}
+	return k
+}
+
+// KeymapConfigPath returns the path of the user's keymap.yaml
+func KeymapConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "bv", KeymapConfigFilename)
+}
+
+// keyList is one key or a list of keys in keymap.yaml
+type keyList []string
+
+func (l *keyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = keyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*l = keys
+	return nil
+}
+
+// LoadKeymap reads remaps from path, a YAML map of action names to a key
+// or list of keys. Returns the default keymap if the file doesn't exist.
+func LoadKeymap(path string) (*Keymap, error) {
+	k := DefaultKeymap()
+	if path == "" {
+		return k, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return k, nil
+		}
+		return nil, fmt.Errorf("reading keymap config: %w", err)
+	}
+
+	remaps := map[string]keyList{}
+	if err := yaml.Unmarshal(data, &remaps); err != nil {
+		return nil, fmt.Errorf("parsing keymap config: %w", err)
+	}
+	actions := make([]string, 0, len(remaps))
+	for action := range remaps {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions) // Report the same error first every time
+	for _, action := range actions {
+		if err := k.remap(action, remaps[action]); err != nil {
+			return nil, fmt.Errorf("keymap config: %w", err)
+		}
+	}
+	return k, k.checkConflicts()
+}
+
+// remap replaces the keys of action
+func (k *Keymap) remap(action string, keys []string) error {
+	for si := range k.sections {
+		for bi := range k.sections[si].Bindings {
+			b := &k.sections[si].Bindings[bi]
+			if b.Action != action {
+				continue
+			}
+			if b.fixed {
+				return fmt.Errorf("%s cannot be remapped", action)
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("%s: no keys given", action)
+			}
+			for i, key := range keys {
+				if key == "space" {
+					keys[i] = " "
+				} else if _, ok := parseKey(key); !ok {
+					return fmt.Errorf("%s: unknown key %q", action, key)
+				}
+			}
+
+			if k.stands[si] == nil {
+				k.stands[si] = map[string]string{}
+			}
+			for _, old := range b.Keys {
+				if _, taken := k.stands[si][old]; !taken {
+					k.stands[si][old] = ""
+				}
+			}
+			for _, key := range keys {
+				k.stands[si][key] = b.Keys[0]
+			}
+			b.Keys = keys
+			b.remapped = true
+			k.remaps++
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action %q", action)
+}
+
+// checkConflicts rejects a remapped key that another action uses in the
+// same context. The defaults reuse keys across views on purpose (h opens
+// the history from the list but moves left on the board), so only keys
+// added by remaps are checked.
+func (k *Keymap) checkConflicts() error {
+	for si, s := range k.sections {
+		for bi, b := range s.Bindings {
+			if !b.remapped {
+				continue
+			}
+			for _, key := range b.Keys {
+				if defaultKeymap[si].Bindings[bi].hasKey(key) {
+					continue
+				}
+				for _, o := range k.sections {
+					if !s.overlaps(o) {
+						continue
+					}
+					for _, other := range o.Bindings {
+						if other.Action != b.Action && other.hasKey(key) {
+							return fmt.Errorf("keymap config: %s is bound to both %s and %s", keyLabel(key), b.Action, other.Action)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// HasRemaps reports whether the user changed any binding
+func (k *Keymap) HasRemaps() bool {
+	return k != nil && k.remaps > 0
+}
+
+// Sections returns the sections that apply in ctx, with remapped keys.
+// Keys of the every-view sections that ctx's own sections use for
+// something else are left out, as the view handles them first.
+func (k *Keymap) Sections(ctx Context) []KeymapSection {
+	var out []KeymapSection
+	for _, s := range k.sections {
+		if !s.appliesIn(ctx) {
+			continue
+		}
+		if s.Contexts != nil {
+			out = append(out, s)
+			continue
+		}
+		shown := s
+		shown.Bindings = nil
+		for _, b := range s.Bindings {
+			var keys []string
+			for _, key := range b.Keys {
+				if !k.shadowed(ctx, key) {
+					keys = append(keys, key)
+				}
+			}
+			if len(keys) > 0 {
+				b.Keys = keys
+				shown.Bindings = append(shown.Bindings, b)
+			}
+		}
+		if len(shown.Bindings) > 0 {
+			out = append(out, shown)
+		}
+	}
+	return out
+}
+
+// shadowed reports whether a section specific to ctx binds key
+func (k *Keymap) shadowed(ctx Context, key string) bool {
+	for _, s := range k.sections {
+		if s.Contexts == nil || !s.appliesIn(ctx) {
+			continue
+		}
+		for _, b := range s.Bindings {
+			if b.hasKey(key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllSections returns every section, with remapped keys
+func (k *Keymap) AllSections() []KeymapSection {
+	return k.sections
+}
+
+// overlaps reports whether two sections apply in a common context
+func (s KeymapSection) overlaps(o KeymapSection) bool {
+	if s.Contexts == nil || o.Contexts == nil {
+		return true
+	}
+	for _, c := range o.Contexts {
+		if s.appliesIn(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b Binding) hasKey(key string) bool {
+	for _, k := range b.Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s KeymapSection) appliesIn(ctx Context) bool {
+	if s.Contexts == nil {
+		return true
+	}
+	for _, c := range s.Contexts {
+		if c == ctx {
+			return true
+		}
+	}
+	return false
+}
+
+// Translate maps a key pressed in ctx to the default key the handlers
+// match. It returns false for a default key whose action was remapped to
+// other keys, unless another action in ctx still uses it.
+func (k *Keymap) Translate(ctx Context, msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	if !k.HasRemaps() {
+		return msg, true
+	}
+	pressed := msg.String()
+	unbound := false
+	for si, s := range k.sections {
+		target, ok := k.stands[si][pressed]
+		if !ok || !s.appliesIn(ctx) {
+			continue
+		}
+		if target == "" {
+			unbound = true
+			continue
+		}
+		if s.Contexts == nil && k.shadowed(ctx, target) {
+			continue // The view uses the default key for something else
+		}
+		if translated, ok := parseKey(target); ok {
+			return translated, true
+		}
+	}
+	if !unbound {
+		return msg, true
+	}
+	for _, s := range k.Sections(ctx) {
+		for _, b := range s.Bindings {
+			if b.hasKey(pressed) {
+				return msg, true
+			}
+		}
+	}
+	return msg, false
+}
+
+// keyTypes maps key names (as in tea.Key.String) to their key type
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if name := (tea.Key{Type: t}).String(); name != "" {
+			if _, ok := types[name]; !ok {
+				types[name] = t
+			}
+		}
+	}
+	return types
+}()
+
+// parseKey builds the key message whose String() is name
+func parseKey(name string) (tea.KeyMsg, bool) {
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t}, true
+	}
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		if t, ok := keyTypes[rest]; ok {
+			return tea.KeyMsg{Type: t, Alt: true}, true
+		}
+		alt, name = true, rest
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// keyNameLabels are display names for keys whose tea name is terse
+var keyNameLabels = map[string]string{
+	"up": "↑", "down": "↓", "left": "←", "right": "→",
+	"enter": "Enter", "esc": "Esc", "tab": "Tab", "shift+tab": "Shift+Tab",
+	"backspace": "Backspace", "home": "Home", "end": "End",
+	"pgup": "PgUp", "pgdown": "PgDn", " ": "Space",
+}
+
+// keyLabel formats a key name for the help overlay ("ctrl+d" → "Ctrl+d")
+func keyLabel(key string) string {
+	if label, ok := keyNameLabels[key]; ok {
+		return label
+	}
+	if strings.Contains(key, " ") && len(key) > 1 {
+		return strings.ReplaceAll(key, " ", "") // Sequence, e.g. "g d" → "gd"
+	}
+	for _, mod := range []string{"ctrl+", "alt+", "shift+"} {
+		if rest, ok := strings.CutPrefix(key, mod); ok {
+			return strings.ToUpper(mod[:1]) + mod[1:] + keyLabel(rest)
+		}
+	}
+	if len(key) > 1 && key[0] == 'f' {
+		return "F" + key[1:]
+	}
+	return key
+}
+
+// KeysLabel formats the keys of a binding for display
+func (b Binding) KeysLabel() string {
+	labels := make([]string, len(b.Keys))
+	for i, key := range b.Keys {
+		labels[i] = keyLabel(key)
+	}
+	return strings.Join(labels, "/")
+}
+
+// keymapActive reports whether keys are commands that remaps apply to,
+// rather than text typed into a search box or keys for a modal
+func (m Model) keymapActive() bool {
+	if !m.keymap.HasRemaps() || m.waitingForGoto || m.list.FilterState() == list.Filtering {
+		return false
+	}
+	if m.showDepEditor || m.showTemplatePicker || m.showMergeAssist || m.showCassModal ||
+		m.showCassTranscript || m.showNotifications || m.showDigestPanel || m.showPriorityReview || m.showForecast || m.showEffortRollup || m.showReleaseNotes ||
+		m.showUpdateModal || m.showTutorial || m.focused == focusTimeTravelInput {
+		return false
+	}
+	if m.board.IsSearchMode() || m.historyView.IsSearchActive() {
+		return false
+	}
+	return !m.CurrentContext().IsOverlay()
+}
+
+// helpContext is the context the help overlay was opened from
+func (m Model) helpContext() Context {
+	under := m
+	under.showHelp = false
+	if m.focused == focusHelp {
+		under.focused = m.focusBeforeHelp
+	}
+	return under.CurrentContext()
+}
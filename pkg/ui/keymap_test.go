@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeKeymap(t *testing.T, config string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), KeymapConfigFilename)
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestDefaultKeymapRegistry(t *testing.T) {
+	seen := map[string]bool{}
+	for _, s := range DefaultKeymap().AllSections() {
+		for _, b := range s.Bindings {
+			if seen[b.Action] {
+				t.Errorf("duplicate action %s", b.Action)
+			}
+			seen[b.Action] = true
+			for _, key := range b.Keys {
+				if strings.Contains(key, " ") {
+					continue // Sequence
+				}
+				parsed, ok := parseKey(key)
+				if !ok || parsed.String() != key {
+					t.Errorf("%s: key %q does not round-trip (got %q)", b.Action, key, parsed.String())
+				}
+			}
+		}
+	}
+}
+
+func TestDefaultKeymapDetailKeysFree(t *testing.T) {
+	sections := DefaultKeymap().AllSections()
+	for _, d := range sections {
+		if d.Title != "Issue Detail" {
+			continue
+		}
+		for _, s := range sections {
+			if s.Title == d.Title || !d.overlaps(s) {
+				continue
+			}
+			for _, b := range s.Bindings {
+				for _, key := range b.Keys {
+					for _, db := range d.Bindings {
+						for _, dk := range db.Keys {
+							if dk == key {
+								t.Errorf("%s and %s both use %q", db.Action, b.Action, key)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLoadKeymap(t *testing.T) {
+	k, err := LoadKeymap(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil || k.HasRemaps() {
+		t.Fatalf("expected the defaults without a file, got %v", err)
+	}
+
+	k, err = LoadKeymap(writeKeymap(t, "view.board: ctrl+b\nnav.down: [j, ctrl+n]\n"))
+	if err != nil {
+		t.Fatalf("LoadKeymap() error = %v", err)
+	}
+	if !k.HasRemaps() {
+		t.Fatal("expected remaps")
+	}
+
+	for config, want := range map[string]string{
+		"view.board: B\n":           "action.copy_bd", // Taken in the list
+		"view.teleport: x\n":        "unknown action",
+		"global.force_quit: q\n":    "cannot be remapped",
+		"view.board: ctrl+banana\n": "unknown key",
+		"view.board: []\n":          "no keys",
+	} {
+		if _, err := LoadKeymap(writeKeymap(t, config)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected error containing %q, got %v", config, want, err)
+		}
+	}
+}
+
+func TestKeymapTranslate(t *testing.T) {
+	k, err := LoadKeymap(writeKeymap(t, "view.board: ctrl+b\nview.history: ctrl+y\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := k.Translate(ContextList, tea.KeyMsg{Type: tea.KeyCtrlB}); !ok || got.String() != "b" {
+		t.Errorf("ctrl+b should stand for b, got %q, %v", got.String(), ok)
+	}
+	if _, ok := k.Translate(ContextList, runeKey("b")); ok {
+		t.Error("b should be unbound once the board moved to ctrl+b")
+	}
+	if got, ok := k.Translate(ContextList, runeKey("x")); !ok || got.String() != "x" {
+		t.Errorf("other keys pass through, got %q, %v", got.String(), ok)
+	}
+
+	// On the board h moves left, so it stays bound and ctrl+y is not h
+	if got, ok := k.Translate(ContextBoard, runeKey("h")); !ok || got.String() != "h" {
+		t.Errorf("h should still move left on the board, got %q, %v", got.String(), ok)
+	}
+	if got, _ := k.Translate(ContextBoard, tea.KeyMsg{Type: tea.KeyCtrlY}); got.String() != "ctrl+y" {
+		t.Errorf("ctrl+y should not turn into h on the board, got %q", got.String())
+	}
+}
+
+func TestRemappedKeysInModel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 130, Height: 50})
+	m = updated.(Model)
+	k, err := LoadKeymap(writeKeymap(t, "view.board: ctrl+b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.keymap = k
+
+	updated, _ = m.Update(runeKey("b"))
+	if updated.(Model).isBoardView {
+		t.Fatal("b should no longer open the board")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = updated.(Model)
+	if !m.isBoardView {
+		t.Fatal("ctrl+b should open the board")
+	}
+
+	// The help overlay lists the remapped key and the board's own bindings
+	updated, _ = m.Update(runeKey("?"))
+	view := updated.(Model).View()
+	for _, want := range []string{"Ctrl+b", "Kanban board", "Previous column", "Swimlanes"} {
+		if !strings.Contains(view, want) && !strings.Contains(view, strings.ToLower(want)) {
+			t.Errorf("help overlay missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "History view") {
+		t.Error("h moves left on the board; the history binding should be left out")
+	}
+	if strings.Contains(view, "Fuzzy search") {
+		t.Error("list filters should not be listed for the board")
+	}
+}
+
+func TestKeymapIgnoredInTextInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 130, Height: 50})
+	m = updated.(Model)
+	k, err := LoadKeymap(writeKeymap(t, "view.board: ctrl+b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.keymap = k
+
+	updated, _ = m.Update(runeKey("/"))
+	m = updated.(Model)
+	updated, _ = m.Update(runeKey("b"))
+	m = updated.(Model)
+	if got := m.list.FilterInput.Value(); got != "b" {
+		t.Errorf("typing in the search box should not be remapped, got %q", got)
+	}
+}
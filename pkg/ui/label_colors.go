@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// LabelColorsConfigFilename is the project config that overrides the
+// colors picked for labels.
+const LabelColorsConfigFilename = "label_colors.yaml"
+
+// labelPalette holds the colors labels are hashed onto, with light mode
+// variants darkened like the theme's own colors
+var labelPalette = []lipgloss.AdaptiveColor{
+	{Light: "#B0306A", Dark: "#FF79C6"}, // Pink
+	{Light: "#006080", Dark: "#8BE9FD"}, // Cyan
+	{Light: "#007700", Dark: "#50FA7B"}, // Green
+	{Light: "#B06800", Dark: "#FFB86C"}, // Orange
+	{Light: "#6B47D9", Dark: "#BD93F9"}, // Purple
+	{Light: "#808000", Dark: "#F1FA8C"}, // Yellow
+	{Light: "#1F6F8B", Dark: "#6FC3DF"}, // Steel blue
+	{Light: "#4F7A28", Dark: "#C3E88D"}, // Lime
+	{Light: "#A0452A", Dark: "#F78C6C"}, // Coral
+	{Light: "#2F5BB7", Dark: "#82AAFF"}, // Blue
+	{Light: "#7A3E9D", Dark: "#E0AFFF"}, // Lavender
+	{Light: "#CC0000", Dark: "#FF5555"}, // Red
+}
+
+// hexColorPattern matches #rgb and #rrggbb
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// LabelColorsConfig pins the colors of chosen labels.
+type LabelColorsConfig struct {
+	// Colors maps a label to a hex color ("#ff5555") or an ANSI color
+	// number ("196"). Labels not listed get a color from their name.
+	Colors map[string]string `yaml:"colors,omitempty"`
+}
+
+// LoadLabelColorsConfig loads .bv/label_colors.yaml from projectDir.
+// Returns an empty config, hashing every label, if the file doesn't exist.
+func LoadLabelColorsConfig(projectDir string) (*LabelColorsConfig, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, ".bv", LabelColorsConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &LabelColorsConfig{}, nil
+		}
+		return nil, fmt.Errorf("reading label colors config: %w", err)
+	}
+
+	config := &LabelColorsConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing label colors config: %w", err)
+	}
+	labels := make([]string, 0, len(config.Colors))
+	for label := range config.Colors {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels) // Report the same error first every time
+	for _, label := range labels {
+		if !isLabelColor(config.Colors[label]) {
+			return nil, fmt.Errorf("label %q: invalid color %q (use #rrggbb or an ANSI number 0-255)", label, config.Colors[label])
+		}
+	}
+	return config, nil
+}
+
+// loadLabelColors finds the project config next to the beads file and
+// returns its overrides keyed by lower-case label, or nil when the file is
+// missing or invalid.
+func loadLabelColors(beadsPath string) map[string]lipgloss.Color {
+	projectDir, err := historyRepoPath(beadsPath)
+	if err != nil {
+		return nil
+	}
+	config, err := LoadLabelColorsConfig(projectDir)
+	if err != nil || len(config.Colors) == 0 {
+		return nil
+	}
+	colors := make(map[string]lipgloss.Color, len(config.Colors))
+	for label, color := range config.Colors {
+		colors[strings.ToLower(label)] = lipgloss.Color(color)
+	}
+	return colors
+}
+
+func isLabelColor(s string) bool {
+	if hexColorPattern.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// LabelColor returns the color of label: its override from
+// .bv/label_colors.yaml, or a palette color picked by a hash of the name,
+// so a label looks the same in every view and every session.
+func (t Theme) LabelColor(label string) lipgloss.TerminalColor {
+	key := strings.ToLower(label)
+	if color, ok := t.LabelColors[key]; ok {
+		return color
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return labelPalette[h.Sum32()%uint32(len(labelPalette))]
+}
+
+// LabelStyle is the style for label, or a shortened form of it
+func (t Theme) LabelStyle(label string) lipgloss.Style {
+	return t.Renderer.NewStyle().Foreground(t.LabelColor(label))
+}
+
+// RenderLabels renders labels joined by sep, each in its own color
+func (t Theme) RenderLabels(labels []string, sep string) string {
+	sepStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = t.LabelStyle(label).Render(label)
+	}
+	return strings.Join(parts, sepStyle.Render(sep))
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoadLabelColorsConfig(t *testing.T) {
+	dir := t.TempDir()
+	config, err := LoadLabelColorsConfig(dir)
+	if err != nil || len(config.Colors) != 0 {
+		t.Fatalf("missing file = %+v, %v", config, err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, ".bv"), 0o755)
+	path := filepath.Join(dir, ".bv", LabelColorsConfigFilename)
+	os.WriteFile(path, []byte("colors:\n  bug: \"#ff5555\"\n  docs: \"33\"\n"), 0o644)
+	config, err = LoadLabelColorsConfig(dir)
+	if err != nil || config.Colors["bug"] != "#ff5555" || config.Colors["docs"] != "33" {
+		t.Fatalf("valid file = %+v, %v", config, err)
+	}
+
+	os.WriteFile(path, []byte("colors:\n  bug: red\n"), 0o644)
+	if _, err := LoadLabelColorsConfig(dir); err == nil || !strings.Contains(err.Error(), `"bug"`) {
+		t.Errorf("named color error = %v", err)
+	}
+	os.WriteFile(path, []byte("colors:\n  bug: \"256\"\n"), 0o644)
+	if _, err := LoadLabelColorsConfig(dir); err == nil {
+		t.Error("ANSI 256 accepted")
+	}
+}
+
+func TestLabelColor(t *testing.T) {
+	theme := Theme{}
+	if !reflect.DeepEqual(theme.LabelColor("backend"), theme.LabelColor("Backend")) {
+		t.Error("label color depends on case")
+	}
+	seen := make(map[lipgloss.TerminalColor]bool)
+	for _, label := range []string{"bug", "feature", "ui", "backend", "docs", "perf", "security", "api"} {
+		seen[theme.LabelColor(label)] = true
+	}
+	if len(seen) < 3 {
+		t.Errorf("8 labels share %d colors", len(seen))
+	}
+
+	theme.LabelColors = map[string]lipgloss.Color{"bug": "#123456"}
+	if got := theme.LabelColor("BUG"); got != lipgloss.Color("#123456") {
+		t.Errorf("override = %v", got)
+	}
+}
+
+func TestLabelCompletions(t *testing.T) {
+	counts := map[string]int{"backend": 2, "bug": 5, "frontend": 3, "backlog": 1, "ui": 4}
+	if got, want := labelCompletions(counts, "ba"), []string{"backend", "backlog"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ba = %v, want %v", got, want)
+	}
+	if got, want := labelCompletions(counts, "END"), []string{"frontend", "backend"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("END = %v, want %v", got, want)
+	}
+	// Prefix matches come before labels that only contain the word
+	counts["kebab"] = 9
+	if got, want := labelCompletions(counts, "b"), []string{"bug", "backend", "backlog", "kebab"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("b = %v, want %v", got, want)
+	}
+	if got := labelCompletions(counts, "bug"); got != nil {
+		t.Errorf("whole label = %v", got)
+	}
+	if got := labelCompletions(counts, ""); got != nil {
+		t.Errorf("empty word = %v", got)
+	}
+}
+
+func TestSearchLabelAutocomplete(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "lc-1", Title: "Token refresh", Labels: []string{"authentication"}, Status: model.StatusOpen},
+		{ID: "lc-2", Title: "Audit trail", Labels: []string{"audit", "authentication"}, Status: model.StatusOpen},
+		{ID: "lc-3", Title: "Dark mode", Labels: []string{"ui"}, Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	m = pressKeys(m, "/", "token au")
+	if got, want := m.labelSuggestions(), []string{"authentication", "audit"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("suggestions = %v, want %v", got, want)
+	}
+	if view := m.View(); !strings.Contains(view, "tab: complete") {
+		t.Error("dropdown not rendered")
+	}
+
+	m = pressKeys(m, "ctrl+n", "tab")
+	if got := m.list.FilterInput.Value(); got != "token audit " {
+		t.Errorf("completed search = %q", got)
+	}
+	if m.labelSuggestions() != nil {
+		t.Error("dropdown still open after completing")
+	}
+	// With nothing to complete, tab accepts the search as before
+	m = pressKeys(m, "tab")
+	if len(m.searchHistory) != 1 {
+		t.Errorf("tab without suggestions did not accept the search: %v", m.searchHistory)
+	}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxLabelCompletions is how many labels the search bar dropdown offers
+const maxLabelCompletions = 6
+
+// labelCompletions returns the labels that complete word: those starting
+// with it, then those containing it, each by issue count. A word that is
+// already a whole label gets none.
+func labelCompletions(counts map[string]int, word string) []string {
+	word = strings.ToLower(word)
+	if word == "" {
+		return nil
+	}
+	var prefixed, containing []string
+	for label := range counts {
+		lower := strings.ToLower(label)
+		switch {
+		case lower == word:
+			return nil
+		case strings.HasPrefix(lower, word):
+			prefixed = append(prefixed, label)
+		case strings.Contains(lower, word):
+			containing = append(containing, label)
+		}
+	}
+	byCount := func(labels []string) {
+		sort.Slice(labels, func(i, j int) bool {
+			if counts[labels[i]] != counts[labels[j]] {
+				return counts[labels[i]] > counts[labels[j]]
+			}
+			return labels[i] < labels[j]
+		})
+	}
+	byCount(prefixed)
+	byCount(containing)
+	completions := append(prefixed, containing...)
+	if len(completions) > maxLabelCompletions {
+		completions = completions[:maxLabelCompletions]
+	}
+	return completions
+}
+
+// searchWord returns the word at the end of a search and the byte offset
+// it starts at
+func searchWord(value string) (string, int) {
+	start := strings.LastIndexAny(value, " ~") + 1
+	return value[start:], start
+}
+
+// labelCounts counts the issues carrying each label
+func (m Model) labelCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range m.issues {
+		for _, label := range issue.Labels {
+			counts[label]++
+		}
+	}
+	return counts
+}
+
+// labelSuggestions are the labels offered for the word being typed in the
+// list search bar
+func (m Model) labelSuggestions() []string {
+	if m.focused != focusList || m.list.FilterState() != list.Filtering {
+		return nil
+	}
+	word, _ := searchWord(m.list.FilterInput.Value())
+	return labelCompletions(m.labelCounts(), word)
+}
+
+// completeLabel replaces the word being typed with label
+func (m *Model) completeLabel(label string) {
+	value := m.list.FilterInput.Value()
+	_, start := searchWord(value)
+	m.list.SetFilterText(value[:start] + label + " ")
+	m.list.SetFilterState(list.Filtering)
+	m.labelSuggestIdx = 0
+}
+
+// overlayLabelSuggestions draws the label dropdown under the word being
+// typed in the search bar. It finds the bar in the rendered body, so it
+// draws nothing if the bar has scrolled the word out of view.
+func (m Model) overlayLabelSuggestions(body string, suggestions []string) string {
+	value := m.list.FilterInput.Value()
+	_, start := searchWord(value)
+	bar := m.list.FilterInput.Prompt + value
+	lines := strings.Split(body, "\n")
+	row, col := -1, 0
+	for i, line := range lines {
+		if at := strings.Index(ansi.Strip(line), bar); at >= 0 {
+			plain := ansi.Strip(line)
+			row = i
+			col = ansi.StringWidth(plain[:at]) + ansi.StringWidth(m.list.FilterInput.Prompt) + ansi.StringWidth(value[:start])
+			break
+		}
+	}
+	if row < 0 {
+		return body
+	}
+
+	t := m.theme
+	counts := m.labelCounts()
+	countStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	items := make([]string, len(suggestions))
+	for i, label := range suggestions {
+		marker := "  "
+		style := t.LabelStyle(label)
+		if i == min(m.labelSuggestIdx, len(suggestions)-1) {
+			marker = "▸ "
+			style = style.Bold(true)
+		}
+		items[i] = marker + style.Render(label) + countStyle.Render(fmt.Sprintf(" (%d)", counts[label]))
+	}
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Render(strings.Join(items, "\n") + "\n" + countStyle.Italic(true).Render("tab: complete"))
+
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	col = max(0, min(col-1, m.width-boxWidth)) // the border lines up with the word
+	for i, bl := range boxLines {
+		r := row + 1 + i
+		if r >= len(lines) {
+			break
+		}
+		line := lines[r]
+		left := ansi.Truncate(line, col, "")
+		if w := ansi.StringWidth(left); w < col {
+			left += strings.Repeat(" ", col-w)
+		}
+		right := ansi.TruncateLeft(line, col+boxWidth, "")
+		lines[r] = left + "\x1b[0m" + bl + right
+	}
+	return strings.Join(lines, "\n")
+}
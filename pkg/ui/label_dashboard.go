@@ -215,7 +215,7 @@ func (m LabelDashboardModel) renderLabelCell(lh analysis.LabelHealth) string {
 	} else if lh.Blocked > 0 {
 		indicator = " ⛔"
 	}
-	return lh.Label + indicator
+	return lipgloss.NewStyle().Foreground(m.theme.LabelColor(lh.Label)).Render(lh.Label) + indicator
 }
 
 func (m LabelDashboardModel) renderHealthCell(lh analysis.LabelHealth) string {
@@ -280,7 +280,7 @@ func (m *LabelPickerModel) View() string {
 				itemStyle = itemStyle.Foreground(t.Primary).Bold(true)
 				countStyle = countStyle.Foreground(t.Primary)
 			} else {
-				itemStyle = itemStyle.Foreground(t.Base.GetForeground())
+				itemStyle = itemStyle.Foreground(t.LabelColor(label))
 			}
 
 			prefix := "  "
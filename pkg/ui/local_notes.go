@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// NotesDirName is the directory under .bv holding private per-issue notes,
+// one Markdown file per issue. A .gitignore keeps them out of commits.
+const NotesDirName = "notes"
+
+// localNoteBadge marks the private note section of the detail view
+const localNoteBadge = "🔒"
+
+// NoteEditedMsg is sent when the editor opened on a private note exits
+type NoteEditedMsg struct {
+	IssueID string
+	Err     error
+}
+
+// NotesDir returns .bv/notes under projectDir
+func NotesDir(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", NotesDirName)
+}
+
+// notePath returns the note file of issueID. IDs are query-escaped so any
+// ID maps to one portable file name and back.
+func notePath(projectDir, issueID string) string {
+	return filepath.Join(NotesDir(projectDir), url.QueryEscape(issueID)+".md")
+}
+
+// LoadNotes reads every private note of projectDir, keyed by issue ID.
+// A missing directory yields no notes; empty notes are skipped.
+func LoadNotes(projectDir string) (map[string]string, error) {
+	notes := make(map[string]string)
+	entries, err := os.ReadDir(NotesDir(projectDir))
+	if os.IsNotExist(err) {
+		return notes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading notes: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		id, err := url.QueryUnescape(strings.TrimSuffix(name, ".md"))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(NotesDir(projectDir), name))
+		if err != nil {
+			return nil, fmt.Errorf("reading note %s: %w", name, err)
+		}
+		if note := strings.TrimSpace(string(data)); note != "" {
+			notes[id] = note
+		}
+	}
+	return notes, nil
+}
+
+// ensureNotesDir creates .bv/notes and the .gitignore that keeps it private
+func ensureNotesDir(projectDir string) error {
+	dir := NotesDir(projectDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating notes directory: %w", err)
+	}
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		content := "# Private bv notes: never commit\n*\n"
+		if err := os.WriteFile(ignore, []byte(content), 0644); err != nil {
+			return fmt.Errorf("writing notes .gitignore: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadLocalNotes loads the notes of the project owning beadsPath, empty
+// when there are none or they cannot be read
+func loadLocalNotes(beadsPath string) map[string]string {
+	if beadsPath == "" {
+		return nil
+	}
+	notes, err := LoadNotes(filepath.Dir(filepath.Dir(beadsPath)))
+	if err != nil {
+		return nil
+	}
+	return notes
+}
+
+// editNoteCmd opens the selected issue's private note in $EDITOR. The note
+// file is created on demand and removed again if left empty.
+func (m *Model) editNoteCmd() tea.Cmd {
+	sel, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return nil
+	}
+	if m.workDir == "" {
+		m.statusMsg = "❌ No project directory for private notes"
+		m.statusIsError = true
+		return nil
+	}
+	id := sel.Issue.ID
+	if err := ensureNotesDir(m.workDir); err != nil {
+		m.statusMsg = "❌ " + err.Error()
+		m.statusIsError = true
+		return nil
+	}
+
+	path := notePath(m.workDir, id)
+	argv := editorCommand()
+	cmd := exec.Command(argv[0], append(argv[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return NoteEditedMsg{IssueID: id, Err: err}
+	})
+}
+
+// handleNoteEdited reloads the edited note into the list and detail view
+func (m *Model) handleNoteEdited(msg NoteEditedMsg) {
+	if msg.Err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Editor failed: %v", msg.Err)
+		m.statusIsError = true
+		return
+	}
+	path := notePath(m.workDir, msg.IssueID)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		m.statusMsg = fmt.Sprintf("❌ Reading note: %v", err)
+		m.statusIsError = true
+		return
+	}
+
+	if m.notes == nil {
+		m.notes = make(map[string]string)
+	}
+	if note := strings.TrimSpace(string(data)); note != "" {
+		m.notes[msg.IssueID] = note
+		m.statusMsg = fmt.Sprintf("%s Saved private note for %s", localNoteBadge, msg.IssueID)
+	} else {
+		delete(m.notes, msg.IssueID)
+		_ = os.Remove(path)
+		m.statusMsg = fmt.Sprintf("No private note for %s", msg.IssueID)
+	}
+	m.statusIsError = false
+
+	m.refreshFilteredViews()
+	m.selectListItem(msg.IssueID)
+	m.updateViewportContent()
+}
+
+// renderLocalNoteMD shows the private note as its own detail section
+func (m Model) renderLocalNoteMD(issueID string) string {
+	note, ok := m.notes[issueID]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("### %s My Notes *(private, e to edit)*\n%s\n\n", localNoteBadge, note)
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadNotes(t *testing.T) {
+	dir := t.TempDir()
+	if notes, err := LoadNotes(dir); err != nil || len(notes) != 0 {
+		t.Fatalf("no notes directory: got %v, %v", notes, err)
+	}
+	if err := ensureNotesDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(NotesDir(dir), ".gitignore")); err != nil || !strings.Contains(string(data), "*") {
+		t.Errorf("notes .gitignore = %q, %v", data, err)
+	}
+
+	for id, note := range map[string]string{"bv-1": "check the cache\n", "api:2/x": "odd id", "bv-3": "  \n"} {
+		if err := os.WriteFile(notePath(dir, id), []byte(note), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notes, err := LoadNotes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 2 || notes["bv-1"] != "check the cache" || notes["api:2/x"] != "odd id" {
+		t.Errorf("notes = %q", notes)
+	}
+}
+
+func TestLocalNoteInDetailAndSearch(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusOpen, Description: "Shared text"},
+		{ID: "B", Title: "Second", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	m.workDir = t.TempDir()
+
+	if m.editNoteCmd() == nil {
+		t.Fatal("e should open the editor on the note")
+	}
+	if err := os.WriteFile(notePath(m.workDir, "A"), []byte("ask Dana about the flag\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.handleNoteEdited(NoteEditedMsg{IssueID: "A"})
+	if m.notes["A"] != "ask Dana about the flag" {
+		t.Fatalf("notes = %q; status %q", m.notes, m.statusMsg)
+	}
+
+	if md := m.renderLocalNoteMD("A"); !strings.Contains(md, "My Notes") || !strings.Contains(md, "ask Dana") {
+		t.Errorf("detail section = %q", md)
+	}
+	item := m.list.Items()[0].(IssueItem)
+	if item.Issue.ID != "A" || !strings.Contains(item.FilterValue(), "ask Dana") {
+		t.Errorf("note should be searchable, filter value %q", item.FilterValue())
+	}
+
+	// Emptying the note removes it
+	if err := os.WriteFile(notePath(m.workDir, "A"), []byte("\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.handleNoteEdited(NoteEditedMsg{IssueID: "A"})
+	if _, ok := m.notes["A"]; ok {
+		t.Error("an empty note should be dropped")
+	}
+	if _, err := os.Stat(notePath(m.workDir, "A")); !os.IsNotExist(err) {
+		t.Errorf("empty note file should be removed: %v", err)
+	}
+}
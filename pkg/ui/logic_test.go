@@ -1,11 +1,18 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // White-box testing of UI model logic
@@ -176,3 +183,372 @@ func TestFormatTimeRel(t *testing.T) {
 		}
 	}
 }
+
+func TestNewModel_LargeIssueSetLoadsLazily(t *testing.T) {
+	issues := make([]model.Issue, largeIssueSetThreshold+1)
+	open := 0
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("bd-%d", i), Title: "t", Status: model.StatusClosed}
+		if i%10 == 0 {
+			issues[i].Status = model.StatusOpen
+			open++
+		}
+	}
+
+	m := NewModel(issues, nil, "")
+	if !m.lazyLoad || !m.boardPending {
+		t.Fatalf("expected lazy loading above %d issues", largeIssueSetThreshold)
+	}
+	if m.analysis.NodeCount != open {
+		t.Errorf("analysis should cover %d unresolved issues, got %d", open, m.analysis.NodeCount)
+	}
+	if len(m.list.Items()) != len(issues) {
+		t.Errorf("list should still hold every issue, got %d", len(m.list.Items()))
+	}
+	if m.analysis.IsPhase2Ready() {
+		t.Error("Phase 2 should wait for Init")
+	}
+	m.width = 160
+	if footer := m.renderFooter(); !strings.Contains(footer, "unresolved only") {
+		t.Errorf("footer should flag the reduced analysis scope, got %q", footer)
+	}
+
+	m.startPhase2()
+	m.analysis.WaitForPhase2()
+	updated, _ := m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	m = updated.(Model)
+	if len(m.triageScores) == 0 {
+		t.Error("expected triage scores once Phase 2 completes")
+	}
+	if m.boardPending || m.board.TotalCount() != len(issues) {
+		t.Errorf("expected board filled after Phase 2, got %d cards", m.board.TotalCount())
+	}
+}
+
+func TestNewModel_SmallIssueSetLoadsEagerly(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "open", Status: model.StatusOpen},
+		{ID: "closed", Status: model.StatusClosed},
+	}
+	m := NewModel(issues, nil, "")
+	if m.lazyLoad || m.boardPending {
+		t.Error("small sets should not load lazily")
+	}
+	if m.analysis.NodeCount != 2 {
+		t.Errorf("analysis should cover closed issues too, got %d nodes", m.analysis.NodeCount)
+	}
+}
+
+func TestHydrateIssue_LazyText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	data := `{"id":"A-1","title":"First","description":"zanzibar","status":"open","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, index, err := loader.LoadIssuesLazy(path, loader.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	if got, err := m.hydrateIssue(issues[0]); err != nil || got.Description != "" {
+		t.Errorf("without a text index the issue should be returned as is, got %q (err %v)", got.Description, err)
+	}
+	m.SetTextIndex(index)
+	if got, err := m.hydrateIssue(issues[0]); err != nil || got.Description != "zanzibar" {
+		t.Errorf("expected the description read from disk, got %q (err %v)", got.Description, err)
+	}
+	m.width, m.height = 80, 120 // tall enough to show the whole detail
+	m.resizePanes()
+	m.showDetails = true
+	m.updateViewportContent()
+	if !strings.Contains(m.viewport.View(), "zanzibar") {
+		t.Error("detail pane should show the hydrated description")
+	}
+
+	// Once the file changes under the index, the text cannot be read and
+	// nothing may be written back or copied without it
+	if err := os.WriteFile(path, []byte(`{"id":"B-9"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.hydrateIssue(issues[0]); err == nil {
+		t.Fatal("expected an error for a stale text index")
+	}
+	m.statusMsg, m.statusIsError = "", false
+	if cmd := m.editIssueCmd(); cmd != nil || !strings.Contains(m.statusMsg, "not editing") {
+		t.Errorf("editing should be refused, status %q", m.statusMsg)
+	}
+	m.statusMsg, m.statusIsError = "", false
+	m.copySelectedIssue(copyMarkdown)
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "not copied") {
+		t.Errorf("copying should be refused, status %q", m.statusMsg)
+	}
+}
+
+func TestToggleShowDeleted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	data := `{"id":"A-1","title":"Kept","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"A-2","title":"Gone","status":"open","issue_type":"task","updated_at":"2025-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"id":"A-2","ts":"2025-02-01T00:00:00Z","by":"alice"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, loader.DeletionsFileName), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	defer m.Stop()
+	if len(m.issues) != 1 || m.deletedCount != 1 {
+		t.Fatalf("expected 1 issue and 1 deletion, got %d issues and %d deletions", len(m.issues), m.deletedCount)
+	}
+
+	m.toggleShowDeleted()
+	if len(m.issues) != 2 {
+		t.Fatalf("expected deleted issue to be shown, got %d issues", len(m.issues))
+	}
+	if gone := m.issueMap["A-2"]; gone == nil || !gone.Status.IsTombstone() {
+		t.Errorf("deleted issue should be shown as a tombstone, got %+v", gone)
+	}
+
+	m.toggleShowDeleted()
+	if len(m.issues) != 1 {
+		t.Errorf("expected deleted issue to be hidden again, got %d issues", len(m.issues))
+	}
+}
+
+func TestMergeAssist_PickAndWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beads.jsonl")
+	current := `{"id":"A-1","title":"Ours","status":"open","issue_type":"task"}` + "\n"
+	orig := `{"id":"A-1","title":"Theirs","status":"closed","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(current), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "beads.orig.jsonl"), []byte(orig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	defer m.Stop()
+	if !strings.Contains(m.statusMsg, "press M") {
+		t.Errorf("expected a merge artifact hint, got %q", m.statusMsg)
+	}
+
+	m.openMergeAssist()
+	if !m.showMergeAssist || len(m.mergeAssist.set.Conflicts) != 1 {
+		t.Fatalf("expected merge assist with one conflict, got %v", m.showMergeAssist)
+	}
+	m, _ = m.handleMergeAssistKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if !strings.Contains(m.mergeAssist.View(), "Theirs") {
+		t.Error("view should show the chosen version")
+	}
+	m, cmd := m.handleMergeAssistKeys(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.showMergeAssist || cmd == nil {
+		t.Fatal("ctrl+s should close merge assist and write")
+	}
+	if msg, ok := cmd().(MergeResolvedMsg); !ok || msg.Err != nil || msg.Resolved != 1 {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Theirs") {
+		t.Errorf("merged file should hold the chosen version: %s", data)
+	}
+}
+
+func TestCustomFieldsConfig_Order(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "order: [severity, customer]\nhidden: [internal]\n"
+	if err := os.WriteFile(filepath.Join(dir, ".bv", CustomFieldsConfigFilename), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadCustomFieldsConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadCustomFieldsConfig() error = %v", err)
+	}
+
+	issue := model.Issue{ID: "C-1", CustomFields: map[string]any{
+		"zone":     "eu",
+		"customer": "Acme",
+		"internal": "secret",
+		"area":     "billing",
+		"severity": "high",
+	}}
+	if got := strings.Join(cfg.FieldNames(issue), ","); got != "severity,customer,area,zone" {
+		t.Errorf("FieldNames() = %s, want severity,customer,area,zone", got)
+	}
+
+	md := cfg.renderCustomFieldsMD(issue)
+	if !strings.Contains(md, "| customer | Acme |") || strings.Contains(md, "secret") {
+		t.Errorf("unexpected custom fields section:\n%s", md)
+	}
+
+	empty, err := LoadCustomFieldsConfig(t.TempDir())
+	if err != nil || len(empty.Order) != 0 {
+		t.Errorf("expected an empty config without a file, got %+v, %v", empty, err)
+	}
+}
+
+func TestOpenAttachment_Headless(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	if browserAvailable() {
+		t.Skip("a browser is always available on this platform")
+	}
+
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	issues := []model.Issue{{
+		ID: "A-1", Title: "Links", Status: model.StatusOpen, IssueType: model.TypeTask,
+		Attachments: []*model.Attachment{
+			{URL: "https://example.com/spec", Title: "Spec"},
+			{URL: "docs/trace.log"},
+		},
+	}}
+	m := NewModel(issues, nil, filepath.Join(beadsDir, "beads.jsonl"))
+	defer m.Stop()
+	m.updateViewportContent()
+
+	m.openAttachment()
+	if m.statusMsg != "🔗 https://example.com/spec" {
+		t.Errorf("expected the link to be printed, got %q", m.statusMsg)
+	}
+
+	m.cycleAttachment(1)
+	m.openAttachment()
+	if want := "🔗 " + filepath.Join(dir, "docs", "trace.log"); m.statusMsg != want {
+		t.Errorf("expected %q, got %q", want, m.statusMsg)
+	}
+
+	m.cycleAttachment(1)
+	if m.attachmentIdx != 0 {
+		t.Errorf("cursor should wrap around, got %d", m.attachmentIdx)
+	}
+}
+
+func TestRenderSimilarIssuesMD(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A-1", Title: "Crash opening empty file", Status: model.StatusOpen},
+		{ID: "A-2", Title: "Crash opening empty file", Status: model.StatusOpen},
+		{ID: "A-3", Title: "Dark mode", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	defer m.Stop()
+
+	if md := m.renderSimilarIssuesMD("A-1"); md != "" {
+		t.Fatalf("expected no section before the index is ready, got %q", md)
+	}
+
+	idx := search.NewVectorIndex(3)
+	_ = idx.Upsert("A-1", search.ContentHash{}, []float32{1, 0, 0})
+	_ = idx.Upsert("A-2", search.ContentHash{}, []float32{1, 0, 0})
+	_ = idx.Upsert("A-3", search.ContentHash{}, []float32{0, 1, 0})
+	updated, _ := m.Update(SimilarityIndexReadyMsg{Index: idx})
+	m = updated.(Model)
+
+	md := m.renderSimilarIssuesMD("A-1")
+	if !strings.Contains(md, "**A-2**") || !strings.Contains(md, "possible duplicate") {
+		t.Errorf("expected A-2 flagged as a possible duplicate, got %q", md)
+	}
+	if strings.Contains(md, "A-3") {
+		t.Errorf("dissimilar issue should be left out, got %q", md)
+	}
+}
+
+func TestSortByComputedMetrics(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: "x", DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "hub", Title: "Hub", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeEpic, Description: strings.Repeat("x", 2000), UpdatedAt: now},
+		{ID: "a", Title: "A", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeChore, Dependencies: blocks("hub"), UpdatedAt: now},
+		{ID: "b", Title: "B", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Dependencies: blocks("hub"), UpdatedAt: now},
+		{ID: "done", Title: "Done", Status: model.StatusClosed, Priority: 0, Dependencies: blocks("a"), UpdatedAt: now},
+	}
+	m := NewModel(issues, nil, "")
+	m.analysis.WaitForPhase2()
+	updated, _ := m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	m = updated.(Model)
+
+	first := func(mode SortMode) string {
+		for m.sortMode != mode {
+			m.cycleSortMode()
+		}
+		return m.list.Items()[0].(IssueItem).Issue.ID
+	}
+	if got := first(SortBlocks); got != "hub" {
+		t.Errorf("hub blocks two open issues and should come first, got %s", got)
+	}
+	if got := first(SortPageRank); got != "hub" {
+		t.Errorf("hub should have the highest PageRank, got %s", got)
+	}
+	// Chores are the lightest work and the closed issue has no ETA
+	if got := first(SortETA); got != "a" {
+		t.Errorf("expected the chore to finish first, got %s", got)
+	}
+	if got := m.list.Items()[len(issues)-1].(IssueItem).Issue.ID; got != "done" {
+		t.Errorf("closed issues should sort last by ETA, got %s", got)
+	}
+	first(SortRisk)
+	if m.riskScores == nil {
+		t.Error("the risk sort should compute risk scores")
+	}
+	if m.sortPending() {
+		t.Error("no sort should be pending once Phase 2 is done")
+	}
+	if got := first(SortDefault); got != "a" {
+		t.Errorf("the default sort should come back after cycling, got %s", got)
+	}
+}
+
+func TestMetricSortPendingUntilPhase2(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := make([]model.Issue, largeIssueSetThreshold+1)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("bd-%d", i), Title: "t", Status: model.StatusClosed}
+		if i%10 == 0 {
+			issues[i].Status = model.StatusOpen
+			issues[i].Dependencies = []*model.Dependency{{IssueID: issues[i].ID, DependsOnID: "bd-5000", Type: model.DepBlocks}}
+		}
+	}
+	issues[5000].Dependencies = nil
+
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+	m = updated.(Model)
+	for m.sortMode != SortPageRank {
+		m.cycleSortMode()
+	}
+	if !m.sortPending() || !strings.Contains(m.renderFooter(), "PageRank (pending)") {
+		t.Fatal("the PageRank sort should be flagged as pending before Phase 2")
+	}
+
+	m.startPhase2()
+	m.analysis.WaitForPhase2()
+	updated, _ = m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	m = updated.(Model)
+	if m.sortPending() || strings.Contains(m.renderFooter(), "pending") {
+		t.Error("the pending flag should clear when Phase 2 arrives")
+	}
+	if got := m.list.Items()[0].(IssueItem).Issue.ID; got != "bd-5000" {
+		t.Errorf("the list should be re-sorted by PageRank, got %s first", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/glamour/ansi"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // MarkdownRenderer provides theme-aware markdown rendering using glamour.
@@ -16,6 +17,15 @@ type MarkdownRenderer struct {
 	useTheme  bool   // true if created with NewMarkdownRendererWithTheme
 }
 
+// markdownColorProfile is the profile markdown is rendered with: plain text
+// when NO_COLOR is set, which glamour does not check itself
+func markdownColorProfile() termenv.Profile {
+	if termenv.EnvNoColor() {
+		return termenv.Ascii
+	}
+	return termenv.TrueColor
+}
+
 // NewMarkdownRenderer creates a new markdown renderer using built-in styles.
 // It uses Dracula style for dark terminals and a light style for light terminals.
 // Prefer NewMarkdownRendererWithTheme for consistent styling with the bv Theme.
@@ -32,6 +42,7 @@ func NewMarkdownRenderer(width int) *MarkdownRenderer {
 	renderer, _ := glamour.NewTermRenderer(
 		glamour.WithStylePath(styleName),
 		glamour.WithWordWrap(width),
+		glamour.WithColorProfile(markdownColorProfile()),
 	)
 
 	return &MarkdownRenderer{
@@ -52,6 +63,7 @@ func NewMarkdownRendererWithTheme(width int, theme Theme) *MarkdownRenderer {
 	renderer, err := glamour.NewTermRenderer(
 		glamour.WithStyles(styleConfig),
 		glamour.WithWordWrap(width),
+		glamour.WithColorProfile(markdownColorProfile()),
 	)
 	if err != nil {
 		// Fall back to built-in style if custom theme fails
@@ -64,6 +76,7 @@ func NewMarkdownRendererWithTheme(width int, theme Theme) *MarkdownRenderer {
 		renderer, _ = glamour.NewTermRenderer(
 			glamour.WithStylePath(styleName),
 			glamour.WithWordWrap(width),
+			glamour.WithColorProfile(markdownColorProfile()),
 		)
 	}
 
@@ -98,6 +111,7 @@ func (mr *MarkdownRenderer) SetWidth(width int) {
 		if r, err := glamour.NewTermRenderer(
 			glamour.WithStyles(styleConfig),
 			glamour.WithWordWrap(width),
+			glamour.WithColorProfile(markdownColorProfile()),
 		); err == nil {
 			mr.renderer = r
 			mr.width = width
@@ -116,6 +130,7 @@ func (mr *MarkdownRenderer) SetWidth(width int) {
 	if r, err := glamour.NewTermRenderer(
 		glamour.WithStylePath(styleName),
 		glamour.WithWordWrap(width),
+		glamour.WithColorProfile(markdownColorProfile()),
 	); err == nil {
 		mr.renderer = r
 		mr.width = width
@@ -137,6 +152,7 @@ func (mr *MarkdownRenderer) SetWidthWithTheme(width int, theme Theme) {
 	r, err := glamour.NewTermRenderer(
 		glamour.WithStyles(styleConfig),
 		glamour.WithWordWrap(width),
+		glamour.WithColorProfile(markdownColorProfile()),
 	)
 	if err != nil {
 		// Fall back to built-in style if custom theme fails
@@ -149,6 +165,7 @@ func (mr *MarkdownRenderer) SetWidthWithTheme(width int, theme Theme) {
 		r, _ = glamour.NewTermRenderer(
 			glamour.WithStylePath(styleName),
 			glamour.WithWordWrap(width),
+			glamour.WithColorProfile(markdownColorProfile()),
 		)
 	}
 	if r != nil {
@@ -0,0 +1,325 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MergeResolvedMsg is sent after merge assist writes the merged beads file
+type MergeResolvedMsg struct {
+	Resolved  int      // Conflicts resolved to a non-current version
+	Artifacts []string // Artifact files the user may now remove
+	Err       error
+}
+
+// MergeAssistModel lets the user pick, per conflicting issue, which variant
+// of a conflicted beads file (the file itself or a merge artifact such as
+// beads.orig.jsonl) to keep. Choices are staged until written.
+type MergeAssistModel struct {
+	set     *loader.MergeSet
+	choices []int // Chosen version per conflict
+	cursor  int
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewMergeAssistModel opens merge assist for a loaded merge set
+func NewMergeAssistModel(set *loader.MergeSet, theme Theme) MergeAssistModel {
+	return MergeAssistModel{
+		set:     set,
+		choices: make([]int, len(set.Conflicts)),
+		theme:   theme,
+	}
+}
+
+// SetSize updates the dimensions
+func (m *MergeAssistModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp selects the previous conflict
+func (m *MergeAssistModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown selects the next conflict
+func (m *MergeAssistModel) MoveDown() {
+	if m.cursor < len(m.choices)-1 {
+		m.cursor++
+	}
+}
+
+// CycleChoice picks the next (delta 1) or previous (delta -1) version of the
+// selected conflict
+func (m *MergeAssistModel) CycleChoice(delta int) {
+	if len(m.choices) == 0 {
+		return
+	}
+	n := len(m.set.Variants)
+	m.choices[m.cursor] = ((m.choices[m.cursor]+delta)%n + n) % n
+}
+
+// Choose picks version i of the selected conflict
+func (m *MergeAssistModel) Choose(i int) {
+	if len(m.choices) > 0 && i >= 0 && i < len(m.set.Variants) {
+		m.choices[m.cursor] = i
+	}
+}
+
+// Choices returns the staged version index per conflict ID
+func (m *MergeAssistModel) Choices() map[string]int {
+	choices := make(map[string]int, len(m.choices))
+	for i, c := range m.set.Conflicts {
+		choices[c.ID] = m.choices[i]
+	}
+	return choices
+}
+
+// Resolved counts conflicts resolved to a version other than the current file's
+func (m *MergeAssistModel) Resolved() int {
+	n := 0
+	for _, choice := range m.choices {
+		if choice != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// mergeDiffFields lists the fields of conflict versions that disagree
+func mergeDiffFields(c loader.MergeConflict) []string {
+	fields := []struct {
+		name  string
+		value func(*model.Issue) string
+	}{
+		{"title", func(i *model.Issue) string { return i.Title }},
+		{"status", func(i *model.Issue) string { return string(i.Status) }},
+		{"priority", func(i *model.Issue) string { return fmt.Sprint(i.Priority) }},
+		{"assignee", func(i *model.Issue) string { return i.Assignee }},
+		{"description", func(i *model.Issue) string { return i.Description }},
+		{"labels", func(i *model.Issue) string { return strings.Join(i.Labels, ",") }},
+		{"dependencies", func(i *model.Issue) string {
+			var deps []string
+			for _, d := range i.Dependencies {
+				if d != nil {
+					deps = append(deps, string(d.Type)+":"+d.DependsOnID)
+				}
+			}
+			return strings.Join(deps, ",")
+		}},
+		{"updated_at", func(i *model.Issue) string { return i.UpdatedAt.String() }},
+	}
+
+	var diff []string
+	missing := false
+	for _, v := range c.Versions {
+		if v.Issue == nil {
+			missing = true
+		}
+	}
+	if missing {
+		diff = append(diff, "presence")
+	}
+	for _, f := range fields {
+		var first *string
+		for _, v := range c.Versions {
+			if v.Issue == nil {
+				continue
+			}
+			value := f.value(v.Issue)
+			if first == nil {
+				first = &value
+			} else if value != *first {
+				diff = append(diff, f.name)
+				break
+			}
+		}
+	}
+	if len(diff) == 0 {
+		diff = append(diff, "other fields")
+	}
+	return diff
+}
+
+// View renders merge assist
+func (m *MergeAssistModel) View() string {
+	if m.width == 0 {
+		m.width = 80
+	}
+	if m.height == 0 {
+		m.height = 24
+	}
+	t := m.theme
+
+	boxWidth := 90
+	if m.width-6 < boxWidth {
+		boxWidth = m.width - 6
+	}
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+	inner := boxWidth - 6
+	maxVisible := m.height - 18 - len(m.set.Variants)
+	if maxVisible > 12 {
+		maxVisible = 12
+	}
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	selStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	itemStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	var artifacts []string
+	for _, a := range m.set.Artifacts {
+		artifacts = append(artifacts, filepath.Base(a))
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Merge assist: "+filepath.Base(m.set.Path)))
+	lines = append(lines, dimStyle.Render(truncateRunesHelper("Artifacts: "+strings.Join(artifacts, ", "), inner, "…")))
+	lines = append(lines, "")
+
+	if len(m.set.Conflicts) == 0 {
+		lines = append(lines, dimStyle.Render("  No conflicting issues; writing only cleans up conflict markers and duplicates"))
+	} else {
+		lines = append(lines, warnStyle.Render(fmt.Sprintf("%d conflicting issues", len(m.set.Conflicts))))
+	}
+
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := start + maxVisible
+	if end > len(m.set.Conflicts) {
+		end = len(m.set.Conflicts)
+	}
+	for i := start; i < end; i++ {
+		c := m.set.Conflicts[i]
+		prefix := "  "
+		style := itemStyle
+		if i == m.cursor {
+			prefix = "> "
+			style = selStyle
+		}
+		title := ""
+		if issue := c.Versions[m.choices[i]].Issue; issue != nil {
+			title = issue.Title
+		} else {
+			title = "(removed)"
+		}
+		label := fmt.Sprintf("%s%-12s %-8s ", prefix, c.ID, m.set.Variants[m.choices[i]])
+		lines = append(lines, style.Render(truncateRunesHelper(label+title, inner, "…")))
+	}
+
+	// Versions of the selected conflict
+	if len(m.set.Conflicts) > 0 {
+		c := m.set.Conflicts[m.cursor]
+		lines = append(lines, "")
+		lines = append(lines, titleStyle.Render(c.ID)+dimStyle.Render("  differs in: "+strings.Join(mergeDiffFields(c), ", ")))
+		for i, v := range c.Versions {
+			mark := "○"
+			style := itemStyle
+			if i == m.choices[m.cursor] {
+				mark = "●"
+				style = selStyle
+			}
+			desc := "(not present)"
+			if v.Issue != nil {
+				desc = fmt.Sprintf("[%s] P%d %s", v.Issue.Status, v.Issue.Priority, v.Issue.Title)
+				if !v.Issue.UpdatedAt.IsZero() {
+					desc += " · updated " + v.Issue.UpdatedAt.Format("2006-01-02 15:04")
+				}
+			}
+			line := fmt.Sprintf("%s %d %-8s %s", mark, i+1, v.Variant, desc)
+			lines = append(lines, style.Render(truncateRunesHelper(line, inner, "…")))
+		}
+	}
+
+	lines = append(lines, "")
+	if n := m.Resolved(); n > 0 {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("%d taken from artifacts, the rest from the current file", n)))
+	}
+	lines = append(lines, dimStyle.Render("j/k: issue | h/l or 1-9: version | ctrl+s: write | esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openMergeAssist loads the merge artifacts next to the beads file
+func (m *Model) openMergeAssist() {
+	if m.refuseReadOnly() {
+		return
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return
+	}
+	set, err := loader.LoadMergeSet(m.beadsPath)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Merge assist: %v", err)
+		m.statusIsError = true
+		return
+	}
+	if len(set.Artifacts) == 0 {
+		m.statusMsg = "🔀 No merge artifacts (beads.orig.jsonl, beads.merge.jsonl, ...) next to " + filepath.Base(m.beadsPath)
+		m.statusIsError = false
+		return
+	}
+	m.mergeAssist = NewMergeAssistModel(set, m.theme)
+	m.mergeAssist.SetSize(m.width, m.height-1)
+	m.showMergeAssist = true
+}
+
+// handleMergeAssistKeys handles keys while merge assist is open
+func (m Model) handleMergeAssistKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	ma := &m.mergeAssist
+	switch key := msg.String(); key {
+	case "esc", "q":
+		m.showMergeAssist = false
+		m.statusMsg = "Merge assist cancelled; nothing written"
+		m.statusIsError = false
+	case "j", "down":
+		ma.MoveDown()
+	case "k", "up":
+		ma.MoveUp()
+	case "l", "right", "tab":
+		ma.CycleChoice(1)
+	case "h", "left", "shift+tab":
+		ma.CycleChoice(-1)
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		ma.Choose(int(key[0] - '1'))
+	case "ctrl+s", "enter":
+		m.showMergeAssist = false
+		return m, resolveMergeCmd(ma.set, ma.Choices(), ma.Resolved())
+	}
+	return m, nil
+}
+
+// resolveMergeCmd writes the merged beads file
+func resolveMergeCmd(set *loader.MergeSet, choices map[string]int, resolved int) tea.Cmd {
+	return func() tea.Msg {
+		err := set.Resolve(choices)
+		return MergeResolvedMsg{Resolved: resolved, Artifacts: set.Artifacts, Err: err}
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/audit"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
@@ -20,12 +22,12 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -38,6 +40,10 @@ const (
 	SplitViewThreshold     = 100
 	WideViewThreshold      = 140
 	UltraWideViewThreshold = 180
+
+	// CompactViewThreshold is the width below which the shortcuts sidebar
+	// and the tutorial TOC no longer fit beside the main view
+	CompactViewThreshold = 80
 )
 
 // focus represents which UI element has keyboard focus
@@ -64,7 +70,17 @@ const (
 	focusFlowMatrix  // Cross-label flow matrix view
 	focusTutorial    // Interactive tutorial (bv-8y31)
 	focusCassModal   // Cass session preview modal (bv-5bqh)
+	focusCassTranscript
 	focusUpdateModal // Self-update modal (bv-182)
+	focusReleaseNotes
+)
+
+// detailTab selects what the detail pane shows for the selected issue
+type detailTab int
+
+const (
+	detailTabInfo    detailTab = iota // Issue fields, dependencies, comments
+	detailTabHistory                  // Change timeline from git history of the beads file
 )
 
 // SortMode represents the current list sorting mode (bv-3ita)
@@ -76,6 +92,11 @@ const (
 	SortCreatedDesc                 // By creation date, newest first
 	SortPriority                    // By priority only (ascending)
 	SortUpdated                     // By last update, newest first
+	SortPageRank                    // By PageRank, highest first (Phase 2)
+	SortTriage                      // By triage score, highest first
+	SortRisk                        // By composite risk, riskiest first
+	SortETA                         // By estimated completion, soonest first
+	SortBlocks                      // By open issues blocked, most first
 	numSortModes                    // Keep this last - used for cycling
 )
 
@@ -90,6 +111,16 @@ func (s SortMode) String() string {
 		return "Priority"
 	case SortUpdated:
 		return "Updated"
+	case SortPageRank:
+		return "PageRank"
+	case SortTriage:
+		return "Triage"
+	case SortRisk:
+		return "Risk"
+	case SortETA:
+		return "ETA"
+	case SortBlocks:
+		return "Blocks"
 	default:
 		return "Default"
 	}
@@ -106,7 +137,9 @@ type LabelGraphAnalysisResult struct {
 // UpdateMsg is sent when a new version is available
 type UpdateMsg struct {
 	TagName string
+	Name    string // Release title
 	URL     string
+	Notes   string // Release notes (markdown)
 }
 
 // Phase2ReadyMsg is sent when async graph analysis Phase 2 completes
@@ -122,6 +155,16 @@ func WaitForPhase2Cmd(stats *analysis.GraphStats) tea.Cmd {
 	}
 }
 
+// StartPhase2Cmd launches deferred Phase 2 analysis, then waits for it like
+// WaitForPhase2Cmd.
+func StartPhase2Cmd(stats *analysis.GraphStats, start func()) tea.Cmd {
+	return func() tea.Msg {
+		start()
+		stats.WaitForPhase2()
+		return Phase2ReadyMsg{Stats: stats}
+	}
+}
+
 // FileChangedMsg is sent when the beads file changes on disk
 type FileChangedMsg struct{}
 
@@ -152,9 +195,9 @@ func WatchFileCmd(w *watcher.Watcher) tea.Cmd {
 // CheckUpdateCmd returns a command that checks for updates
 func CheckUpdateCmd() tea.Cmd {
 	return func() tea.Msg {
-		tag, url, err := updater.CheckForUpdates()
-		if err == nil && tag != "" {
-			return UpdateMsg{TagName: tag, URL: url}
+		rel, err := updater.CheckForRelease()
+		if err == nil && rel != nil {
+			return UpdateMsg{TagName: rel.TagName, Name: rel.Name, URL: rel.HTMLURL, Notes: rel.Body}
 		}
 		return nil
 	}
@@ -201,33 +244,33 @@ func CheckAgentFileCmd(workDir string) tea.Cmd {
 	}
 }
 
-// LoadHistoryCmd returns a command that loads history data in the background
-func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
-	return func() tea.Msg {
-		var repoPath string
-		var err error
-
-		if beadsPath != "" {
-			// If beadsPath is provided (single-repo mode), derive repo root from it.
-			// Try to resolve absolute path first.
-			if absPath, e := filepath.Abs(beadsPath); e == nil {
-				dir := filepath.Dir(absPath)
-				// Standard layout: <repo_root>/.beads/<file.jsonl>
-				if filepath.Base(dir) == ".beads" {
-					repoPath = filepath.Dir(dir)
-				} else {
-					// Legacy/Flat layout: <repo_root>/<file.jsonl>
-					repoPath = dir
-				}
+// historyRepoPath derives the git repository root from the beads file path,
+// falling back to the working directory (workspace mode).
+func historyRepoPath(beadsPath string) (string, error) {
+	if beadsPath != "" {
+		// If beadsPath is provided (single-repo mode), derive repo root from it.
+		// Try to resolve absolute path first.
+		if absPath, e := filepath.Abs(beadsPath); e == nil {
+			dir := filepath.Dir(absPath)
+			// Standard layout: <repo_root>/.beads/<file.jsonl>
+			if filepath.Base(dir) == ".beads" {
+				return filepath.Dir(dir), nil
 			}
+			// Legacy/Flat layout: <repo_root>/<file.jsonl>
+			return dir, nil
 		}
+	}
 
-		// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
-		if repoPath == "" {
-			repoPath, err = os.Getwd()
-			if err != nil {
-				return HistoryLoadedMsg{Error: err}
-			}
+	// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
+	return os.Getwd()
+}
+
+// LoadHistoryCmd returns a command that loads history data in the background
+func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return HistoryLoadedMsg{Error: err}
 		}
 
 		// Convert model.Issue to correlation.BeadInfo
@@ -250,6 +293,79 @@ func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
 	}
 }
 
+// IssueChangesLoadedMsg is sent when an issue's change timeline has been extracted
+type IssueChangesLoadedMsg struct {
+	IssueID string
+	Changes []correlation.IssueChange
+	Error   error
+}
+
+// LoadIssueChangesCmd returns a command that walks the git history of the
+// beads file for changes to a single issue
+func LoadIssueChangesCmd(beadsPath, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return IssueChangesLoadedMsg{IssueID: issueID, Error: err}
+		}
+		changes, err := correlation.NewExtractor(repoPath, beadsPath).ExtractIssueChanges(issueID, correlation.ExtractOptions{})
+		return IssueChangesLoadedMsg{IssueID: issueID, Changes: changes, Error: err}
+	}
+}
+
+// BlameLoadedMsg is sent when status/priority attribution for all issues has been extracted
+type BlameLoadedMsg struct {
+	Blame map[string]*correlation.IssueBlame
+	Error error
+}
+
+// LoadBlameCmd returns a command that attributes every issue's status and
+// priority to the commit that last changed them
+func LoadBlameCmd(beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return BlameLoadedMsg{Error: err}
+		}
+		blame, err := correlation.NewExtractor(repoPath, beadsPath).ExtractBlame(correlation.ExtractOptions{})
+		return BlameLoadedMsg{Blame: blame, Error: err}
+	}
+}
+
+// issueChangesEntry caches the change timeline for one issue
+type issueChangesEntry struct {
+	changes []correlation.IssueChange
+	err     error
+	loading bool
+}
+
+// PastSnapshot identifies the commit whose beads data is being browsed
+type PastSnapshot struct {
+	SHA      string
+	ShortSHA string
+	Subject  string
+}
+
+// PastSnapshotLoadedMsg is sent when the beads file at a past commit has been read
+type PastSnapshotLoadedMsg struct {
+	Snapshot PastSnapshot
+	Issues   []model.Issue
+	Error    error
+}
+
+// LoadPastSnapshotCmd returns a command that reconstructs the beads file at a
+// commit via git show
+func LoadPastSnapshotCmd(beadsPath string, snapshot PastSnapshot) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return PastSnapshotLoadedMsg{Snapshot: snapshot, Error: err}
+		}
+		issues, err := loader.NewGitLoader(repoPath).LoadAt(snapshot.SHA)
+		return PastSnapshotLoadedMsg{Snapshot: snapshot, Issues: issues, Error: err}
+	}
+}
+
 // Model is the main Bubble Tea model for the beads viewer
 type Model struct {
 	// Data
@@ -274,14 +390,21 @@ type Model struct {
 	theme              Theme
 
 	// Update State
-	updateAvailable bool
-	updateTag       string
-	updateURL       string
+	updateAvailable   bool
+	updateTag         string
+	updateName        string
+	updateURL         string
+	updateNotes       string
+	skippedUpdate     string    // Release tag the user chose to skip (persisted)
+	remindUpdateAfter time.Time // Release notes are postponed until then (persisted)
 
 	// Focus and View State
 	focused         focus
 	focusBeforeHelp focus // Stores focus before opening help overlay
 	isSplitView              bool
+	splitStacked             bool             // Split panes stacked (list above details)
+	splitRatio               float64          // List pane share of the split (persisted)
+	splitOrientation         SplitOrientation // Split layout preference (persisted)
 	isBoardView              bool
 	isGraphView              bool
 	isActionableView         bool
@@ -322,6 +445,11 @@ type Model struct {
 	sortMode               SortMode // bv-3ita: current sort mode
 	semanticSearchEnabled  bool
 	semanticIndexBuilding  bool
+	semanticIndexErr       error // Last index build failure; ~ queries stay fuzzy until reload
+
+	// Similar issues in the detail view
+	similarIndex         *search.VectorIndex
+	similarIndexBuilding bool
 	semanticSearch         *SemanticSearch
 	semanticHybridEnabled  bool
 	semanticHybridPreset   search.PresetName
@@ -338,6 +466,37 @@ type Model struct {
 	// Priority hints
 	showPriorityHints bool
 	priorityHints     map[string]*analysis.PriorityRecommendation // issueID -> recommendation
+	priorityDecisions *analysis.PriorityDecisionLog               // Dismissed and snoozed suggestions, nil without a beads file
+
+	// Priority review: accept, dismiss or snooze suggestions in bulk (P)
+	showPriorityReview bool
+	priorityReview     []analysis.PriorityRecommendation
+	reviewMarked       map[string]bool
+	reviewCursor       int
+
+	// Completion forecast (F): the backlog or the selected issue's epic
+	showForecast   bool
+	forecast       analysis.CompletionForecast
+	forecastEpic   string // "" for the whole backlog
+	forecastAgents int
+
+	// Effort rollup (R): estimate totals per epic, label and assignee
+	showEffortRollup bool
+	effortRollup     analysis.EffortRollup
+	effortGroup      int // Index into effortGroupings
+	effortCursor     int
+
+	// Unblock queue (Q): open blockers ranked by the work closing each frees
+	showUnblockQueue bool
+	unblockQueue     analysis.UnblockQueue
+	unblockCursor    int
+
+	// Risk heatmap overlay for list and board (m); scores computed on demand
+	showRiskHeatmap bool
+	riskScores      map[string]float64
+
+	// ETA estimates for the ETA sort; computed on demand
+	etaEstimates map[string]analysis.ETAEstimate
 
 	// Triage insights (bv-151)
 	triageScores  map[string]float64                // issueID -> triage score
@@ -356,6 +515,18 @@ type Model struct {
 	showLabelPicker bool
 	labelPicker     LabelPickerModel
 
+	// Dependency editor overlay (D)
+	showDepEditor bool
+	depEditor     DependencyEditorModel
+
+	// Merge assist for beads.orig/merge/left/right.jsonl artifacts
+	showMergeAssist bool
+	mergeAssist     MergeAssistModel
+
+	// Issue template picker (+)
+	showTemplatePicker bool
+	templatePicker     TemplatePickerModel
+
 	// Repo picker (workspace mode)
 	showRepoPicker bool
 	repoPicker     RepoPickerModel
@@ -372,15 +543,63 @@ type Model struct {
 	timeTravelInput      textinput.Model
 	showTimeTravelPrompt bool
 
+	// Historical snapshot browsing: live data is stashed while the views show
+	// the beads file as of a past commit (read-only)
+	pastSnapshot   *PastSnapshot
+	pastLiveIssues []model.Issue
+	pastLiveStale  bool // beads file changed on disk while viewing the past
+
+	// Detail pane tab and per-issue change timelines (loaded on demand)
+	detailTab    detailTab
+	issueChanges map[string]*issueChangesEntry
+
+	// Linked issue references in the detail text (n/N cycle, gd jumps)
+	detailRefs       []string
+	detailRefIdx     int
+	detailRefIssueID string
+	waitingForGoto   bool
+	attachmentIdx    int // Selected attachment in the detail view (u/U, @ opens)
+
+	// Vim-style count typed before a motion (5j, 10ctrl+d)
+	keyCount countPrefix
+
+	// Jump list of visited issues (ctrl+o back, ctrl+i/ctrl+] forward)
+	nav navHistory
+
+	// Last modifier of each issue's status/priority (loaded on demand)
+	blame        map[string]*correlation.IssueBlame
+	blameLoading bool
+	blameLoaded  bool
+
 	// Status message (for temporary feedback)
 	statusMsg     string
 	statusIsError bool
 
+	// Lazy loading for large issue sets (see largeIssueSetThreshold)
+	lazyLoad     bool              // Analysis covers unresolved issues; triage waits for Phase 2
+	boardPending bool              // Board has not been filled since startup
+	startPhase2  func()            // Launches the startup Phase 2 analysis (no-op once running)
+	textIndex    *loader.TextIndex // Set when text fields load on demand (--lazy-text)
+
+	// Deleted issues (deletions.jsonl tombstones) are hidden unless toggled on
+	showDeleted  bool
+	deletedCount int // Entries in the deletions manifest
+
+	// Archived issues (archive.jsonl, written by bv archive) are hidden unless toggled on
+	showArchived bool
+
+	customFields *CustomFieldsConfig // Detail view order for custom fields
+	statusBar    *StatusBarConfig    // Status bar segments to show
+	keymap       *Keymap             // Key bindings with the user's remaps
+	loadedAt     time.Time           // When the issues were last loaded from disk
+
 	// Workspace mode state
 	workspaceMode    bool            // True when viewing multiple repos
 	availableRepos   []string        // List of repo prefixes available
 	activeRepos      map[string]bool // Which repos are currently shown (nil = all)
 	workspaceSummary string          // Summary text for footer (e.g., "3 repos")
+	workspaceRepos   []WorkspaceRepo // Per-repo load status shown in the repo picker
+	workspaceState   string          // Path where the repo filter is persisted
 
 	// Alerts panel (bv-168)
 	alerts          []drift.Alert
@@ -391,6 +610,11 @@ type Model struct {
 	alertsCursor    int
 	dismissedAlerts map[string]bool
 
+	// Attention digest: stale, long-blocked and priority-inverted issues (A)
+	showDigestPanel bool
+	attentionDigest []analysis.DigestItem
+	digestCursor    int
+
 	// Sprint view (bv-161)
 	sprints        []model.Sprint
 	selectedSprint *model.Sprint
@@ -411,9 +635,93 @@ type Model struct {
 	cassModal      CassSessionModal
 	cassCorrelator *cass.Correlator
 
+	// Confirm/reject/ignore decisions on commit and session correlations
+	correlationFeedback *correlation.FeedbackStore
+
+	// Full-screen transcript of the session picked in the cass modal
+	showCassTranscript bool
+	cassTranscript     CassTranscriptModel
+
+	// Session counts per bead from the background cass indexer
+	cassSessionCounts map[string]int
+	cassIndexing      bool
+
+	// Annotations from the .bv/plugins/ analyzers
+	pluginReport    *plugins.Report
+	pluginsDisabled bool
+
+	// Skips the .bv/hooks.yaml data event hooks on reload (--no-hooks)
+	hooksDisabled bool
+
+	// Checked-out branch and the issues in flight on it, pinned in the list
+	branchContext *correlation.BranchContext
+	workingOn     map[string]bool
+	starred       map[string]bool         // Starred issue IDs, saved in .bv/state.json
+	epicProgress  map[string]epicProgress // Closed/total children per epic
+	openBlockers  map[string][]string     // Unclosed blockers of each blocked issue
+
+	// Local snoozes: issues hidden until a time, saved in .bv/state.json
+	snoozed          map[string]time.Time
+	showSnoozePrompt bool
+	snoozeInput      textinput.Model
+	snoozeIssueID    string
+
+	// Rename prompt: new ID, then a preview of the rewritten lines (ctrl+r)
+	showRenamePrompt bool
+	renameInput      textinput.Model
+	renameIssueID    string
+	renamePlan       *loader.RenamePlan
+	renameError      string
+
+	notes map[string]string // Private per-issue notes from .bv/notes/
+
 	// Self-update modal (bv-182)
 	showUpdateModal bool
 	updateModal     UpdateModal
+
+	// Release notes for a newly detected version
+	showReleaseNotes bool
+	releaseNotes     ReleaseNotesModel
+
+	// Transient notifications and their history
+	toasts             Notifications
+	showNotifications  bool
+	notificationScroll int
+
+	// Startup diagnostics modal (Ctrl+G)
+	loadDuration       time.Duration
+	showDiagnostics    bool
+	diagnosticsProfile *analysis.StartupProfile
+
+	// Writes recorded in .bv/audit.jsonl (Y)
+	showAuditLog bool
+	auditEntries []audit.Entry
+	auditScroll  int
+
+	// Blocker menu (b in the detail view): jump to what an issue waits on
+	showBlockerMenu bool
+	blockerMenuFor  string
+	blockerMenuIdx  int
+
+	// Search results pane (ctrl+f) and the search bar's history (up/down)
+	showSearchResults bool
+	searchResults     []searchResult
+	searchResultIdx   int
+	searchQuery       string
+	searchHistory     []string // Newest first (persisted)
+	searchHistoryPos  int      // Entry shown in the search bar; -1 = the query being typed
+	searchDraft       string   // Query being typed, kept while browsing the history
+	labelSuggestIdx   int      // Label highlighted in the search bar dropdown
+
+	// Command palette (Ctrl+P)
+	showPalette bool
+	palette     CommandPaletteModel
+
+	// Screen-reader friendly rendering (--accessible)
+	accessible bool
+
+	// ASCII-only rendering for terminals without Unicode (--ascii)
+	ascii bool
 }
 
 // labelCount is a simple label->count pair for display
@@ -509,6 +817,17 @@ type WorkspaceInfo struct {
 	FailedCount  int
 	TotalIssues  int
 	RepoPrefixes []string
+	Repos        []WorkspaceRepo // Per-repo counts and load errors for the repo picker
+	StatePath    string          // Where the repo filter is remembered (see WorkspaceRepoStatePath)
+}
+
+// WorkspaceRepo is the load outcome of one workspace repo
+type WorkspaceRepo struct {
+	Name       string
+	Prefix     string
+	IssueCount int
+	OpenCount  int
+	Error      string // Empty when the repo loaded
 }
 
 func (m *Model) updateSemanticIDs(items []list.Item) {
@@ -528,8 +847,32 @@ func (m *Model) updateSemanticIDs(items []list.Item) {
 	m.semanticSearch.SetDocs(docs)
 }
 
+// semanticActive reports whether the current filter query is ranked
+// semantically: semantic mode is on, or the query starts with ~
+func (m *Model) semanticActive() bool {
+	if m.semanticSearchEnabled {
+		return true
+	}
+	_, prefixed := semanticQuery(m.list.FilterInput.Value())
+	return prefixed
+}
+
+// semanticTerm returns the current filter query without the ~ prefix
+func (m *Model) semanticTerm() string {
+	term, _ := semanticQuery(m.list.FilterInput.Value())
+	return term
+}
+
+// fuzzyFilter is the list filter for fuzzy mode, which still honours ~query
+func (m *Model) fuzzyFilter() list.FilterFunc {
+	if m.semanticSearch == nil {
+		return list.DefaultFilter
+	}
+	return m.semanticSearch.PrefixFilter
+}
+
 func (m *Model) shouldShowSearchScores() bool {
-	if !m.semanticSearchEnabled || !m.semanticHybridEnabled || m.semanticSearch == nil {
+	if !m.semanticActive() || !m.semanticHybridEnabled || m.semanticSearch == nil {
 		return false
 	}
 	if m.list.FilterState() == list.Unfiltered {
@@ -548,6 +891,14 @@ func (m *Model) updateListDelegate() {
 		PriorityHints:     m.priorityHints,
 		WorkspaceMode:     m.workspaceMode,
 		ShowSearchScores:  m.shouldShowSearchScores(),
+		ShowRiskHeatmap:   m.showRiskHeatmap,
+		RiskScores:        m.riskScores,
+		SessionCounts:     m.cassSessionCounts,
+		WorkingOn:         m.workingOn,
+		Starred:           m.starred,
+		EpicProgress:      m.epicProgress,
+		OpenBlockers:      m.openBlockers,
+		Accessible:        m.accessible,
 	})
 }
 
@@ -607,12 +958,92 @@ func (m *Model) clearSemanticScores() {
 	}
 }
 
+// largeIssueSetThreshold is the issue count above which the TUI loads lazily:
+// graph analysis covers only unresolved issues, and triage, alerts and the
+// board are deferred until Phase 2 completes instead of blocking startup.
+const largeIssueSetThreshold = 10000
+
+// isLargeIssueSet reports whether issues should be loaded lazily.
+func isLargeIssueSet(issues []model.Issue) bool {
+	return len(issues) > largeIssueSetThreshold
+}
+
+// analysisScope returns the issues fed to graph analysis. Deleted issues
+// (shown with the deleted toggle) never take part. For large issue sets
+// closed issues are left out too: they dominate long-lived trackers, cannot
+// block anything and would only slow Phase 2 down.
+func analysisScope(issues []model.Issue) []model.Issue {
+	large := isLargeIssueSet(issues)
+	keep := func(issue *model.Issue) bool {
+		return !issue.Status.IsTombstone() && !(large && issue.Status == model.StatusClosed)
+	}
+	n := 0
+	for i := range issues {
+		if keep(&issues[i]) {
+			n++
+		}
+	}
+	if n == len(issues) {
+		return issues
+	}
+	scope := make([]model.Issue, 0, n)
+	for i := range issues {
+		if keep(&issues[i]) {
+			scope = append(scope, issues[i])
+		}
+	}
+	return scope
+}
+
+// triageLookups indexes a triage result by issue ID for the list delegate.
+type triageLookups struct {
+	scores    map[string]float64
+	reasons   map[string]analysis.TriageReasons
+	quickWins map[string]bool
+	blockers  map[string]bool
+	unblocks  map[string][]string
+}
+
+func newTriageLookups(result analysis.TriageResult) triageLookups {
+	t := triageLookups{
+		scores:    make(map[string]float64, len(result.Recommendations)),
+		reasons:   make(map[string]analysis.TriageReasons, len(result.Recommendations)),
+		quickWins: make(map[string]bool, len(result.QuickWins)),
+		blockers:  make(map[string]bool, len(result.BlockersToClear)),
+		unblocks:  make(map[string][]string, len(result.Recommendations)),
+	}
+	for _, rec := range result.Recommendations {
+		t.scores[rec.ID] = rec.Score
+		if len(rec.Reasons) > 0 {
+			t.reasons[rec.ID] = analysis.TriageReasons{
+				Primary:    rec.Reasons[0],
+				All:        rec.Reasons,
+				ActionHint: rec.Action,
+			}
+		}
+		t.unblocks[rec.ID] = rec.UnblocksIDs
+	}
+	for _, qw := range result.QuickWins {
+		t.quickWins[qw.ID] = true
+	}
+	for _, bl := range result.BlockersToClear {
+		t.blockers[bl.ID] = true
+	}
+	return t
+}
+
 // NewModel creates a new Model from the given issues
 // beadsPath is the path to the beads.jsonl file for live reload support
 func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath string) Model {
-	// Graph Analysis - Phase 1 is instant, Phase 2 runs in background
-	analyzer := analysis.NewAnalyzer(issues)
-	graphStats := analyzer.AnalyzeAsync(context.Background())
+	lazy := isLargeIssueSet(issues)
+
+	// Graph Analysis - Phase 1 is instant, Phase 2 runs in background.
+	// Large sets hold Phase 2 back until Init so it does not slow startup.
+	analyzer := analysis.NewAnalyzer(analysisScope(issues))
+	graphStats, startPhase2 := analyzer.AnalyzePhase1(context.Background())
+	if !lazy {
+		startPhase2()
+	}
 
 	// Sort issues
 	if activeRecipe != nil && activeRecipe.Sort.Field != "" {
@@ -655,6 +1086,16 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		})
 	}
 
+	notes := loadLocalNotes(beadsPath)
+
+	// Starred issues sort above the rest (see pinStarred)
+	starred := loadStarredIssues(beadsPath)
+	if len(starred) > 0 {
+		sort.SliceStable(issues, func(i, j int) bool {
+			return starred[issues[i].ID] && !starred[issues[j].ID]
+		})
+	}
+
 	// Build lookup map
 	issueMap := make(map[string]*model.Issue, len(issues))
 
@@ -668,6 +1109,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 			GraphScore: graphStats.GetPageRankScore(issues[i].ID),
 			Impact:     graphStats.GetCriticalPathScore(issues[i].ID),
 			RepoPrefix: ExtractRepoPrefix(issues[i].ID),
+			LocalNote:  notes[issues[i].ID],
 		}
 	}
 
@@ -704,6 +1146,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 
 	// Theme
 	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	theme.LabelColors = loadLabelColors(beadsPath)
 
 	// Default dimensions for immediate ready state (updated when WindowSizeMsg arrives)
 	// This eliminates the "Initializing..." phase entirely, fixing slow startup issues
@@ -712,7 +1155,9 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	const defaultHeight = 40
 
 	// List setup - initialize with default dimensions so UI is immediately usable
-	delegate := IssueDelegate{Theme: theme, WorkspaceMode: false}
+	epicProgress := computeEpicProgress(issues)
+	openBlockers := computeOpenBlockers(analyzer, issues)
+	delegate := IssueDelegate{Theme: theme, WorkspaceMode: false, EpicProgress: epicProgress, OpenBlockers: openBlockers}
 	l := list.New(items, delegate, defaultWidth, defaultHeight-3)
 	l.Title = ""
 	l.SetShowTitle(false)
@@ -740,8 +1185,12 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	// Initialize viewport with default dimensions
 	vp := viewport.New(defaultWidth, defaultHeight-2)
 
-	// Initialize sub-components
-	board := NewBoardModel(issues, theme)
+	// Initialize sub-components. Large sets fill the board on first use.
+	board := NewBoardModel(nil, theme)
+	board.SetEpicProgress(epicProgress)
+	if !lazy {
+		board.SetIssues(issues)
+	}
 	labelDashboard := NewLabelDashboardModel(theme)
 	labelDashboard.SetSize(defaultWidth, defaultHeight-1)
 	velocityComparison := NewVelocityComparisonModel(theme) // bv-125
@@ -755,31 +1204,18 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	// This avoids blocking startup on expensive graph analysis
 	priorityHints := make(map[string]*analysis.PriorityRecommendation)
 
-	// Compute triage insights (bv-151) - reuse existing analyzer/stats (bv-runn.12)
-	triageResult := analysis.ComputeTriageFromAnalyzer(analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now())
-	triageScores := make(map[string]float64, len(triageResult.Recommendations))
-	triageReasons := make(map[string]analysis.TriageReasons, len(triageResult.Recommendations))
-	quickWinSet := make(map[string]bool, len(triageResult.QuickWins))
-	blockerSet := make(map[string]bool, len(triageResult.BlockersToClear))
-	unblocksMap := make(map[string][]string, len(triageResult.Recommendations))
-
-	for _, rec := range triageResult.Recommendations {
-		triageScores[rec.ID] = rec.Score
-		if len(rec.Reasons) > 0 {
-			triageReasons[rec.ID] = analysis.TriageReasons{
-				Primary:    rec.Reasons[0],
-				All:        rec.Reasons,
-				ActionHint: rec.Action,
-			}
-		}
-		unblocksMap[rec.ID] = rec.UnblocksIDs
-	}
-	for _, qw := range triageResult.QuickWins {
-		quickWinSet[qw.ID] = true
-	}
-	for _, bl := range triageResult.BlockersToClear {
-		blockerSet[bl.ID] = true
+	// Compute triage insights (bv-151) - reuse existing analyzer/stats (bv-runn.12).
+	// Large sets get triage data when Phase 2 completes.
+	triage := newTriageLookups(analysis.TriageResult{})
+	if !lazy {
+		triage = newTriageLookups(analysis.ComputeTriageFromAnalyzer(analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now()))
 	}
+	triageScores := triage.scores
+	triageReasons := triage.reasons
+	quickWinSet := triage.quickWins
+	blockerSet := triage.blockers
+	unblocksMap := triage.unblocks
+	board.SetMetrics(graphStats, triageScores)
 
 	// Update items with triage data
 	for i := range items {
@@ -840,6 +1276,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		}
 	}
 	semanticSearch.SetIDs(semanticIDs)
+	l.Filter = semanticSearch.PrefixFilter // ~query runs semantically in fuzzy mode
 
 	// Build initial status message if watcher failed
 	var initialStatus string
@@ -847,23 +1284,59 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	if watcherErr != nil {
 		initialStatus = fmt.Sprintf("Live reload unavailable: %v", watcherErr)
 		initialStatusErr = true
+	} else if beadsPath != "" && len(loader.FindMergeArtifacts(filepath.Dir(beadsPath))) > 0 {
+		initialStatus = "🔀 Merge artifacts found next to " + filepath.Base(beadsPath) + " - press M to resolve"
+	}
+
+	// User key remaps; a broken keymap.yaml falls back to the defaults
+	keymap, keymapErr := LoadKeymap(KeymapConfigPath())
+	if keymapErr != nil {
+		keymap = DefaultKeymap()
+		if initialStatus == "" {
+			initialStatus = fmt.Sprintf("Key remaps ignored: %v", keymapErr)
+			initialStatusErr = true
+		}
 	}
 
-	// Precompute drift/health alerts (bv-168)
-	alerts, alertsCritical, alertsWarning, alertsInfo := computeAlerts(issues, graphStats, analyzer)
+	// Precompute drift/health alerts (bv-168); large sets wait for Phase 2
+	var alerts []drift.Alert
+	var alertsCritical, alertsWarning, alertsInfo int
+	if !lazy {
+		alerts, alertsCritical, alertsWarning, alertsInfo = computeAlerts(issues, graphStats, analyzer)
+	}
 
-	// Load sprints from the same directory as beadsPath (bv-161)
+	// Load sprints and the deletions manifest from the same directory as beadsPath (bv-161)
 	var sprints []model.Sprint
+	deletedCount := 0
 	if beadsPath != "" {
 		beadsDir := filepath.Dir(beadsPath)
 		if loaded, err := loader.LoadSprintsFromFile(filepath.Join(beadsDir, loader.SprintsFileName)); err == nil {
 			sprints = loaded
 		}
+		deletedCount = countDeletions(beadsPath)
 	}
 
+	// Restore the split layout from the last session
+	uiState := LoadUIState()
+
 	return Model{
 		issues:                 issues,
 		issueMap:               issueMap,
+		lazyLoad:               lazy,
+		deletedCount:           deletedCount,
+		customFields:           loadCustomFieldsConfig(beadsPath),
+		statusBar:              loadStatusBarConfig(beadsPath),
+		keymap:                 keymap,
+		loadedAt:               time.Now(),
+		correlationFeedback:    loadFeedbackStore(beadsPath),
+		priorityDecisions:      loadPriorityDecisionLog(beadsPath),
+		starred:                starred,
+		epicProgress:           epicProgress,
+		openBlockers:           openBlockers,
+		snoozed:                loadSnoozedIssues(beadsPath),
+		notes:                  notes,
+		boardPending:           lazy,
+		startPhase2:            startPhase2,
 		analyzer:               analyzer,
 		analysis:               graphStats,
 		beadsPath:              beadsPath,
@@ -886,6 +1359,12 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		semanticHybridReady:    false,
 		lastSearchTerm:         "",
 		focused:                focusList,
+		splitRatio:             uiState.SplitRatio,
+		splitOrientation:       uiState.SplitOrientation,
+		skippedUpdate:          uiState.SkippedUpdate,
+		remindUpdateAfter:      uiState.RemindUpdateAfter,
+		searchHistory:          uiState.SearchHistory,
+		searchHistoryPos:       -1,
 		// Initialize as ready with default dimensions to eliminate "Initializing..." phase
 		ready:               true,
 		width:               defaultWidth,
@@ -905,6 +1384,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		recipePicker:        recipePicker,
 		activeRecipe:        activeRecipe,
 		labelPicker:         labelPicker,
+		palette:             NewCommandPaletteModel(theme),
 		labelDrilldownCache: make(map[string][]model.Issue),
 		timeTravelInput:     ti,
 		statusMsg:           initialStatus,
@@ -936,9 +1416,11 @@ func (m Model) Init() tea.Cmd {
 	// Note: ReadyTimeoutCmd is no longer needed since the model is now
 	// initialized as ready with default dimensions in NewModel().
 	// This eliminates the "Initializing..." phase entirely.
-	cmds := []tea.Cmd{
-		CheckUpdateCmd(),
-		WaitForPhase2Cmd(m.analysis),
+	cmds := []tea.Cmd{CheckUpdateCmd()}
+	if m.startPhase2 != nil {
+		cmds = append(cmds, StartPhase2Cmd(m.analysis, m.startPhase2))
+	} else {
+		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
 	}
 	if m.watcher != nil {
 		cmds = append(cmds, WatchFileCmd(m.watcher))
@@ -951,6 +1433,25 @@ func (m Model) Init() tea.Cmd {
 	if m.workDir != "" && !m.workspaceMode {
 		cmds = append(cmds, CheckAgentFileCmd(m.workDir))
 	}
+	// Correlate open beads with cass sessions in the background
+	if len(m.issues) > 0 {
+		cmds = append(cmds, CassIndexCmd(m.issues, m.workDir, nil))
+	}
+	// Run the .bv/plugins/ analyzers
+	if cmd := m.pluginsCmd(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	// Wake expired snoozes now, then check every minute
+	cmds = append(cmds, snoozeTickCmd(0))
+	// Pin the issues the current branch is working on
+	if len(m.issues) > 0 && m.beadsPath != "" && !m.workspaceMode {
+		cmds = append(cmds, LoadBranchContextCmd(m.issues, m.beadsPath))
+	}
+	// The hash embedder is cheap enough to index similar issues up front;
+	// model backends wait until semantic search is first used
+	if len(m.issues) > 1 && search.EmbeddingConfigFromEnv().Provider == search.ProviderHash {
+		cmds = append(cmds, BuildSimilarityIndexCmd(m.issues))
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -958,11 +1459,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	// Remapped keys stand in for the default keys matched below
+	if key, ok := msg.(tea.KeyMsg); ok && m.keymapActive() {
+		translated, bound := m.keymap.Translate(m.CurrentContext(), key)
+		if !bound {
+			return m, nil
+		}
+		msg = translated
+	}
+
 	switch msg := msg.(type) {
 	case UpdateMsg:
 		m.updateAvailable = true
 		m.updateTag = msg.TagName
+		m.updateName = msg.Name
 		m.updateURL = msg.URL
+		m.updateNotes = msg.Notes
+		// Only interrupt plain browsing; otherwise a toast and the footer badge are enough
+		if m.focused == focusList && m.releaseNotesDue(msg.TagName, time.Now()) {
+			m.showReleaseNotesModal()
+		} else if msg.TagName != m.skippedUpdate {
+			cmds = append(cmds, m.toasts.Push(ToastInfo, fmt.Sprintf("bv %s is available - press U to update", msg.TagName)))
+		}
+
+	case toastExpiredMsg:
+		m.toasts.Expire(msg.ID)
+		return m, nil
 
 	case UpdateCompleteMsg:
 		// Forward to the update modal
@@ -994,17 +1516,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case SemanticIndexReadyMsg:
 		m.semanticIndexBuilding = false
+		m.semanticIndexErr = msg.Error
 		if msg.Error != nil {
 			// If indexing fails, revert to fuzzy mode for predictable behavior.
 			m.semanticSearchEnabled = false
-			m.list.Filter = list.DefaultFilter
-			m.statusMsg = fmt.Sprintf("Semantic search unavailable: %v", msg.Error)
+			m.list.Filter = m.fuzzyFilter()
+			m.statusMsg = fmt.Sprintf("Semantic search unavailable, using fuzzy search: %v", msg.Error)
 			m.statusIsError = true
 			break
 		}
 		if m.semanticSearch != nil {
 			m.semanticSearch.SetIndex(msg.Index, msg.Embedder)
 		}
+		if m.similarIndex == nil && !m.similarIndexBuilding {
+			m.similarIndexBuilding = true
+			cmds = append(cmds, BuildSimilarityIndexCmd(m.issues))
+		}
 		if !msg.Loaded {
 			m.statusMsg = fmt.Sprintf("Semantic index built (%d embedded)", msg.Stats.Embedded)
 		} else if msg.Stats.Changed() {
@@ -1015,7 +1542,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusIsError = false
 
 		// Refresh current filter view if the user is actively searching.
-		if m.semanticSearchEnabled && m.list.FilterState() != list.Unfiltered {
+		if m.semanticActive() && m.list.FilterState() != list.Unfiltered {
 			prevState := m.list.FilterState()
 			filterText := m.list.FilterInput.Value()
 			m.list.SetFilterText(filterText)
@@ -1024,6 +1551,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case CassIndexReadyMsg:
+		m.cassIndexing = false
+		if msg.Correlator != nil {
+			m.cassCorrelator = msg.Correlator
+			m.cassSessionCounts = msg.Counts
+			m.updateListDelegate()
+		}
+
+	case StartupProfileMsg:
+		m.diagnosticsProfile = msg.Profile
+		return m, nil
+
+	case PluginsReadyMsg:
+		m.handlePluginsReady(msg)
+
+	case DossierReadyMsg:
+		m.handleDossierReady(msg)
+
+	case SnoozeTickMsg:
+		return m, m.handleSnoozeTick(msg)
+
+	case NoteEditedMsg:
+		m.handleNoteEdited(msg)
+
+	case EventHooksDoneMsg:
+		if cmd := m.handleEventHooksDone(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case SimilarityIndexReadyMsg:
+		m.similarIndexBuilding = false
+		if msg.Error == nil {
+			m.similarIndex = msg.Index
+			m.updateViewportContent()
+		}
+
 	case HybridMetricsReadyMsg:
 		m.semanticHybridBuilding = false
 		if msg.Error != nil {
@@ -1045,8 +1608,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusIsError = false
 
 		// Recompute semantic results if hybrid is enabled and search is active.
-		if m.semanticHybridEnabled && m.semanticSearchEnabled && m.list.FilterState() != list.Unfiltered {
-			currentTerm := m.list.FilterInput.Value()
+		if m.semanticHybridEnabled && m.semanticActive() && m.list.FilterState() != list.Unfiltered {
+			currentTerm := m.semanticTerm()
 			if currentTerm != "" {
 				m.semanticSearch.ResetCache()
 				cmds = append(cmds, ComputeSemanticFilterCmd(m.semanticSearch, currentTerm))
@@ -1054,25 +1617,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case SemanticFilterResultMsg:
+		// The query could not be embedded: keep fuzzy results for this term
+		if m.semanticSearch != nil && msg.Err != nil {
+			m.semanticSearch.MarkFailed(msg.Term)
+			m.statusMsg = fmt.Sprintf("Semantic query failed, showing fuzzy matches: %v", msg.Err)
+			m.statusIsError = true
+			break
+		}
 		// Async semantic filter results arrived - cache and refresh list
 		if m.semanticSearch != nil && msg.Results != nil {
 			m.semanticSearch.SetCachedResults(msg.Term, msg.Results)
 
 			// Refresh list if still filtering with the same term
-			currentTerm := m.list.FilterInput.Value()
-			if m.semanticSearchEnabled && currentTerm == msg.Term {
+			if m.semanticActive() && m.semanticTerm() == msg.Term {
 				m.applySemanticScores(msg.Term)
 				prevState := m.list.FilterState()
-				m.list.SetFilterText(currentTerm)
+				m.list.SetFilterText(m.list.FilterInput.Value())
 				if prevState == list.Filtering {
 					m.list.SetFilterState(list.Filtering)
 				}
 			}
 		}
 
+	case countTimeoutMsg:
+		// A count not followed by a motion: the tutorial and the board run
+		// the digits as page or column jumps
+		if m.showTutorial {
+			m.tutorialModel, cmd = m.tutorialModel.Update(msg)
+			return m, cmd
+		}
+		if m.keyCount.expired(msg) {
+			m.statusMsg = ""
+			if m.focused == focusBoard {
+				m = m.replayBoardDigits()
+			} else {
+				m.keyCount.take()
+			}
+		}
+		return m, nil
+
 	case semanticDebounceTickMsg:
 		// Debounce timer expired - check if we should trigger semantic computation
-		if m.semanticSearchEnabled && m.semanticSearch != nil && m.list.FilterState() != list.Unfiltered {
+		if m.semanticActive() && m.semanticSearch != nil && m.list.FilterState() != list.Unfiltered {
 			pendingTerm := m.semanticSearch.GetPendingTerm()
 			if pendingTerm != "" && time.Since(m.semanticSearch.GetLastQueryTime()) >= 150*time.Millisecond {
 				return m, ComputeSemanticFilterCmd(m.semanticSearch, pendingTerm)
@@ -1095,20 +1681,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.graphView.SetIssues(m.issues, &ins)
 
 		// Generate triage for priority panel (bv-91) - reuse existing analyzer/stats (bv-runn.12)
-		triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now())
+		triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, analysisScope(m.issues), analysis.TriageOptions{}, time.Now())
 		m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
+		m.insightsPanel.SetHealthScore(&triage.ProjectHealth.Score)
+		if m.lazyLoad {
+			// Startup skipped triage; list badges pick it up in applyFilter below
+			lookups := newTriageLookups(triage)
+			m.triageScores, m.triageReasons = lookups.scores, lookups.reasons
+			m.quickWinSet, m.blockerSet, m.unblocksMap = lookups.quickWins, lookups.blockers, lookups.unblocks
+		}
 
 		// Set full recommendations with breakdown for priority radar (bv-93)
 		dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
 		m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
 
 		// Generate priority recommendations now that Phase 2 is ready
-		recommendations := m.analyzer.GenerateRecommendations()
+		// Dismissed and snoozed suggestions stay hidden
+		recommendations := m.priorityDecisions.Pending(m.analyzer.GenerateRecommendations(), time.Now())
 		m.priorityHints = make(map[string]*analysis.PriorityRecommendation, len(recommendations))
 		for i := range recommendations {
 			m.priorityHints[recommendations[i].IssueID] = &recommendations[i]
 		}
 
+		// Risk uses graph degrees and ETAs use critical path depth
+		m.invalidateRiskScores()
+		m.etaEstimates = nil
+
 		// Refresh alerts now that full Phase 2 metrics (cycles, etc.) are available
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
 
@@ -1148,10 +1746,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Re-apply recipe filter if active (to update scores while preserving filter)
 		// Otherwise, update list respecting current filter (open/ready/etc.)
-		if m.activeRecipe != nil {
-			m.applyRecipe(m.activeRecipe)
+		m.refreshFilteredViews()
+
+		done := fmt.Sprintf("Graph analysis complete for %d issues", len(m.issues))
+		if m.alertsCritical > 0 {
+			cmds = append(cmds, m.toasts.Push(ToastWarning, fmt.Sprintf("%s - %d critical alerts (press !)", done, m.alertsCritical)))
 		} else {
-			m.applyFilter()
+			cmds = append(cmds, m.toasts.Push(ToastInfo, done))
+		}
+
+	case BranchContextLoadedMsg:
+		// Not a git repository, or git is unavailable: nothing to pin
+		if msg.Error == nil {
+			m.setBranchContext(msg.Context)
 		}
 
 	case HistoryLoadedMsg:
@@ -1163,6 +1770,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusIsError = true
 		} else if msg.Report != nil {
 			m.historyView = NewHistoryModel(msg.Report, m.theme)
+			m.historyView.SetFeedbackStore(m.correlationFeedback)
 			m.historyView.SetSize(m.width, m.height-1)
 			// Refresh detail pane if visible
 			if m.isSplitView || m.showDetails {
@@ -1188,6 +1796,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// The live data is read-only while a past snapshot is shown; pick the
+		// change up when returning to the present
+		if m.pastSnapshot != nil {
+			m.pastLiveStale = true
+			if m.watcher != nil {
+				cmds = append(cmds, WatchFileCmd(m.watcher))
+			}
+			return m, tea.Batch(cmds...)
+		}
+
 		// Clear ephemeral overlays tied to old data
 		m.clearAttentionOverlay()
 
@@ -1204,14 +1822,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reload issues from disk
 		// Use custom warning handler to prevent stderr pollution during TUI render (bv-fix)
 		var reloadWarnings []string
-		newIssues, err := loader.LoadIssuesFromFileWithOptions(m.beadsPath, loader.ParseOptions{
+		newIssues, err := m.loadIssuesFromDisk(loader.ParseOptions{
 			WarningHandler: func(msg string) {
 				reloadWarnings = append(reloadWarnings, msg)
 			},
 		})
 		if err != nil {
-			m.statusMsg = fmt.Sprintf("Reload error: %v", err)
-			m.statusIsError = true
+			cmds = append(cmds, m.toasts.Push(ToastError, fmt.Sprintf("Reload failed: %v", err)))
 			// Re-start watch for next change
 			if m.watcher != nil {
 				cmds = append(cmds, WatchFileCmd(m.watcher))
@@ -1219,179 +1836,156 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		// Store selected issue ID to restore position after reload
-		var selectedID string
-		if sel := m.list.SelectedItem(); sel != nil {
-			if item, ok := sel.(IssueItem); ok {
-				selectedID = item.Issue.ID
-			}
+		previous := m.issues
+		cacheHit, reloadCmds := m.replaceIssues(newIssues)
+		cmds = append(cmds, reloadCmds...)
+		m.loadedAt = time.Now()
+		if cmd := m.eventHooksCmd(previous, newIssues); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 
-		// Apply default sorting (Open first, Priority, Date)
-		sort.Slice(newIssues, func(i, j int) bool {
-			iClosed := newIssues[i].Status == model.StatusClosed
-			jClosed := newIssues[j].Status == model.StatusClosed
-			if iClosed != jClosed {
-				return !iClosed
-			}
-			if newIssues[i].Priority != newIssues[j].Priority {
-				return newIssues[i].Priority < newIssues[j].Priority
-			}
-			return newIssues[i].CreatedAt.After(newIssues[j].CreatedAt)
-		})
-
-		// Recompute analysis (async Phase 1/Phase 2) with caching
-		m.issues = newIssues
-		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
-		m.analyzer = cachedAnalyzer.Analyzer
-		m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
-		cacheHit := cachedAnalyzer.WasCacheHit()
-		m.labelHealthCached = false
-		m.attentionCached = false
-
-		// Rebuild lookup map
-		m.issueMap = make(map[string]*model.Issue, len(newIssues))
-		for i := range m.issues {
-			m.issueMap[m.issues[i].ID] = &m.issues[i]
+		reloaded := fmt.Sprintf("Reloaded %d issues", len(newIssues))
+		if cacheHit {
+			reloaded += " (cached)"
 		}
-
-		// Clear stale priority hints (will be repopulated after Phase 2)
-		m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
-
-		// Recompute stats
-		m.countOpen, m.countReady, m.countBlocked, m.countClosed = 0, 0, 0, 0
-		for i := range m.issues {
-			issue := &m.issues[i]
-			if issue.Status == model.StatusClosed {
-				m.countClosed++
-				continue
-			}
-			m.countOpen++
-			if issue.Status == model.StatusBlocked {
-				m.countBlocked++
-				continue
-			}
-			isBlocked := false
-			for _, dep := range issue.Dependencies {
-				if dep == nil || !dep.Type.IsBlocking() {
-					continue
-				}
-				if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
-					isBlocked = true
-					break
-				}
-			}
-			if !isBlocked {
-				m.countReady++
-			}
+		if len(reloadWarnings) > 0 {
+			reloaded += fmt.Sprintf(" with %d warnings: %s", len(reloadWarnings), reloadWarnings[0])
+			cmds = append(cmds, m.toasts.Push(ToastWarning, reloaded))
+		} else {
+			cmds = append(cmds, m.toasts.Push(ToastInfo, reloaded))
 		}
 
-		// Recompute alerts for refreshed dataset
-		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
-		m.dismissedAlerts = make(map[string]bool)
-		m.showAlertsPanel = false
-
-		// Rebuild list items
-		items := make([]list.Item, len(m.issues))
-		for i := range m.issues {
-			items[i] = IssueItem{
-				Issue:      m.issues[i],
-				GraphScore: m.analysis.GetPageRankScore(m.issues[i].ID),
-				Impact:     m.analysis.GetCriticalPathScore(m.issues[i].ID),
-				RepoPrefix: ExtractRepoPrefix(m.issues[i].ID),
-			}
+		// Re-start watching for next change
+		if m.watcher != nil {
+			cmds = append(cmds, WatchFileCmd(m.watcher))
 		}
-		m.updateSemanticIDs(items)
-		m.clearSemanticScores()
-		if m.semanticSearch != nil {
-			m.semanticSearch.ResetCache()
-			m.semanticSearch.SetMetricsCache(nil)
+		return m, tea.Batch(cmds...)
+
+	case IssueChangesLoadedMsg:
+		if m.issueChanges == nil {
+			m.issueChanges = make(map[string]*issueChangesEntry)
 		}
-		m.semanticHybridReady = false
-		m.semanticHybridBuilding = false
-		if m.semanticHybridEnabled {
-			m.semanticHybridBuilding = true
-			cmds = append(cmds, BuildHybridMetricsCmd(m.issues))
+		m.issueChanges[msg.IssueID] = &issueChangesEntry{changes: msg.Changes, err: msg.Error}
+		if m.detailTab == detailTabHistory {
+			m.updateViewportContent()
 		}
-		m.list.SetItems(items)
+		return m, nil
 
-		// Restore selection position
-		if selectedID != "" {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
+	case BlameLoadedMsg:
+		m.blameLoading = false
+		if msg.Error == nil {
+			m.blame = msg.Blame
+			m.blameLoaded = true
+			if m.showDetails || m.isSplitView {
+				m.updateViewportContent()
 			}
 		}
+		return m, nil
 
-		// Regenerate sub-views (with Phase 1 data; Phase 2 will update via Phase2ReadyMsg)
-		ins := m.analysis.GenerateInsights(len(m.issues))
-		m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
-		bodyHeight := m.height - 1
-		if bodyHeight < 5 {
-			bodyHeight = 5
+	case IssueEditedMsg:
+		switch {
+		case msg.Err != nil:
+			return m, m.toasts.Push(ToastError, fmt.Sprintf("Edit of %s not saved: %v", msg.IssueID, msg.Err))
+		case len(msg.Changed) == 0:
+			return m, m.toasts.Push(ToastInfo, fmt.Sprintf("No changes to %s", msg.IssueID))
 		}
-		m.insightsPanel.SetSize(m.width, bodyHeight)
-		m.graphView.SetIssues(m.issues, &ins)
-
-		// Generate priority recommendations now that Phase 2 is ready
-		m.board = NewBoardModel(m.issues, m.theme)
-
-		// Re-apply recipe filter if active
-		if m.activeRecipe != nil {
-			m.applyRecipe(m.activeRecipe)
+		cmds = append(cmds, m.toasts.Push(ToastSuccess, fmt.Sprintf("Saved %s (%s)", msg.IssueID, strings.Join(msg.Changed, ", "))))
+		cmds = append(cmds, m.recordAudit(audit.Entry{Action: audit.ActionEdit, IssueIDs: []string{msg.IssueID}, Changes: msg.Changed}))
+		// The watcher reloads the file; without one, reload directly
+		if m.watcher == nil {
+			cmds = append(cmds, func() tea.Msg { return FileChangedMsg{} })
 		}
+		return m, tea.Batch(cmds...)
 
-		// Reload sprints (bv-161)
-		if m.beadsPath != "" {
-			beadsDir := filepath.Dir(m.beadsPath)
-			if loaded, err := loader.LoadSprintsFromFile(filepath.Join(beadsDir, loader.SprintsFileName)); err == nil {
-				m.sprints = loaded
-				// If we have a selected sprint, try to refresh it
-				if m.selectedSprint != nil {
-					found := false
-					for i := range m.sprints {
-						if m.sprints[i].ID == m.selectedSprint.ID {
-							m.selectedSprint = &m.sprints[i]
-							m.sprintViewText = m.renderSprintDashboard()
-							found = true
-							break
-						}
-					}
-					if !found {
-						m.selectedSprint = nil
-						m.sprintViewText = "Sprint not found"
-					}
-				}
-			}
+	case PrioritiesAppliedMsg:
+		if msg.Err != nil {
+			cmds = append(cmds, m.toasts.Push(ToastError, fmt.Sprintf("Applied %d of %d priority suggestions: %v", len(msg.Applied), msg.Requested, msg.Err)))
+		} else {
+			cmds = append(cmds, m.toasts.Push(ToastSuccess, fmt.Sprintf("Applied %d priority suggestion(s): %s", len(msg.Applied), strings.Join(msg.Applied, ", "))))
 		}
-
-		// Keep semantic index current when enabled.
-		if m.semanticSearchEnabled && !m.semanticIndexBuilding {
-			m.semanticIndexBuilding = true
-			cmds = append(cmds, BuildSemanticIndexCmd(m.issues))
+		if len(msg.Applied) > 0 {
+			cmds = append(cmds, m.recordAudit(audit.Entry{Action: audit.ActionPriority, IssueIDs: msg.Applied, Changes: []string{"priority"}}))
+		}
+		// The watcher reloads the file; without one, reload directly
+		if len(msg.Applied) > 0 && m.watcher == nil {
+			cmds = append(cmds, func() tea.Msg { return FileChangedMsg{} })
 		}
+		return m, tea.Batch(cmds...)
 
-		if cacheHit {
-			m.statusMsg = fmt.Sprintf("Reloaded %d issues (cached)", len(newIssues))
-		} else {
-			m.statusMsg = fmt.Sprintf("Reloaded %d issues", len(newIssues))
+	case DependenciesSavedMsg:
+		if msg.Err != nil {
+			return m, m.toasts.Push(ToastError, fmt.Sprintf("Dependencies of %s not saved: %v", msg.IssueID, msg.Err))
 		}
-		if len(reloadWarnings) > 0 {
-			m.statusMsg += fmt.Sprintf(" (%d warnings)", len(reloadWarnings))
+		cmds = append(cmds, m.toasts.Push(ToastSuccess, fmt.Sprintf("Saved %s (%s)", msg.IssueID, strings.Join(msg.Changes, ", "))))
+		cmds = append(cmds, m.recordAudit(audit.Entry{Action: audit.ActionDependencies, IssueIDs: []string{msg.IssueID}, Changes: msg.Changes}))
+		// The watcher reloads the file; without one, reload directly
+		if m.watcher == nil {
+			cmds = append(cmds, func() tea.Msg { return FileChangedMsg{} })
 		}
-		m.statusIsError = false
-		// Invalidate label-derived caches
-		m.labelHealthCached = false
-		m.labelDrilldownCache = make(map[string][]model.Issue)
-		m.updateViewportContent()
+		return m, tea.Batch(cmds...)
 
-		// Re-start watching for next change + wait for Phase 2
-		if m.watcher != nil {
-			cmds = append(cmds, WatchFileCmd(m.watcher))
+	case CassTranscriptLoadedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Transcript: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
 		}
-		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
+		if len(msg.Transcript.Messages) == 0 {
+			m.statusMsg = "Session transcript is empty"
+			m.statusIsError = false
+			return m, nil
+		}
+		m.cassTranscript = NewCassTranscriptModel(msg.Session, msg.Transcript, m.theme)
+		m.cassTranscript.SetSize(m.width, m.height-1)
+		m.showCassModal = false
+		m.showCassTranscript = true
+		m.focused = focusCassTranscript
+		m.statusMsg = ""
+		return m, nil
+
+	case MergeResolvedMsg:
+		if msg.Err != nil {
+			return m, m.toasts.Push(ToastError, fmt.Sprintf("Merge not written: %v", msg.Err))
+		}
+		var artifacts []string
+		for _, a := range msg.Artifacts {
+			artifacts = append(artifacts, filepath.Base(a))
+		}
+		cmds = append(cmds, m.toasts.Push(ToastSuccess, fmt.Sprintf("Wrote merged %s (%d from artifacts) - remove %s when done",
+			filepath.Base(m.beadsPath), msg.Resolved, strings.Join(artifacts, ", "))))
+		cmds = append(cmds, m.recordAudit(audit.Entry{Action: audit.ActionMerge,
+			Changes: append([]string{fmt.Sprintf("%d resolved from artifacts", msg.Resolved)}, artifacts...)}))
+		if m.watcher == nil {
+			cmds = append(cmds, func() tea.Msg { return FileChangedMsg{} })
+		}
+		return m, tea.Batch(cmds...)
+
+	case IssueRenamedMsg:
+		if msg.Err != nil {
+			return m, m.toasts.Push(ToastError, fmt.Sprintf("%s not renamed: %v", msg.OldID, msg.Err))
+		}
+		cmds = append(cmds, m.showRenamedIssue(msg.OldID, msg.NewID)...)
+		cmds = append(cmds, m.toasts.Push(ToastSuccess, fmt.Sprintf("Renamed %s to %s (%d line(s) rewritten)", msg.OldID, msg.NewID, msg.Lines)))
+		cmds = append(cmds, m.recordAudit(audit.Entry{Action: audit.ActionRename, IssueIDs: []string{msg.OldID, msg.NewID},
+			Changes: []string{fmt.Sprintf("%s renamed to %s (%d line(s))", msg.OldID, msg.NewID, msg.Lines)}}))
+		return m, tea.Batch(cmds...)
+
+	case IssueCreatedMsg:
+		if msg.Err != nil {
+			return m, m.toasts.Push(ToastError, fmt.Sprintf("Issue from template %s not created: %v", msg.Template, msg.Err))
+		}
+		cmds = append(cmds, m.showCreatedIssue(msg.Issue)...)
+		cmds = append(cmds, m.toasts.Push(ToastSuccess, fmt.Sprintf("Created %s from template %s", msg.Issue.ID, msg.Template)))
+		cmds = append(cmds, m.recordAudit(audit.Entry{Action: audit.ActionCreate, IssueIDs: []string{msg.Issue.ID}, Changes: []string{"template " + msg.Template}}))
+		return m, tea.Batch(cmds...)
+
+	case PastSnapshotLoadedMsg:
+		if msg.Error != nil {
+			m.statusMsg = fmt.Sprintf("❌ Cannot load %s: %v", msg.Snapshot.ShortSHA, msg.Error)
+			m.statusIsError = true
+			return m, nil
+		}
+		cmds = append(cmds, m.enterPastSnapshot(msg.Snapshot, msg.Issues)...)
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
@@ -1399,6 +1993,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = ""
 		m.statusIsError = false
 
+		// Command palette: the query takes every key
+		if m.showPalette {
+			return m.handlePaletteKeys(msg)
+		}
+
 		// Handle AGENTS.md prompt modal (bv-i8dk)
 		if m.showAgentPrompt {
 			m.agentPromptModal, cmd = m.agentPromptModal.Update(msg)
@@ -1438,8 +2037,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 
 			// Check for dismiss keys
-			switch msg.String() {
-			case "V", "esc", "enter", "q":
+			switch key := msg.String(); key {
+			case "enter":
+				cmds = append(cmds, m.openCassTranscript())
+				return m, tea.Batch(cmds...)
+			case "C", "X", "I":
+				m.cassSessionFeedback(correlationFeedbackKeys[key])
+				return m, tea.Batch(cmds...)
+			case "V", "esc", "q":
 				m.showCassModal = false
 				m.focused = focusList
 				return m, tea.Batch(cmds...)
@@ -1447,6 +2052,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Handle release notes of a newly detected version
+		if m.showReleaseNotes {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleReleaseNotesKeys(msg)
+		}
+
 		// Handle self-update modal (bv-182)
 		if m.showUpdateModal {
 			m.updateModal, cmd = m.updateModal.Update(msg)
@@ -1576,6 +2189,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Attention digest modal: navigate and jump before global keys
+		if m.showDigestPanel {
+			return m.handleDigestPanelKeys(msg)
+		}
+
+		// Snooze prompt: the entry takes every key
+		if m.showSnoozePrompt {
+			return m.handleSnoozePromptKeys(msg)
+		}
+
+		// Rename prompt: the entry takes every key
+		if m.showRenamePrompt {
+			return m.handleRenamePromptKeys(msg)
+		}
+
+		// Priority review modal: decide on suggestions before global keys
+		if m.showPriorityReview {
+			return m.handlePriorityReviewKeys(msg)
+		}
+
+		// Completion forecast modal
+		if m.showForecast {
+			return m.handleForecastKeys(msg)
+		}
+
+		// Effort rollup modal
+		if m.showEffortRollup {
+			return m.handleEffortRollupKeys(msg)
+		}
+
+		// Unblock queue modal
+		if m.showUnblockQueue {
+			return m.handleUnblockQueueKeys(msg)
+		}
+
+		// Audit log modal
+		if m.showAuditLog {
+			return m.handleAuditLogKeys(msg)
+		}
+
+		// Search results pane
+		if m.showSearchResults {
+			return m.handleSearchResultsKeys(msg)
+		}
+
+		// Blocker menu
+		if m.showBlockerMenu {
+			return m.handleBlockerMenuKeys(msg)
+		}
+
+		// Startup diagnostics modal
+		if m.showDiagnostics {
+			return m.handleDiagnosticsKeys(msg)
+		}
+
+		// Notification history modal
+		if m.showNotifications {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleNotificationKeys(msg)
+		}
+
 		// Handle alerts panel modal if open (bv-168)
 		if m.showAlertsPanel {
 			// Build list of active (non-dismissed) alerts
@@ -1603,12 +2279,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					issueID := activeAlerts[m.alertsCursor].IssueID
 					if issueID != "" {
 						// Find the issue in the list and select it
-						for i, item := range m.list.Items() {
-							if it, ok := item.(IssueItem); ok && it.Issue.ID == issueID {
-								m.list.Select(i)
-								break
-							}
-						}
+						m.jumpToIssue(issueID)
 					}
 				}
 				m.showAlertsPanel = false
@@ -1653,6 +2324,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle cass transcript viewer before global keys (it has a search input)
+		if m.showCassTranscript {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleCassTranscriptKeys(msg)
+		}
+
+		// Handle merge assist overlay before global keys (digits pick versions)
+		if m.showMergeAssist {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleMergeAssistKeys(msg)
+		}
+
+		// Handle dependency editor overlay before global keys (it has a search input)
+		if m.showDepEditor {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleDependencyEditorKeys(msg)
+		}
+
+		// Handle template picker overlay before global keys
+		if m.showTemplatePicker {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleTemplatePickerKeys(msg)
+		}
+
 		// Handle recipe picker overlay before global keys (esc/q/etc.)
 		if m.showRecipePicker {
 			if msg.String() == "ctrl+c" {
@@ -1674,6 +2377,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Command palette (Ctrl+P): every action of this view, tutorial
+		// pages and issues by ID
+		if msg.String() == "ctrl+p" && m.list.FilterState() != list.Filtering && m.focused != focusHelp && !m.showTutorial {
+			m.openCommandPalette()
+			return m, nil
+		}
+
 		// Handle help overlay toggle (? or F1)
 		if (msg.String() == "?" || msg.String() == "f1") && m.list.FilterState() != list.Filtering {
 			m.showHelp = !m.showHelp
@@ -1703,7 +2413,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle shortcuts sidebar toggle (; or F2) - bv-3qi5
 		if (msg.String() == ";" || msg.String() == "f2") && m.list.FilterState() != list.Filtering {
 			m.showShortcutsSidebar = !m.showShortcutsSidebar
-			if m.showShortcutsSidebar {
+			if m.showShortcutsSidebar && m.width < CompactViewThreshold {
+				m.statusMsg = "Shortcuts sidebar: hidden until the terminal is wider (; to turn off)"
+				m.statusIsError = false
+			} else if m.showShortcutsSidebar {
 				m.shortcutsSidebar.ResetScroll()
 				m.statusMsg = "Shortcuts sidebar: ; hide | ctrl+j/k scroll"
 				m.statusIsError = false
@@ -1713,8 +2426,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Count prefixes: digits before a motion repeat it (5j, 10ctrl+d)
+		if motions, ok := countableKeys[m.focused]; ok && m.list.FilterState() != list.Filtering &&
+			!(m.focused == focusBoard && m.board.IsSearchMode()) {
+			key := msg.String()
+			if m.keyCount.feed(key) {
+				m.statusMsg = "Count: " + m.keyCount.String()
+				if m.focused == focusBoard {
+					// 1-4 jump to columns unless a motion follows
+					return m, m.keyCount.timeout()
+				}
+				return m, nil
+			}
+			if m.keyCount.pending() {
+				switch {
+				case motions[key]:
+					m = m.repeatMotion(msg, m.keyCount.take()-1)
+				case m.focused == focusBoard:
+					m = m.replayBoardDigits()
+				default:
+					m.keyCount.take()
+				}
+			}
+		}
+
 		// Handle shortcuts sidebar scrolling (Ctrl+j/k when sidebar visible) - bv-3qi5
-		if m.showShortcutsSidebar && m.list.FilterState() != list.Filtering {
+		if m.shortcutsSidebarShown() && m.list.FilterState() != list.Filtering {
 			switch msg.String() {
 			case "ctrl+j":
 				m.shortcutsSidebar.ScrollDown()
@@ -1725,6 +2462,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Search bar history and the search results pane
+		if m.focused == focusList {
+			switch key := msg.String(); {
+			case key == "ctrl+f" && m.list.FilterState() != list.Unfiltered:
+				m.openSearchResults()
+				return m, nil
+			case m.list.FilterState() != list.Filtering:
+			case key == "tab" && len(m.labelSuggestions()) > 0:
+				suggestions := m.labelSuggestions()
+				m.completeLabel(suggestions[min(m.labelSuggestIdx, len(suggestions)-1)])
+				return m, nil
+			case (key == "ctrl+n" || key == "ctrl+p") && len(m.labelSuggestions()) > 0:
+				n := len(m.labelSuggestions())
+				if key == "ctrl+n" {
+					m.labelSuggestIdx = (min(m.labelSuggestIdx, n-1) + 1) % n
+				} else {
+					m.labelSuggestIdx = (min(m.labelSuggestIdx, n-1) + n - 1) % n
+				}
+				return m, nil
+			case key == "up":
+				m.recallSearch(+1)
+				return m, nil
+			case key == "down":
+				m.recallSearch(-1)
+				return m, nil
+			case key == "esc":
+				m.searchHistoryPos = -1
+			case key == "enter" || key == "tab" || key == "ctrl+j" || key == "ctrl+k":
+				m.rememberSearch(m.list.FilterInput.Value())
+			default:
+				m.labelSuggestIdx = 0 // the word changes
+			}
+		}
+
 		// Hybrid search toggle/preset cycle (bv-xbar.6)
 		if m.focused == focusList && m.list.FilterState() != list.Filtering {
 			switch msg.String() {
@@ -1749,8 +2520,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					m.statusMsg = "Semantic search: text-only"
 				}
-				if m.semanticSearchEnabled && m.list.FilterState() != list.Unfiltered {
-					currentTerm := m.list.FilterInput.Value()
+				if m.semanticActive() && m.list.FilterState() != list.Unfiltered {
+					currentTerm := m.semanticTerm()
 					if currentTerm != "" && !m.semanticHybridBuilding {
 						cmds = append(cmds, ComputeSemanticFilterCmd(m.semanticSearch, currentTerm))
 					}
@@ -1770,8 +2541,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					m.statusMsg = fmt.Sprintf("Hybrid preset set (%s)", m.semanticHybridPreset)
 				}
-				if m.semanticSearchEnabled && m.semanticHybridEnabled && m.list.FilterState() != list.Unfiltered {
-					currentTerm := m.list.FilterInput.Value()
+				if m.semanticActive() && m.semanticHybridEnabled && m.list.FilterState() != list.Unfiltered {
+					currentTerm := m.semanticTerm()
 					if currentTerm != "" && !m.semanticHybridBuilding {
 						cmds = append(cmds, ComputeSemanticFilterCmd(m.semanticSearch, currentTerm))
 					}
@@ -1799,7 +2570,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				} else {
 					m.semanticSearchEnabled = false
-					m.list.Filter = list.DefaultFilter
+					m.list.Filter = m.fuzzyFilter()
 					m.statusMsg = "Semantic search unavailable"
 					m.statusIsError = true
 				}
@@ -1808,8 +2579,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, BuildHybridMetricsCmd(m.issues))
 				}
 			} else {
-				m.list.Filter = list.DefaultFilter
-				m.statusMsg = "Fuzzy search enabled"
+				m.list.Filter = m.fuzzyFilter()
+				m.statusMsg = "Fuzzy search enabled (~query for semantic)"
 				m.clearSemanticScores()
 			}
 
@@ -1856,6 +2627,160 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Historical snapshots: t in history browses the selected commit,
+		// t in the list/board/graph returns to the present
+		if msg.String() == "t" && m.list.FilterState() != list.Filtering {
+			if m.focused == focusHistory && !m.historyView.IsSearchActive() && !m.historyView.FileTreeHasFocus() {
+				snapshot, ok := m.selectedHistorySnapshot()
+				if !ok {
+					m.statusMsg = "❌ No commit selected"
+					m.statusIsError = true
+					return m, nil
+				}
+				m.statusMsg = fmt.Sprintf("⏪ Loading beads at %s…", snapshot.ShortSHA)
+				m.statusIsError = false
+				return m, LoadPastSnapshotCmd(m.beadsPath, snapshot)
+			}
+			if m.pastSnapshot != nil && (m.focused == focusList || m.focused == focusBoard || m.focused == focusGraph) {
+				return m, tea.Batch(m.exitPastSnapshot()...)
+			}
+		}
+
+		// Linked issues in the detail view: n/N select a reference, gd opens it
+		if m.focused == focusDetail && m.detailTab == detailTabInfo && len(m.detailRefs) > 0 && m.list.FilterState() != list.Filtering {
+			if m.waitingForGoto {
+				m.waitingForGoto = false
+				if msg.String() == "d" {
+					m.gotoIssueRef()
+					return m, nil
+				}
+				// Any other key cancels the chord and is handled normally
+			} else {
+				switch msg.String() {
+				case "g":
+					m.waitingForGoto = true
+					m.previewIssueRef()
+					return m, nil
+				case "n":
+					m.cycleIssueRef(1)
+					return m, nil
+				case "N":
+					m.cycleIssueRef(-1)
+					return m, nil
+				}
+			}
+		}
+
+		// Attachments in the detail view: u/U select one, @ opens it; <
+		// lists the issue's open blockers to jump to
+		if m.focused == focusDetail && m.detailTab == detailTabInfo && m.list.FilterState() != list.Filtering {
+			switch msg.String() {
+			case "<":
+				m.openBlockerMenu()
+				return m, nil
+			case "@":
+				m.openAttachment()
+				return m, nil
+			case "u":
+				m.cycleAttachment(1)
+				return m, nil
+			case "U":
+				m.cycleAttachment(-1)
+				return m, nil
+			}
+		}
+
+		// Clipboard: y copies the ID, C Markdown, J JSON, B a bd command
+		if format, ok := copyFormatKeys[msg.String()]; ok && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.copySelectedIssue(format)
+			return m, nil
+		}
+
+		// I copies the impact dossier of the selected issue as Markdown
+		if msg.String() == "I" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			return m, m.copyDossierCmd()
+		}
+
+		// O edits the selected issue in $EDITOR (the TUI is suspended meanwhile)
+		if msg.String() == "O" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			return m, m.editIssueCmd()
+		}
+
+		// ctrl+r renames the selected issue, rewriting references to it
+		if msg.String() == "ctrl+r" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openRenamePrompt()
+			return m, nil
+		}
+
+		// * stars or unstars the selected issue
+		if msg.String() == "*" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.toggleStar()
+			return m, nil
+		}
+
+		// e edits the selected issue's private note in $EDITOR
+		if msg.String() == "e" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			return m, m.editNoteCmd()
+		}
+
+		// Z snoozes the selected issue locally, or wakes it
+		if msg.String() == "Z" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openSnoozePrompt()
+			return m, nil
+		}
+
+		// X shows or hides deleted issues (reloads from disk)
+		if msg.String() == "X" && m.list.FilterState() != list.Filtering && m.focused == focusList {
+			return m, tea.Batch(m.toggleShowDeleted()...)
+		}
+
+		// Ctrl+A shows or hides archived issues (reloads from disk)
+		if msg.String() == "ctrl+a" && m.list.FilterState() != list.Filtering && m.focused == focusList {
+			return m, tea.Batch(m.toggleShowArchived()...)
+		}
+
+		// M resolves merge artifacts left next to the beads file
+		if msg.String() == "M" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openMergeAssist()
+			return m, nil
+		}
+
+		// P reviews the priority suggestions: accept, dismiss or snooze
+		if msg.String() == "P" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openPriorityReview()
+			return m, nil
+		}
+
+		// F forecasts when the backlog, or an epic, will be done
+		if msg.String() == "F" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openCompletionForecast()
+			return m, nil
+		}
+
+		// R totals the estimates per epic, label and assignee
+		if msg.String() == "R" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openEffortRollup()
+			return m, nil
+		}
+
+		// Q ranks the open blockers by how much work closing each one frees
+		if msg.String() == "Q" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openUnblockQueue()
+			return m, nil
+		}
+
+		// D edits the selected issue's dependencies
+		if msg.String() == "D" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openDependencyEditor()
+			return m, nil
+		}
+
+		// + creates a new issue from a template in .beads/templates/
+		if msg.String() == "+" && m.list.FilterState() != list.Filtering && (m.focused == focusList || m.focused == focusDetail) {
+			m.openTemplatePicker()
+			return m, nil
+		}
+
 		// Handle keys when not filtering
 		if m.list.FilterState() != list.Filtering {
 			switch msg.String() {
@@ -1960,6 +2885,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					} else {
 						m.focused = focusList
 					}
+				} else if m.focused == focusList || m.focused == focusDetail {
+					// Terminals send Ctrl+i as tab: outside the split view it
+					// moves forward in the jump list, as in vim
+					m.navigateHistory(1)
+					return m, nil
+				}
+
+			case "ctrl+o", "alt+left":
+				// Jump list: back to the previously visited issue
+				if m.focused == focusList || m.focused == focusDetail {
+					m.navigateHistory(-1)
+					return m, nil
+				}
+
+			case "ctrl+]", "alt+right":
+				// Jump list: forward again (Ctrl+i is tab in the split view)
+				if m.focused == focusList || m.focused == focusDetail {
+					m.navigateHistory(1)
+					return m, nil
+				}
+
+			case "ctrl+h", "ctrl+l":
+				// Move the split divider: shrink or grow the list pane
+				if m.focused == focusList || m.focused == focusDetail {
+					delta := SplitRatioStep
+					if msg.String() == "ctrl+h" {
+						delta = -delta
+					}
+					m.adjustSplitRatio(delta)
+					return m, nil
+				}
+
+			case "|":
+				// Cycle split orientation: auto → side by side → stacked
+				if m.focused == focusList || m.focused == focusDetail {
+					m.cycleSplitOrientation()
+					return m, nil
 				}
 
 			case "b":
@@ -1969,6 +2931,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.isActionableView = false
 				m.isHistoryView = false
 				if m.isBoardView {
+					if m.boardPending {
+						m.refreshFilteredViews()
+					}
 					m.focused = focusBoard
 				} else {
 					m.focused = focusList
@@ -2023,8 +2988,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						ins := m.analysis.GenerateInsights(len(m.issues))
 						m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
 						// Include priority triage (bv-91) - reuse existing analyzer/stats (bv-runn.12)
-						triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now())
+						triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, analysisScope(m.issues), analysis.TriageOptions{}, time.Now())
 						m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
+						m.insightsPanel.SetHealthScore(&triage.ProjectHealth.Score)
 						// Set full recommendations with breakdown for priority radar (bv-93)
 						dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
 						m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
@@ -2037,6 +3003,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case "m":
+				// Toggle the risk heatmap (list, details and board)
+				if m.focused == focusList || m.focused == focusDetail || (m.focused == focusBoard && !m.board.IsSearchMode()) {
+					m.toggleRiskHeatmap()
+					return m, nil
+				}
+
+			case "A":
+				// Attention digest: stale, long-blocked and priority-inverted issues
+				if m.focused != focusBoard || !m.board.IsSearchMode() {
+					m.openAttentionDigest()
+					return m, nil
+				}
+
 			case "p":
 				// Toggle priority hints
 				m.showPriorityHints = !m.showPriorityHints
@@ -2156,6 +3136,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case "E":
+				// Notification history
+				m.showNotificationHistory()
+				return m, nil
+
+			case "Y":
+				// Writes made through bv
+				m.showAuditLogModal()
+				return m, nil
+
+			case "ctrl+g":
+				// Phase timings and tuning suggestions
+				return m, m.openStartupDiagnostics()
+
 			case "'", "f5":
 				// Toggle recipe picker overlay
 				m.showRecipePicker = !m.showRecipePicker
@@ -2178,6 +3172,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.showRepoPicker {
 					m.repoPicker = NewRepoPickerModel(m.availableRepos, m.theme)
 					m.repoPicker.SetActiveRepos(m.activeRepos)
+					m.repoPicker.SetRepoStatus(m.workspaceRepos)
 					m.repoPicker.SetSize(m.width, m.height-1)
 					m.focused = focusRepoPicker
 				} else {
@@ -2219,7 +3214,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.handleLabelPickerKeys(msg)
 
 			case focusInsights:
-				m = m.handleInsightsKeys(msg)
+				m, cmd = m.handleInsightsKeys(msg)
+				cmds = append(cmds, cmd)
 
 			case focusBoard:
 				m = m.handleBoardKeys(msg)
@@ -2275,7 +3271,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.handleListKeys(msg)
 
 			case focusDetail:
-				m.viewport, cmd = m.viewport.Update(msg)
+				if msg.String() == "v" {
+					m.toggleDetailTab()
+				} else {
+					m.viewport, cmd = m.viewport.Update(msg)
+					cmds = append(cmds, cmd)
+				}
+			}
+
+			// Fetch the selected issue's change timeline when its tab is showing
+			if cmd := m.issueChangesCmd(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			// Fetch last-modifier attribution once details are visible
+			if cmd := m.blameCmd(); cmd != nil {
 				cmds = append(cmds, cmd)
 			}
 		}
@@ -2342,53 +3351,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.isSplitView = msg.Width > SplitViewThreshold
 		m.ready = true
-		bodyHeight := m.height - 1 // keep 1 row for footer
-		if bodyHeight < 5 {
-			bodyHeight = 5
+		m.resizePanes()
+		if m.showReleaseNotes {
+			m.releaseNotes.SetSize(m.width, m.height-1)
 		}
-
-		if m.isSplitView {
-			// Calculate dimensions accounting for 2 panels with borders(2)+padding(2) = 4 overhead each
-			// Total overhead = 8
-			availWidth := msg.Width - 8
-			if availWidth < 10 {
-				availWidth = 10
-			}
-
-			listInnerWidth := int(float64(availWidth) * 0.4)
-			detailInnerWidth := availWidth - listInnerWidth
-
-			// listHeight fits header (1) + page line (1) inside a panel with Border (2)
-			listHeight := bodyHeight - 4
-			if listHeight < 3 {
-				listHeight = 3
-			}
-
-			m.list.SetSize(listInnerWidth, listHeight)
-			m.viewport = viewport.New(detailInnerWidth, bodyHeight-2) // Account for border
-
-			m.renderer.SetWidthWithTheme(detailInnerWidth, m.theme)
-		} else {
-			listHeight := bodyHeight - 2
-			if listHeight < 3 {
-				listHeight = 3
-			}
-			m.list.SetSize(msg.Width, listHeight)
-			m.viewport = viewport.New(msg.Width, bodyHeight-1)
-
-			// Update renderer for full width
-			m.renderer.SetWidthWithTheme(msg.Width, m.theme)
+		if m.showTutorial {
+			m.tutorialModel.SetSize(m.width, m.height)
 		}
-
-		m.updateListDelegate()
-
-		// Resize label dashboard table and modal overlay sizing
-		m.labelDashboard.SetSize(m.width, bodyHeight)
-
-		m.insightsPanel.SetSize(m.width, bodyHeight)
-		m.updateViewportContent()
 	}
 
 	// Update list for navigation, but NOT for WindowSizeMsg
@@ -2403,13 +3373,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		currentTerm := m.list.FilterInput.Value()
 		if currentTerm != m.lastSearchTerm {
 			m.lastSearchTerm = currentTerm
-			if m.semanticSearchEnabled {
+			if m.semanticActive() {
 				m.clearSemanticScores()
 			}
 		}
-		if m.semanticSearchEnabled && m.semanticHybridEnabled && m.list.FilterState() != list.Unfiltered {
-			if strings.TrimSpace(currentTerm) != "" {
-				m.applySemanticScores(currentTerm)
+		if m.semanticActive() && m.semanticHybridEnabled && m.list.FilterState() != list.Unfiltered {
+			if term := m.semanticTerm(); term != "" {
+				m.applySemanticScores(term)
 			}
 		}
 		m.updateListDelegate()
@@ -2420,8 +3390,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateViewportContent()
 	}
 
+	// A ~query in fuzzy mode builds the semantic index on first use
+	if !m.semanticSearchEnabled && m.semanticActive() && m.semanticSearch != nil &&
+		!m.semanticSearch.Snapshot().Ready && !m.semanticIndexBuilding && m.semanticIndexErr == nil {
+		m.semanticIndexBuilding = true
+		m.statusMsg = "Semantic search: building index…"
+		m.statusIsError = false
+		cmds = append(cmds, BuildSemanticIndexCmd(m.issues))
+	}
+
 	// Trigger async semantic computation if needed (debounced)
-	if m.semanticSearchEnabled && m.semanticSearch != nil && m.list.FilterState() != list.Unfiltered {
+	if m.semanticActive() && m.semanticSearch != nil && m.list.FilterState() != list.Unfiltered {
 		pendingTerm := m.semanticSearch.GetPendingTerm()
 		if pendingTerm != "" {
 			// Debounce: only compute if 150ms since last query change
@@ -2439,6 +3418,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// repeatMotion makes n extra moves for a counted motion key; the key's
+// normal handling then makes the last one
+func (m Model) repeatMotion(msg tea.KeyMsg, n int) Model {
+	for range n {
+		switch m.focused {
+		case focusBoard:
+			m = m.handleBoardKeys(msg)
+		case focusGraph:
+			m = m.handleGraphKeys(msg)
+		case focusList:
+			switch msg.String() {
+			case "j", "down":
+				m.list.CursorDown()
+			case "k", "up":
+				m.list.CursorUp()
+			default:
+				m = m.handleListKeys(msg)
+			}
+		}
+	}
+	return m
+}
+
+// replayBoardDigits runs the digits of a count that no motion followed as
+// ordinary board keys, so a lone 1-4 still jumps to a column
+func (m Model) replayBoardDigits() Model {
+	for _, key := range m.keyCount.takeDigits() {
+		m = m.handleBoardKeys(key)
+	}
+	return m
+}
+
 // handleBoardKeys handles keyboard input when the board is focused (bv-yg39)
 func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 	key := msg.String()
@@ -2541,7 +3552,7 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 	// Copy ID to clipboard (bv-yg39)
 	case "y":
 		if selected := m.board.SelectedIssue(); selected != nil {
-			if err := clipboard.WriteAll(selected.ID); err != nil {
+			if _, err := copyText(selected.ID); err != nil {
 				m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
 				m.statusIsError = true
 			} else {
@@ -2599,6 +3610,10 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 	// Detail panel (bv-r6kh)
 	case "tab":
 		m.board.ToggleDetail()
+		if m.board.IsDetailShown() && m.width < boardDetailMinWidth {
+			m.statusMsg = fmt.Sprintf("Detail panel needs a terminal at least %d columns wide", boardDetailMinWidth)
+			m.statusIsError = false
+		}
 	case "ctrl+j":
 		if m.board.IsDetailShown() {
 			m.board.DetailScrollDown(3)
@@ -2611,12 +3626,7 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 	// Exit to detail view
 	case "enter":
 		if selected := m.board.SelectedIssue(); selected != nil {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selected.ID {
-					m.list.Select(i)
-					break
-				}
-			}
+			m.jumpToIssue(selected.ID)
 			m.isBoardView = false
 			m.focused = focusList
 			if m.isSplitView {
@@ -2651,15 +3661,26 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
-	case "enter":
-		if selected := m.graphView.SelectedIssue(); selected != nil {
+	case "+", "=":
+		m.graphView.ZoomIn()
+		m.statusMsg = fmt.Sprintf("Graph zoom: %s", m.graphView.Zoom())
+		m.statusIsError = false
+	case "-":
+		m.graphView.ZoomOut()
+		m.statusMsg = fmt.Sprintf("Graph zoom: %s", m.graphView.Zoom())
+		m.statusIsError = false
+	case "c":
+		m.graphView.ToggleClusters()
+		if m.graphView.ShowingClusters() {
+			m.statusMsg = fmt.Sprintf("Grouped by %d clusters", m.graphView.ClusterCount())
+		} else {
+			m.statusMsg = "Cluster grouping off"
+		}
+		m.statusIsError = false
+	case "enter":
+		if selected := m.graphView.SelectedIssue(); selected != nil {
 			// Find and select in list
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selected.ID {
-					m.list.Select(i)
-					break
-				}
-			}
+			m.jumpToIssue(selected.ID)
 			m.isGraphView = false
 			m.focused = focusList
 			if m.isSplitView {
@@ -2686,12 +3707,7 @@ func (m Model) handleActionableKeys(msg tea.KeyMsg) Model {
 		// Jump to selected issue in list view
 		selectedID := m.actionableView.SelectedIssueID()
 		if selectedID != "" {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
-			}
+			m.jumpToIssue(selectedID)
 			m.isActionableView = false
 			m.focused = focusList
 			if m.isSplitView {
@@ -2846,12 +3862,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			selectedID = m.historyView.SelectedBeadID()
 		}
 		if selectedID != "" {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
-			}
+			m.jumpToIssue(selectedID)
 			m.isHistoryView = false
 			m.focused = focusList
 			if m.isSplitView {
@@ -2878,7 +3889,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			}
 		}
 		if sha != "" {
-			if err := clipboard.WriteAll(sha); err != nil {
+			if _, err := copyText(sha); err != nil {
 				m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
 				m.statusIsError = true
 			} else {
@@ -2901,6 +3912,9 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			}
 			m.statusIsError = false
 		}
+	case "C", "X", "I":
+		// Confirm/reject/ignore the selected commit ↔ bead correlation
+		m.historyFeedback(correlationFeedbackKeys[msg.String()])
 	case "f", "F":
 		// Toggle file tree panel (bv-190l)
 		m.historyView.ToggleFileTree()
@@ -2955,12 +3969,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 		}
 		if selectedID != "" {
 			// Find and select the bead in the main list
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
-			}
+			m.jumpToIssue(selectedID)
 			// Switch to graph view focused on this bead
 			m.isHistoryView = false
 			m.graphView.SelectByID(selectedID)
@@ -3068,12 +4077,7 @@ func (m Model) handleFlowMatrixKeys(msg tea.KeyMsg) Model {
 		if m.flowMatrix.showDrilldown {
 			// Jump to selected issue from drilldown
 			if selectedIssue := m.flowMatrix.SelectedDrilldownIssue(); selectedIssue != nil {
-				for i, item := range m.list.Items() {
-					if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedIssue.ID {
-						m.list.Select(i)
-						break
-					}
-				}
+				m.jumpToIssue(selectedIssue.ID)
 				m.focused = focusList
 				if m.isSplitView {
 					m.focused = focusDetail
@@ -3144,6 +4148,8 @@ func (m Model) handleRepoPickerKeys(msg tea.KeyMsg) Model {
 			m.statusMsg = fmt.Sprintf("Repo filter: %s", formatRepoList(sortedRepoKeys(selected), 3))
 		}
 		m.statusIsError = false
+		// Remembered per workspace; a failed write only affects the next session
+		_ = saveWorkspaceRepoSelection(m.workspaceState, m.activeRepos)
 
 		// Apply filter to views
 		if m.activeRecipe != nil {
@@ -3185,7 +4191,7 @@ func (m Model) handleLabelPickerKeys(msg tea.KeyMsg) Model {
 }
 
 // handleInsightsKeys handles keyboard input when insights panel is focused
-func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
+func (m Model) handleInsightsKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.focused = focusList
@@ -3212,16 +4218,18 @@ func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
 	case "m":
 		// Toggle heatmap view (bv-95) - "m" for heatMap
 		m.insightsPanel.ToggleHeatmap()
+	case " ":
+		// Expand the selected priority pick inline
+		m.insightsPanel.TogglePickExpanded()
+	case "P":
+		if id := m.insightsPanel.ExpandedPickID(); id != "" {
+			return m, m.applySuggestedPriorityCmd(id)
+		}
 	case "enter":
 		// Jump to selected issue in list view
 		selectedID := m.insightsPanel.SelectedIssueID()
 		if selectedID != "" {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
-			}
+			m.jumpToIssue(selectedID)
 			m.focused = focusList
 			if m.isSplitView {
 				m.focused = focusDetail
@@ -3233,13 +4241,15 @@ func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
 			m.updateViewportContent()
 		}
 	}
-	return m
+	return m, nil
 }
 
 // handleListKeys handles keyboard input when the list is focused
 func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
 	case "enter":
+		// Opening an issue (e.g. a search result) is a jump
+		m.nav.visit(m.selectedIssueID())
 		if !m.isSplitView {
 			m.showDetails = true
 			m.focused = focusDetail
@@ -3285,6 +4295,12 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "a":
 		m.currentFilter = "all"
 		m.applyFilter()
+	case "z":
+		m.currentFilter = "starred"
+		m.applyFilter()
+	case "W":
+		m.currentFilter = "snoozed"
+		m.applyFilter()
 	case "t":
 		// Toggle time-travel mode off, or show prompt for custom revision
 		if m.timeTravelMode {
@@ -3303,12 +4319,6 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 		} else {
 			m.enterTimeTravelMode("HEAD~5")
 		}
-	case "C":
-		// Copy selected issue to clipboard
-		m.copyIssueToClipboard()
-	case "O":
-		// Open beads.jsonl in editor
-		m.openInEditor()
 	case "h":
 		// Toggle history view
 		if !m.isHistoryView {
@@ -3460,9 +4470,18 @@ func (m Model) View() string {
 	} else if m.showAgentPrompt {
 		// AGENTS.md prompt modal (bv-i8dk)
 		body = m.agentPromptModal.CenterModal(m.width, m.height-1)
+	} else if m.showPalette {
+		m.palette.SetSize(m.width, m.height-1)
+		body = m.palette.View()
+	} else if m.showCassTranscript {
+		m.cassTranscript.SetSize(m.width, m.height-1)
+		body = m.cassTranscript.View()
 	} else if m.showCassModal {
 		// Cass session preview modal (bv-5bqh)
 		body = m.cassModal.CenterModal(m.width, m.height-1)
+	} else if m.showReleaseNotes {
+		m.releaseNotes.SetSize(m.width, m.height-1)
+		body = m.releaseNotes.CenterModal(m.width, m.height-1)
 	} else if m.showUpdateModal {
 		// Self-update modal (bv-182)
 		body = m.updateModal.CenterModal(m.width, m.height-1)
@@ -3472,10 +4491,43 @@ func (m Model) View() string {
 		body = m.renderLabelGraphAnalysis()
 	} else if m.showLabelDrilldown && m.labelDrilldownLabel != "" {
 		body = m.renderLabelDrilldown()
+	} else if m.showNotifications {
+		body = m.renderNotificationHistory()
+	} else if m.showAuditLog {
+		body = m.renderAuditLog()
+	} else if m.showSearchResults {
+		body = m.renderSearchResults()
+	} else if m.showBlockerMenu {
+		body = m.renderBlockerMenu()
+	} else if m.showDiagnostics {
+		body = m.renderStartupDiagnostics()
 	} else if m.showAlertsPanel {
 		body = m.renderAlertsPanel()
+	} else if m.showDigestPanel {
+		body = m.renderDigestPanel()
+	} else if m.showSnoozePrompt {
+		body = m.renderSnoozePrompt()
+	} else if m.showRenamePrompt {
+		body = m.renderRenamePrompt()
+	} else if m.showPriorityReview {
+		body = m.renderPriorityReview()
+	} else if m.showForecast {
+		body = m.renderCompletionForecast()
+	} else if m.showEffortRollup {
+		body = m.renderEffortRollup()
+	} else if m.showUnblockQueue {
+		body = m.renderUnblockQueue()
 	} else if m.showTimeTravelPrompt {
 		body = m.renderTimeTravelPrompt()
+	} else if m.showMergeAssist {
+		m.mergeAssist.SetSize(m.width, m.height-1)
+		body = m.mergeAssist.View()
+	} else if m.showDepEditor {
+		m.depEditor.SetSize(m.width, m.height-1)
+		body = m.depEditor.View()
+	} else if m.showTemplatePicker {
+		m.templatePicker.SetSize(m.width, m.height-1)
+		body = m.templatePicker.View()
 	} else if m.showRecipePicker {
 		body = m.recipePicker.View()
 	} else if m.showRepoPicker {
@@ -3489,6 +4541,9 @@ func (m Model) View() string {
 		body = m.tutorialModel.View()
 	} else if m.focused == focusInsights {
 		m.insightsPanel.SetSize(m.width, m.height-1)
+		m.insightsPanel.SetDeletedCount(m.deletedCount)
+		m.insightsPanel.SetFeedbackStats(m.correlationFeedbackStats())
+		m.insightsPanel.SetPriorityHints(m.priorityHints)
 		body = m.insightsPanel.View()
 	} else if m.focused == focusFlowMatrix {
 		m.flowMatrix.SetSize(m.width, m.height-1)
@@ -3520,7 +4575,7 @@ func (m Model) View() string {
 	}
 
 	// Add shortcuts sidebar if enabled (bv-3qi5)
-	if m.showShortcutsSidebar {
+	if m.shortcutsSidebarShown() {
 		// Update sidebar context based on current focus
 		m.shortcutsSidebar.SetContext(ContextFromFocus(m.focused))
 		m.shortcutsSidebar.SetSize(m.shortcutsSidebar.Width(), m.height-2)
@@ -3528,6 +4583,14 @@ func (m Model) View() string {
 		body = lipgloss.JoinHorizontal(lipgloss.Top, body, sidebar)
 	}
 
+	if suggestions := m.labelSuggestions(); len(suggestions) > 0 && !m.accessible {
+		body = m.overlayLabelSuggestions(body, suggestions)
+	}
+
+	if !m.showNotifications && !m.accessible {
+		body = m.overlayToasts(body)
+	}
+
 	footer := m.renderFooter()
 
 	// Ensure the final output fits exactly in the terminal height
@@ -3537,7 +4600,11 @@ func (m Model) View() string {
 		Height(m.height).
 		MaxHeight(m.height)
 
-	return finalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+	frame := finalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+	if m.ascii {
+		frame = ToASCII(frame)
+	}
+	return frame
 }
 
 func (m Model) renderQuitConfirm() string {
@@ -3596,7 +4663,10 @@ func (m Model) renderListWithHeader() string {
 		// Account for repo badges like [API] shown in workspace mode.
 		headerText = "  REPO TYPE PRI STATUS      ID                               TITLE"
 	}
-	header := headerStyle.Render(headerText)
+	if m.accessible {
+		headerText = "  ID TYPE PRIORITY STATUS: TITLE"
+	}
+	header := headerStyle.Render(m.withBranchHeaderLabel(headerText, m.width-2))
 
 	// Page info
 	totalItems := len(m.list.Items())
@@ -3656,6 +4726,165 @@ func (m Model) renderListWithHeader() string {
 		Render(content)
 }
 
+// shortcutsSidebarShown reports whether the shortcuts sidebar is on and the
+// terminal is wide enough to show it
+func (m Model) shortcutsSidebarShown() bool {
+	return m.showShortcutsSidebar && m.width >= CompactViewThreshold
+}
+
+// splitLayout decides whether the split view is shown and whether its panes
+// are stacked, from the terminal size and the orientation preference
+func (m Model) splitLayout() (split, stacked bool) {
+	if m.accessible {
+		return false, false
+	}
+	wide := m.width > SplitViewThreshold
+	tall := m.height >= StackedSplitMinHeight
+	switch m.splitOrientation {
+	case SplitVertical:
+		return wide, false
+	case SplitHorizontal:
+		return wide || tall, true
+	default:
+		if wide {
+			return true, false
+		}
+		return tall, true
+	}
+}
+
+// resizePanes sizes the list, viewport and panels for the current terminal
+// size and split layout
+func (m *Model) resizePanes() {
+	m.isSplitView, m.splitStacked = m.splitLayout()
+	ratio := m.splitRatio
+	if ratio == 0 {
+		ratio = DefaultSplitRatio
+	}
+	bodyHeight := m.height - 1 // keep 1 row for footer
+	if bodyHeight < 5 {
+		bodyHeight = 5
+	}
+
+	switch {
+	case m.isSplitView && m.splitStacked:
+		// Two full-width panels, each with a 2-row border; the list panel
+		// also holds a header and page line
+		innerWidth := m.width - 4
+		if innerWidth < 10 {
+			innerWidth = 10
+		}
+		listPanelRows := int(float64(bodyHeight) * ratio)
+		if listPanelRows < 7 {
+			listPanelRows = 7
+		}
+		detailRows := bodyHeight - listPanelRows - 2
+		if detailRows < 3 {
+			detailRows = 3
+		}
+
+		m.list.SetSize(innerWidth, listPanelRows-4)
+		m.viewport = viewport.New(innerWidth, detailRows)
+
+		m.renderer.SetWidthWithTheme(innerWidth, m.theme)
+
+	case m.isSplitView:
+		// Calculate dimensions accounting for 2 panels with borders(2)+padding(2) = 4 overhead each
+		// Total overhead = 8
+		availWidth := m.width - 8
+		if availWidth < 10 {
+			availWidth = 10
+		}
+
+		listInnerWidth := int(float64(availWidth) * ratio)
+		detailInnerWidth := availWidth - listInnerWidth
+
+		// listHeight fits header (1) + page line (1) inside a panel with Border (2)
+		listHeight := bodyHeight - 4
+		if listHeight < 3 {
+			listHeight = 3
+		}
+
+		m.list.SetSize(listInnerWidth, listHeight)
+		m.viewport = viewport.New(detailInnerWidth, bodyHeight-2) // Account for border
+
+		m.renderer.SetWidthWithTheme(detailInnerWidth, m.theme)
+
+	default:
+		listHeight := bodyHeight - 2
+		if listHeight < 3 {
+			listHeight = 3
+		}
+		m.list.SetSize(m.width, listHeight)
+		m.viewport = viewport.New(m.width, bodyHeight-1)
+
+		// Update renderer for full width
+		m.renderer.SetWidthWithTheme(m.width, m.theme)
+	}
+
+	m.updateListDelegate()
+
+	// Resize label dashboard table and modal overlay sizing
+	m.labelDashboard.SetSize(m.width, bodyHeight)
+
+	m.insightsPanel.SetSize(m.width, bodyHeight)
+	m.updateViewportContent()
+}
+
+// adjustSplitRatio moves the split divider by delta and remembers the layout
+func (m *Model) adjustSplitRatio(delta float64) {
+	if !m.isSplitView {
+		return
+	}
+	ratio := m.splitRatio
+	if ratio == 0 {
+		ratio = DefaultSplitRatio
+	}
+	// Round to the step so repeated presses land on the same stops
+	ratio = clampSplitRatio(float64(int((ratio+delta)/SplitRatioStep+0.5)) * SplitRatioStep)
+	if ratio == m.splitRatio {
+		return
+	}
+	m.splitRatio = ratio
+	m.resizePanes()
+	m.saveUIState()
+	m.statusMsg = fmt.Sprintf("Split: list %d%%", int(ratio*100+0.5))
+	m.statusIsError = false
+}
+
+// cycleSplitOrientation switches between auto, side-by-side and stacked panes
+func (m *Model) cycleSplitOrientation() {
+	m.splitOrientation = m.splitOrientation.next()
+	m.resizePanes()
+	if !m.isSplitView && m.focused == focusDetail && !m.showDetails {
+		m.focused = focusList
+	}
+	m.saveUIState()
+
+	desc := map[SplitOrientation]string{
+		SplitAuto:       "auto",
+		SplitVertical:   "side by side",
+		SplitHorizontal: "stacked",
+	}[m.splitOrientation]
+	if !m.isSplitView {
+		desc += " (terminal too small to split)"
+	}
+	m.statusMsg = "Split layout: " + desc
+	m.statusIsError = false
+}
+
+// saveUIState persists the split layout and update-notice choices; failures
+// only affect the next session
+func (m *Model) saveUIState() {
+	_ = SaveUIState(UIState{
+		SplitRatio:        m.splitRatio,
+		SplitOrientation:  m.splitOrientation,
+		SkippedUpdate:     m.skippedUpdate,
+		RemindUpdateAfter: m.remindUpdateAfter,
+		SearchHistory:     m.searchHistory,
+	})
+}
+
 func (m Model) renderSplitView() string {
 	t := m.theme
 
@@ -3680,7 +4909,7 @@ func (m Model) renderSplitView() string {
 		Bold(true).
 		Width(listInnerWidth)
 
-	header := headerStyle.Render("  TYPE PRI STATUS      ID                     TITLE")
+	header := headerStyle.Render(m.withBranchHeaderLabel("  TYPE PRI STATUS      ID                     TITLE", listInnerWidth))
 
 	// Page info for list
 	totalItems := len(m.list.Items())
@@ -3718,6 +4947,22 @@ func (m Model) renderSplitView() string {
 	// Combine header + list + page indicator
 	listContent := lipgloss.JoinVertical(lipgloss.Left, header, m.list.View(), pageLine)
 
+	if m.splitStacked {
+		// List above details, each panel sized to its content
+		listRows := m.list.Height() + 2
+		listView := listStyle.
+			Width(listInnerWidth + 2).
+			Height(listRows).
+			MaxHeight(listRows + 2).
+			Render(listContent)
+		detailView := detailStyle.
+			Width(m.viewport.Width + 2).
+			Height(m.viewport.Height).
+			MaxHeight(m.viewport.Height + 2).
+			Render(m.viewport.View())
+		return lipgloss.JoinVertical(lipgloss.Left, listView, detailView)
+	}
+
 	// List Panel Width: Inner + 2 (Padding). Border adds another 2.
 	// Use MaxHeight to ensure content doesn't overflow
 	listView := listStyle.
@@ -3769,9 +5014,14 @@ func (m *Model) renderHelpOverlay() string {
 	}
 
 	// Helper to render a section panel
-	renderPanel := func(title string, icon string, colorIdx int, shortcuts []struct{ key, desc string }) string {
+	renderPanel := func(section KeymapSection, colorIdx int) string {
 		color := colors[colorIdx%len(colors)]
 
+		keyWidth := 10
+		for _, b := range section.Bindings {
+			keyWidth = max(keyWidth, min(lipgloss.Width(b.KeysLabel())+2, 16))
+		}
+
 		headerStyle := t.Renderer.NewStyle().
 			Foreground(color).
 			Bold(true).
@@ -3784,19 +5034,19 @@ func (m *Model) renderHelpOverlay() string {
 		keyStyle := t.Renderer.NewStyle().
 			Foreground(color).
 			Bold(true).
-			Width(10)
+			Width(keyWidth)
 
 		descStyle := t.Renderer.NewStyle().
 			Foreground(t.Base.GetForeground()).
-			Width(colWidth - 16)
+			Width(colWidth - 6 - keyWidth)
 
 		var content strings.Builder
-		content.WriteString(headerStyle.Render(icon + " " + title))
+		content.WriteString(headerStyle.Render(section.Icon + " " + section.Title))
 		content.WriteString("\n")
 
-		for _, s := range shortcuts {
-			content.WriteString(keyStyle.Render(s.key))
-			content.WriteString(descStyle.Render(s.desc))
+		for _, b := range section.Bindings {
+			content.WriteString(keyStyle.Render(b.KeysLabel()))
+			content.WriteString(descStyle.Render(b.Help))
 			content.WriteString("\n")
 		}
 
@@ -3809,95 +5059,11 @@ func (m *Model) renderHelpOverlay() string {
 		return panelStyle.Render(content.String())
 	}
 
-	// Define all sections
-	navSection := []struct{ key, desc string }{
-		{"j / ↓", "Move down"},
-		{"k / ↑", "Move up"},
-		{"G/end", "Go to last"},
-		{"Ctrl+d", "Page down"},
-		{"Ctrl+u", "Page up"},
-		{"Tab", "Switch focus"},
-		{"Enter", "View details"},
-		{"Esc", "Back / close"},
-	}
-
-	viewsSection := []struct{ key, desc string }{
-		{"b", "Kanban board"},
-		{"g", "Graph view"},
-		{"i", "Insights"},
-		{"h", "History view"},
-		{"a", "Actionable"},
-		{"f", "Flow matrix"},
-		{"[", "Label dashboard"},
-		{"]", "Attention view"},
-	}
-
-	globalSection := []struct{ key, desc string }{
-		{"?", "This help"},
-		{";", "Shortcuts bar"},
-		{"!", "Alerts panel"},
-		{"'", "Recipes"},
-		{"w", "Repo picker"},
-		{"q", "Back / Quit"},
-		{"Ctrl+c", "Force quit"},
-	}
-
-	filterSection := []struct{ key, desc string }{
-		{"/", "Fuzzy search"},
-		{"Ctrl+S", "Semantic search"},
-		{"H", "Hybrid ranking"},
-		{"Alt+H", "Hybrid preset"},
-		{"o", "Open issues"},
-		{"c", "Closed issues"},
-		{"r", "Ready (unblocked)"},
-		{"l", "Filter by label"},
-		{"s", "Cycle sort"},
-		{"S", "Triage sort"},
-	}
-
-	graphSection := []struct{ key, desc string }{
-		{"hjkl", "Navigate nodes"},
-		{"H/L", "Scroll left/right"},
-		{"PgUp/Dn", "Scroll up/down"},
-		{"Enter", "Jump to issue"},
-	}
-
-	insightsSection := []struct{ key, desc string }{
-		{"h/l/Tab", "Switch panels"},
-		{"j/k", "Navigate items"},
-		{"e", "Explanations"},
-		{"x", "Calc details"},
-		{"m", "Toggle heatmap"},
-		{"Enter", "Jump to issue"},
-	}
-
-	historySection := []struct{ key, desc string }{
-		{"j/k", "Navigate beads"},
-		{"J/K", "Navigate commits"},
-		{"Tab", "Toggle focus"},
-		{"y", "Copy SHA"},
-		{"c", "Confidence filter"},
-	}
-
-	actionsSection := []struct{ key, desc string }{
-		{"p", "Priority hints"},
-		{"t", "Time-travel"},
-		{"T", "Quick time-travel"},
-		{"x", "Export markdown"},
-		{"C", "Copy to clipboard"},
-		{"O", "Open in editor"},
-	}
-
-	// Build panels
-	panels := []string{
-		renderPanel("Navigation", "🧭", 0, navSection),
-		renderPanel("Views", "👁", 1, viewsSection),
-		renderPanel("Global", "🌐", 2, globalSection),
-		renderPanel("Filters & Sort", "🔍", 3, filterSection),
-		renderPanel("Graph View", "📊", 4, graphSection),
-		renderPanel("Insights", "💡", 5, insightsSection),
-		renderPanel("History", "📜", 0, historySection),
-		renderPanel("Actions", "⚡", 1, actionsSection),
+	// Panels come from the keymap, for the view the help was opened from
+	ctx := m.helpContext()
+	var panels []string
+	for i, section := range m.keymap.Sections(ctx) {
+		panels = append(panels, renderPanel(section, i))
 	}
 
 	// Arrange panels into columns
@@ -3931,12 +5097,14 @@ func (m *Model) renderHelpOverlay() string {
 		Foreground(t.Secondary).
 		Italic(true)
 
-	title := titleStyle.Render("⌨️  Keyboard Shortcuts")
+	title := titleStyle.Render("⌨️  Keyboard Shortcuts · " + ctx.Description())
 	subtitle := subtitleStyle.Render("Space: Tutorial │ ? or Esc to close")
 	titleBar := lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", subtitle)
+	remapHint := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).
+		Render("Remap keys in ~/.config/bv/" + KeymapConfigFilename + " (bv --keymap lists actions)")
 
 	// Combine title and body
-	content := lipgloss.JoinVertical(lipgloss.Center, titleBar, "", body)
+	content := lipgloss.JoinVertical(lipgloss.Center, titleBar, "", body, "", remapHint)
 
 	// Outer container
 	containerStyle := t.Renderer.NewStyle().
@@ -4412,10 +5580,29 @@ func (m Model) renderLabelGraphAnalysis() string {
 }
 
 func (m *Model) renderFooter() string {
+	if m.accessible {
+		return m.renderAccessibleFooter()
+	}
+
 	// ══════════════════════════════════════════════════════════════════════════
 	// POLISHED FOOTER - Stripe-level status bar with visual hierarchy
 	// ══════════════════════════════════════════════════════════════════════════
 
+	// Past snapshot banner stays visible alongside status messages
+	pastBanner := ""
+	if m.pastSnapshot != nil {
+		label := m.pastSnapshot.ShortSHA
+		if subject := strings.TrimSpace(strings.SplitN(m.pastSnapshot.Subject, "\n", 2)[0]); subject != "" {
+			label += " " + truncateRunesHelper(subject, 30, "…")
+		}
+		pastBanner = lipgloss.NewStyle().
+			Background(ColorPrioHighBg).
+			Foreground(ColorWarning).
+			Bold(true).
+			Padding(0, 1).
+			Render("⏪ VIEWING PAST " + label + " · read-only")
+	}
+
 	// If there's a status message, show it prominently with polished styling
 	if m.statusMsg != "" {
 		var msgStyle lipgloss.Style
@@ -4433,12 +5620,12 @@ func (m *Model) renderFooter() string {
 				Padding(0, 2)
 		}
 		msgSection := msgStyle.Render("✓ " + m.statusMsg)
-		remaining := m.width - lipgloss.Width(msgSection)
+		remaining := m.width - lipgloss.Width(pastBanner) - lipgloss.Width(msgSection)
 		if remaining < 0 {
 			remaining = 0
 		}
 		filler := lipgloss.NewStyle().Background(ColorBgDark).Width(remaining).Render("")
-		return lipgloss.JoinHorizontal(lipgloss.Bottom, msgSection, filler)
+		return lipgloss.JoinHorizontal(lipgloss.Bottom, pastBanner, msgSection, filler)
 	}
 
 	// ─────────────────────────────────────────────────────────────────────────
@@ -4469,6 +5656,12 @@ func (m *Model) renderFooter() string {
 		case "ready":
 			filterTxt = "READY"
 			filterIcon = "🚀"
+		case "starred":
+			filterTxt = "STARRED"
+			filterIcon = starredBadge
+		case "snoozed":
+			filterTxt = "SNOOZED"
+			filterIcon = snoozeBadge
 		default:
 			if strings.HasPrefix(m.currentFilter, "recipe:") {
 				filterTxt = strings.ToUpper(m.currentFilter[7:])
@@ -4480,18 +5673,21 @@ func (m *Model) renderFooter() string {
 		}
 	}
 
-	filterBadge := lipgloss.NewStyle().
-		Background(ColorPrimary).
-		Foreground(ColorText).
-		Bold(true).
-		Padding(0, 1).
-		Render(fmt.Sprintf("%s %s", filterIcon, filterTxt))
+	filterBadge := ""
+	if m.statusBar.Shows(SegmentFilter) {
+		filterBadge = lipgloss.NewStyle().
+			Background(ColorPrimary).
+			Foreground(ColorText).
+			Bold(true).
+			Padding(0, 1).
+			Render(fmt.Sprintf("%s %s", filterIcon, filterTxt))
+	}
 
 	// Search mode badge when filtering
 	searchBadge := ""
 	if m.list.FilterState() != list.Unfiltered {
 		mode := "fuzzy"
-		if m.semanticSearchEnabled {
+		if m.semanticActive() {
 			mode = "semantic"
 			if m.semanticIndexBuilding {
 				mode = "semantic (indexing)"
@@ -4510,14 +5706,24 @@ func (m *Model) renderFooter() string {
 			Render(fmt.Sprintf("🔎 %s", mode))
 	}
 
+	// Risk heatmap legend while the overlay is on
+	riskBadge := ""
+	if m.showRiskHeatmap && (m.focused == focusList || m.focused == focusDetail || m.focused == focusBoard) {
+		riskBadge = lipgloss.NewStyle().
+			Background(ColorBgHighlight).
+			Foreground(ColorSecondary).
+			Padding(0, 1).
+			Render(RenderRiskLegend())
+	}
+
 	// Sort badge - only show when not default (bv-3ita)
 	sortBadge := ""
-	if m.sortMode != SortDefault {
+	if m.sortMode != SortDefault && m.statusBar.Shows(SegmentSort) {
 		sortBadge = lipgloss.NewStyle().
 			Background(ColorBgHighlight).
 			Foreground(ColorSecondary).
 			Padding(0, 1).
-			Render(fmt.Sprintf("↕ %s", m.sortMode.String()))
+			Render(fmt.Sprintf("↕ %s", m.sortLabel()))
 	}
 
 	labelHint := lipgloss.NewStyle().
@@ -4573,7 +5779,7 @@ func (m *Model) renderFooter() string {
 			Padding(0, 1)
 		statsSection = timeTravelStyle.Render(fmt.Sprintf("⏱ %s: +%d ✅%d ~%d",
 			m.timeTravelSince, d.IssuesAdded, d.IssuesClosed, d.IssuesModified))
-	} else {
+	} else if m.statusBar.Shows(SegmentCounts) {
 		// Polished stats with mini indicators
 		statsStyle := lipgloss.NewStyle().
 			Background(ColorBgHighlight).
@@ -4601,7 +5807,7 @@ func (m *Model) renderFooter() string {
 	// UPDATE BADGE - New version available
 	// ─────────────────────────────────────────────────────────────────────────
 	updateSection := ""
-	if m.updateAvailable {
+	if m.updateAvailable && m.updateTag != m.skippedUpdate && m.statusBar.Shows(SegmentUpdate) {
 		updateStyle := lipgloss.NewStyle().
 			Background(ColorTypeFeature).
 			Foreground(ColorBg).
@@ -4629,7 +5835,7 @@ func (m *Model) renderFooter() string {
 			}
 		}
 	}
-	if activeAlerts > 0 {
+	if activeAlerts > 0 && m.statusBar.Shows(SegmentAlerts) {
 		var alertStyle lipgloss.Style
 		var alertIcon string
 		if activeCritical > 0 {
@@ -4660,7 +5866,7 @@ func (m *Model) renderFooter() string {
 	// SESSION INDICATOR - Cass coding sessions for selected bead (bv-y836)
 	// ─────────────────────────────────────────────────────────────────────────
 	sessionSection := ""
-	if sessionCount := m.getCassSessionCount(); sessionCount > 0 {
+	if sessionCount := m.getCassSessionCount(); sessionCount > 0 && m.statusBar.Shows(SegmentSessions) {
 		sessionStyle := lipgloss.NewStyle().
 			Background(ColorBgHighlight).
 			Foreground(ColorInfo).
@@ -4673,23 +5879,34 @@ func (m *Model) renderFooter() string {
 	}
 
 	// ─────────────────────────────────────────────────────────────────────────
-	// WORKSPACE BADGE - Multi-repo mode indicator
+	// BRANCH BADGE - Current branch and the issues pinned for it
 	// ─────────────────────────────────────────────────────────────────────────
-	workspaceSection := ""
-	if m.workspaceMode && m.workspaceSummary != "" {
-		workspaceStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color("#45B7D1")).
-			Foreground(ColorBg).
-			Bold(true).
-			Padding(0, 1)
-		workspaceSection = workspaceStyle.Render(fmt.Sprintf("📦 %s", m.workspaceSummary))
+	branchSection := ""
+	if m.statusBar.Shows(SegmentBranch) {
+		branchSection = m.renderBranchBadge()
+	}
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// SOURCE, ANALYSIS AND RELOAD BADGES - Where the data comes from and how fresh it is
+	// ─────────────────────────────────────────────────────────────────────────
+	sourceSection := ""
+	if m.statusBar.Shows(SegmentSource) {
+		sourceSection = m.renderSourceSegment()
+	}
+	analysisSection := ""
+	if m.statusBar.Shows(SegmentAnalysis) {
+		analysisSection = m.renderAnalysisSegment()
+	}
+	reloadSection := ""
+	if m.statusBar.Shows(SegmentReload) {
+		reloadSection = m.renderReloadSegment()
 	}
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// REPO FILTER BADGE - Active repo selection (workspace mode)
 	// ─────────────────────────────────────────────────────────────────────────
 	repoFilterSection := ""
-	if m.workspaceMode && m.activeRepos != nil && len(m.activeRepos) > 0 {
+	if m.workspaceMode && m.activeRepos != nil && len(m.activeRepos) > 0 && m.statusBar.Shows(SegmentFilter) {
 		active := sortedRepoKeys(m.activeRepos)
 		label := formatRepoList(active, 3)
 		repoStyle := lipgloss.NewStyle().
@@ -4713,6 +5930,34 @@ func (m *Model) renderFooter() string {
 	var keyHints []string
 	if m.showHelp {
 		keyHints = append(keyHints, "Press any key to close")
+	} else if m.showPalette {
+		keyHints = append(keyHints, "type to search", keyStyle.Render("↑/↓")+" nav", keyStyle.Render("⏎")+" run", keyStyle.Render("esc")+" close")
+	} else if m.showDepEditor {
+		if m.depEditor.Adding() {
+			keyHints = append(keyHints, "type to search", keyStyle.Render("tab")+" type", keyStyle.Render("⏎")+" add", keyStyle.Render("esc")+" back")
+		} else {
+			keyHints = append(keyHints, keyStyle.Render("a")+" add", keyStyle.Render("x")+" remove", keyStyle.Render("^s")+" save", keyStyle.Render("esc")+" cancel")
+		}
+	} else if m.showCassTranscript {
+		if m.cassTranscript.Searching() {
+			keyHints = append(keyHints, "type to search", keyStyle.Render("⏎")+" find", keyStyle.Render("esc")+" cancel")
+		} else {
+			keyHints = append(keyHints, keyStyle.Render("/")+" search", keyStyle.Render("n/N")+" match", keyStyle.Render("[/]")+" code", keyStyle.Render("y")+" copy", keyStyle.Render("esc")+" back")
+		}
+	} else if m.showNotifications || m.showAuditLog {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" scroll", keyStyle.Render("esc")+" close")
+	} else if m.showSearchResults {
+		keyHints = append(keyHints, keyStyle.Render("n/N")+" result", keyStyle.Render("⏎")+" jump", keyStyle.Render("s")+" save", keyStyle.Render("esc")+" close")
+	} else if m.showBlockerMenu {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" move", keyStyle.Render("⏎")+" jump", keyStyle.Render("esc")+" close")
+	} else if m.showDiagnostics {
+		keyHints = append(keyHints, keyStyle.Render("r")+" re-run", keyStyle.Render("esc")+" close")
+	} else if m.showReleaseNotes {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" scroll", keyStyle.Render("u")+" update", keyStyle.Render("l")+" later", keyStyle.Render("s")+" skip", keyStyle.Render("esc")+" close")
+	} else if m.showMergeAssist {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" issue", keyStyle.Render("h/l")+" version", keyStyle.Render("^s")+" write", keyStyle.Render("esc")+" cancel")
+	} else if m.showTemplatePicker {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" create", keyStyle.Render("esc")+" cancel")
 	} else if m.showRecipePicker {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
 	} else if m.showRepoPicker {
@@ -4731,7 +5976,7 @@ func (m *Model) renderFooter() string {
 	} else if m.isActionableView {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" view", keyStyle.Render("a")+" list", keyStyle.Render("?")+" help")
 	} else if m.isHistoryView {
-		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" focus", keyStyle.Render("⏎")+" jump", keyStyle.Render("H")+" close")
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" focus", keyStyle.Render("⏎")+" jump", keyStyle.Render("C/X/I")+" feedback", keyStyle.Render("t")+" view at commit", keyStyle.Render("H")+" close")
 	} else if m.list.FilterState() == list.Filtering {
 		mode := "fuzzy"
 		if m.semanticSearchEnabled {
@@ -4741,18 +5986,27 @@ func (m *Model) renderFooter() string {
 			}
 		}
 		keyHints = append(keyHints, keyStyle.Render("esc")+" cancel", keyStyle.Render("ctrl+s")+" "+mode, keyStyle.Render("⏎")+" select")
-		if m.semanticSearchEnabled {
+		if m.semanticActive() {
 			keyHints = append(keyHints, keyStyle.Render("H")+" hybrid", keyStyle.Render("alt+h")+" preset")
 		}
 	} else if m.showTimeTravelPrompt {
 		keyHints = append(keyHints, keyStyle.Render("⏎")+" compare", keyStyle.Render("esc")+" cancel")
 	} else {
-		if m.timeTravelMode {
+		if m.pastSnapshot != nil {
+			keyHints = append(keyHints, keyStyle.Render("t")+" present", keyStyle.Render("h")+" history", keyStyle.Render("abgi")+" views", keyStyle.Render("?")+" help")
+		} else if m.timeTravelMode {
 			keyHints = append(keyHints, keyStyle.Render("t")+" exit diff", keyStyle.Render("C")+" copy", keyStyle.Render("abgi")+" views", keyStyle.Render("?")+" help")
 		} else if m.isSplitView {
 			keyHints = append(keyHints, keyStyle.Render("tab")+" focus", keyStyle.Render("C")+" copy", keyStyle.Render("x")+" export", keyStyle.Render("?")+" help")
 		} else if m.showDetails {
-			keyHints = append(keyHints, keyStyle.Render("esc")+" back", keyStyle.Render("C")+" copy", keyStyle.Render("O")+" edit", keyStyle.Render("?")+" help")
+			keyHints = append(keyHints, keyStyle.Render("esc")+" back", keyStyle.Render("v")+" history")
+			if len(m.detailRefs) > 0 {
+				keyHints = append(keyHints, keyStyle.Render("n/gd")+" links")
+			}
+			if issue := m.issueMap[m.selectedIssueID()]; issue != nil && len(issue.Attachments) > 0 {
+				keyHints = append(keyHints, keyStyle.Render("o")+" open link")
+			}
+			keyHints = append(keyHints, keyStyle.Render("C")+" copy", keyStyle.Render("O")+" edit", keyStyle.Render("?")+" help")
 		} else {
 			keyHints = append(keyHints, keyStyle.Render("⏎")+" details", keyStyle.Render("t")+" diff", keyStyle.Render("S")+" triage", keyStyle.Render("l")+" labels", keyStyle.Render("?")+" help")
 			if m.workspaceMode {
@@ -4761,44 +6015,41 @@ func (m *Model) renderFooter() string {
 		}
 	}
 
-	keysSection := lipgloss.NewStyle().
-		Foreground(ColorSubtext).
-		Padding(0, 1).
-		Render(strings.Join(keyHints, sep))
+	keysSection := ""
+	if !m.statusBar.Shows(SegmentHints) {
+		labelHint = ""
+	} else {
+		keysSection = lipgloss.NewStyle().
+			Foreground(ColorSubtext).
+			Padding(0, 1).
+			Render(strings.Join(keyHints, sep))
+	}
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// COUNT BADGE - Total issues displayed
 	// ─────────────────────────────────────────────────────────────────────────
-	countBadge := lipgloss.NewStyle().
-		Foreground(ColorSecondary).
-		Padding(0, 1).
-		Render(fmt.Sprintf("%d issues", len(m.list.Items())))
+	countBadge := ""
+	if m.statusBar.Shows(SegmentTotal) {
+		countBadge = lipgloss.NewStyle().
+			Foreground(ColorSecondary).
+			Padding(0, 1).
+			Render(fmt.Sprintf("%d issues", len(m.list.Items())))
+	}
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// ASSEMBLE FOOTER with proper spacing
 	// ─────────────────────────────────────────────────────────────────────────
-	leftWidth := lipgloss.Width(filterBadge) + lipgloss.Width(labelHint) + lipgloss.Width(statsSection)
-	if searchBadge != "" {
-		leftWidth += lipgloss.Width(searchBadge) + 1
-	}
-	if sortBadge != "" {
-		leftWidth += lipgloss.Width(sortBadge) + 1
-	}
-	if alertsSection != "" {
-		leftWidth += lipgloss.Width(alertsSection) + 1
-	}
-	if sessionSection != "" {
-		leftWidth += lipgloss.Width(sessionSection) + 1
-	}
-	if workspaceSection != "" {
-		leftWidth += lipgloss.Width(workspaceSection) + 1
-	}
-	if repoFilterSection != "" {
-		leftWidth += lipgloss.Width(repoFilterSection) + 1
-	}
-	if updateSection != "" {
-		leftWidth += lipgloss.Width(updateSection) + 1
+	var left []string
+	for _, section := range []string{
+		pastBanner, sourceSection, filterBadge, searchBadge, sortBadge, riskBadge, labelHint,
+		alertsSection, sessionSection, branchSection, repoFilterSection, updateSection,
+		analysisSection, reloadSection, statsSection,
+	} {
+		if section != "" {
+			left = append(left, section)
+		}
 	}
+	leftWidth := lipgloss.Width(strings.Join(left, ""))
 	rightWidth := lipgloss.Width(countBadge) + lipgloss.Width(keysSection)
 
 	remaining := m.width - leftWidth - rightWidth - 1
@@ -4807,33 +6058,7 @@ func (m *Model) renderFooter() string {
 	}
 	filler := lipgloss.NewStyle().Background(ColorBgDark).Width(remaining).Render("")
 
-	// Build the footer
-	var parts []string
-	parts = append(parts, filterBadge)
-	if searchBadge != "" {
-		parts = append(parts, searchBadge)
-	}
-	if sortBadge != "" {
-		parts = append(parts, sortBadge)
-	}
-	parts = append(parts, labelHint)
-	if alertsSection != "" {
-		parts = append(parts, alertsSection)
-	}
-	if sessionSection != "" {
-		parts = append(parts, sessionSection)
-	}
-	if workspaceSection != "" {
-		parts = append(parts, workspaceSection)
-	}
-	if repoFilterSection != "" {
-		parts = append(parts, repoFilterSection)
-	}
-	if updateSection != "" {
-		parts = append(parts, updateSection)
-	}
-	parts = append(parts, statsSection, filler, countBadge, keysSection)
-
+	parts := append(left, filler, countBadge, keysSection)
 	return lipgloss.JoinHorizontal(lipgloss.Bottom, parts...)
 }
 
@@ -4903,6 +6128,11 @@ func (m *Model) applyFilter() {
 			}
 		}
 
+		// Snoozed issues only show under the snoozed filter
+		if m.isSnoozed(issue.ID) && m.currentFilter != "snoozed" {
+			continue
+		}
+
 		include := false
 		switch m.currentFilter {
 		case "all":
@@ -4912,19 +6142,12 @@ func (m *Model) applyFilter() {
 		case "closed":
 			include = issue.Status == model.StatusClosed
 		case "ready":
-			// Ready = Open/InProgress AND NO Open Blockers
-			if issue.Status != model.StatusClosed && issue.Status != model.StatusBlocked {
-				isBlocked := false
-				for _, dep := range issue.Dependencies {
-					if dep.Type == model.DepBlocks {
-						if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
-							isBlocked = true
-							break
-						}
-					}
-				}
-				include = !isBlocked
-			}
+			// Ready = Open/InProgress AND NO Open Blockers (shared with --export-filter)
+			include = export.IsReady(issue, m.issueMap)
+		case "starred":
+			include = m.starred[issue.ID]
+		case "snoozed":
+			include = m.isSnoozed(issue.ID)
 		default:
 			if strings.HasPrefix(m.currentFilter, "label:") {
 				label := strings.TrimPrefix(m.currentFilter, "label:")
@@ -4945,6 +6168,7 @@ func (m *Model) applyFilter() {
 				Impact:     m.analysis.GetCriticalPathScore(issue.ID),
 				DiffStatus: m.getDiffStatus(issue.ID),
 				RepoPrefix: ExtractRepoPrefix(issue.ID),
+				LocalNote:  m.notes[issue.ID],
 			}
 			// Add triage data (bv-151)
 			item.TriageScore = m.triageScores[issue.ID]
@@ -4962,10 +6186,14 @@ func (m *Model) applyFilter() {
 
 	// Apply sort mode (bv-3ita)
 	m.sortFilteredItems(filteredItems, filteredIssues)
+	m.pinStarred(filteredItems, filteredIssues)
+	m.pinWorkingOn(filteredItems, filteredIssues)
 
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
 	m.board.SetIssues(filteredIssues)
+	m.board.SetMetrics(m.analysis, m.triageScores)
+	m.boardPending = false
 	// Generate insights for graph view (for metric rankings and sorting)
 	filterIns := m.analysis.GenerateInsights(len(filteredIssues))
 	m.graphView.SetIssues(filteredIssues, &filterIns)
@@ -4977,6 +6205,15 @@ func (m *Model) applyFilter() {
 	m.updateViewportContent()
 }
 
+// sortLabel names the sort mode for the footer, noting when its metrics
+// are not ready yet
+func (m Model) sortLabel() string {
+	if m.sortPending() {
+		return m.sortMode.String() + " (pending)"
+	}
+	return m.sortMode.String()
+}
+
 // cycleSortMode cycles through available sort modes (bv-3ita)
 func (m *Model) cycleSortMode() {
 	m.sortMode = (m.sortMode + 1) % numSortModes
@@ -4995,10 +6232,19 @@ func (m *Model) sortFilteredItems(items []list.Item, issues []model.Issue) {
 		indices[i] = i
 	}
 
+	metric := m.sortMetric()
 	sort.Slice(indices, func(i, j int) bool {
 		iItem := items[indices[i]].(IssueItem)
 		jItem := items[indices[j]].(IssueItem)
 
+		// Metric sorts rank highest first and keep the default order for ties
+		if metric != nil {
+			if mi, mj := metric(iItem.Issue.ID), metric(jItem.Issue.ID); mi != mj {
+				return mi > mj
+			}
+			return defaultIssueLess(iItem.Issue, jItem.Issue)
+		}
+
 		switch m.sortMode {
 		case SortCreatedAsc:
 			// Oldest first
@@ -5013,16 +6259,7 @@ func (m *Model) sortFilteredItems(items []list.Item, issues []model.Issue) {
 			// Most recently updated first
 			return iItem.Issue.UpdatedAt.After(jItem.Issue.UpdatedAt)
 		default:
-			// Default: Open first, then priority, then newest
-			iClosed := iItem.Issue.Status == model.StatusClosed
-			jClosed := jItem.Issue.Status == model.StatusClosed
-			if iClosed != jClosed {
-				return !iClosed
-			}
-			if iItem.Issue.Priority != jItem.Issue.Priority {
-				return iItem.Issue.Priority < jItem.Issue.Priority
-			}
-			return iItem.Issue.CreatedAt.After(jItem.Issue.CreatedAt)
+			return defaultIssueLess(iItem.Issue, jItem.Issue)
 		}
 	})
 
@@ -5037,6 +6274,93 @@ func (m *Model) sortFilteredItems(items []list.Item, issues []model.Issue) {
 	copy(issues, sortedIssues)
 }
 
+// defaultIssueLess is the default list order: open first, then priority,
+// then newest
+func defaultIssueLess(a, b model.Issue) bool {
+	aClosed := a.Status == model.StatusClosed
+	bClosed := b.Status == model.StatusClosed
+	if aClosed != bClosed {
+		return !aClosed
+	}
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+// sortMetric returns the score a metric sort mode ranks issues by, highest
+// first, or nil for the date and priority sorts. Risk and ETA are computed
+// on first use and cached until the issues or graph metrics change.
+func (m *Model) sortMetric() func(id string) float64 {
+	switch m.sortMode {
+	case SortPageRank:
+		if m.analysis == nil {
+			return func(string) float64 { return 0 }
+		}
+		pageRank := m.analysis.PageRank()
+		return func(id string) float64 { return pageRank[id] }
+	case SortTriage:
+		scores := m.triageScores
+		return func(id string) float64 { return scores[id] }
+	case SortRisk:
+		if m.riskScores == nil {
+			m.riskScores = computeRiskScores(m.issues, m.analysis)
+		}
+		scores := m.riskScores
+		return func(id string) float64 { return scores[id] }
+	case SortETA:
+		if m.etaEstimates == nil {
+			m.etaEstimates = analysis.EstimateETAs(m.issues, m.analysis, 1, time.Now())
+		}
+		etas := m.etaEstimates
+		return func(id string) float64 {
+			if eta, ok := etas[id]; ok {
+				return -eta.EstimatedDays
+			}
+			return math.Inf(-1) // Closed: no ETA
+		}
+	case SortBlocks:
+		counts := make(map[string]int)
+		for _, issue := range m.issues {
+			if issue.Status == model.StatusClosed {
+				continue
+			}
+			for _, dep := range issue.Dependencies {
+				if dep != nil && dep.Type.IsBlocking() {
+					counts[dep.DependsOnID]++
+				}
+			}
+		}
+		return func(id string) float64 { return float64(counts[id]) }
+	}
+	return nil
+}
+
+// sortPending reports whether the sort mode ranks by Phase 2 metrics that
+// are still being computed. The list is re-sorted when they arrive.
+func (m Model) sortPending() bool {
+	if m.analysis == nil || m.analysis.IsPhase2Ready() {
+		return false
+	}
+	switch m.sortMode {
+	case SortPageRank, SortETA:
+		return true
+	case SortTriage:
+		return m.lazyLoad // Otherwise triage was computed at startup
+	}
+	return false
+}
+
+// refreshFilteredViews re-applies the active recipe, or the current filter
+// when no recipe is active, to the list, board and graph.
+func (m *Model) refreshFilteredViews() {
+	if m.activeRecipe != nil {
+		m.applyRecipe(m.activeRecipe)
+	} else {
+		m.applyFilter()
+	}
+}
+
 // applyRecipe applies a recipe's filters and sort to the current view
 func (m *Model) applyRecipe(r *recipe.Recipe) {
 	if r == nil {
@@ -5057,6 +6381,11 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			}
 		}
 
+		// Snoozed issues stay hidden under recipes too
+		if m.isSnoozed(issue.ID) {
+			include = false
+		}
+
 		// Apply status filter
 		if len(r.Filters.Status) > 0 {
 			statusMatch := false
@@ -5110,6 +6439,11 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			include = !isBlocked
 		}
 
+		// Apply saved search
+		if include && r.Filters.Search != "" {
+			include = recipe.MatchesSearch(issue, r.Filters.Search)
+		}
+
 		if include {
 			item := IssueItem{
 				Issue:      issue,
@@ -5117,6 +6451,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 				Impact:     m.analysis.GetCriticalPathScore(issue.ID),
 				DiffStatus: m.getDiffStatus(issue.ID),
 				RepoPrefix: ExtractRepoPrefix(issue.ID),
+				LocalNote:  m.notes[issue.ID],
 			}
 			// Add triage data (bv-151)
 			item.TriageScore = m.triageScores[issue.ID]
@@ -5188,10 +6523,14 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			return less
 		})
 	}
+	m.pinStarred(filteredItems, filteredIssues)
+	m.pinWorkingOn(filteredItems, filteredIssues)
 
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
 	m.board.SetIssues(filteredIssues)
+	m.board.SetMetrics(m.analysis, m.triageScores)
+	m.boardPending = false
 	// Generate insights for graph view (for metric rankings and sorting)
 	recipeIns := m.analysis.GenerateInsights(len(filteredIssues))
 	m.graphView.SetIssues(filteredIssues, &recipeIns)
@@ -5219,7 +6558,22 @@ func (m *Model) updateViewportContent() {
 		m.viewport.SetContent("Error: invalid item type")
 		return
 	}
-	item := issueItem.Issue
+	item, err := m.hydrateIssue(issueItem.Issue)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not read the text of %s: %v", item.ID, err)
+		m.statusIsError = true
+	}
+
+	// Collect linked issue references; the cursor resets per issue
+	if m.detailRefIssueID != item.ID {
+		m.detailRefIssueID = item.ID
+		m.detailRefIdx = 0
+		m.attachmentIdx = 0
+	}
+	m.detailRefs = findIssueRefs(issueRefText(item), m.knownIssue, item.ID)
+	linkify := func(text string) string {
+		return highlightIssueRefs(text, m.knownIssue, m.currentIssueRef())
+	}
 
 	var sb strings.Builder
 
@@ -5227,8 +6581,29 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString(fmt.Sprintf("⭐ **Update Available:** [%s](%s)\n\n", m.updateTag, m.updateURL))
 	}
 
+	// Breadcrumb of the jump list when this issue was reached by a jump
+	if m.nav.current() == item.ID {
+		if crumbs := m.nav.breadcrumb(5); crumbs != "" {
+			sb.WriteString(fmt.Sprintf("🧭 %s *(ctrl+o back)*\n\n", crumbs))
+		}
+	}
+
 	// Title Block
-	sb.WriteString(fmt.Sprintf("# %s %s\n", GetTypeIconMD(string(item.IssueType)), item.Title))
+	star := ""
+	if m.starred[item.ID] {
+		star = starredBadge + " "
+	}
+	sb.WriteString(fmt.Sprintf("# %s %s%s\n", GetTypeIconMD(string(item.IssueType)), star, item.Title))
+
+	// Tab bar: the history tab replaces the rest of the detail content
+	if m.detailTab == detailTabHistory {
+		sb.WriteString("Details · **[History]** *(v to switch)*\n\n")
+		sb.WriteString(m.renderIssueChangesMD(item.ID))
+		m.setViewportMarkdown(sb.String())
+		return
+	}
+	sb.WriteString("**[Details]** · History *(v to switch)*\n\n")
+	sb.WriteString(m.renderSnoozedMD(item.ID))
 
 	// Meta Table
 	sb.WriteString("| ID | Status | Priority | Assignee | Created |\n|---|---|---|---|---|\n")
@@ -5245,6 +6620,21 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString(fmt.Sprintf("**Labels:** %s\n\n", strings.Join(item.Labels, ", ")))
 	}
 
+	// Estimate, and the effort of the issue's children when it has any
+	sb.WriteString(m.renderEffortMD(item))
+
+	// Custom fields, ordered by .bv/custom_fields.yaml
+	sb.WriteString(m.customFields.renderCustomFieldsMD(item))
+
+	// Scores, badges and warnings from .bv/plugins/
+	sb.WriteString(m.renderPluginsMD(item))
+
+	// Attachments and external links
+	sb.WriteString(renderAttachmentsMD(item.Attachments, m.attachmentIdx))
+
+	// Last modifiers of status/priority from git history
+	sb.WriteString(m.renderBlameMD(item))
+
 	// Triage Insights (bv-151)
 	if issueItem.TriageScore > 0 || issueItem.TriageReason != "" || issueItem.UnblocksCount > 0 || issueItem.IsQuickWin || issueItem.IsBlocker {
 		sb.WriteString("### 🎯 Triage Insights\n")
@@ -5288,7 +6678,7 @@ func (m *Model) updateViewportContent() {
 	}
 
 	// Search Scores (hybrid mode)
-	if m.semanticSearchEnabled && m.semanticHybridEnabled && issueItem.SearchScoreSet && m.list.FilterState() != list.Unfiltered {
+	if m.semanticActive() && m.semanticHybridEnabled && issueItem.SearchScoreSet && m.list.FilterState() != list.Unfiltered {
 		sb.WriteString("### 🔎 Search Scores\n")
 		sb.WriteString(fmt.Sprintf("- **Hybrid Score:** %.3f\n", issueItem.SearchScore))
 		sb.WriteString(fmt.Sprintf("- **Text Score:** %.3f\n", issueItem.SearchTextScore))
@@ -5320,32 +6710,54 @@ func (m *Model) updateViewportContent() {
 	// Description
 	if item.Description != "" {
 		sb.WriteString("### Description\n")
-		sb.WriteString(item.Description + "\n\n")
+		sb.WriteString(linkify(item.Description) + "\n\n")
 	}
 
+	// Private note, kept apart from the shared fields
+	sb.WriteString(m.renderLocalNoteMD(item.ID))
+
 	// Design Notes
 	if item.Design != "" {
 		sb.WriteString("### Design Notes\n")
-		sb.WriteString(item.Design + "\n\n")
+		sb.WriteString(linkify(item.Design) + "\n\n")
 	}
 
 	// Acceptance Criteria
 	if item.AcceptanceCriteria != "" {
 		sb.WriteString("### Acceptance Criteria\n")
-		sb.WriteString(item.AcceptanceCriteria + "\n\n")
+		sb.WriteString(linkify(item.AcceptanceCriteria) + "\n\n")
 	}
 
 	// Notes
 	if item.Notes != "" {
 		sb.WriteString("### Notes\n")
-		sb.WriteString(item.Notes + "\n\n")
+		sb.WriteString(linkify(item.Notes) + "\n\n")
 	}
 
+	// Inline previews of the issues referenced above
+	if len(m.detailRefs) > 0 {
+		sb.WriteString("### 🔗 Linked Issues\n")
+		for i, id := range m.detailRefs {
+			ref := m.issueMap[id]
+			marker := "-"
+			if i == m.detailRefIdx {
+				marker = "- ▸"
+			}
+			sb.WriteString(fmt.Sprintf("%s %s **%s** %s P%d · %s\n",
+				marker, GetStatusIcon(string(ref.Status)), ref.ID, GetTypeIconMD(string(ref.IssueType)), ref.Priority, ref.Title))
+		}
+		sb.WriteString("\n*n/N select · gd open*\n\n")
+	}
+
+	// Nearest issues by embedding similarity
+	sb.WriteString(m.renderSimilarIssuesMD(item.ID))
+
 	// Dependency Graph (Tree)
 	if len(item.Dependencies) > 0 {
 		rootNode := BuildDependencyTree(item.ID, m.issueMap, 3) // Max depth 3
 		treeStr := RenderDependencyTree(rootNode)
-		sb.WriteString("```\n" + treeStr + "```\n\n")
+		sb.WriteString("```\n" + treeStr + "```\n")
+		sb.WriteString("*D edit dependencies*\n\n")
 	}
 
 	// Comments
@@ -5367,7 +6779,12 @@ func (m *Model) updateViewportContent() {
 		}
 	}
 
-	rendered, err := m.renderer.Render(sb.String())
+	m.setViewportMarkdown(sb.String())
+}
+
+// setViewportMarkdown renders markdown into the detail viewport
+func (m *Model) setViewportMarkdown(md string) {
+	rendered, err := m.renderer.Render(md)
 	if err != nil {
 		m.viewport.SetContent(fmt.Sprintf("Error rendering markdown: %v", err))
 	} else {
@@ -5375,6 +6792,165 @@ func (m *Model) updateViewportContent() {
 	}
 }
 
+// toggleDetailTab switches the detail pane between details and change history
+func (m *Model) toggleDetailTab() {
+	if m.detailTab == detailTabHistory {
+		m.detailTab = detailTabInfo
+	} else {
+		m.detailTab = detailTabHistory
+	}
+	m.viewport.GotoTop()
+	m.updateViewportContent()
+}
+
+// issueChangesCmd starts loading the selected issue's change timeline if the
+// history tab is visible and it has not been loaded yet
+func (m *Model) issueChangesCmd() tea.Cmd {
+	if m.detailTab != detailTabHistory || !(m.showDetails || m.isSplitView) {
+		return nil
+	}
+	sel, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return nil
+	}
+	id := sel.Issue.ID
+	if _, seen := m.issueChanges[id]; seen {
+		return nil
+	}
+	if m.issueChanges == nil {
+		m.issueChanges = make(map[string]*issueChangesEntry)
+	}
+	m.issueChanges[id] = &issueChangesEntry{loading: true}
+	return LoadIssueChangesCmd(m.beadsPath, id)
+}
+
+// blameCmd starts loading status/priority attribution when the detail pane
+// is visible and the current data has not been attributed yet
+func (m *Model) blameCmd() tea.Cmd {
+	if m.blameLoaded || m.blameLoading || m.pastSnapshot != nil || !(m.showDetails || m.isSplitView) {
+		return nil
+	}
+	m.blameLoading = true
+	return LoadBlameCmd(m.beadsPath)
+}
+
+// renderBlameMD renders who last changed the issue's status and priority
+func (m *Model) renderBlameMD(issue model.Issue) string {
+	if m.blame == nil || m.pastSnapshot != nil {
+		return ""
+	}
+	ib := correlation.AttributeIssue(m.blame, correlation.BlameTarget{
+		ID:       issue.ID,
+		Status:   string(issue.Status),
+		Priority: issue.Priority,
+	})
+	uncommitted := make(map[string]bool, len(ib.UncommittedFields))
+	for _, f := range ib.UncommittedFields {
+		uncommitted[f] = true
+	}
+
+	var parts []string
+	describe := func(field string, attr *correlation.FieldAttribution) {
+		switch {
+		case attr == nil:
+			return
+		case uncommitted[field]:
+			parts = append(parts, field+" *uncommitted*")
+		default:
+			sha := attr.CommitSHA
+			if len(sha) > 7 {
+				sha = sha[:7]
+			}
+			parts = append(parts, fmt.Sprintf("%s by **%s** %s (`%s`)", field, attr.Author, FormatTimeRel(attr.Timestamp), sha))
+		}
+	}
+	describe("status", ib.Status)
+	describe("priority", ib.Priority)
+	if len(parts) == 0 {
+		return ""
+	}
+	return "**Last changed:** " + strings.Join(parts, " · ") + "\n\n"
+}
+
+// renderIssueChangesMD renders an issue's change timeline, oldest first
+func (m *Model) renderIssueChangesMD(issueID string) string {
+	var sb strings.Builder
+	sb.WriteString("### 🕰 Change History\n\n")
+
+	entry := m.issueChanges[issueID]
+	switch {
+	case entry == nil || entry.loading:
+		sb.WriteString("*Loading git history…*\n")
+		return sb.String()
+	case entry.err != nil:
+		sb.WriteString(fmt.Sprintf("*History unavailable: %v*\n", entry.err))
+		return sb.String()
+	case len(entry.changes) == 0:
+		sb.WriteString("*No changes to this issue found in git history.*\n")
+		return sb.String()
+	}
+
+	for _, change := range entry.changes {
+		sha := change.CommitSHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		sb.WriteString(fmt.Sprintf("- **%s** `%s` @%s — %s\n",
+			change.Timestamp.Local().Format("2006-01-02 15:04"),
+			sha,
+			change.Author,
+			truncateString(change.CommitMsg, 50),
+		))
+		if change.Created {
+			sb.WriteString("  - 🟢 created\n")
+		}
+		if change.Deleted {
+			sb.WriteString("  - 🗑 removed from beads file\n")
+		}
+		for _, fc := range change.Changes {
+			sb.WriteString("  - " + formatFieldChangeMD(fc) + "\n")
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatFieldChangeMD describes a single field change; long text fields are
+// summarized rather than shown in full
+func formatFieldChangeMD(fc correlation.FieldChange) string {
+	orNone := func(v string) string {
+		if v == "" {
+			return "(none)"
+		}
+		return v
+	}
+	switch fc.Field {
+	case "status":
+		return fmt.Sprintf("%s status: %s → **%s**", getStatusChangeIcon(fc.NewValue), orNone(fc.OldValue), fc.NewValue)
+	case "description":
+		delta := len([]rune(fc.NewValue)) - len([]rune(fc.OldValue))
+		return fmt.Sprintf("📝 description edited (%+d chars)", delta)
+	case "title":
+		return fmt.Sprintf("✏️ title: %q → %q", truncateString(fc.OldValue, 40), truncateString(fc.NewValue, 40))
+	default:
+		return fmt.Sprintf("%s: %s → **%s**", fc.Field, orNone(fc.OldValue), orNone(fc.NewValue))
+	}
+}
+
+// getStatusChangeIcon returns an icon for the status an issue moved to
+func getStatusChangeIcon(status string) string {
+	switch model.Status(status) {
+	case model.StatusInProgress:
+		return getEventIcon(correlation.EventClaimed)
+	case model.StatusClosed:
+		return getEventIcon(correlation.EventClosed)
+	case model.StatusOpen:
+		return getEventIcon(correlation.EventReopened)
+	default:
+		return "•"
+	}
+}
+
 // renderBeadHistoryMD generates markdown for a bead's history
 func (m *Model) renderBeadHistoryMD(beadID string) string {
 	hist := m.historyView.GetHistoryForBead(beadID)
@@ -5505,11 +7081,133 @@ func (m Model) FilteredIssues() []model.Issue {
 	return issues
 }
 
+// SetTextIndex marks the issues as loaded without their text fields
+// (loader.LoadIssuesLazy). Descriptions, notes and comments are then read
+// from disk when an issue is opened, and reloads stay lazy.
+func (m *Model) SetTextIndex(index *loader.TextIndex) {
+	m.textIndex = index
+}
+
+// SetShowArchived records that the issues were loaded with the archive
+// included (--include-archived), so reloads keep it.
+func (m *Model) SetShowArchived(show bool) {
+	m.showArchived = show
+}
+
+// OpenTutorial starts the model with the interactive tutorial open, as
+// after first-run setup.
+func (m *Model) OpenTutorial() {
+	m.showTutorial = true
+	m.focused = focusTutorial
+}
+
+// hydrateIssue returns issue with its text fields read from disk when the
+// model loads them lazily. Past snapshots always carry the full text. An
+// error means the text could not be read, usually because the file changed
+// on disk before the watcher reload rebuilt the index; the returned issue
+// then lacks its text and must not be written back or copied.
+func (m *Model) hydrateIssue(issue model.Issue) (model.Issue, error) {
+	if m.textIndex == nil || m.pastSnapshot != nil {
+		return issue, nil
+	}
+	err := m.textIndex.Hydrate(&issue)
+	return issue, err
+}
+
+// loadIssuesFromDisk re-reads the beads file, keeping text fields lazy when
+// the model was started with a text index.
+func (m *Model) loadIssuesFromDisk(opts loader.ParseOptions) ([]model.Issue, error) {
+	opts.IncludeDeleted = m.showDeleted
+	opts.IncludeArchived = m.showArchived
+	m.deletedCount = countDeletions(m.beadsPath)
+	if m.textIndex == nil {
+		return loader.LoadIssuesFromFileWithOptions(m.beadsPath, opts)
+	}
+	issues, index, err := loader.LoadIssuesLazy(m.beadsPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	m.textIndex = index
+	return issues, nil
+}
+
+// countDeletions returns the number of entries in the deletions manifest
+// next to beadsPath.
+func countDeletions(beadsPath string) int {
+	if beadsPath == "" {
+		return 0
+	}
+	deletions, err := loader.LoadDeletions(filepath.Dir(beadsPath))
+	if err != nil {
+		return 0
+	}
+	return len(deletions)
+}
+
+// toggleShowDeleted reloads the issues with deleted ones included (as
+// tombstones) or left out.
+func (m *Model) toggleShowDeleted() []tea.Cmd {
+	if m.beadsPath == "" || m.pastSnapshot != nil {
+		m.statusMsg = "Deleted issues can only be toggled for a live beads file"
+		m.statusIsError = true
+		return nil
+	}
+	m.showDeleted = !m.showDeleted
+	issues, err := m.loadIssuesFromDisk(loader.ParseOptions{
+		WarningHandler: func(string) {},
+	})
+	if err != nil {
+		m.showDeleted = !m.showDeleted
+		m.statusMsg = fmt.Sprintf("Reload error: %v", err)
+		m.statusIsError = true
+		return nil
+	}
+	_, cmds := m.replaceIssues(issues)
+	if m.showDeleted {
+		m.statusMsg = fmt.Sprintf("Showing deleted issues (%d in deletions manifest)", m.deletedCount)
+	} else {
+		m.statusMsg = "Hiding deleted issues"
+	}
+	m.statusIsError = false
+	return cmds
+}
+
+// toggleShowArchived reloads the issues with those moved to archive.jsonl
+// included or left out.
+func (m *Model) toggleShowArchived() []tea.Cmd {
+	if m.beadsPath == "" || m.pastSnapshot != nil {
+		m.statusMsg = "Archived issues can only be toggled for a live beads file"
+		m.statusIsError = true
+		return nil
+	}
+	m.showArchived = !m.showArchived
+	before := len(m.issues)
+	issues, err := m.loadIssuesFromDisk(loader.ParseOptions{
+		WarningHandler: func(string) {},
+	})
+	if err != nil {
+		m.showArchived = !m.showArchived
+		m.statusMsg = fmt.Sprintf("Reload error: %v", err)
+		m.statusIsError = true
+		return nil
+	}
+	_, cmds := m.replaceIssues(issues)
+	if m.showArchived {
+		m.statusMsg = fmt.Sprintf("Showing archived issues (%d from %s)", max(0, len(issues)-before), loader.ArchiveFileName)
+	} else {
+		m.statusMsg = "Hiding archived issues"
+	}
+	m.statusIsError = false
+	return cmds
+}
+
 // EnableWorkspaceMode configures the model for workspace (multi-repo) view
 func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 	m.workspaceMode = info.Enabled
 	m.availableRepos = normalizeRepoPrefixes(info.RepoPrefixes)
-	m.activeRepos = nil // nil means all repos are active
+	m.workspaceRepos = info.Repos
+	m.workspaceState = info.StatePath
+	m.activeRepos = loadWorkspaceRepoSelection(info.StatePath, m.availableRepos) // nil means all repos are active
 
 	if info.RepoCount > 0 {
 		if info.FailedCount > 0 {
@@ -5519,8 +7217,18 @@ func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 		}
 	}
 
-	// Update delegate to show repo badges
+	// Update delegate, board and graph to show repo badges
 	m.updateListDelegate()
+	m.board.SetWorkspaceMode(m.workspaceMode)
+	m.graphView.SetWorkspaceMode(m.workspaceMode)
+
+	if m.activeRepos != nil {
+		if m.activeRecipe != nil {
+			m.applyRecipe(m.activeRecipe)
+		} else {
+			m.applyFilter()
+		}
+	}
 }
 
 // IsWorkspaceMode returns whether workspace mode is active
@@ -5562,6 +7270,7 @@ func (m *Model) enterHistoryView() {
 
 	// Initialize or update history view
 	m.historyView = NewHistoryModel(report, m.theme)
+	m.historyView.SetFeedbackStore(m.correlationFeedback)
 	m.historyView.SetSize(m.width, m.height-1)
 	m.isHistoryView = true
 	m.focused = focusHistory
@@ -5570,6 +7279,273 @@ func (m *Model) enterHistoryView() {
 	m.statusIsError = false
 }
 
+// replaceIssues swaps in a new dataset and rebuilds every view derived from it.
+// It returns whether the analysis came from cache and the follow-up commands
+// (background indexing, Phase 2 wait).
+func (m *Model) replaceIssues(newIssues []model.Issue) (bool, []tea.Cmd) {
+	var cmds []tea.Cmd
+
+	// Store selected issue ID to restore position after reload
+	var selectedID string
+	if sel := m.list.SelectedItem(); sel != nil {
+		if item, ok := sel.(IssueItem); ok {
+			selectedID = item.Issue.ID
+		}
+	}
+
+	// Apply default sorting (Open first, Priority, Date)
+	sort.Slice(newIssues, func(i, j int) bool {
+		iClosed := newIssues[i].Status == model.StatusClosed
+		jClosed := newIssues[j].Status == model.StatusClosed
+		if iClosed != jClosed {
+			return !iClosed
+		}
+		if newIssues[i].Priority != newIssues[j].Priority {
+			return newIssues[i].Priority < newIssues[j].Priority
+		}
+		return newIssues[i].CreatedAt.After(newIssues[j].CreatedAt)
+	})
+
+	// Recompute analysis (async Phase 1/Phase 2) with caching
+	m.issues = newIssues
+	m.lazyLoad = isLargeIssueSet(newIssues)
+	cachedAnalyzer := analysis.NewCachedAnalyzer(analysisScope(newIssues), nil)
+	m.analyzer = cachedAnalyzer.Analyzer
+	m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
+	cacheHit := cachedAnalyzer.WasCacheHit()
+	m.labelHealthCached = false
+	m.attentionCached = false
+
+	// Rebuild lookup map
+	m.issueMap = make(map[string]*model.Issue, len(newIssues))
+	for i := range m.issues {
+		m.issueMap[m.issues[i].ID] = &m.issues[i]
+	}
+
+	// Clear stale priority hints (will be repopulated after Phase 2)
+	m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
+	m.invalidateRiskScores()
+	m.etaEstimates = nil
+
+	// Recompute stats
+	m.countOpen, m.countReady, m.countBlocked, m.countClosed = 0, 0, 0, 0
+	for i := range m.issues {
+		issue := &m.issues[i]
+		if issue.Status == model.StatusClosed {
+			m.countClosed++
+			continue
+		}
+		m.countOpen++
+		if issue.Status == model.StatusBlocked {
+			m.countBlocked++
+			continue
+		}
+		isBlocked := false
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+				isBlocked = true
+				break
+			}
+		}
+		if !isBlocked {
+			m.countReady++
+		}
+	}
+
+	// Recompute alerts for refreshed dataset
+	m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
+	m.dismissedAlerts = make(map[string]bool)
+	m.showAlertsPanel = false
+
+	// Rebuild list items
+	items := make([]list.Item, len(m.issues))
+	for i := range m.issues {
+		items[i] = IssueItem{
+			Issue:      m.issues[i],
+			GraphScore: m.analysis.GetPageRankScore(m.issues[i].ID),
+			Impact:     m.analysis.GetCriticalPathScore(m.issues[i].ID),
+			RepoPrefix: ExtractRepoPrefix(m.issues[i].ID),
+			LocalNote:  m.notes[m.issues[i].ID],
+		}
+	}
+	m.updateSemanticIDs(items)
+	m.clearSemanticScores()
+	if m.semanticSearch != nil {
+		m.semanticSearch.ResetCache()
+		m.semanticSearch.SetMetricsCache(nil)
+	}
+	m.semanticHybridReady = false
+	m.semanticHybridBuilding = false
+	if m.semanticHybridEnabled {
+		m.semanticHybridBuilding = true
+		cmds = append(cmds, BuildHybridMetricsCmd(m.issues))
+	}
+	m.list.SetItems(items)
+
+	// Restore selection position
+	if selectedID != "" {
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+
+	// Regenerate sub-views (with Phase 1 data; Phase 2 will update via Phase2ReadyMsg)
+	ins := m.analysis.GenerateInsights(len(m.issues))
+	m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
+	bodyHeight := m.height - 1
+	if bodyHeight < 5 {
+		bodyHeight = 5
+	}
+	m.insightsPanel.SetSize(m.width, bodyHeight)
+	m.graphView.SetIssues(m.issues, &ins)
+
+	// Generate priority recommendations now that Phase 2 is ready
+	m.epicProgress = computeEpicProgress(m.issues)
+	m.openBlockers = computeOpenBlockers(m.analyzer, m.issues)
+	m.updateListDelegate()
+	m.board = NewBoardModel(m.issues, m.theme)
+	m.board.SetWorkspaceMode(m.workspaceMode)
+	m.board.SetEpicProgress(m.epicProgress)
+	m.boardPending = false
+
+	// Re-apply recipe filter if active
+	if m.activeRecipe != nil {
+		m.applyRecipe(m.activeRecipe)
+	}
+
+	// Reload sprints (bv-161)
+	if m.beadsPath != "" {
+		beadsDir := filepath.Dir(m.beadsPath)
+		if loaded, err := loader.LoadSprintsFromFile(filepath.Join(beadsDir, loader.SprintsFileName)); err == nil {
+			m.sprints = loaded
+			// If we have a selected sprint, try to refresh it
+			if m.selectedSprint != nil {
+				found := false
+				for i := range m.sprints {
+					if m.sprints[i].ID == m.selectedSprint.ID {
+						m.selectedSprint = &m.sprints[i]
+						m.sprintViewText = m.renderSprintDashboard()
+						found = true
+						break
+					}
+				}
+				if !found {
+					m.selectedSprint = nil
+					m.sprintViewText = "Sprint not found"
+				}
+			}
+		}
+	}
+
+	// Keep semantic index current when enabled or built for a ~query.
+	m.semanticIndexErr = nil
+	if (m.semanticSearchEnabled || (m.semanticSearch != nil && m.semanticSearch.Snapshot().Ready)) && !m.semanticIndexBuilding {
+		m.semanticIndexBuilding = true
+		cmds = append(cmds, BuildSemanticIndexCmd(m.issues))
+	}
+	if m.similarIndex != nil && !m.similarIndexBuilding {
+		m.similarIndexBuilding = true
+		cmds = append(cmds, BuildSimilarityIndexCmd(m.issues))
+	}
+	// Correlate new beads; cached ones are reused
+	if m.cassCorrelator != nil && !m.cassIndexing {
+		m.cassIndexing = true
+		cmds = append(cmds, CassIndexCmd(m.issues, m.workDir, m.cassCorrelator))
+	}
+	if cmd := m.pluginsCmd(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	// Re-detect the branch: reloads follow commits and checkouts
+	if m.beadsPath != "" && !m.workspaceMode {
+		cmds = append(cmds, LoadBranchContextCmd(m.issues, m.beadsPath))
+	}
+
+	// Invalidate label-derived caches and change timelines (new commits)
+	m.labelHealthCached = false
+	m.labelDrilldownCache = make(map[string][]model.Issue)
+	m.issueChanges = nil
+	m.blameLoaded = false
+	m.updateViewportContent()
+
+	cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
+	return cacheHit, cmds
+}
+
+// enterPastSnapshot shows the beads data as of a past commit in every view.
+// The live dataset is stashed and restored by exitPastSnapshot.
+func (m *Model) enterPastSnapshot(snapshot PastSnapshot, issues []model.Issue) []tea.Cmd {
+	if m.pastSnapshot == nil {
+		m.pastLiveIssues = m.issues
+	}
+
+	// The diff overlay compares against live data; it makes no sense here
+	m.timeTravelMode = false
+	m.timeTravelDiff = nil
+	m.timeTravelSince = ""
+	m.newIssueIDs = nil
+	m.closedIssueIDs = nil
+	m.modifiedIssueIDs = nil
+	m.clearAttentionOverlay()
+
+	m.pastSnapshot = &snapshot
+	_, cmds := m.replaceIssues(issues)
+
+	m.isHistoryView = false
+	m.focused = focusList
+	m.statusMsg = fmt.Sprintf("⏪ Viewing %s: %d issues (read-only)", snapshot.ShortSHA, len(issues))
+	m.statusIsError = false
+	return cmds
+}
+
+// exitPastSnapshot restores the live dataset, reloading it from disk if the
+// beads file changed while the past was being viewed.
+func (m *Model) exitPastSnapshot() []tea.Cmd {
+	if m.pastSnapshot == nil {
+		return nil
+	}
+	live := m.pastLiveIssues
+	if m.pastLiveStale && m.beadsPath != "" {
+		reloaded, err := m.loadIssuesFromDisk(loader.ParseOptions{
+			WarningHandler: func(string) {},
+		})
+		if err == nil {
+			live = reloaded
+		}
+	}
+	m.pastSnapshot = nil
+	m.pastLiveIssues = nil
+	m.pastLiveStale = false
+	m.clearAttentionOverlay()
+
+	_, cmds := m.replaceIssues(live)
+	m.statusMsg = fmt.Sprintf("⏩ Back to present: %d issues", len(live))
+	m.statusIsError = false
+	return cmds
+}
+
+// selectedHistorySnapshot returns the commit selected in the history view
+func (m Model) selectedHistorySnapshot() (PastSnapshot, bool) {
+	if m.historyView.IsGitMode() {
+		if commit := m.historyView.SelectedGitCommit(); commit != nil {
+			return PastSnapshot{SHA: commit.SHA, ShortSHA: commit.ShortSHA, Subject: commit.Message}, true
+		}
+	} else if commit := m.historyView.SelectedCommit(); commit != nil {
+		return PastSnapshot{SHA: commit.SHA, ShortSHA: commit.ShortSHA, Subject: commit.Message}, true
+	}
+	return PastSnapshot{}, false
+}
+
+// ViewingPast returns the snapshot being browsed, or nil when showing live data
+func (m Model) ViewingPast() *PastSnapshot {
+	return m.pastSnapshot
+}
+
 // enterTimeTravelMode loads historical data and computes diff
 func (m *Model) enterTimeTravelMode(revision string) {
 	cwd, err := os.Getwd()
@@ -5679,8 +7655,12 @@ func (m *Model) exportToMarkdown() {
 	// Generate smart filename: beads_report_<project>_YYYY-MM-DD.md
 	filename := m.generateExportFilename()
 
-	// Export the issues
-	err := export.SaveMarkdownToFile(m.issues, filename)
+	// Export the issues, branded by .bv/branding.yaml if there is one
+	cwd, _ := os.Getwd()
+	brand, err := export.LoadBranding(cwd)
+	if err == nil {
+		err = export.SaveBrandedMarkdownToFile(m.issues, filename, brand)
+	}
 	if err != nil {
 		m.statusMsg = fmt.Sprintf("❌ Export failed: %v", err)
 		m.statusIsError = true
@@ -5760,66 +7740,7 @@ func (m Model) renderTimeTravelPrompt() string {
 
 // copyIssueToClipboard copies the selected issue to clipboard as Markdown
 func (m *Model) copyIssueToClipboard() {
-	selectedItem := m.list.SelectedItem()
-	if selectedItem == nil {
-		m.statusMsg = "❌ No issue selected"
-		m.statusIsError = true
-		return
-	}
-
-	issueItem, ok := selectedItem.(IssueItem)
-	if !ok {
-		m.statusMsg = "❌ Invalid item type"
-		m.statusIsError = true
-		return
-	}
-	issue := issueItem.Issue
-
-	// Format issue as Markdown
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("# %s %s\n\n", GetTypeIconMD(string(issue.IssueType)), issue.Title))
-	sb.WriteString(fmt.Sprintf("**ID:** %s  \n", issue.ID))
-	sb.WriteString(fmt.Sprintf("**Status:** %s  \n", strings.ToUpper(string(issue.Status))))
-	sb.WriteString(fmt.Sprintf("**Priority:** P%d  \n", issue.Priority))
-	if issue.Assignee != "" {
-		sb.WriteString(fmt.Sprintf("**Assignee:** @%s  \n", issue.Assignee))
-	}
-	sb.WriteString(fmt.Sprintf("**Created:** %s  \n", issue.CreatedAt.Format("2006-01-02")))
-
-	if len(issue.Labels) > 0 {
-		sb.WriteString(fmt.Sprintf("**Labels:** %s  \n", strings.Join(issue.Labels, ", ")))
-	}
-
-	if issue.Description != "" {
-		sb.WriteString(fmt.Sprintf("\n## Description\n\n%s\n", issue.Description))
-	}
-
-	if issue.AcceptanceCriteria != "" {
-		sb.WriteString(fmt.Sprintf("\n## Acceptance Criteria\n\n%s\n", issue.AcceptanceCriteria))
-	}
-
-	// Dependencies
-	if len(issue.Dependencies) > 0 {
-		sb.WriteString("\n## Dependencies\n\n")
-		for _, dep := range issue.Dependencies {
-			if dep == nil {
-				continue
-			}
-			sb.WriteString(fmt.Sprintf("- %s (%s)\n", dep.DependsOnID, dep.Type))
-		}
-	}
-
-	// Copy to clipboard
-	err := clipboard.WriteAll(sb.String())
-	if err != nil {
-		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-		m.statusIsError = true
-		return
-	}
-
-	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", issue.ID)
-	m.statusIsError = false
+	m.copySelectedIssue(copyMarkdown)
 }
 
 // showCassSessionModal shows the cass session preview modal for the selected issue (bv-5bqh)
@@ -5865,6 +7786,7 @@ func (m *Model) showCassSessionModal() {
 
 	// Create and show the modal
 	m.cassModal = NewCassSessionModal(issue.ID, result, m.theme)
+	m.cassModal.SetFeedbackStore(m.correlationFeedback)
 	m.cassModal.SetSize(m.width, m.height)
 	m.showCassModal = true
 	m.focused = focusCassModal
@@ -5909,7 +7831,48 @@ func (m *Model) getCassSessionCount() int {
 	if hint := m.cassCorrelator.GetCached(issueItem.Issue.ID); hint != nil {
 		return hint.ResultCount
 	}
-	return 0
+	return m.cassSessionCounts[issueItem.Issue.ID]
+}
+
+// CassIndexReadyMsg carries the background cass correlation of open beads.
+// Correlator is nil when cass is not available.
+type CassIndexReadyMsg struct {
+	Correlator *cass.Correlator
+	Counts     map[string]int
+}
+
+// cassIndexTimeout bounds one background correlation pass
+const cassIndexTimeout = 2 * time.Minute
+
+// CassIndexCmd correlates all open issues with cass sessions in the
+// background (bounded concurrency), so the session modal opens instantly and
+// list rows can show session counts. A nil correlator is created if cass is
+// healthy; otherwise the pass ends silently.
+func CassIndexCmd(issues []model.Issue, workDir string, correlator *cass.Correlator) tea.Cmd {
+	return func() tea.Msg {
+		var open []model.Issue
+		for _, issue := range issues {
+			if !issue.Status.IsClosed() {
+				open = append(open, issue)
+			}
+		}
+
+		if correlator == nil {
+			detector := cass.NewDetector()
+			if detector.Check() != cass.StatusHealthy {
+				return CassIndexReadyMsg{}
+			}
+			cache := cass.NewCacheWithOptions(cass.WithResultCacheSize(max(cass.DefaultResultCacheSize, len(open))))
+			correlator = cass.NewCorrelator(cass.NewSearcher(detector), cache, workDir)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cassIndexTimeout)
+		defer cancel()
+		return CassIndexReadyMsg{
+			Correlator: correlator,
+			Counts:     correlator.CorrelateAll(ctx, open, cass.DefaultIndexConcurrency),
+		}
+	}
 }
 
 // openInEditor opens the beads file in the user's preferred editor
@@ -6200,6 +8163,9 @@ func (m *Model) RenderDebugView(viewName string, width, height int) string {
 	switch viewName {
 	case "insights":
 		m.insightsPanel.SetSize(width, height-1)
+		m.insightsPanel.SetDeletedCount(m.deletedCount)
+		m.insightsPanel.SetFeedbackStats(m.correlationFeedbackStats())
+		m.insightsPanel.SetPriorityHints(m.priorityHints)
 		return m.insightsPanel.View()
 	case "board":
 		return m.board.View(width, height-1)
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxNavHistory bounds the jump list; the oldest entries are dropped first
+const maxNavHistory = 100
+
+// navHistory is a vim-style jump list of issue IDs. Jumps between issues
+// (graph, insights, board, search, linked references) are recorded;
+// moving through the list with j/k is not.
+type navHistory struct {
+	entries []string
+	pos     int // Index of the current entry; meaningless when entries is empty
+}
+
+// current returns the issue at the cursor, or "" when nothing was recorded
+func (h *navHistory) current() string {
+	if len(h.entries) == 0 {
+		return ""
+	}
+	return h.entries[h.pos]
+}
+
+// visit makes id the current entry, discarding any forward history
+func (h *navHistory) visit(id string) {
+	if id == "" || id == h.current() {
+		return
+	}
+	if len(h.entries) > 0 {
+		h.entries = h.entries[:h.pos+1]
+	}
+	h.entries = append(h.entries, id)
+	if len(h.entries) > maxNavHistory {
+		h.entries = h.entries[len(h.entries)-maxNavHistory:]
+	}
+	h.pos = len(h.entries) - 1
+}
+
+// record notes a jump from one issue to another
+func (h *navHistory) record(from, to string) {
+	h.visit(from)
+	h.visit(to)
+}
+
+// back steps to the previous entry. here is the issue currently shown; if
+// the user moved away from the current entry it is recorded first so that
+// forward returns to it.
+func (h *navHistory) back(here string) (string, bool) {
+	h.visit(here)
+	if h.pos == 0 || len(h.entries) == 0 {
+		return "", false
+	}
+	h.pos--
+	return h.entries[h.pos], true
+}
+
+// forward steps to the next entry after a back
+func (h *navHistory) forward() (string, bool) {
+	if h.pos+1 >= len(h.entries) {
+		return "", false
+	}
+	h.pos++
+	return h.entries[h.pos], true
+}
+
+// remove drops an issue that no longer exists from the history
+func (h *navHistory) remove(id string) {
+	kept := h.entries[:0]
+	pos := h.pos
+	for i, e := range h.entries {
+		if e == id {
+			if i <= h.pos && pos > 0 {
+				pos--
+			}
+			continue
+		}
+		kept = append(kept, e)
+	}
+	h.entries = kept
+	h.pos = pos
+	if h.pos >= len(h.entries) {
+		h.pos = len(h.entries) - 1
+	}
+	if h.pos < 0 {
+		h.pos = 0
+	}
+}
+
+// breadcrumb renders up to max entries ending at the cursor, with the
+// current entry in bold and a count of forward entries
+func (h *navHistory) breadcrumb(max int) string {
+	if len(h.entries) < 2 {
+		return ""
+	}
+	start := h.pos - max + 1
+	if start < 0 {
+		start = 0
+	}
+	parts := make([]string, 0, max+2)
+	if start > 0 {
+		parts = append(parts, "…")
+	}
+	for i := start; i < h.pos; i++ {
+		parts = append(parts, h.entries[i])
+	}
+	parts = append(parts, "**"+h.entries[h.pos]+"**")
+	if ahead := len(h.entries) - h.pos - 1; ahead > 0 {
+		parts = append(parts, fmt.Sprintf("(+%d ahead)", ahead))
+	}
+	return strings.Join(parts, " › ")
+}
+
+// selectedIssueID returns the ID of the issue selected in the list
+func (m *Model) selectedIssueID() string {
+	if sel, ok := m.list.SelectedItem().(IssueItem); ok {
+		return sel.Issue.ID
+	}
+	return ""
+}
+
+// jumpToIssue selects id in the list and records the jump in the
+// navigation history. It reports whether the issue is in the list.
+func (m *Model) jumpToIssue(id string) bool {
+	from := m.selectedIssueID()
+	if !m.selectIssueInList(id) {
+		return false
+	}
+	m.nav.record(from, id)
+	return true
+}
+
+// showIssueDetails selects id, clearing filters if they hide it, and shows
+// its details in place of whichever view was open. It reports whether the
+// issue is in the list.
+func (m *Model) showIssueDetails(id string) bool {
+	if !m.jumpToIssue(id) {
+		m.clearAllFilters()
+		if !m.jumpToIssue(id) {
+			return false
+		}
+	}
+
+	m.clearAttentionOverlay()
+	m.isBoardView = false
+	m.isGraphView = false
+	m.isActionableView = false
+	m.isHistoryView = false
+	m.focused = focusDetail
+	if !m.isSplitView {
+		m.showDetails = true
+	}
+	m.updateViewportContent()
+	m.viewport.GotoTop()
+	return true
+}
+
+// navigateHistory moves back (-1) or forward (+1) through the jump list and
+// shows the issue there, clearing filters if they hide it
+func (m *Model) navigateHistory(dir int) {
+	for {
+		var id string
+		var ok bool
+		if dir < 0 {
+			id, ok = m.nav.back(m.selectedIssueID())
+		} else {
+			id, ok = m.nav.forward()
+		}
+		if !ok {
+			if dir < 0 {
+				m.statusMsg = "🧭 At the start of the jump list"
+			} else {
+				m.statusMsg = "🧭 At the end of the jump list"
+			}
+			m.statusIsError = false
+			return
+		}
+		if _, exists := m.issueMap[id]; !exists {
+			// Deleted since it was visited
+			m.nav.remove(id)
+			continue
+		}
+		if !m.selectIssueInList(id) {
+			m.clearAllFilters()
+			m.selectIssueInList(id)
+		}
+		break
+	}
+
+	if m.isSplitView || m.showDetails {
+		m.updateViewportContent()
+		m.viewport.GotoTop()
+	}
+	m.statusMsg = fmt.Sprintf("🧭 %d/%d %s", m.nav.pos+1, len(m.nav.entries), m.nav.current())
+	m.statusIsError = false
+}
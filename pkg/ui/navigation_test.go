@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNavHistory(t *testing.T) {
+	var h navHistory
+	if _, ok := h.back(""); ok {
+		t.Fatal("back on an empty history should fail")
+	}
+
+	h.record("A", "B")
+	h.record("B", "C")
+	if strings.Join(h.entries, ",") != "A,B,C" || h.current() != "C" {
+		t.Fatalf("unexpected history %v at %d", h.entries, h.pos)
+	}
+
+	if id, ok := h.back("C"); !ok || id != "B" {
+		t.Fatalf("back = %q, %v", id, ok)
+	}
+	if id, _ := h.back("B"); id != "A" {
+		t.Fatalf("second back = %q", id)
+	}
+	if _, ok := h.back("A"); ok {
+		t.Fatal("back at the start should fail")
+	}
+	if id, _ := h.forward(); id != "B" {
+		t.Fatalf("forward = %q", id)
+	}
+	if got := h.breadcrumb(5); got != "A › **B** › (+1 ahead)" {
+		t.Errorf("breadcrumb = %q", got)
+	}
+
+	// A new jump after going back discards the forward entries
+	h.record("B", "D")
+	if strings.Join(h.entries, ",") != "A,B,D" {
+		t.Fatalf("forward history not truncated: %v", h.entries)
+	}
+	if _, ok := h.forward(); ok {
+		t.Fatal("forward at the end should fail")
+	}
+
+	// Moving away with j/k records the current issue before going back
+	if id, _ := h.back("E"); id != "D" || strings.Join(h.entries, ",") != "A,B,D,E" {
+		t.Fatalf("back from an unrecorded issue = %q, %v", id, h.entries)
+	}
+
+	h.remove("D")
+	if strings.Join(h.entries, ",") != "A,B,E" || h.current() != "B" {
+		t.Fatalf("remove = %v at %d", h.entries, h.pos)
+	}
+
+	for i := 0; i < maxNavHistory+10; i++ {
+		h.visit(fmt.Sprintf("X%d", i))
+	}
+	if len(h.entries) != maxNavHistory || h.current() != fmt.Sprintf("X%d", maxNavHistory+9) {
+		t.Errorf("history not capped: %d entries, current %q", len(h.entries), h.current())
+	}
+}
+
+func TestJumpListNavigation(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, IssueType: model.TypeTask, Description: "Needs bv-b."},
+		{ID: "bv-b", Title: "Beta", Status: model.StatusClosed, IssueType: model.TypeTask},
+		{ID: "C", Title: "Gamma", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 80, 120
+	m.splitOrientation = SplitVertical
+	m.resizePanes()
+	m.showDetails = true
+	m.focused = focusDetail
+	m.selectIssueInList("A")
+	m.updateViewportContent()
+
+	send := func(msg tea.KeyMsg) {
+		tm, _ := m.Update(msg)
+		m = tm.(Model)
+	}
+	runes := func(k string) { send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+
+	// gd into a closed issue hidden by the open filter
+	m.currentFilter = "open"
+	m.applyFilter()
+	m.selectIssueInList("A")
+	m.updateViewportContent()
+	runes("g")
+	runes("d")
+	if m.selectedIssueID() != "bv-b" {
+		t.Fatalf("gd should open bv-b, got %q", m.selectedIssueID())
+	}
+	if !strings.Contains(m.viewport.View(), "ctrl+o") {
+		t.Error("expected a breadcrumb in the detail view")
+	}
+
+	// A jump from another view is recorded too
+	m.jumpToIssue("C")
+
+	send(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.selectedIssueID() != "bv-b" {
+		t.Fatalf("ctrl+o should go back to bv-b, got %q", m.selectedIssueID())
+	}
+	send(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.selectedIssueID() != "A" {
+		t.Fatalf("second ctrl+o should go back to A, got %q", m.selectedIssueID())
+	}
+	send(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.selectedIssueID() != "A" || !strings.Contains(m.statusMsg, "start") {
+		t.Fatalf("ctrl+o at the start should stay, got %q (%q)", m.selectedIssueID(), m.statusMsg)
+	}
+
+	// Ctrl+i arrives as tab; outside the split view it goes forward
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	if m.selectedIssueID() != "bv-b" {
+		t.Fatalf("ctrl+i should go forward to bv-b, got %q", m.selectedIssueID())
+	}
+	send(tea.KeyMsg{Type: tea.KeyCtrlCloseBracket})
+	if m.selectedIssueID() != "C" {
+		t.Fatalf("ctrl+] should go forward to C, got %q", m.selectedIssueID())
+	}
+}
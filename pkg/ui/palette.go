@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteKind is what choosing a palette entry does
+type paletteKind int
+
+const (
+	paletteAction   paletteKind = iota // Press the binding's key
+	paletteTutorial                    // Open the tutorial at a page
+	paletteIssue                       // Show an issue's details
+)
+
+// paletteEntry is one choice in the command palette
+type paletteEntry struct {
+	kind   paletteKind
+	title  string
+	detail string // Section, tutorial chapter or issue ID
+	keys   string // Key label shown on the right, for actions
+	key    string // Action: key to press, as matched by the handlers
+	target string // Tutorial page ID or issue ID
+	search string // Text the query is matched against
+}
+
+// CommandPaletteModel is a fuzzy finder over every action of the current
+// view, the tutorial pages and the issues (Ctrl+P). Issues are only listed
+// once something is typed.
+type CommandPaletteModel struct {
+	commands      []paletteEntry
+	issues        []paletteEntry
+	filtered      []paletteEntry
+	input         textinput.Model
+	selectedIndex int
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewCommandPaletteModel creates an empty command palette
+func NewCommandPaletteModel(theme Theme) CommandPaletteModel {
+	ti := textinput.New()
+	ti.Placeholder = "type a command, tutorial topic or issue ID..."
+	ti.CharLimit = 80
+	ti.Width = 50
+	ti.Prompt = "> "
+
+	return CommandPaletteModel{
+		input: ti,
+		theme: theme,
+	}
+}
+
+// SetEntries fills the palette with the bindings of sections, the tutorial
+// pages and issues, and clears the query
+func (m *CommandPaletteModel) SetEntries(sections []KeymapSection, pages []TutorialPage, issues []model.Issue) {
+	m.commands = m.commands[:0]
+	for _, section := range sections {
+		for _, b := range section.Bindings {
+			// Sequences cannot be pressed as one key
+			if b.Action == "global.palette" || len(b.Keys) == 0 || strings.Contains(b.Keys[0], " ") {
+				continue
+			}
+			m.commands = append(m.commands, paletteEntry{
+				kind:   paletteAction,
+				title:  b.Help,
+				detail: section.Title,
+				keys:   b.KeysLabel(),
+				key:    b.Keys[0],
+				search: b.Help + " " + section.Title + " " + b.Action,
+			})
+		}
+	}
+	for _, page := range pages {
+		m.commands = append(m.commands, paletteEntry{
+			kind:   paletteTutorial,
+			title:  "Tutorial: " + page.Title,
+			detail: page.Section,
+			target: page.ID,
+			search: "Tutorial " + page.Title + " " + page.Section,
+		})
+	}
+
+	m.issues = make([]paletteEntry, 0, len(issues))
+	for _, issue := range issues {
+		m.issues = append(m.issues, paletteEntry{
+			kind:   paletteIssue,
+			title:  issue.Title,
+			detail: issue.ID,
+			target: issue.ID,
+			search: issue.ID + " " + issue.Title,
+		})
+	}
+
+	m.input.SetValue("")
+	m.input.Focus()
+	m.filterEntries()
+}
+
+// SetSize updates the palette dimensions
+func (m *CommandPaletteModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *CommandPaletteModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *CommandPaletteModel) MoveDown() {
+	if m.selectedIndex < len(m.filtered)-1 {
+		m.selectedIndex++
+	}
+}
+
+// Selected returns the selected entry, if any
+func (m *CommandPaletteModel) Selected() (paletteEntry, bool) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.filtered) {
+		return paletteEntry{}, false
+	}
+	return m.filtered[m.selectedIndex], true
+}
+
+// UpdateInput processes a key message for the query input
+func (m *CommandPaletteModel) UpdateInput(msg tea.Msg) {
+	m.input, _ = m.input.Update(msg)
+	m.filterEntries()
+}
+
+// filterEntries ranks the entries against the query. Commands come before
+// issues with the same score.
+func (m *CommandPaletteModel) filterEntries() {
+	m.selectedIndex = 0
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.filtered = m.commands
+		return
+	}
+
+	type scored struct {
+		entry paletteEntry
+		score int
+	}
+	var matches []scored
+	for _, group := range [][]paletteEntry{m.commands, m.issues} {
+		for _, entry := range group {
+			if score := fuzzyScore(entry.search, query); score > 0 {
+				matches = append(matches, scored{entry, score})
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	m.filtered = make([]paletteEntry, len(matches))
+	for i, match := range matches {
+		m.filtered[i] = match.entry
+	}
+}
+
+// View renders the command palette
+func (m *CommandPaletteModel) View() string {
+	t := m.theme
+
+	boxWidth := min(72, m.width-4)
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+	innerWidth := boxWidth - 6 // border and padding
+	maxVisible := min(12, m.height-12)
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	inputStyle := t.Renderer.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(t.Secondary).
+		Padding(0, 1).
+		Width(innerWidth - 2)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Command Palette"), "")
+	lines = append(lines, inputStyle.Render(m.input.View()), "")
+
+	if len(m.filtered) == 0 {
+		lines = append(lines, hintStyle.Render("  No matching commands or issues"))
+	} else {
+		start := 0
+		if m.selectedIndex >= maxVisible {
+			start = m.selectedIndex - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(m.filtered))
+
+		for i := start; i < end; i++ {
+			entry := m.filtered[i]
+			selected := i == m.selectedIndex
+
+			prefix := "  "
+			itemStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+			if selected {
+				prefix = "> "
+				itemStyle = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+			}
+
+			right := entry.keys
+			if entry.kind != paletteAction || right == "" {
+				right = entry.detail
+			} else {
+				right = entry.detail + "  " + right
+			}
+			rightWidth := min(lipgloss.Width(right), innerWidth/2)
+			right = truncateRunesHelper(right, rightWidth, "…")
+			titleWidth := innerWidth - len(prefix) - rightWidth - 2
+			title := truncateRunesHelper(entry.title, titleWidth, "…")
+			pad := innerWidth - len(prefix) - lipgloss.Width(title) - lipgloss.Width(right)
+			if pad < 1 {
+				pad = 1
+			}
+			lines = append(lines, itemStyle.Render(prefix+title)+strings.Repeat(" ", pad)+mutedStyle.Render(right))
+		}
+
+		if len(m.filtered) > maxVisible {
+			lines = append(lines, "", hintStyle.Render(fmt.Sprintf("  (%d/%d)", m.selectedIndex+1, len(m.filtered))))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Render("↑/↓: navigate | enter: run | esc: close"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openCommandPalette lists the actions of the current view in the palette
+func (m *Model) openCommandPalette() {
+	m.palette.SetEntries(m.keymap.Sections(m.CurrentContext()), m.tutorialModel.pages, m.issues)
+	m.palette.SetSize(m.width, m.height-1)
+	m.showPalette = true
+}
+
+// handlePaletteKeys handles keys while the command palette is open. Every
+// key other than the navigation keys goes to the query.
+func (m Model) handlePaletteKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "ctrl+p":
+		m.showPalette = false
+	case "down", "ctrl+n", "tab":
+		m.palette.MoveDown()
+	case "up", "shift+tab":
+		m.palette.MoveUp()
+	case "enter":
+		entry, ok := m.palette.Selected()
+		if !ok {
+			return m, nil
+		}
+		m.showPalette = false
+		return m.runPaletteEntry(entry)
+	default:
+		m.palette.UpdateInput(msg)
+	}
+	return m, nil
+}
+
+// runPaletteEntry carries out the chosen palette entry
+func (m Model) runPaletteEntry(entry paletteEntry) (Model, tea.Cmd) {
+	switch entry.kind {
+	case paletteAction:
+		// Press the key as if typed, so the action runs through its handler
+		key, ok := parseKey(entry.key)
+		if !ok {
+			return m, nil
+		}
+		updated, cmd := m.Update(key)
+		return updated.(Model), cmd
+	case paletteTutorial:
+		m.showHelp = false
+		m.showTutorial = true
+		m.tutorialModel.SetSize(m.width, m.height)
+		m.tutorialModel.JumpToSection(entry.target)
+		m.focused = focusTutorial
+	case paletteIssue:
+		if !m.showIssueDetails(entry.target) {
+			m.statusMsg = fmt.Sprintf("❌ %s is not in the list", entry.target)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("🔗 Jumped to %s", entry.target)
+		m.statusIsError = false
+	}
+	return m, nil
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newPaletteTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Fix login crash", Status: model.StatusOpen, IssueType: model.TypeBug},
+		{ID: "bv-2", Title: "Dark mode", Status: model.StatusClosed, IssueType: model.TypeFeature},
+	}
+	m := NewModel(issues, nil, "")
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return tm.(Model)
+}
+
+// paletteRun opens the palette, types query and presses enter
+func paletteRun(t *testing.T, m Model, query string) Model {
+	t.Helper()
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = tm.(Model)
+	if !m.showPalette {
+		t.Fatal("ctrl+p should open the command palette")
+	}
+	for _, r := range query {
+		tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = tm.(Model)
+	}
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tm.(Model)
+	if m.showPalette {
+		t.Fatal("enter should close the palette")
+	}
+	return m
+}
+
+func TestCommandPaletteListsActions(t *testing.T) {
+	m := newPaletteTestModel(t)
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = tm.(Model)
+
+	view := m.View()
+	for _, want := range []string{"Command Palette", "This help", "Kanban board"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("palette missing %q", want)
+		}
+	}
+	// Issues only show up once something is typed
+	for _, entry := range m.palette.filtered {
+		if entry.kind == paletteIssue {
+			t.Fatalf("issue %s listed without a query", entry.target)
+		}
+		if entry.key == "ctrl+p" {
+			t.Fatal("the palette should not list itself")
+		}
+	}
+
+	// j and k are part of the query, not navigation
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = tm.(Model)
+	if got := m.palette.input.Value(); got != "j" {
+		t.Errorf("expected query j, got %q", got)
+	}
+
+	tm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = tm.(Model)
+	if m.showPalette || m.showQuitConfirm {
+		t.Error("esc should only close the palette")
+	}
+}
+
+func TestCommandPaletteRunsAction(t *testing.T) {
+	m := paletteRun(t, newPaletteTestModel(t), "kanban")
+	if !m.isBoardView {
+		t.Error("running Kanban board should open the board")
+	}
+}
+
+func TestCommandPaletteRemappedAction(t *testing.T) {
+	m := newPaletteTestModel(t)
+	k, err := LoadKeymap(writeKeymap(t, "view.board: ctrl+b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.keymap = k
+
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if view := tm.(Model).View(); !strings.Contains(view, "Ctrl+b") {
+		t.Error("the palette should show the remapped key")
+	}
+	m = paletteRun(t, m, "kanban")
+	if !m.isBoardView {
+		t.Error("a remapped action should still run from the palette")
+	}
+}
+
+func TestCommandPaletteJumpsToIssue(t *testing.T) {
+	m := newPaletteTestModel(t)
+	// The closed issue is hidden by the open filter
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = tm.(Model)
+
+	m = paletteRun(t, m, "bv-2")
+	if got := m.selectedIssueID(); got != "bv-2" {
+		t.Fatalf("expected bv-2 selected, got %q", got)
+	}
+	if m.focused != focusDetail {
+		t.Error("jumping to an issue should show its details")
+	}
+
+	m = paletteRun(t, m, "login crash")
+	if got := m.selectedIssueID(); got != "bv-1" {
+		t.Errorf("titles should match too, got %q", got)
+	}
+}
+
+func TestCommandPaletteOpensTutorialPage(t *testing.T) {
+	m := paletteRun(t, newPaletteTestModel(t), "tutorial board view")
+	if !m.showTutorial || m.focused != focusTutorial {
+		t.Fatal("a tutorial entry should open the tutorial")
+	}
+	if got := m.tutorialModel.CurrentPageID(); got != "views-board" {
+		t.Errorf("expected the board page, got %q", got)
+	}
+}
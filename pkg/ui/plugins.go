@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PluginsReadyMsg carries the annotations of the .bv/plugins/ analyzers.
+// Report is nil when the project has no plugins.
+type PluginsReadyMsg struct {
+	Report *plugins.Report
+	Error  error
+}
+
+// RunPluginsCmd runs the project's plugins in the background.
+func RunPluginsCmd(issues []model.Issue, workDir string) tea.Cmd {
+	return func() tea.Msg {
+		report, err := plugins.RunAll(context.Background(), workDir, issues, plugins.DefaultTimeout)
+		return PluginsReadyMsg{Report: report, Error: err}
+	}
+}
+
+// SetPluginsEnabled turns the .bv/plugins/ analyzers on or off (--no-plugins).
+func (m *Model) SetPluginsEnabled(enabled bool) {
+	m.pluginsDisabled = !enabled
+}
+
+// pluginsCmd returns the command to (re)run plugins, or nil when disabled
+func (m *Model) pluginsCmd() tea.Cmd {
+	if m.pluginsDisabled || m.workDir == "" || len(m.issues) == 0 {
+		return nil
+	}
+	return RunPluginsCmd(m.issues, m.workDir)
+}
+
+// handlePluginsReady stores a plugin report and reports failed plugins
+func (m *Model) handlePluginsReady(msg PluginsReadyMsg) {
+	if msg.Error != nil {
+		m.statusMsg = fmt.Sprintf("Plugins: %v", msg.Error)
+		m.statusIsError = true
+		return
+	}
+	m.pluginReport = msg.Report
+	if failed := msg.Report.Failed(); len(failed) > 0 {
+		m.statusMsg = fmt.Sprintf("Plugin %s failed: %s", failed[0].Name, failed[0].Error)
+		if len(failed) > 1 {
+			m.statusMsg = fmt.Sprintf("%d plugins failed (%s: %s)", len(failed), failed[0].Name, failed[0].Error)
+		}
+		m.statusIsError = true
+	}
+	m.updateViewportContent()
+}
+
+// renderPluginsMD renders the plugin annotations of an issue for the detail
+// view, one line per plugin.
+func (m *Model) renderPluginsMD(issue model.Issue) string {
+	annotations := m.pluginReport.For(issue.ID)
+	if len(annotations) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### 🧩 Plugins\n")
+	for _, a := range annotations {
+		var parts []string
+		for _, badge := range a.Badges {
+			parts = append(parts, "`"+badge+"`")
+		}
+		for _, name := range a.SortedScores() {
+			parts = append(parts, fmt.Sprintf("%s %.2f", name, a.Scores[name]))
+		}
+		for _, warning := range a.Warnings {
+			parts = append(parts, "⚠️ "+warning)
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- **%s:** %s\n", a.Plugin, strings.Join(parts, " · ")))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+)
+
+func TestPluginAnnotationsInDetail(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Payments", Status: model.StatusOpen},
+		{ID: "B", Title: "Docs", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	if m.pluginsCmd() != nil {
+		t.Error("no plugins should run without a project directory")
+	}
+
+	updated, _ := m.Update(PluginsReadyMsg{Report: &plugins.Report{
+		Plugins: []plugins.Status{{Name: "risk", Annotations: 1}, {Name: "lint", Error: "exit status 1"}},
+		Annotations: map[string][]plugins.Annotation{
+			"A": {{IssueID: "A", Plugin: "risk", Scores: map[string]float64{"risk": 0.8}, Badges: []string{"pci"}, Warnings: []string{"touches card data"}}},
+		},
+	}})
+	m = updated.(Model)
+
+	detail := m.renderPluginsMD(issues[0])
+	for _, want := range []string{"🧩 Plugins", "**risk:**", "`pci`", "risk 0.80", "touches card data"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail missing %q:\n%s", want, detail)
+		}
+	}
+	if got := m.renderPluginsMD(issues[1]); got != "" {
+		t.Errorf("issue without annotations rendered %q", got)
+	}
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "lint") {
+		t.Errorf("status = %q, want the failed plugin", m.statusMsg)
+	}
+
+	m.SetPluginsEnabled(false)
+	m.workDir = t.TempDir()
+	if m.pluginsCmd() != nil {
+		t.Error("--no-plugins should not run plugins")
+	}
+}
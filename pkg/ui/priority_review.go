@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prioritySnoozeDuration is how long a snoozed suggestion stays hidden
+const prioritySnoozeDuration = 7 * 24 * time.Hour
+
+// PrioritiesAppliedMsg is sent after accepted priority suggestions are
+// written to the beads file
+type PrioritiesAppliedMsg struct {
+	Requested int
+	Applied   []string // IDs written, in order
+	Err       error    // First failure; later suggestions were not written
+}
+
+// priorityChange is an issue with the priority suggested for it
+type priorityChange struct {
+	issueID  string
+	priority int
+	rec      analysis.PriorityRecommendation
+}
+
+// loadPriorityDecisionLog opens the decision log of the project holding the
+// beads file, or returns nil without a beads file
+func loadPriorityDecisionLog(beadsPath string) *analysis.PriorityDecisionLog {
+	if beadsPath == "" {
+		return nil
+	}
+	projectDir, err := historyRepoPath(beadsPath)
+	if err != nil {
+		return nil
+	}
+	log, err := analysis.LoadPriorityDecisionLog(projectDir)
+	if err != nil {
+		return nil
+	}
+	return log
+}
+
+// priorityChangeFor pairs issueID with its pending suggestion, if any
+func (m *Model) priorityChangeFor(issueID string) (priorityChange, bool) {
+	hint := m.priorityHints[issueID]
+	issue, ok := m.issueMap[issueID]
+	if hint == nil || !ok || hint.SuggestedPriority == issue.Priority {
+		return priorityChange{}, false
+	}
+	return priorityChange{issueID: issueID, priority: hint.SuggestedPriority, rec: *hint}, true
+}
+
+// writeSuggestedPriorities writes each priority to the beads file and logs it
+// as accepted. Only the priority is written, so edits made on disk since
+// loading survive. It stops at the first failure and returns the IDs written.
+func writeSuggestedPriorities(beadsPath string, log *analysis.PriorityDecisionLog, changes []priorityChange, now time.Time) ([]string, error) {
+	var applied []string
+	for _, c := range changes {
+		if err := loader.UpdateIssueFieldsInFile(beadsPath, c.issueID, map[string]any{"priority": c.priority}); err != nil {
+			return applied, err
+		}
+		applied = append(applied, c.issueID)
+		if log != nil {
+			if err := log.Record(analysis.NewPriorityDecision(c.rec, analysis.PriorityAccepted, now)); err != nil {
+				return applied, err
+			}
+		}
+	}
+	return applied, nil
+}
+
+// openPriorityReview lists the pending priority suggestions for review
+func (m *Model) openPriorityReview() {
+	if m.analysis == nil || !m.analysis.IsPhase2Ready() {
+		m.statusMsg = "Priority suggestions are still being computed"
+		m.statusIsError = false
+		return
+	}
+	m.priorityReview = m.priorityReview[:0]
+	for _, hint := range m.priorityHints {
+		if hint.SuggestedPriority != hint.CurrentPriority {
+			m.priorityReview = append(m.priorityReview, *hint)
+		}
+	}
+	// Same order as GenerateRecommendations
+	sort.Slice(m.priorityReview, func(i, j int) bool {
+		a, b := m.priorityReview[i], m.priorityReview[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.ImpactScore != b.ImpactScore {
+			return a.ImpactScore > b.ImpactScore
+		}
+		return a.IssueID < b.IssueID
+	})
+	m.reviewMarked = make(map[string]bool)
+	m.reviewCursor = 0
+	m.showPriorityReview = true
+}
+
+// handlePriorityReviewKeys handles keys while the priority review is open
+func (m Model) handlePriorityReviewKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "P":
+		m.showPriorityReview = false
+	case "j", "down":
+		if m.reviewCursor < len(m.priorityReview)-1 {
+			m.reviewCursor++
+		}
+	case "k", "up":
+		if m.reviewCursor > 0 {
+			m.reviewCursor--
+		}
+	case " ":
+		if m.reviewCursor < len(m.priorityReview) {
+			id := m.priorityReview[m.reviewCursor].IssueID
+			if m.reviewMarked[id] {
+				delete(m.reviewMarked, id)
+			} else {
+				m.reviewMarked[id] = true
+			}
+			if m.reviewCursor < len(m.priorityReview)-1 {
+				m.reviewCursor++
+			}
+		}
+	case "*":
+		// Mark everything, or clear the marks if all are marked
+		allMarked := len(m.reviewMarked) == len(m.priorityReview)
+		m.reviewMarked = make(map[string]bool)
+		if !allMarked {
+			for _, rec := range m.priorityReview {
+				m.reviewMarked[rec.IssueID] = true
+			}
+		}
+	case "a":
+		return m, m.acceptReviewed()
+	case "d":
+		m.decideReviewed(analysis.PriorityDismissed)
+	case "z":
+		m.decideReviewed(analysis.PrioritySnoozed)
+	case "enter":
+		if m.reviewCursor < len(m.priorityReview) {
+			id := m.priorityReview[m.reviewCursor].IssueID
+			m.showPriorityReview = false
+			if !m.showIssueDetails(id) {
+				m.statusMsg = fmt.Sprintf("❌ %s is not in the list", id)
+				m.statusIsError = true
+			}
+		}
+	}
+	return m, nil
+}
+
+// reviewTargets returns the marked suggestions, or the one under the cursor
+// when none are marked
+func (m *Model) reviewTargets() []analysis.PriorityRecommendation {
+	var targets []analysis.PriorityRecommendation
+	for _, rec := range m.priorityReview {
+		if m.reviewMarked[rec.IssueID] {
+			targets = append(targets, rec)
+		}
+	}
+	if len(targets) == 0 && m.reviewCursor < len(m.priorityReview) {
+		targets = append(targets, m.priorityReview[m.reviewCursor])
+	}
+	return targets
+}
+
+// resolveReviewed drops decided suggestions from the review and the hints
+func (m *Model) resolveReviewed(ids []string) {
+	done := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		done[id] = true
+		delete(m.priorityHints, id)
+		delete(m.reviewMarked, id)
+	}
+	kept := m.priorityReview[:0]
+	for _, rec := range m.priorityReview {
+		if !done[rec.IssueID] {
+			kept = append(kept, rec)
+		}
+	}
+	m.priorityReview = kept
+	if m.reviewCursor >= len(kept) {
+		m.reviewCursor = max(len(kept)-1, 0)
+	}
+}
+
+// decideReviewed logs a dismissal or snooze of the targeted suggestions
+func (m *Model) decideReviewed(action analysis.PriorityDecisionAction) {
+	targets := m.reviewTargets()
+	if len(targets) == 0 {
+		return
+	}
+	if m.priorityDecisions == nil {
+		m.statusMsg = "❌ No beads file whose project could store priority decisions"
+		m.statusIsError = true
+		return
+	}
+
+	now := time.Now()
+	var ids []string
+	for _, rec := range targets {
+		d := analysis.NewPriorityDecision(rec, action, now)
+		if action == analysis.PrioritySnoozed {
+			until := d.DecidedAt.Add(prioritySnoozeDuration)
+			d.SnoozeUntil = &until
+		}
+		if err := m.priorityDecisions.Record(d); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Failed to save decision: %v", err)
+			m.statusIsError = true
+			break
+		}
+		ids = append(ids, rec.IssueID)
+	}
+	m.resolveReviewed(ids)
+	if len(ids) > 0 {
+		verb := "Dismissed"
+		if action == analysis.PrioritySnoozed {
+			verb = "Snoozed for 7 days:"
+		}
+		m.statusMsg = fmt.Sprintf("%s %s", verb, strings.Join(ids, ", "))
+		m.statusIsError = false
+	}
+}
+
+// acceptReviewed writes the targeted suggestions to the beads file
+func (m *Model) acceptReviewed() tea.Cmd {
+	if m.refuseReadOnly() {
+		return nil
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return nil
+	}
+	var changes []priorityChange
+	var ids []string
+	for _, rec := range m.reviewTargets() {
+		if c, ok := m.priorityChangeFor(rec.IssueID); ok {
+			changes = append(changes, c)
+			ids = append(ids, rec.IssueID)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	m.resolveReviewed(ids)
+
+	beadsPath, log := m.beadsPath, m.priorityDecisions
+	return func() tea.Msg {
+		applied, err := writeSuggestedPriorities(beadsPath, log, changes, time.Now())
+		return PrioritiesAppliedMsg{Requested: len(changes), Applied: applied, Err: err}
+	}
+}
+
+// renderPriorityReview renders the priority review modal
+func (m Model) renderPriorityReview() string {
+	t := m.theme
+	width := min(88, m.width-4)
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		MaxHeight(m.height - 4)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	reasonStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("⚖ Priority Review (%d pending)", len(m.priorityReview))))
+	sb.WriteString("\n\n")
+
+	if len(m.priorityReview) == 0 {
+		sb.WriteString(t.Renderer.NewStyle().Foreground(ColorSuccess).Render("✓ No pending priority suggestions"))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor visible on short terminals
+	maxRows := (m.height - 12) / 2
+	if maxRows < 3 {
+		maxRows = 3
+	}
+	start := 0
+	if m.reviewCursor >= maxRows {
+		start = m.reviewCursor - maxRows + 1
+	}
+
+	for i := start; i < len(m.priorityReview) && i < start+maxRows; i++ {
+		rec := m.priorityReview[i]
+		cursor := "  "
+		if i == m.reviewCursor {
+			cursor = "▸ "
+		}
+		mark := "[ ]"
+		if m.reviewMarked[rec.IssueID] {
+			mark = "[x]"
+		}
+		arrow := "↑"
+		if rec.SuggestedPriority > rec.CurrentPriority {
+			arrow = "↓"
+		}
+		head := fmt.Sprintf("%s%s P%d→P%d %s %s ", cursor, mark, rec.CurrentPriority, rec.SuggestedPriority, arrow, rec.IssueID)
+		tail := fmt.Sprintf(" %3.0f%%", rec.Confidence*100)
+		titleWidth := width - 6 - lipgloss.Width(head) - lipgloss.Width(tail)
+		line := head + padRight(truncateRunesHelper(rec.Title, max(titleWidth, 10), "…"), max(titleWidth, 10)) + tail
+		if i == m.reviewCursor {
+			line = t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+		reason := ""
+		if len(rec.Reasoning) > 0 {
+			reason = truncateRunesHelper(rec.Reasoning[0], width-14, "…")
+		}
+		sb.WriteString(reasonStyle.Render("        " + reason))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
+		"Space: mark • *: mark all • a: accept • d: dismiss • z: snooze 7d • Enter: jump • Esc: close"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(sb.String()),
+	)
+}
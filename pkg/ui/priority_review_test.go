@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPriorityReviewDecisions(t *testing.T) {
+	root := t.TempDir()
+	beadsPath := filepath.Join(root, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beadsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"id":"bv-1","title":"One","status":"open","priority":3,"issue_type":"task"}
+{"id":"bv-2","title":"Two","status":"open","priority":2,"issue_type":"task"}
+{"id":"bv-3","title":"Three","status":"open","priority":1,"issue_type":"task"}
+`
+	if err := os.WriteFile(beadsPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, beadsPath)
+	m.analysis.WaitForPhase2()
+	m.priorityHints = map[string]*analysis.PriorityRecommendation{
+		"bv-1": {IssueID: "bv-1", Title: "One", CurrentPriority: 3, SuggestedPriority: 1, Confidence: 0.9},
+		"bv-2": {IssueID: "bv-2", Title: "Two", CurrentPriority: 2, SuggestedPriority: 0, Confidence: 0.8},
+		"bv-3": {IssueID: "bv-3", Title: "Three", CurrentPriority: 1, SuggestedPriority: 3, Confidence: 0.7},
+	}
+	key := func(s string) {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+		if s == " " {
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+		}
+		m, _ = m.handlePriorityReviewKeys(msg)
+	}
+
+	m.openPriorityReview()
+	if !m.showPriorityReview || len(m.priorityReview) != 3 || m.priorityReview[0].IssueID != "bv-1" {
+		t.Fatalf("expected three suggestions by confidence, got %+v", m.priorityReview)
+	}
+
+	key("d") // Dismiss bv-1 under the cursor
+	key("z") // Snooze bv-2, now under the cursor
+	if len(m.priorityReview) != 1 || m.priorityHints["bv-1"] != nil || m.priorityHints["bv-2"] != nil {
+		t.Fatalf("decided suggestions should leave the review and the hints, got %+v", m.priorityReview)
+	}
+
+	// Someone edits bv-3 on disk after bv loaded it
+	edited := strings.Replace(content, `"title":"Three","status":"open"`, `"title":"Three, renamed","description":"added later","status":"in_progress"`, 1)
+	if err := os.WriteFile(beadsPath, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Accepting rewrites only the priority and logs the decision
+	key(" ")
+	m, cmd := m.handlePriorityReviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd == nil {
+		t.Fatal("expected a write command")
+	}
+	applied, ok := cmd().(PrioritiesAppliedMsg)
+	if !ok || applied.Err != nil || len(applied.Applied) != 1 || applied.Applied[0] != "bv-3" {
+		t.Fatalf("unexpected result %+v", applied)
+	}
+	reloaded, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil || reloaded[2].Priority != 3 {
+		t.Fatalf("beads file not updated: %+v (err %v)", reloaded, err)
+	}
+	if got := reloaded[2]; got.Title != "Three, renamed" || got.Description != "added later" || got.Status != model.StatusInProgress {
+		t.Errorf("applying a priority reverted the edits made on disk: %+v", got)
+	}
+
+	log, err := analysis.LoadPriorityDecisionLog(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[string]analysis.PriorityDecisionAction{
+		"bv-1": analysis.PriorityDismissed, "bv-2": analysis.PrioritySnoozed, "bv-3": analysis.PriorityAccepted,
+	} {
+		if d, ok := log.Latest(id); !ok || d.Action != want {
+			t.Errorf("%s: expected %s in the decision log, got %+v", id, want, d)
+		}
+	}
+
+	// Dismissed and snoozed suggestions are not suggested again
+	m = NewModel(issues, nil, beadsPath)
+	m.analysis.WaitForPhase2()
+	updated, _ := m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	m = updated.(Model)
+	if m.priorityHints["bv-1"] != nil && m.priorityHints["bv-1"].SuggestedPriority == 1 {
+		t.Error("a dismissed suggestion should not come back")
+	}
+}
+
+func TestPriorityReviewMarkAll(t *testing.T) {
+	m := NewModel([]model.Issue{editableIssue()}, nil, "")
+	m.analysis.WaitForPhase2()
+	m.priorityHints = map[string]*analysis.PriorityRecommendation{
+		"bv-1": {IssueID: "bv-1", CurrentPriority: 2, SuggestedPriority: 0},
+	}
+	m.openPriorityReview()
+
+	m, _ = m.handlePriorityReviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	if !m.reviewMarked["bv-1"] {
+		t.Error("* should mark every suggestion")
+	}
+	m, _ = m.handlePriorityReviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	if len(m.reviewMarked) != 0 {
+		t.Error("* should clear the marks when all are marked")
+	}
+
+	// Without a beads file there is nowhere to log a dismissal
+	m, _ = m.handlePriorityReviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if len(m.priorityReview) != 1 || !m.statusIsError {
+		t.Error("dismissing without a decision log should fail visibly")
+	}
+	m, _ = m.handlePriorityReviewKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showPriorityReview {
+		t.Error("esc should close the review")
+	}
+}
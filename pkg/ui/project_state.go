@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectStateFilename is per-project TUI state kept in the project's .bv
+// directory, unlike ui-state.json which is per user. Each feature owns one
+// top-level key (e.g. "starred", "snoozed").
+const ProjectStateFilename = "state.json"
+
+// projectStatePath returns .bv/state.json under projectDir
+func projectStatePath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ProjectStateFilename)
+}
+
+// readProjectStateKey decodes one key of state.json into v. A missing file
+// or key leaves v untouched.
+func readProjectStateKey(projectDir, key string, v any) error {
+	data, err := os.ReadFile(projectStatePath(projectDir))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", ProjectStateFilename, err)
+	}
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("parsing %s: %w", ProjectStateFilename, err)
+	}
+	raw, ok := state[key]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("parsing %s %q: %w", ProjectStateFilename, key, err)
+	}
+	return nil
+}
+
+// writeProjectStateKey sets one key of state.json to v, keeping the other
+// keys
+func writeProjectStateKey(projectDir, key string, v any) error {
+	path := projectStatePath(projectDir)
+	state := make(map[string]json.RawMessage)
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &state) // A broken file is rewritten
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	state[key] = encoded
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating .bv directory: %w", err)
+	}
+	// Write atomically via temp file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,239 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateRemindInterval is how long "remind me later" hides release notes
+const updateRemindInterval = 24 * time.Hour
+
+// ReleaseNotesModel shows the markdown notes of a newer release with
+// choices to update, be reminded later, or skip the version.
+type ReleaseNotesModel struct {
+	tag   string
+	name  string
+	url   string
+	notes string
+
+	lines     []string // Rendered notes, one terminal line each
+	wrapWidth int      // Width lines were last rendered at
+	scroll    int
+
+	width  int
+	height int
+	theme  Theme
+}
+
+// NewReleaseNotesModel creates the release notes modal for a release
+func NewReleaseNotesModel(tag, name, url, notes string, theme Theme) ReleaseNotesModel {
+	return ReleaseNotesModel{
+		tag:    tag,
+		name:   name,
+		url:    url,
+		notes:  notes,
+		theme:  theme,
+		width:  80,
+		height: 24,
+	}
+}
+
+// SetSize updates the dimensions, re-rendering the notes when the width changes
+func (m *ReleaseNotesModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if w := m.contentWidth(); w != m.wrapWidth {
+		m.render(w)
+	}
+}
+
+// contentWidth is the width available to the notes inside the modal border
+func (m *ReleaseNotesModel) contentWidth() int {
+	w := m.modalWidth() - 6 // border and padding
+	if w < 20 {
+		w = 20
+	}
+	return w
+}
+
+func (m *ReleaseNotesModel) modalWidth() int {
+	w := m.width - 10
+	if w > 100 {
+		w = 100
+	}
+	if w < 40 {
+		w = 40
+	}
+	return w
+}
+
+// pageSize is the number of note lines visible at once
+func (m *ReleaseNotesModel) pageSize() int {
+	h := m.height - 14 // border, padding, header, versions, choices
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+// render converts the release markdown to terminal lines at width
+func (m *ReleaseNotesModel) render(width int) {
+	m.wrapWidth = width
+	notes := strings.TrimSpace(m.notes)
+	if notes == "" {
+		m.lines = []string{"No release notes were published for this version."}
+		m.clampScroll()
+		return
+	}
+	rendered, err := NewMarkdownRendererWithTheme(width, m.theme).Render(notes)
+	if err != nil {
+		rendered = wrapText(notes, width)
+	}
+	m.lines = strings.Split(strings.Trim(rendered, "\n"), "\n")
+	m.clampScroll()
+}
+
+func (m *ReleaseNotesModel) clampScroll() {
+	maxScroll := len(m.lines) - m.pageSize()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.scroll > maxScroll {
+		m.scroll = maxScroll
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+// ScrollBy moves the notes by delta lines
+func (m *ReleaseNotesModel) ScrollBy(delta int) {
+	m.scroll += delta
+	m.clampScroll()
+}
+
+// Tag returns the release tag the notes belong to
+func (m ReleaseNotesModel) Tag() string {
+	return m.tag
+}
+
+// View renders the modal
+func (m ReleaseNotesModel) View() string {
+	if m.lines == nil {
+		m.render(m.contentWidth())
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+	newStyle := t.Renderer.NewStyle().Bold(true).Foreground(ColorStatusOpen)
+	keyStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+
+	title := "⭐ Update Available: " + m.tag
+	if m.name != "" && m.name != m.tag {
+		title += " — " + m.name
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(truncateRunesHelper(title, m.contentWidth(), "…")))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Current version: " + version.Version + "  →  "))
+	b.WriteString(newStyle.Render(m.tag))
+	b.WriteString("\n\n")
+
+	end := m.scroll + m.pageSize()
+	if end > len(m.lines) {
+		end = len(m.lines)
+	}
+	b.WriteString(strings.Join(m.lines[m.scroll:end], "\n"))
+	b.WriteString("\n\n")
+
+	if len(m.lines) > m.pageSize() {
+		pos := m.scroll * 100 / (len(m.lines) - m.pageSize())
+		b.WriteString(dimStyle.Render(fmt.Sprintf("j/k scroll  %d%%", pos)))
+		b.WriteString("\n")
+	}
+	if m.url != "" {
+		b.WriteString(dimStyle.Render(truncateRunesHelper(m.url, m.contentWidth(), "…")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	choices := []string{
+		keyStyle.Render("[U]") + " Update now",
+		keyStyle.Render("[L]") + " Remind me later",
+		keyStyle.Render("[S]") + " Skip this version",
+		keyStyle.Render("[Esc]") + " Close",
+	}
+	b.WriteString(strings.Join(choices, "   "))
+
+	return t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(m.modalWidth()).
+		Render(b.String())
+}
+
+// CenterModal returns the modal view centered in the given dimensions
+func (m ReleaseNotesModel) CenterModal(termWidth, termHeight int) string {
+	return lipgloss.Place(termWidth, termHeight, lipgloss.Center, lipgloss.Center, m.View())
+}
+
+// releaseNotesDue reports whether the notes for tag should be shown now,
+// honoring the persisted skip and remind-later choices
+func (m Model) releaseNotesDue(tag string, now time.Time) bool {
+	return tag != "" && tag != m.skippedUpdate && !now.Before(m.remindUpdateAfter)
+}
+
+// showReleaseNotesModal opens the release notes for the available update
+func (m *Model) showReleaseNotesModal() {
+	m.releaseNotes = NewReleaseNotesModel(m.updateTag, m.updateName, m.updateURL, m.updateNotes, m.theme)
+	m.releaseNotes.SetSize(m.width, m.height-1)
+	m.showReleaseNotes = true
+	m.focused = focusReleaseNotes
+}
+
+// closeReleaseNotes hides the release notes modal
+func (m *Model) closeReleaseNotes() {
+	m.showReleaseNotes = false
+	m.focused = focusList
+}
+
+// handleReleaseNotesKeys handles keys while the release notes modal is open
+func (m Model) handleReleaseNotesKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	rn := &m.releaseNotes
+	switch msg.String() {
+	case "j", "down":
+		rn.ScrollBy(1)
+	case "k", "up":
+		rn.ScrollBy(-1)
+	case "ctrl+d", "pgdown", " ":
+		rn.ScrollBy(rn.pageSize() / 2)
+	case "ctrl+u", "pgup":
+		rn.ScrollBy(-rn.pageSize() / 2)
+	case "u", "U", "enter":
+		m.closeReleaseNotes()
+		m.showSelfUpdateModal()
+	case "l", "L":
+		m.remindUpdateAfter = time.Now().Add(updateRemindInterval)
+		m.saveUIState()
+		m.closeReleaseNotes()
+		m.statusMsg = fmt.Sprintf("⏰ Will remind you about %s tomorrow (U to update anytime)", rn.Tag())
+		m.statusIsError = false
+	case "s", "S":
+		m.skippedUpdate = rn.Tag()
+		m.saveUIState()
+		m.closeReleaseNotes()
+		m.statusMsg = fmt.Sprintf("Skipped %s; you'll hear about the next release", rn.Tag())
+		m.statusIsError = false
+	case "esc", "q":
+		m.closeReleaseNotes()
+	}
+	return m, nil
+}
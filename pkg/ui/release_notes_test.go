@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newReleaseNotesTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updated.(Model)
+}
+
+func TestReleaseNotesShownOnUpdate(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+	updated, _ := m.Update(UpdateMsg{TagName: "v9.9.9", Name: "Big one", URL: "https://example", Notes: "## Changes\n\n- Faster graphs"})
+	m = updated.(Model)
+	if !m.showReleaseNotes || m.focused != focusReleaseNotes {
+		t.Fatal("expected release notes modal for a new version")
+	}
+	view := m.View()
+	// Glamour styles each word separately, so look for them one at a time
+	if !strings.Contains(view, "v9.9.9") || !strings.Contains(view, "Faster") || !strings.Contains(view, "graphs") {
+		t.Errorf("release notes not rendered:\n%s", view)
+	}
+
+	// Scrolling and closing leave the update available
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showReleaseNotes || m.focused != focusList || !m.updateAvailable {
+		t.Fatal("esc should close the notes and keep the update badge")
+	}
+}
+
+func TestReleaseNotesSkipVersionPersists(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+	updated, _ := m.Update(UpdateMsg{TagName: "v9.9.9", Notes: "notes"})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = updated.(Model)
+	if m.showReleaseNotes || m.skippedUpdate != "v9.9.9" {
+		t.Fatalf("skip should close the notes and remember v9.9.9, got %q", m.skippedUpdate)
+	}
+	if got := LoadUIState().SkippedUpdate; got != "v9.9.9" {
+		t.Errorf("skipped version not persisted, got %q", got)
+	}
+
+	// A new session does not show the skipped version again, but a newer one
+	m = NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ = m.Update(UpdateMsg{TagName: "v9.9.9", Notes: "notes"})
+	if updated.(Model).showReleaseNotes {
+		t.Error("skipped version should not show release notes")
+	}
+	updated, _ = m.Update(UpdateMsg{TagName: "v10.0.0", Notes: "notes"})
+	if !updated.(Model).showReleaseNotes {
+		t.Error("a newer version should show release notes again")
+	}
+}
+
+func TestReleaseNotesRemindLater(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+	updated, _ := m.Update(UpdateMsg{TagName: "v9.9.9", Notes: "notes"})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	if m.showReleaseNotes {
+		t.Fatal("remind later should close the notes")
+	}
+	saved := LoadUIState().RemindUpdateAfter
+	if saved.Before(time.Now().Add(updateRemindInterval - time.Minute)) {
+		t.Fatalf("remind time not persisted, got %v", saved)
+	}
+
+	if m.releaseNotesDue("v9.9.9", time.Now()) {
+		t.Error("notes should be postponed")
+	}
+	if !m.releaseNotesDue("v9.9.9", saved.Add(time.Second)) {
+		t.Error("notes should be due once the reminder passes")
+	}
+}
+
+func TestReleaseNotesUpdateOpensUpdateModal(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+	updated, _ := m.Update(UpdateMsg{TagName: "v9.9.9"})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "No release notes") {
+		t.Error("expected placeholder for empty release notes")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m = updated.(Model)
+	if m.showReleaseNotes || !m.showUpdateModal || m.focused != focusUpdateModal {
+		t.Fatal("u should hand off to the self-update modal")
+	}
+}
+
+func TestReleaseNotesNotShownOverOtherViews(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+	m.focused = focusInsights
+	updated, _ := m.Update(UpdateMsg{TagName: "v9.9.9", Notes: "notes"})
+	m = updated.(Model)
+	if m.showReleaseNotes || !m.updateAvailable {
+		t.Error("release notes should not interrupt other views")
+	}
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renamePreviewLines caps the changed lines listed in the rename preview
+const renamePreviewLines = 12
+
+// IssueRenamedMsg reports the outcome of renaming an issue ID
+type IssueRenamedMsg struct {
+	OldID string
+	NewID string
+	Lines int // Lines rewritten across the beads files
+	Err   error
+}
+
+// openRenamePrompt asks for a new ID for the selected issue
+func (m *Model) openRenamePrompt() {
+	if m.refuseReadOnly() {
+		return
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return
+	}
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+
+	m.renameIssueID = item.Issue.ID
+	m.renamePlan = nil
+	m.renameError = ""
+	m.renameInput = textinput.New()
+	m.renameInput.SetValue(item.Issue.ID)
+	m.renameInput.CharLimit = 64
+	m.renameInput.Width = 30
+	m.renameInput.Prompt = "New ID: "
+	m.renameInput.PromptStyle = lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
+	m.renameInput.Focus()
+	m.showRenamePrompt = true
+}
+
+// handleRenamePromptKeys edits the new ID; Enter previews the rewrite, and
+// Enter on the preview applies it
+func (m Model) handleRenamePromptKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		if m.renamePlan != nil {
+			m.renamePlan = nil
+			m.renameInput.Focus()
+			return m, nil
+		}
+		m.showRenamePrompt = false
+	case "enter":
+		if m.renamePlan != nil {
+			m.showRenamePrompt = false
+			return m, renameIssueCmd(m.renamePlan)
+		}
+		plan, err := loader.PlanIssueRename(m.beadsPath, m.renameIssueID, strings.TrimSpace(m.renameInput.Value()))
+		if err != nil {
+			m.renameError = err.Error()
+			return m, nil
+		}
+		m.renameError = ""
+		m.renamePlan = plan
+		m.renameInput.Blur()
+	default:
+		if m.renamePlan != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.renameInput, cmd = m.renameInput.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// renameIssueCmd writes a planned rename
+func renameIssueCmd(plan *loader.RenamePlan) tea.Cmd {
+	return func() tea.Msg {
+		return IssueRenamedMsg{OldID: plan.OldID, NewID: plan.NewID, Lines: len(plan.Lines), Err: plan.Apply()}
+	}
+}
+
+// showRenamedIssue applies a just-written rename to the loaded issues and
+// keeps the issue selected, ahead of the watcher's reload
+func (m *Model) showRenamedIssue(oldID, newID string) []tea.Cmd {
+	issues := make([]model.Issue, len(m.issues))
+	for i, issue := range m.issues {
+		issue = issue.Clone()
+		if issue.ID == oldID {
+			issue.ID = newID
+		}
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			if dep.IssueID == oldID {
+				dep.IssueID = newID
+			}
+			if dep.DependsOnID == oldID {
+				dep.DependsOnID = newID
+			}
+		}
+		for _, c := range issue.Comments {
+			if c != nil && c.IssueID == oldID {
+				c.IssueID = newID
+			}
+		}
+		issues[i] = issue
+	}
+	_, cmds := m.replaceIssues(issues)
+	m.jumpToIssue(newID)
+	return cmds
+}
+
+func (m Model) renderRenamePrompt() string {
+	t := m.theme
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 3).
+		Width(min(90, m.width-4))
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	errorStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	title := m.renameIssueID
+	if issue, ok := m.issueMap[m.renameIssueID]; ok {
+		title += ": " + truncateRunesHelper(issue.Title, 40, "…")
+	}
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("✏️  Rename " + title))
+	sb.WriteString("\n\n")
+
+	plan := m.renamePlan
+	if plan == nil {
+		sb.WriteString(mutedStyle.Render("Dependencies, parent links and comments that refer to it are rewritten too"))
+		sb.WriteString("\n\n")
+		sb.WriteString(m.renameInput.View())
+		sb.WriteString("\n\n")
+		if m.renameError != "" {
+			sb.WriteString(errorStyle.Render("❌ " + m.renameError))
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(mutedStyle.Render("Enter: preview • Esc: cancel"))
+		return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+	}
+
+	sb.WriteString(fmt.Sprintf("%s → %s rewrites %d line(s):\n\n", plan.OldID, plan.NewID, len(plan.Lines)))
+	for i, l := range plan.Lines {
+		if i == renamePreviewLines {
+			sb.WriteString(mutedStyle.Render(fmt.Sprintf("  … and %d more", len(plan.Lines)-i)))
+			sb.WriteString("\n")
+			break
+		}
+		what := l.IssueID
+		if issue, ok := m.issueMap[l.IssueID]; ok {
+			what += " " + truncateRunesHelper(issue.Title, 30, "…")
+		}
+		sb.WriteString(fmt.Sprintf("  %-40s %s\n", what,
+			mutedStyle.Render(fmt.Sprintf("%s:%d %s", filepath.Base(l.Path), l.Line, strings.Join(l.Fields, ", ")))))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(mutedStyle.Render("Full diff: bv rename " + plan.OldID + " " + plan.NewID + " --dry-run"))
+	sb.WriteString("\n\n")
+	sb.WriteString(mutedStyle.Render("Enter: rename • Esc: back"))
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
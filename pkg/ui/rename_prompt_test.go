@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenamePrompt(t *testing.T) {
+	beadsPath := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bv-1","title":"Login","status":"open","priority":1,"issue_type":"task"}
+{"id":"bv-2","title":"Form","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+`
+	if err := os.WriteFile(beadsPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beadsPath)
+	m.jumpToIssue("bv-1")
+	m.openRenamePrompt()
+	if !m.showRenamePrompt || m.renameInput.Value() != "bv-1" {
+		t.Fatalf("prompt should open prefilled with the ID, got %v %q", m.showRenamePrompt, m.renameInput.Value())
+	}
+
+	// An ID in use is refused before anything is previewed
+	m.renameInput.SetValue("bv-2")
+	m, _ = m.handleRenamePromptKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.renamePlan != nil || !strings.Contains(m.renameError, "already exists") {
+		t.Fatalf("expected a collision error, got %q", m.renameError)
+	}
+
+	m.renameInput.SetValue("auth-1")
+	m, _ = m.handleRenamePromptKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.renamePlan == nil || len(m.renamePlan.Lines) != 2 {
+		t.Fatalf("expected a preview of 2 lines, got %+v (%s)", m.renamePlan, m.renameError)
+	}
+	if view := m.renderRenamePrompt(); !strings.Contains(view, "bv-1 → auth-1") {
+		t.Errorf("preview should show the rename:\n%s", view)
+	}
+	if data, _ := os.ReadFile(beadsPath); string(data) != content {
+		t.Fatal("previewing must not write")
+	}
+
+	m, cmd := m.handleRenamePromptKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.showRenamePrompt {
+		t.Fatal("Enter on the preview should close the prompt and write")
+	}
+	msg, ok := cmd().(IssueRenamedMsg)
+	if !ok || msg.Err != nil || msg.NewID != "auth-1" {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if _, ok := m.issueMap["auth-1"]; !ok {
+		t.Error("the renamed issue should be shown before the reload")
+	}
+	if dep := m.issueMap["bv-2"].Dependencies[0]; dep.DependsOnID != "auth-1" {
+		t.Errorf("dependency in memory = %s", dep.DependsOnID)
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "auth-1" {
+		t.Error("the renamed issue should stay selected")
+	}
+	onDisk, _ := loader.LoadIssuesFromFile(beadsPath)
+	if len(onDisk) != 2 || onDisk[0].ID != "auth-1" {
+		t.Errorf("beads file not renamed: %+v", onDisk)
+	}
+}
+
+func TestRenamePromptReadOnly(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "x", Status: model.StatusOpen}}, nil, "")
+	m.openRenamePrompt()
+	if m.showRenamePrompt || !strings.Contains(m.statusMsg, "No beads file") {
+		t.Errorf("renaming without a beads file should be refused, got %q", m.statusMsg)
+	}
+}
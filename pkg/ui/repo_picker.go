@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -11,6 +12,8 @@ type RepoPickerModel struct {
 	repos         []string
 	selectedIndex int
 	selected      map[string]bool // repo -> selected
+	status        map[string]WorkspaceRepo
+	failed        []WorkspaceRepo // Repos that failed to load (not selectable)
 	width         int
 	height        int
 	theme         Theme
@@ -58,6 +61,19 @@ func (m *RepoPickerModel) SetActiveRepos(active map[string]bool) {
 	}
 }
 
+// SetRepoStatus attaches per-repo issue counts and load errors for display.
+func (m *RepoPickerModel) SetRepoStatus(repos []WorkspaceRepo) {
+	m.status = make(map[string]WorkspaceRepo, len(repos))
+	m.failed = nil
+	for _, r := range repos {
+		if r.Error != "" {
+			m.failed = append(m.failed, r)
+			continue
+		}
+		m.status[normalizeRepoPrefix(r.Prefix)] = r
+	}
+}
+
 // MoveUp moves selection up.
 func (m *RepoPickerModel) MoveUp() {
 	if m.selectedIndex > 0 {
@@ -151,10 +167,27 @@ func (m *RepoPickerModel) View() string {
 			}
 
 			line := prefix + check + " " + repo
+			if st, ok := m.status[repo]; ok {
+				counts := fmt.Sprintf("%d open / %d", st.OpenCount, st.IssueCount)
+				if pad := boxWidth - 6 - len([]rune(line)) - len(counts); pad > 0 {
+					line += strings.Repeat(" ", pad) + counts
+				} else {
+					line += "  " + counts
+				}
+			}
 			lines = append(lines, nameStyle.Render(line))
 		}
 	}
 
+	if len(m.failed) > 0 {
+		errStyle := t.Renderer.NewStyle().Foreground(t.Blocked)
+		lines = append(lines, "")
+		lines = append(lines, errStyle.Bold(true).Render(fmt.Sprintf("Failed to load (%d)", len(m.failed))))
+		for _, r := range m.failed {
+			lines = append(lines, errStyle.Render(truncate("  ✗ "+r.Name+": "+r.Error, boxWidth-6)))
+		}
+	}
+
 	lines = append(lines, "")
 	footerStyle := t.Renderer.NewStyle().
 		Foreground(t.Secondary).
@@ -43,3 +43,23 @@ func TestRepoPickerViewContainsRepos(t *testing.T) {
 		t.Fatalf("expected repo name in view, got:\n%s", out)
 	}
 }
+
+func TestRepoPickerShowsCountsAndLoadErrors(t *testing.T) {
+	m := NewRepoPickerModel([]string{"api", "web"}, DefaultTheme(lipgloss.NewRenderer(nil)))
+	m.SetSize(80, 24)
+	m.SetRepoStatus([]WorkspaceRepo{
+		{Name: "api", Prefix: "api-", IssueCount: 12, OpenCount: 5},
+		{Name: "web", Prefix: "web-", IssueCount: 3, OpenCount: 3},
+		{Name: "legacy", Prefix: "old-", Error: "no beads file found"},
+	})
+
+	out := m.View()
+	for _, want := range []string{"5 open / 12", "3 open / 3", "Failed to load (1)", "legacy: no beads file found"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, out)
+		}
+	}
+	if got := len(m.SelectedRepos()); got != 2 {
+		t.Errorf("failed repos must not be selectable, got %d selected", got)
+	}
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// computeRiskScores returns the composite risk of every open issue
+func computeRiskScores(issues []model.Issue, stats *analysis.GraphStats) map[string]float64 {
+	scores := make(map[string]float64, len(issues))
+	if stats == nil {
+		return scores
+	}
+	byID := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	for id, signals := range analysis.ComputeAllRiskSignals(byID, stats, time.Now()) {
+		scores[id] = signals.CompositeRisk
+	}
+	return scores
+}
+
+// toggleRiskHeatmap switches the risk heatmap on the list and board
+func (m *Model) toggleRiskHeatmap() {
+	m.showRiskHeatmap = !m.showRiskHeatmap
+	m.applyRiskHeatmap()
+	if !m.showRiskHeatmap {
+		m.statusMsg = "Risk heatmap off"
+		m.statusIsError = false
+		return
+	}
+
+	high := 0
+	for _, score := range m.riskScores {
+		if score >= 0.6 {
+			high++
+		}
+	}
+	m.statusMsg = fmt.Sprintf("🔥 Risk heatmap: %d of %d open issues at 60%%+ risk (m to hide)", high, len(m.riskScores))
+	m.statusIsError = false
+}
+
+// applyRiskHeatmap pushes the heatmap state to the list delegate and board,
+// computing scores on first use
+func (m *Model) applyRiskHeatmap() {
+	if m.showRiskHeatmap && m.riskScores == nil {
+		m.riskScores = computeRiskScores(m.issues, m.analysis)
+	}
+	if m.showRiskHeatmap {
+		m.board.SetRiskScores(m.riskScores)
+	} else {
+		m.board.SetRiskScores(nil)
+	}
+	m.updateListDelegate()
+}
+
+// invalidateRiskScores drops cached scores after the issues or graph
+// metrics change, recomputing them if the heatmap is showing
+func (m *Model) invalidateRiskScores() {
+	m.riskScores = nil
+	if m.showRiskHeatmap {
+		m.applyRiskHeatmap()
+	}
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRiskHeatmapToggle(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-60 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "A", Title: "Stuck", Status: model.StatusBlocked, IssueType: model.TypeTask, CreatedAt: stale, UpdatedAt: stale},
+		{ID: "B", Title: "Fresh", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: now, UpdatedAt: now},
+		{ID: "C", Title: "Done", Status: model.StatusClosed, IssueType: model.TypeTask, CreatedAt: stale, UpdatedAt: stale},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 140, 40
+	m.resizePanes()
+
+	key := func(k string) {
+		tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = tm.(Model)
+	}
+
+	key("m")
+	if !m.showRiskHeatmap || m.riskScores == nil {
+		t.Fatal("m should turn the risk heatmap on")
+	}
+	if _, ok := m.riskScores["C"]; ok {
+		t.Error("closed issues should have no risk score")
+	}
+	if m.riskScores["A"] <= m.riskScores["B"] {
+		t.Errorf("stale blocked issue should be riskier: %v", m.riskScores)
+	}
+	if !strings.Contains(m.statusMsg, "Risk heatmap") {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+	m.statusMsg = "" // The status message replaces the footer
+	if footer := m.renderFooter(); !strings.Contains(footer, "80+") {
+		t.Error("expected the risk legend in the footer")
+	}
+	if m.board.riskScores == nil {
+		t.Error("board should receive the risk scores")
+	}
+
+	// Reloading drops and recomputes the cached scores
+	m.replaceIssues(issues[:2])
+	if m.riskScores == nil || len(m.riskScores) != 2 {
+		t.Errorf("scores should be recomputed after reload, got %v", m.riskScores)
+	}
+
+	key("m")
+	if m.showRiskHeatmap || m.board.riskScores != nil {
+		t.Error("second m should turn the heatmap off")
+	}
+	m.statusMsg = ""
+	if footer := m.renderFooter(); strings.Contains(footer, "80+") {
+		t.Error("legend should be hidden when the heatmap is off")
+	}
+}
+
+func TestRenderRiskCell(t *testing.T) {
+	if got := RenderRiskCell(0.734, true); !strings.Contains(got, " 73") {
+		t.Errorf("RenderRiskCell = %q", got)
+	}
+	if got := RenderRiskCell(0, false); !strings.Contains(got, "·") {
+		t.Errorf("missing score should render a dot, got %q", got)
+	}
+}
@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSearchHistory is how many past searches ui-state.json keeps
+const maxSearchHistory = 50
+
+// snippetContext is how much text a snippet shows before a match
+const snippetContext = 30
+
+// searchResult is an issue the list search matched, with the text around
+// the match when it is outside the ID and title
+type searchResult struct {
+	Issue   model.Issue
+	Field   string // "description", "comment", ... ("" = no snippet)
+	Snippet string
+}
+
+// searchSnippet finds the first query term in the issue's body text and
+// returns the field and the text around it. ID and title matches need no
+// snippet, the result row shows both; a fuzzy or semantic match with no
+// literal term falls back to the start of the description.
+func searchSnippet(issue model.Issue, terms []string) (field, snippet string) {
+	for _, f := range recipe.SearchFields(issue) {
+		if f.Name == "id" || f.Name == "title" || f.Text == "" {
+			continue
+		}
+		text := strings.Join(strings.Fields(f.Text), " ")
+		lower := strings.ToLower(text)
+		if len(lower) != len(text) {
+			text = lower // case folding moved the offsets
+		}
+		for _, term := range terms {
+			if i := strings.Index(lower, term); i >= 0 {
+				return f.Name, snippetFrom(text, i)
+			}
+		}
+	}
+	if desc := strings.Join(strings.Fields(issue.Description), " "); desc != "" {
+		return "description", desc
+	}
+	return "", ""
+}
+
+// snippetFrom cuts text to start a word or so before byte offset at
+func snippetFrom(text string, at int) string {
+	if at <= snippetContext {
+		return text
+	}
+	start := at - snippetContext
+	for start < at && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	if space := strings.IndexByte(text[start:at], ' '); space >= 0 {
+		start += space + 1
+	}
+	return "…" + text[start:]
+}
+
+// highlightTerms renders text with each occurrence of a term in hi
+func highlightTerms(text string, terms []string, base, hi lipgloss.Style) string {
+	if len(terms) == 0 {
+		return base.Render(text)
+	}
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	re, err := regexp.Compile("(?i)" + strings.Join(quoted, "|"))
+	if err != nil {
+		return base.Render(text)
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		sb.WriteString(base.Render(text[last:loc[0]]))
+		sb.WriteString(hi.Render(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	sb.WriteString(base.Render(text[last:]))
+	return sb.String()
+}
+
+// rememberSearch puts query at the front of the search history
+func (m *Model) rememberSearch(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+	history := []string{query}
+	for _, q := range m.searchHistory {
+		if q != query && len(history) < maxSearchHistory {
+			history = append(history, q)
+		}
+	}
+	m.searchHistory = history
+	m.searchHistoryPos = -1
+	m.saveUIState()
+}
+
+// recallSearch steps through the search history from the search bar: up
+// (+1) goes to older searches, down (-1) back towards the query being typed
+func (m *Model) recallSearch(step int) {
+	pos := m.searchHistoryPos + step
+	if pos < -1 || pos >= len(m.searchHistory) {
+		return
+	}
+	if m.searchHistoryPos == -1 {
+		m.searchDraft = m.list.FilterInput.Value()
+	}
+	m.searchHistoryPos = pos
+	query := m.searchDraft
+	if pos >= 0 {
+		query = m.searchHistory[pos]
+	}
+	m.list.SetFilterText(query) // refilters, then keeps the bar open for editing
+	m.list.SetFilterState(list.Filtering)
+}
+
+// openSearchResults lists the issues the current search matches
+func (m *Model) openSearchResults() {
+	query := strings.TrimSpace(m.list.FilterInput.Value())
+	if m.list.FilterState() == list.Unfiltered || query == "" {
+		m.statusMsg = "No search to list results for (/ to search)"
+		m.statusIsError = false
+		return
+	}
+	if m.list.FilterState() == list.Filtering {
+		m.list.SetFilterText(m.list.FilterInput.Value()) // apply it, as enter would
+	}
+	m.rememberSearch(query)
+
+	terms := recipe.SearchTerms(query)
+	m.searchResults = nil
+	for _, item := range m.list.VisibleItems() {
+		issueItem, ok := item.(IssueItem)
+		if !ok {
+			continue
+		}
+		field, snippet := searchSnippet(issueItem.Issue, terms)
+		m.searchResults = append(m.searchResults, searchResult{Issue: issueItem.Issue, Field: field, Snippet: snippet})
+	}
+	m.searchQuery = query
+	m.searchResultIdx = 0
+	if id := m.selectedIssueID(); id != "" {
+		for i, r := range m.searchResults {
+			if r.Issue.ID == id {
+				m.searchResultIdx = i
+			}
+		}
+	}
+	m.showSearchResults = true
+}
+
+// handleSearchResultsKeys handles keys while the search results pane is open
+func (m Model) handleSearchResultsKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	n := len(m.searchResults)
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "j", "down":
+		if m.searchResultIdx < n-1 {
+			m.searchResultIdx++
+		}
+	case "k", "up":
+		if m.searchResultIdx > 0 {
+			m.searchResultIdx--
+		}
+	case "n":
+		if n > 0 {
+			m.searchResultIdx = (m.searchResultIdx + 1) % n
+		}
+	case "N":
+		if n > 0 {
+			m.searchResultIdx = (m.searchResultIdx + n - 1) % n
+		}
+	case "g", "home":
+		m.searchResultIdx = 0
+	case "G", "end":
+		m.searchResultIdx = max(0, n-1)
+	case "enter":
+		if n > 0 {
+			m.selectSearchResult(m.searchResults[m.searchResultIdx].Issue.ID)
+		}
+		m.showSearchResults = false
+	case "s":
+		cmd := m.saveSearchAsRecipe()
+		return m, cmd
+	case "esc", "q", "ctrl+f":
+		m.showSearchResults = false
+	}
+	return m, nil
+}
+
+// selectSearchResult selects id among the issues the search left in the
+// list and records the jump
+func (m *Model) selectSearchResult(id string) {
+	from := m.selectedIssueID()
+	for i, item := range m.list.VisibleItems() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+			m.list.Select(i)
+			m.nav.record(from, id)
+			m.focused = focusList
+			m.updateViewportContent()
+			return
+		}
+	}
+}
+
+// searchRecipeName names the saved filter for query: "search-" and its
+// words, lower-case and joined with dashes
+func searchRecipeName(query string) string {
+	var words []string
+	for _, term := range recipe.SearchTerms(query) {
+		word := strings.Map(func(r rune) rune {
+			if r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, term)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	name := "search-" + strings.Join(words, "-")
+	if len(words) == 0 {
+		name = "search"
+	}
+	return truncateRunesHelper(name, 40, "")
+}
+
+// saveSearchAsRecipe saves the search as a user recipe, so it can be
+// applied again from the recipe picker (') or with bv --recipe
+func (m *Model) saveSearchAsRecipe() tea.Cmd {
+	query := strings.TrimPrefix(m.searchQuery, "~")
+	r := recipe.Recipe{
+		Name:        searchRecipeName(query),
+		Description: "Saved search: " + query,
+		Filters:     recipe.FilterConfig{Search: query},
+	}
+	if err := m.recipeLoader.SaveUserRecipe(r); err != nil {
+		return m.toasts.Push(ToastError, fmt.Sprintf("Search not saved: %v", err))
+	}
+	m.recipePicker = NewRecipePickerModel(m.recipeLoader.List(), m.theme)
+	return m.toasts.Push(ToastSuccess, fmt.Sprintf("Saved as recipe %q (' to apply)", r.Name))
+}
+
+// renderSearchResults renders the search results pane: each issue on one
+// line with the text that matched below it
+func (m Model) renderSearchResults() string {
+	t := m.theme
+	width := min(110, m.width-4)
+	textWidth := width - 8 // border, padding, marker
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	idStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	textStyle := t.Renderer.NewStyle()
+	selectedStyle := t.Renderer.NewStyle().Bold(true)
+	snippetStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	matchStyle := t.Renderer.NewStyle().Foreground(t.Feature).Bold(true)
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("🔎 Search results for %q (%d)", m.searchQuery, len(m.searchResults))))
+	sb.WriteString("\n\n")
+
+	terms := recipe.SearchTerms(m.searchQuery)
+	if len(m.searchResults) == 0 {
+		sb.WriteString(hintStyle.Render("Nothing matches"))
+		sb.WriteString("\n")
+	} else {
+		page := max(1, (m.height-12)/2)
+		start := min(max(0, m.searchResultIdx-page/2), max(0, len(m.searchResults)-page))
+		end := min(len(m.searchResults), start+page)
+		for i := start; i < end; i++ {
+			r := m.searchResults[i]
+			marker, titleBase := "  ", textStyle
+			if i == m.searchResultIdx {
+				marker, titleBase = "▸ ", selectedStyle
+			}
+			idWidth := utf8.RuneCountInString(r.Issue.ID) + 1
+			sb.WriteString(marker)
+			sb.WriteString(idStyle.Render(r.Issue.ID))
+			sb.WriteString(" ")
+			sb.WriteString(highlightTerms(truncateRunesHelper(r.Issue.Title, textWidth-idWidth, "…"), terms, titleBase, matchStyle))
+			sb.WriteString("\n    ")
+			if r.Field != "" {
+				label := r.Field + ": "
+				sb.WriteString(snippetStyle.Render(label))
+				sb.WriteString(highlightTerms(truncateRunesHelper(r.Snippet, textWidth-2-len(label), "…"), terms, snippetStyle, matchStyle))
+			}
+			sb.WriteString("\n")
+		}
+		if len(m.searchResults) > page {
+			sb.WriteString(hintStyle.Render(fmt.Sprintf("%d–%d of %d", start+1, end, len(m.searchResults))))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("j/k or n/N: move • Enter: jump to issue • s: save as recipe • Esc: close"))
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
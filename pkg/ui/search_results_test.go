@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func searchTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	issues := []model.Issue{
+		{ID: "se-1", Title: "Login timeout", Status: model.StatusOpen, Priority: 1},
+		{ID: "se-2", Title: "Session handling", Labels: []string{"auth", "timeout"}, Status: model.StatusOpen, Priority: 2},
+		{ID: "se-3", Title: "Dark mode", Status: model.StatusOpen, Priority: 3},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	return updated.(Model)
+}
+
+func TestSearchSnippet(t *testing.T) {
+	issue := model.Issue{
+		ID:          "sn-1",
+		Title:       "Crash on start",
+		Description: "The app crashes right after the splash screen on some machines, but only after an update",
+		Comments:    []*model.Comment{{Text: "Reproduced on Windows"}},
+	}
+	if field, snippet := searchSnippet(issue, []string{"windows"}); field != "comment" || snippet != "Reproduced on Windows" {
+		t.Errorf("comment match = %q %q", field, snippet)
+	}
+	field, snippet := searchSnippet(issue, []string{"update"})
+	if field != "description" || !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "only after an update") {
+		t.Errorf("description match = %q %q", field, snippet)
+	}
+	// A title match has no snippet of its own; the description stands in
+	if field, snippet := searchSnippet(issue, []string{"crash on"}); field != "description" || !strings.HasPrefix(snippet, "The app") {
+		t.Errorf("title match = %q %q", field, snippet)
+	}
+}
+
+func TestSearchRecipeName(t *testing.T) {
+	for query, want := range map[string]string{
+		"~Login bug!": "search-login-bug",
+		"bv-12":       "search-bv-12",
+		"!!!":         "search",
+	} {
+		if got := searchRecipeName(query); got != want {
+			t.Errorf("searchRecipeName(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestSearchHistory(t *testing.T) {
+	m := searchTestModel(t)
+	m = pressKeys(m, "/", "login", "enter")
+	m = pressKeys(m, "esc", "/", "dark", "enter")
+	if len(m.searchHistory) != 2 || m.searchHistory[0] != "dark" {
+		t.Fatalf("history = %v", m.searchHistory)
+	}
+	if saved := LoadUIState().SearchHistory; len(saved) != 2 {
+		t.Errorf("saved history = %v", saved)
+	}
+
+	m = pressKeys(m, "esc", "/", "ses")
+	m = pressKeys(m, "up", "up")
+	if got := m.list.FilterInput.Value(); got != "login" || m.list.FilterState() != list.Filtering {
+		t.Errorf("up twice = %q (%v), want login", got, m.list.FilterState())
+	}
+	if n := len(m.list.VisibleItems()); n != 1 {
+		t.Errorf("recalled search shows %d issues, want 1", n)
+	}
+	m = pressKeys(m, "down", "down")
+	if got := m.list.FilterInput.Value(); got != "ses" {
+		t.Errorf("down back = %q, want the draft", got)
+	}
+}
+
+func TestSearchResultsPane(t *testing.T) {
+	m := searchTestModel(t)
+	m = pressKeys(m, "/", "timeout", "ctrl+f")
+	if !m.showSearchResults || len(m.searchResults) != 2 {
+		t.Fatalf("results pane open=%v with %d results", m.showSearchResults, len(m.searchResults))
+	}
+	if m.list.FilterState() != list.FilterApplied {
+		t.Errorf("opening the pane left the filter %v", m.list.FilterState())
+	}
+	var session searchResult
+	for _, r := range m.searchResults {
+		if r.Issue.ID == "se-2" {
+			session = r
+		}
+	}
+	if session.Field != "labels" || session.Snippet != "auth timeout" {
+		t.Errorf("se-2 result = %+v", session)
+	}
+	if view := m.View(); !strings.Contains(view, "Search results") {
+		t.Error("results pane not rendered")
+	}
+
+	// n wraps around; enter selects the result in the filtered list
+	start := m.searchResultIdx
+	m = pressKeys(m, "n", "n")
+	if m.searchResultIdx != start {
+		t.Errorf("n twice over 2 results moved from %d to %d", start, m.searchResultIdx)
+	}
+	m = pressKeys(m, "N")
+	want := m.searchResults[m.searchResultIdx].Issue.ID
+	m = pressKeys(m, "enter")
+	if m.showSearchResults || m.selectedIssueID() != want {
+		t.Errorf("enter: pane open=%v, selected %q, want %q", m.showSearchResults, m.selectedIssueID(), want)
+	}
+}
+
+func TestSearchSavedAsRecipe(t *testing.T) {
+	m := searchTestModel(t)
+	m = pressKeys(m, "/", "timeout", "ctrl+f", "s")
+
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".config", "bv", "recipes.yaml"))
+	if err != nil {
+		t.Fatalf("recipe file: %v", err)
+	}
+	if !strings.Contains(string(data), "search: timeout") {
+		t.Errorf("recipe file = %s", data)
+	}
+	r := m.recipeLoader.Get("search-timeout")
+	if r == nil {
+		t.Fatal("saved recipe not loaded")
+	}
+	m.applyRecipe(r)
+	if n := len(m.list.Items()); n != 2 {
+		t.Errorf("saved search shows %d issues, want 2", n)
+	}
+}
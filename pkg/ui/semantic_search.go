@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -28,9 +29,26 @@ type semanticSearchSnapshot struct {
 type semanticResultCache struct {
 	results     map[string][]list.Rank // term -> ranks
 	pendingTerm string                 // term awaiting async computation
+	failedTerm  string                 // term whose query embedding failed; stays fuzzy
 	lastQuery   time.Time              // for debounce
 }
 
+// semanticQueryPrefix marks a filter query as semantic in fuzzy mode (~query)
+const semanticQueryPrefix = "~"
+
+// semanticQueryTimeout bounds embedding one query. Hosted and local model
+// backends need far longer than the built-in hash embedder.
+const semanticQueryTimeout = 5 * time.Second
+
+// semanticQuery strips the ~ prefix from a filter term, reporting whether
+// it was present
+func semanticQuery(term string) (string, bool) {
+	if !strings.HasPrefix(term, semanticQueryPrefix) {
+		return term, false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(term, semanticQueryPrefix)), true
+}
+
 type semanticHybridConfig struct {
 	Enabled bool
 	Preset  search.PresetName
@@ -184,6 +202,7 @@ func (s *SemanticSearch) SetCachedResults(term string, results []list.Rank) {
 	newCache := &semanticResultCache{
 		results:     make(map[string][]list.Rank),
 		pendingTerm: newPendingTerm,
+		failedTerm:  c.failedTerm,
 		lastQuery:   c.lastQuery,
 	}
 	// Copy existing cache entries (keep a small LRU-like cache)
@@ -208,11 +227,28 @@ func (s *SemanticSearch) ClearPending() {
 	newCache := &semanticResultCache{
 		results:     c.results,
 		pendingTerm: "",
+		failedTerm:  c.failedTerm,
 		lastQuery:   c.lastQuery,
 	}
 	s.cache.Store(newCache)
 }
 
+// MarkFailed records that term could not be embedded: it is no longer
+// pending and Filter keeps fuzzy results for it instead of retrying.
+func (s *SemanticSearch) MarkFailed(term string) {
+	c := s.getCache()
+	pending := c.pendingTerm
+	if pending == term {
+		pending = ""
+	}
+	s.cache.Store(&semanticResultCache{
+		results:     c.results,
+		pendingTerm: pending,
+		failedTerm:  term,
+		lastQuery:   c.lastQuery,
+	})
+}
+
 func (s *SemanticSearch) Snapshot() semanticSearchSnapshot {
 	v := s.snapshot.Load()
 	if v == nil {
@@ -254,8 +290,9 @@ func (s *SemanticSearch) SetDocs(docs map[string]string) {
 
 // Filter implements list.FilterFunc, returning ranks sorted by semantic similarity.
 // This is non-blocking: returns cached results or fuzzy fallback immediately,
-// and marks the term as pending for async computation.
+// and marks the term as pending for async computation. A leading ~ is ignored.
 func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
+	term, _ = semanticQuery(term)
 	if term == "" {
 		// Preserve existing sort order when the user hasn't entered a query yet.
 		return list.DefaultFilter(term, targets)
@@ -275,12 +312,16 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 	if cached, ok := c.results[term]; ok {
 		return cached
 	}
+	if c.failedTerm == term {
+		return list.DefaultFilter(term, targets)
+	}
 
 	// No cached results - mark as pending and return fuzzy results
 	// The async computation will be triggered by the model
 	newCache := &semanticResultCache{
 		results:     c.results,
 		pendingTerm: term,
+		failedTerm:  c.failedTerm,
 		lastQuery:   time.Now(),
 	}
 	s.cache.Store(newCache)
@@ -289,20 +330,39 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 	return list.DefaultFilter(term, targets)
 }
 
+// PrefixFilter implements list.FilterFunc for fuzzy mode: queries starting
+// with ~ are ranked semantically, everything else fuzzily.
+func (s *SemanticSearch) PrefixFilter(term string, targets []string) []list.Rank {
+	if _, ok := semanticQuery(term); ok {
+		return s.Filter(term, targets)
+	}
+	return list.DefaultFilter(term, targets)
+}
+
 // ComputeSemanticResults computes semantic similarity results synchronously.
 // This should be called from an async tea.Cmd, not from Filter.
 func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
+	ranks, _ := s.computeSemanticResults(term)
+	return ranks
+}
+
+// computeSemanticResults is ComputeSemanticResults, also reporting why the
+// query could not be embedded
+func (s *SemanticSearch) computeSemanticResults(term string) ([]list.Rank, error) {
 	snap := s.Snapshot()
 	if !snap.Ready || snap.Index == nil || snap.Embedder == nil {
-		return nil
+		return nil, nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), semanticQueryTimeout)
 	defer cancel()
 
 	vecs, err := snap.Embedder.Embed(ctx, []string{term})
-	if err != nil || len(vecs) != 1 {
-		return nil
+	if err == nil && len(vecs) != 1 {
+		err = fmt.Errorf("embedder returned %d vectors for the query", len(vecs))
+	}
+	if err != nil {
+		return nil, err
 	}
 	q := vecs[0]
 
@@ -416,7 +476,7 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 		out = append(out, list.Rank{Index: it.index})
 	}
 	s.SetScores(term, scoreMap)
-	return out
+	return out, nil
 }
 
 // SemanticIndexReadyMsg is emitted when the semantic index build/update completes.
@@ -433,6 +493,7 @@ type SemanticIndexReadyMsg struct {
 type SemanticFilterResultMsg struct {
 	Term    string
 	Results []list.Rank
+	Err     error // Query embedding failed; the term stays on fuzzy results
 }
 
 // HybridMetricsReadyMsg is emitted when hybrid metrics are ready for scoring.
@@ -444,10 +505,11 @@ type HybridMetricsReadyMsg struct {
 // ComputeSemanticFilterCmd computes semantic filter results asynchronously.
 func ComputeSemanticFilterCmd(s *SemanticSearch, term string) tea.Cmd {
 	return func() tea.Msg {
-		results := s.ComputeSemanticResults(term)
+		results, err := s.computeSemanticResults(term)
 		return SemanticFilterResultMsg{
 			Term:    term,
 			Results: results,
+			Err:     err,
 		}
 	}
 }
@@ -504,6 +566,12 @@ func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
+		// Model backends load lazily; load now so the first query is fast
+		if embedder.Provider() != search.ProviderHash && stats.Embedded == 0 {
+			if _, err := embedder.Embed(ctx, []string{"warm up"}); err != nil {
+				return SemanticIndexReadyMsg{Error: err}
+			}
+		}
 		if !loaded || stats.Changed() {
 			if err := idx.Save(indexPath); err != nil {
 				return SemanticIndexReadyMsg{Error: fmt.Errorf("save semantic index: %w", err)}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
@@ -418,6 +419,62 @@ func TestSemanticSearchFilterEmbedError(t *testing.T) {
 
 // =============================================================================
 // Non-blocking Filter Tests (async pattern)
+func TestSemanticSearchPrefixFilter(t *testing.T) {
+	ss := NewSemanticSearch()
+
+	idx := search.NewVectorIndex(3)
+	ss.SetIndex(idx, &mockEmbedder{dim: 3})
+	idx.Upsert("id-1", search.ContentHash{}, []float32{1.0, 0.0, 0.0})
+	ss.SetIDs([]string{"id-1"})
+	targets := []string{"login bug"}
+
+	// Plain queries stay fuzzy and never go pending
+	if ranks := ss.PrefixFilter("login", targets); len(ranks) != 1 {
+		t.Errorf("Expected fuzzy match for plain query, got %d ranks", len(ranks))
+	}
+	if ss.GetPendingTerm() != "" {
+		t.Errorf("Plain query should not be pending, got %q", ss.GetPendingTerm())
+	}
+
+	// ~ queries are semantic, with the prefix stripped
+	ss.PrefixFilter("~ auth failure", targets)
+	if ss.GetPendingTerm() != "auth failure" {
+		t.Errorf("Expected pending term 'auth failure', got %q", ss.GetPendingTerm())
+	}
+}
+
+func TestSemanticSearchMarkFailed(t *testing.T) {
+	ss := NewSemanticSearch()
+
+	idx := search.NewVectorIndex(3)
+	embedder := &mockEmbedder{
+		dim: 3,
+		embedFunc: func(ctx context.Context, texts []string) ([][]float32, error) {
+			return nil, errors.New("model offline")
+		},
+	}
+	ss.SetIndex(idx, embedder)
+	idx.Upsert("id-1", search.ContentHash{}, []float32{1.0, 0.0, 0.0})
+	ss.SetIDs([]string{"id-1"})
+	targets := []string{"login bug"}
+
+	ss.Filter("~login", targets)
+	msg := ComputeSemanticFilterCmd(ss, ss.GetPendingTerm())().(SemanticFilterResultMsg)
+	if msg.Err == nil {
+		t.Fatal("Expected embed error in result message")
+	}
+	ss.MarkFailed(msg.Term)
+
+	// The failed term falls back to fuzzy results without going pending again
+	ranks := ss.Filter("~login", targets)
+	if len(ranks) != 1 {
+		t.Errorf("Expected fuzzy fallback match, got %d ranks", len(ranks))
+	}
+	if ss.GetPendingTerm() != "" {
+		t.Errorf("Failed term should not be pending, got %q", ss.GetPendingTerm())
+	}
+}
+
 // =============================================================================
 
 func TestSemanticSearchFilterNonBlocking(t *testing.T) {
@@ -111,6 +111,12 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"?", "Help"},
 				{";", "This sidebar"},
 				{"p", "Priority ↑↓"},
+				{"m", "Risk heatmap"},
+				{"A", "Attention digest"},
+				{"E", "Notifications"},
+				{"Y", "Audit log"},
+				{"^g", "Startup diagnostics"},
+				{"^p", "Command palette"},
 			},
 		},
 		{
@@ -149,6 +155,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"o", "Open in browser"},
 				{"g", "Graph view"},
 				{"c", "Cycle filter"},
+				{"C/X/I", "Confirm/reject/ignore"},
 			},
 		},
 		{
@@ -162,6 +169,30 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"Enter", "Full view"},
 			},
 		},
+		{
+			title:    "Split",
+			contexts: []string{"split"},
+			items: []shortcutItem{
+				{"Tab", "Focus toggle"},
+				{"^h/^l", "Resize panes"},
+				{"|", "Orientation"},
+				{"^o/^]", "Jump back/fwd"},
+			},
+		},
+		{
+			title:    "Detail",
+			contexts: []string{"detail"},
+			items: []shortcutItem{
+				{"v", "History tab"},
+				{"n/N", "Linked issue"},
+				{"gd", "Open link"},
+				{"u/U", "Attachment"},
+				{"@", "Open attachment"},
+				{"<", "Blockers"},
+				{"^o", "Jump back"},
+				{"^i/^]", "Jump forward"},
+			},
+		},
 		{
 			title:    "Filters",
 			contexts: []string{"list", "split"},
@@ -169,8 +200,12 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"o", "Open only"},
 				{"c", "Closed only"},
 				{"r", "Ready (no blocks)"},
+				{"z", "Starred only"},
+				{"W", "Snoozed only"},
 				{"L", "Label picker"},
 				{"/", "Search"},
+				{"X", "Show deleted"},
+				{"^a", "Show archived"},
 			},
 		},
 		{
@@ -179,9 +214,19 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 			items: []shortcutItem{
 				{"t/T", "Time-travel"},
 				{"x", "Export .md"},
-				{"C", "Copy"},
-				{"O", "Open in $EDITOR"},
-				{"R", "Recipe picker"},
+				{"y", "Copy ID"},
+				{"C/J/B", "Copy MD/JSON/bd"},
+				{"I", "Copy dossier"},
+				{"*", "Star/unstar"},
+				{"Z", "Snooze/wake"},
+				{"e", "Private note"},
+				{"O", "Edit in $EDITOR"},
+				{"D", "Edit deps"},
+				{"^r", "Rename ID"},
+				{"M", "Merge assist"},
+				{"+", "From template"},
+				{"'", "Recipe picker"},
+				{"F/R", "Forecast/Effort"},
 				{"U", "Self-update"},
 				{"V", "Cass sessions"},
 			},
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -166,3 +168,27 @@ func TestShortcutsSidebarWidth(t *testing.T) {
 		t.Errorf("Expected Width() = 34, got %d", sidebar.Width())
 	}
 }
+
+func TestShortcutsSidebarHiddenWhenNarrow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	tm, _ = tm.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(";")})
+	m = tm.(Model)
+	if !m.showShortcutsSidebar || m.shortcutsSidebarShown() {
+		t.Fatal("the sidebar should stay on but hidden on a narrow terminal")
+	}
+	if !strings.Contains(m.statusMsg, "wider") {
+		t.Errorf("expected a hint about the width, got %q", m.statusMsg)
+	}
+	m.statusMsg = ""
+	if strings.Contains(m.View(), "Shortcuts") {
+		t.Error("the sidebar should not be drawn")
+	}
+
+	// It shows up again once the terminal is wide enough
+	tm, _ = m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	if !tm.(Model).shortcutsSidebarShown() {
+		t.Error("the sidebar should show on a wide terminal")
+	}
+}
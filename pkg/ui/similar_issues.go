@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxSimilarIssues caps the "Similar Issues" section of the detail view
+const maxSimilarIssues = 5
+
+// SimilarityIndexReadyMsg is emitted when the similar-issues index is built.
+type SimilarityIndexReadyMsg struct {
+	Index *search.VectorIndex
+	Error error
+}
+
+// BuildSimilarityIndexCmd embeds issue titles/descriptions into the
+// similarity index under .bv/embeddings, falling back to the hash embedder
+// when the configured backend is unavailable.
+func BuildSimilarityIndexCmd(issues []model.Issue) tea.Cmd {
+	return func() tea.Msg {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return SimilarityIndexReadyMsg{Error: err}
+		}
+		docs := search.SimilarityDocuments(issues)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		cfg := search.EmbeddingConfigFromEnv()
+		idx, err := syncSimilarityIndex(ctx, projectDir, cfg, docs)
+		if err != nil && cfg.Provider != search.ProviderHash {
+			idx, err = syncSimilarityIndex(ctx, projectDir, search.EmbeddingConfig{Provider: search.ProviderHash}.Normalized(), docs)
+		}
+		return SimilarityIndexReadyMsg{Index: idx, Error: err}
+	}
+}
+
+func syncSimilarityIndex(ctx context.Context, projectDir string, cfg search.EmbeddingConfig, docs map[string]string) (*search.VectorIndex, error) {
+	embedder, err := search.NewEmbedderFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	indexPath := search.SimilarityIndexPath(projectDir, cfg)
+	idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
+	if err != nil {
+		return nil, err
+	}
+	if !loaded || stats.Changed() {
+		if err := idx.Save(indexPath); err != nil {
+			return nil, fmt.Errorf("save similarity index: %w", err)
+		}
+	}
+	return idx, nil
+}
+
+// renderSimilarIssuesMD lists the issues most similar to issueID, flagging
+// probable duplicates. Empty until the similarity index is ready.
+func (m *Model) renderSimilarIssuesMD(issueID string) string {
+	if m.similarIndex == nil {
+		return ""
+	}
+	results, err := m.similarIndex.SimilarTo(issueID, maxSimilarIssues)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, r := range results {
+		other := m.issueMap[r.IssueID]
+		if other == nil || r.Score < search.DefaultSimilarThreshold {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("### 🔁 Similar Issues\n")
+		}
+		dup := ""
+		if r.Score >= search.DefaultDuplicateThreshold {
+			dup = " · ⚠️ *possible duplicate*"
+		}
+		sb.WriteString(fmt.Sprintf("- %s **%s** %s · %.0f%%%s\n",
+			GetStatusIcon(string(other.Status)), other.ID, other.Title, r.Score*100, dup))
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// snoozeBadge marks snoozed issues
+const snoozeBadge = "💤"
+
+// snoozeCheckInterval is how often expired snoozes are looked for
+const snoozeCheckInterval = time.Minute
+
+// SnoozeTickMsg wakes issues whose snooze has expired
+type SnoozeTickMsg struct {
+	Now time.Time
+}
+
+// LoadSnoozed reads the snoozed issues of projectDir with the time each one
+// wakes up. A missing file yields an empty set.
+func LoadSnoozed(projectDir string) (map[string]time.Time, error) {
+	snoozed := make(map[string]time.Time)
+	if err := readProjectStateKey(projectDir, "snoozed", &snoozed); err != nil {
+		return nil, err
+	}
+	return snoozed, nil
+}
+
+// SaveSnoozed writes the snoozed issues of projectDir
+func SaveSnoozed(projectDir string, snoozed map[string]time.Time) error {
+	return writeProjectStateKey(projectDir, "snoozed", snoozed)
+}
+
+// loadSnoozedIssues loads the snoozes of the project owning beadsPath,
+// empty when there are none or they cannot be read
+func loadSnoozedIssues(beadsPath string) map[string]time.Time {
+	if beadsPath == "" {
+		return nil
+	}
+	snoozed, err := LoadSnoozed(filepath.Dir(filepath.Dir(beadsPath)))
+	if err != nil {
+		return nil
+	}
+	return snoozed
+}
+
+// ParseSnoozeUntil turns a snooze prompt entry into the time the issue
+// wakes up: a number of days or weeks ("3d", "2w", waking at midnight), a
+// duration ("4h"), "tomorrow", or a date ("2006-01-02")
+func ParseSnoozeUntil(spec string, now time.Time) (time.Time, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	midnight := func(days int) time.Time {
+		y, m, d := now.Date()
+		return time.Date(y, m, d+days, 0, 0, 0, 0, now.Location())
+	}
+
+	var until time.Time
+	switch {
+	case spec == "tomorrow":
+		until = midnight(1)
+	case strings.HasSuffix(spec, "d") || strings.HasSuffix(spec, "w"):
+		n, err := strconv.Atoi(spec[:len(spec)-1])
+		if err != nil || n <= 0 {
+			return time.Time{}, fmt.Errorf("invalid snooze %q", spec)
+		}
+		if strings.HasSuffix(spec, "w") {
+			n *= 7
+		}
+		until = midnight(n)
+	default:
+		if d, err := time.ParseDuration(spec); err == nil {
+			until = now.Add(d)
+		} else if t, err := time.ParseInLocation("2006-01-02", spec, now.Location()); err == nil {
+			until = t
+		} else {
+			return time.Time{}, fmt.Errorf("invalid snooze %q (want 3d, 2w, 4h, tomorrow or 2006-01-02)", spec)
+		}
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("snooze until %s is not in the future", until.Format("2006-01-02 15:04"))
+	}
+	return until, nil
+}
+
+// snoozeTickCmd checks for expired snoozes after d
+func snoozeTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(now time.Time) tea.Msg { return SnoozeTickMsg{Now: now} })
+}
+
+// isSnoozed reports whether issueID is hidden by a snooze
+func (m Model) isSnoozed(issueID string) bool {
+	_, ok := m.snoozed[issueID]
+	return ok
+}
+
+// openSnoozePrompt asks how long to snooze the selected issue, or wakes it
+// when it is already snoozed
+func (m *Model) openSnoozePrompt() {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+	id := item.Issue.ID
+	if m.isSnoozed(id) {
+		delete(m.snoozed, id)
+		m.saveSnoozed(fmt.Sprintf("⏰ Woke %s", id))
+		return
+	}
+
+	m.snoozeIssueID = id
+	m.snoozeInput = textinput.New()
+	m.snoozeInput.Placeholder = "7d"
+	m.snoozeInput.CharLimit = 20
+	m.snoozeInput.Width = 20
+	m.snoozeInput.Prompt = snoozeBadge + " Until: "
+	m.snoozeInput.PromptStyle = lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
+	m.snoozeInput.Focus()
+	m.showSnoozePrompt = true
+}
+
+func (m Model) handleSnoozePromptKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.showSnoozePrompt = false
+	case "enter":
+		spec := m.snoozeInput.Value()
+		if strings.TrimSpace(spec) == "" {
+			spec = m.snoozeInput.Placeholder
+		}
+		until, err := ParseSnoozeUntil(spec, time.Now())
+		if err != nil {
+			m.statusMsg = "❌ " + err.Error()
+			m.statusIsError = true
+			return m, nil
+		}
+		m.showSnoozePrompt = false
+		if m.snoozed == nil {
+			m.snoozed = make(map[string]time.Time)
+		}
+		m.snoozed[m.snoozeIssueID] = until
+		m.saveSnoozed(fmt.Sprintf("%s Snoozed %s until %s", snoozeBadge, m.snoozeIssueID, until.Format("Mon Jan 2 15:04")))
+	default:
+		var cmd tea.Cmd
+		m.snoozeInput, cmd = m.snoozeInput.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// saveSnoozed writes the snoozes, refreshes the views and reports status
+func (m *Model) saveSnoozed(status string) {
+	m.statusMsg = status
+	m.statusIsError = false
+	if m.workDir != "" {
+		if err := SaveSnoozed(m.workDir, m.snoozed); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Saving snoozes: %v", err)
+			m.statusIsError = true
+		}
+	}
+	m.refreshFilteredViews()
+}
+
+// handleSnoozeTick wakes the issues whose snooze has expired, with a toast,
+// and schedules the next check
+func (m *Model) handleSnoozeTick(msg SnoozeTickMsg) tea.Cmd {
+	var woken []string
+	for id, until := range m.snoozed {
+		if !until.After(msg.Now) {
+			woken = append(woken, id)
+		}
+	}
+	if len(woken) == 0 {
+		return snoozeTickCmd(snoozeCheckInterval)
+	}
+	sort.Strings(woken)
+	for _, id := range woken {
+		delete(m.snoozed, id)
+	}
+
+	text := fmt.Sprintf("⏰ %s is back from snooze", woken[0])
+	if issue, ok := m.issueMap[woken[0]]; ok && len(woken) == 1 {
+		text = fmt.Sprintf("⏰ %s is back from snooze: %s", woken[0], issue.Title)
+	} else if len(woken) > 1 {
+		text = fmt.Sprintf("⏰ %d issues are back from snooze: %s", len(woken), strings.Join(woken, ", "))
+	}
+	selectedID := ""
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		selectedID = item.Issue.ID
+	}
+	m.saveSnoozed(text)
+	if selectedID != "" {
+		m.selectListItem(selectedID)
+	}
+	return tea.Batch(m.toasts.Push(ToastInfo, text), snoozeTickCmd(snoozeCheckInterval))
+}
+
+// selectListItem selects the list row of issueID, if it is shown
+func (m *Model) selectListItem(issueID string) {
+	for i, item := range m.list.Items() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == issueID {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// renderSnoozedMD notes in the detail view when the issue wakes up
+func (m Model) renderSnoozedMD(issueID string) string {
+	until, ok := m.snoozed[issueID]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s **Snoozed** until %s *(Z to wake)*\n\n", snoozeBadge, until.Format("Mon Jan 2 2006 15:04"))
+}
+
+func (m Model) renderSnoozePrompt() string {
+	t := m.theme
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 3)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	title := m.snoozeIssueID
+	if issue, ok := m.issueMap[m.snoozeIssueID]; ok {
+		title += ": " + truncateRunesHelper(issue.Title, 40, "…")
+	}
+	content := titleStyle.Render(snoozeBadge+" Snooze "+title) + "\n\n" +
+		mutedStyle.Render("Hidden from your views until then; the beads file is not changed") + "\n\n" +
+		m.snoozeInput.View() + "\n\n" +
+		mutedStyle.Render("Examples: 3d, 2w, 4h, tomorrow, 2025-07-01") + "\n\n" +
+		mutedStyle.Render("Enter: snooze • Esc: cancel")
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseSnoozeUntil(t *testing.T) {
+	now := time.Date(2025, 6, 10, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"3d", time.Date(2025, 6, 13, 0, 0, 0, 0, time.UTC)},
+		{"2W", time.Date(2025, 6, 24, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow", time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC)},
+		{"4h", now.Add(4 * time.Hour)},
+		{" 2025-07-01 ", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseSnoozeUntil(tt.spec, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseSnoozeUntil(%q) = %v, %v; want %v", tt.spec, got, err, tt.want)
+		}
+	}
+	for _, spec := range []string{"", "0d", "xd", "soon", "2025-01-01", "-4h"} {
+		if _, err := ParseSnoozeUntil(spec, now); err == nil {
+			t.Errorf("ParseSnoozeUntil(%q) should fail", spec)
+		}
+	}
+}
+
+func TestSnoozeIssue(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusOpen, Priority: 0},
+		{ID: "B", Title: "Second", Status: model.StatusOpen, Priority: 1},
+	}
+	m := NewModel(issues, nil, "")
+	m.workDir = t.TempDir()
+
+	press := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, key := range keys {
+			updated, _ := m.Update(key)
+			m = updated.(Model)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	listed := func() string {
+		var ids []string
+		for _, item := range m.list.Items() {
+			ids = append(ids, item.(IssueItem).Issue.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	press(runes("Z"))
+	if !m.showSnoozePrompt || m.snoozeIssueID != "A" {
+		t.Fatalf("Z should prompt for A, got prompt=%v id=%q", m.showSnoozePrompt, m.snoozeIssueID)
+	}
+	press(runes("3"), runes("d"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showSnoozePrompt || !m.isSnoozed("A") {
+		t.Fatalf("A should be snoozed; status %q", m.statusMsg)
+	}
+	if got := listed(); got != "B" {
+		t.Errorf("list = %s, want the snoozed issue hidden", got)
+	}
+	if saved, err := LoadSnoozed(m.workDir); err != nil || !saved["A"].Equal(m.snoozed["A"]) {
+		t.Errorf("snooze not saved: %v, %v", saved, err)
+	}
+
+	press(runes("W"))
+	if got := listed(); got != "A" {
+		t.Errorf("snoozed filter = %s, want A", got)
+	}
+	if !strings.Contains(m.renderSnoozedMD("A"), "Snoozed") {
+		t.Error("detail view should note the snooze")
+	}
+	press(runes("o"))
+
+	// Expired snoozes come back with a toast
+	if cmd := m.handleSnoozeTick(SnoozeTickMsg{Now: time.Now()}); cmd == nil || len(m.toasts.Active()) != 0 {
+		t.Error("nothing should wake before the snooze expires")
+	}
+	m.handleSnoozeTick(SnoozeTickMsg{Now: m.snoozed["A"]})
+	if m.isSnoozed("A") || listed() != "A,B" {
+		t.Errorf("A should be back, list = %s", listed())
+	}
+	if active := m.toasts.Active(); len(active) != 1 || !strings.Contains(active[0].Message, "A is back from snooze: First") {
+		t.Errorf("toasts = %+v", active)
+	}
+
+	// Z on a snoozed issue wakes it early
+	m.snoozed["B"] = time.Now().Add(time.Hour)
+	m.currentFilter = "snoozed"
+	m.applyFilter()
+	press(runes("Z"))
+	if m.isSnoozed("B") || m.showSnoozePrompt {
+		t.Error("Z should wake a snoozed issue")
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// starredBadge marks starred issues in the list and detail views
+const starredBadge = "★"
+
+// LoadStarred reads the starred issue IDs of projectDir. A missing file
+// yields an empty set.
+func LoadStarred(projectDir string) (map[string]bool, error) {
+	var ids []string
+	if err := readProjectStateKey(projectDir, "starred", &ids); err != nil {
+		return nil, err
+	}
+	starred := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		starred[id] = true
+	}
+	return starred, nil
+}
+
+// SaveStarred writes the starred issue IDs of projectDir
+func SaveStarred(projectDir string, starred map[string]bool) error {
+	ids := make([]string, 0, len(starred))
+	for id, on := range starred {
+		if on {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return writeProjectStateKey(projectDir, "starred", ids)
+}
+
+// loadStarredIssues loads the starred set of the project owning beadsPath,
+// empty when there is none or it cannot be read
+func loadStarredIssues(beadsPath string) map[string]bool {
+	if beadsPath == "" {
+		return nil
+	}
+	starred, err := LoadStarred(filepath.Dir(filepath.Dir(beadsPath)))
+	if err != nil {
+		return nil
+	}
+	return starred
+}
+
+// toggleStar stars or unstars the selected issue and saves the change
+func (m *Model) toggleStar() {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+	id := item.Issue.ID
+	if m.starred == nil {
+		m.starred = make(map[string]bool)
+	}
+	if m.starred[id] {
+		delete(m.starred, id)
+		m.statusMsg = fmt.Sprintf("☆ Unstarred %s", id)
+	} else {
+		m.starred[id] = true
+		m.statusMsg = fmt.Sprintf("%s Starred %s", starredBadge, id)
+	}
+	m.statusIsError = false
+	if m.workDir != "" {
+		if err := SaveStarred(m.workDir, m.starred); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Saving stars: %v", err)
+			m.statusIsError = true
+		}
+	}
+
+	m.updateListDelegate()
+	m.refreshFilteredViews()
+	m.selectListItem(id)
+	m.updateViewportContent()
+}
+
+// pinStarred moves starred issues ahead of unstarred ones, keeping the
+// existing order within each group
+func (m *Model) pinStarred(items []list.Item, issues []model.Issue) {
+	if len(m.starred) == 0 {
+		return
+	}
+	isStarred := func(item list.Item) bool {
+		issueItem, ok := item.(IssueItem)
+		return ok && m.starred[issueItem.Issue.ID]
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return isStarred(items[i]) && !isStarred(items[j])
+	})
+	sort.SliceStable(issues, func(i, j int) bool {
+		return m.starred[issues[i].ID] && !m.starred[issues[j].ID]
+	})
+}
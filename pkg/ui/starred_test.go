@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStarredRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	starred, err := LoadStarred(dir)
+	if err != nil || len(starred) != 0 {
+		t.Fatalf("missing state.json: got %v, %v", starred, err)
+	}
+
+	// Keys other than "starred" survive a save
+	path := filepath.Join(dir, ".bv", ProjectStateFilename)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"other": 1, "starred": ["OLD"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveStarred(dir, map[string]bool{"B": true, "A": true, "C": false}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"other": 1`) {
+		t.Errorf("other keys were dropped:\n%s", data)
+	}
+	starred, err = LoadStarred(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(starred) != 2 || !starred["A"] || !starred["B"] {
+		t.Errorf("starred = %v, want A and B", starred)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadStarred(dir); err == nil {
+		t.Error("expected an error for a malformed state.json")
+	}
+}
+
+func TestToggleStar(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusOpen, Priority: 0},
+		{ID: "B", Title: "Second", Status: model.StatusOpen, Priority: 1},
+		{ID: "C", Title: "Third", Status: model.StatusOpen, Priority: 2},
+	}
+	m := NewModel(issues, nil, "")
+	m.workDir = t.TempDir()
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+	order := func() string {
+		var ids []string
+		for _, item := range m.list.Items() {
+			ids = append(ids, item.(IssueItem).Issue.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	m.list.Select(2)
+	press("*")
+	if !m.starred["C"] {
+		t.Fatalf("C should be starred; status %q", m.statusMsg)
+	}
+	if got := order(); got != "C,A,B" {
+		t.Errorf("order = %s, want the starred issue first", got)
+	}
+	if m.selectedIssueID() != "C" {
+		t.Errorf("selection moved to %s", m.selectedIssueID())
+	}
+	if saved, err := LoadStarred(m.workDir); err != nil || !saved["C"] {
+		t.Errorf("stars not saved: %v, %v", saved, err)
+	}
+
+	press("z")
+	if got := order(); got != "C" {
+		t.Errorf("starred filter shows %s, want C", got)
+	}
+
+	press("*")
+	if m.starred["C"] || len(m.list.Items()) != 0 {
+		t.Errorf("C should be unstarred and leave the starred filter: %v", m.starred)
+	}
+}
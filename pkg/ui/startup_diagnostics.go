@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StartupProfileMsg carries a profiled re-run of the graph analysis
+type StartupProfileMsg struct {
+	Profile *analysis.StartupProfile
+}
+
+// SetLoadDuration records how long reading the beads file took, for the
+// startup diagnostics
+func (m *Model) SetLoadDuration(d time.Duration) {
+	m.loadDuration = d
+}
+
+// startupProfileCmd re-runs the analysis of issues with config in the
+// background, timing each phase as --profile-startup does
+func startupProfileCmd(issues []model.Issue, config analysis.AnalysisConfig) tea.Cmd {
+	return func() tea.Msg {
+		buildStart := time.Now()
+		analyzer := analysis.NewAnalyzer(issues)
+		buildDuration := time.Since(buildStart)
+		_, profile := analyzer.AnalyzeWithProfile(config)
+		profile.BuildGraph = buildDuration
+		return StartupProfileMsg{Profile: profile}
+	}
+}
+
+// openStartupDiagnostics opens the diagnostics modal and starts profiling
+// with the configuration this session's analysis used
+func (m *Model) openStartupDiagnostics() tea.Cmd {
+	config := analysis.TunedConfigForSize(len(m.issues), 0)
+	if m.analysis != nil {
+		config = m.analysis.Config
+	}
+	m.showDiagnostics = true
+	m.diagnosticsProfile = nil
+	return startupProfileCmd(m.issues, config)
+}
+
+// handleDiagnosticsKeys handles keys while the diagnostics modal is open
+func (m Model) handleDiagnosticsKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "ctrl+g":
+		m.showDiagnostics = false
+	case "r":
+		return m, m.openStartupDiagnostics()
+	}
+	return m, nil
+}
+
+// renderStartupDiagnostics renders phase timings, timeouts and tuning
+// suggestions for the current issue set
+func (m Model) renderStartupDiagnostics() string {
+	t := m.theme
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(min(84, m.width-4))
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	headStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	warnStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("🩺 Startup Diagnostics"))
+	sb.WriteString("\n\n")
+
+	p := m.diagnosticsProfile
+	if p == nil {
+		sb.WriteString(mutedStyle.Render(fmt.Sprintf("Profiling the analysis of %d issues…", len(m.issues))))
+		return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+	}
+
+	row := func(name string, d time.Duration, note string) {
+		sb.WriteString(fmt.Sprintf("  %-15s %8s", name, formatProfileDuration(d)))
+		if note != "" {
+			sb.WriteString("  " + note)
+		}
+		sb.WriteString("\n")
+	}
+	metric := func(name string, d time.Duration, timedOut, computed bool, reason string) {
+		switch {
+		case !computed:
+			text := "skipped"
+			if reason != "" {
+				text += ": " + reason
+			}
+			sb.WriteString(fmt.Sprintf("  %-15s %8s  %s\n", name, "-", mutedStyle.Render(text)))
+		case timedOut:
+			row(name, d, warnStyle.Render("TIMEOUT"))
+		default:
+			row(name, d, "")
+		}
+	}
+
+	sb.WriteString(mutedStyle.Render(fmt.Sprintf("%d issues, %d dependencies, density %.4f · %s",
+		p.NodeCount, p.EdgeCount, p.Density, analysis.SizeTier(p.NodeCount))))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(headStyle.Render("Phase 1 (blocking)"))
+	sb.WriteString("\n")
+	if m.loadDuration > 0 {
+		row("Load beads", m.loadDuration, "")
+	}
+	row("Build graph", p.BuildGraph, "")
+	row("Degree", p.Degree, "")
+	row("Topo sort", p.TopoSort, "")
+	sb.WriteString("\n")
+
+	cfg := p.Config
+	sb.WriteString(headStyle.Render("Phase 2 (background)"))
+	sb.WriteString("\n")
+	metric("PageRank", p.PageRank, p.PageRankTO, cfg.ComputePageRank, cfg.PageRankSkipReason)
+	betweenness := "Betweenness"
+	if cfg.BetweennessMode == analysis.BetweennessApproximate {
+		betweenness = "Betweenness≈"
+	}
+	metric(betweenness, p.Betweenness, p.BetweennessTO, cfg.ComputeBetweenness, cfg.BetweennessSkipReason)
+	if p.BetweennessSample > 0 {
+		state := "budget exhausted"
+		if p.BetweennessConverged {
+			state = "converged"
+		}
+		sb.WriteString(mutedStyle.Render(fmt.Sprintf("    %d pivots, %d rounds, ±%.1f%% (%s)",
+			p.BetweennessSample, p.BetweennessRounds, p.BetweennessError*100, state)))
+		sb.WriteString("\n")
+	}
+	metric("Eigenvector", p.Eigenvector, false, cfg.ComputeEigenvector, "")
+	metric("HITS", p.HITS, p.HITSTO, cfg.ComputeHITS, cfg.HITSSkipReason)
+	metric("Critical path", p.CriticalPath, false, cfg.ComputeCriticalPath, "")
+	cycles := ""
+	if p.CycleCount > 0 {
+		cycles = fmt.Sprintf("%d found", p.CycleCount)
+	}
+	if cfg.ComputeCycles && !p.CyclesTO {
+		row("Cycles", p.Cycles, cycles)
+	} else {
+		metric("Cycles", p.Cycles, p.CyclesTO, cfg.ComputeCycles, cfg.CyclesSkipReason)
+	}
+	row("Total", m.loadDuration+p.Total, mutedStyle.Render(fmt.Sprintf("%d workers", p.Phase2Workers)))
+	sb.WriteString("\n")
+
+	sb.WriteString(headStyle.Render("Suggestions"))
+	sb.WriteString("\n")
+	for _, rec := range analysis.ProfileRecommendations(p, m.loadDuration) {
+		if strings.HasPrefix(rec, "⚠") {
+			rec = warnStyle.Render(rec)
+		}
+		sb.WriteString("  " + rec + "\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("Timed by re-running this session's analysis • bv --profile-startup prints the same"))
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("r: re-run • Esc: close"))
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
+
+// formatProfileDuration shows sub-millisecond timings with two decimals
+func formatProfileDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%.2fms", float64(d.Microseconds())/1000)
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStartupDiagnostics(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusOpen},
+		{ID: "B", Title: "Second", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 120, 50
+	m.SetLoadDuration(3 * time.Millisecond)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = updated.(Model)
+	if !m.showDiagnostics || cmd == nil {
+		t.Fatal("ctrl+g should open diagnostics and start profiling")
+	}
+	if !strings.Contains(m.renderStartupDiagnostics(), "Profiling the analysis of 2 issues") {
+		t.Error("diagnostics should show progress until the profile arrives")
+	}
+
+	msg, ok := cmd().(StartupProfileMsg)
+	if !ok || msg.Profile == nil || msg.Profile.NodeCount != 2 || msg.Profile.EdgeCount != 1 {
+		t.Fatalf("profile msg = %+v", msg)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	view := m.renderStartupDiagnostics()
+	for _, want := range []string{"Load beads", "PageRank", "Betweenness", "Small (<100 issues)", "Suggestions", "Startup within acceptable range"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("diagnostics missing %q:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).showDiagnostics {
+		t.Error("esc should close diagnostics")
+	}
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// StatusBarConfigFilename is the project config that picks the status bar
+// segments.
+const StatusBarConfigFilename = "status_bar.yaml"
+
+// Status bar segments that can be hidden in .bv/status_bar.yaml
+const (
+	SegmentSource   = "source"   // Beads file, or the workspace summary
+	SegmentFilter   = "filter"   // Active filter, recipe or repo selection
+	SegmentSort     = "sort"     // Sort mode when not the default
+	SegmentCounts   = "counts"   // Issues by status
+	SegmentAnalysis = "analysis" // Whether full graph analysis has finished
+	SegmentReload   = "reload"   // Time the data was last loaded
+	SegmentAlerts   = "alerts"   // Active project health alerts
+	SegmentSessions = "sessions" // Cass sessions for the selected issue
+	SegmentBranch   = "branch"   // Current git branch
+	SegmentUpdate   = "update"   // New bv release
+	SegmentTotal    = "total"    // Number of issues listed
+	SegmentHints    = "hints"    // Key hints
+)
+
+// StatusBarSegments lists every segment name accepted in the config
+var StatusBarSegments = []string{
+	SegmentSource, SegmentFilter, SegmentSort, SegmentCounts, SegmentAnalysis, SegmentReload,
+	SegmentAlerts, SegmentSessions, SegmentBranch, SegmentUpdate, SegmentTotal, SegmentHints,
+}
+
+// StatusBarConfig chooses which segments the status bar shows.
+type StatusBarConfig struct {
+	// Hidden lists segments left out of the status bar.
+	Hidden []string `yaml:"hidden,omitempty"`
+}
+
+// LoadStatusBarConfig loads .bv/status_bar.yaml from projectDir.
+// Returns an empty config, showing every segment, if the file doesn't exist.
+func LoadStatusBarConfig(projectDir string) (*StatusBarConfig, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, ".bv", StatusBarConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &StatusBarConfig{}, nil
+		}
+		return nil, fmt.Errorf("reading status bar config: %w", err)
+	}
+
+	config := &StatusBarConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing status bar config: %w", err)
+	}
+	for _, name := range config.Hidden {
+		if !isStatusBarSegment(name) {
+			return nil, fmt.Errorf("unknown status bar segment %q (known: %s)", name, strings.Join(StatusBarSegments, ", "))
+		}
+	}
+	return config, nil
+}
+
+// loadStatusBarConfig finds the project config next to the beads file,
+// falling back to an empty config when it is missing or invalid.
+func loadStatusBarConfig(beadsPath string) *StatusBarConfig {
+	projectDir, err := historyRepoPath(beadsPath)
+	if err != nil {
+		return &StatusBarConfig{}
+	}
+	config, err := LoadStatusBarConfig(projectDir)
+	if err != nil {
+		return &StatusBarConfig{}
+	}
+	return config
+}
+
+func isStatusBarSegment(name string) bool {
+	for _, s := range StatusBarSegments {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Shows reports whether segment is enabled.
+func (c *StatusBarConfig) Shows(segment string) bool {
+	if c == nil {
+		return true
+	}
+	for _, name := range c.Hidden {
+		if name == segment {
+			return false
+		}
+	}
+	return true
+}
+
+// renderSourceSegment shows where the issues come from: the beads file, or
+// the workspace summary in multi-repo mode
+func (m Model) renderSourceSegment() string {
+	if m.workspaceMode && m.workspaceSummary != "" {
+		return lipgloss.NewStyle().
+			Background(lipgloss.Color("#45B7D1")).
+			Foreground(ColorBg).
+			Bold(true).
+			Padding(0, 1).
+			Render(fmt.Sprintf("📦 %s", m.workspaceSummary))
+	}
+	if m.beadsPath == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Background(ColorBgHighlight).
+		Foreground(ColorSubtext).
+		Padding(0, 1).
+		Render("📄 " + truncateRunesHelper(filepath.Base(m.beadsPath), 24, "…"))
+}
+
+// renderAnalysisSegment shows whether the slower graph metrics (PageRank,
+// betweenness, cycles) are still being computed, and flags when large issue
+// sets leave closed issues out of the analysis (see analysisScope)
+func (m Model) renderAnalysisSegment() string {
+	if m.analysis == nil {
+		return ""
+	}
+	label := "graph"
+	if m.lazyLoad {
+		label = "graph (unresolved only)"
+	}
+	style := lipgloss.NewStyle().Background(ColorBgHighlight).Padding(0, 1)
+	if m.analysis.IsPhase2Ready() {
+		return style.Foreground(ColorSuccess).Render("✓ " + label)
+	}
+	return style.Foreground(ColorWarning).Render("⏳ " + label)
+}
+
+// renderReloadSegment shows when the issues were last loaded from disk
+func (m Model) renderReloadSegment() string {
+	if m.loadedAt.IsZero() || m.pastSnapshot != nil {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Background(ColorBgHighlight).
+		Foreground(ColorMuted).
+		Padding(0, 1).
+		Render("↻ " + m.loadedAt.Format("15:04:05"))
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeStatusBarConfig(t *testing.T, dir, config string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", StatusBarConfigFilename), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadStatusBarConfig(t *testing.T) {
+	empty, err := LoadStatusBarConfig(t.TempDir())
+	if err != nil || !empty.Shows(SegmentReload) {
+		t.Fatalf("expected every segment without a file, got %+v, %v", empty, err)
+	}
+
+	dir := t.TempDir()
+	writeStatusBarConfig(t, dir, "hidden: [reload, hints]\n")
+	cfg, err := LoadStatusBarConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadStatusBarConfig() error = %v", err)
+	}
+	if cfg.Shows(SegmentReload) || cfg.Shows(SegmentHints) || !cfg.Shows(SegmentCounts) {
+		t.Errorf("unexpected segments for %+v", cfg)
+	}
+
+	writeStatusBarConfig(t, dir, "hidden: [clock]\n")
+	if _, err := LoadStatusBarConfig(dir); err == nil || !strings.Contains(err.Error(), "clock") {
+		t.Errorf("expected an unknown segment error, got %v", err)
+	}
+
+	var none *StatusBarConfig
+	if !none.Shows(SegmentSource) {
+		t.Error("a nil config should show every segment")
+	}
+}
+
+func TestFooterSegments(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	beadsPath := filepath.Join(beadsDir, "beads.jsonl")
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}
+
+	m := NewModel(issues, nil, beadsPath)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 30})
+	m = updated.(Model)
+	m.statusMsg = ""
+	m.loadedAt = time.Date(2025, 1, 2, 13, 4, 5, 0, time.Local)
+
+	footer := m.renderFooter()
+	for _, want := range []string{"📄 beads.jsonl", "graph", "↻ 13:04:05", "1 issues", "ALL"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("footer missing %q:\n%s", want, footer)
+		}
+	}
+
+	m.statusBar = &StatusBarConfig{Hidden: []string{SegmentSource, SegmentReload, SegmentAnalysis, SegmentTotal, SegmentFilter}}
+	footer = m.renderFooter()
+	for _, hidden := range []string{"beads.jsonl", "graph", "↻", "1 issues", "ALL"} {
+		if strings.Contains(footer, hidden) {
+			t.Errorf("footer should hide %q:\n%s", hidden, footer)
+		}
+	}
+
+	// The project config is picked up next to the beads file
+	writeStatusBarConfig(t, dir, "hidden: [counts]\n")
+	if cfg := loadStatusBarConfig(beadsPath); cfg.Shows(SegmentCounts) {
+		t.Errorf("expected counts hidden by the project config, got %+v", cfg)
+	}
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/templates"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IssueCreatedMsg reports the result of creating an issue from a template
+type IssueCreatedMsg struct {
+	Issue    model.Issue
+	Template string
+	Err      error
+}
+
+// TemplatePickerModel represents the issue template picker overlay
+type TemplatePickerModel struct {
+	templates     []templates.Template
+	selectedIndex int
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewTemplatePickerModel creates a new template picker
+func NewTemplatePickerModel(tmpls []templates.Template, theme Theme) TemplatePickerModel {
+	return TemplatePickerModel{
+		templates: tmpls,
+		theme:     theme,
+	}
+}
+
+// SetSize updates the picker dimensions
+func (m *TemplatePickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *TemplatePickerModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *TemplatePickerModel) MoveDown() {
+	if m.selectedIndex < len(m.templates)-1 {
+		m.selectedIndex++
+	}
+}
+
+// SelectedTemplate returns the currently selected template
+func (m *TemplatePickerModel) SelectedTemplate() *templates.Template {
+	if len(m.templates) == 0 || m.selectedIndex >= len(m.templates) {
+		return nil
+	}
+	return &m.templates[m.selectedIndex]
+}
+
+// View renders the template picker overlay
+func (m *TemplatePickerModel) View() string {
+	if m.width == 0 {
+		m.width = 60
+	}
+	if m.height == 0 {
+		m.height = 20
+	}
+
+	t := m.theme
+
+	boxWidth := 60
+	if m.width < 70 {
+		boxWidth = m.width - 10
+	}
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+
+	var lines []string
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		MarginBottom(1)
+	lines = append(lines, titleStyle.Render("New Issue from Template"))
+	lines = append(lines, "")
+
+	now := time.Now()
+	for i, tmpl := range m.templates {
+		isSelected := i == m.selectedIndex
+
+		nameStyle := t.Renderer.NewStyle()
+		if isSelected {
+			nameStyle = nameStyle.Foreground(t.Primary).Bold(true)
+		} else {
+			nameStyle = nameStyle.Foreground(t.Base.GetForeground())
+		}
+
+		prefix := "  "
+		if isSelected {
+			prefix = "▸ "
+		}
+		name := prefix + tmpl.Name
+		if tmpl.Recurrence != nil {
+			name += " 🔁 " + tmpl.Recurrence.Every
+		}
+		lines = append(lines, nameStyle.Render(name))
+
+		// Preview of the title this instance would get, plus its labels
+		preview := tmpl.RenderTitle(now)
+		if len(tmpl.Labels) > 0 {
+			preview += "  [" + strings.Join(tmpl.Labels, ", ") + "]"
+		}
+		descStyle := t.Renderer.NewStyle().
+			Foreground(t.Secondary).
+			Italic(true)
+		lines = append(lines, descStyle.Render("    "+truncateRunesHelper(preview, boxWidth-8, "…")))
+
+		if i < len(m.templates)-1 {
+			lines = append(lines, "")
+		}
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("j/k: navigate • enter: create • esc: cancel"))
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(strings.Join(lines, "\n")),
+	)
+}
+
+// openTemplatePicker loads the templates next to the beads file and shows the picker
+func (m *Model) openTemplatePicker() {
+	if m.refuseReadOnly() {
+		return
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ No beads file to write to"
+		m.statusIsError = true
+		return
+	}
+	dir := templates.Dir(filepath.Dir(m.beadsPath))
+	tmpls, warnings, err := templates.Load(dir)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		m.statusIsError = true
+		return
+	}
+	if len(tmpls) == 0 {
+		m.statusMsg = fmt.Sprintf("No issue templates in %s", dir)
+		if len(warnings) > 0 {
+			m.statusMsg += fmt.Sprintf(" (%d invalid: %s)", len(warnings), warnings[0])
+		}
+		m.statusIsError = len(warnings) > 0
+		return
+	}
+	m.templatePicker = NewTemplatePickerModel(tmpls, m.theme)
+	m.templatePicker.SetSize(m.width, m.height-1)
+	m.showTemplatePicker = true
+	if len(warnings) > 0 {
+		m.statusMsg = fmt.Sprintf("⚠ Skipped %d invalid template(s): %s", len(warnings), warnings[0])
+		m.statusIsError = true
+	}
+}
+
+// handleTemplatePickerKeys handles keys while the template picker is open
+func (m Model) handleTemplatePickerKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "+":
+		m.showTemplatePicker = false
+	case "j", "down":
+		m.templatePicker.MoveDown()
+	case "k", "up":
+		m.templatePicker.MoveUp()
+	case "enter":
+		tmpl := m.templatePicker.SelectedTemplate()
+		if tmpl == nil {
+			return m, nil
+		}
+		m.showTemplatePicker = false
+		fallback := filepath.Base(filepath.Dir(filepath.Dir(m.beadsPath)))
+		issue := tmpl.Instantiate(loader.NewIssueID(m.issues, fallback), time.Time{}, time.Now().UTC())
+		return m, createIssueCmd(m.beadsPath, issue, tmpl.Name)
+	}
+	return m, nil
+}
+
+// createIssueCmd appends a new issue to the beads file
+func createIssueCmd(beadsPath string, issue model.Issue, templateName string) tea.Cmd {
+	return func() tea.Msg {
+		err := loader.AppendIssuesToFile(beadsPath, []model.Issue{issue})
+		return IssueCreatedMsg{Issue: issue, Template: templateName, Err: err}
+	}
+}
+
+// showCreatedIssue adds a just-written issue to the view and selects it,
+// ahead of the watcher's reload
+func (m *Model) showCreatedIssue(issue model.Issue) []tea.Cmd {
+	issues := make([]model.Issue, 0, len(m.issues)+1)
+	issues = append(issues, m.issues...)
+	issues = append(issues, issue)
+	_, cmds := m.replaceIssues(issues)
+	if !m.jumpToIssue(issue.ID) {
+		m.clearAllFilters()
+		m.jumpToIssue(issue.ID)
+	}
+	return cmds
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTemplatePickerCreatesIssue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	content := `{"id":"bv-1","title":"Alpha","status":"open","priority":1,"issue_type":"task"}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	send := func(msg tea.Msg) tea.Cmd {
+		tm, cmd := m.Update(msg)
+		m = tm.(Model)
+		return cmd
+	}
+	runes := func(k string) tea.Cmd { return send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+
+	runes("+")
+	if m.showTemplatePicker || !strings.Contains(m.statusMsg, "No issue templates") {
+		t.Fatalf("without templates the picker should not open, status %q", m.statusMsg)
+	}
+
+	tmplDir := filepath.Join(dir, "templates")
+	if err := os.MkdirAll(tmplDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{
+		"bug.yaml":     "title: Bug in {{date}} build\ntype: bug\nlabels: [triage]\nchecklist: [Reproduce]\n",
+		"release.yaml": "title: Release {{month}}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(tmplDir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runes("+")
+	if !m.showTemplatePicker {
+		t.Fatalf("+ should open the template picker, status %q", m.statusMsg)
+	}
+	if view := m.templatePicker.View(); !strings.Contains(view, "bug") || !strings.Contains(view, "[triage]") {
+		t.Errorf("picker should list templates with labels:\n%s", view)
+	}
+	cmd := send(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.showTemplatePicker {
+		t.Fatal("enter should close the picker and create the issue")
+	}
+	created, ok := cmd().(IssueCreatedMsg)
+	if !ok || created.Err != nil || created.Template != "bug" {
+		t.Fatalf("unexpected create result %+v", created)
+	}
+	send(created)
+
+	if m.selectedIssueID() != created.Issue.ID || !strings.HasPrefix(created.Issue.ID, "bv-") {
+		t.Errorf("new issue %s should be selected, got %s", created.Issue.ID, m.selectedIssueID())
+	}
+	reloaded, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded) != 2 || reloaded[1].IssueType != "bug" || !strings.Contains(reloaded[1].Description, "- [ ] Reproduce") {
+		t.Errorf("issue not written as expected: %+v", reloaded)
+	}
+
+	// Past snapshots are read-only
+	m.pastSnapshot = &PastSnapshot{}
+	runes("+")
+	if m.showTemplatePicker || !m.statusIsError {
+		t.Error("template picker should refuse read-only views")
+	}
+}
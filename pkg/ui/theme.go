@@ -35,6 +35,10 @@ type Theme struct {
 	Selected lipgloss.Style
 	Column   lipgloss.Style
 	Header   lipgloss.Style
+
+	// LabelColors pins label colors by lower-case label
+	// (.bv/label_colors.yaml); other labels get a hashed color
+	LabelColors map[string]lipgloss.Color
 }
 
 // DefaultTheme returns the standard Dracula-inspired theme (adaptive)
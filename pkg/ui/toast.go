@@ -0,0 +1,287 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Toast timing and size limits
+const (
+	toastDuration      = 4 * time.Second
+	toastErrorDuration = 8 * time.Second // Failures stay long enough to read
+	maxVisibleToasts   = 3
+	maxToastHistory    = 100
+	toastMaxWidth      = 50
+)
+
+// ToastLevel is the severity of a notification
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+// icon returns the glyph shown before a toast of this level
+func (l ToastLevel) icon() string {
+	switch l {
+	case ToastSuccess:
+		return "✓"
+	case ToastWarning:
+		return "⚠"
+	case ToastError:
+		return "✗"
+	default:
+		return "ℹ"
+	}
+}
+
+// label names the level in words, for accessible mode
+func (l ToastLevel) label() string {
+	switch l {
+	case ToastSuccess:
+		return "Done"
+	case ToastWarning:
+		return "Warning"
+	case ToastError:
+		return "Error"
+	default:
+		return "Info"
+	}
+}
+
+// color returns the accent color for a toast of this level
+func (l ToastLevel) color() lipgloss.Color {
+	switch l {
+	case ToastSuccess:
+		return ColorSuccess
+	case ToastWarning:
+		return ColorWarning
+	case ToastError:
+		return ColorDanger
+	default:
+		return ColorInfo
+	}
+}
+
+// Toast is a single notification
+type Toast struct {
+	ID      int
+	Level   ToastLevel
+	Message string
+	Time    time.Time
+}
+
+// toastExpiredMsg removes a toast once its display time is over
+type toastExpiredMsg struct {
+	ID int
+}
+
+// Notifications holds the toasts on screen and the history of past ones
+type Notifications struct {
+	active  []Toast
+	history []Toast // Oldest first, at most maxToastHistory
+	nextID  int
+}
+
+// Push shows a toast and records it in the history. The returned command
+// expires the toast after its display time.
+func (n *Notifications) Push(level ToastLevel, message string) tea.Cmd {
+	n.nextID++
+	t := Toast{ID: n.nextID, Level: level, Message: message, Time: time.Now()}
+
+	n.active = append(n.active, t)
+	if len(n.active) > maxVisibleToasts {
+		n.active = n.active[len(n.active)-maxVisibleToasts:]
+	}
+	n.history = append(n.history, t)
+	if len(n.history) > maxToastHistory {
+		n.history = n.history[len(n.history)-maxToastHistory:]
+	}
+
+	d := toastDuration
+	if level == ToastError {
+		d = toastErrorDuration
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg { return toastExpiredMsg{ID: t.ID} })
+}
+
+// Expire removes the toast with id from the screen; it stays in the history
+func (n *Notifications) Expire(id int) {
+	for i, t := range n.active {
+		if t.ID == id {
+			n.active = append(n.active[:i:i], n.active[i+1:]...)
+			return
+		}
+	}
+}
+
+// DismissAll clears every toast from the screen
+func (n *Notifications) DismissAll() {
+	n.active = nil
+}
+
+// Active returns the toasts currently on screen, oldest first
+func (n Notifications) Active() []Toast {
+	return n.active
+}
+
+// History returns past notifications, newest first
+func (n Notifications) History() []Toast {
+	out := make([]Toast, len(n.history))
+	for i, t := range n.history {
+		out[len(n.history)-1-i] = t
+	}
+	return out
+}
+
+// renderToasts renders the active toasts as a right-aligned stack, newest
+// at the bottom, or "" when there are none
+func (m Model) renderToasts() string {
+	active := m.toasts.Active()
+	if len(active) == 0 {
+		return ""
+	}
+	width := min(toastMaxWidth, m.width-4)
+	if width < 20 {
+		return ""
+	}
+
+	var boxes []string
+	for _, t := range active {
+		style := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(t.Level.color()).
+			Padding(0, 1).
+			Width(width - 2) // Border
+		icon := lipgloss.NewStyle().Foreground(t.Level.color()).Bold(true).Render(t.Level.icon())
+		text := truncateRunesHelper(t.Message, (width-6)*2, "…") // At most two lines
+		boxes = append(boxes, style.Render(icon+" "+text))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, boxes...)
+}
+
+// overlayToasts draws the active toasts over the top-right corner of body,
+// below its first (header) line
+func (m Model) overlayToasts(body string) string {
+	toasts := m.renderToasts()
+	if toasts == "" {
+		return body
+	}
+	lines := strings.Split(body, "\n")
+	toastLines := strings.Split(toasts, "\n")
+	toastWidth := lipgloss.Width(toasts)
+	x := m.width - toastWidth - 1
+	if x < 0 {
+		return body
+	}
+
+	for i, tl := range toastLines {
+		row := i + 1
+		if row >= len(lines) {
+			break
+		}
+		line := lines[row]
+		left := ansi.Truncate(line, x, "")
+		if w := ansi.StringWidth(left); w < x {
+			left += strings.Repeat(" ", x-w)
+		}
+		right := ansi.TruncateLeft(line, x+toastWidth, "")
+		lines[row] = left + "\x1b[0m" + tl + right
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showNotificationHistory opens the notification history modal
+func (m *Model) showNotificationHistory() {
+	m.showNotifications = true
+	m.notificationScroll = 0
+	m.toasts.DismissAll()
+}
+
+// notificationPageSize is the number of history rows visible at once
+func (m Model) notificationPageSize() int {
+	h := m.height - 10 // border, padding, title, hint
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+// handleNotificationKeys handles keys while the notification history is open
+func (m Model) handleNotificationKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	maxScroll := len(m.toasts.History()) - m.notificationPageSize()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	switch msg.String() {
+	case "j", "down":
+		if m.notificationScroll < maxScroll {
+			m.notificationScroll++
+		}
+	case "k", "up":
+		if m.notificationScroll > 0 {
+			m.notificationScroll--
+		}
+	case "g", "home":
+		m.notificationScroll = 0
+	case "G", "end":
+		m.notificationScroll = maxScroll
+	case "esc", "q", "E":
+		m.showNotifications = false
+	}
+	return m, nil
+}
+
+// renderNotificationHistory renders the notification history modal
+func (m Model) renderNotificationHistory() string {
+	t := m.theme
+	width := min(90, m.width-4)
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	timeStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
+
+	history := m.toasts.History()
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("🔔 Notifications (%d)", len(history))))
+	sb.WriteString("\n\n")
+
+	if len(history) == 0 {
+		sb.WriteString(hintStyle.Render("No notifications yet"))
+		sb.WriteString("\n")
+	} else {
+		end := min(len(history), m.notificationScroll+m.notificationPageSize())
+		textWidth := width - 18 // padding, time, icon
+		for _, n := range history[m.notificationScroll:end] {
+			icon := t.Renderer.NewStyle().Foreground(n.Level.color()).Bold(true).Render(n.Level.icon())
+			sb.WriteString(timeStyle.Render(n.Time.Format("15:04:05")))
+			sb.WriteString("  ")
+			sb.WriteString(icon)
+			sb.WriteString(" ")
+			sb.WriteString(truncateRunesHelper(n.Message, textWidth, "…"))
+			sb.WriteString("\n")
+		}
+		if len(history) > m.notificationPageSize() {
+			sb.WriteString(hintStyle.Render(fmt.Sprintf("%d–%d of %d", m.notificationScroll+1, end, len(history))))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("j/k: scroll • Esc: close"))
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
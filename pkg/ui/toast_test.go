@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNotificationsPushExpireHistory(t *testing.T) {
+	var n Notifications
+	for i := 1; i <= maxVisibleToasts+1; i++ {
+		if cmd := n.Push(ToastInfo, fmt.Sprintf("event %d", i)); cmd == nil {
+			t.Fatal("Push should return an expiry command")
+		}
+	}
+	active := n.Active()
+	if len(active) != maxVisibleToasts || active[0].Message != "event 2" {
+		t.Fatalf("expected the newest %d toasts, got %+v", maxVisibleToasts, active)
+	}
+
+	n.Expire(active[0].ID)
+	if len(n.Active()) != maxVisibleToasts-1 {
+		t.Errorf("expired toast still shown: %+v", n.Active())
+	}
+	n.Expire(999) // Unknown IDs are ignored
+
+	history := n.History()
+	if len(history) != maxVisibleToasts+1 || history[0].Message != fmt.Sprintf("event %d", maxVisibleToasts+1) {
+		t.Errorf("history should keep every toast, newest first: %+v", history)
+	}
+
+	for i := 0; i < maxToastHistory+10; i++ {
+		n.Push(ToastWarning, "more")
+	}
+	if len(n.History()) != maxToastHistory {
+		t.Errorf("history should be capped at %d, got %d", maxToastHistory, len(n.History()))
+	}
+}
+
+func TestToastsOverlayBody(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+
+	body := strings.Repeat(strings.Repeat("x", 120)+"\n", 10)
+	if got := m.overlayToasts(body); got != body {
+		t.Error("body should be unchanged without toasts")
+	}
+
+	m.toasts.Push(ToastError, "Reload failed: boom")
+	got := m.overlayToasts(body)
+	lines := strings.Split(got, "\n")
+	if !strings.Contains(got, "Reload failed: boom") || lines[0] != strings.Repeat("x", 120) {
+		t.Fatalf("toast should be drawn below the header line:\n%s", got)
+	}
+	if !strings.HasPrefix(lines[1], "xxxxxxxxxx") {
+		t.Errorf("body left of the toast should be kept, got %q", lines[1])
+	}
+}
+
+func TestToastsForWriteBackAndUpdate(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+
+	updated, cmd := m.Update(DependenciesSavedMsg{IssueID: "1", Err: errors.New("disk full")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected a toast expiry command")
+	}
+	active := m.toasts.Active()
+	if len(active) != 1 || active[0].Level != ToastError || !strings.Contains(active[0].Message, "disk full") {
+		t.Fatalf("expected an error toast, got %+v", active)
+	}
+
+	updated, _ = m.Update(toastExpiredMsg{ID: active[0].ID})
+	m = updated.(Model)
+	if len(m.toasts.Active()) != 0 {
+		t.Error("toast should expire")
+	}
+
+	// Updates found while another view is open are announced with a toast
+	m.focused = focusInsights
+	updated, _ = m.Update(UpdateMsg{TagName: "v9.9.9"})
+	m = updated.(Model)
+	if active := m.toasts.Active(); len(active) != 1 || !strings.Contains(active[0].Message, "v9.9.9") {
+		t.Errorf("expected an update toast, got %+v", active)
+	}
+}
+
+func TestNotificationHistoryModal(t *testing.T) {
+	m := newReleaseNotesTestModel(t)
+	m.toasts.Push(ToastSuccess, "Saved bv-1 (title)")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	m = updated.(Model)
+	if !m.showNotifications {
+		t.Fatal("E should open the notification history")
+	}
+	if len(m.toasts.Active()) != 0 {
+		t.Error("opening the history should clear toasts from the screen")
+	}
+	if view := m.View(); !strings.Contains(view, "Notifications (1)") || !strings.Contains(view, "Saved bv-1 (title)") {
+		t.Errorf("history not rendered:\n%s", view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).showNotifications {
+		t.Error("esc should close the notification history")
+	}
+}
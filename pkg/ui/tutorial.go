@@ -45,6 +45,7 @@ type TutorialModel struct {
 	focus       tutorialFocus // Current focus: content or TOC
 	shouldClose bool          // Signal to parent to close tutorial
 	tocCursor   int           // Cursor position in TOC when focused
+	keyCount    countPrefix   // Count typed before a motion (5j); else a page number
 }
 
 // NewTutorialModel creates a new tutorial model with default pages.
@@ -81,7 +82,36 @@ func (m TutorialModel) Init() tea.Cmd {
 // Update handles keyboard input for the tutorial with focus management (bv-wdsd).
 func (m TutorialModel) Update(msg tea.Msg) (TutorialModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case countTimeoutMsg:
+		// No motion followed the digits: they were a page number
+		if m.keyCount.expired(msg) {
+			for _, key := range m.keyCount.takeDigits() {
+				m = m.handleContentKeys(key)
+			}
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		// Count prefixes in the content: 5j scrolls five lines, 3l turns
+		// three pages; digits alone still jump to a page
+		if m.focus == focusTutorialContent || !m.tocVisible {
+			key := msg.String()
+			if m.keyCount.feed(key) {
+				return m, m.keyCount.timeout()
+			}
+			if m.keyCount.pending() {
+				if tutorialCountableKeys[key] {
+					for range m.keyCount.take() - 1 {
+						m = m.handleContentKeys(msg)
+					}
+				} else {
+					for _, digit := range m.keyCount.takeDigits() {
+						m = m.handleContentKeys(digit)
+					}
+				}
+			}
+		}
+
 		// Global keys (work in any focus mode)
 		switch msg.String() {
 		case "esc", "q":
@@ -232,11 +262,11 @@ func (m TutorialModel) View() string {
 
 	// Calculate dimensions
 	contentWidth := m.width - 6 // padding and borders
-	if m.tocVisible {
+	if m.tocVisible && m.tocBeside() {
 		contentWidth -= 24 // TOC sidebar width
 	}
-	if contentWidth < 40 {
-		contentWidth = 40
+	if contentWidth < 20 {
+		contentWidth = 20
 	}
 
 	// Build the view
@@ -262,8 +292,11 @@ func (m TutorialModel) View() string {
 	b.WriteString(pageTitle)
 	b.WriteString("\n")
 
-	// Content area (with optional TOC)
-	if m.tocVisible {
+	// Content area (with optional TOC). On a narrow terminal the TOC takes
+	// the place of the content while it has focus instead of sitting beside it.
+	if m.tocVisible && !m.tocBeside() && m.focus == focusTutorialTOC {
+		b.WriteString(m.renderTOC(pages))
+	} else if m.tocVisible && m.tocBeside() {
 		toc := m.renderTOC(pages)
 		content := m.renderContent(currentPage, contentWidth)
 		// Join TOC and content horizontally
@@ -465,6 +498,11 @@ func (m TutorialModel) renderStructuredContent(page StructuredTutorialPage, widt
 	return content
 }
 
+// tocBeside reports whether the TOC fits beside the page content
+func (m TutorialModel) tocBeside() bool {
+	return m.width >= CompactViewThreshold
+}
+
 // renderTOC renders the table of contents sidebar with focus indication (bv-wdsd).
 func (m TutorialModel) renderTOC(pages []TutorialPage) string {
 	r := m.theme.Renderer
@@ -670,11 +708,11 @@ func (m *TutorialModel) SetSize(width, height int) {
 
 	// Update markdown renderer width to match content area
 	contentWidth := width - 6 // padding and borders
-	if m.tocVisible {
+	if m.tocVisible && m.tocBeside() {
 		contentWidth -= 24 // TOC sidebar width
 	}
-	if contentWidth < 40 {
-		contentWidth = 40
+	if contentWidth < 20 {
+		contentWidth = 20
 	}
 
 	if m.markdownRenderer != nil {
@@ -1442,6 +1480,7 @@ The **Graph view** visualizes these relationships:
 | **j/k** | Move between nodes vertically |
 | **h/l** | Move between siblings |
 | **f** | Focus on selected subgraph |
+| **+/-** | Zoom: ID-only chips, ID and title, or status and PageRank too |
 | **Enter** | View selected issue |
 | **Esc** | Return to list |
 
@@ -1484,6 +1523,14 @@ at home. If not, you'll pick it up in minutes.
 | **Ctrl+d** | Half-page down |
 | **Ctrl+u** | Half-page up |
 
+### Counts
+
+Type a number before a motion to repeat it, as in vim: **5j** moves down
+five, **10Ctrl+d** pages down ten times. Counts work in the list, board,
+graph and in this tutorial. On the board a lone **2** still jumps to the
+second column, and here a lone digit still jumps to that page, once you
+pause without pressing a motion.
+
 ### Universal Keys
 
 These work in every view:
@@ -1539,7 +1586,8 @@ Quickly narrow down what you see:
 
 ### Sorting
 
-Press **s** to cycle through sort modes: priority → created → updated.
+Press **s** to cycle through sort modes: priority → created → updated → PageRank → triage → risk → ETA → blocks.
+Metric sorts say *(pending)* until graph analysis finishes, then re-sort on their own.
 Press **S** (shift+s) to reverse the current sort order.
 
 ### When to Use List View
@@ -1704,6 +1752,14 @@ Press **g** to visualize issue dependencies as a graph.
 | **f** | Focus: show only this node's subgraph |
 | **Esc** | Exit focus / return to list |
 
+### Zoom Levels
+
+Press **+** and **-** to change how much each node box shows:
+
+- **Compact**: ID-only chips, so up to 10 neighbours fit in a row
+- **Normal**: ID and title (the default)
+- **Detailed**: ID, title, status and PageRank rank
+
 ### When to Use Graph View
 
 - **Critical path analysis**: Find what's blocking important work
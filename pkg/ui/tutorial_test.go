@@ -139,24 +139,91 @@ func TestTutorialTOCToggle(t *testing.T) {
 	}
 }
 
+func TestTutorialTOCOnNarrowTerminal(t *testing.T) {
+	m := newTestTutorialModel()
+	m.SetSize(60, 40)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+
+	// The TOC takes the place of the page while it has focus
+	title := m.visiblePages()[0].Title
+	view := m.View()
+	if !strings.Contains(view, "Contents") {
+		t.Errorf("expected the TOC on a narrow terminal:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 62 {
+			t.Fatalf("line %d cells wide on a 60 cell terminal: %q", w, line)
+		}
+	}
+
+	// Tab goes back to the page, with the TOC out of the way
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if view := m.View(); strings.Contains(view, "Contents") || !strings.Contains(view, title) {
+		t.Errorf("expected the page without the TOC:\n%s", view)
+	}
+}
+
 func TestTutorialJumpToPage(t *testing.T) {
 	m := newTestTutorialModel()
 
+	// A digit jumps once no motion has followed it in time
+	pressDigit := func(m TutorialModel, digit string) TutorialModel {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(digit)})
+		m, _ = m.Update(countTimeoutMsg{seq: m.keyCount.seq})
+		return m
+	}
+
 	// Jump to page 3 using number key
-	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	m = pressDigit(m, "3")
 	if m.currentPage != 2 { // 0-indexed
 		t.Errorf("Expected page 2 after '3', got %d", m.currentPage)
 	}
 
 	// Jump to page 1
-	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	m = pressDigit(m, "1")
 	if m.currentPage != 0 {
 		t.Errorf("Expected page 0 after '1', got %d", m.currentPage)
 	}
 
 	// Invalid page number (beyond available pages)
-	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+	m = pressDigit(m, "9")
 	// Should not change if page doesn't exist
+
+	// Another key also ends the page number, which jumps before it
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if m.currentPage != 1 {
+		t.Errorf("Expected page 1 after '2g', got %d", m.currentPage)
+	}
+}
+
+func TestTutorialCountPrefix(t *testing.T) {
+	m := newTestTutorialModel()
+	key := func(k string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)} }
+
+	for _, k := range []string{"1", "2", "j"} {
+		m, _ = m.Update(key(k))
+	}
+	if m.scrollOffset != 12 {
+		t.Errorf("Expected scroll 12 after '12j', got %d", m.scrollOffset)
+	}
+	if m.currentPage != 0 {
+		t.Errorf("A count must not jump pages, got page %d", m.currentPage)
+	}
+
+	for _, k := range []string{"3", "l"} {
+		m, _ = m.Update(key(k))
+	}
+	if m.currentPage != 3 {
+		t.Errorf("Expected page 3 after '3l', got %d", m.currentPage)
+	}
+
+	// A stale timeout from an earlier digit does nothing
+	m, _ = m.Update(key("2"))
+	m, _ = m.Update(countTimeoutMsg{seq: m.keyCount.seq - 1})
+	if m.currentPage != 3 || !m.keyCount.pending() {
+		t.Error("stale timeout consumed the count")
+	}
 }
 
 func TestTutorialJumpMethods(t *testing.T) {
@@ -1220,3 +1287,16 @@ func TestGlamourRenderingAllPages(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenTutorialOnStart(t *testing.T) {
+	m := NewModel(nil, nil, "")
+	m.OpenTutorial()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(Model)
+	if !m.showTutorial || m.focused != focusTutorial {
+		t.Fatal("expected the tutorial to be open")
+	}
+	if m.tutorialModel.width != 100 {
+		t.Errorf("tutorial not sized with the window, width %d", m.tutorialModel.width)
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SplitOrientation controls how the list and detail panes are arranged
+type SplitOrientation string
+
+const (
+	// SplitAuto places panes side by side on wide terminals and stacks them
+	// on narrow but tall ones
+	SplitAuto SplitOrientation = "auto"
+	// SplitVertical always places panes side by side (vertical divider)
+	SplitVertical SplitOrientation = "vertical"
+	// SplitHorizontal always stacks the list above the details (horizontal divider)
+	SplitHorizontal SplitOrientation = "horizontal"
+)
+
+// Split pane ratio bounds and step for Ctrl+h/Ctrl+l
+const (
+	DefaultSplitRatio = 0.4
+	MinSplitRatio     = 0.2
+	MaxSplitRatio     = 0.8
+	SplitRatioStep    = 0.05
+
+	// StackedSplitMinHeight is the terminal height needed to stack the panes
+	StackedSplitMinHeight = 30
+)
+
+// next cycles auto → vertical → horizontal → auto
+func (o SplitOrientation) next() SplitOrientation {
+	switch o {
+	case SplitAuto:
+		return SplitVertical
+	case SplitVertical:
+		return SplitHorizontal
+	default:
+		return SplitAuto
+	}
+}
+
+// UIState is layout, update-notice and search history state remembered
+// across sessions
+type UIState struct {
+	SplitRatio       float64          `json:"split_ratio"`
+	SplitOrientation SplitOrientation `json:"split_orientation"`
+
+	// SkippedUpdate is a release tag whose notes should not be shown again
+	SkippedUpdate string `json:"skipped_update,omitempty"`
+	// RemindUpdateAfter postpones release notes until this time
+	RemindUpdateAfter time.Time `json:"remind_update_after"`
+
+	// SearchHistory is past list searches, newest first
+	SearchHistory []string `json:"search_history,omitempty"`
+}
+
+// DefaultUIState returns the layout used when nothing has been saved
+func DefaultUIState() UIState {
+	return UIState{SplitRatio: DefaultSplitRatio, SplitOrientation: SplitAuto}
+}
+
+// normalize clamps the ratio and replaces unknown orientations
+func (s UIState) normalize() UIState {
+	if s.SplitRatio == 0 {
+		s.SplitRatio = DefaultSplitRatio
+	}
+	s.SplitRatio = clampSplitRatio(s.SplitRatio)
+	switch s.SplitOrientation {
+	case SplitAuto, SplitVertical, SplitHorizontal:
+	default:
+		s.SplitOrientation = SplitAuto
+	}
+	return s
+}
+
+func clampSplitRatio(r float64) float64 {
+	if r < MinSplitRatio {
+		return MinSplitRatio
+	}
+	if r > MaxSplitRatio {
+		return MaxSplitRatio
+	}
+	return r
+}
+
+// UIStatePath returns the path to the UI state config file.
+func UIStatePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "bv", "ui-state.json")
+}
+
+// LoadUIState reads the saved UI state, falling back to defaults when the
+// file is missing or invalid.
+func LoadUIState() UIState {
+	path := UIStatePath()
+	if path == "" {
+		return DefaultUIState()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultUIState()
+	}
+	var state UIState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return DefaultUIState()
+	}
+	return state.normalize()
+}
+
+// SaveUIState writes the UI state to disk.
+func SaveUIState(state UIState) error {
+	path := UIStatePath()
+	if path == "" {
+		return nil // Can't determine path
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state.normalize(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write atomically via temp file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUIStateSaveLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := LoadUIState(); !reflect.DeepEqual(got, DefaultUIState()) {
+		t.Fatalf("expected defaults without a saved file, got %+v", got)
+	}
+
+	want := UIState{SplitRatio: 0.6, SplitOrientation: SplitHorizontal, SearchHistory: []string{"login", "~flaky tests"}}
+	if err := SaveUIState(want); err != nil {
+		t.Fatalf("SaveUIState: %v", err)
+	}
+	if filepath.Base(UIStatePath()) != "ui-state.json" {
+		t.Errorf("unexpected state path %q", UIStatePath())
+	}
+	if got := LoadUIState(); !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip: got %+v, want %+v", got, want)
+	}
+
+	// Out-of-range and unknown values are normalized
+	if err := os.WriteFile(UIStatePath(), []byte(`{"split_ratio":3,"split_orientation":"diagonal"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadUIState(); got.SplitRatio != MaxSplitRatio || got.SplitOrientation != SplitAuto {
+		t.Errorf("expected normalized state, got %+v", got)
+	}
+
+	if err := os.WriteFile(UIStatePath(), []byte(`not json`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadUIState(); !reflect.DeepEqual(got, DefaultUIState()) {
+		t.Errorf("expected defaults for invalid file, got %+v", got)
+	}
+}
+
+func TestSplitOrientationCycle(t *testing.T) {
+	o := SplitAuto
+	seen := []SplitOrientation{o}
+	for i := 0; i < 3; i++ {
+		o = o.next()
+		seen = append(seen, o)
+	}
+	want := []SplitOrientation{SplitAuto, SplitVertical, SplitHorizontal, SplitAuto}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("cycle = %v, want %v", seen, want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openUnblockQueue ranks the open blockers by the work closing each frees
+func (m *Model) openUnblockQueue() {
+	m.unblockQueue = analysis.BuildUnblockQueue(m.analyzer, m.analysis)
+	m.unblockCursor = 0
+	m.showUnblockQueue = true
+}
+
+// handleUnblockQueueKeys handles keys while the unblock queue is open
+func (m Model) handleUnblockQueueKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	items := m.unblockQueue.Items
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "Q":
+		m.showUnblockQueue = false
+	case "j", "down":
+		if m.unblockCursor < len(items)-1 {
+			m.unblockCursor++
+		}
+	case "k", "up":
+		if m.unblockCursor > 0 {
+			m.unblockCursor--
+		}
+	case "g", "home":
+		m.unblockCursor = 0
+	case "G", "end":
+		m.unblockCursor = max(0, len(items)-1)
+	case "enter":
+		if m.unblockCursor < len(items) {
+			id := items[m.unblockCursor].ID
+			m.showUnblockQueue = false
+			if !m.showIssueDetails(id) {
+				m.statusMsg = fmt.Sprintf("❌ %s is not in the list", id)
+				m.statusIsError = true
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderUnblockQueue renders the unblock queue modal: one blocker per line,
+// the one freeing the most work first
+func (m Model) renderUnblockQueue() string {
+	t := m.theme
+	width := min(96, m.width-4)
+	queue := m.unblockQueue
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		MaxHeight(m.height - 4)
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	readyStyle := t.Renderer.NewStyle().Foreground(t.Open)
+	waitStyle := t.Renderer.NewStyle().Foreground(t.Blocked)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("🧵 Unblock Queue"))
+	sb.WriteString("\n")
+	sb.WriteString(mutedStyle.Render(fmt.Sprintf("%d open blockers · %d can start now · ranked by issues freed, cascade included",
+		queue.OpenBlockerCount, queue.ActionableCount)))
+	sb.WriteString("\n\n")
+
+	if len(queue.Items) == 0 {
+		sb.WriteString(mutedStyle.Render("Nothing is blocking open work"))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor visible on short terminals
+	maxRows := max(m.height-14, 5)
+	start := 0
+	if m.unblockCursor >= maxRows {
+		start = m.unblockCursor - maxRows + 1
+	}
+	for i := start; i < len(queue.Items) && i < start+maxRows; i++ {
+		item := queue.Items[i]
+		cursor := "  "
+		if i == m.unblockCursor {
+			cursor = "▸ "
+		}
+		state := readyStyle.Render("ready")
+		if !item.Actionable {
+			state = waitStyle.Render("⛔ " + truncateRunesHelper(strings.Join(item.BlockedBy, ","), 12, "…"))
+		}
+		tail := fmt.Sprintf(" frees %3d  waiting %3d  depth %3.0f  ", item.UnblocksCount, item.WaitingCount, item.CriticalPathScore)
+		name := item.ID + " " + item.Title
+		nameWidth := max(width-6-lipgloss.Width(cursor)-lipgloss.Width(tail)-16, 10)
+		line := cursor + padRight(truncateRunesHelper(name, nameWidth, "…"), nameWidth) + tail
+		if i == m.unblockCursor {
+			line = t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString(state)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(mutedStyle.Italic(true).Render(
+		"frees: issues unblocked by closing it · waiting: issues it blocks • Enter: jump • Esc: close"))
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, boxStyle.Render(sb.String()))
+}
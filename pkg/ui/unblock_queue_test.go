@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestUnblockQueueModal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "schema", Title: "Design schema", Status: model.StatusOpen, Priority: 2},
+		{ID: "api", Title: "Build API", Status: model.StatusOpen, Priority: 1, Dependencies: blocks("api", "schema")},
+		{ID: "ui", Title: "Build UI", Status: model.StatusOpen, Priority: 1, Dependencies: blocks("ui", "api")},
+		{ID: "docs", Title: "Write docs", Status: model.StatusOpen, Priority: 3},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 120, 40
+
+	m = pressKeys(m, "Q")
+	if !m.showUnblockQueue || len(m.unblockQueue.Items) != 2 || m.unblockQueue.Items[0].ID != "schema" {
+		t.Fatalf("Q: open=%v items=%+v", m.showUnblockQueue, m.unblockQueue.Items)
+	}
+	view := m.renderUnblockQueue()
+	if !strings.Contains(view, "Design schema") || !strings.Contains(view, "frees   2") || !strings.Contains(view, "⛔ schema") {
+		t.Errorf("queue should rank schema first and show api waiting on it:\n%s", view)
+	}
+
+	m = pressKeys(m, "j", "enter")
+	if m.showUnblockQueue || m.selectedIssueID() != "api" {
+		t.Errorf("enter: open=%v, selected %q, want api", m.showUnblockQueue, m.selectedIssueID())
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"math"
 	"strings"
 
@@ -126,6 +127,30 @@ func GetHeatGradientColorBg(intensity float64) (bg lipgloss.Color, fg lipgloss.C
 	}
 }
 
+// RenderRiskCell renders a composite risk score (0-1) as a heat-colored
+// percentage cell, 3 columns wide. Issues without a score (closed) render dim.
+func RenderRiskCell(score float64, ok bool) string {
+	if !ok {
+		return lipgloss.NewStyle().Foreground(ColorMuted).Render("  ·")
+	}
+	bg, fg := GetHeatGradientColorBg(score)
+	return lipgloss.NewStyle().Background(bg).Foreground(fg).Render(fmt.Sprintf("%3.0f", score*100))
+}
+
+// RenderRiskLegend renders the risk heatmap scale shown while the overlay is on
+func RenderRiskLegend() string {
+	var sb strings.Builder
+	sb.WriteString("risk ")
+	for _, band := range []struct {
+		intensity float64
+		label     string
+	}{{0.1, "<20"}, {0.3, "20"}, {0.5, "40"}, {0.7, "60"}, {0.9, "80+"}} {
+		bg, fg := GetHeatGradientColorBg(band.intensity)
+		sb.WriteString(lipgloss.NewStyle().Background(bg).Foreground(fg).Padding(0, 1).Render(band.label))
+	}
+	return sb.String()
+}
+
 // RepoColors maps repo prefixes to distinctive colors for visual differentiation
 var RepoColors = []lipgloss.Color{
 	lipgloss.Color("#FF6B6B"), // Coral red
@@ -143,9 +168,10 @@ func GetRepoColor(prefix string) lipgloss.Color {
 	if prefix == "" {
 		return ColorMuted
 	}
-	// Simple hash based on prefix characters
+	// Simple hash based on prefix characters; case-insensitive so "API" and
+	// "api" share a color across views
 	hash := 0
-	for _, c := range prefix {
+	for _, c := range strings.ToLower(prefix) {
 		hash = (hash*31 + int(c)) % len(RepoColors)
 	}
 	if hash < 0 {
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -46,3 +47,43 @@ func TestApplyFilterRespectsWorkspaceRepoFilter(t *testing.T) {
 		t.Fatalf("expected 2 visible items with no repo filter, got %d", got)
 	}
 }
+
+func TestWorkspaceRepoFilterPersists(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "api-AUTH-1", Title: "API", Status: model.StatusOpen},
+		{ID: "web-UI-1", Title: "Web", Status: model.StatusOpen},
+	}
+	info := WorkspaceInfo{
+		Enabled:      true,
+		RepoCount:    2,
+		RepoPrefixes: []string{"api-", "web-"},
+		StatePath:    WorkspaceRepoStatePath(filepath.Join(t.TempDir(), "workspace.yaml")),
+	}
+
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	m.EnableWorkspaceMode(info)
+
+	// Deselect web in the picker and apply
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = updated.(Model)
+	m = m.handleRepoPickerKeys(tea.KeyMsg{Type: tea.KeyDown})
+	m = m.handleRepoPickerKeys(tea.KeyMsg{Type: tea.KeySpace})
+	m = m.handleRepoPickerKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.activeRepos) != 1 || !m.activeRepos["api"] {
+		t.Fatalf("expected api-only filter, got %v", m.activeRepos)
+	}
+
+	// A new session restores the filter
+	m2 := NewModel(issues, nil, "")
+	updated, _ = m2.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m2 = updated.(Model)
+	m2.EnableWorkspaceMode(info)
+	if len(m2.activeRepos) != 1 || !m2.activeRepos["api"] {
+		t.Fatalf("expected restored api-only filter, got %v", m2.activeRepos)
+	}
+	if got := len(m2.list.Items()); got != 1 {
+		t.Fatalf("expected 1 visible item after restore, got %d", got)
+	}
+}
@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -16,9 +19,7 @@ func normalizeRepoPrefixes(prefixes []string) []string {
 	seen := make(map[string]bool, len(prefixes))
 	var out []string
 	for _, raw := range prefixes {
-		p := strings.TrimSpace(raw)
-		p = strings.TrimRight(p, "-:_")
-		p = strings.ToLower(p)
+		p := normalizeRepoPrefix(raw)
 		if p == "" {
 			continue
 		}
@@ -32,6 +33,11 @@ func normalizeRepoPrefixes(prefixes []string) []string {
 	return out
 }
 
+// normalizeRepoPrefix normalizes a single repo prefix (e.g., "API-" -> "api").
+func normalizeRepoPrefix(prefix string) string {
+	return strings.ToLower(strings.TrimRight(strings.TrimSpace(prefix), "-:_"))
+}
+
 func sortedRepoKeys(selected map[string]bool) []string {
 	if len(selected) == 0 {
 		return nil
@@ -61,3 +67,64 @@ func formatRepoList(repos []string, maxNames int) string {
 	head := strings.Join(repos[:maxNames], ",")
 	return fmt.Sprintf("%s+%d", head, len(repos)-maxNames)
 }
+
+// WorkspaceRepoStatePath returns where the repo filter for the workspace
+// configured at configPath is remembered (next to workspace.yaml).
+func WorkspaceRepoStatePath(configPath string) string {
+	if configPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "workspace-state.json")
+}
+
+// workspaceRepoState is the persisted repo filter for one workspace.
+type workspaceRepoState struct {
+	ActiveRepos []string `json:"active_repos"` // Empty means all repos
+}
+
+// loadWorkspaceRepoSelection returns the saved repo filter restricted to
+// available repos, or nil (all repos) when nothing usable was saved.
+func loadWorkspaceRepoSelection(path string, available []string) map[string]bool {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var state workspaceRepoState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+
+	known := make(map[string]bool, len(available))
+	for _, r := range available {
+		known[r] = true
+	}
+	active := make(map[string]bool)
+	for _, r := range state.ActiveRepos {
+		if r = normalizeRepoPrefix(r); known[r] {
+			active[r] = true
+		}
+	}
+	if len(active) == 0 || len(active) == len(available) {
+		return nil
+	}
+	return active
+}
+
+// saveWorkspaceRepoSelection writes the repo filter (nil = all repos).
+func saveWorkspaceRepoSelection(path string, active map[string]bool) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(workspaceRepoState{ActiveRepos: sortedRepoKeys(active)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
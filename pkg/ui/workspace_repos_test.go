@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -321,3 +323,39 @@ func TestNormalizeRepoPrefixes_OrderStability(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkspaceRepoSelectionRoundTrip(t *testing.T) {
+	path := WorkspaceRepoStatePath(filepath.Join(t.TempDir(), "workspace.yaml"))
+	available := []string{"api", "lib", "web"}
+
+	if got := loadWorkspaceRepoSelection(path, available); got != nil {
+		t.Fatalf("expected nil without a saved file, got %v", got)
+	}
+	if err := saveWorkspaceRepoSelection(path, map[string]bool{"web": true, "api": true}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if got := loadWorkspaceRepoSelection(path, available); !reflect.DeepEqual(got, map[string]bool{"api": true, "web": true}) {
+		t.Errorf("round trip = %v", got)
+	}
+
+	// Repos that disappeared from the workspace are dropped; nothing left means all
+	if got := loadWorkspaceRepoSelection(path, []string{"lib"}); got != nil {
+		t.Errorf("expected nil when no saved repo is available, got %v", got)
+	}
+	if err := saveWorkspaceRepoSelection(path, nil); err != nil {
+		t.Fatalf("save all: %v", err)
+	}
+	if got := loadWorkspaceRepoSelection(path, available); got != nil {
+		t.Errorf("expected nil after saving all repos, got %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadWorkspaceRepoSelection(path, available); got != nil {
+		t.Errorf("expected nil for invalid file, got %v", got)
+	}
+	if WorkspaceRepoStatePath("") != "" {
+		t.Error("expected empty path without a workspace config")
+	}
+}
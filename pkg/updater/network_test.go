@@ -89,3 +89,21 @@ func TestCheckForUpdates_Network(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckForRelease_IncludesNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v99.0.0", "name": "Big release", "html_url": "http://example.com/release", "body": "## Changes\n- faster"}`))
+	}))
+	defer server.Close()
+
+	rel, err := checkForRelease(server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("checkForRelease() error = %v", err)
+	}
+	if rel == nil || rel.TagName != "v99.0.0" || rel.Name != "Big release" {
+		t.Fatalf("checkForRelease() = %+v, want v99.0.0", rel)
+	}
+	if rel.Body != "## Changes\n- faster" {
+		t.Errorf("release notes = %q", rel.Body)
+	}
+}
@@ -29,7 +29,9 @@ const (
 // Release represents a GitHub release
 type Release struct {
 	TagName string  `json:"tag_name"`
+	Name    string  `json:"name"`
 	HTMLURL string  `json:"html_url"`
+	Body    string  `json:"body"` // Release notes (markdown)
 	Assets  []Asset `json:"assets"`
 }
 
@@ -53,47 +55,66 @@ type UpdateResult struct {
 // CheckForUpdates queries GitHub for the latest release.
 // Returns the new version tag if an update is available, empty string otherwise.
 func CheckForUpdates() (string, string, error) {
+	rel, err := CheckForRelease()
+	if err != nil || rel == nil {
+		return "", "", err
+	}
+	return rel.TagName, rel.HTMLURL, nil
+}
+
+// CheckForRelease queries GitHub for the latest release and returns it,
+// including its release notes, if it is newer than the running version.
+// Returns nil when no update is available.
+func CheckForRelease() (*Release, error) {
 	// Set a short timeout to avoid blocking startup for too long
 	client := &http.Client{
 		Timeout: 2 * time.Second,
 	}
-	return checkForUpdates(client, "https://api.github.com/repos/Dicklesworthstone/beads_viewer/releases/latest")
+	return checkForRelease(client, "https://api.github.com/repos/Dicklesworthstone/beads_viewer/releases/latest")
 }
 
 func checkForUpdates(client *http.Client, url string) (string, string, error) {
+	rel, err := checkForRelease(client, url)
+	if err != nil || rel == nil {
+		return "", "", err
+	}
+	return rel.TagName, rel.HTMLURL, nil
+}
+
+func checkForRelease(client *http.Client, url string) (*Release, error) {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 	// GitHub recommends sending a UA; some endpoints 403 without it.
 	req.Header.Set("User-Agent", "beads-viewer-update-check")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		// For rate/abuse limits, avoid treating as fatal; just skip update.
 		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
-			return "", "", nil
+			return nil, nil
 		}
-		return "", "", fmt.Errorf("github api returned status: %s", resp.Status)
+		return nil, fmt.Errorf("github api returned status: %s", resp.Status)
 	}
 
 	var rel Release
 	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
-		return "", "", err
+		return nil, err
 	}
 
 	// Compare versions
 	// Assumes SemVer with 'v' prefix
 	if compareVersions(rel.TagName, version.Version) > 0 {
-		return rel.TagName, rel.HTMLURL, nil
+		return &rel, nil
 	}
 
-	return "", "", nil
+	return nil, nil
 }
 
 // compareVersions compares semver-ish strings with optional leading 'v' and optional pre-release
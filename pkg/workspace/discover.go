@@ -0,0 +1,162 @@
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// DefaultScanDepth is how many directory levels below the root ScanRepos
+// descends when no depth is given.
+const DefaultScanDepth = 3
+
+// ScanRepos walks root looking for directories that contain a .beads folder
+// with an issues file, and returns a repo entry for each with a unique prefix
+// derived from its directory name. Hidden directories and the default exclude
+// patterns (node_modules, vendor, ...) are skipped. Paths are relative to root.
+func ScanRepos(root string, maxDepth int) ([]RepoConfig, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultScanDepth
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving scan root: %w", err)
+	}
+	if info, err := os.Stat(absRoot); err != nil {
+		return nil, fmt.Errorf("scan root: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("scan root %s is not a directory", root)
+	}
+
+	excluded := make(map[string]bool)
+	for _, name := range DefaultExcludePatterns() {
+		excluded[name] = true
+	}
+
+	var paths []string
+	err = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == absRoot {
+				return err
+			}
+			return fs.SkipDir // Unreadable subdirectory
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(absRoot, path)
+		if rel != "." {
+			if strings.HasPrefix(d.Name(), ".") || excluded[d.Name()] {
+				return fs.SkipDir
+			}
+			if strings.Count(rel, string(filepath.Separator))+1 > maxDepth {
+				return fs.SkipDir
+			}
+		}
+		if _, err := loader.FindJSONLPath(filepath.Join(path, ".beads")); err == nil {
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", root, err)
+	}
+
+	repos := make([]RepoConfig, 0, len(paths))
+	used := make(map[string]bool, len(paths))
+	for _, rel := range paths {
+		name := repoNameForPath(rel, absRoot)
+		prefix := uniquePrefix(rel, name, used)
+		used[prefix] = true
+		repos = append(repos, RepoConfig{Name: name, Path: rel, Prefix: prefix})
+	}
+	return repos, nil
+}
+
+// repoNameForPath returns a display name for a repo: its directory name, or
+// the root's name for a repo at the scan root.
+func repoNameForPath(rel, absRoot string) string {
+	if rel == "." {
+		return filepath.Base(absRoot)
+	}
+	return filepath.Base(filepath.FromSlash(rel))
+}
+
+// uniquePrefix derives an ID prefix from the repo name ("Web App" -> "web-app-").
+// When two repos share a name the parent directory is prepended
+// ("apps-web-"), then a number.
+func uniquePrefix(rel, name string, used map[string]bool) string {
+	base := sanitizePrefix(name)
+	if base == "" {
+		base = "repo"
+	}
+	if !used[base+"-"] {
+		return base + "-"
+	}
+	if rel != "." {
+		if parent := filepath.Dir(filepath.FromSlash(rel)); parent != "." {
+			if p := sanitizePrefix(filepath.Base(parent)); p != "" && !used[p+"-"+base+"-"] {
+				return p + "-" + base + "-"
+			}
+		}
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d-", base, i)
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+// sanitizePrefix lowercases s and collapses runs of non-alphanumerics to "-".
+func sanitizePrefix(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if b.Len() > 0 && !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// ConfigPath returns the workspace config location for a workspace root.
+func ConfigPath(root string) string {
+	return filepath.Join(root, ".bv", "workspace.yaml")
+}
+
+// WriteConfig writes config as YAML to path, creating the .bv directory.
+// An existing file is only replaced when overwrite is set.
+func WriteConfig(path string, config Config, overwrite bool) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid workspace config: %w", err)
+	}
+	if !overwrite {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encoding workspace config: %w", err)
+	}
+	header := "# Workspace generated by `bv workspace init --scan`.\n" +
+		"# Prefixes are prepended to issue IDs (api-AUTH-1); edit them freely.\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package workspace_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+)
+
+func TestScanRepos(t *testing.T) {
+	root := t.TempDir()
+	issue := func(id string) []model.Issue {
+		return []model.Issue{{ID: id, Title: id, CreatedAt: time.Now(), UpdatedAt: time.Now()}}
+	}
+
+	createTestBeadsFile(t, filepath.Join(root, "apps", "web"), issue("UI-1"))
+	createTestBeadsFile(t, filepath.Join(root, "services", "api"), issue("AUTH-1"))
+	createTestBeadsFile(t, filepath.Join(root, "services", "Web"), issue("SSR-1"))    // Name clash with apps/web
+	createTestBeadsFile(t, filepath.Join(root, "node_modules", "dep"), issue("X-1"))  // Excluded
+	createTestBeadsFile(t, filepath.Join(root, ".cache", "copy"), issue("Y-1"))       // Hidden
+	createTestBeadsFile(t, filepath.Join(root, "a", "b", "c", "deep"), issue("Z-1"))  // Beyond depth 3
+	if err := os.MkdirAll(filepath.Join(root, "empty", ".beads"), 0755); err != nil { // No issues file
+		t.Fatal(err)
+	}
+
+	repos, err := workspace.ScanRepos(root, 0)
+	if err != nil {
+		t.Fatalf("ScanRepos() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, r := range repos {
+		got[r.Path] = r.Name + " " + r.Prefix
+	}
+	want := map[string]string{
+		"apps/web":     "web web-",
+		"services/api": "api api-",
+		"services/Web": "Web services-web-",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ScanRepos() = %v, want %v", got, want)
+	}
+	for path, w := range want {
+		if got[path] != w {
+			t.Errorf("repo %s = %q, want %q", path, got[path], w)
+		}
+	}
+
+	if repos, _ := workspace.ScanRepos(root, 4); len(repos) != 4 {
+		t.Errorf("expected the deep repo at depth 4, got %d repos", len(repos))
+	}
+	if _, err := workspace.ScanRepos(filepath.Join(root, "missing"), 0); err == nil {
+		t.Error("expected an error for a missing scan root")
+	}
+}
+
+func TestWriteConfigRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	createTestBeadsFile(t, root, []model.Issue{{ID: "CORE-1", Title: "Root", CreatedAt: time.Now(), UpdatedAt: time.Now()}})
+	createTestBeadsFile(t, filepath.Join(root, "packages", "lib"), []model.Issue{{ID: "LIB-1", Title: "Lib", CreatedAt: time.Now(), UpdatedAt: time.Now()}})
+
+	repos, err := workspace.ScanRepos(root, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 || repos[0].Path != "." || repos[0].Name != filepath.Base(root) {
+		t.Fatalf("expected the root repo first, got %+v", repos)
+	}
+
+	path := workspace.ConfigPath(root)
+	cfg := workspace.Config{Name: "test", Repos: repos}
+	if err := workspace.WriteConfig(path, cfg, false); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+	if err := workspace.WriteConfig(path, cfg, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected refusal to overwrite, got %v", err)
+	}
+	if err := workspace.WriteConfig(path, cfg, true); err != nil {
+		t.Errorf("overwrite: %v", err)
+	}
+	if err := workspace.WriteConfig(path, workspace.Config{}, true); err == nil {
+		t.Error("expected an empty config to be rejected")
+	}
+
+	issues, results, err := workspace.LoadAllFromConfig(context.Background(), path)
+	if err != nil {
+		t.Fatalf("LoadAllFromConfig() error = %v", err)
+	}
+	if s := workspace.Summarize(results); s.FailedRepos != 0 || len(issues) != 2 {
+		t.Errorf("expected both repos to load, got %d issues, failures %v", len(issues), s.FailedRepoNames)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"path/filepath"
+	"strings"
 
 	"golang.org/x/sync/errgroup"
 
@@ -26,6 +27,23 @@ type LoadResult struct {
 
 	// Error is set if loading failed
 	Error error
+
+	// UnresolvedDeps are cross-repo dependencies whose target was not loaded
+	UnresolvedDeps []UnresolvedDep
+}
+
+// UnresolvedDep is a dependency on another repo's issue that is missing from
+// the merged workspace. It is a warning, not a load failure.
+type UnresolvedDep struct {
+	IssueID     string // Namespaced ID of the issue holding the dependency
+	DependsOnID string // Namespaced ID that could not be resolved
+	TargetRepo  string // Repo that owns DependsOnID's prefix
+	Reason      string // "repo disabled", "repo failed to load" or "issue not found"
+}
+
+// String formats the dependency for warning output
+func (u UnresolvedDep) String() string {
+	return fmt.Sprintf("%s -> %s (%s: %s)", u.IssueID, u.DependsOnID, u.TargetRepo, u.Reason)
 }
 
 // AggregateLoader loads issues from multiple repositories in a workspace
@@ -83,9 +101,62 @@ func (l *AggregateLoader) LoadAll(ctx context.Context) ([]model.Issue, []LoadRes
 		allIssues = append(allIssues, result.Issues...)
 	}
 
+	l.validateCrossRepoDeps(results)
+
 	return allIssues, results, nil
 }
 
+// validateCrossRepoDeps records, on each successfully loaded repo, the
+// dependencies that point into another repo but did not resolve to a loaded issue.
+func (l *AggregateLoader) validateCrossRepoDeps(results []LoadResult) {
+	loaded := make(map[string]bool)
+	failed := make(map[string]bool)
+	for _, result := range results {
+		if result.Error != nil {
+			failed[result.Prefix] = true
+			continue
+		}
+		for _, issue := range result.Issues {
+			loaded[issue.ID] = true
+		}
+	}
+
+	for i := range results {
+		result := &results[i]
+		if result.Error != nil {
+			continue
+		}
+		for _, issue := range result.Issues {
+			for _, dep := range issue.Dependencies {
+				if dep == nil || loaded[dep.DependsOnID] {
+					continue
+				}
+				target := l.repoForID(dep.DependsOnID)
+				if target == nil || target.GetPrefix() == result.Prefix {
+					continue // Local reference; dangling local deps are not a workspace concern
+				}
+				reason := "issue not found"
+				switch {
+				case !target.IsEnabled():
+					reason = "repo disabled"
+				case failed[target.GetPrefix()]:
+					reason = "repo failed to load"
+				}
+				unresolved := UnresolvedDep{
+					IssueID:     issue.ID,
+					DependsOnID: dep.DependsOnID,
+					TargetRepo:  target.GetName(),
+					Reason:      reason,
+				}
+				result.UnresolvedDeps = append(result.UnresolvedDeps, unresolved)
+				if l.logger != nil {
+					l.logger.Printf("WARNING: Unresolved cross-repo dependency %s", unresolved)
+				}
+			}
+		}
+	}
+}
+
 // getEnabledRepos returns all enabled repos from the config
 func (l *AggregateLoader) getEnabledRepos() []RepoConfig {
 	var enabled []RepoConfig
@@ -171,19 +242,23 @@ func (l *AggregateLoader) loadSingleRepo(repo RepoConfig) ([]model.Issue, error)
 	}
 
 	// Apply namespacing to all IDs
-	prefix := repo.GetPrefix()
-	namespacedIssues := l.namespaceIssues(issues, prefix, localIDs)
+	namespacedIssues := l.namespaceIssues(issues, repo, localIDs)
 
 	return namespacedIssues, nil
 }
 
 // namespaceIssues adds the prefix to all issue IDs and dependency references
+// and tags each issue with its source repo.
 // It mutates the issues slice in place to reduce allocations.
-func (l *AggregateLoader) namespaceIssues(issues []model.Issue, prefix string, localIDs map[string]bool) []model.Issue {
+func (l *AggregateLoader) namespaceIssues(issues []model.Issue, repo RepoConfig, localIDs map[string]bool) []model.Issue {
+	prefix := repo.GetPrefix()
 	for i := range issues {
 		// Mutate issue in place
 		issue := &issues[i]
 		issue.ID = QualifyID(issue.ID, prefix)
+		if issue.SourceRepo == "" || issue.SourceRepo == "." {
+			issue.SourceRepo = repo.GetName()
+		}
 
 		// Namespace dependency references in place
 		for _, dep := range issue.Dependencies {
@@ -195,8 +270,9 @@ func (l *AggregateLoader) namespaceIssues(issues []model.Issue, prefix string, l
 			// Resolve DependsOnID
 			if localIDs[dep.DependsOnID] {
 				dep.DependsOnID = QualifyID(dep.DependsOnID, prefix)
-			} else if l.hasKnownPrefix(dep.DependsOnID) {
-				// External reference, keep as is
+			} else if qualified, ok := l.resolveQualifiedID(dep.DependsOnID); ok {
+				// External reference, normalized to the owning repo's prefix
+				dep.DependsOnID = qualified
 			} else {
 				// Assume local
 				dep.DependsOnID = QualifyID(dep.DependsOnID, prefix)
@@ -215,15 +291,42 @@ func (l *AggregateLoader) namespaceIssues(issues []model.Issue, prefix string, l
 	return issues
 }
 
-// hasKnownPrefix checks if an ID already has a known namespace prefix
-func (l *AggregateLoader) hasKnownPrefix(id string) bool {
+// resolveQualifiedID maps an ID that names another repo to its canonical
+// namespaced form. Besides the exact prefix ("api-AUTH-1") it accepts the
+// prefix in any case and with any of the separators "-", ":", "_" or "/"
+// ("API:AUTH-1", "api/AUTH-1"). Disabled repos are included so references to
+// them can be reported as unresolved rather than mistaken for local IDs.
+func (l *AggregateLoader) resolveQualifiedID(id string) (string, bool) {
 	for _, repo := range l.config.Repos {
 		prefix := repo.GetPrefix()
 		if len(id) > len(prefix) && id[:len(prefix)] == prefix {
-			return true
+			return id, true
 		}
 	}
-	return false
+	for _, repo := range l.config.Repos {
+		prefix := repo.GetPrefix()
+		base := strings.TrimRight(prefix, "-:_/")
+		if base == "" || base == prefix || len(id) <= len(base)+1 {
+			continue
+		}
+		if strings.EqualFold(id[:len(base)], base) && strings.ContainsRune("-:_/", rune(id[len(base)])) {
+			return prefix + id[len(base)+1:], true
+		}
+	}
+	return "", false
+}
+
+// repoForID returns the configured repo whose prefix the namespaced ID
+// carries, preferring the longest matching prefix.
+func (l *AggregateLoader) repoForID(id string) *RepoConfig {
+	var best *RepoConfig
+	for i := range l.config.Repos {
+		prefix := l.config.Repos[i].GetPrefix()
+		if strings.HasPrefix(id, prefix) && (best == nil || len(prefix) > len(best.GetPrefix())) {
+			best = &l.config.Repos[i]
+		}
+	}
+	return best
 }
 
 // logRepoError logs an error for a repo that failed to load
@@ -253,7 +356,18 @@ type LoadSummary struct {
 	FailedRepos     int
 	TotalIssues     int
 	FailedRepoNames []string
-	RepoPrefixes    []string // Prefixes of successfully loaded repos
+	RepoPrefixes    []string     // Prefixes of successfully loaded repos
+	Repos           []RepoStatus // Per-repo outcome, in config order
+	UnresolvedDeps  []UnresolvedDep
+}
+
+// RepoStatus is the load outcome of a single repository
+type RepoStatus struct {
+	Name       string
+	Prefix     string
+	IssueCount int
+	OpenCount  int    // Issues not closed or tombstoned
+	Error      string // Empty when the repo loaded successfully
 }
 
 // Summarize returns a summary of the load results
@@ -263,16 +377,26 @@ func Summarize(results []LoadResult) LoadSummary {
 	}
 
 	for _, result := range results {
+		status := RepoStatus{Name: result.RepoName, Prefix: result.Prefix}
 		if result.Error != nil {
+			status.Error = result.Error.Error()
 			summary.FailedRepos++
 			summary.FailedRepoNames = append(summary.FailedRepoNames, result.RepoName)
 		} else {
+			status.IssueCount = len(result.Issues)
+			for _, issue := range result.Issues {
+				if !issue.Status.IsClosed() && !issue.Status.IsTombstone() {
+					status.OpenCount++
+				}
+			}
 			summary.SuccessfulRepos++
 			summary.TotalIssues += len(result.Issues)
 			if result.Prefix != "" {
 				summary.RepoPrefixes = append(summary.RepoPrefixes, result.Prefix)
 			}
 		}
+		summary.Repos = append(summary.Repos, status)
+		summary.UnresolvedDeps = append(summary.UnresolvedDeps, result.UnresolvedDeps...)
 	}
 
 	return summary
@@ -235,6 +235,86 @@ func TestAggregateLoaderNamespacesDependencies(t *testing.T) {
 	}
 }
 
+func TestAggregateLoaderResolvesCrossRepoDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createTestBeadsFile(t, filepath.Join(tmpDir, "api"), []model.Issue{
+		{ID: "AUTH-1", Title: "Auth endpoint", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	})
+	createTestBeadsFile(t, filepath.Join(tmpDir, "web"), []model.Issue{
+		{
+			ID:    "UI-1",
+			Title: "Login page",
+			Dependencies: []*model.Dependency{
+				{IssueID: "UI-1", DependsOnID: "API:AUTH-1", Type: model.DepBlocks}, // Qualified, other case and separator
+				{IssueID: "UI-1", DependsOnID: "api-AUTH-9", Type: model.DepBlocks}, // Missing in api
+				{IssueID: "UI-1", DependsOnID: "lib-UTIL-1", Type: model.DepBlocks}, // Disabled repo
+				{IssueID: "UI-1", DependsOnID: "ops-RUN-1", Type: model.DepBlocks},  // Repo failed to load
+				{IssueID: "UI-1", DependsOnID: "UI-2", Type: model.DepBlocks},       // Local
+			},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		{ID: "UI-2", Title: "Form", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	})
+
+	disabled := false
+	config := &workspace.Config{
+		Repos: []workspace.RepoConfig{
+			{Name: "api", Path: "api", Prefix: "api-"},
+			{Name: "web", Path: "web", Prefix: "web-"},
+			{Name: "lib", Path: "lib", Prefix: "lib-", Enabled: &disabled},
+			{Name: "ops", Path: "missing", Prefix: "ops-"},
+		},
+	}
+
+	issues, results, err := workspace.NewAggregateLoader(config, tmpDir).LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+
+	var ui1 *model.Issue
+	for i := range issues {
+		if issues[i].ID == "web-UI-1" {
+			ui1 = &issues[i]
+		}
+	}
+	if ui1 == nil {
+		t.Fatal("Could not find web-UI-1")
+	}
+	if ui1.SourceRepo != "web" {
+		t.Errorf("SourceRepo = %q, want web", ui1.SourceRepo)
+	}
+	wantDeps := []string{"api-AUTH-1", "api-AUTH-9", "lib-UTIL-1", "ops-RUN-1", "web-UI-2"}
+	for i, want := range wantDeps {
+		if got := ui1.Dependencies[i].DependsOnID; got != want {
+			t.Errorf("dependency %d = %q, want %q", i, got, want)
+		}
+	}
+
+	summary := workspace.Summarize(results)
+	reasons := make(map[string]string)
+	for _, u := range summary.UnresolvedDeps {
+		if u.IssueID != "web-UI-1" {
+			t.Errorf("unexpected unresolved dep holder %q", u.IssueID)
+		}
+		reasons[u.DependsOnID] = u.TargetRepo + ": " + u.Reason
+	}
+	want := map[string]string{
+		"api-AUTH-9": "api: issue not found",
+		"lib-UTIL-1": "lib: repo disabled",
+		"ops-RUN-1":  "ops: repo failed to load",
+	}
+	if len(reasons) != len(want) {
+		t.Fatalf("unresolved deps = %v, want %v", reasons, want)
+	}
+	for id, reason := range want {
+		if reasons[id] != reason {
+			t.Errorf("unresolved %s = %q, want %q", id, reasons[id], reason)
+		}
+	}
+}
+
 func TestAggregateLoaderDisabledRepos(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -341,7 +421,13 @@ func TestAggregateLoaderContextCancellation(t *testing.T) {
 
 func TestSummarize(t *testing.T) {
 	results := []workspace.LoadResult{
-		{RepoName: "api", Issues: make([]model.Issue, 5)},
+		{RepoName: "api", Prefix: "api-", Issues: []model.Issue{
+			{ID: "api-1", Status: model.StatusOpen},
+			{ID: "api-2", Status: model.StatusInProgress},
+			{ID: "api-3", Status: model.StatusClosed},
+			{ID: "api-4", Status: model.StatusClosed},
+			{ID: "api-5", Status: model.StatusBlocked},
+		}},
 		{RepoName: "web", Issues: make([]model.Issue, 3)},
 		{RepoName: "broken", Error: os.ErrNotExist},
 	}
@@ -363,6 +449,15 @@ func TestSummarize(t *testing.T) {
 	if len(summary.FailedRepoNames) != 1 || summary.FailedRepoNames[0] != "broken" {
 		t.Errorf("FailedRepoNames = %v, want [broken]", summary.FailedRepoNames)
 	}
+	if len(summary.Repos) != 3 {
+		t.Fatalf("Repos = %d entries, want 3", len(summary.Repos))
+	}
+	if api := summary.Repos[0]; api.Prefix != "api-" || api.IssueCount != 5 || api.OpenCount != 3 || api.Error != "" {
+		t.Errorf("api status = %+v, want 5 issues, 3 open", api)
+	}
+	if broken := summary.Repos[2]; broken.Name != "broken" || broken.Error == "" || broken.IssueCount != 0 {
+		t.Errorf("broken status = %+v, want load error", broken)
+	}
 }
 
 func TestLoadAllFromConfig(t *testing.T) {
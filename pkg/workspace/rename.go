@@ -0,0 +1,84 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// PlanRename plans renaming the namespaced issue oldID (api-AUTH-1) across
+// the repos of the workspace at configPath. newID may be given with or
+// without the repo prefix. The owning repo's file has the local ID and any
+// self-qualified references rewritten; every other enabled repo has its
+// qualified references rewritten, in each spelling the loader resolves
+// (api-, API:, api/ ...).
+func PlanRename(configPath, oldID, newID string) (*loader.RenamePlan, error) {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace config: %w", err)
+	}
+	root := filepath.Dir(filepath.Dir(configPath))
+
+	var owner *RepoConfig
+	for i := range config.Repos {
+		repo := &config.Repos[i]
+		if repo.IsEnabled() && strings.HasPrefix(oldID, repo.GetPrefix()) &&
+			(owner == nil || len(repo.GetPrefix()) > len(owner.GetPrefix())) {
+			owner = repo
+		}
+	}
+	if owner == nil {
+		return nil, fmt.Errorf("%s does not carry the prefix of an enabled workspace repo", oldID)
+	}
+	prefix := owner.GetPrefix()
+	newID = QualifyID(newID, prefix)
+	oldLocal, newLocal := UnqualifyID(oldID, prefix), UnqualifyID(newID, prefix)
+
+	qualified := map[string]string{oldID: newID}
+	if base := strings.TrimRight(prefix, "-:_/"); base != "" && base != prefix {
+		for _, b := range []string{base, strings.ToUpper(base), strings.ToLower(base)} {
+			for _, sep := range []string{"-", ":", "_", "/"} {
+				qualified[b+sep+oldLocal] = newID
+			}
+		}
+	}
+
+	var files []loader.RenameFile
+	for i := range config.Repos {
+		repo := &config.Repos[i]
+		if !repo.IsEnabled() {
+			continue
+		}
+		repoPath := repo.Path
+		if !filepath.IsAbs(repoPath) {
+			repoPath = filepath.Join(root, repoPath)
+		}
+		path, err := loader.FindJSONLPath(filepath.Join(repoPath, repo.GetBeadsPath()))
+		if err != nil {
+			if repo == owner {
+				return nil, fmt.Errorf("failed to find the beads file of %s: %w", repo.GetName(), err)
+			}
+			continue
+		}
+		if loader.IsCompressedJSONL(path) {
+			if repo == owner {
+				return nil, loader.ErrCompressedReadOnly
+			}
+			continue
+		}
+
+		ids := make(map[string]string, len(qualified)+1)
+		for from, to := range qualified {
+			ids[from] = to
+		}
+		if repo == owner {
+			ids[oldLocal] = newLocal
+		}
+		for _, p := range loader.RenameFiles(path) {
+			files = append(files, loader.RenameFile{Path: p, IDs: ids})
+		}
+	}
+	return loader.PlanRename(oldID, newID, files)
+}
@@ -0,0 +1,64 @@
+package workspace_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+)
+
+func TestPlanRename(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Now()
+	createTestBeadsFile(t, filepath.Join(tmpDir, "api"), []model.Issue{
+		{ID: "AUTH-1", Title: "Login", CreatedAt: now, UpdatedAt: now},
+	})
+	createTestBeadsFile(t, filepath.Join(tmpDir, "web"), []model.Issue{
+		{ID: "UI-1", Title: "Form", CreatedAt: now, UpdatedAt: now, Dependencies: []*model.Dependency{
+			{IssueID: "UI-1", DependsOnID: "API:AUTH-1", Type: model.DepBlocks},
+		}},
+	})
+	configPath := filepath.Join(tmpDir, ".bv", "workspace.yaml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "repos:\n  - path: api\n    prefix: api-\n  - path: web\n    prefix: web-\n"
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := workspace.PlanRename(configPath, "lib-AUTH-1", "AUTH-2"); err == nil {
+		t.Error("an ID without a repo prefix should be refused")
+	}
+
+	// The new ID may be given without the prefix
+	plan, err := workspace.PlanRename(configPath, "api-AUTH-1", "AUTH-2")
+	if err != nil {
+		t.Fatalf("PlanRename() error = %v", err)
+	}
+	if plan.NewID != "api-AUTH-2" || len(plan.Lines) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, _, err := workspace.LoadAllFromConfig(context.Background(), configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]*model.Issue{}
+	for i := range issues {
+		ids[issues[i].ID] = &issues[i]
+	}
+	if ids["api-AUTH-2"] == nil {
+		t.Fatalf("renamed issue missing: %v", issues)
+	}
+	if dep := ids["web-UI-1"].Dependencies[0]; dep.DependsOnID != "api-AUTH-2" {
+		t.Errorf("cross-repo dependency = %s, want api-AUTH-2", dep.DependsOnID)
+	}
+}
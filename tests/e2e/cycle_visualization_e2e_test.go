@@ -316,6 +316,57 @@ func TestCycleVisualization_RobotSuggestCycles(t *testing.T) {
 	}
 }
 
+// TestCycleVisualization_RobotCycles tests the dedicated --robot-cycles report
+func TestCycleVisualization_RobotCycles(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := createMultipleCyclesRepo(t)
+
+	cmd := exec.Command(bv, "--robot-cycles")
+	cmd.Dir = repoDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("--robot-cycles failed: %v\n%s", err, out)
+	}
+
+	var result struct {
+		HasCycles bool `json:"has_cycles"`
+		Cycles    []struct {
+			Members []string `json:"members"`
+			Edges   []struct {
+				From string `json:"from"`
+				To   string `json:"to"`
+			} `json:"edges"`
+		} `json:"cycles"`
+		FeedbackArcSet []struct {
+			Command string `json:"command"`
+		} `json:"feedback_arc_set"`
+		AcyclicAfter bool `json:"acyclic_after"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("JSON unmarshal failed: %v\nOutput: %s", err, out)
+	}
+
+	if !result.HasCycles || len(result.Cycles) == 0 {
+		t.Fatalf("expected cycles to be reported, got %s", out)
+	}
+	for _, c := range result.Cycles {
+		if len(c.Edges) != len(c.Members) {
+			t.Errorf("cycle %v has %d edges, want %d", c.Members, len(c.Edges), len(c.Members))
+		}
+	}
+	if len(result.FeedbackArcSet) == 0 {
+		t.Fatal("expected feedback arc set suggestions")
+	}
+	for _, fa := range result.FeedbackArcSet {
+		if !strings.HasPrefix(fa.Command, "bd dep remove ") {
+			t.Errorf("unexpected command %q", fa.Command)
+		}
+	}
+	if !result.AcyclicAfter {
+		t.Error("expected acyclic_after=true")
+	}
+}
+
 // TestCycleVisualization_CycleCountInStatus tests cycle count appears in status
 func TestCycleVisualization_CycleCountInStatus(t *testing.T) {
 	bv := buildBvBinary(t)
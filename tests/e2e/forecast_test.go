@@ -158,6 +158,47 @@ func TestRobotForecast_AllAndLabelFilter(t *testing.T) {
 	}
 }
 
+func TestRobotVelocity_LabelSamples(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, _ := createForecastRepo(t)
+
+	cmd := exec.Command(bv, "--robot-velocity")
+	cmd.Dir = repoDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("--robot-velocity failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		WindowDays int `json:"window_days"`
+		Overall    struct {
+			RecentClosures int `json:"recent_closures"`
+		} `json:"overall"`
+		Types []struct {
+			IssueType string  `json:"issue_type"`
+			Weight    float64 `json:"weight"`
+		} `json:"types"`
+		Labels []struct {
+			Label          string  `json:"label"`
+			RecentClosures int     `json:"recent_closures"`
+			MinutesPerDay  float64 `json:"minutes_per_day"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.WindowDays != 30 || payload.Overall.RecentClosures != 2 {
+		t.Fatalf("expected 2 closures in a 30 day window, got %+v", payload)
+	}
+	if len(payload.Types) == 0 {
+		t.Fatalf("expected type weights")
+	}
+	// Only closed issues teach the model: backend has 150 minutes closed over 30 days
+	if len(payload.Labels) != 1 || payload.Labels[0].Label != "backend" || payload.Labels[0].MinutesPerDay != 5 {
+		t.Fatalf("unexpected labels: %+v", payload.Labels)
+	}
+}
+
 func mustParseRFC3339(t *testing.T, s string) time.Time {
 	t.Helper()
 	parsed, err := time.Parse(time.RFC3339, s)
@@ -491,6 +491,10 @@ func TestRobotUsageHintsPresent(t *testing.T) {
 		{"--robot-plan", "plan"},
 		{"--robot-priority", "priority"},
 		{"--robot-suggest", "suggest"},
+		{"--robot-cycles", "cycles"},
+		{"--robot-clusters", "clusters"},
+		{"--robot-stale", "stale"},
+		{"--robot-unblock", "unblock"},
 		{"--robot-triage", "triage"},
 	}
 
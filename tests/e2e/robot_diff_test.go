@@ -156,6 +156,76 @@ func TestRobotDiffIncludesHashesAndNewIssues(t *testing.T) {
 	}
 }
 
+func TestRobotDiffBetweenSnapshotFiles(t *testing.T) {
+	bv := buildBvBinary(t)
+	dir := t.TempDir() // No .beads directory: both sides come from files
+
+	older := `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Beta","status":"open","priority":2,"issue_type":"task"}`
+	newer := `{"id":"A","title":"Alpha","status":"closed","priority":1,"issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"B","type":"blocks"}]}
+{"id":"B","title":"Beta","status":"open","priority":0,"issue_type":"task"}
+{"id":"C","title":"Gamma","status":"open","priority":2,"issue_type":"task"}`
+	if err := os.WriteFile(filepath.Join(dir, "old.jsonl"), []byte(older), 0o644); err != nil {
+		t.Fatalf("write old: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.jsonl"), []byte(newer), 0o644); err != nil {
+		t.Fatalf("write new: %v", err)
+	}
+
+	cmd := exec.Command(bv, "--robot-diff", "--diff-since", "old.jsonl", "--diff-to", "new.jsonl")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("--robot-diff between files failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		ResolvedRevision   string `json:"resolved_revision"`
+		ResolvedToRevision string `json:"resolved_to_revision"`
+		FromDataHash       string `json:"from_data_hash"`
+		ToDataHash         string `json:"to_data_hash"`
+		Diff               struct {
+			NewIssues []struct {
+				ID string `json:"id"`
+			} `json:"new_issues"`
+			ClosedIssues []struct {
+				ID string `json:"id"`
+			} `json:"closed_issues"`
+			PriorityChanges []struct {
+				IssueID  string `json:"issue_id"`
+				NewValue string `json:"new_value"`
+			} `json:"priority_changes"`
+			DependencyChanges []struct {
+				IssueID     string `json:"issue_id"`
+				DependsOnID string `json:"depends_on_id"`
+				Change      string `json:"change"`
+			} `json:"dependency_changes"`
+		} `json:"diff"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+
+	if payload.ResolvedRevision != "old.jsonl" || payload.ResolvedToRevision != "new.jsonl" {
+		t.Errorf("revisions = %q -> %q", payload.ResolvedRevision, payload.ResolvedToRevision)
+	}
+	if payload.FromDataHash == payload.ToDataHash {
+		t.Error("data hashes should differ between snapshots")
+	}
+	if len(payload.Diff.NewIssues) != 1 || payload.Diff.NewIssues[0].ID != "C" {
+		t.Errorf("expected new issue C, got %+v", payload.Diff.NewIssues)
+	}
+	if len(payload.Diff.ClosedIssues) != 1 || payload.Diff.ClosedIssues[0].ID != "A" {
+		t.Errorf("expected closed issue A, got %+v", payload.Diff.ClosedIssues)
+	}
+	if len(payload.Diff.PriorityChanges) != 1 || payload.Diff.PriorityChanges[0].NewValue != "P0" {
+		t.Errorf("expected B priority change to P0, got %+v", payload.Diff.PriorityChanges)
+	}
+	if len(payload.Diff.DependencyChanges) != 1 || payload.Diff.DependencyChanges[0].Change != "added" {
+		t.Errorf("expected one added dependency, got %+v", payload.Diff.DependencyChanges)
+	}
+}
+
 func TestDiffSinceAutoJSON_MalformedIssues_NoStderr(t *testing.T) {
 	bv := buildBvBinary(t)
 	repoDir := initGitRepoWithMalformedIssues(t)
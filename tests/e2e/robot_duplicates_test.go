@@ -0,0 +1,63 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestRobotDuplicatesContract(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Crash when opening an empty file","description":"Editor panics on empty input","status":"open","priority":1,"issue_type":"bug"}
+{"id":"B","title":"Crash when opening an empty file","description":"Editor panics on empty input","status":"open","priority":2,"issue_type":"bug"}
+{"id":"C","title":"Add dark mode toggle","status":"open","priority":2,"issue_type":"feature"}
+{"id":"D","title":"Add dark mode toggle","status":"closed","priority":2,"issue_type":"feature"}`)
+
+	run := func(args ...string) []byte {
+		cmd := exec.Command(bv, append([]string{"--robot-duplicates"}, args...)...)
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("robot-duplicates %v failed: %v\n%s", args, err, out)
+		}
+		return out
+	}
+
+	type payload struct {
+		DataHash  string  `json:"data_hash"`
+		Provider  string  `json:"provider"`
+		IndexPath string  `json:"index_path"`
+		Threshold float64 `json:"threshold"`
+		Count     int     `json:"count"`
+		Pairs     []struct {
+			Issue1     string  `json:"issue1"`
+			Issue2     string  `json:"issue2"`
+			Similarity float64 `json:"similarity"`
+		} `json:"pairs"`
+	}
+
+	var open payload
+	if err := json.Unmarshal(run(), &open); err != nil {
+		t.Fatalf("robot-duplicates json decode: %v", err)
+	}
+	if open.DataHash == "" || open.Provider != "hash" || open.IndexPath == "" {
+		t.Fatalf("missing metadata: %+v", open)
+	}
+	if open.Count != 1 || len(open.Pairs) != 1 {
+		t.Fatalf("expected only the open A/B pair, got %+v", open.Pairs)
+	}
+	if p := open.Pairs[0]; p.Issue1 != "A" || p.Issue2 != "B" || p.Similarity < open.Threshold {
+		t.Fatalf("unexpected pair: %+v", p)
+	}
+
+	var all payload
+	if err := json.Unmarshal(run("--duplicates-include-closed"), &all); err != nil {
+		t.Fatalf("robot-duplicates json decode: %v", err)
+	}
+	if all.Count != 2 {
+		t.Fatalf("expected A/B and C/D with closed issues, got %+v", all.Pairs)
+	}
+}
@@ -129,6 +129,10 @@ func TestRobotEmptyDataEdgeCases(t *testing.T) {
 		{"insights empty", "--robot-insights"},
 		{"priority empty", "--robot-priority"},
 		{"suggest empty", "--robot-suggest"},
+		{"cycles empty", "--robot-cycles"},
+		{"clusters empty", "--robot-clusters"},
+		{"stale empty", "--robot-stale"},
+		{"unblock empty", "--robot-unblock"},
 	}
 
 	for _, tc := range tests {
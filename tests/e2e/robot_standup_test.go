@@ -0,0 +1,105 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRobotStandup_GitBaseline compares the working beads file with the last
+// commit before the standup period.
+func TestRobotStandup_GitBaseline(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	beadsPath := filepath.Join(repoDir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beadsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	threeDaysAgo := time.Now().Add(-72 * time.Hour).Format(time.RFC3339)
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+threeDaysAgo, "GIT_COMMITTER_DATE="+threeDaysAgo,
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	before := `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task"}
+{"id":"C","title":"Gamma","status":"open","priority":2,"issue_type":"task"}
+{"id":"D","title":"Delta","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"D","depends_on_id":"A","type":"blocks"}]}
+`
+	if err := os.WriteFile(beadsPath, []byte(before), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("init")
+	git("add", ".beads/beads.jsonl")
+	git("commit", "-m", "baseline")
+	baseline := git("rev-parse", "HEAD")
+
+	// Since then: A closed (unblocking D), B opened and now blocks C
+	after := `{"id":"A","title":"Alpha","status":"closed","priority":1,"issue_type":"task"}
+{"id":"B","title":"Beta","status":"open","priority":1,"issue_type":"task"}
+{"id":"C","title":"Gamma","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"B","type":"blocks"}]}
+{"id":"D","title":"Delta","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"D","depends_on_id":"A","type":"blocks"}]}
+`
+	if err := os.WriteFile(beadsPath, []byte(after), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "--robot-standup", "--standup-since", "48h")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-standup failed: %v", err)
+	}
+	var standup struct {
+		Baseline     string `json:"baseline"`
+		Closed       []struct{ ID string }
+		Opened       []struct{ ID string }
+		NewlyBlocked []struct {
+			ID     string
+			Reason string
+		} `json:"newly_blocked"`
+		Unblocked []struct{ ID string }
+	}
+	if err := json.Unmarshal(out, &standup); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if standup.Baseline != baseline {
+		t.Errorf("baseline = %q, want %q", standup.Baseline, baseline)
+	}
+	if len(standup.Closed) != 1 || standup.Closed[0].ID != "A" {
+		t.Errorf("closed = %+v, want A", standup.Closed)
+	}
+	if len(standup.Opened) != 1 || standup.Opened[0].ID != "B" {
+		t.Errorf("opened = %+v, want B", standup.Opened)
+	}
+	if len(standup.NewlyBlocked) != 1 || standup.NewlyBlocked[0].Reason != "blocked by B" {
+		t.Errorf("newly_blocked = %+v, want C blocked by B", standup.NewlyBlocked)
+	}
+	if len(standup.Unblocked) != 1 || standup.Unblocked[0].ID != "D" {
+		t.Errorf("unblocked = %+v, want D", standup.Unblocked)
+	}
+
+	cmd = exec.Command(bv, "--robot-standup", "--standup-since", "48h", "--format=markdown")
+	cmd.Dir = repoDir
+	out, err = cmd.Output()
+	if err != nil {
+		t.Fatalf("--format=markdown failed: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "# Standup:") || !strings.Contains(string(out), "## Unblocked (1)") {
+		t.Errorf("unexpected markdown:\n%s", out)
+	}
+}